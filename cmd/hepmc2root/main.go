@@ -10,9 +10,15 @@
 //
 // $> hepmc2root ./hepmc.ascii
 // $> hepmc2root -o out.root -t mytree ./hepmc.ascii
+// $> hepmc2root -b 'Event_nbr,Particle_*' ./hepmc.ascii
+// $> hepmc2root -list
 //
 // Options:
 //
+//	-b string
+//	  	comma-separated list of patterns of branches to write (default: all)
+//	-list
+//	  	list the available branches and exit
 //	-o string
 //	  	path to output ROOT file name (default "out.root")
 //	-t string
@@ -24,6 +30,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	stdpath "path"
+	"strings"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rtree"
@@ -37,6 +45,8 @@ func main() {
 
 	oname := flag.String("o", "out.root", "path to output ROOT file name")
 	tname := flag.String("t", "tree", "name of the output tree")
+	bsel := flag.String("b", "", "comma-separated list of patterns of branches to write (default: all)")
+	list := flag.Bool("list", false, "list the available branches and exit")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, `hepmc2root converts a HepMC2 ASCII file into a ROOT file and (flat) tree.
@@ -47,6 +57,8 @@ Example:
 
 $> hepmc2root ./hepmc.ascii
 $> hepmc2root -o out.root -t mytree ./hepmc.ascii
+$> hepmc2root -b 'Event_nbr,Particle_*' ./hepmc.ascii
+$> hepmc2root -list
 
 Options:
 `)
@@ -55,19 +67,36 @@ Options:
 
 	flag.Parse()
 
+	if *list {
+		for _, name := range rootcnv.Branches() {
+			fmt.Fprintln(os.Stdout, name)
+		}
+		return
+	}
+
 	if flag.NArg() != 1 {
 		flag.Usage()
 		log.Fatalf("missing input HepMC filename argument")
 	}
 	fname := flag.Arg(0)
 
-	err := process(*oname, *tname, fname)
+	var branches []string
+	if *bsel != "" {
+		branches = strings.Split(*bsel, ",")
+	}
+
+	err := process(*oname, *tname, fname, branches)
 	if err != nil {
 		log.Fatalf("%+v", err)
 	}
 }
 
-func process(oname, tname, fname string) error {
+func process(oname, tname, fname string, branches []string) error {
+	sel, err := selector(branches)
+	if err != nil {
+		return err
+	}
+
 	f, err := os.Open(fname)
 	if err != nil {
 		return fmt.Errorf("could not open HepMC file %q: %w", fname, err)
@@ -80,7 +109,7 @@ func process(oname, tname, fname string) error {
 	}
 	defer o.Close()
 
-	tree, err := rootcnv.NewFlatTreeWriter(o, tname, rtree.WithTitle(tname))
+	tree, err := rootcnv.NewFlatTreeWriterWith(o, tname, sel, rtree.WithTitle(tname))
 	if err != nil {
 		return fmt.Errorf("could not create output ROOT tree %q: %w", tname, err)
 	}
@@ -102,3 +131,29 @@ func process(oname, tname, fname string) error {
 
 	return nil
 }
+
+// selector returns a function selecting the branches whose name match one
+// of the provided patterns, or nil if no pattern was provided.
+func selector(patterns []string) (func(name string) bool, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	for i, pat := range patterns {
+		pat = strings.TrimSpace(pat)
+		_, err := stdpath.Match(pat, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid branch pattern %q: %w", pat, err)
+		}
+		patterns[i] = pat
+	}
+
+	return func(name string) bool {
+		for _, pat := range patterns {
+			if ok, _ := stdpath.Match(pat, name); ok {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
@@ -20,16 +20,31 @@ func TestConvert(t *testing.T) {
 	}
 	defer os.RemoveAll(tmp)
 
-	for _, name := range []string{
-		"testdata/small.hepmc",
+	for _, tc := range []struct {
+		name     string
+		fname    string
+		branches []string
+		want     string
+	}{
+		{
+			name:  "small",
+			fname: "testdata/small.hepmc",
+			want:  "testdata/small.hepmc.txt",
+		},
+		{
+			name:     "small-selected",
+			fname:    "testdata/small.hepmc",
+			branches: []string{"Event_nbr", " Particle_p[xyz]", "Vertex_bc"},
+			want:     "testdata/small-selected.hepmc.txt",
+		},
 	} {
-		t.Run(name, func(t *testing.T) {
-			oname := filepath.Join(tmp, filepath.Base(name)+".root")
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
 			tname := "tree"
 
-			err := process(oname, tname, name)
+			err := process(oname, tname, tc.fname, tc.branches)
 			if err != nil {
-				t.Fatalf("could not convert %q: %+v", name, err)
+				t.Fatalf("could not convert %q: %+v", tc.fname, err)
 			}
 
 			var (
@@ -41,9 +56,9 @@ func TestConvert(t *testing.T) {
 				t.Fatalf("could not dump ROOT file %q: %+v", oname, err)
 			}
 
-			want, err := os.ReadFile(name + ".txt")
+			want, err := os.ReadFile(tc.want)
 			if err != nil {
-				t.Fatalf("could not load reference file %q: %+v", name, err)
+				t.Fatalf("could not load reference file %q: %+v", tc.want, err)
 			}
 
 			if got, want := out.String(), string(want); got != want {
@@ -52,3 +67,39 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertErrors(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "hepmc2root-")
+	if err != nil {
+		t.Fatalf("could not create tmpdir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	oname := filepath.Join(tmp, "out.root")
+	for _, tc := range []struct {
+		name     string
+		branches []string
+		want     string
+	}{
+		{
+			name:     "invalid-pattern",
+			branches: []string{"["},
+			want:     `invalid branch pattern "[": syntax error in pattern`,
+		},
+		{
+			name:     "no-branch",
+			branches: []string{"not-there"},
+			want:     `could not create output ROOT tree "tree": hepmc: could not create flat-tree writer "tree": no branch selected`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := process(oname, "tree", "testdata/small.hepmc", tc.branches)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.want; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,456 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command lcio2root converts a LCIO file into a ROOT file and (flat) tree.
+//
+// Each LCIO collection is converted into a set of branches, one per field
+// of the elements of the collection, prefixed with the collection name:
+//
+//   - <coll>_len holds the number of elements of the collection,
+//   - <coll>_<field> holds the values of field for all the elements,
+//   - fixed-size arrays are split into one branch per component
+//     (<coll>_<field>_0, <coll>_<field>_1, ...),
+//   - references to other LCIO objects are stored as the index of the
+//     referenced object in its collection (or -1),
+//   - variable-size fields are stored as a flat list of values
+//     (<coll>_<field>, with <coll>_<field>_len values in total) together
+//     with the number of values of each element (<coll>_<field>_n).
+//
+// The layout of the output tree is inferred from the first event of the
+// LCIO file.
+//
+// Usage: lcio2root [OPTIONS] file.slcio
+//
+// Example:
+//
+// $> lcio2root ./file.slcio
+// $> lcio2root -o out.root -t mytree ./file.slcio
+//
+// Options:
+//
+//	-o string
+//	  	path to output ROOT file name (default "out.root")
+//	-t string
+//	  	name of the output tree (default "tree")
+package main // import "go-hep.org/x/hep/cmd/lcio2root"
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"reflect"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/lcio"
+)
+
+func main() {
+	log.SetPrefix("lcio2root: ")
+	log.SetFlags(0)
+
+	oname := flag.String("o", "out.root", "path to output ROOT file name")
+	tname := flag.String("t", "tree", "name of the output tree")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, `lcio2root converts a LCIO file into a ROOT file and (flat) tree.
+
+Usage: lcio2root [OPTIONS] file.slcio
+
+Example:
+
+$> lcio2root ./file.slcio
+$> lcio2root -o out.root -t mytree ./file.slcio
+
+Options:
+`)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		log.Fatalf("missing input LCIO filename argument")
+	}
+	fname := flag.Arg(0)
+
+	err := process(*oname, *tname, fname)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func process(oname, tname, fname string) error {
+	r, err := lcio.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open LCIO file %q: %w", fname, err)
+	}
+	defer r.Close()
+
+	o, err := groot.Create(oname)
+	if err != nil {
+		return fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
+	}
+	defer o.Close()
+
+	var (
+		cnv  *converter
+		tree rtree.Writer
+	)
+	for r.Next() {
+		evt := r.Event()
+		if cnv == nil {
+			cnv = newConverter(&evt)
+			tree, err = cnv.writer(o, tname)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = cnv.fill(&evt)
+		if err != nil {
+			return fmt.Errorf("could not convert event %d: %w", evt.EventNumber, err)
+		}
+
+		_, err = tree.Write()
+		if err != nil {
+			return fmt.Errorf("could not write event %d: %w", evt.EventNumber, err)
+		}
+	}
+
+	err = r.Err()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("could not read LCIO file %q: %w", fname, err)
+	}
+
+	if cnv == nil {
+		cnv = newConverter(nil)
+		tree, err = cnv.writer(o, tname)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tree.Close()
+	if err != nil {
+		return fmt.Errorf("could not close ROOT tree writer: %w", err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output ROOT file %q: %w", oname, err)
+	}
+
+	return nil
+}
+
+// converter converts LCIO events into flat ROOT tree entries.
+type converter struct {
+	hdr struct {
+		run  int32
+		evt  int32
+		time int64
+		det  string
+	}
+
+	wvars  []rtree.WriteVar
+	colls  []collection
+	blocks []*block
+
+	refs map[uintptr]int32 // index of LCIO objects in their collection
+}
+
+// collection describes how a LCIO collection is converted.
+type collection struct {
+	name  string
+	typ   reflect.Type // type of the LCIO collection
+	field int          // index of the elements field of the LCIO collection
+	blk   *block
+	fill  func(v reflect.Value)
+}
+
+// block is a set of branches holding the same number of values.
+type block struct {
+	name  string // name of the branch holding the number of values
+	n     *int32
+	reset []func()
+}
+
+func newConverter(evt *lcio.Event) *converter {
+	cnv := &converter{
+		refs: make(map[uintptr]int32),
+	}
+	cnv.wvars = []rtree.WriteVar{
+		{Name: "RunNumber", Value: &cnv.hdr.run},
+		{Name: "EventNumber", Value: &cnv.hdr.evt},
+		{Name: "TimeStamp", Value: &cnv.hdr.time},
+		{Name: "Detector", Value: &cnv.hdr.det},
+	}
+	if evt == nil {
+		return cnv
+	}
+
+	for _, name := range evt.Names() {
+		rv := reflect.ValueOf(evt.Get(name)).Elem()
+		field := elementsOf(rv.Type())
+		if field < 0 {
+			log.Printf("skipping collection %q: unsupported type %T", name, evt.Get(name))
+			continue
+		}
+
+		var (
+			ft    = rv.Type().Field(field)
+			elt   = ft.Type.Elem()
+			vname = name
+		)
+		if elt.Kind() != reflect.Struct {
+			vname = name + "_" + ft.Name
+		}
+
+		if !supported(elt) {
+			log.Printf("skipping collection %q: unsupported type %T", name, evt.Get(name))
+			continue
+		}
+
+		blk := cnv.newBlock(name + "_len")
+		fill := cnv.add(blk, vname, elt)
+		cnv.colls = append(cnv.colls, collection{
+			name:  name,
+			typ:   rv.Type(),
+			field: field,
+			blk:   blk,
+			fill:  fill,
+		})
+	}
+
+	return cnv
+}
+
+func (cnv *converter) writer(dir riofs.Directory, name string) (rtree.Writer, error) {
+	w, err := rtree.NewWriter(dir, name, cnv.wvars, rtree.WithTitle(name))
+	if err != nil {
+		return nil, fmt.Errorf("could not create output ROOT tree %q: %w", name, err)
+	}
+	return w, nil
+}
+
+// elementsOf returns the index of the field holding the elements of the
+// provided LCIO collection type, or -1.
+func elementsOf(rt reflect.Type) int {
+	if rt.Kind() != reflect.Struct {
+		return -1
+	}
+	for i := 0; i < rt.NumField(); i++ {
+		ft := rt.Field(i)
+		if ft.IsExported() && ft.Type.Kind() == reflect.Slice {
+			return i
+		}
+	}
+	return -1
+}
+
+func (cnv *converter) newBlock(name string) *block {
+	blk := &block{name: name, n: new(int32)}
+	cnv.wvars = append(cnv.wvars, rtree.WriteVar{Name: name, Value: blk.n})
+	cnv.blocks = append(cnv.blocks, blk)
+	return blk
+}
+
+// supported returns whether values of type rt can be stored in the
+// output tree.
+func supported(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Ptr, reflect.Interface:
+		return true
+	case reflect.Array, reflect.Slice:
+		return supported(rt.Elem())
+	case reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			ft := rt.Field(i)
+			if ft.IsExported() && supported(ft.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// add creates the branches needed to store values of type rt, named name,
+// in the provided block.
+// add returns the function filling these branches with a value.
+// Values of type rt must be supported.
+func (cnv *converter) add(blk *block, name string, rt reflect.Type) func(v reflect.Value) {
+	switch rt.Kind() {
+	case reflect.Array:
+		fills := make([]func(v reflect.Value), rt.Len())
+		for i := range fills {
+			fills[i] = cnv.add(blk, fmt.Sprintf("%s_%d", name, i), rt.Elem())
+		}
+		return func(v reflect.Value) {
+			for i, fill := range fills {
+				fill(v.Index(i))
+			}
+		}
+
+	case reflect.Struct:
+		var (
+			idx   []int
+			fills []func(v reflect.Value)
+		)
+		for i := 0; i < rt.NumField(); i++ {
+			ft := rt.Field(i)
+			if !ft.IsExported() || !supported(ft.Type) {
+				continue
+			}
+			idx = append(idx, i)
+			fills = append(fills, cnv.add(blk, name+"_"+ft.Name, ft.Type))
+		}
+		return func(v reflect.Value) {
+			for i, fill := range fills {
+				fill(v.Field(idx[i]))
+			}
+		}
+
+	case reflect.Ptr, reflect.Interface:
+		ptr := new([]int32)
+		cnv.column(blk, name, reflect.ValueOf(ptr))
+		return func(v reflect.Value) {
+			*ptr = append(*ptr, cnv.index(v))
+		}
+
+	case reflect.Slice:
+		ptr := new([]int32)
+		cnv.column(blk, name+"_n", reflect.ValueOf(ptr))
+		var (
+			sub  = cnv.newBlock(name + "_len")
+			fill = cnv.add(sub, name, rt.Elem())
+		)
+		return func(v reflect.Value) {
+			n := v.Len()
+			*ptr = append(*ptr, int32(n))
+			*sub.n += int32(n)
+			for i := 0; i < n; i++ {
+				fill(v.Index(i))
+			}
+		}
+
+	default:
+		var (
+			typ = builtins[rt.Kind()]
+			ptr = reflect.New(reflect.SliceOf(typ))
+			sli = ptr.Elem()
+		)
+		cnv.column(blk, name, ptr)
+		return func(v reflect.Value) {
+			sli.Set(reflect.Append(sli, v.Convert(typ)))
+		}
+	}
+}
+
+// column creates a branch named name, holding the values of the provided
+// block, from a pointer to a slice.
+func (cnv *converter) column(blk *block, name string, ptr reflect.Value) {
+	cnv.wvars = append(cnv.wvars, rtree.WriteVar{
+		Name:  name,
+		Value: ptr.Interface(),
+		Count: blk.name,
+	})
+	sli := ptr.Elem()
+	blk.reset = append(blk.reset, func() { sli.SetLen(0) })
+}
+
+// index returns the index of the LCIO object pointed at by v in its
+// collection, or -1.
+func (cnv *converter) index(v reflect.Value) int32 {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return -1
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return -1
+	}
+	i, ok := cnv.refs[v.Pointer()]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+func (cnv *converter) fill(evt *lcio.Event) error {
+	cnv.hdr.run = evt.RunNumber
+	cnv.hdr.evt = evt.EventNumber
+	cnv.hdr.time = evt.TimeStamp
+	cnv.hdr.det = evt.Detector
+
+	for _, blk := range cnv.blocks {
+		*blk.n = 0
+		for _, reset := range blk.reset {
+			reset()
+		}
+	}
+
+	for k := range cnv.refs {
+		delete(cnv.refs, k)
+	}
+	for _, name := range evt.Names() {
+		rv := reflect.ValueOf(evt.Get(name)).Elem()
+		field := elementsOf(rv.Type())
+		if field < 0 {
+			continue
+		}
+		elems := rv.Field(field)
+		for i := 0; i < elems.Len(); i++ {
+			elem := elems.Index(i)
+			if elem.Kind() != reflect.Struct {
+				continue
+			}
+			cnv.refs[elem.Addr().Pointer()] = int32(i)
+		}
+	}
+
+	for _, coll := range cnv.colls {
+		if !evt.Has(coll.name) {
+			continue
+		}
+		rv := reflect.ValueOf(evt.Get(coll.name)).Elem()
+		if rv.Type() != coll.typ {
+			return fmt.Errorf(
+				"invalid type for collection %q (got=%v, want=%v)",
+				coll.name, rv.Type(), coll.typ,
+			)
+		}
+		elems := rv.Field(coll.field)
+		*coll.blk.n = int32(elems.Len())
+		for i := 0; i < elems.Len(); i++ {
+			coll.fill(elems.Index(i))
+		}
+	}
+
+	return nil
+}
+
+var builtins = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
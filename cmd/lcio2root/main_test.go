@@ -0,0 +1,68 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rcmd"
+)
+
+func TestConvert(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "lcio2root-")
+	if err != nil {
+		t.Fatalf("could not create tmpdir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{
+			name: "../../lcio/testdata/event_golden.slcio",
+			want: "testdata/event_golden.root.txt",
+		},
+		{
+			name: "../../lcio/testdata/event-compressed_golden.slcio",
+			want: "testdata/event_golden.root.txt",
+		},
+		{
+			name: "../../lcio/testdata/run-header_golden.slcio",
+			want: "testdata/run-header_golden.root.txt",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, filepath.Base(tc.name)+".root")
+			tname := "tree"
+
+			err := process(oname, tname, tc.name)
+			if err != nil {
+				t.Fatalf("could not convert %q: %+v", tc.name, err)
+			}
+
+			var (
+				out  = new(strings.Builder)
+				deep = true
+			)
+			err = rcmd.Dump(out, oname, deep, nil)
+			if err != nil {
+				t.Fatalf("could not dump ROOT file %q: %+v", oname, err)
+			}
+
+			want, err := os.ReadFile(tc.want)
+			if err != nil {
+				t.Fatalf("could not load reference file %q: %+v", tc.want, err)
+			}
+
+			if got, want := out.String(), string(want); got != want {
+				t.Fatalf("invalid root-dump output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
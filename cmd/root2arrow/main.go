@@ -5,15 +5,24 @@
 // root2arrow converts the content of a ROOT TTree to an ARROW file.
 //
 //	Usage of root2arrow:
+//	  -chunk int
+//	    	number of entries per ARROW record (0: one entry per record, -1: all entries in one record)
 //	  -o string
-//	    	path to output ARROW file name (default "output.data")
+//	    	path to output ARROW file name, or "-" for stdout (default "output.data")
 //	  -stream
 //	    	enable ARROW stream (default is to create an ARROW file)
 //	  -t string
 //	    	name of the tree to convert (default "tree")
 //
+// ARROW files are written with the ARROW IPC file format, also known as
+// the Feather (V2) format, and can be read with e.g. pyarrow.feather or
+// pandas.read_feather.
 //
-//	$> root2arrow -o foo.data -t tree ../../groot/testdata/simple.root
+// ARROW streams are written with the ARROW IPC streaming format.
+// Streams can be written to the standard output, to be piped into
+// ARROW-native tools without intermediate files.
+//
+//	$> root2arrow -o foo.data -chunk -1 -t tree ../../groot/testdata/simple.root
 //	$> arrow-ls ./foo.data
 //	version: V4
 //	schema:
@@ -28,6 +37,8 @@
 //	  col[0] "one": [1 2 3 4]
 //	  col[1] "two": [1.1 2.2 3.3 4.4]
 //	  col[2] "three": ["uno" "dos" "tres" "quatro"]
+//
+//	$> root2arrow -o - -stream -chunk 1000 -t tree ../../groot/testdata/simple.root | some-arrow-tool
 package main // import "go-hep.org/x/hep/cmd/root2arrow"
 
 import (
@@ -52,9 +63,10 @@ func main() {
 	log.SetPrefix("root2arrow: ")
 	log.SetFlags(0)
 
-	oname := flag.String("o", "output.data", "path to output ARROW file name")
+	oname := flag.String("o", "output.data", `path to output ARROW file name, or "-" for stdout`)
 	tname := flag.String("t", "tree", "name of the tree to convert")
 	stream := flag.Bool("stream", false, "enable ARROW stream (default is to create an ARROW file)")
+	chunk := flag.Int64("chunk", 0, "number of entries per ARROW record (0: one entry per record, -1: all entries in one record)")
 
 	flag.Parse()
 
@@ -64,13 +76,21 @@ func main() {
 	}
 	fname := flag.Arg(0)
 
-	err := process(*oname, fname, *tname, *stream)
+	err := process(*oname, fname, *tname, options{
+		stream: *stream,
+		chunk:  *chunk,
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
-func process(oname, fname, tname string, stream bool) error {
+type options struct {
+	stream bool  // whether to write an ARROW stream instead of an ARROW file
+	chunk  int64 // number of entries per ARROW record
+}
+
+func process(oname, fname, tname string, opts options) error {
 	f, err := groot.Open(fname)
 	if err != nil {
 		return err
@@ -89,14 +109,17 @@ func process(oname, fname, tname string, stream bool) error {
 
 	mem := memory.NewGoAllocator()
 
-	r := rarrow.NewRecordReader(tree, rarrow.WithAllocator(mem))
+	r := rarrow.NewRecordReader(tree, rarrow.WithAllocator(mem), rarrow.WithChunk(opts.chunk))
 	defer r.Release()
 
 	var o *os.File
 
 	switch oname {
-	case "":
+	case "", "-":
 		o = os.Stdout
+		if !opts.stream && !seekable(o) {
+			return fmt.Errorf("ARROW files can not be written to a non-seekable stdout (use -stream)")
+		}
 	default:
 		o, err = os.Create(oname)
 		if err != nil {
@@ -106,13 +129,39 @@ func process(oname, fname, tname string, stream bool) error {
 	}
 
 	switch {
-	case stream:
+	case opts.stream:
 		err = processStream(o, r, mem)
 	default:
 		err = processFile(o, r, mem)
 	}
+	if err != nil {
+		return err
+	}
+
+	if o == os.Stdout {
+		return nil
+	}
 
-	return err
+	err = o.Sync()
+	if err != nil {
+		return fmt.Errorf("could not sync data to disk: %w", err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output file: %w", err)
+	}
+
+	return nil
+}
+
+// seekable returns whether the provided file is a regular file.
+func seekable(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode().IsRegular()
 }
 
 func processStream(o io.Writer, r array.RecordReader, mem memory.Allocator) error {
@@ -160,15 +209,5 @@ func processFile(o *os.File, r array.RecordReader, mem memory.Allocator) error {
 		return fmt.Errorf("could not close Arrow file writer: %w", err)
 	}
 
-	err = o.Sync()
-	if err != nil {
-		return fmt.Errorf("could not sync data to disk: %w", err)
-	}
-
-	err = o.Close()
-	if err != nil {
-		return fmt.Errorf("could not close output file: %w", err)
-	}
-
 	return nil
 }
@@ -6,6 +6,7 @@ package main // import "go-hep.org/x/hep/cmd/root2arrow"
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"testing"
@@ -31,6 +32,7 @@ func TestFile(t *testing.T) {
 		file   string
 		tree   string
 		stream bool
+		chunk  int64
 		want   string
 	}{
 		{
@@ -55,6 +57,19 @@ func TestFile(t *testing.T) {
 			stream: true,
 			want:   "testdata/leaves.root.stream",
 		},
+		{
+			file:  "../../groot/testdata/simple.root",
+			tree:  "tree",
+			chunk: 3,
+			want:  "testdata/simple.root.chunk-3.file",
+		},
+		{
+			file:   "../../groot/testdata/simple.root",
+			tree:   "tree",
+			stream: true,
+			chunk:  -1,
+			want:   "testdata/simple.root.chunk-all.stream",
+		},
 		{
 			file: "../../groot/testdata/embedded-std-vector.root",
 			tree: "modules",
@@ -75,7 +90,10 @@ func TestFile(t *testing.T) {
 			f.Close()
 			defer os.Remove(f.Name())
 
-			err = process(f.Name(), tc.file, tc.tree, tc.stream)
+			err = process(f.Name(), tc.file, tc.tree, options{
+				stream: tc.stream,
+				chunk:  tc.chunk,
+			})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -101,6 +119,69 @@ func TestFile(t *testing.T) {
 
 }
 
+func TestStdout(t *testing.T) {
+	tmp, err := os.CreateTemp("", "root2arrow-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.Close()
+
+	done := make(chan error)
+	go func() {
+		_, err := io.Copy(tmp, pr)
+		done <- err
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = pw
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	const fname = "../../groot/testdata/simple.root"
+
+	err = process("-", fname, "tree", options{})
+	if err == nil {
+		t.Fatalf("expected an error writing an ARROW file to a pipe")
+	}
+
+	err = process("-", fname, "tree", options{stream: true})
+	os.Stdout = stdout
+	pw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = <-done
+	if err != nil {
+		t.Fatalf("could not read stdout: %+v", err)
+	}
+
+	want, err := os.ReadFile("testdata/simple.root.stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := arrowCat(tmp.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(got), string(want); got != want {
+		t.Fatalf(
+			"arrow stream differ:\n%s",
+			diff.Format(got, want),
+		)
+	}
+}
+
 func arrowCat(fname string) ([]byte, error) {
 	return exec.Command("arrow-cat", fname).CombinedOutput()
 }
@@ -0,0 +1,149 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// quoteMode describes how CSV fields are quoted.
+type quoteMode uint8
+
+const (
+	quoteMinimal quoteMode = iota // quote fields only when needed
+	quoteAll                      // quote all fields
+	quoteNone                     // never quote fields
+)
+
+func parseQuoteMode(v string) (quoteMode, error) {
+	switch v {
+	case "minimal":
+		return quoteMinimal, nil
+	case "all":
+		return quoteAll, nil
+	case "none":
+		return quoteNone, nil
+	}
+	return 0, fmt.Errorf("invalid quoting mode %q", v)
+}
+
+func parseDelimiter(v string) (rune, error) {
+	if v == `\t` {
+		return '\t', nil
+	}
+	r, n := utf8.DecodeRuneInString(v)
+	switch {
+	case n == 0 || n != len(v):
+		return 0, fmt.Errorf("invalid delimiter %q: need exactly one character", v)
+	case r == '"', r == '\r', r == '\n', r == utf8.RuneError:
+		return 0, fmt.Errorf("invalid delimiter %q", v)
+	}
+	return r, nil
+}
+
+// csvWriter writes CSV records with a configurable delimiter and
+// quoting mode.
+type csvWriter struct {
+	f     *os.File
+	w     *bufio.Writer
+	comma rune
+	quote quoteMode
+}
+
+func createCSV(fname string, comma rune, quote quoteMode) (*csvWriter, error) {
+	f, err := os.Create(fname)
+	if err != nil {
+		return nil, err
+	}
+	return &csvWriter{
+		f:     f,
+		w:     bufio.NewWriter(f),
+		comma: comma,
+		quote: quote,
+	}, nil
+}
+
+// writeHeader writes the comment line and column names of the CSV file.
+func (w *csvWriter) writeHeader(fname string, names []string) error {
+	_, err := fmt.Fprintf(w.w, "## Automatically generated from %q\n", fname)
+	if err != nil {
+		return err
+	}
+	return w.write(names)
+}
+
+func (w *csvWriter) write(rec []string) error {
+	for i, field := range rec {
+		if i > 0 {
+			_, err := w.w.WriteRune(w.comma)
+			if err != nil {
+				return err
+			}
+		}
+
+		quote := false
+		switch w.quote {
+		case quoteMinimal:
+			quote = w.needsQuotes(field)
+		case quoteAll:
+			quote = true
+		case quoteNone:
+			if w.needsQuotes(field) {
+				return fmt.Errorf("field %q needs quoting", field)
+			}
+		}
+
+		if !quote {
+			_, err := w.w.WriteString(field)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, err := w.w.WriteString(`"` + strings.ReplaceAll(field, `"`, `""`) + `"`)
+		if err != nil {
+			return err
+		}
+	}
+	return w.w.WriteByte('\n')
+}
+
+// needsQuotes reports whether the field needs to be quoted.
+// The rules follow the ones of encoding/csv.
+func (w *csvWriter) needsQuotes(field string) bool {
+	switch {
+	case field == "":
+		return false
+	case field == `\.`:
+		return true
+	case strings.ContainsRune(field, w.comma),
+		strings.ContainsAny(field, "\"\r\n"):
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return r == ' ' || r == '\t'
+}
+
+func (w *csvWriter) Close() error {
+	if w.f == nil {
+		return nil
+	}
+
+	err := w.w.Flush()
+	if err != nil {
+		_ = w.f.Close()
+		w.f = nil
+		return err
+	}
+
+	err = w.f.Close()
+	w.f = nil
+	return err
+}
@@ -0,0 +1,250 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// flattenMode describes how non-flat branches are converted to CSV cells.
+type flattenMode uint8
+
+const (
+	flattenNone    flattenMode = iota // ignore non-flat branches
+	flattenExplode                    // one row per element of jagged and array branches
+	flattenIndex                      // one column per element, with index-suffixed names
+	flattenJSON                       // one column per branch, with JSON-encoded cells
+)
+
+func parseFlattenMode(v string) (flattenMode, error) {
+	switch v {
+	case "none":
+		return flattenNone, nil
+	case "explode":
+		return flattenExplode, nil
+	case "index":
+		return flattenIndex, nil
+	case "json":
+		return flattenJSON, nil
+	}
+	return 0, fmt.Errorf("invalid flattening mode %q", v)
+}
+
+// layout describes how a value is flattened into CSV cells.
+//
+// Structs are flattened into one cell per exported field, arrays and
+// slices into one cell per element.
+// The number of cells of a slice is the maximum number of elements seen
+// by the layout; missing elements are represented with empty cells.
+type layout struct {
+	kind   reflect.Kind
+	n      int       // number of elements, for arrays and slices
+	elem   *layout   // layout of elements, for arrays and slices
+	fields []*layout // layout of exported fields, for structs
+	names  []string  // names of exported fields, for structs
+	index  []int     // index of exported fields, for structs
+}
+
+func newLayout(rt reflect.Type) *layout {
+	lay := &layout{kind: rt.Kind()}
+	switch lay.kind {
+	case reflect.Array:
+		lay.n = rt.Len()
+		lay.elem = newLayout(rt.Elem())
+	case reflect.Slice:
+		lay.elem = newLayout(rt.Elem())
+	case reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			ft := rt.Field(i)
+			if !ft.IsExported() {
+				continue
+			}
+			lay.fields = append(lay.fields, newLayout(ft.Type))
+			lay.names = append(lay.names, fieldName(ft))
+			lay.index = append(lay.index, i)
+		}
+	}
+	return lay
+}
+
+// dynamic reports whether the number of cells of the layout depends on
+// the flattened values.
+func (lay *layout) dynamic() bool {
+	switch lay.kind {
+	case reflect.Slice:
+		return true
+	case reflect.Array:
+		return lay.elem.dynamic()
+	case reflect.Struct:
+		for _, field := range lay.fields {
+			if field.dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// update updates the number of elements of the slices of the layout with
+// the provided value.
+func (lay *layout) update(rv reflect.Value) {
+	switch lay.kind {
+	case reflect.Array, reflect.Slice:
+		n := rv.Len()
+		if n > lay.n {
+			lay.n = n
+		}
+		for i := 0; i < n; i++ {
+			lay.elem.update(rv.Index(i))
+		}
+	case reflect.Struct:
+		for i, field := range lay.fields {
+			field.update(rv.Field(lay.index[i]))
+		}
+	}
+}
+
+// header appends the names of the cells of the layout to dst.
+func (lay *layout) header(dst []string, name string) []string {
+	switch lay.kind {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < lay.n; i++ {
+			dst = lay.elem.header(dst, name+"["+strconv.Itoa(i)+"]")
+		}
+	case reflect.Struct:
+		for i, field := range lay.fields {
+			dst = field.header(dst, name+"."+lay.names[i])
+		}
+	default:
+		dst = append(dst, name)
+	}
+	return dst
+}
+
+// cells appends the cells of the provided value to dst.
+// Invalid values are flattened into empty cells.
+func (lay *layout) cells(dst []string, rv reflect.Value) []string {
+	switch lay.kind {
+	case reflect.Array, reflect.Slice:
+		n := 0
+		if rv.IsValid() {
+			n = rv.Len()
+		}
+		for i := 0; i < lay.n; i++ {
+			v := reflect.Value{}
+			if i < n {
+				v = rv.Index(i)
+			}
+			dst = lay.elem.cells(dst, v)
+		}
+	case reflect.Struct:
+		for i, field := range lay.fields {
+			v := reflect.Value{}
+			if rv.IsValid() {
+				v = rv.Field(lay.index[i])
+			}
+			dst = field.cells(dst, v)
+		}
+	default:
+		if !rv.IsValid() {
+			return append(dst, "")
+		}
+		dst = append(dst, formatValue(rv))
+	}
+	return dst
+}
+
+func fieldName(ft reflect.StructField) string {
+	name := ft.Tag.Get("groot")
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" {
+		name = ft.Name
+	}
+	return name
+}
+
+func formatValue(rv reflect.Value) string {
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits())
+	case reflect.String:
+		return rv.String()
+	}
+	panic(fmt.Errorf("invalid value type %v", rv.Type()))
+}
+
+// formatJSON returns the JSON encoding of the provided value.
+// Structs are encoded as JSON objects, with their exported fields in
+// declaration order.
+// Non-finite floating point values are encoded as JSON strings.
+func formatJSON(rv reflect.Value) string {
+	var o strings.Builder
+	appendJSON(&o, rv)
+	return o.String()
+}
+
+func appendJSON(o *strings.Builder, rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			o.WriteString("[]")
+			return
+		}
+		o.WriteString("[")
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				o.WriteString(",")
+			}
+			appendJSON(o, rv.Index(i))
+		}
+		o.WriteString("]")
+	case reflect.Struct:
+		rt := rv.Type()
+		o.WriteString("{")
+		n := 0
+		for i := 0; i < rt.NumField(); i++ {
+			ft := rt.Field(i)
+			if !ft.IsExported() {
+				continue
+			}
+			if n > 0 {
+				o.WriteString(",")
+			}
+			n++
+			o.WriteString(strconv.Quote(fieldName(ft)))
+			o.WriteString(":")
+			appendJSON(o, rv.Field(i))
+		}
+		o.WriteString("}")
+	case reflect.String:
+		raw, err := json.Marshal(rv.String())
+		if err != nil {
+			panic(fmt.Errorf("could not encode string %q to JSON: %w", rv.String(), err))
+		}
+		o.Write(raw)
+	case reflect.Float32, reflect.Float64:
+		v := rv.Float()
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			o.WriteString(strconv.Quote(formatValue(rv)))
+			return
+		}
+		o.WriteString(formatValue(rv))
+	default:
+		o.WriteString(formatValue(rv))
+	}
+}
@@ -5,17 +5,37 @@
 // root2csv converts the content of a ROOT TTree to a CSV file.
 //
 //	Usage of root2csv:
+//	  -d string
+//	    	column delimiter (default ";")
 //	  -f string
 //	    	path to input ROOT file name
+//	  -flatten string
+//	    	flattening mode for non-flat branches (none, explode, index, json) (default "none")
 //	  -o string
 //	    	path to output CSV file name (default "output.csv")
+//	  -q string
+//	    	quoting mode for fields (minimal, all, none) (default "minimal")
 //	  -t string
 //	    	name of the tree or graph to convert (default "tree")
 //
 // By default, root2csv will write out a CSV file with ';' as a column delimiter.
-// root2csv ignores the branches of the TTree that are not supported by CSV:
-//   - slices/arrays
-//   - C++ objects
+// Fields are quoted only when needed, unless another quoting mode is requested:
+//   - minimal: quote fields containing the delimiter, quotes or new lines,
+//   - all: quote all fields,
+//   - none: never quote fields, fail if a field would need quoting.
+//
+// By default, root2csv ignores the branches of the TTree that are not flat
+// (slices, arrays and C++ objects).
+// These branches can be converted with one of the following flattening modes:
+//   - explode: write one row per element of slices and arrays,
+//     repeating the values of the other branches,
+//   - index: write one column per element, with index-suffixed names
+//     (e.g. "Slice[0]", "Slice[1]" or "Obj.Field"),
+//   - json: write one JSON-encoded cell per branch.
+//
+// Slices are written out with as many columns (or rows) as their largest
+// value; missing elements are written out as empty cells.
+// Objects are flattened into one column per exported field.
 //
 // Example:
 //
@@ -38,9 +58,8 @@ import (
 	"fmt"
 	"log"
 	"reflect"
-	"strings"
+	"strconv"
 
-	"go-hep.org/x/hep/csvutil"
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
@@ -56,6 +75,9 @@ func main() {
 	fname := flag.String("f", "", "path to input ROOT file name")
 	oname := flag.String("o", "output.csv", "path to output CSV file name")
 	tname := flag.String("t", "tree", "name of the tree or graph to convert")
+	flatten := flag.String("flatten", "none", "flattening mode for non-flat branches (none, explode, index, json)")
+	delim := flag.String("d", ";", "column delimiter")
+	quote := flag.String("q", "minimal", "quoting mode for fields (minimal, all, none)")
 
 	flag.Parse()
 
@@ -64,13 +86,47 @@ func main() {
 		log.Fatalf("missing input ROOT filename argument")
 	}
 
-	err := process(*oname, *fname, *tname)
+	var (
+		opts = newOptions()
+		err  error
+	)
+
+	opts.flatten, err = parseFlattenMode(*flatten)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts.comma, err = parseDelimiter(*delim)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts.quote, err = parseQuoteMode(*quote)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = process(*oname, *fname, *tname, opts)
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
-func process(oname, fname, tname string) error {
+type options struct {
+	flatten flattenMode
+	comma   rune
+	quote   quoteMode
+}
+
+func newOptions() options {
+	return options{
+		flatten: flattenNone,
+		comma:   ';',
+		quote:   quoteMinimal,
+	}
+}
+
+func process(oname, fname, tname string, opts options) error {
 
 	f, err := groot.Open(fname)
 	if err != nil {
@@ -85,93 +141,90 @@ func process(oname, fname, tname string) error {
 
 	switch obj := obj.(type) {
 	case rtree.Tree:
-		return processTree(oname, fname, obj)
+		return processTree(oname, fname, obj, opts)
 	case rhist.GraphErrors: // Note: test rhist.GraphErrors before rhist.Graph
-		return processGraphErrors(oname, fname, obj)
+		return processGraphErrors(oname, fname, obj, opts)
 	case rhist.Graph:
-		return processGraph(oname, fname, obj)
+		return processGraph(oname, fname, obj, opts)
 	default:
 		return fmt.Errorf("object %q in file %q is not a rtree.Tree nor a rhist.Graph", tname, fname)
 	}
 }
 
-func processTree(oname, fname string, tree rtree.Tree) error {
-	var nt = ntuple{n: tree.Entries()}
+func processTree(oname, fname string, tree rtree.Tree, opts options) error {
+	var nt ntuple
 	log.Printf("scanning leaves...")
 	for _, leaf := range tree.Leaves() {
-		kind := leaf.Type().Kind()
-		switch kind {
-		case reflect.Array, reflect.Map, reflect.Slice, reflect.Struct:
-			log.Printf(">>> %q %v not supported (%v)", leaf.Name(), leaf.Class(), kind)
-			continue
-		case reflect.String:
-			// ok
-		case reflect.Bool,
-			reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-			reflect.Float32, reflect.Float64:
-			if leaf.LeafCount() != nil {
-				log.Printf(">>> %q %v not supported (slice)", leaf.Name(), leaf.Class())
+		rt := leafType(leaf)
+		switch kind := rt.Kind(); kind {
+		case reflect.Array, reflect.Slice, reflect.Struct:
+			if len(leaf.Branch().Branches()) > 0 {
+				log.Printf(">>> %q %v ignored (split into sub-branches)", leaf.Name(), leaf.Class())
 				continue
 			}
-			if leaf.Len() > 1 {
-				log.Printf(">>> %q %v not supported (array)", leaf.Name(), leaf.Class())
+			if opts.flatten == flattenNone {
+				log.Printf(">>> %q %v not supported (%v)", leaf.Name(), leaf.Class(), kind)
 				continue
 			}
+		case reflect.String,
+			reflect.Bool,
+			reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			// ok
 		default:
 			log.Printf(">>> %q %v not supported (%v) (unknown!)", leaf.Name(), leaf.Class(), kind)
 			continue
 		}
 
-		nt.add(leaf.Name(), leaf)
+		nt.add(leaf, rt, opts.flatten)
 	}
 	log.Printf("scanning leaves... [done]")
 
-	r, err := rtree.NewReader(tree, nt.args)
-	if err != nil {
-		return fmt.Errorf("could not create tree reader: %w", err)
-	}
-	defer r.Close()
-
-	nrows := 0
-	err = r.Read(func(ctx rtree.RCtx) error {
-		nt.fill()
-		nrows++
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("could not read tree: %w", err)
+	if nt.dynamic() {
+		// first pass: compute the number of columns of jagged branches.
+		err := nt.scan(tree, func() { nt.update() })
+		if err != nil {
+			return fmt.Errorf("could not scan tree: %w", err)
+		}
 	}
 
-	tbl, err := csvutil.Create(oname)
+	tbl, err := createCSV(oname, opts.comma, opts.quote)
 	if err != nil {
 		return fmt.Errorf("could not create output CSV file: %w", err)
 	}
 	defer tbl.Close()
-	tbl.Writer.Comma = ';'
 
-	names := make([]string, len(nt.cols))
-	for i, col := range nt.cols {
-		names[i] = col.name
-	}
-	err = tbl.WriteHeader(fmt.Sprintf(
-		"## Automatically generated from %q\n%s\n",
-		fname,
-		strings.Join(names, string(tbl.Writer.Comma)),
-	))
+	err = tbl.writeHeader(fname, nt.header())
 	if err != nil {
 		return fmt.Errorf("could not write CSV header: %w", err)
 	}
 
-	row := make([]interface{}, len(nt.cols))
-	for irow := 0; irow < nrows; irow++ {
-		for i, col := range nt.cols {
-			row[i] = col.slice.Index(irow).Interface()
+	var (
+		irow = 0
+		row  []string
+		werr error
+	)
+	err = nt.scan(tree, func() {
+		if werr != nil {
+			return
 		}
-		err = tbl.WriteRow(row...)
-		if err != nil {
-			return fmt.Errorf("could not write row %d to CSV file: %w", irow, err)
+		n := nt.nrows()
+		for i := 0; i < n; i++ {
+			row = nt.row(row[:0], i)
+			werr = tbl.write(row)
+			if werr != nil {
+				werr = fmt.Errorf("could not write row %d to CSV file: %w", irow, werr)
+				return
+			}
+			irow++
 		}
+	})
+	if err != nil {
+		return fmt.Errorf("could not read tree: %w", err)
+	}
+	if werr != nil {
+		return werr
 	}
 
 	err = tbl.Close()
@@ -182,81 +235,192 @@ func processTree(oname, fname string, tree rtree.Tree) error {
 	return nil
 }
 
+// leafType returns the type of the values held by the provided leaf.
+func leafType(leaf rtree.Leaf) reflect.Type {
+	rt := leaf.Type()
+	switch rt.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Struct, reflect.String:
+		return rt
+	}
+
+	shape := leaf.Shape()
+	switch {
+	case leaf.LeafCount() != nil:
+		for i := range shape {
+			rt = reflect.ArrayOf(shape[len(shape)-1-i], rt)
+		}
+		rt = reflect.SliceOf(rt)
+	case len(shape) > 0:
+		for i := range shape {
+			rt = reflect.ArrayOf(shape[len(shape)-1-i], rt)
+		}
+	case leaf.Len() > 1:
+		rt = reflect.ArrayOf(leaf.Len(), rt)
+	}
+	return rt
+}
+
 type ntuple struct {
-	n    int64
-	cols []column
+	cols []*column
 	args []rtree.ReadVar
-	vars []interface{}
 }
 
-func (nt *ntuple) add(name string, leaf rtree.Leaf) {
-	n := len(nt.cols)
-	nt.cols = append(nt.cols, newColumn(name, leaf, nt.n))
-	col := &nt.cols[n]
+func (nt *ntuple) add(leaf rtree.Leaf, rt reflect.Type, mode flattenMode) {
+	col := newColumn(leaf.Name(), rt, mode)
+	nt.cols = append(nt.cols, col)
 	nt.args = append(nt.args, rtree.ReadVar{
-		Name:  name,
+		Name:  leaf.Branch().Name(),
 		Leaf:  leaf.Name(),
 		Value: col.data.Addr().Interface(),
 	})
-	nt.vars = append(nt.vars, col.data.Addr().Interface())
 }
 
-func (nt *ntuple) fill() {
-	for i := range nt.cols {
-		col := &nt.cols[i]
-		col.fill()
+// scan reads all the entries of the tree, calling fct for each entry.
+func (nt *ntuple) scan(tree rtree.Tree, fct func()) error {
+	r, err := rtree.NewReader(tree, nt.args)
+	if err != nil {
+		return fmt.Errorf("could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		fct()
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+
+	return r.Close()
+}
+
+func (nt *ntuple) dynamic() bool {
+	for _, col := range nt.cols {
+		if col.lay != nil && col.lay.dynamic() {
+			return true
+		}
+	}
+	return false
+}
+
+func (nt *ntuple) update() {
+	for _, col := range nt.cols {
+		col.update()
+	}
+}
+
+func (nt *ntuple) header() []string {
+	var names []string
+	for _, col := range nt.cols {
+		names = col.header(names)
+	}
+	return names
+}
+
+// nrows returns the number of CSV rows for the current entry.
+func (nt *ntuple) nrows() int {
+	n := 1
+	for _, col := range nt.cols {
+		if !col.explode {
+			continue
+		}
+		if v := col.data.Len(); v > n {
+			n = v
+		}
+	}
+	return n
+}
+
+// row appends the cells of the i-th CSV row of the current entry to dst.
+func (nt *ntuple) row(dst []string, i int) []string {
+	for _, col := range nt.cols {
+		dst = col.cells(dst, i)
+	}
+	return dst
 }
 
 type column struct {
-	name  string
-	i     int64
-	leaf  rtree.Leaf
-	etype reflect.Type
-	shape []int
-	data  reflect.Value
-	slice reflect.Value
+	name string
+	data reflect.Value
+
+	lay     *layout // layout of non-flat values, nil for scalars
+	explode bool    // whether each element is written out in its own row
+	json    bool    // whether values are encoded as JSON
+}
+
+func newColumn(name string, rt reflect.Type, mode flattenMode) *column {
+	col := &column{
+		name: name,
+		data: reflect.New(rt).Elem(),
+	}
+
+	switch rt.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Struct:
+		switch mode {
+		case flattenExplode:
+			if rt.Kind() == reflect.Struct {
+				col.lay = newLayout(rt)
+				break
+			}
+			col.lay = newLayout(rt.Elem())
+			col.explode = true
+		case flattenIndex:
+			col.lay = newLayout(rt)
+		case flattenJSON:
+			col.json = true
+		}
+	}
+
+	return col
 }
 
-func newColumn(name string, leaf rtree.Leaf, n int64) column {
-	etype := leaf.Type()
-	shape := []int{int(n)}
-	if leaf.Len() > 1 && leaf.Kind() != reflect.String {
-		etype = reflect.ArrayOf(leaf.Len(), etype)
-		shape = append(shape, leaf.Len())
-	}
-	rtype := reflect.SliceOf(etype)
-	return column{
-		name:  name,
-		i:     0,
-		leaf:  leaf,
-		etype: etype,
-		shape: shape,
-		data:  reflect.New(etype).Elem(),
-		slice: reflect.MakeSlice(rtype, int(n), int(n)),
+func (col *column) update() {
+	switch {
+	case col.lay == nil:
+		return
+	case col.explode:
+		for i := 0; i < col.data.Len(); i++ {
+			col.lay.update(col.data.Index(i))
+		}
+	default:
+		col.lay.update(col.data)
 	}
 }
 
-func (col *column) fill() {
-	col.slice.Index(int(col.i)).Set(col.data)
-	col.i++
+func (col *column) header(dst []string) []string {
+	if col.lay == nil {
+		return append(dst, col.name)
+	}
+	return col.lay.header(dst, col.name)
 }
 
-func processGraph(oname, fname string, g rhist.Graph) error {
+func (col *column) cells(dst []string, i int) []string {
+	switch {
+	case col.json:
+		return append(dst, formatJSON(col.data))
+	case col.lay == nil:
+		return append(dst, formatValue(col.data))
+	case col.explode:
+		v := reflect.Value{}
+		if i < col.data.Len() {
+			v = col.data.Index(i)
+		}
+		return col.lay.cells(dst, v)
+	default:
+		return col.lay.cells(dst, col.data)
+	}
+}
+
+func processGraph(oname, fname string, g rhist.Graph, opts options) error {
 	names := []string{"x", "y"}
 
-	tbl, err := csvutil.Create(oname)
+	tbl, err := createCSV(oname, opts.comma, opts.quote)
 	if err != nil {
 		return fmt.Errorf("could not create output CSV file: %w", err)
 	}
 	defer tbl.Close()
-	tbl.Writer.Comma = ';'
 
-	err = tbl.WriteHeader(fmt.Sprintf(
-		"## Automatically generated from %q\n%s\n",
-		fname,
-		strings.Join(names, string(tbl.Writer.Comma)),
-	))
+	err = tbl.writeHeader(fname, names)
 	if err != nil {
 		return fmt.Errorf("could not write CSV header: %w", err)
 	}
@@ -266,7 +430,7 @@ func processGraph(oname, fname string, g rhist.Graph) error {
 		var (
 			x, y = g.XY(i)
 		)
-		err = tbl.WriteRow(x, y)
+		err = tbl.write(formatFloats(x, y))
 		if err != nil {
 			return fmt.Errorf("could not write row %d to CSV file: %w", i, err)
 		}
@@ -280,21 +444,16 @@ func processGraph(oname, fname string, g rhist.Graph) error {
 	return nil
 }
 
-func processGraphErrors(oname, fname string, g rhist.GraphErrors) error {
+func processGraphErrors(oname, fname string, g rhist.GraphErrors, opts options) error {
 	names := []string{"x", "y", "ex-lo", "ex-hi", "ey-lo", "ey-hi"}
 
-	tbl, err := csvutil.Create(oname)
+	tbl, err := createCSV(oname, opts.comma, opts.quote)
 	if err != nil {
 		return fmt.Errorf("could not create output CSV file: %w", err)
 	}
 	defer tbl.Close()
-	tbl.Writer.Comma = ';'
 
-	err = tbl.WriteHeader(fmt.Sprintf(
-		"## Automatically generated from %q\n%s\n",
-		fname,
-		strings.Join(names, string(tbl.Writer.Comma)),
-	))
+	err = tbl.writeHeader(fname, names)
 	if err != nil {
 		return fmt.Errorf("could not write CSV header: %w", err)
 	}
@@ -306,7 +465,7 @@ func processGraphErrors(oname, fname string, g rhist.GraphErrors) error {
 			xlo, xhi = g.XError(i)
 			ylo, yhi = g.YError(i)
 		)
-		err = tbl.WriteRow(x, y, xlo, xhi, ylo, yhi)
+		err = tbl.write(formatFloats(x, y, xlo, xhi, ylo, yhi))
 		if err != nil {
 			return fmt.Errorf("could not write row %d to CSV file: %w", i, err)
 		}
@@ -319,3 +478,11 @@ func processGraphErrors(oname, fname string, g rhist.GraphErrors) error {
 
 	return nil
 }
+
+func formatFloats(vs ...float64) []string {
+	rec := make([]string, len(vs))
+	for i, v := range vs {
+		rec[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return rec
+}
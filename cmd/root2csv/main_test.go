@@ -17,6 +17,7 @@ func TestROOT2CSV(t *testing.T) {
 		file string
 		tree string
 		want string
+		opts options
 		skip bool
 	}{
 		{
@@ -40,6 +41,36 @@ func TestROOT2CSV(t *testing.T) {
 			want: "testdata/small-evnt-tree-nosplit.root.csv",
 			skip: true, // FIXME(sbinet)
 		},
+		{
+			file: "../../groot/testdata/small-evnt-tree-nosplit.root",
+			tree: "tree",
+			want: "testdata/small-evnt-tree-nosplit-index.root.csv",
+			opts: options{flatten: flattenIndex, comma: ';'},
+		},
+		{
+			file: "../../groot/testdata/ndim-slice.root",
+			tree: "tree",
+			want: "testdata/ndim-slice-json.root.csv",
+			opts: options{flatten: flattenJSON, comma: ';'},
+		},
+		{
+			file: "../../groot/testdata/small-flat-tree.root",
+			tree: "tree",
+			want: "testdata/small-flat-tree-explode.root.csv",
+			opts: options{flatten: flattenExplode, comma: ','},
+		},
+		{
+			file: "../../groot/testdata/ndim-slice.root",
+			tree: "tree",
+			want: "testdata/ndim-slice-index.root.csv",
+			opts: options{flatten: flattenIndex, comma: '\t'},
+		},
+		{
+			file: "../../groot/testdata/simple.root",
+			tree: "tree",
+			want: "testdata/simple-quote-all.root.csv",
+			opts: options{comma: ',', quote: quoteAll},
+		},
 		{
 			file: "../../groot/testdata/graphs.root",
 			tree: "tg",
@@ -73,7 +104,12 @@ func TestROOT2CSV(t *testing.T) {
 			f.Close()
 			defer os.Remove(f.Name())
 
-			err = process(f.Name(), tc.file, tc.tree)
+			opts := tc.opts
+			if opts == (options{}) {
+				opts = newOptions()
+			}
+
+			err = process(f.Name(), tc.file, tc.tree, opts)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -83,15 +83,7 @@
 //	  SliceFloat32 "SliceFloat32[N]/F"  TBranch
 //	  SliceFloat64 "SliceFloat64[N]/D"  TBranch
 //
-//	$> root2npy $GOPATH/src/go-hep.org/x/hep/groot/testdata/small-flat-tree.root
-//	root2npy: scanning leaves...
-//	root2npy: >>> "SliceInt32" []int32 not supported
-//	root2npy: >>> "SliceInt64" []int64 not supported
-//	root2npy: >>> "SliceInt32" []int32 not supported
-//	root2npy: >>> "SliceInt64" []int64 not supported
-//	root2npy: >>> "SliceFloat32" []float32 not supported
-//	root2npy: >>> "SliceFloat64" []float64 not supported
-//	root2npy: scanning leaves... [done]
+//	$> root2npy -f $GOPATH/src/go-hep.org/x/hep/groot/testdata/small-flat-tree.root
 //
 //	$> npyio-ls ./output.npz
 //	================================================================================
@@ -121,6 +113,37 @@
 //	... 97 98 98 98 98 98 98 98 98 98 98 99 99 99 99 99 99 99 99 99 99]
 //
 //	[...]
+//
+//	entry: SliceInt32
+//	npy-header: Header{Major:2, Minor:0, Descr:{Type:<i4, Fortran:false, Shape:[450]}}
+//	data = [1 2 2 3 3 3 4 4 4 4 5 5 5 5 5 6 6 6 6 6 6 7 7 7 7 7 7 7 8 8 8 8 ...
+//	... 98 98 98 98 98 98 98 98 99 99 99 99 99 99 99 99 99]
+//
+//	entry: SliceInt32_offsets
+//	npy-header: Header{Major:2, Minor:0, Descr:{Type:<i8, Fortran:false, Shape:[101]}}
+//	data = [0 0 1 3 6 10 15 21 28 36 45 45 46 48 51 55 60 66 73 81 90 ...
+//	... 405 405 406 408 411 415 420 426 433 441 450]
+//
+//	[...]
+//
+// Variable-length branches, such as "SliceInt32" above, are converted into
+// two NumPy arrays, following the layout of awkward arrays:
+//   - a flat content array, holding the elements of all the entries,
+//   - an offsets array (suffixed with "_offsets"), holding the boundaries
+//     of each entry inside the content array.
+//
+// The third entry of the "SliceInt32" branch can thus be retrieved with:
+//
+//	$> python3 -c 'import sys, numpy as np; f = np.load(sys.argv[1]); o = f["SliceInt32_offsets"]; print(f["SliceInt32"][o[2]:o[3]])' ./output.npz
+//	[2 2]
+//
+// or converted to an awkward array with:
+//
+//	>>> ak.unflatten(f["SliceInt32"], np.diff(f["SliceInt32_offsets"]))
+//
+// Branches that can not be represented as NumPy arrays (C++ objects,
+// nested variable-length branches, ...) are ignored.
+// The arrays are stored inside a compressed (deflate) NumPy data file.
 package main
 
 import (
@@ -181,6 +204,7 @@ func process(oname, fname, tname string) error {
 	}
 
 	cols := rnpy.NewColumns(tree)
+	jags := rnpy.NewJaggedColumns(tree)
 
 	out, err := os.Create(oname)
 	if err != nil {
@@ -191,33 +215,38 @@ func process(oname, fname, tname string) error {
 	npz := zip.NewWriter(out)
 	defer npz.Close()
 
-	wrk := make([]byte, 1*1024*1024)
-	buf := new(bytes.Buffer)
-	for _, col := range cols {
-		buf.Reset()
+	w := npzWriter{
+		npz: npz,
+		buf: new(bytes.Buffer),
+		wrk: make([]byte, 1*1024*1024),
+	}
 
+	for _, col := range cols {
 		sli, err := col.Slice()
 		if err != nil {
 			return fmt.Errorf("could not read %q: %w", col.Name(), err)
 		}
 
-		err = npyio.Write(buf, sli)
+		err = w.write(col.Name(), sli)
 		if err != nil {
-			return fmt.Errorf("could not write %q: %w", col.Name(), err)
+			return fmt.Errorf("could not process column %q: %w", col.Name(), err)
 		}
+	}
 
+	for _, col := range jags {
+		sli, offsets, err := col.Slices()
 		if err != nil {
-			return fmt.Errorf("could not process column %q: %w", col.Name(), err)
+			return fmt.Errorf("could not read %q: %w", col.Name(), err)
 		}
 
-		wz, err := npz.Create(col.Name())
+		err = w.write(col.Name(), sli)
 		if err != nil {
-			return fmt.Errorf("could not create column %q: %w", col.Name(), err)
+			return fmt.Errorf("could not process column %q: %w", col.Name(), err)
 		}
 
-		_, err = io.CopyBuffer(wz, buf, wrk)
+		err = w.write(col.Name()+offsetsSuffix, offsets)
 		if err != nil {
-			return fmt.Errorf("could not save column %q: %w", col.Name(), err)
+			return fmt.Errorf("could not process offsets of column %q: %w", col.Name(), err)
 		}
 	}
 
@@ -238,3 +267,34 @@ func process(oname, fname, tname string) error {
 
 	return nil
 }
+
+// offsetsSuffix is the suffix of the name of the NumPy arrays holding the
+// offsets of variable-length branches.
+const offsetsSuffix = "_offsets"
+
+type npzWriter struct {
+	npz *zip.Writer
+	buf *bytes.Buffer
+	wrk []byte
+}
+
+func (w *npzWriter) write(name string, sli interface{}) error {
+	w.buf.Reset()
+
+	err := npyio.Write(w.buf, sli)
+	if err != nil {
+		return fmt.Errorf("could not write %q: %w", name, err)
+	}
+
+	wz, err := w.npz.Create(name)
+	if err != nil {
+		return fmt.Errorf("could not create column %q: %w", name, err)
+	}
+
+	_, err = io.CopyBuffer(wz, w.buf, w.wrk)
+	if err != nil {
+		return fmt.Errorf("could not save column %q: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root2parquet converts the content of a ROOT TTree to a Parquet file.
+//
+//	Usage of root2parquet:
+//	  -b string
+//	    	comma-separated list of branches to convert (glob patterns) (default all)
+//	  -f string
+//	    	path to input ROOT file name
+//	  -o string
+//	    	path to output Parquet file name (default "output.parquet")
+//	  -rows int
+//	    	maximum number of entries per row group (0: no limit)
+//	  -size int
+//	    	maximum size in bytes of a row group (0: no limit) (default 134217728)
+//	  -t string
+//	    	name of the tree to convert (default "tree")
+//
+// Scalar branches are converted to Parquet columns of the corresponding
+// type.
+// Fixed-size arrays and variable-length arrays (jagged branches) are converted
+// to list columns, following the standard 3-level LIST structure of Parquet.
+// Multi-dimensional arrays are converted to nested list columns.
+// Branches holding C++ objects are ignored.
+//
+// Entries are written out in row groups of at most -rows entries and
+// -size bytes.
+// Column chunks are written out uncompressed, with a single data page.
+//
+// Example:
+//
+//	$> root2parquet -o out.parquet -t tree -f testdata/small-flat-tree.root
+//	$> root2parquet -o out.parquet -t tree -f testdata/small-flat-tree.root -b 'Int*,SliceFloat64' -rows 1000
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path"
+	"reflect"
+	"strings"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func main() {
+	log.SetPrefix("root2parquet: ")
+	log.SetFlags(0)
+
+	fname := flag.String("f", "", "path to input ROOT file name")
+	oname := flag.String("o", "output.parquet", "path to output Parquet file name")
+	tname := flag.String("t", "tree", "name of the tree to convert")
+	bnames := flag.String("b", "", "comma-separated list of branches to convert (glob patterns) (default all)")
+	rows := flag.Int64("rows", 0, "maximum number of entries per row group (0: no limit)")
+	size := flag.Int64("size", 128<<20, "maximum size in bytes of a row group (0: no limit)")
+
+	flag.Parse()
+
+	if *fname == "" {
+		flag.Usage()
+		log.Fatalf("missing input ROOT filename argument")
+	}
+
+	opts := newOptions()
+	if *bnames != "" {
+		opts.branches = strings.Split(*bnames, ",")
+	}
+	opts.rows = *rows
+	opts.size = *size
+
+	err := process(*oname, *fname, *tname, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+type options struct {
+	branches []string // glob patterns of the branches to convert
+	rows     int64    // maximum number of entries per row group
+	size     int64    // maximum size in bytes of a row group
+}
+
+func newOptions() options {
+	return options{
+		size: 128 << 20,
+	}
+}
+
+func process(oname, fname, tname string, opts options) error {
+	switch {
+	case opts.rows < 0:
+		return fmt.Errorf("invalid number of entries per row group (%d)", opts.rows)
+	case opts.size < 0:
+		return fmt.Errorf("invalid row group size (%d)", opts.size)
+	}
+	for _, pattern := range opts.branches {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("invalid branch pattern %q: %w", pattern, err)
+		}
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file: %w", err)
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get(tname)
+	if err != nil {
+		return fmt.Errorf("could not get ROOT object: %w", err)
+	}
+
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		return fmt.Errorf("object %q in file %q is not a rtree.Tree", tname, fname)
+	}
+
+	var (
+		cols  []*pqColumn
+		rvars []rtree.ReadVar
+	)
+	log.Printf("scanning leaves...")
+	for _, leaf := range tree.Leaves() {
+		if !selected(leaf.Branch().Name(), opts.branches) {
+			continue
+		}
+		if len(leaf.Branch().Branches()) > 0 {
+			log.Printf(">>> %q %v ignored (split into sub-branches)", leaf.Name(), leaf.Class())
+			continue
+		}
+		col, err := newColumn(columnName(leaf), leafType(leaf))
+		if err != nil {
+			log.Printf(">>> %q %v not supported (%v)", leaf.Name(), leaf.Class(), err)
+			continue
+		}
+		cols = append(cols, col)
+		rvars = append(rvars, rtree.ReadVar{
+			Name:  leaf.Branch().Name(),
+			Leaf:  leaf.Name(),
+			Value: col.data.Addr().Interface(),
+		})
+	}
+	log.Printf("scanning leaves... [done]")
+
+	if len(cols) == 0 {
+		return fmt.Errorf("no branch to convert in tree %q", tname)
+	}
+
+	r, err := rtree.NewReader(tree, rvars)
+	if err != nil {
+		return fmt.Errorf("could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	pq, err := createParquet(oname, cols)
+	if err != nil {
+		return fmt.Errorf("could not create output Parquet file: %w", err)
+	}
+	defer pq.Close()
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		pq.append()
+		if (opts.rows > 0 && pq.nrows >= opts.rows) || (opts.size > 0 && pq.size() >= opts.size) {
+			err := pq.flush()
+			if err != nil {
+				return fmt.Errorf("could not write row group: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not read tree: %w", err)
+	}
+
+	err = r.Close()
+	if err != nil {
+		return fmt.Errorf("could not close tree reader: %w", err)
+	}
+
+	err = pq.Close()
+	if err != nil {
+		return fmt.Errorf("could not close Parquet output file: %w", err)
+	}
+
+	return nil
+}
+
+// selected returns whether the named branch matches one of the provided
+// patterns.
+// All branches are selected when no pattern is provided.
+func selected(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// columnName returns the name of the column holding the values of the
+// provided leaf: the name of its branch, or the name of the leaf for
+// branches with multiple leaves.
+func columnName(leaf rtree.Leaf) string {
+	br := leaf.Branch()
+	if len(br.Leaves()) == 1 {
+		return br.Name()
+	}
+	return leaf.Name()
+}
+
+// leafType returns the type of the values held by the provided leaf.
+func leafType(leaf rtree.Leaf) reflect.Type {
+	rt := leaf.Type()
+	switch rt.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Struct, reflect.String:
+		return rt
+	}
+
+	shape := leaf.Shape()
+	switch {
+	case leaf.LeafCount() != nil:
+		for i := range shape {
+			rt = reflect.ArrayOf(shape[len(shape)-1-i], rt)
+		}
+		rt = reflect.SliceOf(rt)
+	case len(shape) > 0:
+		for i := range shape {
+			rt = reflect.ArrayOf(shape[len(shape)-1-i], rt)
+		}
+	case leaf.Len() > 1:
+		rt = reflect.ArrayOf(leaf.Len(), rt)
+	}
+	return rt
+}
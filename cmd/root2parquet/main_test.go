@@ -0,0 +1,138 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main // import "go-hep.org/x/hep/cmd/root2parquet"
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var regen = flag.Bool("regen", false, "regenerate reference files")
+
+func TestROOT2Parquet(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		file string
+		tree string
+		opts options
+		want string
+	}{
+		{
+			name: "simple",
+			file: "../../groot/testdata/simple.root",
+			tree: "tree",
+			opts: newOptions(),
+			want: "testdata/simple.parquet",
+		},
+		{
+			name: "leaves",
+			file: "../../groot/testdata/leaves.root",
+			tree: "tree",
+			opts: newOptions(),
+			want: "testdata/leaves.parquet",
+		},
+		{
+			name: "ndim-slice",
+			file: "../../groot/testdata/ndim-slice.root",
+			tree: "tree",
+			opts: newOptions(),
+			want: "testdata/ndim-slice.parquet",
+		},
+		{
+			name: "row-groups",
+			file: "../../groot/testdata/small-flat-tree.root",
+			tree: "tree",
+			opts: options{rows: 30},
+			want: "testdata/small-flat-tree-rows.parquet",
+		},
+		{
+			name: "row-groups-size",
+			file: "../../groot/testdata/small-flat-tree.root",
+			tree: "tree",
+			opts: options{size: 16 << 10},
+			want: "testdata/small-flat-tree-size.parquet",
+		},
+		{
+			name: "branches",
+			file: "../../groot/testdata/small-flat-tree.root",
+			tree: "tree",
+			opts: options{branches: []string{"Int*", "Str", "Slice*", "N"}, size: 128 << 20},
+			want: "testdata/small-flat-tree-branches.parquet",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(t.TempDir(), "out.parquet")
+			err := process(oname, tc.file, tc.tree, tc.opts)
+			if err != nil {
+				t.Fatalf("could not convert: %+v", err)
+			}
+
+			got, err := os.ReadFile(oname)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *regen {
+				_ = os.WriteFile(tc.want, got, 0644)
+			}
+
+			want, err := os.ReadFile(tc.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Fatalf("Parquet files differ")
+			}
+		})
+	}
+}
+
+func TestROOT2ParquetErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		tree string
+		opts options
+	}{
+		{"invalid-rows", "tree", options{rows: -1}},
+		{"invalid-size", "tree", options{size: -1}},
+		{"invalid-pattern", "tree", options{branches: []string{"[Int"}}},
+		{"no-branch", "tree", options{branches: []string{"NotThere"}}},
+		{"no-tree", "NotThere", newOptions()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(t.TempDir(), "out.parquet")
+			err := process(oname, "../../groot/testdata/small-flat-tree.root", tc.tree, tc.opts)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestRLE(t *testing.T) {
+	levels := []uint8{
+		0, 1,
+		1, 1,
+		1, 1,
+		0, 0,
+		0, 1,
+	}
+	for _, tc := range []struct {
+		off  int
+		want []byte
+	}{
+		{0, []byte{1 << 1, 0, 2 << 1, 1, 2 << 1, 0}},
+		{1, []byte{3 << 1, 1, 1 << 1, 0, 1 << 1, 1}},
+	} {
+		got := appendRLE(nil, levels, tc.off)
+		if !bytes.Equal(got, tc.want) {
+			t.Fatalf("invalid RLE encoding (off=%d):\ngot= %v\nwant=%v", tc.off, got, tc.want)
+		}
+	}
+}
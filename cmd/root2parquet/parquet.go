@@ -0,0 +1,494 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+)
+
+const pqMagic = "PAR1"
+
+// Parquet physical types.
+const (
+	pqBoolean   = 0
+	pqInt32     = 1
+	pqInt64     = 2
+	pqFloat     = 4
+	pqDouble    = 5
+	pqByteArray = 6
+)
+
+// Parquet converted types.
+const (
+	pqNone   = -1
+	pqUTF8   = 0
+	pqList   = 3
+	pqUint8  = 11
+	pqUint16 = 12
+	pqUint32 = 13
+	pqUint64 = 14
+	pqInt8   = 15
+	pqInt16  = 16
+)
+
+// Parquet repetition types.
+const (
+	pqRequired = 0
+	pqRepeated = 2
+)
+
+// Parquet encodings.
+const (
+	pqPlain = 0
+	pqRLE   = 3
+)
+
+const pqDataPage = 0
+
+// pqWriter writes a Parquet file, one row group at a time.
+//
+// Columns are written out uncompressed, with one PLAIN-encoded data page
+// per column chunk.
+type pqWriter struct {
+	f   *os.File
+	w   *bufio.Writer
+	pos int64 // current position in the file
+
+	cols []*pqColumn
+	rgs  []pqRowGroup
+
+	nrows int64 // number of rows of the current row group
+	tot   int64 // total number of rows
+
+	enc tcEncoder
+}
+
+type pqRowGroup struct {
+	chunks []pqChunk
+	size   int64
+	nrows  int64
+}
+
+type pqChunk struct {
+	offset int64
+	size   int64
+	nvals  int64
+}
+
+func createParquet(fname string, cols []*pqColumn) (*pqWriter, error) {
+	f, err := os.Create(fname)
+	if err != nil {
+		return nil, err
+	}
+	w := &pqWriter{
+		f:    f,
+		w:    bufio.NewWriter(f),
+		cols: cols,
+	}
+
+	err = w.write([]byte(pqMagic))
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *pqWriter) write(p []byte) error {
+	n, err := w.w.Write(p)
+	w.pos += int64(n)
+	return err
+}
+
+// append appends the current values of the columns as a new row.
+func (w *pqWriter) append() {
+	for _, col := range w.cols {
+		col.append(col.data, 0, 0)
+	}
+	w.nrows++
+}
+
+// size returns the size of the buffered data of the current row group.
+func (w *pqWriter) size() int64 {
+	var n int64
+	for _, col := range w.cols {
+		n += col.size()
+	}
+	return n
+}
+
+// flush writes out the buffered rows as a new row group.
+func (w *pqWriter) flush() error {
+	if w.nrows == 0 {
+		return nil
+	}
+
+	rg := pqRowGroup{
+		chunks: make([]pqChunk, len(w.cols)),
+		nrows:  w.nrows,
+	}
+	for i, col := range w.cols {
+		page := col.page()
+		w.enc.reset()
+		w.enc.i32(1, pqDataPage)
+		w.enc.i32(2, int32(len(page)))
+		w.enc.i32(3, int32(len(page)))
+		w.enc.begin(5)
+		w.enc.i32(1, int32(col.nvals))
+		w.enc.i32(2, pqPlain)
+		w.enc.i32(3, pqRLE)
+		w.enc.i32(4, pqRLE)
+		w.enc.end()
+		w.enc.end()
+
+		chunk := pqChunk{
+			offset: w.pos,
+			size:   int64(len(w.enc.bytes()) + len(page)),
+			nvals:  int64(col.nvals),
+		}
+		err := w.write(w.enc.bytes())
+		if err != nil {
+			return fmt.Errorf("could not write page header of column %q: %w", col.name, err)
+		}
+		err = w.write(page)
+		if err != nil {
+			return fmt.Errorf("could not write page of column %q: %w", col.name, err)
+		}
+		rg.chunks[i] = chunk
+		rg.size += chunk.size
+		col.reset()
+	}
+
+	w.rgs = append(w.rgs, rg)
+	w.tot += w.nrows
+	w.nrows = 0
+	return nil
+}
+
+// Close flushes the last row group, writes the file metadata and closes
+// the file.
+func (w *pqWriter) Close() error {
+	if w.f == nil {
+		return nil
+	}
+	defer func() {
+		_ = w.f.Close()
+		w.f = nil
+	}()
+
+	err := w.flush()
+	if err != nil {
+		return err
+	}
+
+	meta := w.meta()
+	err = w.write(meta)
+	if err != nil {
+		return fmt.Errorf("could not write file metadata: %w", err)
+	}
+	err = w.write(binary.LittleEndian.AppendUint32(nil, uint32(len(meta))))
+	if err != nil {
+		return fmt.Errorf("could not write file metadata length: %w", err)
+	}
+	err = w.write([]byte(pqMagic))
+	if err != nil {
+		return err
+	}
+
+	err = w.w.Flush()
+	if err != nil {
+		return err
+	}
+
+	err = w.f.Close()
+	w.f = nil
+	return err
+}
+
+// meta returns the Thrift encoded FileMetaData of the file.
+func (w *pqWriter) meta() []byte {
+	var elems []pqSchema
+	for _, col := range w.cols {
+		elems = col.schema(elems)
+	}
+
+	enc := &w.enc
+	enc.reset()
+	enc.i32(1, 1) // version
+	enc.list(2, tcStruct, len(elems)+1)
+	enc.begin(-1)
+	enc.str(4, "schema")
+	enc.i32(5, int32(len(w.cols)))
+	enc.end()
+	for _, elem := range elems {
+		elem.encode(enc)
+	}
+	enc.i64(3, w.tot)
+	enc.list(4, tcStruct, len(w.rgs))
+	for _, rg := range w.rgs {
+		enc.begin(-1)
+		enc.list(1, tcStruct, len(rg.chunks))
+		for i, chunk := range rg.chunks {
+			col := w.cols[i]
+			enc.begin(-1)
+			enc.i64(2, chunk.offset)
+			enc.begin(3)
+			enc.i32(1, col.ptype)
+			enc.i32s(2, pqPlain, pqRLE)
+			enc.strs(3, col.path()...)
+			enc.i32(4, 0) // uncompressed
+			enc.i64(5, chunk.nvals)
+			enc.i64(6, chunk.size)
+			enc.i64(7, chunk.size)
+			enc.i64(9, chunk.offset)
+			enc.end()
+			enc.end()
+		}
+		enc.i64(2, rg.size)
+		enc.i64(3, rg.nrows)
+		enc.end()
+	}
+	enc.str(6, "go-hep.org/x/hep/cmd/root2parquet")
+	enc.end()
+
+	return enc.bytes()
+}
+
+type pqSchema struct {
+	name  string
+	ptype int32 // physical type, or -1 for groups
+	rep   int32
+	nkids int32
+	ctype int32
+}
+
+func (elem pqSchema) encode(enc *tcEncoder) {
+	enc.begin(-1)
+	if elem.ptype >= 0 {
+		enc.i32(1, elem.ptype)
+	}
+	enc.i32(3, elem.rep)
+	enc.str(4, elem.name)
+	if elem.ptype < 0 {
+		enc.i32(5, elem.nkids)
+	}
+	if elem.ctype != pqNone {
+		enc.i32(6, elem.ctype)
+	}
+	enc.end()
+}
+
+// pqColumn buffers the values of a tree leaf, converted to a Parquet
+// column.
+//
+// Arrays and slices are converted to (possibly nested) lists, following
+// the standard 3-level LIST structure:
+//
+//	required group name (LIST) {
+//	  repeated group list {
+//	    required element;
+//	  }
+//	}
+type pqColumn struct {
+	name  string
+	data  reflect.Value
+	depth int // number of nested lists
+
+	ptype int32 // Parquet physical type
+	ctype int32 // Parquet converted type
+
+	levels []uint8 // repetition and definition levels
+	vals   []byte  // PLAIN encoded values
+	bools  []bool  // boolean values
+	nvals  int     // number of values, including empty lists
+}
+
+func newColumn(name string, rt reflect.Type) (*pqColumn, error) {
+	col := &pqColumn{
+		name: name,
+		data: reflect.New(rt).Elem(),
+	}
+
+	for rt.Kind() == reflect.Array || rt.Kind() == reflect.Slice {
+		col.depth++
+		rt = rt.Elem()
+	}
+
+	if col.depth > math.MaxUint8 {
+		return nil, fmt.Errorf("too many nested lists (%d)", col.depth)
+	}
+
+	col.ctype = pqNone
+	switch rt.Kind() {
+	case reflect.Bool:
+		col.ptype = pqBoolean
+	case reflect.Int8:
+		col.ptype = pqInt32
+		col.ctype = pqInt8
+	case reflect.Int16:
+		col.ptype = pqInt32
+		col.ctype = pqInt16
+	case reflect.Int32:
+		col.ptype = pqInt32
+	case reflect.Int64:
+		col.ptype = pqInt64
+	case reflect.Uint8:
+		col.ptype = pqInt32
+		col.ctype = pqUint8
+	case reflect.Uint16:
+		col.ptype = pqInt32
+		col.ctype = pqUint16
+	case reflect.Uint32:
+		col.ptype = pqInt32
+		col.ctype = pqUint32
+	case reflect.Uint64:
+		col.ptype = pqInt64
+		col.ctype = pqUint64
+	case reflect.Float32:
+		col.ptype = pqFloat
+	case reflect.Float64:
+		col.ptype = pqDouble
+	case reflect.String:
+		col.ptype = pqByteArray
+		col.ctype = pqUTF8
+	default:
+		return nil, fmt.Errorf("unsupported type %v", rt)
+	}
+
+	return col, nil
+}
+
+// path returns the path of the column leaf in the schema.
+func (col *pqColumn) path() []string {
+	path := []string{col.name}
+	for i := 0; i < col.depth; i++ {
+		path = append(path, "list", "element")
+	}
+	return path
+}
+
+// schema appends the schema elements of the column to dst.
+func (col *pqColumn) schema(dst []pqSchema) []pqSchema {
+	name := col.name
+	for i := 0; i < col.depth; i++ {
+		dst = append(dst,
+			pqSchema{name: name, ptype: -1, rep: pqRequired, nkids: 1, ctype: pqList},
+			pqSchema{name: "list", ptype: -1, rep: pqRepeated, nkids: 1, ctype: pqNone},
+		)
+		name = "element"
+	}
+	return append(dst, pqSchema{name: name, ptype: col.ptype, rep: pqRequired, ctype: col.ctype})
+}
+
+// append appends the provided value, at the provided list depth and
+// repetition level.
+func (col *pqColumn) append(rv reflect.Value, depth, rep int) {
+	if depth == col.depth {
+		col.levels = append(col.levels, uint8(rep), uint8(col.depth))
+		col.nvals++
+		col.put(rv)
+		return
+	}
+
+	n := rv.Len()
+	if n == 0 {
+		// empty list: only the enclosing lists are defined.
+		col.levels = append(col.levels, uint8(rep), uint8(depth))
+		col.nvals++
+		return
+	}
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			rep = depth + 1
+		}
+		col.append(rv.Index(i), depth+1, rep)
+	}
+}
+
+func (col *pqColumn) put(rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		col.bools = append(col.bools, rv.Bool())
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		col.vals = binary.LittleEndian.AppendUint32(col.vals, uint32(rv.Int()))
+	case reflect.Int64:
+		col.vals = binary.LittleEndian.AppendUint64(col.vals, uint64(rv.Int()))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		col.vals = binary.LittleEndian.AppendUint32(col.vals, uint32(rv.Uint()))
+	case reflect.Uint64:
+		col.vals = binary.LittleEndian.AppendUint64(col.vals, rv.Uint())
+	case reflect.Float32:
+		col.vals = binary.LittleEndian.AppendUint32(col.vals, math.Float32bits(float32(rv.Float())))
+	case reflect.Float64:
+		col.vals = binary.LittleEndian.AppendUint64(col.vals, math.Float64bits(rv.Float()))
+	case reflect.String:
+		str := rv.String()
+		col.vals = binary.LittleEndian.AppendUint32(col.vals, uint32(len(str)))
+		col.vals = append(col.vals, str...)
+	default:
+		panic(fmt.Errorf("invalid value type %v", rv.Type()))
+	}
+}
+
+func (col *pqColumn) size() int64 {
+	return int64(len(col.levels) + len(col.vals) + len(col.bools)/8)
+}
+
+func (col *pqColumn) reset() {
+	col.levels = col.levels[:0]
+	col.vals = col.vals[:0]
+	col.bools = col.bools[:0]
+	col.nvals = 0
+}
+
+// page returns the content of the data page holding the buffered values.
+func (col *pqColumn) page() []byte {
+	var page []byte
+	if col.depth > 0 {
+		// repetition levels, then definition levels.
+		for _, off := range []int{0, 1} {
+			beg := len(page)
+			page = append(page, 0, 0, 0, 0)
+			page = appendRLE(page, col.levels, off)
+			binary.LittleEndian.PutUint32(page[beg:], uint32(len(page)-beg-4))
+		}
+	}
+
+	if col.ptype == pqBoolean {
+		bits := make([]byte, (len(col.bools)+7)/8)
+		for i, v := range col.bools {
+			if v {
+				bits[i/8] |= 1 << (i % 8)
+			}
+		}
+		return append(page, bits...)
+	}
+	return append(page, col.vals...)
+}
+
+// appendRLE appends the RLE encoding of the levels to dst.
+// levels holds interleaved repetition and definition levels: off selects
+// which ones are encoded.
+// Levels fit in one byte, as the number of nested lists is at most 255.
+func appendRLE(dst []byte, levels []uint8, off int) []byte {
+	for i := off; i < len(levels); {
+		var (
+			v = levels[i]
+			n = 0
+		)
+		for i < len(levels) && levels[i] == v {
+			n++
+			i += 2
+		}
+		dst = binary.AppendUvarint(dst, uint64(n)<<1)
+		dst = append(dst, v)
+	}
+	return dst
+}
@@ -0,0 +1,116 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/binary"
+)
+
+// Thrift compact protocol types.
+const (
+	tcStop   = 0
+	tcI32    = 5
+	tcI64    = 6
+	tcBinary = 8
+	tcList   = 9
+	tcStruct = 12
+)
+
+// tcEncoder encodes Thrift structures with the compact protocol, as used
+// by the Parquet file metadata and page headers.
+type tcEncoder struct {
+	buf  []byte
+	last int16   // id of the last written field of the current struct
+	ids  []int16 // ids of the last written fields of the enclosing structs
+}
+
+func (enc *tcEncoder) bytes() []byte { return enc.buf }
+
+func (enc *tcEncoder) reset() {
+	enc.buf = enc.buf[:0]
+	enc.last = 0
+	enc.ids = enc.ids[:0]
+}
+
+func (enc *tcEncoder) uvarint(v uint64) {
+	enc.buf = binary.AppendUvarint(enc.buf, v)
+}
+
+func (enc *tcEncoder) varint(v int64) {
+	enc.buf = binary.AppendVarint(enc.buf, v) // zigzag encoding
+}
+
+func (enc *tcEncoder) field(id int16, typ byte) {
+	delta := id - enc.last
+	switch {
+	case delta > 0 && delta <= 15:
+		enc.buf = append(enc.buf, byte(delta)<<4|typ)
+	default:
+		enc.buf = append(enc.buf, typ)
+		enc.varint(int64(id))
+	}
+	enc.last = id
+}
+
+func (enc *tcEncoder) i32(id int16, v int32) {
+	enc.field(id, tcI32)
+	enc.varint(int64(v))
+}
+
+func (enc *tcEncoder) i64(id int16, v int64) {
+	enc.field(id, tcI64)
+	enc.varint(v)
+}
+
+func (enc *tcEncoder) str(id int16, v string) {
+	enc.field(id, tcBinary)
+	enc.uvarint(uint64(len(v)))
+	enc.buf = append(enc.buf, v...)
+}
+
+func (enc *tcEncoder) list(id int16, typ byte, n int) {
+	enc.field(id, tcList)
+	switch {
+	case n < 15:
+		enc.buf = append(enc.buf, byte(n)<<4|typ)
+	default:
+		enc.buf = append(enc.buf, 0xf0|typ)
+		enc.uvarint(uint64(n))
+	}
+}
+
+func (enc *tcEncoder) i32s(id int16, vs ...int32) {
+	enc.list(id, tcI32, len(vs))
+	for _, v := range vs {
+		enc.varint(int64(v))
+	}
+}
+
+func (enc *tcEncoder) strs(id int16, vs ...string) {
+	enc.list(id, tcBinary, len(vs))
+	for _, v := range vs {
+		enc.uvarint(uint64(len(v)))
+		enc.buf = append(enc.buf, v...)
+	}
+}
+
+// begin starts a structure, either as the field id of the enclosing
+// structure or, when id is negative, as an element of a list.
+func (enc *tcEncoder) begin(id int16) {
+	if id >= 0 {
+		enc.field(id, tcStruct)
+	}
+	enc.ids = append(enc.ids, enc.last)
+	enc.last = 0
+}
+
+// end ends a structure.
+func (enc *tcEncoder) end() {
+	enc.buf = append(enc.buf, tcStop)
+	if n := len(enc.ids); n > 0 {
+		enc.last = enc.ids[n-1]
+		enc.ids = enc.ids[:n-1]
+	}
+}
@@ -45,6 +45,35 @@ type Conn struct {
 	Comment rune        `json:"comment"` // comment character for start of line (default: '#')
 	Header  bool        `json:"header"`  // whether the CSV-file has a column header
 	Names   []string    `json:"names"`   // column names
+
+	// Infer is the number of rows used to infer the types of the columns.
+	// All the rows are used if Infer is not positive.
+	Infer int `json:"infer"`
+
+	NA      []string      `json:"na"`      // values denoting a missing field, in addition to the empty one
+	Missing MissingPolicy `json:"missing"` // how records with missing fields are handled
+}
+
+// MissingPolicy describes how records with missing fields are handled.
+type MissingPolicy int
+
+const (
+	MissingZero  MissingPolicy = iota // missing fields are set to the zero value of their column
+	MissingSkip                       // records with missing fields are skipped
+	MissingError                      // records with missing fields are rejected with an error
+)
+
+// isNA returns whether the field denotes a missing value.
+func (c *Conn) isNA(field string) bool {
+	if field == "" {
+		return true
+	}
+	for _, na := range c.NA {
+		if field == na {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Conn) setDefaults() {
@@ -137,7 +166,10 @@ func (drv *csvDriver) Open(cfg string) (driver.Conn, error) {
 	if drv.dbs == nil {
 		drv.dbs = make(map[string]*csvConn)
 	}
-	conn := drv.dbs[c.File]
+	// connections are keyed by their configuration, so that a file opened
+	// with different options is imported anew.
+	key := cfg
+	conn := drv.dbs[key]
 	if conn == nil {
 		var f *os.File
 		switch {
@@ -173,6 +205,7 @@ func (drv *csvDriver) Open(cfg string) (driver.Conn, error) {
 		conn = &csvConn{
 			f:    f,
 			cfg:  c,
+			key:  key,
 			drv:  drv,
 			refs: 0,
 		}
@@ -188,7 +221,7 @@ func (drv *csvDriver) Open(cfg string) (driver.Conn, error) {
 				return nil, err
 			}
 		}
-		drv.dbs[c.File] = conn
+		drv.dbs[key] = conn
 	}
 	conn.refs++
 
@@ -198,6 +231,7 @@ func (drv *csvDriver) Open(cfg string) (driver.Conn, error) {
 type csvConn struct {
 	f    *os.File
 	cfg  Conn
+	key  string // key of the connection in the driver cache
 	drv  *csvDriver
 	refs int
 
@@ -260,7 +294,7 @@ func (conn *csvConn) Close() error {
 
 	conn.drv.mu.Lock()
 	if conn.refs == 1 {
-		delete(conn.drv.dbs, conn.f.Name())
+		delete(conn.drv.dbs, conn.key)
 	}
 	conn.refs = 0
 	conn.drv.mu.Unlock()
@@ -6,12 +6,14 @@ package csvdriver
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
 	"io"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"go-hep.org/x/hep/csvutil"
 )
@@ -61,16 +63,16 @@ func (conn *csvConn) importCSV() error {
 	}
 	defer rows.Close()
 
-	vargs, pargs := schema.Args()
+	vargs := schema.Args()
 	def := schema.Def()
 	insert := "insert into csv values(" + def + ");"
 	for rows.Next() {
-		err = rows.Scan(pargs...)
+		ok, err := schema.convert(vargs, rows.Fields(), &conn.cfg)
 		if err != nil {
 			return err
 		}
-		for i, arg := range pargs {
-			vargs[i].Value = reflect.ValueOf(arg).Elem().Interface()
+		if !ok {
+			continue
 		}
 		_, err = conn.ExecContext(ctx, insert, vargs)
 		if err != nil {
@@ -104,67 +106,113 @@ func inferSchema(conn *csvConn, header bool, names []string) (schemaType, error)
 	tbl.Reader.Comma = conn.cfg.Comma
 	tbl.Reader.Comment = conn.cfg.Comment
 
-	return inferSchemaFromTable(tbl, header, names)
+	return inferSchemaFromTable(tbl, header, names, &conn.cfg)
 }
 
-func inferSchemaFromTable(tbl *csvutil.Table, header bool, names []string) (schemaType, error) {
-	var (
-		beg int64 = 0
-		end int64 = 1
-	)
+// inferSchemaFromTable infers the schema of the table from the first
+// cfg.Infer rows of data, or from all the rows if cfg.Infer is not positive.
+func inferSchemaFromTable(tbl *csvutil.Table, header bool, names []string, cfg *Conn) (schemaType, error) {
 	if header {
-		end++
+		rows, err := tbl.ReadRows(0, 1)
+		if err != nil {
+			return nil, err
+		}
+		if !rows.Next() {
+			return nil, rows.Err()
+		}
+		if len(names) == 0 {
+			names = rows.Fields()
+		}
+	}
+
+	end := int64(-1)
+	if cfg.Infer > 0 {
+		end = int64(cfg.Infer)
 	}
-	rows, err := tbl.ReadRows(beg, end)
+	rows, err := tbl.ReadRows(0, end)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	if header {
-		if !rows.Next() {
-			return nil, rows.Err()
+	var kinds []reflect.Kind
+	for rows.Next() {
+		fields := rows.Fields()
+		if kinds == nil {
+			kinds = make([]reflect.Kind, len(fields))
 		}
-		if len(names) == 0 {
-			names = rows.Fields()
+		for i, field := range fields {
+			if i >= len(kinds) || cfg.isNA(field) {
+				continue
+			}
+			kinds[i] = mergeKinds(kinds[i], kindOf(field))
 		}
 	}
-
-	if !rows.Next() {
-		return nil, rows.Err()
+	err = rows.Err()
+	if err == io.EOF {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if kinds == nil {
+		return nil, nil
 	}
 
-	return inferSchemaFromFields(rows.Fields(), names)
+	return newSchema(kinds, names), nil
 }
 
-func inferSchemaFromFields(fields []string, names []string) (schemaType, error) {
+func newSchema(kinds []reflect.Kind, names []string) schemaType {
 	if len(names) == 0 {
-		names = make([]string, len(fields))
+		names = make([]string, len(kinds))
 	}
-	schema := make(schemaType, len(fields))
-	for i, field := range fields {
-		var err error
+	schema := make(schemaType, len(kinds))
+	for i, kind := range kinds {
 		name := names[i]
 		if name == "" {
 			name = fmt.Sprintf("var%d", i+1)
 		}
-
 		schema[i].n = name
-		_, err = strconv.ParseInt(field, 10, 64)
-		if err == nil {
+		switch kind {
+		case reflect.Int64:
 			schema[i].v = reflect.ValueOf(int64(0))
-			continue
-		}
-
-		_, err = strconv.ParseFloat(field, 64)
-		if err == nil {
+		case reflect.Float64:
 			schema[i].v = reflect.ValueOf(float64(0))
-			continue
+		case reflect.Bool:
+			schema[i].v = reflect.ValueOf(false)
+		default:
+			schema[i].v = reflect.ValueOf("")
 		}
+	}
+	return schema
+}
 
-		schema[i].v = reflect.ValueOf("")
+// kindOf returns the kind of the value held by the CSV field.
+func kindOf(field string) reflect.Kind {
+	if _, err := strconv.ParseInt(field, 10, 64); err == nil {
+		return reflect.Int64
+	}
+	if _, err := strconv.ParseFloat(field, 64); err == nil {
+		return reflect.Float64
 	}
-	return schema, nil
+	if _, err := strconv.ParseBool(field); err == nil {
+		return reflect.Bool
+	}
+	return reflect.String
+}
+
+// mergeKinds returns the kind able to hold values of kinds a and b.
+func mergeKinds(a, b reflect.Kind) reflect.Kind {
+	switch {
+	case a == reflect.Invalid:
+		return b
+	case a == b:
+		return a
+	case a == reflect.Int64 && b == reflect.Float64,
+		a == reflect.Float64 && b == reflect.Int64:
+		return reflect.Float64
+	}
+	return reflect.String
 }
 
 type schemaType []struct {
@@ -182,19 +230,55 @@ func (st *schemaType) Decl() string {
 	return strings.Join(o, ", ")
 }
 
-func (st *schemaType) Args() ([]driver.NamedValue, []interface{}) {
+func (st *schemaType) Args() []driver.NamedValue {
 	vargs := make([]driver.NamedValue, len(*st))
-	pargs := make([]interface{}, len(*st))
 	for i, v := range *st {
-		ptr := reflect.New(v.v.Type())
 		vargs[i] = driver.NamedValue{
 			Name:    v.n,
 			Ordinal: i + 1,
-			Value:   ptr.Elem().Interface(),
+			Value:   reflect.Zero(v.v.Type()).Interface(),
+		}
+	}
+	return vargs
+}
+
+// convert converts the CSV fields into the values of vargs.
+// convert returns false if the record should be skipped, because of a
+// missing value.
+func (st *schemaType) convert(vargs []driver.NamedValue, fields []string, cfg *Conn) (bool, error) {
+	for i, v := range *st {
+		var (
+			field string
+			err   error
+		)
+		if i < len(fields) {
+			field = fields[i]
+		}
+		if cfg.isNA(field) {
+			switch cfg.Missing {
+			case MissingSkip:
+				return false, nil
+			case MissingError:
+				return false, fmt.Errorf("csvdriver: missing value for column %q", v.n)
+			}
+			vargs[i].Value = reflect.Zero(v.v.Type()).Interface()
+			continue
+		}
+		switch v.v.Kind() {
+		case reflect.Int64:
+			vargs[i].Value, err = strconv.ParseInt(field, 10, 64)
+		case reflect.Float64:
+			vargs[i].Value, err = strconv.ParseFloat(field, 64)
+		case reflect.Bool:
+			vargs[i].Value, err = strconv.ParseBool(field)
+		default:
+			vargs[i].Value = field
+		}
+		if err != nil {
+			return false, fmt.Errorf("csvdriver: could not convert column %q: %w", v.n, err)
 		}
-		pargs[i] = ptr.Interface()
 	}
-	return vargs, pargs
+	return true, nil
 }
 
 func (st *schemaType) Def() string {
@@ -204,3 +288,127 @@ func (st *schemaType) Def() string {
 	}
 	return strings.Join(o, ", ")
 }
+
+var chunkID atomic.Int64
+
+// Chunks reads the CSV file described by c in chunks of at most n records.
+//
+// For each chunk, f is called with a database holding the records of that
+// chunk in a table named "csv".
+// The database is closed when f returns, so that only one chunk is held
+// in memory at any time.
+// The types of the columns are inferred once, before reading the chunks,
+// so that all the chunks share the same schema.
+func (c Conn) Chunks(n int, f func(db *sql.DB) error) error {
+	if n <= 0 {
+		return fmt.Errorf("csvdriver: invalid chunk size (%d)", n)
+	}
+	c.setDefaults()
+
+	open := func() (*csvutil.Table, error) {
+		tbl, err := csvutil.Open(c.File)
+		if err != nil {
+			return nil, err
+		}
+		tbl.Reader.Comma = c.Comma
+		tbl.Reader.Comment = c.Comment
+		return tbl, nil
+	}
+
+	tbl, err := open()
+	if err != nil {
+		return err
+	}
+	schema, err := inferSchemaFromTable(tbl, c.Header, c.Names, &c)
+	_ = tbl.Close()
+	if err != nil {
+		return err
+	}
+	if schema == nil {
+		return nil
+	}
+
+	tbl, err = open()
+	if err != nil {
+		return err
+	}
+	defer tbl.Close()
+
+	beg := int64(0)
+	if c.Header {
+		beg++
+	}
+	rows, err := tbl.ReadRows(beg, -1)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		vargs  = schema.Args()
+		args   = make([]any, len(vargs))
+		create = "create table csv (" + schema.Decl() + ");"
+		insert = "insert into csv values(" + schema.Def() + ");"
+	)
+	for {
+		db, err := sql.Open("ql", fmt.Sprintf("memory://csvdriver-chunk-%d", chunkID.Add(1)))
+		if err != nil {
+			return err
+		}
+
+		nrecs, err := func() (int, error) {
+			tx, err := db.Begin()
+			if err != nil {
+				return 0, err
+			}
+			defer tx.Rollback()
+
+			_, err = tx.Exec(create)
+			if err != nil {
+				return 0, err
+			}
+			_, err = tx.Exec("create index csv_id on csv (id());")
+			if err != nil {
+				return 0, err
+			}
+
+			nrecs := 0
+			for nrecs < n && rows.Next() {
+				ok, err := schema.convert(vargs, rows.Fields(), &c)
+				if err != nil {
+					return nrecs, err
+				}
+				if !ok {
+					continue
+				}
+				for i := range vargs {
+					args[i] = vargs[i].Value
+				}
+				_, err = tx.Exec(insert, args...)
+				if err != nil {
+					return nrecs, err
+				}
+				nrecs++
+			}
+			return nrecs, tx.Commit()
+		}()
+		if err == nil && nrecs > 0 {
+			err = f(db)
+		}
+		if e := db.Close(); e != nil && err == nil {
+			err = e
+		}
+		if err != nil {
+			return err
+		}
+		if nrecs < n {
+			break
+		}
+	}
+
+	err = rows.Err()
+	if err == io.EOF {
+		err = nil
+	}
+	return err
+}
@@ -0,0 +1,138 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+)
+
+// Binned1D holds the bins of a 1-dim histogram, in the format expected by
+// Func1D for a binned fit.
+//
+// Only the bins that are not masked are retained, in the order of the bins
+// of the histogram.
+type Binned1D struct {
+	X     []float64     // centers of the bins
+	Y     []float64     // contents of the bins
+	Err   []float64     // errors on the contents of the bins
+	Edges []hbook.Range // edges of the bins
+	Index []int         // indices of the bins in the histogram
+}
+
+// NewBinned1D returns the bins of the histogram h that are not masked.
+//
+// A bin is masked when mask returns true for the index of that bin in the
+// histogram. If mask is nil, the bins without any entry are masked.
+func NewBinned1D(h *hbook.H1D, mask func(i int, bin hbook.Bin1D) bool) *Binned1D {
+	if mask == nil {
+		mask = func(i int, bin hbook.Bin1D) bool {
+			return bin.Entries() <= 0
+		}
+	}
+
+	var (
+		bins = h.Binning.Bins
+		n    = len(bins)
+		b    = &Binned1D{
+			X:     make([]float64, 0, n),
+			Y:     make([]float64, 0, n),
+			Err:   make([]float64, 0, n),
+			Edges: make([]hbook.Range, 0, n),
+			Index: make([]int, 0, n),
+		}
+	)
+	for i, bin := range bins {
+		if mask(i, bin) {
+			continue
+		}
+		b.X = append(b.X, bin.XMid())
+		b.Y = append(b.Y, bin.SumW())
+		b.Err = append(b.Err, bin.ErrW())
+		b.Edges = append(b.Edges, bin.Range)
+		b.Index = append(b.Index, i)
+	}
+	return b
+}
+
+// Len returns the number of retained bins.
+func (b *Binned1D) Len() int {
+	return len(b.Y)
+}
+
+// Func1D returns a copy of f, with its data set to the retained bins.
+func (b *Binned1D) Func1D(f Func1D) Func1D {
+	f.X = b.X
+	f.Y = b.Y
+	f.Err = b.Err
+	return f
+}
+
+// Binned2D holds the bins of a 2-dim histogram, in the format expected by
+// FuncND for a binned fit.
+//
+// Only the bins that are not masked are retained, in the order of the bins
+// of the histogram.
+type Binned2D struct {
+	X      [][]float64   // centers (x,y) of the bins
+	Y      []float64     // contents of the bins
+	Err    []float64     // errors on the contents of the bins
+	XEdges []hbook.Range // edges of the bins along x
+	YEdges []hbook.Range // edges of the bins along y
+	Index  [][2]int      // indices (ix,iy) of the bins in the histogram
+}
+
+// NewBinned2D returns the bins of the histogram h that are not masked.
+//
+// A bin is masked when mask returns true for the indices (ix,iy) of that bin
+// in the histogram. If mask is nil, the bins without any entry are masked.
+func NewBinned2D(h *hbook.H2D, mask func(ix, iy int, bin hbook.Bin2D) bool) *Binned2D {
+	if mask == nil {
+		mask = func(ix, iy int, bin hbook.Bin2D) bool {
+			return bin.Entries() <= 0
+		}
+	}
+
+	var (
+		bins = h.Binning.Bins
+		nx   = h.Binning.Nx
+		n    = len(bins)
+		b    = &Binned2D{
+			X:      make([][]float64, 0, n),
+			Y:      make([]float64, 0, n),
+			Err:    make([]float64, 0, n),
+			XEdges: make([]hbook.Range, 0, n),
+			YEdges: make([]hbook.Range, 0, n),
+			Index:  make([][2]int, 0, n),
+		}
+	)
+	for i, bin := range bins {
+		ix, iy := i%nx, i/nx
+		if mask(ix, iy, bin) {
+			continue
+		}
+		b.X = append(b.X, []float64{bin.XMid(), bin.YMid()})
+		b.Y = append(b.Y, bin.SumW())
+		b.Err = append(b.Err, math.Sqrt(bin.SumW2()))
+		b.XEdges = append(b.XEdges, bin.XRange)
+		b.YEdges = append(b.YEdges, bin.YRange)
+		b.Index = append(b.Index, [2]int{ix, iy})
+	}
+	return b
+}
+
+// Len returns the number of retained bins.
+func (b *Binned2D) Len() int {
+	return len(b.Y)
+}
+
+// FuncND returns a copy of f, with its data set to the retained bins.
+func (b *Binned2D) FuncND(f FuncND) FuncND {
+	f.X = b.X
+	f.Y = b.Y
+	f.Err = b.Err
+	return f
+}
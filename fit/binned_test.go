@@ -0,0 +1,81 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestBinned1D(t *testing.T) {
+	h := hbook.NewH1D(4, 0, 4)
+	h.Fill(0.5, 1)
+	h.Fill(0.5, 1)
+	h.Fill(2.5, 3)
+
+	b := fit.NewBinned1D(h, nil)
+	if got, want := b.Len(), 2; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	for _, tc := range []struct {
+		name      string
+		got, want any
+	}{
+		{"x", b.X, []float64{0.5, 2.5}},
+		{"y", b.Y, []float64{2, 3}},
+		{"err", b.Err, []float64{1.4142135623730951, 3}},
+		{"edges", b.Edges, []hbook.Range{{Min: 0, Max: 1}, {Min: 2, Max: 3}}},
+		{"index", b.Index, []int{0, 2}},
+	} {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Fatalf("invalid %s:\ngot= %v\nwant=%v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	b = fit.NewBinned1D(h, func(i int, bin hbook.Bin1D) bool { return i == 1 })
+	if got, want := b.Index, []int{0, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid masked indices: got=%v, want=%v", got, want)
+	}
+
+	f := b.Func1D(fit.Func1D{})
+	if !reflect.DeepEqual(f.X, b.X) || !reflect.DeepEqual(f.Y, b.Y) || !reflect.DeepEqual(f.Err, b.Err) {
+		t.Fatalf("invalid Func1D data")
+	}
+}
+
+func TestBinned2D(t *testing.T) {
+	h := hbook.NewH2D(2, 0, 2, 2, 0, 2)
+	h.Fill(0.5, 0.5, 1)
+	h.Fill(1.5, 1.5, 2)
+	h.Fill(1.5, 1.5, 2)
+
+	b := fit.NewBinned2D(h, nil)
+	if got, want := b.Len(), 2; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	for _, tc := range []struct {
+		name      string
+		got, want any
+	}{
+		{"x", b.X, [][]float64{{0.5, 0.5}, {1.5, 1.5}}},
+		{"y", b.Y, []float64{1, 4}},
+		{"err", b.Err, []float64{1, 2.8284271247461903}},
+		{"xedges", b.XEdges, []hbook.Range{{Min: 0, Max: 1}, {Min: 1, Max: 2}}},
+		{"yedges", b.YEdges, []hbook.Range{{Min: 0, Max: 1}, {Min: 1, Max: 2}}},
+		{"index", b.Index, [][2]int{{0, 0}, {1, 1}}},
+	} {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Fatalf("invalid %s:\ngot= %v\nwant=%v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	f := b.FuncND(fit.FuncND{})
+	if !reflect.DeepEqual(f.X, b.X) || !reflect.DeepEqual(f.Y, b.Y) || !reflect.DeepEqual(f.Err, b.Err) {
+		t.Fatalf("invalid FuncND data")
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+// Cost is the cost function minimized by a fit, as a function of the
+// parameters of the fit.
+//
+// Cost functions are negative log-likelihoods, up to a constant:
+// the cost of a least-squares fit is χ²/2.
+// A change of 1/2 of the cost thus corresponds to one standard deviation
+// of the parameters.
+type Cost func(ps []float64) float64
+
+// Cost returns the least-squares cost function of fitting f to its data,
+// as minimized by Curve1D.
+//
+// The Gaussian constraints of f.Params are not included.
+// Cost panics if the covariance matrix f.Cov is invalid.
+func (f Func1D) Cost() Cost {
+	f.init()
+	if err := f.initCov(); err != nil {
+		panic(err)
+	}
+	return f.fct
+}
+
+// PoissonCost returns the binned Poisson likelihood cost function of fitting
+// f to its data, as minimized by H1DPoisson.
+//
+// The Gaussian constraints of f.Params are not included.
+func (f Func1D) PoissonCost() Cost {
+	f.initPoisson()
+	return f.fct
+}
+
+// Cost returns the least-squares cost function of fitting f to its data,
+// as minimized by CurveND.
+//
+// The Gaussian constraints of f.Params are not included.
+// Cost panics if the covariance matrix f.Cov is invalid.
+func (f FuncND) Cost() Cost {
+	f.init()
+	if err := f.initCov(); err != nil {
+		panic(err)
+	}
+	return f.fct
+}
+
+// PoissonCost returns the binned Poisson likelihood cost function of fitting
+// f to its data, as minimized by H2DPoisson.
+//
+// The Gaussian constraints of f.Params are not included.
+func (f FuncND) PoissonCost() Cost {
+	f.initPoisson()
+	return f.fct
+}
@@ -10,20 +10,27 @@ import (
 
 // Curve1D returns the result of a non-linear least squares to fit
 // a function f to the underlying data with method m.
+//
+// Curve1DResult also provides the errors on the parameters and the goodness
+// of fit.
 func Curve1D(f Func1D, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
-	f.init()
-
-	p := optimize.Problem{
-		Func: f.fct,
-		Grad: f.grad,
-		Hess: f.hess,
-	}
+	res, err := Curve1DResult(f, settings, m)
+	return res.optResult(), err
+}
 
-	if m == nil {
-		m = &optimize.NelderMead{}
+// Curve1DResult returns the result of a non-linear least squares to fit
+// a function f to the underlying data with method m, as Curve1D does, with
+// the errors on the parameters and the goodness of fit.
+func Curve1DResult(f Func1D, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	f.init()
+	if err := f.initCov(); err != nil {
+		return nil, err
 	}
+	return f.minimize(settings, m)
+}
 
-	p0 := make([]float64, len(f.Ps))
-	copy(p0, f.Ps)
-	return optimize.Minimize(p, p0, settings, m)
+// minimize minimizes the cost function of f, starting from its initial
+// parameters, with method m.
+func (f *Func1D) minimize(settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	return run(objective{f.fct, f.grad, len(f.X), f.unit}, f.Ps, f.Params, settings, m)
 }
@@ -11,20 +11,21 @@ import (
 // CurveND returns the result of a non-linear least squares to fit
 // a function f to the underlying data with method m, where there
 // is more than one independent variable.
+//
+// CurveNDResult also provides the errors on the parameters and the goodness
+// of fit.
 func CurveND(f FuncND, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
-	f.init()
-
-	p := optimize.Problem{
-		Func: f.fct,
-		Grad: f.grad,
-		Hess: f.hess,
-	}
+	res, err := CurveNDResult(f, settings, m)
+	return res.optResult(), err
+}
 
-	if m == nil {
-		m = &optimize.NelderMead{}
+// CurveNDResult returns the result of a non-linear least squares to fit
+// a function f to the underlying data with method m, as CurveND does, with
+// the errors on the parameters and the goodness of fit.
+func CurveNDResult(f FuncND, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	f.init()
+	if err := f.initCov(); err != nil {
+		return nil, err
 	}
-
-	p0 := make([]float64, len(f.Ps))
-	copy(p0, f.Ps)
-	return optimize.Minimize(p, p0, settings, m)
+	return run(objective{f.fct, nil, len(f.X), f.unit}, f.Ps, f.Params, settings, m)
 }
@@ -6,8 +6,13 @@
 package fit // import "go-hep.org/x/hep/fit"
 
 import (
+	"fmt"
+	"math"
+
 	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/floats"
 	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
 )
 
 //go:generate go get github.com/campoy/embedmd
@@ -28,15 +33,34 @@ type Func1D struct {
 	// length N filled with zeros.
 	Ps []float64
 
+	// Params configures the bounds, fixing and constraints of the
+	// parameters, indexed as Ps.
+	// If Params is nil, all the parameters are free and unbounded.
+	Params []Param
+
+	// Grad, when not nil, stores in grad the gradient of F at x with
+	// respect to the parameters ps.
+	// Grad is used to compute the gradient of the cost function
+	// analytically, instead of with finite differences.
+	Grad func(grad []float64, x float64, ps []float64)
+
 	X   []float64
 	Y   []float64
 	Err []float64
 
-	sig2 []float64 // inverse of squares of measurement errors along Y.
+	// Cov, when not nil, is the covariance matrix of the measurements
+	// along Y, for a generalized least-squares fit of correlated
+	// measurements. Err is ignored when Cov is not nil.
+	// Curve1D returns an error when Cov is not positive definite, or when
+	// its dimension differs from the length of Y.
+	Cov *mat.SymDense
+
+	sig2 []float64     // inverse of squares of measurement errors along Y.
+	winv *mat.SymDense // inverse of the covariance matrix of the measurements along Y.
+	unit bool          // whether the measurement errors are unknown, and taken as unity.
 
 	fct  func(ps []float64) float64 // cost function (objective function)
-	grad func(grad, ps []float64)
-	hess func(hess *mat.SymDense, x []float64)
+	grad func(grad, ps []float64)   // gradient of the cost function, nil if not analytic.
 }
 
 func (f *Func1D) init() {
@@ -78,13 +102,110 @@ func (f *Func1D) init() {
 		return 0.5 * chi2
 	}
 
-	f.grad = func(grad, ps []float64) {
-		fd.Gradient(grad, f.fct, ps, nil)
+	f.unit = f.Err == nil && f.Cov == nil
+	f.winv = nil
+
+	f.grad = nil
+	if f.Grad != nil {
+		var (
+			df  = make([]float64, len(f.Ps))
+			wr  = mat.NewVecDense(len(f.Y), nil)
+			res = mat.NewVecDense(len(f.Y), nil)
+		)
+		f.grad = func(grad, ps []float64) {
+			f.weighted(wr, res, ps)
+			clear(grad)
+			for i := range f.X {
+				f.Grad(df, f.X[i], ps)
+				floats.AddScaled(grad, wr.AtVec(i), df)
+			}
+		}
+	}
+}
+
+// initCov initializes the generalized least-squares cost function of f,
+// when the covariance matrix of the measurements is provided.
+func (f *Func1D) initCov() error {
+	if f.Cov == nil {
+		return nil
 	}
 
-	f.hess = func(hess *mat.SymDense, x []float64) {
-		fd.Hessian(hess, f.fct, x, nil)
+	winv, err := invCov(f.Cov, len(f.Y))
+	if err != nil {
+		return err
 	}
+	f.winv = winv
+
+	res := mat.NewVecDense(len(f.Y), nil)
+	f.fct = func(ps []float64) float64 {
+		for i := range f.X {
+			res.SetVec(i, f.F(f.X[i], ps)-f.Y[i])
+		}
+		return 0.5 * mat.Inner(res, f.winv, res)
+	}
+	return nil
+}
+
+// weighted stores in wr the residuals of the fit, weighted by the inverse of
+// the covariance matrix of the measurements.
+// res is used to store the residuals.
+func (f *Func1D) weighted(wr, res *mat.VecDense, ps []float64) {
+	for i := range f.X {
+		res.SetVec(i, f.F(f.X[i], ps)-f.Y[i])
+	}
+	switch f.winv {
+	case nil:
+		for i := range f.X {
+			wr.SetVec(i, res.AtVec(i)*f.sig2[i])
+		}
+	default:
+		wr.MulVec(f.winv, res)
+	}
+}
+
+// initPoisson initializes f for a binned extended maximum-likelihood fit,
+// where Y holds the observed number of entries in each bin and F the
+// expected number of entries in the bin centered on x.
+//
+// The cost function is the negative log of the ratio of the Poisson
+// likelihood of the model over the one of the saturated model, so its
+// minimum approaches χ²/2 in the large-statistics limit.
+func (f *Func1D) initPoisson() {
+	f.init()
+	f.unit = false
+	f.fct = func(ps []float64) float64 {
+		var nll float64
+		for i := range f.X {
+			nll += poissonNLL(f.Y[i], f.F(f.X[i], ps))
+		}
+		return nll
+	}
+
+	if f.Grad != nil {
+		df := make([]float64, len(f.Ps))
+		f.grad = func(grad, ps []float64) {
+			clear(grad)
+			for i := range f.X {
+				nu := f.F(f.X[i], ps)
+				f.Grad(df, f.X[i], ps)
+				floats.AddScaled(grad, 1-f.Y[i]/nu, df)
+			}
+		}
+	}
+}
+
+// poissonNLL returns the negative log-likelihood of observing n entries
+// when nu are expected, relative to the one of observing n when n are expected.
+func poissonNLL(n, nu float64) float64 {
+	switch {
+	case nu <= 0 && n > 0:
+		return math.Inf(+1)
+	case nu <= 0:
+		return 0
+	case n <= 0:
+		return nu
+	}
+	return nu - n + n*math.Log(n/nu)
 }
 
 // FuncND describes a multivariate function F(x0, x1... xn; p0, p1... pn)
@@ -104,6 +225,11 @@ type FuncND struct {
 	// length N filled with zeros.
 	Ps []float64
 
+	// Params configures the bounds, fixing and constraints of the
+	// parameters, indexed as Ps.
+	// If Params is nil, all the parameters are free and unbounded.
+	Params []Param
+
 	// X is the multidimensional slice of the independent variables,
 	// it must be structured so that the X[i] is a list of values for the
 	// independent variables that corresponds to a single Y value.
@@ -113,11 +239,18 @@ type FuncND struct {
 	Y   []float64
 	Err []float64
 
-	sig2 []float64 // inverse of squares of measurement errors along Y.
+	// Cov, when not nil, is the covariance matrix of the measurements
+	// along Y, for a generalized least-squares fit of correlated
+	// measurements. Err is ignored when Cov is not nil.
+	// CurveND returns an error when Cov is not positive definite, or when
+	// its dimension differs from the length of Y.
+	Cov *mat.SymDense
 
-	fct  func(ps []float64) float64 // cost function (objective function)
-	grad func(grad, ps []float64)
-	hess func(hess *mat.SymDense, x []float64) // hessian matrix
+	sig2 []float64     // inverse of squares of measurement errors along Y.
+	winv *mat.SymDense // inverse of the covariance matrix of the measurements along Y.
+	unit bool          // whether the measurement errors are unknown, and taken as unity.
+
+	fct func(ps []float64) float64 // cost function (objective function)
 }
 
 func (f *FuncND) init() {
@@ -159,11 +292,124 @@ func (f *FuncND) init() {
 		return 0.5 * chi2
 	}
 
-	f.grad = func(grad []float64, ps []float64) {
-		fd.Gradient(grad, f.fct, ps, nil)
+	f.unit = f.Err == nil && f.Cov == nil
+	f.winv = nil
+}
+
+// initCov initializes the generalized least-squares cost function of f,
+// when the covariance matrix of the measurements is provided.
+func (f *FuncND) initCov() error {
+	if f.Cov == nil {
+		return nil
+	}
+
+	winv, err := invCov(f.Cov, len(f.Y))
+	if err != nil {
+		return err
+	}
+	f.winv = winv
+
+	res := mat.NewVecDense(len(f.Y), nil)
+	f.fct = func(ps []float64) float64 {
+		for i := range f.X {
+			res.SetVec(i, f.F(f.X[i], ps)-f.Y[i])
+		}
+		return 0.5 * mat.Inner(res, f.winv, res)
+	}
+	return nil
+}
+
+// invCov returns the inverse of the covariance matrix cov of n measurements.
+func invCov(cov *mat.SymDense, n int) (*mat.SymDense, error) {
+	if dim := cov.SymmetricDim(); dim != n {
+		return nil, fmt.Errorf("fit: invalid covariance matrix dimension (got=%d, want=%d)", dim, n)
+	}
+
+	var chol mat.Cholesky
+	if ok := chol.Factorize(cov); !ok {
+		return nil, fmt.Errorf("fit: covariance matrix is not positive definite")
+	}
+
+	inv := mat.NewSymDense(n, nil)
+	if err := chol.InverseTo(inv); err != nil {
+		return nil, fmt.Errorf("fit: could not invert covariance matrix: %w", err)
+	}
+	return inv, nil
+}
+
+// initPoisson initializes f for a binned extended maximum-likelihood fit,
+// where Y holds the observed number of entries in each bin and F the
+// expected number of entries in the bin centered on x.
+func (f *FuncND) initPoisson() {
+	f.init()
+	f.unit = false
+	f.fct = func(ps []float64) float64 {
+		var nll float64
+		for i := range f.X {
+			nll += poissonNLL(f.Y[i], f.F(f.X[i], ps))
+		}
+		return nll
+	}
+}
+
+// minimize minimizes the cost function fct, starting from the parameters ps,
+// with method m.
+// The gradient of fct is computed with grad, or with finite differences when
+// grad is nil.
+// The parameters are bounded, fixed and constrained as configured by params.
+// In case m is nil, optimize.NelderMead is used.
+func minimize(fct Cost, grad func(grad, ps []float64), ps []float64, params []Param, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
+	if m == nil {
+		m = &optimize.NelderMead{}
+	}
+
+	tr, err := newTransform(ps, params)
+	if err != nil {
+		return nil, err
+	}
+	if tr == nil {
+		p0 := make([]float64, len(ps))
+		copy(p0, ps)
+		return optimize.Minimize(problem(fct, grad), p0, settings, m)
 	}
 
-	f.hess = func(hess *mat.SymDense, x []float64) {
-		fd.Hessian(hess, f.fct, x, nil)
+	res, err := optimize.Minimize(problem(tr.cost(fct), tr.grad(grad)), tr.internal(ps), settings, m)
+	if res != nil {
+		tr.result(res, fct, grad)
+	}
+	return res, err
+}
+
+// objective is the cost function minimized by a fit.
+type objective struct {
+	fct   Cost
+	grad  func(grad, ps []float64) // gradient of fct, nil if not analytic.
+	ndata int                      // number of data points, negative when the χ² is not defined.
+	unit  bool                     // whether fct is a χ² computed with unit measurement errors.
+}
+
+// run minimizes the cost function of obj, as minimize does, and returns the
+// result of the fit.
+func run(obj objective, ps []float64, params []Param, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	res, err := minimize(obj.fct, obj.grad, ps, params, settings, m)
+	return newResult(res, obj, params), err
+}
+
+// problem returns the optimization problem of minimizing fct, with its
+// gradient computed with grad, and its hessian computed with finite
+// differences.
+// The gradient is computed with finite differences when grad is nil.
+func problem(fct func(ps []float64) float64, grad func(grad, ps []float64)) optimize.Problem {
+	if grad == nil {
+		grad = func(grad, ps []float64) {
+			fd.Gradient(grad, fct, ps, nil)
+		}
+	}
+	return optimize.Problem{
+		Func: fct,
+		Grad: grad,
+		Hess: func(hess *mat.SymDense, ps []float64) {
+			fd.Hessian(hess, fct, ps, nil)
+		},
 	}
 }
@@ -0,0 +1,138 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestGLS(t *testing.T) {
+	// average of 2 correlated measurements.
+	var (
+		y1, s1 = 10.0, 1.0
+		y2, s2 = 12.0, 2.0
+		rho    = 0.5
+		cov    = mat.NewSymDense(2, []float64{
+			s1 * s1, rho * s1 * s2,
+			rho * s1 * s2, s2 * s2,
+		})
+		cst = func(x float64, ps []float64) float64 { return ps[0] }
+	)
+
+	// best linear unbiased estimate of the average.
+	var (
+		v12  = rho * s1 * s2
+		w1   = (s2*s2 - v12) / (s1*s1 + s2*s2 - 2*v12)
+		want = w1*y1 + (1-w1)*y2
+		err  = math.Sqrt((s1*s1*s2*s2 - v12*v12) / (s1*s1 + s2*s2 - 2*v12))
+	)
+
+	for _, tc := range []struct {
+		name string
+		grad func(grad []float64, x float64, ps []float64)
+		m    optimize.Method
+	}{
+		{"numeric", nil, &optimize.NelderMead{}},
+		{"analytic", func(grad []float64, x float64, ps []float64) { grad[0] = 1 }, &optimize.BFGS{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err2 := fit.Curve1DResult(
+				fit.Func1D{
+					F:    cst,
+					Grad: tc.grad,
+					X:    []float64{0, 1},
+					Y:    []float64{y1, y2},
+					Cov:  cov,
+					Ps:   []float64{1},
+				},
+				nil, tc.m,
+			)
+			if err2 != nil {
+				t.Fatalf("could not fit: %+v", err2)
+			}
+			if got := res.X[0]; math.Abs(got-want) > 1e-6 {
+				t.Fatalf("invalid average: got=%v, want=%v", got, want)
+			}
+			if got := res.Errs()[0]; math.Abs(got-err) > 1e-5 {
+				t.Fatalf("invalid error: got=%v, want=%v", got, err)
+			}
+		})
+	}
+}
+
+func TestGLSDiagonal(t *testing.T) {
+	// a diagonal covariance matrix is equivalent to per-point errors.
+	var (
+		xs = []float64{0, 1, 2, 3, 4}
+		ys = []float64{1.1, 2.9, 5.2, 6.8, 9.1}
+		es = []float64{0.1, 0.2, 0.3, 0.2, 0.1}
+		f  = func(x []float64, ps []float64) float64 { return ps[0] + ps[1]*x[0] }
+		xn = make([][]float64, len(xs))
+	)
+	cov := mat.NewSymDense(len(es), nil)
+	for i, e := range es {
+		cov.SetSym(i, i, e*e)
+		xn[i] = []float64{xs[i]}
+	}
+
+	ref, err := fit.CurveNDResult(fit.FuncND{F: f, X: xn, Y: ys, Err: es, Ps: []float64{0, 1}}, nil, nil)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	res, err := fit.CurveNDResult(fit.FuncND{F: f, X: xn, Y: ys, Cov: cov, Ps: []float64{0, 1}}, nil, nil)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	if got, want := res.X, ref.X; !floats.EqualApprox(got, want, 1e-6) {
+		t.Fatalf("invalid fit:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := res.Chi2, ref.Chi2; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("invalid chi2: got=%v, want=%v", got, want)
+	}
+}
+
+func TestGLSInvalidCov(t *testing.T) {
+	var (
+		xs = []float64{0, 1, 2}
+		ys = []float64{1, 2, 3}
+		xn = [][]float64{{0}, {1}, {2}}
+		f1 = func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x }
+		fn = func(x []float64, ps []float64) float64 { return ps[0] + ps[1]*x[0] }
+	)
+
+	for _, tc := range []struct {
+		name string
+		cov  *mat.SymDense
+	}{
+		{"not-positive-definite", mat.NewSymDense(3, []float64{1, 2, 0, 2, 1, 0, 0, 0, 1})},
+		{"invalid-dimension", mat.NewSymDense(2, []float64{1, 0, 0, 1})},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := fit.Curve1D(fit.Func1D{F: f1, X: xs, Y: ys, Cov: tc.cov, N: 2}, nil, nil)
+			if err == nil {
+				t.Fatalf("expected an error from Curve1D")
+			}
+
+			_, err = fit.CurveND(fit.FuncND{F: fn, X: xn, Y: ys, Cov: tc.cov, N: 2}, nil, nil)
+			if err == nil {
+				t.Fatalf("expected an error from CurveND")
+			}
+
+			_, err = fit.Simultaneous([]fit.Channel{{
+				Func:   fit.Func1D{F: f1, X: xs, Y: ys, Cov: tc.cov},
+				Params: []int{0, 1},
+			}}, []float64{0, 0}, nil, nil, nil)
+			if err == nil {
+				t.Fatalf("expected an error from Simultaneous")
+			}
+		})
+	}
+}
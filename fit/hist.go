@@ -14,27 +14,98 @@ import (
 // Only bins with at least an entry are considered for the fit.
 // In case settings is nil, the optimize.DefaultSettingsLocal is used.
 // In case m is nil, the same default optimization method than for Curve1D is used.
+//
+// H1DResult also provides the errors on the parameters and the goodness of
+// fit.
 func H1D(h *hbook.H1D, f Func1D, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
-	var (
-		n     = h.Len()
-		xdata = make([]float64, 0, n)
-		ydata = make([]float64, 0, n)
-		yerrs = make([]float64, 0, n)
-		bins  = h.Binning.Bins
-	)
-
-	for _, bin := range bins {
-		if bin.Entries() <= 0 {
-			continue
-		}
-		xdata = append(xdata, bin.XMid())
-		ydata = append(ydata, bin.SumW())
-		yerrs = append(yerrs, bin.ErrW())
-	}
+	res, err := H1DResult(h, f, settings, m)
+	return res.optResult(), err
+}
 
-	f.X = xdata
-	f.Y = ydata
-	f.Err = yerrs
+// H1DResult returns the fit of histogram h with function f and optimization
+// method m, as H1D does, with the errors on the parameters and the goodness
+// of fit.
+func H1DResult(h *hbook.H1D, f Func1D, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	f = NewBinned1D(h, nil).Func1D(f)
+	return Curve1DResult(f, settings, m)
+}
 
-	return Curve1D(f, settings, m)
+// H1DPoisson returns the binned extended maximum-likelihood fit of histogram h
+// with function f and optimization method m.
+//
+// The contents of the bins are considered as Poisson distributed numbers of
+// entries, and f is the model of the expected number of entries in the bin
+// centered on x.
+// Contrary to H1D, all the bins are considered for the fit, including the empty
+// ones, which makes it suitable for low-statistics spectra.
+// In case settings is nil, the optimize.DefaultSettingsLocal is used.
+// In case m is nil, the same default optimization method than for Curve1D is used.
+//
+// H1DPoissonResult also provides the errors on the parameters and the
+// goodness of fit.
+func H1DPoisson(h *hbook.H1D, f Func1D, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
+	res, err := H1DPoissonResult(h, f, settings, m)
+	return res.optResult(), err
+}
+
+// H1DPoissonResult returns the binned extended maximum-likelihood fit of
+// histogram h with function f and optimization method m, as H1DPoisson does,
+// with the errors on the parameters and the goodness of fit.
+func H1DPoissonResult(h *hbook.H1D, f Func1D, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	f = NewBinned1D(h, func(int, hbook.Bin1D) bool { return false }).Func1D(f)
+	f.initPoisson()
+	return f.minimize(settings, m)
+}
+
+// H2D returns the fit of histogram h with function f and optimization method m.
+//
+// The function f is evaluated at the centers (x,y) of the bins.
+// Only the bins that are not masked are considered for the fit, as for
+// NewBinned2D: if mask is nil, the bins without any entry are masked.
+// In case settings is nil, the optimize.DefaultSettingsLocal is used.
+// In case m is nil, the same default optimization method than for CurveND is used.
+//
+// H2DResult also provides the errors on the parameters and the goodness of
+// fit.
+func H2D(h *hbook.H2D, f FuncND, mask func(ix, iy int, bin hbook.Bin2D) bool, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
+	res, err := H2DResult(h, f, mask, settings, m)
+	return res.optResult(), err
+}
+
+// H2DResult returns the fit of histogram h with function f and optimization
+// method m, as H2D does, with the errors on the parameters and the goodness
+// of fit.
+func H2DResult(h *hbook.H2D, f FuncND, mask func(ix, iy int, bin hbook.Bin2D) bool, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	f = NewBinned2D(h, mask).FuncND(f)
+	return CurveNDResult(f, settings, m)
+}
+
+// H2DPoisson returns the binned extended maximum-likelihood fit of histogram h
+// with function f and optimization method m.
+//
+// The contents of the bins are considered as Poisson distributed numbers of
+// entries, and f is the model of the expected number of entries in the bin
+// centered on (x,y).
+// Only the bins that are not masked are considered for the fit, as for
+// NewBinned2D, except that if mask is nil, no bin is masked.
+// In case settings is nil, the optimize.DefaultSettingsLocal is used.
+// In case m is nil, the same default optimization method than for CurveND is used.
+//
+// H2DPoissonResult also provides the errors on the parameters and the
+// goodness of fit.
+func H2DPoisson(h *hbook.H2D, f FuncND, mask func(ix, iy int, bin hbook.Bin2D) bool, settings *optimize.Settings, m optimize.Method) (*optimize.Result, error) {
+	res, err := H2DPoissonResult(h, f, mask, settings, m)
+	return res.optResult(), err
+}
+
+// H2DPoissonResult returns the binned extended maximum-likelihood fit of
+// histogram h with function f and optimization method m, as H2DPoisson does,
+// with the errors on the parameters and the goodness of fit.
+func H2DPoissonResult(h *hbook.H2D, f FuncND, mask func(ix, iy int, bin hbook.Bin2D) bool, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	if mask == nil {
+		mask = func(int, int, hbook.Bin2D) bool { return false }
+	}
+	f = NewBinned2D(h, mask).FuncND(f)
+	f.initPoisson()
+	return run(objective{f.fct, nil, len(f.X), f.unit}, f.Ps, f.Params, settings, m)
 }
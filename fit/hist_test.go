@@ -5,9 +5,143 @@
 package fit_test
 
 import (
+	"math"
 	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hbook"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat/distuv"
 )
 
 func TestH1D(t *testing.T) {
 	checkPlot(ExampleH1D_gaussian, t, "h1d-gauss-plot.png")
 }
+
+func TestH1DPoisson(t *testing.T) {
+	const (
+		n     = 200
+		mu    = 1.0
+		sigma = 2.0
+	)
+	dist := distuv.Normal{
+		Mu:    mu,
+		Sigma: sigma,
+		Src:   rand.New(rand.NewSource(1234)),
+	}
+	h := hbook.NewH1D(40, -10, +10)
+	for range n {
+		h.Fill(dist.Rand(), 1)
+	}
+
+	gauss := func(x float64, ps []float64) float64 {
+		v := (x - ps[1]) / ps[2]
+		return ps[0] * math.Exp(-0.5*v*v)
+	}
+
+	res, err := fit.H1DPoisson(h, fit.Func1D{
+		F:  gauss,
+		Ps: []float64{10, 0, 1},
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("could not fit histogram: %+v", err)
+	}
+	if err := res.Status.Err(); err != nil {
+		t.Fatalf("invalid fit status: %+v", err)
+	}
+
+	// the extended likelihood fit preserves the number of entries.
+	var sum float64
+	for _, bin := range h.Binning.Bins {
+		sum += gauss(bin.XMid(), res.X)
+	}
+	if got, want := sum, float64(h.Entries()); math.Abs(got-want) > 1e-2*want {
+		t.Fatalf("invalid fitted yield: got=%v, want=%v", got, want)
+	}
+
+	if got, want := res.X[1:], []float64{mu, sigma}; !floats.EqualApprox(got, want, 0.3) {
+		t.Fatalf("invalid fit:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestH2D(t *testing.T) {
+	const (
+		a = 1.0
+		b = 2.0
+		c = -0.5
+	)
+	h := hbook.NewH2D(10, 0, 10, 10, 0, 10)
+	for ix := range 10 {
+		for iy := range 10 {
+			x, y := float64(ix)+0.5, float64(iy)+0.5
+			v := a + b*x + c*y + 10
+			if ix == 3 && iy == 4 {
+				v = 1000 // dead channel, masked from the fit.
+			}
+			h.Fill(x, y, v)
+		}
+	}
+
+	res, err := fit.H2D(
+		h,
+		fit.FuncND{
+			F: func(x, ps []float64) float64 {
+				return ps[0] + ps[1]*x[0] + ps[2]*x[1]
+			},
+			Ps: []float64{0, 1, 1},
+		},
+		func(ix, iy int, bin hbook.Bin2D) bool {
+			return ix == 3 && iy == 4
+		},
+		nil, nil,
+	)
+	if err != nil {
+		t.Fatalf("could not fit histogram: %+v", err)
+	}
+	if err := res.Status.Err(); err != nil {
+		t.Fatalf("invalid fit status: %+v", err)
+	}
+	if got, want := res.X, []float64{a + 10, b, c}; !floats.EqualApprox(got, want, 1e-3) {
+		t.Fatalf("invalid fit:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestH2DPoisson(t *testing.T) {
+	const n = 500
+	var (
+		src = rand.New(rand.NewSource(1234))
+		dx  = distuv.Normal{Mu: 1, Sigma: 1, Src: src}
+		dy  = distuv.Normal{Mu: -1, Sigma: 2, Src: src}
+		h   = hbook.NewH2D(20, -5, 5, 20, -10, 10)
+	)
+	for range n {
+		h.Fill(dx.Rand(), dy.Rand(), 1)
+	}
+
+	// expected number of entries in a bin of area 0.5 x 1.
+	gauss := func(x, ps []float64) float64 {
+		vx := (x[0] - ps[1]) / ps[2]
+		vy := (x[1] - ps[3]) / ps[4]
+		return ps[0] * 0.5 / (2 * math.Pi * ps[2] * ps[4]) * math.Exp(-0.5*(vx*vx+vy*vy))
+	}
+
+	res, err := fit.H2DPoissonResult(h, fit.FuncND{
+		F:  gauss,
+		Ps: []float64{400, 0, 1.5, 0, 1.5},
+	}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("could not fit histogram: %+v", err)
+	}
+	if err := res.Status.Err(); err != nil {
+		t.Fatalf("invalid fit status: %+v", err)
+	}
+
+	want := []float64{n, 1, 1, -1, 2}
+	errs := res.Errs()
+	for i := range want {
+		if got := res.X[i]; math.Abs(got-want[i]) > 3*errs[i] {
+			t.Fatalf("invalid parameter %d: got=%v ± %v, want=%v", i, got, errs[i], want[i])
+		}
+	}
+}
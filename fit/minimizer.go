@@ -0,0 +1,602 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"math"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// Minimizer is a serial minimization algorithm of a cost function, which
+// only needs the values of that function.
+//
+// A Minimizer is turned into an optimization method, usable with all the
+// fit functions of this package as well as with optimize.Minimize, with
+// NewMethod. The results of fits are thus reported in the same way,
+// whichever gonum optimization method or Minimizer is used.
+type Minimizer interface {
+	// Init initializes the minimizer from the initial location x of the
+	// minimization, where the cost function is f.
+	Init(x []float64, f float64)
+
+	// Iterate performs one major iteration of the minimization, evaluating
+	// the cost function with fct.
+	// Iterate returns the best location found so far, the cost function at
+	// that location and whether the minimizer has converged.
+	// The returned slice may be retained by the minimizer.
+	Iterate(fct func(x []float64) float64) (x []float64, f float64, done bool)
+}
+
+// NewMethod returns an optimization method running the minimizer mz.
+func NewMethod(mz Minimizer) optimize.Method {
+	return &method{mz: mz}
+}
+
+// method adapts a Minimizer to the optimize.Method interface.
+type method struct {
+	mz     Minimizer
+	status optimize.Status
+}
+
+var (
+	_ optimize.Method   = (*method)(nil)
+	_ optimize.Statuser = (*method)(nil)
+)
+
+func (m *method) Init(dim, tasks int) int {
+	m.status = optimize.NotTerminated
+	return 1
+}
+
+func (*method) Uses(has optimize.Available) (optimize.Available, error) {
+	return optimize.Available{}, nil
+}
+
+func (m *method) Status() (optimize.Status, error) {
+	return m.status, nil
+}
+
+func (m *method) Run(operation chan<- optimize.Task, result <-chan optimize.Task, tasks []optimize.Task) {
+	var (
+		task = tasks[0]
+		stop = false
+	)
+
+	// send sends task with the operation op, and waits for the reply of the
+	// caller, unless the caller requested the optimization to stop.
+	send := func(op optimize.Operation) {
+		task.Op = op
+		operation <- task
+		reply := <-result
+		if reply.Op == optimize.PostIteration {
+			stop = true
+			return
+		}
+		task = reply
+	}
+
+	eval := func(x []float64) float64 {
+		if stop {
+			return math.NaN()
+		}
+		copy(task.X, x)
+		send(optimize.FuncEvaluation)
+		if stop {
+			return math.NaN()
+		}
+		return task.F
+	}
+
+	x0 := make([]float64, len(task.X))
+	copy(x0, task.X)
+	f0 := eval(x0)
+	if !stop {
+		m.mz.Init(x0, f0)
+	}
+
+	for !stop {
+		x, f, done := m.mz.Iterate(eval)
+		if stop {
+			break
+		}
+		copy(task.X, x)
+		task.F = f
+		send(optimize.MajorIteration)
+		if done && !stop {
+			m.status = optimize.MethodConverge
+			task.Op = optimize.MethodDone
+			operation <- task
+			break
+		}
+	}
+
+	// drain the results channel until the caller closes it.
+	for range result {
+	}
+	close(operation)
+}
+
+// Migrad is a variable-metric minimizer, in the spirit of the MIGRAD
+// algorithm of MINUIT.
+//
+// Migrad computes the gradient of the cost function by finite differences,
+// and updates an approximation of the inverse of its Hessian matrix with
+// the BFGS formula.
+// Migrad converges when the estimated distance to the minimum (EDM),
+// gᵀVg/2, is smaller than Tol.
+type Migrad struct {
+	// Tol is the convergence tolerance on the EDM.
+	// If Tol is 0, 1e-6 is used.
+	Tol float64
+
+	x    []float64
+	f    float64
+	g    []float64
+	v    *mat.SymDense
+	edm  float64
+	init bool // whether the gradient and metric are initialized.
+}
+
+// EDM returns the estimated distance to the minimum at the last iteration.
+func (mz *Migrad) EDM() float64 {
+	return mz.edm
+}
+
+func (mz *Migrad) Init(x []float64, f float64) {
+	mz.x = append(mz.x[:0], x...)
+	mz.f = f
+	mz.g = make([]float64, len(x))
+	mz.v = mat.NewSymDense(len(x), nil)
+	mz.edm = math.Inf(+1)
+	mz.init = false
+}
+
+func (mz *Migrad) Iterate(fct func(x []float64) float64) ([]float64, float64, bool) {
+	tol := mz.Tol
+	if tol == 0 {
+		tol = 1e-6
+	}
+
+	if !mz.init {
+		mz.reset(fct)
+		mz.init = true
+	}
+
+	var (
+		n   = len(mz.x)
+		g   = mat.NewVecDense(n, mz.g)
+		dir = mat.NewVecDense(n, nil)
+	)
+	dir.MulVec(mz.v, g)
+	dir.ScaleVec(-1, dir)
+
+	slope := mat.Dot(g, dir)
+	mz.edm = -0.5 * slope
+	if mz.edm < tol {
+		return mz.x, mz.f, true
+	}
+
+	x, f, ok := mz.search(fct, dir.RawVector().Data, slope)
+	if !ok {
+		// no progress along the current direction: restart from the
+		// diagonal estimate of the metric, and give up if that was
+		// already the case.
+		if mz.isDiag() {
+			return mz.x, mz.f, true
+		}
+		mz.reset(fct)
+		return mz.x, mz.f, false
+	}
+
+	g1 := make([]float64, n)
+	numGradient(g1, fct, x, f)
+
+	s := make([]float64, n)
+	y := make([]float64, n)
+	floats.SubTo(s, x, mz.x)
+	floats.SubTo(y, g1, mz.g)
+	mz.update(s, y)
+
+	mz.x = x
+	mz.f = f
+	mz.g = g1
+	return mz.x, mz.f, false
+}
+
+// reset computes the gradient at the current location, and initializes the
+// metric with the inverse of the diagonal of the Hessian matrix.
+func (mz *Migrad) reset(fct func(x []float64) float64) {
+	n := len(mz.x)
+	numGradient(mz.g, fct, mz.x, mz.f)
+	mz.v = mat.NewSymDense(n, nil)
+	x := make([]float64, n)
+	for i := range mz.x {
+		copy(x, mz.x)
+		h := numStep(mz.x[i])
+		x[i] = mz.x[i] + h
+		fp := fct(x)
+		x[i] = mz.x[i] - h
+		fm := fct(x)
+		d2 := (fp - 2*mz.f + fm) / (h * h)
+		if !(d2 > 0) || math.IsInf(d2, 0) {
+			d2 = 1
+		}
+		mz.v.SetSym(i, i, 1/d2)
+	}
+}
+
+func (mz *Migrad) isDiag() bool {
+	n := len(mz.x)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if mz.v.At(i, j) != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// search performs a backtracking line search from the current location along
+// dir, where the directional derivative of the cost function is slope.
+func (mz *Migrad) search(fct func(x []float64) float64, dir []float64, slope float64) ([]float64, float64, bool) {
+	const (
+		armijo = 1e-4
+		amin   = 1e-10
+	)
+	x := make([]float64, len(mz.x))
+	for a := 1.0; a > amin; {
+		floats.AddScaledTo(x, mz.x, a, dir)
+		f := fct(x)
+		if f <= mz.f+armijo*a*slope {
+			return x, f, true
+		}
+		// minimum of the parabola through f(0), f'(0) and f(a), kept
+		// within [a/10, a/2].
+		next := a / 2
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			p := -slope * a * a / (2 * (f - mz.f - slope*a))
+			next = math.Max(a/10, math.Min(p, a/2))
+		}
+		a = next
+	}
+	return nil, 0, false
+}
+
+// update updates the metric with the BFGS formula, from the step s and the
+// change of gradient y.
+func (mz *Migrad) update(s, y []float64) {
+	sy := floats.Dot(s, y)
+	if !(sy > 0) {
+		// the curvature condition does not hold: keep the current metric.
+		return
+	}
+	var (
+		n  = len(s)
+		vy = mat.NewVecDense(n, nil)
+	)
+	vy.MulVec(mz.v, mat.NewVecDense(n, y))
+	yvy := mat.Dot(mat.NewVecDense(n, y), vy)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := mz.v.At(i, j) +
+				(sy+yvy)*s[i]*s[j]/(sy*sy) -
+				(vy.AtVec(i)*s[j]+s[i]*vy.AtVec(j))/sy
+			mz.v.SetSym(i, j, v)
+		}
+	}
+}
+
+// numStep returns the step used to compute derivatives by finite differences
+// at x.
+func numStep(x float64) float64 {
+	return 1e-5 * math.Max(1, math.Abs(x))
+}
+
+// numGradient computes in grad the gradient of fct at x, by central finite
+// differences.
+func numGradient(grad []float64, fct func(x []float64) float64, x []float64, f float64) {
+	xx := make([]float64, len(x))
+	copy(xx, x)
+	for i := range x {
+		h := numStep(x[i])
+		xx[i] = x[i] + h
+		fp := fct(xx)
+		xx[i] = x[i] - h
+		fm := fct(xx)
+		xx[i] = x[i]
+		grad[i] = (fp - fm) / (2 * h)
+	}
+}
+
+// SimulatedAnnealing is a stochastic minimizer, suited to rough cost
+// functions with many local minima.
+//
+// Each iteration of SimulatedAnnealing performs a number of random moves at
+// a fixed temperature, accepting moves which increase the cost function by
+// Δ with a probability exp(-Δ/T), before cooling down the temperature.
+// The size of the moves is adapted to keep a reasonable acceptance rate.
+// SimulatedAnnealing converges when the temperature falls below TMin.
+type SimulatedAnnealing struct {
+	// Src is the source of pseudo-random numbers.
+	// If Src is nil, a source seeded with 1 is used, so minimizations
+	// are reproducible.
+	Src rand.Source
+
+	// T0 is the initial temperature. If T0 is 0, 1 is used.
+	T0 float64
+
+	// TMin is the final temperature. If TMin is 0, 1e-6*T0 is used.
+	TMin float64
+
+	// Cooling is the factor applied to the temperature after each
+	// iteration. If Cooling is 0, 0.9 is used.
+	Cooling float64
+
+	// Moves is the number of random moves per iteration.
+	// If Moves is 0, 20 times the number of parameters is used.
+	Moves int
+
+	// Step is the initial size of the moves, relative to the magnitude of
+	// the initial parameters. If Step is 0, 0.1 is used.
+	Step float64
+
+	rnd   *rand.Rand
+	t     float64
+	x     []float64
+	f     float64
+	best  []float64
+	fbest float64
+	steps []float64
+}
+
+func (mz *SimulatedAnnealing) Init(x []float64, f float64) {
+	src := mz.Src
+	if src == nil {
+		src = rand.NewSource(1)
+	}
+	mz.rnd = rand.New(src)
+
+	mz.t = mz.T0
+	if mz.t == 0 {
+		mz.t = 1
+	}
+	step := mz.Step
+	if step == 0 {
+		step = 0.1
+	}
+
+	mz.x = append(mz.x[:0], x...)
+	mz.f = f
+	mz.best = append(mz.best[:0], x...)
+	mz.fbest = f
+	mz.steps = make([]float64, len(x))
+	for i, v := range x {
+		mz.steps[i] = step * math.Max(1, math.Abs(v))
+	}
+}
+
+func (mz *SimulatedAnnealing) Iterate(fct func(x []float64) float64) ([]float64, float64, bool) {
+	var (
+		tmin    = mz.TMin
+		cooling = mz.Cooling
+		moves   = mz.Moves
+	)
+	if tmin == 0 {
+		tmin = 1e-6 * mz.T0
+		if mz.T0 == 0 {
+			tmin = 1e-6
+		}
+	}
+	if cooling == 0 {
+		cooling = 0.9
+	}
+	if moves == 0 {
+		moves = 20 * len(mz.x)
+	}
+
+	var (
+		x      = make([]float64, len(mz.x))
+		accept = make([]int, len(mz.x))
+	)
+	for k := 0; k < moves; k++ {
+		// move along a single, randomly chosen, direction so the
+		// step sizes can be adapted independently.
+		i := mz.rnd.Intn(len(x))
+		copy(x, mz.x)
+		x[i] += mz.steps[i] * mz.rnd.NormFloat64()
+		f := fct(x)
+		if math.IsNaN(f) {
+			continue
+		}
+		if df := f - mz.f; df <= 0 || mz.rnd.Float64() < math.Exp(-df/mz.t) {
+			copy(mz.x, x)
+			mz.f = f
+			accept[i]++
+		}
+		if f < mz.fbest {
+			copy(mz.best, x)
+			mz.fbest = f
+		}
+	}
+
+	expect := float64(moves) / float64(len(x))
+	for i, n := range accept {
+		switch rate := float64(n) / expect; {
+		case rate > 0.6:
+			mz.steps[i] *= 1.5
+		case rate < 0.2:
+			mz.steps[i] /= 1.5
+		}
+	}
+
+	mz.t *= cooling
+	return mz.best, mz.fbest, mz.t < tmin
+}
+
+// DifferentialEvolution is a stochastic, population-based, minimizer suited
+// to rough cost functions with many local minima.
+//
+// At each iteration, every member of the population is challenged by a trial
+// location, obtained by crossing it with the sum of another member and of
+// the weighted difference of two other members.
+// DifferentialEvolution converges when the spread of the cost function over
+// the population is smaller than Tol.
+type DifferentialEvolution struct {
+	// Src is the source of pseudo-random numbers.
+	// If Src is nil, a source seeded with 1 is used, so minimizations
+	// are reproducible.
+	Src rand.Source
+
+	// Pop is the size of the population.
+	// If Pop is 0, 10 times the number of parameters, and at least 8, is
+	// used.
+	Pop int
+
+	// F is the differential weight. If F is 0, 0.8 is used.
+	F float64
+
+	// CR is the crossover probability. If CR is 0, 0.9 is used.
+	CR float64
+
+	// Scale is the spread of the initial population around the initial
+	// location, relative to the magnitude of the initial parameters.
+	// If Scale is 0, 1 is used.
+	Scale float64
+
+	// Tol is the convergence tolerance on the spread of the cost function
+	// over the population. If Tol is 0, 1e-8 is used.
+	Tol float64
+
+	rnd  *rand.Rand
+	init bool // whether the population has been evaluated.
+	pop  [][]float64
+	fs   []float64
+	x0   []float64
+	f0   float64
+}
+
+func (mz *DifferentialEvolution) Init(x []float64, f float64) {
+	src := mz.Src
+	if src == nil {
+		src = rand.NewSource(1)
+	}
+	mz.rnd = rand.New(src)
+	mz.x0 = append(mz.x0[:0], x...)
+	mz.f0 = f
+	mz.init = false
+}
+
+// populate creates and evaluates the initial population, spread around the
+// initial location, which is kept as the first member.
+func (mz *DifferentialEvolution) populate(fct func(x []float64) float64) {
+	var (
+		n     = len(mz.x0)
+		np    = mz.Pop
+		scale = mz.Scale
+	)
+	if np == 0 {
+		np = max(10*n, 8)
+	}
+	if np < 4 {
+		panic("fit: differential evolution needs a population of at least 4 members")
+	}
+	if scale == 0 {
+		scale = 1
+	}
+
+	mz.pop = make([][]float64, np)
+	mz.fs = make([]float64, np)
+	mz.pop[0] = append([]float64(nil), mz.x0...)
+	mz.fs[0] = mz.f0
+	for k := 1; k < np; k++ {
+		x := make([]float64, n)
+		for i, v := range mz.x0 {
+			x[i] = v + scale*math.Max(1, math.Abs(v))*(2*mz.rnd.Float64()-1)
+		}
+		mz.pop[k] = x
+		mz.fs[k] = nanInf(fct(x))
+	}
+}
+
+func (mz *DifferentialEvolution) Iterate(fct func(x []float64) float64) ([]float64, float64, bool) {
+	var (
+		wf  = mz.F
+		cr  = mz.CR
+		tol = mz.Tol
+	)
+	if wf == 0 {
+		wf = 0.8
+	}
+	if cr == 0 {
+		cr = 0.9
+	}
+	if tol == 0 {
+		tol = 1e-8
+	}
+
+	if !mz.init {
+		mz.populate(fct)
+		mz.init = true
+	}
+
+	var (
+		n  = len(mz.x0)
+		np = len(mz.pop)
+		y  = make([]float64, n)
+	)
+	for k := range mz.pop {
+		a, b, c := mz.pick(k, np)
+		j := mz.rnd.Intn(n)
+		for i := range y {
+			y[i] = mz.pop[k][i]
+			if i == j || mz.rnd.Float64() < cr {
+				y[i] = mz.pop[a][i] + wf*(mz.pop[b][i]-mz.pop[c][i])
+			}
+		}
+		if f := nanInf(fct(y)); f <= mz.fs[k] {
+			copy(mz.pop[k], y)
+			mz.fs[k] = f
+		}
+	}
+
+	ibest := floats.MinIdx(mz.fs)
+	spread := floats.Max(mz.fs) - mz.fs[ibest]
+	return mz.pop[ibest], mz.fs[ibest], spread < tol
+}
+
+// pick returns the indices of three distinct members of the population,
+// distinct from k.
+func (mz *DifferentialEvolution) pick(k, np int) (a, b, c int) {
+	draw := func(excl ...int) int {
+	loop:
+		for {
+			i := mz.rnd.Intn(np)
+			for _, j := range excl {
+				if i == j {
+					continue loop
+				}
+			}
+			return i
+		}
+	}
+	a = draw(k)
+	b = draw(k, a)
+	c = draw(k, a, b)
+	return a, b, c
+}
+
+// nanInf returns +Inf if v is NaN, and v otherwise, so that invalid
+// locations always lose comparisons.
+func nanInf(v float64) float64 {
+	if math.IsNaN(v) {
+		return math.Inf(+1)
+	}
+	return v
+}
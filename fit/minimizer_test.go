@@ -0,0 +1,142 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/optimize/functions"
+)
+
+func TestMinimizerCurve1D(t *testing.T) {
+	xdata, ydata, err := readXY("testdata/gauss-data.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := fit.Func1D{
+		F: func(x float64, ps []float64) float64 {
+			v := x - ps[1]
+			return ps[0] * math.Exp(-v*v/ps[2])
+		},
+		X:  xdata,
+		Y:  ydata,
+		Ps: []float64{5, 25, 25},
+	}
+	want := []float64{3, 30, 20}
+
+	for _, tc := range []struct {
+		name string
+		mz   fit.Minimizer
+		tol  float64
+	}{
+		{"migrad", &fit.Migrad{}, 1e-3},
+		{"annealing", &fit.SimulatedAnnealing{}, 1e-2},
+		{"evolution", &fit.DifferentialEvolution{}, 1e-3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := fit.Curve1DResult(f, nil, fit.NewMethod(tc.mz))
+			if err != nil {
+				t.Fatalf("could not fit: %+v", err)
+			}
+			if err := res.Status.Err(); err != nil {
+				t.Fatalf("invalid status: %+v", err)
+			}
+			if got := res.X; !floats.EqualApprox(got, want, tc.tol) {
+				t.Fatalf("invalid parameters:\ngot= %v\nwant=%v", got, want)
+			}
+			if res.Cov() == nil {
+				t.Fatalf("missing covariance matrix")
+			}
+			if got := res.NDF; got != len(xdata)-len(want) {
+				t.Fatalf("invalid ndf: got=%d, want=%d", got, len(xdata)-len(want))
+			}
+		})
+	}
+}
+
+func TestMinimizerRosenbrock(t *testing.T) {
+	fct := functions.ExtendedRosenbrock{}.Func
+	want := []float64{1, 1}
+
+	for _, tc := range []struct {
+		name string
+		mz   fit.Minimizer
+		tol  float64
+	}{
+		{"migrad", &fit.Migrad{Tol: 1e-10}, 1e-4},
+		{"evolution", &fit.DifferentialEvolution{Tol: 1e-12}, 1e-4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := optimize.Minimize(
+				optimize.Problem{Func: fct},
+				[]float64{-1.2, 1},
+				&optimize.Settings{Converger: &optimize.FunctionConverge{Iterations: 1000}},
+				fit.NewMethod(tc.mz),
+			)
+			if err != nil {
+				t.Fatalf("could not minimize: %+v", err)
+			}
+			if got := res.X; !floats.EqualApprox(got, want, tc.tol) {
+				t.Fatalf("invalid minimum:\ngot= %v\nwant=%v", got, want)
+			}
+			if res.Stats.FuncEvaluations == 0 {
+				t.Fatalf("invalid number of function evaluations")
+			}
+		})
+	}
+}
+
+func TestMinimizerRough(t *testing.T) {
+	// Rastrigin function: a parabola modulated by many local minima.
+	rastrigin := func(x []float64) float64 {
+		v := 10 * float64(len(x))
+		for _, xi := range x {
+			v += xi*xi - 10*math.Cos(2*math.Pi*xi)
+		}
+		return v
+	}
+	var (
+		x0   = []float64{3.1, -2.9}
+		want = []float64{0, 0}
+	)
+
+	for _, tc := range []struct {
+		name string
+		mz   fit.Minimizer
+	}{
+		{"annealing", &fit.SimulatedAnnealing{T0: 10}},
+		{"evolution", &fit.DifferentialEvolution{Scale: 5}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := optimize.Minimize(
+				optimize.Problem{Func: rastrigin},
+				x0, nil, fit.NewMethod(tc.mz),
+			)
+			if err != nil {
+				t.Fatalf("could not minimize: %+v", err)
+			}
+			if got := res.X; !floats.EqualApprox(got, want, 1e-2) {
+				t.Fatalf("invalid minimum:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+
+	// a local minimizer is trapped in the nearest local minimum.
+	res, err := optimize.Minimize(
+		optimize.Problem{Func: rastrigin},
+		x0, nil, fit.NewMethod(&fit.Migrad{}),
+	)
+	if err != nil {
+		t.Fatalf("could not minimize: %+v", err)
+	}
+	if got := res.X; floats.EqualApprox(got, want, 1e-2) {
+		t.Fatalf("migrad found the global minimum of a rough function: %v", got)
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// MinosError holds the asymmetric errors on a parameter, as computed by Minos.
+type MinosError struct {
+	Lower float64 // lower error, negative or null
+	Upper float64 // upper error, positive or null
+}
+
+// Minos returns the asymmetric errors on the parameters ps that minimize the
+// cost function fct, from scans of the profile likelihood.
+//
+// For each free parameter, the errors are the distances from the minimum to
+// the values where the profile of fct, minimized with respect to all the
+// other free parameters, increases by nsigma²/2.
+// The parameters are bounded, fixed and constrained as configured by params,
+// as for the fit. Fixed parameters have null errors.
+// When the profile does not cross the threshold within the bounds of a
+// parameter, the corresponding error is infinite.
+//
+// The profiles are minimized with settings and method m, as for the fit.
+func Minos(fct Cost, ps []float64, params []Param, nsigma float64, settings *optimize.Settings, m optimize.Method) ([]MinosError, error) {
+	p, err := newProfiler(fct, ps, params, nsigma, settings, m)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]MinosError, len(ps))
+	for i := range ps {
+		if p.params[i].Fixed {
+			continue
+		}
+		for _, dir := range []float64{-1, +1} {
+			var (
+				s    = dir * p.sigma[i]
+				tmax = p.tmax([]int{i}, []float64{s})
+				v    = make([]float64, 1)
+			)
+			t, ok, err := p.crossing(func(t float64) (float64, error) {
+				v[0] = ps[i] + t*s
+				return p.profile([]int{i}, v)
+			}, tmax)
+			if err != nil {
+				return nil, fmt.Errorf("fit: could not scan profile of parameter %d: %w", i, err)
+			}
+			d := math.Inf(+1)
+			if ok {
+				d = t * p.sigma[i]
+			}
+			switch {
+			case dir < 0:
+				errs[i].Lower = -d
+			default:
+				errs[i].Upper = +d
+			}
+		}
+	}
+	return errs, nil
+}
+
+// Contour returns n points of the contour of the free parameters i and j
+// where the profile of the cost function fct, minimized with respect to all
+// the other free parameters, increases by nsigma²/2 from its minimum at ps.
+//
+// The points are located along n rays starting from the minimum, in
+// counter-clockwise order. The contour is clipped to the bounds of the
+// parameters.
+// The parameters are bounded, fixed and constrained as configured by params,
+// as for the fit.
+//
+// The profiles are minimized with settings and method m, as for the fit.
+func Contour(fct Cost, ps []float64, params []Param, i, j int, nsigma float64, n int, settings *optimize.Settings, m optimize.Method) ([][2]float64, error) {
+	if i == j || i < 0 || j < 0 || i >= len(ps) || j >= len(ps) {
+		return nil, fmt.Errorf("fit: invalid contour parameters (i=%d, j=%d)", i, j)
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("fit: invalid number of contour points (n=%d)", n)
+	}
+
+	p, err := newProfiler(fct, ps, params, nsigma, settings, m)
+	if err != nil {
+		return nil, err
+	}
+	if p.params[i].Fixed || p.params[j].Fixed {
+		return nil, fmt.Errorf("fit: contour of fixed parameter (i=%d, j=%d)", i, j)
+	}
+
+	var (
+		pts = make([][2]float64, n)
+		idx = []int{i, j}
+		v   = make([]float64, 2)
+	)
+	for k := range pts {
+		var (
+			theta = 2 * math.Pi * float64(k) / float64(n)
+			s     = []float64{
+				p.sigma[i] * math.Cos(theta),
+				p.sigma[j] * math.Sin(theta),
+			}
+			tmax = p.tmax(idx, s)
+		)
+		t, ok, err := p.crossing(func(t float64) (float64, error) {
+			v[0] = ps[i] + t*s[0]
+			v[1] = ps[j] + t*s[1]
+			return p.profile(idx, v)
+		}, tmax)
+		if err != nil {
+			return nil, fmt.Errorf("fit: could not scan profile of parameters (%d,%d): %w", i, j, err)
+		}
+		if !ok {
+			t = tmax
+		}
+		pts[k] = [2]float64{ps[i] + t*s[0], ps[j] + t*s[1]}
+	}
+	return pts, nil
+}
+
+// profiler computes the profile of a cost function around its minimum.
+type profiler struct {
+	fct    Cost
+	ps     []float64 // parameters at the minimum
+	params []Param
+	fmin   float64 // value of the cost function at the minimum
+	up     float64 // increase of the cost function defining the errors
+	sigma  []float64
+
+	settings *optimize.Settings
+	m        optimize.Method
+}
+
+func newProfiler(fct Cost, ps []float64, params []Param, nsigma float64, settings *optimize.Settings, m optimize.Method) (*profiler, error) {
+	if !(nsigma > 0) {
+		return nil, fmt.Errorf("fit: invalid number of standard deviations (nsigma=%v)", nsigma)
+	}
+	if params == nil {
+		params = make([]Param, len(ps))
+	}
+	if _, err := newTransform(ps, params); err != nil {
+		return nil, err
+	}
+
+	p := &profiler{
+		fct:      fct,
+		ps:       ps,
+		params:   params,
+		fmin:     fct(ps) + penalty(params, ps),
+		up:       0.5 * nsigma * nsigma,
+		sigma:    make([]float64, len(ps)),
+		settings: settings,
+		m:        m,
+	}
+
+	// estimate the errors from the hessian, to set the scale of the scans.
+	cov, err := covariance(fct, ps, params)
+	for i := range p.sigma {
+		v := 0.0
+		if err == nil {
+			v = nsigma * math.Sqrt(cov.At(i, i))
+		}
+		if !(v > 0) || math.IsInf(v, 0) {
+			v = 0.1 * nsigma * math.Max(1, math.Abs(ps[i]))
+		}
+		p.sigma[i] = v
+	}
+
+	return p, nil
+}
+
+// profile returns the minimum of the cost function, with the parameters idx
+// fixed to the values vs, minus the threshold defining the errors.
+func (p *profiler) profile(idx []int, vs []float64) (float64, error) {
+	var (
+		ps     = make([]float64, len(p.ps))
+		params = make([]Param, len(p.params))
+		nfree  = 0
+	)
+	copy(ps, p.ps)
+	copy(params, p.params)
+	for k, i := range idx {
+		ps[i] = vs[k]
+		params[i].Fixed = true
+	}
+	for _, par := range params {
+		if !par.Fixed {
+			nfree++
+		}
+	}
+
+	if nfree == 0 {
+		return p.fct(ps) + penalty(params, ps) - p.fmin - p.up, nil
+	}
+
+	res, err := minimize(p.fct, nil, ps, params, p.settings, p.m)
+	if res == nil {
+		return 0, err
+	}
+	return res.F - p.fmin - p.up, nil
+}
+
+// tmax returns the maximum distance along the direction s, starting from the
+// minimum, before the parameters idx reach their bounds.
+func (p *profiler) tmax(idx []int, s []float64) float64 {
+	tmax := math.Inf(+1)
+	for k, i := range idx {
+		var (
+			par = p.params[i]
+			t   = math.Inf(+1)
+		)
+		if !par.bounded() {
+			continue
+		}
+		switch {
+		case s[k] > 0:
+			t = (par.Max - p.ps[i]) / s[k]
+		case s[k] < 0:
+			t = (par.Min - p.ps[i]) / s[k]
+		}
+		tmax = math.Min(tmax, t)
+	}
+	return tmax
+}
+
+// crossing returns the distance t, in (0, tmax], where g crosses zero,
+// with g(0) < 0.
+// crossing returns false when g does not cross zero in (0, tmax].
+func (p *profiler) crossing(g func(t float64) (float64, error), tmax float64) (float64, bool, error) {
+	const (
+		nbracket = 32
+		nbisect  = 64
+		tol      = 1e-5
+	)
+
+	lo, hi := 0.0, 1.0
+	for i := 0; ; i++ {
+		if i >= nbracket {
+			return 0, false, nil
+		}
+		hi = math.Min(hi, tmax)
+		v, err := g(hi)
+		if err != nil {
+			return 0, false, err
+		}
+		if v >= 0 {
+			break
+		}
+		if hi >= tmax {
+			return 0, false, nil
+		}
+		lo = hi
+		hi *= 2
+	}
+
+	for i := 0; i < nbisect && hi-lo > tol*hi; i++ {
+		mid := 0.5 * (lo + hi)
+		v, err := g(mid)
+		if err != nil {
+			return 0, false, err
+		}
+		switch {
+		case v < 0:
+			lo = mid
+		default:
+			hi = mid
+		}
+	}
+	return 0.5 * (lo + hi), true, nil
+}
+
+// covariance returns the covariance matrix of the parameters ps minimizing
+// the cost function fct, from the inverse of the hessian of fct with respect
+// to the free parameters.
+// The rows and columns of fixed parameters are null.
+func covariance(fct Cost, ps []float64, params []Param) (*mat.SymDense, error) {
+	if params == nil {
+		params = make([]Param, len(ps))
+	}
+	free := make([]int, 0, len(ps))
+	for i, p := range params {
+		if !p.Fixed {
+			free = append(free, i)
+		}
+	}
+	if len(free) == 0 {
+		return nil, fmt.Errorf("fit: no free parameter")
+	}
+
+	// the hessian is computed with respect to the free parameters, scaled
+	// by their magnitude, so the finite differences steps are relative.
+	var (
+		n     = len(free)
+		buf   = make([]float64, len(ps))
+		x     = make([]float64, n)
+		scale = make([]float64, n)
+	)
+	copy(buf, ps)
+	for k, i := range free {
+		scale[k] = math.Max(1, math.Abs(ps[i]))
+		x[k] = ps[i] / scale[k]
+	}
+	cost := func(x []float64) float64 {
+		for k, i := range free {
+			buf[i] = x[k] * scale[k]
+		}
+		return fct(buf) + penalty(params, buf)
+	}
+
+	hess := mat.NewSymDense(n, nil)
+	fd.Hessian(hess, cost, x, &fd.Settings{Formula: fd.Central})
+	for i := range n {
+		for j := i; j < n; j++ {
+			hess.SetSym(i, j, hess.At(i, j)/(scale[i]*scale[j]))
+		}
+	}
+
+	var chol mat.Cholesky
+	if ok := chol.Factorize(hess); !ok {
+		return nil, fmt.Errorf("fit: hessian is not positive definite")
+	}
+	var inv mat.SymDense
+	if err := chol.InverseTo(&inv); err != nil {
+		return nil, fmt.Errorf("fit: could not invert hessian: %w", err)
+	}
+
+	cov := mat.NewSymDense(len(ps), nil)
+	for ki, i := range free {
+		for kj, j := range free[ki:] {
+			cov.SetSym(i, j, inv.At(ki, ki+kj))
+		}
+	}
+	return cov, nil
+}
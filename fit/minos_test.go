@@ -0,0 +1,129 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestMinosLinear(t *testing.T) {
+	var (
+		xs = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		ys = []float64{2.1, 2.4, 3.1, 3.4, 4.1, 4.4, 5.1, 5.4, 6.1, 6.4}
+		f  = fit.Func1D{
+			F:  func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x },
+			X:  xs,
+			Y:  ys,
+			Ps: []float64{1, 1},
+		}
+	)
+
+	res, err := fit.Curve1D(f, nil, &optimize.NelderMead{})
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+
+	errs, err := fit.Minos(f.Cost(), res.X, nil, 1, nil, nil)
+	if err != nil {
+		t.Fatalf("could not compute minos errors: %+v", err)
+	}
+
+	// errors of a linear model are symmetric, and equal to the ones
+	// from the inverse of (XᵀX).
+	want := []float64{math.Sqrt(285.0 / 825.0), math.Sqrt(10.0 / 825.0)}
+	for i, e := range errs {
+		if math.Abs(-e.Lower-want[i]) > 1e-3 || math.Abs(e.Upper-want[i]) > 1e-3 {
+			t.Fatalf("invalid errors for parameter %d: got=%+v, want=%v", i, e, want[i])
+		}
+	}
+
+	const n = 16
+	pts, err := fit.Contour(f.Cost(), res.X, nil, 0, 1, 1, n, nil, nil)
+	if err != nil {
+		t.Fatalf("could not compute contour: %+v", err)
+	}
+	if got, want := len(pts), n; got != want {
+		t.Fatalf("invalid number of contour points: got=%d, want=%d", got, want)
+	}
+	var (
+		cost = f.Cost()
+		fmin = cost(res.X)
+	)
+	for i, pt := range pts {
+		if got, want := cost(pt[:])-fmin, 0.5; math.Abs(got-want) > 1e-3 {
+			t.Fatalf("invalid contour point %d (%v): got=%v, want=%v", i, pt, got, want)
+		}
+	}
+}
+
+func TestMinosPoisson(t *testing.T) {
+	const n = 3
+	h := hbook.NewH1D(1, 0, 1)
+	for range n {
+		h.Fill(0.5, 1)
+	}
+
+	f := fit.NewBinned1D(h, nil).Func1D(fit.Func1D{
+		F:      func(x float64, ps []float64) float64 { return ps[0] },
+		Ps:     []float64{1},
+		Params: []fit.Param{{Min: 0, Max: math.Inf(+1)}},
+	})
+	res, err := fit.H1DPoisson(h, f, nil, nil)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	if got, want := res.X[0], float64(n); math.Abs(got-want) > 1e-3 {
+		t.Fatalf("invalid fit: got=%v, want=%v", got, want)
+	}
+
+	errs, err := fit.Minos(f.PoissonCost(), res.X, f.Params, 1, nil, nil)
+	if err != nil {
+		t.Fatalf("could not compute minos errors: %+v", err)
+	}
+
+	// likelihood-ratio interval for a Poisson mean, with n=3.
+	nll := func(nu float64) float64 { return nu - n + n*math.Log(n/nu) }
+	e := errs[0]
+	if !(e.Upper > -e.Lower) {
+		t.Fatalf("errors are not asymmetric: %+v", e)
+	}
+	for _, v := range []float64{n + e.Lower, n + e.Upper} {
+		if got, want := nll(v), 0.5; math.Abs(got-want) > 1e-3 {
+			t.Fatalf("invalid interval bound %v: got=%v, want=%v", v, got, want)
+		}
+	}
+}
+
+func TestMinosErrors(t *testing.T) {
+	f := fit.Func1D{
+		F:  func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x },
+		X:  []float64{0, 1, 2},
+		Y:  []float64{1, 2, 3},
+		Ps: []float64{1, 1},
+	}
+
+	if _, err := fit.Minos(f.Cost(), f.Ps, nil, 0, nil, nil); err == nil {
+		t.Fatalf("expected an error for invalid nsigma")
+	}
+	if _, err := fit.Contour(f.Cost(), f.Ps, nil, 0, 0, 1, 10, nil, nil); err == nil {
+		t.Fatalf("expected an error for invalid parameters")
+	}
+	if _, err := fit.Contour(f.Cost(), f.Ps, []fit.Param{{Fixed: true}, {}}, 0, 1, 1, 10, nil, nil); err == nil {
+		t.Fatalf("expected an error for fixed parameter")
+	}
+
+	errs, err := fit.Minos(f.Cost(), f.Ps, []fit.Param{{Fixed: true}, {}}, 1, nil, nil)
+	if err != nil {
+		t.Fatalf("could not compute minos errors: %+v", err)
+	}
+	if got, want := errs[0], (fit.MinosError{}); got != want {
+		t.Fatalf("invalid errors for fixed parameter: got=%+v, want=%+v", got, want)
+	}
+}
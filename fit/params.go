@@ -0,0 +1,271 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// Param configures a parameter of a fit.
+//
+// The zero value describes a free and unbounded parameter.
+type Param struct {
+	// Fixed indicates whether the parameter is fixed to its initial value.
+	Fixed bool
+
+	// Min and Max are the bounds of the parameter.
+	// The parameter is bounded only when Min < Max.
+	// One-sided bounds are described with an infinite Min or Max.
+	Min, Max float64
+
+	// Constraint, when not nil, adds a Gaussian penalty term on the
+	// parameter to the cost function.
+	Constraint *Constraint
+}
+
+// Constraint describes a Gaussian constraint on a parameter, as used for
+// nuisance parameters measured by an auxiliary measurement.
+//
+// The penalty term added to the cost function for a parameter p is
+// ((p-Mean)/Sigma)²/2, i.e. the negative log-likelihood of the
+// auxiliary measurement.
+type Constraint struct {
+	Mean  float64
+	Sigma float64
+}
+
+func (p Param) bounded() bool {
+	return p.Min < p.Max
+}
+
+// external returns the external value of the parameter from its internal
+// value v, as seen by the minimizer.
+//
+// The transformations are the ones of MINUIT: a sine for doubly bounded
+// parameters and a square root for one-sided bounds.
+func (p Param) external(v float64) float64 {
+	if !p.bounded() {
+		return v
+	}
+	lo, hi := !math.IsInf(p.Min, -1), !math.IsInf(p.Max, +1)
+	switch {
+	case lo && hi:
+		return p.Min + 0.5*(p.Max-p.Min)*(math.Sin(v)+1)
+	case lo:
+		return p.Min - 1 + math.Sqrt(v*v+1)
+	case hi:
+		return p.Max + 1 - math.Sqrt(v*v+1)
+	}
+	return v
+}
+
+// internal returns the internal value of the parameter from its external
+// value v.
+func (p Param) internal(v float64) float64 {
+	if !p.bounded() {
+		return v
+	}
+	lo, hi := !math.IsInf(p.Min, -1), !math.IsInf(p.Max, +1)
+	switch {
+	case lo && hi:
+		x := 2*(v-p.Min)/(p.Max-p.Min) - 1
+		return math.Asin(math.Max(-1, math.Min(+1, x)))
+	case lo:
+		x := v - p.Min + 1
+		return math.Sqrt(x*x - 1)
+	case hi:
+		x := p.Max - v + 1
+		return math.Sqrt(x*x - 1)
+	}
+	return v
+}
+
+// derivative returns the derivative of the external value of the parameter
+// with respect to its internal value v.
+func (p Param) derivative(v float64) float64 {
+	if !p.bounded() {
+		return 1
+	}
+	lo, hi := !math.IsInf(p.Min, -1), !math.IsInf(p.Max, +1)
+	switch {
+	case lo && hi:
+		return 0.5 * (p.Max - p.Min) * math.Cos(v)
+	case lo:
+		return v / math.Sqrt(v*v+1)
+	case hi:
+		return -v / math.Sqrt(v*v+1)
+	}
+	return 1
+}
+
+// transform maps the parameters of a fit to the free and unbounded internal
+// parameters seen by the minimizer.
+type transform struct {
+	ps     []float64 // external parameters, holding the values of the fixed ones
+	params []Param
+	free   []int // indices of the free parameters
+}
+
+func newTransform(ps []float64, params []Param) (*transform, error) {
+	if params == nil {
+		return nil, nil
+	}
+	if len(params) != len(ps) {
+		return nil, fmt.Errorf(
+			"fit: mismatch number of parameters (params=%d, ps=%d)",
+			len(params), len(ps),
+		)
+	}
+
+	tr := &transform{
+		ps:     make([]float64, len(ps)),
+		params: params,
+		free:   make([]int, 0, len(ps)),
+	}
+	copy(tr.ps, ps)
+
+	for i, p := range params {
+		if p.Min > p.Max {
+			return nil, fmt.Errorf(
+				"fit: invalid bounds for parameter %d (min=%v, max=%v)",
+				i, p.Min, p.Max,
+			)
+		}
+		if p.bounded() && (ps[i] < p.Min || p.Max < ps[i]) {
+			return nil, fmt.Errorf(
+				"fit: initial value of parameter %d out of bounds (v=%v, min=%v, max=%v)",
+				i, ps[i], p.Min, p.Max,
+			)
+		}
+		if p.Constraint != nil && !(p.Constraint.Sigma > 0) {
+			return nil, fmt.Errorf(
+				"fit: invalid constraint width for parameter %d (sigma=%v)",
+				i, p.Constraint.Sigma,
+			)
+		}
+		if !p.Fixed {
+			tr.free = append(tr.free, i)
+		}
+	}
+
+	if len(tr.free) == 0 {
+		return nil, fmt.Errorf("fit: no free parameter")
+	}
+
+	return tr, nil
+}
+
+// internal returns the internal parameters corresponding to the external
+// parameters ps.
+func (tr *transform) internal(ps []float64) []float64 {
+	o := make([]float64, len(tr.free))
+	for k, i := range tr.free {
+		o[k] = tr.params[i].internal(ps[i])
+	}
+	return o
+}
+
+// external fills dst with the external parameters corresponding to the
+// internal parameters in.
+func (tr *transform) external(dst, in []float64) {
+	copy(dst, tr.ps)
+	for k, i := range tr.free {
+		dst[i] = tr.params[i].external(in[k])
+	}
+}
+
+// penalty returns the sum of the Gaussian penalty terms of the constrained
+// parameters.
+func (tr *transform) penalty(ps []float64) float64 {
+	return penalty(tr.params, ps)
+}
+
+// penalty returns the sum of the Gaussian penalty terms of the parameters
+// ps, constrained as configured by params.
+func penalty(params []Param, ps []float64) float64 {
+	var sum float64
+	for i, p := range params {
+		if p.Constraint == nil {
+			continue
+		}
+		v := (ps[i] - p.Constraint.Mean) / p.Constraint.Sigma
+		sum += 0.5 * v * v
+	}
+	return sum
+}
+
+// cost returns the cost function of the internal parameters, from the cost
+// function fct of the external parameters.
+func (tr *transform) cost(fct Cost) func(in []float64) float64 {
+	ps := make([]float64, len(tr.ps))
+	return func(in []float64) float64 {
+		tr.external(ps, in)
+		return fct(ps) + tr.penalty(ps)
+	}
+}
+
+// grad returns the gradient of the cost function of the internal parameters,
+// from the gradient grad of the cost function of the external parameters.
+// grad returns nil when grad is nil.
+func (tr *transform) grad(grad func(grad, ps []float64)) func(grad, in []float64) {
+	if grad == nil {
+		return nil
+	}
+	var (
+		ps = make([]float64, len(tr.ps))
+		g  = make([]float64, len(tr.ps))
+	)
+	return func(dst, in []float64) {
+		tr.external(ps, in)
+		tr.extGrad(g, ps, grad)
+		for k, i := range tr.free {
+			dst[k] = g[i] * tr.params[i].derivative(in[k])
+		}
+	}
+}
+
+// extGrad stores in dst the gradient of the cost function, including the
+// penalty terms, with respect to the external parameters ps.
+func (tr *transform) extGrad(dst, ps []float64, grad func(grad, ps []float64)) {
+	grad(dst, ps)
+	for i, p := range tr.params {
+		if p.Constraint == nil {
+			continue
+		}
+		dst[i] += (ps[i] - p.Constraint.Mean) / (p.Constraint.Sigma * p.Constraint.Sigma)
+	}
+}
+
+// result converts the location of the minimum of res, from internal to
+// external parameters.
+// The gradient and the hessian, when present, are recomputed with respect to
+// the external parameters.
+func (tr *transform) result(res *optimize.Result, fct Cost, grad func(grad, ps []float64)) {
+	ps := make([]float64, len(tr.ps))
+	tr.external(ps, res.X)
+	res.X = ps
+
+	cost := func(ps []float64) float64 {
+		return fct(ps) + tr.penalty(ps)
+	}
+	if res.Gradient != nil {
+		res.Gradient = make([]float64, len(ps))
+		switch grad {
+		case nil:
+			fd.Gradient(res.Gradient, cost, ps, nil)
+		default:
+			tr.extGrad(res.Gradient, ps, grad)
+		}
+	}
+	if res.Hessian != nil {
+		res.Hessian = mat.NewSymDense(len(ps), nil)
+		fd.Hessian(res.Hessian, cost, ps, nil)
+	}
+}
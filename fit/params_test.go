@@ -0,0 +1,137 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestParams(t *testing.T) {
+	const (
+		a = 2.0
+		b = 0.5
+	)
+	var (
+		xs = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		ys = make([]float64, len(xs))
+	)
+	for i, x := range xs {
+		ys[i] = a + b*x
+	}
+
+	// ps[2] is not constrained by the data.
+	line := func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x }
+
+	for _, tc := range []struct {
+		name   string
+		ps     []float64
+		params []fit.Param
+		want   []float64
+		tol    float64
+	}{
+		{
+			name: "free",
+			ps:   []float64{1, 1},
+			want: []float64{a, b},
+			tol:  1e-3,
+		},
+		{
+			name:   "fixed",
+			ps:     []float64{a, 1, 3},
+			params: []fit.Param{{Fixed: true}, {}, {Fixed: true}},
+			want:   []float64{a, b, 3},
+			tol:    1e-3,
+		},
+		{
+			name:   "bounded",
+			ps:     []float64{1, 0.1, 3},
+			params: []fit.Param{{}, {Min: 0, Max: 0.3}, {Fixed: true}},
+			want:   []float64{2.9, 0.3, 3},
+			tol:    1e-2,
+		},
+		{
+			name:   "lower-bound",
+			ps:     []float64{4, 1, 3},
+			params: []fit.Param{{Min: 3, Max: math.Inf(+1)}, {}, {Fixed: true}},
+			want:   []float64{3, 0.3421052631578947, 3},
+			tol:    1e-2,
+		},
+		{
+			name:   "upper-bound",
+			ps:     []float64{1, 0.1, 3},
+			params: []fit.Param{{}, {Min: math.Inf(-1), Max: 0.3}, {Fixed: true}},
+			want:   []float64{2.9, 0.3, 3},
+			tol:    1e-2,
+		},
+		{
+			name: "constraint",
+			ps:   []float64{1, 1, 3},
+			params: []fit.Param{
+				{}, {},
+				{Constraint: &fit.Constraint{Mean: 7, Sigma: 1}},
+			},
+			want: []float64{a, b, 7},
+			tol:  1e-3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := fit.Curve1D(
+				fit.Func1D{
+					F:      line,
+					X:      xs,
+					Y:      ys,
+					Ps:     tc.ps,
+					Params: tc.params,
+				},
+				nil, &optimize.NelderMead{},
+			)
+			if err != nil {
+				t.Fatalf("could not fit: %+v", err)
+			}
+			if err := res.Status.Err(); err != nil {
+				t.Fatalf("invalid fit status: %+v", err)
+			}
+			if got, want := res.X, tc.want; !floats.EqualApprox(got, want, tc.tol) {
+				t.Fatalf("invalid fit:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestParamsErrors(t *testing.T) {
+	line := func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x }
+
+	for _, tc := range []struct {
+		name   string
+		params []fit.Param
+	}{
+		{"mismatch", []fit.Param{{}}},
+		{"invalid-bounds", []fit.Param{{Min: 2, Max: 1}, {}}},
+		{"out-of-bounds", []fit.Param{{Min: 2, Max: 3}, {}}},
+		{"invalid-constraint", []fit.Param{{Constraint: &fit.Constraint{}}, {}}},
+		{"all-fixed", []fit.Param{{Fixed: true}, {Fixed: true}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := fit.Curve1D(
+				fit.Func1D{
+					F:      line,
+					X:      []float64{0, 1},
+					Y:      []float64{1, 2},
+					Ps:     []float64{1, 1},
+					Params: tc.params,
+				},
+				nil, nil,
+			)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Result is the result of a fit.
+type Result struct {
+	*optimize.Result
+
+	// NLL is the value of the cost function at its minimum, i.e. the
+	// negative log-likelihood of the fit, including the penalty terms of
+	// the constrained parameters.
+	// For least-squares fits, NLL is χ²/2.
+	// For binned Poisson likelihood fits, NLL is relative to the
+	// likelihood of the saturated model.
+	NLL float64
+
+	// Chi2 is the χ² of the fit, i.e. twice NLL.
+	// For least-squares fits without measurement errors, the χ² is
+	// computed assuming unit errors, and is the residual sum of squares.
+	// For binned Poisson likelihood fits, Chi2 is the likelihood-ratio χ²
+	// of Baker and Cousins.
+	// Chi2 is NaN for unbinned fits.
+	Chi2 float64
+
+	// NDF is the number of degrees of freedom of the fit: the number of
+	// data points and of constrained parameters, minus the number of free
+	// parameters.
+	// NDF is zero for unbinned fits.
+	NDF int
+
+	// PValue is the probability to get a χ² larger than Chi2, for NDF
+	// degrees of freedom.
+	// PValue is NaN when NDF is not positive.
+	PValue float64
+
+	params []Param
+	fct    Cost
+	ps     []float64 // parameters at the minimum, for the covariance matrix.
+	unit   bool      // whether the χ² is computed with unit measurement errors.
+
+	once sync.Once
+	cov  *mat.SymDense
+	corr *mat.SymDense
+}
+
+func newResult(res *optimize.Result, obj objective, params []Param) *Result {
+	if res == nil {
+		return nil
+	}
+	o := &Result{
+		Result: res,
+		NLL:    res.F,
+		Chi2:   math.NaN(),
+		PValue: math.NaN(),
+		params: params,
+		fct:    obj.fct,
+		ps:     slices.Clone(res.X),
+		unit:   obj.unit,
+	}
+
+	if obj.ndata >= 0 {
+		ndf := obj.ndata
+		for i := range res.X {
+			if i >= len(params) {
+				ndf--
+				continue
+			}
+			if !params[i].Fixed {
+				ndf--
+			}
+			if params[i].Constraint != nil {
+				ndf++
+			}
+		}
+		o.Chi2 = 2 * res.F
+		o.NDF = ndf
+		if ndf > 0 {
+			o.PValue = distuv.ChiSquared{K: float64(ndf)}.Survival(o.Chi2)
+		}
+	}
+
+	return o
+}
+
+// optResult returns the optimization result of the fit, or nil.
+func (res *Result) optResult() *optimize.Result {
+	if res == nil {
+		return nil
+	}
+	return res.Result
+}
+
+// Cov returns the covariance matrix of the parameters, from the inverse of
+// the hessian of the cost function at its minimum.
+// The rows and columns of fixed parameters are null.
+// Cov returns nil when the hessian is not positive definite.
+//
+// For least-squares fits without measurement errors, the errors are
+// estimated from the residuals of the fit: the covariance matrix is scaled
+// by χ²/ndf, as MINUIT does. Cov then returns nil when NDF is not positive.
+//
+// The covariance matrix is computed, with finite differences, on the first
+// call to Cov or to any method using it.
+func (res *Result) Cov() *mat.SymDense {
+	res.once.Do(res.hesse)
+	return res.cov
+}
+
+// Corr returns the correlation matrix of the parameters.
+// Corr returns nil when Cov does.
+func (res *Result) Corr() *mat.SymDense {
+	res.once.Do(res.hesse)
+	return res.corr
+}
+
+// hesse computes the covariance and correlation matrices of the parameters.
+func (res *Result) hesse() {
+	cov, err := covariance(res.fct, res.ps, res.params)
+	if err != nil {
+		return
+	}
+	if res.unit {
+		if res.NDF <= 0 {
+			return
+		}
+		cov.ScaleSym(res.Chi2NDF(), cov)
+	}
+	res.cov = cov
+	res.corr = correlation(cov)
+}
+
+// Chi2NDF returns the χ² of the fit, divided by its number of degrees of
+// freedom.
+func (res *Result) Chi2NDF() float64 {
+	return res.Chi2 / float64(res.NDF)
+}
+
+// Errs returns the parabolic errors on the parameters, from the diagonal of
+// the covariance matrix.
+// Errs returns nil when the covariance matrix is not available.
+func (res *Result) Errs() []float64 {
+	cov := res.Cov()
+	if cov == nil {
+		return nil
+	}
+	errs := make([]float64, cov.SymmetricDim())
+	for i := range errs {
+		errs[i] = math.Sqrt(cov.At(i, i))
+	}
+	return errs
+}
+
+// GlobalCorr returns the global correlation coefficients of the parameters,
+// i.e. the largest correlation between each parameter and any linear
+// combination of the other free parameters.
+// The coefficients of fixed parameters are null.
+// GlobalCorr returns nil when the covariance matrix is not available.
+func (res *Result) GlobalCorr() []float64 {
+	cov := res.Cov()
+	if cov == nil {
+		return nil
+	}
+
+	var (
+		n    = cov.SymmetricDim()
+		free = make([]int, 0, n)
+	)
+	for i := range n {
+		if cov.At(i, i) > 0 {
+			free = append(free, i)
+		}
+	}
+	if len(free) == 0 {
+		return make([]float64, n)
+	}
+
+	sub := mat.NewSymDense(len(free), nil)
+	for ki, i := range free {
+		for kj, j := range free[ki:] {
+			sub.SetSym(ki, ki+kj, cov.At(i, j))
+		}
+	}
+
+	var (
+		chol mat.Cholesky
+		inv  mat.SymDense
+		gcc  = make([]float64, n)
+	)
+	if ok := chol.Factorize(sub); !ok {
+		return nil
+	}
+	if err := chol.InverseTo(&inv); err != nil {
+		return nil
+	}
+	for k, i := range free {
+		v := 1 - 1/(sub.At(k, k)*inv.At(k, k))
+		gcc[i] = math.Sqrt(math.Max(0, v))
+	}
+	return gcc
+}
+
+// Propagate returns the value of the quantity f derived from the parameters
+// of the fit, and its error, propagated linearly from the covariance matrix
+// of the parameters.
+// The error is NaN when the covariance matrix is not available.
+func (res *Result) Propagate(f func(ps []float64) float64) (v, err float64) {
+	v = f(res.X)
+	cov := res.Cov()
+	if cov == nil {
+		return v, math.NaN()
+	}
+
+	grad := fd.Gradient(nil, f, res.X, nil)
+	g := mat.NewVecDense(len(grad), grad)
+	return v, math.Sqrt(math.Max(0, mat.Inner(g, cov, g)))
+}
+
+// Report returns a formatted report of the parameters of the fit, with their
+// values, errors and global correlation coefficients, followed by the
+// goodness of fit.
+// Parameters without a name in names are reported as p0, p1, ...
+func (res *Result) Report(names ...string) string {
+	var (
+		o    = new(strings.Builder)
+		errs = res.Errs()
+		gcc  = res.GlobalCorr()
+	)
+
+	fmt.Fprintf(o, "%-12s %14s    %-14s %s\n", "parameter", "value", "error", "global-corr")
+	for i, v := range res.X {
+		name := fmt.Sprintf("p%d", i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		switch {
+		case i < len(res.params) && res.params[i].Fixed:
+			fmt.Fprintf(o, "%-12s %14.6g    %-14s\n", name, v, "(fixed)")
+		case errs == nil:
+			fmt.Fprintf(o, "%-12s %14.6g    %-14s\n", name, v, "(n/a)")
+		case gcc == nil:
+			fmt.Fprintf(o, "%-12s %14.6g ± %-14.6g\n", name, v, errs[i])
+		default:
+			fmt.Fprintf(o, "%-12s %14.6g ± %-14.6g %.3f\n", name, v, errs[i], gcc[i])
+		}
+	}
+	switch {
+	case math.IsNaN(res.Chi2):
+		fmt.Fprintf(o, "nll=%g\n", res.NLL)
+	default:
+		fmt.Fprintf(o, "chi2/ndf=%g/%d p-value=%g\n", res.Chi2, res.NDF, res.PValue)
+	}
+	return o.String()
+}
+
+// correlation returns the correlation matrix of the covariance matrix cov.
+func correlation(cov *mat.SymDense) *mat.SymDense {
+	n := cov.SymmetricDim()
+	corr := mat.NewSymDense(n, nil)
+	for i := range n {
+		si := math.Sqrt(cov.At(i, i))
+		for j := i; j < n; j++ {
+			sj := math.Sqrt(cov.At(j, j))
+			if si == 0 || sj == 0 {
+				continue
+			}
+			corr.SetSym(i, j, cov.At(i, j)/(si*sj))
+		}
+	}
+	return corr
+}
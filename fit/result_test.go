@@ -0,0 +1,229 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestResultCovariance(t *testing.T) {
+	var (
+		xs = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		ys = []float64{2.1, 2.4, 3.1, 3.4, 4.1, 4.4, 5.1, 5.4, 6.1, 6.4}
+	)
+	res, err := fit.Curve1DResult(
+		fit.Func1D{
+			F:   func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x },
+			X:   xs,
+			Y:   ys,
+			Err: []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			Ps:  []float64{1, 1},
+		},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	if res.Cov() == nil {
+		t.Fatalf("no covariance matrix")
+	}
+
+	// covariance matrix of a linear model is the inverse of (XᵀX).
+	cov := mat.NewSymDense(2, []float64{
+		285.0 / 825, -45.0 / 825,
+		-45.0 / 825, 10.0 / 825,
+	})
+	if !mat.EqualApprox(res.Cov(), cov, 1e-4) {
+		t.Fatalf("invalid covariance:\ngot= %v\nwant=%v", mat.Formatted(res.Cov()), mat.Formatted(cov))
+	}
+
+	rho := -45.0 / math.Sqrt(285*10)
+	corr := mat.NewSymDense(2, []float64{1, rho, rho, 1})
+	if !mat.EqualApprox(res.Corr(), corr, 1e-4) {
+		t.Fatalf("invalid correlation:\ngot= %v\nwant=%v", mat.Formatted(res.Corr()), mat.Formatted(corr))
+	}
+
+	// with 2 parameters, global correlations are the absolute correlation.
+	if got, want := res.GlobalCorr(), []float64{-rho, -rho}; !floats.EqualApprox(got, want, 1e-4) {
+		t.Fatalf("invalid global correlations:\ngot= %v\nwant=%v", got, want)
+	}
+
+	if got, want := res.Errs(), []float64{math.Sqrt(285.0 / 825), math.Sqrt(10.0 / 825)}; !floats.EqualApprox(got, want, 1e-4) {
+		t.Fatalf("invalid errors:\ngot= %v\nwant=%v", got, want)
+	}
+
+	const x = 12.0
+	v, e := res.Propagate(func(ps []float64) float64 { return ps[0] + ps[1]*x })
+	if got, want := v, res.X[0]+res.X[1]*x; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("invalid propagated value: got=%v, want=%v", got, want)
+	}
+	if got, want := e, math.Sqrt(cov.At(0, 0)+x*x*cov.At(1, 1)+2*x*cov.At(0, 1)); math.Abs(got-want) > 1e-4 {
+		t.Fatalf("invalid propagated error: got=%v, want=%v", got, want)
+	}
+
+	report := res.Report("offset")
+	for _, want := range []string{"parameter", "offset", "p1", "±"} {
+		if !strings.Contains(report, want) {
+			t.Fatalf("report does not contain %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestResultCovarianceUnweighted(t *testing.T) {
+	var (
+		xs = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		ys = []float64{2.1, 2.4, 3.1, 3.4, 4.1, 4.4, 5.1, 5.4, 6.1, 6.4}
+		f  = func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x }
+	)
+	res, err := fit.Curve1DResult(
+		fit.Func1D{F: f, X: xs, Y: ys, Ps: []float64{1, 1}},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+
+	// without measurement errors, the covariance matrix is scaled by χ²/ndf.
+	cov := mat.NewSymDense(2, []float64{
+		285.0 / 825, -45.0 / 825,
+		-45.0 / 825, 10.0 / 825,
+	})
+	cov.ScaleSym(res.Chi2/8, cov)
+	if !mat.EqualApprox(res.Cov(), cov, 1e-6) {
+		t.Fatalf("invalid covariance:\ngot= %v\nwant=%v", mat.Formatted(res.Cov()), mat.Formatted(cov))
+	}
+
+	// no degrees of freedom left to estimate the errors.
+	res, err = fit.Curve1DResult(
+		fit.Func1D{F: f, X: xs[:2], Y: ys[:2], Ps: []float64{1, 1}},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	if cov := res.Cov(); cov != nil {
+		t.Fatalf("unexpected covariance matrix with ndf=0:\n%v", mat.Formatted(cov))
+	}
+	if errs := res.Errs(); errs != nil {
+		t.Fatalf("unexpected errors with ndf=0: %v", errs)
+	}
+}
+
+func TestResultFixed(t *testing.T) {
+	res, err := fit.Curve1DResult(
+		fit.Func1D{
+			F:      func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x },
+			X:      []float64{0, 1, 2, 3},
+			Y:      []float64{1, 2, 3, 4},
+			Ps:     []float64{1, 2},
+			Params: []fit.Param{{Fixed: true}, {}},
+		},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	if res.Cov() == nil {
+		t.Fatalf("no covariance matrix")
+	}
+	for i := range 2 {
+		if got := res.Cov().At(0, i); got != 0 {
+			t.Fatalf("invalid covariance of fixed parameter: got=%v", got)
+		}
+	}
+	if got, want := res.GlobalCorr(), []float64{0, 0}; !floats.Equal(got, want) {
+		t.Fatalf("invalid global correlations:\ngot= %v\nwant=%v", got, want)
+	}
+	if report := res.Report(); !strings.Contains(report, "(fixed)") {
+		t.Fatalf("report does not flag fixed parameter:\n%s", report)
+	}
+}
+
+func TestResultGoodnessOfFit(t *testing.T) {
+	var (
+		xs = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		ys = []float64{2.1, 2.4, 3.1, 3.4, 4.1, 4.4, 5.1, 5.4, 6.1, 6.4}
+		es = []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}
+	)
+	line := func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x }
+
+	res, err := fit.Curve1DResult(
+		fit.Func1D{F: line, X: xs, Y: ys, Err: es, Ps: []float64{1, 1}},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+
+	var chi2 float64
+	for i, x := range xs {
+		v := (line(x, res.X) - ys[i]) / es[i]
+		chi2 += v * v
+	}
+	if got, want := res.Chi2, chi2; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("invalid chi2: got=%v, want=%v", got, want)
+	}
+	if got, want := res.NLL, 0.5*chi2; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("invalid nll: got=%v, want=%v", got, want)
+	}
+	if got, want := res.NDF, 8; got != want {
+		t.Fatalf("invalid ndf: got=%d, want=%d", got, want)
+	}
+	if got, want := res.Chi2NDF(), chi2/8; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("invalid chi2/ndf: got=%v, want=%v", got, want)
+	}
+	if got, want := res.PValue, (distuv.ChiSquared{K: 8}).Survival(chi2); math.Abs(got-want) > 1e-6 {
+		t.Fatalf("invalid p-value: got=%v, want=%v", got, want)
+	}
+	if report := res.Report(); !strings.Contains(report, "chi2/ndf=") {
+		t.Fatalf("report does not contain the goodness of fit:\n%s", report)
+	}
+
+	// fixed and constrained parameters.
+	res, err = fit.Curve1DResult(
+		fit.Func1D{
+			F: line, X: xs, Y: ys, Err: es, Ps: []float64{2, 1},
+			Params: []fit.Param{
+				{Fixed: true},
+				{Constraint: &fit.Constraint{Mean: 0.5, Sigma: 0.1}},
+			},
+		},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	if got, want := res.NDF, 10; got != want {
+		t.Fatalf("invalid ndf: got=%d, want=%d", got, want)
+	}
+
+	// unbinned fits have no χ².
+	res, err = fit.Unbinned1D(
+		fit.PDF1D{
+			F:   func(x float64, ps []float64) float64 { return math.Exp(ps[0] * x) },
+			Ps:  []float64{-1},
+			Min: 0, Max: 5,
+			X: []float64{0.1, 0.5, 0.7, 1.2, 2.5},
+		},
+		nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	if !math.IsNaN(res.Chi2) || !math.IsNaN(res.PValue) || res.NDF != 0 {
+		t.Fatalf("invalid goodness of fit for unbinned fit: chi2=%v, ndf=%d, p=%v", res.Chi2, res.NDF, res.PValue)
+	}
+	if got, want := res.NLL, res.F; got != want {
+		t.Fatalf("invalid nll: got=%v, want=%v", got, want)
+	}
+}
@@ -0,0 +1,494 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/integrate/quad"
+)
+
+// Shape is a parametrized 1D function, used to model the data of a fit.
+//
+// A shape can be used as the model of a Func1D or of a PDF1D:
+//
+//	f := fit.Func1D{F: s.Eval, Grad: s.Grad, N: s.NParams()}
+type Shape interface {
+	// NParams returns the number of parameters of the shape.
+	NParams() int
+
+	// Eval returns the value of the shape at x, for the parameters ps.
+	Eval(x float64, ps []float64) float64
+
+	// Grad stores in grad the gradient of the shape at x, with respect
+	// to the parameters ps.
+	Grad(grad []float64, x float64, ps []float64)
+}
+
+var (
+	_ Shape = (*Gaussian)(nil)
+	_ Shape = (*DoubleGaussian)(nil)
+	_ Shape = (*CrystalBall)(nil)
+	_ Shape = (*Exponential)(nil)
+	_ Shape = (*Landau)(nil)
+	_ Shape = (*Chebyshev)(nil)
+	_ Shape = (*Bernstein)(nil)
+	_ Shape = (*Argus)(nil)
+	_ Shape = (*Sum)(nil)
+	_ Shape = (*Product)(nil)
+	_ Shape = (*Normalized)(nil)
+)
+
+// numGrad stores in grad the gradient of the shape s at x, computed with
+// finite differences.
+func numGrad(s Shape, grad []float64, x float64, ps []float64) {
+	fd.Gradient(grad, func(ps []float64) float64 {
+		return s.Eval(x, ps)
+	}, ps, &fd.Settings{Formula: fd.Central})
+}
+
+// Gaussian is the normal probability density function.
+//
+// Its parameters are the mean and the standard deviation.
+type Gaussian struct{}
+
+func (Gaussian) NParams() int { return 2 }
+
+func (Gaussian) Eval(x float64, ps []float64) float64 {
+	return gauss(x, ps[0], ps[1])
+}
+
+func (Gaussian) Grad(grad []float64, x float64, ps []float64) {
+	var (
+		mu, sigma = ps[0], ps[1]
+		v         = (x - mu) / sigma
+		f         = gauss(x, mu, sigma)
+	)
+	grad[0] = f * v / sigma
+	grad[1] = f * (v*v - 1) / sigma
+}
+
+func gauss(x, mu, sigma float64) float64 {
+	v := (x - mu) / sigma
+	return math.Exp(-0.5*v*v) / (math.Sqrt(2*math.Pi) * sigma)
+}
+
+// DoubleGaussian is the sum of two normal probability density functions
+// with the same mean.
+//
+// Its parameters are the mean, the standard deviations of the two
+// Gaussians, and the fraction of the first one.
+type DoubleGaussian struct{}
+
+func (DoubleGaussian) NParams() int { return 4 }
+
+func (DoubleGaussian) Eval(x float64, ps []float64) float64 {
+	var (
+		mu   = ps[0]
+		frac = ps[3]
+	)
+	return frac*gauss(x, mu, ps[1]) + (1-frac)*gauss(x, mu, ps[2])
+}
+
+func (DoubleGaussian) Grad(grad []float64, x float64, ps []float64) {
+	var (
+		mu, s1, s2 = ps[0], ps[1], ps[2]
+		frac       = ps[3]
+		v1, g1     = (x - mu) / s1, gauss(x, mu, s1)
+		v2, g2     = (x - mu) / s2, gauss(x, mu, s2)
+	)
+	grad[0] = frac*g1*v1/s1 + (1-frac)*g2*v2/s2
+	grad[1] = frac * g1 * (v1*v1 - 1) / s1
+	grad[2] = (1 - frac) * g2 * (v2*v2 - 1) / s2
+	grad[3] = g1 - g2
+}
+
+// CrystalBall is the Crystal Ball probability density function: a Gaussian
+// core with a power-law tail on its low side.
+//
+// Its parameters are the mean and the standard deviation of the Gaussian
+// core, the number of standard deviations alpha where the tail starts and
+// the exponent n of the tail, with n > 1.
+// The gradient is computed with finite differences.
+type CrystalBall struct{}
+
+func (CrystalBall) NParams() int { return 4 }
+
+func (CrystalBall) Eval(x float64, ps []float64) float64 {
+	var (
+		mu, sigma = ps[0], ps[1]
+		alpha     = math.Abs(ps[2])
+		n         = ps[3]
+		v         = (x - mu) / sigma
+		ea        = math.Exp(-0.5 * alpha * alpha)
+		c         = n / alpha / (n - 1) * ea
+		d         = math.Sqrt(math.Pi/2) * (1 + math.Erf(alpha/math.Sqrt2))
+		norm      = 1 / (sigma * (c + d))
+	)
+	if v > -alpha {
+		return norm * math.Exp(-0.5*v*v)
+	}
+	var (
+		a = math.Pow(n/alpha, n) * ea
+		b = n/alpha - alpha
+	)
+	return norm * a * math.Pow(b-v, -n)
+}
+
+func (s CrystalBall) Grad(grad []float64, x float64, ps []float64) {
+	numGrad(s, grad, x, ps)
+}
+
+// Exponential is the exponential function exp(c*x).
+//
+// Its parameter is the slope c.
+type Exponential struct{}
+
+func (Exponential) NParams() int { return 1 }
+
+func (Exponential) Eval(x float64, ps []float64) float64 {
+	return math.Exp(ps[0] * x)
+}
+
+func (Exponential) Grad(grad []float64, x float64, ps []float64) {
+	grad[0] = x * math.Exp(ps[0]*x)
+}
+
+// Landau is the Landau probability density function, as approximated by
+// the DENLAN routine of CERNLIB.
+//
+// Its parameters are the location and the scale of the distribution.
+// The gradient is computed with finite differences.
+type Landau struct{}
+
+func (Landau) NParams() int { return 2 }
+
+func (Landau) Eval(x float64, ps []float64) float64 {
+	x0, xi := ps[0], ps[1]
+	if xi <= 0 {
+		return 0
+	}
+	return denlan((x-x0)/xi) / xi
+}
+
+func (s Landau) Grad(grad []float64, x float64, ps []float64) {
+	numGrad(s, grad, x, ps)
+}
+
+// denlan returns the value of the standard Landau density at v.
+func denlan(v float64) float64 {
+	var (
+		p1 = [5]float64{0.4259894875, -0.1249762550, 0.03984243700, -0.006298287635, 0.001511162253}
+		q1 = [5]float64{1.0, -0.3388260629, 0.09594393323, -0.01608042283, 0.003778942063}
+		p2 = [5]float64{0.1788541609, 0.1173957403, 0.01488850518, -0.001394989411, 0.0001283617211}
+		q2 = [5]float64{1.0, 0.7428795082, 0.3153932961, 0.06694219548, 0.008790609714}
+		p3 = [5]float64{0.1788544503, 0.09359161662, 0.006325387654, 0.00006611667319, -0.000002031049101}
+		q3 = [5]float64{1.0, 0.6097809921, 0.2560616665, 0.04746722384, 0.006957301675}
+		p4 = [5]float64{0.9874054407, 118.6723273, 849.2794360, -743.7792444, 427.0262186}
+		q4 = [5]float64{1.0, 106.8615961, 337.6496214, 2016.712389, 1597.063511}
+		p5 = [5]float64{1.003675074, 167.5702434, 4789.711289, 21217.86767, -22324.94910}
+		q5 = [5]float64{1.0, 156.9424537, 3745.310488, 9834.698876, 66924.28357}
+		p6 = [5]float64{1.000827619, 664.9143136, 62972.92665, 475554.6998, -5743609.109}
+		q6 = [5]float64{1.0, 651.4101098, 56974.73333, 165917.4725, -2815759.939}
+		a1 = [3]float64{0.04166666667, -0.01996527778, 0.02709538966}
+		a2 = [2]float64{-1.845568670, -4.284640743}
+	)
+
+	ratio := func(p, q [5]float64, u float64) float64 {
+		return (p[0] + (p[1]+(p[2]+(p[3]+p[4]*u)*u)*u)*u) /
+			(q[0] + (q[1]+(q[2]+(q[3]+q[4]*u)*u)*u)*u)
+	}
+
+	switch {
+	case v < -5.5:
+		u := math.Exp(v + 1)
+		if u < 1e-10 {
+			return 0
+		}
+		return 0.3989422803 * (math.Exp(-1/u) / math.Sqrt(u)) *
+			(1 + (a1[0]+(a1[1]+a1[2]*u)*u)*u)
+	case v < -1:
+		u := math.Exp(-v - 1)
+		return math.Exp(-u) * math.Sqrt(u) * ratio(p1, q1, v)
+	case v < 1:
+		return ratio(p2, q2, v)
+	case v < 5:
+		return ratio(p3, q3, v)
+	case v < 12:
+		u := 1 / v
+		return u * u * ratio(p4, q4, u)
+	case v < 50:
+		u := 1 / v
+		return u * u * ratio(p5, q5, u)
+	case v < 300:
+		u := 1 / v
+		return u * u * ratio(p6, q6, u)
+	}
+	u := 1 / (v - v*math.Log(v)/(v+1))
+	return u * u * (1 + (a2[0]+a2[1]*u)*u)
+}
+
+// Chebyshev is a linear combination of Chebyshev polynomials of the first
+// kind, over [Min, Max].
+//
+// Its parameters are the Degree+1 coefficients of the polynomials T0, T1, ...
+type Chebyshev struct {
+	Min, Max float64
+	Degree   int
+}
+
+func (s Chebyshev) NParams() int { return s.Degree + 1 }
+
+func (s Chebyshev) Eval(x float64, ps []float64) float64 {
+	var (
+		u      = 2*(x-s.Min)/(s.Max-s.Min) - 1
+		t0, t1 = 1.0, u
+		sum    = ps[0] * t0
+	)
+	for k := 1; k <= s.Degree; k++ {
+		sum += ps[k] * t1
+		t0, t1 = t1, 2*u*t1-t0
+	}
+	return sum
+}
+
+func (s Chebyshev) Grad(grad []float64, x float64, ps []float64) {
+	var (
+		u      = 2*(x-s.Min)/(s.Max-s.Min) - 1
+		t0, t1 = 1.0, u
+	)
+	grad[0] = t0
+	for k := 1; k <= s.Degree; k++ {
+		grad[k] = t1
+		t0, t1 = t1, 2*u*t1-t0
+	}
+}
+
+// Bernstein is a linear combination of Bernstein basis polynomials, over
+// [Min, Max].
+// Bernstein polynomials are positive over [Min, Max] when all their
+// coefficients are positive.
+//
+// Its parameters are the Degree+1 coefficients of the basis polynomials.
+type Bernstein struct {
+	Min, Max float64
+	Degree   int
+}
+
+func (s Bernstein) NParams() int { return s.Degree + 1 }
+
+func (s Bernstein) Eval(x float64, ps []float64) float64 {
+	var sum float64
+	s.basis(x, func(k int, b float64) {
+		sum += ps[k] * b
+	})
+	return sum
+}
+
+func (s Bernstein) Grad(grad []float64, x float64, ps []float64) {
+	s.basis(x, func(k int, b float64) {
+		grad[k] = b
+	})
+}
+
+// basis calls f with the values of the basis polynomials at x.
+func (s Bernstein) basis(x float64, f func(k int, b float64)) {
+	var (
+		n = s.Degree
+		u = (x - s.Min) / (s.Max - s.Min)
+		c = 1.0 // binomial coefficient
+	)
+	for k := 0; k <= n; k++ {
+		f(k, c*math.Pow(u, float64(k))*math.Pow(1-u, float64(n-k)))
+		c = c * float64(n-k) / float64(k+1)
+	}
+}
+
+// Argus is the ARGUS function, describing the invariant mass of
+// combinatorial background near a kinematic endpoint:
+//
+//	f(m) = m * (1-(m/m0)²)^(p) * exp(c*(1-(m/m0)²)), for m < m0.
+//
+// Its parameters are the endpoint m0, the curvature c and the power p,
+// which is 0.5 for the original ARGUS function.
+type Argus struct{}
+
+func (Argus) NParams() int { return 3 }
+
+func (Argus) Eval(x float64, ps []float64) float64 {
+	m0, c, p := ps[0], ps[1], ps[2]
+	z := x / m0
+	u := 1 - z*z
+	if u <= 0 || x < 0 {
+		return 0
+	}
+	return x * math.Pow(u, p) * math.Exp(c*u)
+}
+
+func (s Argus) Grad(grad []float64, x float64, ps []float64) {
+	m0, c, p := ps[0], ps[1], ps[2]
+	z := x / m0
+	u := 1 - z*z
+	if u <= 0 || x < 0 {
+		clear(grad[:3])
+		return
+	}
+	f := x * math.Pow(u, p) * math.Exp(c*u)
+	grad[0] = f * (p/u + c) * 2 * z * z / m0
+	grad[1] = f * u
+	grad[2] = f * math.Log(u)
+}
+
+// Sum is the sum of shapes, weighted by yields.
+//
+// Its parameters are the yields of each shape, followed by the parameters
+// of each shape, in order.
+type Sum []Shape
+
+func (s Sum) NParams() int {
+	n := len(s)
+	for _, v := range s {
+		n += v.NParams()
+	}
+	return n
+}
+
+func (s Sum) Eval(x float64, ps []float64) float64 {
+	var (
+		sum float64
+		i   = len(s)
+	)
+	for k, v := range s {
+		n := v.NParams()
+		sum += ps[k] * v.Eval(x, ps[i:i+n])
+		i += n
+	}
+	return sum
+}
+
+func (s Sum) Grad(grad []float64, x float64, ps []float64) {
+	i := len(s)
+	for k, v := range s {
+		n := v.NParams()
+		grad[k] = v.Eval(x, ps[i:i+n])
+		v.Grad(grad[i:i+n], x, ps[i:i+n])
+		for j := i; j < i+n; j++ {
+			grad[j] *= ps[k]
+		}
+		i += n
+	}
+}
+
+// Product is the product of shapes.
+//
+// Its parameters are the parameters of each shape, in order.
+type Product []Shape
+
+func (s Product) NParams() int {
+	var n int
+	for _, v := range s {
+		n += v.NParams()
+	}
+	return n
+}
+
+func (s Product) Eval(x float64, ps []float64) float64 {
+	var (
+		prod = 1.0
+		i    = 0
+	)
+	for _, v := range s {
+		n := v.NParams()
+		prod *= v.Eval(x, ps[i:i+n])
+		i += n
+	}
+	return prod
+}
+
+func (s Product) Grad(grad []float64, x float64, ps []float64) {
+	vs := make([]float64, len(s))
+	i := 0
+	for k, v := range s {
+		n := v.NParams()
+		vs[k] = v.Eval(x, ps[i:i+n])
+		i += n
+	}
+
+	i = 0
+	for k, v := range s {
+		n := v.NParams()
+		other := 1.0
+		for j, vj := range vs {
+			if j != k {
+				other *= vj
+			}
+		}
+		v.Grad(grad[i:i+n], x, ps[i:i+n])
+		for j := i; j < i+n; j++ {
+			grad[j] *= other
+		}
+		i += n
+	}
+}
+
+// Normalized is a shape normalized numerically over [Min, Max], so it can
+// be used as a probability density over that range, e.g. as a component
+// of a Sum with yields.
+type Normalized struct {
+	Shape    Shape
+	Min, Max float64
+}
+
+func (s Normalized) NParams() int { return s.Shape.NParams() }
+
+func (s Normalized) Eval(x float64, ps []float64) float64 {
+	return s.Shape.Eval(x, ps) / s.integral(ps, nil)
+}
+
+func (s Normalized) Grad(grad []float64, x float64, ps []float64) {
+	var (
+		n     = len(grad)
+		dnorm = make([]float64, n)
+		norm  = s.integral(ps, dnorm)
+		v     = s.Shape.Eval(x, ps)
+	)
+	s.Shape.Grad(grad, x, ps)
+	for i := range grad {
+		grad[i] = (grad[i]*norm - v*dnorm[i]) / (norm * norm)
+	}
+}
+
+// integral returns the integral of the shape over [Min, Max], and stores
+// in grad the integral of its gradient, when grad is not nil.
+func (s Normalized) integral(ps []float64, grad []float64) float64 {
+	var (
+		xs = make([]float64, pdfNodes)
+		ws = make([]float64, pdfNodes)
+		dx = (s.Max - s.Min) / pdfIntervals
+		g  []float64
+	)
+	quad.Legendre{}.FixedLocations(xs, ws, 0, dx)
+	if grad != nil {
+		clear(grad)
+		g = make([]float64, len(grad))
+	}
+
+	var sum float64
+	for i := range pdfIntervals {
+		x0 := s.Min + float64(i)*dx
+		for j := range xs {
+			x := x0 + xs[j]
+			sum += ws[j] * s.Shape.Eval(x, ps)
+			if grad != nil {
+				s.Shape.Grad(g, x, ps)
+				for k := range grad {
+					grad[k] += ws[j] * g[k]
+				}
+			}
+		}
+	}
+	return sum
+}
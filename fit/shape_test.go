@@ -0,0 +1,184 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/integrate/quad"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestShapeGrad(t *testing.T) {
+	for _, tc := range []struct {
+		shape fit.Shape
+		ps    []float64
+		xs    []float64
+	}{
+		{fit.Gaussian{}, []float64{1, 2}, []float64{-1, 0, 1, 2.5}},
+		{fit.DoubleGaussian{}, []float64{1, 0.5, 2, 0.7}, []float64{-1, 0, 1, 2.5}},
+		{fit.CrystalBall{}, []float64{1, 0.5, 1.5, 3}, []float64{-2, 0, 1, 2.5}},
+		{fit.Exponential{}, []float64{-0.5}, []float64{0, 1, 2.5}},
+		{fit.Landau{}, []float64{1, 0.5}, []float64{0, 1, 2.5, 10}},
+		{fit.Chebyshev{Min: -1, Max: 3, Degree: 3}, []float64{1, 2, 3, 4}, []float64{-1, 0, 1, 2.5}},
+		{fit.Bernstein{Min: -1, Max: 3, Degree: 3}, []float64{1, 2, 3, 4}, []float64{-1, 0, 1, 2.5}},
+		{fit.Argus{}, []float64{5.29, -20, 0.5}, []float64{5.2, 5.25, 5.28}},
+		{
+			fit.Sum{fit.Gaussian{}, fit.Exponential{}},
+			[]float64{10, 20, 1, 2, -0.5},
+			[]float64{-1, 0, 1, 2.5},
+		},
+		{
+			fit.Product{fit.Gaussian{}, fit.Chebyshev{Min: -1, Max: 3, Degree: 1}},
+			[]float64{1, 2, 1, 0.5},
+			[]float64{-1, 0, 1, 2.5},
+		},
+		{
+			fit.Normalized{Shape: fit.Exponential{}, Min: 0, Max: 5},
+			[]float64{-0.5},
+			[]float64{0, 1, 2.5},
+		},
+	} {
+		t.Run(fmt.Sprintf("%T", tc.shape), func(t *testing.T) {
+			if got, want := tc.shape.NParams(), len(tc.ps); got != want {
+				t.Fatalf("invalid number of parameters: got=%d, want=%d", got, want)
+			}
+			for _, x := range tc.xs {
+				var (
+					got  = make([]float64, len(tc.ps))
+					want = fd.Gradient(nil, func(ps []float64) float64 {
+						return tc.shape.Eval(x, ps)
+					}, tc.ps, &fd.Settings{Formula: fd.Central})
+				)
+				tc.shape.Grad(got, x, tc.ps)
+				if !floats.EqualApprox(got, want, 1e-5) {
+					t.Fatalf("invalid gradient at x=%v:\ngot= %v\nwant=%v", x, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestShapeNormalization(t *testing.T) {
+	for _, tc := range []struct {
+		shape    fit.Shape
+		ps       []float64
+		min, max float64
+		tol      float64
+	}{
+		{fit.Gaussian{}, []float64{1, 2}, -30, 30, 1e-6},
+		{fit.DoubleGaussian{}, []float64{1, 0.5, 2, 0.7}, -30, 30, 1e-6},
+		{fit.CrystalBall{}, []float64{1, 0.5, 1.5, 3}, -1000, 30, 1e-4},
+		{fit.Landau{}, []float64{0, 1}, -10, 1e5, 1e-4},
+		{fit.Normalized{Shape: fit.Argus{}, Min: 5.2, Max: 5.29}, []float64{5.29, -20, 0.5}, 5.2, 5.29, 1e-4},
+	} {
+		t.Run(fmt.Sprintf("%T", tc.shape), func(t *testing.T) {
+			f := func(x float64) float64 { return tc.shape.Eval(x, tc.ps) }
+			// integrate piecewise, for shapes with long tails.
+			var (
+				sum float64
+				lo  = tc.min
+			)
+			for _, hi := range []float64{-10, 10, 100, 1e3, 1e4, tc.max} {
+				hi = math.Min(hi, tc.max)
+				if hi <= lo {
+					continue
+				}
+				sum += quad.Fixed(f, lo, hi, 2000, nil, 0)
+				lo = hi
+			}
+			if got, want := sum, 1.0; math.Abs(got-want) > tc.tol {
+				t.Fatalf("invalid normalization: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestShapeValues(t *testing.T) {
+	// mode of the standard Landau distribution.
+	const mode = -0.22278
+	landau := fit.Landau{}
+	for _, x := range []float64{mode - 0.05, mode + 0.05} {
+		if landau.Eval(x, []float64{0, 1}) >= landau.Eval(mode, []float64{0, 1}) {
+			t.Fatalf("invalid Landau mode")
+		}
+	}
+
+	cheb := fit.Chebyshev{Min: -1, Max: 1, Degree: 3}
+	for _, x := range []float64{-1, -0.3, 0, 0.5, 1} {
+		got := cheb.Eval(x, []float64{1, 2, 3, 4})
+		want := 1 + 2*x + 3*(2*x*x-1) + 4*(4*x*x*x-3*x)
+		if math.Abs(got-want) > 1e-12 {
+			t.Fatalf("invalid Chebyshev value at x=%v: got=%v, want=%v", x, got, want)
+		}
+	}
+
+	// Bernstein basis polynomials are a partition of unity.
+	bern := fit.Bernstein{Min: 2, Max: 4, Degree: 5}
+	for _, x := range []float64{2, 2.3, 3, 3.9, 4} {
+		if got, want := bern.Eval(x, []float64{1, 1, 1, 1, 1, 1}), 1.0; math.Abs(got-want) > 1e-12 {
+			t.Fatalf("invalid Bernstein value at x=%v: got=%v, want=%v", x, got, want)
+		}
+	}
+
+	if got := (fit.Argus{}).Eval(5.3, []float64{5.29, -20, 0.5}); got != 0 {
+		t.Fatalf("invalid ARGUS value above endpoint: got=%v", got)
+	}
+}
+
+func TestShapeFit(t *testing.T) {
+	var (
+		model = fit.Sum{fit.Gaussian{}, fit.Exponential{}}
+		want  = []float64{50, 10, 2, 0.5, -0.3}
+		xs    = make([]float64, 50)
+		ys    = make([]float64, len(xs))
+	)
+	for i := range xs {
+		xs[i] = 0.2 * float64(i)
+		ys[i] = model.Eval(xs[i], want)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		params []fit.Param
+	}{
+		{"free", nil},
+		{
+			"bounded",
+			[]fit.Param{
+				{Min: 0, Max: 100}, {Min: 0, Max: math.Inf(+1)}, {},
+				{Min: 0.1, Max: 1}, {Min: math.Inf(-1), Max: 0},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := fit.Curve1D(
+				fit.Func1D{
+					F:      model.Eval,
+					Grad:   model.Grad,
+					X:      xs,
+					Y:      ys,
+					Ps:     []float64{40, 8, 2.2, 0.6, -0.2},
+					Params: tc.params,
+				},
+				nil, &optimize.BFGS{},
+			)
+			if err != nil {
+				t.Fatalf("could not fit: %+v", err)
+			}
+			if err := res.Status.Err(); err != nil {
+				t.Fatalf("invalid fit status: %+v", err)
+			}
+			if got := res.X; !floats.EqualApprox(got, want, 1e-4) {
+				t.Fatalf("invalid fit:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
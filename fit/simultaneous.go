@@ -0,0 +1,113 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/optimize"
+)
+
+// Channel is a dataset of a simultaneous fit, with its model.
+type Channel struct {
+	// Func is the model and the data of the channel.
+	// The initial parameters of Func and their configuration are ignored:
+	// they are taken from the parameters of the simultaneous fit.
+	Func Func1D
+
+	// Params maps the parameters of the channel to the parameters of the
+	// simultaneous fit: the i-th parameter of Func is the Params[i]-th
+	// parameter of the simultaneous fit.
+	// Parameters shared between channels are mapped to the same index.
+	Params []int
+
+	// Poisson selects a binned Poisson likelihood for the channel,
+	// as for H1DPoisson, instead of a χ².
+	Poisson bool
+}
+
+// Simultaneous returns the result of the simultaneous fit of all the channels
+// with method m, starting from the initial parameters ps.
+// The parameters are bounded, fixed and constrained as configured by params,
+// indexed as ps. If params is nil, all the parameters are free and unbounded.
+//
+// The cost function is the sum of the cost functions of each channel, so
+// that shared parameters are constrained by all the channels they appear in.
+// In case settings is nil, the optimize.DefaultSettingsLocal is used.
+// In case m is nil, the same default optimization method than for Curve1D is used.
+func Simultaneous(chans []Channel, ps []float64, params []Param, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	fct, err := SimultaneousCost(chans, len(ps))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ndata int
+		unit  = true
+	)
+	for _, ch := range chans {
+		ndata += len(ch.Func.X)
+		unit = unit && !ch.Poisson && ch.Func.Err == nil && ch.Func.Cov == nil
+	}
+	return run(objective{fct, nil, ndata, unit}, ps, params, settings, m)
+}
+
+// SimultaneousCost returns the cost function of the simultaneous fit of all
+// the channels, as a function of the n parameters of the simultaneous fit.
+func SimultaneousCost(chans []Channel, n int) (Cost, error) {
+	if len(chans) == 0 {
+		return nil, fmt.Errorf("fit: no channel to fit")
+	}
+
+	fcts := make([]func(ps []float64) float64, len(chans))
+	for i, ch := range chans {
+		if len(ch.Params) == 0 {
+			return nil, fmt.Errorf("fit: channel %d has no parameter", i)
+		}
+		for _, j := range ch.Params {
+			if j < 0 || j >= n {
+				return nil, fmt.Errorf(
+					"fit: channel %d has invalid parameter index %d (nparams=%d)",
+					i, j, n,
+				)
+			}
+		}
+
+		f := ch.Func
+		f.N = len(ch.Params)
+		f.Ps = nil
+		f.Params = nil
+		switch {
+		case ch.Poisson:
+			f.initPoisson()
+		default:
+			f.init()
+			if err := f.initCov(); err != nil {
+				return nil, fmt.Errorf("fit: channel %d: %w", i, err)
+			}
+		}
+
+		var (
+			idx = ch.Params
+			sub = make([]float64, len(idx))
+		)
+		fcts[i] = func(ps []float64) float64 {
+			for k, j := range idx {
+				sub[k] = ps[j]
+			}
+			return f.fct(sub)
+		}
+	}
+
+	fct := func(ps []float64) float64 {
+		var sum float64
+		for _, fct := range fcts {
+			sum += fct(ps)
+		}
+		return sum
+	}
+
+	return fct, nil
+}
@@ -0,0 +1,76 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/optimize"
+)
+
+func TestSimultaneous(t *testing.T) {
+	const (
+		a = 2.0 // shared offset
+		b = 0.5 // slope of the signal region
+		c = 4.0 // level of the control region
+	)
+
+	var (
+		xs = []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		sr = make([]float64, len(xs))
+		cr = hbook.NewH1D(10, 0, 10)
+	)
+	for i, x := range xs {
+		sr[i] = a + b*x
+		cr.Fill(x+0.5, a*c)
+	}
+
+	line := func(x float64, ps []float64) float64 { return ps[0] + ps[1]*x }
+	flat := func(x float64, ps []float64) float64 { return ps[0] * ps[1] }
+
+	res, err := fit.Simultaneous(
+		[]fit.Channel{
+			{
+				Func:   fit.Func1D{F: line, X: xs, Y: sr},
+				Params: []int{0, 1},
+			},
+			{
+				Func:    fit.NewBinned1D(cr, nil).Func1D(fit.Func1D{F: flat}),
+				Params:  []int{0, 2},
+				Poisson: true,
+			},
+		},
+		[]float64{1, 1, 1}, nil, nil, &optimize.NelderMead{},
+	)
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+	if err := res.Status.Err(); err != nil {
+		t.Fatalf("invalid fit status: %+v", err)
+	}
+
+	if got, want := res.X, []float64{a, b, c}; !floats.EqualApprox(got, want, 1e-3) {
+		t.Fatalf("invalid fit:\ngot= %v\nwant=%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		chans []fit.Channel
+	}{
+		{"no-channel", nil},
+		{"no-param", []fit.Channel{{Func: fit.Func1D{F: line, X: xs, Y: sr}}}},
+		{"invalid-param", []fit.Channel{{Func: fit.Func1D{F: line, X: xs, Y: sr}, Params: []int{0, 3}}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := fit.Simultaneous(tc.chans, []float64{1, 1, 1}, nil, nil, nil)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
+	"go-hep.org/x/hep/hbook"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ToyConfig configures a study of pseudo-experiments.
+type ToyConfig struct {
+	// N is the number of pseudo-experiments.
+	N int
+
+	// Workers is the number of pseudo-experiments generated and fitted
+	// concurrently. If Workers is 0, runtime.GOMAXPROCS(0) is used.
+	Workers int
+
+	// Seed is the seed of the pseudo-random numbers generators.
+	// The i-th pseudo-experiment is generated from the seed Seed+i, so
+	// studies are reproducible, independently of the number of workers.
+	Seed uint64
+
+	// Settings are the optimization settings of the fits.
+	Settings *optimize.Settings
+
+	// Method creates the optimization method of each fit.
+	// If Method is nil, the same default optimization method than for
+	// Curve1D is used.
+	Method func() optimize.Method
+}
+
+// Toys holds the results of a study of pseudo-experiments.
+type Toys struct {
+	Truth  []float64   // true values of the parameters
+	Params [][]float64 // fitted values of the parameters, for each successful fit
+	Errs   [][]float64 // errors on the fitted parameters, for each successful fit
+	Failed int         // number of pseudo-experiments whose fit failed
+}
+
+// Pulls returns the pulls of the i-th parameter, (fit-truth)/error, for each
+// successful fit.
+// The pulls of fixed parameters are NaN.
+func (toys *Toys) Pulls(i int) []float64 {
+	pulls := make([]float64, len(toys.Params))
+	for k, ps := range toys.Params {
+		pulls[k] = (ps[i] - toys.Truth[i]) / toys.Errs[k][i]
+		if toys.Errs[k][i] == 0 {
+			pulls[k] = math.NaN()
+		}
+	}
+	return pulls
+}
+
+// PullStats returns the mean and the standard deviation of the pulls of the
+// i-th parameter.
+// For an unbiased fit with correct errors, the pulls are normally distributed
+// with a null mean and a unit standard deviation.
+func (toys *Toys) PullStats(i int) (mean, std float64) {
+	return stat.MeanStdDev(toys.Pulls(i), nil)
+}
+
+// PullH1D returns the distribution of the pulls of the i-th parameter,
+// in a histogram with n bins from min to max.
+func (toys *Toys) PullH1D(i, n int, min, max float64) *hbook.H1D {
+	h := hbook.NewH1D(n, min, max)
+	for _, v := range toys.Pulls(i) {
+		h.Fill(v, 1)
+	}
+	return h
+}
+
+// ToysH1D runs a study of pseudo-experiments of the binned Poisson likelihood
+// fit of f to histograms with the binning of h, as performed by H1DPoisson.
+//
+// The contents of the bins of each pseudo-experiment are Poisson fluctuated
+// around the expectations of f with the parameters ps, which are also the
+// initial parameters of the fits.
+// f.F must be safe for concurrent use.
+func ToysH1D(h *hbook.H1D, f Func1D, ps []float64, cfg ToyConfig) (*Toys, error) {
+	bins := NewBinned1D(h, func(int, hbook.Bin1D) bool { return false })
+	nus := make([]float64, bins.Len())
+	for i, x := range bins.X {
+		nus[i] = f.F(x, ps)
+	}
+
+	return runToys(ps, cfg, func(src rand.Source, m optimize.Method) (*Result, error) {
+		ys := make([]float64, len(nus))
+		for i, nu := range nus {
+			if nu > 0 {
+				ys[i] = distuv.Poisson{Lambda: nu, Src: src}.Rand()
+			}
+		}
+
+		f := f
+		f.X = bins.X
+		f.Y = ys
+		f.Err = nil
+		f.Ps = ps
+		f.initPoisson()
+		return f.minimize(cfg.Settings, m)
+	})
+}
+
+// ToysUnbinned1D runs a study of pseudo-experiments of the unbinned
+// maximum-likelihood fit of f, as performed by Unbinned1D.
+//
+// The events of each pseudo-experiment are sampled from f with the parameters
+// ps, which are also the initial parameters of the fits.
+// Each pseudo-experiment has n events, except for extended fits where the
+// number of events is Poisson fluctuated around the integral of f, and n is
+// ignored.
+// f.F must be safe for concurrent use.
+func ToysUnbinned1D(f PDF1D, ps []float64, n int, cfg ToyConfig) (*Toys, error) {
+	f.X = nil
+	f.W = nil
+	f.Ps = ps
+	f.init()
+
+	// envelope of the density, for the accept-reject sampling.
+	var fmax float64
+	for _, x := range f.nodes {
+		fmax = math.Max(fmax, f.F(x, ps))
+	}
+	fmax = math.Max(fmax, math.Max(f.F(f.Min, ps), f.F(f.Max, ps)))
+	if !(fmax > 0) {
+		return nil, fmt.Errorf("fit: invalid density for sampling (max=%v)", fmax)
+	}
+	fmax *= 1.1
+	norm := f.integral(ps)
+
+	return runToys(ps, cfg, func(src rand.Source, m optimize.Method) (*Result, error) {
+		var (
+			rnd = rand.New(src)
+			nev = n
+		)
+		if f.Extended {
+			nev = int(distuv.Poisson{Lambda: norm, Src: src}.Rand())
+		}
+
+		xs := make([]float64, 0, nev)
+		for len(xs) < nev {
+			x := f.Min + (f.Max-f.Min)*rnd.Float64()
+			if rnd.Float64()*fmax <= f.F(x, ps) {
+				xs = append(xs, x)
+			}
+		}
+
+		f := f
+		f.X = xs
+		f.init()
+		return run(objective{f.fct, nil, -1, false}, f.Ps, f.Params, cfg.Settings, m)
+	})
+}
+
+// runToys runs the pseudo-experiments of the study configured by cfg, each
+// of them generated and fitted by toy.
+func runToys(truth []float64, cfg ToyConfig, toy func(src rand.Source, m optimize.Method) (*Result, error)) (*Toys, error) {
+	if cfg.N <= 0 {
+		return nil, fmt.Errorf("fit: invalid number of pseudo-experiments (n=%d)", cfg.N)
+	}
+
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, cfg.N)
+
+	var (
+		wg   sync.WaitGroup
+		ids  = make(chan int)
+		ress = make([]*Result, cfg.N)
+	)
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := range ids {
+				var m optimize.Method
+				if cfg.Method != nil {
+					m = cfg.Method()
+				}
+				res, err := toy(rand.NewSource(cfg.Seed+uint64(i)), m)
+				if err != nil || res == nil || res.Status.Err() != nil || res.Cov() == nil {
+					continue
+				}
+				ress[i] = res
+			}
+		}()
+	}
+	for i := range cfg.N {
+		ids <- i
+	}
+	close(ids)
+	wg.Wait()
+
+	toys := &Toys{
+		Truth:  make([]float64, len(truth)),
+		Params: make([][]float64, 0, cfg.N),
+		Errs:   make([][]float64, 0, cfg.N),
+	}
+	copy(toys.Truth, truth)
+	for _, res := range ress {
+		if res == nil {
+			toys.Failed++
+			continue
+		}
+		toys.Params = append(toys.Params, res.X)
+		toys.Errs = append(toys.Errs, res.Errs())
+	}
+	return toys, nil
+}
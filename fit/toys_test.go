@@ -0,0 +1,89 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestToysH1D(t *testing.T) {
+	var (
+		h     = hbook.NewH1D(20, -5, 5)
+		truth = []float64{50, 0.5, 1.2}
+		f     = fit.Func1D{
+			F: func(x float64, ps []float64) float64 {
+				v := (x - ps[1]) / ps[2]
+				return ps[0] * math.Exp(-0.5*v*v)
+			},
+		}
+	)
+
+	toys, err := fit.ToysH1D(h, f, truth, fit.ToyConfig{N: 200, Seed: 1234})
+	if err != nil {
+		t.Fatalf("could not run toys: %+v", err)
+	}
+	if got, want := len(toys.Params)+toys.Failed, 200; got != want {
+		t.Fatalf("invalid number of toys: got=%d, want=%d", got, want)
+	}
+	if toys.Failed > 10 {
+		t.Fatalf("too many failed fits: %d", toys.Failed)
+	}
+	for i := range truth {
+		mean, std := toys.PullStats(i)
+		if math.Abs(mean) > 0.25 || math.Abs(std-1) > 0.2 {
+			t.Fatalf("invalid pulls of parameter %d: mean=%v, std=%v", i, mean, std)
+		}
+	}
+
+	h1 := toys.PullH1D(1, 20, -5, 5)
+	if got, want := h1.Entries(), int64(len(toys.Params)); got != want {
+		t.Fatalf("invalid pull histogram entries: got=%d, want=%d", got, want)
+	}
+
+	// studies are reproducible, whatever the number of workers.
+	ref, err := fit.ToysH1D(h, f, truth, fit.ToyConfig{N: 20, Seed: 42, Workers: 1})
+	if err != nil {
+		t.Fatalf("could not run toys: %+v", err)
+	}
+	got, err := fit.ToysH1D(h, f, truth, fit.ToyConfig{N: 20, Seed: 42, Workers: 4})
+	if err != nil {
+		t.Fatalf("could not run toys: %+v", err)
+	}
+	if !reflect.DeepEqual(got, ref) {
+		t.Fatalf("toys are not reproducible")
+	}
+}
+
+func TestToysUnbinned1D(t *testing.T) {
+	var (
+		truth = []float64{-0.5}
+		f     = fit.PDF1D{
+			F:   func(x float64, ps []float64) float64 { return math.Exp(ps[0] * x) },
+			Min: 0,
+			Max: 5,
+		}
+	)
+
+	toys, err := fit.ToysUnbinned1D(f, truth, 200, fit.ToyConfig{N: 100, Seed: 1234})
+	if err != nil {
+		t.Fatalf("could not run toys: %+v", err)
+	}
+	if toys.Failed > 5 {
+		t.Fatalf("too many failed fits: %d", toys.Failed)
+	}
+	mean, std := toys.PullStats(0)
+	if math.Abs(mean) > 0.3 || math.Abs(std-1) > 0.25 {
+		t.Fatalf("invalid pulls: mean=%v, std=%v", mean, std)
+	}
+
+	if _, err := fit.ToysUnbinned1D(f, truth, 200, fit.ToyConfig{}); err == nil {
+		t.Fatalf("expected an error for invalid number of toys")
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/integrate/quad"
+	"gonum.org/v1/gonum/optimize"
+)
+
+// PDF1D describes a 1D probability density function to fit to a sample of
+// events with an unbinned maximum-likelihood fit.
+type PDF1D struct {
+	// F is the probability density function, up to a normalization
+	// factor: F is normalized numerically over [Min, Max] during the fit.
+	// ps is the slice of parameters to optimize during the fit.
+	// F must be positive over [Min, Max].
+	F func(x float64, ps []float64) float64
+
+	// N is the number of parameters to optimize during the fit.
+	// If N is 0, Ps must not be nil.
+	N int
+
+	// Ps is the initial values for the parameters.
+	// If Ps is nil, the set of initial parameters values is a slice of
+	// length N filled with zeros.
+	Ps []float64
+
+	// Params configures the bounds, fixing and constraints of the
+	// parameters, indexed as Ps.
+	// If Params is nil, all the parameters are free and unbounded.
+	Params []Param
+
+	// Min and Max are the bounds of the range of the fit.
+	// Events outside of [Min, Max] are ignored.
+	Min, Max float64
+
+	// Extended selects an extended maximum-likelihood fit, where F is the
+	// density of the expected number of events, including the yield,
+	// instead of a probability density.
+	Extended bool
+
+	X []float64 // values of the events
+	W []float64 // weights of the events; all events have a unit weight if W is nil.
+
+	xs []float64 // values of the events within the range of the fit
+	ws []float64 // weights of the events within the range of the fit
+
+	sumw float64 // sum of weights of the events within the range of the fit
+
+	nodes   []float64 // locations of the quadrature nodes over [Min, Max]
+	weights []float64 // weights of the quadrature nodes over [Min, Max]
+
+	fct func(ps []float64) float64 // cost function (objective function)
+}
+
+const (
+	pdfIntervals = 64 // number of intervals for the numerical normalization
+	pdfNodes     = 8  // number of quadrature nodes per interval
+)
+
+func (f *PDF1D) init() {
+	if f.Ps == nil {
+		f.Ps = make([]float64, f.N)
+	}
+
+	if len(f.Ps) == 0 {
+		panic("fit: invalid number of initial parameters")
+	}
+
+	if f.W != nil && len(f.W) != len(f.X) {
+		panic("fit: mismatch length")
+	}
+
+	if !(f.Min < f.Max) {
+		panic("fit: invalid range")
+	}
+
+	f.sumw = 0
+	f.xs = make([]float64, 0, len(f.X))
+	f.ws = make([]float64, 0, len(f.X))
+	for i, x := range f.X {
+		if x < f.Min || f.Max < x {
+			continue
+		}
+		w := 1.0
+		if f.W != nil {
+			w = f.W[i]
+		}
+		f.xs = append(f.xs, x)
+		f.ws = append(f.ws, w)
+		f.sumw += w
+	}
+
+	var (
+		xs = make([]float64, pdfNodes)
+		ws = make([]float64, pdfNodes)
+		dx = (f.Max - f.Min) / pdfIntervals
+	)
+	quad.Legendre{}.FixedLocations(xs, ws, 0, dx)
+	f.nodes = make([]float64, 0, pdfIntervals*pdfNodes)
+	f.weights = make([]float64, 0, pdfIntervals*pdfNodes)
+	for i := range pdfIntervals {
+		x0 := f.Min + float64(i)*dx
+		for j := range xs {
+			f.nodes = append(f.nodes, x0+xs[j])
+			f.weights = append(f.weights, ws[j])
+		}
+	}
+
+	f.fct = func(ps []float64) float64 {
+		norm := f.integral(ps)
+		if !(norm > 0) {
+			return math.Inf(+1)
+		}
+
+		var nll float64
+		for i, x := range f.xs {
+			v := f.F(x, ps)
+			if !(v > 0) {
+				return math.Inf(+1)
+			}
+			nll -= f.ws[i] * math.Log(v)
+		}
+
+		switch {
+		case f.Extended:
+			nll += norm
+		default:
+			nll += f.sumw * math.Log(norm)
+		}
+		return nll
+	}
+}
+
+// integral returns the integral of F over [Min, Max].
+func (f *PDF1D) integral(ps []float64) float64 {
+	var sum float64
+	for i, x := range f.nodes {
+		sum += f.weights[i] * f.F(x, ps)
+	}
+	return sum
+}
+
+// Cost returns the negative log-likelihood of the unbinned fit of f to its
+// sample of events, as minimized by Unbinned1D.
+//
+// The Gaussian constraints of f.Params are not included.
+func (f PDF1D) Cost() Cost {
+	f.init()
+	return f.fct
+}
+
+// Unbinned1D returns the unbinned maximum-likelihood fit of the probability
+// density function f to its sample of events, with optimization method m.
+//
+// The density is normalized numerically over the range of the fit.
+// For weighted events, the log-likelihood of each event is multiplied by its
+// weight: the errors derived from the covariance matrix of the result are
+// then only correct when the weights are close to unity.
+// In case settings is nil, the optimize.DefaultSettingsLocal is used.
+// In case m is nil, the same default optimization method than for Curve1D is used.
+func Unbinned1D(f PDF1D, settings *optimize.Settings, m optimize.Method) (*Result, error) {
+	f.init()
+	return run(objective{f.fct, nil, -1, false}, f.Ps, f.Params, settings, m)
+}
@@ -0,0 +1,112 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fit_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestUnbinned1D(t *testing.T) {
+	const (
+		n     = 500
+		mu    = 1.0
+		sigma = 0.5
+	)
+	var (
+		dist = distuv.Normal{Mu: mu, Sigma: sigma, Src: rand.New(rand.NewSource(1234))}
+		xs   = make([]float64, n)
+	)
+	for i := range xs {
+		xs[i] = dist.Rand()
+	}
+
+	gauss := func(x float64, ps []float64) float64 {
+		v := (x - ps[0]) / ps[1]
+		return math.Exp(-0.5 * v * v)
+	}
+
+	for _, tc := range []struct {
+		name string
+		f    fit.PDF1D
+		want []float64
+	}{
+		{
+			name: "pdf",
+			f: fit.PDF1D{
+				F:  gauss,
+				Ps: []float64{0, 1},
+			},
+			want: []float64{mu, sigma},
+		},
+		{
+			name: "extended",
+			f: fit.PDF1D{
+				F: func(x float64, ps []float64) float64 {
+					return ps[2] / (math.Sqrt(2*math.Pi) * ps[1]) * gauss(x, ps)
+				},
+				Ps:       []float64{0, 1, 100},
+				Extended: true,
+			},
+			want: []float64{mu, sigma, n},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tc.f
+			f.X = xs
+			f.Min = -5
+			f.Max = +5
+			res, err := fit.Unbinned1D(f, nil, &optimize.NelderMead{})
+			if err != nil {
+				t.Fatalf("could not fit: %+v", err)
+			}
+			if err := res.Status.Err(); err != nil {
+				t.Fatalf("invalid fit status: %+v", err)
+			}
+
+			errs := res.Errs()
+			for i, want := range tc.want {
+				if got := res.X[i]; math.Abs(got-want) > 3*errs[i] {
+					t.Fatalf("invalid parameter %d: got=%v ± %v, want=%v", i, got, errs[i], want)
+				}
+			}
+
+			// error on the mean of a Gaussian sample.
+			if got, want := errs[0], sigma/math.Sqrt(n); math.Abs(got-want) > 0.1*want {
+				t.Fatalf("invalid error on mean: got=%v, want=%v", got, want)
+			}
+			if tc.f.Extended {
+				if got, want := errs[2], math.Sqrt(n); math.Abs(got-want) > 0.1*want {
+					t.Fatalf("invalid error on yield: got=%v, want=%v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnbinned1DWeights(t *testing.T) {
+	// a weight of 2 for an event is equivalent to 2 events.
+	var (
+		f1 = fit.PDF1D{
+			F:   func(x float64, ps []float64) float64 { return math.Exp(-ps[0] * x) },
+			Ps:  []float64{1},
+			Min: 0, Max: 10,
+			X: []float64{0.5, 1, 1, 2, 3, 3, 11},
+		}
+		f2 = f1
+	)
+	f2.X = []float64{0.5, 1, 2, 3, 11}
+	f2.W = []float64{1, 2, 1, 2, 3}
+
+	ps := []float64{0.7}
+	if got, want := f2.Cost()(ps), f1.Cost()(ps); math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid weighted cost: got=%v, want=%v", got, want)
+	}
+}
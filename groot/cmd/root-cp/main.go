@@ -11,6 +11,18 @@
 //	$> root-cp f.root out.root
 //	$> root-cp f1.root f2.root f3.root out.root
 //	$> root-cp f1.root:hist.* f2.root:h2 out.root
+//	$> root-cp -k 'dir1/*,tree' f.root out.root
+//	$> root-cp -c 505 zlib.root zstd.root
+//
+// Directories selected with a REGEXP or with a key pattern are copied
+// with their whole content.
+//
+// options:
+//
+//	-c int
+//	  	compression of the output ROOT file, as 100*algorithm+level (e.g. 505 for ZSTD, level 5) (default -1)
+//	-k string
+//	  	comma-separated list of patterns of keys to copy (default=all keys)
 package main // import "go-hep.org/x/hep/groot/cmd/root-cp"
 
 import (
@@ -18,6 +30,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"go-hep.org/x/hep/groot/rcmd"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
@@ -29,6 +42,11 @@ func main() {
 	log.SetFlags(0)
 	log.SetOutput(os.Stderr)
 
+	var (
+		compr = flag.Int("c", -1, "compression of the output ROOT file, as 100*algorithm+level (e.g. 505 for ZSTD, level 5)")
+		keys  = flag.String("k", "", "comma-separated list of patterns of keys to copy (default=all keys)")
+	)
+
 	flag.Usage = func() {
 		fmt.Fprintf(
 			os.Stderr,
@@ -38,6 +56,8 @@ ex:
  $> root-cp f.root out.root
  $> root-cp f1.root f2.root f3.root out.root
  $> root-cp f1.root:hist.* f2.root:h2 out.root
+ $> root-cp -k 'dir1/*,tree' f.root out.root
+ $> root-cp -c 505 zlib.root zstd.root
 
 options:
 `,
@@ -56,7 +76,15 @@ options:
 	dst := flag.Arg(flag.NArg() - 1)
 	srcs := flag.Args()[:flag.NArg()-1]
 
-	err := rcmd.Copy(dst, srcs)
+	var opts []rcmd.CopyOption
+	if *compr >= 0 {
+		opts = append(opts, rcmd.CopyCompression(int32(*compr)))
+	}
+	if *keys != "" {
+		opts = append(opts, rcmd.CopyKeys(strings.Split(*keys, ",")...))
+	}
+
+	err := rcmd.Copy(dst, srcs, opts...)
 	if err != nil {
 		log.Fatal(err)
 	}
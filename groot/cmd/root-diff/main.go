@@ -9,6 +9,8 @@
 //
 //	$> root-diff ./ref.root ./chk.root
 //	$> root-diff -k=key1,tree,my-tree ./ref.root ./chk.root
+//	$> root-diff -rtol=1e-6 -exclude='Evt*,Run' ./ref.root ./chk.root
+//	$> root-diff -json ./ref.root ./chk.root > report.json
 //
 //	$> root-diff -h
 //	Usage: root-diff [options] a.root b.root
@@ -17,8 +19,28 @@
 //	 $> root-diff ./testdata/small-flat-tree.root ./testdata/small-flat-tree.root
 //
 //	options:
+//	  -atol float
+//	    	absolute tolerance for floating point comparisons
+//	  -exclude string
+//	    	comma-separated list of patterns of branches to exclude from the comparison
+//	  -include string
+//	    	comma-separated list of patterns of branches to compare (default=all branches)
+//	  -json
+//	    	write a machine-readable JSON report
 //	  -k string
 //	    	comma-separated list of keys to inspect and compare (default=all common keys)
+//	  -rtol float
+//	    	relative tolerance for floating point comparisons
+//
+// Floating point values ref and chk are considered equal when:
+//
+//	|ref - chk| <= atol + rtol*|ref|
+//
+// Branch patterns follow the syntax of path.Match.
+//
+// In JSON mode, root-diff compares all the requested keys and reports, for
+// each differing branch of a tree, the first entry at which the values
+// diverge and the number of differing entries.
 package main // import "go-hep.org/x/hep/groot/cmd/root-diff"
 
 import (
@@ -35,7 +57,14 @@ import (
 )
 
 func main() {
-	keysFlag := flag.String("k", "", "comma-separated list of keys to inspect and compare (default=all common keys)")
+	var (
+		keysFlag = flag.String("k", "", "comma-separated list of keys to inspect and compare (default=all common keys)")
+		rtolFlag = flag.Float64("rtol", 0, "relative tolerance for floating point comparisons")
+		atolFlag = flag.Float64("atol", 0, "absolute tolerance for floating point comparisons")
+		inclFlag = flag.String("include", "", "comma-separated list of patterns of branches to compare (default=all branches)")
+		exclFlag = flag.String("exclude", "", "comma-separated list of patterns of branches to exclude from the comparison")
+		jsonFlag = flag.Bool("json", false, "write a machine-readable JSON report")
+	)
 
 	log.SetPrefix("root-diff: ")
 	log.SetFlags(0)
@@ -59,13 +88,24 @@ options:
 		log.Fatalf("need 2 input ROOT files to compare")
 	}
 
-	err := rootdiff(flag.Arg(0), flag.Arg(1), *keysFlag)
+	opts := []rcmd.DiffOption{
+		rcmd.DiffTolerance(*rtolFlag, *atolFlag),
+		rcmd.DiffJSON(*jsonFlag),
+	}
+	if *inclFlag != "" {
+		opts = append(opts, rcmd.DiffInclude(strings.Split(*inclFlag, ",")...))
+	}
+	if *exclFlag != "" {
+		opts = append(opts, rcmd.DiffExclude(strings.Split(*exclFlag, ",")...))
+	}
+
+	err := rootdiff(flag.Arg(0), flag.Arg(1), *keysFlag, opts...)
 	if err != nil {
 		log.Fatalf("%+v", err)
 	}
 }
 
-func rootdiff(ref, chk string, keysFlag string) error {
+func rootdiff(ref, chk string, keysFlag string, opts ...rcmd.DiffOption) error {
 	fref, err := groot.Open(ref)
 	if err != nil {
 		return fmt.Errorf("could not open reference file: %w", err)
@@ -83,7 +123,7 @@ func rootdiff(ref, chk string, keysFlag string) error {
 		keys = strings.Split(keysFlag, ",")
 	}
 
-	err = rcmd.Diff(nil, fchk, fref, keys)
+	err = rcmd.Diff(nil, fref, fchk, keys, opts...)
 	if err != nil {
 		return fmt.Errorf("files differ: %w", err)
 	}
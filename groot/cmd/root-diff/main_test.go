@@ -4,7 +4,11 @@
 
 package main
 
-import "testing"
+import (
+	"testing"
+
+	"go-hep.org/x/hep/groot/rcmd"
+)
 
 func TestROOTDiff(t *testing.T) {
 	const allkeys = ""
@@ -13,3 +17,15 @@ func TestROOTDiff(t *testing.T) {
 		t.Fatalf("%+v", err)
 	}
 }
+
+func TestROOTDiffOptions(t *testing.T) {
+	err := rootdiff(
+		"../../testdata/small-flat-tree.root", "../../testdata/small-flat-tree.root", "tree",
+		rcmd.DiffTolerance(1e-6, 1e-12),
+		rcmd.DiffInclude("Float*", "Slice*"),
+		rcmd.DiffExclude("SliceInt64"),
+	)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+}
@@ -28,20 +28,40 @@
 //	[000][SliceInt64]: []
 //	[...]
 //
+//	$> root-dump -branches='Int*,Str' -entries=10:12 -format=table ./testdata/small-flat-tree.root
+//	>>> file[./testdata/small-flat-tree.root]
+//	key[000]: tree;1 "my tree title" (TTree)
+//	entry   Int32   Int64   Str
+//	10      10      10      evt-010
+//	11      11      11      evt-011
+//
+//	$> root-dump -branches='Int*,Str' -entries=:2 -format=jsonl ./testdata/small-flat-tree.root
+//	{"entry":0,"Int32":0,"Int64":0,"Str":"evt-000"}
+//	{"entry":1,"Int32":1,"Int64":1,"Str":"evt-001"}
+//
 //	$> root-dump -h
 //	Usage: root-dump [options] f0.root [f1.root [...]]
 //
 //	ex:
 //	 $> root-dump ./testdata/small-flat-tree.root
 //	 $> root-dump -deep=0 ./testdata/small-flat-tree.root
+//	 $> root-dump -branches='Int*,Str' -entries=10:20 -format=csv ./testdata/small-flat-tree.root
 //
 //	options:
+//	  -branches string
+//	    	comma-separated list of patterns of branches to dump (default=all branches)
 //	  -cpu-profile string
 //	    	path to CPU profile output file
 //	  -deep
 //	    	enable deep dumping of values (including Trees' entries) (default true)
+//	  -entries string
+//	    	range of entries to dump, as beg:end (default=all entries)
+//	  -format string
+//	    	output format of Trees' entries (text, table, jsonl, csv) (default "text")
 //	  -name string
 //	    	regex of object names to dump
+//
+// With the jsonl and csv formats, only the entries of Trees are displayed.
 package main // import "go-hep.org/x/hep/groot/cmd/root-dump"
 
 import (
@@ -53,6 +73,8 @@ import (
 	"os"
 	"regexp"
 	"runtime/pprof"
+	"strconv"
+	"strings"
 
 	"go-hep.org/x/hep/groot/rcmd"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
@@ -63,6 +85,9 @@ var (
 	deepFlag = flag.Bool("deep", true, "enable deep dumping of values (including Trees' entries)")
 	nameFlag = flag.String("name", "", "regex of object names to dump")
 	cpuFlag  = flag.String("cpu-profile", "", "path to CPU profile output file")
+	brsFlag  = flag.String("branches", "", "comma-separated list of patterns of branches to dump (default=all branches)")
+	evtFlag  = flag.String("entries", "", "range of entries to dump, as beg:end (default=all entries)")
+	fmtFlag  = flag.String("format", "text", "output format of Trees' entries (text, table, jsonl, csv)")
 )
 
 func main() {
@@ -75,6 +100,7 @@ func main() {
 ex:
  $> root-dump ./testdata/small-flat-tree.root
  $> root-dump -deep=0 ./testdata/small-flat-tree.root
+ $> root-dump -branches='Int*,Str' -entries=10:20 -format=csv ./testdata/small-flat-tree.root
 
 options:
 `,
@@ -93,6 +119,11 @@ options:
 		log.Fatalf("need at least one input ROOT file")
 	}
 
+	opts, err := dumpOptions(*brsFlag, *evtFlag, *fmtFlag)
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+
 	if *cpuFlag != "" {
 		f, err := os.Create(*cpuFlag)
 		if err != nil {
@@ -109,7 +140,7 @@ options:
 	defer out.Flush()
 
 	for _, fname := range flag.Args() {
-		err := dump(out, fname, *deepFlag)
+		err := dump(out, fname, *deepFlag, opts...)
 		if err != nil {
 			out.Flush()
 			log.Fatalf("error dumping file %q: %+v", fname, err)
@@ -117,9 +148,71 @@ options:
 	}
 }
 
-func dump(w io.Writer, fname string, deep bool) error {
-	fmt.Fprintf(w, ">>> file[%s]\n", fname)
-	return rcmd.Dump(w, fname, deep, match)
+func dump(w io.Writer, fname string, deep bool, opts ...rcmd.DumpOption) error {
+	switch *fmtFlag {
+	case "jsonl", "csv":
+		// only display entries.
+	default:
+		fmt.Fprintf(w, ">>> file[%s]\n", fname)
+	}
+	return rcmd.Dump(w, fname, deep, match, opts...)
+}
+
+func dumpOptions(branches, entries, format string) ([]rcmd.DumpOption, error) {
+	var opts []rcmd.DumpOption
+
+	if branches != "" {
+		opts = append(opts, rcmd.DumpBranches(strings.Split(branches, ",")...))
+	}
+
+	if entries != "" {
+		beg, end, err := parseRange(entries)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entries range %q: %w", entries, err)
+		}
+		opts = append(opts, rcmd.DumpEntries(beg, end))
+	}
+
+	switch format {
+	case "text":
+		opts = append(opts, rcmd.DumpAs(rcmd.DumpText))
+	case "table":
+		opts = append(opts, rcmd.DumpAs(rcmd.DumpTable))
+	case "jsonl":
+		opts = append(opts, rcmd.DumpAs(rcmd.DumpJSONL))
+	case "csv":
+		opts = append(opts, rcmd.DumpAs(rcmd.DumpCSV))
+	default:
+		return nil, fmt.Errorf("invalid output format %q", format)
+	}
+
+	return opts, nil
+}
+
+// parseRange parses a beg:end range of entries.
+// A missing beg value selects the first entry, a missing end value
+// selects all the entries after beg.
+func parseRange(v string) (beg, end int64, err error) {
+	i := strings.Index(v, ":")
+	if i < 0 {
+		return 0, 0, fmt.Errorf("missing ':' separator")
+	}
+
+	beg, end = 0, -1
+	if s := v[:i]; s != "" {
+		beg, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not parse beginning of range: %w", err)
+		}
+	}
+	if s := v[i+1:]; s != "" {
+		end, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("could not parse end of range: %w", err)
+		}
+	}
+
+	return beg, end, nil
 }
 
 var reName *regexp.Regexp
@@ -41,3 +41,62 @@ func TestROOTDump(t *testing.T) {
 		})
 	}
 }
+
+func TestROOTDumpOptions(t *testing.T) {
+	const deep = true
+	opts, err := dumpOptions("one,three", "1:3", "table")
+	if err != nil {
+		t.Fatalf("could not create dump options: %+v", err)
+	}
+
+	o := new(bytes.Buffer)
+	err = dump(o, "../../testdata/simple.root", deep, opts...)
+	if err != nil {
+		t.Fatalf("could not dump: %+v", err)
+	}
+
+	want := `>>> file[../../testdata/simple.root]
+key[000]: tree;1 "fake data" (TTree)
+entry   one     three
+1       2       dos
+2       3       tres
+`
+	if got := o.String(); got != want {
+		t.Fatalf("error:\n%s\n", diff.Format(got, want))
+	}
+
+	for _, tc := range []struct {
+		entries string
+		format  string
+	}{
+		{entries: "1", format: "text"},
+		{entries: "a:2", format: "text"},
+		{entries: "1:b", format: "text"},
+		{entries: "1:2", format: "yaml"},
+	} {
+		_, err := dumpOptions("", tc.entries, tc.format)
+		if err == nil {
+			t.Fatalf("expected an error for entries=%q, format=%q", tc.entries, tc.format)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	for _, tc := range []struct {
+		v        string
+		beg, end int64
+	}{
+		{v: ":", beg: 0, end: -1},
+		{v: "2:", beg: 2, end: -1},
+		{v: ":5", beg: 0, end: 5},
+		{v: "2:5", beg: 2, end: 5},
+	} {
+		beg, end, err := parseRange(tc.v)
+		if err != nil {
+			t.Fatalf("could not parse %q: %+v", tc.v, err)
+		}
+		if beg != tc.beg || end != tc.end {
+			t.Fatalf("invalid range for %q: got=[%d, %d), want=[%d, %d)", tc.v, beg, end, tc.beg, tc.end)
+		}
+	}
+}
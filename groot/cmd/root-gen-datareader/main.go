@@ -34,6 +34,37 @@
 //		ROOT_SliceFloat32 []float32   `groot:"SliceFloat32[N]"`
 //		ROOT_SliceFloat64 []float64   `groot:"SliceFloat64[N]"`
 //	}
+//
+// With the -groups flag, root-gen-datareader also presents the jagged
+// branches sharing the same leaf-count (e.g. pt[n], eta[n], phi[n]) as a
+// single collection of generated structs:
+//
+//	$> root-gen-datareader -t tree -groups testdata/small-flat-tree.root
+//	[...]
+//	// Slice is an element of the collection of branches whose size is
+//	// given by the "N" branch.
+//	type Slice struct {
+//		Int32   int32   // SliceInt32
+//		Int64   int64   // SliceInt64
+//		[...]
+//	}
+//
+//	// Slices returns the Slice collection of the current entry.
+//	func (d *Data) Slices() []Slice {
+//		sli := make([]Slice, len(d.ROOT_SliceInt32))
+//		for i := range sli {
+//			sli[i] = Slice{
+//				Int32: d.ROOT_SliceInt32[i],
+//				Int64: d.ROOT_SliceInt64[i],
+//				[...]
+//			}
+//		}
+//		return sli
+//	}
+//
+// The name of the generated struct is the longest prefix shared by all the
+// branches of the collection (up to an underscore or a camel-case boundary),
+// or the name of the leaf-count stripped of its leading 'n'.
 package main // import "go-hep.org/x/hep/groot/cmd/root-gen-datareader"
 
 import (
@@ -47,6 +78,7 @@ import (
 	"reflect"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/riofs"
@@ -63,6 +95,7 @@ func main() {
 		pkgName    = flag.String("p", "event", "name of the package where to generate the data model")
 		outName    = flag.String("o", "", "name of the file where to store the generated data model (STDOUT)")
 		dataReader = flag.Bool("reader", false, "generate data reader code")
+		groups     = flag.Bool("groups", false, "group jagged branches sharing the same leaf-count into slices of structs")
 		verbose    = flag.Bool("v", false, "enable verbose mode")
 	)
 
@@ -75,7 +108,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	ctx := newContext(*pkgName, flag.Arg(0), *treeName, *dataReader, *verbose)
+	ctx := newContext(*pkgName, flag.Arg(0), *treeName, *dataReader, *groups, *verbose)
 
 	var o io.Writer = os.Stdout
 	if *outName != "" {
@@ -149,6 +182,9 @@ func process(w io.Writer, ctx *Context) error {
 				},
 			)
 			ctx.checkType(rv.Type().Elem())
+			if cnt := leafCount(tree, rvar); cnt != "" {
+				ctx.addJagged(cnt, rvar.Name, rv.Type().Elem())
+			}
 
 		default:
 			ctx.DataReader.Fields = append(
@@ -165,6 +201,10 @@ func process(w io.Writer, ctx *Context) error {
 	}
 	delete(ctx.Defs, "DataReader")
 
+	if ctx.GenGroups {
+		ctx.genGroups()
+	}
+
 	err = ctx.genCode(w)
 	if err != nil {
 		return fmt.Errorf("could not generate reader code: %w", err)
@@ -199,12 +239,32 @@ type Context struct {
 	DataReader    *StructDef
 	Defs          map[string]*StructDef
 	GenDataReader bool
+	GenGroups     bool
+	Groups        []*GroupDef
 	File          string
 	Tree          string
 	Verbose       bool
+
+	jagged []jaggedDef // jagged branches, in tree order
+}
+
+// GroupDef models a collection of jagged branches sharing the same
+// leaf-count, as a slice of Go structs.
+// Each field of the struct is bound to the Data field of its branch.
+type GroupDef struct {
+	Name   string // name of the Go struct
+	Func   string // name of the Data method returning the collection
+	Count  string // name of the leaf-count
+	Fields []FieldDef
 }
 
-func newContext(pkg, file, tree string, dataReader, verbose bool) *Context {
+type jaggedDef struct {
+	count  string
+	branch string
+	elem   reflect.Type
+}
+
+func newContext(pkg, file, tree string, dataReader, groups, verbose bool) *Context {
 	ctx := &Context{
 		Package: pkg,
 		Imports: make(map[string]int),
@@ -215,6 +275,7 @@ func newContext(pkg, file, tree string, dataReader, verbose bool) *Context {
 			},
 		},
 		GenDataReader: dataReader,
+		GenGroups:     groups,
 		File:          file,
 		Tree:          tree,
 		Verbose:       verbose,
@@ -277,6 +338,25 @@ type Data struct {
 {{ end}}}
 {{end}}
 
+{{range .Groups}}
+// {{.Name}} is an element of the collection of branches whose size is
+// given by the {{printf "%q" .Count}} branch.
+type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.Type}} // {{.Tag}}
+{{end}}}
+
+// {{.Func}} returns the {{.Name}} collection of the current entry.
+func (d *Data) {{.Func}}() []{{.Name}} {
+	sli := make([]{{.Name}}, len(d.{{(index .Fields 0).VarName}}))
+	for i := range sli {
+		sli[i] = {{.Name}}{
+{{range .Fields}}			{{.Name}}: d.{{.VarName}}[i],
+{{end}}		}
+	}
+	return sli
+}
+{{end}}
+
 {{if .GenDataReader}}
 {{with .DataReader}}
 type DataReader struct {
@@ -334,3 +414,138 @@ func (ctx *Context) checkType(typ reflect.Type) {
 		ctx.Imports[pkg]++
 	}
 }
+
+// leafCount returns the name of the leaf-count of the provided read-var,
+// if any.
+func leafCount(tree rtree.Tree, rvar rtree.ReadVar) string {
+	b := tree.Branch(rvar.Name)
+	if b == nil {
+		return ""
+	}
+	leaf := b.Leaf(rvar.Leaf)
+	if leaf == nil || leaf.LeafCount() == nil {
+		return ""
+	}
+	return leaf.LeafCount().Name()
+}
+
+func (ctx *Context) addJagged(count, branch string, elem reflect.Type) {
+	ctx.jagged = append(ctx.jagged, jaggedDef{
+		count:  count,
+		branch: branch,
+		elem:   elem,
+	})
+}
+
+// genGroups creates the collections of jagged branches sharing the same
+// leaf-count.
+// Leaf-counts with only one jagged branch are left alone.
+func (ctx *Context) genGroups() {
+	var (
+		counts []string
+		groups = make(map[string][]jaggedDef)
+		names  = make(map[string]bool)
+	)
+	for _, j := range ctx.jagged {
+		if _, dup := groups[j.count]; !dup {
+			counts = append(counts, j.count)
+		}
+		groups[j.count] = append(groups[j.count], j)
+	}
+	for name := range ctx.Defs {
+		names[name] = true
+	}
+	names["Data"] = true
+	names["DataReader"] = true
+
+	for _, count := range counts {
+		js := groups[count]
+		if len(js) < 2 {
+			continue
+		}
+		branches := make([]string, len(js))
+		for i, j := range js {
+			branches[i] = j.branch
+		}
+		name, prefix := groupName(count, branches)
+		for names[name] || names[name+"s"] {
+			name += "Elem"
+		}
+		names[name] = true
+		names[name+"s"] = true
+
+		grp := &GroupDef{
+			Name:   name,
+			Func:   name + "s",
+			Count:  count,
+			Fields: make([]FieldDef, len(js)),
+		}
+		for i, j := range js {
+			grp.Fields[i] = FieldDef{
+				Name:    goIdent(strings.TrimPrefix(j.branch, prefix)),
+				Type:    j.elem.String(),
+				VarName: goName(j.branch),
+				Tag:     j.branch,
+			}
+		}
+		ctx.printf("group %q: %d branches (count=%q)", grp.Name, len(grp.Fields), count)
+		ctx.Groups = append(ctx.Groups, grp)
+	}
+}
+
+// groupName returns the name of the Go struct modeling the provided jagged
+// branches, and the prefix of the branch names to strip to create the
+// names of its fields.
+func groupName(count string, branches []string) (name, prefix string) {
+	prefix = branches[0]
+	for _, b := range branches[1:] {
+		n := 0
+		for n < len(prefix) && n < len(b) && prefix[n] == b[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+
+	if i := strings.LastIndex(prefix, "_"); i > 0 {
+		return goIdent(prefix[:i]), prefix[:i+1]
+	}
+
+	// find the longest camel-case boundary shared by all branches.
+	for n := len(prefix); n > 0; n-- {
+		ok := true
+		for _, b := range branches {
+			if len(b) <= n || !unicode.IsUpper(rune(b[n])) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return goIdent(prefix[:n]), prefix[:n]
+		}
+	}
+
+	name = count
+	if len(name) > 1 && (name[0] == 'n' || name[0] == 'N') {
+		if c := rune(name[1]); unicode.IsUpper(c) || c == '_' {
+			name = strings.TrimLeft(name[1:], "_")
+		}
+	}
+	if name == count {
+		name += "Elem"
+	}
+	return goIdent(name), ""
+}
+
+// goIdent returns a valid exported Go identifier from the provided name.
+func goIdent(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, strings.TrimLeft(name, "_"))
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "X" + name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
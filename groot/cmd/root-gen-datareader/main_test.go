@@ -16,9 +16,15 @@ func TestGenerate(t *testing.T) {
 	newCtx := func(file, tree string) *Context {
 		const (
 			gen     = true
+			groups  = false
 			verbose = false
 		)
-		return newContext("event", file, tree, gen, verbose)
+		return newContext("event", file, tree, gen, groups, verbose)
+	}
+	newGrp := func(file, tree string) *Context {
+		ctx := newCtx(file, tree)
+		ctx.GenGroups = true
+		return ctx
 	}
 
 	for _, tc := range []struct {
@@ -33,6 +39,10 @@ func TestGenerate(t *testing.T) {
 			ctx:  newCtx("../../testdata/small-flat-tree.root", "tree"),
 			want: "testdata/small-flat-tree.root.txt",
 		},
+		{
+			ctx:  newGrp("../../testdata/small-flat-tree.root", "tree"),
+			want: "testdata/small-flat-tree-groups.root.txt",
+		},
 		{
 			ctx:  newCtx("../../testdata/small-evnt-tree-fullsplit.root", "tree"),
 			want: "testdata/small-evnt-tree.root.txt",
@@ -49,8 +59,12 @@ func TestGenerate(t *testing.T) {
 			ctx:  newCtx("../../testdata/leaves.root", "tree"),
 			want: "testdata/leaves.root.txt",
 		},
+		{
+			ctx:  newGrp("../../testdata/leaves.root", "tree"),
+			want: "testdata/leaves-groups.root.txt",
+		},
 	} {
-		t.Run(tc.ctx.File, func(t *testing.T) {
+		t.Run(tc.want, func(t *testing.T) {
 			o := new(strings.Builder)
 			err := process(o, tc.ctx)
 			if err != nil {
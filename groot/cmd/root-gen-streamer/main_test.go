@@ -32,8 +32,13 @@ func TestGenerate(t *testing.T) {
 			types: []string{"Event", "HLV", "Particle"},
 			want:  "testdata/rdatatest.txt",
 		},
+		{
+			pkg:   "go-hep.org/x/hep/groot/internal/rdatatest",
+			types: []string{"T3", "T4", "TObject"},
+			want:  "testdata/rdatatest-nested.txt",
+		},
 	} {
-		t.Run(tc.pkg, func(t *testing.T) {
+		t.Run(tc.want, func(t *testing.T) {
 			buf := new(bytes.Buffer)
 			err := generate(buf, tc.pkg, tc.types)
 			if err != nil {
@@ -83,23 +88,43 @@ key[000]: data;1 "" (go_hep_org::x::hep::groot::internal::rdatatest::ArrBuiltins
 		{
 			name:  "struct-t1",
 			pkg:   "go-hep.org/x/hep/groot/internal/rdatatest",
-			types: []string{"HLV", "T1"}, // FIXME(sbinet): only select T1 and let root-gen-streamer pick-up HLV
+			types: []string{"T1"},
 			out:   "../../internal/rdatatest/pkg_gen.go",
 			tmpl:  "NewT1",
 			want: `>>> file[testdata/out.root]
 key[000]: data;1 "" (go_hep_org::x::hep::groot::internal::rdatatest::T1) => &{hello {1 2 3 4}}
 `,
 		},
-		//		{
-		//			name:  "struct-t2",
-		//			pkg:   "go-hep.org/x/hep/groot/internal/rdatatest",
-		//			types: []string{"HLV", "T2"}, // FIXME(sbinet): only select T2 and let root-gen-streamer pick-up HLV
-		//			out:   "../../internal/rdatatest/pkg_gen.go",
-		//			tmpl:  "NewT2",
-		//			want: `>>> file[testdata/out.root]
-		//key[000]: data;1 "" (go_hep_org::x::hep::groot::internal::rdatatest::T1) => &{hello {1 2 3 4}}
-		//`,
-		//		},
+		{
+			name:  "struct-t2",
+			pkg:   "go-hep.org/x/hep/groot/internal/rdatatest",
+			types: []string{"T2"},
+			out:   "../../internal/rdatatest/pkg_gen.go",
+			tmpl:  "NewT2",
+			want: `>>> file[testdata/out.root]
+key[000]: data;1 "" (go_hep_org::x::hep::groot::internal::rdatatest::T2) => &{hello [{1 2 3 4} {-1 -2 -3 -4}]}
+`,
+		},
+		{
+			name:  "struct-t3",
+			pkg:   "go-hep.org/x/hep/groot/internal/rdatatest",
+			types: []string{"T3"},
+			out:   "../../internal/rdatatest/pkg_gen.go",
+			tmpl:  "NewT3",
+			want: `>>> file[testdata/out.root]
+key[000]: data;1 "" (go_hep_org::x::hep::groot::internal::rdatatest::T3) => &{hello [{1 2 3 4} {-1 -2 -3 -4}] [{e- 11 {1 2 3 4}} {mu+ -13 {5 6 7 8}}] [[1 2 3] [4] [5 6]] map[one:1 three:3 two:2] map[1:{1 2 3 4} 2:{5 6 7 8}] map[a:[a1 a2] b:[b1]]}
+`,
+		},
+		{
+			name:  "struct-t4",
+			pkg:   "go-hep.org/x/hep/groot/internal/rdatatest",
+			types: []string{"T4"},
+			out:   "../../internal/rdatatest/pkg_gen.go",
+			tmpl:  "NewT4",
+			want: `>>> file[testdata/out.root]
+key[000]: data;1 "" (go_hep_org::x::hep::groot::internal::rdatatest::T4) => &{{1 2 3 4} {hello {1 2 3 4}} 42}
+`,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			os.Remove(tc.out)
@@ -0,0 +1,142 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-grep scans a tree for the entries matching a selection and displays
+// the index and the values of selected branches of each matching entry.
+//
+// Usage: root-grep [options] selection file.root
+//
+// ex:
+//
+//	$> root-grep 'one == 3 || two < 2' ./testdata/simple.root
+//	entry   one     two
+//	0       1       1.1
+//	2       3       3.3
+//
+//	$> root-grep -b 'Int32,Str' -format=jsonl 'SliceFloat64[2] == 93' ./testdata/small-flat-tree.root
+//	{"entry":93,"Int32":93,"Str":"evt-093"}
+//
+// Selections are TTree::Draw-like expressions, using the Go syntax, where
+// identifiers refer to branches of the tree.
+// Variable-length arrays can be indexed (out of range elements evaluate to
+// NaN) and a few functions from the math package are available
+// (abs, sqrt, pow, exp, log, min, max, ...).
+// Entries for which the selection evaluates to 0 or NaN do not match.
+//
+// By default, the branches used by the selection are displayed.
+//
+// As grep(1), root-grep exits with status 0 if at least one entry matched,
+// 1 if no entry matched and 2 if an error occurred.
+//
+// options:
+//
+//	-b string
+//	  	comma-separated list of patterns of branches to display (default=branches of the selection)
+//	-format string
+//	  	output format of matching entries (text, table, jsonl, csv) (default "table")
+//	-n int
+//	  	stop after n matching entries (default=all entries)
+//	-t string
+//	  	name of the tree to scan (default "tree")
+package main // import "go-hep.org/x/hep/groot/cmd/root-grep"
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+)
+
+var (
+	fset = flag.NewFlagSet("grep", flag.ContinueOnError)
+
+	treeFlag   = fset.String("t", "tree", "name of the tree to scan")
+	branchFlag = fset.String("b", "", "comma-separated list of patterns of branches to display (default=branches of the selection)")
+	maxFlag    = fset.Int64("n", 0, "stop after n matching entries (default=all entries)")
+	fmtFlag    = fset.String("format", "table", "output format of matching entries (text, table, jsonl, csv)")
+
+	usage = `Usage: root-grep [options] selection file.root
+
+ex:
+ $> root-grep 'one == 3 || two < 2' ./testdata/simple.root
+ $> root-grep -t tree -b 'evtNum,run,n*' -n 1 'evtNum == 123456 && run == 300000' ./f.root
+
+options:
+`
+)
+
+func main() {
+	log.SetPrefix("root-grep: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	fset.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 2
+	}
+
+	if fset.NArg() != 2 {
+		fmt.Fprintf(stderr, "error: you need to give a selection and a ROOT file\n\n")
+		fset.Usage()
+		return 2
+	}
+
+	var format rcmd.DumpFormat
+	switch *fmtFlag {
+	case "text":
+		format = rcmd.DumpText
+	case "table":
+		format = rcmd.DumpTable
+	case "jsonl":
+		format = rcmd.DumpJSONL
+	case "csv":
+		format = rcmd.DumpCSV
+	default:
+		log.Printf("invalid output format %q", *fmtFlag)
+		return 2
+	}
+
+	opts := []rcmd.GrepOption{
+		rcmd.GrepMax(*maxFlag),
+		rcmd.GrepAs(format),
+	}
+	if *branchFlag != "" {
+		opts = append(opts, rcmd.GrepBranches(strings.Split(*branchFlag, ",")...))
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	n, err := rcmd.Grep(out, fset.Arg(1), *treeFlag, fset.Arg(0), opts...)
+	if err != nil {
+		out.Flush()
+		log.Printf("%+v", err)
+		return 2
+	}
+
+	if n == 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestROOTGrep(t *testing.T) {
+	tmp := t.TempDir()
+
+	for _, tc := range []struct {
+		args []string
+		rc   int
+		want string
+	}{
+		{
+			args: []string{"-b=", "-n=0", "-format=table", "one == 3 || two < 2", "../../testdata/simple.root"},
+			want: `entry   one     two
+0       1       1.1
+2       3       3.3
+`,
+		},
+		{
+			args: []string{"-b=Int32,Str", "-n=1", "-format=jsonl", "SliceFloat64[2] == 93 || Int32 > 95", "../../testdata/small-flat-tree.root"},
+			want: `{"entry":93,"Int32":93,"Str":"evt-093"}
+`,
+		},
+		{
+			args: []string{"-b=", "-n=0", "-format=csv", "one > 10", "../../testdata/simple.root"},
+			rc:   1,
+		},
+		{
+			args: []string{"-format=table", "one > 10", filepath.Join(tmp, "not-there.root")},
+			rc:   2,
+		},
+		{
+			args: []string{"-format=xml", "one > 1", "../../testdata/simple.root"},
+			rc:   2,
+		},
+		{
+			args: []string{"-format=table", "../../testdata/simple.root"},
+			rc:   2,
+		},
+		{
+			args: []string{"-h"},
+			rc:   0,
+		},
+		{
+			args: []string{"-=3"},
+			rc:   2,
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			rc := run(out, out, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-grep %q: got=%d, want=%d\n%s",
+					tc.args, rc, tc.rc, out.String(),
+				)
+			}
+			if tc.want == "" {
+				return
+			}
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid root-grep output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
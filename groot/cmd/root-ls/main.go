@@ -37,6 +37,44 @@
 //	  SliceUInt64  "SliceInt64[N]/l"    TBranch
 //	  SliceFloat32 "SliceFloat32[N]/F"  TBranch
 //	  SliceFloat64 "SliceFloat64[N]/D"  TBranch
+//
+//	$> root-ls -class='TH1*,TH2*' ./testdata/dirs-6.14.00.root
+//	=== [./testdata/dirs-6.14.00.root] ===
+//	version: 61400
+//	TDirectoryFile   dir1    dir1    (cycle=1)
+//	  TDirectoryFile dir11   dir11   (cycle=1)
+//	    TH1F         h1      h1      (cycle=1)
+//
+//	$> root-ls -json ./testdata/simple.root
+//	{
+//	  "file": "./testdata/simple.root",
+//	  "version": 60600,
+//	  "keys": [
+//	    {
+//	      "name": "tree",
+//	      "class": "TTree",
+//	      "title": "fake data",
+//	      "cycle": 1,
+//	      "nbytes": 515,
+//	      "objlen": 1743,
+//	      "entries": 4,
+//	      "branches": [
+//	        {
+//	          "name": "one",
+//	          "title": "one/I",
+//	          "class": "TBranch"
+//	        },
+//	        [...]
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// Name and class filters take comma-separated lists of patterns, following
+// the syntax of path.Match.
+// Directories are displayed when they contain keys matching the filters.
+//
+// In JSON mode, root-ls writes one JSON document per input ROOT file.
 package main // import "go-hep.org/x/hep/groot/cmd/root-ls"
 
 import (
@@ -48,6 +86,7 @@ import (
 	"log"
 	"os"
 	"runtime/pprof"
+	"strings"
 
 	"go-hep.org/x/hep/groot/rcmd"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
@@ -61,12 +100,16 @@ var (
 	siFlag   = fset.Bool("sinfos", false, "print StreamerInfos")
 	treeFlag = fset.Bool("t", false, "print Tree(s) (recursively)")
 	cpuFlag  = fset.String("cpu-profile", "", "path to CPU profile output file")
+	jsonFlag = fset.Bool("json", false, "print a JSON description of the file(s)")
+	nameFlag = fset.String("name", "", "comma-separated list of patterns of key names to display")
+	clsFlag  = fset.String("class", "", "comma-separated list of patterns of key classes to display")
 
 	usage = `Usage: root-ls [options] file1.root [file2.root [...]]
 
 ex:
  $> root-ls ./testdata/graphs.root
  $> root-ls -t -sinfos ./testdata/graphs.root
+ $> root-ls -json -class='TH1*' ./testdata/dirs-6.14.00.root
 
 options:
 `
@@ -115,10 +158,17 @@ func run(stdout, stderr io.Writer, args []string) int {
 	opts := []rcmd.ListOption{
 		rcmd.ListStreamers(*siFlag),
 		rcmd.ListTrees(*treeFlag),
+		rcmd.ListJSON(*jsonFlag),
+	}
+	if *nameFlag != "" {
+		opts = append(opts, rcmd.ListNames(strings.Split(*nameFlag, ",")...))
+	}
+	if *clsFlag != "" {
+		opts = append(opts, rcmd.ListClasses(strings.Split(*clsFlag, ",")...))
 	}
 
 	for ii, fname := range fset.Args() {
-		if ii > 0 {
+		if ii > 0 && !*jsonFlag {
 			fmt.Fprintf(out, "\n")
 		}
 		err := rcmd.List(out, fname, opts...)
@@ -75,3 +75,28 @@ func TestROOTls(t *testing.T) {
 		})
 	}
 }
+
+func TestROOTlsJSON(t *testing.T) {
+	// flags are shared across invocations of run: reset them.
+	for _, name := range []string{"sinfos", "t", "cpu-profile", "json", "class"} {
+		f := fset.Lookup(name)
+		_ = f.Value.Set(f.DefValue)
+		defer f.Value.Set(f.DefValue)
+	}
+
+	out := new(bytes.Buffer)
+	rc := run(out, out, []string{
+		"-json", "-class=TH1*,TGraph", "../../testdata/dirs-6.14.00.root", "../../testdata/graphs.root",
+	})
+	if rc != 0 {
+		t.Fatalf("invalid exit-code for root-ls: got=%d, want=0\n%s", rc, out.String())
+	}
+
+	want, err := os.ReadFile("testdata/dirs-graphs.json")
+	if err != nil {
+		t.Fatalf("could not open reference file: %v", err)
+	}
+	if got, want := out.String(), string(want); got != want {
+		t.Fatalf("error:\ngot = %v\nwant= %v\n", got, want)
+	}
+}
@@ -4,14 +4,23 @@
 
 // root-merge merges ROOT files' content into a merged ROOT file.
 //
+// Histograms are summed, trees are concatenated and the directory
+// hierarchy of the first input ROOT file is replicated in the merged
+// ROOT file.
+//
 // Usage: root-merge [options] file1.root [file2.root [file3.root [...]]]
 //
 // ex:
 //
 //	$> root-merge -o out.root ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
+//	$> root-merge -o out.root -c 404 -j 4 ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
 //
 // options:
 //
+//	-c int
+//	  	compression of the output ROOT file, as 100*algorithm+level (e.g. 404 for LZ4, level 4) (default -1)
+//	-j int
+//	  	number of input ROOT files to read concurrently (default 1)
 //	-o string
 //	  	path to merged output ROOT file (default "out.root")
 //	-v	enable verbose mode
@@ -34,6 +43,8 @@ func main() {
 
 	var (
 		oname   = flag.String("o", "out.root", "path to merged output ROOT file")
+		compr   = flag.Int("c", -1, "compression of the output ROOT file, as 100*algorithm+level (e.g. 404 for LZ4, level 4)")
+		nworker = flag.Int("j", 1, "number of input ROOT files to read concurrently")
 		verbose = flag.Bool("v", false, "enable verbose mode")
 	)
 
@@ -44,6 +55,7 @@ func main() {
 
 ex:
  $> root-merge -o out.root ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
+ $> root-merge -o out.root -c 404 -j 4 ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
 
 options:
 `,
@@ -60,7 +72,12 @@ options:
 
 	fnames := flag.Args()
 
-	err := rcmd.Merge(*oname, fnames, *verbose)
+	opts := []rcmd.MergeOption{rcmd.MergeWorkers(*nworker)}
+	if *compr >= 0 {
+		opts = append(opts, rcmd.MergeCompression(int32(*compr)))
+	}
+
+	err := rcmd.Merge(*oname, fnames, *verbose, opts...)
 	if err != nil {
 		log.Fatalf("could not merge ROOT files: %+v", err)
 	}
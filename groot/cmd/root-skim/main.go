@@ -0,0 +1,100 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-skim copies the entries of an input tree passing a selection into
+// an output ROOT file, optionally keeping only a subset of its branches.
+//
+// Usage: root-skim [options] file.root
+//
+// ex:
+//
+//	$> root-skim -o out.root -cut 'Int32 > 10' ./testdata/small-flat-tree.root
+//	$> root-skim -o out.root -t dir/tree -cut 'pt > 10 && abs(eta) < 2.5' -b 'pt,eta,jet_*' ./f.root
+//
+// Selections are TTree::Draw-like expressions, using the Go syntax, where
+// identifiers refer to branches of the input tree.
+// Variable-length arrays can be indexed (out of range elements evaluate to
+// NaN) and a few functions from the math package are available
+// (abs, sqrt, pow, exp, log, min, max, ...).
+// Entries for which the selection evaluates to 0 or NaN are discarded.
+//
+// Branches holding the size of a kept variable-length branch are always kept.
+//
+// options:
+//
+//	-b string
+//	  	comma-separated list of patterns of branches to keep (default=all branches)
+//	-c int
+//	  	compression of the output ROOT file, as 100*algorithm+level (e.g. 505 for ZSTD, level 5) (default -1)
+//	-cut string
+//	  	selection expression (default=all entries)
+//	-o string
+//	  	path to output ROOT file (default "out.root")
+//	-t string
+//	  	input tree name to skim (default "tree")
+//	-v	enable verbose mode
+package main // import "go-hep.org/x/hep/groot/cmd/root-skim"
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+)
+
+func main() {
+	log.SetPrefix("root-skim: ")
+	log.SetFlags(0)
+
+	var (
+		oname   = flag.String("o", "out.root", "path to output ROOT file")
+		tname   = flag.String("t", "tree", "input tree name to skim")
+		cut     = flag.String("cut", "", "selection expression (default=all entries)")
+		bnames  = flag.String("b", "", "comma-separated list of patterns of branches to keep (default=all branches)")
+		compr   = flag.Int("c", -1, "compression of the output ROOT file, as 100*algorithm+level (e.g. 505 for ZSTD, level 5)")
+		verbose = flag.Bool("v", false, "enable verbose mode")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(
+			os.Stderr,
+			`Usage: root-skim [options] file.root
+
+ex:
+ $> root-skim -o out.root -cut 'Int32 > 10' ./testdata/small-flat-tree.root
+ $> root-skim -o out.root -t dir/tree -cut 'pt > 10 && abs(eta) < 2.5' -b 'pt,eta,jet_*' ./f.root
+
+options:
+`,
+		)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		log.Fatalf("missing input file")
+	}
+
+	fname := flag.Arg(0)
+
+	opts := []rcmd.SkimOption{rcmd.SkimVerbose(*verbose)}
+	if *bnames != "" {
+		opts = append(opts, rcmd.SkimBranches(strings.Split(*bnames, ",")...))
+	}
+	if *compr >= 0 {
+		opts = append(opts, rcmd.SkimCompression(int32(*compr)))
+	}
+
+	_, err := rcmd.Skim(*oname, fname, *tname, *cut, opts...)
+	if err != nil {
+		log.Fatalf("could not skim ROOT file: %+v", err)
+	}
+}
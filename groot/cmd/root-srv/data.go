@@ -21,10 +21,11 @@ import (
 )
 
 const (
-	plotH1     = "h1"
-	plotH2     = "h2"
-	plotS2     = "s2"
-	plotBranch = "branch"
+	plotH1       = "h1"
+	plotH2       = "h2"
+	plotS2       = "s2"
+	plotBranch   = "branch"
+	plotTreeExpr = "tree-expr"
 )
 
 type plot struct {
@@ -33,6 +34,8 @@ type plot struct {
 	Dir  string   `json:"dir"`
 	Obj  string   `json:"obj"`
 	Vars []string `json:"vars"`
+	Expr string   `json:"expr,omitempty"`
+	Cut  string   `json:"cut,omitempty"`
 
 	Options rsrv.PlotOptions `json:"options"`
 }
@@ -140,6 +143,10 @@ func dirTree(dir riofs.Directory, path string, root jsNode) ([]jsNode, error) {
 				Text: fmt.Sprintf("%s (entries=%d)", k.Name(), tree.Entries()),
 				Icon: "fa fa-tree",
 			}
+			node.Attr, err = treeAttr(node)
+			if err != nil {
+				return nil, err
+			}
 			node.Children, err = newJsNodes(tree, node)
 			if err != nil {
 				return nil, err
@@ -195,6 +202,33 @@ func iconFor(obj root.Object) string {
 	return "fa fa-cube"
 }
 
+// treeAttr returns the attributes of a tree node, holding the template
+// of the plot request for expressions drawn from that tree.
+func treeAttr(node jsNode) (jsAttr, error) {
+	cmd := new(bytes.Buffer)
+	req := plot{
+		Type: plotTreeExpr,
+		URI:  node.URI,
+		Dir:  node.Dir,
+		Obj:  node.Obj,
+		Options: rsrv.PlotOptions{
+			Type:   "svg",
+			Height: -1,
+			Width:  20 * vg.Centimeter,
+		},
+	}
+	err := json.NewEncoder(cmd).Encode(req)
+	if err != nil {
+		return nil, err
+	}
+	return jsAttr{
+		"tree": true,
+		"name": stdpath.Join(node.Dir, node.Obj),
+		"href": "/plot",
+		"cmd":  cmd.String(),
+	}, nil
+}
+
 func attrFor(obj root.Object, node jsNode) (jsAttr, error) {
 	cmd := new(bytes.Buffer)
 	cls := obj.Class()
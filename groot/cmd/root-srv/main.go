@@ -17,6 +17,25 @@
 //
 //	$> root-srv -addr :8080 -serv https -host example.com
 //	2017/04/06 15:13:59 https server listening on :8080 at example.com
+//
+// Selecting a tree in the file browser allows to draw the histogram of an
+// expression of its branches, for the entries passing an optional selection,
+// similar to ROOT's TTree::Draw:
+//
+//	expression: sqrt(px*px + py*py)
+//	selection:  n > 2 && abs(eta) < 2.5
+//
+// Expressions use the Go syntax, where identifiers refer to branches.
+//
+// Besides the rendered plots, root-srv exposes the content of ROOT objects
+// as JSON, so clients can build their own plots:
+//
+//   - /h1, /h2 and /s2 return the content of histograms and graphs,
+//   - /list-tree returns the branches and leaves of a tree,
+//   - /tree-slice returns the values of a range of entries of a tree.
+//
+// See the documentation of the go-hep.org/x/hep/groot/rsrv package for
+// the JSON payloads of these end-points.
 package main // import "go-hep.org/x/hep/groot/cmd/root-srv"
 
 import (
@@ -146,10 +146,19 @@ const page = `<html>
 */
 {{- end}}
 
+		$('#groot-draw-expr, #groot-draw-cut').keypress(function(event) {
+			if (event.keyCode == 13) {
+				drawTree();
+			}
+		});
+
 		$('#groot-file-tree').jstree();
 		$("#groot-file-tree").on("select_node.jstree",
 			function(evt, data){
 				data.instance.toggle_node(data.node);
+				if (data.node.a_attr.tree) {
+					selectTree(data.node.a_attr);
+				}
 				if (data.node.a_attr.plot) {
 					data.instance.deselect_node(data.node);
 					data.instance.disable_node(data.node);
@@ -182,6 +191,40 @@ const page = `<html>
 		});
 	});
 
+	var drawCmd = null;
+
+	function selectTree(attr) {
+		drawCmd = JSON.parse(attr.cmd);
+		$("#groot-draw-tree").text(attr.name);
+		$("#groot-draw-button").prop("disabled", false);
+	};
+
+	function drawTree() {
+		var expr = $("#groot-draw-expr").val().trim();
+		if (drawCmd == null || expr == "") {
+			return;
+		}
+		var cmd = Object.assign({}, drawCmd);
+		cmd.expr = expr;
+		cmd.cut = $("#groot-draw-cut").val().trim();
+		var id = uuidv4();
+		plotPlaceholder(id);
+		$.post({
+			type: 'POST',
+			url: "/plot",
+			data: JSON.stringify(cmd),
+			success: function(data, status) {
+				plotCallback(data, status, id);
+			},
+			contentType: "application/json",
+			dataType: 'json',
+		}).fail(function(er) {
+			$("#"+id).remove();
+			updateHeight();
+			alert("draw failed: "+er.responseText);
+		});
+	};
+
 	function displayFileTree(data) {
 		$('#groot-file-tree').jstree(true).settings.core.data = JSON.parse(data);
 		$("#groot-file-tree").jstree(true).refresh();
@@ -260,6 +303,14 @@ const page = `<html>
 		<input type="hidden" value="upload" />
 	</form>
 
+	</div>
+	<div id="groot-draw" class="w3-bar-item">
+		<div>Tree: <span id="groot-draw-tree"><i>select a tree</i></span></div>
+		<input id="groot-draw-expr" class="w3-input" type="text" placeholder="expression, e.g. sqrt(px*px + py*py)">
+		<input id="groot-draw-cut" class="w3-input" type="text" placeholder="selection, e.g. n > 2 && abs(eta) < 2.5">
+		<button id="groot-draw-button" class="w3-button w3-blue w3-small" onclick="drawTree()" disabled>
+		<i class="fa fa-area-chart" aria-hidden="true"></i> Draw
+		</button>
 	</div>
 	<div id="groot-file-tree" class="w3-bar-item">
 	</div>
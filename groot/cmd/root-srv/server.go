@@ -55,6 +55,12 @@ func newServer(local bool, dir string, mux *http.ServeMux) *server {
 	mux.HandleFunc("/plot-h2", app.srv.PlotH2)
 	mux.HandleFunc("/plot-s2", app.srv.PlotS2)
 	mux.HandleFunc("/plot-branch", app.srv.PlotTree)
+	mux.HandleFunc("/plot-tree-expr", app.srv.PlotTreeExpr)
+	mux.HandleFunc("/list-tree", app.srv.Tree)
+	mux.HandleFunc("/h1", app.srv.H1)
+	mux.HandleFunc("/h2", app.srv.H2)
+	mux.HandleFunc("/s2", app.srv.S2)
+	mux.HandleFunc("/tree-slice", app.srv.TreeSlice)
 
 	return app
 }
@@ -347,6 +353,17 @@ func (srv *server) process(preq plotRequest) {
 			Vars:    pl.Vars,
 			Options: pl.Options,
 		}
+	case plotTreeExpr:
+		h = srv.srv.PlotTreeExpr
+		ep = "/plot-tree-expr"
+		req = rsrv.PlotTreeExprRequest{
+			URI:     pl.URI,
+			Dir:     pl.Dir,
+			Obj:     pl.Obj,
+			Expr:    pl.Expr,
+			Cut:     pl.Cut,
+			Options: pl.Options,
+		}
 	default:
 		preq.resp <- plotResponse{err: fmt.Errorf("root-srv: unknown plot request %q", pl.Type)}
 		return
@@ -0,0 +1,129 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-stat displays a report about the storage of the trees of ROOT files.
+//
+// For each tree, root-stat displays a table with the number of entries,
+// the number of baskets, the number of bytes on disk, the number of bytes
+// before and after compression, the compression factor and the average
+// basket size of each branch, followed by a file-level summary.
+// It is meant to help finding out which branches take up most of the space
+// of a file.
+//
+// Usage: root-stat [options] file1.root [file2.root [...]]
+//
+// ex:
+//
+//	$> root-stat ./testdata/simple.root
+//	=== [./testdata/simple.root] ===
+//
+//	tree "tree" (cycle=1, entries=4, branches=3)
+//	BRANCH  ENTRIES  BASKETS  DISK  UNZIPPED  ZIPPED  FACTOR  AVG-BASKET
+//	one     4        1        86    86        86      1.00    86.0
+//	two     4        1        86    86        86      1.00    86.0
+//	three   4        1        116   116       116     1.00    116.0
+//	total   4        3        288   288       288     1.00    96.0
+//
+//	summary:
+//	  file size:     5614 bytes
+//	  trees:         1
+//	  branches:      3
+//	  baskets:       3
+//	  baskets size:  288 bytes (5.1% of file)
+//	  unzipped size: 288 bytes
+//	  zipped size:   288 bytes
+//	  comp. factor:  1.00
+//
+// options:
+//
+//	-sort
+//	  	sort branches by decreasing size on disk
+//	-t string
+//	  	comma-separated list of patterns of trees to inspect (default: all)
+package main // import "go-hep.org/x/hep/groot/cmd/root-stat"
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+)
+
+var (
+	fset = flag.NewFlagSet("stat", flag.ContinueOnError)
+
+	sortBySize = fset.Bool("sort", false, "sort branches by decreasing size on disk")
+	trees      = fset.String("t", "", "comma-separated list of patterns of trees to inspect (default: all)")
+
+	usage = `Usage: root-stat [options] file1.root [file2.root [...]]
+
+ex:
+ $> root-stat ./testdata/simple.root
+ $> root-stat -sort -t 'tree*' ./testdata/small-flat-tree.root
+
+options:
+`
+)
+
+func main() {
+	log.SetPrefix("root-stat: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	fset.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() <= 0 {
+		fmt.Fprintf(stderr, "error: you need to give a ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	opts := []rcmd.StatOption{
+		rcmd.StatSortBySize(*sortBySize),
+	}
+	if *trees != "" {
+		opts = append(opts, rcmd.StatTrees(strings.Split(*trees, ",")...))
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	rc := 0
+	for ii, fname := range fset.Args() {
+		if ii > 0 {
+			fmt.Fprintf(out, "\n")
+		}
+		err := rcmd.Stat(out, fname, opts...)
+		if err != nil {
+			out.Flush()
+			log.Printf("%+v", err)
+			rc = 1
+		}
+	}
+
+	return rc
+}
@@ -0,0 +1,58 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestROOTStat(t *testing.T) {
+	tmp := t.TempDir()
+
+	for _, tc := range []struct {
+		args []string
+		rc   int
+	}{
+		{
+			args: []string{"../../testdata/simple.root"},
+		},
+		{
+			args: []string{"-sort", "-t", "tree,ntup", "../../testdata/small-flat-tree.root", "../../testdata/tntuple.root"},
+		},
+		{
+			args: []string{filepath.Join(tmp, "not-there.root")},
+			rc:   1,
+		},
+		{
+			args: []string{"-t", "[", "../../testdata/simple.root"},
+			rc:   1,
+		},
+		{
+			args: []string{"-sort"},
+			rc:   1,
+		},
+		{
+			args: []string{"-h"},
+			rc:   0,
+		},
+		{
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			rc := run(out, out, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-stat %q: got=%d, want=%d\n%s",
+					tc.args, rc, tc.rc, out.String(),
+				)
+			}
+		})
+	}
+}
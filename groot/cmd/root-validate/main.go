@@ -0,0 +1,112 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-validate checks the structural integrity of ROOT files.
+//
+// root-validate walks through the records, streamer infos and keys of the
+// provided ROOT files and checks:
+//   - the consistency of the sizes, positions and compressed payloads of
+//     all the records,
+//   - that the classes referenced by the streamer infos and by the keys are
+//     described,
+//   - the consistency of the trees: cluster ranges, number of entries of
+//     branches and baskets, location, header and payload of all the baskets.
+//
+// root-validate exits with a non-zero exit code if a problem was found,
+// after having displayed a detailed report.
+// It is meant to be used before replicating or archiving ROOT files.
+//
+// Usage: root-validate [options] file1.root [file2.root [...]]
+//
+// ex:
+//
+//	$> root-validate -v ./testdata/small-flat-tree.root
+//	=== [./testdata/small-flat-tree.root] ===
+//	version:   60806
+//	records:   25
+//	streamers: 20
+//	keys:      1
+//	trees:     1
+//	status: OK
+//
+// options:
+//
+//	-v	enable verbose mode
+package main // import "go-hep.org/x/hep/groot/cmd/root-validate"
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+)
+
+var (
+	fset = flag.NewFlagSet("validate", flag.ContinueOnError)
+
+	verbose = fset.Bool("v", false, "enable verbose mode")
+
+	usage = `Usage: root-validate [options] file1.root [file2.root [...]]
+
+ex:
+ $> root-validate ./testdata/graphs.root
+ $> root-validate -v ./testdata/graphs.root ./testdata/small-flat-tree.root
+
+options:
+`
+)
+
+func main() {
+	log.SetPrefix("root-validate: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	fset.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() <= 0 {
+		fmt.Fprintf(stderr, "error: you need to give a ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	rc := 0
+	for ii, fname := range fset.Args() {
+		if ii > 0 {
+			fmt.Fprintf(out, "\n")
+		}
+		err := rcmd.Validate(out, fname, *verbose)
+		if err != nil {
+			out.Flush()
+			log.Printf("%+v", err)
+			rc = 1
+		}
+	}
+
+	return rc
+}
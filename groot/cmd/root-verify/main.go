@@ -0,0 +1,102 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-verify checks the integrity of the records of ROOT files.
+//
+// root-verify walks through all the records of the provided ROOT files,
+// decompresses their payload and checks the consistency of the sizes and
+// checksums of the compressed blocks.
+// root-verify exits with a non-zero exit code if a damaged record was found.
+//
+// Usage: root-verify [options] file1.root [file2.root [...]]
+//
+// ex:
+//
+//	$> root-verify -v ./testdata/graphs.root
+//	=== [./testdata/graphs.root] ===
+//	version: 60806
+//	records: 7
+//	gaps:    0
+//	status: OK
+//
+// options:
+//
+//	-v	enable verbose mode
+package main // import "go-hep.org/x/hep/groot/cmd/root-verify"
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+)
+
+var (
+	fset = flag.NewFlagSet("verify", flag.ContinueOnError)
+
+	verbose = fset.Bool("v", false, "enable verbose mode")
+
+	usage = `Usage: root-verify [options] file1.root [file2.root [...]]
+
+ex:
+ $> root-verify ./testdata/graphs.root
+ $> root-verify -v ./testdata/graphs.root ./testdata/small-flat-tree.root
+
+options:
+`
+)
+
+func main() {
+	log.SetPrefix("root-verify: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	fset.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() <= 0 {
+		fmt.Fprintf(stderr, "error: you need to give a ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	rc := 0
+	for ii, fname := range fset.Args() {
+		if ii > 0 {
+			fmt.Fprintf(out, "\n")
+		}
+		err := rcmd.Verify(out, fname, *verbose)
+		if err != nil {
+			out.Flush()
+			log.Printf("%+v", err)
+			rc = 1
+		}
+	}
+
+	return rc
+}
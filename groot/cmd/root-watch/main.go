@@ -0,0 +1,142 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-watch follows a tree stored in a ROOT file that is being written to
+// (e.g. by a data acquisition job regularly saving its tree) and displays
+// its new entries as they appear.
+//
+// root-watch re-opens the file at each inspection, refreshes the header of
+// the tree and displays the entries appended since the previous inspection.
+// root-watch stops on interrupt (Ctrl-C) or, if requested, after some time
+// without new entries.
+//
+// Usage: root-watch [options] file.root
+//
+// ex:
+//
+//	$> root-watch -t tree -branches='one,two' ./testdata/simple.root
+//	[000][one]: 1
+//	[000][two]: 1.1
+//	[001][one]: 2
+//	[001][two]: 2.2
+//	[...]
+//
+//	$> root-watch -t tree -from=2 -format=jsonl -idle=10s ./daq.root
+//	{"entry":2,"one":3,"two":3.3,"three":"tres"}
+//	{"entry":3,"one":4,"two":4.4,"three":"quatro"}
+//	[...]
+//
+// options:
+//
+//	-branches string
+//	  	comma-separated list of patterns of branches to display (default=all branches)
+//	-format string
+//	  	output format of entries (text, jsonl) (default "text")
+//	-from int
+//	  	index of the first entry to display
+//	-idle duration
+//	  	stop after this duration without new entries (default=never)
+//	-poll duration
+//	  	interval between two inspections of the file (default 1s)
+//	-t string
+//	  	name of the tree to follow (default "tree")
+package main // import "go-hep.org/x/hep/groot/cmd/root-watch"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+)
+
+var (
+	fset = flag.NewFlagSet("watch", flag.ContinueOnError)
+
+	treeFlag   = fset.String("t", "tree", "name of the tree to follow")
+	branchFlag = fset.String("branches", "", "comma-separated list of patterns of branches to display (default=all branches)")
+	fromFlag   = fset.Int64("from", 0, "index of the first entry to display")
+	pollFlag   = fset.Duration("poll", time.Second, "interval between two inspections of the file")
+	idleFlag   = fset.Duration("idle", 0, "stop after this duration without new entries (default=never)")
+	fmtFlag    = fset.String("format", "text", "output format of entries (text, jsonl)")
+
+	usage = `Usage: root-watch [options] file.root
+
+ex:
+ $> root-watch ./testdata/simple.root
+ $> root-watch -t tree -branches='one,two' -from=2 -format=jsonl -idle=10s ./daq.root
+
+options:
+`
+)
+
+func main() {
+	log.SetPrefix("root-watch: ")
+	log.SetFlags(0)
+
+	os.Exit(run(os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+func run(stdout, stderr io.Writer, args []string) int {
+	fset.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fset.PrintDefaults()
+	}
+
+	err := fset.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		log.Printf("could not parse args %q: %+v", args, err)
+		return 1
+	}
+
+	if fset.NArg() != 1 {
+		fmt.Fprintf(stderr, "error: you need to give a ROOT file\n\n")
+		fset.Usage()
+		return 1
+	}
+
+	var format rcmd.DumpFormat
+	switch *fmtFlag {
+	case "text":
+		format = rcmd.DumpText
+	case "jsonl":
+		format = rcmd.DumpJSONL
+	default:
+		log.Printf("invalid output format %q", *fmtFlag)
+		return 1
+	}
+
+	opts := []rcmd.WatchOption{
+		rcmd.WatchFrom(*fromFlag),
+		rcmd.WatchInterval(*pollFlag),
+		rcmd.WatchIdle(*idleFlag),
+		rcmd.WatchAs(format),
+	}
+	if *branchFlag != "" {
+		opts = append(opts, rcmd.WatchBranches(strings.Split(*branchFlag, ",")...))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err = rcmd.Watch(ctx, stdout, fset.Arg(0), *treeFlag, opts...)
+	if err != nil {
+		log.Printf("%+v", err)
+		return 1
+	}
+
+	return 0
+}
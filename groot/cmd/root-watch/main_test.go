@@ -0,0 +1,76 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestROOTWatch(t *testing.T) {
+	tmp := t.TempDir()
+
+	for _, tc := range []struct {
+		args []string
+		rc   int
+		want string
+	}{
+		{
+			args: []string{"-poll=10ms", "-idle=30ms", "-branches=one,three", "../../testdata/simple.root"},
+			want: `[000][one]: 1
+[000][three]: uno
+[001][one]: 2
+[001][three]: dos
+[002][one]: 3
+[002][three]: tres
+[003][one]: 4
+[003][three]: quatro
+`,
+		},
+		{
+			args: []string{"-poll=10ms", "-idle=30ms", "-from=3", "-format=jsonl", "-branches=two", "../../testdata/simple.root"},
+			want: `{"entry":3,"two":4.4}
+`,
+		},
+		{
+			args: []string{"-poll=10ms", "-idle=30ms", "-from=0", "-format=text", "-branches=", filepath.Join(tmp, "not-there.root")},
+			rc:   1,
+		},
+		{
+			args: []string{"-format=csv", "../../testdata/simple.root"},
+			rc:   1,
+		},
+		{
+			args: []string{"-format=text"},
+			rc:   1,
+		},
+		{
+			args: []string{"-h"},
+			rc:   0,
+		},
+		{
+			args: []string{"-=3"},
+			rc:   1,
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			out := new(bytes.Buffer)
+			rc := run(out, out, tc.args)
+			if rc != tc.rc {
+				t.Fatalf(
+					"invalid exit-code for root-watch %q: got=%d, want=%d\n%s",
+					tc.args, rc, tc.rc, out.String(),
+				)
+			}
+			if tc.rc != 0 || tc.want == "" {
+				return
+			}
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid root-watch output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root2rntuple converts the content of a ROOT TTree to a ROOT RNTuple.
+//
+// Usage: root2rntuple [options] file.root
+//
+// ex:
+//
+//	$> root2rntuple -o out.root -t tree ./testdata/simple.root
+//
+// options:
+//
+//	-n string
+//	  	name of the output RNTuple (default: name of the input tree)
+//	-o string
+//	  	path to output ROOT file (default "out.root")
+//	-t string
+//	  	name of the tree to convert (default "tree")
+package main // import "go-hep.org/x/hep/groot/cmd/root2rntuple"
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/exp/rntup"
+	"go-hep.org/x/hep/groot/riofs"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func main() {
+	log.SetPrefix("root2rntuple: ")
+	log.SetFlags(0)
+
+	var (
+		oname = flag.String("o", "out.root", "path to output ROOT file")
+		tname = flag.String("t", "tree", "name of the tree to convert")
+		nname = flag.String("n", "", "name of the output RNTuple (default: name of the input tree)")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(
+			os.Stderr,
+			`Usage: root2rntuple [options] file.root
+
+ex:
+ $> root2rntuple -o out.root -t tree ./testdata/simple.root
+
+options:
+`,
+		)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		log.Fatalf("missing input ROOT filename argument")
+	}
+	fname := flag.Arg(0)
+
+	err := process(*oname, fname, *tname, *nname)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func process(oname, fname, tname, nname string) error {
+	f, err := groot.Open(fname)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get(tname)
+	if err != nil {
+		return err
+	}
+
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		return fmt.Errorf("object %q in file %q is not a rtree.Tree", tname, fname)
+	}
+
+	if nname == "" {
+		nname = tree.Name()
+	}
+
+	o, err := groot.Create(oname)
+	if err != nil {
+		return fmt.Errorf("could not create output file: %w", err)
+	}
+	defer o.Close()
+
+	_, err = rntup.ConvertTree(o, nname, tree)
+	if err != nil {
+		return fmt.Errorf("could not convert tree %q: %w", tname, err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main // import "go-hep.org/x/hep/groot/cmd/root2rntuple"
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/exp/rntup"
+)
+
+func TestProcess(t *testing.T) {
+	for _, tc := range []struct {
+		file string
+		tree string
+		name string
+		want string
+	}{
+		{
+			file: "../../testdata/simple.root",
+			tree: "tree",
+			want: "tree",
+		},
+		{
+			file: "../../testdata/leaves.root",
+			tree: "tree",
+			name: "ntpl",
+			want: "ntpl",
+		},
+		{
+			file: "../../testdata/embedded-std-vector.root",
+			tree: "modules",
+			want: "modules",
+		},
+		{
+			file: "../../testdata/small-evnt-tree-fullsplit.root",
+			tree: "tree",
+			name: "evts",
+			want: "evts",
+		},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			oname := filepath.Join(t.TempDir(), "out.root")
+			err := process(oname, tc.file, tc.tree, tc.name)
+			if err != nil {
+				t.Fatalf("could not convert tree: %+v", err)
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			obj, err := f.Get(tc.want)
+			if err != nil {
+				t.Fatalf("could not get RNTuple: %+v", err)
+			}
+
+			if _, ok := obj.(*rntup.Anchor); !ok {
+				t.Fatalf("invalid RNTuple type: %T", obj)
+			}
+		})
+	}
+}
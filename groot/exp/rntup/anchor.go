@@ -0,0 +1,168 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/internal/xxh3"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Version of the RNTuple binary format written by groot.
+const (
+	VersionEpoch = 1
+	VersionMajor = 0
+	VersionMinor = 0
+	VersionPatch = 0
+)
+
+const defaultMaxKeySize = 0x40000000 // 1 GiB
+
+// Anchor is the entry point of an RNTuple stored in a ROOT file.
+// It locates the header and footer envelopes of the RNTuple.
+type Anchor struct {
+	epoch uint16
+	major uint16
+	minor uint16
+	patch uint16
+
+	seekHeader   uint64 // file offset of the header envelope
+	nbytesHeader uint64 // size of the compressed header envelope
+	lenHeader    uint64 // size of the uncompressed header envelope
+	seekFooter   uint64 // file offset of the footer envelope
+	nbytesFooter uint64 // size of the compressed footer envelope
+	lenFooter    uint64 // size of the uncompressed footer envelope
+	maxKeySize   uint64 // maximum size of a key payload
+}
+
+func (*Anchor) Class() string {
+	return "ROOT::RNTuple"
+}
+
+func (*Anchor) RVersion() int16 {
+	return rvers.ROOT_RNTuple
+}
+
+// Version returns the version of the RNTuple binary format.
+func (a *Anchor) Version() (epoch, major, minor, patch uint16) {
+	return a.epoch, a.major, a.minor, a.patch
+}
+
+func (a *Anchor) String() string {
+	return fmt.Sprintf(
+		"Anchor{version:%d.%d.%d.%d, header:{seek:%d, nbytes:%d, len:%d}, footer:{seek:%d, nbytes:%d, len:%d}}",
+		a.epoch, a.major, a.minor, a.patch,
+		a.seekHeader, a.nbytesHeader, a.lenHeader,
+		a.seekFooter, a.nbytesFooter, a.lenFooter,
+	)
+}
+
+// checksum returns the XXH3 checksum of the streamed anchor members.
+func (a *Anchor) checksum() uint64 {
+	var buf [4*2 + 7*8]byte
+	for i, v := range []uint16{a.epoch, a.major, a.minor, a.patch} {
+		binary.BigEndian.PutUint16(buf[2*i:], v)
+	}
+	for i, v := range []uint64{
+		a.seekHeader, a.nbytesHeader, a.lenHeader,
+		a.seekFooter, a.nbytesFooter, a.lenFooter,
+		a.maxKeySize,
+	} {
+		binary.BigEndian.PutUint64(buf[8+8*i:], v)
+	}
+	return xxh3.Sum64(buf[:])
+}
+
+func (a *Anchor) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.Pos()
+	hdr := w.WriteHeader(a.Class(), a.RVersion())
+
+	w.WriteU16(a.epoch)
+	w.WriteU16(a.major)
+	w.WriteU16(a.minor)
+	w.WriteU16(a.patch)
+
+	w.WriteU64(a.seekHeader)
+	w.WriteU64(a.nbytesHeader)
+	w.WriteU64(a.lenHeader)
+	w.WriteU64(a.seekFooter)
+	w.WriteU64(a.nbytesFooter)
+	w.WriteU64(a.lenFooter)
+	w.WriteU64(a.maxKeySize)
+
+	_, err := w.SetHeader(hdr)
+	if err != nil {
+		return int(w.Pos() - pos), err
+	}
+
+	// the checksum is stored after the class buffer.
+	w.WriteU64(a.checksum())
+
+	return int(w.Pos() - pos), w.Err()
+}
+
+func (a *Anchor) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(a.Class(), a.RVersion())
+	if hdr.Vers < 2 {
+		return fmt.Errorf("rntup: invalid %s version=%d", a.Class(), hdr.Vers)
+	}
+
+	a.epoch = r.ReadU16()
+	a.major = r.ReadU16()
+	a.minor = r.ReadU16()
+	a.patch = r.ReadU16()
+
+	a.seekHeader = r.ReadU64()
+	a.nbytesHeader = r.ReadU64()
+	a.lenHeader = r.ReadU64()
+	a.seekFooter = r.ReadU64()
+	a.nbytesFooter = r.ReadU64()
+	a.lenFooter = r.ReadU64()
+	a.maxKeySize = r.ReadU64()
+
+	r.CheckHeader(hdr)
+
+	chksum := r.ReadU64()
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	if got := a.checksum(); got != chksum {
+		return fmt.Errorf("rntup: invalid anchor checksum (got=0x%x, want=0x%x)", got, chksum)
+	}
+
+	return nil
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := &Anchor{}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("ROOT::RNTuple", f)
+	}
+}
+
+var (
+	_ root.Object        = (*Anchor)(nil)
+	_ rbytes.RVersioner  = (*Anchor)(nil)
+	_ rbytes.Marshaler   = (*Anchor)(nil)
+	_ rbytes.Unmarshaler = (*Anchor)(nil)
+)
@@ -0,0 +1,273 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ColumnType describes the on-disk representation of a column.
+type ColumnType uint16
+
+const (
+	ColBit          ColumnType = 0x00
+	ColByte         ColumnType = 0x01
+	ColChar         ColumnType = 0x02
+	ColInt8         ColumnType = 0x03
+	ColUInt8        ColumnType = 0x04
+	ColInt16        ColumnType = 0x05
+	ColUInt16       ColumnType = 0x06
+	ColInt32        ColumnType = 0x07
+	ColUInt32       ColumnType = 0x08
+	ColInt64        ColumnType = 0x09
+	ColUInt64       ColumnType = 0x0A
+	ColReal16       ColumnType = 0x0B
+	ColReal32       ColumnType = 0x0C
+	ColReal64       ColumnType = 0x0D
+	ColIndex32      ColumnType = 0x0E
+	ColIndex64      ColumnType = 0x0F
+	ColSwitch       ColumnType = 0x10
+	ColSplitInt16   ColumnType = 0x11
+	ColSplitUInt16  ColumnType = 0x12
+	ColSplitInt32   ColumnType = 0x13
+	ColSplitUInt32  ColumnType = 0x14
+	ColSplitInt64   ColumnType = 0x15
+	ColSplitUInt64  ColumnType = 0x16
+	ColSplitReal16  ColumnType = 0x17
+	ColSplitReal32  ColumnType = 0x18
+	ColSplitReal64  ColumnType = 0x19
+	ColSplitIndex32 ColumnType = 0x1A
+	ColSplitIndex64 ColumnType = 0x1B
+	ColReal32Trunc  ColumnType = 0x1C
+	ColReal32Quant  ColumnType = 0x1D
+)
+
+func (ct ColumnType) String() string {
+	switch ct {
+	case ColBit:
+		return "Bit"
+	case ColByte:
+		return "Byte"
+	case ColChar:
+		return "Char"
+	case ColInt8:
+		return "Int8"
+	case ColUInt8:
+		return "UInt8"
+	case ColInt16:
+		return "Int16"
+	case ColUInt16:
+		return "UInt16"
+	case ColInt32:
+		return "Int32"
+	case ColUInt32:
+		return "UInt32"
+	case ColInt64:
+		return "Int64"
+	case ColUInt64:
+		return "UInt64"
+	case ColReal16:
+		return "Real16"
+	case ColReal32:
+		return "Real32"
+	case ColReal64:
+		return "Real64"
+	case ColIndex32:
+		return "Index32"
+	case ColIndex64:
+		return "Index64"
+	case ColSwitch:
+		return "Switch"
+	case ColSplitInt16:
+		return "SplitInt16"
+	case ColSplitUInt16:
+		return "SplitUInt16"
+	case ColSplitInt32:
+		return "SplitInt32"
+	case ColSplitUInt32:
+		return "SplitUInt32"
+	case ColSplitInt64:
+		return "SplitInt64"
+	case ColSplitUInt64:
+		return "SplitUInt64"
+	case ColSplitReal16:
+		return "SplitReal16"
+	case ColSplitReal32:
+		return "SplitReal32"
+	case ColSplitReal64:
+		return "SplitReal64"
+	case ColSplitIndex32:
+		return "SplitIndex32"
+	case ColSplitIndex64:
+		return "SplitIndex64"
+	case ColReal32Trunc:
+		return "Real32Trunc"
+	case ColReal32Quant:
+		return "Real32Quant"
+	}
+	return fmt.Sprintf("ColumnType(0x%02x)", uint16(ct))
+}
+
+// bits returns the number of bits on storage of a column element.
+func (ct ColumnType) bits() uint16 {
+	switch ct {
+	case ColBit:
+		return 1
+	case ColByte, ColChar, ColInt8, ColUInt8:
+		return 8
+	case ColInt16, ColUInt16, ColReal16,
+		ColSplitInt16, ColSplitUInt16, ColSplitReal16:
+		return 16
+	case ColInt32, ColUInt32, ColReal32, ColIndex32,
+		ColSplitInt32, ColSplitUInt32, ColSplitReal32, ColSplitIndex32:
+		return 32
+	case ColInt64, ColUInt64, ColReal64, ColIndex64,
+		ColSplitInt64, ColSplitUInt64, ColSplitReal64, ColSplitIndex64:
+		return 64
+	case ColSwitch:
+		return 96
+	}
+	panic(fmt.Errorf("rntup: unknown column type %v", ct))
+}
+
+// size returns the in-memory size (in bytes) of a column element.
+func (ct ColumnType) size() int {
+	if ct == ColBit {
+		return 1
+	}
+	return int(ct.bits()) / 8
+}
+
+// wcolumn buffers the elements of a column until they are committed to a page.
+type wcolumn struct {
+	id    uint32 // physical column ID
+	typ   ColumnType
+	field *wfield
+
+	buf   []byte // little-endian elements of the current page
+	n     int64  // number of elements in the current page
+	first int64  // index of the first element of the current cluster
+	tot   int64  // total number of elements written
+	pages []page // pages of the current cluster
+}
+
+// page describes a committed page.
+type page struct {
+	n   int32 // number of elements
+	loc locator
+}
+
+func newColumn(typ ColumnType, field *wfield) *wcolumn {
+	return &wcolumn{typ: typ, field: field}
+}
+
+func (col *wcolumn) appendBool(v bool) {
+	var b byte
+	if v {
+		b = 1
+	}
+	col.buf = append(col.buf, b)
+	col.n++
+}
+
+func (col *wcolumn) appendU8(v uint8) {
+	col.buf = append(col.buf, v)
+	col.n++
+}
+
+func (col *wcolumn) appendU16(v uint16) {
+	col.buf = binary.LittleEndian.AppendUint16(col.buf, v)
+	col.n++
+}
+
+func (col *wcolumn) appendU32(v uint32) {
+	col.buf = binary.LittleEndian.AppendUint32(col.buf, v)
+	col.n++
+}
+
+func (col *wcolumn) appendU64(v uint64) {
+	col.buf = binary.LittleEndian.AppendUint64(col.buf, v)
+	col.n++
+}
+
+func (col *wcolumn) appendBytes(p []byte) {
+	col.buf = append(col.buf, p...)
+	col.n += int64(len(p))
+}
+
+// encode returns the on-disk representation of the current page.
+func (col *wcolumn) encode() []byte {
+	switch col.typ {
+	case ColBit:
+		out := make([]byte, (len(col.buf)+7)/8)
+		for i, v := range col.buf {
+			if v != 0 {
+				out[i/8] |= 1 << (i % 8)
+			}
+		}
+		return out
+
+	case ColSplitInt16:
+		out := make([]byte, len(col.buf))
+		for i := 0; i < len(out); i += 2 {
+			v := int16(binary.LittleEndian.Uint16(col.buf[i:]))
+			binary.LittleEndian.PutUint16(out[i:], uint16((v<<1)^(v>>15)))
+		}
+		return split(out, 2)
+
+	case ColSplitInt32:
+		out := make([]byte, len(col.buf))
+		for i := 0; i < len(out); i += 4 {
+			v := int32(binary.LittleEndian.Uint32(col.buf[i:]))
+			binary.LittleEndian.PutUint32(out[i:], uint32((v<<1)^(v>>31)))
+		}
+		return split(out, 4)
+
+	case ColSplitInt64:
+		out := make([]byte, len(col.buf))
+		for i := 0; i < len(out); i += 8 {
+			v := int64(binary.LittleEndian.Uint64(col.buf[i:]))
+			binary.LittleEndian.PutUint64(out[i:], uint64((v<<1)^(v>>63)))
+		}
+		return split(out, 8)
+
+	case ColSplitIndex64:
+		out := make([]byte, len(col.buf))
+		prev := uint64(0)
+		for i := 0; i < len(out); i += 8 {
+			v := binary.LittleEndian.Uint64(col.buf[i:])
+			binary.LittleEndian.PutUint64(out[i:], v-prev)
+			prev = v
+		}
+		return split(out, 8)
+
+	case ColSplitUInt16:
+		return split(col.buf, 2)
+	case ColSplitUInt32, ColSplitReal32:
+		return split(col.buf, 4)
+	case ColSplitUInt64, ColSplitReal64:
+		return split(col.buf, 8)
+	}
+
+	out := make([]byte, len(col.buf))
+	copy(out, col.buf)
+	return out
+}
+
+// split applies the byte-stream split encoding to the elements of size sz
+// held in p: the i-th byte of all elements are stored contiguously.
+func split(p []byte, sz int) []byte {
+	var (
+		n   = len(p) / sz
+		out = make([]byte, len(p))
+	)
+	for i := 0; i < n; i++ {
+		for b := 0; b < sz; b++ {
+			out[b*n+i] = p[i*sz+b]
+		}
+	}
+	return out
+}
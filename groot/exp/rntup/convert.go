@@ -0,0 +1,146 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// ConvertTree converts the provided tree into an RNTuple with the provided
+// name, under the directory dir, and returns the number of converted entries.
+//
+// Each top-level branch of the tree is mapped to a top-level field of the RNTuple:
+//   - branches with a single leaf are mapped to fields of the leaf type,
+//   - leaf-list branches are mapped to untyped records, with one sub-field per leaf,
+//   - variable-length arrays (jagged branches) are mapped to std::vector<T> collections,
+//   - fixed-size arrays are mapped to std::array<T,N> fields,
+//   - object branches are mapped to untyped records.
+//
+// Leaves only used as the count of variable-length arrays are not converted,
+// as their values are held by the collection fields.
+//
+// Unless overridden by the provided options, ConvertTree uses the compression
+// settings of the branches of the tree.
+func ConvertTree(dir riofs.Directory, name string, tree rtree.Tree, opts ...WriteOption) (int64, error) {
+	var (
+		rvars  = rtree.NewReadVars(tree)
+		counts = make(map[rtree.Leaf]struct{})
+	)
+	for _, leaf := range tree.Leaves() {
+		if lc := leaf.LeafCount(); lc != nil {
+			counts[lc] = struct{}{}
+		}
+	}
+
+	var (
+		wvars []WriteVar
+		leafs = make(map[string][]int) // read-vars indices, by branch name
+		names []string
+	)
+	for i, rvar := range rvars {
+		leaf := tree.Branch(rvar.Name).Leaf(rvar.Leaf)
+		if _, ok := counts[leaf]; ok {
+			continue
+		}
+		if _, ok := leafs[rvar.Name]; !ok {
+			names = append(names, rvar.Name)
+		}
+		leafs[rvar.Name] = append(leafs[rvar.Name], i)
+	}
+
+	for _, bname := range names {
+		idx := leafs[bname]
+		if len(idx) == 1 {
+			wvars = append(wvars, WriteVar{
+				Name:  fieldNameFrom(bname),
+				Value: rvars[idx[0]].Value,
+			})
+			continue
+		}
+
+		// leaf-list branch: bind all its leaves to the fields of a struct.
+		fields := make([]reflect.StructField, len(idx))
+		for i, j := range idx {
+			fields[i] = reflect.StructField{
+				Name: "Leaf" + strconv.Itoa(i),
+				Type: reflect.TypeOf(rvars[j].Value).Elem(),
+				Tag:  reflect.StructTag(`groot:"` + rvars[j].Leaf + `"`),
+			}
+		}
+		ptr := reflect.New(reflect.StructOf(fields))
+		for i, j := range idx {
+			rvars[j].Value = ptr.Elem().Field(i).Addr().Interface()
+		}
+		wvars = append(wvars, WriteVar{
+			Name:  fieldNameFrom(bname),
+			Value: ptr.Interface(),
+		})
+	}
+
+	if compr, ok := treeCompression(tree); ok {
+		opts = append([]WriteOption{withCompression(compr)}, opts...)
+	}
+
+	w, err := NewWriter(dir, name, wvars, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("rntup: could not create RNTuple writer: %w", err)
+	}
+
+	r, err := rtree.NewReader(tree, rvars)
+	if err != nil {
+		return 0, fmt.Errorf("rntup: could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		_, err := w.Write()
+		if err != nil {
+			return fmt.Errorf("could not write entry %d: %w", ctx.Entry, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return w.Entries(), fmt.Errorf("rntup: could not convert tree %q: %w", tree.Name(), err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return w.Entries(), fmt.Errorf("rntup: could not close RNTuple writer: %w", err)
+	}
+
+	return w.Entries(), nil
+}
+
+// fieldNameFrom returns a valid RNTuple field name from the provided branch name.
+func fieldNameFrom(name string) string {
+	name = strings.Trim(name, ".")
+	return strings.ReplaceAll(name, ".", "_")
+}
+
+// treeCompression returns the compression settings of the branches of the
+// provided tree.
+func treeCompression(tree rtree.Tree) (int32, bool) {
+	for _, b := range tree.Branches() {
+		if compr := b.Compression(); compr >= 0 {
+			return rcompress.SettingsFrom(compr).Compression(), true
+		}
+	}
+	return 0, false
+}
+
+// withCompression configures an RNTuple to use the provided compression settings.
+func withCompression(compr int32) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = compr
+		return nil
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestConvertTree(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		tname string
+		opts  []WriteOption
+		names []string
+		compr int32
+	}{
+		{
+			fname: "../../testdata/simple.root",
+			tname: "tree",
+			names: []string{"one", "two", "three"},
+		},
+		{
+			fname: "../../testdata/x-flat-tree.root",
+			tname: "tree",
+			names: []string{
+				"B", "Str", "I8", "I16", "I32", "I64", "U8", "U16", "U32", "U64",
+				"F32", "F64", "D16", "D32",
+				"ArrBs", "ArrI8", "ArrI16", "ArrI32", "ArrI64",
+				"ArrU8", "ArrU16", "ArrU32", "ArrU64",
+				"ArrF32", "ArrF64", "ArrD16", "ArrD32",
+				"SliBs", "SliI8", "SliI16", "SliI32", "SliI64",
+				"SliU8", "SliU16", "SliU32", "SliU64",
+				"SliF32", "SliF64", "SliD16", "SliD32",
+			},
+		},
+		{
+			fname: "../../testdata/x-flat-tree.root",
+			tname: "tree",
+			opts:  []WriteOption{WithoutCompression(), WithPageSize(64)},
+			names: []string{
+				"B", "Str", "I8", "I16", "I32", "I64", "U8", "U16", "U32", "U64",
+				"F32", "F64", "D16", "D32",
+				"ArrBs", "ArrI8", "ArrI16", "ArrI32", "ArrI64",
+				"ArrU8", "ArrU16", "ArrU32", "ArrU64",
+				"ArrF32", "ArrF64", "ArrD16", "ArrD32",
+				"SliBs", "SliI8", "SliI16", "SliI32", "SliI64",
+				"SliU8", "SliU16", "SliU32", "SliU64",
+				"SliF32", "SliF64", "SliD16", "SliD32",
+			},
+		},
+		{
+			fname: "../../testdata/root_numpy_struct.root",
+			tname: "test",
+			names: []string{"branch1", "branch2"},
+		},
+		{
+			fname: "../../testdata/embedded-std-vector.root",
+			tname: "modules",
+			names: []string{"hits_n", "hits_time_mc"},
+		},
+		{
+			fname: "../../testdata/small-evnt-tree-fullsplit.root",
+			tname: "tree",
+			names: []string{"evt"},
+		},
+		{
+			fname: "../../testdata/small-evnt-tree-nosplit.root",
+			tname: "tree",
+			names: []string{"evt"},
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			src, err := riofs.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open input file: %+v", err)
+			}
+			defer src.Close()
+
+			obj, err := riofs.Dir(src).Get(tc.tname)
+			if err != nil {
+				t.Fatalf("could not get tree: %+v", err)
+			}
+			tree := obj.(rtree.Tree)
+
+			oname := filepath.Join(t.TempDir(), "out.root")
+			dst, err := riofs.Create(oname)
+			if err != nil {
+				t.Fatalf("could not create output file: %+v", err)
+			}
+			defer dst.Close()
+
+			n, err := ConvertTree(dst, "ntpl", tree, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not convert tree: %+v", err)
+			}
+			if got, want := n, tree.Entries(); got != want {
+				t.Fatalf("invalid number of converted entries: got=%d, want=%d", got, want)
+			}
+
+			err = dst.Close()
+			if err != nil {
+				t.Fatalf("could not close output file: %+v", err)
+			}
+
+			f, err := riofs.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			obj, err = f.Get("ntpl")
+			if err != nil {
+				t.Fatalf("could not get anchor: %+v", err)
+			}
+
+			nt, err := readNTuple(f, obj.(*Anchor))
+			if err != nil {
+				t.Fatalf("could not read RNTuple: %+v", err)
+			}
+
+			if got, want := nt.entries, tree.Entries(); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			var names []string
+			for _, f := range nt.fields {
+				if f.parent != f.id {
+					continue
+				}
+				names = append(names, f.name)
+			}
+			if !reflect.DeepEqual(names, tc.names) {
+				t.Fatalf("invalid fields:\ngot= %q\nwant=%q", names, tc.names)
+			}
+
+			compr := tc.compr
+			if tc.opts == nil {
+				compr = rcompress.SettingsFrom(tree.Branches()[0].Compression()).Compression()
+			}
+			if got, want := int32(nt.compr), compr; got != want {
+				t.Fatalf("invalid compression: got=%d, want=%d", got, want)
+			}
+
+			want := readTree(t, tree)
+			for i := range want {
+				got := nt.entry(int64(i))
+				if !reflect.DeepEqual(got, want[i]) {
+					t.Fatalf("invalid entry %d:\ngot= %v\nwant=%v", i, got, want[i])
+				}
+			}
+		})
+	}
+}
+
+// readTree returns the values of the provided tree, in the layout
+// of the entries of the converted RNTuple.
+func readTree(t *testing.T, tree rtree.Tree) [][]interface{} {
+	t.Helper()
+
+	counts := make(map[rtree.Leaf]struct{})
+	for _, leaf := range tree.Leaves() {
+		if lc := leaf.LeafCount(); lc != nil {
+			counts[lc] = struct{}{}
+		}
+	}
+
+	var (
+		rvars = rtree.NewReadVars(tree)
+		names []string
+		leafs = make(map[string][]int)
+	)
+	for i, rvar := range rvars {
+		if _, ok := counts[tree.Branch(rvar.Name).Leaf(rvar.Leaf)]; ok {
+			continue
+		}
+		if _, ok := leafs[rvar.Name]; !ok {
+			names = append(names, rvar.Name)
+		}
+		leafs[rvar.Name] = append(leafs[rvar.Name], i)
+	}
+
+	r, err := rtree.NewReader(tree, rvars)
+	if err != nil {
+		t.Fatalf("could not create tree reader: %+v", err)
+	}
+	defer r.Close()
+
+	var entries [][]interface{}
+	err = r.Read(func(ctx rtree.RCtx) error {
+		var entry []interface{}
+		for _, name := range names {
+			idx := leafs[name]
+			if len(idx) == 1 {
+				entry = append(entry, ifaceOf(reflect.ValueOf(rvars[idx[0]].Value).Elem()))
+				continue
+			}
+			vs := make([]interface{}, len(idx))
+			for i, j := range idx {
+				vs[i] = ifaceOf(reflect.ValueOf(rvars[j].Value).Elem())
+			}
+			entry = append(entry, vs)
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+
+	return entries
+}
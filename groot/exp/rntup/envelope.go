@@ -0,0 +1,98 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+
+	"go-hep.org/x/hep/groot/internal/xxh3"
+)
+
+// envelope types.
+const (
+	envHeader   = 0x01
+	envFooter   = 0x02
+	envPageList = 0x03
+)
+
+// locator describes a byte range on storage.
+type locator struct {
+	size   int32
+	offset uint64
+}
+
+// link describes the location of an envelope.
+type link struct {
+	length uint64 // uncompressed size of the envelope
+	loc    locator
+}
+
+// ebuf serializes RNTuple meta-data, in little-endian.
+type ebuf struct {
+	p []byte
+}
+
+func (w *ebuf) Len() int { return len(w.p) }
+
+func (w *ebuf) writeU16(v uint16) { w.p = binary.LittleEndian.AppendUint16(w.p, v) }
+func (w *ebuf) writeU32(v uint32) { w.p = binary.LittleEndian.AppendUint32(w.p, v) }
+func (w *ebuf) writeU64(v uint64) { w.p = binary.LittleEndian.AppendUint64(w.p, v) }
+func (w *ebuf) writeI32(v int32)  { w.writeU32(uint32(v)) }
+func (w *ebuf) writeI64(v int64)  { w.writeU64(uint64(v)) }
+
+func (w *ebuf) writeStr(v string) {
+	w.writeU32(uint32(len(v)))
+	w.p = append(w.p, v...)
+}
+
+func (w *ebuf) writeLocator(loc locator) {
+	w.writeI32(loc.size)
+	w.writeU64(loc.offset)
+}
+
+func (w *ebuf) writeLink(lnk link) {
+	w.writeU64(lnk.length)
+	w.writeLocator(lnk.loc)
+}
+
+// beginRecord starts a record frame and returns its position.
+func (w *ebuf) beginRecord() int {
+	pos := len(w.p)
+	w.writeI64(0)
+	return pos
+}
+
+// beginList starts a list frame of n items and returns its position.
+func (w *ebuf) beginList(n int) int {
+	pos := len(w.p)
+	w.writeI64(0)
+	w.writeU32(uint32(n))
+	return pos
+}
+
+// endRecord closes the record frame started at pos.
+func (w *ebuf) endRecord(pos int) {
+	binary.LittleEndian.PutUint64(w.p[pos:], uint64(len(w.p)-pos))
+}
+
+// endList closes the list frame started at pos.
+func (w *ebuf) endList(pos int) {
+	binary.LittleEndian.PutUint64(w.p[pos:], uint64(-int64(len(w.p)-pos)))
+}
+
+// envelope wraps the provided payload into an envelope of type typ,
+// adding the envelope preamble and the trailing checksum.
+func envelope(typ uint16, payload []byte) []byte {
+	const (
+		preamble = 8
+		chksum   = 8
+	)
+	n := preamble + len(payload) + chksum
+	w := ebuf{p: make([]byte, 0, n)}
+	w.writeU64(uint64(typ) | uint64(n)<<16)
+	w.p = append(w.p, payload...)
+	w.writeU64(xxh3.Sum64(w.p))
+	return w.p
+}
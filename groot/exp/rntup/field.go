@@ -0,0 +1,240 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// field structural roles.
+const (
+	roleLeaf       = 0x00
+	roleCollection = 0x01
+	roleRecord     = 0x02
+	roleVariant    = 0x03
+	roleStreamer   = 0x04
+)
+
+// field flags.
+const (
+	flagRepetitive = 0x01
+)
+
+// wfield describes a field being written.
+type wfield struct {
+	id     uint32
+	parent *wfield
+	name   string
+	tname  string // C++ type name of the field
+	role   uint16
+	nrep   uint64 // number of repetitions, for fixed-size arrays
+	cols   []*wcolumn
+	subs   []*wfield
+	fidx   []int // index of the struct fields of the sub-fields, for records
+
+	nitems uint64 // number of items of the collection in the current cluster
+}
+
+func newField(name string, rt reflect.Type, parent *wfield) (*wfield, error) {
+	f := &wfield{
+		parent: parent,
+		name:   name,
+		role:   roleLeaf,
+	}
+
+	var typ ColumnType
+	switch rt.Kind() {
+	case reflect.Bool:
+		f.tname, typ = "bool", ColBit
+	case reflect.Int8:
+		f.tname, typ = "std::int8_t", ColInt8
+	case reflect.Int16:
+		f.tname, typ = "std::int16_t", ColSplitInt16
+	case reflect.Int32:
+		f.tname, typ = "std::int32_t", ColSplitInt32
+	case reflect.Int64, reflect.Int:
+		f.tname, typ = "std::int64_t", ColSplitInt64
+	case reflect.Uint8:
+		f.tname, typ = "std::uint8_t", ColUInt8
+	case reflect.Uint16:
+		f.tname, typ = "std::uint16_t", ColSplitUInt16
+	case reflect.Uint32:
+		f.tname, typ = "std::uint32_t", ColSplitUInt32
+	case reflect.Uint64, reflect.Uint:
+		f.tname, typ = "std::uint64_t", ColSplitUInt64
+	case reflect.Float32:
+		f.tname, typ = "float", ColSplitReal32
+	case reflect.Float64:
+		f.tname, typ = "double", ColSplitReal64
+
+	case reflect.String:
+		f.tname = "std::string"
+		f.cols = []*wcolumn{
+			newColumn(ColSplitIndex64, f),
+			newColumn(ColChar, f),
+		}
+		return f, nil
+
+	case reflect.Slice:
+		item, err := newField("_0", rt.Elem(), f)
+		if err != nil {
+			return nil, err
+		}
+		if item.tname != "" {
+			// collections of untyped records are untyped.
+			f.tname = "std::vector<" + item.tname + ">"
+		}
+		f.role = roleCollection
+		f.cols = []*wcolumn{newColumn(ColSplitIndex64, f)}
+		f.subs = []*wfield{item}
+		return f, nil
+
+	case reflect.Array:
+		item, err := newField("_0", rt.Elem(), f)
+		if err != nil {
+			return nil, err
+		}
+		if item.tname == "" {
+			return nil, fmt.Errorf("rntup: unsupported array of untyped records %v for field %q", rt, name)
+		}
+		f.tname = "std::array<" + item.tname + "," + strconv.Itoa(rt.Len()) + ">"
+		f.nrep = uint64(rt.Len())
+		f.subs = []*wfield{item}
+		return f, nil
+
+	case reflect.Struct:
+		// structs are written as untyped records.
+		f.role = roleRecord
+		for i := 0; i < rt.NumField(); i++ {
+			ft := rt.Field(i)
+			if !ft.IsExported() {
+				continue
+			}
+			sub, err := newField(fieldNameOf(ft), ft.Type, f)
+			if err != nil {
+				return nil, err
+			}
+			f.subs = append(f.subs, sub)
+			f.fidx = append(f.fidx, i)
+		}
+		if len(f.subs) == 0 {
+			return nil, fmt.Errorf("rntup: struct type %v for field %q has no exported fields", rt, name)
+		}
+		return f, nil
+
+	default:
+		return nil, fmt.Errorf("rntup: unsupported type %v for field %q", rt, name)
+	}
+
+	f.cols = []*wcolumn{newColumn(typ, f)}
+	return f, nil
+}
+
+func (f *wfield) flags() uint16 {
+	if f.nrep > 0 {
+		return flagRepetitive
+	}
+	return 0
+}
+
+// write appends the provided value to the columns of the field and
+// returns the number of bytes (before compression) written.
+func (f *wfield) write(rv reflect.Value) int {
+	switch rv.Kind() {
+	case reflect.Bool:
+		f.cols[0].appendBool(rv.Bool())
+		return 1
+	case reflect.Int8:
+		f.cols[0].appendU8(uint8(rv.Int()))
+		return 1
+	case reflect.Int16:
+		f.cols[0].appendU16(uint16(rv.Int()))
+		return 2
+	case reflect.Int32:
+		f.cols[0].appendU32(uint32(rv.Int()))
+		return 4
+	case reflect.Int64, reflect.Int:
+		f.cols[0].appendU64(uint64(rv.Int()))
+		return 8
+	case reflect.Uint8:
+		f.cols[0].appendU8(uint8(rv.Uint()))
+		return 1
+	case reflect.Uint16:
+		f.cols[0].appendU16(uint16(rv.Uint()))
+		return 2
+	case reflect.Uint32:
+		f.cols[0].appendU32(uint32(rv.Uint()))
+		return 4
+	case reflect.Uint64, reflect.Uint:
+		f.cols[0].appendU64(rv.Uint())
+		return 8
+	case reflect.Float32:
+		f.cols[0].appendU32(math.Float32bits(float32(rv.Float())))
+		return 4
+	case reflect.Float64:
+		f.cols[0].appendU64(math.Float64bits(rv.Float()))
+		return 8
+
+	case reflect.String:
+		str := rv.String()
+		f.cols[1].appendBytes([]byte(str))
+		f.nitems += uint64(len(str))
+		f.cols[0].appendU64(f.nitems)
+		return 8 + len(str)
+
+	case reflect.Slice:
+		var (
+			n  = rv.Len()
+			nb = 8
+		)
+		for i := 0; i < n; i++ {
+			nb += f.subs[0].write(rv.Index(i))
+		}
+		f.nitems += uint64(n)
+		f.cols[0].appendU64(f.nitems)
+		return nb
+
+	case reflect.Array:
+		nb := 0
+		for i := 0; i < rv.Len(); i++ {
+			nb += f.subs[0].write(rv.Index(i))
+		}
+		return nb
+
+	case reflect.Struct:
+		nb := 0
+		for i, sub := range f.subs {
+			nb += sub.write(rv.Field(f.fidx[i]))
+		}
+		return nb
+	}
+
+	panic(fmt.Errorf("rntup: unsupported type %v for field %q", rv.Type(), f.name))
+}
+
+// reset resets the cluster-local state of the field and its sub-fields.
+func (f *wfield) reset() {
+	f.nitems = 0
+	for _, sub := range f.subs {
+		sub.reset()
+	}
+}
+
+// fieldNameOf returns the name of the field associated with the provided
+// struct field, taking into account its groot struct tag, if any.
+func fieldNameOf(ft reflect.StructField) string {
+	tag, ok := ft.Tag.Lookup("groot")
+	if !ok || tag == "" {
+		return ft.Name
+	}
+	if i := strings.Index(tag, "["); i > 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
@@ -0,0 +1,536 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+const (
+	defaultPageSize    = 64 * 1024         // approximate size of uncompressed pages, in bytes
+	defaultClusterSize = 128 * 1024 * 1024 // approximate size of uncompressed clusters, in bytes
+
+	writerName = "go-hep.org/x/hep/groot"
+)
+
+// WriteVar describes a variable to be written out to an RNTuple.
+//
+// Supported types are booleans, signed and unsigned integers, floats,
+// strings, structs, as well as slices and arrays of these types.
+// Slices are written as std::vector<T> collections, arrays as
+// std::array<T,N> fields and structs as untyped records.
+// The names of the sub-fields of a record are taken from the groot
+// struct tags of the struct fields, if any.
+type WriteVar struct {
+	Name  string      // name of the field
+	Value interface{} // pointer to the value to write
+}
+
+// WriteOption configures how an RNTuple should be created.
+type WriteOption func(opt *wopt) error
+
+type wopt struct {
+	descr    string // description of the RNTuple
+	compress int32  // compression algorithm name and compression level
+	pagesz   int    // approximate size of uncompressed pages
+	clustsz  int    // approximate size of uncompressed clusters
+}
+
+// WithDescription sets the description of the RNTuple.
+func WithDescription(descr string) WriteOption {
+	return func(opt *wopt) error {
+		opt.descr = descr
+		return nil
+	}
+}
+
+// WithLZ4 configures an RNTuple to use LZ4 as a compression mechanism.
+func WithLZ4(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.LZ4, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithLZMA configures an RNTuple to use LZMA as a compression mechanism.
+func WithLZMA(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.LZMA, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithoutCompression configures an RNTuple to not use any compression mechanism.
+func WithoutCompression() WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = 0
+		return nil
+	}
+}
+
+// WithZlib configures an RNTuple to use zlib as a compression mechanism.
+func WithZlib(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.ZLIB, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithZstd configures an RNTuple to use zstd as a compression mechanism.
+// zstd (level 5) is the default compression mechanism of RNTuples.
+func WithZstd(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.ZSTD, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithPageSize configures the approximate size (in bytes, before compression)
+// of the pages of an RNTuple.
+// If size is <= 0, the default page size is used.
+func WithPageSize(size int) WriteOption {
+	return func(opt *wopt) error {
+		if size <= 0 {
+			size = defaultPageSize
+		}
+		opt.pagesz = size
+		return nil
+	}
+}
+
+// WithClusterSize configures the approximate size (in bytes, before compression)
+// of the clusters of an RNTuple.
+// If size is <= 0, the default cluster size is used.
+func WithClusterSize(size int) WriteOption {
+	return func(opt *wopt) error {
+		if size <= 0 {
+			size = defaultClusterSize
+		}
+		opt.clustsz = size
+		return nil
+	}
+}
+
+// cluster describes a committed cluster.
+type cluster struct {
+	first   int64 // first entry of the cluster
+	entries int64 // number of entries in the cluster
+	cols    []clusterColumn
+}
+
+// clusterColumn describes the pages of a column in a cluster.
+type clusterColumn struct {
+	first int64 // index of the first element of the column in the cluster
+	pages []page
+}
+
+// Writer writes entries to an RNTuple.
+type Writer struct {
+	f     *riofs.File
+	dir   riofs.Directory
+	name  string
+	cfg   wopt
+	wvars []WriteVar
+
+	fields []*wfield  // top-level fields
+	all    []*wfield  // all fields, in on-disk order
+	cols   []*wcolumn // all columns, in on-disk order
+
+	hdr    link   // location of the header envelope
+	chksum uint64 // checksum of the header envelope
+
+	entries  int64 // number of entries written
+	first    int64 // first entry of the current cluster
+	clustsz  int   // size of the current cluster
+	clusters []cluster
+
+	closed bool
+}
+
+// NewWriter creates a new RNTuple with the given name and under the given
+// directory dir, ready to be filled with data.
+func NewWriter(dir riofs.Directory, name string, wvars []WriteVar, opts ...WriteOption) (*Writer, error) {
+	if dir == nil {
+		return nil, fmt.Errorf("rntup: missing parent directory")
+	}
+
+	cfg := wopt{
+		compress: rcompress.Settings{Alg: rcompress.ZSTD, Lvl: 5}.Compression(),
+		pagesz:   defaultPageSize,
+		clustsz:  defaultClusterSize,
+	}
+	for _, opt := range opts {
+		err := opt(&cfg)
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not configure RNTuple writer: %w", err)
+		}
+	}
+
+	w := &Writer{
+		f:     fileOf(dir),
+		dir:   dir,
+		name:  name,
+		cfg:   cfg,
+		wvars: wvars,
+	}
+
+	for _, wvar := range wvars {
+		rv := reflect.ValueOf(wvar.Value)
+		if rv.Kind() != reflect.Ptr {
+			return nil, fmt.Errorf("rntup: write-var %q is not a pointer (type=%T)", wvar.Name, wvar.Value)
+		}
+		f, err := newField(wvar.Name, rv.Type().Elem(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not create field for write-var %q: %w", wvar.Name, err)
+		}
+		w.fields = append(w.fields, f)
+	}
+
+	// on-disk IDs are assigned in a breadth-first order.
+	queue := append([]*wfield(nil), w.fields...)
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		f.id = uint32(len(w.all))
+		w.all = append(w.all, f)
+		queue = append(queue, f.subs...)
+	}
+	for _, f := range w.all {
+		for _, col := range f.cols {
+			col.id = uint32(len(w.cols))
+			w.cols = append(w.cols, col)
+		}
+	}
+
+	env := envelope(envHeader, w.header())
+	hdr, err := w.writeEnvelope(env)
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not write RNTuple header: %w", err)
+	}
+	w.hdr = hdr
+	w.chksum = binary.LittleEndian.Uint64(env[len(env)-8:])
+
+	return w, nil
+}
+
+// Name returns the name of the RNTuple.
+func (w *Writer) Name() string { return w.name }
+
+// Entries returns the number of entries written so far.
+func (w *Writer) Entries() int64 { return w.entries }
+
+// Write writes the current values of the write-vars to the RNTuple and
+// returns the number of bytes (before compression) written.
+func (w *Writer) Write() (int, error) {
+	if w.closed {
+		return 0, fmt.Errorf("rntup: RNTuple %q already closed", w.name)
+	}
+
+	tot := 0
+	for i, f := range w.fields {
+		tot += f.write(reflect.ValueOf(w.wvars[i].Value).Elem())
+	}
+	w.entries++
+	w.clustsz += tot
+
+	for _, col := range w.cols {
+		if len(col.buf) < w.cfg.pagesz {
+			continue
+		}
+		err := w.commitPage(col)
+		if err != nil {
+			return tot, fmt.Errorf("rntup: could not commit page of field %q: %w", col.field.name, err)
+		}
+	}
+
+	if w.clustsz >= w.cfg.clustsz {
+		err := w.Flush()
+		if err != nil {
+			return tot, err
+		}
+	}
+
+	return tot, nil
+}
+
+// Flush commits the current cluster to stable storage.
+func (w *Writer) Flush() error {
+	if w.entries == w.first {
+		return nil
+	}
+
+	clu := cluster{
+		first:   w.first,
+		entries: w.entries - w.first,
+		cols:    make([]clusterColumn, len(w.cols)),
+	}
+	for i, col := range w.cols {
+		err := w.commitPage(col)
+		if err != nil {
+			return fmt.Errorf("rntup: could not commit page of field %q: %w", col.field.name, err)
+		}
+		clu.cols[i] = clusterColumn{first: col.first, pages: col.pages}
+		col.first = col.tot
+		col.pages = nil
+	}
+	w.clusters = append(w.clusters, clu)
+
+	for _, f := range w.fields {
+		f.reset()
+	}
+	w.first = w.entries
+	w.clustsz = 0
+
+	return nil
+}
+
+// Close writes the RNTuple meta-data and the RNTuple anchor to the
+// parent directory.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	defer func() {
+		w.closed = true
+	}()
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("rntup: could not flush RNTuple %q: %w", w.name, err)
+	}
+
+	var plist link
+	if len(w.clusters) > 0 {
+		plist, err = w.writeEnvelope(envelope(envPageList, w.pageList()))
+		if err != nil {
+			return fmt.Errorf("rntup: could not write RNTuple page list: %w", err)
+		}
+	}
+
+	footer, err := w.writeEnvelope(envelope(envFooter, w.footer(plist)))
+	if err != nil {
+		return fmt.Errorf("rntup: could not write RNTuple footer: %w", err)
+	}
+
+	anchor := &Anchor{
+		epoch:        VersionEpoch,
+		major:        VersionMajor,
+		minor:        VersionMinor,
+		patch:        VersionPatch,
+		seekHeader:   w.hdr.loc.offset,
+		nbytesHeader: uint64(w.hdr.loc.size),
+		lenHeader:    w.hdr.length,
+		seekFooter:   footer.loc.offset,
+		nbytesFooter: uint64(footer.loc.size),
+		lenFooter:    footer.length,
+		maxKeySize:   defaultMaxKeySize,
+	}
+
+	err = w.dir.Put(w.name, anchor)
+	if err != nil {
+		return fmt.Errorf("rntup: could not save RNTuple anchor %q: %w", w.name, err)
+	}
+
+	return nil
+}
+
+// header returns the payload of the header envelope.
+func (w *Writer) header() []byte {
+	var buf ebuf
+	buf.writeU64(0) // feature flags
+	buf.writeStr(w.name)
+	buf.writeStr(w.cfg.descr)
+	buf.writeStr(writerName)
+
+	fields := buf.beginList(len(w.all))
+	for _, f := range w.all {
+		rec := buf.beginRecord()
+		buf.writeU32(0) // field version
+		buf.writeU32(0) // type version
+		switch f.parent {
+		case nil:
+			buf.writeU32(f.id)
+		default:
+			buf.writeU32(f.parent.id)
+		}
+		buf.writeU16(f.role)
+		buf.writeU16(f.flags())
+		if f.nrep > 0 {
+			buf.writeU64(f.nrep)
+		}
+		buf.writeStr(f.name)
+		buf.writeStr(f.tname)
+		buf.writeStr("") // type alias
+		buf.writeStr("") // field description
+		buf.endRecord(rec)
+	}
+	buf.endList(fields)
+
+	cols := buf.beginList(len(w.cols))
+	for _, col := range w.cols {
+		rec := buf.beginRecord()
+		buf.writeU16(uint16(col.typ))
+		buf.writeU16(col.typ.bits())
+		buf.writeU32(col.field.id)
+		buf.writeU16(0) // flags
+		buf.writeU16(0) // representation index
+		buf.endRecord(rec)
+	}
+	buf.endList(cols)
+
+	buf.endList(buf.beginList(0)) // alias columns
+	buf.endList(buf.beginList(0)) // extra type information
+
+	return buf.p
+}
+
+// pageList returns the payload of the page list envelope, describing
+// all the clusters of the RNTuple.
+func (w *Writer) pageList() []byte {
+	var buf ebuf
+	buf.writeU64(w.chksum)
+
+	summaries := buf.beginList(len(w.clusters))
+	for _, clu := range w.clusters {
+		rec := buf.beginRecord()
+		buf.writeI64(clu.first)
+		buf.writeI64(clu.entries) // flags are stored in the 8 most significant bits.
+		buf.endRecord(rec)
+	}
+	buf.endList(summaries)
+
+	clusters := buf.beginList(len(w.clusters))
+	for _, clu := range w.clusters {
+		cols := buf.beginList(len(clu.cols))
+		for _, col := range clu.cols {
+			pages := buf.beginList(len(col.pages))
+			for _, p := range col.pages {
+				buf.writeI32(p.n)
+				buf.writeLocator(p.loc)
+			}
+			buf.writeI64(col.first)
+			buf.writeU32(uint32(w.cfg.compress))
+			buf.endList(pages)
+		}
+		buf.endList(cols)
+	}
+	buf.endList(clusters)
+
+	return buf.p
+}
+
+// footer returns the payload of the footer envelope.
+func (w *Writer) footer(plist link) []byte {
+	var buf ebuf
+	buf.writeU64(0) // feature flags
+	buf.writeU64(w.chksum)
+
+	ext := buf.beginRecord() // schema extension
+	buf.endList(buf.beginList(0))
+	buf.endList(buf.beginList(0))
+	buf.endList(buf.beginList(0))
+	buf.endList(buf.beginList(0))
+	buf.endRecord(ext)
+
+	ngroups := 0
+	if len(w.clusters) > 0 {
+		ngroups = 1
+	}
+	groups := buf.beginList(ngroups)
+	if ngroups > 0 {
+		rec := buf.beginRecord()
+		buf.writeI64(0)         // minimum entry
+		buf.writeI64(w.entries) // entry span
+		buf.writeU32(uint32(len(w.clusters)))
+		buf.writeLink(plist)
+		buf.endRecord(rec)
+	}
+	buf.endList(groups)
+
+	return buf.p
+}
+
+// commitPage compresses and writes the buffered elements of the provided
+// column to a new page.
+func (w *Writer) commitPage(col *wcolumn) error {
+	if col.n == 0 {
+		return nil
+	}
+
+	loc, err := w.writeBlob(col.encode())
+	if err != nil {
+		return err
+	}
+
+	col.pages = append(col.pages, page{n: int32(col.n), loc: loc})
+	col.tot += col.n
+	col.n = 0
+	col.buf = col.buf[:0]
+	return nil
+}
+
+// writeEnvelope compresses and writes the provided envelope.
+func (w *Writer) writeEnvelope(env []byte) (link, error) {
+	loc, err := w.writeBlob(env)
+	if err != nil {
+		return link{}, err
+	}
+	return link{length: uint64(len(env)), loc: loc}, nil
+}
+
+// writeBlob compresses and writes the provided data as an anonymous
+// key to the underlying ROOT file.
+// writeBlob returns the location of the (compressed) data.
+func (w *Writer) writeBlob(data []byte) (locator, error) {
+	zip, err := rcompress.Compress(nil, data, w.cfg.compress)
+	if err != nil {
+		return locator{}, fmt.Errorf("rntup: could not compress blob: %w", err)
+	}
+	if len(zip) > defaultMaxKeySize {
+		return locator{}, fmt.Errorf("rntup: blob too big (size=%d)", len(zip))
+	}
+
+	key, err := riofs.NewKey(nil, "", "", "RBlob", 1, zip, w.f, riofs.WithKeyCompression(0))
+	if err != nil {
+		return locator{}, fmt.Errorf("rntup: could not create blob key: %w", err)
+	}
+
+	buf := rbytes.NewWBuffer(make([]byte, key.KeyLen()), nil, 0, w.f)
+	_, err = key.MarshalROOT(buf)
+	if err != nil {
+		return locator{}, fmt.Errorf("rntup: could not marshal blob key: %w", err)
+	}
+
+	_, err = w.f.WriteAt(buf.Bytes(), key.SeekKey())
+	if err != nil {
+		return locator{}, fmt.Errorf("rntup: could not write blob key: %w", err)
+	}
+
+	off := key.SeekKey() + int64(key.KeyLen())
+	_, err = w.f.WriteAt(key.Buffer(), off)
+	if err != nil {
+		return locator{}, fmt.Errorf("rntup: could not write blob: %w", err)
+	}
+
+	return locator{size: int32(len(zip)), offset: uint64(off)}, nil
+}
+
+func fileOf(d riofs.Directory) *riofs.File {
+	const max = 1<<31 - 1
+	for i := 0; i < max; i++ {
+		p := d.Parent()
+		if p == nil {
+			return d.(*riofs.File)
+		}
+		d = p
+	}
+	panic("impossible")
+}
@@ -0,0 +1,853 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/internal/xxh3"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestAnchor(t *testing.T) {
+	want := &Anchor{
+		epoch: 1, major: 2, minor: 3, patch: 4,
+		seekHeader: 5, nbytesHeader: 6, lenHeader: 7,
+		seekFooter: 8, nbytesFooter: 9, lenFooter: 10,
+		maxKeySize: 11,
+	}
+
+	wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+	_, err := want.MarshalROOT(wbuf)
+	if err != nil {
+		t.Fatalf("could not marshal anchor: %+v", err)
+	}
+
+	if got, want := len(wbuf.Bytes()), 4+2+4*2+7*8+8; got != want {
+		t.Fatalf("invalid anchor size: got=%d, want=%d", got, want)
+	}
+
+	var got Anchor
+	err = got.UnmarshalROOT(rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, nil))
+	if err != nil {
+		t.Fatalf("could not unmarshal anchor: %+v", err)
+	}
+	if got != *want {
+		t.Fatalf("invalid r/w round-trip:\ngot= %v\nwant=%v", &got, want)
+	}
+
+	raw := wbuf.Bytes()
+	raw[len(raw)-1] ^= 0xff
+	err = got.UnmarshalROOT(rbytes.NewRBuffer(raw, nil, 0, nil))
+	if err == nil {
+		t.Fatalf("expected a checksum error")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, tc := range []struct {
+		typ  ColumnType
+		vs   []uint64
+		want []byte
+	}{
+		{
+			typ:  ColBit,
+			vs:   []uint64{1, 0, 1, 1, 0, 0, 0, 0, 1},
+			want: []byte{0x0d, 0x01},
+		},
+		{
+			typ:  ColSplitUInt32,
+			vs:   []uint64{0x04030201, 0x14131211},
+			want: []byte{0x01, 0x11, 0x02, 0x12, 0x03, 0x13, 0x04, 0x14},
+		},
+		{
+			typ:  ColSplitInt16,
+			vs:   []uint64{1, 0xffff, 0x100},
+			want: []byte{0x02, 0x01, 0x00, 0x00, 0x00, 0x02},
+		},
+		{
+			typ: ColSplitIndex64,
+			vs:  []uint64{3, 5, 5, 10},
+			want: []byte{
+				3, 2, 0, 5,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+			},
+		},
+	} {
+		t.Run(tc.typ.String(), func(t *testing.T) {
+			col := newColumn(tc.typ, nil)
+			for _, v := range tc.vs {
+				switch tc.typ.size() {
+				case 1:
+					col.appendBool(v != 0)
+				case 2:
+					col.appendU16(uint16(v))
+				case 4:
+					col.appendU32(uint32(v))
+				case 8:
+					col.appendU64(v)
+				}
+			}
+			got := col.encode()
+			if !bytes.Equal(got, tc.want) {
+				t.Fatalf("invalid encoding:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	type P2 struct {
+		X float64
+		Y int32 `groot:"y"`
+	}
+	type Data struct {
+		B    bool
+		I8   int8
+		I16  int16
+		I32  int32
+		I64  int64
+		U8   uint8
+		U16  uint16
+		U32  uint32
+		U64  uint64
+		F32  float32
+		F64  float64
+		Str  string
+		Arr  [3]float64
+		SliF []float32
+		SliS []string
+		SliV [][]int32
+		Rec  P2
+		SliR []P2
+	}
+
+	const nevts = 1000
+	gen := func(i int) Data {
+		d := Data{
+			B:   i%3 == 0,
+			I8:  int8(-i),
+			I16: int16(-i * 10),
+			I32: int32(-i * 100),
+			I64: int64(-i * 1000),
+			U8:  uint8(i),
+			U16: uint16(i * 10),
+			U32: uint32(i * 100),
+			U64: uint64(i * 1000),
+			F32: float32(i) + 0.5,
+			F64: float64(i) + 0.25,
+			Str: strings.Repeat("x", i%5) + strconv.Itoa(i),
+			Arr: [3]float64{float64(i), float64(2 * i), math.Inf(-1)},
+			Rec: P2{X: float64(i), Y: int32(-i)},
+		}
+		for j := 0; j < i%4; j++ {
+			d.SliF = append(d.SliF, float32(i+j))
+			d.SliS = append(d.SliS, fmt.Sprintf("s-%d-%d", i, j))
+			d.SliV = append(d.SliV, make([]int32, j))
+			for k := range d.SliV[j] {
+				d.SliV[j][k] = int32(i + k)
+			}
+			d.SliR = append(d.SliR, P2{X: float64(j), Y: int32(i)})
+		}
+		return d
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []WriteOption
+	}{
+		{name: "default"},
+		{name: "no-compression", opts: []WriteOption{WithoutCompression()}},
+		{name: "zlib", opts: []WriteOption{WithZlib(1)}},
+		{name: "small-pages", opts: []WriteOption{WithPageSize(128)}},
+		{name: "small-clusters", opts: []WriteOption{WithPageSize(256), WithClusterSize(2048)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "rntuple.root")
+			f, err := riofs.Create(fname)
+			if err != nil {
+				t.Fatalf("could not create file: %+v", err)
+			}
+			defer f.Close()
+
+			var data Data
+			rv := reflect.ValueOf(&data).Elem()
+			var wvars []WriteVar
+			for i := 0; i < rv.NumField(); i++ {
+				wvars = append(wvars, WriteVar{
+					Name:  rv.Type().Field(i).Name,
+					Value: rv.Field(i).Addr().Interface(),
+				})
+			}
+
+			opts := append([]WriteOption{WithDescription("my ntuple")}, tc.opts...)
+			w, err := NewWriter(f, "ntpl", wvars, opts...)
+			if err != nil {
+				t.Fatalf("could not create writer: %+v", err)
+			}
+
+			for i := 0; i < nevts; i++ {
+				data = gen(i)
+				_, err = w.Write()
+				if err != nil {
+					t.Fatalf("could not write entry %d: %+v", i, err)
+				}
+			}
+
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close writer: %+v", err)
+			}
+
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %+v", err)
+			}
+
+			f, err = riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			obj, err := f.Get("ntpl")
+			if err != nil {
+				t.Fatalf("could not get anchor: %+v", err)
+			}
+			anchor, ok := obj.(*Anchor)
+			if !ok {
+				t.Fatalf("invalid anchor type: %T", obj)
+			}
+			if epoch, major, _, _ := anchor.Version(); epoch != 1 || major != 0 {
+				t.Fatalf("invalid anchor version: %v", anchor)
+			}
+
+			nt, err := readNTuple(f, anchor)
+			if err != nil {
+				t.Fatalf("could not read RNTuple: %+v", err)
+			}
+
+			if got, want := nt.name, "ntpl"; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			if got, want := nt.descr, "my ntuple"; got != want {
+				t.Fatalf("invalid description: got=%q, want=%q", got, want)
+			}
+			if got, want := nt.entries, int64(nevts); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			var types []string
+			for _, f := range nt.fields {
+				if f.parent != f.id {
+					continue
+				}
+				types = append(types, f.name+":"+f.tname)
+			}
+			want := []string{
+				"B:bool",
+				"I8:std::int8_t",
+				"I16:std::int16_t",
+				"I32:std::int32_t",
+				"I64:std::int64_t",
+				"U8:std::uint8_t",
+				"U16:std::uint16_t",
+				"U32:std::uint32_t",
+				"U64:std::uint64_t",
+				"F32:float",
+				"F64:double",
+				"Str:std::string",
+				"Arr:std::array<double,3>",
+				"SliF:std::vector<float>",
+				"SliS:std::vector<std::string>",
+				"SliV:std::vector<std::vector<std::int32_t>>",
+				"Rec:",
+				"SliR:",
+			}
+			if !reflect.DeepEqual(types, want) {
+				t.Fatalf("invalid fields:\ngot= %q\nwant=%q", types, want)
+			}
+
+			for i := 0; i < nevts; i++ {
+				want := toIface(reflect.ValueOf(gen(i)))
+				got := nt.entry(int64(i))
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("invalid entry %d:\ngot= %v\nwant=%v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "rntuple.root")
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	var x float64
+	w, err := NewWriter(f, "ntpl", []WriteVar{{Name: "x", Value: &x}})
+	if err != nil {
+		t.Fatalf("could not create writer: %+v", err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close writer: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	f, err = riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("ntpl")
+	if err != nil {
+		t.Fatalf("could not get anchor: %+v", err)
+	}
+
+	nt, err := readNTuple(f, obj.(*Anchor))
+	if err != nil {
+		t.Fatalf("could not read RNTuple: %+v", err)
+	}
+	if nt.entries != 0 {
+		t.Fatalf("invalid number of entries: got=%d, want=0", nt.entries)
+	}
+}
+
+func TestWriterInvalid(t *testing.T) {
+	f, err := riofs.Create(filepath.Join(t.TempDir(), "rntuple.root"))
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	for _, tc := range []struct {
+		name string
+		wvar WriteVar
+		want string
+	}{
+		{
+			name: "not-a-pointer",
+			wvar: WriteVar{Name: "x", Value: 42.0},
+			want: `rntup: write-var "x" is not a pointer (type=float64)`,
+		},
+		{
+			name: "map",
+			wvar: WriteVar{Name: "x", Value: new(map[string]int)},
+			want: `rntup: could not create field for write-var "x": rntup: unsupported type map[string]int for field "x"`,
+		},
+		{
+			name: "slice-of-complex",
+			wvar: WriteVar{Name: "x", Value: new([]complex128)},
+			want: `rntup: could not create field for write-var "x": rntup: unsupported type complex128 for field "_0"`,
+		},
+		{
+			name: "empty-struct",
+			wvar: WriteVar{Name: "x", Value: new(struct{ x int })},
+			want: `rntup: could not create field for write-var "x": rntup: struct type struct { x int } for field "x" has no exported fields`,
+		},
+		{
+			name: "array-of-structs",
+			wvar: WriteVar{Name: "x", Value: new([2]struct{ X int })},
+			want: `rntup: could not create field for write-var "x": rntup: unsupported array of untyped records [2]struct { X int } for field "x"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWriter(f, "ntpl", []WriteVar{tc.wvar})
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.want; got != want {
+				t.Fatalf("invalid error:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+}
+
+// toIface converts the fields of the provided struct value into the
+// values returned by rntuple.entry.
+func toIface(rv reflect.Value) []interface{} {
+	o := make([]interface{}, rv.NumField())
+	for i := range o {
+		o[i] = ifaceOf(rv.Field(i))
+	}
+	return o
+}
+
+// ifaceOf converts the provided value into the value returned by rntuple.value.
+func ifaceOf(rv reflect.Value) interface{} {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		o := make([]interface{}, rv.Len())
+		for i := range o {
+			o[i] = ifaceOf(rv.Index(i))
+		}
+		return o
+	case reflect.Struct:
+		return toIface(rv)
+	case reflect.Float32:
+		return float32(rv.Float())
+	case reflect.Float64:
+		return rv.Float()
+	}
+	return rv.Interface()
+}
+
+// rntuple is a minimal RNTuple reader, used to test the writer.
+type rntuple struct {
+	name    string
+	descr   string
+	entries int64
+	compr   uint32 // compression settings of the last page range
+	fields  []rfield
+	cols    []rcolumn
+}
+
+type rfield struct {
+	id     uint32
+	parent uint32
+	role   uint16
+	nrep   uint64
+	name   string
+	tname  string
+	subs   []uint32
+	cols   []uint32
+}
+
+type rcolumn struct {
+	typ   ColumnType
+	field uint32
+	data  []uint64 // decoded elements
+}
+
+// rbuf decodes RNTuple meta-data.
+type rbuf struct {
+	p   []byte
+	c   int
+	err error
+}
+
+func (r *rbuf) next(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if r.c+n > len(r.p) {
+		r.err = fmt.Errorf("short buffer (pos=%d, n=%d, len=%d)", r.c, n, len(r.p))
+		return make([]byte, n)
+	}
+	o := r.p[r.c : r.c+n]
+	r.c += n
+	return o
+}
+
+func (r *rbuf) u16() uint16 { return binary.LittleEndian.Uint16(r.next(2)) }
+func (r *rbuf) u32() uint32 { return binary.LittleEndian.Uint32(r.next(4)) }
+func (r *rbuf) u64() uint64 { return binary.LittleEndian.Uint64(r.next(8)) }
+func (r *rbuf) i32() int32  { return int32(r.u32()) }
+func (r *rbuf) i64() int64  { return int64(r.u64()) }
+func (r *rbuf) str() string { return string(r.next(int(r.u32()))) }
+
+func (r *rbuf) locator() locator {
+	return locator{size: r.i32(), offset: r.u64()}
+}
+
+// record reads a record frame header and returns the end of the frame.
+func (r *rbuf) record() int {
+	beg := r.c
+	n := r.i64()
+	if n < 0 {
+		r.err = fmt.Errorf("expected a record frame (size=%d)", n)
+	}
+	return beg + int(n)
+}
+
+// list reads a list frame header and returns the number of items and
+// the end of the frame.
+func (r *rbuf) list() (int, int) {
+	beg := r.c
+	n := r.i64()
+	if n >= 0 {
+		r.err = fmt.Errorf("expected a list frame (size=%d)", n)
+	}
+	return int(r.u32()), beg - int(n)
+}
+
+func (r *rbuf) skip(end int) {
+	if r.err == nil && r.c > end {
+		r.err = fmt.Errorf("frame overflow (pos=%d, end=%d)", r.c, end)
+	}
+	r.c = end
+}
+
+func readBlob(f *riofs.File, loc locator, n int) ([]byte, error) {
+	raw := make([]byte, loc.size)
+	_, err := f.ReadAt(raw, int64(loc.offset))
+	if err != nil {
+		return nil, fmt.Errorf("could not read blob: %w", err)
+	}
+	if int(loc.size) == n {
+		return raw, nil
+	}
+	out := make([]byte, n)
+	err = rcompress.Decompress(out, bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress blob: %w", err)
+	}
+	return out, nil
+}
+
+// readEnvelope reads the envelope located at lnk and returns its payload
+// and its checksum.
+func readEnvelope(f *riofs.File, typ uint16, lnk link) (*rbuf, uint64, error) {
+	raw, err := readBlob(f, lnk.loc, int(lnk.length))
+	if err != nil {
+		return nil, 0, err
+	}
+	pre := binary.LittleEndian.Uint64(raw)
+	if got, want := uint16(pre&0xffff), typ; got != want {
+		return nil, 0, fmt.Errorf("invalid envelope type: got=%d, want=%d", got, want)
+	}
+	if got, want := pre>>16, uint64(len(raw)); got != want {
+		return nil, 0, fmt.Errorf("invalid envelope length: got=%d, want=%d", got, want)
+	}
+	n := len(raw) - 8
+	chksum := binary.LittleEndian.Uint64(raw[n:])
+	if got := xxh3.Sum64(raw[:n]); got != chksum {
+		return nil, 0, fmt.Errorf("invalid envelope checksum: got=0x%x, want=0x%x", got, chksum)
+	}
+	return &rbuf{p: raw[:n], c: 8}, chksum, nil
+}
+
+func readNTuple(f *riofs.File, anchor *Anchor) (*rntuple, error) {
+	var nt rntuple
+
+	hdr, hdrChecksum, err := readEnvelope(f, envHeader, link{
+		length: anchor.lenHeader,
+		loc:    locator{size: int32(anchor.nbytesHeader), offset: anchor.seekHeader},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read header: %w", err)
+	}
+
+	_ = hdr.u64() // feature flags
+	nt.name = hdr.str()
+	nt.descr = hdr.str()
+	if got, want := hdr.str(), writerName; got != want {
+		return nil, fmt.Errorf("invalid writer: got=%q, want=%q", got, want)
+	}
+
+	n, end := hdr.list()
+	for i := 0; i < n; i++ {
+		rend := hdr.record()
+		fd := rfield{id: uint32(i)}
+		_ = hdr.u32() // field version
+		_ = hdr.u32() // type version
+		fd.parent = hdr.u32()
+		fd.role = hdr.u16()
+		flags := hdr.u16()
+		if flags&flagRepetitive != 0 {
+			fd.nrep = hdr.u64()
+		}
+		fd.name = hdr.str()
+		fd.tname = hdr.str()
+		_ = hdr.str() // type alias
+		_ = hdr.str() // description
+		hdr.skip(rend)
+		nt.fields = append(nt.fields, fd)
+	}
+	hdr.skip(end)
+	for i, fd := range nt.fields {
+		if fd.parent != fd.id {
+			nt.fields[fd.parent].subs = append(nt.fields[fd.parent].subs, uint32(i))
+		}
+	}
+
+	n, end = hdr.list()
+	for i := 0; i < n; i++ {
+		rend := hdr.record()
+		col := rcolumn{typ: ColumnType(hdr.u16())}
+		if got, want := hdr.u16(), col.typ.bits(); got != want {
+			return nil, fmt.Errorf("invalid bits on storage for column %d: got=%d, want=%d", i, got, want)
+		}
+		col.field = hdr.u32()
+		_ = hdr.u16() // flags
+		_ = hdr.u16() // representation index
+		hdr.skip(rend)
+		nt.cols = append(nt.cols, col)
+		nt.fields[col.field].cols = append(nt.fields[col.field].cols, uint32(i))
+	}
+	hdr.skip(end)
+	if hdr.err != nil {
+		return nil, fmt.Errorf("could not decode header: %w", hdr.err)
+	}
+
+	ftr, _, err := readEnvelope(f, envFooter, link{
+		length: anchor.lenFooter,
+		loc:    locator{size: int32(anchor.nbytesFooter), offset: anchor.seekFooter},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read footer: %w", err)
+	}
+	_ = ftr.u64() // feature flags
+	if got, want := ftr.u64(), hdrChecksum; got != want {
+		return nil, fmt.Errorf("invalid footer header-checksum: got=0x%x, want=0x%x", got, want)
+	}
+	ftr.skip(ftr.record()) // schema extension
+
+	var plists []link
+	n, end = ftr.list()
+	for i := 0; i < n; i++ {
+		rend := ftr.record()
+		_ = ftr.u64() // min entry
+		nt.entries += ftr.i64()
+		_ = ftr.u32() // number of clusters
+		plists = append(plists, link{length: ftr.u64(), loc: ftr.locator()})
+		ftr.skip(rend)
+	}
+	ftr.skip(end)
+	if ftr.err != nil {
+		return nil, fmt.Errorf("could not decode footer: %w", ftr.err)
+	}
+
+	for _, lnk := range plists {
+		pl, _, err := readEnvelope(f, envPageList, lnk)
+		if err != nil {
+			return nil, fmt.Errorf("could not read page list: %w", err)
+		}
+		if got, want := pl.u64(), hdrChecksum; got != want {
+			return nil, fmt.Errorf("invalid page-list header-checksum: got=0x%x, want=0x%x", got, want)
+		}
+		_, send := pl.list() // cluster summaries
+		pl.skip(send)
+
+		nclusters, end := pl.list()
+		for i := 0; i < nclusters; i++ {
+			ncols, cend := pl.list()
+			if ncols != len(nt.cols) {
+				return nil, fmt.Errorf("invalid number of columns: got=%d, want=%d", ncols, len(nt.cols))
+			}
+			bases := make([]int, ncols)
+			for icol, col := range nt.cols {
+				bases[icol] = len(col.data)
+			}
+			for icol := range nt.cols {
+				col := &nt.cols[icol]
+				npages, pend := pl.list()
+				var data []uint64
+				for j := 0; j < npages; j++ {
+					nelems := pl.i32()
+					loc := pl.locator()
+					vs, err := readPage(f, col.typ, int(nelems), loc)
+					if err != nil {
+						return nil, fmt.Errorf("could not read page: %w", err)
+					}
+					data = append(data, vs...)
+				}
+				first := pl.i64()
+				nt.compr = pl.u32()
+				pl.skip(pend)
+
+				if got, want := first, int64(len(col.data)); got != want {
+					return nil, fmt.Errorf("invalid first element index: got=%d, want=%d", got, want)
+				}
+				col.data = append(col.data, data...)
+			}
+			pl.skip(cend)
+
+			// make cluster-local collection offsets global.
+			for icol := range nt.cols {
+				col := &nt.cols[icol]
+				if col.typ != ColSplitIndex64 {
+					continue
+				}
+				fd := nt.fields[col.field]
+				item := fd.cols[len(fd.cols)-1]
+				if fd.role == roleCollection {
+					item = nt.firstColumn(fd.subs[0])
+				}
+				for j := bases[icol]; j < len(col.data); j++ {
+					col.data[j] += uint64(bases[item])
+				}
+			}
+		}
+		pl.skip(end)
+		if pl.err != nil {
+			return nil, fmt.Errorf("could not decode page list: %w", pl.err)
+		}
+	}
+
+	return &nt, nil
+}
+
+// firstColumn returns the index of the first column of the provided field
+// or of its first sub-field.
+func (nt *rntuple) firstColumn(id uint32) uint32 {
+	fd := nt.fields[id]
+	if len(fd.cols) > 0 {
+		return fd.cols[0]
+	}
+	return nt.firstColumn(fd.subs[0])
+}
+
+func readPage(f *riofs.File, typ ColumnType, n int, loc locator) ([]uint64, error) {
+	size := n * int(typ.bits()) / 8
+	if typ == ColBit {
+		size = (n + 7) / 8
+	}
+	raw, err := readBlob(f, loc, size)
+	if err != nil {
+		return nil, err
+	}
+
+	unsplit := func(sz int) []uint64 {
+		o := make([]uint64, n)
+		for i := range o {
+			var v uint64
+			for b := 0; b < sz; b++ {
+				v |= uint64(raw[b*n+i]) << (8 * b)
+			}
+			o[i] = v
+		}
+		return o
+	}
+	unzigzag := func(vs []uint64) []uint64 {
+		for i, v := range vs {
+			vs[i] = (v >> 1) ^ -(v & 1)
+		}
+		return vs
+	}
+
+	switch typ {
+	case ColBit:
+		o := make([]uint64, n)
+		for i := range o {
+			o[i] = uint64(raw[i/8]>>(i%8)) & 1
+		}
+		return o, nil
+	case ColChar, ColInt8, ColUInt8:
+		o := make([]uint64, n)
+		for i := range o {
+			o[i] = uint64(raw[i])
+		}
+		return o, nil
+	case ColSplitUInt16:
+		return unsplit(2), nil
+	case ColSplitUInt32, ColSplitReal32:
+		return unsplit(4), nil
+	case ColSplitUInt64, ColSplitReal64:
+		return unsplit(8), nil
+	case ColSplitInt16:
+		return unzigzag(unsplit(2)), nil
+	case ColSplitInt32:
+		return unzigzag(unsplit(4)), nil
+	case ColSplitInt64:
+		return unzigzag(unsplit(8)), nil
+	case ColSplitIndex64:
+		o := unsplit(8)
+		for i := 1; i < len(o); i++ {
+			o[i] += o[i-1]
+		}
+		return o, nil
+	}
+	return nil, fmt.Errorf("unknown column type %v", typ)
+}
+
+// entry returns the values of the top-level fields for the i-th entry.
+func (nt *rntuple) entry(i int64) []interface{} {
+	var o []interface{}
+	for _, fd := range nt.fields {
+		if fd.parent != fd.id {
+			continue
+		}
+		o = append(o, nt.value(fd.id, i))
+	}
+	return o
+}
+
+// rng returns the range of items of the i-th element of a collection column.
+func (col *rcolumn) rng(i int64) (beg, end int64) {
+	if i > 0 {
+		beg = int64(col.data[i-1])
+	}
+	return beg, int64(col.data[i])
+}
+
+func (nt *rntuple) value(id uint32, i int64) interface{} {
+	fd := nt.fields[id]
+	switch {
+	case fd.nrep > 0:
+		o := make([]interface{}, fd.nrep)
+		for j := range o {
+			o[j] = nt.value(fd.subs[0], i*int64(fd.nrep)+int64(j))
+		}
+		return o
+	case fd.role == roleRecord:
+		o := make([]interface{}, len(fd.subs))
+		for j, sub := range fd.subs {
+			o[j] = nt.value(sub, i)
+		}
+		return o
+	case fd.role == roleCollection:
+		beg, end := nt.cols[fd.cols[0]].rng(i)
+		o := make([]interface{}, 0, end-beg)
+		for j := beg; j < end; j++ {
+			o = append(o, nt.value(fd.subs[0], j))
+		}
+		return o
+	case fd.tname == "std::string":
+		beg, end := nt.cols[fd.cols[0]].rng(i)
+		chars := nt.cols[fd.cols[1]].data[beg:end]
+		o := make([]byte, len(chars))
+		for j, c := range chars {
+			o[j] = byte(c)
+		}
+		return string(o)
+	}
+
+	v := nt.cols[fd.cols[0]].data[i]
+	switch fd.tname {
+	case "bool":
+		return v != 0
+	case "std::int8_t":
+		return int8(v)
+	case "std::int16_t":
+		return int16(v)
+	case "std::int32_t":
+		return int32(v)
+	case "std::int64_t":
+		return int64(v)
+	case "std::uint8_t":
+		return uint8(v)
+	case "std::uint16_t":
+		return uint16(v)
+	case "std::uint32_t":
+		return uint32(v)
+	case "std::uint64_t":
+		return v
+	case "float":
+		return math.Float32frombits(uint32(v))
+	case "double":
+		return math.Float64frombits(v)
+	}
+	panic(fmt.Errorf("unknown field type %q", fd.tname))
+}
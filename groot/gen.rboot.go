@@ -28,7 +28,7 @@ import (
 var (
 	classes = []string{
 		// rbase
-		"TAttAxis", "TAttBBox2D", "TAttFill", "TAttLine", "TAttMarker", "TAttPad",
+		"TAtt3D", "TAttAxis", "TAttBBox2D", "TAttFill", "TAttLine", "TAttMarker", "TAttPad", "TAttText",
 		"TDatime",
 		"TNamed",
 		"TObject", "TObjString",
@@ -79,6 +79,7 @@ var (
 		"TGraph", "TGraphErrors", "TGraphAsymmErrors", "TGraphMultiErrors",
 		"TH1", "TH1C", "TH1D", "TH1F", "TH1I", "TH1K", "TH1S",
 		"TH2", "TH2C", "TH2D", "TH2F", "TH2I", "TH2Poly", "TH2PolyBin", "TH2S",
+		"TH3", "TH3D", "TH3F", "TH3I",
 		"TLimit", "TLimitDataSource",
 		"TMultiGraph",
 		"TProfile", "TProfile2D",
@@ -92,6 +93,7 @@ var (
 
 		// rntup
 		// "ROOT::Experimental::RNTuple", // FIXME(sbinet): TODO
+		"ROOT::RNTuple",
 
 		// rphys
 		"TFeldmanCousins",
@@ -115,7 +117,10 @@ var (
 		// rpad
 		"TAttCanvas",
 		"TCanvas",
+		"TLatex",
+		"TLine",
 		"TPad",
+		"TText",
 	}
 )
 
@@ -18,6 +18,7 @@ import (
 func main() {
 	genH1()
 	genH2()
+	genH3()
 }
 
 func genH1() {
@@ -136,6 +137,64 @@ func genH2() {
 	genroot.GoFmt(f)
 }
 
+func genH3() {
+	fname := "./rhist/h3_gen.go"
+	year := genroot.ExtractYear(fname)
+	f, err := os.Create(fname)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	genroot.GenImports(year, "rhist", f,
+		"fmt", "math", "reflect",
+		"",
+		"go-hep.org/x/hep/groot/root",
+		"go-hep.org/x/hep/groot/rcont",
+		"go-hep.org/x/hep/groot/rbytes",
+		"go-hep.org/x/hep/groot/rtypes",
+		"go-hep.org/x/hep/groot/rvers",
+		"go-hep.org/x/hep/hbook",
+	)
+
+	for i, typ := range []struct {
+		Name string
+		Type string
+		Elem string
+	}{
+		{
+			Name: "H3F",
+			Type: "rcont.ArrayF",
+			Elem: "float32",
+		},
+		{
+			Name: "H3D",
+			Type: "rcont.ArrayD",
+			Elem: "float64",
+		},
+		{
+			Name: "H3I",
+			Type: "rcont.ArrayI",
+			Elem: "int32",
+		},
+	} {
+		if i > 0 {
+			fmt.Fprintf(f, "\n")
+		}
+		tmpl := template.Must(template.New(typ.Name).Parse(h3Tmpl))
+		err = tmpl.Execute(f, typ)
+		if err != nil {
+			log.Fatalf("error executing template for %q: %v\n", typ.Name, err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	genroot.GoFmt(f)
+}
+
 const h1Tmpl = `// {{.Name}} implements ROOT T{{.Name}}
 type {{.Name}} struct {
 	th1
@@ -256,6 +315,11 @@ func (h*{{.Name}}) XAxis() Axis {
 	return &h.th1.xaxis
 }
 
+// YAxis returns the axis along Y.
+func (h*{{.Name}}) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
 // bin returns the regularized bin number given an x bin pair.
 func (h *{{.Name}}) bin(ix int) int {
 	nx := h.th1.xaxis.nbins + 1 // overflow bin
@@ -577,6 +641,11 @@ func (h*{{.Name}}) YAxis() Axis {
 	return &h.th1.yaxis
 }
 
+// ZAxis returns the axis along Z.
+func (h*{{.Name}}) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
 // YBinCenter returns the bin center value in Y.
 func (h *{{.Name}}) YBinCenter(i int) float64 {
 	return float64(h.th1.yaxis.BinCenter(i))
@@ -673,12 +742,22 @@ func (h *{{.Name}}) entries(height, err float64) int64 {
 // AsH2D creates a new hbook.H2D from this ROOT histogram.
 func (h *{{.Name}}) AsH2D() *hbook.H2D {
 	var (
-		nx = h.NbinsX()
-		ny = h.NbinsY()
-		hh = hbook.NewH2D(
-			nx, h.XAxis().XMin(), h.XAxis().XMax(),
-			ny, h.YAxis().XMin(), h.YAxis().XMax(),
-		)
+		nx     = h.NbinsX()
+		ny     = h.NbinsY()
+		xedges = make([]float64, nx+1)
+		yedges = make([]float64, ny+1)
+	)
+	for ix := range xedges[:nx] {
+		xedges[ix] = h.XBinLowEdge(ix + 1)
+	}
+	xedges[nx] = h.XBinLowEdge(nx) + h.XBinWidth(nx)
+	for iy := range yedges[:ny] {
+		yedges[iy] = h.YBinLowEdge(iy + 1)
+	}
+	yedges[ny] = h.YBinLowEdge(ny) + h.YBinWidth(ny)
+
+	var (
+		hh       = hbook.NewH2DFromEdges(xedges, yedges)
 		xinrange = 1
 		yinrange = 1
 	)
@@ -721,18 +800,7 @@ func (h *{{.Name}}) AsH2D() *hbook.H2D {
 
 	for ix := 0; ix < nx; ix++ {
 		for iy := 0; iy < ny; iy++ {
-			var (
-				i    = iy*nx + ix
-				xmin = h.XBinLowEdge(ix + 1)
-				xmax = h.XBinWidth(ix+1) + xmin
-				ymin = h.YBinLowEdge(iy + 1)
-				ymax = h.YBinWidth(iy+1) + ymin
-				bin  = &hh.Binning.Bins[i]
-			)
-			bin.XRange.Min = xmin
-			bin.XRange.Max = xmax
-			bin.YRange.Min = ymin
-			bin.YRange.Max = ymax
+			bin := &hh.Binning.Bins[iy*nx+ix]
 			bin.Dist = h.dist2D(ix+1, iy+1)
 		}
 	}
@@ -757,6 +825,46 @@ func (h *{{.Name}}) UnmarshalYODA(raw []byte) error {
 	return nil
 }
 
+func (h *{{.Name}}) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*{{.Name}})
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.{{.Name}} (%T)", src.(root.Named).Name(), src)
+	}
+
+	err := h.th2.merge(&hsrc.th2)
+	if err != nil {
+		return fmt.Errorf("rhist: could not merge %q: %w", hsrc.Name(), err)
+	}
+
+	if len(h.th2.th1.sumw2.Data) > 0 || len(hsrc.th2.th1.sumw2.Data) > 0 {
+		sumw2 := h.sumw2s()
+		for i, v := range hsrc.sumw2s() {
+			sumw2[i] += v
+		}
+		h.th2.th1.sumw2.Data = sumw2
+	}
+
+	for i, v := range hsrc.arr.Data {
+		h.arr.Data[i] += v
+	}
+
+	return nil
+}
+
+// sumw2s returns the sum of squares of weights of all the cells.
+// The sum of squares of weights is derived from the cells content
+// when it was not recorded.
+func (h *{{.Name}}) sumw2s() []float64 {
+	if len(h.th2.th1.sumw2.Data) > 0 {
+		return h.th2.th1.sumw2.Data
+	}
+	sumw2 := make([]float64, len(h.arr.Data))
+	for i, v := range h.arr.Data {
+		sumw2[i] = float64(v)
+	}
+	return sumw2
+}
+
 func (h *{{.Name}}) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
 		return 0, w.Err()
@@ -804,6 +912,7 @@ func init() {
 
 var (
 	_ root.Object        = (*{{.Name}})(nil)
+	_ root.Merger        = (*{{.Name}})(nil)
 	_ root.Named         = (*{{.Name}})(nil)
 	_ H2                 = (*{{.Name}})(nil)
 	_ rbytes.Marshaler   = (*{{.Name}})(nil)
@@ -811,3 +920,364 @@ var (
 	_ rbytes.RSlicer     = (*{{.Name}})(nil)
 )
 `
+
+const h3Tmpl = `// {{.Name}} implements ROOT T{{.Name}}
+type {{.Name}} struct {
+	th3
+	arr {{.Type}}
+}
+
+func new{{.Name}}() *{{.Name}} {
+	return &{{.Name}}{
+		th3: *newH3(),
+	}
+}
+
+// New{{.Name}}From creates a new {{.Name}} from hbook 3-dim histogram.
+//
+// The outflows of the hbook histogram are stored in the first
+// under/overflow cell of the corresponding region.
+func New{{.Name}}From(h *hbook.H3D) *{{.Name}} {
+	var (
+		hroot  = new{{.Name}}()
+		bng    = &h.Binning
+		nx     = bng.Nx
+		ny     = bng.Ny
+		nz     = bng.Nz
+		ncells = (nx + 2) * (ny + 2) * (nz + 2)
+		edges  = func(bins []hbook.Bin1D) []float64 {
+			o := make([]float64, 0, len(bins)+1)
+			for _, bin := range bins {
+				o = append(o, bin.Range.Min)
+			}
+			return append(o, bins[len(bins)-1].Range.Max)
+		}
+	)
+
+	hroot.th3.th1.entries = float64(h.Entries())
+	hroot.th3.th1.tsumw = h.SumW()
+	hroot.th3.th1.tsumw2 = h.SumW2()
+	hroot.th3.th1.tsumwx = h.SumWX()
+	hroot.th3.th1.tsumwx2 = h.SumWX2()
+	hroot.th3.tsumwy = h.SumWY()
+	hroot.th3.tsumwy2 = h.SumWY2()
+	hroot.th3.tsumwxy = h.SumWXY()
+	hroot.th3.tsumwz = h.SumWZ()
+	hroot.th3.tsumwz2 = h.SumWZ2()
+	hroot.th3.tsumwxz = h.SumWXZ()
+	hroot.th3.tsumwyz = h.SumWYZ()
+	hroot.th3.th1.ncells = ncells
+
+	hroot.th3.th1.xaxis.nbins = nx
+	hroot.th3.th1.xaxis.xmin = h.XMin()
+	hroot.th3.th1.xaxis.xmax = h.XMax()
+	hroot.th3.th1.xaxis.xbins.Data = edges(bng.XEdges)
+
+	hroot.th3.th1.yaxis.nbins = ny
+	hroot.th3.th1.yaxis.xmin = h.YMin()
+	hroot.th3.th1.yaxis.xmax = h.YMax()
+	hroot.th3.th1.yaxis.xbins.Data = edges(bng.YEdges)
+
+	hroot.th3.th1.zaxis.nbins = nz
+	hroot.th3.th1.zaxis.xmin = h.ZMin()
+	hroot.th3.th1.zaxis.xmax = h.ZMax()
+	hroot.th3.th1.zaxis.xbins.Data = edges(bng.ZEdges)
+
+	hroot.arr.Data = make([]{{.Elem}}, ncells)
+	hroot.th3.th1.sumw2.Data = make([]float64, ncells)
+
+	set := func(ix, iy, iz int, d *hbook.Dist3D) {
+		i := hroot.bin(ix, iy, iz)
+		hroot.arr.Data[i] = {{.Elem}}(d.SumW())
+		hroot.th3.th1.sumw2.Data[i] = d.SumW2()
+	}
+
+	for iz := 0; iz < nz; iz++ {
+		for iy := 0; iy < ny; iy++ {
+			for ix := 0; ix < nx; ix++ {
+				bin := &bng.Bins[(iz*ny+iy)*nx+ix]
+				set(ix+1, iy+1, iz+1, &bin.Dist)
+			}
+		}
+	}
+
+	cell := func(o, n int) int {
+		switch o {
+		case -1:
+			return 0
+		case +1:
+			return n + 1
+		}
+		return 1
+	}
+	for oz := -1; oz <= 1; oz++ {
+		for oy := -1; oy <= 1; oy++ {
+			for ox := -1; ox <= 1; ox++ {
+				d := bng.Outflow(ox, oy, oz)
+				if d == nil {
+					continue
+				}
+				set(cell(ox, nx), cell(oy, ny), cell(oz, nz), d)
+			}
+		}
+	}
+
+	hroot.th3.th1.SetName(h.Name())
+	if v, ok := h.Annotation()["title"]; ok && v != nil {
+		hroot.th3.th1.SetTitle(v.(string))
+	}
+
+	return hroot
+}
+
+func (*{{.Name}}) RVersion() int16 {
+	return rvers.{{.Name}}
+}
+
+func (*{{.Name}}) isH3() {}
+
+// Class returns the ROOT class name.
+func (*{{.Name}}) Class() string {
+	return "T{{.Name}}"
+}
+
+func (h *{{.Name}}) Array() {{.Type}} {
+	return h.arr
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *{{.Name}}) Rank() int {
+	return 3
+}
+
+// NbinsX returns the number of bins in X.
+func (h *{{.Name}}) NbinsX() int {
+	return h.th1.xaxis.nbins
+}
+
+// XAxis returns the axis along X.
+func (h *{{.Name}}) XAxis() Axis {
+	return &h.th1.xaxis
+}
+
+// NbinsY returns the number of bins in Y.
+func (h *{{.Name}}) NbinsY() int {
+	return h.th1.yaxis.nbins
+}
+
+// YAxis returns the axis along Y.
+func (h *{{.Name}}) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
+// NbinsZ returns the number of bins in Z.
+func (h *{{.Name}}) NbinsZ() int {
+	return h.th1.zaxis.nbins
+}
+
+// ZAxis returns the axis along Z.
+func (h *{{.Name}}) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
+// BinContent returns the content of the (ix,iy,iz) bin.
+// Bin indices start at 1; 0 and Nbins+1 address the under- and overflow bins.
+func (h *{{.Name}}) BinContent(ix, iy, iz int) float64 {
+	return float64(h.arr.Data[h.bin(ix, iy, iz)])
+}
+
+// BinError returns the error of the (ix,iy,iz) bin.
+// Bin indices start at 1; 0 and Nbins+1 address the under- and overflow bins.
+func (h *{{.Name}}) BinError(ix, iy, iz int) float64 {
+	i := h.bin(ix, iy, iz)
+	if len(h.th1.sumw2.Data) > 0 {
+		return math.Sqrt(float64(h.th1.sumw2.Data[i]))
+	}
+	return math.Sqrt(math.Abs(float64(h.arr.Data[i])))
+}
+
+// AsH3D creates a new hbook.H3D from this ROOT histogram.
+//
+// The outflows of the hbook histogram are the sums of the ROOT
+// under/overflow cells of the corresponding regions.
+func (h *{{.Name}}) AsH3D() *hbook.H3D {
+	var (
+		nx    = h.NbinsX()
+		ny    = h.NbinsY()
+		nz    = h.NbinsZ()
+		edges = func(axis Axis) []float64 {
+			n := axis.NBins()
+			o := make([]float64, n+1)
+			for i := range o[:n] {
+				o[i] = axis.BinLowEdge(i + 1)
+			}
+			o[n] = axis.BinLowEdge(n) + axis.BinWidth(n)
+			return o
+		}
+		hh = hbook.NewH3DFromEdges(
+			edges(h.XAxis()),
+			edges(h.YAxis()),
+			edges(h.ZAxis()),
+		)
+		bng = &hh.Binning
+	)
+	hh.Ann = hbook.Annotation{
+		"name":  h.Name(),
+		"title": h.Title(),
+	}
+
+	dist := func(d0 hbook.Dist0D) hbook.Dist3D {
+		var d hbook.Dist3D
+		d.X.Dist = d0
+		d.Y.Dist = d0
+		d.Z.Dist = d0
+		return d
+	}
+
+	code := func(i, n int) int {
+		switch i {
+		case 0:
+			return -1
+		case n + 1:
+			return +1
+		}
+		return 0
+	}
+	for iz := 0; iz <= nz+1; iz++ {
+		for iy := 0; iy <= ny+1; iy++ {
+			for ix := 0; ix <= nx+1; ix++ {
+				d0 := h.dist0D(ix, iy, iz)
+				o := bng.Outflow(code(ix, nx), code(iy, ny), code(iz, nz))
+				if o == nil {
+					bin := &bng.Bins[((iz-1)*ny+iy-1)*nx+ix-1]
+					bin.Dist = dist(d0)
+					continue
+				}
+				d0.N += o.X.Dist.N
+				d0.SumW += o.X.Dist.SumW
+				d0.SumW2 += o.X.Dist.SumW2
+				*o = dist(d0)
+			}
+		}
+	}
+
+	bng.Dist = dist(hbook.Dist0D{
+		N:     int64(h.Entries()),
+		SumW:  h.SumW(),
+		SumW2: h.SumW2(),
+	})
+	bng.Dist.X.Stats.SumWX = h.SumWX()
+	bng.Dist.X.Stats.SumWX2 = h.SumWX2()
+	bng.Dist.Y.Stats.SumWX = h.SumWY()
+	bng.Dist.Y.Stats.SumWX2 = h.SumWY2()
+	bng.Dist.Z.Stats.SumWX = h.SumWZ()
+	bng.Dist.Z.Stats.SumWX2 = h.SumWZ2()
+	bng.Dist.Stats.SumWXY = h.SumWXY()
+	bng.Dist.Stats.SumWXZ = h.SumWXZ()
+	bng.Dist.Stats.SumWYZ = h.SumWYZ()
+
+	return hh
+}
+
+// dist0D returns the weight moments of the (ix,iy,iz) bin.
+func (h *{{.Name}}) dist0D(ix, iy, iz int) hbook.Dist0D {
+	var (
+		i     = h.bin(ix, iy, iz)
+		sumw  = float64(h.arr.Data[i])
+		sumw2 = math.Abs(sumw)
+	)
+	if len(h.th1.sumw2.Data) > 0 {
+		sumw2 = h.th1.sumw2.Data[i]
+	}
+	var n int64
+	if sumw > 0 && sumw2 > 0 {
+		// effective number of entries.
+		n = int64(sumw*sumw/sumw2 + 0.5)
+	}
+	return hbook.Dist0D{
+		N:     n,
+		SumW:  sumw,
+		SumW2: sumw2,
+	}
+}
+
+// bin returns the regularized bin number given an (x,y,z) bin index triplet.
+func (h *{{.Name}}) bin(ix, iy, iz int) int {
+	nx := h.th1.xaxis.nbins + 1 // overflow bin
+	ny := h.th1.yaxis.nbins + 1 // overflow bin
+	nz := h.th1.zaxis.nbins + 1 // overflow bin
+	switch {
+	case ix < 0:
+		ix = 0
+	case ix > nx:
+		ix = nx
+	}
+	switch {
+	case iy < 0:
+		iy = 0
+	case iy > ny:
+		iy = ny
+	}
+	switch {
+	case iz < 0:
+		iz = 0
+	case iz > nz:
+		iz = nz
+	}
+	return ix + (nx+1)*(iy+(ny+1)*iz)
+}
+
+func (h *{{.Name}}) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(h.Class(), h.RVersion())
+	w.WriteObject(&h.th3)
+	w.WriteObject(&h.arr)
+
+	return w.SetHeader(hdr)
+}
+
+func (h *{{.Name}}) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(h.Class(), h.RVersion())
+	if hdr.Vers < 1 {
+		return fmt.Errorf("rhist: T{{.Name}} version too old (%d<1)", hdr.Vers)
+	}
+
+	r.ReadObject(&h.th3)
+	r.ReadObject(&h.arr)
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (h *{{.Name}}) RMembers() (mbrs []rbytes.Member) {
+	mbrs = append(mbrs, h.th3.RMembers()...)
+	mbrs = append(mbrs, rbytes.Member{
+		Name: "fArray", Value: &h.arr.Data,
+	})
+	return mbrs
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := new{{.Name}}()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("T{{.Name}}", f)
+}
+
+var (
+	_ root.Object        = (*{{.Name}})(nil)
+	_ root.Named         = (*{{.Name}})(nil)
+	_ H3                 = (*{{.Name}})(nil)
+	_ rbytes.Marshaler   = (*{{.Name}})(nil)
+	_ rbytes.Unmarshaler = (*{{.Name}})(nil)
+	_ rbytes.RSlicer     = (*{{.Name}})(nil)
+)
+`
@@ -14,6 +14,11 @@ import (
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/root"
 	_ "go-hep.org/x/hep/groot/ztypes"
+
+	// enable remote access to ROOT files.
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/s3"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
 )
 
 const (
@@ -23,6 +28,11 @@ const (
 // Open opens the named ROOT file for reading. If successful, methods on the
 // returned file can be used for reading; the associated file descriptor
 // has mode os.O_RDONLY.
+//
+// Open handles local files as well as remote ones, served over http(s)://,
+// root:// (xrootd) or stored in S3-compatible object stores (s3://, gs://).
+// Additional transports can be enabled by importing the package providing
+// them (see riofs.Register.)
 func Open(path string) (*File, error) {
 	return riofs.Open(path)
 }
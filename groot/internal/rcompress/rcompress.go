@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/klauspost/compress/flate"
 	"github.com/klauspost/compress/zlib"
@@ -43,6 +44,17 @@ const (
 	lz4Version  = 1 // keep in sync with pierrec/lz4
 )
 
+// default compression levels, as used by ROOT for its
+// kUseAnalysis (404) and kUseGeneralPurpose (505) settings.
+const (
+	lz4DefaultLevel  = 4
+	zstdDefaultLevel = 5
+)
+
+// lz4ChecksumSize is the size of the xxHash64 checksum ROOT prepends
+// to each LZ4 compressed block.
+const lz4ChecksumSize = 8
+
 var (
 	// errNoCompression is returned when the compression algorithm
 	// couldn't compress the input or when the compressed output is bigger
@@ -80,22 +92,14 @@ func (set Settings) Compression() int32 {
 		case ZLIB:
 			lvl = 6
 		case LZ4:
-			lvl = 1
+			lvl = lz4DefaultLevel
 		case LZMA:
 			lvl = 1
 		case ZSTD:
-			lvl = 1 // FIXME(sbinet): check with ROOT-6.20.00 default
+			lvl = zstdDefaultLevel
 		default:
 			panic(fmt.Errorf("rcompress: unknown compression algorithm: %v", alg))
 		}
-	case lvl == flate.BestSpeed:
-		if alg == ZSTD {
-			lvl = int(zstd.SpeedFastest)
-		}
-	case lvl == flate.BestCompression:
-		if alg == ZSTD {
-			lvl = int(zstd.SpeedBestCompression)
-		}
 	case lvl > 99:
 		lvl = 99
 	}
@@ -266,7 +270,7 @@ func compressBlock(alg Kind, lvl int, tgt, src []byte) (int, error) {
 		hdr[1] = '4'
 		hdr[2] = lz4Version
 
-		const chksum = 8
+		const chksum = lz4ChecksumSize
 		var room = int(float64(srcsz) * 2e-4) // lz4 needs some extra scratch space
 		dst := make([]byte, HeaderSize+chksum+len(src)+room)
 		wrk := dst[HeaderSize:]
@@ -276,7 +280,9 @@ func compressBlock(alg Kind, lvl int, tgt, src []byte) (int, error) {
 			if lvl > 9 {
 				lvl = 9
 			}
-			c := lz4.CompressorHC{Level: lz4.CompressionLevel(lvl)}
+			// ROOT levels [4,9] select the LZ4-HC compressor.
+			// pierrec/lz4 encodes its HC levels as search depths.
+			c := lz4.CompressorHC{Level: lz4.Level1 << (lvl - 1)}
 			n, err = c.CompressBlock(src, wrk[chksum:])
 		default:
 			ht := make([]int, 1<<16)
@@ -286,7 +292,7 @@ func compressBlock(alg Kind, lvl int, tgt, src []byte) (int, error) {
 			return 0, fmt.Errorf("rcompress: could not compress with LZ4: %w", err)
 		}
 
-		if n == 0 {
+		if n == 0 || n+chksum >= len(src) || n+chksum > len(buf.p) {
 			// not compressible.
 			return len(src), errNoCompression
 		}
@@ -302,7 +308,7 @@ func compressBlock(alg Kind, lvl int, tgt, src []byte) (int, error) {
 		hdr[1] = 'S'
 		hdr[2] = zstdVersion
 
-		w, err := zstd.NewWriter(buf, zstd.WithEncoderLevel(zstd.EncoderLevel(lvl)))
+		w, err := zstd.NewWriter(buf, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(lvl)))
 		if err != nil {
 			return 0, fmt.Errorf("rcompress: could not create ZSTD compressor: %w", err)
 		}
@@ -349,13 +355,79 @@ func compressBlock(alg Kind, lvl int, tgt, src []byte) (int, error) {
 	return n, nil
 }
 
+// decoder holds the scratch space and the decompressors needed to
+// decompress ROOT payloads.
+// decoders are pooled to reduce allocations when many baskets are
+// decompressed.
+type decoder struct {
+	hdr  [HeaderSize]byte
+	lr   io.LimitedReader
+	buf  []byte // scratch space for LZ4 blocks
+	zlib io.ReadCloser
+	zstd *zstd.Decoder
+}
+
+var decoders = sync.Pool{
+	New: func() any {
+		return new(decoder)
+	},
+}
+
+// release puts the decoder back into the pool, dropping references to
+// the last decompressed source.
+func (dec *decoder) release() {
+	const maxScratch = 4 * 1024 * 1024
+	dec.lr = io.LimitedReader{}
+	if cap(dec.buf) > maxScratch {
+		dec.buf = nil
+	}
+	decoders.Put(dec)
+}
+
+func (dec *decoder) zlibReader(r io.Reader) (io.Reader, error) {
+	if dec.zlib == nil {
+		rc, err := zlib.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		dec.zlib = rc
+		return rc, nil
+	}
+	err := dec.zlib.(zlib.Resetter).Reset(r, nil)
+	if err != nil {
+		return nil, err
+	}
+	return dec.zlib, nil
+}
+
+func (dec *decoder) zstdReader(r io.Reader) (io.Reader, error) {
+	if dec.zstd == nil {
+		// with a concurrency of 1, stream decoding happens synchronously
+		// and no goroutine is left behind when the decoder is dropped
+		// from the pool.
+		rc, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		dec.zstd = rc
+	}
+	err := dec.zstd.Reset(r)
+	if err != nil {
+		return nil, err
+	}
+	return dec.zstd, nil
+}
+
 // Decompress decompresses src into dst.
 func Decompress(dst []byte, src io.Reader) error {
+	dec := decoders.Get().(*decoder)
+	defer dec.release()
+
 	var (
 		beg    = 0
 		end    = 0
 		buflen = len(dst)
-		hdr    = make([]byte, HeaderSize)
+		hdr    = dec.hdr[:]
 	)
 
 	for end < buflen {
@@ -368,14 +440,14 @@ func Decompress(dst []byte, src io.Reader) error {
 		srcsz := int64(hdr[3]) | int64(hdr[4])<<8 | int64(hdr[5])<<16
 		tgtsz := int64(hdr[6]) | int64(hdr[7])<<8 | int64(hdr[8])<<16
 		end += int(tgtsz)
-		lr := &io.LimitedReader{R: src, N: srcsz}
+		dec.lr = io.LimitedReader{R: src, N: srcsz}
+		lr := &dec.lr
 		switch kindOf(hdr) {
 		case ZLIB:
-			rc, err := zlib.NewReader(lr)
+			rc, err := dec.zlibReader(lr)
 			if err != nil {
 				return fmt.Errorf("rcompress: could not create ZLIB reader: %w", err)
 			}
-			defer rc.Close()
 
 			_, err = io.ReadFull(rc, dst[beg:end])
 			if err != nil {
@@ -383,13 +455,28 @@ func Decompress(dst []byte, src io.Reader) error {
 			}
 
 		case LZ4:
-			src := make([]byte, srcsz)
+			if int64(cap(dec.buf)) < srcsz {
+				dec.buf = make([]byte, srcsz)
+			}
+			src := dec.buf[:srcsz]
 			_, err = io.ReadFull(lr, src)
 			if err != nil {
 				return fmt.Errorf("rcompress: could not read LZ4 block: %w", err)
 			}
-			const chksum = 8
-			// FIXME: we skip the 32b checksum. use it!
+			const chksum = lz4ChecksumSize
+			if len(src) < chksum {
+				return fmt.Errorf("rcompress: LZ4 block too small (%d bytes)", len(src))
+			}
+			var (
+				want = binary.BigEndian.Uint64(src[:chksum])
+				got  = xxHash64.Checksum(src[chksum:], 0)
+			)
+			if got != want {
+				return fmt.Errorf(
+					"rcompress: invalid LZ4 block checksum (got=0x%x, want=0x%x)",
+					got, want,
+				)
+			}
 			_, err = lz4.UncompressBlock(src[chksum:], dst[beg:end])
 			if err != nil {
 				switch {
@@ -419,7 +506,7 @@ func Decompress(dst []byte, src io.Reader) error {
 			}
 
 		case ZSTD:
-			rc, err := zstd.NewReader(lr)
+			rc, err := dec.zstdReader(lr)
 			if err != nil {
 				return fmt.Errorf("rcompress: could not create ZSTD reader: %w", err)
 			}
@@ -428,11 +515,11 @@ func Decompress(dst []byte, src io.Reader) error {
 				return fmt.Errorf("rcompress: could not decompress ZSTD block: %w", err)
 			}
 			if lr.N > 0 {
-				panic("zstd extra bytes")
+				return fmt.Errorf("rcompress: ZSTD block has %d extra bytes", lr.N)
 			}
 
 		default:
-			panic(fmt.Errorf("rcompress: unknown compression algorithm %q", hdr[:2]))
+			return fmt.Errorf("rcompress: unknown compression algorithm %q", hdr[:2])
 		}
 		beg = end
 	}
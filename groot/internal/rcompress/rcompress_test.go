@@ -91,6 +91,9 @@ void testcompress(const char *fname, int size) {
 		{name: "zstd-9", opt: riofs.WithZstd(9)},
 		{name: "zstd-best-speed", opt: riofs.WithZstd(flate.BestSpeed)},
 		{name: "zstd-best-compr", opt: riofs.WithZstd(flate.BestCompression)},
+		// ROOT-encoded settings
+		{name: "compr-404-1", opt: riofs.WithCompression(404)},
+		{name: "compr-505-1", opt: riofs.WithCompression(505)},
 	} {
 		for k, want := range wants {
 			if (k == "16mb" || k == "10mb") &&
@@ -166,12 +169,17 @@ func TestRoundtrip(t *testing.T) {
 	}{
 		// lz4
 		{name: "lz4-default", opt: rcompress.Settings{Alg: rcompress.LZ4, Lvl: flate.DefaultCompression}},
+		{name: "lz4-1", opt: rcompress.Settings{Alg: rcompress.LZ4, Lvl: 1}},
+		{name: "lz4-4", opt: rcompress.Settings{Alg: rcompress.LZ4, Lvl: 4}},
+		{name: "lz4-9", opt: rcompress.Settings{Alg: rcompress.LZ4, Lvl: 9}},
 		// lzma
 		{name: "lzma-default", opt: rcompress.Settings{Alg: rcompress.LZMA, Lvl: flate.DefaultCompression}},
 		// zlib
 		{name: "zlib-default", opt: rcompress.Settings{Alg: rcompress.ZLIB, Lvl: flate.DefaultCompression}},
 		// zstd
 		{name: "zstd-default", opt: rcompress.Settings{Alg: rcompress.ZSTD, Lvl: flate.DefaultCompression}},
+		{name: "zstd-1", opt: rcompress.Settings{Alg: rcompress.ZSTD, Lvl: 1}},
+		{name: "zstd-9", opt: rcompress.Settings{Alg: rcompress.ZSTD, Lvl: 9}},
 	} {
 		for _, k := range keysOf(wants) {
 			tname := fmt.Sprintf("%s-%s", tc.name, k)
@@ -200,6 +208,58 @@ func TestRoundtrip(t *testing.T) {
 		}
 	}
 }
+
+func TestLZ4Checksum(t *testing.T) {
+	want := []byte(strings.Repeat("-+", 10*1024))
+	compr := rcompress.Settings{Alg: rcompress.LZ4, Lvl: 1}.Compression()
+
+	xsrc, err := rcompress.Compress(nil, want, compr)
+	if err != nil {
+		t.Fatalf("could not compress: %+v", err)
+	}
+	if len(xsrc) >= len(want) {
+		t.Fatalf("input was not compressed")
+	}
+
+	xdst := make([]byte, len(want))
+	err = rcompress.Decompress(xdst, bytes.NewReader(xsrc))
+	if err != nil {
+		t.Fatalf("could not decompress: %+v", err)
+	}
+	if !bytes.Equal(xdst, want) {
+		t.Fatalf("round-trip failed")
+	}
+
+	// corrupt the last byte of the compressed payload.
+	xsrc[len(xsrc)-1] ^= 0xff
+	err = rcompress.Decompress(xdst, bytes.NewReader(xsrc))
+	if err == nil {
+		t.Fatalf("expected an error on corrupted LZ4 block")
+	}
+	if got, want := err.Error(), "rcompress: invalid LZ4 block checksum"; !strings.HasPrefix(got, want) {
+		t.Fatalf("invalid error:\ngot= %q\nwant=%q", got, want)
+	}
+}
+
+func TestSettingsCompression(t *testing.T) {
+	for _, tc := range []struct {
+		set  rcompress.Settings
+		want int32
+	}{
+		{rcompress.Settings{Alg: rcompress.ZLIB, Lvl: flate.DefaultCompression}, 106},
+		{rcompress.Settings{Alg: rcompress.LZ4, Lvl: flate.DefaultCompression}, 404},
+		{rcompress.Settings{Alg: rcompress.ZSTD, Lvl: flate.DefaultCompression}, 505},
+		{rcompress.Settings{Alg: rcompress.ZSTD, Lvl: 9}, 509},
+		{rcompress.Settings{Alg: rcompress.LZMA, Lvl: 120}, 299},
+	} {
+		t.Run(fmt.Sprintf("%v-%d", tc.set.Alg, tc.set.Lvl), func(t *testing.T) {
+			if got, want := tc.set.Compression(), tc.want; got != want {
+				t.Fatalf("invalid compression: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
 func BenchmarkCompression(b *testing.B) {
 	b.ReportAllocs()
 
@@ -0,0 +1,162 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcompress
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zlib"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/pierrec/xxHash/xxHash64"
+	"github.com/ulikunitz/xz"
+)
+
+// Verify checks the integrity of the compressed ROOT payload src, that
+// should decompress into n bytes.
+//
+// Verify checks the headers of all the compressed blocks held in src,
+// decompresses each block in full and verifies the block checksums,
+// when the compression algorithm provides one (ZLIB's Adler-32,
+// LZ4's XXH64, LZMA's CRC32 and ZSTD's XXH64 content checksum.)
+func Verify(src []byte, n int) error {
+	var (
+		beg = 0
+		tot = 0
+		dst []byte
+	)
+	for beg < len(src) {
+		if len(src)-beg < HeaderSize {
+			return fmt.Errorf(
+				"rcompress: truncated block header at offset %d (%d bytes)",
+				beg, len(src)-beg,
+			)
+		}
+		hdr := src[beg : beg+HeaderSize]
+		var (
+			kind  = kindOf(hdr)
+			srcsz = int(hdr[3]) | int(hdr[4])<<8 | int(hdr[5])<<16
+			tgtsz = int(hdr[6]) | int(hdr[7])<<8 | int(hdr[8])<<16
+		)
+		beg += HeaderSize
+		if beg+srcsz > len(src) {
+			return fmt.Errorf(
+				"rcompress: block at offset %d overflows payload (block=%d bytes, remaining=%d bytes)",
+				beg-HeaderSize, srcsz, len(src)-beg,
+			)
+		}
+		if tot+tgtsz > n {
+			return fmt.Errorf(
+				"rcompress: decompressed size overflows payload (got>=%d bytes, want=%d bytes)",
+				tot+tgtsz, n,
+			)
+		}
+
+		if kind == UndefinedCompression {
+			return fmt.Errorf(
+				"rcompress: unknown compression algorithm %q for block at offset %d",
+				hdr[:2], beg-HeaderSize,
+			)
+		}
+
+		if cap(dst) < tgtsz {
+			dst = make([]byte, tgtsz)
+		}
+		err := verifyBlock(kind, dst[:tgtsz], src[beg:beg+srcsz])
+		if err != nil {
+			return fmt.Errorf("rcompress: invalid %v block at offset %d: %w", kind, beg-HeaderSize, err)
+		}
+		beg += srcsz
+		tot += tgtsz
+	}
+
+	if tot != n {
+		return fmt.Errorf("rcompress: invalid decompressed size (got=%d bytes, want=%d bytes)", tot, n)
+	}
+
+	return nil
+}
+
+// verifyBlock decompresses the provided block into dst, making sure the
+// block holds exactly len(dst) bytes of decompressed data.
+func verifyBlock(kind Kind, dst, src []byte) error {
+	// readAll decompresses r into dst and checks that r is then exhausted,
+	// so that the stream checksum, if any, is verified.
+	readAll := func(r io.Reader) error {
+		_, err := io.ReadFull(r, dst)
+		if err != nil {
+			return fmt.Errorf("could not decompress block: %w", err)
+		}
+		var extra [1]byte
+		n, err := r.Read(extra[:])
+		switch {
+		case n > 0:
+			return fmt.Errorf("decompressed block larger than %d bytes", len(dst))
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return fmt.Errorf("could not verify block: %w", err)
+		}
+		_, err = io.Copy(io.Discard, r)
+		if err != nil {
+			return fmt.Errorf("could not verify block: %w", err)
+		}
+		return nil
+	}
+
+	switch kind {
+	case ZLIB:
+		r, err := zlib.NewReader(bytes.NewReader(src))
+		if err != nil {
+			return fmt.Errorf("could not create ZLIB reader: %w", err)
+		}
+		defer r.Close()
+		return readAll(r)
+
+	case LZ4:
+		const chksum = lz4ChecksumSize
+		if len(src) < chksum {
+			return fmt.Errorf("LZ4 block too small (%d bytes)", len(src))
+		}
+		var (
+			want = binary.BigEndian.Uint64(src[:chksum])
+			got  = xxHash64.Checksum(src[chksum:], 0)
+		)
+		if got != want {
+			return fmt.Errorf("invalid LZ4 block checksum (got=0x%x, want=0x%x)", got, want)
+		}
+		n, err := lz4.UncompressBlock(src[chksum:], dst)
+		if err != nil {
+			return fmt.Errorf("could not decompress block: %w", err)
+		}
+		if n != len(dst) {
+			return fmt.Errorf("invalid decompressed block size (got=%d bytes, want=%d bytes)", n, len(dst))
+		}
+		return nil
+
+	case LZMA:
+		r, err := xz.NewReader(bytes.NewReader(src))
+		if err != nil {
+			return fmt.Errorf("could not create LZMA reader: %w", err)
+		}
+		return readAll(r)
+
+	case ZSTD:
+		r, err := zstd.NewReader(bytes.NewReader(src), zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return fmt.Errorf("could not create ZSTD reader: %w", err)
+		}
+		defer r.Close()
+		return readAll(r)
+
+	case OldCompression:
+		return fmt.Errorf("old compression algorithm unsupported")
+	}
+
+	return fmt.Errorf("unknown compression algorithm %v", kind)
+}
@@ -0,0 +1,101 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcompress_test
+
+import (
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+)
+
+func TestVerify(t *testing.T) {
+	want := []byte(strings.Repeat("-+", 10*1024))
+
+	for _, alg := range []rcompress.Kind{
+		rcompress.ZLIB,
+		rcompress.LZ4,
+		rcompress.LZMA,
+		rcompress.ZSTD,
+	} {
+		t.Run(alg.String(), func(t *testing.T) {
+			compr := rcompress.Settings{Alg: alg, Lvl: 1}.Compression()
+			xsrc, err := rcompress.Compress(nil, want, compr)
+			if err != nil {
+				t.Fatalf("could not compress: %+v", err)
+			}
+			if len(xsrc) >= len(want) {
+				t.Fatalf("input was not compressed")
+			}
+
+			err = rcompress.Verify(xsrc, len(want))
+			if err != nil {
+				t.Fatalf("could not verify payload: %+v", err)
+			}
+
+			for _, tc := range []struct {
+				name string
+				src  func() []byte
+				n    int
+				want string
+			}{
+				{
+					name: "invalid-size",
+					src:  func() []byte { return xsrc },
+					n:    len(want) + 1,
+					want: "rcompress: invalid decompressed size",
+				},
+				{
+					name: "overflow-size",
+					src:  func() []byte { return xsrc },
+					n:    len(want) - 1,
+					want: "rcompress: decompressed size overflows payload",
+				},
+				{
+					name: "truncated-header",
+					src:  func() []byte { return xsrc[:rcompress.HeaderSize-1] },
+					n:    len(want),
+					want: "rcompress: truncated block header",
+				},
+				{
+					name: "truncated-block",
+					src:  func() []byte { return xsrc[:len(xsrc)-1] },
+					n:    len(want),
+					want: "rcompress: block at offset 0 overflows payload",
+				},
+				{
+					name: "unknown-algorithm",
+					src: func() []byte {
+						src := append([]byte(nil), xsrc...)
+						src[0], src[1] = 'X', 'X'
+						return src
+					},
+					n:    len(want),
+					want: `rcompress: unknown compression algorithm "XX"`,
+				},
+				{
+					name: "corrupted-block",
+					src: func() []byte {
+						src := append([]byte(nil), xsrc...)
+						src[len(src)-1] ^= 0xff
+						return src
+					},
+					n:    len(want),
+					want: "rcompress: invalid " + alg.String() + " block at offset 0",
+				},
+			} {
+				t.Run(tc.name, func(t *testing.T) {
+					err := rcompress.Verify(tc.src(), tc.n)
+					if err == nil {
+						t.Fatalf("expected an error")
+					}
+					if got, want := err.Error(), tc.want; !strings.HasPrefix(got, want) {
+						t.Fatalf("invalid error:\ngot= %q\nwant=%q", got, want)
+					}
+				})
+			}
+		})
+	}
+}
@@ -317,6 +317,48 @@ func NewT2() *T2 {
 	}
 }
 
+// T3 exercizes a user type containing slices and maps of builtins and
+// user-types.
+type T3 struct {
+	name string              `groot:"Name"`
+	hlvs []HLV               `groot:"MyHLVs"`
+	ps   []Particle          `groot:"Particles"`
+	mat  [][]float64         `groot:"Matrix"`
+	idx  map[string]int32    `groot:"Index"`
+	hmap map[int32]HLV       `groot:"HLVMap"`
+	smap map[string][]string `groot:"SliceMap"`
+}
+
+func NewT3() *T3 {
+	return &T3{
+		name: "hello",
+		hlvs: []HLV{{1, 2, 3, 4}, {-1, -2, -3, -4}},
+		ps: []Particle{
+			{name: "e-", pid: 11, mom: HLV{1, 2, 3, 4}},
+			{name: "mu+", pid: -13, mom: HLV{5, 6, 7, 8}},
+		},
+		mat:  [][]float64{{1, 2, 3}, {4}, {5, 6}},
+		idx:  map[string]int32{"one": 1, "two": 2, "three": 3},
+		hmap: map[int32]HLV{1: {1, 2, 3, 4}, 2: {5, 6, 7, 8}},
+		smap: map[string][]string{"a": {"a1", "a2"}, "b": {"b1"}},
+	}
+}
+
+// T4 exercizes a user type embedding other user-types.
+type T4 struct {
+	HLV
+	T1
+	id int `groot:"ID"`
+}
+
+func NewT4() *T4 {
+	return &T4{
+		HLV: HLV{1, 2, 3, 4},
+		T1:  *NewT1(),
+		id:  42,
+	}
+}
+
 // FIXME(sbinet)
 //  - support types that "inherit" from TObject
 //  - support types that contain a TList
@@ -0,0 +1,353 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rexpr provides formulae evaluating TTree::Draw-like expressions
+// over the branches of a tree.
+//
+// Expressions use the Go syntax and are evaluated as float64 values:
+//   - identifiers refer to branches,
+//   - fixed-size and variable-size arrays can be indexed (out of range
+//     elements evaluate to NaN),
+//   - arithmetic, comparison and logical operators are supported, with
+//     booleans evaluated as 0 or 1,
+//   - a few functions from the math package are available (abs, sqrt,
+//     pow, exp, log, ...).
+package rexpr // import "go-hep.org/x/hep/groot/internal/rexpr"
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"reflect"
+	"strconv"
+
+	"go-hep.org/x/hep/groot/rtree/rfunc"
+)
+
+// Expr is a formula evaluating an expression as a float64.
+type Expr struct {
+	src   string
+	node  ast.Expr
+	names []string
+
+	fct func() float64
+}
+
+var _ rfunc.Formula = (*Expr)(nil)
+
+// New parses the provided expression and returns the associated formula.
+func New(src string) (*Expr, error) {
+	node, err := parser.ParseExpr(src)
+	if err != nil {
+		return nil, fmt.Errorf("rexpr: could not parse expression %q: %w", src, err)
+	}
+
+	e := &Expr{src: src, node: node}
+	set := make(map[string]struct{})
+	var walk func(n ast.Node) bool
+	walk = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			// function names are not branch names.
+			for _, arg := range n.Args {
+				ast.Inspect(arg, walk)
+			}
+			return false
+		case *ast.Ident:
+			if _, dup := set[n.Name]; !dup {
+				set[n.Name] = struct{}{}
+				e.names = append(e.names, n.Name)
+			}
+		}
+		return true
+	}
+	ast.Inspect(node, walk)
+
+	return e, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string { return e.src }
+
+// RVars implements rfunc.Formula.
+func (e *Expr) RVars() []string { return e.names }
+
+// Bind implements rfunc.Formula.
+func (e *Expr) Bind(ptrs []interface{}) error {
+	if got, want := len(ptrs), len(e.names); got != want {
+		return fmt.Errorf(
+			"rexpr: invalid number of bind arguments (got=%d, want=%d)",
+			got, want,
+		)
+	}
+
+	vars := make(map[string]reflect.Value, len(ptrs))
+	for i, ptr := range ptrs {
+		vars[e.names[i]] = reflect.ValueOf(ptr).Elem()
+	}
+
+	fct, err := compile(e.node, vars)
+	if err != nil {
+		return fmt.Errorf("rexpr: could not compile expression %q: %w", e.src, err)
+	}
+	e.fct = fct
+	return nil
+}
+
+// Func implements rfunc.Formula.
+//
+// Func returns a func() float64.
+func (e *Expr) Func() interface{} {
+	return e.fct
+}
+
+// Cut is a formula evaluating an expression as a selection.
+//
+// Entries for which the expression evaluates to 0 or NaN are rejected.
+type Cut struct {
+	expr *Expr
+	fct  func() bool
+}
+
+var _ rfunc.Formula = (*Cut)(nil)
+
+// NewCut parses the provided expression and returns the associated
+// selection formula.
+func NewCut(src string) (*Cut, error) {
+	e, err := New(src)
+	if err != nil {
+		return nil, err
+	}
+	return &Cut{expr: e}, nil
+}
+
+// String returns the source of the selection.
+func (c *Cut) String() string { return c.expr.src }
+
+// RVars implements rfunc.Formula.
+func (c *Cut) RVars() []string { return c.expr.names }
+
+// Bind implements rfunc.Formula.
+func (c *Cut) Bind(ptrs []interface{}) error {
+	err := c.expr.Bind(ptrs)
+	if err != nil {
+		return err
+	}
+	fct := c.expr.fct
+	c.fct = func() bool {
+		v := fct()
+		return v != 0 && !math.IsNaN(v)
+	}
+	return nil
+}
+
+// Func implements rfunc.Formula.
+//
+// Func returns a func() bool.
+func (c *Cut) Func() interface{} {
+	return c.fct
+}
+
+func compile(node ast.Expr, vars map[string]reflect.Value) (func() float64, error) {
+	switch node := node.(type) {
+	case *ast.ParenExpr:
+		return compile(node.X, vars)
+
+	case *ast.BasicLit:
+		switch node.Kind {
+		case token.INT, token.FLOAT:
+			v, err := strconv.ParseFloat(node.Value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q: %w", node.Value, err)
+			}
+			return func() float64 { return v }, nil
+		}
+		return nil, fmt.Errorf("invalid literal %s", node.Value)
+
+	case *ast.Ident:
+		rv, ok := vars[node.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %q", node.Name)
+		}
+		if !isScalar(rv.Kind()) {
+			return nil, fmt.Errorf("variable %q is not a scalar (type=%v)", node.Name, rv.Type())
+		}
+		return func() float64 { return asFloat(rv) }, nil
+
+	case *ast.IndexExpr:
+		id, ok := node.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("invalid indexed expression")
+		}
+		rv, ok := vars[id.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %q", id.Name)
+		}
+		switch rv.Kind() {
+		case reflect.Array, reflect.Slice:
+			if !isScalar(rv.Type().Elem().Kind()) {
+				return nil, fmt.Errorf("variable %q is not an array of scalars (type=%v)", id.Name, rv.Type())
+			}
+		default:
+			return nil, fmt.Errorf("variable %q is not an array (type=%v)", id.Name, rv.Type())
+		}
+		idx, err := compile(node.Index, vars)
+		if err != nil {
+			return nil, err
+		}
+		return func() float64 {
+			i := idx()
+			if i < 0 || int(i) >= rv.Len() || i != math.Trunc(i) {
+				return math.NaN()
+			}
+			return asFloat(rv.Index(int(i)))
+		}, nil
+
+	case *ast.UnaryExpr:
+		x, err := compile(node.X, vars)
+		if err != nil {
+			return nil, err
+		}
+		switch node.Op {
+		case token.ADD:
+			return x, nil
+		case token.SUB:
+			return func() float64 { return -x() }, nil
+		case token.NOT:
+			return func() float64 { return b2f(x() == 0) }, nil
+		}
+		return nil, fmt.Errorf("invalid unary operator %v", node.Op)
+
+	case *ast.BinaryExpr:
+		x, err := compile(node.X, vars)
+		if err != nil {
+			return nil, err
+		}
+		y, err := compile(node.Y, vars)
+		if err != nil {
+			return nil, err
+		}
+		switch node.Op {
+		case token.ADD:
+			return func() float64 { return x() + y() }, nil
+		case token.SUB:
+			return func() float64 { return x() - y() }, nil
+		case token.MUL:
+			return func() float64 { return x() * y() }, nil
+		case token.QUO:
+			return func() float64 { return x() / y() }, nil
+		case token.REM:
+			return func() float64 { return math.Mod(x(), y()) }, nil
+		case token.EQL:
+			return func() float64 { return b2f(x() == y()) }, nil
+		case token.NEQ:
+			return func() float64 { return b2f(x() != y()) }, nil
+		case token.LSS:
+			return func() float64 { return b2f(x() < y()) }, nil
+		case token.LEQ:
+			return func() float64 { return b2f(x() <= y()) }, nil
+		case token.GTR:
+			return func() float64 { return b2f(x() > y()) }, nil
+		case token.GEQ:
+			return func() float64 { return b2f(x() >= y()) }, nil
+		case token.LAND:
+			return func() float64 { return b2f(x() != 0 && y() != 0) }, nil
+		case token.LOR:
+			return func() float64 { return b2f(x() != 0 || y() != 0) }, nil
+		}
+		return nil, fmt.Errorf("invalid binary operator %v", node.Op)
+
+	case *ast.CallExpr:
+		id, ok := node.Fun.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("invalid function call")
+		}
+		args := make([]func() float64, len(node.Args))
+		for i, arg := range node.Args {
+			f, err := compile(arg, vars)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = f
+		}
+		if fct, ok := funcs1[id.Name]; ok {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("function %s expects 1 argument (got=%d)", id.Name, len(args))
+			}
+			x := args[0]
+			return func() float64 { return fct(x()) }, nil
+		}
+		if fct, ok := funcs2[id.Name]; ok {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("function %s expects 2 arguments (got=%d)", id.Name, len(args))
+			}
+			x, y := args[0], args[1]
+			return func() float64 { return fct(x(), y()) }, nil
+		}
+		return nil, fmt.Errorf("unknown function %q", id.Name)
+	}
+
+	return nil, fmt.Errorf("invalid expression node %T", node)
+}
+
+var funcs1 = map[string]func(float64) float64{
+	"abs":   math.Abs,
+	"sqrt":  math.Sqrt,
+	"exp":   math.Exp,
+	"log":   math.Log,
+	"log10": math.Log10,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"asin":  math.Asin,
+	"acos":  math.Acos,
+	"atan":  math.Atan,
+	"sinh":  math.Sinh,
+	"cosh":  math.Cosh,
+	"tanh":  math.Tanh,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+}
+
+var funcs2 = map[string]func(float64, float64) float64{
+	"pow":   math.Pow,
+	"atan2": math.Atan2,
+	"hypot": math.Hypot,
+	"min":   math.Min,
+	"max":   math.Max,
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func asFloat(rv reflect.Value) float64 {
+	switch rv.Kind() {
+	case reflect.Bool:
+		return b2f(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+	panic(fmt.Errorf("rexpr: invalid value type %v", rv.Type()))
+}
+
+func b2f(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,184 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rexpr
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestExpr(t *testing.T) {
+	f, err := riofs.Open("../../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(rtree.Tree)
+
+	for _, tc := range []struct {
+		expr string
+		want func(i float64) float64
+	}{
+		{"Int32", func(i float64) float64 { return i }},
+		{"-Float32 + 2*Int64", func(i float64) float64 { return i }},
+		{"(Int32 + 1) / 2", func(i float64) float64 { return (i + 1) / 2 }},
+		{"Int32 % 3", func(i float64) float64 { return math.Mod(i, 3) }},
+		{"pow(Float64, 2) + abs(-1)", func(i float64) float64 { return i*i + 1 }},
+		{"max(Int32, 50)", func(i float64) float64 { return math.Max(i, 50) }},
+		{"Int32 >= 10 && !(Int32 > 20) || Int32 == 42", func(i float64) float64 {
+			return b2f(i >= 10 && i <= 20 || i == 42)
+		}},
+		{"Int32 != 3", func(i float64) float64 { return b2f(i != 3) }},
+		{"ArrayFloat64[9]", func(i float64) float64 { return i }},
+		{"ArrayFloat64[10]", func(i float64) float64 { return math.NaN() }},
+		{"SliceFloat64[N-1]", func(i float64) float64 {
+			if math.Mod(i, 10) == 0 {
+				return math.NaN()
+			}
+			return i
+		}},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := New(tc.expr)
+			if err != nil {
+				t.Fatalf("could not parse expression: %+v", err)
+			}
+
+			r, err := rtree.NewReader(tree, nil, rtree.WithRange(0, 20))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			f, err := r.Formula(e)
+			if err != nil {
+				t.Fatalf("could not bind expression: %+v", err)
+			}
+			eval := f.Func().(func() float64)
+
+			err = r.Read(func(ctx rtree.RCtx) error {
+				got := eval()
+				want := tc.want(float64(ctx.Entry))
+				if got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+					return fmt.Errorf("entry[%d]: got=%v, want=%v", ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+		})
+	}
+}
+
+func TestCut(t *testing.T) {
+	f, err := riofs.Open("../../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(rtree.Tree)
+
+	for _, tc := range []struct {
+		cut  string
+		want int
+	}{
+		{"Int32 < 10", 10},
+		{"Int32 >= 10 && Int32 < 15", 5},
+		{"N", 90},
+		{"SliceFloat64[0] > 50", 45},
+		{"ArrayFloat64[10] > 0", 0},
+		{"1", 100},
+		{"0", 0},
+	} {
+		t.Run(tc.cut, func(t *testing.T) {
+			c, err := NewCut(tc.cut)
+			if err != nil {
+				t.Fatalf("could not parse selection: %+v", err)
+			}
+
+			r, err := rtree.NewReader(tree, nil)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			f, err := r.Formula(c)
+			if err != nil {
+				t.Fatalf("could not bind selection: %+v", err)
+			}
+			pass := f.Func().(func() bool)
+
+			n := 0
+			err = r.Read(func(ctx rtree.RCtx) error {
+				if pass() {
+					n++
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+
+			if got, want := n, tc.want; got != want {
+				t.Fatalf("invalid number of selected entries: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		want string
+	}{
+		{"Int32 +", `rexpr: could not parse expression "Int32 +": 1:8: expected operand, found 'EOF'`},
+		{"foo(Int32)", `rexpr: could not compile expression "foo(Int32)": unknown function "foo"`},
+		{"sqrt(Int32, 2)", `rexpr: could not compile expression "sqrt(Int32, 2)": function sqrt expects 1 argument (got=2)`},
+		{"Int32[0]", `rexpr: could not compile expression "Int32[0]": variable "Int32" is not an array (type=int32)`},
+		{"Str", `rexpr: could not compile expression "Str": variable "Str" is not a scalar (type=string)`},
+		{"Int32 << 2", `rexpr: could not compile expression "Int32 << 2": invalid binary operator <<`},
+		{`"str"`, `rexpr: could not compile expression "\"str\"": invalid literal "str"`},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := New(tc.expr)
+			if err == nil {
+				var (
+					i32 int32
+					str string
+				)
+				vars := map[string]interface{}{
+					"Int32": &i32,
+					"Str":   &str,
+				}
+				ptrs := make([]interface{}, len(e.RVars()))
+				for i, name := range e.RVars() {
+					ptrs[i] = vars[name]
+				}
+				err = e.Bind(ptrs)
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.want; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package xxh3 implements the 64-bit variant of the XXH3 hash algorithm,
+// as used by ROOT to checksum RNTuple envelopes, pages and anchors.
+//
+// See https://github.com/Cyan4973/xxHash for the reference implementation.
+package xxh3 // import "go-hep.org/x/hep/groot/internal/xxh3"
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	prime32_1 = 0x9E3779B1
+	prime32_2 = 0x85EBCA77
+	prime32_3 = 0xC2B2AE3D
+
+	prime64_1 = 0x9E3779B185EBCA87
+	prime64_2 = 0xC2B2AE3D27D4EB4F
+	prime64_3 = 0x165667B19E3779F9
+	prime64_4 = 0x85EBCA77C2B2AE63
+	prime64_5 = 0x27D4EB2F165667C5
+
+	stripeLen   = 64
+	consumeRate = 8
+	accNB       = stripeLen / 8
+
+	secretMergeAccsStart = 11
+	secretLastAccStart   = 7
+	secretSizeMin        = 136
+)
+
+var secret = [192]byte{
+	0xb8, 0xfe, 0x6c, 0x39, 0x23, 0xa4, 0x4b, 0xbe, 0x7c, 0x01, 0x81, 0x2c, 0xf7, 0x21, 0xad, 0x1c,
+	0xde, 0xd4, 0x6d, 0xe9, 0x83, 0x90, 0x97, 0xdb, 0x72, 0x40, 0xa4, 0xa4, 0xb7, 0xb3, 0x67, 0x1f,
+	0xcb, 0x79, 0xe6, 0x4e, 0xcc, 0xc0, 0xe5, 0x78, 0x82, 0x5a, 0xd0, 0x7d, 0xcc, 0xff, 0x72, 0x21,
+	0xb8, 0x08, 0x46, 0x74, 0xf7, 0x43, 0x24, 0x8e, 0xe0, 0x35, 0x90, 0xe6, 0x81, 0x3a, 0x26, 0x4c,
+	0x3c, 0x28, 0x52, 0xbb, 0x91, 0xc3, 0x00, 0xcb, 0x88, 0xd0, 0x65, 0x8b, 0x1b, 0x53, 0x2e, 0xa3,
+	0x71, 0x64, 0x48, 0x97, 0xa2, 0x0d, 0xf9, 0x4e, 0x38, 0x19, 0xef, 0x46, 0xa9, 0xde, 0xac, 0xd8,
+	0xa8, 0xfa, 0x76, 0x3f, 0xe3, 0x9c, 0x34, 0x3f, 0xf9, 0xdc, 0xbb, 0xc7, 0xc7, 0x0b, 0x4f, 0x1d,
+	0x8a, 0x51, 0xe0, 0x4b, 0xcd, 0xb4, 0x59, 0x31, 0xc8, 0x9f, 0x7e, 0xc9, 0xd9, 0x78, 0x73, 0x64,
+	0xea, 0xc5, 0xac, 0x83, 0x34, 0xd3, 0xeb, 0xc3, 0xc5, 0x81, 0xa0, 0xff, 0xfa, 0x13, 0x63, 0xeb,
+	0x17, 0x0d, 0xdd, 0x51, 0xb7, 0xf0, 0xda, 0x49, 0xd3, 0x16, 0x55, 0x26, 0x29, 0xd4, 0x68, 0x9e,
+	0x2b, 0x16, 0xbe, 0x58, 0x7d, 0x47, 0xa1, 0xfc, 0x8f, 0xf8, 0xb8, 0xd1, 0x7a, 0xd0, 0x31, 0xce,
+	0x45, 0xcb, 0x3a, 0x8f, 0x95, 0x16, 0x04, 0x28, 0xaf, 0xd7, 0xfb, 0xca, 0xbb, 0x4b, 0x40, 0x7e,
+}
+
+// Sum64 returns the 64-bit XXH3 hash of p, with a zero seed.
+func Sum64(p []byte) uint64 {
+	n := len(p)
+	switch {
+	case n == 0:
+		return avalanche64(u64(secret[56:]) ^ u64(secret[64:]))
+	case n <= 3:
+		return hash1to3(p)
+	case n <= 8:
+		return hash4to8(p)
+	case n <= 16:
+		return hash9to16(p)
+	case n <= 128:
+		return hash17to128(p)
+	case n <= 240:
+		return hash129to240(p)
+	default:
+		return hashLong(p)
+	}
+}
+
+func u32(p []byte) uint32 { return binary.LittleEndian.Uint32(p) }
+func u64(p []byte) uint64 { return binary.LittleEndian.Uint64(p) }
+
+func mulFold64(lhs, rhs uint64) uint64 {
+	hi, lo := bits.Mul64(lhs, rhs)
+	return hi ^ lo
+}
+
+// avalanche64 is the XXH64 avalanche.
+func avalanche64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= prime64_2
+	h ^= h >> 29
+	h *= prime64_3
+	h ^= h >> 32
+	return h
+}
+
+func avalanche(h uint64) uint64 {
+	h ^= h >> 37
+	h *= 0x165667919E3779F9
+	h ^= h >> 32
+	return h
+}
+
+func rrmxmx(h uint64, n int) uint64 {
+	h ^= bits.RotateLeft64(h, 49) ^ bits.RotateLeft64(h, 24)
+	h *= 0x9FB21C651E98DF25
+	h ^= (h >> 35) + uint64(n)
+	h *= 0x9FB21C651E98DF25
+	h ^= h >> 28
+	return h
+}
+
+func hash1to3(p []byte) uint64 {
+	n := len(p)
+	combo := uint32(p[0])<<16 | uint32(p[n>>1])<<24 | uint32(p[n-1]) | uint32(n)<<8
+	flip := uint64(u32(secret[0:]) ^ u32(secret[4:]))
+	return avalanche64(uint64(combo) ^ flip)
+}
+
+func hash4to8(p []byte) uint64 {
+	n := len(p)
+	v := uint64(u32(p[n-4:])) + uint64(u32(p))<<32
+	flip := u64(secret[8:]) ^ u64(secret[16:])
+	return rrmxmx(v^flip, n)
+}
+
+func hash9to16(p []byte) uint64 {
+	n := len(p)
+	flip1 := u64(secret[24:]) ^ u64(secret[32:])
+	flip2 := u64(secret[40:]) ^ u64(secret[48:])
+	lo := u64(p) ^ flip1
+	hi := u64(p[n-8:]) ^ flip2
+	acc := uint64(n) + bits.ReverseBytes64(lo) + hi + mulFold64(lo, hi)
+	return avalanche(acc)
+}
+
+func mix16(p, s []byte) uint64 {
+	return mulFold64(u64(p)^u64(s), u64(p[8:])^u64(s[8:]))
+}
+
+func hash17to128(p []byte) uint64 {
+	n := len(p)
+	acc := uint64(n) * prime64_1
+	if n > 32 {
+		if n > 64 {
+			if n > 96 {
+				acc += mix16(p[48:], secret[96:])
+				acc += mix16(p[n-64:], secret[112:])
+			}
+			acc += mix16(p[32:], secret[64:])
+			acc += mix16(p[n-48:], secret[80:])
+		}
+		acc += mix16(p[16:], secret[32:])
+		acc += mix16(p[n-32:], secret[48:])
+	}
+	acc += mix16(p, secret[0:])
+	acc += mix16(p[n-16:], secret[16:])
+	return avalanche(acc)
+}
+
+func hash129to240(p []byte) uint64 {
+	const (
+		startOffset = 3
+		lastOffset  = 17
+	)
+	n := len(p)
+	acc := uint64(n) * prime64_1
+	rounds := n / 16
+	for i := 0; i < 8; i++ {
+		acc += mix16(p[16*i:], secret[16*i:])
+	}
+	acc = avalanche(acc)
+	for i := 8; i < rounds; i++ {
+		acc += mix16(p[16*i:], secret[16*(i-8)+startOffset:])
+	}
+	acc += mix16(p[n-16:], secret[secretSizeMin-lastOffset:])
+	return avalanche(acc)
+}
+
+func accumulate512(acc *[accNB]uint64, p, s []byte) {
+	for i := 0; i < accNB; i++ {
+		v := u64(p[8*i:])
+		k := v ^ u64(s[8*i:])
+		acc[i^1] += v
+		acc[i] += uint64(uint32(k)) * (k >> 32)
+	}
+}
+
+func scramble(acc *[accNB]uint64, s []byte) {
+	for i := 0; i < accNB; i++ {
+		v := acc[i]
+		v ^= v >> 47
+		v ^= u64(s[8*i:])
+		acc[i] = v * prime32_1
+	}
+}
+
+func hashLong(p []byte) uint64 {
+	const (
+		stripes  = (len(secret) - stripeLen) / consumeRate
+		blockLen = stripeLen * stripes
+	)
+
+	acc := [accNB]uint64{
+		prime32_3, prime64_1, prime64_2, prime64_3,
+		prime64_4, prime32_2, prime64_5, prime32_1,
+	}
+
+	n := len(p)
+	blocks := (n - 1) / blockLen
+	for b := 0; b < blocks; b++ {
+		for i := 0; i < stripes; i++ {
+			accumulate512(&acc, p[b*blockLen+i*stripeLen:], secret[i*consumeRate:])
+		}
+		scramble(&acc, secret[len(secret)-stripeLen:])
+	}
+
+	last := ((n - 1) - blockLen*blocks) / stripeLen
+	for i := 0; i < last; i++ {
+		accumulate512(&acc, p[blocks*blockLen+i*stripeLen:], secret[i*consumeRate:])
+	}
+	accumulate512(&acc, p[n-stripeLen:], secret[len(secret)-stripeLen-secretLastAccStart:])
+
+	h := uint64(n) * prime64_1
+	for i := 0; i < 4; i++ {
+		h += mulFold64(
+			acc[2*i]^u64(secret[secretMergeAccsStart+16*i:]),
+			acc[2*i+1]^u64(secret[secretMergeAccsStart+16*i+8:]),
+		)
+	}
+	return avalanche(h)
+}
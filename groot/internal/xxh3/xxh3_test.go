@@ -0,0 +1,59 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xxh3
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSum64(t *testing.T) {
+	buf := make([]byte, 5000)
+	for i := range buf {
+		buf[i] = byte((i*7 + 3) % 251)
+	}
+
+	// reference values computed with libxxhash-0.8.1.
+	for _, tc := range []struct {
+		n    int
+		want uint64
+	}{
+		{0, 0x2d06800538d394c2},
+		{1, 0x13e608bc156defed},
+		{2, 0x1c9074b93943b86c},
+		{3, 0xa9088dda485b481c},
+		{4, 0x6d9253b16c8b1ed3},
+		{5, 0x998620e10e3a4b37},
+		{8, 0x60539db630471163},
+		{9, 0xfeff668361d723a8},
+		{12, 0x6829454be0cc3199},
+		{16, 0xb8c859b0f030b585},
+		{17, 0x714a04408e79b80f},
+		{32, 0x19ff4ee1d6ba1a55},
+		{33, 0x3e44983ad21679c8},
+		{64, 0x38bcde5122f74956},
+		{65, 0x95a166c5957453d9},
+		{96, 0x75d654bdaee123df},
+		{97, 0x1296f9e2421ab74c},
+		{128, 0x4634ae6a253a60e4},
+		{129, 0xc095b9b1b087722d},
+		{200, 0xa369f2930049476f},
+		{240, 0x887af00281f75d38},
+		{241, 0x82b1de299f6e411e},
+		{512, 0xc5aecd4d330685c6},
+		{1024, 0xf75e768c7cdd54b2},
+		{1025, 0x667a5eabe344e5df},
+		{2048, 0x9e5e4a8160109a5d},
+		{4096, 0xbfbe1b9d928ede47},
+		{5000, 0x8e5898f51713d386},
+	} {
+		t.Run(fmt.Sprintf("len=%d", tc.n), func(t *testing.T) {
+			got := Sum64(buf[:tc.n])
+			if got != tc.want {
+				t.Fatalf("invalid hash: got=0x%016x, want=0x%016x", got, tc.want)
+			}
+		})
+	}
+}
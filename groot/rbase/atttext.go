@@ -0,0 +1,96 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcolors"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+type AttText struct {
+	Angle float32
+	Size  float32
+	Align int16
+	Color int16
+	Font  int16
+}
+
+func NewAttText() *AttText {
+	return &AttText{
+		Angle: 0,
+		Size:  0.05,
+		Align: 11,
+		Color: rcolors.Black,
+		Font:  62,
+	}
+}
+
+func (*AttText) Class() string {
+	return "TAttText"
+}
+
+func (*AttText) RVersion() int16 {
+	return rvers.AttText
+}
+
+func (a *AttText) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(a.Class(), a.RVersion())
+	w.WriteF32(a.Angle)
+	w.WriteF32(a.Size)
+	w.WriteI16(a.Align)
+	w.WriteI16(a.Color)
+	w.WriteI16(a.Font)
+	return w.SetHeader(hdr)
+}
+
+func (a *AttText) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(a.Class(), a.RVersion())
+
+	a.Angle = r.ReadF32()
+	a.Size = r.ReadF32()
+	a.Align = r.ReadI16()
+	a.Color = r.ReadI16()
+	a.Font = r.ReadI16()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (a *AttText) RMembers() []rbytes.Member {
+	return []rbytes.Member{
+		{Name: "fTextAngle", Value: &a.Angle},
+		{Name: "fTextSize", Value: &a.Size},
+		{Name: "fTextAlign", Value: &a.Align},
+		{Name: "fTextColor", Value: &a.Color},
+		{Name: "fTextFont", Value: &a.Font},
+	}
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewAttText()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TAttText", f)
+}
+
+var (
+	_ root.Object        = (*AttText)(nil)
+	_ rbytes.Marshaler   = (*AttText)(nil)
+	_ rbytes.Unmarshaler = (*AttText)(nil)
+)
@@ -123,6 +123,10 @@ func TestWRBuffer(t *testing.T) {
 				fFrameBorderMode: 17,
 			},
 		},
+		{
+			name: "TAttText",
+			want: &AttText{Angle: 45, Size: 0.04, Align: 22, Color: 2, Font: 42},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			{
@@ -0,0 +1,420 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rbrowse provides a TBrowser-like data model of the content
+// of ROOT files.
+//
+// The content of a ROOT file is exposed as a tree of nodes: the file,
+// its directories and keys, the branches and leaves of the trees.
+// Nodes are loaded lazily, when their children are first requested, so
+// that GUI and TUI front-ends can browse large files without decoding
+// objects that are never displayed.
+//
+// Browsers and nodes are not safe for concurrent use.
+package rbrowse // import "go-hep.org/x/hep/groot/rbrowse"
+
+import (
+	"errors"
+	"fmt"
+	stdpath "path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// Kind describes the kind of a node.
+type Kind uint8
+
+const (
+	File   Kind = iota // a ROOT file
+	Dir                // a ROOT directory
+	Object             // a ROOT object stored under a key
+	Tree               // a ROOT tree stored under a key
+	Branch             // a branch of a tree
+	Leaf               // a leaf of a branch
+)
+
+func (k Kind) String() string {
+	switch k {
+	case File:
+		return "file"
+	case Dir:
+		return "dir"
+	case Object:
+		return "object"
+	case Tree:
+		return "tree"
+	case Branch:
+		return "branch"
+	case Leaf:
+		return "leaf"
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// SkipNode is used as a return value from WalkFuncs to indicate that
+// the children of the node named in the call are to be skipped.
+// It is not returned as an error by any function.
+var SkipNode = errors.New("rbrowse: skip this node") //lint:ignore ST1012 EOF-like sentry
+
+// Browser exposes the content of a ROOT file as a tree of nodes.
+type Browser struct {
+	f    *riofs.File
+	own  bool // whether the browser owns the file
+	root *Node
+}
+
+// Open opens the named ROOT file and returns a browser over its content.
+func Open(fname string) (*Browser, error) {
+	f, err := groot.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("rbrowse: could not open ROOT file %q: %w", fname, err)
+	}
+
+	b := New(f)
+	b.own = true
+	return b, nil
+}
+
+// New returns a browser over the content of the provided ROOT file.
+// Closing the browser does not close the file.
+func New(f *riofs.File) *Browser {
+	b := &Browser{f: f}
+	b.root = &Node{
+		Kind:  File,
+		Path:  "/",
+		Name:  f.Name(),
+		Title: f.Title(),
+		Class: f.Class(),
+		dir:   f,
+	}
+	return b
+}
+
+// Close closes the browser and the underlying ROOT file, if it was
+// opened by the browser.
+func (b *Browser) Close() error {
+	if !b.own || b.f == nil {
+		return nil
+	}
+	err := b.f.Close()
+	b.f = nil
+	return err
+}
+
+// Root returns the node describing the ROOT file.
+func (b *Browser) Root() *Node {
+	return b.root
+}
+
+// Lookup returns the node located at the provided path.
+//
+// Paths are made of '/'-separated node names, relative to the root node,
+// e.g. "dir1/dir11/tree/branch/leaf".
+// Keys are matched with their highest cycle, unless an explicit cycle is
+// provided, e.g. "dir1/h1;2".
+func (b *Browser) Lookup(path string) (*Node, error) {
+	node := b.root
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if name == "" {
+			continue
+		}
+		sub, err := node.child(name)
+		if err != nil {
+			return nil, fmt.Errorf("rbrowse: could not find %q: %w", path, err)
+		}
+		node = sub
+	}
+	return node, nil
+}
+
+// Node is a node of the browsing tree.
+type Node struct {
+	Kind  Kind
+	Path  string // path of the node, from the root node
+	Name  string
+	Title string
+	Class string // ROOT class of the node
+	Cycle int    // cycle of the key, for directories, objects and trees
+
+	Type    string // C++ type of the leaf elements, for leaves
+	Entries int64  // number of entries, for branches and loaded trees
+
+	// Size is the number of bytes of the node on disk.
+	// For directories, objects and trees, it is the size of the key
+	// record; the size of the baskets of the branches of a tree are
+	// reported by its branches.
+	// For branches, it is the size of their baskets, after compression.
+	Size int64
+
+	// RawSize is the number of bytes of the node before compression.
+	RawSize int64
+
+	parent *Node
+	nodes  []*Node
+	loaded bool
+
+	obj    root.Object
+	dir    riofs.Directory
+	branch rtree.Branch
+	leaf   rtree.Leaf
+}
+
+// Parent returns the parent node of the node.
+// Parent returns nil for the root node.
+func (n *Node) Parent() *Node {
+	return n.parent
+}
+
+// Children returns the children of the node, loading them if needed.
+//
+// The children of directories are their keys, the children of trees are
+// their branches and the children of branches are their sub-branches
+// followed by their leaves.
+// Objects and leaves have no children.
+func (n *Node) Children() ([]*Node, error) {
+	if n.loaded {
+		return n.nodes, nil
+	}
+
+	var err error
+	switch n.Kind {
+	case File, Dir:
+		err = n.loadDir()
+	case Tree:
+		err = n.loadTree()
+	case Branch:
+		n.loadBranches(n.branch.Branches(), n.branch.Leaves())
+	}
+	if err != nil {
+		return nil, err
+	}
+	n.loaded = true
+	return n.nodes, nil
+}
+
+// Object returns the ROOT object described by the node, loading it from
+// disk if needed.
+func (n *Node) Object() (root.Object, error) {
+	switch n.Kind {
+	case File, Dir:
+		if n.dir == nil {
+			err := n.loadObject()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return n.dir.(root.Object), nil
+	case Branch:
+		return n.branch, nil
+	case Leaf:
+		return n.leaf, nil
+	}
+
+	err := n.loadObject()
+	if err != nil {
+		return nil, err
+	}
+	return n.obj, nil
+}
+
+func (n *Node) String() string {
+	return fmt.Sprintf("%s %s (%s)", n.Kind, n.Path, n.Class)
+}
+
+func (n *Node) loadObject() error {
+	if n.obj != nil {
+		return nil
+	}
+
+	dir := n.parent.dir
+	obj, err := dir.Get(n.Name + ";" + strconv.Itoa(n.Cycle))
+	if err != nil {
+		return fmt.Errorf("rbrowse: could not load %q: %w", n.Path, err)
+	}
+	n.obj = obj
+
+	switch obj := obj.(type) {
+	case riofs.Directory:
+		n.dir = obj
+	case rtree.Tree:
+		n.Entries = obj.Entries()
+	}
+	return nil
+}
+
+func (n *Node) loadDir() error {
+	if n.dir == nil {
+		err := n.loadObject()
+		if err != nil {
+			return err
+		}
+	}
+
+	keys := n.dir.Keys()
+	n.nodes = make([]*Node, 0, len(keys))
+	for i := range keys {
+		key := &keys[i]
+		sub := &Node{
+			Kind:    kindOf(key),
+			Path:    stdpath.Join(n.Path, key.Name()),
+			Name:    key.Name(),
+			Title:   key.Title(),
+			Class:   key.ClassName(),
+			Cycle:   key.Cycle(),
+			Size:    int64(key.Nbytes()),
+			RawSize: int64(key.KeyLen()) + int64(key.ObjLen()),
+			parent:  n,
+		}
+		n.nodes = append(n.nodes, sub)
+	}
+	return nil
+}
+
+func (n *Node) loadTree() error {
+	err := n.loadObject()
+	if err != nil {
+		return err
+	}
+
+	tree, ok := n.obj.(rtree.Tree)
+	if !ok {
+		return fmt.Errorf("rbrowse: %q is not a tree (type=%T)", n.Path, n.obj)
+	}
+	n.loadBranches(tree.Branches(), nil)
+	return nil
+}
+
+func (n *Node) loadBranches(branches []rtree.Branch, leaves []rtree.Leaf) {
+	n.nodes = make([]*Node, 0, len(branches)+len(leaves))
+	for _, b := range branches {
+		n.nodes = append(n.nodes, &Node{
+			Kind:    Branch,
+			Path:    stdpath.Join(n.Path, b.Name()),
+			Name:    b.Name(),
+			Title:   b.Title(),
+			Class:   b.Class(),
+			Entries: b.Entries(),
+			Size:    b.ZipBytes(),
+			RawSize: b.TotBytes(),
+			parent:  n,
+			branch:  b,
+		})
+	}
+
+	for _, leaf := range leaves {
+		n.nodes = append(n.nodes, &Node{
+			Kind:   Leaf,
+			Path:   stdpath.Join(n.Path, leaf.Name()),
+			Name:   leaf.Name(),
+			Title:  leaf.Title(),
+			Class:  leaf.Class(),
+			Type:   leaf.TypeName(),
+			parent: n,
+			leaf:   leaf,
+		})
+	}
+}
+
+// child returns the child node with the provided name.
+// Names may hold an explicit key cycle.
+func (n *Node) child(name string) (*Node, error) {
+	nodes, err := n.Children()
+	if err != nil {
+		return nil, err
+	}
+
+	cycle := -1
+	if i := strings.LastIndex(name, ";"); i > 0 && (n.Kind == File || n.Kind == Dir) {
+		v, err := strconv.Atoi(name[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cycle in %q: %w", name, err)
+		}
+		name, cycle = name[:i], v
+	}
+
+	var node *Node
+	for _, sub := range nodes {
+		if sub.Name != name {
+			continue
+		}
+		switch {
+		case cycle >= 0:
+			if sub.Cycle == cycle {
+				return sub, nil
+			}
+		case node == nil || sub.Cycle > node.Cycle:
+			node = sub
+		}
+	}
+	if node == nil {
+		return nil, fmt.Errorf("no node %q under %q", name, n.Path)
+	}
+	return node, nil
+}
+
+// WalkFunc is the type of the function called for each node visited
+// by Walk.
+//
+// If the function returns SkipNode, the children of the node are skipped.
+// Any other non-nil error stops the walk and is returned by Walk.
+type WalkFunc func(node *Node) error
+
+// Walk walks the tree of nodes rooted at node, calling fn for each node,
+// including node, in depth-first order.
+//
+// Walk loads the children of all the visited nodes, which may decode
+// all the objects of the ROOT file.
+func Walk(node *Node, fn WalkFunc) error {
+	err := walk(node, fn)
+	if err == SkipNode {
+		return nil
+	}
+	return err
+}
+
+func walk(node *Node, fn WalkFunc) error {
+	err := fn(node)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := node.Children()
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range nodes {
+		err := walk(sub, fn)
+		if err != nil && err != SkipNode {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	dirType  = reflect.TypeOf((*riofs.Directory)(nil)).Elem()
+	treeType = reflect.TypeOf((*rtree.Tree)(nil)).Elem()
+)
+
+// kindOf returns the kind of node associated with the payload of the
+// provided key.
+func kindOf(key *riofs.Key) Kind {
+	rt := key.ObjectType()
+	switch {
+	case rt == nil:
+		return Object
+	case rt.Implements(dirType):
+		return Dir
+	case rt.Implements(treeType):
+		return Tree
+	}
+	return Object
+}
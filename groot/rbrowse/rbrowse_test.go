@@ -0,0 +1,258 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbrowse_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rbrowse"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/internal/diff"
+)
+
+func TestWalk(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		skip  string
+		want  string
+	}{
+		{
+			fname: "../testdata/dirs-6.14.00.root",
+			want: `file / (TFile)
+dir /dir1 (TDirectoryFile)
+dir /dir1/dir11 (TDirectoryFile)
+object /dir1/dir11/h1 (TH1F)
+dir /dir2 (TDirectoryFile)
+dir /dir3 (TDirectoryFile)
+`,
+		},
+		{
+			fname: "../testdata/dirs-6.14.00.root",
+			skip:  "/dir1",
+			want: `file / (TFile)
+dir /dir1 (TDirectoryFile)
+dir /dir2 (TDirectoryFile)
+dir /dir3 (TDirectoryFile)
+`,
+		},
+		{
+			fname: "../testdata/simple.root",
+			want: `file / (TFile)
+tree /tree (TTree)
+branch /tree/one (TBranch)
+leaf /tree/one/one (TLeafI)
+branch /tree/two (TBranch)
+leaf /tree/two/two (TLeafF)
+branch /tree/three (TBranch)
+leaf /tree/three/three (TLeafC)
+`,
+		},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			b, err := rbrowse.Open(tc.fname)
+			if err != nil {
+				t.Fatalf("could not open browser: %+v", err)
+			}
+			defer b.Close()
+
+			o := new(strings.Builder)
+			err = rbrowse.Walk(b.Root(), func(node *rbrowse.Node) error {
+				fmt.Fprintf(o, "%v\n", node)
+				if node.Path == tc.skip {
+					return rbrowse.SkipNode
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not walk file: %+v", err)
+			}
+
+			if got, want := o.String(), tc.want; got != want {
+				t.Fatalf("invalid walk:\n%s", diff.Format(got, want))
+			}
+
+			err = b.Close()
+			if err != nil {
+				t.Fatalf("could not close browser: %+v", err)
+			}
+		})
+	}
+}
+
+// nodeInfo holds the exported data of a rbrowse.Node.
+type nodeInfo struct {
+	Kind    rbrowse.Kind
+	Path    string
+	Name    string
+	Title   string
+	Class   string
+	Cycle   int
+	Type    string
+	Entries int64
+	Size    int64
+	RawSize int64
+}
+
+func TestLookup(t *testing.T) {
+	f, err := groot.Open("../testdata/small-evnt-tree-fullsplit.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	b := rbrowse.New(f)
+	defer b.Close()
+
+	if got, want := b.Root().Parent(), (*rbrowse.Node)(nil); got != want {
+		t.Fatalf("invalid root parent: %v", got)
+	}
+
+	for _, tc := range []struct {
+		path string
+		want nodeInfo
+		err  string
+	}{
+		{
+			path: "/",
+			want: nodeInfo{Kind: rbrowse.File, Path: "/", Class: "TFile"},
+		},
+		{
+			path: "tree",
+			want: nodeInfo{
+				Kind: rbrowse.Tree, Path: "/tree", Name: "tree", Title: "my tree title",
+				Class: "TTree", Cycle: 1, Entries: 100, Size: 3250, RawSize: 23563,
+			},
+		},
+		{
+			path: "tree;1",
+			want: nodeInfo{
+				Kind: rbrowse.Tree, Path: "/tree", Name: "tree", Title: "my tree title",
+				Class: "TTree", Cycle: 1, Entries: 100, Size: 3250, RawSize: 23563,
+			},
+		},
+		{
+			path: "/tree/evt/P3/P3.Py",
+			want: nodeInfo{
+				Kind: rbrowse.Branch, Path: "/tree/evt/P3/P3.Py", Name: "P3.Py", Title: "P3.Py",
+				Class: "TBranchElement", Entries: 100, Size: 295, RawSize: 872,
+			},
+		},
+		{
+			path: "/tree/evt/SliceF64/SliceF64",
+			want: nodeInfo{
+				Kind: rbrowse.Leaf, Path: "/tree/evt/SliceF64/SliceF64", Name: "SliceF64", Title: "SliceF64[N]",
+				Class: "TLeafElement", Type: "double*",
+			},
+		},
+		{
+			path: "tree;2",
+			err:  `rbrowse: could not find "tree;2": no node "tree" under "/"`,
+		},
+		{
+			path: "tree;x",
+			err:  `rbrowse: could not find "tree;x": invalid cycle in "tree;x": strconv.Atoi: parsing "x": invalid syntax`,
+		},
+		{
+			path: "tree/evt/not-there",
+			err:  `rbrowse: could not find "tree/evt/not-there": no node "not-there" under "/tree/evt"`,
+		},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			node, err := b.Lookup(tc.path)
+			switch {
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error:\ngot= %q\nwant=%q", got, want)
+				}
+				return
+			case err != nil:
+				t.Fatalf("could not lookup node: %+v", err)
+			case tc.err != "":
+				t.Fatalf("expected an error")
+			}
+
+			if tc.want.Kind == rbrowse.Tree {
+				// make sure the tree is loaded.
+				_, err = node.Object()
+				if err != nil {
+					t.Fatalf("could not load tree: %+v", err)
+				}
+			}
+
+			got := nodeInfo{
+				Kind:    node.Kind,
+				Path:    node.Path,
+				Name:    node.Name,
+				Title:   node.Title,
+				Class:   node.Class,
+				Cycle:   node.Cycle,
+				Type:    node.Type,
+				Entries: node.Entries,
+				Size:    node.Size,
+				RawSize: node.RawSize,
+			}
+			if tc.want.Kind == rbrowse.File {
+				got.Name = ""
+			}
+			if got != tc.want {
+				t.Fatalf("invalid node:\ngot= %+v\nwant=%+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestObject(t *testing.T) {
+	b, err := rbrowse.Open("../testdata/dirs-6.14.00.root")
+	if err != nil {
+		t.Fatalf("could not open browser: %+v", err)
+	}
+	defer b.Close()
+
+	node, err := b.Lookup("dir1/dir11/h1")
+	if err != nil {
+		t.Fatalf("could not lookup node: %+v", err)
+	}
+	if got, want := node.Parent().Path, "/dir1/dir11"; got != want {
+		t.Fatalf("invalid parent: got=%q, want=%q", got, want)
+	}
+
+	obj, err := node.Object()
+	if err != nil {
+		t.Fatalf("could not load object: %+v", err)
+	}
+	if _, ok := obj.(*rhist.H1F); !ok {
+		t.Fatalf("invalid object type: %T", obj)
+	}
+
+	nodes, err := node.Children()
+	if err != nil {
+		t.Fatalf("could not load children: %+v", err)
+	}
+	if len(nodes) != 0 {
+		t.Fatalf("invalid number of children: %d", len(nodes))
+	}
+
+	b, err = rbrowse.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("could not open browser: %+v", err)
+	}
+	defer b.Close()
+
+	node, err = b.Lookup("tree/two")
+	if err != nil {
+		t.Fatalf("could not lookup node: %+v", err)
+	}
+	obj, err = node.Object()
+	if err != nil {
+		t.Fatalf("could not load object: %+v", err)
+	}
+	if _, ok := obj.(rtree.Branch); !ok {
+		t.Fatalf("invalid object type: %T", obj)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbytes
+
+// Interner deduplicates strings decoded from ROOT buffers, so that
+// repeated values (e.g. categorical string branches) share the same
+// backing storage instead of being allocated for each entry.
+//
+// An Interner holds at most a bounded number of strings.
+// An Interner is not safe for concurrent use.
+type Interner struct {
+	max int               // maximum number of interned strings
+	db  map[string]string // set of interned strings
+}
+
+// maxInternLen is the maximum length of strings considered for interning.
+// Longer strings are less likely to be repeated.
+const maxInternLen = 128
+
+// NewInterner creates a new Interner holding at most n strings.
+func NewInterner(n int) *Interner {
+	return &Interner{
+		max: n,
+		db:  make(map[string]string),
+	}
+}
+
+// Len returns the number of interned strings.
+func (in *Interner) Len() int {
+	return len(in.db)
+}
+
+// Bytes returns a string with the content of p.
+// The returned string does not alias p.
+func (in *Interner) Bytes(p []byte) string {
+	if len(p) > maxInternLen {
+		return string(p)
+	}
+	// the compiler optimizes the conversion away for map lookups.
+	if s, ok := in.db[string(p)]; ok {
+		return s
+	}
+	s := string(p)
+	if len(in.db) < in.max {
+		in.db[s] = s
+	}
+	return s
+}
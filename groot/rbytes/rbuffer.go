@@ -5,6 +5,7 @@
 package rbytes
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -62,6 +63,7 @@ type RBuffer struct {
 	offset uint32
 	refs   map[int64]interface{}
 	sictx  StreamerInfoContext
+	strs   *Interner // optional string interner
 }
 
 func NewRBuffer(data []byte, refs map[int64]interface{}, offset uint32, ctx StreamerInfoContext) *RBuffer {
@@ -95,6 +97,20 @@ func (r *RBuffer) Reset(data []byte, refs map[int64]interface{}, offset uint32,
 	return r
 }
 
+// SetInterner configures the buffer to deduplicate the decoded strings
+// with the provided interner.
+// A nil interner disables interning.
+//
+// The interner is kept across calls to Reset.
+func (r *RBuffer) SetInterner(in *Interner) {
+	r.strs = in
+}
+
+// Interner returns the string interner associated with this buffer, if any.
+func (r *RBuffer) Interner() *Interner {
+	return r.strs
+}
+
 // ReadHeader reads the serialization header for the given class and its known maximum version.
 func (r *RBuffer) ReadHeader(class string, vmax int16) Header {
 	hdr := Header{
@@ -244,20 +260,25 @@ func (r *RBuffer) ReadString() string {
 	if n == 0 {
 		return ""
 	}
-	v := r.ReadU8()
-	if v == 0 {
+	beg := r.r.c
+	if r.r.p[beg] == 0 {
+		r.r.c++
 		return ""
 	}
-	buf := make([]byte, n)
-	buf[0] = v
-	if n != 0 {
-		r.read(buf[1:])
-		if r.err != nil {
-			return ""
-		}
-		return string(buf)
+	end := beg + n
+	if end > len(r.r.p) {
+		end = len(r.r.p)
+	}
+	r.r.c = end
+	return r.str(r.r.p[beg:end])
+}
+
+// str returns the content of p as a string, interning it if needed.
+func (r *RBuffer) str(p []byte) string {
+	if r.strs != nil {
+		return r.strs.Bytes(p)
 	}
-	return ""
+	return string(p)
 }
 
 func (r *RBuffer) ReadCString(n int) string {
@@ -265,15 +286,18 @@ func (r *RBuffer) ReadCString(n int) string {
 		return ""
 	}
 
-	buf := make([]byte, n)
-	for i := 0; i < n; i++ {
-		r.read(buf[i : i+1])
-		if buf[i] == 0 {
-			buf = buf[:i]
-			break
-		}
+	beg := r.r.c
+	end := beg + n
+	if end > len(r.r.p) {
+		end = len(r.r.p)
+	}
+	buf := r.r.p[beg:end]
+	if i := bytes.IndexByte(buf, 0); i >= 0 {
+		buf = buf[:i]
+		end = beg + i + 1
 	}
-	return string(buf)
+	r.r.c = end
+	return r.str(buf)
 }
 
 func (r *RBuffer) ReadBool() bool {
@@ -7,7 +7,10 @@ package rbytes
 import (
 	"fmt"
 	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
+	"unsafe"
 )
 
 func TestRBuffer(t *testing.T) {
@@ -109,3 +112,131 @@ func BenchmarkReadF32s(b *testing.B) {
 		})
 	}
 }
+
+func TestRBufferStrings(t *testing.T) {
+	want := []string{"", "a", "hello", strings.Repeat("x", 300), "hello", "a", ""}
+
+	wbuf := NewWBuffer(nil, nil, 0, nil)
+	for _, str := range want {
+		wbuf.WriteString(str)
+	}
+	wbuf.WriteStdVectorStrs(want)
+
+	for _, tc := range []struct {
+		name string
+		in   *Interner
+	}{
+		{name: "no-interning"},
+		{name: "interning", in: NewInterner(16)},
+		{name: "interning-full", in: NewInterner(1)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rbuf := NewRBuffer(wbuf.Bytes(), nil, 0, nil)
+			rbuf.SetInterner(tc.in)
+			for i, want := range want {
+				got := rbuf.ReadString()
+				if got != want {
+					t.Fatalf("invalid string[%d]: got=%q, want=%q", i, got, want)
+				}
+			}
+			var got []string
+			rbuf.ReadStdVectorStrs(&got)
+			if err := rbuf.Err(); err != nil {
+				t.Fatalf("could not read vector<string>: %+v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid vector<string>:\ngot= %q\nwant=%q", got, want)
+			}
+			if rbuf.Len() != 0 {
+				t.Fatalf("buffer not fully consumed: %d bytes left", rbuf.Len())
+			}
+			if tc.in != nil {
+				if got, max := tc.in.Len(), tc.in.max; got > max {
+					t.Fatalf("too many interned strings: got=%d, max=%d", got, max)
+				}
+			}
+		})
+	}
+}
+
+func TestRBufferCString(t *testing.T) {
+	for _, tc := range []struct {
+		data string
+		n    int
+		want string
+		pos  int64
+	}{
+		{"hello\x00world", 11, "hello", 6},
+		{"hello\x00world", 3, "hel", 3},
+		{"hello", 5, "hello", 5},
+		{"hello", 10, "hello", 5},
+		{"\x00abc", 4, "", 1},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			rbuf := NewRBuffer([]byte(tc.data), nil, 0, nil)
+			got := rbuf.ReadCString(tc.n)
+			if got != tc.want {
+				t.Fatalf("invalid C-string: got=%q, want=%q", got, tc.want)
+			}
+			if got, want := rbuf.Pos(), tc.pos; got != want {
+				t.Fatalf("invalid position: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestInterner(t *testing.T) {
+	in := NewInterner(2)
+	buf := []byte("hello")
+
+	s1 := in.Bytes(buf)
+	buf[0] = 'j'
+	if s1 != "hello" {
+		t.Fatalf("interned string aliases input buffer: %q", s1)
+	}
+
+	s2 := in.Bytes([]byte("hello"))
+	if unsafe.StringData(s1) != unsafe.StringData(s2) {
+		t.Fatalf("strings were not interned")
+	}
+
+	_ = in.Bytes([]byte("world"))
+	_ = in.Bytes([]byte("bye"))
+	if got, want := in.Len(), 2; got != want {
+		t.Fatalf("invalid number of interned strings: got=%d, want=%d", got, want)
+	}
+}
+
+var strsBenchSink = 0
+
+func BenchmarkReadStdVectorStrs(b *testing.B) {
+	for _, sz := range []int{1, 16, 1024} {
+		wbuf := NewWBuffer(nil, nil, 0, nil)
+		strs := make([]string, sz)
+		for i := range strs {
+			strs[i] = fmt.Sprintf("category-%d", i%8)
+		}
+		wbuf.WriteStdVectorStrs(strs)
+
+		for _, tc := range []struct {
+			name string
+			in   *Interner
+		}{
+			{name: "alloc"},
+			{name: "intern", in: NewInterner(1024)},
+		} {
+			b.Run(fmt.Sprintf("%s-%d", tc.name, sz), func(b *testing.B) {
+				rbuf := NewRBuffer(wbuf.Bytes(), nil, 0, nil)
+				rbuf.SetInterner(tc.in)
+				var sli []string
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					rbuf.r.c = 0
+					rbuf.ReadStdVectorStrs(&sli)
+					strsBenchSink += len(sli)
+				}
+			})
+		}
+	}
+}
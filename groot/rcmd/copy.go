@@ -9,6 +9,7 @@ import (
 	"log"
 	stdpath "path"
 	"regexp"
+	"strings"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/riofs"
@@ -16,16 +17,65 @@ import (
 	"go-hep.org/x/hep/groot/rtree"
 )
 
+// CopyOption controls how Copy behaves.
+type CopyOption func(*copyCmd)
+
+// CopyCompression sets the compression scheme of the output ROOT file,
+// encoded as ROOT does: 100*algorithm + level.
+// e.g. 404 selects LZ4 at level 4 and 505 selects ZSTD at level 5.
+//
+// Copied objects and trees are re-compressed with the provided scheme.
+// By default, the output ROOT file uses the default groot compression.
+func CopyCompression(compression int32) CopyOption {
+	return func(cmd *copyCmd) {
+		cmd.compr = &compression
+	}
+}
+
+// CopyKeys restricts the copy to the keys whose path matches at least one
+// of the provided patterns.
+// Paths of keys are relative to the top directory of their ROOT file,
+// e.g. "dir1/dir11/hist".
+//
+// Patterns follow the syntax of path.Match.
+func CopyKeys(patterns ...string) CopyOption {
+	return func(cmd *copyCmd) {
+		cmd.keys = append(cmd.keys, patterns...)
+	}
+}
+
 // Copy copies the content of the ROOT files fnames into the output
 // ROOT file named oname.
-func Copy(oname string, fnames []string) error {
-	o, err := groot.Create(oname)
+//
+// Each input ROOT file name may be suffixed with a regular expression,
+// as in "file.root:REGEXP", to select the keys to copy.
+// Selected directories are copied with their whole content.
+//
+// Copy's behaviour can be customized with a set of optional CopyOptions.
+func Copy(oname string, fnames []string, opts ...CopyOption) error {
+	var cmd copyCmd
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	for _, pat := range cmd.keys {
+		_, err := stdpath.Match(pat, "")
+		if err != nil {
+			return fmt.Errorf("invalid key pattern %q: %w", pat, err)
+		}
+	}
+
+	var fopts []riofs.FileOption
+	if cmd.compr != nil {
+		fopts = append(fopts, riofs.WithCompression(*cmd.compr))
+	}
+
+	o, err := groot.Create(oname, fopts...)
 	if err != nil {
 		return fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
 	}
 	defer o.Close()
 
-	var cmd copyCmd
 	for _, arg := range fnames {
 		err := cmd.process(o, arg)
 		if err != nil {
@@ -40,7 +90,10 @@ func Copy(oname string, fnames []string) error {
 	return nil
 }
 
-type copyCmd struct{}
+type copyCmd struct {
+	compr *int32   // compression of the output file
+	keys  []string // patterns of keys to copy
+}
 
 func (cmd copyCmd) process(o *riofs.File, arg string) error {
 	log.Printf("copying %q...", arg)
@@ -57,14 +110,18 @@ func (cmd copyCmd) process(o *riofs.File, arg string) error {
 	}
 	defer f.Close()
 
+	var dirs []string // selected directories
 	err = riofs.Walk(f, func(path string, obj root.Object, err error) error {
 		if err != nil {
 			return err
 		}
 		name := path[len(f.Name()):]
-		if !re.MatchString(name) {
+		if !cmd.accept(re, dirs, name) {
 			return nil
 		}
+		if _, ok := obj.(riofs.Directory); ok && name != "" {
+			dirs = append(dirs, name+"/")
+		}
 
 		var (
 			dst riofs.Directory
@@ -89,6 +146,33 @@ func (cmd copyCmd) process(o *riofs.File, arg string) error {
 	return nil
 }
 
+// accept returns whether the named key should be copied.
+// Keys are copied when they are located under a selected directory, or when
+// they match both the regular expression and the key patterns.
+func (cmd copyCmd) accept(re *regexp.Regexp, dirs []string, name string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(name, dir) {
+			return true
+		}
+	}
+
+	if !re.MatchString(name) {
+		return false
+	}
+
+	if len(cmd.keys) == 0 {
+		return true
+	}
+
+	key := strings.TrimPrefix(name, "/")
+	for _, pat := range cmd.keys {
+		if ok, _ := stdpath.Match(pat, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (cmd copyCmd) copyObj(odir riofs.Directory, k string, obj root.Object) error {
 	var err error
 	switch obj := obj.(type) {
@@ -108,7 +192,7 @@ func (cmd copyCmd) copyObj(odir riofs.Directory, k string, obj root.Object) erro
 }
 
 func (cmd copyCmd) copyTree(dir riofs.Directory, name string, tree rtree.Tree) error {
-	dst, err := rtree.NewWriter(dir, name, rtree.WriteVarsFromTree(tree))
+	dst, err := rtree.NewWriter(dir, name, rtree.WriteVarsFromTree(tree), rtree.WithTitle(tree.Title()))
 	if err != nil {
 		return fmt.Errorf("could not create output copy tree: %w", err)
 	}
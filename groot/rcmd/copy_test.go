@@ -6,6 +6,7 @@ package rcmd_test
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -98,6 +99,7 @@ func TestROOTCp(t *testing.T) {
 	for _, tc := range []struct {
 		oname string
 		fname string
+		opts  []rcmd.CopyOption
 		keys  []int
 	}{
 		{
@@ -160,10 +162,39 @@ func TestROOTCp(t *testing.T) {
 			fname: refname + ":NONE.*",
 			keys:  []int{},
 		},
+		{
+			oname: "out-subdir.root",
+			fname: refname + ":^/dir-1$",
+			keys:  []int{3, 4},
+		},
+		{
+			oname: "out-keys-subdir.root",
+			fname: refname,
+			opts:  []rcmd.CopyOption{rcmd.CopyKeys("dir-1/dir-12")},
+			keys:  []int{4},
+		},
+		{
+			oname: "out-keys.root",
+			fname: refname,
+			opts:  []rcmd.CopyOption{rcmd.CopyKeys("key*", "dir-2")},
+			keys:  []int{0, 1, 5},
+		},
+		{
+			oname: "out-keys-regexp.root",
+			fname: refname + ":^/key$",
+			opts:  []rcmd.CopyOption{rcmd.CopyKeys("key*", "dir-2")},
+			keys:  []int{0},
+		},
+		{
+			oname: "out-keys-zstd.root",
+			fname: refname,
+			opts:  []rcmd.CopyOption{rcmd.CopyCompression(505), rcmd.CopyKeys("*/*/str-*")},
+			keys:  []int{3, 4},
+		},
 	} {
 		t.Run(tc.oname, func(t *testing.T) {
 			oname := filepath.Join(dir, tc.oname)
-			err := rcmd.Copy(oname, []string{tc.fname})
+			err := rcmd.Copy(oname, []string{tc.fname}, tc.opts...)
 			if err != nil {
 				t.Fatalf("%+v", err)
 			}
@@ -300,3 +331,49 @@ func TestROOTCpTree(t *testing.T) {
 		t.Fatalf("dumps differ:\n%s\n", diff.Format(got, want))
 	}
 }
+
+func TestROOTCpCompression(t *testing.T) {
+	tmp := t.TempDir()
+
+	fname := "../testdata/simple.root"
+	for _, compr := range []int32{0, 101, 207, 404, 505} {
+		t.Run(fmt.Sprintf("compr=%d", compr), func(t *testing.T) {
+			oname := filepath.Join(tmp, fmt.Sprintf("out-%d.root", compr))
+			err := rcmd.Copy(oname, []string{fname}, rcmd.CopyCompression(compr))
+			if err != nil {
+				t.Fatalf("could not copy file: %+v", err)
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			if got, want := f.Compression(), compr; got != want {
+				t.Fatalf("invalid compression: got=%d, want=%d", got, want)
+			}
+
+			want := new(bytes.Buffer)
+			err = rcmd.Dump(want, fname, true, nil)
+			if err != nil {
+				t.Fatalf("could not dump ref file %q: %+v", fname, err)
+			}
+
+			got := new(bytes.Buffer)
+			err = rcmd.Dump(got, oname, true, nil)
+			if err != nil {
+				t.Fatalf("could not dump new file %q: %+v", oname, err)
+			}
+
+			if got, want := got.String(), want.String(); got != want {
+				t.Fatalf("dumps differ:\n%s\n", diff.Format(got, want))
+			}
+		})
+	}
+
+	err := rcmd.Copy(filepath.Join(tmp, "invalid.root"), []string{fname}, rcmd.CopyKeys("[a-"))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
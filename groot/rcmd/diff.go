@@ -5,9 +5,11 @@
 package rcmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"os"
 	stdpath "path"
 	"reflect"
@@ -20,15 +22,71 @@ import (
 	"go-hep.org/x/hep/groot/rtree"
 )
 
+// DiffOption controls how Diff behaves.
+type DiffOption func(*diffCmd)
+
+// DiffTolerance sets the relative and absolute tolerances used to compare
+// floating point values.
+//
+// Two floating point values ref and chk are considered equal when:
+//
+//	|ref - chk| <= atol + rtol*|ref|
+//
+// By default, floating point values are compared exactly.
+func DiffTolerance(rtol, atol float64) DiffOption {
+	return func(cmd *diffCmd) {
+		cmd.rtol = rtol
+		cmd.atol = atol
+	}
+}
+
+// DiffInclude restricts the comparison of trees to the branches whose
+// name matches at least one of the provided patterns.
+//
+// Patterns follow the syntax of path.Match.
+func DiffInclude(patterns ...string) DiffOption {
+	return func(cmd *diffCmd) {
+		cmd.incl = append(cmd.incl, patterns...)
+	}
+}
+
+// DiffExclude excludes from the comparison of trees the branches whose
+// name matches any of the provided patterns.
+//
+// Patterns follow the syntax of path.Match.
+func DiffExclude(patterns ...string) DiffOption {
+	return func(cmd *diffCmd) {
+		cmd.excl = append(cmd.excl, patterns...)
+	}
+}
+
+// DiffJSON enables the JSON report mode.
+//
+// In JSON report mode, Diff compares all the requested keys and writes
+// a JSON document describing their differences, including the first
+// entry at which the values of each branch of a tree diverge.
+func DiffJSON(v bool) DiffOption {
+	return func(cmd *diffCmd) {
+		cmd.json = v
+	}
+}
+
 // Diff compares the values of the list of keys between the two provided ROOT files.
 // Diff writes the differing data (if any) to w.
 //
 // if w is nil, os.Stdout is used.
 // if the slice of keys is nil, all keys are considered.
-func Diff(w io.Writer, ref, chk *riofs.File, keys []string) error {
-	cmd, err := newDiffCmd(w, ref, chk, keys)
+//
+// Diff's behaviour can be customized with a set of optional DiffOptions.
+func Diff(w io.Writer, ref, chk *riofs.File, keys []string, opts ...DiffOption) error {
+	cmd, err := newDiffCmd(w, ref, chk, keys, opts...)
 	if err != nil {
-		return fmt.Errorf("could not compute keys to compare: %w", err)
+		err = fmt.Errorf("could not compute keys to compare: %w", err)
+		if cmd != nil && cmd.json {
+			cmd.rep.Error = err.Error()
+			return cmd.writeReport(err)
+		}
+		return err
 	}
 
 	return cmd.diffFiles()
@@ -39,9 +97,42 @@ type diffCmd struct {
 	fref *riofs.File
 	fchk *riofs.File
 	keys []string
+
+	rtol float64  // relative tolerance for floating point values
+	atol float64  // absolute tolerance for floating point values
+	incl []string // patterns of branches to compare
+	excl []string // patterns of branches to ignore
+	json bool     // whether to write a JSON report
+
+	rep diffReport
 }
 
-func newDiffCmd(w io.Writer, fref, fchk *riofs.File, keys []string) (*diffCmd, error) {
+// diffReport is the JSON report of a Diff.
+type diffReport struct {
+	Ref   string    `json:"ref"`
+	Chk   string    `json:"chk"`
+	OK    bool      `json:"ok"`
+	Error string    `json:"error,omitempty"`
+	Keys  []keyDiff `json:"keys"`
+}
+
+type keyDiff struct {
+	Key      string       `json:"key"`
+	Status   string       `json:"status"` // ok, differ or missing
+	Error    string       `json:"error,omitempty"`
+	Entries  int64        `json:"entries,omitempty"` // number of differing tree entries
+	Branches []branchDiff `json:"branches,omitempty"`
+}
+
+type branchDiff struct {
+	Name    string `json:"name"`
+	First   int64  `json:"first"`   // first entry where the values diverge
+	Entries int64  `json:"entries"` // number of differing entries
+	Ref     string `json:"ref"`     // reference value at the first divergence
+	Chk     string `json:"chk"`     // check value at the first divergence
+}
+
+func newDiffCmd(w io.Writer, fref, fchk *riofs.File, keys []string, opts ...DiffOption) (*diffCmd, error) {
 	var (
 		err   error
 		ukeys []string
@@ -52,6 +143,25 @@ func newDiffCmd(w io.Writer, fref, fchk *riofs.File, keys []string) (*diffCmd, e
 		cmd.w = os.Stdout
 	}
 
+	for _, opt := range opts {
+		opt(cmd)
+	}
+
+	cmd.rep = diffReport{
+		Ref:  fref.Name(),
+		Chk:  fchk.Name(),
+		Keys: []keyDiff{},
+	}
+
+	for _, patterns := range [][]string{cmd.incl, cmd.excl} {
+		for _, pattern := range patterns {
+			_, err := stdpath.Match(pattern, "")
+			if err != nil {
+				return cmd, fmt.Errorf("invalid branch pattern %q: %w", pattern, err)
+			}
+		}
+	}
+
 	if len(keys) != 0 {
 		for _, k := range keys {
 			k = strings.TrimSpace(k)
@@ -62,7 +172,7 @@ func newDiffCmd(w io.Writer, fref, fchk *riofs.File, keys []string) (*diffCmd, e
 		}
 
 		if len(ukeys) == 0 {
-			return nil, fmt.Errorf("empty key set")
+			return cmd, fmt.Errorf("empty key set")
 		}
 	} else {
 		for _, k := range cmd.fref.Keys() {
@@ -75,14 +185,16 @@ func newDiffCmd(w io.Writer, fref, fchk *riofs.File, keys []string) (*diffCmd, e
 		_, err = cmd.fref.Get(k)
 		if err != nil {
 			allgood = false
-			fmt.Fprintf(cmd.w, "key[%s] -- missing from ref-file\n", k)
+			cmd.printf("key[%s] -- missing from ref-file\n", k)
+			cmd.rep.Keys = append(cmd.rep.Keys, keyDiff{Key: k, Status: "missing", Error: "missing from ref-file"})
 			log.Printf("key %q is missing from ref-file=%q", k, cmd.fref.Name())
 		}
 
 		_, err = cmd.fchk.Get(k)
 		if err != nil {
 			allgood = false
-			fmt.Fprintf(cmd.w, "key[%s] -- missing from chk-file\n", k)
+			cmd.printf("key[%s] -- missing from chk-file\n", k)
+			cmd.rep.Keys = append(cmd.rep.Keys, keyDiff{Key: k, Status: "missing", Error: "missing from chk-file"})
 			log.Printf("key %q is missing from chk-file=%q", k, cmd.fchk.Name())
 		}
 
@@ -90,18 +202,40 @@ func newDiffCmd(w io.Writer, fref, fchk *riofs.File, keys []string) (*diffCmd, e
 	}
 
 	if len(cmd.keys) == 0 {
-		return nil, fmt.Errorf("empty key set")
+		return cmd, fmt.Errorf("empty key set")
 	}
 
 	if !allgood {
-		return nil, fmt.Errorf("key set differ")
+		return cmd, fmt.Errorf("key set differ")
 	}
 
 	sort.Strings(cmd.keys)
 	return cmd, nil
 }
 
+// printf writes the formatted text to the output of the command, unless
+// the command is in JSON report mode.
+func (cmd *diffCmd) printf(format string, args ...interface{}) {
+	if cmd.json {
+		return
+	}
+	fmt.Fprintf(cmd.w, format, args...)
+}
+
+// writeReport writes the JSON report to the output of the command and
+// returns the provided error.
+func (cmd *diffCmd) writeReport(err error) error {
+	cmd.rep.OK = err == nil
+	enc := json.NewEncoder(cmd.w)
+	enc.SetIndent("", "  ")
+	if e := enc.Encode(cmd.rep); e != nil && err == nil {
+		err = fmt.Errorf("could not write JSON report: %w", e)
+	}
+	return err
+}
+
 func (cmd *diffCmd) diffFiles() error {
+	var errs []error
 	for _, key := range cmd.keys {
 		ref, err := cmd.fref.Get(key)
 		if err != nil {
@@ -113,16 +247,35 @@ func (cmd *diffCmd) diffFiles() error {
 			return err
 		}
 
-		err = cmd.diffObject(key, ref, chk)
+		kdiff := keyDiff{Key: key, Status: "ok"}
+		err = cmd.diffObject(&kdiff, key, ref, chk)
 		if err != nil {
-			return err
+			if !cmd.json {
+				return err
+			}
+			kdiff.Status = "differ"
+			kdiff.Error = err.Error()
+			errs = append(errs, err)
 		}
+		cmd.rep.Keys = append(cmd.rep.Keys, kdiff)
 	}
 
-	return nil
+	if !cmd.json {
+		return nil
+	}
+
+	var err error
+	switch len(errs) {
+	case 0:
+	case 1:
+		err = errs[0]
+	default:
+		err = fmt.Errorf("%d keys differ: %w", len(errs), errs[0])
+	}
+	return cmd.writeReport(err)
 }
 
-func (cmd *diffCmd) diffObject(key string, ref, chk root.Object) error {
+func (cmd *diffCmd) diffObject(kdiff *keyDiff, key string, ref, chk root.Object) error {
 	refType := reflect.TypeOf(ref)
 	chkType := reflect.TypeOf(chk)
 
@@ -132,14 +285,14 @@ func (cmd *diffCmd) diffObject(key string, ref, chk root.Object) error {
 
 	switch ref := ref.(type) {
 	case rtree.Tree:
-		return cmd.diffTree(key, ref, chk.(rtree.Tree))
+		return cmd.diffTree(kdiff, key, ref, chk.(rtree.Tree))
 	case riofs.Directory:
-		return cmd.diffDir(key, ref, chk.(riofs.Directory))
+		return cmd.diffDir(kdiff, key, ref, chk.(riofs.Directory))
 
 	case root.Object:
 		ok := reflect.DeepEqual(ref, chk)
 		if !ok {
-			fmt.Fprintf(cmd.w, "key[%s] (%T) -- (-ref +chk)\n-%v\n+%v\n", key, ref, ref, chk)
+			cmd.printf("key[%s] (%T) -- (-ref +chk)\n-%v\n+%v\n", key, ref, ref, chk)
 			return fmt.Errorf("%s: keys differ", key)
 		}
 		return nil
@@ -148,7 +301,7 @@ func (cmd *diffCmd) diffObject(key string, ref, chk root.Object) error {
 	}
 }
 
-func (cmd *diffCmd) diffDir(key string, ref, chk riofs.Directory) error {
+func (cmd *diffCmd) diffDir(kdiff *keyDiff, key string, ref, chk riofs.Directory) error {
 	kref := ref.Keys()
 	kchk := chk.Keys()
 	if len(kref) != len(kchk) {
@@ -187,7 +340,7 @@ func (cmd *diffCmd) diffDir(key string, ref, chk riofs.Directory) error {
 			return fmt.Errorf("%s: could not retrieve %s from chk-directory", key, k)
 		}
 
-		err = cmd.diffObject(stdpath.Join(key, k), oref, ochk)
+		err = cmd.diffObject(kdiff, stdpath.Join(key, k), oref, ochk)
 		if err != nil {
 			return fmt.Errorf("%s: values for %s in directory differ: %w", key, k, err)
 		}
@@ -196,13 +349,21 @@ func (cmd *diffCmd) diffDir(key string, ref, chk riofs.Directory) error {
 	return nil
 }
 
-func (cmd *diffCmd) diffTree(key string, ref, chk rtree.Tree) error {
+func (cmd *diffCmd) diffTree(kdiff *keyDiff, key string, ref, chk rtree.Tree) error {
 	if eref, echk := ref.Entries(), chk.Entries(); eref != echk {
 		return fmt.Errorf("%s: number of entries differ: ref=%v chk=%v", key, eref, echk)
 	}
 
-	refVars := rtree.NewReadVars(ref)
-	chkVars := rtree.NewReadVars(chk)
+	refVars := cmd.filter(rtree.NewReadVars(ref))
+	chkVars := cmd.filter(rtree.NewReadVars(chk))
+
+	if names := varNames(refVars); !reflect.DeepEqual(names, varNames(chkVars)) {
+		return fmt.Errorf("%s: branches differ: ref=%q chk=%q", key, names, varNames(chkVars))
+	}
+
+	if len(refVars) == 0 {
+		return nil
+	}
 
 	quit := make(chan struct{})
 	defer close(quit)
@@ -213,8 +374,12 @@ func (cmd *diffCmd) diffTree(key string, ref, chk rtree.Tree) error {
 	go cmd.treeDump(quit, refc, ref, refVars)
 	go cmd.treeDump(quit, chkc, chk, chkVars)
 
-	allgood := true
-	n := chk.Entries()
+	var (
+		opts     = cmd.cmpOptions()
+		branches = make([]branchDiff, len(refVars))
+		allgood  = true
+		n        = chk.Entries()
+	)
 	for i := int64(0); i < n; i++ {
 		ref := <-refc
 		chk := <-chkc
@@ -228,21 +393,42 @@ func (cmd *diffCmd) diffTree(key string, ref, chk rtree.Tree) error {
 			return fmt.Errorf("%s: tree out of sync (ref=%d, chk=%d)", key, ref.n, chk.n)
 		}
 
+		good := true
 		for ii := range refVars {
 			var (
 				ref  = reflect.Indirect(reflect.ValueOf(refVars[ii].Value)).Interface()
 				chk  = reflect.Indirect(reflect.ValueOf(chkVars[ii].Value)).Interface()
-				diff = cmp.Diff(ref, chk)
+				diff = cmp.Diff(ref, chk, opts...)
 			)
 			if diff != "" {
-				fmt.Fprintf(cmd.w, "key[%s][%04d].%s -- (-ref +chk)\n%s", key, i, refVars[ii].Name, diff)
+				cmd.printf("key[%s][%04d].%s -- (-ref +chk)\n%s", key, i, refVars[ii].Name, diff)
 				allgood = false
+				good = false
+
+				bdiff := &branches[ii]
+				if bdiff.Entries == 0 {
+					bdiff.First = i
+					bdiff.Ref = fmt.Sprintf("%v", ref)
+					bdiff.Chk = fmt.Sprintf("%v", chk)
+				}
+				bdiff.Entries++
 			}
 		}
+		if !good {
+			kdiff.Entries++
+		}
 		ref.ok <- 1
 		chk.ok <- 1
 	}
 
+	for i, bdiff := range branches {
+		if bdiff.Entries == 0 {
+			continue
+		}
+		bdiff.Name = varName(refVars[i])
+		kdiff.Branches = append(kdiff.Branches, bdiff)
+	}
+
 	if !allgood {
 		return fmt.Errorf("%s: trees differ", key)
 	}
@@ -250,6 +436,80 @@ func (cmd *diffCmd) diffTree(key string, ref, chk rtree.Tree) error {
 	return nil
 }
 
+// filter returns the read-vars whose branch names match the include and
+// exclude patterns of the command.
+func (cmd *diffCmd) filter(vars []rtree.ReadVar) []rtree.ReadVar {
+	if len(cmd.incl) == 0 && len(cmd.excl) == 0 {
+		return vars
+	}
+
+	match := func(patterns []string, name string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := stdpath.Match(pattern, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	o := vars[:0:0]
+	for _, rvar := range vars {
+		if len(cmd.incl) > 0 && !match(cmd.incl, rvar.Name) {
+			continue
+		}
+		if match(cmd.excl, rvar.Name) {
+			continue
+		}
+		o = append(o, rvar)
+	}
+	return o
+}
+
+// cmpOptions returns the options used to compare values.
+func (cmd *diffCmd) cmpOptions() []cmp.Option {
+	if cmd.rtol == 0 && cmd.atol == 0 {
+		return nil
+	}
+
+	isFloat := func(v interface{}) bool {
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Float32, reflect.Float64:
+			return true
+		}
+		return false
+	}
+
+	return []cmp.Option{
+		cmp.FilterValues(
+			func(ref, chk interface{}) bool {
+				return isFloat(ref) && isFloat(chk)
+			},
+			cmp.Comparer(func(ref, chk interface{}) bool {
+				var (
+					x = reflect.ValueOf(ref).Float()
+					y = reflect.ValueOf(chk).Float()
+				)
+				return x == y || math.Abs(x-y) <= cmd.atol+cmd.rtol*math.Abs(x)
+			}),
+		),
+	}
+}
+
+func varName(rvar rtree.ReadVar) string {
+	if rvar.Leaf == "" || rvar.Leaf == rvar.Name {
+		return rvar.Name
+	}
+	return rvar.Name + "." + rvar.Leaf
+}
+
+func varNames(vars []rtree.ReadVar) []string {
+	names := make([]string, len(vars))
+	for i, rvar := range vars {
+		names[i] = varName(rvar)
+	}
+	return names
+}
+
 type treeEntry struct {
 	n   int64
 	err error
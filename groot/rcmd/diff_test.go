@@ -5,9 +5,12 @@
 package rcmd_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -853,3 +856,178 @@ key[dir-1/dir-11/tree][0004].I32 -- (-ref +chk)
 		})
 	}
 }
+
+func TestDiffOptions(t *testing.T) {
+	tmp := t.TempDir()
+
+	create := func(name string, eps, delta float64) *riofs.File {
+		fname := filepath.Join(tmp, name)
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+
+		var data struct {
+			I32 int32
+			F32 float32
+			F64 float64
+			Arr [2]float64
+		}
+		w, err := rtree.NewWriter(f, "tree", rtree.WriteVarsFromStruct(&data))
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+
+		for i := 0; i < 5; i++ {
+			data.I32 = int32(i)
+			data.F32 = float32(i) * (1 + float32(eps))
+			data.F64 = float64(i) * (1 + eps)
+			data.Arr = [2]float64{float64(i), float64(i + 2)}
+			if i >= 3 {
+				data.Arr[1] += delta
+			}
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event #%d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+
+		f, err = groot.Open(fname)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+
+	var (
+		fref = create("ref.root", 0, 0)
+		fchk = create("chk.root", 1e-7, 1)
+	)
+
+	for _, tc := range []struct {
+		name string
+		opts []rcmd.DiffOption
+		err  string
+	}{
+		{
+			name: "exact",
+			err:  "tree: trees differ",
+		},
+		{
+			name: "tolerance",
+			opts: []rcmd.DiffOption{rcmd.DiffTolerance(1e-6, 0)},
+			err:  "tree: trees differ",
+		},
+		{
+			name: "tolerance-exclude",
+			opts: []rcmd.DiffOption{
+				rcmd.DiffTolerance(1e-6, 0),
+				rcmd.DiffExclude("Arr"),
+			},
+		},
+		{
+			name: "abs-tolerance",
+			opts: []rcmd.DiffOption{rcmd.DiffTolerance(0, 1)},
+		},
+		{
+			name: "include",
+			opts: []rcmd.DiffOption{rcmd.DiffInclude("I*")},
+		},
+		{
+			name: "include-exclude",
+			opts: []rcmd.DiffOption{
+				rcmd.DiffInclude("*"),
+				rcmd.DiffExclude("F*", "Arr"),
+			},
+		},
+		{
+			name: "invalid-pattern",
+			opts: []rcmd.DiffOption{rcmd.DiffInclude("[")},
+			err:  `could not compute keys to compare: invalid branch pattern "[": syntax error in pattern`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(strings.Builder)
+			err := rcmd.Diff(out, fref, fchk, nil, tc.opts...)
+			switch {
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error.\ngot= %s\nwant=%s\n", got, want)
+				}
+			case err != nil && tc.err == "":
+				t.Fatalf("unexpected error: %+v\n%s", err, out.String())
+			case err == nil && tc.err != "":
+				t.Fatalf("expected an error: %s", tc.err)
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		err := rcmd.Diff(out, fref, fchk, nil, rcmd.DiffTolerance(1e-6, 0), rcmd.DiffJSON(true))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		type branch struct {
+			Name    string `json:"name"`
+			First   int64  `json:"first"`
+			Entries int64  `json:"entries"`
+			Ref     string `json:"ref"`
+			Chk     string `json:"chk"`
+		}
+		var rep struct {
+			Ref  string `json:"ref"`
+			Chk  string `json:"chk"`
+			OK   bool   `json:"ok"`
+			Keys []struct {
+				Key      string   `json:"key"`
+				Status   string   `json:"status"`
+				Error    string   `json:"error"`
+				Entries  int64    `json:"entries"`
+				Branches []branch `json:"branches"`
+			} `json:"keys"`
+		}
+		err = json.Unmarshal(out.Bytes(), &rep)
+		if err != nil {
+			t.Fatalf("could not decode JSON report: %+v\n%s", err, out.String())
+		}
+
+		if rep.OK {
+			t.Fatalf("invalid report status")
+		}
+		if got, want := len(rep.Keys), 1; got != want {
+			t.Fatalf("invalid number of keys: got=%d, want=%d", got, want)
+		}
+
+		key := rep.Keys[0]
+		if got, want := key.Status, "differ"; got != want {
+			t.Fatalf("invalid key status: got=%q, want=%q", got, want)
+		}
+		if got, want := key.Entries, int64(2); got != want {
+			t.Fatalf("invalid number of differing entries: got=%d, want=%d", got, want)
+		}
+
+		want := []branch{{
+			Name:    "Arr",
+			First:   3,
+			Entries: 2,
+			Ref:     "[3 5]",
+			Chk:     "[3 6]",
+		}}
+		if !reflect.DeepEqual(key.Branches, want) {
+			t.Fatalf("invalid branches report:\ngot= %+v\nwant=%+v", key.Branches, want)
+		}
+	})
+}
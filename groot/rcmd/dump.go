@@ -5,13 +5,18 @@
 package rcmd
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	stdpath "path"
 	"reflect"
 	"strconv"
+	"text/tabwriter"
 
 	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/internal/rexpr"
 	"go-hep.org/x/hep/groot/rdict"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
@@ -21,18 +26,60 @@ import (
 	"go-hep.org/x/hep/hbook/yodacnv"
 )
 
+// DumpFormat describes how the entries of trees are displayed.
+type DumpFormat uint8
+
+const (
+	DumpText  DumpFormat = iota // one line per entry and branch
+	DumpTable                   // one row per entry, one column per branch
+	DumpJSONL                   // one JSON object per entry
+	DumpCSV                     // one CSV record per entry
+)
+
+// DumpOption controls how Dump behaves.
+type DumpOption func(*dumpCmd)
+
+// DumpBranches restricts the dump of trees to the branches whose name
+// matches at least one of the provided patterns.
+// Leaves of a branch are named "branch.leaf".
+//
+// Patterns follow the syntax of path.Match.
+func DumpBranches(patterns ...string) DumpOption {
+	return func(cmd *dumpCmd) {
+		cmd.branches = append(cmd.branches, patterns...)
+	}
+}
+
+// DumpEntries restricts the dump of trees to the half-open interval
+// [beg, end) of entries.
+// A negative end value selects all the entries after beg.
+// The interval is clipped to the number of entries of each tree.
+func DumpEntries(beg, end int64) DumpOption {
+	return func(cmd *dumpCmd) {
+		cmd.beg = beg
+		cmd.end = end
+	}
+}
+
+// DumpAs sets the format used to display the entries of trees.
+//
+// With the DumpJSONL and DumpCSV formats, only the entries of trees are
+// displayed, so the output can be directly consumed by other tools.
+// The default format is DumpText.
+func DumpAs(format DumpFormat) DumpOption {
+	return func(cmd *dumpCmd) {
+		cmd.format = format
+	}
+}
+
 // Dump dumps the content of the fname ROOT file to the provided io.Writer.
 // If deep is true, Dump will recursively inspect directories and trees.
 // Dump only display the content of ROOT objects satisfying the provided filter function.
 //
 // If filter is nil, Dump will consider all ROOT objects.
-func Dump(w io.Writer, fname string, deep bool, filter func(name string) bool) error {
-	f, err := groot.Open(fname)
-	if err != nil {
-		return fmt.Errorf("could not open file with read-access: %w", err)
-	}
-	defer f.Close()
-
+//
+// Dump's behaviour can be customized with a set of optional DumpOptions.
+func Dump(w io.Writer, fname string, deep bool, filter func(name string) bool, opts ...DumpOption) error {
 	if filter == nil {
 		filter = func(string) bool { return true }
 	}
@@ -41,6 +88,42 @@ func Dump(w io.Writer, fname string, deep bool, filter func(name string) bool) e
 		w:     w,
 		deep:  deep,
 		match: filter,
+		end:   -1,
+	}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	switch cmd.format {
+	case DumpText, DumpTable, DumpJSONL, DumpCSV:
+		// ok.
+	default:
+		return fmt.Errorf("invalid dump format %d", cmd.format)
+	}
+
+	if cmd.beg < 0 {
+		return fmt.Errorf("invalid entry range [%d, %d)", cmd.beg, cmd.end)
+	}
+	if cmd.end >= 0 && cmd.end < cmd.beg {
+		return fmt.Errorf("invalid entry range [%d, %d)", cmd.beg, cmd.end)
+	}
+
+	for _, pat := range cmd.branches {
+		_, err := stdpath.Match(pat, "")
+		if err != nil {
+			return fmt.Errorf("invalid branch pattern %q: %w", pat, err)
+		}
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open file with read-access: %w", err)
+	}
+	defer f.Close()
+
+	switch cmd.format {
+	case DumpJSONL, DumpCSV:
+		return cmd.dumpRecords(f)
 	}
 	return cmd.dumpDir(f)
 }
@@ -49,6 +132,15 @@ type dumpCmd struct {
 	w     io.Writer
 	deep  bool
 	match func(name string) bool
+
+	branches []string // patterns of branches to dump
+	beg, end int64    // range of entries to dump
+	format   DumpFormat
+
+	accept func() bool // selection function bound to the current tree
+	sel    *rexpr.Cut  // selection of entries to dump, if any
+	max    int64       // maximum number of selected entries to dump, if positive
+	nsel   int64       // number of selected entries
 }
 
 func (cmd *dumpCmd) dumpDir(dir riofs.Directory) error {
@@ -142,28 +234,146 @@ func (cmd *dumpCmd) dumpList(lst root.List) error {
 	return nil
 }
 
+// dumpRecords dumps the entries of the trees contained in dir,
+// recursively.
+func (cmd *dumpCmd) dumpRecords(dir riofs.Directory) error {
+	if !cmd.deep {
+		return nil
+	}
+
+	for _, key := range dir.Keys() {
+		if !cmd.match(key.Name()) {
+			continue
+		}
+		obj, err := key.Object()
+		if err != nil {
+			return fmt.Errorf("could not decode object %q from dir %q: %w", key.Name(), dir.(root.Named).Name(), err)
+		}
+		switch obj := obj.(type) {
+		case rtree.Tree:
+			err = cmd.dumpTree(obj)
+		case riofs.Directory:
+			err = cmd.dumpRecords(obj)
+		}
+		if err != nil {
+			return fmt.Errorf("error dumping key %q: %w", key.Name(), err)
+		}
+	}
+	return nil
+}
+
 func (cmd *dumpCmd) dumpTree(t rtree.Tree) error {
-	vars := rtree.NewReadVars(t)
-	r, err := rtree.NewReader(t, vars)
+	var (
+		vars  []rtree.ReadVar
+		names [][]byte
+	)
+	for _, v := range rtree.NewReadVars(t) {
+		name := rvarName(v)
+		if !cmd.selectBranch(v.Name, name) {
+			continue
+		}
+		vars = append(vars, v)
+		names = append(names, []byte(name))
+	}
+	if len(vars) == 0 {
+		return nil
+	}
+
+	beg, end := cmd.beg, cmd.end
+	if n := t.Entries(); end < 0 || end > n {
+		end = n
+	}
+	if beg > end {
+		beg = end
+	}
+
+	r, err := rtree.NewReader(t, vars, rtree.WithRange(beg, end))
 	if err != nil {
 		return fmt.Errorf("could not create reader: %w", err)
 	}
 	defer r.Close()
 
-	names := make([][]byte, len(vars))
-	for i, v := range vars {
-		name := v.Name
-		if v.Leaf != "" && v.Leaf != v.Name {
-			name = v.Name + "." + v.Leaf
+	cmd.accept = nil
+	if cmd.sel != nil {
+		sel, err := r.Formula(cmd.sel)
+		if err != nil {
+			return fmt.Errorf("could not bind selection: %w", err)
+		}
+		cmd.accept = sel.Func().(func() bool)
+	}
+
+	switch cmd.format {
+	case DumpTable:
+		err = cmd.dumpTreeTable(r, vars, names)
+	case DumpJSONL:
+		err = cmd.dumpTreeJSONL(r, vars, names)
+	case DumpCSV:
+		err = cmd.dumpTreeCSV(r, vars, names)
+	default:
+		err = cmd.dumpTreeText(r, vars, names)
+	}
+	if err != nil {
+		return fmt.Errorf("rcmd: could not read through tree: %w", err)
+	}
+	return nil
+}
+
+var errStopDump = errors.New("rcmd: stop dump")
+
+// read reads the entries of the provided reader and calls f for each entry
+// passing the selection, if any.
+func (cmd *dumpCmd) read(r *rtree.Reader, f func(rctx rtree.RCtx) error) error {
+	err := r.Read(func(rctx rtree.RCtx) error {
+		if cmd.accept != nil && !cmd.accept() {
+			return nil
+		}
+		err := f(rctx)
+		if err != nil {
+			return err
+		}
+		cmd.nsel++
+		if cmd.max > 0 && cmd.nsel >= cmd.max {
+			return errStopDump
 		}
-		names[i] = []byte(name)
+		return nil
+	})
+	if errors.Is(err, errStopDump) {
+		return nil
 	}
+	return err
+}
 
+// rvarName returns the display name of the provided read-var.
+func rvarName(v rtree.ReadVar) string {
+	if v.Leaf != "" && v.Leaf != v.Name {
+		return v.Name + "." + v.Leaf
+	}
+	return v.Name
+}
+
+// selectBranch returns whether the read-var for the provided branch and
+// full leaf name should be dumped.
+func (cmd *dumpCmd) selectBranch(branch, name string) bool {
+	if len(cmd.branches) == 0 {
+		return true
+	}
+	for _, pat := range cmd.branches {
+		if ok, _ := stdpath.Match(pat, name); ok {
+			return true
+		}
+		if ok, _ := stdpath.Match(pat, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (cmd *dumpCmd) dumpTreeText(r *rtree.Reader, vars []rtree.ReadVar, names [][]byte) error {
 	// FIXME(sbinet): don't use a "global" buffer for when rtree.Reader reads multiple
 	// events in parallel.
 	buf := make([]byte, 0, 8*1024)
 	hdr := make([]byte, 0, 6)
-	err = r.Read(func(rctx rtree.RCtx) error {
+	return cmd.read(r, func(rctx rtree.RCtx) error {
 		hdr = hdr[:0]
 		hdr = append(hdr, '[')
 		switch {
@@ -183,17 +393,108 @@ func (cmd *dumpCmd) dumpTree(t rtree.Tree) error {
 			// All of this is a convoluted (but efficient) way to do:
 			//  fmt.Fprintf(cmd.w, "[%03d][%s]: %v\n", rctx.Entry, name, rv.Interface())
 			buf = append(buf, fmt.Sprintf("%v\n", rv.Interface())...)
-			_, err = cmd.w.Write(buf)
+			_, err := cmd.w.Write(buf)
 			if err != nil {
 				return err
 			}
 		}
 		return nil
 	})
+}
+
+func (cmd *dumpCmd) dumpTreeTable(r *rtree.Reader, vars []rtree.ReadVar, names [][]byte) error {
+	w := tabwriter.NewWriter(cmd.w, 8, 4, 1, ' ', 0)
+	buf := make([]byte, 0, 8*1024)
+	buf = append(buf, "entry"...)
+	for _, name := range names {
+		buf = append(buf, '\t')
+		buf = append(buf, name...)
+	}
+	buf = append(buf, '\n')
+	_, err := w.Write(buf)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.read(r, func(rctx rtree.RCtx) error {
+		buf = buf[:0]
+		buf = strconv.AppendInt(buf, rctx.Entry, 10)
+		for _, v := range vars {
+			rv := reflect.Indirect(reflect.ValueOf(v.Value))
+			buf = append(buf, '\t')
+			buf = append(buf, fmt.Sprintf("%v", rv.Interface())...)
+		}
+		buf = append(buf, '\n')
+		_, err := w.Write(buf)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("rcmd: could not read through tree: %w", err)
+		return err
 	}
-	return nil
+	return w.Flush()
+}
+
+func (cmd *dumpCmd) dumpTreeJSONL(r *rtree.Reader, vars []rtree.ReadVar, names [][]byte) error {
+	keys := make([][]byte, len(names))
+	for i, name := range names {
+		key, err := json.Marshal(string(name))
+		if err != nil {
+			return fmt.Errorf("could not encode branch name %q: %w", name, err)
+		}
+		keys[i] = append(key, ':')
+	}
+
+	buf := make([]byte, 0, 8*1024)
+	return cmd.read(r, func(rctx rtree.RCtx) error {
+		buf = buf[:0]
+		buf = append(buf, `{"entry":`...)
+		buf = strconv.AppendInt(buf, rctx.Entry, 10)
+		for i, v := range vars {
+			rv := reflect.Indirect(reflect.ValueOf(v.Value))
+			val, err := json.Marshal(rv.Interface())
+			if err != nil {
+				// values not representable in JSON (e.g. NaN or Inf floats)
+				// are represented with their textual representation.
+				val, err = json.Marshal(fmt.Sprintf("%v", rv.Interface()))
+				if err != nil {
+					return fmt.Errorf("could not encode %q: %w", names[i], err)
+				}
+			}
+			buf = append(buf, ',')
+			buf = append(buf, keys[i]...)
+			buf = append(buf, val...)
+		}
+		buf = append(buf, '}', '\n')
+		_, err := cmd.w.Write(buf)
+		return err
+	})
+}
+
+func (cmd *dumpCmd) dumpTreeCSV(r *rtree.Reader, vars []rtree.ReadVar, names [][]byte) error {
+	w := csv.NewWriter(cmd.w)
+	rec := make([]string, 1+len(vars))
+	rec[0] = "entry"
+	for i, name := range names {
+		rec[i+1] = string(name)
+	}
+	err := w.Write(rec)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.read(r, func(rctx rtree.RCtx) error {
+		rec[0] = strconv.FormatInt(rctx.Entry, 10)
+		for i, v := range vars {
+			rv := reflect.Indirect(reflect.ValueOf(v.Value))
+			rec[i+1] = fmt.Sprintf("%v", rv.Interface())
+		}
+		return w.Write(rec)
+	})
+	if err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
 }
 
 func (cmd *dumpCmd) dumpH1(h1 rhist.H1) error {
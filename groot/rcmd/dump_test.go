@@ -5,6 +5,7 @@
 package rcmd_test
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -353,6 +354,157 @@ key[004]: tree;1 "my tree title" (TTree)
 	}
 }
 
+func TestDumpOptions(t *testing.T) {
+	const deep = true
+	for _, tc := range []struct {
+		name  string
+		fname string
+		opts  []rcmd.DumpOption
+		want  string
+		err   error
+	}{
+		{
+			name:  "branches",
+			fname: "../testdata/simple.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpBranches("t*")},
+			want: `key[000]: tree;1 "fake data" (TTree)
+[000][two]: 1.1
+[000][three]: uno
+[001][two]: 2.2
+[001][three]: dos
+[002][two]: 3.3
+[002][three]: tres
+[003][two]: 4.4
+[003][three]: quatro
+`,
+		},
+		{
+			name:  "leaves",
+			fname: "../testdata/root_numpy_struct.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpBranches("branch2", "*.intleaf")},
+			want: `key[000]: test;1 "identical leaf names in different branches" (TTree)
+[000][branch1.intleaf]: 10
+[000][branch2.intleaf]: 20
+[000][branch2.floatleaf]: 781.2
+`,
+		},
+		{
+			name:  "entries",
+			fname: "../testdata/simple.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpEntries(1, 3)},
+			want: `key[000]: tree;1 "fake data" (TTree)
+[001][one]: 2
+[001][two]: 2.2
+[001][three]: dos
+[002][one]: 3
+[002][two]: 3.3
+[002][three]: tres
+`,
+		},
+		{
+			name:  "entries-clipped",
+			fname: "../testdata/simple.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpEntries(3, 10)},
+			want: `key[000]: tree;1 "fake data" (TTree)
+[003][one]: 4
+[003][two]: 4.4
+[003][three]: quatro
+`,
+		},
+		{
+			name:  "entries-past-end",
+			fname: "../testdata/simple.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpEntries(10, -1)},
+			want: `key[000]: tree;1 "fake data" (TTree)
+`,
+		},
+		{
+			name:  "table",
+			fname: "../testdata/simple.root",
+			opts: []rcmd.DumpOption{
+				rcmd.DumpAs(rcmd.DumpTable),
+				rcmd.DumpEntries(2, -1),
+			},
+			want: `key[000]: tree;1 "fake data" (TTree)
+entry   one     two     three
+2       3       3.3     tres
+3       4       4.4     quatro
+`,
+		},
+		{
+			name:  "jsonl",
+			fname: "../testdata/small-flat-tree.root",
+			opts: []rcmd.DumpOption{
+				rcmd.DumpAs(rcmd.DumpJSONL),
+				rcmd.DumpBranches("Str", "ArrayInt32", "N", "SliceFloat64"),
+				rcmd.DumpEntries(0, 3),
+			},
+			want: `{"entry":0,"Str":"evt-000","ArrayInt32":[0,0,0,0,0,0,0,0,0,0],"N":0,"SliceFloat64":[]}
+{"entry":1,"Str":"evt-001","ArrayInt32":[1,1,1,1,1,1,1,1,1,1],"N":1,"SliceFloat64":[1]}
+{"entry":2,"Str":"evt-002","ArrayInt32":[2,2,2,2,2,2,2,2,2,2],"N":2,"SliceFloat64":[2,2]}
+`,
+		},
+		{
+			name:  "csv",
+			fname: "../testdata/small-flat-tree.root",
+			opts: []rcmd.DumpOption{
+				rcmd.DumpAs(rcmd.DumpCSV),
+				rcmd.DumpBranches("Str", "N", "SliceFloat64"),
+				rcmd.DumpEntries(1, 3),
+			},
+			want: `entry,Str,N,SliceFloat64
+1,evt-001,1,[1]
+2,evt-002,2,[2 2]
+`,
+		},
+		{
+			name:  "csv-no-tree",
+			fname: "../testdata/graphs.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpAs(rcmd.DumpCSV)},
+			want:  "",
+		},
+		{
+			name:  "invalid-range",
+			fname: "../testdata/simple.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpEntries(3, 1)},
+			err:   fmt.Errorf("invalid entry range [3, 1)"),
+		},
+		{
+			name:  "invalid-pattern",
+			fname: "../testdata/simple.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpBranches("[a-")},
+			err:   fmt.Errorf(`invalid branch pattern "[a-": syntax error in pattern`),
+		},
+		{
+			name:  "invalid-format",
+			fname: "../testdata/simple.root",
+			opts:  []rcmd.DumpOption{rcmd.DumpAs(42)},
+			err:   fmt.Errorf("invalid dump format 42"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := new(strings.Builder)
+			err := rcmd.Dump(got, tc.fname, deep, nil, tc.opts...)
+			switch {
+			case err != nil && tc.err != nil:
+				if got, want := err.Error(), tc.err.Error(); got != want {
+					t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+				}
+				return
+			case err != nil && tc.err == nil:
+				t.Fatalf("could not run root-dump: %+v", err)
+			case err == nil && tc.err != nil:
+				t.Fatalf("expected an error (%v)", tc.err)
+			}
+
+			if got, want := got.String(), tc.want; got != want {
+				diff := cmp.Diff(want, got)
+				t.Fatalf("invalid root-dump output: -- (-ref +got)\n%s", diff)
+			}
+		})
+	}
+}
+
 func BenchmarkDump(b *testing.B) {
 	const deep = true
 	out := new(strings.Builder)
@@ -0,0 +1,145 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"fmt"
+	"io"
+	stdpath "path"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/internal/rexpr"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// GrepOption controls how Grep behaves.
+type GrepOption func(*grepCmd)
+
+// GrepBranches selects the branches whose values are displayed for each
+// matching entry, as a list of patterns.
+// Leaves of a branch are named "branch.leaf".
+// By default, the branches used by the selection are displayed.
+//
+// Patterns follow the syntax of path.Match.
+func GrepBranches(patterns ...string) GrepOption {
+	return func(cmd *grepCmd) {
+		cmd.branches = append(cmd.branches, patterns...)
+	}
+}
+
+// GrepMax stops the scan of the tree after n matching entries.
+// By default, all the entries of the tree are scanned.
+func GrepMax(n int64) GrepOption {
+	return func(cmd *grepCmd) {
+		cmd.max = n
+	}
+}
+
+// GrepAs sets the format used to display the matching entries.
+// The default format is DumpTable.
+func GrepAs(format DumpFormat) GrepOption {
+	return func(cmd *grepCmd) {
+		cmd.format = format
+	}
+}
+
+// Grep scans the tree tname of the fname ROOT file for the entries
+// satisfying the provided selection, and displays the index and the values
+// of the selected branches of each matching entry into the provided
+// io.Writer.
+//
+// The selection is a TTree::Draw-like expression using the Go syntax,
+// e.g. "evtNum == 123456 && run == 300000", where identifiers refer to
+// branches of the tree.
+// Entries for which the selection evaluates to 0 or NaN do not match.
+//
+// Grep returns the number of matching entries.
+// Grep's behaviour can be customized with a set of optional GrepOptions.
+func Grep(w io.Writer, fname, tname, cut string, opts ...GrepOption) (int64, error) {
+	cmd := grepCmd{
+		format: DumpTable,
+	}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	switch cmd.format {
+	case DumpText, DumpTable, DumpJSONL, DumpCSV:
+		// ok.
+	default:
+		return 0, fmt.Errorf("invalid grep format %d", cmd.format)
+	}
+
+	for _, pat := range cmd.branches {
+		_, err := stdpath.Match(pat, "")
+		if err != nil {
+			return 0, fmt.Errorf("invalid branch pattern %q: %w", pat, err)
+		}
+	}
+
+	if cut == "" {
+		return 0, fmt.Errorf("invalid empty selection")
+	}
+	sel, err := rexpr.NewCut(cut)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selection: %w", err)
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		return 0, fmt.Errorf("could not open file %q: %w", fname, err)
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get(tname)
+	if err != nil {
+		return 0, fmt.Errorf("could not get tree %q: %w", tname, err)
+	}
+
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		return 0, fmt.Errorf("object %q is not a Tree", tname)
+	}
+
+	branches := cmd.branches
+	if len(branches) == 0 {
+		branches = sel.RVars()
+	}
+
+	dump := dumpCmd{
+		w:        w,
+		deep:     true,
+		branches: branches,
+		end:      -1,
+		format:   cmd.format,
+		sel:      sel,
+		max:      cmd.max,
+	}
+
+	found := false
+	for _, v := range rtree.NewReadVars(tree) {
+		if dump.selectBranch(v.Name, rvarName(v)) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("no branch to display in tree %q", tname)
+	}
+
+	err = dump.dumpTree(tree)
+	if err != nil {
+		return dump.nsel, fmt.Errorf("could not scan tree %q: %w", tname, err)
+	}
+
+	return dump.nsel, nil
+}
+
+type grepCmd struct {
+	branches []string // patterns of branches to display
+	max      int64    // maximum number of matching entries
+	format   DumpFormat
+}
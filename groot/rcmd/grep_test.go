@@ -0,0 +1,166 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rcmd"
+)
+
+func TestGrep(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fname string
+		tname string
+		cut   string
+		opts  []rcmd.GrepOption
+		n     int64
+		want  string
+	}{
+		{
+			name:  "simple",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "one == 3 || two < 2",
+			n:     2,
+			want: `entry   one     two
+0       1       1.1
+2       3       3.3
+`,
+		},
+		{
+			name:  "simple-max",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "one >= 2",
+			opts: []rcmd.GrepOption{
+				rcmd.GrepMax(2),
+				rcmd.GrepBranches("one", "three"),
+				rcmd.GrepAs(rcmd.DumpText),
+			},
+			n: 2,
+			want: `[001][one]: 2
+[001][three]: dos
+[002][one]: 3
+[002][three]: tres
+`,
+		},
+		{
+			name:  "small-flat-tree",
+			fname: "../testdata/small-flat-tree.root",
+			tname: "tree",
+			cut:   "Int32 > 90 && SliceFloat64[2] == 93",
+			opts: []rcmd.GrepOption{
+				rcmd.GrepBranches("Int32", "Str", "N"),
+				rcmd.GrepAs(rcmd.DumpJSONL),
+			},
+			n: 1,
+			want: `{"entry":93,"Int32":93,"Str":"evt-093","N":3}
+`,
+		},
+		{
+			name:  "small-flat-tree-none",
+			fname: "../testdata/small-flat-tree.root",
+			tname: "tree",
+			cut:   "Int64 < 0",
+			opts:  []rcmd.GrepOption{rcmd.GrepAs(rcmd.DumpCSV)},
+			n:     0,
+			want:  "entry,Int64\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := new(strings.Builder)
+			n, err := rcmd.Grep(got, tc.fname, tc.tname, tc.cut, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not grep tree: %+v", err)
+			}
+
+			if got, want := n, tc.n; got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			if got, want := got.String(), tc.want; got != want {
+				t.Fatalf("invalid root-grep output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
+
+func TestGrepErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fname string
+		tname string
+		cut   string
+		opts  []rcmd.GrepOption
+		want  string
+	}{
+		{
+			name:  "empty-cut",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			want:  "invalid empty selection",
+		},
+		{
+			name:  "invalid-cut",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "one >",
+			want:  `invalid selection: rexpr: could not parse expression "one >": 1:6: expected operand, found 'EOF'`,
+		},
+		{
+			name:  "invalid-format",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "one > 2",
+			opts:  []rcmd.GrepOption{rcmd.GrepAs(42)},
+			want:  "invalid grep format 42",
+		},
+		{
+			name:  "invalid-pattern",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "one > 2",
+			opts:  []rcmd.GrepOption{rcmd.GrepBranches("[")},
+			want:  `invalid branch pattern "[": syntax error in pattern`,
+		},
+		{
+			name:  "no-branch",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "one > 2",
+			opts:  []rcmd.GrepOption{rcmd.GrepBranches("not-there")},
+			want:  `no branch to display in tree "tree"`,
+		},
+		{
+			name:  "not-a-tree",
+			fname: "../testdata/dirs-6.14.00.root",
+			tname: "dir1",
+			cut:   "one > 2",
+			want:  `object "dir1" is not a Tree`,
+		},
+		{
+			name:  "unknown-branch",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "four > 2",
+			opts:  []rcmd.GrepOption{rcmd.GrepBranches("one")},
+			want:  `could not scan tree "tree": could not bind selection: rtree: could not create formula: rtree: could not find all needed ReadVars (missing: [four])`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := rcmd.Grep(io.Discard, tc.fname, tc.tname, tc.cut, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.want; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ package rcmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	stdpath "path"
 	"text/tabwriter"
 
 	"go-hep.org/x/hep/groot"
@@ -24,6 +26,10 @@ type lsCmd struct {
 
 	streamers bool
 	trees     bool
+	json      bool
+
+	names   []string // patterns of key names to display
+	classes []string // patterns of key classes to display
 }
 
 // ListStreamers enables the display of streamer informations
@@ -42,6 +48,40 @@ func ListTrees(v bool) ListOption {
 	}
 }
 
+// ListJSON enables the JSON output mode.
+//
+// In JSON output mode, List writes a JSON document describing the keys
+// of the provided ROOT file, with their class, cycle and size.
+// Trees are described with their number of entries and their branches,
+// irrespective of the ListTrees option.
+func ListJSON(v bool) ListOption {
+	return func(cmd *lsCmd) {
+		cmd.json = v
+	}
+}
+
+// ListNames restricts the display to the keys whose name matches at least
+// one of the provided patterns.
+// Directories are displayed when they contain a matching key.
+//
+// Patterns follow the syntax of path.Match.
+func ListNames(patterns ...string) ListOption {
+	return func(cmd *lsCmd) {
+		cmd.names = append(cmd.names, patterns...)
+	}
+}
+
+// ListClasses restricts the display to the keys whose class name matches
+// at least one of the provided patterns.
+// Directories are displayed when they contain a matching key.
+//
+// Patterns follow the syntax of path.Match.
+func ListClasses(patterns ...string) ListOption {
+	return func(cmd *lsCmd) {
+		cmd.classes = append(cmd.classes, patterns...)
+	}
+}
+
 // List displays the summary content of the named ROOT file into the
 // provided io Writer.
 //
@@ -57,6 +97,19 @@ func List(w io.Writer, fname string, opts ...ListOption) error {
 		opt(&cmd)
 	}
 
+	for _, pats := range [][]string{cmd.names, cmd.classes} {
+		for _, pat := range pats {
+			_, err := stdpath.Match(pat, "")
+			if err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pat, err)
+			}
+		}
+	}
+
+	if cmd.json {
+		return cmd.lsJSON(fname)
+	}
+
 	return cmd.ls(fname)
 }
 
@@ -102,6 +155,9 @@ type keyer interface {
 }
 
 func (ls lsCmd) walk(w io.Writer, k keyer) {
+	if !ls.accept(k) {
+		return
+	}
 	if ls.trees && isTreelike(k.ClassName()) {
 		obj := k.Value()
 		tree, ok := obj.(rtree.Tree)
@@ -144,6 +200,59 @@ func (ls lsCmd) walk(w io.Writer, k keyer) {
 	}
 }
 
+// accept returns whether the provided key should be displayed.
+// Keys are displayed when they match the name and class filters, or when
+// they contain such keys.
+func (ls lsCmd) accept(k keyer) bool {
+	if ls.match(k) {
+		return true
+	}
+
+	switch {
+	case isDirlike(k.ClassName()):
+		if dir, ok := k.Value().(riofs.Directory); ok {
+			for _, k := range dir.Keys() {
+				if ls.accept(&k) {
+					return true
+				}
+			}
+		}
+	case isObjFinder(k.Value().(root.Object)):
+		if obj, ok := k.Value().(root.ObjectFinder); ok {
+			for _, name := range obj.Keys() {
+				o, err := obj.Get(name)
+				if err != nil {
+					panic(err)
+				}
+				if ls.accept(keyObj{name, o}) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// match returns whether the provided key matches the name and class filters.
+func (ls lsCmd) match(k keyer) bool {
+	return matchAny(ls.names, k.Name()) && matchAny(ls.classes, k.ClassName())
+}
+
+// matchAny returns whether name matches any of the provided patterns.
+// An empty list of patterns matches any name.
+func matchAny(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pat := range patterns {
+		ok, _ := stdpath.Match(pat, name)
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
 func isDirlike(class string) bool {
 	switch class {
 	case "TDirectory", "TDirectoryFile":
@@ -247,3 +356,157 @@ func (k keyObj) Cycle() int {
 func (k keyObj) Value() any {
 	return k.obj
 }
+
+// lsFile is the JSON description of a ROOT file.
+type lsFile struct {
+	File      string       `json:"file"`
+	Version   int          `json:"version"`
+	Streamers []lsStreamer `json:"streamers,omitempty"`
+	Keys      []lsKey      `json:"keys"`
+}
+
+// lsStreamer is the JSON description of a streamer info.
+type lsStreamer struct {
+	Name     string      `json:"name"`
+	Version  int         `json:"version"`
+	Title    string      `json:"title"`
+	Elements []lsElement `json:"elements"`
+}
+
+// lsElement is the JSON description of a streamer element.
+type lsElement struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Offset int    `json:"offset"`
+	Kind   int    `json:"kind"`
+	Size   int    `json:"size"`
+	Title  string `json:"title"`
+}
+
+// lsKey is the JSON description of a key.
+// Sizes are only available for keys stored on disk.
+type lsKey struct {
+	Name     string     `json:"name"`
+	Class    string     `json:"class"`
+	Title    string     `json:"title"`
+	Cycle    int        `json:"cycle"`
+	NBytes   int32      `json:"nbytes,omitempty"` // size on disk, including the key header
+	ObjLen   int32      `json:"objlen,omitempty"` // uncompressed size of the object
+	Entries  *int64     `json:"entries,omitempty"`
+	Branches []lsBranch `json:"branches,omitempty"`
+	Keys     []lsKey    `json:"keys,omitempty"`
+}
+
+// lsBranch is the JSON description of a branch.
+type lsBranch struct {
+	Name     string     `json:"name"`
+	Title    string     `json:"title"`
+	Class    string     `json:"class"`
+	Branches []lsBranch `json:"branches,omitempty"`
+}
+
+func (ls lsCmd) lsJSON(fname string) error {
+	f, err := groot.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	o := lsFile{
+		File:    fname,
+		Version: f.Version(),
+		Keys:    make([]lsKey, 0),
+	}
+
+	if ls.streamers {
+		for _, si := range f.StreamerInfos() {
+			v := lsStreamer{
+				Name:     si.Name(),
+				Version:  si.ClassVersion(),
+				Title:    si.Title(),
+				Elements: make([]lsElement, 0, len(si.Elements())),
+			}
+			for _, elm := range si.Elements() {
+				v.Elements = append(v.Elements, lsElement{
+					Name:   elm.Name(),
+					Type:   elm.TypeName(),
+					Offset: int(elm.Offset()),
+					Kind:   int(elm.Type()),
+					Size:   int(elm.Size()),
+					Title:  elm.Title(),
+				})
+			}
+			o.Streamers = append(o.Streamers, v)
+		}
+	}
+
+	for _, k := range f.Keys() {
+		o.Keys = ls.appendKey(o.Keys, &k)
+	}
+
+	enc := json.NewEncoder(ls.w)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(o)
+	if err != nil {
+		return fmt.Errorf("could not encode JSON description of %q: %w", fname, err)
+	}
+
+	return nil
+}
+
+func (ls lsCmd) appendKey(keys []lsKey, k keyer) []lsKey {
+	if !ls.accept(k) {
+		return keys
+	}
+
+	o := lsKey{
+		Name:  k.Name(),
+		Class: k.ClassName(),
+		Title: k.Title(),
+		Cycle: k.Cycle(),
+	}
+	if k, ok := k.(*riofs.Key); ok {
+		o.NBytes = k.Nbytes()
+		o.ObjLen = k.ObjLen()
+	}
+
+	switch {
+	case isTreelike(k.ClassName()):
+		if tree, ok := k.Value().(rtree.Tree); ok {
+			n := tree.Entries()
+			o.Entries = &n
+			o.Branches = jsonBranches(tree)
+		}
+	case isDirlike(k.ClassName()):
+		if dir, ok := k.Value().(riofs.Directory); ok {
+			for _, k := range dir.Keys() {
+				o.Keys = ls.appendKey(o.Keys, &k)
+			}
+		}
+	case isObjFinder(k.Value().(root.Object)):
+		if obj, ok := k.Value().(root.ObjectFinder); ok {
+			for _, name := range obj.Keys() {
+				v, err := obj.Get(name)
+				if err != nil {
+					panic(err)
+				}
+				o.Keys = ls.appendKey(o.Keys, keyObj{name, v})
+			}
+		}
+	}
+
+	return append(keys, o)
+}
+
+func jsonBranches(bres brancher) []lsBranch {
+	var o []lsBranch
+	for _, b := range bres.Branches() {
+		o = append(o, lsBranch{
+			Name:     b.Name(),
+			Title:    b.Title(),
+			Class:    b.Class(),
+			Branches: jsonBranches(b),
+		})
+	}
+	return o
+}
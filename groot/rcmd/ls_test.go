@@ -5,6 +5,9 @@
 package rcmd_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -82,6 +85,61 @@ version: 60600
 			name: "../testdata/tcanvas.root",
 			want: loadRef("./testdata/tcanvas.root-ls.txt"),
 		},
+		{
+			name: "../testdata/dirs-6.14.00.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListClasses("TH1*"),
+			},
+			want: `=== [../testdata/dirs-6.14.00.root] ===
+version: 61400
+TDirectoryFile   dir1    dir1    (cycle=1)
+  TDirectoryFile dir11   dir11   (cycle=1)
+    TH1F         h1      h1      (cycle=1)
+`,
+		},
+		{
+			name: "../testdata/dirs-6.14.00.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListNames("dir[23]", "h2"),
+			},
+			want: `=== [../testdata/dirs-6.14.00.root] ===
+version: 61400
+TDirectoryFile dir2    dir2    (cycle=1)
+TDirectoryFile dir3    dir3    (cycle=1)
+`,
+		},
+		{
+			name: "../testdata/small-flat-tree.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListNames("tree"),
+				rcmd.ListClasses("TH1*"),
+			},
+			want: `=== [../testdata/small-flat-tree.root] ===
+version: 60806
+`,
+		},
+		{
+			name: "../testdata/simple.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListJSON(true),
+			},
+			want: loadRef("./testdata/simple.root-ls.json"),
+		},
+		{
+			name: "../testdata/dirs-6.14.00.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListJSON(true),
+				rcmd.ListClasses("TH1*"),
+			},
+			want: loadRef("./testdata/dirs-6.14.00.root-ls.json"),
+		},
+		{
+			name: "../testdata/small-evnt-tree-fullsplit.root",
+			opts: []rcmd.ListOption{
+				rcmd.ListJSON(true),
+			},
+			want: loadRef("./testdata/small-evnt-tree-fullsplit.root-ls.json"),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			out := new(strings.Builder)
@@ -96,3 +154,47 @@ version: 60600
 		})
 	}
 }
+
+func TestListJSONStreamers(t *testing.T) {
+	out := new(bytes.Buffer)
+	err := rcmd.List(out, "../testdata/simple.root", rcmd.ListJSON(true), rcmd.ListStreamers(true))
+	if err != nil {
+		t.Fatalf("could not run root-ls: %+v", err)
+	}
+
+	var doc struct {
+		Streamers []struct {
+			Name     string `json:"name"`
+			Elements []struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"elements"`
+		} `json:"streamers"`
+	}
+	err = json.Unmarshal(out.Bytes(), &doc)
+	if err != nil {
+		t.Fatalf("could not decode JSON output: %+v", err)
+	}
+
+	if got, want := len(doc.Streamers), 18; got != want {
+		t.Fatalf("invalid number of streamers: got=%d, want=%d", got, want)
+	}
+
+	for _, si := range doc.Streamers {
+		if si.Name != "TTree" {
+			continue
+		}
+		if got, want := si.Elements[0].Name, "TNamed"; got != want {
+			t.Fatalf("invalid first element: got=%q, want=%q", got, want)
+		}
+		return
+	}
+	t.Fatalf("could not find TTree streamer")
+}
+
+func TestListInvalidPattern(t *testing.T) {
+	err := rcmd.List(io.Discard, "../testdata/simple.root", rcmd.ListNames("[a-"))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
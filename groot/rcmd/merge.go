@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	stdpath "path"
+	"sync"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rhist"
@@ -16,25 +17,66 @@ import (
 	"go-hep.org/x/hep/groot/rtree"
 )
 
+// MergeOption controls how Merge behaves.
+type MergeOption func(*mergeCmd)
+
+// MergeCompression sets the compression scheme of the output ROOT file,
+// encoded as ROOT does: 100*algorithm + level.
+// e.g. 404 selects LZ4 at level 4 and 505 selects ZSTD at level 5.
+//
+// By default, the output ROOT file uses the default groot compression.
+func MergeCompression(compression int32) MergeOption {
+	return func(cmd *mergeCmd) {
+		cmd.compr = &compression
+	}
+}
+
+// MergeWorkers sets the maximum number of input ROOT files that are
+// opened and read concurrently.
+//
+// Objects are still merged in the order of the input ROOT files.
+// By default, input ROOT files are read one at a time.
+func MergeWorkers(n int) MergeOption {
+	return func(cmd *mergeCmd) {
+		cmd.workers = n
+	}
+}
+
 // Merge merges all input fnames ROOT files into the output oname one.
-func Merge(oname string, fnames []string, verbose bool) error {
-	o, err := groot.Create(oname)
+//
+// Histograms are summed, trees are concatenated and the directory
+// hierarchy of the first input ROOT file is replicated in the output
+// ROOT file.
+//
+// Merge's behaviour can be customized with a set of optional MergeOptions.
+func Merge(oname string, fnames []string, verbose bool, opts ...MergeOption) error {
+	cmd := mergeCmd{verbose: verbose, workers: 1}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+	if cmd.workers < 1 {
+		return fmt.Errorf("invalid number of workers (%d)", cmd.workers)
+	}
+
+	var fopts []riofs.FileOption
+	if cmd.compr != nil {
+		fopts = append(fopts, riofs.WithCompression(*cmd.compr))
+	}
+
+	o, err := groot.Create(oname, fopts...)
 	if err != nil {
 		return fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
 	}
 	defer o.Close()
 
-	cmd := mergeCmd{verbose: verbose}
 	tsks, err := cmd.mergeTasksFrom(o, fnames[0])
 	if err != nil {
 		return fmt.Errorf("could not create merge tasks: %w", err)
 	}
 
-	for _, fname := range fnames[1:] {
-		err := cmd.process(tsks, fname)
-		if err != nil {
-			return fmt.Errorf("could not process ROOT file %q: %w", fname, err)
-		}
+	err = cmd.process(tsks, fnames[1:])
+	if err != nil {
+		return err
 	}
 
 	for i := range tsks {
@@ -55,6 +97,8 @@ func Merge(oname string, fnames []string, verbose bool) error {
 
 type mergeCmd struct {
 	verbose bool
+	workers int    // number of input files read concurrently
+	compr   *int32 // compression of the output file
 }
 
 func (mergeCmd) acceptObj(obj root.Object) bool {
@@ -72,20 +116,108 @@ func (mergeCmd) acceptObj(obj root.Object) bool {
 	}
 }
 
-func (cmd mergeCmd) process(tsks []task, fname string) error {
-	if cmd.verbose {
-		log.Printf("merging [%s]...", fname)
+// input holds the objects of an input ROOT file that need to be merged.
+type input struct {
+	f    *riofs.File
+	objs []root.Object // objects to merge, in tasks order
+	err  error
+}
+
+// process merges the content of the provided ROOT files into the tasks.
+//
+// Up to cmd.workers input ROOT files are opened and read concurrently,
+// while their objects are merged in the order of the fnames slice.
+func (cmd mergeCmd) process(tsks []task, fnames []string) error {
+	var (
+		names = make([]string, len(tsks))
+		ins   = make([]chan input, len(fnames))
+		sem   = make(chan struct{}, cmd.workers)
+		quit  = make(chan struct{})
+		wg    sync.WaitGroup
+	)
+	for i := range tsks {
+		names[i] = tsks[i].path()
+	}
+	for i := range ins {
+		ins[i] = make(chan input, 1)
+	}
+
+	defer func() {
+		close(quit)
+		wg.Wait()
+		// close input files that were read but not merged.
+		for _, ch := range ins {
+			select {
+			case in := <-ch:
+				if in.f != nil {
+					in.f.Close()
+				}
+			default:
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i, fname := range fnames {
+			select {
+			case sem <- struct{}{}:
+			case <-quit:
+				return
+			}
+			wg.Add(1)
+			go func(i int, fname string) {
+				defer wg.Done()
+				ins[i] <- cmd.read(names, fname)
+			}(i, fname)
+		}
+	}()
+
+	for i, fname := range fnames {
+		err := cmd.merge(tsks, fname, <-ins[i])
+		<-sem
+		if err != nil {
+			return fmt.Errorf("could not process ROOT file %q: %w", fname, err)
+		}
 	}
 
+	return nil
+}
+
+// read opens the input ROOT file fname and retrieves the named objects.
+func (cmd mergeCmd) read(names []string, fname string) input {
 	f, err := groot.Open(fname)
 	if err != nil {
-		return fmt.Errorf("could not open input ROOT file %q: %w", fname, err)
+		return input{err: fmt.Errorf("could not open input ROOT file %q: %w", fname, err)}
+	}
+
+	objs := make([]root.Object, len(names))
+	for i, name := range names {
+		obj, err := riofs.Dir(f).Get(name)
+		if err != nil {
+			f.Close()
+			return input{err: fmt.Errorf("could not get %q: %w", name, err)}
+		}
+		objs[i] = obj
+	}
+
+	return input{f: f, objs: objs}
+}
+
+func (cmd mergeCmd) merge(tsks []task, fname string, in input) error {
+	if in.err != nil {
+		return in.err
+	}
+	defer in.f.Close()
+
+	if cmd.verbose {
+		log.Printf("merging [%s]...", fname)
 	}
-	defer f.Close()
 
 	for i := range tsks {
 		tsk := &tsks[i]
-		err = tsk.merge(f)
+		err := tsk.merge(in.objs[i])
 		if err != nil {
 			return fmt.Errorf("could not merge task %d (%s) for file %q: %w", i, tsk.path(), fname, err)
 		}
@@ -200,16 +332,10 @@ func (tsk *task) path() string {
 	return stdpath.Join(tsk.dir, tsk.key)
 }
 
-func (tsk *task) merge(f *riofs.File) error {
-	name := tsk.path()
-	obj, err := riofs.Dir(f).Get(name)
-	if err != nil {
-		return fmt.Errorf("could not get %q: %w", name, err)
-	}
-
-	err = tsk.mergeObj(tsk.obj, obj)
+func (tsk *task) merge(obj root.Object) error {
+	err := tsk.mergeObj(tsk.obj, obj)
 	if err != nil {
-		return fmt.Errorf("could not merge %q: %w", name, err)
+		return fmt.Errorf("could not merge %q: %w", tsk.path(), err)
 	}
 
 	return nil
@@ -241,15 +367,9 @@ func (tsk *task) mergeObj(dst, src root.Object) error {
 	}
 
 	switch dst := dst.(type) {
-	case rhist.H2:
-		return tsk.mergeH2(dst, src.(rhist.H2))
 	case root.Merger:
 		return dst.ROOTMerge(src)
 	default:
 		return fmt.Errorf("could not find suitable merge-API for (dst=%T, src=%T)", dst, src)
 	}
 }
-
-func (tsk *task) mergeH2(dst, src rhist.H2) error {
-	panic("not implemented")
-}
@@ -83,7 +83,11 @@ func TestMerge(t *testing.T) {
 			name:   "h2d-2",
 			inputs: []funcT{makeH2D(1), makeH2D(1)},
 			output: makeH2D(2),
-			panics: "not implemented", // FIXME(sbinet)
+		},
+		{
+			name:   "h2d-3",
+			inputs: []funcT{makeH2D(1), makeH2D(2)},
+			output: makeH2D(3),
 		},
 		{
 			name:   "graph-1",
@@ -173,6 +177,94 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMergeOptions(t *testing.T) {
+	tmp := t.TempDir()
+
+	var fnames []string
+	for i := 0; i < 4; i++ {
+		fname := filepath.Join(tmp, fmt.Sprintf("flat-tree-%02d.root", i))
+		err := makeFlatTree(1)(t, fname)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		fnames = append(fnames, fname)
+	}
+
+	refname := filepath.Join(tmp, "flat-tree.want.root")
+	err := makeFlatTree(len(fnames))(t, refname)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	want := new(bytes.Buffer)
+	err = rcmd.Dump(want, refname, true, nil)
+	if err != nil {
+		t.Fatalf("could not run root-dump: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		workers int
+		compr   int32
+	}{
+		{name: "workers-1-zlib", workers: 1, compr: 101},
+		{name: "workers-2-lz4", workers: 2, compr: 404},
+		{name: "workers-8-zstd", workers: 8, compr: 505},
+		{name: "workers-3-none", workers: 3, compr: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			err := rcmd.Merge(
+				oname, fnames, false,
+				rcmd.MergeWorkers(tc.workers),
+				rcmd.MergeCompression(tc.compr),
+			)
+			if err != nil {
+				t.Fatalf("could not run root-merge: %+v", err)
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			if got, want := f.Compression(), tc.compr; got != want {
+				t.Fatalf("invalid compression: got=%d, want=%d", got, want)
+			}
+
+			got := new(bytes.Buffer)
+			err = rcmd.Dump(got, oname, true, nil)
+			if err != nil {
+				t.Fatalf("could not run root-dump: %+v", err)
+			}
+
+			if got, want := got.String(), want.String(); got != want {
+				t.Fatalf("invalid root-merge output:\ngot:\n%swant:\n%s", got, want)
+			}
+		})
+	}
+
+	t.Run("missing-file", func(t *testing.T) {
+		oname := filepath.Join(tmp, "missing.root")
+		err := rcmd.Merge(
+			oname, append(fnames, filepath.Join(tmp, "not-there.root")), false,
+			rcmd.MergeWorkers(2),
+		)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+
+	t.Run("invalid-workers", func(t *testing.T) {
+		oname := filepath.Join(tmp, "invalid.root")
+		err := rcmd.Merge(oname, fnames, false, rcmd.MergeWorkers(0))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
 func makeFlatTree(n int) func(t *testing.T, fname string) error {
 	return func(t *testing.T, fname string) error {
 		type Data struct {
@@ -0,0 +1,218 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"fmt"
+	"log"
+	stdpath "path"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/internal/rexpr"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// SkimOption controls how Skim behaves.
+type SkimOption func(*skimCmd)
+
+// SkimBranches restricts the output tree to the branches whose name matches
+// at least one of the provided patterns.
+// Branches holding the size of a selected variable-length branch are
+// always kept.
+// By default, all branches are kept.
+//
+// Patterns follow the syntax of path.Match.
+func SkimBranches(patterns ...string) SkimOption {
+	return func(cmd *skimCmd) {
+		cmd.branches = append(cmd.branches, patterns...)
+	}
+}
+
+// SkimCompression sets the compression scheme of the output ROOT file,
+// encoded as ROOT does: 100*algorithm + level.
+// By default, the output ROOT file uses the default groot compression.
+func SkimCompression(compression int32) SkimOption {
+	return func(cmd *skimCmd) {
+		cmd.compr = &compression
+	}
+}
+
+// SkimVerbose enables the verbose mode.
+func SkimVerbose(verbose bool) SkimOption {
+	return func(cmd *skimCmd) {
+		cmd.verbose = verbose
+	}
+}
+
+// Skim copies the entries of the tree tname from the input file fname
+// that pass the provided selection into a tree of the same name in the
+// output file oname.
+//
+// The selection is a TTree::Draw-like expression using the Go syntax,
+// e.g. "pt > 10 && abs(eta) < 2.5", where identifiers refer to branches of
+// the input tree.
+// Entries for which the selection evaluates to 0 or NaN are discarded.
+// All entries are kept when the selection is empty.
+//
+// Skim returns the number of entries written to the output tree.
+// Skim's behaviour can be customized with a set of optional SkimOptions.
+func Skim(oname, fname, tname, cut string, opts ...SkimOption) (int64, error) {
+	var cmd skimCmd
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	for _, pat := range cmd.branches {
+		_, err := stdpath.Match(pat, "")
+		if err != nil {
+			return 0, fmt.Errorf("invalid branch pattern %q: %w", pat, err)
+		}
+	}
+
+	var sel *rexpr.Cut
+	if cut != "" {
+		var err error
+		sel, err = rexpr.NewCut(cut)
+		if err != nil {
+			return 0, fmt.Errorf("invalid selection: %w", err)
+		}
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		return 0, fmt.Errorf("could not open input file %q: %w", fname, err)
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get(tname)
+	if err != nil {
+		return 0, fmt.Errorf("could not get tree %q: %w", tname, err)
+	}
+
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		return 0, fmt.Errorf("object %q is not a Tree", tname)
+	}
+
+	wvars := cmd.wvars(tree)
+	if len(wvars) == 0 {
+		return 0, fmt.Errorf("no branch to keep in tree %q", tname)
+	}
+
+	var fopts []riofs.FileOption
+	if cmd.compr != nil {
+		fopts = append(fopts, riofs.WithCompression(*cmd.compr))
+	}
+
+	o, err := groot.Create(oname, fopts...)
+	if err != nil {
+		return 0, fmt.Errorf("could not create output file %q: %w", oname, err)
+	}
+	defer o.Close()
+
+	var (
+		dirName = stdpath.Dir(tname)
+		objName = stdpath.Base(tname)
+		dir     = riofs.Directory(o)
+	)
+	if dirName != "/" && dirName != "" && dirName != "." {
+		_, err = riofs.Dir(o).Mkdir(dirName)
+		if err != nil {
+			return 0, fmt.Errorf("could not create output directory %q: %w", dirName, err)
+		}
+		odir, err := riofs.Dir(o).Get(dirName)
+		if err != nil {
+			return 0, fmt.Errorf("could not fetch output directory %q: %w", dirName, err)
+		}
+		dir = odir.(riofs.Directory)
+	}
+
+	w, err := rtree.NewWriter(dir, objName, wvars, rtree.WithTitle(tree.Title()))
+	if err != nil {
+		return 0, fmt.Errorf("could not create tree writer: %w", err)
+	}
+	defer w.Close()
+
+	r, err := rtree.NewReader(tree, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	if cmd.verbose {
+		log.Printf("skimming %d entries of %q into %q...", tree.Entries(), tname, oname)
+	}
+
+	switch sel {
+	case nil:
+		_, err = rtree.Copy(w, r)
+	default:
+		_, err = rtree.CopyIf(w, r, sel)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not skim tree: %w", err)
+	}
+
+	n := w.Entries()
+	if cmd.verbose {
+		log.Printf("skimming %d entries of %q into %q... [ok] (selected: %d)", tree.Entries(), tname, oname, n)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close tree writer: %w", err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		return 0, fmt.Errorf("could not close output file %q: %w", oname, err)
+	}
+
+	return n, nil
+}
+
+type skimCmd struct {
+	branches []string // patterns of branches to keep
+	compr    *int32   // compression of the output file
+	verbose  bool
+}
+
+// wvars returns the write-variables of the branches to keep.
+func (cmd skimCmd) wvars(tree rtree.Tree) []rtree.WriteVar {
+	wvars := rtree.WriteVarsFromTree(tree)
+	if len(cmd.branches) == 0 {
+		return wvars
+	}
+
+	keep := make(map[string]bool, len(wvars))
+	for _, wvar := range wvars {
+		if !cmd.accept(wvar.Name) {
+			continue
+		}
+		keep[wvar.Name] = true
+		if wvar.Count != "" {
+			keep[wvar.Count] = true
+		}
+	}
+
+	o := wvars[:0]
+	for _, wvar := range wvars {
+		if !keep[wvar.Name] {
+			continue
+		}
+		o = append(o, wvar)
+	}
+	return o
+}
+
+func (cmd skimCmd) accept(name string) bool {
+	for _, pat := range cmd.branches {
+		if ok, _ := stdpath.Match(pat, name); ok {
+			return true
+		}
+	}
+	return false
+}
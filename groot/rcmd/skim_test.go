@@ -0,0 +1,179 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rcmd"
+)
+
+func TestSkim(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-root-skim-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, tc := range []struct {
+		name  string
+		fname string
+		tname string
+		cut   string
+		opts  []rcmd.SkimOption
+		n     int64
+		want  string
+	}{
+		{
+			name:  "simple",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "two > 2 && one != 3",
+			n:     2,
+			want: `key[000]: tree;1 "fake data" (TTree)
+[000][one]: 2
+[000][two]: 2.2
+[000][three]: dos
+[001][one]: 4
+[001][two]: 4.4
+[001][three]: quatro
+`,
+		},
+		{
+			name:  "simple-no-cut",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			opts:  []rcmd.SkimOption{rcmd.SkimBranches("t*")},
+			n:     4,
+			want: `key[000]: tree;1 "fake data" (TTree)
+[000][two]: 1.1
+[000][three]: uno
+[001][two]: 2.2
+[001][three]: dos
+[002][two]: 3.3
+[002][three]: tres
+[003][two]: 4.4
+[003][three]: quatro
+`,
+		},
+		{
+			name:  "small-flat-tree",
+			fname: "../testdata/small-flat-tree.root",
+			tname: "tree",
+			cut:   "Int32 >= 2 && SliceFloat64[1] < 4",
+			opts: []rcmd.SkimOption{
+				rcmd.SkimBranches("Int32", "SliceFloat64"),
+				rcmd.SkimCompression(404),
+				rcmd.SkimVerbose(true),
+			},
+			n: 2,
+			want: `key[000]: tree;1 "my tree title" (TTree)
+[000][Int32]: 2
+[000][N]: 2
+[000][SliceFloat64]: [2 2]
+[001][Int32]: 3
+[001][N]: 3
+[001][SliceFloat64]: [3 3 3]
+`,
+		},
+		{
+			name:  "small-flat-tree-none",
+			fname: "../testdata/small-flat-tree.root",
+			tname: "tree",
+			cut:   "Float64 < 0",
+			opts:  []rcmd.SkimOption{rcmd.SkimBranches("Int64")},
+			n:     0,
+			want:  "key[000]: tree;1 \"my tree title\" (TTree)\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			n, err := rcmd.Skim(oname, tc.fname, tc.tname, tc.cut, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not skim tree: %+v", err)
+			}
+
+			if got, want := n, tc.n; got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			got := new(bytes.Buffer)
+			err = rcmd.Dump(got, oname, true, nil)
+			if err != nil {
+				t.Fatalf("could not dump output file: %+v", err)
+			}
+
+			if got, want := got.String(), tc.want; got != want {
+				t.Fatalf("invalid root-dump output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
+
+func TestSkimErrors(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-root-skim-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	oname := filepath.Join(tmp, "out.root")
+	for _, tc := range []struct {
+		name  string
+		fname string
+		tname string
+		cut   string
+		opts  []rcmd.SkimOption
+		want  string
+	}{
+		{
+			name:  "invalid-cut",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "one >",
+			want:  `invalid selection: rexpr: could not parse expression "one >": 1:6: expected operand, found 'EOF'`,
+		},
+		{
+			name:  "invalid-pattern",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			opts:  []rcmd.SkimOption{rcmd.SkimBranches("[")},
+			want:  `invalid branch pattern "[": syntax error in pattern`,
+		},
+		{
+			name:  "no-branch",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			opts:  []rcmd.SkimOption{rcmd.SkimBranches("not-there")},
+			want:  `no branch to keep in tree "tree"`,
+		},
+		{
+			name:  "not-a-tree",
+			fname: "../testdata/dirs-6.14.00.root",
+			tname: "dir1",
+			want:  `object "dir1" is not a Tree`,
+		},
+		{
+			name:  "unknown-branch",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			cut:   "four > 2",
+			want:  `could not skim tree: rtree: could not bind selection: rtree: could not create formula: rtree: could not find all needed ReadVars (missing: [four])`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := rcmd.Skim(oname, tc.fname, tc.tname, tc.cut, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.want; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
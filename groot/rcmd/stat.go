@@ -0,0 +1,235 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"fmt"
+	"io"
+	stdpath "path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// StatOption controls how Stat behaves.
+type StatOption func(*statCmd)
+
+// StatSortBySize sorts the branches of each tree by decreasing size on disk.
+// By default, branches are displayed in the order of the tree.
+func StatSortBySize(v bool) StatOption {
+	return func(cmd *statCmd) {
+		cmd.sortBySize = v
+	}
+}
+
+// StatTrees restricts the report to the trees whose name matches at least
+// one of the provided patterns.
+// By default, all the trees of the file are reported.
+//
+// Patterns follow the syntax of path.Match.
+func StatTrees(patterns ...string) StatOption {
+	return func(cmd *statCmd) {
+		cmd.trees = append(cmd.trees, patterns...)
+	}
+}
+
+// Stat displays a report about the storage of the trees of the named
+// ROOT file into the provided io Writer.
+//
+// For each tree, Stat displays a table with, for each branch:
+//   - the number of entries,
+//   - the number of baskets,
+//   - the number of bytes on disk (baskets and their keys),
+//   - the number of bytes before and after compression,
+//   - the compression factor (uncompressed over compressed bytes),
+//   - the average size on disk of a basket.
+//
+// Stat then displays a file-level summary.
+// Stat's behaviour can be customized with a set of optional StatOptions.
+func Stat(w io.Writer, fname string, opts ...StatOption) error {
+	var cmd statCmd
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	for _, pat := range cmd.trees {
+		_, err := stdpath.Match(pat, "")
+		if err != nil {
+			return fmt.Errorf("invalid tree pattern %q: %w", pat, err)
+		}
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open file %q: %w", fname, err)
+	}
+	defer f.Close()
+
+	fmt.Fprintf(w, "=== [%s] ===\n", fname)
+
+	var (
+		top = stdpath.Join(f.Name(), ".") + "/"
+		sum branchStat
+		nt  int
+		nb  int
+	)
+	err = riofs.WalkKeys(f, func(path string, key *riofs.Key, err error) error {
+		if err != nil {
+			return err
+		}
+
+		switch key.ClassName() {
+		case "TTree", "TNtuple", "TNtupleD":
+		default:
+			return nil
+		}
+
+		name := strings.TrimPrefix(path, top)
+		if !cmd.accept(name) {
+			return nil
+		}
+
+		obj, err := key.Object()
+		if err != nil {
+			return fmt.Errorf("could not load tree %q: %w", name, err)
+		}
+
+		tree, ok := obj.(rtree.Tree)
+		if !ok {
+			return fmt.Errorf("object %q is not a tree (type=%T)", name, obj)
+		}
+
+		stats := cmd.branches(tree)
+		tot := branchStat{name: "total"}
+		for _, st := range stats {
+			tot.add(st)
+		}
+		tot.entries = tree.Entries()
+
+		fmt.Fprintf(w, "\ntree %q (cycle=%d, entries=%d, branches=%d)\n", name, key.Cycle(), tree.Entries(), len(stats))
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintf(tw, "BRANCH\tENTRIES\tBASKETS\tDISK\tUNZIPPED\tZIPPED\tFACTOR\tAVG-BASKET\n")
+		for _, st := range stats {
+			st.print(tw)
+		}
+		tot.print(tw)
+		tw.Flush()
+
+		nt++
+		nb += len(stats)
+		sum.add(tot)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not walk through file %q: %w", fname, err)
+	}
+
+	fmt.Fprintf(w, "\nsummary:\n")
+	frac := ""
+	if fi, err := f.Stat(); err == nil && fi.Size() > 0 {
+		fmt.Fprintf(w, "  file size:     %d bytes\n", fi.Size())
+		frac = fmt.Sprintf(" (%.1f%% of file)", 100*float64(sum.disk)/float64(fi.Size()))
+	}
+	fmt.Fprintf(w, "  trees:         %d\n", nt)
+	fmt.Fprintf(w, "  branches:      %d\n", nb)
+	fmt.Fprintf(w, "  baskets:       %d\n", sum.baskets)
+	fmt.Fprintf(w, "  baskets size:  %d bytes%s\n", sum.disk, frac)
+	fmt.Fprintf(w, "  unzipped size: %d bytes\n", sum.tot)
+	fmt.Fprintf(w, "  zipped size:   %d bytes\n", sum.zip)
+	fmt.Fprintf(w, "  comp. factor:  %s\n", sum.factor())
+
+	return nil
+}
+
+type statCmd struct {
+	sortBySize bool
+	trees      []string // patterns of trees to report
+}
+
+func (cmd statCmd) accept(name string) bool {
+	if len(cmd.trees) == 0 {
+		return true
+	}
+	for _, pat := range cmd.trees {
+		if ok, _ := stdpath.Match(pat, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// branches returns the statistics of all the (sub-)branches of the tree.
+func (cmd statCmd) branches(tree rtree.Tree) []branchStat {
+	var (
+		stats []branchStat
+		walk  func(b rtree.Branch)
+	)
+	walk = func(b rtree.Branch) {
+		bkt := rtree.Baskets(b)
+		stats = append(stats, branchStat{
+			name:    b.Name(),
+			entries: b.Entries(),
+			baskets: bkt.N,
+			disk:    bkt.Bytes,
+			tot:     b.TotBytes(),
+			zip:     b.ZipBytes(),
+		})
+		for _, sub := range b.Branches() {
+			walk(sub)
+		}
+	}
+	for _, b := range tree.Branches() {
+		walk(b)
+	}
+
+	if cmd.sortBySize {
+		sort.SliceStable(stats, func(i, j int) bool {
+			return stats[i].disk > stats[j].disk
+		})
+	}
+	return stats
+}
+
+type branchStat struct {
+	name    string
+	entries int64
+	baskets int
+	disk    int64 // bytes on disk, including keys
+	tot     int64 // bytes before compression
+	zip     int64 // bytes after compression
+}
+
+func (st *branchStat) add(o branchStat) {
+	st.baskets += o.baskets
+	st.disk += o.disk
+	st.tot += o.tot
+	st.zip += o.zip
+}
+
+func (st branchStat) factor() string {
+	if st.zip <= 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.2f", float64(st.tot)/float64(st.zip))
+}
+
+func (st branchStat) avg() string {
+	if st.baskets <= 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.1f", float64(st.disk)/float64(st.baskets))
+}
+
+func (st branchStat) print(w io.Writer) {
+	fmt.Fprintf(
+		w, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\n",
+		st.name, st.entries, st.baskets, st.disk, st.tot, st.zip,
+		st.factor(), st.avg(),
+	)
+}
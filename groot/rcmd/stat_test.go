@@ -0,0 +1,148 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rcmd"
+)
+
+func TestStat(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fname string
+		opts  []rcmd.StatOption
+		want  string
+	}{
+		{
+			name:  "simple",
+			fname: "../testdata/simple.root",
+			want: `=== [../testdata/simple.root] ===
+
+tree "tree" (cycle=1, entries=4, branches=3)
+BRANCH  ENTRIES  BASKETS  DISK  UNZIPPED  ZIPPED  FACTOR  AVG-BASKET
+one     4        1        86    86        86      1.00    86.0
+two     4        1        86    86        86      1.00    86.0
+three   4        1        116   116       116     1.00    116.0
+total   4        3        288   288       288     1.00    96.0
+
+summary:
+  file size:     5614 bytes
+  trees:         1
+  branches:      3
+  baskets:       3
+  baskets size:  288 bytes (5.1% of file)
+  unzipped size: 288 bytes
+  zipped size:   288 bytes
+  comp. factor:  1.00
+`,
+		},
+		{
+			name:  "small-flat-tree-sorted",
+			fname: "../testdata/small-flat-tree.root",
+			opts: []rcmd.StatOption{
+				rcmd.StatSortBySize(true),
+				rcmd.StatTrees("tree"),
+			},
+			want: `=== [../testdata/small-flat-tree.root] ===
+
+tree "tree" (cycle=1, entries=100, branches=20)
+BRANCH        ENTRIES  BASKETS  DISK  UNZIPPED  ZIPPED  FACTOR  AVG-BASKET
+SliceFloat64  100      1        690   4087      690     5.92    690.0
+SliceUInt64   100      1        646   4086      646     6.33    646.0
+SliceInt64    100      1        644   4085      644     6.34    644.0
+SliceFloat32  100      1        603   2287      603     3.79    603.0
+SliceInt32    100      1        598   2285      598     3.82    598.0
+SliceUInt32   100      1        598   2286      598     3.82    598.0
+ArrayFloat64  100      1        475   8079      475     17.01   475.0
+Str           100      1        464   1278      464     2.75    464.0
+ArrayUInt64   100      1        440   8078      440     18.36   440.0
+ArrayInt64    100      1        439   8077      439     18.40   439.0
+ArrayFloat32  100      1        437   4079      437     9.33    437.0
+ArrayUInt32   100      1        402   4078      402     10.14   402.0
+ArrayInt32    100      1        401   4077      401     10.17   401.0
+Float64       100      1        297   874       297     2.94    297.0
+Float32       100      1        285   474       285     1.66    285.0
+UInt64        100      1        260   873       260     3.36    260.0
+Int64         100      1        259   872       259     3.37    259.0
+UInt32        100      1        245   473       245     1.93    245.0
+Int32         100      1        244   472       244     1.93    244.0
+N             100      1        117   468       117     4.00    117.0
+total         100      20       8544  61368     8544    7.18    427.2
+
+summary:
+  file size:     15465 bytes
+  trees:         1
+  branches:      20
+  baskets:       20
+  baskets size:  8544 bytes (55.2% of file)
+  unzipped size: 61368 bytes
+  zipped size:   8544 bytes
+  comp. factor:  7.18
+`,
+		},
+		{
+			name:  "no-tree",
+			fname: "../testdata/dirs-6.14.00.root",
+			want: `=== [../testdata/dirs-6.14.00.root] ===
+
+summary:
+  file size:     5399 bytes
+  trees:         0
+  branches:      0
+  baskets:       0
+  baskets size:  0 bytes (0.0% of file)
+  unzipped size: 0 bytes
+  zipped size:   0 bytes
+  comp. factor:  n/a
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := new(strings.Builder)
+			err := rcmd.Stat(got, tc.fname, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not run root-stat: %+v", err)
+			}
+
+			if got, want := got.String(), tc.want; got != want {
+				t.Fatalf("invalid root-stat output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
+
+func TestStatErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fname string
+		opts  []rcmd.StatOption
+		want  string
+	}{
+		{
+			name:  "invalid-pattern",
+			fname: "../testdata/simple.root",
+			opts:  []rcmd.StatOption{rcmd.StatTrees("[")},
+			want:  `invalid tree pattern "[": syntax error in pattern`,
+		},
+		{
+			name:  "no-file",
+			fname: "../testdata/not-there.root",
+			want:  `could not open file "../testdata/not-there.root": riofs: unable to open "../testdata/not-there.root": open ../testdata/not-there.root: no such file or directory`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := rcmd.Stat(new(strings.Builder), tc.fname, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.want; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"fmt"
+	"io"
+	stdpath "path"
+	"strings"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rdict"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/groot/rtypes"
+)
+
+// Validate checks the structural integrity of the named ROOT file and
+// displays the corresponding report into the provided io Writer.
+//
+// Validate checks:
+//   - the integrity of all the records of the file (sizes, positions and
+//     compressed payloads), as Verify does,
+//   - that the classes referenced by the streamer infos of the file are
+//     described,
+//   - that the classes of the objects attached to the keys of the file are
+//     described,
+//   - the consistency of the trees of the file: cluster ranges, number of
+//     entries of branches and baskets, location and payload of baskets.
+//
+// Validate returns an error if the file could not be read or if problems
+// were found.
+func Validate(w io.Writer, fname string, verbose bool) error {
+	fmt.Fprintf(w, "=== [%s] ===\n", fname)
+
+	rep, err := riofs.Verify(fname)
+	if err != nil {
+		return fmt.Errorf("could not verify file: %w", err)
+	}
+
+	var issues []string
+	for _, dmg := range rep.Damages {
+		issues = append(issues, fmt.Sprintf("record: %v", dmg))
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		fmt.Fprintf(w, "status: could not open file\n")
+		return fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	sinfos := f.StreamerInfos()
+	for _, si := range sinfos {
+		for _, se := range si.Elements() {
+			var name string
+			switch se := se.(type) {
+			case *rdict.StreamerBase:
+				name = se.Name()
+			case *rdict.StreamerObject, *rdict.StreamerObjectAny:
+				name = se.TypeName()
+			default:
+				// pointers to (possibly abstract) classes may be nil.
+				continue
+			}
+			if !described(f, name) {
+				issues = append(issues, fmt.Sprintf(
+					"streamer %q: element %q: no streamer for class %q",
+					si.Name(), se.Name(), name,
+				))
+			}
+		}
+	}
+
+	var (
+		nkeys  int
+		ntrees int
+		top    = stdpath.Join(f.Name(), ".") + "/"
+	)
+	err = riofs.WalkKeys(f, func(path string, key *riofs.Key, err error) error {
+		name := strings.TrimPrefix(path, top)
+		nkeys++
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("key %q: could not load directory: %v", name, err))
+			return nil
+		}
+
+		switch key.ClassName() {
+		case "TDirectory", "TDirectoryFile":
+			return nil
+		case "TTree", "TNtuple", "TNtupleD":
+			// trees are decoded and validated below.
+		default:
+			if !described(f, key.ClassName()) {
+				issues = append(issues, fmt.Sprintf(
+					"key %q (%s;%d): no streamer for class %q",
+					name, key.ClassName(), key.Cycle(), key.ClassName(),
+				))
+			}
+			return nil
+		}
+
+		ntrees++
+		obj, err := validateObject(key)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf(
+				"key %q (%s;%d): could not decode tree: %v",
+				name, key.ClassName(), key.Cycle(), err,
+			))
+			return nil
+		}
+
+		tree, ok := obj.(rtree.Tree)
+		if !ok {
+			issues = append(issues, fmt.Sprintf(
+				"key %q (%s;%d): invalid tree type %T",
+				name, key.ClassName(), key.Cycle(), obj,
+			))
+			return nil
+		}
+		for _, err := range rtree.Validate(tree) {
+			issues = append(issues, fmt.Sprintf("tree %q: %v", name, err))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not walk through file: %w", err)
+	}
+
+	if verbose {
+		fmt.Fprintf(w, "version:   %v\n", rep.Version)
+		fmt.Fprintf(w, "records:   %d\n", rep.Records)
+		fmt.Fprintf(w, "streamers: %d\n", len(sinfos))
+		fmt.Fprintf(w, "keys:      %d\n", nkeys)
+		fmt.Fprintf(w, "trees:     %d\n", ntrees)
+	}
+
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "status: OK\n")
+		return nil
+	}
+
+	fmt.Fprintf(w, "status: %d problem(s)\n", len(issues))
+	for _, issue := range issues {
+		fmt.Fprintf(w, " - %s\n", issue)
+	}
+
+	return fmt.Errorf("file %q has %d problem(s)", fname, len(issues))
+}
+
+// validateObject loads the object attached to the provided key,
+// converting decoding panics into errors.
+func validateObject(key *riofs.Key) (obj interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			obj = nil
+			err = fmt.Errorf("%v", e)
+		}
+	}()
+	return key.Object()
+}
+
+// described returns whether the provided class is described by a streamer
+// of the file or is known to groot.
+func described(f *riofs.File, class string) bool {
+	if class == "string" || rtypes.Factory.HasKey(class) {
+		return true
+	}
+	_, err := f.StreamerInfo(class, -1)
+	return err == nil
+}
@@ -0,0 +1,131 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestValidate(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "damaged.root")
+	f, err := groot.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	var data struct {
+		I32 int32
+		F64 [100]float64
+	}
+	w, err := rtree.NewWriter(f, "tree", rtree.WriteVarsFromStruct(&data))
+	if err != nil {
+		t.Fatalf("could not create tree writer: %+v", err)
+	}
+	for i := 0; i < 10; i++ {
+		data.I32 = int32(i)
+		for j := range data.F64 {
+			data.F64[j] = float64(i)
+		}
+		_, err = w.Write()
+		if err != nil {
+			t.Fatalf("could not write entry %d: %+v", i, err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close tree writer: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+	// damage the payload of the last basket.
+	const hdr = 26 // offset of the class name in a (small) key header
+	pos := bytes.LastIndex(raw, []byte("\x07TBasket")) - hdr
+	if pos < 0 {
+		t.Fatalf("could not find basket")
+	}
+	nbytes := int(binary.BigEndian.Uint32(raw[pos:]))
+	raw[pos+nbytes-1] ^= 0xff
+	err = os.WriteFile(fname, raw, 0644)
+	if err != nil {
+		t.Fatalf("could not write damaged file: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		verbose bool
+		want    string
+		err     bool
+	}{
+		{
+			name:    "../testdata/dirs-6.14.00.root",
+			verbose: true,
+			want: `=== [../testdata/dirs-6.14.00.root] ===
+version:   61400
+records:   13
+streamers: 14
+keys:      5
+trees:     0
+status: OK
+`,
+		},
+		{
+			name:    "../testdata/small-evnt-tree-fullsplit.root",
+			verbose: true,
+			want: `=== [../testdata/small-evnt-tree-fullsplit.root] ===
+version:   60806
+records:   46
+streamers: 19
+keys:      1
+trees:     1
+status: OK
+`,
+		},
+		{
+			name: "../testdata/small-flat-tree.root",
+			want: `=== [../testdata/small-flat-tree.root] ===
+status: OK
+`,
+		},
+		{
+			name: fname,
+			want: `=== [` + fname + `] ===
+status: 1 problem(s)
+ - record: At:` + strconv.Itoa(pos) + ` N=` + strconv.Itoa(nbytes) + ` class="TBasket" name="F64" cycle=1: invalid payload: rcompress: invalid ZLIB block at offset 0: could not verify block: zlib: invalid checksum
+`,
+			err: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(strings.Builder)
+			err := rcmd.Validate(out, tc.name, tc.verbose)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("could not validate file: %+v", err)
+			case err == nil && tc.err:
+				t.Fatalf("expected an error")
+			}
+
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
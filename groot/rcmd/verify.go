@@ -0,0 +1,43 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"fmt"
+	"io"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// Verify checks the integrity of all the records of the named ROOT file
+// and displays the corresponding report into the provided io Writer.
+//
+// Verify returns an error if the file could not be read or if damaged
+// records were found.
+func Verify(w io.Writer, fname string, verbose bool) error {
+	fmt.Fprintf(w, "=== [%s] ===\n", fname)
+	rep, err := riofs.Verify(fname)
+	if err != nil {
+		return fmt.Errorf("could not verify file: %w", err)
+	}
+
+	if verbose {
+		fmt.Fprintf(w, "version: %v\n", rep.Version)
+		fmt.Fprintf(w, "records: %d\n", rep.Records)
+		fmt.Fprintf(w, "gaps:    %d\n", rep.Gaps)
+	}
+
+	if rep.OK() {
+		fmt.Fprintf(w, "status: OK\n")
+		return nil
+	}
+
+	fmt.Fprintf(w, "status: %d damaged record(s)\n", len(rep.Damages))
+	for _, dmg := range rep.Damages {
+		fmt.Fprintf(w, " - %v\n", dmg)
+	}
+
+	return fmt.Errorf("file %q has %d damaged record(s)", fname, len(rep.Damages))
+}
@@ -0,0 +1,97 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rcmd"
+)
+
+func TestVerify(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "damaged.root")
+	f, err := groot.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	err = f.Put("str", rbase.NewObjString(strings.Repeat("hello ", 1000)))
+	if err != nil {
+		t.Fatalf("could not write object: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	f, err = groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	key := f.Keys()[0]
+	_ = f.Close()
+
+	raw, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read file: %+v", err)
+	}
+	raw[key.SeekKey()+int64(key.Nbytes())-1] ^= 0xff
+	err = os.WriteFile(fname, raw, 0644)
+	if err != nil {
+		t.Fatalf("could not write damaged file: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		verbose bool
+		want    string
+		err     bool
+	}{
+		{
+			name:    "../testdata/graphs.root",
+			verbose: true,
+			want: `=== [../testdata/graphs.root] ===
+version: 60806
+records: 7
+gaps:    0
+status: OK
+`,
+		},
+		{
+			name: "../testdata/small-flat-tree.root",
+			want: `=== [../testdata/small-flat-tree.root] ===
+status: OK
+`,
+		},
+		{
+			name: fname,
+			want: `=== [` + fname + `] ===
+status: 1 damaged record(s)
+ - At:` + strconv.Itoa(int(key.SeekKey())) + ` N=` + strconv.Itoa(int(key.Nbytes())) + ` class="TObjString" name="str" cycle=1: invalid payload: rcompress: invalid ZLIB block at offset 0: could not verify block: zlib: invalid checksum
+`,
+			err: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(strings.Builder)
+			err := rcmd.Verify(out, tc.name, tc.verbose)
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("could not verify file: %+v", err)
+			case err == nil && tc.err:
+				t.Fatalf("expected an error")
+			}
+
+			if got, want := out.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	stdpath "path"
+	"time"
+
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// WatchOption controls how Watch behaves.
+type WatchOption func(*watchCmd)
+
+// WatchBranches restricts the display of entries to the branches whose name
+// matches at least one of the provided patterns.
+// Leaves of a branch are named "branch.leaf".
+//
+// Patterns follow the syntax of path.Match.
+func WatchBranches(patterns ...string) WatchOption {
+	return func(cmd *watchCmd) {
+		cmd.branches = append(cmd.branches, patterns...)
+	}
+}
+
+// WatchFrom sets the index of the first entry to display.
+// By default, all the entries of the tree are displayed.
+func WatchFrom(beg int64) WatchOption {
+	return func(cmd *watchCmd) {
+		cmd.beg = beg
+	}
+}
+
+// WatchInterval sets the interval between two inspections of the file.
+// The default is one second.
+func WatchInterval(d time.Duration) WatchOption {
+	return func(cmd *watchCmd) {
+		cmd.every = d
+	}
+}
+
+// WatchIdle stops Watch after the provided duration without new entries.
+// By default, Watch only stops when its context is done.
+func WatchIdle(d time.Duration) WatchOption {
+	return func(cmd *watchCmd) {
+		cmd.idle = d
+	}
+}
+
+// WatchAs sets the format used to display the entries of the tree.
+// Only the DumpText and DumpJSONL formats are supported.
+// The default format is DumpText.
+func WatchAs(format DumpFormat) WatchOption {
+	return func(cmd *watchCmd) {
+		cmd.format = format
+	}
+}
+
+// Watch follows the named tree of the fname ROOT file, while it is being
+// written to by another process (e.g. a data acquisition job regularly
+// saving its tree), and displays the new entries of the tree into the
+// provided io.Writer as they appear.
+//
+// The file is re-opened at each inspection.
+// Failures to open the file or to retrieve the tree are considered
+// transient: they are only reported if no entry could be read before
+// Watch stops.
+//
+// Watch stops when the provided context is done or, if requested, after
+// some time without new entries.
+// Watch's behaviour can be customized with a set of optional WatchOptions.
+func Watch(ctx context.Context, w io.Writer, fname, tname string, opts ...WatchOption) error {
+	cmd := watchCmd{
+		every: time.Second,
+	}
+	for _, opt := range opts {
+		opt(&cmd)
+	}
+
+	switch cmd.format {
+	case DumpText, DumpJSONL:
+		// ok.
+	default:
+		return fmt.Errorf("invalid watch format %d", cmd.format)
+	}
+
+	if cmd.beg < 0 {
+		return fmt.Errorf("invalid first entry %d", cmd.beg)
+	}
+
+	if cmd.every <= 0 {
+		return fmt.Errorf("invalid polling interval %v", cmd.every)
+	}
+
+	for _, pat := range cmd.branches {
+		_, err := stdpath.Match(pat, "")
+		if err != nil {
+			return fmt.Errorf("invalid branch pattern %q: %w", pat, err)
+		}
+	}
+
+	var (
+		dump = dumpCmd{
+			w:        w,
+			deep:     true,
+			branches: cmd.branches,
+			format:   cmd.format,
+		}
+		tail = rtree.NewTail(fname, tname, cmd.beg)
+		tick = time.NewTicker(cmd.every)
+		last = time.Now()
+		read = false
+		ierr error
+	)
+	defer tick.Stop()
+
+	for {
+		n, err := tail.Poll(func(tree rtree.Tree, beg, end int64) error {
+			dump.beg = beg
+			dump.end = end
+			return dump.dumpTree(tree)
+		})
+		switch {
+		case err != nil:
+			ierr = err
+		case n > 0:
+			ierr = nil
+			read = true
+			last = time.Now()
+		}
+
+		if cmd.idle > 0 && time.Since(last) >= cmd.idle {
+			if ierr != nil && !read {
+				return fmt.Errorf("could not watch tree %q: %w", tname, ierr)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tick.C:
+		}
+	}
+}
+
+type watchCmd struct {
+	branches []string // patterns of branches to display
+	beg      int64    // first entry to display
+	every    time.Duration
+	idle     time.Duration
+	format   DumpFormat
+}
@@ -0,0 +1,140 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/groot/rcmd"
+)
+
+func TestWatch(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fname string
+		tname string
+		opts  []rcmd.WatchOption
+		want  string
+	}{
+		{
+			name:  "simple",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			want: `[000][one]: 1
+[000][two]: 1.1
+[000][three]: uno
+[001][one]: 2
+[001][two]: 2.2
+[001][three]: dos
+[002][one]: 3
+[002][two]: 3.3
+[002][three]: tres
+[003][one]: 4
+[003][two]: 4.4
+[003][three]: quatro
+`,
+		},
+		{
+			name:  "simple-jsonl",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			opts: []rcmd.WatchOption{
+				rcmd.WatchFrom(2),
+				rcmd.WatchBranches("one", "three"),
+				rcmd.WatchAs(rcmd.DumpJSONL),
+			},
+			want: `{"entry":2,"one":3,"three":"tres"}
+{"entry":3,"one":4,"three":"quatro"}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]rcmd.WatchOption{
+				rcmd.WatchInterval(10 * time.Millisecond),
+				rcmd.WatchIdle(50 * time.Millisecond),
+			}, tc.opts...)
+			got := new(strings.Builder)
+			err := rcmd.Watch(context.Background(), got, tc.fname, tc.tname, opts...)
+			if err != nil {
+				t.Fatalf("could not watch tree: %+v", err)
+			}
+			if got, want := got.String(), tc.want; got != want {
+				t.Fatalf("invalid root-watch output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
+
+func TestWatchErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fname string
+		tname string
+		opts  []rcmd.WatchOption
+		want  string
+	}{
+		{
+			name:  "invalid-format",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			opts:  []rcmd.WatchOption{rcmd.WatchAs(rcmd.DumpCSV)},
+			want:  "invalid watch format 3",
+		},
+		{
+			name:  "invalid-pattern",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			opts:  []rcmd.WatchOption{rcmd.WatchBranches("[")},
+			want:  `invalid branch pattern "[": syntax error in pattern`,
+		},
+		{
+			name:  "invalid-interval",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			opts:  []rcmd.WatchOption{rcmd.WatchInterval(0)},
+			want:  "invalid polling interval 0s",
+		},
+		{
+			name:  "not-a-tree",
+			fname: "../testdata/dirs-6.14.00.root",
+			tname: "dir1/dir11/h1",
+			want:  `could not watch tree "dir1/dir11/h1": rtree: object "dir1/dir11/h1" in file "../testdata/dirs-6.14.00.root" is not a Tree`,
+		},
+		{
+			name:  "no-tree",
+			fname: "../testdata/simple.root",
+			tname: "not-there",
+			want:  `could not watch tree "not-there": rtree: could not retrieve tree "not-there": riofs: simple.root: could not find key "not-there;9999"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]rcmd.WatchOption{
+				rcmd.WatchInterval(10 * time.Millisecond),
+				rcmd.WatchIdle(30 * time.Millisecond),
+			}, tc.opts...)
+			err := rcmd.Watch(context.Background(), io.Discard, tc.fname, tc.tname, opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.want; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
+
+func TestWatchCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := rcmd.Watch(ctx, io.Discard, "../testdata/not-there.root", "tree", rcmd.WatchInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("could not watch tree: %+v", err)
+	}
+}
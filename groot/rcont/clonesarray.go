@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rmeta"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
@@ -82,10 +83,13 @@ func (arr *ClonesArray) TestBits(bits uint32) bool {
 	return arr.arr.TestBits(bits)
 }
 
+// BypassStreamer configures whether the elements of the TClonesArray
+// should be streamed member-wise (bypass=true) or object-wise.
 func (arr *ClonesArray) BypassStreamer(bypass bool) {
 	switch bypass {
 	case true:
 		arr.arr.obj.SetBit(rbytes.BypassStreamer)
+		arr.arr.obj.ResetBit(rbytes.CannotHandleMemberWiseStreaming)
 	default:
 		arr.arr.obj.ResetBit(rbytes.BypassStreamer)
 	}
@@ -127,7 +131,11 @@ func (arr *ClonesArray) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 
 	switch {
 	case arr.CanBypassStreamer():
-		panic("rcont: writing TClonesArray with streamer by-pass not implemented")
+		err := arr.writeMemberWise(w, si)
+		if err != nil {
+			w.SetErr(fmt.Errorf("rcont: could not marshal TClonesArray elements member-wise: %w", err))
+			return 0, w.Err()
+		}
 	default:
 		for i, obj := range arr.arr.objs {
 			switch obj {
@@ -194,11 +202,13 @@ func (arr *ClonesArray) UnmarshalROOT(r *rbytes.RBuffer) error {
 
 	switch {
 	case arr.TestBits(rbytes.BypassStreamer) && !arr.TestBits(rbytes.CannotHandleMemberWiseStreaming):
-		for i := range arr.arr.objs {
-			obj := fct().Interface().(root.Object)
-			arr.arr.objs[i] = obj
+		err := arr.readMemberWise(r, si, fct)
+		if err != nil {
+			if r.Err() == nil {
+				r.SetErr(fmt.Errorf("rcont: could not unmarshal TClonesArray elements member-wise: %w", err))
+			}
+			return r.Err()
 		}
-		panic("rcont: TClonesArray with BypassStreamer not supported")
 	default:
 		for i := range arr.arr.objs {
 			nch := r.ReadI8()
@@ -217,6 +227,282 @@ func (arr *ClonesArray) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// writeMemberWise writes all the elements of the array member-wise:
+// the first data member of all elements, then the second data member
+// of all elements, etc...
+//
+// elements are first marshaled object-wise and then transposed.
+func (arr *ClonesArray) writeMemberWise(w *rbytes.WBuffer, si rbytes.StreamerInfo) error {
+	objs := make([][]mbrChunk, len(arr.arr.objs))
+	for i, obj := range arr.arr.objs {
+		if obj == nil {
+			return fmt.Errorf("nil element [%d/%d]", i+1, len(arr.arr.objs))
+		}
+		wbuf := rbytes.NewWBuffer(nil, nil, 0, w)
+		_, err := obj.(rbytes.Marshaler).MarshalROOT(wbuf)
+		if err != nil {
+			return fmt.Errorf("could not marshal element [%d/%d] (%T): %w", i+1, len(arr.arr.objs), obj, err)
+		}
+
+		rbuf := rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, w)
+		hdr := rbuf.ReadHeader(si.Name(), -1)
+		objs[i], err = readObjectWise(rbuf, si)
+		if err != nil {
+			return fmt.Errorf("could not split element [%d/%d] (%T): %w", i+1, len(arr.arr.objs), obj, err)
+		}
+		rbuf.CheckHeader(hdr)
+		if err := rbuf.Err(); err != nil {
+			return fmt.Errorf("could not split element [%d/%d] (%T): %w", i+1, len(arr.arr.objs), obj, err)
+		}
+	}
+
+	writeMemberWise(w, objs)
+	return w.Err()
+}
+
+// readMemberWise reads all the elements of the array, streamed member-wise.
+//
+// elements are first transposed back into their object-wise
+// representation and then unmarshaled.
+func (arr *ClonesArray) readMemberWise(r *rbytes.RBuffer, si rbytes.StreamerInfo, fct rtypes.FactoryFct) error {
+	objs, err := readMemberWise(r, si, len(arr.arr.objs))
+	if err != nil {
+		return err
+	}
+
+	for i := range arr.arr.objs {
+		wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+		hdr := wbuf.WriteHeader(si.Name(), int16(si.ClassVersion()))
+		writeObjectWise(wbuf, objs[i])
+		_, err := wbuf.SetHeader(hdr)
+		if err != nil {
+			return fmt.Errorf("could not rebuild element [%d/%d]: %w", i+1, len(objs), err)
+		}
+
+		obj := fct().Interface().(root.Object)
+		rbuf := rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, r)
+		rbuf.ReadObject(obj.(rbytes.Unmarshaler))
+		if err := rbuf.Err(); err != nil {
+			return fmt.Errorf("could not unmarshal element [%d/%d] (%T): %w", i+1, len(objs), obj, err)
+		}
+		arr.arr.objs[i] = obj
+	}
+
+	return nil
+}
+
+// mbrChunk holds the object-wise encoding of a data member of
+// a TClonesArray element.
+type mbrChunk struct {
+	raw []byte // encoding of a data member
+
+	// base class data members.
+	base string
+	vers int16
+	subs []mbrChunk
+}
+
+// isMbrBase returns whether the streamer element is a base class that is
+// itself streamed member-wise.
+// TObject and TNamed bases are always streamed with their own streamer.
+func isMbrBase(se rbytes.StreamerElement) bool {
+	return se.Type() == rmeta.Base
+}
+
+func baseVersionOf(se rbytes.StreamerElement) int {
+	if se, ok := se.(interface{ Base() int }); ok {
+		return se.Base()
+	}
+	return -1
+}
+
+func readObjectWise(r *rbytes.RBuffer, si rbytes.StreamerInfo) ([]mbrChunk, error) {
+	chunks := make([]mbrChunk, 0, len(si.Elements()))
+	for _, se := range si.Elements() {
+		if !isMbrBase(se) {
+			raw, err := readMbrElem(r, se)
+			if err != nil {
+				return nil, err
+			}
+			chunks = append(chunks, mbrChunk{raw: raw})
+			continue
+		}
+
+		hdr := r.ReadHeader(se.Name(), -1)
+		bsi, err := r.StreamerInfo(se.Name(), int(hdr.Vers))
+		if err != nil {
+			return nil, fmt.Errorf("could not find streamer for base %q: %w", se.Name(), err)
+		}
+		subs, err := readObjectWise(r, bsi)
+		if err != nil {
+			return nil, err
+		}
+		r.CheckHeader(hdr)
+		chunks = append(chunks, mbrChunk{base: se.Name(), vers: hdr.Vers, subs: subs})
+	}
+	return chunks, r.Err()
+}
+
+func readMemberWise(r *rbytes.RBuffer, si rbytes.StreamerInfo, n int) ([][]mbrChunk, error) {
+	objs := make([][]mbrChunk, n)
+	for _, se := range si.Elements() {
+		if !isMbrBase(se) {
+			for i := range objs {
+				raw, err := readMbrElem(r, se)
+				if err != nil {
+					return nil, err
+				}
+				objs[i] = append(objs[i], mbrChunk{raw: raw})
+			}
+			continue
+		}
+
+		bsi, err := r.StreamerInfo(se.Name(), baseVersionOf(se))
+		if err != nil {
+			return nil, fmt.Errorf("could not find streamer for base %q: %w", se.Name(), err)
+		}
+		subs, err := readMemberWise(r, bsi, n)
+		if err != nil {
+			return nil, err
+		}
+		for i := range objs {
+			objs[i] = append(objs[i], mbrChunk{
+				base: se.Name(),
+				vers: int16(bsi.ClassVersion()),
+				subs: subs[i],
+			})
+		}
+	}
+	return objs, r.Err()
+}
+
+func writeObjectWise(w *rbytes.WBuffer, chunks []mbrChunk) {
+	for _, c := range chunks {
+		if c.base == "" {
+			_, _ = w.Write(c.raw)
+			continue
+		}
+		hdr := w.WriteHeader(c.base, c.vers)
+		writeObjectWise(w, c.subs)
+		_, _ = w.SetHeader(hdr)
+	}
+}
+
+func writeMemberWise(w *rbytes.WBuffer, objs [][]mbrChunk) {
+	if len(objs) == 0 {
+		return
+	}
+	for j, c := range objs[0] {
+		if c.base == "" {
+			for i := range objs {
+				_, _ = w.Write(objs[i][j].raw)
+			}
+			continue
+		}
+		subs := make([][]mbrChunk, len(objs))
+		for i := range objs {
+			subs[i] = objs[i][j].subs
+		}
+		writeMemberWise(w, subs)
+	}
+}
+
+// readMbrElem returns the raw encoding of the provided streamer element.
+func readMbrElem(r *rbytes.RBuffer, se rbytes.StreamerElement) ([]byte, error) {
+	beg := r.Pos()
+	err := skipMbrElem(r, se)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	end := r.Pos()
+
+	raw := make([]byte, end-beg)
+	r.SetPos(beg)
+	r.ReadArrayU8(raw)
+	return raw, r.Err()
+}
+
+const (
+	byteCountMask = 0x40000000
+	newClassTag   = 0xFFFFFFFF
+)
+
+// tarraySizes holds the size of the elements of the TArrayX types.
+var tarraySizes = map[string]int64{
+	"TArrayC":   1,
+	"TArrayS":   2,
+	"TArrayI":   4,
+	"TArrayF":   4,
+	"TArrayL":   8,
+	"TArrayL64": 8,
+	"TArrayD":   8,
+}
+
+func skipMbrElem(r *rbytes.RBuffer, se rbytes.StreamerElement) error {
+	var (
+		typ = se.Type()
+		n   = 1
+	)
+	if rmeta.OffsetL < typ && typ < rmeta.OffsetP {
+		typ -= rmeta.OffsetL
+		n = se.ArrayLen()
+	}
+
+	for i := 0; i < n; i++ {
+		switch typ {
+		case rmeta.Char, rmeta.UChar, rmeta.Bool:
+			r.ReadU8()
+		case rmeta.Short, rmeta.UShort:
+			r.ReadU16()
+		case rmeta.Int, rmeta.UInt, rmeta.Float, rmeta.Counter, rmeta.Bits:
+			r.ReadU32()
+		case rmeta.Long, rmeta.ULong, rmeta.Long64, rmeta.ULong64, rmeta.Double:
+			r.ReadU64()
+		case rmeta.Float16:
+			r.ReadF16(se)
+		case rmeta.Double32:
+			r.ReadD32(se)
+		case rmeta.TString, rmeta.STLstring:
+			r.ReadString()
+		case rmeta.TObject:
+			r.SkipObject()
+		case rmeta.TNamed, rmeta.Object, rmeta.Any, rmeta.Objectp, rmeta.Anyp,
+			rmeta.STL, rmeta.Streamer:
+			if sz, ok := tarraySizes[se.TypeName()]; ok {
+				// TArrayX are streamed without byte count.
+				n := int64(r.ReadI32())
+				r.SetPos(r.Pos() + n*sz)
+				continue
+			}
+			hdr := r.ReadHeader(se.TypeName(), -1)
+			if hdr.Len <= 0 {
+				return fmt.Errorf("no byte count for element %q (type=%q)", se.Name(), se.TypeName())
+			}
+			r.SetPos(hdr.Pos + int64(hdr.Len) + 4)
+		case rmeta.ObjectP, rmeta.AnyP:
+			// references to already streamed objects or classes are
+			// position dependent and can not be relocated.
+			beg := r.Pos()
+			bcnt := r.ReadU32()
+			if bcnt == 0 {
+				// nil pointer.
+				continue
+			}
+			tag := r.ReadU32()
+			if bcnt&byteCountMask == 0 || tag != newClassTag {
+				return fmt.Errorf("unsupported member-wise reference for element %q (type=%q)", se.Name(), se.TypeName())
+			}
+			r.SetPos(beg + int64(bcnt&^byteCountMask) + 4)
+		default:
+			return fmt.Errorf("unsupported member-wise element %q (type=%q, kind=%v)", se.Name(), se.TypeName(), se.Type())
+		}
+	}
+	return nil
+}
+
 func init() {
 	f := func() reflect.Value {
 		o := NewClonesArray()
@@ -5,6 +5,7 @@
 package rcont_test
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -17,6 +18,9 @@ import (
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/rdict"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 )
@@ -24,7 +28,7 @@ import (
 func TestTClonesArray(t *testing.T) {
 	for _, fname := range []string{
 		"../testdata/tclonesarray-no-streamerbypass.root",
-		// "../testdata/tclonesarray-with-streamerbypass.root", // FIXME(sbinet): needs member-wise streaming.
+		"../testdata/tclonesarray-with-streamerbypass.root",
 	} {
 		t.Run(fname, func(t *testing.T) {
 			f, err := groot.Open(fname)
@@ -60,6 +64,48 @@ func TestTClonesArray(t *testing.T) {
 	}
 }
 
+func TestTClonesArrayMemberWise(t *testing.T) {
+	f, err := riofs.Open("../testdata/tclonesarray-with-streamerbypass.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	key := f.Keys()[0]
+	want, err := key.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := key.Object()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tca := o.(*rcont.ClonesArray)
+	if !tca.CanBypassStreamer() {
+		t.Fatalf("expected a member-wise TClonesArray")
+	}
+
+	for _, tc := range []struct {
+		name string
+		ctx  rbytes.StreamerInfoContext
+	}{
+		{"file", f},
+		{"groot", rdict.StreamerInfos},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			wbuf := rbytes.NewWBuffer(nil, nil, 0, tc.ctx)
+			_, err := tca.MarshalROOT(wbuf)
+			if err != nil {
+				t.Fatalf("could not marshal TClonesArray: %+v", err)
+			}
+			if got := wbuf.Bytes(); !bytes.Equal(got, want) {
+				t.Fatalf("invalid member-wise encoding:\ngot= %x\nwant=%x", got, want)
+			}
+		})
+	}
+}
+
 func TestTClonesArrayRW(t *testing.T) {
 	dir, err := os.MkdirTemp("", "groot-")
 	if err != nil {
@@ -83,21 +129,37 @@ func TestTClonesArrayRW(t *testing.T) {
 				})
 				return o
 			}(),
+			cmp: cmpClonesArray,
+		},
+		{
+			name: "TClonesArray-member-wise",
+			want: func() *rcont.ClonesArray {
+				o := rcont.NewClonesArray()
+				o.BypassStreamer(true)
+				o.SetElems([]root.Object{
+					rbase.NewObjString("Elem-0"),
+					rbase.NewObjString("elem-1"),
+					rbase.NewObjString("Elem-20"),
+				})
+				return o
+			}(),
 			cmp: func(got, want *rcont.ClonesArray) bool {
-				if g, w := got.Len(), want.Len(); g != w {
-					return false
-				}
-				if g, w := got.Last(), want.Last(); g != w {
-					return false
-				}
-				for i := 0; i < got.Len(); i++ {
-					if g, w := got.At(i), want.At(i); !reflect.DeepEqual(g, w) {
-						return false
-					}
-				}
-				return true
+				return got.CanBypassStreamer() && cmpClonesArray(got, want)
 			},
 		},
+		{
+			name: "TClonesArray-member-wise-with-base",
+			want: func() *rcont.ClonesArray {
+				o := rcont.NewClonesArray()
+				o.BypassStreamer(true)
+				o.SetElems([]root.Object{
+					rhist.NewAxis("xaxis"),
+					rhist.NewAxis("yaxis"),
+				})
+				return o
+			}(),
+			cmp: cmpClonesArray,
+		},
 	} {
 		fname := filepath.Join(dir, fmt.Sprintf("tclonesarray-%d.root", i))
 		t.Run(tc.name, func(t *testing.T) {
@@ -203,3 +265,18 @@ void rootls(const char *fname, const char *kname) {
 		})
 	}
 }
+
+func cmpClonesArray(got, want *rcont.ClonesArray) bool {
+	if g, w := got.Len(), want.Len(); g != w {
+		return false
+	}
+	if g, w := got.Last(), want.Last(); g != w {
+		return false
+	}
+	for i := 0; i < got.Len(); i++ {
+		if g, w := got.At(i), want.At(i); !reflect.DeepEqual(g, w) {
+			return false
+		}
+	}
+	return true
+}
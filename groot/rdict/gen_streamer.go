@@ -11,6 +11,7 @@ import (
 	"go/types"
 	"log"
 	"reflect"
+	"strings"
 
 	"go-hep.org/x/hep/groot/rmeta"
 	"golang.org/x/tools/go/packages"
@@ -27,6 +28,9 @@ type genStreamer struct {
 
 	verbose bool // enable verbose mode
 
+	done  map[string]bool // set of types already generated
+	depth int             // nesting level of the containers being generated
+
 	binMa *types.Interface // encoding.BinaryMarshaler
 	binUn *types.Interface // encoding.BinaryUnmarshaler
 	rvers *types.Interface // rbytes.RVersioner
@@ -60,6 +64,7 @@ func NewGenStreamer(p string, verbose bool) (Generator, error) {
 			"go-hep.org/x/hep/groot/rmeta":  1,
 		},
 		verbose: verbose,
+		done:    make(map[string]bool),
 	}
 
 	err = g.init()
@@ -109,7 +114,14 @@ func (g *genStreamer) printf(format string, args ...interface{}) {
 }
 
 // Generate implements rdict.Generator
+//
+// Generate also generates the streamers of the user types, defined in the
+// same package, that the named type depends on.
 func (g *genStreamer) Generate(typeName string) error {
+	if g.done[typeName] {
+		return nil
+	}
+
 	scope := g.pkg.Scope()
 	obj := scope.Lookup(typeName)
 	if obj == nil {
@@ -128,18 +140,85 @@ func (g *genStreamer) Generate(typeName string) error {
 	if g.verbose {
 		log.Printf("typ: %q: %+v\n", typeName, typ)
 	}
+	g.done[typeName] = true
 
-	if !types.Implements(tn.Type(), g.rvers) && !types.Implements(types.NewPointer(tn.Type()), g.rvers) {
+	// methods promoted from embedded fields describe the embedded type,
+	// not the type at hand.
+	if !hasMethod(tn, "RVersion") {
 		g.genRVersioner(typ, typeName)
 	}
+	if !hasMethod(tn, "Class") {
+		g.genClass(typ, typeName)
+	}
 
 	g.genStreamer(typ, typeName)
 	g.genMarshal(typ, typeName)
-	// g.genUnmarshal(typ, typeName)
+	g.genUnmarshal(typ, typeName)
+
+	for _, dep := range g.deps(typ) {
+		err := g.Generate(dep)
+		if err != nil {
+			return fmt.Errorf("could not generate streamer for %q (needed by %q): %w", dep, typeName, err)
+		}
+	}
 
 	return nil
 }
 
+// deps returns the names of the user types, defined in the package being
+// processed, that the provided struct depends on.
+func (g *genStreamer) deps(typ *types.Struct) []string {
+	var (
+		deps  []string
+		seen  = make(map[string]bool)
+		visit func(t types.Type)
+	)
+	visit = func(t types.Type) {
+		switch t := t.(type) {
+		case *types.Named:
+			if t.Obj().Pkg() != g.pkg {
+				return
+			}
+			if _, ok := t.Underlying().(*types.Struct); !ok {
+				visit(t.Underlying())
+				return
+			}
+			name := t.Obj().Name()
+			if seen[name] {
+				return
+			}
+			seen[name] = true
+			deps = append(deps, name)
+		case *types.Array:
+			visit(t.Elem())
+		case *types.Slice:
+			visit(t.Elem())
+		case *types.Map:
+			visit(t.Key())
+			visit(t.Elem())
+		}
+	}
+
+	for i := 0; i < typ.NumFields(); i++ {
+		visit(typ.Field(i).Type())
+	}
+	return deps
+}
+
+// hasMethod returns whether the named type declares the provided method.
+func hasMethod(tn *types.TypeName, name string) bool {
+	typ, ok := tn.Type().(*types.Named)
+	if !ok {
+		return false
+	}
+	for i := 0; i < typ.NumMethods(); i++ {
+		if typ.Method(i).Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *genStreamer) genMarshal(t types.Type, typeName string) {
 	g.printf(`// MarshalROOT implements rbytes.Marshaler
 func (o *%[1]s) MarshalROOT(w *rbytes.WBuffer) (int, error) {
@@ -157,30 +236,51 @@ func (o *%[1]s) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	for i := 0; i < typ.NumFields(); i++ {
 		ft := typ.Field(i)
 		n := ft.Name() // no `groot:"foo"` redirection.
-		g.genMarshalType(ft.Type(), n)
+		if ft.Embedded() {
+			g.printf("w.WriteObject(&o.%s)\n", n)
+			continue
+		}
+		g.genMarshalType(ft.Type(), "o."+n)
 	}
 
 	g.printf("\n\treturn w.SetHeader(hdr)\n}\n\n")
 }
 
-// func (g *genStreamer) genUnmarshal(t types.Type, typeName string) {
-// 	g.printf(`// UnmarshalROOT implements rbytes.Unmarshaler
-// func (o *%[1]s) UnmarshalROOT(r *rbytes.RBuffer) error {
-// 	rs, err := rdict.RStreamer(r, o)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	return rs.RStreamROOT(r)
-// }
-// `,
-// 		typeName,
-// 	)
-// }
+func (g *genStreamer) genUnmarshal(t types.Type, typeName string) {
+	g.printf(`// UnmarshalROOT implements rbytes.Unmarshaler
+func (o *%[1]s) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(o.Class(), o.RVersion())
+
+`,
+		typeName,
+	)
+
+	typ := t.Underlying().(*types.Struct)
+	for i := 0; i < typ.NumFields(); i++ {
+		ft := typ.Field(i)
+		n := ft.Name() // no `groot:"foo"` redirection.
+		if ft.Embedded() {
+			g.printf("r.ReadObject(&o.%s)\n", n)
+			continue
+		}
+		g.genUnmarshalType(ft.Type(), "o."+n)
+	}
+
+	g.printf("\n\tr.CheckHeader(hdr)\n\treturn r.Err()\n}\n\n")
+}
 
 func (g *genStreamer) genRVersioner(t types.Type, typeName string) {
 	g.printf("func (*%s) RVersion() int16 { return 1 }\n\n", typeName)
 }
 
+func (g *genStreamer) genClass(t types.Type, typeName string) {
+	g.printf("func (*%s) Class() string { return %q }\n\n", typeName, g.pkg.Path()+"."+typeName)
+}
+
 func (g *genStreamer) genStreamer(t types.Type, typeName string) {
 	g.printf(`func init() {
 	// Streamer for %[1]s.
@@ -200,6 +300,10 @@ func (g *genStreamer) genStreamer(t types.Type, typeName string) {
 				n = nn
 			}
 		}
+		if ft.Embedded() {
+			g.genStreamerBase(ft.Type())
+			continue
+		}
 		g.genStreamerType(ft.Type(), n)
 	}
 
@@ -262,15 +366,27 @@ func (g *genStreamer) genStreamerType(t types.Type, n string) {
 					g.se(ut.Elem(), n, "+ rmeta.OffsetL", ut.Len()),
 				)
 			}
-		default:
+		case *types.Struct:
+			g.imps["go-hep.org/x/hep/groot/rbase"]++
 			g.printf(
-				"%s\n",
-				g.se(ut.Elem(), n, "+ rmeta.OffsetL", ut.Len()),
+				"&rdict.StreamerObjectAny{StreamerElement:rdict.Element{\nName: *rbase.NewNamed(%[1]q, %[2]q),\nType: rmeta.Any + rmeta.OffsetL,\nSize: %[4]d,\nEName:rdict.GoName2Cxx(%[3]q),\nArrLen:%[5]d,\nArrDim:1,\nMaxIdx:[5]int32{%[5]d, 0, 0, 0, 0},\n}.New()},\n",
+				n, "",
+				ut.Elem().String(), g.gosizes.Sizeof(ut),
+				ut.Len(),
 			)
+		default:
+			log.Fatalf("unhandled array type: %v (underlying: %v)\n", t, ut)
 		}
 	case *types.Slice:
-		// FIXME(sbinet): collect+visit element type.
-		g.printf("rdict.NewStreamerSTL(%q, rmeta.STLvector, rmeta.%v),\n", n, gotype2RMeta(ut.Elem()))
+		switch ut.Elem().Underlying().(type) {
+		case *types.Basic:
+			g.printf("rdict.NewStreamerSTL(%q, rmeta.STLvector, rmeta.%v),\n", n, gotype2RMeta(ut.Elem()))
+		default:
+			g.genStreamerSTL(t, n, "rmeta.STLvector")
+		}
+
+	case *types.Map:
+		g.genStreamerSTL(t, n, "rmeta.STLmap")
 
 	case *types.Struct:
 		g.imps["go-hep.org/x/hep/groot/rbase"]++
@@ -285,57 +401,28 @@ func (g *genStreamer) genStreamerType(t types.Type, n string) {
 	}
 }
 
-func (g *genStreamer) wt(t types.Type, n, meth, arr string) {
-	ut := t.Underlying()
-	switch ut := ut.(type) {
-	case *types.Basic:
-		switch kind := ut.Kind(); kind {
-		case types.Bool:
-			g.printf("w.Write%sBool(o.%s%s)\n", meth, n, arr)
-		case types.Uint8:
-			g.printf("w.Write%sU8(o.%s%s)\n", meth, n, arr)
-		case types.Uint16:
-			g.printf("w.Write%sU16(o.%s%s)\n", meth, n, arr)
-		case types.Uint32:
-			g.printf("w.Write%sU32(o.%s%s)\n", meth, n, arr)
-		case types.Uint64:
-			g.printf("w.Write%sU64(o.%s%s)\n", meth, n, arr)
-		case types.Int8:
-			g.printf("w.Write%sI8(o.%s%s)\n", meth, n, arr)
-		case types.Int16:
-			g.printf("w.Write%sI16(o.%s%s)\n", meth, n, arr)
-		case types.Int32:
-			g.printf("w.Write%sI32(o.%s%s)\n", meth, n, arr)
-		case types.Int64:
-			g.printf("w.Write%sI64(o.%s%s)\n", meth, n, arr)
-		case types.Float32:
-			g.printf("w.Write%sF32(o.%s%s)\n", meth, n, arr)
-		case types.Float64:
-			g.printf("w.Write%sF64(o.%s%s)\n", meth, n, arr)
-
-		case types.Uint:
-			g.printf("w.Write%sU64(uint64(o.%s%s))\n", meth, n, arr)
-		case types.Int:
-			g.printf("w.Write%sI64(int64(o.%s%s))\n", meth, n, arr)
-
-		case types.Complex64:
-			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
-		case types.Complex128:
-			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
-
-		case types.String:
-			g.printf("w.Write%sString(o.%s%s)\n", meth, n, arr)
-
-		default:
-			log.Fatalf("unhandled type: %v (underlying: %v)\n", t, ut)
-		}
-
-	case *types.Struct:
-		g.printf("w.WriteObject(&o.%s)\n", n)
+// genStreamerSTL generates the streamer element for a data member n,
+// stored as a STL container of type vtype.
+func (g *genStreamer) genStreamerSTL(t types.Type, n, vtype string) {
+	g.imps["go-hep.org/x/hep/groot/rbase"]++
+	g.printf(
+		"rdict.NewCxxStreamerSTL(rdict.Element{\nName: *rbase.NewNamed(%[1]q, %[2]q),\nType: rmeta.Streamer,\nSize: %[4]d,\nEName: %[3]q,\n}.New(), %[5]s, rmeta.Any),\n",
+		n, "",
+		g.cxxName(t), g.gosizes.Sizeof(t.Underlying()),
+		vtype,
+	)
+}
 
-	default:
-		log.Fatalf("unhandled marshal type: %v (underlying %v)", t, ut)
+// genStreamerBase generates the streamer element for an embedded type.
+func (g *genStreamer) genStreamerBase(t types.Type) {
+	if _, ok := t.Underlying().(*types.Struct); !ok {
+		log.Fatalf("unhandled embedded type: %v (underlying: %v)\n", t, t.Underlying())
 	}
+	g.imps["go-hep.org/x/hep/groot/rbase"]++
+	g.printf(
+		"rdict.NewStreamerBase(rdict.Element{\nName: *rbase.NewNamed(rdict.GoName2Cxx(((*%[1]s)(nil)).Class()), %[2]q),\nType: rmeta.Base,\nEName: %[3]q,\n}.New(), int32(((*%[1]s)(nil)).RVersion())),\n",
+		g.typeName(t), "", "BASE",
+	)
 }
 
 func (g *genStreamer) se(t types.Type, n, rtype string, arrlen int64) string {
@@ -471,7 +558,7 @@ func (g *genStreamer) se(t types.Type, n, rtype string, arrlen int64) string {
 			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
 
 		case types.String:
-			return fmt.Sprintf("&rdict.StreamerString{rdict.Element{\nName: *rbase.NewNamed(%[1]q, %[2]q),\nType: rmeta.TString %[4]s,\nSize: %[5]d,\nEName:%[3]q,\nArrLen:%[6]d,\nArrDim:%[7]d,\nMaxIdx:%#[8]v,\n}.New()}",
+			return fmt.Sprintf("&rdict.StreamerString{StreamerElement: rdict.Element{\nName: *rbase.NewNamed(%[1]q, %[2]q),\nType: rmeta.TString %[4]s,\nSize: %[5]d,\nEName:%[3]q,\nArrLen:%[6]d,\nArrDim:%[7]d,\nMaxIdx:%#[8]v,\n}.New()}",
 				n, "",
 				"TString",
 				rtype,
@@ -496,70 +583,368 @@ func (g *genStreamer) se(t types.Type, n, rtype string, arrlen int64) string {
 	return ""
 }
 
-func (g *genStreamer) genMarshalType(t types.Type, n string) {
-	ut := t.Underlying()
-	switch ut := ut.(type) {
+// genMarshalType generates the code marshaling the data member x of type t.
+func (g *genStreamer) genMarshalType(t types.Type, x string) {
+	switch ut := t.Underlying().(type) {
+	case *types.Slice:
+		g.imps["go-hep.org/x/hep/groot/rvers"]++
+		g.printf("{\nhdr := w.WriteHeader(%q, rvers.StreamerInfo)\n", g.cxxName(t))
+		g.genMarshalSlice(ut, x)
+		g.printf("w.SetHeader(hdr)\n}\n")
+	default:
+		g.genMarshalElem(t, x)
+	}
+}
+
+// genMarshalElem generates the code marshaling the value x of type t,
+// as an element of a data member.
+// Contrary to data members, slices elements have no header.
+func (g *genStreamer) genMarshalElem(t types.Type, x string) {
+	switch ut := t.Underlying().(type) {
 	case *types.Basic:
-		switch kind := ut.Kind(); kind {
-		case types.Bool:
-			g.wt(ut, n, "", "")
-		case types.Uint8:
-			g.wt(ut, n, "", "")
-		case types.Uint16:
-			g.wt(ut, n, "", "")
-		case types.Uint32:
-			g.wt(ut, n, "", "")
-		case types.Uint64:
-			g.wt(ut, n, "", "")
-		case types.Int8:
-			g.wt(ut, n, "", "")
-		case types.Int16:
-			g.wt(ut, n, "", "")
-		case types.Int32:
-			g.wt(ut, n, "", "")
-		case types.Int64:
-			g.wt(ut, n, "", "")
-		case types.Float32:
-			g.wt(ut, n, "", "")
-		case types.Float64:
-			g.wt(ut, n, "", "")
+		meth, wire := basicMeth(t)
+		switch {
+		case wire != "":
+			g.printf("w.Write%s(%s(%s))\n", meth, wire, x)
+		default:
+			g.printf("w.Write%s(%s)\n", meth, x)
+		}
 
-		case types.Uint:
-			g.wt(ut, n, "", "")
-		case types.Int:
-			g.wt(ut, n, "", "")
+	case *types.Array:
+		if meth, ok := bulkMeth(ut.Elem()); ok {
+			g.printf("w.WriteArray%s(%s[:])\n", meth, x)
+			return
+		}
+		i := g.vname("i")
+		g.depth++
+		g.printf("for %s := range %s {\n", i, x)
+		g.genMarshalElem(ut.Elem(), x+"["+i+"]")
+		g.printf("}\n")
+		g.depth--
 
-		case types.Complex64:
-			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
-		case types.Complex128:
-			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
+	case *types.Slice:
+		g.genMarshalSlice(ut, x)
 
-		case types.String:
-			g.wt(ut, n, "", "")
+	case *types.Map:
+		g.genMarshalMap(t, ut, x)
+
+	case *types.Struct:
+		g.printf("w.WriteObject(&%s)\n", x)
+
+	default:
+		log.Fatalf("gen-marshal-type: unhandled type: %v (underlying: %v)\n", t, ut)
+	}
+}
+
+func (g *genStreamer) genMarshalSlice(t *types.Slice, x string) {
+	g.printf("w.WriteI32(int32(len(%s)))\n", x)
+	if meth, ok := bulkMeth(t.Elem()); ok {
+		g.printf("w.WriteArray%s(%s)\n", meth, x)
+		return
+	}
+	i := g.vname("i")
+	g.depth++
+	g.printf("for %s := range %s {\n", i, x)
+	g.genMarshalElem(t.Elem(), x+"["+i+"]")
+	g.printf("}\n")
+	g.depth--
+}
+
+// genMarshalMap generates the code marshaling the map x, as a std::map.
+// Keys are sorted to get a reproducible output.
+func (g *genStreamer) genMarshalMap(t types.Type, ut *types.Map, x string) {
+	var (
+		keys = g.vname("keys")
+		k    = g.vname("k")
+		v    = g.vname("v")
+	)
+	g.imps["go-hep.org/x/hep/groot/rvers"]++
+	g.imps["slices"]++
+	g.printf("{\nhdr := w.WriteHeader(%q, rvers.StreamerInfo)\n", g.cxxName(t))
+	g.printf("w.WriteI32(int32(len(%s)))\n", x)
+	g.printf("if len(%s) > 0 {\n", x)
+	g.printf("%s := make([]%s, 0, len(%s))\n", keys, g.typeName(ut.Key()), x)
+	g.printf("for %[1]s := range %[2]s {\n%[3]s = append(%[3]s, %[1]s)\n}\n", k, x, keys)
+	g.printf("slices.Sort(%s)\n", keys)
+
+	g.depth++
+	g.genBlockBeg(ut.Key(), "w")
+	g.printf("for _, %s := range %s {\n", k, keys)
+	g.genMarshalElem(ut.Key(), k)
+	g.printf("}\n")
+	g.genBlockEnd(ut.Key(), "w")
+	g.genBlockBeg(ut.Elem(), "w")
+	g.printf("for _, %s := range %s {\n%s := %s[%s]\n", k, keys, v, x, k)
+	g.genMarshalElem(ut.Elem(), v)
+	g.printf("}\n")
+	g.genBlockEnd(ut.Elem(), "w")
+	g.depth--
+
+	g.printf("}\nw.SetHeader(hdr)\n}\n")
+}
+
+// genUnmarshalType generates the code unmarshaling the data member x of type t.
+func (g *genStreamer) genUnmarshalType(t types.Type, x string) {
+	switch ut := t.Underlying().(type) {
+	case *types.Slice:
+		g.imps["go-hep.org/x/hep/groot/rvers"]++
+		g.printf("{\nhdr := r.ReadHeader(%q, rvers.StreamerInfo)\n", g.cxxName(t))
+		g.genUnmarshalSlice(t, ut, x)
+		g.printf("r.CheckHeader(hdr)\n}\n")
+	default:
+		g.genUnmarshalElem(t, x)
+	}
+}
 
+// genUnmarshalElem generates the code unmarshaling the value x of type t,
+// as an element of a data member.
+func (g *genStreamer) genUnmarshalElem(t types.Type, x string) {
+	switch ut := t.Underlying().(type) {
+	case *types.Basic:
+		meth, wire := basicMeth(t)
+		switch {
+		case wire != "":
+			g.printf("%s = %s(r.Read%s())\n", x, g.typeName(t), meth)
 		default:
-			log.Fatalf("unhandled type: %v (underlying: %v)\n", t, ut)
+			g.printf("%s = r.Read%s()\n", x, meth)
 		}
 
 	case *types.Array:
-		switch ut.Elem().Underlying().(type) {
-		case *types.Basic:
-			g.wt(ut.Elem(), n, "Array", "[:]")
-		default:
-			g.printf("for i := range o.%s {\n", n)
-			g.wt(ut.Elem(), n+"[i]", "", "")
-			g.printf("}\n")
+		if meth, ok := bulkMeth(ut.Elem()); ok {
+			g.printf("r.ReadArray%s(%s[:])\n", meth, x)
+			return
 		}
+		i := g.vname("i")
+		g.depth++
+		g.printf("for %s := range %s {\n", i, x)
+		g.genUnmarshalElem(ut.Elem(), x+"["+i+"]")
+		g.printf("}\n")
+		g.depth--
 
 	case *types.Slice:
-		g.wt(ut.Elem(), n, "Array", "")
+		g.genUnmarshalSlice(t, ut, x)
+
+	case *types.Map:
+		g.genUnmarshalMap(t, ut, x)
 
 	case *types.Struct:
-		g.printf("w.WriteObject(&o.%s)\n", n)
+		g.printf("r.ReadObject(&%s)\n", x)
 
 	default:
-		log.Fatalf("gen-marshal-type: unhandled type: %v (underlying: %v)\n", t, ut)
+		log.Fatalf("gen-unmarshal-type: unhandled type: %v (underlying: %v)\n", t, ut)
+	}
+}
+
+func (g *genStreamer) genUnmarshalSlice(t types.Type, ut *types.Slice, x string) {
+	n := g.vname("n")
+	g.printf("%s := int(r.ReadI32())\n", n)
+	g.printf("%s = make(%s, %s)\n", x, g.typeName(t), n)
+	if meth, ok := bulkMeth(ut.Elem()); ok {
+		g.printf("r.ReadArray%s(%s)\n", meth, x)
+		return
+	}
+	i := g.vname("i")
+	g.depth++
+	g.printf("for %s := range %s {\n", i, x)
+	g.genUnmarshalElem(ut.Elem(), x+"["+i+"]")
+	g.printf("}\n")
+	g.depth--
+}
+
+func (g *genStreamer) genUnmarshalMap(t types.Type, ut *types.Map, x string) {
+	var (
+		n    = g.vname("n")
+		i    = g.vname("i")
+		k    = g.vname("k")
+		keys = g.vname("keys")
+		vals = g.vname("vals")
+	)
+	g.imps["go-hep.org/x/hep/groot/rvers"]++
+	g.printf("{\nhdr := r.ReadHeader(%q, rvers.StreamerInfo)\n", g.cxxName(t))
+	g.printf("%s := int(r.ReadI32())\n", n)
+	g.printf("%s := make([]%s, %s)\n", keys, g.typeName(ut.Key()), n)
+	g.printf("%s := make([]%s, %s)\n", vals, g.typeName(ut.Elem()), n)
+	g.printf("if %s > 0 {\n", n)
+
+	g.depth++
+	g.genBlockBeg(ut.Key(), "r")
+	g.printf("for %s := range %s {\n", i, keys)
+	g.genUnmarshalElem(ut.Key(), keys+"["+i+"]")
+	g.printf("}\n")
+	g.genBlockEnd(ut.Key(), "r")
+	g.genBlockBeg(ut.Elem(), "r")
+	g.printf("for %s := range %s {\n", i, vals)
+	g.genUnmarshalElem(ut.Elem(), vals+"["+i+"]")
+	g.printf("}\n")
+	g.genBlockEnd(ut.Elem(), "r")
+	g.depth--
+
+	g.printf("}\n")
+	g.printf("%s = make(%s, %s)\n", x, g.typeName(t), n)
+	g.printf("for %[1]s, %[2]s := range %[3]s {\n%[4]s[%[2]s] = %[5]s[%[1]s]\n}\n", i, k, keys, x, vals)
+	g.printf("r.CheckHeader(hdr)\n}\n")
+}
+
+// genBlockBeg generates the code opening a block of keys or values of type t
+// of a std::map, for writing (buf="w") or reading (buf="r").
+// As for ROOT, blocks of builtins (except strings) have no header.
+func (g *genStreamer) genBlockBeg(t types.Type, buf string) {
+	if !hasBlockHeader(t) {
+		return
+	}
+	switch buf {
+	case "w":
+		vers := "rvers.StreamerInfo"
+		if _, ok := t.Underlying().(*types.Struct); ok {
+			vers = fmt.Sprintf("int16(((*%s)(nil)).RVersion())", g.typeName(t))
+		}
+		g.printf("{\nhdr := w.WriteHeader(%q, %s)\n", g.cxxName(t), vers)
+	case "r":
+		g.printf("{\nhdr := r.ReadHeader(%q, -1)\n", g.cxxName(t))
+	}
+}
+
+// genBlockEnd generates the code closing a block of keys or values of type t
+// of a std::map, for writing (buf="w") or reading (buf="r").
+func (g *genStreamer) genBlockEnd(t types.Type, buf string) {
+	if !hasBlockHeader(t) {
+		return
+	}
+	switch buf {
+	case "w":
+		g.printf("w.SetHeader(hdr)\n}\n")
+	case "r":
+		g.printf("r.CheckHeader(hdr)\n}\n")
+	}
+}
+
+func hasBlockHeader(t types.Type) bool {
+	ut, ok := t.Underlying().(*types.Basic)
+	return !ok || ut.Kind() == types.String
+}
+
+// vname returns the name of a variable for the current nesting level.
+func (g *genStreamer) vname(name string) string {
+	if g.depth == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s%d", name, g.depth)
+}
+
+// typeName returns the Go name of the provided type, as seen from the
+// generated code.
+func (g *genStreamer) typeName(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == g.pkg {
+			return ""
+		}
+		g.imps[pkg.Path()]++
+		return pkg.Name()
+	})
+}
+
+// cxxName returns the C++ name of the provided type, as used to name STL
+// containers.
+func (g *genStreamer) cxxName(t types.Type) string {
+	switch ut := t.Underlying().(type) {
+	case *types.Basic:
+		if ut.Kind() == types.String {
+			return "string"
+		}
+		name, ok := rmeta.GoType2Cxx[ut.Name()]
+		if !ok {
+			log.Fatalf("unhandled type: %v (underlying: %v)\n", t, ut)
+		}
+		return name
+
+	case *types.Struct:
+		if _, ok := t.(*types.Named); !ok {
+			log.Fatalf("unhandled anonymous struct type: %v\n", t)
+		}
+		return GoName2Cxx(t.String())
+
+	case *types.Slice:
+		return fmt.Sprintf("vector<%s>", tmplArg(g.cxxName(ut.Elem())))
+
+	case *types.Map:
+		switch kt := ut.Key().Underlying().(type) {
+		case *types.Basic:
+			if kt.Info()&types.IsOrdered == 0 {
+				log.Fatalf("unhandled map key type: %v (underlying: %v)\n", ut.Key(), kt)
+			}
+		default:
+			log.Fatalf("unhandled map key type: %v (underlying: %v)\n", ut.Key(), kt)
+		}
+		return fmt.Sprintf("map<%s,%s>", g.cxxName(ut.Key()), tmplArg(g.cxxName(ut.Elem())))
+	}
+
+	log.Fatalf("unhandled type: %v (underlying: %v)\n", t, t.Underlying())
+	panic("unreachable")
+}
+
+// tmplArg returns the provided name, ready to be used as the last argument
+// of a C++ template.
+func tmplArg(name string) string {
+	if strings.HasSuffix(name, ">") {
+		name += " "
+	}
+	return name
+}
+
+// basicMeth returns the suffix of the rbytes methods handling the provided
+// basic type, and the Go type to convert to, when the provided type is not
+// directly handled by these methods.
+func basicMeth(t types.Type) (meth, wire string) {
+	ut := t.Underlying().(*types.Basic)
+	switch ut.Kind() {
+	case types.Bool:
+		meth, wire = "Bool", "bool"
+	case types.Uint8:
+		meth, wire = "U8", "uint8"
+	case types.Uint16:
+		meth, wire = "U16", "uint16"
+	case types.Uint32, types.Uint:
+		meth, wire = "U32", "uint32"
+	case types.Uint64:
+		meth, wire = "U64", "uint64"
+	case types.Int8:
+		meth, wire = "I8", "int8"
+	case types.Int16:
+		meth, wire = "I16", "int16"
+	case types.Int32, types.Int:
+		meth, wire = "I32", "int32"
+	case types.Int64:
+		meth, wire = "I64", "int64"
+	case types.Float32:
+		meth, wire = "F32", "float32"
+	case types.Float64:
+		meth, wire = "F64", "float64"
+	case types.String:
+		meth, wire = "String", "string"
+	default:
+		log.Fatalf("unhandled type: %v (underlying: %v)\n", t, ut) // FIXME(sbinet): complex64, complex128
+	}
+
+	if _, ok := t.(*types.Basic); ok && ut.Kind() != types.Int && ut.Kind() != types.Uint {
+		// no conversion needed.
+		wire = ""
+	}
+	return meth, wire
+}
+
+// bulkMeth returns the suffix of the rbytes methods handling arrays of the
+// provided element type, if any.
+func bulkMeth(t types.Type) (string, bool) {
+	ut, ok := t.(*types.Basic)
+	if !ok {
+		return "", false
+	}
+	switch ut.Kind() {
+	case types.Int, types.Uint, types.Complex64, types.Complex128:
+		return "", false
 	}
+	meth, _ := basicMeth(ut)
+	return meth, true
 }
 
 // Generate implements rdict.Generator
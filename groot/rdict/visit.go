@@ -121,16 +121,21 @@ func (v *visitor) visitSE(depth int, se rbytes.StreamerElement) error {
 			rmeta.STLvector:
 
 			etn := se.ElemTypeName()
-			tname := strings.TrimRight(etn[0], "*")
-			if _, ok := rmeta.CxxBuiltins[tname]; ok {
-				// no-op: C++ builtin.
-				return nil
-			}
-			si, err := v.ctx.StreamerInfo(tname, -1)
+			err := v.visitType(depth, etn[0])
 			if err != nil {
-				return fmt.Errorf("could not find std::container<T> element %q: %w", tname, err)
+				return fmt.Errorf("could not find std::container<T> element %q: %w", etn[0], err)
 			}
-			return v.run(depth+1, si)
+			return nil
+
+		case rmeta.STLmap, rmeta.STLmultimap, rmeta.STLunorderedmap, rmeta.STLunorderedmultimap:
+			etn := se.ElemTypeName()
+			for _, tname := range etn[:2] {
+				err := v.visitType(depth, tname)
+				if err != nil {
+					return fmt.Errorf("could not find std::map<K,V> element %q: %w", tname, err)
+				}
+			}
+			return nil
 
 		default:
 			return fmt.Errorf("rdict: cant visit non-vector-like STL streamers %#v", se)
@@ -154,3 +159,51 @@ func (v *visitor) visitSE(depth int, se rbytes.StreamerElement) error {
 
 	return nil
 }
+
+// visitType visits the streamer of the named type, the type of an element
+// of a STL container.
+func (v *visitor) visitType(depth int, tname string) error {
+	tname = strings.TrimRight(tname, "*")
+	if _, ok := rmeta.CxxBuiltins[tname]; ok {
+		// no-op: C++ builtin.
+		return nil
+	}
+	if isBuiltinPair(tname) {
+		// no-op: std::pair<K,V> of C++ builtins.
+		return nil
+	}
+
+	switch {
+	case hasStdPrefix(tname, "vector", "list", "deque", "set", "multiset", "unordered_set", "unordered_multiset"):
+		return v.visitType(depth, rmeta.CxxTemplateFrom(tname).Args[0])
+	case hasStdPrefix(tname, "map", "multimap", "unordered_map", "unordered_multimap"):
+		for _, arg := range rmeta.CxxTemplateFrom(tname).Args[:2] {
+			err := v.visitType(depth, arg)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	si, err := v.ctx.StreamerInfo(tname, -1)
+	if err != nil {
+		return err
+	}
+	return v.run(depth+1, si)
+}
+
+// isBuiltinPair returns whether the provided type name is a std::pair<K,V>
+// of C++ builtins.
+// ROOT does not store streamers for such types.
+func isBuiltinPair(tname string) bool {
+	if !hasStdPrefix(tname, "pair") {
+		return false
+	}
+	for _, arg := range rmeta.CxxTemplateFrom(tname).Args {
+		if _, ok := rmeta.CxxBuiltins[arg]; !ok {
+			return false
+		}
+	}
+	return true
+}
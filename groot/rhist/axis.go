@@ -66,6 +66,22 @@ func (a *taxis) XBins() []float64 {
 	return a.xbins.Data
 }
 
+// sameBinning reports whether a and o have the same bins.
+func (a *taxis) sameBinning(o *taxis) bool {
+	if a.nbins != o.nbins || a.xmin != o.xmin || a.xmax != o.xmax {
+		return false
+	}
+	if len(a.xbins.Data) != len(o.xbins.Data) {
+		return false
+	}
+	for i, v := range a.xbins.Data {
+		if v != o.xbins.Data[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (a *taxis) BinCenter(i int) float64 {
 	if len(a.xbins.Data) == 0 || i < 1 || i > a.nbins {
 		width := (a.xmax - a.xmin) / float64(a.nbins)
@@ -99,6 +115,25 @@ func (a *taxis) BinWidth(i int) float64 {
 	return a.xbins.Data[i] - a.xbins.Data[i-1]
 }
 
+// BinLabel returns the alphanumeric label of the i-th bin,
+// or the empty string if that bin has no label.
+func (a *taxis) BinLabel(i int) string {
+	if a.labels == nil {
+		return ""
+	}
+	for j := 0; j < a.labels.Len(); j++ {
+		// ROOT stores the bin index of a label in its unique ID.
+		lbl, ok := a.labels.At(j).(root.UIDer)
+		if !ok || int(lbl.UID()) != i {
+			continue
+		}
+		if lbl, ok := lbl.(root.Named); ok {
+			return lbl.Name()
+		}
+	}
+	return ""
+}
+
 func (a *taxis) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
 		return 0, w.Err()
@@ -14,6 +14,7 @@ import (
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
 )
 
 // Efficiency handles efficiency histograms.
@@ -31,10 +32,122 @@ type Efficiency struct {
 	confLvl float64    // confidence level (default = 0.683, 1 sigma)
 	funcs   rcont.List // ->pointer to list of functions
 
-	passedHist H1      // histogram for events which passed certain criteria
-	statOpt    int32   // defines how the confidence intervals are determined
-	totHist    H1      // histogram for total number of events
-	weight     float64 // weight for all events (default = 1)
+	passedHist root.Named // histogram for events which passed certain criteria
+	statOpt    int32      // defines how the confidence intervals are determined
+	totHist    root.Named // histogram for total number of events
+	weight     float64    // weight for all events (default = 1)
+}
+
+// status bits of TEfficiency.
+const (
+	effIsBayesian  = 1 << 14 // bayesian statistics are used
+	effUseBinPrior = 1 << 17 // use a different prior for each bin
+)
+
+// NewEfficiencyFrom creates a new Efficiency from an hbook 1-dim efficiency.
+func NewEfficiencyFrom(e *hbook.Eff1D) *Efficiency {
+	var (
+		name  = e.Name()
+		title = ""
+	)
+	if v, ok := e.Annotation()["title"].(string); ok {
+		title = v
+	}
+
+	passed := NewH1DFrom(e.Passed)
+	passed.SetName(name + "_passed")
+	total := NewH1DFrom(e.Total)
+	total.SetName(name + "_total")
+
+	o := &Efficiency{
+		named:      *rbase.NewNamed(name, title),
+		attline:    rbase.AttLine{Color: 1, Style: 1, Width: 1},
+		attfill:    rbase.AttFill{Color: 19, Style: 1001},
+		attmark:    rbase.AttMarker{Color: 1, Style: 1, Width: 1},
+		betaAlpha:  e.Alpha,
+		betaBeta:   e.Beta,
+		confLvl:    e.CL,
+		funcs:      *rcont.NewList("", nil),
+		passedHist: passed,
+		statOpt:    int32(e.Stat),
+		totHist:    total,
+		weight:     1,
+	}
+
+	if len(e.BinPriors) > 0 {
+		// ROOT stores the priors of the under- and overflow bins too.
+		nbins := e.Len()
+		o.betaBinParams = make([][2]float64, nbins+2)
+		for i := range o.betaBinParams {
+			o.betaBinParams[i] = [2]float64{e.Alpha, e.Beta}
+		}
+		copy(o.betaBinParams[1:nbins+1], e.BinPriors)
+		o.named.SetBit(effUseBinPrior)
+	}
+
+	switch e.Stat {
+	case hbook.EffJeffrey, hbook.EffUniform, hbook.EffBayesian:
+		o.named.SetBit(effIsBayesian)
+	}
+
+	return o
+}
+
+// Name returns the name of this efficiency.
+func (o *Efficiency) Name() string {
+	return o.named.Name()
+}
+
+// Title returns the title of this efficiency.
+func (o *Efficiency) Title() string {
+	return o.named.Title()
+}
+
+// Passed returns the histogram of events passing the selection.
+// The histogram is a TH1, a TH2 or a TH3, depending on the dimension of
+// the efficiency.
+func (o *Efficiency) Passed() root.Named {
+	return o.passedHist
+}
+
+// Total returns the histogram of all events.
+// The histogram is a TH1, a TH2 or a TH3, depending on the dimension of
+// the efficiency.
+func (o *Efficiency) Total() root.Named {
+	return o.totHist
+}
+
+// AsEff1D creates a new hbook.Eff1D from this ROOT efficiency.
+// AsEff1D returns an error if the efficiency is not 1-dimensional.
+func (o *Efficiency) AsEff1D() (*hbook.Eff1D, error) {
+	type h1der interface {
+		AsH1D() *hbook.H1D
+	}
+
+	passed, ok := o.passedHist.(h1der)
+	if !ok {
+		return nil, fmt.Errorf("rhist: TEfficiency %q is not 1-dim (passed histogram is a %T)", o.Name(), o.passedHist)
+	}
+	total, ok := o.totHist.(h1der)
+	if !ok {
+		return nil, fmt.Errorf("rhist: TEfficiency %q is not 1-dim (total histogram is a %T)", o.Name(), o.totHist)
+	}
+
+	e := hbook.NewEff1DFrom(passed.AsH1D(), total.AsH1D())
+	e.Ann["name"] = o.Name()
+	e.Ann["title"] = o.Title()
+	e.Stat = hbook.EffStat(o.statOpt)
+	e.CL = o.confLvl
+	e.Alpha = o.betaAlpha
+	e.Beta = o.betaBeta
+
+	if nbins := e.Len(); len(o.betaBinParams) > 1 {
+		n := min(len(o.betaBinParams)-1, nbins)
+		e.BinPriors = make([][2]float64, n)
+		copy(e.BinPriors, o.betaBinParams[1:])
+	}
+
+	return e, nil
 }
 
 func (*Efficiency) Class() string {
@@ -111,14 +224,22 @@ func (o *Efficiency) UnmarshalROOT(r *rbytes.RBuffer) error {
 	{
 		o.passedHist = nil
 		if oo := r.ReadObjectAny(); oo != nil { // obj-ptr
-			o.passedHist = oo.(H1)
+			h, ok := oo.(root.Named)
+			if !ok {
+				return fmt.Errorf("rhist: invalid TEfficiency histogram type %T", oo)
+			}
+			o.passedHist = h
 		}
 	}
 	o.statOpt = r.ReadI32()
 	{
 		o.totHist = nil
 		if oo := r.ReadObjectAny(); oo != nil { // obj-ptr
-			o.totHist = oo.(H1)
+			h, ok := oo.(root.Named)
+			if !ok {
+				return fmt.Errorf("rhist: invalid TEfficiency histogram type %T", oo)
+			}
+			o.totHist = h
 		}
 	}
 	o.weight = r.ReadF64()
@@ -189,6 +310,7 @@ func init() {
 
 var (
 	_ root.Object        = (*Efficiency)(nil)
+	_ root.Named         = (*Efficiency)(nil)
 	_ rbytes.RVersioner  = (*Efficiency)(nil)
 	_ rbytes.Marshaler   = (*Efficiency)(nil)
 	_ rbytes.Unmarshaler = (*Efficiency)(nil)
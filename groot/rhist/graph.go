@@ -95,6 +95,24 @@ func (g *tgraph) XY(i int) (float64, float64) {
 	return g.x[i], g.y[i]
 }
 
+// XAxis returns the axis along X of the histogram attached to this graph,
+// if any.
+func (g *tgraph) XAxis() Axis {
+	if g.histo == nil {
+		return nil
+	}
+	return g.histo.XAxis()
+}
+
+// YAxis returns the axis along Y of the histogram attached to this graph,
+// if any.
+func (g *tgraph) YAxis() Axis {
+	if g.histo == nil {
+		return nil
+	}
+	return g.histo.YAxis()
+}
+
 func (g *tgraph) ROOTMerge(src root.Object) error {
 	switch src := src.(type) {
 	case *tgraph:
@@ -316,8 +334,9 @@ func NewGraphErrorsFrom(s2 *hbook.S2D) GraphErrors {
 		groot.y[i] = pt.Y
 		groot.yerr[i] = pt.ErrY.Min
 
-		ymax = math.Max(ymax, pt.Y)
-		ymin = math.Min(ymin, pt.Y)
+		// make sure the error bars are within the graph range.
+		ymax = math.Max(ymax, pt.Y+pt.ErrY.Min)
+		ymin = math.Min(ymin, pt.Y-pt.ErrY.Min)
 	}
 
 	groot.tgraph.Named.SetName(s2.Name())
@@ -501,8 +520,9 @@ func NewGraphAsymmErrorsFrom(s2 *hbook.S2D) GraphErrors {
 		groot.yerrlo[i] = pt.ErrY.Min
 		groot.yerrhi[i] = pt.ErrY.Max
 
-		ymax = math.Max(ymax, pt.Y)
-		ymin = math.Min(ymin, pt.Y)
+		// make sure the error bars are within the graph range.
+		ymax = math.Max(ymax, pt.Y+pt.ErrY.Max)
+		ymin = math.Min(ymin, pt.Y-pt.ErrY.Min)
 	}
 
 	groot.tgraph.Named.SetName(s2.Name())
@@ -139,6 +139,11 @@ func (h *H1F) XAxis() Axis {
 	return &h.th1.xaxis
 }
 
+// YAxis returns the axis along Y.
+func (h *H1F) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
 // bin returns the regularized bin number given an x bin pair.
 func (h *H1F) bin(ix int) int {
 	nx := h.th1.xaxis.nbins + 1 // overflow bin
@@ -422,6 +427,11 @@ func (h *H1D) XAxis() Axis {
 	return &h.th1.xaxis
 }
 
+// YAxis returns the axis along Y.
+func (h *H1D) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
 // bin returns the regularized bin number given an x bin pair.
 func (h *H1D) bin(ix int) int {
 	nx := h.th1.xaxis.nbins + 1 // overflow bin
@@ -705,6 +715,11 @@ func (h *H1I) XAxis() Axis {
 	return &h.th1.xaxis
 }
 
+// YAxis returns the axis along Y.
+func (h *H1I) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
 // bin returns the regularized bin number given an x bin pair.
 func (h *H1I) bin(ix int) int {
 	nx := h.th1.xaxis.nbins + 1 // overflow bin
@@ -176,6 +176,11 @@ func (h *H2F) YAxis() Axis {
 	return &h.th1.yaxis
 }
 
+// ZAxis returns the axis along Z.
+func (h *H2F) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
 // YBinCenter returns the bin center value in Y.
 func (h *H2F) YBinCenter(i int) float64 {
 	return float64(h.th1.yaxis.BinCenter(i))
@@ -272,12 +277,22 @@ func (h *H2F) entries(height, err float64) int64 {
 // AsH2D creates a new hbook.H2D from this ROOT histogram.
 func (h *H2F) AsH2D() *hbook.H2D {
 	var (
-		nx = h.NbinsX()
-		ny = h.NbinsY()
-		hh = hbook.NewH2D(
-			nx, h.XAxis().XMin(), h.XAxis().XMax(),
-			ny, h.YAxis().XMin(), h.YAxis().XMax(),
-		)
+		nx     = h.NbinsX()
+		ny     = h.NbinsY()
+		xedges = make([]float64, nx+1)
+		yedges = make([]float64, ny+1)
+	)
+	for ix := range xedges[:nx] {
+		xedges[ix] = h.XBinLowEdge(ix + 1)
+	}
+	xedges[nx] = h.XBinLowEdge(nx) + h.XBinWidth(nx)
+	for iy := range yedges[:ny] {
+		yedges[iy] = h.YBinLowEdge(iy + 1)
+	}
+	yedges[ny] = h.YBinLowEdge(ny) + h.YBinWidth(ny)
+
+	var (
+		hh       = hbook.NewH2DFromEdges(xedges, yedges)
 		xinrange = 1
 		yinrange = 1
 	)
@@ -320,18 +335,7 @@ func (h *H2F) AsH2D() *hbook.H2D {
 
 	for ix := 0; ix < nx; ix++ {
 		for iy := 0; iy < ny; iy++ {
-			var (
-				i    = iy*nx + ix
-				xmin = h.XBinLowEdge(ix + 1)
-				xmax = h.XBinWidth(ix+1) + xmin
-				ymin = h.YBinLowEdge(iy + 1)
-				ymax = h.YBinWidth(iy+1) + ymin
-				bin  = &hh.Binning.Bins[i]
-			)
-			bin.XRange.Min = xmin
-			bin.XRange.Max = xmax
-			bin.YRange.Min = ymin
-			bin.YRange.Max = ymax
+			bin := &hh.Binning.Bins[iy*nx+ix]
 			bin.Dist = h.dist2D(ix+1, iy+1)
 		}
 	}
@@ -356,6 +360,46 @@ func (h *H2F) UnmarshalYODA(raw []byte) error {
 	return nil
 }
 
+func (h *H2F) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*H2F)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.H2F (%T)", src.(root.Named).Name(), src)
+	}
+
+	err := h.th2.merge(&hsrc.th2)
+	if err != nil {
+		return fmt.Errorf("rhist: could not merge %q: %w", hsrc.Name(), err)
+	}
+
+	if len(h.th2.th1.sumw2.Data) > 0 || len(hsrc.th2.th1.sumw2.Data) > 0 {
+		sumw2 := h.sumw2s()
+		for i, v := range hsrc.sumw2s() {
+			sumw2[i] += v
+		}
+		h.th2.th1.sumw2.Data = sumw2
+	}
+
+	for i, v := range hsrc.arr.Data {
+		h.arr.Data[i] += v
+	}
+
+	return nil
+}
+
+// sumw2s returns the sum of squares of weights of all the cells.
+// The sum of squares of weights is derived from the cells content
+// when it was not recorded.
+func (h *H2F) sumw2s() []float64 {
+	if len(h.th2.th1.sumw2.Data) > 0 {
+		return h.th2.th1.sumw2.Data
+	}
+	sumw2 := make([]float64, len(h.arr.Data))
+	for i, v := range h.arr.Data {
+		sumw2[i] = float64(v)
+	}
+	return sumw2
+}
+
 func (h *H2F) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
 		return 0, w.Err()
@@ -403,6 +447,7 @@ func init() {
 
 var (
 	_ root.Object        = (*H2F)(nil)
+	_ root.Merger        = (*H2F)(nil)
 	_ root.Named         = (*H2F)(nil)
 	_ H2                 = (*H2F)(nil)
 	_ rbytes.Marshaler   = (*H2F)(nil)
@@ -567,6 +612,11 @@ func (h *H2D) YAxis() Axis {
 	return &h.th1.yaxis
 }
 
+// ZAxis returns the axis along Z.
+func (h *H2D) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
 // YBinCenter returns the bin center value in Y.
 func (h *H2D) YBinCenter(i int) float64 {
 	return float64(h.th1.yaxis.BinCenter(i))
@@ -663,12 +713,22 @@ func (h *H2D) entries(height, err float64) int64 {
 // AsH2D creates a new hbook.H2D from this ROOT histogram.
 func (h *H2D) AsH2D() *hbook.H2D {
 	var (
-		nx = h.NbinsX()
-		ny = h.NbinsY()
-		hh = hbook.NewH2D(
-			nx, h.XAxis().XMin(), h.XAxis().XMax(),
-			ny, h.YAxis().XMin(), h.YAxis().XMax(),
-		)
+		nx     = h.NbinsX()
+		ny     = h.NbinsY()
+		xedges = make([]float64, nx+1)
+		yedges = make([]float64, ny+1)
+	)
+	for ix := range xedges[:nx] {
+		xedges[ix] = h.XBinLowEdge(ix + 1)
+	}
+	xedges[nx] = h.XBinLowEdge(nx) + h.XBinWidth(nx)
+	for iy := range yedges[:ny] {
+		yedges[iy] = h.YBinLowEdge(iy + 1)
+	}
+	yedges[ny] = h.YBinLowEdge(ny) + h.YBinWidth(ny)
+
+	var (
+		hh       = hbook.NewH2DFromEdges(xedges, yedges)
 		xinrange = 1
 		yinrange = 1
 	)
@@ -711,18 +771,7 @@ func (h *H2D) AsH2D() *hbook.H2D {
 
 	for ix := 0; ix < nx; ix++ {
 		for iy := 0; iy < ny; iy++ {
-			var (
-				i    = iy*nx + ix
-				xmin = h.XBinLowEdge(ix + 1)
-				xmax = h.XBinWidth(ix+1) + xmin
-				ymin = h.YBinLowEdge(iy + 1)
-				ymax = h.YBinWidth(iy+1) + ymin
-				bin  = &hh.Binning.Bins[i]
-			)
-			bin.XRange.Min = xmin
-			bin.XRange.Max = xmax
-			bin.YRange.Min = ymin
-			bin.YRange.Max = ymax
+			bin := &hh.Binning.Bins[iy*nx+ix]
 			bin.Dist = h.dist2D(ix+1, iy+1)
 		}
 	}
@@ -747,6 +796,46 @@ func (h *H2D) UnmarshalYODA(raw []byte) error {
 	return nil
 }
 
+func (h *H2D) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*H2D)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.H2D (%T)", src.(root.Named).Name(), src)
+	}
+
+	err := h.th2.merge(&hsrc.th2)
+	if err != nil {
+		return fmt.Errorf("rhist: could not merge %q: %w", hsrc.Name(), err)
+	}
+
+	if len(h.th2.th1.sumw2.Data) > 0 || len(hsrc.th2.th1.sumw2.Data) > 0 {
+		sumw2 := h.sumw2s()
+		for i, v := range hsrc.sumw2s() {
+			sumw2[i] += v
+		}
+		h.th2.th1.sumw2.Data = sumw2
+	}
+
+	for i, v := range hsrc.arr.Data {
+		h.arr.Data[i] += v
+	}
+
+	return nil
+}
+
+// sumw2s returns the sum of squares of weights of all the cells.
+// The sum of squares of weights is derived from the cells content
+// when it was not recorded.
+func (h *H2D) sumw2s() []float64 {
+	if len(h.th2.th1.sumw2.Data) > 0 {
+		return h.th2.th1.sumw2.Data
+	}
+	sumw2 := make([]float64, len(h.arr.Data))
+	for i, v := range h.arr.Data {
+		sumw2[i] = float64(v)
+	}
+	return sumw2
+}
+
 func (h *H2D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
 		return 0, w.Err()
@@ -794,6 +883,7 @@ func init() {
 
 var (
 	_ root.Object        = (*H2D)(nil)
+	_ root.Merger        = (*H2D)(nil)
 	_ root.Named         = (*H2D)(nil)
 	_ H2                 = (*H2D)(nil)
 	_ rbytes.Marshaler   = (*H2D)(nil)
@@ -958,6 +1048,11 @@ func (h *H2I) YAxis() Axis {
 	return &h.th1.yaxis
 }
 
+// ZAxis returns the axis along Z.
+func (h *H2I) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
 // YBinCenter returns the bin center value in Y.
 func (h *H2I) YBinCenter(i int) float64 {
 	return float64(h.th1.yaxis.BinCenter(i))
@@ -1054,12 +1149,22 @@ func (h *H2I) entries(height, err float64) int64 {
 // AsH2D creates a new hbook.H2D from this ROOT histogram.
 func (h *H2I) AsH2D() *hbook.H2D {
 	var (
-		nx = h.NbinsX()
-		ny = h.NbinsY()
-		hh = hbook.NewH2D(
-			nx, h.XAxis().XMin(), h.XAxis().XMax(),
-			ny, h.YAxis().XMin(), h.YAxis().XMax(),
-		)
+		nx     = h.NbinsX()
+		ny     = h.NbinsY()
+		xedges = make([]float64, nx+1)
+		yedges = make([]float64, ny+1)
+	)
+	for ix := range xedges[:nx] {
+		xedges[ix] = h.XBinLowEdge(ix + 1)
+	}
+	xedges[nx] = h.XBinLowEdge(nx) + h.XBinWidth(nx)
+	for iy := range yedges[:ny] {
+		yedges[iy] = h.YBinLowEdge(iy + 1)
+	}
+	yedges[ny] = h.YBinLowEdge(ny) + h.YBinWidth(ny)
+
+	var (
+		hh       = hbook.NewH2DFromEdges(xedges, yedges)
 		xinrange = 1
 		yinrange = 1
 	)
@@ -1102,18 +1207,7 @@ func (h *H2I) AsH2D() *hbook.H2D {
 
 	for ix := 0; ix < nx; ix++ {
 		for iy := 0; iy < ny; iy++ {
-			var (
-				i    = iy*nx + ix
-				xmin = h.XBinLowEdge(ix + 1)
-				xmax = h.XBinWidth(ix+1) + xmin
-				ymin = h.YBinLowEdge(iy + 1)
-				ymax = h.YBinWidth(iy+1) + ymin
-				bin  = &hh.Binning.Bins[i]
-			)
-			bin.XRange.Min = xmin
-			bin.XRange.Max = xmax
-			bin.YRange.Min = ymin
-			bin.YRange.Max = ymax
+			bin := &hh.Binning.Bins[iy*nx+ix]
 			bin.Dist = h.dist2D(ix+1, iy+1)
 		}
 	}
@@ -1138,6 +1232,46 @@ func (h *H2I) UnmarshalYODA(raw []byte) error {
 	return nil
 }
 
+func (h *H2I) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*H2I)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.H2I (%T)", src.(root.Named).Name(), src)
+	}
+
+	err := h.th2.merge(&hsrc.th2)
+	if err != nil {
+		return fmt.Errorf("rhist: could not merge %q: %w", hsrc.Name(), err)
+	}
+
+	if len(h.th2.th1.sumw2.Data) > 0 || len(hsrc.th2.th1.sumw2.Data) > 0 {
+		sumw2 := h.sumw2s()
+		for i, v := range hsrc.sumw2s() {
+			sumw2[i] += v
+		}
+		h.th2.th1.sumw2.Data = sumw2
+	}
+
+	for i, v := range hsrc.arr.Data {
+		h.arr.Data[i] += v
+	}
+
+	return nil
+}
+
+// sumw2s returns the sum of squares of weights of all the cells.
+// The sum of squares of weights is derived from the cells content
+// when it was not recorded.
+func (h *H2I) sumw2s() []float64 {
+	if len(h.th2.th1.sumw2.Data) > 0 {
+		return h.th2.th1.sumw2.Data
+	}
+	sumw2 := make([]float64, len(h.arr.Data))
+	for i, v := range h.arr.Data {
+		sumw2[i] = float64(v)
+	}
+	return sumw2
+}
+
 func (h *H2I) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
 		return 0, w.Err()
@@ -1185,6 +1319,7 @@ func init() {
 
 var (
 	_ root.Object        = (*H2I)(nil)
+	_ root.Merger        = (*H2I)(nil)
 	_ root.Named         = (*H2I)(nil)
 	_ H2                 = (*H2I)(nil)
 	_ rbytes.Marshaler   = (*H2I)(nil)
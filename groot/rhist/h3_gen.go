@@ -0,0 +1,1100 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Automatically generated. DO NOT EDIT.
+
+package rhist
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
+)
+
+// H3F implements ROOT TH3F
+type H3F struct {
+	th3
+	arr rcont.ArrayF
+}
+
+func newH3F() *H3F {
+	return &H3F{
+		th3: *newH3(),
+	}
+}
+
+// NewH3FFrom creates a new H3F from hbook 3-dim histogram.
+//
+// The outflows of the hbook histogram are stored in the first
+// under/overflow cell of the corresponding region.
+func NewH3FFrom(h *hbook.H3D) *H3F {
+	var (
+		hroot  = newH3F()
+		bng    = &h.Binning
+		nx     = bng.Nx
+		ny     = bng.Ny
+		nz     = bng.Nz
+		ncells = (nx + 2) * (ny + 2) * (nz + 2)
+		edges  = func(bins []hbook.Bin1D) []float64 {
+			o := make([]float64, 0, len(bins)+1)
+			for _, bin := range bins {
+				o = append(o, bin.Range.Min)
+			}
+			return append(o, bins[len(bins)-1].Range.Max)
+		}
+	)
+
+	hroot.th3.th1.entries = float64(h.Entries())
+	hroot.th3.th1.tsumw = h.SumW()
+	hroot.th3.th1.tsumw2 = h.SumW2()
+	hroot.th3.th1.tsumwx = h.SumWX()
+	hroot.th3.th1.tsumwx2 = h.SumWX2()
+	hroot.th3.tsumwy = h.SumWY()
+	hroot.th3.tsumwy2 = h.SumWY2()
+	hroot.th3.tsumwxy = h.SumWXY()
+	hroot.th3.tsumwz = h.SumWZ()
+	hroot.th3.tsumwz2 = h.SumWZ2()
+	hroot.th3.tsumwxz = h.SumWXZ()
+	hroot.th3.tsumwyz = h.SumWYZ()
+	hroot.th3.th1.ncells = ncells
+
+	hroot.th3.th1.xaxis.nbins = nx
+	hroot.th3.th1.xaxis.xmin = h.XMin()
+	hroot.th3.th1.xaxis.xmax = h.XMax()
+	hroot.th3.th1.xaxis.xbins.Data = edges(bng.XEdges)
+
+	hroot.th3.th1.yaxis.nbins = ny
+	hroot.th3.th1.yaxis.xmin = h.YMin()
+	hroot.th3.th1.yaxis.xmax = h.YMax()
+	hroot.th3.th1.yaxis.xbins.Data = edges(bng.YEdges)
+
+	hroot.th3.th1.zaxis.nbins = nz
+	hroot.th3.th1.zaxis.xmin = h.ZMin()
+	hroot.th3.th1.zaxis.xmax = h.ZMax()
+	hroot.th3.th1.zaxis.xbins.Data = edges(bng.ZEdges)
+
+	hroot.arr.Data = make([]float32, ncells)
+	hroot.th3.th1.sumw2.Data = make([]float64, ncells)
+
+	set := func(ix, iy, iz int, d *hbook.Dist3D) {
+		i := hroot.bin(ix, iy, iz)
+		hroot.arr.Data[i] = float32(d.SumW())
+		hroot.th3.th1.sumw2.Data[i] = d.SumW2()
+	}
+
+	for iz := 0; iz < nz; iz++ {
+		for iy := 0; iy < ny; iy++ {
+			for ix := 0; ix < nx; ix++ {
+				bin := &bng.Bins[(iz*ny+iy)*nx+ix]
+				set(ix+1, iy+1, iz+1, &bin.Dist)
+			}
+		}
+	}
+
+	cell := func(o, n int) int {
+		switch o {
+		case -1:
+			return 0
+		case +1:
+			return n + 1
+		}
+		return 1
+	}
+	for oz := -1; oz <= 1; oz++ {
+		for oy := -1; oy <= 1; oy++ {
+			for ox := -1; ox <= 1; ox++ {
+				d := bng.Outflow(ox, oy, oz)
+				if d == nil {
+					continue
+				}
+				set(cell(ox, nx), cell(oy, ny), cell(oz, nz), d)
+			}
+		}
+	}
+
+	hroot.th3.th1.SetName(h.Name())
+	if v, ok := h.Annotation()["title"]; ok && v != nil {
+		hroot.th3.th1.SetTitle(v.(string))
+	}
+
+	return hroot
+}
+
+func (*H3F) RVersion() int16 {
+	return rvers.H3F
+}
+
+func (*H3F) isH3() {}
+
+// Class returns the ROOT class name.
+func (*H3F) Class() string {
+	return "TH3F"
+}
+
+func (h *H3F) Array() rcont.ArrayF {
+	return h.arr
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *H3F) Rank() int {
+	return 3
+}
+
+// NbinsX returns the number of bins in X.
+func (h *H3F) NbinsX() int {
+	return h.th1.xaxis.nbins
+}
+
+// XAxis returns the axis along X.
+func (h *H3F) XAxis() Axis {
+	return &h.th1.xaxis
+}
+
+// NbinsY returns the number of bins in Y.
+func (h *H3F) NbinsY() int {
+	return h.th1.yaxis.nbins
+}
+
+// YAxis returns the axis along Y.
+func (h *H3F) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
+// NbinsZ returns the number of bins in Z.
+func (h *H3F) NbinsZ() int {
+	return h.th1.zaxis.nbins
+}
+
+// ZAxis returns the axis along Z.
+func (h *H3F) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
+// BinContent returns the content of the (ix,iy,iz) bin.
+// Bin indices start at 1; 0 and Nbins+1 address the under- and overflow bins.
+func (h *H3F) BinContent(ix, iy, iz int) float64 {
+	return float64(h.arr.Data[h.bin(ix, iy, iz)])
+}
+
+// BinError returns the error of the (ix,iy,iz) bin.
+// Bin indices start at 1; 0 and Nbins+1 address the under- and overflow bins.
+func (h *H3F) BinError(ix, iy, iz int) float64 {
+	i := h.bin(ix, iy, iz)
+	if len(h.th1.sumw2.Data) > 0 {
+		return math.Sqrt(float64(h.th1.sumw2.Data[i]))
+	}
+	return math.Sqrt(math.Abs(float64(h.arr.Data[i])))
+}
+
+// AsH3D creates a new hbook.H3D from this ROOT histogram.
+//
+// The outflows of the hbook histogram are the sums of the ROOT
+// under/overflow cells of the corresponding regions.
+func (h *H3F) AsH3D() *hbook.H3D {
+	var (
+		nx    = h.NbinsX()
+		ny    = h.NbinsY()
+		nz    = h.NbinsZ()
+		edges = func(axis Axis) []float64 {
+			n := axis.NBins()
+			o := make([]float64, n+1)
+			for i := range o[:n] {
+				o[i] = axis.BinLowEdge(i + 1)
+			}
+			o[n] = axis.BinLowEdge(n) + axis.BinWidth(n)
+			return o
+		}
+		hh = hbook.NewH3DFromEdges(
+			edges(h.XAxis()),
+			edges(h.YAxis()),
+			edges(h.ZAxis()),
+		)
+		bng = &hh.Binning
+	)
+	hh.Ann = hbook.Annotation{
+		"name":  h.Name(),
+		"title": h.Title(),
+	}
+
+	dist := func(d0 hbook.Dist0D) hbook.Dist3D {
+		var d hbook.Dist3D
+		d.X.Dist = d0
+		d.Y.Dist = d0
+		d.Z.Dist = d0
+		return d
+	}
+
+	code := func(i, n int) int {
+		switch i {
+		case 0:
+			return -1
+		case n + 1:
+			return +1
+		}
+		return 0
+	}
+	for iz := 0; iz <= nz+1; iz++ {
+		for iy := 0; iy <= ny+1; iy++ {
+			for ix := 0; ix <= nx+1; ix++ {
+				d0 := h.dist0D(ix, iy, iz)
+				o := bng.Outflow(code(ix, nx), code(iy, ny), code(iz, nz))
+				if o == nil {
+					bin := &bng.Bins[((iz-1)*ny+iy-1)*nx+ix-1]
+					bin.Dist = dist(d0)
+					continue
+				}
+				d0.N += o.X.Dist.N
+				d0.SumW += o.X.Dist.SumW
+				d0.SumW2 += o.X.Dist.SumW2
+				*o = dist(d0)
+			}
+		}
+	}
+
+	bng.Dist = dist(hbook.Dist0D{
+		N:     int64(h.Entries()),
+		SumW:  h.SumW(),
+		SumW2: h.SumW2(),
+	})
+	bng.Dist.X.Stats.SumWX = h.SumWX()
+	bng.Dist.X.Stats.SumWX2 = h.SumWX2()
+	bng.Dist.Y.Stats.SumWX = h.SumWY()
+	bng.Dist.Y.Stats.SumWX2 = h.SumWY2()
+	bng.Dist.Z.Stats.SumWX = h.SumWZ()
+	bng.Dist.Z.Stats.SumWX2 = h.SumWZ2()
+	bng.Dist.Stats.SumWXY = h.SumWXY()
+	bng.Dist.Stats.SumWXZ = h.SumWXZ()
+	bng.Dist.Stats.SumWYZ = h.SumWYZ()
+
+	return hh
+}
+
+// dist0D returns the weight moments of the (ix,iy,iz) bin.
+func (h *H3F) dist0D(ix, iy, iz int) hbook.Dist0D {
+	var (
+		i     = h.bin(ix, iy, iz)
+		sumw  = float64(h.arr.Data[i])
+		sumw2 = math.Abs(sumw)
+	)
+	if len(h.th1.sumw2.Data) > 0 {
+		sumw2 = h.th1.sumw2.Data[i]
+	}
+	var n int64
+	if sumw > 0 && sumw2 > 0 {
+		// effective number of entries.
+		n = int64(sumw*sumw/sumw2 + 0.5)
+	}
+	return hbook.Dist0D{
+		N:     n,
+		SumW:  sumw,
+		SumW2: sumw2,
+	}
+}
+
+// bin returns the regularized bin number given an (x,y,z) bin index triplet.
+func (h *H3F) bin(ix, iy, iz int) int {
+	nx := h.th1.xaxis.nbins + 1 // overflow bin
+	ny := h.th1.yaxis.nbins + 1 // overflow bin
+	nz := h.th1.zaxis.nbins + 1 // overflow bin
+	switch {
+	case ix < 0:
+		ix = 0
+	case ix > nx:
+		ix = nx
+	}
+	switch {
+	case iy < 0:
+		iy = 0
+	case iy > ny:
+		iy = ny
+	}
+	switch {
+	case iz < 0:
+		iz = 0
+	case iz > nz:
+		iz = nz
+	}
+	return ix + (nx+1)*(iy+(ny+1)*iz)
+}
+
+func (h *H3F) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(h.Class(), h.RVersion())
+	w.WriteObject(&h.th3)
+	w.WriteObject(&h.arr)
+
+	return w.SetHeader(hdr)
+}
+
+func (h *H3F) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(h.Class(), h.RVersion())
+	if hdr.Vers < 1 {
+		return fmt.Errorf("rhist: TH3F version too old (%d<1)", hdr.Vers)
+	}
+
+	r.ReadObject(&h.th3)
+	r.ReadObject(&h.arr)
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (h *H3F) RMembers() (mbrs []rbytes.Member) {
+	mbrs = append(mbrs, h.th3.RMembers()...)
+	mbrs = append(mbrs, rbytes.Member{
+		Name: "fArray", Value: &h.arr.Data,
+	})
+	return mbrs
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := newH3F()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TH3F", f)
+}
+
+var (
+	_ root.Object        = (*H3F)(nil)
+	_ root.Named         = (*H3F)(nil)
+	_ H3                 = (*H3F)(nil)
+	_ rbytes.Marshaler   = (*H3F)(nil)
+	_ rbytes.Unmarshaler = (*H3F)(nil)
+	_ rbytes.RSlicer     = (*H3F)(nil)
+)
+
+// H3D implements ROOT TH3D
+type H3D struct {
+	th3
+	arr rcont.ArrayD
+}
+
+func newH3D() *H3D {
+	return &H3D{
+		th3: *newH3(),
+	}
+}
+
+// NewH3DFrom creates a new H3D from hbook 3-dim histogram.
+//
+// The outflows of the hbook histogram are stored in the first
+// under/overflow cell of the corresponding region.
+func NewH3DFrom(h *hbook.H3D) *H3D {
+	var (
+		hroot  = newH3D()
+		bng    = &h.Binning
+		nx     = bng.Nx
+		ny     = bng.Ny
+		nz     = bng.Nz
+		ncells = (nx + 2) * (ny + 2) * (nz + 2)
+		edges  = func(bins []hbook.Bin1D) []float64 {
+			o := make([]float64, 0, len(bins)+1)
+			for _, bin := range bins {
+				o = append(o, bin.Range.Min)
+			}
+			return append(o, bins[len(bins)-1].Range.Max)
+		}
+	)
+
+	hroot.th3.th1.entries = float64(h.Entries())
+	hroot.th3.th1.tsumw = h.SumW()
+	hroot.th3.th1.tsumw2 = h.SumW2()
+	hroot.th3.th1.tsumwx = h.SumWX()
+	hroot.th3.th1.tsumwx2 = h.SumWX2()
+	hroot.th3.tsumwy = h.SumWY()
+	hroot.th3.tsumwy2 = h.SumWY2()
+	hroot.th3.tsumwxy = h.SumWXY()
+	hroot.th3.tsumwz = h.SumWZ()
+	hroot.th3.tsumwz2 = h.SumWZ2()
+	hroot.th3.tsumwxz = h.SumWXZ()
+	hroot.th3.tsumwyz = h.SumWYZ()
+	hroot.th3.th1.ncells = ncells
+
+	hroot.th3.th1.xaxis.nbins = nx
+	hroot.th3.th1.xaxis.xmin = h.XMin()
+	hroot.th3.th1.xaxis.xmax = h.XMax()
+	hroot.th3.th1.xaxis.xbins.Data = edges(bng.XEdges)
+
+	hroot.th3.th1.yaxis.nbins = ny
+	hroot.th3.th1.yaxis.xmin = h.YMin()
+	hroot.th3.th1.yaxis.xmax = h.YMax()
+	hroot.th3.th1.yaxis.xbins.Data = edges(bng.YEdges)
+
+	hroot.th3.th1.zaxis.nbins = nz
+	hroot.th3.th1.zaxis.xmin = h.ZMin()
+	hroot.th3.th1.zaxis.xmax = h.ZMax()
+	hroot.th3.th1.zaxis.xbins.Data = edges(bng.ZEdges)
+
+	hroot.arr.Data = make([]float64, ncells)
+	hroot.th3.th1.sumw2.Data = make([]float64, ncells)
+
+	set := func(ix, iy, iz int, d *hbook.Dist3D) {
+		i := hroot.bin(ix, iy, iz)
+		hroot.arr.Data[i] = float64(d.SumW())
+		hroot.th3.th1.sumw2.Data[i] = d.SumW2()
+	}
+
+	for iz := 0; iz < nz; iz++ {
+		for iy := 0; iy < ny; iy++ {
+			for ix := 0; ix < nx; ix++ {
+				bin := &bng.Bins[(iz*ny+iy)*nx+ix]
+				set(ix+1, iy+1, iz+1, &bin.Dist)
+			}
+		}
+	}
+
+	cell := func(o, n int) int {
+		switch o {
+		case -1:
+			return 0
+		case +1:
+			return n + 1
+		}
+		return 1
+	}
+	for oz := -1; oz <= 1; oz++ {
+		for oy := -1; oy <= 1; oy++ {
+			for ox := -1; ox <= 1; ox++ {
+				d := bng.Outflow(ox, oy, oz)
+				if d == nil {
+					continue
+				}
+				set(cell(ox, nx), cell(oy, ny), cell(oz, nz), d)
+			}
+		}
+	}
+
+	hroot.th3.th1.SetName(h.Name())
+	if v, ok := h.Annotation()["title"]; ok && v != nil {
+		hroot.th3.th1.SetTitle(v.(string))
+	}
+
+	return hroot
+}
+
+func (*H3D) RVersion() int16 {
+	return rvers.H3D
+}
+
+func (*H3D) isH3() {}
+
+// Class returns the ROOT class name.
+func (*H3D) Class() string {
+	return "TH3D"
+}
+
+func (h *H3D) Array() rcont.ArrayD {
+	return h.arr
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *H3D) Rank() int {
+	return 3
+}
+
+// NbinsX returns the number of bins in X.
+func (h *H3D) NbinsX() int {
+	return h.th1.xaxis.nbins
+}
+
+// XAxis returns the axis along X.
+func (h *H3D) XAxis() Axis {
+	return &h.th1.xaxis
+}
+
+// NbinsY returns the number of bins in Y.
+func (h *H3D) NbinsY() int {
+	return h.th1.yaxis.nbins
+}
+
+// YAxis returns the axis along Y.
+func (h *H3D) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
+// NbinsZ returns the number of bins in Z.
+func (h *H3D) NbinsZ() int {
+	return h.th1.zaxis.nbins
+}
+
+// ZAxis returns the axis along Z.
+func (h *H3D) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
+// BinContent returns the content of the (ix,iy,iz) bin.
+// Bin indices start at 1; 0 and Nbins+1 address the under- and overflow bins.
+func (h *H3D) BinContent(ix, iy, iz int) float64 {
+	return float64(h.arr.Data[h.bin(ix, iy, iz)])
+}
+
+// BinError returns the error of the (ix,iy,iz) bin.
+// Bin indices start at 1; 0 and Nbins+1 address the under- and overflow bins.
+func (h *H3D) BinError(ix, iy, iz int) float64 {
+	i := h.bin(ix, iy, iz)
+	if len(h.th1.sumw2.Data) > 0 {
+		return math.Sqrt(float64(h.th1.sumw2.Data[i]))
+	}
+	return math.Sqrt(math.Abs(float64(h.arr.Data[i])))
+}
+
+// AsH3D creates a new hbook.H3D from this ROOT histogram.
+//
+// The outflows of the hbook histogram are the sums of the ROOT
+// under/overflow cells of the corresponding regions.
+func (h *H3D) AsH3D() *hbook.H3D {
+	var (
+		nx    = h.NbinsX()
+		ny    = h.NbinsY()
+		nz    = h.NbinsZ()
+		edges = func(axis Axis) []float64 {
+			n := axis.NBins()
+			o := make([]float64, n+1)
+			for i := range o[:n] {
+				o[i] = axis.BinLowEdge(i + 1)
+			}
+			o[n] = axis.BinLowEdge(n) + axis.BinWidth(n)
+			return o
+		}
+		hh = hbook.NewH3DFromEdges(
+			edges(h.XAxis()),
+			edges(h.YAxis()),
+			edges(h.ZAxis()),
+		)
+		bng = &hh.Binning
+	)
+	hh.Ann = hbook.Annotation{
+		"name":  h.Name(),
+		"title": h.Title(),
+	}
+
+	dist := func(d0 hbook.Dist0D) hbook.Dist3D {
+		var d hbook.Dist3D
+		d.X.Dist = d0
+		d.Y.Dist = d0
+		d.Z.Dist = d0
+		return d
+	}
+
+	code := func(i, n int) int {
+		switch i {
+		case 0:
+			return -1
+		case n + 1:
+			return +1
+		}
+		return 0
+	}
+	for iz := 0; iz <= nz+1; iz++ {
+		for iy := 0; iy <= ny+1; iy++ {
+			for ix := 0; ix <= nx+1; ix++ {
+				d0 := h.dist0D(ix, iy, iz)
+				o := bng.Outflow(code(ix, nx), code(iy, ny), code(iz, nz))
+				if o == nil {
+					bin := &bng.Bins[((iz-1)*ny+iy-1)*nx+ix-1]
+					bin.Dist = dist(d0)
+					continue
+				}
+				d0.N += o.X.Dist.N
+				d0.SumW += o.X.Dist.SumW
+				d0.SumW2 += o.X.Dist.SumW2
+				*o = dist(d0)
+			}
+		}
+	}
+
+	bng.Dist = dist(hbook.Dist0D{
+		N:     int64(h.Entries()),
+		SumW:  h.SumW(),
+		SumW2: h.SumW2(),
+	})
+	bng.Dist.X.Stats.SumWX = h.SumWX()
+	bng.Dist.X.Stats.SumWX2 = h.SumWX2()
+	bng.Dist.Y.Stats.SumWX = h.SumWY()
+	bng.Dist.Y.Stats.SumWX2 = h.SumWY2()
+	bng.Dist.Z.Stats.SumWX = h.SumWZ()
+	bng.Dist.Z.Stats.SumWX2 = h.SumWZ2()
+	bng.Dist.Stats.SumWXY = h.SumWXY()
+	bng.Dist.Stats.SumWXZ = h.SumWXZ()
+	bng.Dist.Stats.SumWYZ = h.SumWYZ()
+
+	return hh
+}
+
+// dist0D returns the weight moments of the (ix,iy,iz) bin.
+func (h *H3D) dist0D(ix, iy, iz int) hbook.Dist0D {
+	var (
+		i     = h.bin(ix, iy, iz)
+		sumw  = float64(h.arr.Data[i])
+		sumw2 = math.Abs(sumw)
+	)
+	if len(h.th1.sumw2.Data) > 0 {
+		sumw2 = h.th1.sumw2.Data[i]
+	}
+	var n int64
+	if sumw > 0 && sumw2 > 0 {
+		// effective number of entries.
+		n = int64(sumw*sumw/sumw2 + 0.5)
+	}
+	return hbook.Dist0D{
+		N:     n,
+		SumW:  sumw,
+		SumW2: sumw2,
+	}
+}
+
+// bin returns the regularized bin number given an (x,y,z) bin index triplet.
+func (h *H3D) bin(ix, iy, iz int) int {
+	nx := h.th1.xaxis.nbins + 1 // overflow bin
+	ny := h.th1.yaxis.nbins + 1 // overflow bin
+	nz := h.th1.zaxis.nbins + 1 // overflow bin
+	switch {
+	case ix < 0:
+		ix = 0
+	case ix > nx:
+		ix = nx
+	}
+	switch {
+	case iy < 0:
+		iy = 0
+	case iy > ny:
+		iy = ny
+	}
+	switch {
+	case iz < 0:
+		iz = 0
+	case iz > nz:
+		iz = nz
+	}
+	return ix + (nx+1)*(iy+(ny+1)*iz)
+}
+
+func (h *H3D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(h.Class(), h.RVersion())
+	w.WriteObject(&h.th3)
+	w.WriteObject(&h.arr)
+
+	return w.SetHeader(hdr)
+}
+
+func (h *H3D) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(h.Class(), h.RVersion())
+	if hdr.Vers < 1 {
+		return fmt.Errorf("rhist: TH3D version too old (%d<1)", hdr.Vers)
+	}
+
+	r.ReadObject(&h.th3)
+	r.ReadObject(&h.arr)
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (h *H3D) RMembers() (mbrs []rbytes.Member) {
+	mbrs = append(mbrs, h.th3.RMembers()...)
+	mbrs = append(mbrs, rbytes.Member{
+		Name: "fArray", Value: &h.arr.Data,
+	})
+	return mbrs
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := newH3D()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TH3D", f)
+}
+
+var (
+	_ root.Object        = (*H3D)(nil)
+	_ root.Named         = (*H3D)(nil)
+	_ H3                 = (*H3D)(nil)
+	_ rbytes.Marshaler   = (*H3D)(nil)
+	_ rbytes.Unmarshaler = (*H3D)(nil)
+	_ rbytes.RSlicer     = (*H3D)(nil)
+)
+
+// H3I implements ROOT TH3I
+type H3I struct {
+	th3
+	arr rcont.ArrayI
+}
+
+func newH3I() *H3I {
+	return &H3I{
+		th3: *newH3(),
+	}
+}
+
+// NewH3IFrom creates a new H3I from hbook 3-dim histogram.
+//
+// The outflows of the hbook histogram are stored in the first
+// under/overflow cell of the corresponding region.
+func NewH3IFrom(h *hbook.H3D) *H3I {
+	var (
+		hroot  = newH3I()
+		bng    = &h.Binning
+		nx     = bng.Nx
+		ny     = bng.Ny
+		nz     = bng.Nz
+		ncells = (nx + 2) * (ny + 2) * (nz + 2)
+		edges  = func(bins []hbook.Bin1D) []float64 {
+			o := make([]float64, 0, len(bins)+1)
+			for _, bin := range bins {
+				o = append(o, bin.Range.Min)
+			}
+			return append(o, bins[len(bins)-1].Range.Max)
+		}
+	)
+
+	hroot.th3.th1.entries = float64(h.Entries())
+	hroot.th3.th1.tsumw = h.SumW()
+	hroot.th3.th1.tsumw2 = h.SumW2()
+	hroot.th3.th1.tsumwx = h.SumWX()
+	hroot.th3.th1.tsumwx2 = h.SumWX2()
+	hroot.th3.tsumwy = h.SumWY()
+	hroot.th3.tsumwy2 = h.SumWY2()
+	hroot.th3.tsumwxy = h.SumWXY()
+	hroot.th3.tsumwz = h.SumWZ()
+	hroot.th3.tsumwz2 = h.SumWZ2()
+	hroot.th3.tsumwxz = h.SumWXZ()
+	hroot.th3.tsumwyz = h.SumWYZ()
+	hroot.th3.th1.ncells = ncells
+
+	hroot.th3.th1.xaxis.nbins = nx
+	hroot.th3.th1.xaxis.xmin = h.XMin()
+	hroot.th3.th1.xaxis.xmax = h.XMax()
+	hroot.th3.th1.xaxis.xbins.Data = edges(bng.XEdges)
+
+	hroot.th3.th1.yaxis.nbins = ny
+	hroot.th3.th1.yaxis.xmin = h.YMin()
+	hroot.th3.th1.yaxis.xmax = h.YMax()
+	hroot.th3.th1.yaxis.xbins.Data = edges(bng.YEdges)
+
+	hroot.th3.th1.zaxis.nbins = nz
+	hroot.th3.th1.zaxis.xmin = h.ZMin()
+	hroot.th3.th1.zaxis.xmax = h.ZMax()
+	hroot.th3.th1.zaxis.xbins.Data = edges(bng.ZEdges)
+
+	hroot.arr.Data = make([]int32, ncells)
+	hroot.th3.th1.sumw2.Data = make([]float64, ncells)
+
+	set := func(ix, iy, iz int, d *hbook.Dist3D) {
+		i := hroot.bin(ix, iy, iz)
+		hroot.arr.Data[i] = int32(d.SumW())
+		hroot.th3.th1.sumw2.Data[i] = d.SumW2()
+	}
+
+	for iz := 0; iz < nz; iz++ {
+		for iy := 0; iy < ny; iy++ {
+			for ix := 0; ix < nx; ix++ {
+				bin := &bng.Bins[(iz*ny+iy)*nx+ix]
+				set(ix+1, iy+1, iz+1, &bin.Dist)
+			}
+		}
+	}
+
+	cell := func(o, n int) int {
+		switch o {
+		case -1:
+			return 0
+		case +1:
+			return n + 1
+		}
+		return 1
+	}
+	for oz := -1; oz <= 1; oz++ {
+		for oy := -1; oy <= 1; oy++ {
+			for ox := -1; ox <= 1; ox++ {
+				d := bng.Outflow(ox, oy, oz)
+				if d == nil {
+					continue
+				}
+				set(cell(ox, nx), cell(oy, ny), cell(oz, nz), d)
+			}
+		}
+	}
+
+	hroot.th3.th1.SetName(h.Name())
+	if v, ok := h.Annotation()["title"]; ok && v != nil {
+		hroot.th3.th1.SetTitle(v.(string))
+	}
+
+	return hroot
+}
+
+func (*H3I) RVersion() int16 {
+	return rvers.H3I
+}
+
+func (*H3I) isH3() {}
+
+// Class returns the ROOT class name.
+func (*H3I) Class() string {
+	return "TH3I"
+}
+
+func (h *H3I) Array() rcont.ArrayI {
+	return h.arr
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *H3I) Rank() int {
+	return 3
+}
+
+// NbinsX returns the number of bins in X.
+func (h *H3I) NbinsX() int {
+	return h.th1.xaxis.nbins
+}
+
+// XAxis returns the axis along X.
+func (h *H3I) XAxis() Axis {
+	return &h.th1.xaxis
+}
+
+// NbinsY returns the number of bins in Y.
+func (h *H3I) NbinsY() int {
+	return h.th1.yaxis.nbins
+}
+
+// YAxis returns the axis along Y.
+func (h *H3I) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
+// NbinsZ returns the number of bins in Z.
+func (h *H3I) NbinsZ() int {
+	return h.th1.zaxis.nbins
+}
+
+// ZAxis returns the axis along Z.
+func (h *H3I) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
+// BinContent returns the content of the (ix,iy,iz) bin.
+// Bin indices start at 1; 0 and Nbins+1 address the under- and overflow bins.
+func (h *H3I) BinContent(ix, iy, iz int) float64 {
+	return float64(h.arr.Data[h.bin(ix, iy, iz)])
+}
+
+// BinError returns the error of the (ix,iy,iz) bin.
+// Bin indices start at 1; 0 and Nbins+1 address the under- and overflow bins.
+func (h *H3I) BinError(ix, iy, iz int) float64 {
+	i := h.bin(ix, iy, iz)
+	if len(h.th1.sumw2.Data) > 0 {
+		return math.Sqrt(float64(h.th1.sumw2.Data[i]))
+	}
+	return math.Sqrt(math.Abs(float64(h.arr.Data[i])))
+}
+
+// AsH3D creates a new hbook.H3D from this ROOT histogram.
+//
+// The outflows of the hbook histogram are the sums of the ROOT
+// under/overflow cells of the corresponding regions.
+func (h *H3I) AsH3D() *hbook.H3D {
+	var (
+		nx    = h.NbinsX()
+		ny    = h.NbinsY()
+		nz    = h.NbinsZ()
+		edges = func(axis Axis) []float64 {
+			n := axis.NBins()
+			o := make([]float64, n+1)
+			for i := range o[:n] {
+				o[i] = axis.BinLowEdge(i + 1)
+			}
+			o[n] = axis.BinLowEdge(n) + axis.BinWidth(n)
+			return o
+		}
+		hh = hbook.NewH3DFromEdges(
+			edges(h.XAxis()),
+			edges(h.YAxis()),
+			edges(h.ZAxis()),
+		)
+		bng = &hh.Binning
+	)
+	hh.Ann = hbook.Annotation{
+		"name":  h.Name(),
+		"title": h.Title(),
+	}
+
+	dist := func(d0 hbook.Dist0D) hbook.Dist3D {
+		var d hbook.Dist3D
+		d.X.Dist = d0
+		d.Y.Dist = d0
+		d.Z.Dist = d0
+		return d
+	}
+
+	code := func(i, n int) int {
+		switch i {
+		case 0:
+			return -1
+		case n + 1:
+			return +1
+		}
+		return 0
+	}
+	for iz := 0; iz <= nz+1; iz++ {
+		for iy := 0; iy <= ny+1; iy++ {
+			for ix := 0; ix <= nx+1; ix++ {
+				d0 := h.dist0D(ix, iy, iz)
+				o := bng.Outflow(code(ix, nx), code(iy, ny), code(iz, nz))
+				if o == nil {
+					bin := &bng.Bins[((iz-1)*ny+iy-1)*nx+ix-1]
+					bin.Dist = dist(d0)
+					continue
+				}
+				d0.N += o.X.Dist.N
+				d0.SumW += o.X.Dist.SumW
+				d0.SumW2 += o.X.Dist.SumW2
+				*o = dist(d0)
+			}
+		}
+	}
+
+	bng.Dist = dist(hbook.Dist0D{
+		N:     int64(h.Entries()),
+		SumW:  h.SumW(),
+		SumW2: h.SumW2(),
+	})
+	bng.Dist.X.Stats.SumWX = h.SumWX()
+	bng.Dist.X.Stats.SumWX2 = h.SumWX2()
+	bng.Dist.Y.Stats.SumWX = h.SumWY()
+	bng.Dist.Y.Stats.SumWX2 = h.SumWY2()
+	bng.Dist.Z.Stats.SumWX = h.SumWZ()
+	bng.Dist.Z.Stats.SumWX2 = h.SumWZ2()
+	bng.Dist.Stats.SumWXY = h.SumWXY()
+	bng.Dist.Stats.SumWXZ = h.SumWXZ()
+	bng.Dist.Stats.SumWYZ = h.SumWYZ()
+
+	return hh
+}
+
+// dist0D returns the weight moments of the (ix,iy,iz) bin.
+func (h *H3I) dist0D(ix, iy, iz int) hbook.Dist0D {
+	var (
+		i     = h.bin(ix, iy, iz)
+		sumw  = float64(h.arr.Data[i])
+		sumw2 = math.Abs(sumw)
+	)
+	if len(h.th1.sumw2.Data) > 0 {
+		sumw2 = h.th1.sumw2.Data[i]
+	}
+	var n int64
+	if sumw > 0 && sumw2 > 0 {
+		// effective number of entries.
+		n = int64(sumw*sumw/sumw2 + 0.5)
+	}
+	return hbook.Dist0D{
+		N:     n,
+		SumW:  sumw,
+		SumW2: sumw2,
+	}
+}
+
+// bin returns the regularized bin number given an (x,y,z) bin index triplet.
+func (h *H3I) bin(ix, iy, iz int) int {
+	nx := h.th1.xaxis.nbins + 1 // overflow bin
+	ny := h.th1.yaxis.nbins + 1 // overflow bin
+	nz := h.th1.zaxis.nbins + 1 // overflow bin
+	switch {
+	case ix < 0:
+		ix = 0
+	case ix > nx:
+		ix = nx
+	}
+	switch {
+	case iy < 0:
+		iy = 0
+	case iy > ny:
+		iy = ny
+	}
+	switch {
+	case iz < 0:
+		iz = 0
+	case iz > nz:
+		iz = nz
+	}
+	return ix + (nx+1)*(iy+(ny+1)*iz)
+}
+
+func (h *H3I) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(h.Class(), h.RVersion())
+	w.WriteObject(&h.th3)
+	w.WriteObject(&h.arr)
+
+	return w.SetHeader(hdr)
+}
+
+func (h *H3I) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(h.Class(), h.RVersion())
+	if hdr.Vers < 1 {
+		return fmt.Errorf("rhist: TH3I version too old (%d<1)", hdr.Vers)
+	}
+
+	r.ReadObject(&h.th3)
+	r.ReadObject(&h.arr)
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (h *H3I) RMembers() (mbrs []rbytes.Member) {
+	mbrs = append(mbrs, h.th3.RMembers()...)
+	mbrs = append(mbrs, rbytes.Member{
+		Name: "fArray", Value: &h.arr.Data,
+	})
+	return mbrs
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := newH3I()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TH3I", f)
+}
+
+var (
+	_ root.Object        = (*H3I)(nil)
+	_ root.Named         = (*H3I)(nil)
+	_ H3                 = (*H3I)(nil)
+	_ rbytes.Marshaler   = (*H3I)(nil)
+	_ rbytes.Unmarshaler = (*H3I)(nil)
+	_ rbytes.RSlicer     = (*H3I)(nil)
+)
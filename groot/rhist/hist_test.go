@@ -16,6 +16,8 @@ import (
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/hbook"
 )
 
 func TestRWHist(t *testing.T) {
@@ -123,3 +125,55 @@ func TestROOT4Hist(t *testing.T) {
 		t.Fatalf("invalid H1D name: got=%q, want=%q", got, want)
 	}
 }
+
+func TestH2Merge(t *testing.T) {
+	fill := func(n int) *hbook.H2D {
+		h := hbook.NewH2D(5, 0, 5, 4, 0, 4)
+		for i := 0; i < n; i++ {
+			h.Fill(1, 1, 1)
+			h.Fill(2, 3, 2)
+			h.Fill(-1, 5, 1)
+		}
+		return h
+	}
+
+	type H2 interface {
+		root.Object
+		root.Merger
+		AsH2D() *hbook.H2D
+	}
+
+	for _, tc := range []struct {
+		name string
+		new  func(h *hbook.H2D) H2
+	}{
+		{"H2F", func(h *hbook.H2D) H2 { return rhist.NewH2FFrom(h) }},
+		{"H2D", func(h *hbook.H2D) H2 { return rhist.NewH2DFrom(h) }},
+		{"H2I", func(h *hbook.H2D) H2 { return rhist.NewH2IFrom(h) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				dst  = tc.new(fill(1))
+				src  = tc.new(fill(2))
+				want = tc.new(fill(3))
+			)
+
+			err := dst.ROOTMerge(src)
+			if err != nil {
+				t.Fatalf("could not merge histograms: %+v", err)
+			}
+
+			if got, want := dst.AsH2D(), want.AsH2D(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid merged histogram:\ngot= %+v\nwant=%+v", got, want)
+			}
+
+			err = dst.ROOTMerge(tc.new(hbook.NewH2D(5, 0, 5, 4, 0, 5)))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), `rhist: could not merge "": y-axis binnings differ`; got != want {
+				t.Fatalf("invalid error: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
 )
 
 // Profile1D is a 1-dim profile histogram.
@@ -33,6 +34,63 @@ func newProfile1D() *Profile1D {
 	}
 }
 
+// NewProfile1DFrom creates a new 1-dim profile histogram from hbook.
+func NewProfile1DFrom(p *hbook.P1D) *Profile1D {
+	var (
+		proot  = newProfile1D()
+		bins   = p.Binning().Bins()
+		nbins  = len(bins)
+		edges  = make([]float64, 0, nbins+1)
+		oflows = p.Binning().Outflows()
+		h1     = &proot.h1d.th1
+	)
+
+	h1.entries = float64(p.Entries())
+	h1.tsumw = p.SumW()
+	h1.tsumw2 = p.SumW2()
+	h1.tsumwx = p.SumWX()
+	h1.tsumwx2 = p.SumWX2()
+	h1.ncells = nbins + 2
+
+	h1.xaxis.nbins = nbins
+	h1.xaxis.xmin = p.XMin()
+	h1.xaxis.xmax = p.XMax()
+
+	proot.sumwy = p.SumWY()
+	proot.sumwy2 = p.SumWY2()
+
+	proot.h1d.arr.Data = make([]float64, nbins+2)
+	h1.sumw2.Data = make([]float64, nbins+2)
+	proot.binEntries.Data = make([]float64, nbins+2)
+	proot.binSumw2.Data = make([]float64, nbins+2)
+
+	set := func(i int, sumw, sumw2, sumwy, sumwy2 float64) {
+		proot.h1d.arr.Data[i] = sumwy
+		h1.sumw2.Data[i] = sumwy2
+		proot.binEntries.Data[i] = sumw
+		proot.binSumw2.Data[i] = sumw2
+	}
+
+	for i := range bins {
+		bin := &bins[i]
+		if i == 0 {
+			edges = append(edges, bin.XMin())
+		}
+		edges = append(edges, bin.XMax())
+		set(i+1, bin.SumW(), bin.SumW2(), bin.SumWY(), bin.SumWY2())
+	}
+	set(0, oflows[0].SumW(), oflows[0].SumW2(), oflows[0].SumWY(), oflows[0].SumWY2())
+	set(nbins+1, oflows[1].SumW(), oflows[1].SumW2(), oflows[1].SumWY(), oflows[1].SumWY2())
+
+	h1.SetName(p.Name())
+	if v, ok := p.Annotation()["title"]; ok && v != nil {
+		h1.SetTitle(v.(string))
+	}
+	h1.xaxis.xbins.Data = edges
+
+	return proot
+}
+
 func (*Profile1D) Class() string {
 	return "TProfile"
 }
@@ -41,6 +99,105 @@ func (*Profile1D) RVersion() int16 {
 	return rvers.Profile
 }
 
+// Name returns the name of this profile histogram.
+func (p *Profile1D) Name() string {
+	return p.h1d.Name()
+}
+
+// Title returns the title of this profile histogram.
+func (p *Profile1D) Title() string {
+	return p.h1d.Title()
+}
+
+// XAxis returns the axis along X.
+func (p *Profile1D) XAxis() Axis {
+	return p.h1d.XAxis()
+}
+
+// YAxis returns the axis along Y.
+func (p *Profile1D) YAxis() Axis {
+	return p.h1d.YAxis()
+}
+
+// AsP1D creates a new hbook.P1D from this ROOT profile histogram.
+func (p *Profile1D) AsP1D() *hbook.P1D {
+	var (
+		h1    = &p.h1d.th1
+		nbins = h1.xaxis.nbins
+		edges = h1.xaxis.xbins.Data
+		bins  = make([]hbook.Dist2D, nbins)
+	)
+	if len(edges) == 0 {
+		edges = make([]float64, nbins+1)
+		width := (h1.xaxis.xmax - h1.xaxis.xmin) / float64(nbins)
+		for i := range edges {
+			edges[i] = h1.xaxis.xmin + float64(i)*width
+		}
+		edges[nbins] = h1.xaxis.xmax
+	}
+
+	for i := range bins {
+		bins[i] = p.dist2D(i + 1)
+	}
+
+	dist := hbook.Dist2D{
+		X: hbook.Dist1D{
+			Dist: hbook.Dist0D{
+				N:     int64(h1.entries),
+				SumW:  h1.tsumw,
+				SumW2: h1.tsumw2,
+			},
+		},
+		Y: hbook.Dist1D{
+			Dist: hbook.Dist0D{
+				N:     int64(h1.entries),
+				SumW:  h1.tsumw,
+				SumW2: h1.tsumw2,
+			},
+		},
+	}
+	dist.X.Stats.SumWX = h1.tsumwx
+	dist.X.Stats.SumWX2 = h1.tsumwx2
+	dist.Y.Stats.SumWX = p.sumwy
+	dist.Y.Stats.SumWX2 = p.sumwy2
+
+	oflows := [2]hbook.Dist2D{
+		p.dist2D(0),         // underflow
+		p.dist2D(nbins + 1), // overflow
+	}
+
+	pp := hbook.NewP1DFromDists(edges, bins, oflows, dist)
+	pp.Annotation()["name"] = p.Name()
+	pp.Annotation()["title"] = p.Title()
+	return pp
+}
+
+// dist2D returns the distribution of the i-th cell of this profile histogram.
+// The per-bin moments along X are not stored by ROOT and are left empty.
+func (p *Profile1D) dist2D(i int) hbook.Dist2D {
+	var (
+		sumw  = p.binEntries.Data[i]
+		sumw2 = sumw
+		n     int64
+	)
+	if len(p.binSumw2.Data) > 0 {
+		sumw2 = p.binSumw2.Data[i]
+	}
+	if sumw2 > 0 {
+		n = int64(sumw*sumw/sumw2 + 0.5)
+	}
+	d0 := hbook.Dist0D{N: n, SumW: sumw, SumW2: sumw2}
+	d := hbook.Dist2D{
+		X: hbook.Dist1D{Dist: d0},
+		Y: hbook.Dist1D{Dist: d0},
+	}
+	d.Y.Stats.SumWX = p.h1d.arr.Data[i]
+	if len(p.h1d.th1.sumw2.Data) > 0 {
+		d.Y.Stats.SumWX2 = p.h1d.th1.sumw2.Data[i]
+	}
+	return d
+}
+
 // MarshalROOT implements rbytes.Marshaler
 func (p *Profile1D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
@@ -96,6 +253,7 @@ func init() {
 
 var (
 	_ root.Object        = (*Profile1D)(nil)
+	_ root.Named         = (*Profile1D)(nil)
 	_ rbytes.RVersioner  = (*Profile1D)(nil)
 	_ rbytes.Marshaler   = (*Profile1D)(nil)
 	_ rbytes.Unmarshaler = (*Profile1D)(nil)
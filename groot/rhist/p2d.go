@@ -13,6 +13,7 @@ import (
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
 )
 
 // Profile2D is a 2-dim profile histogram.
@@ -33,6 +34,101 @@ func newProfile2D() *Profile2D {
 	}
 }
 
+// NewProfile2DFrom creates a new 2-dim profile histogram from hbook.
+//
+// The outflows of the hbook profile histogram along the sides of the
+// (x,y) plane are stored in the first under/overflow cell of the
+// corresponding side.
+func NewProfile2DFrom(p *hbook.P2D) *Profile2D {
+	var (
+		proot  = newProfile2D()
+		bng    = p.Binning()
+		bins   = bng.Bins()
+		nx     = bng.Nx()
+		ny     = bng.Ny()
+		xedges = make([]float64, 0, nx+1)
+		yedges = make([]float64, 0, ny+1)
+		oflows = bng.Outflows()
+		h2     = &proot.h2d.th2
+		ncells = (nx + 2) * (ny + 2)
+	)
+
+	h2.th1.entries = float64(p.Entries())
+	h2.th1.tsumw = p.SumW()
+	h2.th1.tsumw2 = p.SumW2()
+	h2.th1.tsumwx = p.SumWX()
+	h2.th1.tsumwx2 = p.SumWX2()
+	h2.tsumwy = p.SumWY()
+	h2.tsumwy2 = p.SumWY2()
+	h2.tsumwxy = p.SumWXY()
+	h2.th1.ncells = ncells
+
+	h2.th1.xaxis.nbins = nx
+	h2.th1.xaxis.xmin = p.XMin()
+	h2.th1.xaxis.xmax = p.XMax()
+
+	h2.th1.yaxis.nbins = ny
+	h2.th1.yaxis.xmin = p.YMin()
+	h2.th1.yaxis.xmax = p.YMax()
+
+	proot.sumwz = p.SumWZ()
+	proot.sumwz2 = p.SumWZ2()
+
+	proot.h2d.arr.Data = make([]float64, ncells)
+	h2.th1.sumw2.Data = make([]float64, ncells)
+	proot.binEntries.Data = make([]float64, ncells)
+	proot.binSumw2.Data = make([]float64, ncells)
+
+	set := func(ix, iy int, d hbook.Dist3D) {
+		i := proot.h2d.bin(ix, iy)
+		proot.h2d.arr.Data[i] = d.SumWZ()
+		h2.th1.sumw2.Data[i] = d.SumWZ2()
+		proot.binEntries.Data[i] = d.SumW()
+		proot.binSumw2.Data[i] = d.SumW2()
+	}
+
+	for iy := 0; iy < ny; iy++ {
+		for ix := 0; ix < nx; ix++ {
+			bin := bins[iy*nx+ix]
+			if iy == 0 {
+				xedges = append(xedges, bin.XMin())
+			}
+			if ix == 0 {
+				yedges = append(yedges, bin.YMin())
+			}
+			i := proot.h2d.bin(ix+1, iy+1)
+			proot.h2d.arr.Data[i] = bin.SumWZ()
+			h2.th1.sumw2.Data[i] = bin.SumWZ2()
+			proot.binEntries.Data[i] = bin.SumW()
+			proot.binSumw2.Data[i] = bin.SumW2()
+		}
+	}
+	xedges = append(xedges, p.XMax())
+	yedges = append(yedges, p.YMax())
+
+	for i, v := range []struct{ ix, iy int }{
+		hbook.BngNW - 1: {0, ny + 1},
+		hbook.BngN - 1:  {1, ny + 1},
+		hbook.BngNE - 1: {nx + 1, ny + 1},
+		hbook.BngE - 1:  {nx + 1, 1},
+		hbook.BngSE - 1: {nx + 1, 0},
+		hbook.BngS - 1:  {1, 0},
+		hbook.BngSW - 1: {0, 0},
+		hbook.BngW - 1:  {0, 1},
+	} {
+		set(v.ix, v.iy, oflows[i])
+	}
+
+	h2.th1.SetName(p.Name())
+	if v, ok := p.Annotation()["title"]; ok && v != nil {
+		h2.th1.SetTitle(v.(string))
+	}
+	h2.th1.xaxis.xbins.Data = xedges
+	h2.th1.yaxis.xbins.Data = yedges
+
+	return proot
+}
+
 func (*Profile2D) Class() string {
 	return "TProfile2D"
 }
@@ -41,6 +137,16 @@ func (*Profile2D) RVersion() int16 {
 	return rvers.Profile2D
 }
 
+// Name returns the name of this profile histogram.
+func (p2d *Profile2D) Name() string {
+	return p2d.h2d.Name()
+}
+
+// Title returns the title of this profile histogram.
+func (p2d *Profile2D) Title() string {
+	return p2d.h2d.Title()
+}
+
 // MarshalROOT implements rbytes.Marshaler
 func (p2d *Profile2D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
@@ -96,6 +202,7 @@ func init() {
 
 var (
 	_ root.Object        = (*Profile2D)(nil)
+	_ root.Named         = (*Profile2D)(nil)
 	_ rbytes.RVersioner  = (*Profile2D)(nil)
 	_ rbytes.Marshaler   = (*Profile2D)(nil)
 	_ rbytes.Unmarshaler = (*Profile2D)(nil)
@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"go-hep.org/x/hep/groot/internal/rtests"
+	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/hbook"
@@ -142,6 +143,59 @@ func TestCreate(t *testing.T) {
 				}(),
 			},
 		},
+		{
+			Name: "TProfile",
+			ROOT: "retrieved: [p1d]\n",
+			Want: []rtests.ROOTer{
+				func() *rhist.Profile1D {
+					p := hbook.NewP1D(10, 0, 10)
+					p.Annotation()["name"] = "p1d"
+					p.Annotation()["title"] = "my title"
+					p.Fill(-1, 1, 1)
+					p.Fill(+20, 2, 1)
+					p.Fill(1, 1, 1)
+					p.Fill(1, 3, 2)
+					p.Fill(3, 10, 1)
+					return rhist.NewProfile1DFrom(p)
+				}(),
+			},
+		},
+		{
+			Name: "TProfile2D",
+			ROOT: "retrieved: [p2d]\n",
+			Want: []rtests.ROOTer{
+				func() *rhist.Profile2D {
+					p := hbook.NewP2D(5, 0, 5, 2, -1, +1)
+					p.Annotation()["name"] = "p2d"
+					p.Annotation()["title"] = "my title"
+					p.Fill(-1, 0, 1, 1)
+					p.Fill(+20, 2, 2, 1)
+					p.Fill(1, -0.5, 1, 1)
+					p.Fill(1, -0.5, 3, 2)
+					p.Fill(3, +0.5, 10, 1)
+					return rhist.NewProfile2DFrom(p)
+				}(),
+			},
+		},
+		{
+			Name: "TEfficiency",
+			ROOT: "retrieved: [eff]\n",
+			Want: []rtests.ROOTer{
+				func() *rhist.Efficiency {
+					e := hbook.NewEff1D(10, 0, 10)
+					e.Annotation()["name"] = "eff"
+					e.Annotation()["title"] = "my title"
+					e.Stat = hbook.EffBayesian
+					e.BinPriors = [][2]float64{{1, 2}, {2, 3}}
+					e.Fill(-1, true, 1)
+					e.Fill(1, true, 1)
+					e.Fill(1, false, 1)
+					e.Fill(3, false, 2)
+					e.Fill(20, true, 1)
+					return rhist.NewEfficiencyFrom(e)
+				}(),
+			},
+		},
 		{
 			Name: "TGraph",
 			ROOT: "retrieved: [tg]\n",
@@ -258,6 +312,20 @@ func TestCreate(t *testing.T) {
 						t.Fatalf("error reading back value[%d].\ngot:\n%s\nwant:\n%s", i, got, want)
 					}
 
+				case *rhist.Profile1D, *rhist.Profile2D, *rhist.Efficiency:
+					// no YODA conversion for these ROOT types: compare ROOT encodings.
+					enc := func(v rtests.ROOTer) []byte {
+						wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+						_, err := v.MarshalROOT(wbuf)
+						if err != nil {
+							t.Fatalf("could not marshal ROOT: %+v", err)
+						}
+						return wbuf.Bytes()
+					}
+					if got, want := enc(rgot.(rtests.ROOTer)), enc(want); !bytes.Equal(got, want) {
+						t.Fatalf("error reading back value[%d].\ngot = %#v\nwant= %#v", i, rgot, tc.Want[i])
+					}
+
 				default:
 					if got := rgot.(rtests.ROOTer); !reflect.DeepEqual(got, want) {
 						t.Fatalf("error reading back value[%d].\ngot = %#v\nwant= %#v", i, got, want)
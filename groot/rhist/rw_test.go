@@ -6,6 +6,7 @@ package rhist
 
 import (
 	"io"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -16,6 +17,8 @@ import (
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
 )
 
 func TestWRBuffer(t *testing.T) {
@@ -436,3 +439,417 @@ func TestGraphObjectFinder(t *testing.T) {
 		t.Fatalf("invalid element name: got=%q, want=%q", got, want)
 	}
 }
+
+func TestProfile1DFrom(t *testing.T) {
+	p := hbook.NewP1D(4, 0, 4)
+	p.Annotation()["name"] = "p1d"
+	p.Annotation()["title"] = "my title"
+	p.Fill(-1, 1, 1)
+	p.Fill(1, 1, 1)
+	p.Fill(1, 3, 2)
+	p.Fill(3, 10, 1)
+	p.Fill(10, 2, 3)
+
+	pr := NewProfile1DFrom(p)
+	if got, want := pr.Name(), "p1d"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := pr.Title(), "my title"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{"sumwy", pr.h1d.arr.Data, []float64{1, 0, 7, 0, 10, 6}},
+		{"sumwy2", pr.h1d.th1.sumw2.Data, []float64{1, 0, 19, 0, 100, 12}},
+		{"entries", pr.binEntries.Data, []float64{1, 0, 3, 0, 1, 3}},
+		{"sumw2", pr.binSumw2.Data, []float64{1, 0, 5, 0, 1, 9}},
+		{"xbins", pr.h1d.th1.xaxis.xbins.Data, []float64{0, 1, 2, 3, 4}},
+	} {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Fatalf("invalid %s:\ngot= %v\nwant=%v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	if got, want := pr.h1d.th1.entries, 5.0; got != want {
+		t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+	}
+	if got, want := pr.sumwy, 24.0; got != want {
+		t.Fatalf("invalid sum of w*y: got=%v, want=%v", got, want)
+	}
+	if got, want := pr.sumwy2, 132.0; got != want {
+		t.Fatalf("invalid sum of w*y*y: got=%v, want=%v", got, want)
+	}
+}
+
+func TestProfile2DFrom(t *testing.T) {
+	p := hbook.NewP2D(2, 0, 2, 2, 0, 2)
+	p.Annotation()["name"] = "p2d"
+	p.Annotation()["title"] = "my title"
+	p.Fill(-1, 1, 1, 1)
+	p.Fill(1, 0, 1, 1)
+	p.Fill(1, 0, 3, 2)
+	p.Fill(0, 1, 10, 1)
+	p.Fill(10, 10, 2, 3)
+
+	pr := NewProfile2DFrom(p)
+	if got, want := pr.Name(), "p2d"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := pr.Title(), "my title"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+
+	// cells are indexed as ix + (nx+2)*iy.
+	for _, tc := range []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{"sumwz", pr.h2d.arr.Data, []float64{0, 0, 0, 0, 1, 0, 7, 0, 0, 10, 0, 0, 0, 0, 0, 6}},
+		{"sumwz2", pr.h2d.th1.sumw2.Data, []float64{0, 0, 0, 0, 1, 0, 19, 0, 0, 100, 0, 0, 0, 0, 0, 12}},
+		{"entries", pr.binEntries.Data, []float64{0, 0, 0, 0, 1, 0, 3, 0, 0, 1, 0, 0, 0, 0, 0, 3}},
+		{"sumw2", pr.binSumw2.Data, []float64{0, 0, 0, 0, 1, 0, 5, 0, 0, 1, 0, 0, 0, 0, 0, 9}},
+		{"xbins", pr.h2d.th1.xaxis.xbins.Data, []float64{0, 1, 2}},
+		{"ybins", pr.h2d.th1.yaxis.xbins.Data, []float64{0, 1, 2}},
+	} {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Fatalf("invalid %s:\ngot= %v\nwant=%v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	if got, want := pr.h2d.th1.entries, 5.0; got != want {
+		t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+	}
+	if got, want := pr.sumwz, 24.0; got != want {
+		t.Fatalf("invalid sum of w*z: got=%v, want=%v", got, want)
+	}
+	if got, want := pr.sumwz2, 132.0; got != want {
+		t.Fatalf("invalid sum of w*z*z: got=%v, want=%v", got, want)
+	}
+}
+
+func TestH3(t *testing.T) {
+	const (
+		nx, ny, nz = 2, 3, 4
+		ncells     = (nx + 2) * (ny + 2) * (nz + 2)
+	)
+	newH3 := func() *th3 {
+		h := newH3()
+		h.SetName("h3")
+		h.SetTitle("my title")
+		h.ncells = ncells
+		h.xaxis.nbins, h.xaxis.xmin, h.xaxis.xmax = nx, 0, 2
+		h.yaxis.nbins, h.yaxis.xmin, h.yaxis.xmax = ny, 0, 3
+		h.zaxis.nbins, h.zaxis.xmin, h.zaxis.xmax = nz, 0, 4
+		h.entries = 2
+		h.tsumw = 3
+		h.tsumw2 = 5
+		h.tsumwx = 4
+		h.tsumwx2 = 6
+		h.tsumwy = 7
+		h.tsumwy2 = 17
+		h.tsumwxy = 10
+		h.tsumwz = 8
+		h.tsumwz2 = 24
+		h.tsumwxz = 11
+		h.tsumwyz = 18
+		h.sumw2.Data = make([]float64, ncells)
+		h.funcs = *rcont.NewList("", []root.Object{})
+		return h
+	}
+
+	hf := &H3F{th3: *newH3(), arr: rcont.ArrayF{Data: make([]float32, ncells)}}
+	hd := &H3D{th3: *newH3(), arr: rcont.ArrayD{Data: make([]float64, ncells)}}
+	hi := &H3I{th3: *newH3(), arr: rcont.ArrayI{Data: make([]int32, ncells)}}
+	for _, v := range []struct {
+		ix, iy, iz int
+		w          float64
+	}{
+		{1, 2, 3, 1},
+		{2, 1, 4, 2},
+	} {
+		i := hf.bin(v.ix, v.iy, v.iz)
+		hf.arr.Data[i] = float32(v.w)
+		hd.arr.Data[i] = v.w
+		hi.arr.Data[i] = int32(v.w)
+		for _, h := range []*th3{&hf.th3, &hd.th3, &hi.th3} {
+			h.sumw2.Data[i] = v.w * v.w
+		}
+	}
+
+	fname := filepath.Join(t.TempDir(), "h3.root")
+	w, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range []root.Object{hf, hd, hi} {
+		err = riofs.Dir(w).Put(h.Class(), h)
+		if err != nil {
+			t.Fatalf("could not write %s: %+v", h.Class(), err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for _, v := range []struct {
+		name string
+		vers int
+	}{
+		{"TAtt3D", rvers.Att3D},
+		{"TH3", rvers.H3},
+		{"TH3F", rvers.H3F},
+		{"TH3D", rvers.H3D},
+		{"TH3I", rvers.H3I},
+	} {
+		si, err := r.StreamerInfo(v.name, -1)
+		if err != nil {
+			t.Fatalf("could not find streamer for %q: %+v", v.name, err)
+		}
+		if got, want := si.ClassVersion(), v.vers; got != want {
+			t.Fatalf("invalid %s version: got=%d, want=%d", v.name, got, want)
+		}
+	}
+
+	type h3 interface {
+		H3
+		BinContent(ix, iy, iz int) float64
+		BinError(ix, iy, iz int) float64
+	}
+
+	for _, want := range []h3{hf, hd, hi} {
+		name := want.Name()
+		obj, err := riofs.Dir(r).Get(want.(root.Object).Class())
+		if err != nil {
+			t.Fatalf("could not read %s: %+v", name, err)
+		}
+		got := obj.(h3)
+		if got, want := got.SumWYZ(), want.SumWYZ(); got != want {
+			t.Fatalf("invalid sum of w*y*z: got=%v, want=%v", got, want)
+		}
+		if got, want := got.BinContent(2, 1, 4), 2.0; got != want {
+			t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+		}
+		if got, want := got.BinError(1, 2, 3), 1.0; got != want {
+			t.Fatalf("invalid bin error: got=%v, want=%v", got, want)
+		}
+		if got, want := got.BinContent(0, 0, 0), 0.0; got != want {
+			t.Fatalf("invalid underflow: got=%v, want=%v", got, want)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("error reading back %s:\ngot= %+v\nwant=%+v", obj.Class(), got, want)
+		}
+	}
+}
+
+func TestEfficiency(t *testing.T) {
+	f, err := riofs.Open("../testdata/tconfidence-level.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get("eff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eff := obj.(*Efficiency)
+	if got, want := eff.Name(), "eff"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := eff.Passed().Name(), "eff_passed"; got != want {
+		t.Fatalf("invalid passed name: got=%q, want=%q", got, want)
+	}
+	if got, want := eff.Total().Name(), "eff_total"; got != want {
+		t.Fatalf("invalid total name: got=%q, want=%q", got, want)
+	}
+
+	e, err := eff.AsEff1D()
+	if err != nil {
+		t.Fatalf("could not convert efficiency: %+v", err)
+	}
+	if got, want := e.Len(), 20; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+	if got, want := e.Stat, hbook.EffClopperPearson; got != want {
+		t.Fatalf("invalid statistic: got=%v, want=%v", got, want)
+	}
+	if got, want := e.CL, 0.682689492137; got != want {
+		t.Fatalf("invalid confidence level: got=%v, want=%v", got, want)
+	}
+	if got, want := e.BinPriors[:3], [][2]float64{{1, 2}, {2, 3}, {1, 1}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid bin priors: got=%v, want=%v", got, want)
+	}
+
+	// round-trip.
+	e.Stat = hbook.EffWilson
+	e.Fill(1, true, 1)
+	e.Fill(1, false, 1)
+	e.Fill(1, true, 1)
+
+	rt := NewEfficiencyFrom(e)
+	if !reflect.DeepEqual(rt.betaBinParams, eff.betaBinParams) {
+		t.Fatalf("invalid bin priors:\ngot= %v\nwant=%v", rt.betaBinParams, eff.betaBinParams)
+	}
+	if !rt.named.TestBits(effUseBinPrior) {
+		t.Fatalf("missing bin-prior status bit")
+	}
+	if rt.named.TestBits(effIsBayesian) {
+		t.Fatalf("unexpected bayesian status bit")
+	}
+
+	got, err := rt.AsEff1D()
+	if err != nil {
+		t.Fatalf("could not convert efficiency: %+v", err)
+	}
+	for i := 0; i < e.Len(); i++ {
+		if got, want := got.Eff(i), e.Eff(i); got != want {
+			t.Fatalf("invalid efficiency for bin %d: got=%v, want=%v", i, got, want)
+		}
+		glo, ghi := got.Interval(i)
+		wlo, whi := e.Interval(i)
+		if glo != wlo || ghi != whi {
+			t.Fatalf("invalid interval for bin %d: got=[%v, %v], want=[%v, %v]", i, glo, ghi, wlo, whi)
+		}
+	}
+}
+
+func TestEfficiency2D(t *testing.T) {
+	eff := NewEfficiencyFrom(hbook.NewEff1D(10, 0, 10))
+	eff.named.SetName("eff2d")
+
+	passed := NewH2DFrom(hbook.NewH2D(4, 0, 4, 5, 0, 5))
+	passed.SetName("eff2d_passed")
+	total := NewH2DFrom(hbook.NewH2D(4, 0, 4, 5, 0, 5))
+	total.SetName("eff2d_total")
+	eff.passedHist = passed
+	eff.totHist = total
+
+	fname := filepath.Join(t.TempDir(), "eff2d.root")
+	w, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = riofs.Dir(w).Put("eff2d", eff)
+	if err != nil {
+		t.Fatalf("could not write efficiency: %+v", err)
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	obj, err := riofs.Dir(r).Get("eff2d")
+	if err != nil {
+		t.Fatalf("could not read efficiency: %+v", err)
+	}
+	got := obj.(*Efficiency)
+	if _, ok := got.Passed().(H2); !ok {
+		t.Fatalf("invalid passed histogram type %T", got.Passed())
+	}
+	if _, ok := got.Total().(H2); !ok {
+		t.Fatalf("invalid total histogram type %T", got.Total())
+	}
+
+	_, err = got.AsEff1D()
+	if err == nil {
+		t.Fatalf("expected an error converting a 2-dim efficiency")
+	}
+}
+
+func TestGraphErrorsRange(t *testing.T) {
+	s2 := hbook.NewS2D(
+		hbook.Point2D{X: 1, Y: 2, ErrY: hbook.Range{Min: 1, Max: 3}},
+		hbook.Point2D{X: 2, Y: 4, ErrY: hbook.Range{Min: 5, Max: 1}},
+	)
+
+	for _, tc := range []struct {
+		name     string
+		g        GraphErrors
+		min, max float64
+	}{
+		{"TGraphErrors", NewGraphErrorsFrom(s2), -1, 9},
+		{"TGraphAsymmErrors", NewGraphAsymmErrorsFrom(s2), -1, 5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var min, max float64
+			switch g := tc.g.(type) {
+			case *tgrapherrs:
+				min, max = g.min, g.max
+			case *tgraphasymmerrs:
+				min, max = g.min, g.max
+			}
+			if min != tc.min || max != tc.max {
+				t.Fatalf("invalid range: got=[%v, %v], want=[%v, %v]", min, max, tc.min, tc.max)
+			}
+		})
+	}
+}
+
+func TestAxisBinLabel(t *testing.T) {
+	newLabel := func(bin int, txt string) root.Object {
+		obj := rbase.NewObject()
+		obj.SetID(uint32(bin))
+
+		wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+		hdr := wbuf.WriteHeader("TObjString", rvers.ObjString)
+		wbuf.WriteObject(obj)
+		wbuf.WriteString(txt)
+		_, err := wbuf.SetHeader(hdr)
+		if err != nil {
+			t.Fatalf("could not marshal label %q: %+v", txt, err)
+		}
+
+		lbl := rbase.NewObjString("")
+		err = lbl.UnmarshalROOT(rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, nil))
+		if err != nil {
+			t.Fatalf("could not unmarshal label %q: %+v", txt, err)
+		}
+		return lbl
+	}
+
+	axis := NewAxis("xaxis")
+	axis.nbins = 3
+	axis.xmax = 3
+
+	if got, want := axis.BinLabel(1), ""; got != want {
+		t.Fatalf("invalid label: got=%q, want=%q", got, want)
+	}
+
+	axis.labels = &rcont.HashList{List: *rcont.NewList("", []root.Object{
+		newLabel(1, "ee"),
+		newLabel(3, "mumu"),
+	})}
+
+	for _, tc := range []struct {
+		bin  int
+		want string
+	}{
+		{0, ""},
+		{1, "ee"},
+		{2, ""},
+		{3, "mumu"},
+		{4, ""},
+	} {
+		if got := axis.BinLabel(tc.bin); got != tc.want {
+			t.Fatalf("invalid label for bin %d: got=%q, want=%q", tc.bin, got, tc.want)
+		}
+	}
+}
@@ -5,6 +5,8 @@
 package riofs
 
 import (
+	"fmt"
+
 	"go-hep.org/x/hep/groot/internal/rcompress"
 )
 
@@ -12,6 +14,23 @@ func (f *File) setCompression(alg rcompress.Kind, lvl int) {
 	f.compression = rcompress.Settings{Alg: alg, Lvl: lvl}.Compression()
 }
 
+// WithCompression configures a ROOT file to use the provided compression
+// scheme, encoded as ROOT does: 100*algorithm + level.
+// e.g. 404 selects LZ4 at level 4 and 505 selects ZSTD at level 5.
+func WithCompression(compression int32) FileOption {
+	return func(f *File) error {
+		cfg := rcompress.SettingsFrom(compression)
+		switch cfg.Alg {
+		case 0, rcompress.ZLIB, rcompress.LZMA, rcompress.LZ4, rcompress.ZSTD:
+			// ok.
+		default:
+			return fmt.Errorf("riofs: invalid compression algorithm %v (compression=%d)", cfg.Alg, compression)
+		}
+		f.setCompression(cfg.Alg, cfg.Lvl)
+		return nil
+	}
+}
+
 // WithLZ4 configures a ROOT file to use LZ4 as a compression mechanism.
 func WithLZ4(level int) FileOption {
 	return func(f *File) error {
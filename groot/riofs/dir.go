@@ -746,7 +746,19 @@ func isCoreType(typename string) bool {
 
 func isCxxBuiltin(typename string) bool {
 	_, ok := rmeta.CxxBuiltins[typename]
-	return ok
+	if ok {
+		return true
+	}
+	if !strings.HasPrefix(typename, "pair<") && !strings.HasPrefix(typename, "std::pair<") {
+		return false
+	}
+	// std::pair<K,V> of C++ builtins have no streamer.
+	for _, arg := range rmeta.CxxTemplateFrom(typename).Args {
+		if _, ok := rmeta.CxxBuiltins[arg]; !ok {
+			return false
+		}
+	}
+	return true
 }
 
 var (
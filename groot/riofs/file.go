@@ -287,6 +287,13 @@ func (f *File) Version() int {
 	return int(f.version)
 }
 
+// End returns the position of the end of the file, as recorded in its header.
+// The end of a file being written to grows each time its writer saves the
+// file header.
+func (f *File) End() int64 {
+	return f.end
+}
+
 func (f *File) readHeader() error {
 
 	buf := make([]byte, 64+12) // 64: small file + extra space for big file
@@ -615,7 +622,7 @@ func (f *File) findDepStreamers() error {
 				deps = append(deps, depsType{se.TypeName(), -1})
 
 			case *rdict.StreamerSTL:
-				for _, etn := range se.ElemTypeName() {
+				for _, etn := range stdElemTypes(se.ElemTypeName()) {
 					deps = append(deps, depsType{etn, -1})
 				}
 			}
@@ -640,6 +647,35 @@ func (f *File) findDepStreamers() error {
 	return nil
 }
 
+// stdElemTypes returns the names of the provided STL element types,
+// looking through nested STL containers.
+func stdElemTypes(names []string) []string {
+	var o []string
+	for _, name := range names {
+		if isStdContainer(name) {
+			o = append(o, stdElemTypes(rmeta.CxxTemplateFrom(name).Args)...)
+			continue
+		}
+		o = append(o, name)
+	}
+	return o
+}
+
+func isStdContainer(typename string) bool {
+	for _, p := range []string{
+		"vector", "list", "deque",
+		"set", "multiset", "unordered_set", "unordered_multiset",
+		"map", "multimap", "unordered_map", "unordered_multimap",
+	} {
+		switch {
+		case strings.HasPrefix(typename, p+"<"),
+			strings.HasPrefix(typename, "std::"+p+"<"):
+			return true
+		}
+	}
+	return false
+}
+
 // markFree marks unused bytes on the file.
 // it's the equivalent of slice[beg:end] = nil.
 func (f *File) markFree(beg, end int64) {
@@ -15,14 +15,36 @@ import (
 	"github.com/go-mmap/mmap"
 )
 
-var drivers = struct {
+type driverDB struct {
 	sync.RWMutex
 	db map[string]func(path string) (Reader, error)
-}{
+}
+
+// names returns the sorted list of registered plugins.
+// names must be called with the lock held.
+func (db *driverDB) names() []string {
+	names := make([]string, 0, len(db.db))
+	for name := range db.db {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var drivers = driverDB{
 	db: make(map[string]func(path string) (Reader, error)),
 }
 
 // Register registers a plugin to open ROOT files.
+// Plugins are selected by riofs.Open from the URL scheme of the file to
+// open (e.g. "root" for "root://server/file.root").
+//
+// Plugins usually call Register from the init function of their package,
+// so that importing the package for its side effects is enough to enable
+// a new transport:
+//
+//	import _ "go-hep.org/x/hep/groot/riofs/plugin/http"
+//
 // Register panics if it is called twice with the same name of if the plugin
 // function is nil.
 func Register(name string, f func(path string) (Reader, error)) {
@@ -42,31 +64,53 @@ func Register(name string, f func(path string) (Reader, error)) {
 func Drivers() []string {
 	drivers.RLock()
 	defer drivers.RUnlock()
-	names := make([]string, 0, len(drivers.db))
-	for name := range drivers.db {
-		names = append(names, name)
+	return drivers.names()
+}
+
+// Registered returns whether a plugin has been registered for the
+// provided URL scheme.
+func Registered(scheme string) bool {
+	drivers.RLock()
+	defer drivers.RUnlock()
+	_, ok := drivers.db[scheme]
+	return ok
+}
+
+// schemeOf returns the URL scheme of the provided path.
+// schemeOf returns "file" for local paths, including Windows-like paths
+// starting with a drive letter.
+func schemeOf(path string) string {
+	u, err := url.Parse(path)
+	if err != nil || len(u.Scheme) <= 1 {
+		return "file"
 	}
-	sort.Strings(names)
-	return names
+	return strings.ToLower(u.Scheme)
 }
 
 func openFile(path string) (Reader, error) {
 	drivers.RLock()
 	defer drivers.RUnlock()
 
-	if f, err := openLocalFile(path); err == nil {
-		return f, nil
+	scheme := schemeOf(path)
+	if scheme == "file" {
+		if f, err := openLocalFile(path); err == nil {
+			return f, nil
+		}
 	}
 
-	scheme := "file"
-	if u, err := url.Parse(path); err == nil {
-		scheme = u.Scheme
-	}
 	if open, ok := drivers.db[scheme]; ok {
 		return open(path)
 	}
 
-	return nil, fmt.Errorf("riofs: no ROOT plugin to open [%s] (scheme=%s)", path, scheme)
+	if f, err := openLocalFile(path); err == nil {
+		// a local file whose name looks like a URL.
+		return f, nil
+	}
+
+	return nil, fmt.Errorf(
+		"riofs: no ROOT plugin to open [%s] (scheme=%s, registered=%v)",
+		path, scheme, drivers.names(),
+	)
 }
 
 func openLocalFile(path string) (Reader, error) {
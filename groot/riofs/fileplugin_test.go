@@ -63,3 +63,64 @@ func TestDrivers(t *testing.T) {
 		t.Fatalf("got=%v, want=%v", got, want)
 	}
 }
+
+func TestSchemeOf(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		want string
+	}{
+		{"file.root", "file"},
+		{"./dir/file.root", "file"},
+		{"/dir/file.root", "file"},
+		{"file:///dir/file.root", "file"},
+		{"C:/dir/file.root", "file"},
+		{"root://server:1094/dir/file.root", "root"},
+		{"HTTPS://example.org/file.root", "https"},
+		{"s3://bucket/file.root", "s3"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			if got, want := schemeOf(tc.path), tc.want; got != want {
+				t.Fatalf("invalid scheme: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestOpenPlugin(t *testing.T) {
+	const name = "test-open-plugin"
+	defer func() {
+		drivers.Lock()
+		defer drivers.Unlock()
+		delete(drivers.db, name)
+	}()
+
+	if Registered(name) {
+		t.Fatalf("plugin %q should not be registered", name)
+	}
+
+	var got string
+	Register(name, func(path string) (Reader, error) {
+		got = path
+		return openLocalFile("../testdata/simple.root")
+	})
+
+	if !Registered(name) {
+		t.Fatalf("plugin %q should be registered", name)
+	}
+
+	const path = name + "://server/simple.root"
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("could not open %q: %+v", path, err)
+	}
+	defer f.Close()
+
+	if got != path {
+		t.Fatalf("invalid path: got=%q, want=%q", got, path)
+	}
+
+	_, err = Open("no-such-plugin://server/simple.root")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
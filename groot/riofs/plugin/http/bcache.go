@@ -0,0 +1,249 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"sync"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// bcache is a reader that fetches fixed-size blocks from a remote resource
+// and keeps the most recently used ones in memory.
+//
+// Contiguous missing blocks are coalesced into a single request, and
+// blocks following a read can be fetched ahead of time.
+// Read-ahead blocks are fetched with their own requests, so reads never
+// wait for them.
+type bcache struct {
+	r    io.ReaderAt
+	c    io.Closer
+	size int64 // size of the remote resource
+
+	blksz int64 // size of a block
+	ahead int   // number of blocks to read ahead
+	nmax  int   // maximum number of blocks held in memory
+
+	mu   sync.Mutex
+	lru  *list.List              // list of *block, most recently used first
+	blks map[int64]*list.Element // block index -> element in lru
+	pos  int64                   // current position for Read
+
+	stats struct {
+		hits   int64
+		misses int64
+		reqs   int64 // number of remote requests
+	}
+}
+
+type block struct {
+	idx  int64
+	buf  []byte
+	err  error
+	done chan struct{}
+}
+
+func newBCache(r reader, size int64, cfg *config) *bcache {
+	return &bcache{
+		r:     r,
+		c:     r,
+		size:  size,
+		blksz: cfg.blksz,
+		ahead: cfg.ahead,
+		nmax:  cfg.nblks,
+		lru:   list.New(),
+		blks:  make(map[int64]*list.Element),
+	}
+}
+
+// Close implements io.Closer.
+func (r *bcache) Close() error {
+	r.mu.Lock()
+	r.lru.Init()
+	r.blks = make(map[int64]*list.Element)
+	r.mu.Unlock()
+	return r.c.Close()
+}
+
+// Size returns the size of the remote resource.
+func (r *bcache) Size() int64 {
+	return r.size
+}
+
+// Read implements io.Reader.
+func (r *bcache) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	pos := r.pos
+	r.mu.Unlock()
+
+	n, err := r.ReadAt(p, pos)
+
+	r.mu.Lock()
+	r.pos = pos + int64(n)
+	r.mu.Unlock()
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *bcache) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("riofs/http: negative offset %d", off)
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	end := off + int64(len(p))
+	if end > r.size {
+		end = r.size
+	}
+
+	var (
+		beg  = off / r.blksz
+		last = (end - 1) / r.blksz
+		blks = r.blocks(beg, last)
+		n    int
+	)
+
+	for _, blk := range blks {
+		<-blk.done
+		if blk.err != nil {
+			return n, blk.err
+		}
+		boff := blk.idx * r.blksz
+		lo := off + int64(n) - boff
+		n += copy(p[n:], blk.buf[lo:])
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// blocks returns the blocks [beg, last], issuing remote requests for
+// the missing ones as well as for the read-ahead blocks.
+func (r *bcache) blocks(beg, last int64) []*block {
+	nblks := r.size / r.blksz
+	if r.size%r.blksz != 0 {
+		nblks++
+	}
+
+	ahead := last + int64(r.ahead)
+	if ahead >= nblks {
+		ahead = nblks - 1
+	}
+
+	var (
+		blks = make([]*block, 0, last-beg+1)
+		miss []*block
+	)
+
+	r.mu.Lock()
+	for idx := beg; idx <= ahead; idx++ {
+		elmt, ok := r.blks[idx]
+		switch {
+		case ok:
+			r.lru.MoveToFront(elmt)
+			if idx <= last {
+				r.stats.hits++
+			}
+		default:
+			blk := &block{idx: idx, done: make(chan struct{})}
+			elmt = r.lru.PushFront(blk)
+			r.blks[idx] = elmt
+			miss = append(miss, blk)
+			if idx <= last {
+				r.stats.misses++
+			}
+		}
+		if idx <= last {
+			blks = append(blks, elmt.Value.(*block))
+		}
+	}
+	r.evict()
+	r.mu.Unlock()
+
+	// coalesce contiguous missing blocks into a single request,
+	// keeping the requested blocks apart from the read-ahead ones.
+	for i := 0; i < len(miss); {
+		var (
+			j    = i + 1
+			want = miss[i].idx <= last
+		)
+		for j < len(miss) && miss[j].idx == miss[j-1].idx+1 && (miss[j].idx <= last) == want {
+			j++
+		}
+		go r.fetch(miss[i:j])
+		i = j
+	}
+
+	return blks
+}
+
+// evict removes the least recently used blocks from the cache.
+// evict must be called with the lock held.
+func (r *bcache) evict() {
+	for r.lru.Len() > r.nmax {
+		elmt := r.lru.Back()
+		blk := r.lru.Remove(elmt).(*block)
+		delete(r.blks, blk.idx)
+	}
+}
+
+// fetch retrieves the provided contiguous blocks with a single request.
+func (r *bcache) fetch(blks []*block) {
+	var (
+		beg = blks[0].idx * r.blksz
+		end = (blks[len(blks)-1].idx + 1) * r.blksz
+	)
+	if end > r.size {
+		end = r.size
+	}
+
+	buf := make([]byte, end-beg)
+	n, err := r.r.ReadAt(buf, beg)
+	if err == io.EOF && int64(n) == end-beg {
+		err = nil
+	}
+	if err != nil {
+		err = fmt.Errorf("riofs/http: could not fetch range [%d, %d): %w", beg, end, err)
+	}
+
+	r.mu.Lock()
+	r.stats.reqs++
+	for _, blk := range blks {
+		lo := blk.idx*r.blksz - beg
+		hi := lo + r.blksz
+		if hi > int64(len(buf)) {
+			hi = int64(len(buf))
+		}
+		blk.buf = buf[lo:hi:hi]
+		blk.err = err
+		if err != nil {
+			// do not keep failed blocks around, so they may be retried.
+			if elmt, ok := r.blks[blk.idx]; ok && elmt.Value.(*block) == blk {
+				r.lru.Remove(elmt)
+				delete(r.blks, blk.idx)
+			}
+		}
+		close(blk.done)
+	}
+	r.mu.Unlock()
+}
+
+var (
+	_ riofs.Reader = (*bcache)(nil)
+)
@@ -0,0 +1,227 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/groot/riofs"
+	_ "go-hep.org/x/hep/groot/ztypes"
+)
+
+type countingServer struct {
+	n atomic.Int64
+	h http.Handler
+}
+
+func (srv *countingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		srv.n.Add(1)
+	}
+	srv.h.ServeHTTP(w, r)
+}
+
+func TestBCache(t *testing.T) {
+	want, err := os.ReadFile("../../../testdata/simple.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cnt := &countingServer{h: http.FileServer(http.Dir("../../../testdata"))}
+	srv := httptest.NewServer(cnt)
+	defer srv.Close()
+
+	const blksz = 512
+	r, err := Open(
+		srv.URL+"/simple.root",
+		WithBlockSize(blksz), WithReadAhead(2), WithCacheSize(4),
+	)
+	if err != nil {
+		t.Fatalf("could not open remote file: %+v", err)
+	}
+	defer r.Close()
+
+	bc, ok := r.(*bcache)
+	if !ok {
+		t.Fatalf("invalid reader type %T", r)
+	}
+	if got, want := bc.Size(), int64(len(want)); got != want {
+		t.Fatalf("invalid size: got=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		off, len int64
+	}{
+		{0, 10},
+		{10, 100},
+		{0, blksz},
+		{blksz - 10, 20},
+		{3 * blksz, 3 * blksz},
+		{int64(len(want)) - 10, 10},
+		{0, int64(len(want))},
+	} {
+		got := make([]byte, tc.len)
+		n, err := bc.ReadAt(got, tc.off)
+		if err != nil {
+			t.Fatalf("could not read [%d, %d): %+v", tc.off, tc.off+tc.len, err)
+		}
+		if n != len(got) {
+			t.Fatalf("invalid number of bytes: got=%d, want=%d", n, len(got))
+		}
+		if !bytes.Equal(got, want[tc.off:tc.off+tc.len]) {
+			t.Fatalf("invalid content for [%d, %d)", tc.off, tc.off+tc.len)
+		}
+		if got, max := bc.lru.Len(), bc.nmax; got > max {
+			t.Fatalf("too many cached blocks: got=%d, max=%d", got, max)
+		}
+	}
+
+	// read past end of file.
+	buf := make([]byte, 20)
+	n, err := bc.ReadAt(buf, int64(len(want))-10)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF, got %+v", err)
+	}
+	if n != 10 {
+		t.Fatalf("invalid number of bytes: got=%d, want=10", n)
+	}
+	_, err = bc.ReadAt(buf, int64(len(want)))
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF, got %+v", err)
+	}
+
+	// sequential read.
+	all, err := io.ReadAll(bc)
+	if err != nil {
+		t.Fatalf("could not read all: %+v", err)
+	}
+	if !bytes.Equal(all, want) {
+		t.Fatalf("invalid sequential read")
+	}
+}
+
+func TestBCacheHits(t *testing.T) {
+	cnt := &countingServer{h: http.FileServer(http.Dir("../../../testdata"))}
+	srv := httptest.NewServer(cnt)
+	defer srv.Close()
+
+	r, err := Open(srv.URL+"/simple.root", WithBlockSize(1024), WithReadAhead(0))
+	if err != nil {
+		t.Fatalf("could not open remote file: %+v", err)
+	}
+	defer r.Close()
+
+	buf := make([]byte, 100)
+	for i := 0; i < 10; i++ {
+		_, err := r.ReadAt(buf, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, want := cnt.n.Load(), int64(1); got != want {
+		t.Fatalf("invalid number of GET requests: got=%d, want=%d", got, want)
+	}
+
+	bc := r.(*bcache)
+	if got, want := bc.stats.hits, int64(9); got != want {
+		t.Fatalf("invalid number of cache hits: got=%d, want=%d", got, want)
+	}
+}
+
+func TestBCacheReadAheadAsync(t *testing.T) {
+	const blksz = 512
+
+	// requests past the first block are blocked until the read completes.
+	var (
+		release = make(chan struct{})
+		files   = http.FileServer(http.Dir("../../../testdata"))
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var beg, end int64
+		_, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &beg, &end)
+		if err == nil && end >= blksz {
+			<-release
+		}
+		files.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	r, err := Open(srv.URL+"/simple.root", WithBlockSize(blksz), WithReadAhead(2))
+	if err != nil {
+		t.Fatalf("could not open remote file: %+v", err)
+	}
+	defer r.Close()
+
+	done := make(chan error)
+	go func() {
+		_, err := r.ReadAt(make([]byte, 10), 0)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("could not read: %+v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("read waited for the read-ahead blocks")
+	}
+}
+
+func TestOpenRemote(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("../../../testdata")))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "default"},
+		{name: "small-blocks", opts: []Option{WithBlockSize(64), WithCacheSize(2)}},
+		{name: "no-read-ahead", opts: []Option{WithReadAhead(0)}},
+		{name: "disk-cache", opts: []Option{WithDiskCache()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Open(srv.URL+"/simple.root", tc.opts...)
+			if err != nil {
+				t.Fatalf("could not open remote file: %+v", err)
+			}
+
+			f, err := riofs.NewReader(r)
+			if err != nil {
+				t.Fatalf("could not create ROOT reader: %+v", err)
+			}
+			defer f.Close()
+
+			_, err = f.Get("tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+		})
+	}
+}
+
+func TestInvalidOptions(t *testing.T) {
+	for _, opt := range []Option{
+		WithBlockSize(0),
+		WithReadAhead(-1),
+		WithCacheSize(0),
+	} {
+		_, err := Open("http://example.org/file.root", opt)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	}
+}
@@ -3,9 +3,14 @@
 // license that can be found in the LICENSE file.
 
 // Package http is a plugin for riofs.Open to support opening ROOT files over http(s).
+//
+// Remote files are read with HTTP range requests.
+// Fetched bytes are split into fixed-size blocks and the most recently used
+// ones are kept in an in-memory LRU cache.
 package http
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -20,27 +25,143 @@ func init() {
 	riofs.Register("https", openFile)
 }
 
-func openFile(path string) (riofs.Reader, error) {
-	r, err := httpio.Open(path)
+const (
+	defaultBlockSize = 1 * 1024 * 1024 // size of a cached block
+	defaultReadAhead = 2               // number of blocks to read ahead
+	defaultNBlocks   = 64              // number of cached blocks
+)
+
+type config struct {
+	cli   *http.Client
+	blksz int64 // size of a cached block
+	ahead int   // number of blocks to read ahead
+	nblks int   // number of cached blocks
+	disk  bool  // whether to cache all the fetched bytes on disk
+}
+
+func newConfig() *config {
+	return &config{
+		blksz: defaultBlockSize,
+		ahead: defaultReadAhead,
+		nblks: defaultNBlocks,
+	}
+}
+
+// Option configures how a remote ROOT file is accessed.
+type Option func(cfg *config) error
+
+// WithClient configures the HTTP client used to access the remote file.
+func WithClient(cli *http.Client) Option {
+	return func(cfg *config) error {
+		cfg.cli = cli
+		return nil
+	}
+}
+
+// WithBlockSize configures the size (in bytes) of the blocks fetched from
+// the remote file with HTTP range requests.
+func WithBlockSize(n int64) Option {
+	return func(cfg *config) error {
+		if n <= 0 {
+			return fmt.Errorf("riofs/http: invalid block size %d", n)
+		}
+		cfg.blksz = n
+		return nil
+	}
+}
+
+// WithReadAhead configures the number of blocks fetched ahead of the
+// last read block.
+// A value of zero disables read-ahead.
+func WithReadAhead(n int) Option {
+	return func(cfg *config) error {
+		if n < 0 {
+			return fmt.Errorf("riofs/http: invalid number of read-ahead blocks %d", n)
+		}
+		cfg.ahead = n
+		return nil
+	}
+}
+
+// WithCacheSize configures the maximum number of blocks held in the
+// in-memory LRU cache.
+func WithCacheSize(n int) Option {
+	return func(cfg *config) error {
+		if n <= 0 {
+			return fmt.Errorf("riofs/http: invalid cache size %d", n)
+		}
+		cfg.nblks = n
+		return nil
+	}
+}
+
+// WithDiskCache configures the remote file to keep all the fetched bytes
+// in a temporary file on disk, instead of using a bounded in-memory cache.
+func WithDiskCache() Option {
+	return func(cfg *config) error {
+		cfg.disk = true
+		return nil
+	}
+}
+
+// Open opens the remote ROOT file located at the provided URL.
+//
+// Open uses HTTP range requests to only fetch the needed parts of the
+// remote file.
+// If the HTTP server does not support range requests, the whole file is
+// downloaded into a temporary file.
+//
+// The returned value can be used with riofs.NewReader.
+func Open(path string, opts ...Option) (riofs.Reader, error) {
+	cfg := newConfig()
+	for _, opt := range opts {
+		err := opt(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("riofs/http: could not apply option: %w", err)
+		}
+	}
+
+	var hopts []httpio.Option
+	if cfg.cli != nil {
+		hopts = append(hopts, httpio.WithClient(cfg.cli))
+	}
+
+	r, err := httpio.Open(path, hopts...)
 	if err != nil {
 		// HTTP server may not support accept-range.
-		return tmpFileFrom(path)
+		return tmpFileFrom(cfg.cli, path)
 	}
-	rc, err := rcacheOf(&preader{r: r, n: runtime.NumCPU()})
-	if err != nil {
-		_ = r.Close()
-		return tmpFileFrom(path)
+
+	if cfg.disk {
+		rc, err := rcacheOf(&preader{r: r, n: runtime.NumCPU()})
+		if err != nil {
+			_ = r.Close()
+			return tmpFileFrom(cfg.cli, path)
+		}
+		return rc, nil
 	}
-	return rc, nil
+
+	return newBCache(&preader{r: r, n: runtime.NumCPU()}, r.Size(), cfg), nil
 }
 
-func tmpFileFrom(path string) (riofs.Reader, error) {
-	resp, err := http.Get(path)
+func openFile(path string) (riofs.Reader, error) {
+	return Open(path)
+}
+
+func tmpFileFrom(cli *http.Client, path string) (riofs.Reader, error) {
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+	resp, err := cli.Get(path)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("riofs/http: invalid GET response: code=%v", resp.StatusCode)
+	}
+
 	f, err := os.CreateTemp("", "riofs-remote-")
 	if err != nil {
 		return nil, err
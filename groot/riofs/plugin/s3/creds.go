@@ -0,0 +1,183 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Credentials holds the secrets used to access an object store.
+// A zero Credentials value denotes anonymous access.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // optional
+}
+
+func (c Credentials) anonymous() bool {
+	return c.AccessKeyID == "" && c.SecretAccessKey == ""
+}
+
+// env is the environment used to look up credentials, regions and endpoints.
+type env struct {
+	getenv func(string) string
+	home   func() (string, error)
+}
+
+var osEnv = env{
+	getenv: os.Getenv,
+	home:   os.UserHomeDir,
+}
+
+// credentials returns the credentials found by walking the standard
+// provider chain:
+//   - the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+//     environment variables,
+//   - the shared credentials file (AWS_SHARED_CREDENTIALS_FILE, or
+//     ~/.aws/credentials) for the AWS_PROFILE profile (or "default").
+//
+// credentials returns anonymous credentials when none could be found.
+func (e env) credentials() (Credentials, error) {
+	creds := Credentials{
+		AccessKeyID:     e.getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: e.getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    e.getenv("AWS_SESSION_TOKEN"),
+	}
+	if !creds.anonymous() {
+		return creds, nil
+	}
+
+	fname := e.getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if fname == "" {
+		home, err := e.home()
+		if err != nil {
+			return creds, nil
+		}
+		fname = filepath.Join(home, ".aws", "credentials")
+	}
+
+	sec, err := e.section(fname, e.profile())
+	switch {
+	case err == nil:
+		// ok.
+	case os.IsNotExist(err):
+		return creds, nil
+	default:
+		return creds, fmt.Errorf("riofs/s3: could not read credentials file: %w", err)
+	}
+
+	creds.AccessKeyID = sec["aws_access_key_id"]
+	creds.SecretAccessKey = sec["aws_secret_access_key"]
+	creds.SessionToken = sec["aws_session_token"]
+	return creds, nil
+}
+
+// region returns the region found by walking the standard provider chain:
+//   - the AWS_REGION and AWS_DEFAULT_REGION environment variables,
+//   - the shared configuration file (AWS_CONFIG_FILE, or ~/.aws/config).
+//
+// region returns "us-east-1" when no region could be found.
+func (e env) region() string {
+	for _, k := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if v := e.getenv(k); v != "" {
+			return v
+		}
+	}
+
+	const def = "us-east-1"
+	fname := e.getenv("AWS_CONFIG_FILE")
+	if fname == "" {
+		home, err := e.home()
+		if err != nil {
+			return def
+		}
+		fname = filepath.Join(home, ".aws", "config")
+	}
+
+	name := e.profile()
+	if name != "default" {
+		name = "profile " + name
+	}
+	sec, err := e.section(fname, name)
+	if err != nil || sec["region"] == "" {
+		return def
+	}
+	return sec["region"]
+}
+
+// endpoint returns the user-provided endpoint for the S3 service, if any.
+func (e env) endpoint() string {
+	for _, k := range []string{"AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"} {
+		if v := e.getenv(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// timeout returns the time limit of requests set with GROOT_S3_TIMEOUT, or
+// zero if none was set.
+func (e env) timeout() (time.Duration, error) {
+	v := e.getenv("GROOT_S3_TIMEOUT")
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("riofs/s3: invalid GROOT_S3_TIMEOUT value %q", v)
+	}
+	return d, nil
+}
+
+func (e env) profile() string {
+	if v := e.getenv("AWS_PROFILE"); v != "" {
+		return v
+	}
+	return "default"
+}
+
+// section returns the key/value pairs of the named section of an INI file.
+func (e env) section(fname, name string) (map[string]string, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return iniSection(f, name)
+}
+
+func iniSection(r io.Reader, name string) (map[string]string, error) {
+	var (
+		sc  = bufio.NewScanner(r)
+		cur string
+		sec = make(map[string]string)
+	)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "", line[0] == '#', line[0] == ';':
+			continue
+		case line[0] == '[' && line[len(line)-1] == ']':
+			cur = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		if cur != name {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		sec[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+	}
+	return sec, sc.Err()
+}
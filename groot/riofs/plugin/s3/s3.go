@@ -0,0 +1,231 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package s3 is a plugin for riofs.Open to support opening ROOT files
+// stored in S3-compatible object stores.
+//
+// Files are addressed as:
+//
+//	s3://bucket/path/to/file.root
+//	gs://bucket/path/to/file.root
+//
+// The "gs" scheme targets the S3-compatible XML API of Google Cloud Storage
+// (https://storage.googleapis.com), authenticated with HMAC keys.
+//
+// Credentials are looked up with the standard AWS provider chain:
+// the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+// environment variables, then the shared credentials file (selected with
+// AWS_SHARED_CREDENTIALS_FILE and AWS_PROFILE.)
+// Requests are sent anonymously when no credentials could be found.
+//
+// The region is taken from AWS_REGION, AWS_DEFAULT_REGION or the shared
+// configuration file.
+// A custom endpoint (e.g. for MinIO or Ceph) may be provided with
+// AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL.
+//
+// Requests, including the transfer of their response, have no time limit by
+// default. A limit may be set with WithTimeout or with the GROOT_S3_TIMEOUT
+// environment variable (e.g. GROOT_S3_TIMEOUT=5m.)
+package s3 // import "go-hep.org/x/hep/groot/riofs/plugin/s3"
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-hep.org/x/hep/groot/riofs"
+	rhttp "go-hep.org/x/hep/groot/riofs/plugin/http"
+)
+
+func init() {
+	riofs.Register("s3", openFile)
+	riofs.Register("gs", openFile)
+}
+
+const gcsEndpoint = "https://storage.googleapis.com"
+
+type config struct {
+	creds    *Credentials
+	region   string
+	endpoint string
+	base     http.RoundTripper
+	timeout  *time.Duration
+	hopts    []rhttp.Option
+}
+
+// Option configures how an object store is accessed.
+type Option func(cfg *config) error
+
+// WithCredentials configures the credentials used to access the object
+// store, bypassing the standard provider chain.
+func WithCredentials(creds Credentials) Option {
+	return func(cfg *config) error {
+		cfg.creds = &creds
+		return nil
+	}
+}
+
+// WithRegion configures the region of the object store.
+func WithRegion(region string) Option {
+	return func(cfg *config) error {
+		cfg.region = region
+		return nil
+	}
+}
+
+// WithEndpoint configures the URL of the object store service
+// (e.g. "https://minio.example.org:9000".)
+// Objects are then addressed with path-style URLs.
+func WithEndpoint(endpoint string) Option {
+	return func(cfg *config) error {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("riofs/s3: invalid endpoint %q: %w", endpoint, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("riofs/s3: invalid endpoint %q", endpoint)
+		}
+		cfg.endpoint = strings.TrimRight(endpoint, "/")
+		return nil
+	}
+}
+
+// WithTransport configures the HTTP transport used to send the signed
+// requests to the object store.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(cfg *config) error {
+		cfg.base = rt
+		return nil
+	}
+}
+
+// WithTimeout configures the time limit of each request sent to the object
+// store, including the transfer of its response.
+// A zero timeout means no time limit.
+func WithTimeout(d time.Duration) Option {
+	return func(cfg *config) error {
+		if d < 0 {
+			return fmt.Errorf("riofs/s3: invalid timeout %v", d)
+		}
+		cfg.timeout = &d
+		return nil
+	}
+}
+
+// WithHTTPOptions configures how the remote object is read and cached.
+func WithHTTPOptions(opts ...rhttp.Option) Option {
+	return func(cfg *config) error {
+		cfg.hopts = append(cfg.hopts, opts...)
+		return nil
+	}
+}
+
+// Open opens the ROOT file located at the provided s3:// or gs:// URL.
+//
+// The returned value can be used with riofs.NewReader.
+func Open(path string, opts ...Option) (riofs.Reader, error) {
+	return open(osEnv, path, opts...)
+}
+
+func open(env env, path string, opts ...Option) (riofs.Reader, error) {
+	cfg := &config{base: http.DefaultTransport}
+	for _, opt := range opts {
+		err := opt(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("riofs/s3: could not apply option: %w", err)
+		}
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("riofs/s3: could not parse URL %q: %w", path, err)
+	}
+
+	if cfg.creds == nil {
+		creds, err := env.credentials()
+		if err != nil {
+			return nil, err
+		}
+		cfg.creds = &creds
+	}
+
+	switch u.Scheme {
+	case "s3":
+		if cfg.region == "" {
+			cfg.region = env.region()
+		}
+		if cfg.endpoint == "" {
+			cfg.endpoint = env.endpoint()
+		}
+	case "gs":
+		if cfg.region == "" {
+			cfg.region = "auto"
+		}
+		if cfg.endpoint == "" {
+			cfg.endpoint = gcsEndpoint
+		}
+	default:
+		return nil, fmt.Errorf("riofs/s3: invalid URL scheme %q", u.Scheme)
+	}
+
+	if cfg.timeout == nil {
+		timeout, err := env.timeout()
+		if err != nil {
+			return nil, err
+		}
+		cfg.timeout = &timeout
+	}
+
+	uri, err := objectURL(u, cfg.endpoint, cfg.region)
+	if err != nil {
+		return nil, err
+	}
+
+	cli := &http.Client{
+		Timeout: *cfg.timeout,
+		Transport: &transport{
+			sig: &signer{
+				creds:  *cfg.creds,
+				region: cfg.region,
+				now:    time.Now,
+			},
+			base: cfg.base,
+		},
+	}
+
+	hopts := append([]rhttp.Option{rhttp.WithClient(cli)}, cfg.hopts...)
+	r, err := rhttp.Open(uri, hopts...)
+	if err != nil {
+		return nil, fmt.Errorf("riofs/s3: could not open %q: %w", path, err)
+	}
+	return r, nil
+}
+
+// objectURL returns the HTTP(S) URL of the object addressed by u.
+func objectURL(u *url.URL, endpoint, region string) (string, error) {
+	bucket := u.Host
+	key := strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || key == "" {
+		return "", fmt.Errorf("riofs/s3: invalid object URL %q (want %s://bucket/key)", u.String(), u.Scheme)
+	}
+
+	obj := (&url.URL{Path: key}).EscapedPath()
+	switch {
+	case endpoint != "":
+		// path-style addressing.
+		return endpoint + "/" + bucket + "/" + obj, nil
+	case strings.Contains(bucket, "."):
+		// virtual-hosted addressing does not work over TLS with dotted
+		// bucket names.
+		return "https://s3." + region + ".amazonaws.com/" + bucket + "/" + obj, nil
+	default:
+		return "https://" + bucket + ".s3." + region + ".amazonaws.com/" + obj, nil
+	}
+}
+
+func openFile(path string) (riofs.Reader, error) {
+	return Open(path)
+}
@@ -0,0 +1,204 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	sigAlgorithm    = "AWS4-HMAC-SHA256"
+	sigService      = "s3"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	amzDateFormat   = "20060102T150405Z"
+)
+
+// signer signs HTTP requests with the AWS Signature Version 4 scheme.
+//
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+type signer struct {
+	creds  Credentials
+	region string
+	now    func() time.Time
+}
+
+// sign adds the authentication headers to the provided request.
+func (s *signer) sign(req *http.Request) {
+	if s.creds.anonymous() {
+		return
+	}
+
+	now := s.now().UTC()
+	date := now.Format(amzDateFormat)
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	}
+	req.Header.Set("X-Amz-Date", date)
+	if s.creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.creds.SessionToken)
+	}
+
+	creq, hdrs := canonicalRequest(req)
+	scope := strings.Join([]string{date[:8], s.region, sigService, "aws4_request"}, "/")
+	str := strings.Join([]string{
+		sigAlgorithm,
+		date,
+		scope,
+		hexSHA256([]byte(creq)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.creds.SecretAccessKey), []byte(date[:8]))
+	key = hmacSHA256(key, []byte(s.region))
+	key = hmacSHA256(key, []byte(sigService))
+	key = hmacSHA256(key, []byte("aws4_request"))
+	sig := hex.EncodeToString(hmacSHA256(key, []byte(str)))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigAlgorithm, s.creds.AccessKeyID, scope, hdrs, sig,
+	))
+}
+
+// canonicalRequest returns the canonical form of the provided request,
+// together with the list of signed headers.
+func canonicalRequest(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	hdrs := map[string]string{"host": host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		if k != "range" && !strings.HasPrefix(k, "x-amz-") {
+			continue
+		}
+		vs := make([]string, len(v))
+		for i := range v {
+			vs[i] = strings.Join(strings.Fields(v[i]), " ")
+		}
+		hdrs[k] = strings.Join(vs, ",")
+	}
+
+	keys := make([]string, 0, len(hdrs))
+	for k := range hdrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var chdrs strings.Builder
+	for _, k := range keys {
+		chdrs.WriteString(k + ":" + hdrs[k] + "\n")
+	}
+	signed := strings.Join(keys, ";")
+
+	payload := req.Header.Get("X-Amz-Content-Sha256")
+	if payload == "" {
+		payload = unsignedPayload
+	}
+
+	creq := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL),
+		chdrs.String(),
+		signed,
+		payload,
+	}, "\n")
+	return creq, signed
+}
+
+func canonicalURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		v, err := url.PathUnescape(seg)
+		if err != nil {
+			v = seg
+		}
+		segs[i] = uriEncode(v)
+	}
+	return strings.Join(segs, "/")
+}
+
+func canonicalQuery(u *url.URL) string {
+	vs := u.Query()
+	keys := make([]string, 0, len(vs))
+	for k := range vs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var kvs []string
+	for _, k := range keys {
+		vals := append([]string(nil), vs[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			kvs = append(kvs, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(kvs, "&")
+}
+
+// uriEncode encodes s following the AWS rules: every byte except the
+// unreserved characters 'A'-'Z', 'a'-'z', '0'-'9', '-', '.', '_' and '~'
+// is percent-encoded.
+func uriEncode(s string) string {
+	const hex = "0123456789ABCDEF"
+	var o strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~':
+			o.WriteByte(c)
+		default:
+			o.WriteByte('%')
+			o.WriteByte(hex[c>>4])
+			o.WriteByte(hex[c&0xf])
+		}
+	}
+	return o.String()
+}
+
+func hexSHA256(p []byte) string {
+	sum := sha256.Sum256(p)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key, p []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(p)
+	return h.Sum(nil)
+}
+
+// transport is an http.RoundTripper that signs requests before sending
+// them over the wire.
+type transport struct {
+	sig  *signer
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrip must not modify the original request.
+	req = req.Clone(req.Context())
+	t.sig.sign(req)
+	return t.base.RoundTrip(req)
+}
+
+var (
+	_ http.RoundTripper = (*transport)(nil)
+)
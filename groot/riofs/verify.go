@@ -0,0 +1,215 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/rbytes"
+)
+
+// VerifyReport describes the result of the integrity check of a ROOT file.
+type VerifyReport struct {
+	File    string   // name of the verified file
+	Version int      // ROOT version the file was created with
+	Records int      // number of verified records
+	Gaps    int      // number of free segments
+	Damages []Damage // list of damaged records
+}
+
+// OK returns whether the verified file has no damaged record.
+func (rep *VerifyReport) OK() bool {
+	return len(rep.Damages) == 0
+}
+
+// Damage describes a damaged record of a ROOT file.
+type Damage struct {
+	Pos    int64  // position of the record in the file
+	Nbytes int32  // number of bytes of the record (key header + payload)
+	ObjLen int32  // length of the uncompressed payload
+	KeyLen int32  // length of the key header
+	Class  string // class of the record payload, if known
+	Name   string // name of the record payload, if known
+	Cycle  int16  // cycle of the record payload, if known
+	Err    error  // description of the damage
+}
+
+func (d Damage) String() string {
+	return fmt.Sprintf(
+		"At:%d N=%d class=%q name=%q cycle=%d: %v",
+		d.Pos, d.Nbytes, d.Class, d.Name, d.Cycle, d.Err,
+	)
+}
+
+// Verify checks the integrity of all the records of the named ROOT file.
+//
+// Verify walks through the records of the file, from the beginning of the
+// file up to the end of the last record, and checks for each record:
+//   - the consistency of the key header (sizes and position on file),
+//   - the decompression of the key payload, including the size of the
+//     decompressed data and the checksums of the compressed blocks (Adler-32
+//     for ZLIB, XXH64 for LZ4 and ZSTD, CRC32 for LZMA.)
+//
+// Damaged records are reported in the returned VerifyReport.
+// Verify stops walking through the file when a record header is too damaged
+// to locate the next record.
+// Verify only returns an error when the file could not be read.
+func Verify(path string) (*VerifyReport, error) {
+	r, err := openFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("riofs: unable to open %q: %w", path, err)
+	}
+	defer r.Close()
+
+	rep, err := verify(r)
+	if err != nil {
+		return nil, fmt.Errorf("riofs: could not verify %q: %w", path, err)
+	}
+	rep.File = path
+
+	return rep, nil
+}
+
+func verify(r io.ReaderAt) (*VerifyReport, error) {
+	var (
+		rep  VerifyReport
+		hdr  = make([]byte, 64)
+		cur  int64
+		end  int64
+		info int64
+		free int64
+	)
+
+	_, err := r.ReadAt(hdr, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not read file header: %w", err)
+	}
+	if string(hdr[:4]) != string(rootMagic) {
+		return nil, fmt.Errorf("not a ROOT file")
+	}
+
+	rb := rbytes.NewRBuffer(hdr[4:], nil, 0, nil)
+	vers := rb.ReadI32()
+	cur = int64(rb.ReadI32())
+	switch {
+	case vers < 1000000: // small file
+		end = int64(rb.ReadI32())
+		free = int64(rb.ReadI32())
+		_ = rb.ReadI32() // nbytes-free
+		_ = rb.ReadI32() // nfree
+		_ = rb.ReadI32() // nbytes-name
+		_ = rb.ReadU8()  // units
+		_ = rb.ReadI32() // compression
+		info = int64(rb.ReadI32())
+	default:
+		end = rb.ReadI64()
+		free = rb.ReadI64()
+		_ = rb.ReadI32() // nbytes-free
+		_ = rb.ReadI32() // nfree
+		_ = rb.ReadI32() // nbytes-name
+		_ = rb.ReadU8()  // units
+		_ = rb.ReadI32() // compression
+		info = rb.ReadI64()
+	}
+	if rb.Err() != nil {
+		return nil, fmt.Errorf("could not decode file header: %w", rb.Err())
+	}
+	rep.Version = int(vers % 1000000)
+
+	var (
+		buf []byte
+		raw [4]byte
+	)
+	for cur < end {
+		dmg := Damage{Pos: cur}
+		n, err := r.ReadAt(raw[:], cur)
+		if n != len(raw) {
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("could not read record at %d: %w", cur, err)
+			}
+			dmg.Err = fmt.Errorf("truncated file: could not read record size")
+			rep.Damages = append(rep.Damages, dmg)
+			break
+		}
+
+		nbytes := int32(rbytes.NewRBuffer(raw[:], nil, 0, nil).ReadI32())
+		dmg.Nbytes = nbytes
+		switch {
+		case nbytes < 0:
+			rep.Gaps++
+			cur += int64(-nbytes)
+			continue
+		case nbytes == 0 || cur+int64(nbytes) > end:
+			dmg.Err = fmt.Errorf("invalid record size %d (end of file at %d)", nbytes, end)
+			rep.Damages = append(rep.Damages, dmg)
+			return &rep, nil
+		}
+
+		buf = rbytes.ResizeU8(buf, int(nbytes))
+		n, err = r.ReadAt(buf, cur)
+		if n != len(buf) {
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("could not read record at %d: %w", cur, err)
+			}
+			dmg.Err = fmt.Errorf("truncated file: could only read %d bytes out of %d", n, nbytes)
+			rep.Damages = append(rep.Damages, dmg)
+			break
+		}
+
+		rep.Records++
+		err = verifyRecord(&dmg, buf, cur)
+		switch cur {
+		case free:
+			dmg.Class = "FreeSegments"
+		case info:
+			dmg.Class = "StreamerInfo"
+		}
+		if err != nil {
+			dmg.Err = err
+			rep.Damages = append(rep.Damages, dmg)
+		}
+		cur += int64(nbytes)
+	}
+
+	return &rep, nil
+}
+
+// verifyRecord checks the integrity of the provided record, located at pos,
+// and fills the provided damage description with the content of the key header.
+func verifyRecord(dmg *Damage, rec []byte, pos int64) error {
+	var key Key
+	err := key.UnmarshalROOT(rbytes.NewRBuffer(rec, nil, 0, nil))
+	if err != nil {
+		return fmt.Errorf("could not decode key header: %w", err)
+	}
+	dmg.ObjLen = key.objlen
+	dmg.KeyLen = key.keylen
+	dmg.Class = key.class
+	dmg.Name = key.name
+	dmg.Cycle = key.cycle
+
+	switch {
+	case key.keylen <= 0 || key.keylen > key.nbytes:
+		return fmt.Errorf("invalid key header length %d", key.keylen)
+	case key.objlen < 0:
+		return fmt.Errorf("invalid object length %d", key.objlen)
+	case key.seekkey != pos:
+		return fmt.Errorf("invalid key position %d", key.seekkey)
+	}
+
+	if !key.isCompressed() {
+		return nil
+	}
+
+	err = rcompress.Verify(rec[key.keylen:], int(key.objlen))
+	if err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	return nil
+}
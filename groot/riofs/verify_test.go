@@ -0,0 +1,144 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+)
+
+func TestVerify(t *testing.T) {
+	for _, fname := range []string{
+		"../testdata/dirs-6.14.00.root",
+		"../testdata/leaves.root",
+		"../testdata/g4-merge.root",
+		"../testdata/small-evnt-tree-fullsplit.root",
+		"../testdata/ntpl001_staff.root",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			rep, err := Verify(fname)
+			if err != nil {
+				t.Fatalf("could not verify file: %+v", err)
+			}
+			if rep.Records == 0 {
+				t.Fatalf("no record verified")
+			}
+			if !rep.OK() {
+				t.Fatalf("invalid report: %v", rep.Damages)
+			}
+		})
+	}
+}
+
+func TestVerifyDamaged(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opt  FileOption
+	}{
+		{name: "zlib", opt: WithZlib(1)},
+		{name: "lz4", opt: WithLZ4(1)},
+		{name: "lzma", opt: WithLZMA(1)},
+		{name: "zstd", opt: WithZstd(1)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "file.root")
+			f, err := Create(fname, tc.opt)
+			if err != nil {
+				t.Fatalf("could not create file: %+v", err)
+			}
+
+			for _, name := range []string{"str1", "str2"} {
+				err = f.Put(name, rbase.NewObjString(strings.Repeat(name+"-hello ", 1000)))
+				if err != nil {
+					t.Fatalf("could not write object %q: %+v", name, err)
+				}
+			}
+
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %+v", err)
+			}
+
+			rep, err := Verify(fname)
+			if err != nil {
+				t.Fatalf("could not verify file: %+v", err)
+			}
+			if !rep.OK() {
+				t.Fatalf("invalid report: %v", rep.Damages)
+			}
+
+			f, err = Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			var key Key
+			for _, k := range f.Keys() {
+				if k.Name() == "str1" {
+					key = k
+				}
+			}
+			_ = f.Close()
+
+			if !key.isCompressed() {
+				t.Fatalf("key payload is not compressed")
+			}
+
+			raw, err := os.ReadFile(fname)
+			if err != nil {
+				t.Fatalf("could not read file: %+v", err)
+			}
+
+			// corrupt the last byte of the compressed payload.
+			pos := key.SeekKey() + int64(key.Nbytes()) - 1
+			raw[pos] ^= 0xff
+			err = os.WriteFile(fname, raw, 0644)
+			if err != nil {
+				t.Fatalf("could not write damaged file: %+v", err)
+			}
+
+			rep, err = Verify(fname)
+			if err != nil {
+				t.Fatalf("could not verify damaged file: %+v", err)
+			}
+			if rep.OK() {
+				t.Fatalf("damaged record not detected")
+			}
+			if got, want := len(rep.Damages), 1; got != want {
+				t.Fatalf("invalid number of damaged records: got=%d, want=%d (%v)", got, want, rep.Damages)
+			}
+			dmg := rep.Damages[0]
+			if got, want := dmg.Pos, key.SeekKey(); got != want {
+				t.Fatalf("invalid damaged record position: got=%d, want=%d", got, want)
+			}
+			if got, want := dmg.Name, "str1"; got != want {
+				t.Fatalf("invalid damaged record name: got=%q, want=%q", got, want)
+			}
+			if got, want := dmg.Class, "TObjString"; got != want {
+				t.Fatalf("invalid damaged record class: got=%q, want=%q", got, want)
+			}
+
+			// truncate the file in the middle of the damaged record.
+			err = os.WriteFile(fname, raw[:key.SeekKey()+int64(key.KeyLen())], 0644)
+			if err != nil {
+				t.Fatalf("could not write truncated file: %+v", err)
+			}
+
+			rep, err = Verify(fname)
+			if err != nil {
+				t.Fatalf("could not verify truncated file: %+v", err)
+			}
+			if got, want := len(rep.Damages), 1; got != want {
+				t.Fatalf("invalid number of damaged records: got=%d, want=%d (%v)", got, want, rep.Damages)
+			}
+			if got, want := rep.Damages[0].Err.Error(), "truncated file"; !strings.HasPrefix(got, want) {
+				t.Fatalf("invalid error:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+}
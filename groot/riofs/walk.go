@@ -105,6 +105,175 @@ func walk(path string, obj root.Object, walkFn WalkFunc) error {
 // containing directory.
 type WalkFunc func(path string, obj root.Object, err error) error
 
+// WalkKeys walks the ROOT file tree rooted at dir, calling walkFn for each
+// key in the ROOT file tree, including keys of nested directories.
+//
+// Contrary to Walk, WalkKeys does not load the objects attached to the keys
+// (except for directories, to descend into them.)
+// Objects can be loaded on demand with Key.Object.
+// All the cycles of a given key are visited, in the order they are stored
+// in their directory.
+func WalkKeys(dir Directory, walkFn WalkKeyFunc) error {
+	top := stdpath.Join(dir.(root.Named).Name(), ".")
+	err := walkKeys(top, dir, walkFn)
+	if err == SkipDir {
+		return nil
+	}
+	return err
+}
+
+func walkKeys(path string, dir Directory, walkFn WalkKeyFunc) error {
+	for _, key := range dir.Keys() {
+		kpath := stdpath.Join(path, key.Name())
+		if !isDirKey(key) {
+			err := walkFn(kpath, &key, nil)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		sub, err := subdirOf(dir, key)
+		err = walkFn(kpath, &key, err)
+		switch {
+		case err == SkipDir:
+			continue
+		case err != nil:
+			return err
+		case sub == nil:
+			continue
+		}
+
+		err = walkKeys(kpath, sub, walkFn)
+		if err != nil && err != SkipDir {
+			return err
+		}
+	}
+	return nil
+}
+
+// WalkKeyFunc is the type of the function called for each key visited by
+// WalkKeys. The path argument contains the name of the directory given to
+// WalkKeys as a prefix.
+//
+// If the key is a directory that could not be loaded, the incoming error
+// describes the problem and WalkKeys will not descend into that directory.
+// If an error is returned, processing stops. The sole exception is when the
+// function returns the special value SkipDir. If the function returns SkipDir
+// when invoked on a directory key, WalkKeys skips the directory's contents
+// entirely. If the function returns SkipDir when invoked on a non-directory key,
+// WalkKeys skips the remaining keys in the containing directory.
+type WalkKeyFunc func(path string, key *Key, err error) error
+
+// KeyIter iterates over all the keys of a ROOT file tree, including keys
+// of nested directories, in the same order than WalkKeys.
+//
+// Typical usage:
+//
+//	it := riofs.NewKeyIter(f)
+//	for it.Next() {
+//	    key := it.Key()
+//	    fmt.Printf("%s: %s;%d\n", it.Path(), key.ClassName(), key.Cycle())
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type KeyIter struct {
+	stack []keyFrame
+	dir   Directory // directory holding the current key
+	path  string
+	key   Key
+	err   error
+}
+
+type keyFrame struct {
+	dir  Directory
+	path string
+	keys []Key
+}
+
+// NewKeyIter creates a new iterator over the keys of the ROOT file tree
+// rooted at dir.
+func NewKeyIter(dir Directory) *KeyIter {
+	top := stdpath.Join(dir.(root.Named).Name(), ".")
+	return &KeyIter{
+		stack: []keyFrame{{dir: dir, path: top, keys: dir.Keys()}},
+	}
+}
+
+// Next advances the iterator to the next key.
+// Next returns false when the iteration stops, either by reaching the end
+// of the ROOT file tree or when an error occurred.
+func (it *KeyIter) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	// descend into the last visited directory, if any.
+	if isDirKey(it.key) {
+		dir, err := subdirOf(it.dir, it.key)
+		if err != nil {
+			it.err = fmt.Errorf("riofs: could not load directory %q: %w", it.path, err)
+			return false
+		}
+		it.stack = append(it.stack, keyFrame{dir: dir, path: it.path, keys: dir.Keys()})
+	}
+
+	for len(it.stack) > 0 {
+		top := &it.stack[len(it.stack)-1]
+		if len(top.keys) == 0 {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		it.dir = top.dir
+		it.key = top.keys[0]
+		it.path = stdpath.Join(top.path, it.key.Name())
+		top.keys = top.keys[1:]
+		return true
+	}
+
+	it.dir = nil
+	it.key = Key{}
+	it.path = ""
+	return false
+}
+
+// SkipDir instructs the iterator to not descend into the directory
+// associated with the current key.
+func (it *KeyIter) SkipDir() {
+	it.key = Key{}
+}
+
+// Path returns the full path of the current key.
+func (it *KeyIter) Path() string { return it.path }
+
+// Key returns the current key.
+func (it *KeyIter) Key() *Key { return &it.key }
+
+// Err returns the first error encountered during the iteration.
+func (it *KeyIter) Err() error { return it.err }
+
+func isDirKey(key Key) bool {
+	switch key.ClassName() {
+	case "TDirectory", "TDirectoryFile":
+		return true
+	}
+	return false
+}
+
+// subdirOf returns the directory attached to the provided key.
+func subdirOf(dir Directory, key Key) (Directory, error) {
+	obj, err := dir.Get(fmt.Sprintf("%s;%d", key.Name(), key.Cycle()))
+	if err != nil {
+		return nil, err
+	}
+	sub, ok := obj.(Directory)
+	if !ok {
+		return nil, fmt.Errorf("riofs: key %q is not a directory (%T)", key.Name(), obj)
+	}
+	return sub, nil
+}
+
 // recDir handles nested paths.
 type recDir struct {
 	dir Directory
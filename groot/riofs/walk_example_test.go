@@ -58,6 +58,59 @@ func ExampleWalk() {
 	// dirs-6.14.00.root/dir1/dir11/h1 (TH1F)
 }
 
+func ExampleWalkKeys() {
+	f, err := riofs.Open("../testdata/dirs-6.14.00.root")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	err = riofs.WalkKeys(f, func(path string, key *riofs.Key, err error) error {
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s (%s;%d)\n", path, key.ClassName(), key.Cycle())
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("could not walk through file: %v", err)
+	}
+
+	// Output:
+	// dirs-6.14.00.root/dir1 (TDirectoryFile;1)
+	// dirs-6.14.00.root/dir1/dir11 (TDirectoryFile;1)
+	// dirs-6.14.00.root/dir1/dir11/h1 (TH1F;1)
+	// dirs-6.14.00.root/dir2 (TDirectoryFile;1)
+	// dirs-6.14.00.root/dir3 (TDirectoryFile;1)
+}
+
+func ExampleKeyIter() {
+	f, err := riofs.Open("../testdata/dirs-6.14.00.root")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	it := riofs.NewKeyIter(f)
+	for it.Next() {
+		key := it.Key()
+		if key.ClassName() != "TH1F" {
+			continue
+		}
+		obj, err := key.Object()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s: entries=%v\n", it.Path(), obj.(rhist.H1).Entries())
+	}
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Output:
+	// dirs-6.14.00.root/dir1/dir11/h1: entries=5
+}
+
 func ExampleGet() {
 	f, err := riofs.Open("../testdata/dirs-6.14.00.root")
 	if err != nil {
@@ -482,6 +482,126 @@ data/file.root/dir2/dir22 (TDirectoryFile)
 	}
 }
 
+func TestWalkKeys(t *testing.T) {
+	fname := stdpath.Join(t.TempDir(), "keys.root")
+	f, err := Create(fname)
+	if err != nil {
+		t.Fatalf("could not create ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	rd := Dir(f)
+	for _, name := range []string{
+		"dir1/dir11",
+		"dir2",
+	} {
+		_, err = rd.Mkdir(name)
+		if err != nil {
+			t.Fatalf("could not create dir %q: %+v", name, err)
+		}
+	}
+	for _, name := range []string{
+		"str1",
+		"dir1/str11",
+		"dir1/dir11/str111",
+		"dir1/dir11/str111",
+		"dir2/str21",
+	} {
+		err = rd.Put(name, rbase.NewObjString(name))
+		if err != nil {
+			t.Fatalf("could not put %q: %+v", name, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		skip string
+		want string
+	}{
+		{
+			name: "all",
+			want: `keys.root/dir1 (TDirectory;1)
+keys.root/dir1/dir11 (TDirectory;1)
+keys.root/dir1/dir11/str111 (TObjString;1)
+keys.root/dir1/dir11/str111 (TObjString;2)
+keys.root/dir1/str11 (TObjString;1)
+keys.root/dir2 (TDirectory;1)
+keys.root/dir2/str21 (TObjString;1)
+keys.root/str1 (TObjString;1)
+`,
+		},
+		{
+			name: "skip-dir1",
+			skip: "keys.root/dir1",
+			want: `keys.root/dir1 (TDirectory;1)
+keys.root/dir2 (TDirectory;1)
+keys.root/dir2/str21 (TObjString;1)
+keys.root/str1 (TObjString;1)
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o := new(strings.Builder)
+			err := WalkKeys(f, func(path string, key *Key, err error) error {
+				if err != nil {
+					return err
+				}
+				path = strings.TrimPrefix(path, stdpath.Dir(fname)+"/")
+				fmt.Fprintf(o, "%s (%s;%d)\n", path, key.ClassName(), key.Cycle())
+				if path == tc.skip {
+					return SkipDir
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not walk keys: %+v", err)
+			}
+			if got, want := o.String(), tc.want; got != want {
+				t.Fatalf("invalid WalkKeys display:\n%s", diff.Format(got, want))
+			}
+
+			o.Reset()
+			it := NewKeyIter(f)
+			for it.Next() {
+				path := strings.TrimPrefix(it.Path(), stdpath.Dir(fname)+"/")
+				fmt.Fprintf(o, "%s (%s;%d)\n", path, it.Key().ClassName(), it.Key().Cycle())
+				if path == tc.skip {
+					it.SkipDir()
+				}
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("could not iterate over keys: %+v", err)
+			}
+			if got, want := o.String(), tc.want; got != want {
+				t.Fatalf("invalid KeyIter display:\n%s", diff.Format(got, want))
+			}
+		})
+	}
+
+	// load objects lazily.
+	n := 0
+	err = WalkKeys(f, func(path string, key *Key, err error) error {
+		if key.ClassName() != "TObjString" {
+			return nil
+		}
+		obj, err := key.Object()
+		if err != nil {
+			return err
+		}
+		if got, want := obj.(root.ObjString).String(), strings.TrimPrefix(path, fname+"/"); got != want {
+			return fmt.Errorf("invalid object: got=%q, want=%q", got, want)
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not load objects: %+v", err)
+	}
+	if n != 5 {
+		t.Fatalf("invalid number of loaded objects: got=%d, want=5", n)
+	}
+}
+
 type unknownDirImpl struct{}
 
 func (dir *unknownDirImpl) Get(namecycle string) (root.Object, error) { panic("not implemented") }
@@ -128,3 +128,96 @@ func (col Column) Slice() (sli interface{}, err error) {
 
 	return slice.Interface(), nil
 }
+
+// NewJaggedColumns returns all the variable-length ReadVars of the provided
+// Tree as a slice of JaggedColumns.
+//
+// ReadVars whose elements can not be represented as NumPy arrays are
+// silently discarded.
+func NewJaggedColumns(tree rtree.Tree) []JaggedColumn {
+	var (
+		rvars = rtree.NewReadVars(tree)
+		cols  []JaggedColumn
+	)
+
+	for _, rvar := range rvars {
+		rt := reflect.TypeOf(rvar.Value).Elem()
+		if rt.Kind() != reflect.Slice || !isNumPyType(rt.Elem()) {
+			continue
+		}
+		cols = append(cols, JaggedColumn{
+			tree: tree,
+			rvar: rvar,
+			etyp: rt.Elem(),
+		})
+	}
+
+	return cols
+}
+
+// JaggedColumn provides a NumPy representation of a variable-length
+// Branch or Leaf.
+//
+// The data of a JaggedColumn is represented as a flat content array, holding
+// the elements of all the entries, and an offsets array, holding the
+// boundaries of each entry inside the content array, as used by the
+// awkward-array library.
+type JaggedColumn struct {
+	tree rtree.Tree
+	rvar rtree.ReadVar
+	etyp reflect.Type
+}
+
+// Name returns the branch name this JaggedColumn is bound to.
+func (col JaggedColumn) Name() string {
+	return col.rvar.Name
+}
+
+// Slices reads the whole data from the underlying ROOT Tree into memory.
+//
+// Slices returns the flat content of all the entries and the offsets of
+// each entry inside the content.
+// The i-th entry spans content[offsets[i]:offsets[i+1]].
+func (col JaggedColumn) Slices() (content interface{}, offsets []int64, err error) {
+	r, err := rtree.NewReader(col.tree, []rtree.ReadVar{col.rvar})
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"rnpy: could not create ROOT reader for %q: %w",
+			col.rvar.Name, err,
+		)
+	}
+	defer r.Close()
+
+	var (
+		n     = col.tree.Entries()
+		data  = reflect.ValueOf(col.rvar.Value).Elem()
+		slice = reflect.MakeSlice(reflect.SliceOf(col.etyp), 0, int(n))
+	)
+
+	offsets = make([]int64, 1, n+1)
+	err = r.Read(func(ctx rtree.RCtx) error {
+		slice = reflect.AppendSlice(slice, data)
+		offsets = append(offsets, int64(slice.Len()))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"rnpy: could not read ROOT data for %q: %w",
+			col.rvar.Name, err,
+		)
+	}
+
+	return slice.Interface(), offsets, nil
+}
+
+func isNumPyType(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Chan, reflect.Interface,
+		reflect.Struct, reflect.Slice, reflect.Map,
+		reflect.Ptr, reflect.UnsafePointer:
+		return false
+	case reflect.Array:
+		return isNumPyType(rt.Elem())
+	}
+	return true
+}
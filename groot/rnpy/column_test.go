@@ -169,3 +169,59 @@ func TestColumnReadFail(t *testing.T) {
 		t.Fatalf("invalid error:\ngot= %+v\nwant=%+v", got, want)
 	}
 }
+
+func TestJaggedColumns(t *testing.T) {
+	f, err := riofs.Open("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := obj.(rtree.Tree)
+
+	cols := NewJaggedColumns(tree)
+
+	var names []string
+	for _, col := range cols {
+		names = append(names, col.Name())
+	}
+	want := []string{
+		"SliceInt32", "SliceInt64", "SliceUInt32", "SliceUInt64",
+		"SliceFloat32", "SliceFloat64",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("invalid jagged columns:\ngot= %q\nwant=%q", names, want)
+	}
+
+	sli, offsets, err := cols[0].Slices()
+	if err != nil {
+		t.Fatalf("could not read jagged column: %+v", err)
+	}
+
+	content := sli.([]int32)
+	if got, want := len(offsets), int(tree.Entries())+1; got != want {
+		t.Fatalf("invalid number of offsets: got=%d, want=%d", got, want)
+	}
+	if got, want := offsets[len(offsets)-1], int64(len(content)); got != want {
+		t.Fatalf("invalid last offset: got=%d, want=%d", got, want)
+	}
+
+	for i := 0; i < int(tree.Entries()); i++ {
+		var (
+			beg = offsets[i]
+			end = offsets[i+1]
+		)
+		if got, want := end-beg, int64(i%10); got != want {
+			t.Fatalf("entry[%d]: invalid length: got=%d, want=%d", i, got, want)
+		}
+		for _, v := range content[beg:end] {
+			if v != int32(i) {
+				t.Fatalf("entry[%d]: invalid content: got=%v, want=%v", i, content[beg:end], i)
+			}
+		}
+	}
+}
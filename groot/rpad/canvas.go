@@ -103,6 +103,16 @@ func (c *Canvas) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// Pad returns the top-level pad of this canvas.
+func (c *Canvas) Pad() *Pad {
+	return &c.pad
+}
+
+// Size returns the width and height of this canvas, in pixels.
+func (c *Canvas) Size() (w, h int) {
+	return int(c.fCw), int(c.fCh)
+}
+
 // Keys implements the ObjectFinder interface.
 func (c *Canvas) Keys() []string {
 	return c.pad.Keys()
@@ -0,0 +1,111 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Latex is a text primitive, drawn on a pad, holding a TeX-like formula.
+type Latex struct {
+	Text
+	attline rbase.AttLine
+
+	fLimitFactorSize int32   // lower bound for subscripts/superscripts size
+	fOriginSize      float64 // Font size of the starting font
+}
+
+// NewLatex returns a new TeX-like text primitive at (x,y).
+func NewLatex(x, y float64, text string) *Latex {
+	return &Latex{
+		Text:             *NewText(x, y, text),
+		attline:          *rbase.NewAttLine(),
+		fLimitFactorSize: 3,
+		fOriginSize:      0.04,
+	}
+}
+
+func (*Latex) RVersion() int16 {
+	return rvers.Latex
+}
+
+func (*Latex) Class() string {
+	return "TLatex"
+}
+
+// ROOTMarshaler is the interface implemented by an object that can
+// marshal itself to a ROOT buffer
+func (ltx *Latex) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(ltx.Class(), ltx.RVersion())
+	w.WriteObject(&ltx.Text)
+	w.WriteObject(&ltx.attline)
+	w.WriteI32(ltx.fLimitFactorSize)
+	w.WriteF64(ltx.fOriginSize)
+
+	return w.SetHeader(hdr)
+}
+
+// ROOTUnmarshaler is the interface implemented by an object that can
+// unmarshal itself from a ROOT buffer
+func (ltx *Latex) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(ltx.Class(), ltx.RVersion())
+	if hdr.Vers > rvers.Latex {
+		panic(fmt.Errorf(
+			"rpad: invalid %s version=%d > %d",
+			ltx.Class(), hdr.Vers, ltx.RVersion(),
+		))
+	}
+
+	r.ReadObject(&ltx.Text)
+	r.ReadObject(&ltx.attline)
+	if hdr.Vers > 1 {
+		ltx.fLimitFactorSize = r.ReadI32()
+		ltx.fOriginSize = r.ReadF64()
+	}
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (ltx *Latex) RMembers() (mbrs []rbytes.Member) {
+	mbrs = append(mbrs, ltx.Text.RMembers()...)
+	mbrs = append(mbrs, ltx.attline.RMembers()...)
+	mbrs = append(mbrs, []rbytes.Member{
+		{Name: "fLimitFactorSize", Value: &ltx.fLimitFactorSize},
+		{Name: "fOriginSize", Value: &ltx.fOriginSize},
+	}...)
+	return mbrs
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewLatex(0, 0, "")
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TLatex", f)
+}
+
+var (
+	_ root.Object        = (*Latex)(nil)
+	_ root.Named         = (*Latex)(nil)
+	_ rbytes.Marshaler   = (*Latex)(nil)
+	_ rbytes.Unmarshaler = (*Latex)(nil)
+	_ rbytes.RSlicer     = (*Latex)(nil)
+)
@@ -0,0 +1,153 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// kLineNDC is the bit set on TLine when its coordinates are expressed
+// in Normalized Device Coordinates (NDC.)
+const kLineNDC = 1 << 14
+
+// Line is a line segment primitive drawn on a pad.
+type Line struct {
+	obj     rbase.Object
+	attline rbase.AttLine
+
+	fX1 float64 // X of 1st point
+	fY1 float64 // Y of 1st point
+	fX2 float64 // X of 2nd point
+	fY2 float64 // Y of 2nd point
+}
+
+// NewLine returns a new line segment from (x1,y1) to (x2,y2).
+func NewLine(x1, y1, x2, y2 float64) *Line {
+	return &Line{
+		obj:     *rbase.NewObject(),
+		attline: *rbase.NewAttLine(),
+		fX1:     x1,
+		fY1:     y1,
+		fX2:     x2,
+		fY2:     y2,
+	}
+}
+
+func (*Line) RVersion() int16 {
+	return rvers.Line
+}
+
+func (*Line) Class() string {
+	return "TLine"
+}
+
+// Points returns the two end points of the line segment.
+func (ln *Line) Points() (x1, y1, x2, y2 float64) {
+	return ln.fX1, ln.fY1, ln.fX2, ln.fY2
+}
+
+// NDC returns whether the end points of the line segment are expressed
+// in Normalized Device Coordinates.
+func (ln *Line) NDC() bool {
+	return ln.obj.TestBits(kLineNDC)
+}
+
+// SetNDC sets whether the end points of the line segment are expressed
+// in Normalized Device Coordinates.
+func (ln *Line) SetNDC(v bool) {
+	switch v {
+	case true:
+		ln.obj.SetBit(kLineNDC)
+	default:
+		ln.obj.ResetBit(kLineNDC)
+	}
+}
+
+// AttLine returns the line attributes of this primitive.
+func (ln *Line) AttLine() *rbase.AttLine {
+	return &ln.attline
+}
+
+// ROOTMarshaler is the interface implemented by an object that can
+// marshal itself to a ROOT buffer
+func (ln *Line) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(ln.Class(), ln.RVersion())
+	w.WriteObject(&ln.obj)
+	w.WriteObject(&ln.attline)
+	writeAttBBox2D(w)
+	w.WriteF64(ln.fX1)
+	w.WriteF64(ln.fY1)
+	w.WriteF64(ln.fX2)
+	w.WriteF64(ln.fY2)
+
+	return w.SetHeader(hdr)
+}
+
+// ROOTUnmarshaler is the interface implemented by an object that can
+// unmarshal itself from a ROOT buffer
+func (ln *Line) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(ln.Class(), ln.RVersion())
+	if hdr.Vers > rvers.Line {
+		panic(fmt.Errorf(
+			"rpad: invalid %s version=%d > %d",
+			ln.Class(), hdr.Vers, ln.RVersion(),
+		))
+	}
+
+	r.ReadObject(&ln.obj)
+	r.ReadObject(&ln.attline)
+	if hdr.Vers > 2 {
+		_ = r.ReadHeader("TAttBBox2D", rvers.AttBBox2D)
+	}
+	ln.fX1 = r.ReadF64()
+	ln.fY1 = r.ReadF64()
+	ln.fX2 = r.ReadF64()
+	ln.fY2 = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (ln *Line) RMembers() (mbrs []rbytes.Member) {
+	mbrs = append(mbrs, ln.obj.RMembers()...)
+	mbrs = append(mbrs, ln.attline.RMembers()...)
+	mbrs = append(mbrs, []rbytes.Member{
+		{Name: "fX1", Value: &ln.fX1},
+		{Name: "fY1", Value: &ln.fY1},
+		{Name: "fX2", Value: &ln.fX2},
+		{Name: "fY2", Value: &ln.fY2},
+	}...)
+	return mbrs
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewLine(0, 0, 0, 0)
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TLine", f)
+}
+
+var (
+	_ root.Object        = (*Line)(nil)
+	_ rbytes.Marshaler   = (*Line)(nil)
+	_ rbytes.Unmarshaler = (*Line)(nil)
+	_ rbytes.RSlicer     = (*Line)(nil)
+)
@@ -202,6 +202,47 @@ func (p *Pad) UnmarshalROOT(r *rbytes.RBuffer) error {
 	return r.Err()
 }
 
+// Primitives returns the list of primitives (histograms, graphs, sub-pads, ...)
+// drawn on this pad.
+func (pad *Pad) Primitives() []root.Object {
+	if pad.fPrimitives == nil {
+		return nil
+	}
+	objs := make([]root.Object, pad.fPrimitives.Len())
+	for i := range objs {
+		objs[i] = pad.fPrimitives.At(i)
+	}
+	return objs
+}
+
+// UserRange returns the range of the X and Y axes of this pad, in user coordinates.
+// For axes with a logarithmic scale, the returned bounds are the log10 of
+// the displayed range.
+func (pad *Pad) UserRange() (xmin, xmax, ymin, ymax float64) {
+	return pad.fUxmin, pad.fUxmax, pad.fUymin, pad.fUymax
+}
+
+// LogX returns whether the X axis has a logarithmic scale.
+func (pad *Pad) LogX() bool { return pad.fLogx != 0 }
+
+// LogY returns whether the Y axis has a logarithmic scale.
+func (pad *Pad) LogY() bool { return pad.fLogy != 0 }
+
+// LogZ returns whether the Z axis has a logarithmic scale.
+func (pad *Pad) LogZ() bool { return pad.fLogz != 0 }
+
+// GridX returns whether a grid is drawn along the X axis.
+func (pad *Pad) GridX() bool { return pad.fGridx }
+
+// GridY returns whether a grid is drawn along the Y axis.
+func (pad *Pad) GridY() bool { return pad.fGridy }
+
+// NDC returns the position of the bottom left corner and the size of this
+// pad, in Normalized Device Coordinates of its parent pad.
+func (pad *Pad) NDC() (xlow, ylow, w, h float64) {
+	return pad.fXlowNDC, pad.fYlowNDC, pad.fWNDC, pad.fHNDC
+}
+
 // Keys implements the ObjectFinder interface.
 func (pad *Pad) Keys() []string {
 	var keys []string
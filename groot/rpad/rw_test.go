@@ -0,0 +1,119 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/internal/rtests"
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+)
+
+func TestWRBuffer(t *testing.T) {
+	ndc := NewText(0.2, 0.8, "NDC text")
+	ndc.SetNDC(true)
+	ndc.atttext = rbase.AttText{Angle: 10, Size: 0.03, Align: 22, Color: 2, Font: 42}
+
+	ltx := NewLatex(1, 2, "#sqrt{s} = 13 TeV")
+	ltx.SetNDC(true)
+
+	line := NewLine(0, 1, 10, 1)
+	line.attline = rbase.AttLine{Color: 2, Style: 2, Width: 3}
+
+	for _, tc := range []struct {
+		name string
+		want rtests.ROOTer
+	}{
+		{
+			name: "TText",
+			want: NewText(1, 2, "text"),
+		},
+		{
+			name: "TText-ndc",
+			want: ndc,
+		},
+		{
+			name: "TLatex",
+			want: ltx,
+		},
+		{
+			name: "TLine",
+			want: line,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+			_, err := tc.want.MarshalROOT(wbuf)
+			if err != nil {
+				t.Fatalf("could not marshal ROOT: %v", err)
+			}
+
+			rbuf := rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, nil)
+			class := tc.want.Class()
+			obj := rtypes.Factory.Get(class)().Interface().(rbytes.Unmarshaler)
+			err = obj.UnmarshalROOT(rbuf)
+			if err != nil {
+				t.Fatalf("could not unmarshal ROOT: %v", err)
+			}
+
+			if !reflect.DeepEqual(obj, tc.want) {
+				t.Fatalf("error\ngot= %+v (%T)\nwant=%+v (%T)\n", obj, obj, tc.want, tc.want)
+			}
+		})
+	}
+}
+
+func TestPrimitivesRW(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "prims.root")
+
+	txt := NewText(0.1, 0.9, "text")
+	txt.SetNDC(true)
+
+	want := map[string]root.Object{
+		"text":  txt,
+		"latex": NewLatex(1, 2, "E = mc^{2}"),
+		"line":  NewLine(0, 1, 10, 1),
+	}
+
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	for _, k := range []string{"text", "latex", "line"} {
+		err = f.Put(k, want[k])
+		if err != nil {
+			t.Fatalf("could not write %q: %+v", k, err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+
+	for k, v := range want {
+		got, err := r.Get(k)
+		if err != nil {
+			t.Fatalf("could not read %q: %+v", k, err)
+		}
+		if !reflect.DeepEqual(got, v) {
+			t.Fatalf("invalid %q:\ngot= %+v\nwant=%+v", k, got, v)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpad
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// kTextNDC is the bit set on TText and TLatex when their coordinates are
+// expressed in Normalized Device Coordinates (NDC.)
+const kTextNDC = 1 << 14
+
+// Text is a text primitive drawn on a pad.
+type Text struct {
+	named   rbase.Named
+	atttext rbase.AttText
+
+	fX float64 // X position of text (left, center, etc..)
+	fY float64 // Y position of text (left, center, etc..)
+}
+
+// NewText returns a new text primitive at (x,y), displaying the provided text.
+func NewText(x, y float64, text string) *Text {
+	return &Text{
+		named:   *rbase.NewNamed("", text),
+		atttext: *rbase.NewAttText(),
+		fX:      x,
+		fY:      y,
+	}
+}
+
+func (*Text) RVersion() int16 {
+	return rvers.Text
+}
+
+func (*Text) Class() string {
+	return "TText"
+}
+
+func (txt *Text) Name() string {
+	return txt.named.Name()
+}
+
+func (txt *Text) Title() string {
+	return txt.named.Title()
+}
+
+// Text returns the text displayed by this primitive.
+func (txt *Text) Text() string {
+	return txt.named.Title()
+}
+
+// XY returns the position of the text.
+func (txt *Text) XY() (x, y float64) {
+	return txt.fX, txt.fY
+}
+
+// NDC returns whether the position of the text is expressed
+// in Normalized Device Coordinates.
+func (txt *Text) NDC() bool {
+	return txt.named.TestBits(kTextNDC)
+}
+
+// SetNDC sets whether the position of the text is expressed
+// in Normalized Device Coordinates.
+func (txt *Text) SetNDC(v bool) {
+	switch v {
+	case true:
+		txt.named.SetBit(kTextNDC)
+	default:
+		txt.named.ResetBit(kTextNDC)
+	}
+}
+
+// AttText returns the text attributes of this primitive.
+func (txt *Text) AttText() *rbase.AttText {
+	return &txt.atttext
+}
+
+// ROOTMarshaler is the interface implemented by an object that can
+// marshal itself to a ROOT buffer
+func (txt *Text) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	hdr := w.WriteHeader(txt.Class(), txt.RVersion())
+	w.WriteObject(&txt.named)
+	w.WriteObject(&txt.atttext)
+	writeAttBBox2D(w)
+	w.WriteF64(txt.fX)
+	w.WriteF64(txt.fY)
+
+	return w.SetHeader(hdr)
+}
+
+// ROOTUnmarshaler is the interface implemented by an object that can
+// unmarshal itself from a ROOT buffer
+func (txt *Text) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	hdr := r.ReadHeader(txt.Class(), txt.RVersion())
+	if hdr.Vers > rvers.Text {
+		panic(fmt.Errorf(
+			"rpad: invalid %s version=%d > %d",
+			txt.Class(), hdr.Vers, txt.RVersion(),
+		))
+	}
+
+	r.ReadObject(&txt.named)
+	r.ReadObject(&txt.atttext)
+	if hdr.Vers > 2 {
+		_ = r.ReadHeader("TAttBBox2D", rvers.AttBBox2D)
+	}
+	txt.fX = r.ReadF64()
+	txt.fY = r.ReadF64()
+
+	r.CheckHeader(hdr)
+	return r.Err()
+}
+
+func (txt *Text) RMembers() (mbrs []rbytes.Member) {
+	mbrs = append(mbrs, txt.named.RMembers()...)
+	mbrs = append(mbrs, txt.atttext.RMembers()...)
+	mbrs = append(mbrs, []rbytes.Member{
+		{Name: "fX", Value: &txt.fX},
+		{Name: "fY", Value: &txt.fY},
+	}...)
+	return mbrs
+}
+
+// writeAttBBox2D writes the (empty) TAttBBox2D base class.
+func writeAttBBox2D(w *rbytes.WBuffer) {
+	hdr := w.WriteHeader("TAttBBox2D", rvers.AttBBox2D)
+	_, _ = w.SetHeader(hdr)
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewText(0, 0, "")
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TText", f)
+}
+
+var (
+	_ root.Object        = (*Text)(nil)
+	_ root.Named         = (*Text)(nil)
+	_ rbytes.Marshaler   = (*Text)(nil)
+	_ rbytes.Unmarshaler = (*Text)(nil)
+	_ rbytes.RSlicer     = (*Text)(nil)
+)
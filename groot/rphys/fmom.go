@@ -0,0 +1,66 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rphys
+
+import (
+	"go-hep.org/x/hep/fmom"
+	"gonum.org/v1/gonum/spatial/r2"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// NewLorentzVectorFrom creates a new TLorentzVector from the provided
+// 4-momentum.
+func NewLorentzVectorFrom(p4 fmom.P4) *LorentzVector {
+	return NewLorentzVector(p4.Px(), p4.Py(), p4.Pz(), p4.E())
+}
+
+// P4 returns the 4-momentum held by the TLorentzVector.
+func (vec *LorentzVector) P4() fmom.PxPyPzE {
+	return fmom.NewPxPyPzE(vec.p.x, vec.p.y, vec.p.z, vec.e)
+}
+
+// SetP4 sets the components of the TLorentzVector from the provided
+// 4-momentum.
+func (vec *LorentzVector) SetP4(p4 fmom.P4) {
+	vec.SetPxPyPzE(p4.Px(), p4.Py(), p4.Pz(), p4.E())
+}
+
+// Vect returns the spatial components of the TLorentzVector.
+func (vec *LorentzVector) Vect() r3.Vec {
+	return vec.p.Vec()
+}
+
+// NewVector3From creates a new TVector3 from the provided 3-vector.
+func NewVector3From(v r3.Vec) *Vector3 {
+	return NewVector3(v.X, v.Y, v.Z)
+}
+
+// Vec returns the components of the TVector3 as a 3-vector.
+func (vec *Vector3) Vec() r3.Vec {
+	return r3.Vec{X: vec.x, Y: vec.y, Z: vec.z}
+}
+
+// SetVec sets the components of the TVector3 from the provided 3-vector.
+func (vec *Vector3) SetVec(v r3.Vec) {
+	vec.x = v.X
+	vec.y = v.Y
+	vec.z = v.Z
+}
+
+// NewVector2From creates a new TVector2 from the provided 2-vector.
+func NewVector2From(v r2.Vec) *Vector2 {
+	return NewVector2(v.X, v.Y)
+}
+
+// Vec returns the components of the TVector2 as a 2-vector.
+func (vec *Vector2) Vec() r2.Vec {
+	return r2.Vec{X: vec.x, Y: vec.y}
+}
+
+// SetVec sets the components of the TVector2 from the provided 2-vector.
+func (vec *Vector2) SetVec(v r2.Vec) {
+	vec.x = v.X
+	vec.y = v.Y
+}
@@ -0,0 +1,220 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rphys_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rphys"
+	"go-hep.org/x/hep/groot/rtree"
+	"gonum.org/v1/gonum/spatial/r2"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+func TestFmom(t *testing.T) {
+	p4 := fmom.NewPxPyPzE(1, 2, 3, 10)
+
+	tlv := rphys.NewLorentzVectorFrom(&p4)
+	if got, want := tlv.P4(), p4; got != want {
+		t.Fatalf("invalid p4: got=%v, want=%v", got, want)
+	}
+	if got, want := tlv.Vect(), (r3.Vec{X: 1, Y: 2, Z: 3}); got != want {
+		t.Fatalf("invalid 3-vector: got=%v, want=%v", got, want)
+	}
+
+	p4.SetPtEtaPhiM(10, 0.5, 1, 5)
+	tlv.SetP4(&p4)
+	if got, want := tlv.P4(), p4; got != want {
+		t.Fatalf("invalid p4: got=%v, want=%v", got, want)
+	}
+
+	v3 := rphys.NewVector3From(r3.Vec{X: 1, Y: 2, Z: 3})
+	if got, want := v3.Vec(), (r3.Vec{X: 1, Y: 2, Z: 3}); got != want {
+		t.Fatalf("invalid 3-vector: got=%v, want=%v", got, want)
+	}
+	v3.SetVec(r3.Vec{X: -1, Y: -2, Z: -3})
+	if got, want := v3.Vec(), (r3.Vec{X: -1, Y: -2, Z: -3}); got != want {
+		t.Fatalf("invalid 3-vector: got=%v, want=%v", got, want)
+	}
+
+	v2 := rphys.NewVector2From(r2.Vec{X: 1, Y: 2})
+	if got, want := v2.Vec(), (r2.Vec{X: 1, Y: 2}); got != want {
+		t.Fatalf("invalid 2-vector: got=%v, want=%v", got, want)
+	}
+	v2.SetVec(r2.Vec{X: -1, Y: -2})
+	if got, want := v2.Vec(), (r2.Vec{X: -1, Y: -2}); got != want {
+		t.Fatalf("invalid 2-vector: got=%v, want=%v", got, want)
+	}
+}
+
+func TestReadTLV(t *testing.T) {
+	for _, fname := range []string{
+		"../testdata/tlv-split00.root",
+		"../testdata/tlv-split01.root",
+		"../testdata/tlv-split99.root",
+	} {
+		t.Run(fname, func(t *testing.T) {
+			f, err := groot.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open ROOT file: %+v", err)
+			}
+			defer f.Close()
+
+			o, err := f.Get("tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+
+			var tlv rphys.LorentzVector
+			r, err := rtree.NewReader(o.(rtree.Tree), []rtree.ReadVar{
+				{Name: "p4", Value: &tlv},
+			})
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx rtree.RCtx) error {
+				i := float64(ctx.Entry)
+				want := fmom.NewPxPyPzE(i, i+1, i+2, i+3)
+				if got := tlv.P4(); got != want {
+					t.Fatalf("entry[%d]: invalid p4: got=%v, want=%v", ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+		})
+	}
+}
+
+func TestRWTree(t *testing.T) {
+	type event struct {
+		P4  fmom.PxPyPzE
+		V3  r3.Vec
+		V2  r2.Vec
+		P4s []fmom.PxPyPzE
+	}
+
+	const nevts = 5
+	evts := make([]event, nevts)
+	for i := range evts {
+		v := float64(i)
+		evts[i] = event{
+			P4: fmom.NewPxPyPzE(v, v+1, v+2, v+10),
+			V3: r3.Vec{X: v, Y: -v, Z: 2 * v},
+			V2: r2.Vec{X: v, Y: -v},
+		}
+		for j := 0; j < i; j++ {
+			evts[i].P4s = append(evts[i].P4s, fmom.NewPxPyPzE(v, float64(j), 0, 20))
+		}
+	}
+
+	for _, split := range []int{0, 1, 99} {
+		t.Run(fmt.Sprintf("split=%d", split), func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "tlv.root")
+			func() {
+				f, err := groot.Create(fname)
+				if err != nil {
+					t.Fatalf("could not create ROOT file: %+v", err)
+				}
+				defer f.Close()
+
+				var (
+					tlv  = rphys.NewLorentzVector(0, 0, 0, 0)
+					v3   = rphys.NewVector3(0, 0, 0)
+					v2   = rphys.NewVector2(0, 0)
+					tlvs []rphys.LorentzVector
+				)
+				w, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{
+					{Name: "p4", Value: tlv},
+					{Name: "v3", Value: v3},
+					{Name: "v2", Value: v2},
+					{Name: "p4s", Value: &tlvs},
+				}, rtree.WithSplitLevel(split))
+				if err != nil {
+					t.Fatalf("could not create tree writer: %+v", err)
+				}
+				defer w.Close()
+
+				for _, evt := range evts {
+					tlv.SetP4(&evt.P4)
+					v3.SetVec(evt.V3)
+					v2.SetVec(evt.V2)
+					tlvs = tlvs[:0]
+					for i := range evt.P4s {
+						tlvs = append(tlvs, *rphys.NewLorentzVectorFrom(&evt.P4s[i]))
+					}
+					_, err = w.Write()
+					if err != nil {
+						t.Fatalf("could not write event: %+v", err)
+					}
+				}
+
+				err = w.Close()
+				if err != nil {
+					t.Fatalf("could not close tree writer: %+v", err)
+				}
+
+				err = f.Close()
+				if err != nil {
+					t.Fatalf("could not close ROOT file: %+v", err)
+				}
+			}()
+
+			f, err := groot.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open ROOT file: %+v", err)
+			}
+			defer f.Close()
+
+			o, err := f.Get("tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+
+			var (
+				tlv  rphys.LorentzVector
+				v3   rphys.Vector3
+				v2   rphys.Vector2
+				tlvs []rphys.LorentzVector
+			)
+			r, err := rtree.NewReader(o.(rtree.Tree), []rtree.ReadVar{
+				{Name: "p4", Value: &tlv},
+				{Name: "v3", Value: &v3},
+				{Name: "v2", Value: &v2},
+				{Name: "p4s", Value: &tlvs},
+			})
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx rtree.RCtx) error {
+				got := event{
+					P4: tlv.P4(),
+					V3: v3.Vec(),
+					V2: v2.Vec(),
+				}
+				for i := range tlvs {
+					got.P4s = append(got.P4s, tlvs[i].P4())
+				}
+				if want := evts[ctx.Entry]; !reflect.DeepEqual(got, want) {
+					t.Fatalf("split=%d, entry[%d]: invalid event:\ngot= %+v\nwant=%+v", split, ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+		})
+	}
+}
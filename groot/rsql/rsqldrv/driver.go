@@ -242,6 +242,8 @@ type driverRows struct {
 	reader *rtree.Reader
 	row    rowCtx
 	rows   chan rowCtx
+	quit   chan struct{} // closed to stop the reader goroutine
+	done   chan struct{} // closed when the reader goroutine has returned
 
 	eval   expression
 	filter expression
@@ -549,6 +551,13 @@ func (r *driverRows) ColumnTypeNullable(i int) (nullable, ok bool) {
 
 // Close closes the rows iterator.
 func (r *driverRows) Close() error {
+	if r.quit != nil {
+		// stop the reader goroutine before closing the tree reader
+		// it may still be using.
+		close(r.quit)
+		<-r.done
+		r.quit = nil
+	}
 	return r.reader.Close()
 }
 
@@ -559,10 +568,16 @@ type rowCtx struct {
 	err  error
 }
 
+// errRowsClosed stops the reading of the tree when the rows are closed.
+var errRowsClosed = errors.New("rsqldrv: rows closed")
+
 func (r *driverRows) start() {
 	r.rows = make(chan rowCtx)
+	r.quit = make(chan struct{})
+	r.done = make(chan struct{})
 	r.row.ctx.Entry = -1
 	go func() {
+		defer close(r.done)
 		defer close(r.rows)
 		err := r.reader.Read(func(ctx rtree.RCtx) error {
 			ectx := newExecCtx(r.conn, r.args)
@@ -598,15 +613,28 @@ func (r *driverRows) start() {
 				done: make(chan int),
 			}
 
-			r.rows <- evt
-			<-evt.done
+			select {
+			case r.rows <- evt:
+			case <-r.quit:
+				return errRowsClosed
+			}
+			select {
+			case <-evt.done:
+			case <-r.quit:
+				return errRowsClosed
+			}
 			return nil
 		})
-		if err != nil {
-			r.rows <- rowCtx{err: err}
+		if errors.Is(err, errRowsClosed) {
 			return
 		}
-		r.rows <- rowCtx{err: io.EOF}
+		if err == nil {
+			err = io.EOF
+		}
+		select {
+		case r.rows <- rowCtx{err: err}:
+		case <-r.quit:
+		}
 	}()
 }
 
@@ -477,6 +477,29 @@ func (eventData) want(i int64) (data eventData) {
 	return data
 }
 
+func TestQueryClose(t *testing.T) {
+	db, err := sql.Open("root", "../../testdata/simple.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// closing the rows must not race with the reading of the tree.
+	for i := 0; i < 100; i++ {
+		rows, err := db.Query(`SELECT (one, two) FROM tree`)
+		if err != nil {
+			t.Fatalf("could not query: %+v", err)
+		}
+		if i%2 == 1 && !rows.Next() {
+			t.Fatalf("could not read first row: %+v", rows.Err())
+		}
+		err = rows.Close()
+		if err != nil {
+			t.Fatalf("could not close rows: %+v", err)
+		}
+	}
+}
+
 func TestFlatTree(t *testing.T) {
 	db, err := sql.Open("root", "../../testdata/x-flat-tree.root")
 	if err != nil {
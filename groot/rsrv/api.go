@@ -87,6 +87,145 @@ type TreeResponse struct {
 	Tree Tree   `json:"tree"`
 }
 
+type H1Request struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+}
+
+type H1Response struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+	H1  H1     `json:"h1"`
+}
+
+// H1 describes the content of a 1-dim histogram.
+type H1 struct {
+	Type      string  `json:"type"`
+	Name      string  `json:"name"`
+	Title     string  `json:"title"`
+	Entries   int64   `json:"entries"`
+	SumW      float64 `json:"sumw"`
+	SumW2     float64 `json:"sumw2"`
+	Bins      []Bin1D `json:"bins"`
+	Underflow Dist    `json:"underflow"`
+	Overflow  Dist    `json:"overflow"`
+}
+
+// Bin1D describes the content of a 1-dim histogram bin.
+type Bin1D struct {
+	XMin    float64 `json:"xmin"`
+	XMax    float64 `json:"xmax"`
+	Entries int64   `json:"entries"`
+	SumW    float64 `json:"sumw"`
+	SumW2   float64 `json:"sumw2"`
+}
+
+// Dist describes the content of the under/overflow bins of a histogram.
+type Dist struct {
+	Entries int64   `json:"entries"`
+	SumW    float64 `json:"sumw"`
+	SumW2   float64 `json:"sumw2"`
+}
+
+type H2Request struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+}
+
+type H2Response struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+	H2  H2     `json:"h2"`
+}
+
+// H2 describes the content of a 2-dim histogram.
+type H2 struct {
+	Type    string  `json:"type"`
+	Name    string  `json:"name"`
+	Title   string  `json:"title"`
+	Entries int64   `json:"entries"`
+	SumW    float64 `json:"sumw"`
+	SumW2   float64 `json:"sumw2"`
+	Nx      int     `json:"nx"`
+	Ny      int     `json:"ny"`
+	Bins    []Bin2D `json:"bins"`
+}
+
+// Bin2D describes the content of a 2-dim histogram bin.
+type Bin2D struct {
+	XMin    float64 `json:"xmin"`
+	XMax    float64 `json:"xmax"`
+	YMin    float64 `json:"ymin"`
+	YMax    float64 `json:"ymax"`
+	Entries int64   `json:"entries"`
+	SumW    float64 `json:"sumw"`
+	SumW2   float64 `json:"sumw2"`
+}
+
+type S2Request struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+}
+
+type S2Response struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+	S2  S2     `json:"s2"`
+}
+
+// S2 describes the content of a 2-dim scatter.
+type S2 struct {
+	Type   string    `json:"type"`
+	Name   string    `json:"name"`
+	Title  string    `json:"title"`
+	Points []Point2D `json:"points"`
+}
+
+// Point2D describes a 2-dim point and its (possibly asymmetric) errors.
+type Point2D struct {
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	XErrLow float64 `json:"xerr_low,omitempty"`
+	XErrHi  float64 `json:"xerr_high,omitempty"`
+	YErrLow float64 `json:"yerr_low,omitempty"`
+	YErrHi  float64 `json:"yerr_high,omitempty"`
+}
+
+// TreeSliceRequest describes a request for the entries [Beg, End) of
+// a tree.
+// All the branches of the tree are returned when Vars is empty.
+// At most 100 entries are returned when End is not provided.
+type TreeSliceRequest struct {
+	URI  string   `json:"uri"`
+	Dir  string   `json:"dir"`
+	Obj  string   `json:"obj"`
+	Vars []string `json:"vars,omitempty"`
+	Beg  int64    `json:"beg"`
+	End  int64    `json:"end,omitempty"`
+}
+
+type TreeSliceResponse struct {
+	URI     string   `json:"uri"`
+	Dir     string   `json:"dir"`
+	Obj     string   `json:"obj"`
+	Beg     int64    `json:"beg"`
+	End     int64    `json:"end"`
+	Columns []Column `json:"columns"`
+}
+
+// Column holds the values of a tree branch, one JSON value per entry.
+type Column struct {
+	Name   string            `json:"name"`
+	Type   string            `json:"type"`
+	Values []json.RawMessage `json:"values"`
+}
+
 type PlotH1Request struct {
 	URI string `json:"uri"`
 	Dir string `json:"dir"`
@@ -120,6 +259,26 @@ type PlotTreeRequest struct {
 	Options PlotOptions `json:"options"`
 }
 
+// PlotTreeExprRequest describes a request for the histogram of an
+// expression evaluated over the entries of a tree, for the entries
+// passing the (optional) selection.
+// Expressions and selections follow the Go syntax, with identifiers
+// referring to branches (e.g. "sqrt(px*px + py*py)" or "n > 2 && !flag").
+// The histogram range is computed from the data unless Min < Max, and
+// the histogram has 100 bins when Bins is not provided.
+type PlotTreeExprRequest struct {
+	URI  string  `json:"uri"`
+	Dir  string  `json:"dir"`
+	Obj  string  `json:"obj"`
+	Expr string  `json:"expr"`
+	Cut  string  `json:"cut,omitempty"`
+	Bins int     `json:"bins,omitempty"`
+	Min  float64 `json:"min,omitempty"`
+	Max  float64 `json:"max,omitempty"`
+
+	Options PlotOptions `json:"options"`
+}
+
 type PlotResponse struct {
 	URI string `json:"uri"`
 	Dir string `json:"dir"`
@@ -14,10 +14,12 @@ import (
 	"os"
 	stdpath "path"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 
 	uuid "github.com/hashicorp/go-uuid"
+	"go-hep.org/x/hep/groot/internal/rexpr"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/root"
@@ -897,3 +899,608 @@ func (srv *Server) handlePlotTree(w http.ResponseWriter, r *http.Request) error
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(resp)
 }
+
+// PlotTreeExpr plots the histogram of the expression evaluated over the
+// entries of the tree specified by the PlotTreeExprRequest, for the entries
+// passing the optional selection:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree", "expr": "sqrt(px*px+py*py)"}
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree", "expr": "abs(eta)", "cut": "pt > 10",
+//	   "bins": 50, "min": 0, "max": 2.5,
+//	   "options": {"type": "svg", "title": "my plot title", "x": "my x-axis", "y": "my y-axis"}
+//	}
+//
+// PlotTreeExpr replies with a PlotResponse, where "data" contains the base64 encoded representation of
+// the plot.
+func (srv *Server) PlotTreeExpr(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handlePlotTreeExpr)(w, r)
+}
+
+func (srv *Server) handlePlotTreeExpr(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var (
+		req  PlotTreeExprRequest
+		resp PlotResponse
+	)
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode plot-tree-expr request: %w", err)
+	}
+
+	if req.Expr == "" {
+		return fmt.Errorf("rsrv: empty tree-draw expression")
+	}
+
+	nbins := req.Bins
+	switch {
+	case nbins == 0:
+		nbins = 100
+	case nbins < 0:
+		return fmt.Errorf("rsrv: invalid number of bins (%d)", nbins)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		if f == nil {
+			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
+		}
+
+		obj, err := riofs.Dir(f).Get(req.Dir)
+		if err != nil {
+			return fmt.Errorf("could not find directory %q in file %q: %w", req.Dir, req.URI, err)
+		}
+		dir, ok := obj.(riofs.Directory)
+		if !ok {
+			return fmt.Errorf("rsrv: %q in file %q is not a directory", req.Dir, req.URI)
+		}
+
+		obj, err = dir.Get(req.Obj)
+		if err != nil {
+			return fmt.Errorf("could not find object %q under directory %q in file %q: %w", req.Obj, req.Dir, req.URI, err)
+		}
+
+		tree, ok := obj.(rtree.Tree)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a tree (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+
+		expr, err := rexpr.New(req.Expr)
+		if err != nil {
+			return err
+		}
+
+		var cut *rexpr.Cut
+		if req.Cut != "" {
+			cut, err = rexpr.NewCut(req.Cut)
+			if err != nil {
+				return err
+			}
+		}
+
+		r, err := rtree.NewReader(tree, nil)
+		if err != nil {
+			return fmt.Errorf("could not create reader for tree %q of file %q: %w", tree.Name(), req.URI, err)
+		}
+		defer r.Close()
+
+		fexpr, err := r.Formula(expr)
+		if err != nil {
+			return fmt.Errorf("could not bind expression %q to tree %q: %w", req.Expr, tree.Name(), err)
+		}
+		eval := fexpr.Func().(func() float64)
+
+		pass := func() bool { return true }
+		if cut != nil {
+			fcut, err := r.Formula(cut)
+			if err != nil {
+				return fmt.Errorf("could not bind selection %q to tree %q: %w", req.Cut, tree.Name(), err)
+			}
+			pass = fcut.Func().(func() bool)
+		}
+
+		min := +math.MaxFloat64
+		max := -math.MaxFloat64
+		vals := make([]float64, 0, int(tree.Entries()))
+		err = r.Read(func(ctx rtree.RCtx) error {
+			if !pass() {
+				return nil
+			}
+			v := eval()
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				max = math.Max(max, v)
+				min = math.Min(min, v)
+			}
+			vals = append(vals, v)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not complete scan: %w", err)
+		}
+
+		err = r.Close()
+		if err != nil {
+			return fmt.Errorf("could not close reader: %w", err)
+		}
+
+		switch {
+		case req.Min < req.Max:
+			min = req.Min
+			max = req.Max
+		case min > max:
+			// no valid entry.
+			min = 0
+			max = 1
+		default:
+			min = math.Nextafter(min, min-1)
+			max = math.Nextafter(max, max+1)
+		}
+		h1 := hbook.NewH1D(nbins, min, max)
+		for _, v := range vals {
+			h1.Fill(v, 1)
+		}
+
+		req.Options.init()
+
+		pl := hplot.New()
+		pl.Title.Text = req.Expr
+		if req.Options.Title != "" {
+			pl.Title.Text = req.Options.Title
+		}
+		pl.X.Label.Text = req.Options.X
+		pl.Y.Label.Text = req.Options.Y
+
+		h := hplot.NewH1D(h1)
+		h.Infos.Style = hplot.HInfoSummary
+		h.Color = req.Options.Line.Color
+		h.FillColor = req.Options.FillColor
+
+		pl.Add(h, hplot.NewGrid())
+
+		out, err := srv.render(pl, req.Options)
+		if err != nil {
+			return fmt.Errorf("could not render tree plot: %w", err)
+		}
+
+		resp.URI = req.URI
+		resp.Dir = req.Dir
+		resp.Obj = req.Obj
+		resp.Data = base64.StdEncoding.EncodeToString(out)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// H1 returns the content of the 1-dim histogram specified by the H1Request:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1"}
+//
+// H1 replies with a H1Response:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1",
+//	  "h1": {
+//	    "type": "TH1F", "name": "h1", "title": "my title", "entries": 42,
+//	    "sumw": 42, "sumw2": 42,
+//	    "bins": [{"xmin": 0, "xmax": 1, "entries": 2, "sumw": 2, "sumw2": 2}, ...],
+//	    "underflow": {"entries": 0, "sumw": 0, "sumw2": 0},
+//	    "overflow": {"entries": 1, "sumw": 1, "sumw2": 1}
+//	  }
+//	}
+func (srv *Server) H1(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleH1)(w, r)
+}
+
+func (srv *Server) handleH1(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req H1Request
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode h1 request: %w", err)
+	}
+
+	resp := H1Response{
+		URI: req.URI,
+		Dir: req.Dir,
+		Obj: req.Obj,
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		obj, err := lookup(f, req.URI, req.Dir, req.Obj)
+		if err != nil {
+			return err
+		}
+
+		robj, ok := obj.(rhist.H1)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a 1-dim histogram (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+
+		h1 := rootcnv.H1D(robj)
+		resp.H1 = H1{
+			Type:      robj.Class(),
+			Name:      robj.Name(),
+			Title:     robj.Title(),
+			Entries:   h1.Entries(),
+			SumW:      h1.SumW(),
+			SumW2:     h1.SumW2(),
+			Bins:      make([]Bin1D, len(h1.Binning.Bins)),
+			Underflow: newDist(h1.Binning.Underflow()),
+			Overflow:  newDist(h1.Binning.Overflow()),
+		}
+		for i := range h1.Binning.Bins {
+			bin := &h1.Binning.Bins[i]
+			resp.H1.Bins[i] = Bin1D{
+				XMin:    bin.XMin(),
+				XMax:    bin.XMax(),
+				Entries: bin.Entries(),
+				SumW:    bin.SumW(),
+				SumW2:   bin.SumW2(),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// H2 returns the content of the 2-dim histogram specified by the H2Request:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h2"}
+//
+// H2 replies with a H2Response:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h2",
+//	  "h2": {
+//	    "type": "TH2F", "name": "h2", "title": "my title", "entries": 42,
+//	    "sumw": 42, "sumw2": 42, "nx": 10, "ny": 10,
+//	    "bins": [{"xmin": 0, "xmax": 1, "ymin": 0, "ymax": 1, "entries": 2, "sumw": 2, "sumw2": 2}, ...]
+//	  }
+//	}
+func (srv *Server) H2(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleH2)(w, r)
+}
+
+func (srv *Server) handleH2(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req H2Request
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode h2 request: %w", err)
+	}
+
+	resp := H2Response{
+		URI: req.URI,
+		Dir: req.Dir,
+		Obj: req.Obj,
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		obj, err := lookup(f, req.URI, req.Dir, req.Obj)
+		if err != nil {
+			return err
+		}
+
+		robj, ok := obj.(rhist.H2)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a 2-dim histogram (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+
+		h2 := rootcnv.H2D(robj)
+		resp.H2 = H2{
+			Type:    robj.Class(),
+			Name:    robj.Name(),
+			Title:   robj.Title(),
+			Entries: h2.Entries(),
+			SumW:    h2.SumW(),
+			SumW2:   h2.SumW2(),
+			Nx:      h2.Binning.Nx,
+			Ny:      h2.Binning.Ny,
+			Bins:    make([]Bin2D, len(h2.Binning.Bins)),
+		}
+		for i := range h2.Binning.Bins {
+			bin := &h2.Binning.Bins[i]
+			resp.H2.Bins[i] = Bin2D{
+				XMin:    bin.XMin(),
+				XMax:    bin.XMax(),
+				YMin:    bin.YMin(),
+				YMax:    bin.YMax(),
+				Entries: bin.Entries(),
+				SumW:    bin.SumW(),
+				SumW2:   bin.SumW2(),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// S2 returns the content of the 2-dim scatter specified by the S2Request:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "gr"}
+//
+// S2 replies with a S2Response:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "gr",
+//	  "s2": {
+//	    "type": "TGraphErrors", "name": "gr", "title": "my title",
+//	    "points": [{"x": 1, "y": 2, "xerr_low": 0.1, "xerr_high": 0.1, "yerr_low": 0.2, "yerr_high": 0.2}, ...]
+//	  }
+//	}
+func (srv *Server) S2(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleS2)(w, r)
+}
+
+func (srv *Server) handleS2(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req S2Request
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode s2 request: %w", err)
+	}
+
+	resp := S2Response{
+		URI: req.URI,
+		Dir: req.Dir,
+		Obj: req.Obj,
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		obj, err := lookup(f, req.URI, req.Dir, req.Obj)
+		if err != nil {
+			return err
+		}
+
+		robj, ok := obj.(rhist.Graph)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a 2-dim scatter (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+
+		resp.S2 = S2{
+			Type:   robj.Class(),
+			Name:   robj.Name(),
+			Title:  robj.Title(),
+			Points: make([]Point2D, robj.Len()),
+		}
+		for i := range resp.S2.Points {
+			pt := &resp.S2.Points[i]
+			pt.X, pt.Y = robj.XY(i)
+		}
+		if robj, ok := robj.(rhist.GraphErrors); ok {
+			for i := range resp.S2.Points {
+				pt := &resp.S2.Points[i]
+				pt.XErrLow, pt.XErrHi = robj.XError(i)
+				pt.YErrLow, pt.YErrHi = robj.YError(i)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+const (
+	defaultTreeSliceLen = 100   // default number of entries of a tree slice
+	maxTreeSliceLen     = 10000 // maximum number of entries of a tree slice
+)
+
+// TreeSlice returns the values of the entries [beg, end) of the tree
+// specified by the TreeSliceRequest:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree", "beg": 0, "end": 2}
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree", "vars": ["pt", "eta"], "beg": 10}
+//
+// All branches are returned when no variable is provided.
+// At most 100 entries are returned when no end entry is provided, and at
+// most 10000 entries may be requested at once.
+//
+// TreeSlice replies with a TreeSliceResponse, holding the values of
+// each variable as a column:
+//
+//	{"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree",
+//	  "beg": 0, "end": 2,
+//	  "columns": [
+//	    {"name": "pt", "type": "float32", "values": [42.1, 12.3]},
+//	    {"name": "jets", "type": "[]float64", "values": [[1, 2], []]}
+//	  ]
+//	}
+func (srv *Server) TreeSlice(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleTreeSlice)(w, r)
+}
+
+func (srv *Server) handleTreeSlice(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req TreeSliceRequest
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode tree-slice request: %w", err)
+	}
+
+	resp := TreeSliceResponse{
+		URI: req.URI,
+		Dir: req.Dir,
+		Obj: req.Obj,
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		obj, err := lookup(f, req.URI, req.Dir, req.Obj)
+		if err != nil {
+			return err
+		}
+
+		tree, ok := obj.(rtree.Tree)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a tree (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+
+		beg, end := req.Beg, req.End
+		if end <= 0 {
+			end = beg + defaultTreeSliceLen
+		}
+		if end > tree.Entries() {
+			end = tree.Entries()
+		}
+		switch {
+		case beg < 0 || beg > end:
+			return fmt.Errorf("rsrv: invalid tree slice [%d, %d) for tree %v:%s/%s with %d entries", req.Beg, req.End, req.URI, req.Dir, req.Obj, tree.Entries())
+		case end-beg > maxTreeSliceLen:
+			return fmt.Errorf("rsrv: tree slice [%d, %d) is too large (max=%d entries)", beg, end, maxTreeSliceLen)
+		}
+
+		rvars := rtree.NewReadVars(tree)
+		if len(req.Vars) > 0 {
+			all := rvars
+			rvars = make([]rtree.ReadVar, 0, len(req.Vars))
+		loop:
+			for _, name := range req.Vars {
+				for _, rv := range all {
+					if rv.Name == name {
+						rvars = append(rvars, rv)
+						continue loop
+					}
+				}
+				return fmt.Errorf("rsrv: tree %v:%s/%s has no branch %q", req.URI, req.Dir, req.Obj, name)
+			}
+		}
+
+		resp.Beg = beg
+		resp.End = end
+		resp.Columns = make([]Column, len(rvars))
+		for i, rv := range rvars {
+			resp.Columns[i] = Column{
+				Name:   rv.Name,
+				Type:   reflect.TypeOf(rv.Value).Elem().String(),
+				Values: make([]json.RawMessage, 0, int(end-beg)),
+			}
+		}
+		if beg == end {
+			return nil
+		}
+
+		r, err := rtree.NewReader(tree, rvars, rtree.WithRange(beg, end))
+		if err != nil {
+			return fmt.Errorf(
+				"could not create reader for tree %q of file %q: %w",
+				tree.Name(), req.URI, err,
+			)
+		}
+		defer r.Close()
+
+		err = r.Read(func(ctx rtree.RCtx) error {
+			for i, rv := range rvars {
+				raw, err := json.Marshal(rv.Value)
+				if err != nil {
+					return fmt.Errorf("could not encode entry %d of branch %q: %w", ctx.Entry, rv.Name, err)
+				}
+				resp.Columns[i].Values = append(resp.Columns[i].Values, raw)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not complete scan: %w", err)
+		}
+
+		err = r.Close()
+		if err != nil {
+			return fmt.Errorf("could not close reader: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// lookup retrieves the object named oname under the directory dname of
+// the provided ROOT file.
+func lookup(f *riofs.File, uri, dname, oname string) (root.Object, error) {
+	if f == nil {
+		return nil, fmt.Errorf("rsrv: could not find ROOT file named %q", uri)
+	}
+
+	obj, err := riofs.Dir(f).Get(dname)
+	if err != nil {
+		return nil, fmt.Errorf("could not find directory %q in file %q: %w", dname, uri, err)
+	}
+	dir, ok := obj.(riofs.Directory)
+	if !ok {
+		return nil, fmt.Errorf("rsrv: %q in file %q is not a directory", dname, uri)
+	}
+
+	obj, err = dir.Get(oname)
+	if err != nil {
+		return nil, fmt.Errorf("could not find object %q under directory %q in file %q: %w", oname, dname, uri, err)
+	}
+	return obj, nil
+}
+
+func newDist(d *hbook.Dist1D) Dist {
+	return Dist{
+		Entries: d.Entries(),
+		SumW:    d.SumW(),
+		SumW2:   d.SumW2(),
+	}
+}
@@ -58,6 +58,11 @@ func newTestServer() *httptest.Server {
 	mux.HandleFunc("/plot-h2", srv.PlotH2)
 	mux.HandleFunc("/plot-s2", srv.PlotS2)
 	mux.HandleFunc("/plot-tree", srv.PlotTree)
+	mux.HandleFunc("/plot-tree-expr", srv.PlotTreeExpr)
+	mux.HandleFunc("/h1", srv.H1)
+	mux.HandleFunc("/h2", srv.H2)
+	mux.HandleFunc("/s2", srv.S2)
+	mux.HandleFunc("/tree-slice", srv.TreeSlice)
 
 	return httptest.NewServer(mux)
 }
@@ -1020,6 +1025,342 @@ func testPlotTree(t *testing.T, ts *httptest.Server, req PlotTreeRequest, resp *
 	}
 }
 
+func TestH1(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	uri := localURI(t, "../testdata/dirs-6.14.00.root")
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	var resp H1Response
+	testPost(t, ts, "/h1", H1Request{URI: uri, Dir: "/dir1/dir11", Obj: "h1"}, &resp)
+
+	h1 := resp.H1
+	if got, want := h1.Type, "TH1F"; got != want {
+		t.Fatalf("invalid type: got=%q, want=%q", got, want)
+	}
+	if got, want := h1.Name, "h1"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	var (
+		entries = h1.Underflow.Entries + h1.Overflow.Entries
+		sumw    = h1.Underflow.SumW + h1.Overflow.SumW
+	)
+	for i, bin := range h1.Bins {
+		if bin.XMin >= bin.XMax {
+			t.Fatalf("invalid bin %d: [%v, %v)", i, bin.XMin, bin.XMax)
+		}
+		if i > 0 && bin.XMin != h1.Bins[i-1].XMax {
+			t.Fatalf("invalid bin %d edges: xmin=%v, prev-xmax=%v", i, bin.XMin, h1.Bins[i-1].XMax)
+		}
+		entries += bin.Entries
+		sumw += bin.SumW
+	}
+	if got, want := entries, h1.Entries; got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := sumw, h1.SumW; got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+}
+
+func TestH2(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	uri := localURI(t, "../../hbook/rootcnv/testdata/gauss-h2.root")
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	var resp H2Response
+	testPost(t, ts, "/h2", H2Request{URI: uri, Obj: "h2d"}, &resp)
+
+	h2 := resp.H2
+	if got, want := h2.Type, "TH2D"; got != want {
+		t.Fatalf("invalid type: got=%q, want=%q", got, want)
+	}
+	if got, want := len(h2.Bins), h2.Nx*h2.Ny; got != want || got == 0 {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+	var sumw float64
+	for _, bin := range h2.Bins {
+		sumw += bin.SumW
+	}
+	if sumw <= 0 || sumw > h2.SumW {
+		t.Fatalf("invalid sumw: got=%v, want<=%v", sumw, h2.SumW)
+	}
+}
+
+func TestS2(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	uri := localURI(t, "../testdata/graphs.root")
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	for _, tc := range []struct {
+		obj  string
+		want S2
+	}{
+		{
+			obj: "tg",
+			want: S2{
+				Type:  "TGraph",
+				Name:  "tg",
+				Title: "graph without errors",
+				Points: []Point2D{
+					{X: 1, Y: 2},
+					{X: 2, Y: 4},
+					{X: 3, Y: 6},
+					{X: 4, Y: 8},
+				},
+			},
+		},
+		{
+			obj: "tgae",
+			want: S2{
+				Type:  "TGraphAsymmErrors",
+				Name:  "tgae",
+				Title: "graph with asymmetric errors",
+				Points: []Point2D{
+					{X: 1, Y: 2, XErrLow: 0.1, XErrHi: 0.2, YErrLow: 0.3, YErrHi: 0.4},
+					{X: 2, Y: 4, XErrLow: 0.2, XErrHi: 0.4, YErrLow: 0.6, YErrHi: 0.8},
+					{X: 3, Y: 6, XErrLow: 0.30000000000000004, XErrHi: 0.6000000000000001, YErrLow: 0.8999999999999999, YErrHi: 1.2000000000000002},
+					{X: 4, Y: 8, XErrLow: 0.4, XErrHi: 0.8, YErrLow: 1.2, YErrHi: 1.6},
+				},
+			},
+		},
+	} {
+		t.Run(tc.obj, func(t *testing.T) {
+			var resp S2Response
+			testPost(t, ts, "/s2", S2Request{URI: uri, Obj: tc.obj}, &resp)
+			if !reflect.DeepEqual(resp.S2, tc.want) {
+				t.Fatalf("invalid scatter:\ngot= %+v\nwant=%+v", resp.S2, tc.want)
+			}
+		})
+	}
+}
+
+func TestTreeSlice(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	uri := localURI(t, "../testdata/small-flat-tree.root")
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	for _, tc := range []struct {
+		name string
+		req  TreeSliceRequest
+		beg  int64
+		end  int64
+		want []Column
+	}{
+		{
+			name: "scalars",
+			req:  TreeSliceRequest{URI: uri, Obj: "tree", Vars: []string{"Int32", "Float64", "Str"}, Beg: 1, End: 3},
+			beg:  1,
+			end:  3,
+			want: []Column{
+				{Name: "Int32", Type: "int32", Values: raw("1", "2")},
+				{Name: "Float64", Type: "float64", Values: raw("1", "2")},
+				{Name: "Str", Type: "string", Values: raw(`"evt-001"`, `"evt-002"`)},
+			},
+		},
+		{
+			name: "arrays",
+			req:  TreeSliceRequest{URI: uri, Obj: "tree", Vars: []string{"ArrayInt32", "N", "SliceFloat64"}, Beg: 0, End: 3},
+			beg:  0,
+			end:  3,
+			want: []Column{
+				{Name: "ArrayInt32", Type: "[10]int32", Values: raw(
+					"[0,0,0,0,0,0,0,0,0,0]",
+					"[1,1,1,1,1,1,1,1,1,1]",
+					"[2,2,2,2,2,2,2,2,2,2]",
+				)},
+				{Name: "N", Type: "int32", Values: raw("0", "1", "2")},
+				{Name: "SliceFloat64", Type: "[]float64", Values: raw("[]", "[1]", "[2,2]")},
+			},
+		},
+		{
+			name: "clamp",
+			req:  TreeSliceRequest{URI: uri, Obj: "tree", Vars: []string{"Int64"}, Beg: 98},
+			beg:  98,
+			end:  100,
+			want: []Column{
+				{Name: "Int64", Type: "int64", Values: raw("98", "99")},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp TreeSliceResponse
+			testPost(t, ts, "/tree-slice", tc.req, &resp)
+
+			if resp.Beg != tc.beg || resp.End != tc.end {
+				t.Fatalf("invalid range: got=[%d, %d), want=[%d, %d)", resp.Beg, resp.End, tc.beg, tc.end)
+			}
+			if !reflect.DeepEqual(resp.Columns, tc.want) {
+				t.Fatalf("invalid columns:\ngot= %s\nwant=%s", resp.Columns, tc.want)
+			}
+		})
+	}
+
+	t.Run("all-branches", func(t *testing.T) {
+		var resp TreeSliceResponse
+		testPost(t, ts, "/tree-slice", TreeSliceRequest{URI: uri, Obj: "tree", Beg: 0, End: 1}, &resp)
+		if got, want := len(resp.Columns), 20; got != want {
+			t.Fatalf("invalid number of columns: got=%d, want=%d", got, want)
+		}
+	})
+
+	for _, tc := range []struct {
+		name string
+		req  TreeSliceRequest
+	}{
+		{"no-such-branch", TreeSliceRequest{URI: uri, Obj: "tree", Vars: []string{"NotThere"}}},
+		{"invalid-range", TreeSliceRequest{URI: uri, Obj: "tree", Beg: 10, End: 5}},
+		{"negative-beg", TreeSliceRequest{URI: uri, Obj: "tree", Beg: -1}},
+		{"not-a-tree", TreeSliceRequest{URI: uri, Obj: "NotThere"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testPostStatus(t, ts, "/tree-slice", tc.req, http.StatusInternalServerError)
+		})
+	}
+}
+
+func TestPlotTreeExpr(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	uri := localURI(t, "../testdata/small-flat-tree.root")
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	for _, tc := range []struct {
+		req  PlotTreeExprRequest
+		want string
+	}{
+		{
+			req: PlotTreeExprRequest{
+				URI:  uri,
+				Obj:  "tree",
+				Expr: "sqrt(Float64) + ArrayFloat64[2]",
+				Cut:  "N > 2 && Int32%2 == 0",
+				Bins: 20,
+			},
+			want: "testdata/tree_expr_golden.png",
+		},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			var resp PlotResponse
+			testPost(t, ts, "/plot-tree-expr", tc.req, &resp)
+
+			raw, err := base64.StdEncoding.DecodeString(resp.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *cmpimg.GenerateTestData {
+				_ = os.WriteFile(tc.want, raw, 0644)
+			}
+
+			want, err := os.ReadFile(tc.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ok, err := cmpimg.EqualApprox("png", raw, want, 0.1); !ok || err != nil {
+				_ = os.WriteFile(strings.Replace(tc.want, "_golden", "", -1), raw, 0644)
+				fatalf := t.Fatalf
+				if runtime.GOOS == "darwin" {
+					// ignore errors for darwin and mac-silicon
+					fatalf = t.Logf
+				}
+				fatalf("reference files differ: err=%v ok=%v", err, ok)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  PlotTreeExprRequest
+	}{
+		{"empty-expr", PlotTreeExprRequest{URI: uri, Obj: "tree"}},
+		{"invalid-expr", PlotTreeExprRequest{URI: uri, Obj: "tree", Expr: "Int32 +"}},
+		{"invalid-cut", PlotTreeExprRequest{URI: uri, Obj: "tree", Expr: "Int32", Cut: "Int32 >"}},
+		{"no-such-branch", PlotTreeExprRequest{URI: uri, Obj: "tree", Expr: "NotThere"}},
+		{"no-such-func", PlotTreeExprRequest{URI: uri, Obj: "tree", Expr: "foo(Int32)"}},
+		{"string-branch", PlotTreeExprRequest{URI: uri, Obj: "tree", Expr: "Str"}},
+		{"not-an-array", PlotTreeExprRequest{URI: uri, Obj: "tree", Expr: "Int32[0]"}},
+		{"invalid-bins", PlotTreeExprRequest{URI: uri, Obj: "tree", Expr: "Int32", Bins: -1}},
+		{"not-a-tree", PlotTreeExprRequest{URI: uri, Obj: "NotThere", Expr: "Int32"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hresp := testPostStatus(t, ts, "/plot-tree-expr", tc.req, http.StatusInternalServerError)
+			hresp.Body.Close()
+		})
+	}
+}
+
+func localURI(t *testing.T, fname string) string {
+	t.Helper()
+	fname, err := filepath.Abs(fname)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	return "file://" + fname
+}
+
+func raw(vs ...string) []json.RawMessage {
+	o := make([]json.RawMessage, len(vs))
+	for i, v := range vs {
+		o[i] = json.RawMessage(v)
+	}
+	return o
+}
+
+func testPost(t *testing.T, ts *httptest.Server, ep string, req, resp interface{}) {
+	t.Helper()
+
+	hresp := testPostStatus(t, ts, ep, req, http.StatusOK)
+	defer hresp.Body.Close()
+
+	err := json.NewDecoder(hresp.Body).Decode(resp)
+	if err != nil {
+		t.Fatalf("could not decode response: %v", err)
+	}
+}
+
+func testPostStatus(t *testing.T, ts *httptest.Server, ep string, req interface{}, status int) *http.Response {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	err := json.NewEncoder(body).Encode(req)
+	if err != nil {
+		t.Fatalf("could not encode request: %v", err)
+	}
+
+	hreq, err := http.NewRequest(http.MethodPost, ts.URL+ep, body)
+	if err != nil {
+		t.Fatalf("could not create http request: %v", err)
+	}
+	srv.addCookies(hreq)
+
+	hresp, err := ts.Client().Do(hreq)
+	if err != nil {
+		t.Fatalf("could not post http request: %v", err)
+	}
+
+	if hresp.StatusCode != status {
+		hresp.Body.Close()
+		t.Fatalf("invalid status code for %q: got=%v, want=%v", ep, hresp.StatusCode, status)
+	}
+	return hresp
+}
+
 func (srv *Server) addCookies(req *http.Request) {
 	for _, cookie := range srv.cookies {
 		req.AddCookie(cookie)
@@ -158,6 +158,23 @@ func (b *tbranch) Class() string {
 	return "TBranch"
 }
 
+// Compression returns the compression algorithm and level of the branch.
+func (b *tbranch) Compression() int32 {
+	return int32(b.compress)
+}
+
+func (b *tbranch) Entries() int64 {
+	return b.entries
+}
+
+func (b *tbranch) TotBytes() int64 {
+	return b.totBytes
+}
+
+func (b *tbranch) ZipBytes() int64 {
+	return b.zipBytes
+}
+
 func (b *tbranch) getTree() *ttree {
 	return b.tree
 }
@@ -6,12 +6,34 @@ package rtree
 
 import (
 	"fmt"
+
+	"go-hep.org/x/hep/groot/rtree/rfunc"
 )
 
 // Copy copies from src to dst until either the reader is depleted or
 // an error occurs. It returns the number of bytes copied and the first error
 // encountered while copying, if any.
 func Copy(dst Writer, src *Reader) (int64, error) {
+	return copyIf(dst, src, nil)
+}
+
+// CopyIf copies from src to dst the entries for which the provided
+// selection evaluates to true, until either the reader is depleted or
+// an error occurs. It returns the number of bytes copied and the first error
+// encountered while copying, if any.
+//
+// The selection is bound to the reader and must evaluate to a bool,
+// ie: its Func method must return a func() bool.
+// Branches needed by the selection that are not copied to dst are
+// automatically loaded from src.
+func CopyIf(dst Writer, src *Reader, sel rfunc.Formula) (int64, error) {
+	if sel == nil {
+		return 0, fmt.Errorf("rtree: invalid nil selection")
+	}
+	return copyIf(dst, src, sel)
+}
+
+func copyIf(dst Writer, src *Reader, sel rfunc.Formula) (int64, error) {
 	// FIXME(sbinet): optimize for the case(s) where:
 	//  - all branches are being copied
 	//  - compression is the same
@@ -31,15 +53,36 @@ func Copy(dst Writer, src *Reader) (int64, error) {
 		}
 	}
 
-	orig := src.rvars
+	var (
+		orig  = src.rvars
+		evals = src.evals
+	)
 	defer func() {
 		src.rvars = orig
+		src.evals = evals
 		src.dirty = true
 	}()
 	src.rvars = rvars
 	src.dirty = true
 
+	accept := func() bool { return true }
+	if sel != nil {
+		f, err := src.Formula(sel)
+		if err != nil {
+			return 0, fmt.Errorf("rtree: could not bind selection: %w", err)
+		}
+		fct, ok := f.Func().(func() bool)
+		if !ok {
+			return 0, fmt.Errorf("rtree: invalid selection function type %T (want func() bool)", f.Func())
+		}
+		accept = fct
+		src.dirty = true
+	}
+
 	err = src.Read(func(ctx RCtx) error {
+		if !accept() {
+			return nil
+		}
 		written, err := dst.Write()
 		if err != nil {
 			return fmt.Errorf("rtree: could not write entry %d to tree: %w", ctx.Entry, err)
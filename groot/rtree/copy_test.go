@@ -15,6 +15,7 @@ import (
 	"go-hep.org/x/hep/groot/rcmd"
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/groot/rtree/rfunc"
 )
 
 func TestCopyTree(t *testing.T) {
@@ -396,3 +397,157 @@ func TestCopyTree(t *testing.T) {
 		})
 	}
 }
+
+func TestCopyIfTree(t *testing.T) {
+	const deep = true
+	tmp, err := os.MkdirTemp("", "groot-rtree-copy-if-")
+	if err != nil {
+		t.Fatalf("could not create tmpdir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, tc := range []struct {
+		name     string
+		branches map[string]int
+		sel      rfunc.Formula
+		want     string
+		err      error
+	}{
+		{
+			name: "all",
+			sel: rfunc.NewFuncF32ToBool([]string{"two"}, func(v float32) bool {
+				return v > 2
+			}),
+			want: `key[000]: tree;1 "fake data" (TTree)
+[000][one]: 2
+[000][two]: 2.2
+[000][three]: dos
+[001][one]: 3
+[001][two]: 3.3
+[001][three]: tres
+[002][one]: 4
+[002][two]: 4.4
+[002][three]: quatro
+`,
+		},
+		{
+			name: "slim",
+			branches: map[string]int{
+				"one":   1,
+				"three": 1,
+			},
+			sel: rfunc.NewFuncF32ToBool([]string{"two"}, func(v float32) bool {
+				return v < 2 || v > 4
+			}),
+			want: `key[000]: tree;1 "fake data" (TTree)
+[000][one]: 1
+[000][three]: uno
+[001][one]: 4
+[001][three]: quatro
+`,
+		},
+		{
+			name: "none",
+			sel: rfunc.NewFuncF32ToBool([]string{"two"}, func(v float32) bool {
+				return v > 10
+			}),
+			want: "key[000]: tree;1 \"fake data\" (TTree)\n",
+		},
+		{
+			name: "invalid-func",
+			sel: rfunc.NewFuncI32ToF64([]string{"one"}, func(v int32) float64 {
+				return float64(v)
+			}),
+			err: fmt.Errorf("rtree: invalid selection function type func() float64 (want func() bool)"),
+		},
+		{
+			name: "invalid-branch",
+			sel: rfunc.NewFuncF32ToBool([]string{"not-there"}, func(v float32) bool {
+				return true
+			}),
+			err: fmt.Errorf("rtree: could not bind selection: rtree: could not create formula: rtree: could not find all needed ReadVars (missing: [not-there])"),
+		},
+		{
+			name: "nil",
+			err:  fmt.Errorf("rtree: invalid nil selection"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := groot.Open("../testdata/simple.root")
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			defer f.Close()
+
+			obj, err := riofs.Dir(f).Get("tree")
+			if err != nil {
+				t.Fatalf("could not get input tree: %+v", err)
+			}
+
+			src := obj.(rtree.Tree)
+
+			wvars := rtree.WriteVarsFromTree(src)
+			if len(tc.branches) > 0 {
+				all := wvars
+				wvars = make([]rtree.WriteVar, 0, len(tc.branches))
+				for _, wvar := range all {
+					if _, ok := tc.branches[wvar.Name]; !ok {
+						continue
+					}
+					wvars = append(wvars, wvar)
+				}
+			}
+
+			oname := filepath.Join(tmp, fmt.Sprintf("copy-if-%s.root", tc.name))
+			o, err := groot.Create(oname)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			defer o.Close()
+
+			dst, err := rtree.NewWriter(o, src.Name(), wvars, rtree.WithTitle(src.Title()))
+			if err != nil {
+				t.Fatalf("could not create tree writer: %+v", err)
+			}
+
+			r, err := rtree.NewReader(src, nil)
+			if err != nil {
+				t.Fatalf("could not create tree reader: %+v", err)
+			}
+			defer r.Close()
+
+			_, err = rtree.CopyIf(dst, r, tc.sel)
+			switch {
+			case err != nil && tc.err != nil:
+				if got, want := err.Error(), tc.err.Error(); got != want {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+				}
+				return
+			case err != nil && tc.err == nil:
+				t.Fatalf("could not copy tree: %+v", err)
+			case err == nil && tc.err != nil:
+				t.Fatalf("expected an error (%v)", tc.err)
+			}
+
+			err = dst.Close()
+			if err != nil {
+				t.Fatalf("could not close tree: %+v", err)
+			}
+
+			err = o.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %+v", err)
+			}
+
+			got := new(bytes.Buffer)
+			err = rcmd.Dump(got, oname, deep, nil)
+			if err != nil {
+				t.Errorf("could not dump output file: %+v", err)
+			}
+
+			if got, want := got.String(), tc.want; got != want {
+				t.Fatalf("invalid root-dump output:\ngot:\n%s\nwant:\n%s\n", got, want)
+			}
+		})
+	}
+}
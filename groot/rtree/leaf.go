@@ -325,10 +325,21 @@ func (leaf *tleafElement) Type() reflect.Type {
 
 func (leaf *tleafElement) TypeName() string {
 	name := leaf.src.Type().Name()
-	if name == "" {
-		panic(fmt.Errorf("rtree: invalid typename for leaf %q", leaf.Name()))
+	if name != "" {
+		return name
+	}
+
+	// unnamed Go types (structs, arrays, ...) generated from streamers:
+	// use the type name of the streamer describing the branch.
+	if b, ok := leaf.branch.(*tbranchElement); ok {
+		switch {
+		case b.estreamer != nil:
+			return b.estreamer.TypeName()
+		case b.streamer != nil:
+			return b.streamer.Name()
+		}
 	}
-	return name
+	panic(fmt.Errorf("rtree: invalid typename for leaf %q", leaf.Name()))
 }
 
 func (leaf *tleafElement) MarshalROOT(w *rbytes.WBuffer) (int, error) {
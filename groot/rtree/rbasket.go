@@ -16,8 +16,14 @@ type rbasket struct {
 	span rspan  // basket entry span
 	bk   Basket // current basket
 	buf  []byte
+
+	strs *rbytes.Interner // strings decoded from this branch
 }
 
+// maxInternedStrings is the maximum number of distinct strings
+// deduplicated across the entries of a branch.
+const maxInternedStrings = 1024
+
 func (rbk *rbasket) reset() {
 	rbk.id = 0
 	rbk.span = rspan{}
@@ -101,5 +107,10 @@ func (rbk *rbasket) inflate(name string, id int, span rspan, eoff int, f *riofs.
 		}
 	}
 
+	if rbk.strs == nil {
+		rbk.strs = rbytes.NewInterner(maxInternedStrings)
+	}
+	rbk.bk.rbuf.SetInterner(rbk.strs)
+
 	return nil
 }
@@ -276,13 +276,17 @@ func newRTree(t *ttree, rvars []ReadVar, n int, beg, end int64) *rtree {
 		usr[rvar.Name+"."+rvar.Leaf] = struct{}{}
 	}
 
-	var rcounts []ReadVar
+	var (
+		rcounts []ReadVar
+		cnts    = make(map[string]struct{})
+	)
 	for _, rvar := range rvars {
 		if rvar.count == "" {
 			continue
 		}
 		leaf := t.Branch(rvar.Name).Leaf(rvar.Leaf).LeafCount()
 		name := leaf.Branch().Name() + "." + leaf.Name()
+		cnts[name] = struct{}{}
 		if _, ok := usr[name]; !ok {
 			var ptr interface{}
 			switch leaf := leaf.(type) {
@@ -305,7 +309,17 @@ func newRTree(t *ttree, rvars []ReadVar, n int, beg, end int64) *rtree {
 			})
 		}
 	}
-	r.rvs = append(rcounts, r.rvs...)
+	// leaf-counts requested by the user need to be read before the
+	// leaves they describe.
+	var rvs []ReadVar
+	for _, rvar := range rvars {
+		if _, ok := cnts[rvar.Name+"."+rvar.Leaf]; ok {
+			rcounts = append(rcounts, rvar)
+			continue
+		}
+		rvs = append(rvs, rvar)
+	}
+	r.rvs = append(rcounts, rvs...)
 	r.rvs = bindRVarsTo(t, r.rvs)
 
 	r.lvs = make([]rleaf, 0, len(r.rvs))
@@ -536,6 +536,47 @@ func TestReaderVarsWithCounterLeaf(t *testing.T) {
 	}
 }
 
+func TestReaderVarsWithCounterLeafAfter(t *testing.T) {
+	f, err := riofs.Open("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := obj.(Tree)
+
+	var (
+		data  []float64
+		n     int32
+		rvars = []ReadVar{
+			{Name: "SliceFloat64", Value: &data},
+			{Name: "N", Value: &n},
+		}
+	)
+	r, err := NewReader(tree, rvars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx RCtx) error {
+		if got, want := int(n), int(ctx.Entry%10); got != want {
+			return fmt.Errorf("entry[%d]: invalid count: got=%d, want=%d", ctx.Entry, got, want)
+		}
+		if got, want := len(data), int(n); got != want {
+			return fmt.Errorf("entry[%d]: invalid slice length: got=%d, want=%d", ctx.Entry, got, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestScannerStructWithStdVectorBool(t *testing.T) {
 	files, err := filepath.Glob("../testdata/stdvec-bool-*.root")
 	if err != nil {
@@ -37,6 +37,20 @@ type Branch interface {
 	Branch(name string) Branch
 	Leaf(name string) Leaf
 
+	// Compression returns the compression algorithm and level of the branch.
+	Compression() int32
+
+	// Entries returns the number of entries of the branch.
+	Entries() int64
+
+	// TotBytes returns the number of bytes of the branch's baskets
+	// before compression.
+	TotBytes() int64
+
+	// ZipBytes returns the number of bytes of the branch's baskets
+	// after compression.
+	ZipBytes() int64
+
 	setTree(*ttree)
 	getTree() *ttree
 	loadEntry(i int64) error
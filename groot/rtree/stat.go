@@ -0,0 +1,46 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+// BasketStat describes the baskets of a branch.
+type BasketStat struct {
+	N     int   // number of baskets of the branch
+	Bytes int64 // number of bytes of the baskets on file, including their key
+}
+
+// Baskets returns statistics about the baskets of the provided branch.
+// Baskets stored with the tree (e.g. baskets recovered from a file that
+// was not properly closed) are also accounted for.
+// Sub-branches are not taken into account.
+func Baskets(b Branch) BasketStat {
+	var (
+		br  = asBranch(b)
+		nbk = br.writeBasket
+		st  BasketStat
+	)
+	if nbk > len(br.basketBytes) {
+		nbk = len(br.basketBytes)
+	}
+	for _, n := range br.basketBytes[:nbk] {
+		if n <= 0 {
+			continue
+		}
+		st.N++
+		st.Bytes += int64(n)
+	}
+	if nbk < len(br.basketEntry) && br.basketEntry[nbk] == br.entries {
+		// all entries are held by the baskets on file.
+		return st
+	}
+	for i := range br.baskets {
+		bkt := &br.baskets[i]
+		if bkt.key.Nbytes() <= 0 {
+			continue
+		}
+		st.N++
+		st.Bytes += int64(bkt.key.Nbytes())
+	}
+	return st
+}
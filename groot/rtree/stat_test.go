@@ -0,0 +1,44 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestBaskets(t *testing.T) {
+	f, err := riofs.Open("../testdata/small-evnt-tree-fullsplit.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		want BasketStat
+	}{
+		{name: "evt", want: BasketStat{N: 0, Bytes: 0}},
+		{name: "Beg", want: BasketStat{N: 1, Bytes: 462}},
+		{name: "P3.Px", want: BasketStat{N: 1, Bytes: 247}},
+		{name: "StlVecF64", want: BasketStat{N: 1, Bytes: 974}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := tree.Branch(tc.name)
+			if b == nil {
+				t.Fatalf("could not find branch %q", tc.name)
+			}
+			if got, want := Baskets(b), tc.want; got != want {
+				t.Fatalf("invalid basket stats: got=%+v, want=%+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// Tail follows a tree stored in a ROOT file that is being written to,
+// e.g. by a data acquisition job regularly saving its tree and the file header.
+//
+// Each call to Poll re-opens the file, refreshes the tree header and
+// presents the entries appended since the previous call.
+type Tail struct {
+	fname string
+	tname string
+
+	end  int64 // end of the file when last polled
+	next int64 // index of the next entry to present
+}
+
+// NewTail returns a Tail following the named tree of the named ROOT file.
+// The first call to Poll will present the entries of the tree starting
+// from entry beg.
+func NewTail(fname, tname string, beg int64) *Tail {
+	return &Tail{
+		fname: fname,
+		tname: tname,
+		next:  beg,
+	}
+}
+
+// Entry returns the index of the next entry to be presented by Poll.
+func (t *Tail) Entry() int64 {
+	return t.next
+}
+
+// Poll re-opens the file and, if entries were appended to the tree since the
+// previous call, calls f with the refreshed tree and the half-open interval
+// [beg, end) of the new entries.
+// The tree is only valid during the call to f.
+//
+// Poll returns the number of new entries.
+// A file whose header was not updated since the previous call is not
+// inspected any further.
+// When f returns an error, the new entries will be presented again by the
+// next call to Poll.
+func (t *Tail) Poll(f func(tree Tree, beg, end int64) error) (int64, error) {
+	file, err := riofs.Open(t.fname)
+	if err != nil {
+		return 0, fmt.Errorf("rtree: could not open file %q: %w", t.fname, err)
+	}
+	defer file.Close()
+
+	if file.End() == t.end {
+		return 0, nil
+	}
+
+	obj, err := riofs.Dir(file).Get(t.tname)
+	if err != nil {
+		return 0, fmt.Errorf("rtree: could not retrieve tree %q: %w", t.tname, err)
+	}
+	tree, ok := obj.(Tree)
+	if !ok {
+		return 0, fmt.Errorf("rtree: object %q in file %q is not a Tree", t.tname, t.fname)
+	}
+
+	var (
+		beg = t.next
+		end = tree.Entries()
+	)
+	if end <= beg {
+		t.end = file.End()
+		return 0, nil
+	}
+
+	err = f(tree, beg, end)
+	if err != nil {
+		return 0, err
+	}
+
+	t.end = file.End()
+	t.next = end
+	return end - beg, nil
+}
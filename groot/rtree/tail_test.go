@@ -0,0 +1,116 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestTail(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "groot-rtree-tail-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "tail.root")
+
+	// write simulates a file being appended to, by atomically replacing
+	// the followed file with a file holding n entries.
+	write := func(n int) {
+		t.Helper()
+		tmpname := filepath.Join(tmp, fmt.Sprintf("tail-%d.root", n))
+		f, err := groot.Create(tmpname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		var v int32
+		w, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{{Name: "v", Value: &v}})
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		for i := 0; i < n; i++ {
+			v = int32(i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d: %+v", i, err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+		err = os.Rename(tmpname, fname)
+		if err != nil {
+			t.Fatalf("could not rename file: %+v", err)
+		}
+	}
+
+	tail := rtree.NewTail(fname, "tree", 1)
+	_, err = tail.Poll(func(rtree.Tree, int64, int64) error { return nil })
+	if err == nil {
+		t.Fatalf("expected an error polling a missing file")
+	}
+
+	var got []int32
+	poll := func() int64 {
+		t.Helper()
+		n, err := tail.Poll(func(tree rtree.Tree, beg, end int64) error {
+			var v int32
+			r, err := rtree.NewReader(tree, []rtree.ReadVar{{Name: "v", Value: &v}}, rtree.WithRange(beg, end))
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			return r.Read(func(rtree.RCtx) error {
+				got = append(got, v)
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatalf("could not poll tree: %+v", err)
+		}
+		return n
+	}
+
+	for _, tc := range []struct {
+		entries int // number of entries on file, -1 to leave the file untouched
+		n       int64
+		next    int64
+	}{
+		{entries: 0, n: 0, next: 1},
+		{entries: 3, n: 2, next: 3},
+		{entries: -1, n: 0, next: 3},
+		{entries: 3, n: 0, next: 3},
+		{entries: 7, n: 4, next: 7},
+	} {
+		if tc.entries >= 0 {
+			write(tc.entries)
+		}
+		if got, want := poll(), tc.n; got != want {
+			t.Fatalf("invalid number of new entries: got=%d, want=%d", got, want)
+		}
+		if got, want := tail.Entry(), tc.next; got != want {
+			t.Fatalf("invalid next entry: got=%d, want=%d", got, want)
+		}
+	}
+
+	if want := []int32{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, want)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// Validate checks the structural consistency of the provided tree:
+//   - the cluster ranges are ordered and within the entries of the tree,
+//   - each branch holds (at least) the number of entries of the tree,
+//   - the baskets of each branch are ordered, located on file and hold
+//     the number of entries recorded by their branch,
+//   - the payload of each basket can be decompressed.
+//
+// Validate returns the list of inconsistencies found, if any.
+// Only trees read from a ROOT file can be validated.
+func Validate(t Tree) []error {
+	var tree *ttree
+	switch t := t.(type) {
+	case *ttree:
+		tree = t
+	case *tntuple:
+		tree = &t.ttree
+	case *tntupleD:
+		tree = &t.ttree
+	default:
+		return []error{fmt.Errorf("rtree: validation of %T trees is not supported", t)}
+	}
+
+	v := validator{tree: tree, f: tree.getFile()}
+	v.clusters()
+	for _, b := range tree.branches {
+		v.branch(b)
+	}
+	return v.errs
+}
+
+type validator struct {
+	tree *ttree
+	f    *riofs.File
+	buf  []byte
+	errs []error
+}
+
+func (v *validator) errorf(format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf(format, args...))
+}
+
+func (v *validator) clusters() {
+	var (
+		ranges = v.tree.clusters.ranges
+		sizes  = v.tree.clusters.sizes
+		prev   = int64(-1)
+	)
+	if len(ranges) != len(sizes) {
+		v.errorf("invalid number of cluster ranges (ranges=%d, sizes=%d)", len(ranges), len(sizes))
+		return
+	}
+	for i, end := range ranges {
+		switch {
+		case end <= prev:
+			v.errorf("cluster range %d: invalid last entry %d (previous=%d)", i, end, prev)
+		case end >= v.tree.entries:
+			v.errorf("cluster range %d: invalid last entry %d (entries=%d)", i, end, v.tree.entries)
+		}
+		if sizes[i] < 0 {
+			v.errorf("cluster range %d: invalid cluster size %d", i, sizes[i])
+		}
+		prev = end
+	}
+}
+
+func (v *validator) branch(b Branch) {
+	defer func() {
+		for _, sub := range b.Branches() {
+			v.branch(sub)
+		}
+	}()
+
+	var (
+		name = b.Name()
+		br   = asBranch(b)
+		nbk  = br.writeBasket
+	)
+	if br.entries < v.tree.entries {
+		// branches filled directly (ie: not through the tree) may hold more entries.
+		v.errorf("branch %q: invalid number of entries %d (tree=%d)", name, br.entries, v.tree.entries)
+	}
+
+	if nbk < 0 || nbk > len(br.basketSeek) || nbk > len(br.basketBytes) || nbk >= len(br.basketEntry) {
+		v.errorf(
+			"branch %q: invalid number of baskets %d (seeks=%d, bytes=%d, entries=%d)",
+			name, nbk, len(br.basketSeek), len(br.basketBytes), len(br.basketEntry),
+		)
+		return
+	}
+
+	for i := 0; i < nbk; i++ {
+		var (
+			beg  = br.basketEntry[i]
+			end  = br.basketEntry[i+1]
+			seek = br.basketSeek[i]
+			size = br.basketBytes[i]
+		)
+		if end < beg {
+			v.errorf("branch %q: basket %d: invalid entry range [%d, %d)", name, i, beg, end)
+			continue
+		}
+		if seek <= 0 || size <= 0 {
+			v.errorf("branch %q: basket %d: invalid location (seek=%d, nbytes=%d)", name, i, seek, size)
+			continue
+		}
+		err := v.basket(seek, size, end-beg)
+		if err != nil {
+			v.errorf("branch %q: basket %d: %w", name, i, err)
+		}
+	}
+
+	n := br.basketEntry[nbk]
+	if n != br.entries {
+		// baskets recovered from an unclosed file are stored with the tree.
+		for i := range br.baskets {
+			n += int64(br.baskets[i].nevbuf)
+		}
+	}
+	switch {
+	case nbk == 0 && len(br.baskets) == 0 && len(br.branches) > 0:
+		// split branch: data is held by the sub-branches.
+	case n != br.entries:
+		v.errorf("branch %q: invalid number of entries in baskets %d (branch=%d)", name, n, br.entries)
+	}
+}
+
+// basket checks the basket located at seek on file, with the provided size
+// in bytes and number of entries.
+func (v *validator) basket(seek int64, size int32, n int64) (err error) {
+	defer func() {
+		// decoding a damaged basket may panic.
+		if e := recover(); e != nil {
+			err = fmt.Errorf("could not decode basket: %v", e)
+		}
+	}()
+
+	v.buf = rbytes.ResizeU8(v.buf, int(size))
+	_, err = v.f.ReadAt(v.buf, seek)
+	if err != nil {
+		return fmt.Errorf("could not read basket: %w", err)
+	}
+
+	var bk Basket
+	err = bk.UnmarshalROOT(rbytes.NewRBuffer(v.buf, nil, 0, v.f))
+	if err != nil {
+		return fmt.Errorf("could not decode basket header: %w", err)
+	}
+
+	switch {
+	case bk.key.ClassName() != "TBasket":
+		return fmt.Errorf("invalid basket class %q", bk.key.ClassName())
+	case bk.key.SeekKey() != seek:
+		return fmt.Errorf("invalid basket position %d (branch=%d)", bk.key.SeekKey(), seek)
+	case bk.key.Nbytes() != size:
+		return fmt.Errorf("invalid basket size %d (branch=%d)", bk.key.Nbytes(), size)
+	case int64(bk.nevbuf) != n:
+		return fmt.Errorf("invalid number of entries %d (branch=%d)", bk.nevbuf, n)
+	}
+
+	bk.key.SetFile(v.f)
+	_, err = bk.key.Load(make([]byte, bk.key.ObjLen()))
+	if err != nil {
+		return fmt.Errorf("could not decompress basket: %w", err)
+	}
+
+	return nil
+}
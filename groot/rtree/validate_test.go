@@ -0,0 +1,170 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		fname  string
+		tname  string
+		damage func(t *ttree)
+		want   []string
+	}{
+		{
+			name:  "simple",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+		},
+		{
+			name:  "small-flat-tree",
+			fname: "../testdata/small-flat-tree.root",
+			tname: "tree",
+		},
+		{
+			name:  "evnt-tree-fullsplit",
+			fname: "../testdata/small-evnt-tree-fullsplit.root",
+			tname: "tree",
+		},
+		{
+			name:  "tntuple",
+			fname: "../testdata/tntuple.root",
+			tname: "ntup",
+		},
+		{
+			name:  "clusters",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			damage: func(t *ttree) {
+				t.clusters.ranges = []int64{2, 1, 4}
+				t.clusters.sizes = []int64{1, 1, -1}
+			},
+			want: []string{
+				"cluster range 1: invalid last entry 1 (previous=2)",
+				"cluster range 2: invalid last entry 4 (entries=4)",
+				"cluster range 2: invalid cluster size -1",
+			},
+		},
+		{
+			name:  "cluster-sizes",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			damage: func(t *ttree) {
+				t.clusters.ranges = []int64{2}
+				t.clusters.sizes = nil
+			},
+			want: []string{
+				"invalid number of cluster ranges (ranges=1, sizes=0)",
+			},
+		},
+		{
+			name:  "entries",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			damage: func(t *ttree) {
+				asBranch(t.branches[0]).entries = 3
+			},
+			want: []string{
+				`branch "one": invalid number of entries 3 (tree=4)`,
+				`branch "one": invalid number of entries in baskets 4 (branch=3)`,
+			},
+		},
+		{
+			name:  "basket-entries",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			damage: func(t *ttree) {
+				asBranch(t.branches[1]).basketEntry[1] = 3
+			},
+			want: []string{
+				`branch "two": basket 0: invalid number of entries 4 (branch=3)`,
+				`branch "two": invalid number of entries in baskets 3 (branch=4)`,
+			},
+		},
+		{
+			name:  "basket-seek",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			damage: func(t *ttree) {
+				asBranch(t.branches[2]).basketSeek[0] = asBranch(t.branches[1]).basketSeek[0] + 1
+				asBranch(t.branches[1]).basketSeek[0] = 0
+			},
+			want: []string{
+				`branch "two": basket 0: invalid location (seek=0, nbytes=86)`,
+				`branch "three": basket 0: could not decode basket: rtree: invalid basket[] state (flag=34 <= 40)`,
+			},
+		},
+		{
+			name:  "basket-bytes",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			damage: func(t *ttree) {
+				asBranch(t.branches[2]).basketBytes[0]--
+			},
+			want: []string{
+				`branch "three": basket 0: invalid basket size 116 (branch=115)`,
+			},
+		},
+		{
+			name:  "num-baskets",
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			damage: func(t *ttree) {
+				asBranch(t.branches[0]).writeBasket = 100
+			},
+			want: []string{
+				`branch "one": invalid number of baskets 100 (seeks=1, bytes=1, entries=2)`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := riofs.Open(tc.fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			obj, err := f.Get(tc.tname)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tree := obj.(Tree)
+			if tc.damage != nil {
+				switch tree := tree.(type) {
+				case *ttree:
+					tc.damage(tree)
+				default:
+					t.Fatalf("invalid tree type %T", tree)
+				}
+			}
+
+			errs := Validate(tree)
+			got := make([]string, len(errs))
+			for i, err := range errs {
+				got[i] = err.Error()
+			}
+			if got, want := fmt.Sprintf("%q", got), fmt.Sprintf("%q", tc.want); got != want {
+				t.Fatalf("invalid validation errors:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
+
+func TestValidateChain(t *testing.T) {
+	errs := Validate(Chain())
+	if len(errs) != 1 {
+		t.Fatalf("invalid number of errors: got=%d, want=1", len(errs))
+	}
+	if got, want := errs[0].Error(), "rtree: validation of *rtree.chain trees is not supported"; got != want {
+		t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+	}
+}
@@ -11,12 +11,14 @@ const ROOT = 63200
 
 // ROOT classes versions
 const (
+	Att3D                    = 1  // ROOT version for TAtt3D
 	AttAxis                  = 4  // ROOT version for TAttAxis
 	AttBBox2D                = 0  // ROOT version for TAttBBox2D
 	AttFill                  = 2  // ROOT version for TAttFill
 	AttLine                  = 2  // ROOT version for TAttLine
 	AttMarker                = 2  // ROOT version for TAttMarker
 	AttPad                   = 4  // ROOT version for TAttPad
+	AttText                  = 2  // ROOT version for TAttText
 	Datime                   = 1  // ROOT version for TDatime
 	Named                    = 1  // ROOT version for TNamed
 	Object                   = 1  // ROOT version for TObject
@@ -89,6 +91,10 @@ const (
 	H2Poly                   = 3  // ROOT version for TH2Poly
 	H2PolyBin                = 1  // ROOT version for TH2PolyBin
 	H2S                      = 4  // ROOT version for TH2S
+	H3                       = 6  // ROOT version for TH3
+	H3D                      = 4  // ROOT version for TH3D
+	H3F                      = 4  // ROOT version for TH3F
+	H3I                      = 4  // ROOT version for TH3I
 	Limit                    = 2  // ROOT version for TLimit
 	LimitDataSource          = 2  // ROOT version for TLimitDataSource
 	MultiGraph               = 2  // ROOT version for TMultiGraph
@@ -99,6 +105,7 @@ const (
 	DirectoryFile            = 5  // ROOT version for TDirectoryFile
 	File                     = 8  // ROOT version for TFile
 	Key                      = 4  // ROOT version for TKey
+	ROOT_RNTuple             = 2  // ROOT version for ROOT::RNTuple
 	FeldmanCousins           = 1  // ROOT version for TFeldmanCousins
 	LorentzVector            = 4  // ROOT version for TLorentzVector
 	Vector2                  = 3  // ROOT version for TVector2
@@ -129,5 +136,8 @@ const (
 	Tree                     = 20 // ROOT version for TTree
 	AttCanvas                = 1  // ROOT version for TAttCanvas
 	Canvas                   = 8  // ROOT version for TCanvas
+	Latex                    = 2  // ROOT version for TLatex
+	Line                     = 3  // ROOT version for TLine
 	Pad                      = 13 // ROOT version for TPad
+	Text                     = 3  // ROOT version for TText
 )
@@ -33,6 +33,63 @@ func (ann Annotation) clone() Annotation {
 	return out
 }
 
+// yodaKeys maps the keys of hbook annotations to their YODA counterparts.
+//
+// The "name" of an hbook object is stored as the YODA "Path", with a
+// leading "/", and the YODA "Type" is derived from the type of the object.
+// All the other keys, including user-defined ones, are transmitted verbatim.
+var yodaKeys = map[string]string{
+	"title":  "Title",
+	"xlabel": "XLabel",
+	"ylabel": "YLabel",
+	"zlabel": "ZLabel",
+}
+
+// hbookKeys maps the keys of YODA annotations to their hbook counterparts.
+var hbookKeys = func() map[string]string {
+	keys := make(map[string]string, len(yodaKeys))
+	for k, v := range yodaKeys {
+		keys[v] = k
+	}
+	return keys
+}()
+
+// toYODA returns a new Annotation with fields compatible with YODA, for an
+// object of the provided YODA type and name.
+func (ann Annotation) toYODA(typ, name string) Annotation {
+	out := make(Annotation, len(ann)+2)
+	out["Type"] = typ
+	out["Path"] = "/" + name
+	out["Title"] = ""
+	for k, v := range ann {
+		if k == "name" {
+			continue
+		}
+		if key, ok := yodaKeys[k]; ok {
+			k = key
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// fromYODA fills ann with the YODA compatible fields of yoda.
+func (ann Annotation) fromYODA(yoda Annotation) {
+	for k, v := range yoda {
+		switch k {
+		case "Type":
+			continue
+		case "Path":
+			ann["name"] = strings.TrimPrefix(v.(string), "/")
+			continue
+		}
+		if key, ok := hbookKeys[k]; ok {
+			k = key
+		}
+		ann[k] = v
+	}
+}
+
 // MarshalYODA implements the YODAMarshaler interface.
 func (ann Annotation) MarshalYODA() ([]byte, error) {
 	return ann.marshalYODAv2()
@@ -83,3 +83,56 @@ func TestAnnotationClone(t *testing.T) {
 		)
 	}
 }
+
+func TestAnnotationYODAMapping(t *testing.T) {
+	want := Annotation{
+		"name":   "dir/h1d",
+		"title":  "my title",
+		"xlabel": "$p_T$ [GeV]",
+		"ylabel": "events",
+		"meta":   "data",
+	}
+
+	type yodaer interface {
+		Annotation() Annotation
+		MarshalYODA() ([]byte, error)
+		UnmarshalYODA([]byte) error
+	}
+
+	for _, tc := range []struct {
+		name string
+		w, r yodaer
+	}{
+		{"H1D", NewH1D(10, 0, 10), &H1D{}},
+		{"H2D", NewH2D(10, 0, 10, 10, 0, 10), &H2D{}},
+		{"P1D", NewP1D(10, 0, 10), &P1D{}},
+		{"S2D", NewS2D(Point2D{X: 1, Y: 1}), &S2D{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range want {
+				tc.w.Annotation()[k] = v
+			}
+
+			raw, err := tc.w.MarshalYODA()
+			if err != nil {
+				t.Fatalf("could not marshal: %+v", err)
+			}
+			for _, key := range []string{
+				"Path: /dir/h1d", "Title: my title", "XLabel:", "YLabel: events", "meta: data",
+			} {
+				if !bytes.Contains(raw, []byte(key)) {
+					t.Fatalf("missing YODA annotation %q:\n%s", key, raw)
+				}
+			}
+
+			err = tc.r.UnmarshalYODA(raw)
+			if err != nil {
+				t.Fatalf("could not unmarshal: %+v", err)
+			}
+
+			if got := tc.r.Annotation(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid annotation:\ngot= %#v\nwant=%#v", got, want)
+			}
+		})
+	}
+}
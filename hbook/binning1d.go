@@ -28,6 +28,12 @@ var (
 	errShortYAxis     = errors.New("hbook: too few 1-dim Y-bins")
 	errNotSortedYAxis = errors.New("hbook: Y-edges slice not sorted")
 	errDupEdgesYAxis  = errors.New("hbook: duplicates in Y-edge values")
+
+	errInvalidZAxis   = errors.New("hbook: invalid Z-axis limits")
+	errEmptyZAxis     = errors.New("hbook: Z-axis with zero bins")
+	errShortZAxis     = errors.New("hbook: too few 1-dim Z-bins")
+	errNotSortedZAxis = errors.New("hbook: Z-edges slice not sorted")
+	errDupEdgesZAxis  = errors.New("hbook: duplicates in Z-edge values")
 )
 
 // Binning1D is a 1-dim binning of the x-axis.
@@ -125,6 +131,28 @@ func (bng *Binning1D) clone() Binning1D {
 	return o
 }
 
+// empty returns a new binning with the same bins and no entries.
+func (bng *Binning1D) empty() Binning1D {
+	o := Binning1D{
+		Bins:   make([]Bin1D, len(bng.Bins)),
+		XRange: bng.XRange,
+	}
+	for i, bin := range bng.Bins {
+		o.Bins[i].Range = bin.Range
+	}
+	return o
+}
+
+func (bng *Binning1D) addScaled(a, a2 float64, o *Binning1D) {
+	bng.Dist.addScaled(a, a2, o.Dist)
+	for i := range bng.Outflows {
+		bng.Outflows[i].addScaled(a, a2, o.Outflows[i])
+	}
+	for i := range bng.Bins {
+		bng.Bins[i].addScaled(a, a2, o.Bins[i])
+	}
+}
+
 func (bng *Binning1D) entries() int64 {
 	return bng.Dist.Entries()
 }
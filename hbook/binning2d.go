@@ -133,6 +133,52 @@ func newBinning2DFromEdges(xedges, yedges []float64) Binning2D {
 	return bng
 }
 
+func (bng *Binning2D) clone() Binning2D {
+	o := *bng
+	o.Bins = append([]Bin2D(nil), bng.Bins...)
+	o.XEdges = append([]Bin1D(nil), bng.XEdges...)
+	o.YEdges = append([]Bin1D(nil), bng.YEdges...)
+	return o
+}
+
+// empty returns a new binning with the same bins and no entries.
+func (bng *Binning2D) empty() Binning2D {
+	o := Binning2D{
+		Bins:   make([]Bin2D, len(bng.Bins)),
+		XRange: bng.XRange,
+		YRange: bng.YRange,
+		Nx:     bng.Nx,
+		Ny:     bng.Ny,
+		XEdges: append([]Bin1D(nil), bng.XEdges...),
+		YEdges: append([]Bin1D(nil), bng.YEdges...),
+	}
+	for i, bin := range bng.Bins {
+		o.Bins[i].XRange = bin.XRange
+		o.Bins[i].YRange = bin.YRange
+	}
+	return o
+}
+
+func (bng *Binning2D) addScaled(a, a2 float64, o *Binning2D) {
+	bng.Dist.addScaled(a, a2, o.Dist)
+	for i := range bng.Outflows {
+		bng.Outflows[i].addScaled(a, a2, o.Outflows[i])
+	}
+	for i := range bng.Bins {
+		bng.Bins[i].Dist.addScaled(a, a2, o.Bins[i].Dist)
+	}
+}
+
+func (bng *Binning2D) scaleW(f float64) {
+	bng.Dist.scaleW(f)
+	for i := range bng.Outflows {
+		bng.Outflows[i].scaleW(f)
+	}
+	for i := range bng.Bins {
+		bng.Bins[i].Dist.scaleW(f)
+	}
+}
+
 func (bng *Binning2D) entries() int64 {
 	return bng.Dist.Entries()
 }
@@ -180,7 +226,7 @@ func (bng *Binning2D) coordToIndex(x, y float64) int {
 	iy := Bin1Ds(bng.YEdges).IndexOf(y)
 
 	switch {
-	case ix == bng.Nx && iy == bng.Ny: // GAP
+	case ix == bng.Nx || iy == bng.Ny: // GAP
 		return len(bng.Bins)
 	case ix == OverflowBin1D && iy == OverflowBin1D:
 		return -BngNE
@@ -0,0 +1,283 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import "sort"
+
+// Binning3D is a 3-dim binning of the (x,y,z) space.
+//
+// The bin (ix,iy,iz) is located at index (iz*Ny+iy)*Nx+ix of Bins.
+type Binning3D struct {
+	Bins     []Bin3D
+	Dist     Dist3D
+	Outflows [26]Dist3D // outflows, see Binning3D.Outflow
+	XRange   Range
+	YRange   Range
+	ZRange   Range
+	Nx       int
+	Ny       int
+	Nz       int
+	XEdges   []Bin1D
+	YEdges   []Bin1D
+	ZEdges   []Bin1D
+}
+
+func newBinning3D(nx int, xlow, xhigh float64, ny int, ylow, yhigh float64, nz int, zlow, zhigh float64) Binning3D {
+	if xlow >= xhigh {
+		panic(errInvalidXAxis)
+	}
+	if ylow >= yhigh {
+		panic(errInvalidYAxis)
+	}
+	if zlow >= zhigh {
+		panic(errInvalidZAxis)
+	}
+	if nx <= 0 {
+		panic(errEmptyXAxis)
+	}
+	if ny <= 0 {
+		panic(errEmptyYAxis)
+	}
+	if nz <= 0 {
+		panic(errEmptyZAxis)
+	}
+	edges := func(n int, low, high float64) []float64 {
+		o := make([]float64, n+1)
+		width := (high - low) / float64(n)
+		for i := range o {
+			o[i] = low + float64(i)*width
+		}
+		o[n] = high
+		return o
+	}
+	return newBinning3DFrom(
+		edges(nx, xlow, xhigh),
+		edges(ny, ylow, yhigh),
+		edges(nz, zlow, zhigh),
+	)
+}
+
+func newBinning3DFromEdges(xedges, yedges, zedges []float64) Binning3D {
+	for _, axis := range []struct {
+		edges  []float64
+		short  error
+		sorted error
+		dups   error
+	}{
+		{xedges, errShortXAxis, errNotSortedXAxis, errDupEdgesXAxis},
+		{yedges, errShortYAxis, errNotSortedYAxis, errDupEdgesYAxis},
+		{zedges, errShortZAxis, errNotSortedZAxis, errDupEdgesZAxis},
+	} {
+		if len(axis.edges) <= 1 {
+			panic(axis.short)
+		}
+		if !sort.IsSorted(sort.Float64Slice(axis.edges)) {
+			panic(axis.sorted)
+		}
+		for i := 1; i < len(axis.edges); i++ {
+			if axis.edges[i-1] == axis.edges[i] {
+				panic(axis.dups)
+			}
+		}
+	}
+	return newBinning3DFrom(xedges, yedges, zedges)
+}
+
+func newBinning3DFrom(xedges, yedges, zedges []float64) Binning3D {
+	var (
+		nx = len(xedges) - 1
+		ny = len(yedges) - 1
+		nz = len(zedges) - 1
+	)
+	bng := Binning3D{
+		Bins:   make([]Bin3D, nx*ny*nz),
+		XRange: Range{Min: xedges[0], Max: xedges[nx]},
+		YRange: Range{Min: yedges[0], Max: yedges[ny]},
+		ZRange: Range{Min: zedges[0], Max: zedges[nz]},
+		Nx:     nx,
+		Ny:     ny,
+		Nz:     nz,
+		XEdges: make([]Bin1D, nx),
+		YEdges: make([]Bin1D, ny),
+		ZEdges: make([]Bin1D, nz),
+	}
+	for i := range bng.XEdges {
+		bng.XEdges[i].Range = Range{Min: xedges[i], Max: xedges[i+1]}
+	}
+	for i := range bng.YEdges {
+		bng.YEdges[i].Range = Range{Min: yedges[i], Max: yedges[i+1]}
+	}
+	for i := range bng.ZEdges {
+		bng.ZEdges[i].Range = Range{Min: zedges[i], Max: zedges[i+1]}
+	}
+	for iz := 0; iz < nz; iz++ {
+		for iy := 0; iy < ny; iy++ {
+			for ix := 0; ix < nx; ix++ {
+				bin := &bng.Bins[bng.index(ix, iy, iz)]
+				bin.XRange = bng.XEdges[ix].Range
+				bin.YRange = bng.YEdges[iy].Range
+				bin.ZRange = bng.ZEdges[iz].Range
+			}
+		}
+	}
+	return bng
+}
+
+func (bng *Binning3D) clone() Binning3D {
+	o := *bng
+	o.Bins = append([]Bin3D(nil), bng.Bins...)
+	o.XEdges = append([]Bin1D(nil), bng.XEdges...)
+	o.YEdges = append([]Bin1D(nil), bng.YEdges...)
+	o.ZEdges = append([]Bin1D(nil), bng.ZEdges...)
+	return o
+}
+
+// empty returns a new binning with the same bins and no entries.
+func (bng *Binning3D) empty() Binning3D {
+	o := Binning3D{
+		Bins:   make([]Bin3D, len(bng.Bins)),
+		XRange: bng.XRange,
+		YRange: bng.YRange,
+		ZRange: bng.ZRange,
+		Nx:     bng.Nx,
+		Ny:     bng.Ny,
+		Nz:     bng.Nz,
+		XEdges: append([]Bin1D(nil), bng.XEdges...),
+		YEdges: append([]Bin1D(nil), bng.YEdges...),
+		ZEdges: append([]Bin1D(nil), bng.ZEdges...),
+	}
+	for i, bin := range bng.Bins {
+		o.Bins[i].XRange = bin.XRange
+		o.Bins[i].YRange = bin.YRange
+		o.Bins[i].ZRange = bin.ZRange
+	}
+	return o
+}
+
+func (bng *Binning3D) entries() int64 {
+	return bng.Dist.Entries()
+}
+
+func (bng *Binning3D) effEntries() float64 {
+	return bng.Dist.EffEntries()
+}
+
+// index returns the index in Bins of the (ix,iy,iz) bin.
+func (bng *Binning3D) index(ix, iy, iz int) int {
+	return (iz*bng.Ny+iy)*bng.Nx + ix
+}
+
+// Outflow returns the distribution of the entries outside of the range
+// of the binning.
+// Each of ox, oy and oz is -1, 0 or +1 to select the entries below, inside
+// or above the range of the x-, y- and z-axis, respectively.
+// Outflow returns nil when ox, oy and oz are all zero.
+func (bng *Binning3D) Outflow(ox, oy, oz int) *Dist3D {
+	i := outflowIndex3D(ox, oy, oz)
+	if i < 0 {
+		return nil
+	}
+	return &bng.Outflows[i]
+}
+
+// outflowIndex3D returns the index in Binning3D.Outflows of the region
+// (ox,oy,oz), or -1 for the in-range region.
+func outflowIndex3D(ox, oy, oz int) int {
+	const inrange = 13 // (0,0,0)
+	i := (ox + 1) + 3*(oy+1) + 9*(oz+1)
+	switch {
+	case i == inrange:
+		return -1
+	case i > inrange:
+		return i - 1
+	}
+	return i
+}
+
+// outflowCode returns -1, 0 or +1 for an under-flow, in-range or over-flow
+// 1-dim bin index.
+func outflowCode(i int) int {
+	switch i {
+	case UnderflowBin1D:
+		return -1
+	case OverflowBin1D:
+		return +1
+	}
+	return 0
+}
+
+func (bng *Binning3D) fill(x, y, z, w float64) {
+	bng.Dist.fill(x, y, z, w)
+
+	var (
+		ix = Bin1Ds(bng.XEdges).IndexOf(x)
+		iy = Bin1Ds(bng.YEdges).IndexOf(y)
+		iz = Bin1Ds(bng.ZEdges).IndexOf(z)
+	)
+	if ix == bng.Nx || iy == bng.Ny || iz == bng.Nz {
+		// GAP bin
+		return
+	}
+	if d := bng.Outflow(outflowCode(ix), outflowCode(iy), outflowCode(iz)); d != nil {
+		d.fill(x, y, z, w)
+		return
+	}
+	bng.Bins[bng.index(ix, iy, iz)].fill(x, y, z, w)
+}
+
+// coordToIndex returns the index in Bins of the bin containing (x,y,z),
+// or -1 if the coordinates are outside of the binning range.
+func (bng *Binning3D) coordToIndex(x, y, z float64) int {
+	var (
+		ix = Bin1Ds(bng.XEdges).IndexOf(x)
+		iy = Bin1Ds(bng.YEdges).IndexOf(y)
+		iz = Bin1Ds(bng.ZEdges).IndexOf(z)
+	)
+	switch {
+	case ix < 0 || iy < 0 || iz < 0:
+		return -1
+	case ix == bng.Nx || iy == bng.Ny || iz == bng.Nz:
+		return -1
+	}
+	return bng.index(ix, iy, iz)
+}
+
+func (bng *Binning3D) addScaled(a, a2 float64, o *Binning3D) {
+	bng.Dist.addScaled(a, a2, o.Dist)
+	for i := range bng.Outflows {
+		bng.Outflows[i].addScaled(a, a2, o.Outflows[i])
+	}
+	for i := range bng.Bins {
+		bng.Bins[i].Dist.addScaled(a, a2, o.Bins[i].Dist)
+	}
+}
+
+func (bng *Binning3D) scaleW(f float64) {
+	bng.Dist.scaleW(f)
+	for i := range bng.Outflows {
+		bng.Outflows[i].scaleW(f)
+	}
+	for i := range bng.Bins {
+		bng.Bins[i].Dist.scaleW(f)
+	}
+}
+
+// sameEdges returns whether the two binnings have the same bins.
+func (bng *Binning3D) sameEdges(o *Binning3D) bool {
+	same := func(a, b []Bin1D) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !fuzzyEq(a[i].Range.Min, b[i].Range.Min) || !fuzzyEq(a[i].Range.Max, b[i].Range.Max) {
+				return false
+			}
+		}
+		return true
+	}
+	return same(bng.XEdges, o.XEdges) &&
+		same(bng.YEdges, o.YEdges) &&
+		same(bng.ZEdges, o.ZEdges)
+}
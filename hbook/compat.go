@@ -0,0 +1,234 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Chi2TestH1D performs a χ² test of the compatibility of the shapes of
+// two 1D-histograms, possibly weighted and with different normalizations.
+//
+// The χ² is computed over the in-range bins where at least one of the
+// histograms is not empty, as:
+//
+//	χ² = Σ (w1/W1 - w2/W2)² / (σ1²/W1² + σ2²/W2²)
+//
+// where w and σ² are the sum of weights and the sum of squared weights
+// of the bins, and W the sum of weights of the in-range bins of each
+// histogram.
+// The number of degrees of freedom is the number of such bins minus one.
+//
+// Chi2TestH1D returns an error if the binnings of the histograms are not
+// compatible or if one of the histograms is empty.
+func Chi2TestH1D(h1, h2 *H1D) (chi2 float64, ndf int, pvalue float64, err error) {
+	err = checkCompatH1D(h1, h2)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var (
+		bins1  = h1.Binning.Bins
+		bins2  = h2.Binning.Bins
+		w1, w2 float64
+	)
+	for i := range bins1 {
+		w1 += bins1[i].SumW()
+		w2 += bins2[i].SumW()
+	}
+	if w1 == 0 || w2 == 0 {
+		return 0, 0, 0, fmt.Errorf("hbook: empty histogram in χ² test of %q and %q", h1.Name(), h2.Name())
+	}
+
+	nbins := 0
+	for i := range bins1 {
+		var (
+			b1  = bins1[i]
+			b2  = bins2[i]
+			num = b1.SumW()/w1 - b2.SumW()/w2
+			den = b1.SumW2()/(w1*w1) + b2.SumW2()/(w2*w2)
+		)
+		if den == 0 {
+			continue
+		}
+		chi2 += num * num / den
+		nbins++
+	}
+
+	ndf = nbins - 1
+	if ndf <= 0 {
+		return chi2, 0, 1, nil
+	}
+	pvalue = distuv.ChiSquared{K: float64(ndf)}.Survival(chi2)
+	return chi2, ndf, pvalue, nil
+}
+
+// Chi2TestH1DUnweighted performs a χ² test of the compatibility of the shapes
+// of two unweighted 1D-histograms, with possibly different numbers of entries.
+//
+// The χ² is computed over the in-range bins where at least one of the
+// histograms is not empty, as:
+//
+//	χ² = 1/(N1 N2) Σ (N2 n1 - N1 n2)² / (n1 + n2)
+//
+// where n is the number of entries in the bins (their sum of weights)
+// and N the number of entries of the in-range bins of each histogram.
+// The number of degrees of freedom is the number of such bins minus one.
+//
+// For histograms with unit weights, this test is more accurate than
+// Chi2TestH1D in bins with few entries, as the bin contents are treated
+// as Poisson counts.
+//
+// Chi2TestH1DUnweighted returns an error if the binnings of the histograms
+// are not compatible or if one of the histograms is empty.
+func Chi2TestH1DUnweighted(h1, h2 *H1D) (chi2 float64, ndf int, pvalue float64, err error) {
+	err = checkCompatH1D(h1, h2)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	var (
+		bins1  = h1.Binning.Bins
+		bins2  = h2.Binning.Bins
+		n1, n2 float64
+	)
+	for i := range bins1 {
+		n1 += bins1[i].SumW()
+		n2 += bins2[i].SumW()
+	}
+	if n1 == 0 || n2 == 0 {
+		return 0, 0, 0, fmt.Errorf("hbook: empty histogram in χ² test of %q and %q", h1.Name(), h2.Name())
+	}
+
+	nbins := 0
+	for i := range bins1 {
+		var (
+			c1  = bins1[i].SumW()
+			c2  = bins2[i].SumW()
+			num = n2*c1 - n1*c2
+			den = c1 + c2
+		)
+		if den == 0 {
+			continue
+		}
+		chi2 += num * num / den
+		nbins++
+	}
+	chi2 /= n1 * n2
+
+	ndf = nbins - 1
+	if ndf <= 0 {
+		return chi2, 0, 1, nil
+	}
+	pvalue = distuv.ChiSquared{K: float64(ndf)}.Survival(chi2)
+	return chi2, ndf, pvalue, nil
+}
+
+// KSTestH1D performs a Kolmogorov-Smirnov test of the compatibility of
+// the shapes of two 1D-histograms.
+//
+// KSTestH1D returns the maximum distance between the normalized cumulative
+// distributions of the in-range bins of the histograms and the probability
+// of observing a larger distance for compatible histograms.
+// The number of entries of weighted histograms is taken as their
+// effective number of entries.
+// As the test is performed on binned data, the returned probability is
+// only an approximation of the one of the unbinned test.
+//
+// KSTestH1D returns an error if the binnings of the histograms are not
+// compatible or if one of the histograms is empty.
+func KSTestH1D(h1, h2 *H1D) (dist, pvalue float64, err error) {
+	err = checkCompatH1D(h1, h2)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		bins1        = h1.Binning.Bins
+		bins2        = h2.Binning.Bins
+		w1, w2       float64
+		w1sq, w2sq   float64
+		cdf1, cdf2   float64
+		neff1, neff2 float64
+	)
+	for i := range bins1 {
+		w1 += bins1[i].SumW()
+		w2 += bins2[i].SumW()
+		w1sq += bins1[i].SumW2()
+		w2sq += bins2[i].SumW2()
+	}
+	if w1 == 0 || w2 == 0 {
+		return 0, 0, fmt.Errorf("hbook: empty histogram in Kolmogorov-Smirnov test of %q and %q", h1.Name(), h2.Name())
+	}
+
+	for i := range bins1 {
+		cdf1 += bins1[i].SumW() / w1
+		cdf2 += bins2[i].SumW() / w2
+		dist = math.Max(dist, math.Abs(cdf1-cdf2))
+	}
+
+	neff1 = w1 * w1 / w1sq
+	neff2 = w2 * w2 / w2sq
+	z := dist * math.Sqrt(neff1*neff2/(neff1+neff2))
+	return dist, kolmogorovProb(z), nil
+}
+
+// checkCompatH1D returns an error if the binnings of the provided
+// histograms are not compatible.
+func checkCompatH1D(h1, h2 *H1D) error {
+	var (
+		bins1 = h1.Binning.Bins
+		bins2 = h2.Binning.Bins
+	)
+	if len(bins1) != len(bins2) {
+		return fmt.Errorf("hbook: x binnings are not equivalent in %v / %v", h1.Name(), h2.Name())
+	}
+	for i := range bins1 {
+		b1 := bins1[i]
+		b2 := bins2[i]
+		if !fuzzyEq(b1.XMin(), b2.XMin()) || !fuzzyEq(b1.XMax(), b2.XMax()) {
+			return fmt.Errorf("hbook: x binnings are not equivalent in %v / %v", h1.Name(), h2.Name())
+		}
+	}
+	return nil
+}
+
+// kolmogorovProb returns the probability for the Kolmogorov distribution
+// to be larger than z, i.e. the probability of observing a larger
+// (scaled) Kolmogorov-Smirnov distance for compatible distributions.
+func kolmogorovProb(z float64) float64 {
+	switch {
+	case z < 0.2:
+		return 1
+	case z < 0.755:
+		const w = 2.50662827 // √(2π)
+		var (
+			v  = 1 / (z * z)
+			c1 = -math.Pi * math.Pi / 8
+		)
+		p := 1 - w*(math.Exp(c1*v)+math.Exp(9*c1*v)+math.Exp(25*c1*v))/z
+		return math.Max(0, math.Min(1, p))
+	case z < 6.8116:
+		var (
+			v = z * z
+			p float64
+		)
+		// the series converges quickly: keep the first terms only.
+		n := int(math.Max(1, math.Round(3/z)))
+		for j := 1; j <= n; j++ {
+			sign := 1.0
+			if j%2 == 0 {
+				sign = -1
+			}
+			p += sign * math.Exp(-2*float64(j*j)*v)
+		}
+		return math.Max(0, math.Min(1, 2*p))
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestChi2TestH1D(t *testing.T) {
+	h1 := NewH1D(3, 0, 3)
+	h2 := NewH1D(3, 0, 3)
+	for i, n := range []int{10, 20, 30} {
+		for j := 0; j < n; j++ {
+			h1.Fill(float64(i), 1)
+		}
+	}
+	for i, n := range []int{20, 20, 20} {
+		for j := 0; j < n; j++ {
+			h2.Fill(float64(i), 1)
+		}
+	}
+
+	chi2, ndf, pval, err := Chi2TestH1D(h1, h1)
+	if err != nil {
+		t.Fatalf("could not run χ² test: %+v", err)
+	}
+	if chi2 != 0 || ndf != 2 || pval != 1 {
+		t.Fatalf("invalid self-compatibility: chi2=%v, ndf=%d, p=%v", chi2, ndf, pval)
+	}
+
+	chi2, ndf, pval, err = Chi2TestH1D(h1, h2)
+	if err != nil {
+		t.Fatalf("could not run χ² test: %+v", err)
+	}
+
+	// W1=60, W2=60:
+	//  bin-0: (10-20)²/(10+20)
+	//  bin-1: (20-20)²/(20+20)
+	//  bin-2: (30-20)²/(30+20)
+	want := 100.0/30 + 100.0/50
+	if !scalar.EqualWithinAbsOrRel(chi2, want, 1e-12, 1e-12) {
+		t.Fatalf("invalid chi2: got=%v, want=%v", chi2, want)
+	}
+	if ndf != 2 {
+		t.Fatalf("invalid ndf: got=%d, want=2", ndf)
+	}
+	if got, want := pval, math.Exp(-want/2); !scalar.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+		t.Fatalf("invalid p-value: got=%v, want=%v", got, want)
+	}
+
+	_, _, _, err = Chi2TestH1D(h1, NewH1D(4, 0, 3))
+	if err == nil {
+		t.Fatalf("expected an error for incompatible binnings")
+	}
+	_, _, _, err = Chi2TestH1D(h1, NewH1D(3, 0, 3))
+	if err == nil {
+		t.Fatalf("expected an error for an empty histogram")
+	}
+}
+
+func TestChi2TestH1DUnweighted(t *testing.T) {
+	h1 := NewH1D(4, 0, 4)
+	h2 := NewH1D(4, 0, 4)
+	for i, n := range []int{10, 20, 30, 0} {
+		for j := 0; j < n; j++ {
+			h1.Fill(float64(i), 1)
+		}
+	}
+	for i, n := range []int{12, 18, 35, 0} {
+		for j := 0; j < n; j++ {
+			h2.Fill(float64(i), 1)
+		}
+	}
+	h2.Fill(-1, 1) // under-flow entries are not considered.
+
+	chi2, ndf, pval, err := Chi2TestH1DUnweighted(h1, h1)
+	if err != nil {
+		t.Fatalf("could not run χ² test: %+v", err)
+	}
+	if chi2 != 0 || ndf != 2 || pval != 1 {
+		t.Fatalf("invalid self-compatibility: chi2=%v, ndf=%d, p=%v", chi2, ndf, pval)
+	}
+
+	chi2, ndf, pval, err = Chi2TestH1DUnweighted(h1, h2)
+	if err != nil {
+		t.Fatalf("could not run χ² test: %+v", err)
+	}
+
+	// N1=60, N2=65:
+	//  bin-0: (65*10-60*12)²/(10+12)
+	//  bin-1: (65*20-60*18)²/(20+18)
+	//  bin-2: (65*30-60*35)²/(30+35)
+	//  bin-3: empty.
+	want := (70.0*70/22 + 220.0*220/38 + 150.0*150/65) / (60 * 65)
+	if !scalar.EqualWithinAbsOrRel(chi2, want, 1e-12, 1e-12) {
+		t.Fatalf("invalid chi2: got=%v, want=%v", chi2, want)
+	}
+	if ndf != 2 {
+		t.Fatalf("invalid ndf: got=%d, want=2", ndf)
+	}
+	if got, want := pval, math.Exp(-want/2); !scalar.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+		t.Fatalf("invalid p-value: got=%v, want=%v", got, want)
+	}
+
+	_, _, _, err = Chi2TestH1DUnweighted(h1, NewH1D(3, 0, 4))
+	if err == nil {
+		t.Fatalf("expected an error for incompatible binnings")
+	}
+	_, _, _, err = Chi2TestH1DUnweighted(h1, NewH1D(4, 0, 4))
+	if err == nil {
+		t.Fatalf("expected an error for an empty histogram")
+	}
+}
+
+func TestKSTestH1D(t *testing.T) {
+	h1 := NewH1D(4, 0, 4)
+	h2 := NewH1D(4, 0, 4)
+	for i, n := range []int{10, 20, 30, 40} {
+		for j := 0; j < n; j++ {
+			h1.Fill(float64(i), 1)
+		}
+	}
+	for i, n := range []int{40, 30, 20, 10} {
+		for j := 0; j < n; j++ {
+			h2.Fill(float64(i), 1)
+		}
+	}
+
+	dist, pval, err := KSTestH1D(h1, h1)
+	if err != nil {
+		t.Fatalf("could not run KS test: %+v", err)
+	}
+	if dist != 0 || pval != 1 {
+		t.Fatalf("invalid self-compatibility: dist=%v, p=%v", dist, pval)
+	}
+
+	dist, pval, err = KSTestH1D(h1, h2)
+	if err != nil {
+		t.Fatalf("could not run KS test: %+v", err)
+	}
+	// cumulative distributions: [0.1, 0.3, 0.6, 1] and [0.4, 0.7, 0.9, 1]
+	if got, want := dist, 0.4; !scalar.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+		t.Fatalf("invalid distance: got=%v, want=%v", got, want)
+	}
+	if got, want := pval, kolmogorovProb(0.4*math.Sqrt(50)); !scalar.EqualWithinRel(got, want, 1e-12) {
+		t.Fatalf("invalid p-value: got=%v, want=%v", got, want)
+	}
+
+	_, _, err = KSTestH1D(h1, NewH1D(4, 0, 5))
+	if err == nil {
+		t.Fatalf("expected an error for incompatible binnings")
+	}
+}
+
+func TestKolmogorovProb(t *testing.T) {
+	for _, tc := range []struct {
+		z, want float64
+	}{
+		{0, 1},
+		{0.1, 1},
+		{0.5, 0.9639452436648751},
+		{1, 0.26999967167735456},
+		{1.5, 0.022217487693888},
+		{2, 0.0006709252557796953},
+		{10, 0},
+	} {
+		got := kolmogorovProb(tc.z)
+		if !scalar.EqualWithinAbs(got, tc.want, 1e-6) {
+			t.Errorf("invalid Kolmogorov probability for z=%v: got=%v, want=%v", tc.z, got, tc.want)
+		}
+	}
+}
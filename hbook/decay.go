@@ -0,0 +1,136 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"time"
+)
+
+// decayRebase is the maximum weight factor of an entry before the
+// reference time of a decaying histogram is moved forward.
+const decayRebase = 0x1p64
+
+// decay holds the state of an exponential decay of weights.
+//
+// Entries are filled with a weight factor exp(+λ(t-ref)) relative to the
+// reference time ref, so that older entries need not be rescaled at each
+// fill. The whole histogram is rescaled when that factor gets too large.
+type decay struct {
+	lambda float64   // decay rate, in 1/s
+	ref    time.Time // reference time of the stored weights
+}
+
+func newDecay(halfLife time.Duration) decay {
+	if halfLife <= 0 {
+		panic("hbook: invalid decay half-life")
+	}
+	return decay{lambda: math.Ln2 / halfLife.Seconds()}
+}
+
+// factor returns the weight factor of an entry at time t, relative to
+// the reference time.
+func (d *decay) factor(t time.Time) float64 {
+	return math.Exp(d.lambda * t.Sub(d.ref).Seconds())
+}
+
+// fill returns the weight factor of an entry at time t.
+// fill calls scale, to rescale the stored weights, whenever the reference
+// time needs to be moved.
+func (d *decay) fill(t time.Time, scale func(f float64)) float64 {
+	if d.ref.IsZero() {
+		d.ref = t
+	}
+	f := d.factor(t)
+	if f > decayRebase {
+		scale(1 / f)
+		d.ref = t
+		f = 1
+	}
+	return f
+}
+
+// DecayH1D is a 1-dim histogram whose entries are exponentially down-weighted
+// with their age, for the monitoring of streams of data where only the
+// recent behavior matters.
+//
+// An entry filled at time t with weight w contributes a weight
+// w*2^(-(now-t)/halfLife) to the histogram at time now.
+// The number of entries is not affected by the decay.
+type DecayH1D struct {
+	h *H1D
+	d decay
+}
+
+// NewDecayH1D returns a new decaying histogram, filling the provided histogram,
+// whose weights are halved every halfLife.
+// NewDecayH1D panics if halfLife is not strictly positive.
+func NewDecayH1D(h *H1D, halfLife time.Duration) *DecayH1D {
+	return &DecayH1D{h: h, d: newDecay(halfLife)}
+}
+
+// HalfLife returns the half-life of the weights of the histogram.
+func (h *DecayH1D) HalfLife() time.Duration {
+	return time.Duration(math.Ln2 / h.d.lambda * float64(time.Second))
+}
+
+// Fill fills the histogram with x and weight w, for an entry at time t.
+func (h *DecayH1D) Fill(t time.Time, x, w float64) {
+	f := h.d.fill(t, h.h.Scale)
+	h.h.Fill(x, w*f)
+}
+
+// H1D returns a snapshot of the histogram, with its weights decayed
+// up to time t.
+func (h *DecayH1D) H1D(t time.Time) *H1D {
+	o := h.h.Clone()
+	if !h.d.ref.IsZero() {
+		o.Scale(1 / h.d.factor(t))
+	}
+	return o
+}
+
+// DecayH2D is a 2-dim histogram whose entries are exponentially down-weighted
+// with their age, for the monitoring of streams of data where only the
+// recent behavior matters.
+//
+// An entry filled at time t with weight w contributes a weight
+// w*2^(-(now-t)/halfLife) to the histogram at time now.
+// The number of entries is not affected by the decay.
+type DecayH2D struct {
+	h *H2D
+	d decay
+}
+
+// NewDecayH2D returns a new decaying histogram, filling the provided histogram,
+// whose weights are halved every halfLife.
+// NewDecayH2D panics if halfLife is not strictly positive.
+func NewDecayH2D(h *H2D, halfLife time.Duration) *DecayH2D {
+	return &DecayH2D{h: h, d: newDecay(halfLife)}
+}
+
+// HalfLife returns the half-life of the weights of the histogram.
+func (h *DecayH2D) HalfLife() time.Duration {
+	return time.Duration(math.Ln2 / h.d.lambda * float64(time.Second))
+}
+
+// Fill fills the histogram with (x,y) and weight w, for an entry at time t.
+func (h *DecayH2D) Fill(t time.Time, x, y, w float64) {
+	f := h.d.fill(t, h.h.Binning.scaleW)
+	h.h.Fill(x, y, w*f)
+}
+
+// H2D returns a snapshot of the histogram, with its weights decayed
+// up to time t.
+func (h *DecayH2D) H2D(t time.Time) *H2D {
+	o := &H2D{
+		Binning: h.h.Binning.clone(),
+		Ann:     h.h.Ann.clone(),
+	}
+	if !h.d.ref.IsZero() {
+		o.Binning.scaleW(1 / h.d.factor(t))
+	}
+	return o
+}
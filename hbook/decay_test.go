@@ -0,0 +1,127 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecayH1D(t *testing.T) {
+	const halfLife = 10 * time.Second
+	var (
+		t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		h  = NewDecayH1D(NewH1D(4, 0, 4), halfLife)
+	)
+
+	if got, want := h.HalfLife(), halfLife; got != want {
+		t.Fatalf("invalid half-life: got=%v, want=%v", got, want)
+	}
+
+	if got := h.H1D(t0); got.SumW() != 0 {
+		t.Fatalf("invalid empty histogram: sumw=%v", got.SumW())
+	}
+
+	h.Fill(t0, 0.5, 1)
+	h.Fill(t0.Add(halfLife), 1.5, 2)
+	h.Fill(t0.Add(-halfLife), 2.5, 4) // late entry.
+
+	for _, tc := range []struct {
+		t    time.Time
+		want []float64
+	}{
+		{t0, []float64{1, 4, 2, 0}},
+		{t0.Add(halfLife), []float64{0.5, 2, 1, 0}},
+		{t0.Add(3 * halfLife), []float64{0.125, 0.5, 0.25, 0}},
+	} {
+		snap := h.H1D(tc.t)
+		for i, want := range tc.want {
+			if got := snap.Value(i); math.Abs(got-want) > 1e-12 {
+				t.Fatalf("t=%v: invalid bin %d: got=%v, want=%v", tc.t, i, got, want)
+			}
+		}
+		if got, want := snap.Entries(), int64(3); got != want {
+			t.Fatalf("t=%v: invalid entries: got=%d, want=%d", tc.t, got, want)
+		}
+	}
+
+	// snapshots do not modify the histogram.
+	if got, want := h.H1D(t0.Add(halfLife)).Value(1), 2.0; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid bin after snapshots: got=%v, want=%v", got, want)
+	}
+}
+
+func TestDecayH1DRebase(t *testing.T) {
+	const halfLife = time.Second
+	var (
+		t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		t1 = t0.Add(100 * halfLife)
+		h  = NewDecayH1D(NewH1D(2, 0, 2), halfLife)
+	)
+
+	h.Fill(t0, 0.5, 1)
+	h.Fill(t1, 1.5, 1)
+
+	if got, want := h.d.ref, t1; !got.Equal(want) {
+		t.Fatalf("invalid reference time: got=%v, want=%v", got, want)
+	}
+
+	snap := h.H1D(t1)
+	if got, want := snap.Value(0), math.Ldexp(1, -100); math.Abs(got-want) > 1e-12*want {
+		t.Fatalf("invalid bin 0: got=%v, want=%v", got, want)
+	}
+	if got, want := snap.Value(1), 1.0; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid bin 1: got=%v, want=%v", got, want)
+	}
+}
+
+func TestDecayH2D(t *testing.T) {
+	const halfLife = time.Minute
+	var (
+		t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		h  = NewDecayH2D(NewH2D(2, 0, 2, 2, 0, 2), halfLife)
+	)
+
+	h.Fill(t0, 0.5, 0.5, 1)
+	h.Fill(t0.Add(halfLife), 1.5, 1.5, 1)
+	h.Fill(t0.Add(halfLife), 5, 5, 1) // N-E outflow.
+
+	snap := h.H2D(t0.Add(2 * halfLife))
+	for _, tc := range []struct {
+		ix, iy int
+		want   float64
+	}{
+		{0, 0, 0.25},
+		{1, 1, 0.5},
+		{0, 1, 0},
+	} {
+		if got := snap.Binning.Bins[tc.iy*2+tc.ix].SumW(); math.Abs(got-tc.want) > 1e-12 {
+			t.Fatalf("invalid bin (%d,%d): got=%v, want=%v", tc.ix, tc.iy, got, tc.want)
+		}
+	}
+	if got, want := snap.Binning.Outflows[BngNE-1].SumW(), 0.5; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid N-E outflow: got=%v, want=%v", got, want)
+	}
+	if got, want := snap.SumW(), 1.25; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := snap.Entries(), int64(3); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+}
+
+func TestDecayInvalidHalfLife(t *testing.T) {
+	defer func() {
+		e := recover()
+		if e == nil {
+			t.Fatalf("expected a panic")
+		}
+		if got, want := e.(string), "hbook: invalid decay half-life"; got != want {
+			t.Fatalf("invalid panic message: got=%q, want=%q", got, want)
+		}
+	}()
+	_ = NewDecayH1D(NewH1D(1, 0, 1), 0)
+}
@@ -7,4 +7,21 @@
 // hbook is a work in progress of a concurrent friendly histogram filling toolkit.
 // It is loosely based on AIDA interfaces and concepts as well as the "simplicity"
 // of HBOOK and the previous work of YODA.
+//
+// # Annotations
+//
+// Histograms and scatters carry an Annotation, a bag of key/values.
+// The following keys have a well-defined meaning and are converted to and
+// from the YODA and ROOT formats:
+//
+//	hbook      YODA        ROOT
+//	"name"     "Path"      object name
+//	"title"    "Title"     object title
+//	"xlabel"   "XLabel"    title of the X axis
+//	"ylabel"   "YLabel"    title of the Y axis
+//	"zlabel"   "ZLabel"    title of the Z axis
+//
+// The YODA path is the name of the object, with a leading "/".
+// User-defined keys are transmitted verbatim to and from YODA, but have no
+// ROOT counterpart and are dropped when converting to ROOT.
 package hbook
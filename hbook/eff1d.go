@@ -0,0 +1,440 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"go-hep.org/x/hep/rio"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// EffStat describes how the confidence intervals of an efficiency are computed.
+//
+// The values of the EffStat constants match the ones of ROOT's
+// TEfficiency::EStatOption.
+type EffStat int32
+
+const (
+	EffClopperPearson EffStat = 0 // Clopper-Pearson interval (frequentist)
+	EffNormal         EffStat = 1 // normal approximation (frequentist)
+	EffWilson         EffStat = 2 // Wilson interval (frequentist)
+	EffAgrestiCoull   EffStat = 3 // Agresti-Coull interval (frequentist)
+	EffFeldmanCousins EffStat = 4 // Feldman-Cousins interval (frequentist)
+	EffJeffrey        EffStat = 5 // Jeffrey prior: Beta(0.5, 0.5) (bayesian)
+	EffUniform        EffStat = 6 // uniform prior: Beta(1, 1) (bayesian)
+	EffBayesian       EffStat = 7 // custom Beta(alpha, beta) prior (bayesian)
+	EffMidP           EffStat = 8 // mid-P Lancaster interval (frequentist)
+)
+
+func (st EffStat) String() string {
+	switch st {
+	case EffClopperPearson:
+		return "clopper-pearson"
+	case EffNormal:
+		return "normal"
+	case EffWilson:
+		return "wilson"
+	case EffAgrestiCoull:
+		return "agresti-coull"
+	case EffFeldmanCousins:
+		return "feldman-cousins"
+	case EffJeffrey:
+		return "jeffrey"
+	case EffUniform:
+		return "uniform"
+	case EffBayesian:
+		return "bayesian"
+	case EffMidP:
+		return "mid-p"
+	}
+	return fmt.Sprintf("EffStat(%d)", int32(st))
+}
+
+func (st EffStat) bayesian() bool {
+	switch st {
+	case EffJeffrey, EffUniform, EffBayesian:
+		return true
+	}
+	return false
+}
+
+// DefaultEffCL is the default confidence level of efficiency intervals (1 sigma).
+const DefaultEffCL = 0.682689492137
+
+// Eff1D is a 1-dim efficiency histogram.
+//
+// Eff1D is made of a pair of 1-dim histograms with the same binning,
+// holding the total number of events and the number of events passing
+// a selection.
+type Eff1D struct {
+	Passed *H1D // histogram of events passing the selection
+	Total  *H1D // histogram of all events
+
+	Ann Annotation
+
+	Stat EffStat // statistic used to compute the confidence intervals
+	CL   float64 // confidence level of the intervals
+
+	// Alpha and Beta are the parameters of the Beta prior used with EffBayesian.
+	Alpha float64
+	Beta  float64
+
+	// BinPriors optionally holds bin-by-bin (alpha, beta) parameters
+	// of the Beta prior, overriding Alpha and Beta.
+	BinPriors [][2]float64
+}
+
+// NewEff1D returns a 1-dim efficiency histogram with n bins between xmin and xmax.
+func NewEff1D(n int, xmin, xmax float64) *Eff1D {
+	return NewEff1DFrom(NewH1D(n, xmin, xmax), NewH1D(n, xmin, xmax))
+}
+
+// NewEff1DFrom returns a 1-dim efficiency histogram from the histograms of
+// events passing a selection and of all events.
+// It panics if the two histograms do not have the same binning.
+func NewEff1DFrom(passed, total *H1D) *Eff1D {
+	pbins := passed.Binning.Bins
+	tbins := total.Binning.Bins
+	if len(pbins) != len(tbins) {
+		panic("hbook: passed and total histograms have different number of bins")
+	}
+	for i := range pbins {
+		if pbins[i].Range != tbins[i].Range {
+			panic(fmt.Errorf("hbook: passed and total histograms have different bin edges (bin=%d)", i))
+		}
+	}
+
+	return &Eff1D{
+		Passed: passed,
+		Total:  total,
+		Ann:    make(Annotation),
+		Stat:   EffClopperPearson,
+		CL:     DefaultEffCL,
+		Alpha:  1,
+		Beta:   1,
+	}
+}
+
+// Name returns the name of this efficiency histogram, if any.
+func (e *Eff1D) Name() string {
+	v, ok := e.Ann["name"]
+	if !ok {
+		return ""
+	}
+	n, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	return n
+}
+
+// Annotation returns the annotations attached to this efficiency histogram.
+func (e *Eff1D) Annotation() Annotation {
+	return e.Ann
+}
+
+// Rank returns the number of dimensions of this efficiency histogram.
+func (e *Eff1D) Rank() int {
+	return 1
+}
+
+// Entries returns the total number of entries of this efficiency histogram.
+func (e *Eff1D) Entries() int64 {
+	return e.Total.Entries()
+}
+
+// Len returns the number of bins of this efficiency histogram.
+func (e *Eff1D) Len() int {
+	return len(e.Total.Binning.Bins)
+}
+
+// Fill fills this efficiency histogram with x and weight w.
+// The passed histogram is only filled when pass is true.
+func (e *Eff1D) Fill(x float64, pass bool, w float64) {
+	e.Total.Fill(x, w)
+	if pass {
+		e.Passed.Fill(x, w)
+	}
+}
+
+// XMin returns the low edge of the X-axis of this efficiency histogram.
+func (e *Eff1D) XMin() float64 {
+	return e.Total.XMin()
+}
+
+// XMax returns the high edge of the X-axis of this efficiency histogram.
+func (e *Eff1D) XMax() float64 {
+	return e.Total.XMax()
+}
+
+// counts returns the (effective) number of passed and total events in bin i.
+// Weighted bins are rescaled to their effective number of entries.
+func (e *Eff1D) counts(i int) (passed, total float64) {
+	pbin := &e.Passed.Binning.Bins[i]
+	tbin := &e.Total.Binning.Bins[i]
+	passed = pbin.SumW()
+	total = tbin.SumW()
+	if sumw2 := tbin.SumW2(); sumw2 > 0 && sumw2 != total {
+		scale := total / sumw2
+		passed *= scale
+		total *= scale
+	}
+	return passed, total
+}
+
+func (e *Eff1D) prior(i int) (alpha, beta float64) {
+	switch e.Stat {
+	case EffJeffrey:
+		return 0.5, 0.5
+	case EffUniform:
+		return 1, 1
+	}
+	if i < len(e.BinPriors) {
+		return e.BinPriors[i][0], e.BinPriors[i][1]
+	}
+	return e.Alpha, e.Beta
+}
+
+// Eff returns the efficiency in bin i.
+//
+// For bayesian statistics, Eff returns the mean of the posterior distribution.
+func (e *Eff1D) Eff(i int) float64 {
+	passed, total := e.counts(i)
+	if e.Stat.bayesian() {
+		a, b := e.prior(i)
+		return (passed + a) / (total + a + b)
+	}
+	if total == 0 {
+		return 0
+	}
+	return passed / total
+}
+
+// Interval returns the lower and upper bounds of the confidence interval
+// of the efficiency in bin i.
+//
+// Interval panics for the EffFeldmanCousins statistic.
+func (e *Eff1D) Interval(i int) (lo, hi float64) {
+	passed, total := e.counts(i)
+	cl := e.CL
+	switch e.Stat {
+	case EffClopperPearson:
+		return effClopperPearson(total, passed, cl)
+	case EffNormal:
+		return effNormal(total, passed, cl)
+	case EffWilson:
+		return effWilson(total, passed, cl)
+	case EffAgrestiCoull:
+		return effAgrestiCoull(total, passed, cl)
+	case EffJeffrey, EffUniform, EffBayesian:
+		a, b := e.prior(i)
+		return effBayesian(total, passed, cl, a, b)
+	case EffMidP:
+		return effMidP(total, passed, cl)
+	default:
+		panic(fmt.Errorf("hbook: efficiency interval for %v statistic not supported", e.Stat))
+	}
+}
+
+func effClopperPearson(total, passed, cl float64) (lo, hi float64) {
+	alpha := 0.5 * (1 - cl)
+	lo = 0
+	if passed > 0 {
+		lo = distuv.Beta{Alpha: passed, Beta: total - passed + 1}.Quantile(alpha)
+	}
+	hi = 1
+	if passed < total {
+		hi = distuv.Beta{Alpha: passed + 1, Beta: total - passed}.Quantile(1 - alpha)
+	}
+	return lo, hi
+}
+
+func effNormal(total, passed, cl float64) (lo, hi float64) {
+	if total == 0 {
+		return 0, 1
+	}
+	var (
+		eff   = passed / total
+		sigma = math.Sqrt(eff * (1 - eff) / total)
+		delta = distuv.UnitNormal.Quantile(0.5*(1+cl)) * sigma
+	)
+	return math.Max(0, eff-delta), math.Min(1, eff+delta)
+}
+
+func effWilson(total, passed, cl float64) (lo, hi float64) {
+	if total == 0 {
+		return 0, 1
+	}
+	var (
+		eff   = passed / total
+		kappa = distuv.UnitNormal.Quantile(0.5 * (1 + cl))
+		k2    = kappa * kappa
+		mode  = (passed + 0.5*k2) / (total + k2)
+		delta = kappa / (total + k2) * math.Sqrt(total*eff*(1-eff)+0.25*k2)
+	)
+	return math.Max(0, mode-delta), math.Min(1, mode+delta)
+}
+
+func effAgrestiCoull(total, passed, cl float64) (lo, hi float64) {
+	var (
+		kappa = distuv.UnitNormal.Quantile(0.5 * (1 + cl))
+		k2    = kappa * kappa
+		mode  = (passed + 0.5*k2) / (total + k2)
+		delta = kappa * math.Sqrt(mode*(1-mode)/(total+k2))
+	)
+	return math.Max(0, mode-delta), math.Min(1, mode+delta)
+}
+
+// effMidP returns the mid-P Lancaster interval, following ROOT's
+// TEfficiency::MidPInterval.
+func effMidP(total, passed, cl float64) (lo, hi float64) {
+	if total == 0 {
+		return 0, 1
+	}
+	alpha := 0.5 * (1 - cl)
+	return effMidPBound(total, passed, 1-alpha, false), effMidPBound(total, passed, alpha, true)
+}
+
+// effMidPBound returns the efficiency at which the mid-P probability of
+// observing less than passed events is equal to v.
+func effMidPBound(total, passed, v float64, upper bool) float64 {
+	switch {
+	case upper && passed >= total:
+		return 1
+	case !upper && passed <= 0:
+		return 0
+	case passed > 0 && passed < 1:
+		// linear interpolation for non-integer counts.
+		p0 := effMidPBound(total, 0, v, upper)
+		p1 := effMidPBound(total, 1, v, upper)
+		return p0 + (p1-p0)*passed
+	}
+
+	const tol = 1e-9
+	var (
+		pmin = 0.0
+		pmax = 1.0
+		p    float64
+	)
+	for math.Abs(pmax-pmin) > tol {
+		p = 0.5 * (pmin + pmax)
+		// binomial probabilities, extended to non-integer counts.
+		prob := 0.5 * distuv.Beta{Alpha: passed + 1, Beta: total - passed + 1}.Prob(p) / (total + 1)
+		if passed >= 1 {
+			prob += 1 - distuv.Beta{Alpha: passed, Beta: total - passed + 1}.CDF(p)
+		}
+		if prob > v {
+			pmin = p
+		} else {
+			pmax = p
+		}
+	}
+	return p
+}
+
+// effBayesian returns the central interval of the posterior Beta distribution.
+func effBayesian(total, passed, cl, alpha, beta float64) (lo, hi float64) {
+	var (
+		a = passed + alpha
+		b = total - passed + beta
+	)
+	if a <= 0 || b <= 0 {
+		return 0, 1
+	}
+	post := distuv.Beta{Alpha: a, Beta: b}
+	return post.Quantile(0.5 * (1 - cl)), post.Quantile(0.5 * (1 + cl))
+}
+
+// check various interfaces
+var _ Object = (*Eff1D)(nil)
+var _ Histogram = (*Eff1D)(nil)
+
+// serialization interfaces
+var _ rio.Marshaler = (*Eff1D)(nil)
+var _ rio.Unmarshaler = (*Eff1D)(nil)
+var _ rio.Streamer = (*Eff1D)(nil)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (e *Eff1D) MarshalBinary() (data []byte, err error) {
+	var buf [8]byte
+	for _, v := range []encoding.BinaryMarshaler{e.Passed, e.Total, &e.Ann} {
+		sub, err := v.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint64(buf[:8], uint64(len(sub)))
+		data = append(data, buf[:8]...)
+		data = append(data, sub...)
+	}
+	binary.LittleEndian.PutUint32(buf[:4], uint32(e.Stat))
+	data = append(data, buf[:4]...)
+	for _, v := range []float64{e.CL, e.Alpha, e.Beta} {
+		binary.LittleEndian.PutUint64(buf[:8], math.Float64bits(v))
+		data = append(data, buf[:8]...)
+	}
+	binary.LittleEndian.PutUint64(buf[:8], uint64(len(e.BinPriors)))
+	data = append(data, buf[:8]...)
+	for _, prior := range e.BinPriors {
+		for _, v := range prior {
+			binary.LittleEndian.PutUint64(buf[:8], math.Float64bits(v))
+			data = append(data, buf[:8]...)
+		}
+	}
+	return data, err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (e *Eff1D) UnmarshalBinary(data []byte) (err error) {
+	e.Passed = new(H1D)
+	e.Total = new(H1D)
+	for _, v := range []encoding.BinaryUnmarshaler{e.Passed, e.Total, &e.Ann} {
+		n := int(binary.LittleEndian.Uint64(data[:8]))
+		data = data[8:]
+		err = v.UnmarshalBinary(data[:n])
+		if err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	e.Stat = EffStat(binary.LittleEndian.Uint32(data[:4]))
+	data = data[4:]
+	for _, v := range []*float64{&e.CL, &e.Alpha, &e.Beta} {
+		*v = math.Float64frombits(binary.LittleEndian.Uint64(data[:8]))
+		data = data[8:]
+	}
+	n := int(binary.LittleEndian.Uint64(data[:8]))
+	data = data[8:]
+	e.BinPriors = nil
+	if n > 0 {
+		e.BinPriors = make([][2]float64, n)
+	}
+	for i := range e.BinPriors {
+		for j := range e.BinPriors[i] {
+			e.BinPriors[i][j] = math.Float64frombits(binary.LittleEndian.Uint64(data[:8]))
+			data = data[8:]
+		}
+	}
+	return err
+}
+
+// RioMarshal implements rio.RioMarshaler
+func (e *Eff1D) RioMarshal(w io.Writer) error {
+	return rioMarshal(w, e)
+}
+
+// RioUnmarshal implements rio.RioUnmarshaler
+func (e *Eff1D) RioUnmarshal(r io.Reader) error {
+	return rioUnmarshal(r, e)
+}
+
+// RioVersion implements rio.RioStreamer
+func (e *Eff1D) RioVersion() rio.Version {
+	return 0
+}
@@ -0,0 +1,119 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestEff1D(t *testing.T) {
+	const tol = 1e-6
+	for _, tc := range []struct {
+		stat   EffStat
+		eff    float64
+		lo, hi float64
+	}{
+		{EffClopperPearson, 0.3, 0.141672, 0.508262},
+		{EffNormal, 0.3, 0.155086, 0.444914},
+		{EffWilson, 0.3, 0.178821, 0.457543},
+		{EffAgrestiCoull, 0.3, 0.177747, 0.458617},
+		{EffJeffrey, 0.318182, 0.179932, 0.457751},
+		{EffUniform, 0.333333, 0.198874, 0.468800},
+		{EffBayesian, 0.333333, 0.212712, 0.454591},
+		{EffMidP, 0.3, 0.172152, 0.465643},
+	} {
+		t.Run(tc.stat.String(), func(t *testing.T) {
+			e := NewEff1D(2, 0, 2)
+			e.Ann["name"] = "eff"
+			e.Stat = tc.stat
+			e.Alpha = 2
+			e.Beta = 3
+			for i := 0; i < 10; i++ {
+				e.Fill(0.5, i < 3, 1)
+			}
+
+			if got, want := e.Name(), "eff"; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			if got, want := e.Entries(), int64(10); got != want {
+				t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+			}
+			if got, want := e.Passed.Entries(), int64(3); got != want {
+				t.Fatalf("invalid passed entries: got=%d, want=%d", got, want)
+			}
+
+			if got, want := e.Eff(0), tc.eff; !scalar.EqualWithinAbs(got, want, tol) {
+				t.Fatalf("invalid efficiency: got=%v, want=%v", got, want)
+			}
+			lo, hi := e.Interval(0)
+			if !scalar.EqualWithinAbs(lo, tc.lo, tol) || !scalar.EqualWithinAbs(hi, tc.hi, tol) {
+				t.Fatalf("invalid interval: got=[%v, %v], want=[%v, %v]", lo, hi, tc.lo, tc.hi)
+			}
+
+			// empty bin.
+			lo, hi = e.Interval(1)
+			if lo < 0 || hi > 1 || lo > hi {
+				t.Fatalf("invalid interval for empty bin: [%v, %v]", lo, hi)
+			}
+		})
+	}
+}
+
+func TestEff1DWeighted(t *testing.T) {
+	e1 := NewEff1D(1, 0, 1)
+	e2 := NewEff1D(1, 0, 1)
+	for i := 0; i < 10; i++ {
+		e1.Fill(0.5, i < 4, 1)
+		e2.Fill(0.5, i < 4, 2)
+	}
+	if got, want := e2.Eff(0), e1.Eff(0); got != want {
+		t.Fatalf("invalid weighted efficiency: got=%v, want=%v", got, want)
+	}
+	lo1, hi1 := e1.Interval(0)
+	lo2, hi2 := e2.Interval(0)
+	if !scalar.EqualWithinAbs(lo1, lo2, 1e-12) || !scalar.EqualWithinAbs(hi1, hi2, 1e-12) {
+		t.Fatalf("invalid weighted interval: got=[%v, %v], want=[%v, %v]", lo2, hi2, lo1, hi1)
+	}
+}
+
+func TestEff1DPanics(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		fct  func()
+		want string
+	}{
+		{
+			name: "nbins",
+			fct:  func() { NewEff1DFrom(NewH1D(2, 0, 1), NewH1D(3, 0, 1)) },
+			want: "hbook: passed and total histograms have different number of bins",
+		},
+		{
+			name: "edges",
+			fct:  func() { NewEff1DFrom(NewH1D(2, 0, 1), NewH1D(2, 0, 2)) },
+			want: "hbook: passed and total histograms have different bin edges (bin=0)",
+		},
+		{
+			name: "feldman-cousins",
+			fct: func() {
+				e := NewEff1D(2, 0, 1)
+				e.Stat = EffFeldmanCousins
+				e.Interval(0)
+			},
+			want: "hbook: efficiency interval for feldman-cousins statistic not supported",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			panicked, msg := panics(tc.fct)
+			if !panicked {
+				t.Fatalf("expected a panic")
+			}
+			if msg != tc.want {
+				t.Fatalf("invalid panic message:\ngot= %q\nwant=%q", msg, tc.want)
+			}
+		})
+	}
+}
@@ -7,14 +7,14 @@ package hbook
 import (
 	"bufio"
 	"bytes"
-	"encoding/binary"
 	"encoding/gob"
 	"fmt"
 	"io"
 	"math"
-	"strings"
+	"sort"
 
 	"go-hep.org/x/hep/rio"
+	"gonum.org/v1/gonum/interp"
 )
 
 // H1D is a 1-dim histogram with weighted entries.
@@ -249,6 +249,194 @@ func (h *H1D) Integral(args ...float64) float64 {
 	return integral
 }
 
+// IntegralRange computes the integral of the histogram over [xmin, xmax).
+//
+// Unlike Integral, the bins partially contained in the range contribute
+// to the integral in proportion of their width within the range, assuming
+// the entries are uniformly distributed inside each bin.
+//
+// If xmin is math.Inf(-1) then the underflow bin is included.
+// If xmax is math.Inf(+1) then the overflow bin is included.
+//
+// IntegralRange panics if xmin > xmax.
+func (h *H1D) IntegralRange(xmin, xmax float64) float64 {
+	if xmin > xmax {
+		panic("hbook: min > max")
+	}
+
+	integral := 0.0
+	for _, bin := range h.Binning.Bins {
+		var (
+			lo = math.Max(xmin, bin.Range.Min)
+			hi = math.Min(xmax, bin.Range.Max)
+		)
+		if lo >= hi {
+			continue
+		}
+		integral += bin.SumW() * (hi - lo) / bin.XWidth()
+	}
+	if math.IsInf(xmin, -1) {
+		integral += h.Binning.Outflows[0].SumW()
+	}
+	if math.IsInf(xmax, +1) {
+		integral += h.Binning.Outflows[1].SumW()
+	}
+	return integral
+}
+
+// InterpMethod describes how the contents of the bins of a histogram
+// are interpolated.
+type InterpMethod int
+
+const (
+	InterpLinear InterpMethod = iota // linear interpolation
+	InterpSpline                     // natural cubic spline interpolation
+)
+
+// Interpolate returns the content of the bins of this histogram at x,
+// linearly interpolated between the centers of the bins.
+//
+// Outside of the centers of the first and last bins, the content of
+// the first and last bins, respectively, is returned.
+func (h *H1D) Interpolate(x float64) float64 {
+	bins := h.Binning.Bins
+	n := len(bins)
+	switch {
+	case x <= bins[0].XMid():
+		return bins[0].SumW()
+	case x >= bins[n-1].XMid():
+		return bins[n-1].SumW()
+	}
+
+	i := sort.Search(n, func(i int) bool { return bins[i].XMid() > x }) - 1
+	var (
+		x0 = bins[i].XMid()
+		x1 = bins[i+1].XMid()
+		y0 = bins[i].SumW()
+		y1 = bins[i+1].SumW()
+	)
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
+
+// Interpolator returns a function interpolating the contents of the bins
+// of this histogram between the centers of the bins, with the provided
+// method.
+//
+// Outside of the centers of the first and last bins, the function
+// returns the content of the first and last bins, respectively.
+// The returned function does not track subsequent modifications of the
+// histogram.
+func (h *H1D) Interpolator(method InterpMethod) func(x float64) float64 {
+	var (
+		bins = h.Binning.Bins
+		xs   = make([]float64, len(bins))
+		ys   = make([]float64, len(bins))
+	)
+	for i, bin := range bins {
+		xs[i] = bin.XMid()
+		ys[i] = bin.SumW()
+	}
+	if len(bins) == 1 {
+		return interp.Constant(ys[0]).Predict
+	}
+
+	var fit interp.FittablePredictor
+	switch method {
+	case InterpLinear:
+		fit = new(interp.PiecewiseLinear)
+	case InterpSpline:
+		fit = new(interp.NaturalCubic)
+	default:
+		panic(fmt.Errorf("hbook: invalid interpolation method %d", method))
+	}
+	err := fit.Fit(xs, ys)
+	if err != nil {
+		panic(fmt.Errorf("hbook: could not fit interpolator: %w", err))
+	}
+	return fit.Predict
+}
+
+// Cumulative returns the cumulative distribution of this histogram,
+// as a new histogram with the same binning.
+//
+// If forward is true, each bin of the returned histogram holds the sum of
+// the underflow and of all the bins up to (and including) that bin.
+// Otherwise, each bin holds the sum of the overflow and of all the bins
+// from (and including) that bin.
+//
+// The underflow (resp. overflow) of a forward (resp. backward) cumulative
+// distribution is left unchanged, while the other outflow holds the
+// sum of all the entries.
+func (h *H1D) Cumulative(forward bool) *H1D {
+	var (
+		o    = h.Clone()
+		bins = o.Binning.Bins
+		oflw = &o.Binning.Outflows
+	)
+	switch {
+	case forward:
+		sum := oflw[0]
+		for i := range bins {
+			bins[i].Dist.addScaled(1, 1, sum)
+			sum = bins[i].Dist
+		}
+		oflw[1].addScaled(1, 1, sum)
+	default:
+		sum := oflw[1]
+		for i := len(bins) - 1; i >= 0; i-- {
+			bins[i].Dist.addScaled(1, 1, sum)
+			sum = bins[i].Dist
+		}
+		oflw[0].addScaled(1, 1, sum)
+	}
+	if name := h.Name(); name != "" {
+		o.Ann["name"] = name + "_cumulative"
+	}
+	return o
+}
+
+// Quantile returns the value x such that a fraction p of the sum of weights
+// of the in-range bins lies below x.
+// The value is linearly interpolated within the bin containing x.
+// Under- and overflows are not considered.
+//
+// Quantile panics if p is not in the [0, 1] range.
+// Quantile returns NaN if the sum of weights of the in-range bins is zero.
+// The weights of the bins are assumed to be positive.
+func (h *H1D) Quantile(p float64) float64 {
+	if p < 0 || p > 1 || math.IsNaN(p) {
+		panic("hbook: quantile out of [0, 1] range")
+	}
+
+	sum := 0.0
+	for _, bin := range h.Binning.Bins {
+		sum += bin.SumW()
+	}
+	if sum == 0 {
+		return math.NaN()
+	}
+
+	var (
+		cut = p * sum
+		c0  = 0.0
+	)
+	for _, bin := range h.Binning.Bins {
+		c1 := c0 + bin.SumW()
+		if c1 > c0 && cut <= c1 {
+			return bin.XMin() + bin.XWidth()*(cut-c0)/(c1-c0)
+		}
+		c0 = c1
+	}
+	return h.XMax()
+}
+
+// Median returns the median of the in-range bins of this histogram.
+//
+// Median is a shorthand for h.Quantile(0.5).
+func (h *H1D) Median() float64 {
+	return h.Quantile(0.5)
+}
+
 // Value returns the content of the idx-th bin.
 //
 // Value implements gonum/plot/plotter.Valuer
@@ -294,34 +482,12 @@ func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 
 // RioMarshal implements rio.RioMarshaler
 func (h *H1D) RioMarshal(w io.Writer) error {
-	data, err := h.MarshalBinary()
-	if err != nil {
-		return err
-	}
-	var buf [8]byte
-	binary.LittleEndian.PutUint64(buf[:], uint64(len(data)))
-	_, err = w.Write(buf[:])
-	if err != nil {
-		return err
-	}
-	_, err = w.Write(data)
-	return err
+	return rioMarshal(w, h)
 }
 
 // RioUnmarshal implements rio.RioUnmarshaler
 func (h *H1D) RioUnmarshal(r io.Reader) error {
-	buf := make([]byte, 8)
-	_, err := io.ReadFull(r, buf)
-	if err != nil {
-		return err
-	}
-	n := int64(binary.LittleEndian.Uint64(buf))
-	buf = make([]byte, int(n))
-	_, err = io.ReadFull(r, buf)
-	if err != nil {
-		return err
-	}
-	return h.UnmarshalBinary(buf)
+	return rioUnmarshal(r, h)
 }
 
 // RioVersion implements rio.RioStreamer
@@ -331,21 +497,7 @@ func (h *H1D) RioVersion() rio.Version {
 
 // annToYODA creates a new Annotation with fields compatible with YODA
 func (h *H1D) annToYODA() Annotation {
-	ann := make(Annotation, len(h.Ann))
-	ann["Type"] = "Histo1D"
-	ann["Path"] = "/" + h.Name()
-	ann["Title"] = ""
-	for k, v := range h.Ann {
-		if k == "name" {
-			continue
-		}
-		if k == "title" {
-			ann["Title"] = v
-			continue
-		}
-		ann[k] = v
-	}
-	return ann
+	return h.Ann.toYODA("Histo1D", h.Name())
 }
 
 // annFromYODA creates a new Annotation from YODA compatible fields
@@ -353,20 +505,7 @@ func (h *H1D) annFromYODA(ann Annotation) {
 	if len(h.Ann) == 0 {
 		h.Ann = make(Annotation, len(ann))
 	}
-	for k, v := range ann {
-		switch k {
-		case "Type":
-			// noop
-		case "Path":
-			name := v.(string)
-			name = strings.TrimPrefix(name, "/")
-			h.Ann["name"] = name
-		case "Title":
-			h.Ann["title"] = v
-		default:
-			h.Ann[k] = v
-		}
-	}
+	h.Ann.fromYODA(ann)
 }
 
 // MarshalYODA implements the YODAMarshaler interface.
@@ -374,6 +513,11 @@ func (h *H1D) MarshalYODA() ([]byte, error) {
 	return h.marshalYODAv2()
 }
 
+// MarshalYODA2 marshals the histogram into the YODA2 format.
+func (h *H1D) MarshalYODA2() ([]byte, error) {
+	return h.marshalYODAv3()
+}
+
 func (h *H1D) marshalYODAv1() ([]byte, error) {
 	buf := new(bytes.Buffer)
 	ann := h.annToYODA()
@@ -473,6 +617,66 @@ func (h *H1D) marshalYODAv2() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+func (h *H1D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := h.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_HISTO1D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	fmt.Fprintf(buf, "# Mean: %e\n", h.XMean())
+	fmt.Fprintf(buf, "# Integral: %e\n", h.Integral())
+
+	// gaps between bins are stored as masked bins.
+	var (
+		bins   = h.Binning.Bins
+		edges  = make([]float64, 0, len(bins)+1)
+		masked []int
+		rows   = make([]*Dist1D, 0, len(bins)+2)
+	)
+	rows = append(rows, &h.Binning.Outflows[0])
+	for i := range bins {
+		bin := &bins[i]
+		switch {
+		case i == 0:
+			edges = append(edges, bin.Range.Min)
+		case bin.Range.Min != bins[i-1].Range.Max:
+			masked = append(masked, len(edges))
+			edges = append(edges, bin.Range.Min)
+			rows = append(rows, new(Dist1D))
+		}
+		edges = append(edges, bin.Range.Max)
+		rows = append(rows, &bin.Dist)
+	}
+	rows = append(rows, &h.Binning.Outflows[1])
+
+	writeYODA2Edges(buf, 1, edges)
+	if len(masked) > 0 {
+		buf.WriteString("MaskedBins: [")
+		for i, v := range masked {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%d", v)
+		}
+		buf.WriteString("]\n")
+	}
+	fmt.Fprintf(buf, "# sumW\t sumW2\t sumW(A1)\t sumW2(A1)\t numEntries\n")
+	for _, d := range rows {
+		fmt.Fprintf(
+			buf,
+			"%e\t%e\t%e\t%e\t%e\n",
+			d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), float64(d.Entries()),
+		)
+	}
+	fmt.Fprintf(buf, "END YODA_HISTO1D_V3\n\n")
+	return buf.Bytes(), err
+}
+
 // UnmarshalYODA implements the YODAUnmarshaler interface.
 func (h *H1D) UnmarshalYODA(data []byte) error {
 	r := newRBuffer(data)
@@ -485,6 +689,8 @@ func (h *H1D) UnmarshalYODA(data []byte) error {
 		return h.unmarshalYODAv1(r)
 	case 2:
 		return h.unmarshalYODAv2(r)
+	case 3:
+		return h.unmarshalYODAv3(r)
 	default:
 		return fmt.Errorf("hbook: invalid YODA version %v", vers)
 	}
@@ -728,6 +934,61 @@ scanLoop:
 	return err
 }
 
+func (h *H1D) unmarshalYODAv3(r *rbuffer) error {
+	ann, err := readYODA2Ann(r)
+	if err != nil {
+		return err
+	}
+	h.annFromYODA(ann)
+
+	body, err := readYODA2Body(r, 5)
+	if err != nil {
+		return err
+	}
+	if len(body.edges) != 1 {
+		return fmt.Errorf("hbook: invalid H1D-YODA2 number of axes (got=%d, want=1)", len(body.edges))
+	}
+	var (
+		edges = body.edges[0]
+		n     = len(edges) - 1
+	)
+	if n < 1 || len(body.rows) != n+2 {
+		return fmt.Errorf("hbook: invalid H1D-YODA2 number of bins (got=%d, want=%d)", len(body.rows), n+2)
+	}
+
+	dist := func(row []float64) Dist1D {
+		var d Dist1D
+		d.Dist.SumW = row[0]
+		d.Dist.SumW2 = row[1]
+		d.Stats.SumWX = row[2]
+		d.Stats.SumWX2 = row[3]
+		d.Dist.N = int64(row[4])
+		return d
+	}
+
+	bng := Binning1D{
+		Bins: make([]Bin1D, 0, n),
+		Outflows: [2]Dist1D{
+			dist(body.rows[0]),
+			dist(body.rows[n+1]),
+		},
+		XRange: Range{Min: edges[0], Max: edges[n]},
+	}
+	for i, row := range body.rows {
+		d := dist(row)
+		bng.Dist.addScaled(1, 1, d)
+		if i == 0 || i == n+1 || body.isMasked(i) {
+			continue
+		}
+		bng.Bins = append(bng.Bins, Bin1D{
+			Range: Range{Min: edges[i-1], Max: edges[i]},
+			Dist:  d,
+		})
+	}
+	h.Binning = bng
+	return nil
+}
+
 // Counts return a slice of Count, ignoring outerflow.
 // The low and high error is equal to 0.5 * sqrt(sum(w^2)).
 func (h *H1D) Counts() []Count {
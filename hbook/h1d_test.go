@@ -743,3 +743,207 @@ func TestH1DClone(t *testing.T) {
 		)
 	}
 }
+
+func TestH1DCumulative(t *testing.T) {
+	h := NewH1D(4, 0, 4)
+	h.Annotation()["name"] = "h1"
+	h.Fill(-1, 1)
+	h.Fill(0.5, 2)
+	h.Fill(1.5, 3)
+	h.Fill(1.5, 1)
+	h.Fill(3.5, 4)
+	h.Fill(5, 5)
+
+	for _, tc := range []struct {
+		name    string
+		forward bool
+		bins    []float64
+		sumw2   []float64
+		oflows  [2]float64
+	}{
+		{
+			name:    "forward",
+			forward: true,
+			bins:    []float64{3, 7, 7, 11},
+			sumw2:   []float64{5, 15, 15, 31},
+			oflows:  [2]float64{1, 16},
+		},
+		{
+			name:    "backward",
+			forward: false,
+			bins:    []float64{15, 13, 9, 9},
+			sumw2:   []float64{55, 51, 41, 41},
+			oflows:  [2]float64{16, 5},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hc := h.Cumulative(tc.forward)
+			if got, want := hc.Name(), "h1_cumulative"; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			if got, want := hc.Entries(), h.Entries(); got != want {
+				t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+			}
+			for i, want := range tc.bins {
+				if got := hc.Value(i); got != want {
+					t.Fatalf("invalid bin[%d]: got=%v, want=%v", i, got, want)
+				}
+				if got, want := hc.Binning.Bins[i].SumW2(), tc.sumw2[i]; got != want {
+					t.Fatalf("invalid bin[%d] sumw2: got=%v, want=%v", i, got, want)
+				}
+			}
+			for i, want := range tc.oflows {
+				if got := hc.Binning.Outflows[i].SumW(); got != want {
+					t.Fatalf("invalid outflow[%d]: got=%v, want=%v", i, got, want)
+				}
+			}
+		})
+	}
+
+	if got, want := h.Value(1), 4.0; got != want {
+		t.Fatalf("cumulative modified input histogram: got=%v, want=%v", got, want)
+	}
+}
+
+func TestH1DQuantile(t *testing.T) {
+	h := NewH1D(4, 0, 4)
+	h.Fill(-1, 10) // underflows are ignored.
+	h.Fill(0.5, 2)
+	h.Fill(1.5, 2)
+	h.Fill(3.5, 4)
+	h.Fill(5, 10) // overflows are ignored.
+
+	for _, tc := range []struct {
+		p    float64
+		want float64
+	}{
+		{0, 0},
+		{0.125, 0.5},
+		{0.25, 1},
+		{0.5, 2},
+		{0.75, 3.5},
+		{1, 4},
+	} {
+		t.Run(fmt.Sprintf("p=%v", tc.p), func(t *testing.T) {
+			if got := h.Quantile(tc.p); !scalar.EqualWithinULP(got, tc.want, 2) {
+				t.Fatalf("invalid quantile: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+
+	if got, want := h.Median(), 2.0; got != want {
+		t.Fatalf("invalid median: got=%v, want=%v", got, want)
+	}
+
+	for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+		panicked, _ := panics(func() { h.Quantile(p) })
+		if !panicked {
+			t.Fatalf("quantile(%v) should have panicked", p)
+		}
+	}
+
+	if got := NewH1D(4, 0, 4).Quantile(0.5); !math.IsNaN(got) {
+		t.Fatalf("invalid quantile of empty histogram: got=%v, want=NaN", got)
+	}
+}
+
+func TestH1DIntegralRange(t *testing.T) {
+	h := NewH1DFromBins([]Range{
+		{Min: 0, Max: 1},
+		{Min: 1, Max: 3},
+		{Min: 4, Max: 5},
+	}...)
+	h.Fill(0.5, 2)
+	h.Fill(2, 4)
+	h.Fill(4.5, 8)
+	h.Fill(-1, 16)
+	h.Fill(+9, 32)
+
+	for _, tc := range []struct {
+		xmin, xmax float64
+		want       float64
+	}{
+		{0, 5, 14},
+		{0.5, 2, 1 + 2},
+		{0.25, 0.5, 0.5},
+		{2.5, 4.5, 1 + 4},
+		{3, 4, 0},
+		{2, 2, 0},
+		{-10, 10, 14},
+		{math.Inf(-1), 1, 2 + 16},
+		{4.75, math.Inf(+1), 2 + 32},
+		{math.Inf(-1), math.Inf(+1), 62},
+	} {
+		if got := h.IntegralRange(tc.xmin, tc.xmax); !scalar.EqualWithinAbs(got, tc.want, 1e-12) {
+			t.Fatalf("invalid integral over [%v, %v): got=%v, want=%v", tc.xmin, tc.xmax, got, tc.want)
+		}
+	}
+
+	func() {
+		defer func() {
+			if e := recover(); e == nil {
+				t.Fatalf("expected a panic")
+			}
+		}()
+		h.IntegralRange(1, 0)
+	}()
+}
+
+func TestH1DInterpolate(t *testing.T) {
+	h := NewH1D(4, 0, 4)
+	for i, w := range []float64{1, 3, 2, 4} {
+		h.Fill(float64(i)+0.5, w)
+	}
+
+	lin := h.Interpolator(InterpLinear)
+	spl := h.Interpolator(InterpSpline)
+	for _, tc := range []struct {
+		x    float64
+		want float64
+	}{
+		{-1, 1},
+		{0.25, 1},
+		{0.5, 1},
+		{1, 2},
+		{1.5, 3},
+		{1.75, 2.75},
+		{2.5, 2},
+		{3, 3},
+		{3.5, 4},
+		{10, 4},
+	} {
+		if got := h.Interpolate(tc.x); !scalar.EqualWithinAbs(got, tc.want, 1e-12) {
+			t.Fatalf("invalid linear interpolation at x=%v: got=%v, want=%v", tc.x, got, tc.want)
+		}
+		if got := lin(tc.x); !scalar.EqualWithinAbs(got, tc.want, 1e-12) {
+			t.Fatalf("invalid linear interpolator at x=%v: got=%v, want=%v", tc.x, got, tc.want)
+		}
+	}
+
+	// the spline goes through the bin centers, and is smooth in-between.
+	for i, bin := range h.Binning.Bins {
+		if got, want := spl(bin.XMid()), bin.SumW(); !scalar.EqualWithinAbs(got, want, 1e-12) {
+			t.Fatalf("invalid spline at bin %d: got=%v, want=%v", i, got, want)
+		}
+	}
+	// natural cubic spline through (0.5,1), (1.5,3), (2.5,2), (3.5,4):
+	// the second derivatives at the inner knots are (-6, +6).
+	for _, tc := range []struct {
+		x    float64
+		want float64
+	}{
+		{1, 2 + 6.0/16},
+		{2, 2.5},
+		{3, 3 - 6.0/16},
+	} {
+		if got := spl(tc.x); !scalar.EqualWithinAbs(got, tc.want, 1e-12) {
+			t.Fatalf("invalid spline at x=%v: got=%v, want=%v", tc.x, got, tc.want)
+		}
+	}
+
+	one := NewH1D(1, 0, 1)
+	one.Fill(0.5, 3)
+	if got, want := one.Interpolator(InterpSpline)(0.2), 3.0; got != want {
+		t.Fatalf("invalid 1-bin interpolation: got=%v, want=%v", got, want)
+	}
+}
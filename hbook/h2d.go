@@ -8,8 +8,9 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
-	"math"
-	"strings"
+	"io"
+
+	"go-hep.org/x/hep/rio"
 )
 
 // H2D is a 2-dim histogram with weighted entries.
@@ -246,6 +247,106 @@ func (h *H2D) Integral() float64 {
 	return h.SumW()
 }
 
+// ProjectX returns the projection of this histogram on the x-axis.
+//
+// Only the entries within the y-range of the histogram are included
+// in the projection.
+func (h *H2D) ProjectX() *H1D {
+	o := h.ProjectXRange(0, h.Binning.Ny)
+	for i, oflow := range []int{BngW, BngE} {
+		d := h.Binning.Outflows[oflow-1].X
+		o.Binning.Outflows[i].addScaled(1, 1, d)
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+	return o
+}
+
+// ProjectY returns the projection of this histogram on the y-axis.
+//
+// Only the entries within the x-range of the histogram are included
+// in the projection.
+func (h *H2D) ProjectY() *H1D {
+	o := h.ProjectYRange(0, h.Binning.Nx)
+	for i, oflow := range []int{BngS, BngN} {
+		d := h.Binning.Outflows[oflow-1].Y
+		o.Binning.Outflows[i].addScaled(1, 1, d)
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+	return o
+}
+
+// ProjectXRange returns the projection on the x-axis of the y-bins
+// of this histogram with indices in [iy1, iy2).
+//
+// The x-outflows of the histogram are not included in the projection.
+// ProjectXRange panics if the range of y-bins is invalid.
+func (h *H2D) ProjectXRange(iy1, iy2 int) *H1D {
+	bng := &h.Binning
+	if iy1 < 0 || iy2 > bng.Ny || iy1 >= iy2 {
+		panic(fmt.Errorf("hbook: invalid y-bins range [%d, %d)", iy1, iy2))
+	}
+	o := NewH1DFromEdges(binEdges(bng.XEdges))
+	h.projectAnn(o.Ann, "_px")
+
+	for iy := iy1; iy < iy2; iy++ {
+		for ix := 0; ix < bng.Nx; ix++ {
+			d := bng.Bins[iy*bng.Nx+ix].Dist.X
+			o.Binning.Bins[ix].Dist.addScaled(1, 1, d)
+			o.Binning.Dist.addScaled(1, 1, d)
+		}
+	}
+	return o
+}
+
+// ProjectYRange returns the projection on the y-axis of the x-bins
+// of this histogram with indices in [ix1, ix2).
+//
+// The y-outflows of the histogram are not included in the projection.
+// ProjectYRange panics if the range of x-bins is invalid.
+func (h *H2D) ProjectYRange(ix1, ix2 int) *H1D {
+	bng := &h.Binning
+	if ix1 < 0 || ix2 > bng.Nx || ix1 >= ix2 {
+		panic(fmt.Errorf("hbook: invalid x-bins range [%d, %d)", ix1, ix2))
+	}
+	o := NewH1DFromEdges(binEdges(bng.YEdges))
+	h.projectAnn(o.Ann, "_py")
+
+	for iy := 0; iy < bng.Ny; iy++ {
+		for ix := ix1; ix < ix2; ix++ {
+			d := bng.Bins[iy*bng.Nx+ix].Dist.Y
+			o.Binning.Bins[iy].Dist.addScaled(1, 1, d)
+			o.Binning.Dist.addScaled(1, 1, d)
+		}
+	}
+	return o
+}
+
+// SliceX returns the distribution along the x-axis of the entries
+// of this histogram in the y-bin with index iy.
+//
+// SliceX panics if iy is not a valid y-bin index.
+func (h *H2D) SliceX(iy int) *H1D {
+	return h.ProjectXRange(iy, iy+1)
+}
+
+// SliceY returns the distribution along the y-axis of the entries
+// of this histogram in the x-bin with index ix.
+//
+// SliceY panics if ix is not a valid x-bin index.
+func (h *H2D) SliceY(ix int) *H1D {
+	return h.ProjectYRange(ix, ix+1)
+}
+
+// projectAnn fills the annotation of a projection of this histogram.
+func (h *H2D) projectAnn(ann Annotation, suffix string) {
+	if name := h.Name(); name != "" {
+		ann["name"] = name + suffix
+	}
+	if v, ok := h.Ann["title"]; ok {
+		ann["title"] = v
+	}
+}
+
 // GridXYZ returns an anonymous struct value that implements
 // gonum/plot/plotter.GridXYZ and is ready to plot.
 func (h *H2D) GridXYZ() h2dGridXYZ {
@@ -278,23 +379,29 @@ func (g h2dGridXYZ) Y(r int) float64 {
 var _ Object = (*H2D)(nil)
 var _ Histogram = (*H2D)(nil)
 
+// serialization interfaces
+var _ rio.Marshaler = (*H2D)(nil)
+var _ rio.Unmarshaler = (*H2D)(nil)
+var _ rio.Streamer = (*H2D)(nil)
+
+// RioMarshal implements rio.RioMarshaler
+func (h *H2D) RioMarshal(w io.Writer) error {
+	return rioMarshal(w, h)
+}
+
+// RioUnmarshal implements rio.RioUnmarshaler
+func (h *H2D) RioUnmarshal(r io.Reader) error {
+	return rioUnmarshal(r, h)
+}
+
+// RioVersion implements rio.RioStreamer
+func (h *H2D) RioVersion() rio.Version {
+	return 0
+}
+
 // annToYODA creates a new Annotation with fields compatible with YODA
 func (h *H2D) annToYODA() Annotation {
-	ann := make(Annotation, len(h.Ann))
-	ann["Type"] = "Histo2D"
-	ann["Path"] = "/" + h.Name()
-	ann["Title"] = ""
-	for k, v := range h.Ann {
-		if k == "name" {
-			continue
-		}
-		if k == "title" {
-			ann["Title"] = v
-			continue
-		}
-		ann[k] = v
-	}
-	return ann
+	return h.Ann.toYODA("Histo2D", h.Name())
 }
 
 // annFromYODA creates a new Annotation from YODA compatible fields
@@ -302,20 +409,7 @@ func (h *H2D) annFromYODA(ann Annotation) {
 	if len(h.Ann) == 0 {
 		h.Ann = make(Annotation, len(ann))
 	}
-	for k, v := range ann {
-		switch k {
-		case "Type":
-			// noop
-		case "Path":
-			name := v.(string)
-			name = strings.TrimPrefix(name, "/")
-			h.Ann["name"] = name
-		case "Title":
-			h.Ann["title"] = v
-		default:
-			h.Ann[k] = v
-		}
-	}
+	h.Ann.fromYODA(ann)
 }
 
 // MarshalYODA implements the YODAMarshaler interface.
@@ -323,6 +417,14 @@ func (h *H2D) MarshalYODA() ([]byte, error) {
 	return h.marshalYODAv2()
 }
 
+// MarshalYODA2 marshals the histogram into the YODA2 format.
+//
+// The outflows of the histogram are stored in the first outflow bin
+// of the corresponding region.
+func (h *H2D) MarshalYODA2() ([]byte, error) {
+	return h.marshalYODAv3()
+}
+
 func (h *H2D) marshalYODAv1() ([]byte, error) {
 	buf := new(bytes.Buffer)
 	ann := h.annToYODA()
@@ -408,6 +510,71 @@ func (h *H2D) marshalYODAv2() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+func (h *H2D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := h.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_HISTO2D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	fmt.Fprintf(buf, "# Mean: (%e, %e)\n", h.XMean(), h.YMean())
+	fmt.Fprintf(buf, "# Integral: %e\n", h.Integral())
+
+	var (
+		bng   = &h.Binning
+		nx    = bng.Nx
+		ny    = bng.Ny
+		edges = func(bins []Bin1D) []float64 {
+			o := make([]float64, 0, len(bins)+1)
+			for _, bin := range bins {
+				o = append(o, bin.Range.Min)
+			}
+			return append(o, bins[len(bins)-1].Range.Max)
+		}
+		// YODA2 bins, including outflows, with the X index varying fastest.
+		rows = make([]*Dist2D, (nx+2)*(ny+2))
+		cell = func(ix, iy int) int { return iy*(nx+2) + ix }
+	)
+	for iy := 0; iy < ny; iy++ {
+		for ix := 0; ix < nx; ix++ {
+			rows[cell(ix+1, iy+1)] = &bng.Bins[iy*nx+ix].Dist
+		}
+	}
+	for i, ixy := range [8][2]int{
+		BngNW - 1: {0, ny + 1},
+		BngN - 1:  {1, ny + 1},
+		BngNE - 1: {nx + 1, ny + 1},
+		BngE - 1:  {nx + 1, 1},
+		BngSE - 1: {nx + 1, 0},
+		BngS - 1:  {1, 0},
+		BngSW - 1: {0, 0},
+		BngW - 1:  {0, 1},
+	} {
+		rows[cell(ixy[0], ixy[1])] = &bng.Outflows[i]
+	}
+
+	writeYODA2Edges(buf, 1, edges(bng.XEdges))
+	writeYODA2Edges(buf, 2, edges(bng.YEdges))
+	fmt.Fprintf(buf, "# sumW\t sumW2\t sumW(A1)\t sumW2(A1)\t sumW(A2)\t sumW2(A2)\t sumW(A1A2)\t numEntries\n")
+	var zero Dist2D
+	for _, d := range rows {
+		if d == nil {
+			d = &zero
+		}
+		fmt.Fprintf(
+			buf,
+			"%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+			d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(), d.SumWXY(), float64(d.Entries()),
+		)
+	}
+	fmt.Fprintf(buf, "END YODA_HISTO2D_V3\n\n")
+	return buf.Bytes(), err
+}
+
 // UnmarshalYODA implements the YODAUnmarshaler interface.
 func (h *H2D) UnmarshalYODA(data []byte) error {
 	r := newRBuffer(data)
@@ -420,6 +587,8 @@ func (h *H2D) UnmarshalYODA(data []byte) error {
 		return h.unmarshalYODAv1(r)
 	case 2:
 		return h.unmarshalYODAv2(r)
+	case 3:
+		return h.unmarshalYODAv3(r)
 	default:
 		return fmt.Errorf("hbook: invalid YODA version %v", vers)
 	}
@@ -452,10 +621,6 @@ func (h *H2D) unmarshalYODAv1(r *rbuffer) error {
 	var (
 		dist Dist2D
 		bins []Bin2D
-		xmin = math.Inf(+1)
-		xmax = math.Inf(-1)
-		ymin = math.Inf(+1)
-		ymax = math.Inf(-1)
 	)
 	s := bufio.NewScanner(r)
 scanLoop:
@@ -502,25 +667,19 @@ scanLoop:
 			d.Y.Dist = d.X.Dist
 			xset[bin.XRange.Min] = 1
 			yset[bin.YRange.Min] = 1
-			xmin = math.Min(xmin, bin.XRange.Min)
-			xmax = math.Max(xmax, bin.XRange.Max)
-			ymin = math.Min(ymin, bin.YRange.Min)
-			ymax = math.Max(ymax, bin.YRange.Max)
 			bins = append(bins, bin)
 
 		default:
 			return fmt.Errorf("hbook: invalid H2D-YODA data: %q", string(buf))
 		}
 	}
-	h.Binning = newBinning2D(len(xset), xmin, xmax, len(yset), ymin, ymax)
-	h.Binning.Dist = dist
-	// YODA bins are transposed wrt ours
-	for ix := 0; ix < h.Binning.Nx; ix++ {
-		for iy := 0; iy < h.Binning.Ny; iy++ {
-			h.Binning.Bins[iy*h.Binning.Nx+ix] = bins[ix*h.Binning.Ny+iy]
-		}
+	bng, err := binning2DFromYODA(len(xset), len(yset), bins)
+	if err != nil {
+		return err
 	}
-	return err
+	h.Binning = bng
+	h.Binning.Dist = dist
+	return nil
 }
 
 func (h *H2D) unmarshalYODAv2(r *rbuffer) error {
@@ -550,10 +709,6 @@ func (h *H2D) unmarshalYODAv2(r *rbuffer) error {
 	var (
 		dist Dist2D
 		bins []Bin2D
-		xmin = math.Inf(+1)
-		xmax = math.Inf(-1)
-		ymin = math.Inf(+1)
-		ymax = math.Inf(-1)
 	)
 	s := bufio.NewScanner(r)
 scanLoop:
@@ -604,23 +759,130 @@ scanLoop:
 			d.Y.Dist = d.X.Dist
 			xset[bin.XRange.Min] = 1
 			yset[bin.YRange.Min] = 1
-			xmin = math.Min(xmin, bin.XRange.Min)
-			xmax = math.Max(xmax, bin.XRange.Max)
-			ymin = math.Min(ymin, bin.YRange.Min)
-			ymax = math.Max(ymax, bin.YRange.Max)
 			bins = append(bins, bin)
 
 		default:
 			return fmt.Errorf("hbook: invalid H2D-YODA data: %q", string(buf))
 		}
 	}
-	h.Binning = newBinning2D(len(xset), xmin, xmax, len(yset), ymin, ymax)
+	bng, err := binning2DFromYODA(len(xset), len(yset), bins)
+	if err != nil {
+		return err
+	}
+	h.Binning = bng
 	h.Binning.Dist = dist
+	return nil
+}
+
+func (h *H2D) unmarshalYODAv3(r *rbuffer) error {
+	ann, err := readYODA2Ann(r)
+	if err != nil {
+		return err
+	}
+	h.annFromYODA(ann)
+
+	body, err := readYODA2Body(r, 8)
+	if err != nil {
+		return err
+	}
+	if len(body.edges) != 2 {
+		return fmt.Errorf("hbook: invalid H2D-YODA2 number of axes (got=%d, want=2)", len(body.edges))
+	}
+	var (
+		nx = len(body.edges[0]) - 1
+		ny = len(body.edges[1]) - 1
+	)
+	if nx < 1 || ny < 1 || len(body.rows) != (nx+2)*(ny+2) {
+		return fmt.Errorf("hbook: invalid H2D-YODA2 number of bins (got=%d, want=%d)", len(body.rows), (nx+2)*(ny+2))
+	}
+
+	dist := func(row []float64) Dist2D {
+		var d Dist2D
+		d.X.Dist.SumW = row[0]
+		d.X.Dist.SumW2 = row[1]
+		d.X.Stats.SumWX = row[2]
+		d.X.Stats.SumWX2 = row[3]
+		d.Y.Stats.SumWX = row[4]
+		d.Y.Stats.SumWX2 = row[5]
+		d.Stats.SumWXY = row[6]
+		d.X.Dist.N = int64(row[7])
+		d.Y.Dist = d.X.Dist
+		return d
+	}
+
+	// outflow region of a YODA2 bin, as a BngXXX index.
+	outflow := func(ix, iy int) int {
+		var (
+			xlo = ix == 0
+			xhi = ix == nx+1
+			ylo = iy == 0
+			yhi = iy == ny+1
+		)
+		switch {
+		case xlo && yhi:
+			return BngNW
+		case xhi && yhi:
+			return BngNE
+		case yhi:
+			return BngN
+		case xhi && ylo:
+			return BngSE
+		case xlo && ylo:
+			return BngSW
+		case ylo:
+			return BngS
+		case xhi:
+			return BngE
+		case xlo:
+			return BngW
+		}
+		return 0
+	}
+
+	bng := newBinning2DFromEdges(body.edges[0], body.edges[1])
+	for i, row := range body.rows {
+		var (
+			d  = dist(row)
+			ix = i % (nx + 2)
+			iy = i / (nx + 2)
+		)
+		bng.Dist.addScaled(1, 1, d)
+		if o := outflow(ix, iy); o != 0 {
+			bng.Outflows[o-1].addScaled(1, 1, d)
+			continue
+		}
+		bng.Bins[(iy-1)*nx+ix-1].Dist = d
+	}
+	h.Binning = bng
+	return nil
+}
+
+// binning2DFromYODA creates a new 2-dim binning from the nx*ny bins
+// of a YODA histogram.
+// The bin edges are inferred from the bins, so that variable-width
+// binnings are preserved.
+func binning2DFromYODA(nx, ny int, bins []Bin2D) (Binning2D, error) {
+	if len(bins) == 0 || len(bins) != nx*ny {
+		return Binning2D{}, fmt.Errorf("hbook: invalid H2D-YODA number of bins (got=%d, want=%dx%d)", len(bins), nx, ny)
+	}
+	xedges := make([]float64, nx+1)
+	for ix := range xedges[:nx] {
+		xedges[ix] = bins[ix*ny].XRange.Min
+	}
+	xedges[nx] = bins[(nx-1)*ny].XRange.Max
+
+	yedges := make([]float64, ny+1)
+	for iy := range yedges[:ny] {
+		yedges[iy] = bins[iy].YRange.Min
+	}
+	yedges[ny] = bins[ny-1].YRange.Max
+
+	bng := newBinning2DFromEdges(xedges, yedges)
 	// YODA bins are transposed wrt ours
-	for ix := 0; ix < h.Binning.Nx; ix++ {
-		for iy := 0; iy < h.Binning.Ny; iy++ {
-			h.Binning.Bins[iy*h.Binning.Nx+ix] = bins[ix*h.Binning.Ny+iy]
+	for ix := 0; ix < nx; ix++ {
+		for iy := 0; iy < ny; iy++ {
+			bng.Bins[iy*nx+ix] = bins[ix*ny+iy]
 		}
 	}
-	return err
+	return bng, nil
 }
@@ -279,6 +279,81 @@ func TestH2EdgesWithPanics(t *testing.T) {
 	}
 }
 
+func TestH2DVariableBins(t *testing.T) {
+	h := NewH2DFromEdges(
+		[]float64{0, 1, 3, 6},
+		[]float64{-1, 0, 0.5, 2},
+	)
+	h.Fill(0.5, -0.5, 1)
+	h.Fill(2.0, +0.2, 2)
+	h.Fill(2.9, +0.4, 3)
+	h.Fill(5.0, +1.5, 4)
+	h.Fill(6.0, +1.5, 5) // E
+	h.Fill(0.5, -2.0, 6) // S
+
+	for _, tc := range []struct {
+		x, y float64
+		xrng Range
+		yrng Range
+		sumw float64
+	}{
+		{0.5, -0.5, Range{0, 1}, Range{-1, 0}, 1},
+		{2.0, +0.2, Range{1, 3}, Range{0, 0.5}, 5},
+		{5.0, +1.5, Range{3, 6}, Range{0.5, 2}, 4},
+		{3.0, +0.5, Range{3, 6}, Range{0.5, 2}, 4},
+		{0.0, +1.9, Range{0, 1}, Range{0.5, 2}, 0},
+	} {
+		bin := h.Bin(tc.x, tc.y)
+		if bin == nil {
+			t.Fatalf("(%v,%v): no bin", tc.x, tc.y)
+		}
+		if bin.XRange != tc.xrng || bin.YRange != tc.yrng {
+			t.Fatalf("(%v,%v): invalid bin ranges: got=(%v,%v), want=(%v,%v)",
+				tc.x, tc.y, bin.XRange, bin.YRange, tc.xrng, tc.yrng,
+			)
+		}
+		if got, want := bin.SumW(), tc.sumw; got != want {
+			t.Fatalf("(%v,%v): invalid sumw: got=%v, want=%v", tc.x, tc.y, got, want)
+		}
+	}
+
+	if got, want := h.Binning.Outflows[BngE-1].SumW(), 5.0; got != want {
+		t.Fatalf("invalid E-outflow: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Binning.Outflows[BngS-1].SumW(), 6.0; got != want {
+		t.Fatalf("invalid S-outflow: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Integral(), 21.0; got != want {
+		t.Fatalf("invalid integral: got=%v, want=%v", got, want)
+	}
+
+	raw, err := h.MarshalYODA()
+	if err != nil {
+		t.Fatalf("could not marshal h2d: %+v", err)
+	}
+
+	var hh H2D
+	err = hh.UnmarshalYODA(raw)
+	if err != nil {
+		t.Fatalf("could not unmarshal h2d: %+v", err)
+	}
+
+	if !reflect.DeepEqual(hh.Binning.XEdges, h.Binning.XEdges) {
+		t.Fatalf("invalid x-edges:\ngot= %v\nwant=%v", hh.Binning.XEdges, h.Binning.XEdges)
+	}
+	if !reflect.DeepEqual(hh.Binning.YEdges, h.Binning.YEdges) {
+		t.Fatalf("invalid y-edges:\ngot= %v\nwant=%v", hh.Binning.YEdges, h.Binning.YEdges)
+	}
+	if !reflect.DeepEqual(hh.Binning.Bins, h.Binning.Bins) {
+		t.Fatalf("invalid bins:\ngot= %v\nwant=%v", hh.Binning.Bins, h.Binning.Bins)
+	}
+
+	hh.Fill(2.9, +0.4, 3)
+	if got, want := hh.Bin(2.9, 0.4).SumW(), 8.0; got != want {
+		t.Fatalf("invalid sumw after round-trip: got=%v, want=%v", got, want)
+	}
+}
+
 // check H2D can be plotted
 var _ plotter.GridXYZ = ((*H2D)(nil)).GridXYZ()
 
@@ -487,3 +562,148 @@ func TestH2DFillN(t *testing.T) {
 		h2.FillN(xs, ys, []float64{1})
 	}()
 }
+
+func TestH2DProject(t *testing.T) {
+	h := NewH2DFromEdges([]float64{0, 1, 2, 4}, []float64{0, 1, 3})
+	h.Ann["name"] = "h2"
+	h.Ann["title"] = "response"
+
+	type entry struct{ x, y, w float64 }
+	entries := []entry{
+		{0.5, 0.5, 1}, {0.5, 0.5, 2}, {1.5, 0.5, 1}, {3.5, 0.5, 4},
+		{0.5, 2.5, 1}, {1.5, 1.5, 2}, {2.5, 2.5, 3},
+		{-1, 0.5, 1}, {5, 2.5, 2}, // W and E outflows
+		{0.5, -1, 1}, {2.5, 5, 2}, // S and N outflows
+		{-1, -1, 4}, {5, 5, 1}, // corners
+	}
+	for _, e := range entries {
+		h.Fill(e.x, e.y, e.w)
+	}
+
+	for _, tc := range []struct {
+		name string
+		got  func() *H1D
+		want func() *H1D
+	}{
+		{
+			name: "px",
+			got:  h.ProjectX,
+			want: func() *H1D {
+				o := NewH1DFromEdges([]float64{0, 1, 2, 4})
+				for _, e := range entries {
+					if 0 <= e.y && e.y < 3 {
+						o.Fill(e.x, e.w)
+					}
+				}
+				return o
+			},
+		},
+		{
+			name: "py",
+			got:  h.ProjectY,
+			want: func() *H1D {
+				o := NewH1DFromEdges([]float64{0, 1, 3})
+				for _, e := range entries {
+					if 0 <= e.x && e.x < 4 {
+						o.Fill(e.y, e.w)
+					}
+				}
+				return o
+			},
+		},
+		{
+			name: "px-range",
+			got:  func() *H1D { return h.ProjectXRange(1, 2) },
+			want: func() *H1D {
+				o := NewH1DFromEdges([]float64{0, 1, 2, 4})
+				for _, e := range entries {
+					if 1 <= e.y && e.y < 3 && 0 <= e.x && e.x < 4 {
+						o.Fill(e.x, e.w)
+					}
+				}
+				return o
+			},
+		},
+		{
+			name: "slice-x",
+			got:  func() *H1D { return h.SliceX(0) },
+			want: func() *H1D {
+				o := NewH1DFromEdges([]float64{0, 1, 2, 4})
+				for _, e := range entries {
+					if 0 <= e.y && e.y < 1 && 0 <= e.x && e.x < 4 {
+						o.Fill(e.x, e.w)
+					}
+				}
+				return o
+			},
+		},
+		{
+			name: "py-range",
+			got:  func() *H1D { return h.ProjectYRange(1, 3) },
+			want: func() *H1D {
+				o := NewH1DFromEdges([]float64{0, 1, 3})
+				for _, e := range entries {
+					if 1 <= e.x && e.x < 4 && 0 <= e.y && e.y < 3 {
+						o.Fill(e.y, e.w)
+					}
+				}
+				return o
+			},
+		},
+		{
+			name: "slice-y",
+			got:  func() *H1D { return h.SliceY(2) },
+			want: func() *H1D {
+				o := NewH1DFromEdges([]float64{0, 1, 3})
+				for _, e := range entries {
+					if 2 <= e.x && e.x < 4 && 0 <= e.y && e.y < 3 {
+						o.Fill(e.y, e.w)
+					}
+				}
+				return o
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.got()
+			want := tc.want()
+			if !reflect.DeepEqual(got.Binning, want.Binning) {
+				t.Fatalf("invalid projection:\ngot= %+v\nwant=%+v", got.Binning, want.Binning)
+			}
+			if got, want := got.Ann["title"], h.Ann["title"]; got != want {
+				t.Fatalf("invalid title: got=%v, want=%v", got, want)
+			}
+		})
+	}
+
+	if got, want := h.ProjectX().Name(), "h2_px"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := h.SliceY(0).Name(), "h2_py"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		f    func()
+		err  string
+	}{
+		{"px-empty", func() { h.ProjectXRange(1, 1) }, "hbook: invalid y-bins range [1, 1)"},
+		{"px-high", func() { h.ProjectXRange(0, 3) }, "hbook: invalid y-bins range [0, 3)"},
+		{"py-low", func() { h.ProjectYRange(-1, 2) }, "hbook: invalid x-bins range [-1, 2)"},
+		{"slice-y", func() { h.SliceY(3) }, "hbook: invalid x-bins range [3, 4)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				e := recover()
+				if e == nil {
+					t.Fatalf("expected a panic")
+				}
+				if got, want := e.(error).Error(), tc.err; got != want {
+					t.Fatalf("invalid panic message: got=%q, want=%q", got, want)
+				}
+			}()
+			tc.f()
+		})
+	}
+}
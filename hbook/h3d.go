@@ -0,0 +1,484 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"fmt"
+	"io"
+
+	"go-hep.org/x/hep/rio"
+)
+
+// H3D is a 3-dim histogram with weighted entries.
+type H3D struct {
+	Binning Binning3D
+	Ann     Annotation
+}
+
+// NewH3D creates a new 3-dim histogram.
+func NewH3D(nx int, xlow, xhigh float64, ny int, ylow, yhigh float64, nz int, zlow, zhigh float64) *H3D {
+	return &H3D{
+		Binning: newBinning3D(nx, xlow, xhigh, ny, ylow, yhigh, nz, zlow, zhigh),
+		Ann:     make(Annotation),
+	}
+}
+
+// NewH3DFromEdges creates a new 3-dim histogram from slices
+// of edges in x, y and z.
+// The number of bins in x, y and z is thus len(edges)-1.
+// It panics if the length of edges is <=1 (in any dimension.)
+// It panics if the edges are not sorted (in any dimension.)
+// It panics if there are duplicate edge values (in any dimension.)
+func NewH3DFromEdges(xedges, yedges, zedges []float64) *H3D {
+	return &H3D{
+		Binning: newBinning3DFromEdges(xedges, yedges, zedges),
+		Ann:     make(Annotation),
+	}
+}
+
+// Clone returns a deep copy of this 3-dim histogram.
+func (h *H3D) Clone() *H3D {
+	return &H3D{
+		Binning: h.Binning.clone(),
+		Ann:     h.Ann.clone(),
+	}
+}
+
+// Name returns the name of this histogram, if any
+func (h *H3D) Name() string {
+	v, ok := h.Ann["name"]
+	if !ok {
+		return ""
+	}
+	n, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	return n
+}
+
+// Annotation returns the annotations attached to this histogram
+func (h *H3D) Annotation() Annotation {
+	return h.Ann
+}
+
+// Rank returns the number of dimensions for this histogram
+func (h *H3D) Rank() int {
+	return 3
+}
+
+// Entries returns the number of entries in this histogram
+func (h *H3D) Entries() int64 {
+	return h.Binning.entries()
+}
+
+// EffEntries returns the number of effective entries in this histogram
+func (h *H3D) EffEntries() float64 {
+	return h.Binning.effEntries()
+}
+
+// SumW returns the sum of weights in this histogram.
+// Overflows are included in the computation.
+func (h *H3D) SumW() float64 {
+	return h.Binning.Dist.SumW()
+}
+
+// SumW2 returns the sum of squared weights in this histogram.
+// Overflows are included in the computation.
+func (h *H3D) SumW2() float64 {
+	return h.Binning.Dist.SumW2()
+}
+
+// SumWX returns the 1st order weighted x moment
+// Overflows are included in the computation.
+func (h *H3D) SumWX() float64 {
+	return h.Binning.Dist.SumWX()
+}
+
+// SumWX2 returns the 2nd order weighted x moment
+// Overflows are included in the computation.
+func (h *H3D) SumWX2() float64 {
+	return h.Binning.Dist.SumWX2()
+}
+
+// SumWY returns the 1st order weighted y moment
+// Overflows are included in the computation.
+func (h *H3D) SumWY() float64 {
+	return h.Binning.Dist.SumWY()
+}
+
+// SumWY2 returns the 2nd order weighted y moment
+// Overflows are included in the computation.
+func (h *H3D) SumWY2() float64 {
+	return h.Binning.Dist.SumWY2()
+}
+
+// SumWZ returns the 1st order weighted z moment
+// Overflows are included in the computation.
+func (h *H3D) SumWZ() float64 {
+	return h.Binning.Dist.SumWZ()
+}
+
+// SumWZ2 returns the 2nd order weighted z moment
+// Overflows are included in the computation.
+func (h *H3D) SumWZ2() float64 {
+	return h.Binning.Dist.SumWZ2()
+}
+
+// SumWXY returns the 1st order weighted x*y moment
+// Overflows are included in the computation.
+func (h *H3D) SumWXY() float64 {
+	return h.Binning.Dist.SumWXY()
+}
+
+// SumWXZ returns the 1st order weighted x*z moment
+// Overflows are included in the computation.
+func (h *H3D) SumWXZ() float64 {
+	return h.Binning.Dist.SumWXZ()
+}
+
+// SumWYZ returns the 1st order weighted y*z moment
+// Overflows are included in the computation.
+func (h *H3D) SumWYZ() float64 {
+	return h.Binning.Dist.SumWYZ()
+}
+
+// XMean returns the mean X.
+// Overflows are included in the computation.
+func (h *H3D) XMean() float64 {
+	return h.Binning.Dist.xMean()
+}
+
+// YMean returns the mean Y.
+// Overflows are included in the computation.
+func (h *H3D) YMean() float64 {
+	return h.Binning.Dist.yMean()
+}
+
+// ZMean returns the mean Z.
+// Overflows are included in the computation.
+func (h *H3D) ZMean() float64 {
+	return h.Binning.Dist.zMean()
+}
+
+// XVariance returns the variance in X.
+// Overflows are included in the computation.
+func (h *H3D) XVariance() float64 {
+	return h.Binning.Dist.xVariance()
+}
+
+// YVariance returns the variance in Y.
+// Overflows are included in the computation.
+func (h *H3D) YVariance() float64 {
+	return h.Binning.Dist.yVariance()
+}
+
+// ZVariance returns the variance in Z.
+// Overflows are included in the computation.
+func (h *H3D) ZVariance() float64 {
+	return h.Binning.Dist.zVariance()
+}
+
+// XStdDev returns the standard deviation in X.
+// Overflows are included in the computation.
+func (h *H3D) XStdDev() float64 {
+	return h.Binning.Dist.xStdDev()
+}
+
+// YStdDev returns the standard deviation in Y.
+// Overflows are included in the computation.
+func (h *H3D) YStdDev() float64 {
+	return h.Binning.Dist.yStdDev()
+}
+
+// ZStdDev returns the standard deviation in Z.
+// Overflows are included in the computation.
+func (h *H3D) ZStdDev() float64 {
+	return h.Binning.Dist.zStdDev()
+}
+
+// XStdErr returns the standard error in X.
+// Overflows are included in the computation.
+func (h *H3D) XStdErr() float64 {
+	return h.Binning.Dist.xStdErr()
+}
+
+// YStdErr returns the standard error in Y.
+// Overflows are included in the computation.
+func (h *H3D) YStdErr() float64 {
+	return h.Binning.Dist.yStdErr()
+}
+
+// ZStdErr returns the standard error in Z.
+// Overflows are included in the computation.
+func (h *H3D) ZStdErr() float64 {
+	return h.Binning.Dist.zStdErr()
+}
+
+// XRMS returns the RMS in X.
+// Overflows are included in the computation.
+func (h *H3D) XRMS() float64 {
+	return h.Binning.Dist.xRMS()
+}
+
+// YRMS returns the RMS in Y.
+// Overflows are included in the computation.
+func (h *H3D) YRMS() float64 {
+	return h.Binning.Dist.yRMS()
+}
+
+// ZRMS returns the RMS in Z.
+// Overflows are included in the computation.
+func (h *H3D) ZRMS() float64 {
+	return h.Binning.Dist.zRMS()
+}
+
+// Fill fills this histogram with (x,y,z) and weight w.
+func (h *H3D) Fill(x, y, z, w float64) {
+	h.Binning.fill(x, y, z, w)
+}
+
+// FillN fills this histogram with the provided slices (xs,ys,zs) and weights ws.
+// if ws is nil, the histogram will be filled with entries of weight 1.
+// Otherwise, FillN panics if the slices lengths differ.
+func (h *H3D) FillN(xs, ys, zs, ws []float64) {
+	if len(xs) != len(ys) || len(xs) != len(zs) {
+		panic(fmt.Errorf("hbook: lengths mismatch"))
+	}
+	switch ws {
+	case nil:
+		for i := range xs {
+			h.Binning.fill(xs[i], ys[i], zs[i], 1)
+		}
+	default:
+		if len(xs) != len(ws) {
+			panic(fmt.Errorf("hbook: lengths mismatch"))
+		}
+		for i := range xs {
+			h.Binning.fill(xs[i], ys[i], zs[i], ws[i])
+		}
+	}
+}
+
+// Bin returns the bin at coordinates (x,y,z) for this 3-dim histogram.
+// Bin returns nil for under/over flow bins.
+func (h *H3D) Bin(x, y, z float64) *Bin3D {
+	idx := h.Binning.coordToIndex(x, y, z)
+	if idx < 0 {
+		return nil
+	}
+	return &h.Binning.Bins[idx]
+}
+
+// XMin returns the low edge of the X-axis of this histogram.
+func (h *H3D) XMin() float64 {
+	return h.Binning.XRange.Min
+}
+
+// XMax returns the high edge of the X-axis of this histogram.
+func (h *H3D) XMax() float64 {
+	return h.Binning.XRange.Max
+}
+
+// YMin returns the low edge of the Y-axis of this histogram.
+func (h *H3D) YMin() float64 {
+	return h.Binning.YRange.Min
+}
+
+// YMax returns the high edge of the Y-axis of this histogram.
+func (h *H3D) YMax() float64 {
+	return h.Binning.YRange.Max
+}
+
+// ZMin returns the low edge of the Z-axis of this histogram.
+func (h *H3D) ZMin() float64 {
+	return h.Binning.ZRange.Min
+}
+
+// ZMax returns the high edge of the Z-axis of this histogram.
+func (h *H3D) ZMax() float64 {
+	return h.Binning.ZRange.Max
+}
+
+// Integral computes the integral of the histogram.
+//
+// Overflows are included in the computation.
+func (h *H3D) Integral() float64 {
+	return h.SumW()
+}
+
+// Scale scales the content of each bin by the given factor.
+func (h *H3D) Scale(factor float64) {
+	h.Binning.scaleW(factor)
+}
+
+// ProjectX returns the projection of this histogram on the x-axis.
+//
+// Only the entries within the y- and z-ranges of the histogram are
+// included in the projection.
+func (h *H3D) ProjectX() *H1D {
+	return h.project1D(0, "_px")
+}
+
+// ProjectY returns the projection of this histogram on the y-axis.
+//
+// Only the entries within the x- and z-ranges of the histogram are
+// included in the projection.
+func (h *H3D) ProjectY() *H1D {
+	return h.project1D(1, "_py")
+}
+
+// ProjectZ returns the projection of this histogram on the z-axis.
+//
+// Only the entries within the x- and y-ranges of the histogram are
+// included in the projection.
+func (h *H3D) ProjectZ() *H1D {
+	return h.project1D(2, "_pz")
+}
+
+// ProjectXY returns the projection of this histogram on the (x,y) plane.
+//
+// Only the entries within the z-range of the histogram are included
+// in the projection.
+func (h *H3D) ProjectXY() *H2D {
+	return h.project2D(0, 1, "_pxy")
+}
+
+// ProjectXZ returns the projection of this histogram on the (x,z) plane.
+//
+// Only the entries within the y-range of the histogram are included
+// in the projection.
+func (h *H3D) ProjectXZ() *H2D {
+	return h.project2D(0, 2, "_pxz")
+}
+
+// ProjectYZ returns the projection of this histogram on the (y,z) plane.
+//
+// Only the entries within the x-range of the histogram are included
+// in the projection.
+func (h *H3D) ProjectYZ() *H2D {
+	return h.project2D(1, 2, "_pyz")
+}
+
+// project1D projects this histogram on the axis u (0:x, 1:y, 2:z).
+func (h *H3D) project1D(u int, suffix string) *H1D {
+	var (
+		bng   = &h.Binning
+		edges = [3][]Bin1D{bng.XEdges, bng.YEdges, bng.ZEdges}
+		o     = NewH1DFromEdges(binEdges(edges[u]))
+	)
+	h.projectAnn(o.Ann, suffix)
+
+	for iz := 0; iz < bng.Nz; iz++ {
+		for iy := 0; iy < bng.Ny; iy++ {
+			for ix := 0; ix < bng.Nx; ix++ {
+				var (
+					idx = [3]int{ix, iy, iz}
+					d   = bng.Bins[bng.index(ix, iy, iz)].Dist.axis(u)
+				)
+				o.Binning.Bins[idx[u]].Dist.addScaled(1, 1, d)
+				o.Binning.Dist.addScaled(1, 1, d)
+			}
+		}
+	}
+
+	// outflows along u, in-range along the other axes.
+	for i, ou := range []int{-1, +1} {
+		var c [3]int
+		c[u] = ou
+		d := bng.Outflow(c[0], c[1], c[2]).axis(u)
+		o.Binning.Outflows[i].addScaled(1, 1, d)
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+	return o
+}
+
+// project2D projects this histogram on the (u,v) plane, where u and v
+// are axis indices (0:x, 1:y, 2:z.)
+func (h *H3D) project2D(u, v int, suffix string) *H2D {
+	var (
+		bng   = &h.Binning
+		edges = [3][]Bin1D{bng.XEdges, bng.YEdges, bng.ZEdges}
+		nu    = len(edges[u])
+		o     = NewH2DFromEdges(binEdges(edges[u]), binEdges(edges[v]))
+	)
+	h.projectAnn(o.Ann, suffix)
+
+	for iz := 0; iz < bng.Nz; iz++ {
+		for iy := 0; iy < bng.Ny; iy++ {
+			for ix := 0; ix < bng.Nx; ix++ {
+				var (
+					idx = [3]int{ix, iy, iz}
+					d   = bng.Bins[bng.index(ix, iy, iz)].Dist.plane(u, v)
+				)
+				o.Binning.Bins[idx[v]*nu+idx[u]].Dist.addScaled(1, 1, d)
+				o.Binning.Dist.addScaled(1, 1, d)
+			}
+		}
+	}
+
+	// outflows in the (u,v) plane, in-range along the third axis.
+	for _, oflow := range []struct{ i, ou, ov int }{
+		{BngNW, -1, +1},
+		{BngN, 0, +1},
+		{BngNE, +1, +1},
+		{BngE, +1, 0},
+		{BngSE, +1, -1},
+		{BngS, 0, -1},
+		{BngSW, -1, -1},
+		{BngW, -1, 0},
+	} {
+		var c [3]int
+		c[u] = oflow.ou
+		c[v] = oflow.ov
+		d := bng.Outflow(c[0], c[1], c[2]).plane(u, v)
+		o.Binning.Outflows[oflow.i-1].addScaled(1, 1, d)
+		o.Binning.Dist.addScaled(1, 1, d)
+	}
+	return o
+}
+
+// projectAnn fills the annotation of a projection of this histogram.
+func (h *H3D) projectAnn(ann Annotation, suffix string) {
+	if name := h.Name(); name != "" {
+		ann["name"] = name + suffix
+	}
+	if v, ok := h.Ann["title"]; ok {
+		ann["title"] = v
+	}
+}
+
+// binEdges returns the edges of the provided contiguous bins.
+func binEdges(bins []Bin1D) []float64 {
+	o := make([]float64, 0, len(bins)+1)
+	for _, bin := range bins {
+		o = append(o, bin.Range.Min)
+	}
+	return append(o, bins[len(bins)-1].Range.Max)
+}
+
+// check various interfaces
+var _ Object = (*H3D)(nil)
+var _ Histogram = (*H3D)(nil)
+
+// serialization interfaces
+var _ rio.Marshaler = (*H3D)(nil)
+var _ rio.Unmarshaler = (*H3D)(nil)
+var _ rio.Streamer = (*H3D)(nil)
+
+// RioMarshal implements rio.RioMarshaler
+func (h *H3D) RioMarshal(w io.Writer) error {
+	return rioMarshal(w, h)
+}
+
+// RioUnmarshal implements rio.RioUnmarshaler
+func (h *H3D) RioUnmarshal(r io.Reader) error {
+	return rioUnmarshal(r, h)
+}
+
+// RioVersion implements rio.RioStreamer
+func (h *H3D) RioVersion() rio.Version {
+	return 0
+}
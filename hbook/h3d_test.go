@@ -0,0 +1,289 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/rand"
+)
+
+func TestH3D(t *testing.T) {
+	h := NewH3D(2, 0, 2, 3, 0, 3, 4, 0, 4)
+	if got, want := len(h.Binning.Bins), 2*3*4; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+	h.Annotation()["name"] = "h3"
+	if got, want := h.Name(), "h3"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	for _, v := range [][4]float64{
+		{0.5, 0.5, 0.5, 1},
+		{1.5, 2.5, 3.5, 2},
+		{1.5, 2.5, 3.5, 1},
+		{-1, 0.5, 0.5, 1},
+		{5, 5, 5, 1},
+		{0.5, 0.5, -1, 3},
+	} {
+		h.Fill(v[0], v[1], v[2], v[3])
+	}
+
+	if got, want := h.Entries(), int64(6); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+	if got, want := h.SumW(), 9.0; got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h.SumW2(), 17.0; got != want {
+		t.Fatalf("invalid sumw2: got=%v, want=%v", got, want)
+	}
+
+	bin := h.Bin(1.5, 2.5, 3.5)
+	if bin == nil {
+		t.Fatalf("could not find bin")
+	}
+	if got, want := bin.SumW(), 3.0; got != want {
+		t.Fatalf("invalid bin sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := bin, &h.Binning.Bins[(3*3+2)*2+1]; got != want {
+		t.Fatalf("invalid bin address")
+	}
+	if got, want := [3]float64{bin.XMid(), bin.YMid(), bin.ZMid()}, [3]float64{1.5, 2.5, 3.5}; got != want {
+		t.Fatalf("invalid bin center: got=%v, want=%v", got, want)
+	}
+	if h.Bin(-1, 0.5, 0.5) != nil {
+		t.Fatalf("expected a nil bin for outflows")
+	}
+
+	for _, tc := range []struct {
+		ox, oy, oz int
+		want       float64
+	}{
+		{-1, 0, 0, 1},
+		{+1, +1, +1, 1},
+		{0, 0, -1, 3},
+		{0, 0, +1, 0},
+	} {
+		if got := h.Binning.Outflow(tc.ox, tc.oy, tc.oz).SumW(); got != tc.want {
+			t.Fatalf("invalid outflow(%d,%d,%d): got=%v, want=%v", tc.ox, tc.oy, tc.oz, got, tc.want)
+		}
+	}
+	if h.Binning.Outflow(0, 0, 0) != nil {
+		t.Fatalf("expected a nil outflow for the in-range region")
+	}
+
+	h.Scale(2)
+	if got, want := h.SumW(), 18.0; got != want {
+		t.Fatalf("invalid scaled sumw: got=%v, want=%v", got, want)
+	}
+}
+
+func TestH3DFromEdges(t *testing.T) {
+	h := NewH3DFromEdges(
+		[]float64{0, 1, 4},
+		[]float64{-1, 0, 10},
+		[]float64{0, 0.5},
+	)
+	if got, want := [3]int{h.Binning.Nx, h.Binning.Ny, h.Binning.Nz}, [3]int{2, 2, 1}; got != want {
+		t.Fatalf("invalid number of bins: got=%v, want=%v", got, want)
+	}
+	h.Fill(3, 5, 0.25, 1)
+	bin := h.Bin(3, 5, 0.25)
+	if got, want := bin.XEdges(), (Range{Min: 1, Max: 4}); got != want {
+		t.Fatalf("invalid x-edges: got=%v, want=%v", got, want)
+	}
+	if got, want := bin.Volume(), 3*10*0.5; got != want {
+		t.Fatalf("invalid volume: got=%v, want=%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		xs, ys, zs []float64
+		want       error
+	}{
+		{"short-z", []float64{0, 1}, []float64{0, 1}, []float64{0}, errShortZAxis},
+		{"unsorted-y", []float64{0, 1}, []float64{1, 0}, []float64{0, 1}, errNotSortedYAxis},
+		{"dups-x", []float64{0, 1, 1}, []float64{0, 1}, []float64{0, 1}, errDupEdgesXAxis},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				err := recover()
+				if err != tc.want {
+					t.Fatalf("invalid panic: got=%v, want=%v", err, tc.want)
+				}
+			}()
+			_ = NewH3DFromEdges(tc.xs, tc.ys, tc.zs)
+		})
+	}
+}
+
+func TestH3DProject(t *testing.T) {
+	var (
+		rnd = rand.New(rand.NewSource(1234))
+		h3  = NewH3DFromEdges([]float64{0, 1, 3, 4}, []float64{0, 2, 4}, []float64{0, 1, 2, 4})
+		hx  = NewH1DFromEdges([]float64{0, 1, 3, 4})
+		hy  = NewH1DFromEdges([]float64{0, 2, 4})
+		hz  = NewH1DFromEdges([]float64{0, 1, 2, 4})
+		hxy = NewH2DFromEdges([]float64{0, 1, 3, 4}, []float64{0, 2, 4})
+		hxz = NewH2DFromEdges([]float64{0, 1, 3, 4}, []float64{0, 1, 2, 4})
+		hyz = NewH2DFromEdges([]float64{0, 2, 4}, []float64{0, 1, 2, 4})
+	)
+	h3.Annotation()["name"] = "h3"
+
+	in := func(v, min, max float64) bool { return min <= v && v < max }
+	for i := 0; i < 1000; i++ {
+		var (
+			x = rnd.Float64()*6 - 1
+			y = rnd.Float64()*6 - 1
+			z = rnd.Float64()*6 - 1
+			w = rnd.Float64() + 0.5
+		)
+		h3.Fill(x, y, z, w)
+		if in(y, 0, 4) && in(z, 0, 4) {
+			hx.Fill(x, w)
+		}
+		if in(x, 0, 4) && in(z, 0, 4) {
+			hy.Fill(y, w)
+		}
+		if in(x, 0, 4) && in(y, 0, 4) {
+			hz.Fill(z, w)
+		}
+		if in(z, 0, 4) {
+			hxy.Fill(x, y, w)
+		}
+		if in(y, 0, 4) {
+			hxz.Fill(x, z, w)
+		}
+		if in(x, 0, 4) {
+			hyz.Fill(y, z, w)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		got  *H1D
+		want *H1D
+	}{
+		{"h3_px", h3.ProjectX(), hx},
+		{"h3_py", h3.ProjectY(), hy},
+		{"h3_pz", h3.ProjectZ(), hz},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := tc.got.Name(), tc.name; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			tc.got.Ann = tc.want.Ann
+			cmpH1D(t, tc.got, tc.want)
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		got  *H2D
+		want *H2D
+	}{
+		{"h3_pxy", h3.ProjectXY(), hxy},
+		{"h3_pxz", h3.ProjectXZ(), hxz},
+		{"h3_pyz", h3.ProjectYZ(), hyz},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := tc.got.Name(), tc.name; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			var (
+				got  = tc.got.Binning
+				want = tc.want.Binning
+			)
+			if !reflect.DeepEqual(got.XEdges, want.XEdges) || !reflect.DeepEqual(got.YEdges, want.YEdges) {
+				t.Fatalf("invalid edges")
+			}
+			cmpDist2D(t, "total", got.Dist, want.Dist)
+			for i := range got.Outflows {
+				cmpDist2D(t, "outflow", got.Outflows[i], want.Outflows[i])
+			}
+			for i := range got.Bins {
+				cmpDist2D(t, "bin", got.Bins[i].Dist, want.Bins[i].Dist)
+			}
+		})
+	}
+}
+
+func TestAddH3D(t *testing.T) {
+	h1 := NewH3D(2, 0, 2, 2, 0, 2, 2, 0, 2)
+	h1.Fill(0.5, 0.5, 0.5, 1)
+	h1.Fill(3, 0.5, 0.5, 2)
+
+	h2 := NewH3D(2, 0, 2, 2, 0, 2, 2, 0, 2)
+	h2.Fill(0.5, 0.5, 0.5, 3)
+	h2.Fill(1.5, 1.5, 1.5, 1)
+
+	h := AddH3D(h1, h2)
+	if got, want := h.SumW(), 7.0; got != want {
+		t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Entries(), int64(4); got != want {
+		t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Bin(0.5, 0.5, 0.5).SumW2(), 10.0; got != want {
+		t.Fatalf("invalid bin sumw2: got=%v, want=%v", got, want)
+	}
+	if got, want := h.Binning.Outflow(+1, 0, 0).SumW(), 2.0; got != want {
+		t.Fatalf("invalid outflow sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := h1.SumW(), 3.0; got != want {
+		t.Fatalf("h1 was modified: got=%v, want=%v", got, want)
+	}
+
+	h = SubH3D(h1, h2)
+	if got, want := h.Bin(0.5, 0.5, 0.5).SumW(), -2.0; got != want {
+		t.Fatalf("invalid bin sumw: got=%v, want=%v", got, want)
+	}
+
+	defer func() {
+		if err := recover(); err == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	_ = AddH3D(h1, NewH3D(2, 0, 2, 2, 0, 2, 2, 0, 3))
+}
+
+func cmpH1D(t *testing.T, got, want *H1D) {
+	t.Helper()
+	cmpDist1D(t, "total", got.Binning.Dist, want.Binning.Dist)
+	for i := range got.Binning.Outflows {
+		cmpDist1D(t, "outflow", got.Binning.Outflows[i], want.Binning.Outflows[i])
+	}
+	if len(got.Binning.Bins) != len(want.Binning.Bins) {
+		t.Fatalf("invalid number of bins")
+	}
+	for i := range got.Binning.Bins {
+		if got, want := got.Binning.Bins[i].Range, want.Binning.Bins[i].Range; got != want {
+			t.Fatalf("invalid bin range: got=%v, want=%v", got, want)
+		}
+		cmpDist1D(t, "bin", got.Binning.Bins[i].Dist, want.Binning.Bins[i].Dist)
+	}
+}
+
+func cmpDist1D(t *testing.T, name string, got, want Dist1D) {
+	t.Helper()
+	if got.Dist.N != want.Dist.N ||
+		!fuzzyEq(got.Dist.SumW, want.Dist.SumW) ||
+		!fuzzyEq(got.Dist.SumW2, want.Dist.SumW2) ||
+		!fuzzyEq(got.Stats.SumWX, want.Stats.SumWX) ||
+		!fuzzyEq(got.Stats.SumWX2, want.Stats.SumWX2) {
+		t.Fatalf("invalid %s distribution:\ngot= %+v\nwant=%+v", name, got, want)
+	}
+}
+
+func cmpDist2D(t *testing.T, name string, got, want Dist2D) {
+	t.Helper()
+	cmpDist1D(t, name+"-x", got.X, want.X)
+	cmpDist1D(t, name+"-y", got.Y, want.Y)
+	if !fuzzyEq(got.Stats.SumWXY, want.Stats.SumWXY) {
+		t.Fatalf("invalid %s distribution:\ngot= %+v\nwant=%+v", name, got, want)
+	}
+}
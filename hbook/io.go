@@ -4,7 +4,11 @@
 
 package hbook
 
-import "io"
+import (
+	"encoding"
+	"encoding/binary"
+	"io"
+)
 
 type rbuffer struct {
 	p []byte // buffer of data to read from
@@ -35,3 +39,35 @@ func (r *rbuffer) next(n int) []byte {
 	r.c += n
 	return p
 }
+
+// rioMarshal writes the binary representation of v to w, prefixed with its size.
+func rioMarshal(w io.Writer, v encoding.BinaryMarshaler) error {
+	data, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(len(data)))
+	_, err = w.Write(buf[:])
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// rioUnmarshal reads from r the size-prefixed binary representation of v.
+func rioUnmarshal(r io.Reader, v encoding.BinaryUnmarshaler) error {
+	buf := make([]byte, 8)
+	_, err := io.ReadFull(r, buf)
+	if err != nil {
+		return err
+	}
+	n := int64(binary.LittleEndian.Uint64(buf))
+	buf = make([]byte, int(n))
+	_, err = io.ReadFull(r, buf)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalBinary(buf)
+}
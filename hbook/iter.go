@@ -0,0 +1,206 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+)
+
+// AxisBin is the index, along a dimension, of the outflow bins of 2-dim
+// histograms that span the whole range of that dimension.
+const AxisBin = -3
+
+// BinView is a dimension-agnostic view of a bin of a histogram.
+type BinView struct {
+	// Index holds the indices of the bin along each dimension.
+	// Under- and overflow bins are indexed along a dimension with
+	// UnderflowBin1D and OverflowBin1D, and with AxisBin when they span
+	// the whole range of that dimension.
+	Index []int
+
+	Edges   []Range // edges of the bin along each dimension
+	Value   float64 // content of the bin (sum of weights, or mean for profiles)
+	Error   float64 // error on the content of the bin
+	Entries int64   // number of entries in the bin
+}
+
+// Flow returns whether the bin is an under- or overflow bin.
+func (b BinView) Flow() bool {
+	for _, i := range b.Index {
+		if i < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// BinIterator is implemented by histograms whose bins can be iterated over.
+type BinIterator interface {
+	// IterBins calls yield for each bin of the histogram, until yield
+	// returns false.
+	// Under- and overflow bins are included when flows is true.
+	IterBins(flows bool, yield func(bin BinView) bool)
+}
+
+var (
+	_ BinIterator = (*H1D)(nil)
+	_ BinIterator = (*H2D)(nil)
+	_ BinIterator = (*P1D)(nil)
+)
+
+// IterBins calls yield for each bin of the histogram, until yield returns
+// false.
+// When flows is true, the underflow bin is yielded first and the overflow
+// bin last.
+func (h *H1D) IterBins(flows bool, yield func(bin BinView) bool) {
+	bng := &h.Binning
+	oflow := func(i int) BinView {
+		d := &bng.Outflows[i]
+		return BinView{
+			Index:   []int{oflowIndex1D[i]},
+			Edges:   []Range{oflowRange(bng.XRange, oflowIndex1D[i])},
+			Value:   d.SumW(),
+			Error:   d.errW(),
+			Entries: d.Entries(),
+		}
+	}
+
+	if flows && !yield(oflow(0)) {
+		return
+	}
+	for i := range bng.Bins {
+		bin := &bng.Bins[i]
+		if !yield(BinView{
+			Index:   []int{i},
+			Edges:   []Range{bin.Range},
+			Value:   bin.SumW(),
+			Error:   bin.ErrW(),
+			Entries: bin.Entries(),
+		}) {
+			return
+		}
+	}
+	if flows {
+		yield(oflow(1))
+	}
+}
+
+// IterBins calls yield for each bin of the histogram, until yield returns
+// false.
+// Bins are yielded row by row, the bin (ix,iy) after the bin (ix-1,iy).
+// When flows is true, the outflow bins are yielded last, in the order of the
+// BngXXX constants.
+func (h *H2D) IterBins(flows bool, yield func(bin BinView) bool) {
+	bng := &h.Binning
+	for i := range bng.Bins {
+		bin := &bng.Bins[i]
+		if !yield(BinView{
+			Index:   []int{i % bng.Nx, i / bng.Nx},
+			Edges:   []Range{bin.XRange, bin.YRange},
+			Value:   bin.SumW(),
+			Error:   math.Sqrt(bin.SumW2()),
+			Entries: bin.Entries(),
+		}) {
+			return
+		}
+	}
+	if !flows {
+		return
+	}
+	for i := range bng.Outflows {
+		var (
+			d      = &bng.Outflows[i]
+			ix, iy = oflowIndex2D(i + 1)
+		)
+		if !yield(BinView{
+			Index:   []int{ix, iy},
+			Edges:   []Range{oflowRange(bng.XRange, ix), oflowRange(bng.YRange, iy)},
+			Value:   d.SumW(),
+			Error:   math.Sqrt(d.SumW2()),
+			Entries: d.Entries(),
+		}) {
+			return
+		}
+	}
+}
+
+// IterBins calls yield for each bin of the profile histogram, until yield
+// returns false.
+// The value of a bin is its mean Y and its error the standard error on
+// that mean.
+// When flows is true, the underflow bin is yielded first and the overflow
+// bin last.
+func (p *P1D) IterBins(flows bool, yield func(bin BinView) bool) {
+	bng := &p.bng
+	oflow := func(i int) BinView {
+		d := &bng.outflows[i]
+		return BinView{
+			Index:   []int{oflowIndex1D[i]},
+			Edges:   []Range{oflowRange(bng.xrange, oflowIndex1D[i])},
+			Value:   d.yMean(),
+			Error:   d.yStdErr(),
+			Entries: d.Entries(),
+		}
+	}
+
+	if flows && !yield(oflow(0)) {
+		return
+	}
+	for i := range bng.bins {
+		bin := &bng.bins[i]
+		if !yield(BinView{
+			Index:   []int{i},
+			Edges:   []Range{bin.xrange},
+			Value:   bin.YMean(),
+			Error:   bin.YStdErr(),
+			Entries: bin.Entries(),
+		}) {
+			return
+		}
+	}
+	if flows {
+		yield(oflow(1))
+	}
+}
+
+// oflowIndex1D holds the indices of the under- and overflow bins of a
+// 1-dim binning.
+var oflowIndex1D = [2]int{UnderflowBin1D, OverflowBin1D}
+
+// oflowIndex2D returns the indices along x and y of the outflow bin of a
+// 2-dim binning, identified by one of the BngXXX constants.
+func oflowIndex2D(oflow int) (ix, iy int) {
+	switch oflow {
+	case BngNW:
+		return UnderflowBin1D, OverflowBin1D
+	case BngN:
+		return AxisBin, OverflowBin1D
+	case BngNE:
+		return OverflowBin1D, OverflowBin1D
+	case BngE:
+		return OverflowBin1D, AxisBin
+	case BngSE:
+		return OverflowBin1D, UnderflowBin1D
+	case BngS:
+		return AxisBin, UnderflowBin1D
+	case BngSW:
+		return UnderflowBin1D, UnderflowBin1D
+	case BngW:
+		return UnderflowBin1D, AxisBin
+	}
+	panic("hbook: invalid outflow index")
+}
+
+// oflowRange returns the edges, along an axis spanning rng, of the outflow
+// bin indexed by i along that axis.
+func oflowRange(rng Range, i int) Range {
+	switch i {
+	case UnderflowBin1D:
+		return Range{Min: math.Inf(-1), Max: rng.Min}
+	case OverflowBin1D:
+		return Range{Min: rng.Max, Max: math.Inf(+1)}
+	}
+	return rng
+}
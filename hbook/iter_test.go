@@ -0,0 +1,106 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestIterBinsH1D(t *testing.T) {
+	h := NewH1D(2, 0, 2)
+	h.Fill(-1, 1)
+	h.Fill(0.5, 2)
+	h.Fill(1.5, 3)
+	h.Fill(1.5, 1)
+	h.Fill(3, 4)
+
+	var got []BinView
+	h.IterBins(true, func(bin BinView) bool {
+		got = append(got, bin)
+		return true
+	})
+
+	inf := math.Inf(1)
+	want := []BinView{
+		{Index: []int{UnderflowBin1D}, Edges: []Range{{-inf, 0}}, Value: 1, Error: 1, Entries: 1},
+		{Index: []int{0}, Edges: []Range{{0, 1}}, Value: 2, Error: 2, Entries: 1},
+		{Index: []int{1}, Edges: []Range{{1, 2}}, Value: 4, Error: math.Sqrt(10), Entries: 2},
+		{Index: []int{OverflowBin1D}, Edges: []Range{{2, inf}}, Value: 4, Error: 4, Entries: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid bins:\ngot= %+v\nwant=%+v", got, want)
+	}
+	for i, bin := range got {
+		if got, want := bin.Flow(), i == 0 || i == len(got)-1; got != want {
+			t.Fatalf("bin[%d]: invalid flow: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	got = got[:0]
+	h.IterBins(false, func(bin BinView) bool {
+		got = append(got, bin)
+		return false
+	})
+	if !reflect.DeepEqual(got, want[1:2]) {
+		t.Fatalf("invalid early stop:\ngot= %+v\nwant=%+v", got, want[1:2])
+	}
+}
+
+func TestIterBinsH2D(t *testing.T) {
+	h := NewH2D(2, 0, 2, 2, 0, 2)
+	h.Fill(1.5, 0.5, 2)
+	h.Fill(3, 1, 1)  // E
+	h.Fill(-1, 3, 1) // NW
+
+	var got []BinView
+	h.IterBins(true, func(bin BinView) bool {
+		got = append(got, bin)
+		return true
+	})
+	if got, want := len(got), 4+8; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+
+	inf := math.Inf(1)
+	for _, tc := range []struct {
+		i    int
+		want BinView
+	}{
+		{1, BinView{Index: []int{1, 0}, Edges: []Range{{1, 2}, {0, 1}}, Value: 2, Error: 2, Entries: 1}},
+		{4 + BngNW - 1, BinView{Index: []int{UnderflowBin1D, OverflowBin1D}, Edges: []Range{{-inf, 0}, {2, inf}}, Value: 1, Error: 1, Entries: 1}},
+		{4 + BngE - 1, BinView{Index: []int{OverflowBin1D, AxisBin}, Edges: []Range{{2, inf}, {0, 2}}, Value: 1, Error: 1, Entries: 1}},
+	} {
+		if got := got[tc.i]; !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("bin[%d]: got=%+v, want=%+v", tc.i, got, tc.want)
+		}
+	}
+}
+
+func TestIterBinsP1D(t *testing.T) {
+	p := NewP1D(2, 0, 2)
+	p.Fill(0.5, 1, 1)
+	p.Fill(0.5, 3, 1)
+	p.Fill(5, 2, 1)
+
+	var got []BinView
+	p.IterBins(true, func(bin BinView) bool {
+		got = append(got, bin)
+		return true
+	})
+	if got, want := len(got), 4; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+	if got, want := got[1].Value, 2.0; got != want {
+		t.Fatalf("invalid bin value: got=%v, want=%v", got, want)
+	}
+	if got, want := got[1].Error, 1.0; got != want {
+		t.Fatalf("invalid bin error: got=%v, want=%v", got, want)
+	}
+	if got, want := got[3].Value, 2.0; got != want {
+		t.Fatalf("invalid overflow value: got=%v, want=%v", got, want)
+	}
+}
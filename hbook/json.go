@@ -0,0 +1,424 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonDist1D is the JSON representation of a Dist1D.
+type jsonDist1D struct {
+	N      int64   `json:"n"`
+	SumW   float64 `json:"sumw"`
+	SumW2  float64 `json:"sumw2"`
+	SumWX  float64 `json:"sumwx"`
+	SumWX2 float64 `json:"sumwx2"`
+}
+
+func newJSONDist1D(d Dist1D) jsonDist1D {
+	return jsonDist1D{
+		N:      d.Dist.N,
+		SumW:   d.Dist.SumW,
+		SumW2:  d.Dist.SumW2,
+		SumWX:  d.Stats.SumWX,
+		SumWX2: d.Stats.SumWX2,
+	}
+}
+
+func (d jsonDist1D) dist() Dist1D {
+	var o Dist1D
+	o.Dist = Dist0D{N: d.N, SumW: d.SumW, SumW2: d.SumW2}
+	o.Stats.SumWX = d.SumWX
+	o.Stats.SumWX2 = d.SumWX2
+	return o
+}
+
+// jsonDist2D is the JSON representation of a Dist2D.
+type jsonDist2D struct {
+	N      int64   `json:"n"`
+	SumW   float64 `json:"sumw"`
+	SumW2  float64 `json:"sumw2"`
+	SumWX  float64 `json:"sumwx"`
+	SumWX2 float64 `json:"sumwx2"`
+	SumWY  float64 `json:"sumwy"`
+	SumWY2 float64 `json:"sumwy2"`
+	SumWXY float64 `json:"sumwxy"`
+}
+
+func newJSONDist2D(d Dist2D) jsonDist2D {
+	return jsonDist2D{
+		N:      d.X.Dist.N,
+		SumW:   d.X.Dist.SumW,
+		SumW2:  d.X.Dist.SumW2,
+		SumWX:  d.X.Stats.SumWX,
+		SumWX2: d.X.Stats.SumWX2,
+		SumWY:  d.Y.Stats.SumWX,
+		SumWY2: d.Y.Stats.SumWX2,
+		SumWXY: d.Stats.SumWXY,
+	}
+}
+
+func (d jsonDist2D) dist() Dist2D {
+	var o Dist2D
+	o.X.Dist = Dist0D{N: d.N, SumW: d.SumW, SumW2: d.SumW2}
+	o.X.Stats.SumWX = d.SumWX
+	o.X.Stats.SumWX2 = d.SumWX2
+	o.Y.Dist = o.X.Dist
+	o.Y.Stats.SumWX = d.SumWY
+	o.Y.Stats.SumWX2 = d.SumWY2
+	o.Stats.SumWXY = d.SumWXY
+	return o
+}
+
+type jsonBin1D struct {
+	XMin float64 `json:"xmin"`
+	XMax float64 `json:"xmax"`
+	jsonDist1D
+}
+
+type jsonBin2D struct {
+	XMin float64 `json:"xmin"`
+	XMax float64 `json:"xmax"`
+	YMin float64 `json:"ymin"`
+	YMax float64 `json:"ymax"`
+	jsonDist2D
+}
+
+type jsonBinP1D struct {
+	XMin float64 `json:"xmin"`
+	XMax float64 `json:"xmax"`
+	jsonDist2D
+}
+
+type jsonH1D struct {
+	Type       string      `json:"type"`
+	Annotation Annotation  `json:"annotation,omitempty"`
+	Dist       jsonDist1D  `json:"dist"`
+	Underflow  jsonDist1D  `json:"underflow"`
+	Overflow   jsonDist1D  `json:"overflow"`
+	Bins       []jsonBin1D `json:"bins"`
+}
+
+type jsonH2D struct {
+	Type       string        `json:"type"`
+	Annotation Annotation    `json:"annotation,omitempty"`
+	Dist       jsonDist2D    `json:"dist"`
+	Outflows   [8]jsonDist2D `json:"outflows"` // outflows, in the BngNW, ..., BngW order.
+	Nx         int           `json:"nx"`
+	Ny         int           `json:"ny"`
+	Bins       []jsonBin2D   `json:"bins"` // bins, with the X index varying fastest.
+}
+
+type jsonP1D struct {
+	Type       string       `json:"type"`
+	Annotation Annotation   `json:"annotation,omitempty"`
+	Dist       jsonDist2D   `json:"dist"`
+	Underflow  jsonDist2D   `json:"underflow"`
+	Overflow   jsonDist2D   `json:"overflow"`
+	Bins       []jsonBinP1D `json:"bins"`
+}
+
+type jsonPoint2D struct {
+	X    float64    `json:"x"`
+	Y    float64    `json:"y"`
+	ErrX [2]float64 `json:"xerr"` // low and high errors on x
+	ErrY [2]float64 `json:"yerr"` // low and high errors on y
+}
+
+type jsonS2D struct {
+	Type       string        `json:"type"`
+	Annotation Annotation    `json:"annotation,omitempty"`
+	Points     []jsonPoint2D `json:"points"`
+	CovY       []float64     `json:"ycov,omitempty"` // row-major covariance matrix of y
+}
+
+// checkJSONType checks the type of a JSON encoded value.
+func checkJSONType(got, want string) error {
+	if got != want {
+		return fmt.Errorf("hbook: invalid JSON type %q (want=%q)", got, want)
+	}
+	return nil
+}
+
+// checkJSONEdges checks the edges of an axis are strictly increasing.
+func checkJSONEdges(edges []float64) error {
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i-1] < edges[i]) {
+			return fmt.Errorf("hbook: invalid JSON bin edges %v (not strictly increasing)", edges)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (h *H1D) MarshalJSON() ([]byte, error) {
+	raw := jsonH1D{
+		Type:       "H1D",
+		Annotation: h.Ann,
+		Dist:       newJSONDist1D(h.Binning.Dist),
+		Underflow:  newJSONDist1D(h.Binning.Outflows[0]),
+		Overflow:   newJSONDist1D(h.Binning.Outflows[1]),
+		Bins:       make([]jsonBin1D, len(h.Binning.Bins)),
+	}
+	for i, bin := range h.Binning.Bins {
+		raw.Bins[i] = jsonBin1D{
+			XMin:       bin.Range.Min,
+			XMax:       bin.Range.Max,
+			jsonDist1D: newJSONDist1D(bin.Dist),
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *H1D) UnmarshalJSON(p []byte) error {
+	var raw jsonH1D
+	err := json.Unmarshal(p, &raw)
+	if err != nil {
+		return err
+	}
+	err = checkJSONType(raw.Type, "H1D")
+	if err != nil {
+		return err
+	}
+	if len(raw.Bins) == 0 {
+		return fmt.Errorf("hbook: invalid H1D-JSON data (no bins)")
+	}
+
+	bins := make([]Bin1D, len(raw.Bins))
+	for i, bin := range raw.Bins {
+		if !(bin.XMin < bin.XMax) || (i > 0 && bin.XMin < raw.Bins[i-1].XMax) {
+			return fmt.Errorf("hbook: invalid H1D-JSON bin %d [%v, %v)", i, bin.XMin, bin.XMax)
+		}
+		bins[i] = Bin1D{
+			Range: Range{Min: bin.XMin, Max: bin.XMax},
+			Dist:  bin.dist(),
+		}
+	}
+
+	h.Binning = Binning1D{
+		Bins: bins,
+		Dist: raw.Dist.dist(),
+		Outflows: [2]Dist1D{
+			raw.Underflow.dist(),
+			raw.Overflow.dist(),
+		},
+		XRange: Range{Min: bins[0].Range.Min, Max: bins[len(bins)-1].Range.Max},
+	}
+	h.Ann = raw.Annotation
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (h *H2D) MarshalJSON() ([]byte, error) {
+	raw := jsonH2D{
+		Type:       "H2D",
+		Annotation: h.Ann,
+		Dist:       newJSONDist2D(h.Binning.Dist),
+		Nx:         h.Binning.Nx,
+		Ny:         h.Binning.Ny,
+		Bins:       make([]jsonBin2D, len(h.Binning.Bins)),
+	}
+	for i, d := range h.Binning.Outflows {
+		raw.Outflows[i] = newJSONDist2D(d)
+	}
+	for i, bin := range h.Binning.Bins {
+		raw.Bins[i] = jsonBin2D{
+			XMin:       bin.XRange.Min,
+			XMax:       bin.XRange.Max,
+			YMin:       bin.YRange.Min,
+			YMax:       bin.YRange.Max,
+			jsonDist2D: newJSONDist2D(bin.Dist),
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *H2D) UnmarshalJSON(p []byte) error {
+	var raw jsonH2D
+	err := json.Unmarshal(p, &raw)
+	if err != nil {
+		return err
+	}
+	err = checkJSONType(raw.Type, "H2D")
+	if err != nil {
+		return err
+	}
+	var (
+		nx = raw.Nx
+		ny = raw.Ny
+	)
+	if nx <= 0 || ny <= 0 || len(raw.Bins) != nx*ny {
+		return fmt.Errorf("hbook: invalid H2D-JSON number of bins (got=%d, want=%dx%d)", len(raw.Bins), nx, ny)
+	}
+
+	xedges := make([]float64, nx+1)
+	for ix := range xedges[:nx] {
+		xedges[ix] = raw.Bins[ix].XMin
+	}
+	xedges[nx] = raw.Bins[nx-1].XMax
+
+	yedges := make([]float64, ny+1)
+	for iy := range yedges[:ny] {
+		yedges[iy] = raw.Bins[iy*nx].YMin
+	}
+	yedges[ny] = raw.Bins[(ny-1)*nx].YMax
+
+	for _, edges := range [][]float64{xedges, yedges} {
+		err = checkJSONEdges(edges)
+		if err != nil {
+			return err
+		}
+	}
+
+	bng := newBinning2DFromEdges(xedges, yedges)
+	bng.Dist = raw.Dist.dist()
+	for i, d := range raw.Outflows {
+		bng.Outflows[i] = d.dist()
+	}
+	for i, bin := range raw.Bins {
+		bng.Bins[i].Dist = bin.dist()
+	}
+
+	h.Binning = bng
+	h.Ann = raw.Annotation
+	if h.Ann == nil {
+		h.Ann = make(Annotation)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p *P1D) MarshalJSON() ([]byte, error) {
+	raw := jsonP1D{
+		Type:       "P1D",
+		Annotation: p.ann,
+		Dist:       newJSONDist2D(p.bng.dist),
+		Underflow:  newJSONDist2D(p.bng.outflows[0]),
+		Overflow:   newJSONDist2D(p.bng.outflows[1]),
+		Bins:       make([]jsonBinP1D, len(p.bng.bins)),
+	}
+	for i, bin := range p.bng.bins {
+		raw.Bins[i] = jsonBinP1D{
+			XMin:       bin.xrange.Min,
+			XMax:       bin.xrange.Max,
+			jsonDist2D: newJSONDist2D(bin.dist),
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *P1D) UnmarshalJSON(data []byte) error {
+	var raw jsonP1D
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	err = checkJSONType(raw.Type, "P1D")
+	if err != nil {
+		return err
+	}
+	n := len(raw.Bins)
+	if n == 0 {
+		return fmt.Errorf("hbook: invalid P1D-JSON data (no bins)")
+	}
+
+	edges := make([]float64, n+1)
+	for i, bin := range raw.Bins {
+		edges[i] = bin.XMin
+	}
+	edges[n] = raw.Bins[n-1].XMax
+	err = checkJSONEdges(edges)
+	if err != nil {
+		return err
+	}
+
+	bng := newBinningP1D(n, edges[0], edges[n])
+	bng.dist = raw.Dist.dist()
+	bng.outflows = [2]Dist2D{
+		raw.Underflow.dist(),
+		raw.Overflow.dist(),
+	}
+	for i, bin := range raw.Bins {
+		bng.bins[i].xrange = Range{Min: bin.XMin, Max: bin.XMax}
+		bng.bins[i].dist = bin.dist()
+	}
+
+	p.bng = bng
+	p.ann = raw.Annotation
+	if p.ann == nil {
+		p.ann = make(Annotation)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *S2D) MarshalJSON() ([]byte, error) {
+	raw := jsonS2D{
+		Type:       "S2D",
+		Annotation: s.ann,
+		Points:     make([]jsonPoint2D, len(s.pts)),
+		CovY:       s.cov,
+	}
+	for i, pt := range s.pts {
+		raw.Points[i] = jsonPoint2D{
+			X:    pt.X,
+			Y:    pt.Y,
+			ErrX: [2]float64{pt.ErrX.Min, pt.ErrX.Max},
+			ErrY: [2]float64{pt.ErrY.Min, pt.ErrY.Max},
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *S2D) UnmarshalJSON(p []byte) error {
+	var raw jsonS2D
+	err := json.Unmarshal(p, &raw)
+	if err != nil {
+		return err
+	}
+	err = checkJSONType(raw.Type, "S2D")
+	if err != nil {
+		return err
+	}
+
+	if n := len(raw.Points); len(raw.CovY) != 0 && len(raw.CovY) != n*n {
+		return fmt.Errorf("hbook: invalid S2D-JSON covariance matrix size (got=%d, want=%dx%d)", len(raw.CovY), n, n)
+	}
+
+	s.pts = make([]Point2D, len(raw.Points))
+	s.cov = symMatrix(raw.CovY)
+	for i, pt := range raw.Points {
+		s.pts[i] = Point2D{
+			X:    pt.X,
+			Y:    pt.Y,
+			ErrX: Range{Min: pt.ErrX[0], Max: pt.ErrX[1]},
+			ErrY: Range{Min: pt.ErrY[0], Max: pt.ErrY[1]},
+		}
+	}
+	s.ann = raw.Annotation
+	if s.ann == nil {
+		s.ann = make(Annotation)
+	}
+	return nil
+}
+
+var (
+	_ json.Marshaler   = (*H1D)(nil)
+	_ json.Unmarshaler = (*H1D)(nil)
+	_ json.Marshaler   = (*H2D)(nil)
+	_ json.Unmarshaler = (*H2D)(nil)
+	_ json.Marshaler   = (*P1D)(nil)
+	_ json.Unmarshaler = (*P1D)(nil)
+	_ json.Marshaler   = (*S2D)(nil)
+	_ json.Unmarshaler = (*S2D)(nil)
+)
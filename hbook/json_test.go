@@ -0,0 +1,203 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestH1DJSON(t *testing.T) {
+	for _, h := range []*H1D{
+		func() *H1D {
+			h := NewH1D(10, -4, 4)
+			h.Annotation()["name"] = "h1"
+			h.Annotation()["title"] = "my title"
+			h.Fill(1, 1)
+			h.Fill(2, 1)
+			h.Fill(-3, 1)
+			h.Fill(-4, 1)
+			h.Fill(0, 2)
+			h.Fill(10, 1)
+			h.Fill(-10, 1)
+			return h
+		}(),
+		func() *H1D {
+			h := NewH1DFromBins([]Range{
+				{Min: 0, Max: 1},
+				{Min: 3, Max: 4},
+			}...)
+			h.Fill(0.5, 1)
+			h.Fill(2, 1)
+			h.Fill(3.5, 2)
+			return h
+		}(),
+	} {
+		raw, err := json.Marshal(h)
+		if err != nil {
+			t.Fatalf("could not marshal H1D: %+v", err)
+		}
+
+		var got H1D
+		err = json.Unmarshal(raw, &got)
+		if err != nil {
+			t.Fatalf("could not unmarshal H1D: %+v", err)
+		}
+
+		if !reflect.DeepEqual(got.Binning, h.Binning) {
+			t.Fatalf("invalid binning:\ngot= %+v\nwant=%+v", got.Binning, h.Binning)
+		}
+		if !reflect.DeepEqual(got.Ann, h.Ann) {
+			t.Fatalf("invalid annotation:\ngot= %v\nwant=%v", got.Ann, h.Ann)
+		}
+	}
+}
+
+func TestH2DJSON(t *testing.T) {
+	h := NewH2DFromEdges([]float64{-1, 0, 0.5, 1}, []float64{-2, 0, 2})
+	h.Annotation()["name"] = "h2"
+	h.Fill(+0.5, +1, 1)
+	h.Fill(-0.5, +1, 1)
+	h.Fill(+0.0, -1, 1)
+	h.Fill(-2.0, +3, 2) // N-W
+	h.Fill(+2.0, +0, 3) // E
+	h.Fill(+0.7, -3, 4) // S
+
+	raw, err := json.Marshal(h)
+	if err != nil {
+		t.Fatalf("could not marshal H2D: %+v", err)
+	}
+
+	var got H2D
+	err = json.Unmarshal(raw, &got)
+	if err != nil {
+		t.Fatalf("could not unmarshal H2D: %+v", err)
+	}
+
+	if !reflect.DeepEqual(got.Binning, h.Binning) {
+		t.Fatalf("invalid binning:\ngot= %+v\nwant=%+v", got.Binning, h.Binning)
+	}
+	if !reflect.DeepEqual(got.Ann, h.Ann) {
+		t.Fatalf("invalid annotation:\ngot= %v\nwant=%v", got.Ann, h.Ann)
+	}
+}
+
+func TestP1DJSON(t *testing.T) {
+	p := NewP1D(10, -4, +4)
+	p.Annotation()["name"] = "p1"
+	for i := 0; i < 10; i++ {
+		v := float64(i)
+		p.Fill(v, v*2, 1)
+	}
+	p.Fill(-10, 10, 1)
+
+	raw, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("could not marshal P1D: %+v", err)
+	}
+
+	var got P1D
+	err = json.Unmarshal(raw, &got)
+	if err != nil {
+		t.Fatalf("could not unmarshal P1D: %+v", err)
+	}
+
+	if !reflect.DeepEqual(got.bng, p.bng) {
+		t.Fatalf("invalid binning:\ngot= %+v\nwant=%+v", got.bng, p.bng)
+	}
+	if !reflect.DeepEqual(got.ann, p.ann) {
+		t.Fatalf("invalid annotation:\ngot= %v\nwant=%v", got.ann, p.ann)
+	}
+}
+
+func TestS2DJSON(t *testing.T) {
+	s := NewS2D(
+		Point2D{X: 1, Y: 2, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 1, Max: 2}},
+		Point2D{X: 2, Y: 4, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 2, Max: 3}},
+	)
+	s.Annotation()["name"] = "s2"
+
+	raw, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("could not marshal S2D: %+v", err)
+	}
+
+	const want = `{"type":"S2D","annotation":{"name":"s2"},"points":[` +
+		`{"x":1,"y":2,"xerr":[0.5,0.5],"yerr":[1,2]},` +
+		`{"x":2,"y":4,"xerr":[0.5,0.5],"yerr":[2,3]}]}`
+	if got := string(raw); got != want {
+		t.Fatalf("invalid JSON:\ngot= %s\nwant=%s", got, want)
+	}
+
+	var got S2D
+	err = json.Unmarshal(raw, &got)
+	if err != nil {
+		t.Fatalf("could not unmarshal S2D: %+v", err)
+	}
+
+	if !reflect.DeepEqual(got.pts, s.pts) {
+		t.Fatalf("invalid points:\ngot= %+v\nwant=%+v", got.pts, s.pts)
+	}
+	if !reflect.DeepEqual(got.ann, s.ann) {
+		t.Fatalf("invalid annotation:\ngot= %v\nwant=%v", got.ann, s.ann)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		v    json.Unmarshaler
+		raw  string
+		err  string
+	}{
+		{
+			name: "h1d-type",
+			v:    new(H1D),
+			raw:  `{"type":"H2D"}`,
+			err:  `hbook: invalid JSON type "H2D" (want="H1D")`,
+		},
+		{
+			name: "h1d-no-bins",
+			v:    new(H1D),
+			raw:  `{"type":"H1D"}`,
+			err:  "hbook: invalid H1D-JSON data (no bins)",
+		},
+		{
+			name: "h1d-overlap",
+			v:    new(H1D),
+			raw:  `{"type":"H1D","bins":[{"xmin":0,"xmax":2},{"xmin":1,"xmax":3}]}`,
+			err:  "hbook: invalid H1D-JSON bin 1 [1, 3)",
+		},
+		{
+			name: "h2d-bins",
+			v:    new(H2D),
+			raw:  `{"type":"H2D","nx":2,"ny":2,"bins":[{"xmin":0,"xmax":1,"ymin":0,"ymax":1}]}`,
+			err:  "hbook: invalid H2D-JSON number of bins (got=1, want=2x2)",
+		},
+		{
+			name: "p1d-edges",
+			v:    new(P1D),
+			raw:  `{"type":"P1D","bins":[{"xmin":1,"xmax":0}]}`,
+			err:  "hbook: invalid JSON bin edges [1 0] (not strictly increasing)",
+		},
+		{
+			name: "s2d-type",
+			v:    new(S2D),
+			raw:  `{"type":"P1D"}`,
+			err:  `hbook: invalid JSON type "P1D" (want="S2D")`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.v.UnmarshalJSON([]byte(tc.raw))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,492 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ntup
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// AggrFunc is an aggregation function over the rows of an n-tuple.
+type AggrFunc int
+
+// List of aggregation functions.
+const (
+	AggrCount AggrFunc = iota // number of rows
+	AggrSum                   // sum of values
+	AggrMean                  // arithmetic mean of values
+	AggrMin                   // minimum value
+	AggrMax                   // maximum value
+)
+
+func (f AggrFunc) String() string {
+	switch f {
+	case AggrCount:
+		return "count"
+	case AggrSum:
+		return "sum"
+	case AggrMean:
+		return "mean"
+	case AggrMin:
+		return "min"
+	case AggrMax:
+		return "max"
+	}
+	return fmt.Sprintf("AggrFunc(%d)", int(f))
+}
+
+// Aggr describes the aggregation of an expression over the rows of an n-tuple.
+type Aggr struct {
+	Func AggrFunc
+	Expr string // expression to aggregate (e.g. "x" or "x*y")
+}
+
+// Count returns the aggregation counting the rows where expr is not NULL.
+// If expr is "", all rows are counted.
+func Count(expr string) Aggr { return Aggr{Func: AggrCount, Expr: expr} }
+
+// Sum returns the aggregation summing the values of expr.
+func Sum(expr string) Aggr { return Aggr{Func: AggrSum, Expr: expr} }
+
+// Mean returns the aggregation computing the mean of the values of expr.
+func Mean(expr string) Aggr { return Aggr{Func: AggrMean, Expr: expr} }
+
+// Min returns the aggregation computing the minimum of the values of expr.
+func Min(expr string) Aggr { return Aggr{Func: AggrMin, Expr: expr} }
+
+// Max returns the aggregation computing the maximum of the values of expr.
+func Max(expr string) Aggr { return Aggr{Func: AggrMax, Expr: expr} }
+
+// AggrRow is a row of an aggregation table.
+type AggrRow struct {
+	Keys   []interface{} // values of the group-by expressions
+	Values []float64     // values of the aggregations
+}
+
+// Aggregate groups the rows of the n-tuple by the comma-separated list of
+// expressions groupBy and computes the aggregations aggrs for each group.
+// Only the rows satisfying the where clause are considered. An empty
+// where clause selects all rows and an empty groupBy list puts all rows
+// in a single group.
+//
+// The returned rows are sorted by increasing values of the group-by keys.
+// The aggregations of a group with no values are NaN, except for
+// AggrCount and AggrSum which are zero.
+//
+// Aggregate first tries to push the aggregation down to the underlying
+// database and falls back to scanning the selected rows when the database
+// does not support it.
+//
+// e.g.
+//
+//	rows, err := nt.Aggregate("run", "x>10", ntup.Count(""), ntup.Mean("x"))
+func (nt *Ntuple) Aggregate(groupBy, where string, aggrs ...Aggr) ([]AggrRow, error) {
+	if len(aggrs) == 0 {
+		return nil, fmt.Errorf("hbook/ntup: expected at least one aggregation")
+	}
+	for _, aggr := range aggrs {
+		switch aggr.Func {
+		case AggrCount, AggrSum, AggrMean, AggrMin, AggrMax:
+		default:
+			return nil, fmt.Errorf("hbook/ntup: invalid aggregation function %v", aggr.Func)
+		}
+		if aggr.Expr == "" && aggr.Func != AggrCount {
+			return nil, fmt.Errorf("hbook/ntup: missing expression for %v aggregation", aggr.Func)
+		}
+	}
+
+	var keys []string
+	if groupBy = strings.TrimSpace(groupBy); groupBy != "" {
+		keys = strings.Split(groupBy, ",")
+		for i, k := range keys {
+			keys[i] = strings.TrimSpace(k)
+		}
+	}
+
+	rows, err := nt.aggregateDB(keys, where, aggrs)
+	if err == nil {
+		return rows, nil
+	}
+
+	rows, err = nt.aggregateScan(keys, where, aggrs)
+	if err != nil {
+		return nil, fmt.Errorf("hbook/ntup: could not aggregate n-tuple: %w", err)
+	}
+	return rows, nil
+}
+
+// aggregateDB runs the aggregation inside the underlying database.
+func (nt *Ntuple) aggregateDB(keys []string, where string, aggrs []Aggr) ([]AggrRow, error) {
+	var (
+		exprs = append(aggrExprs(nil), keys...)
+		cols  = make([]int, len(aggrs)) // column of each aggregation
+		ns    = make([]int, len(aggrs)) // column of the count of each mean aggregation
+	)
+	for i, aggr := range aggrs {
+		switch aggr.Func {
+		case AggrCount:
+			expr := aggr.Expr
+			if expr == "" {
+				expr = "*"
+			}
+			cols[i] = exprs.add("count(" + expr + ")")
+		case AggrSum:
+			cols[i] = exprs.add("sum(" + aggr.Expr + ")")
+		case AggrMean:
+			// avg() truncates on integer columns with some databases.
+			cols[i] = exprs.add("sum(" + aggr.Expr + ")")
+			ns[i] = exprs.add("count(" + aggr.Expr + ")")
+		case AggrMin:
+			cols[i] = exprs.add("min(" + aggr.Expr + ")")
+		case AggrMax:
+			cols[i] = exprs.add("max(" + aggr.Expr + ")")
+		}
+	}
+
+	// FIXME(sbinet) this is vulnerable to SQL injections...
+	query := "select " + strings.Join(exprs, ", ") + " from " + nt.name
+	if where != "" {
+		query += " where " + where
+	}
+	if len(keys) > 0 {
+		query += " group by " + strings.Join(keys, ", ")
+	}
+
+	rs, err := nt.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	var (
+		out  []AggrRow
+		vals = make([]interface{}, len(exprs))
+		args = make([]interface{}, len(exprs))
+	)
+	for i := range vals {
+		args[i] = &vals[i]
+	}
+
+	for rs.Next() {
+		err = rs.Scan(args...)
+		if err != nil {
+			return nil, err
+		}
+		row := AggrRow{
+			Keys:   aggrKeys(vals[:len(keys)]),
+			Values: make([]float64, len(aggrs)),
+		}
+		for i, aggr := range aggrs {
+			v, err := aggrValue(vals[cols[i]])
+			if err != nil {
+				return nil, err
+			}
+			switch aggr.Func {
+			case AggrCount, AggrSum:
+				if math.IsNaN(v) {
+					v = 0
+				}
+			case AggrMean:
+				n, err := aggrValue(vals[ns[i]])
+				if err != nil {
+					return nil, err
+				}
+				switch {
+				case n > 0:
+					v /= n
+				default:
+					v = math.NaN()
+				}
+			}
+			row.Values[i] = v
+		}
+		out = append(out, row)
+	}
+
+	err = rs.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	sortAggrRows(out)
+	return out, nil
+}
+
+// aggregateScan runs the aggregation by scanning the selected rows of
+// the n-tuple.
+func (nt *Ntuple) aggregateScan(keys []string, where string, aggrs []Aggr) ([]AggrRow, error) {
+	var (
+		exprs = append(aggrExprs(nil), keys...)
+		cols  = make([]int, len(aggrs)) // column of each aggregation, -1 to count all rows
+	)
+	for i, aggr := range aggrs {
+		cols[i] = -1
+		if aggr.Expr != "" {
+			cols[i] = exprs.add(aggr.Expr)
+		}
+	}
+
+	rs, err := nt.queryRows(exprs, where)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	type group struct {
+		row AggrRow
+		ns  []float64
+	}
+
+	names, err := rs.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		groups = make(map[string]*group)
+		vals   = make([]interface{}, len(names))
+		args   = make([]interface{}, len(names))
+	)
+	for i := range vals {
+		args[i] = &vals[i]
+	}
+
+	newGroup := func(keys []interface{}) *group {
+		grp := &group{
+			row: AggrRow{
+				Keys:   keys,
+				Values: make([]float64, len(aggrs)),
+			},
+			ns: make([]float64, len(aggrs)),
+		}
+		for i, aggr := range aggrs {
+			switch aggr.Func {
+			case AggrMin:
+				grp.row.Values[i] = math.Inf(+1)
+			case AggrMax:
+				grp.row.Values[i] = math.Inf(-1)
+			}
+		}
+		return grp
+	}
+
+	for rs.Next() {
+		err = rs.Scan(args...)
+		if err != nil {
+			return nil, err
+		}
+
+		id := fmt.Sprintf("%#v", vals[:len(keys)])
+		grp, ok := groups[id]
+		if !ok {
+			grp = newGroup(aggrKeys(vals[:len(keys)]))
+			groups[id] = grp
+		}
+
+		for i, aggr := range aggrs {
+			if cols[i] < 0 {
+				grp.ns[i]++
+				continue
+			}
+			raw := vals[cols[i]]
+			if raw == nil {
+				continue
+			}
+			grp.ns[i]++
+			if aggr.Func == AggrCount {
+				continue
+			}
+			v, err := aggrValue(raw)
+			if err != nil {
+				return nil, err
+			}
+			switch aggr.Func {
+			case AggrSum, AggrMean:
+				grp.row.Values[i] += v
+			case AggrMin:
+				grp.row.Values[i] = math.Min(grp.row.Values[i], v)
+			case AggrMax:
+				grp.row.Values[i] = math.Max(grp.row.Values[i], v)
+			}
+		}
+	}
+
+	err = rs.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 && len(groups) == 0 {
+		groups[""] = newGroup(nil)
+	}
+
+	out := make([]AggrRow, 0, len(groups))
+	for _, grp := range groups {
+		for i, aggr := range aggrs {
+			switch aggr.Func {
+			case AggrCount:
+				grp.row.Values[i] = grp.ns[i]
+			case AggrMean:
+				grp.row.Values[i] /= grp.ns[i]
+			case AggrMin, AggrMax:
+				if grp.ns[i] == 0 {
+					grp.row.Values[i] = math.NaN()
+				}
+			}
+		}
+		out = append(out, grp.row)
+	}
+
+	sortAggrRows(out)
+	return out, nil
+}
+
+// queryRows selects the expressions exprs from the rows of the n-tuple
+// satisfying the where clause.
+// All the columns are selected when exprs is empty.
+func (nt *Ntuple) queryRows(exprs []string, where string) (*sql.Rows, error) {
+	sels := []string{strings.Join(exprs, ", ")}
+	switch len(exprs) {
+	case 0:
+		sels[0] = "*"
+	case 1:
+	default:
+		// some databases (e.g. ROOT/SQL) expect a tuple of expressions.
+		sels = append(sels, "("+sels[0]+")")
+	}
+
+	var err error
+	for _, sel := range sels {
+		// FIXME(sbinet) this is vulnerable to SQL injections...
+		query := "select " + sel + " from " + nt.name
+		if where != "" {
+			query += " where " + where
+		}
+
+		var rs *sql.Rows
+		rs, err = nt.db.Query(query)
+		if err != nil {
+			continue
+		}
+
+		var names []string
+		names, err = rs.Columns()
+		if err == nil && len(exprs) > 0 && len(names) != len(exprs) {
+			err = fmt.Errorf("hbook/ntup: invalid number of columns (got=%d, want=%d)", len(names), len(exprs))
+		}
+		if err != nil {
+			_ = rs.Close()
+			continue
+		}
+		return rs, nil
+	}
+	return nil, err
+}
+
+// aggrExprs is a list of selected expressions, without duplicates.
+type aggrExprs []string
+
+// add adds expr to the list if needed and returns its index.
+func (exprs *aggrExprs) add(expr string) int {
+	for i, v := range *exprs {
+		if v == expr {
+			return i
+		}
+	}
+	*exprs = append(*exprs, expr)
+	return len(*exprs) - 1
+}
+
+// aggrKeys returns a copy of the group-by keys, with raw bytes converted
+// to strings.
+func aggrKeys(vs []interface{}) []interface{} {
+	if len(vs) == 0 {
+		return nil
+	}
+	keys := make([]interface{}, len(vs))
+	for i, v := range vs {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		keys[i] = v
+	}
+	return keys
+}
+
+// aggrValue converts the result of an aggregation to a float64.
+// NULL values are converted to NaN.
+func aggrValue(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case nil:
+		return math.NaN(), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("hbook/ntup: invalid aggregation value type %T", v)
+}
+
+// sortAggrRows sorts the rows by increasing values of their keys.
+func sortAggrRows(rows []AggrRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		ki := rows[i].Keys
+		kj := rows[j].Keys
+		for k := range ki {
+			if c := cmpAggrKey(ki[k], kj[k]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+func cmpAggrKey(a, b interface{}) int {
+	if sa, ok := a.(string); ok {
+		if sb, ok := b.(string); ok {
+			return strings.Compare(sa, sb)
+		}
+	}
+	fa, erra := aggrValue(a)
+	fb, errb := aggrValue(b)
+	if erra == nil && errb == nil {
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return +1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
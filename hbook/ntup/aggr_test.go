@@ -0,0 +1,185 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ntup
+
+import (
+	"database/sql"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	db, err := sql.Open("ql", "memory://aggr.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tx.Exec("create table data (run int, name string, x float64);")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range []struct {
+		run  int
+		name string
+		x    float64
+	}{
+		{2, "b", 1},
+		{1, "a", 2},
+		{2, "a", 3},
+		{1, "a", 4},
+		{3, "c", 5},
+		{2, "b", 6},
+	} {
+		_, err = tx.Exec("insert into data values($1, $2, $3);", row.run, row.name, row.x)
+		if err != nil {
+			t.Fatalf("could not insert row %d: %+v", i, err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nt, err := Open(db, "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nan := math.NaN()
+	for _, tc := range []struct {
+		name    string
+		groupBy string
+		where   string
+		aggrs   []Aggr
+		want    []AggrRow
+	}{
+		{
+			name:  "no-group",
+			aggrs: []Aggr{Count(""), Sum("x"), Mean("x"), Min("x"), Max("x")},
+			want: []AggrRow{
+				{Values: []float64{6, 21, 3.5, 1, 6}},
+			},
+		},
+		{
+			name:    "group-run",
+			groupBy: "run",
+			aggrs:   []Aggr{Count(""), Sum("x"), Mean("x"), Min("x"), Max("x")},
+			want: []AggrRow{
+				{Keys: []interface{}{int64(1)}, Values: []float64{2, 6, 3, 2, 4}},
+				{Keys: []interface{}{int64(2)}, Values: []float64{3, 10, 10.0 / 3, 1, 6}},
+				{Keys: []interface{}{int64(3)}, Values: []float64{1, 5, 5, 5, 5}},
+			},
+		},
+		{
+			name:    "group-run-name-where",
+			groupBy: "run, name",
+			where:   "x > 1",
+			aggrs:   []Aggr{Count("x"), Mean("run")},
+			want: []AggrRow{
+				{Keys: []interface{}{int64(1), "a"}, Values: []float64{2, 1}},
+				{Keys: []interface{}{int64(2), "a"}, Values: []float64{1, 2}},
+				{Keys: []interface{}{int64(2), "b"}, Values: []float64{1, 2}},
+				{Keys: []interface{}{int64(3), "c"}, Values: []float64{1, 3}},
+			},
+		},
+		{
+			name:  "empty",
+			where: "x > 10",
+			aggrs: []Aggr{Count(""), Sum("x"), Mean("x"), Min("x"), Max("x")},
+			want: []AggrRow{
+				{Values: []float64{0, 0, nan, nan, nan}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := nt.Aggregate(tc.groupBy, tc.where, tc.aggrs...)
+			if err != nil {
+				t.Fatalf("could not aggregate: %+v", err)
+			}
+			if !eqAggrRows(got, tc.want) {
+				t.Fatalf("invalid aggregation:\ngot= %v\nwant=%v", got, tc.want)
+			}
+
+			// check the fallback, scan-based, aggregation.
+			var keys []string
+			switch tc.groupBy {
+			case "":
+			case "run":
+				keys = []string{"run"}
+			default:
+				keys = []string{"run", "name"}
+			}
+			got, err = nt.aggregateScan(keys, tc.where, tc.aggrs)
+			if err != nil {
+				t.Fatalf("could not aggregate by scanning: %+v", err)
+			}
+			if !eqAggrRows(got, tc.want) {
+				t.Fatalf("invalid scan aggregation:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAggregateInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		aggrs []Aggr
+		err   string
+	}{
+		{
+			aggrs: nil,
+			err:   "hbook/ntup: expected at least one aggregation",
+		},
+		{
+			aggrs: []Aggr{{Func: AggrFunc(42), Expr: "x"}},
+			err:   "hbook/ntup: invalid aggregation function AggrFunc(42)",
+		},
+		{
+			aggrs: []Aggr{Sum("")},
+			err:   "hbook/ntup: missing expression for sum aggregation",
+		},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			_, err := nt.Aggregate("", "", tc.aggrs...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func eqAggrRows(a, b []AggrRow) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i].Keys) != len(b[i].Keys) {
+			return false
+		}
+		if len(a[i].Keys) > 0 && !reflect.DeepEqual(a[i].Keys, b[i].Keys) {
+			return false
+		}
+		if len(a[i].Values) != len(b[i].Values) {
+			return false
+		}
+		for j, va := range a[i].Values {
+			vb := b[i].Values[j]
+			switch {
+			case math.IsNaN(va) && math.IsNaN(vb):
+			case math.Abs(va-vb) > 1e-12:
+				return false
+			}
+		}
+	}
+	return true
+}
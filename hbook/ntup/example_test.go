@@ -151,3 +151,38 @@ func ExampleNtuple_scanH1D() {
 	// V1StdDev:    3.027650
 	// V1StdErr:    0.957427
 }
+
+func ExampleNtuple_aggregate() {
+	nt, err := ntcsv.Open(
+		"ntcsv/testdata/simple-with-header.csv",
+		ntcsv.Comma(';'),
+		ntcsv.Header(),
+		ntcsv.Columns("v1", "v2", "v3"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		err = nt.DB().Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	rows, err := nt.Aggregate(
+		"", "v1 >= 5",
+		ntup.Count(""), ntup.Sum("v2"), ntup.Mean("v2"), ntup.Min("v2"), ntup.Max("v2"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, row := range rows {
+		fmt.Printf("count=%v sum=%v mean=%v min=%v max=%v\n",
+			row.Values[0], row.Values[1], row.Values[2], row.Values[3], row.Values[4],
+		)
+	}
+
+	// Output:
+	// count=5 sum=35 mean=7 min=5 max=9
+}
@@ -31,9 +31,22 @@
 // Override the names from the CSV header with our own:
 //
 //	nt, err := ntcsv.Open("testdata/simple-with-header.csv", ntcsv.Header(), ntcsv.Columns("v1", "v2", "v3")
+//
+// The types of the columns (int64, float64, bool or string) are inferred
+// from the data. Empty fields are missing values, which are by default
+// replaced with the zero value of their column:
+//
+//	nt, err := ntcsv.Open("testdata/data.csv", ntcsv.NA("NA", "n/a"), ntcsv.Missing(ntcsv.MissingSkip))
+//
+// Large CSV files can be read in chunks of a bounded number of rows:
+//
+//	err := ntcsv.ReadChunks("testdata/large.csv", 10000, func(nt *ntup.Ntuple) error {
+//	    return nt.Scan("var1", func(v float64) error { ... })
+//	})
 package ntcsv // import "go-hep.org/x/hep/hbook/ntup/ntcsv"
 
 import (
+	"database/sql"
 	"fmt"
 
 	"go-hep.org/x/hep/csvutil/csvdriver"
@@ -62,6 +75,36 @@ func Open(name string, opts ...Option) (*ntup.Ntuple, error) {
 	return nt, nil
 }
 
+// ReadChunks reads a CSV file in chunks of at most n rows, and calls f with
+// an n-tuple connected to each chunk.
+//
+// Only one chunk is held in memory at any time: the n-tuple passed to f
+// must not be used after f returns.
+// The types of the columns are inferred once, before reading the chunks.
+func ReadChunks(name string, n int, f func(nt *ntup.Ntuple) error, opts ...Option) error {
+	c := csvdriver.Conn{File: name}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c.Chunks(n, func(db *sql.DB) error {
+		nt, err := ntup.Open(db, "csv")
+		if err != nil {
+			return fmt.Errorf("could not open n-tuple: %w", err)
+		}
+		return f(nt)
+	})
+}
+
+// MissingPolicy describes how rows with missing values are handled.
+type MissingPolicy = csvdriver.MissingPolicy
+
+const (
+	MissingZero  = csvdriver.MissingZero  // missing values are replaced with the zero value of their column
+	MissingSkip  = csvdriver.MissingSkip  // rows with missing values are skipped
+	MissingError = csvdriver.MissingError // rows with missing values are rejected with an error
+)
+
 // Option configures the underlying sql.DB connection to the n-tuple.
 type Option func(c *csvdriver.Conn)
 
@@ -97,3 +140,27 @@ func Columns(names ...string) Option {
 		copy(c.Names, names)
 	}
 }
+
+// Infer configures the n-tuple to infer the types of the columns from the
+// first n rows of data.
+// All the rows are used if n is not positive (the default.)
+func Infer(n int) Option {
+	return func(c *csvdriver.Conn) {
+		c.Infer = n
+	}
+}
+
+// NA configures the n-tuple to consider the given values, in addition to
+// empty fields, as missing values.
+func NA(values ...string) Option {
+	return func(c *csvdriver.Conn) {
+		c.NA = append([]string(nil), values...)
+	}
+}
+
+// Missing configures how the n-tuple handles rows with missing values.
+func Missing(policy MissingPolicy) Option {
+	return func(c *csvdriver.Conn) {
+		c.Missing = policy
+	}
+}
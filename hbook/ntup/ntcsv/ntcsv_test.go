@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"testing"
 
+	"go-hep.org/x/hep/hbook/ntup"
 	"go-hep.org/x/hep/hbook/ntup/ntcsv"
 )
 
@@ -139,3 +140,97 @@ func testCSV(t *testing.T, name, query string, opts ...ntcsv.Option) {
 		t.Fatalf("%s: got=\n%v\nwant=\n%v\n", name, got, want)
 	}
 }
+
+func TestMissing(t *testing.T) {
+	type dataType struct {
+		i int64
+		f float64
+		b bool
+		s string
+	}
+
+	for _, tc := range []struct {
+		policy ntcsv.MissingPolicy
+		want   []dataType
+		err    bool
+	}{
+		{
+			policy: ntcsv.MissingZero,
+			want: []dataType{
+				{0, 0.5, true, "a"},
+				{1, 0, false, "b"},
+				{0, 2, true, ""},
+				{3, 3.5, false, "d"},
+			},
+		},
+		{
+			policy: ntcsv.MissingSkip,
+			want: []dataType{
+				{0, 0.5, true, "a"},
+				{3, 3.5, false, "d"},
+			},
+		},
+		{
+			policy: ntcsv.MissingError,
+			err:    true,
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			nt, err := ntcsv.Open(
+				"testdata/missing.csv",
+				ntcsv.Header(), ntcsv.Comma(';'),
+				ntcsv.NA("NA"), ntcsv.Missing(tc.policy),
+			)
+			if err == nil {
+				defer nt.DB().Close()
+			}
+
+			var got []dataType
+			if err == nil {
+				err = nt.Scan("i, f, b, str", func(i int64, f float64, b bool, s string) error {
+					got = append(got, dataType{i, f, b, s})
+					return nil
+				})
+			}
+			switch {
+			case err != nil && !tc.err:
+				t.Fatalf("could not read n-tuple: %+v", err)
+			case err == nil && tc.err:
+				t.Fatalf("expected an error")
+			case tc.err:
+				return
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid data:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadChunks(t *testing.T) {
+	var (
+		sizes []int
+		got   []int64
+	)
+	err := ntcsv.ReadChunks("testdata/simple.csv", 3, func(nt *ntup.Ntuple) error {
+		n := 0
+		err := nt.Scan("var1", func(i int64) error {
+			got = append(got, i)
+			n++
+			return nil
+		})
+		sizes = append(sizes, n)
+		return err
+	}, ntcsv.Comma(';'))
+	if err != nil {
+		t.Fatalf("could not read chunks: %+v", err)
+	}
+
+	if want := []int{3, 3, 3, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Fatalf("invalid chunk sizes: got=%v, want=%v", sizes, want)
+	}
+	if want := []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid data: got=%v, want=%v", got, want)
+	}
+}
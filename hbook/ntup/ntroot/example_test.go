@@ -7,6 +7,7 @@ package ntroot_test
 import (
 	"fmt"
 	"log"
+	"os"
 
 	"go-hep.org/x/hep/hbook/ntup/ntroot"
 )
@@ -41,3 +42,50 @@ func ExampleOpen() {
 	// row=(3, 3.3, "tres")
 	// row=(4, 4.4, "quatro")
 }
+
+func ExampleCreate() {
+	const fname = "../../../groot/testdata/ntroot-create.root"
+	defer os.Remove(fname)
+
+	type Row struct {
+		N int32   `hbook:"n"`
+		X float64 `hbook:"x"`
+	}
+
+	w, err := ntroot.Create(fname, "tree", Row{})
+	if err != nil {
+		log.Fatalf("could not create n-tuple: %+v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 3; i++ {
+		err = w.Write(Row{N: int32(i), X: float64(i) * 1.5})
+		if err != nil {
+			log.Fatalf("could not write row: %+v", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		log.Fatalf("could not close n-tuple: %+v", err)
+	}
+
+	nt, err := ntroot.Open(fname, "tree")
+	if err != nil {
+		log.Fatalf("could not open n-tuple: %+v", err)
+	}
+	defer nt.DB().Close()
+
+	err = nt.Scan("(n, x)", func(n int32, x float64) error {
+		fmt.Printf("row=(%v, %v)\n", n, x)
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("could not scan n-tuple: %+v", err)
+	}
+
+	// Output:
+	// row=(0, 0)
+	// row=(1, 1.5)
+	// row=(2, 3)
+}
@@ -12,10 +12,23 @@
 //	    log.Fatalf("%+v", err)
 //	}
 //	defer nt.DB().Close()
+//
+// Writing n-tuple data into a ROOT tree:
+//
+//	w, err := ntroot.Create("out.root", "mytree", struct {
+//	    X float64 `hbook:"x"`
+//	    N int64   `hbook:"n"`
+//	}{})
+//	if err != nil {
+//	    log.Fatalf("%+v", err)
+//	}
+//	defer w.Close()
 package ntroot // import "go-hep.org/x/hep/hbook/ntup/ntroot"
 
 import (
 	"fmt"
+	"go/ast"
+	"reflect"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/riofs"
@@ -53,3 +66,138 @@ func Open(name, tree string) (*ntup.Ntuple, error) {
 	}
 	return nt, nil
 }
+
+// Writer writes n-tuple data into a ROOT tree.
+type Writer struct {
+	f    *groot.File
+	t    rtree.Writer
+	cols []ntup.Descriptor
+	vals []reflect.Value // values of the tree branches
+
+	rtyp   reflect.Type // type of the n-tuple row, for struct-based n-tuples
+	fields []int        // indices of the exported fields of rtyp
+}
+
+// Create creates the named ROOT file and returns a writer of n-tuple data
+// into a new ROOT tree with the given name.
+// The n-tuple schema is inferred from the cols argument, as for ntup.Create.
+//
+// Writers created from a struct value are filled with values of that struct
+// type; otherwise, they are filled with one value per column.
+func Create(name, tree string, cols ...interface{}) (*Writer, error) {
+	nt, err := ntup.Create(nil, tree, cols...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create n-tuple %q: %w", tree, err)
+	}
+
+	w := &Writer{
+		cols: nt.Cols(),
+		vals: make([]reflect.Value, len(nt.Cols())),
+	}
+	if len(cols) == 1 {
+		if rt := reflect.Indirect(reflect.ValueOf(cols[0])).Type(); rt.Kind() == reflect.Struct {
+			w.rtyp = rt
+			for i := 0; i < rt.NumField(); i++ {
+				if ast.IsExported(rt.Field(i).Name) {
+					w.fields = append(w.fields, i)
+				}
+			}
+		}
+	}
+
+	wvars := make([]rtree.WriteVar, len(w.cols))
+	for i, col := range w.cols {
+		ptr := reflect.New(col.Type())
+		w.vals[i] = ptr.Elem()
+		wvars[i] = rtree.WriteVar{Name: col.Name(), Value: ptr.Interface()}
+	}
+
+	w.f, err = groot.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("could not create ROOT file: %w", err)
+	}
+
+	w.t, err = rtree.NewWriter(w.f, tree, wvars)
+	if err != nil {
+		_ = w.f.Close()
+		return nil, fmt.Errorf("could not create ROOT tree %q: %w", tree, err)
+	}
+
+	return w, nil
+}
+
+// Cols returns the columns' descriptors of the n-tuple.
+func (w *Writer) Cols() []ntup.Descriptor {
+	return w.cols
+}
+
+// Entries returns the number of rows written so far.
+func (w *Writer) Entries() int64 {
+	return w.t.Entries()
+}
+
+// Write writes a row of n-tuple data.
+//
+// For struct-based n-tuples, Write expects a single value (or pointer to
+// a value) of that struct type.
+// Otherwise, Write expects one value per column, of the column type.
+func (w *Writer) Write(vs ...interface{}) error {
+	if w.rtyp != nil {
+		if len(vs) != 1 {
+			return fmt.Errorf("hbook/ntup/ntroot: invalid number of values (got=%d, want=1)", len(vs))
+		}
+		rv := reflect.Indirect(reflect.ValueOf(vs[0]))
+		if !rv.IsValid() || rv.Type() != w.rtyp {
+			return fmt.Errorf("hbook/ntup/ntroot: invalid row type (got=%T, want=%v)", vs[0], w.rtyp)
+		}
+		for i, j := range w.fields {
+			w.vals[i].Set(rv.Field(j))
+		}
+		return w.write()
+	}
+
+	if len(vs) != len(w.vals) {
+		return fmt.Errorf("hbook/ntup/ntroot: invalid number of values (got=%d, want=%d)", len(vs), len(w.vals))
+	}
+	for i, v := range vs {
+		rv := reflect.ValueOf(v)
+		if !rv.IsValid() || rv.Type() != w.vals[i].Type() {
+			return fmt.Errorf(
+				"hbook/ntup/ntroot: invalid type for column %q (got=%T, want=%v)",
+				w.cols[i].Name(), v, w.vals[i].Type(),
+			)
+		}
+		w.vals[i].Set(rv)
+	}
+	return w.write()
+}
+
+func (w *Writer) write() error {
+	_, err := w.t.Write()
+	if err != nil {
+		return fmt.Errorf("could not write n-tuple row: %w", err)
+	}
+	return nil
+}
+
+// Close writes the ROOT tree metadata and closes the underlying ROOT file.
+func (w *Writer) Close() error {
+	if w.f == nil {
+		return nil
+	}
+	defer func() {
+		w.f = nil
+	}()
+
+	err := w.t.Close()
+	if err != nil {
+		_ = w.f.Close()
+		return fmt.Errorf("could not close ROOT tree: %w", err)
+	}
+
+	err = w.f.Close()
+	if err != nil {
+		return fmt.Errorf("could not close ROOT file: %w", err)
+	}
+	return nil
+}
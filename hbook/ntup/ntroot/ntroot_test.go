@@ -6,8 +6,12 @@ package ntroot_test
 
 import (
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"go-hep.org/x/hep/hbook/ntup"
 	"go-hep.org/x/hep/hbook/ntup/ntroot"
 )
 
@@ -34,7 +38,7 @@ func TestOpen(t *testing.T) {
 		{
 			name: "../../../groot/testdata/simple.rootXXX",
 			tree: "tree",
-			err:  fmt.Errorf(`could not open ROOT file: riofs: unable to open "../../../groot/testdata/simple.rootXXX": riofs: no ROOT plugin to open [../../../groot/testdata/simple.rootXXX] (scheme=)`),
+			err:  fmt.Errorf(`could not open ROOT file: riofs: unable to open "../../../groot/testdata/simple.rootXXX": open ../../../groot/testdata/simple.rootXXX: no such file or directory`),
 		},
 	} {
 		t.Run(tc.name+":"+tc.tree, func(t *testing.T) {
@@ -58,3 +62,159 @@ func TestOpen(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "ntroot-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type Row struct {
+		I int32   `hbook:"i"`
+		F float64 `hbook:"f"`
+		S string  `hbook:"s"`
+		x int     // unexported fields are ignored.
+	}
+
+	for _, tc := range []struct {
+		name  string
+		cols  []interface{}
+		query string
+		write func(w *ntroot.Writer, i int) error
+	}{
+		{
+			name:  "struct",
+			cols:  []interface{}{Row{}},
+			query: "(i, f, s)",
+			write: func(w *ntroot.Writer, i int) error {
+				return w.Write(&Row{I: int32(i), F: float64(i) * 1.5, S: fmt.Sprintf("evt-%d", i)})
+			},
+		},
+		{
+			name:  "builtins",
+			cols:  []interface{}{int32(0), float64(0), ""},
+			query: "(var1, var2, var3)",
+			write: func(w *ntroot.Writer, i int) error {
+				return w.Write(int32(i), float64(i)*1.5, fmt.Sprintf("evt-%d", i))
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			const nevts = 5
+			fname := filepath.Join(tmp, tc.name+".root")
+
+			w, err := ntroot.Create(fname, "tree", tc.cols...)
+			if err != nil {
+				t.Fatalf("could not create n-tuple: %+v", err)
+			}
+			defer w.Close()
+
+			for i := 0; i < nevts; i++ {
+				err = tc.write(w, i)
+				if err != nil {
+					t.Fatalf("could not write row %d: %+v", i, err)
+				}
+			}
+			if got, want := w.Entries(), int64(nevts); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close n-tuple: %+v", err)
+			}
+
+			nt, err := ntroot.Open(fname, "tree")
+			if err != nil {
+				t.Fatalf("could not open n-tuple: %+v", err)
+			}
+			defer nt.DB().Close()
+
+			n := 0
+			err = nt.Scan(tc.query, func(i int32, f float64, s string) error {
+				if got, want := i, int32(n); got != want {
+					t.Errorf("row %d: invalid i: got=%d, want=%d", n, got, want)
+				}
+				if got, want := f, float64(n)*1.5; got != want {
+					t.Errorf("row %d: invalid f: got=%v, want=%v", n, got, want)
+				}
+				if got, want := s, fmt.Sprintf("evt-%d", n); got != want {
+					t.Errorf("row %d: invalid s: got=%q, want=%q", n, got, want)
+				}
+				n++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not scan n-tuple: %+v", err)
+			}
+			if n != nevts {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", n, nevts)
+			}
+		})
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "ntroot-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	w, err := ntroot.Create(filepath.Join(tmp, "out.root"), "tree", int32(0), float64(0))
+	if err != nil {
+		t.Fatalf("could not create n-tuple: %+v", err)
+	}
+	defer w.Close()
+
+	for _, tc := range []struct {
+		vs  []interface{}
+		err string
+	}{
+		{
+			vs:  []interface{}{int32(1)},
+			err: "hbook/ntup/ntroot: invalid number of values (got=1, want=2)",
+		},
+		{
+			vs:  []interface{}{int32(1), float32(2)},
+			err: `hbook/ntup/ntroot: invalid type for column "var2" (got=float32, want=float64)`,
+		},
+		{
+			vs:  []interface{}{nil, float64(2)},
+			err: `hbook/ntup/ntroot: invalid type for column "var1" (got=<nil>, want=int32)`,
+		},
+	} {
+		err := w.Write(tc.vs...)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		if got, want := err.Error(), tc.err; got != want {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	nt, err := ntroot.Open("../../../groot/testdata/simple.root", "tree")
+	if err != nil {
+		t.Fatalf("could not open n-tuple: %+v", err)
+	}
+	defer nt.DB().Close()
+
+	// aggregations are not supported by the ROOT/SQL driver and are
+	// computed by scanning the tree.
+	rows, err := nt.Aggregate("", "one > 1", ntup.Count(""), ntup.Sum("one"), ntup.Mean("two"), ntup.Max("two"))
+	if err != nil {
+		t.Fatalf("could not aggregate n-tuple: %+v", err)
+	}
+	if got, want := len(rows), 1; got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	want := []float64{3, 9, float64(float32(2.2)+float32(3.3)+float32(4.4)) / 3, float64(float32(4.4))}
+	for i, v := range rows[0].Values {
+		if math.Abs(v-want[i]) > 1e-6 {
+			t.Fatalf("invalid aggregation values:\ngot= %v\nwant=%v", rows[0].Values, want)
+		}
+	}
+}
@@ -130,19 +130,8 @@ func AddScaledH1D(h1 *H1D, alpha float64, h2 *H1D) *H1D {
 		panic(fmt.Errorf("hbook: h1 and h2 have different range"))
 	}
 
-	var (
-		o  = h1.Clone()
-		a2 = alpha * alpha
-	)
-
-	for i := range o.Binning.Bins {
-		o := &o.Binning.Bins[i]
-		o.addScaled(alpha, a2, h2.Binning.Bins[i])
-	}
-
-	o.Binning.Dist.addScaled(alpha, a2, h2.Binning.Dist)
-	o.Binning.Outflows[0].addScaled(alpha, a2, h2.Binning.Outflows[0])
-	o.Binning.Outflows[1].addScaled(alpha, a2, h2.Binning.Outflows[1])
+	o := h1.Clone()
+	o.Binning.addScaled(alpha, alpha*alpha, &h2.Binning)
 	return o
 }
 
@@ -157,3 +146,27 @@ func AddH1D(h1, h2 *H1D) *H1D {
 func SubH1D(h1, h2 *H1D) *H1D {
 	return AddScaledH1D(h1, -1, h2)
 }
+
+// AddScaledH3D returns the histogram with the bin-by-bin h1+alpha*h2
+// operation, assuming statistical uncertainties are uncorrelated.
+func AddScaledH3D(h1 *H3D, alpha float64, h2 *H3D) *H3D {
+	if !h1.Binning.sameEdges(&h2.Binning) {
+		panic(fmt.Errorf("hbook: h1 and h2 have different binnings"))
+	}
+
+	o := h1.Clone()
+	o.Binning.addScaled(alpha, alpha*alpha, &h2.Binning)
+	return o
+}
+
+// AddH3D returns the bin-by-bin summed histogram of h1 and h2
+// assuming their statistical uncertainties are uncorrelated.
+func AddH3D(h1, h2 *H3D) *H3D {
+	return AddScaledH3D(h1, 1, h2)
+}
+
+// SubH3D returns the bin-by-bin subtracted histogram of h1 and h2
+// assuming their statistical uncertainties are uncorrelated.
+func SubH3D(h1, h2 *H3D) *H3D {
+	return AddScaledH3D(h1, -1, h2)
+}
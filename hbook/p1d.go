@@ -8,8 +8,11 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
 	"math"
-	"strings"
+	"sort"
+
+	"go-hep.org/x/hep/rio"
 )
 
 // P1D is a 1-dim profile histogram.
@@ -46,6 +49,61 @@ func NewP1DFromH1D(h *H1D) *P1D {
 	}
 }
 
+// NewP1DFromH2D creates a 1-dim profile histogram from a 2-dim histogram,
+// profiling the y-values of the entries of each column of x-bins.
+// The profile histogram has the same x-bins as the 2-dim histogram.
+// Entries outside of the x- or y-range of the 2-dim histogram are not
+// included in the profile histogram.
+func NewP1DFromH2D(h *H2D) *P1D {
+	var (
+		bng   = &h.Binning
+		edges = make([]float64, 0, bng.Nx+1)
+	)
+	for _, xbin := range bng.XEdges {
+		edges = append(edges, xbin.Range.Min)
+	}
+	edges = append(edges, bng.XEdges[bng.Nx-1].Range.Max)
+
+	p := &P1D{
+		bng: newBinningP1DFromEdges(edges),
+		ann: make(Annotation),
+	}
+	for i := range bng.Bins {
+		var (
+			dist = bng.Bins[i].Dist
+			bin  = &p.bng.bins[i%bng.Nx]
+		)
+		bin.dist.addScaled(1, 1, dist)
+		p.bng.dist.addScaled(1, 1, dist)
+	}
+	return p
+}
+
+// NewP1DFromDists creates a 1-dim profile histogram with bins delimited by
+// the provided edges, from the distributions of its bins, of its under- and
+// overflows and of the whole histogram.
+func NewP1DFromDists(edges []float64, bins []Dist2D, outflows [2]Dist2D, dist Dist2D) *P1D {
+	n := len(bins)
+	if len(edges) != n+1 {
+		panic("hbook: invalid number of edges")
+	}
+	for i := range n {
+		if edges[i] >= edges[i+1] {
+			panic("hbook: invalid edges")
+		}
+	}
+	p := &P1D{
+		bng: newBinningP1DFromEdges(edges),
+		ann: make(Annotation),
+	}
+	for i := range p.bng.bins {
+		p.bng.bins[i].dist = bins[i]
+	}
+	p.bng.outflows = outflows
+	p.bng.dist = dist
+	return p
+}
+
 // Name returns the name of this profile histogram, if any
 func (p *P1D) Name() string {
 	v, ok := p.ann["name"]
@@ -96,6 +154,30 @@ func (p *P1D) SumW2() float64 {
 	return p.bng.dist.SumW2()
 }
 
+// SumWX returns the sum of weights*x in this profile histogram.
+// Overflows are included in the computation.
+func (p *P1D) SumWX() float64 {
+	return p.bng.dist.SumWX()
+}
+
+// SumWX2 returns the sum of weights*x*x in this profile histogram.
+// Overflows are included in the computation.
+func (p *P1D) SumWX2() float64 {
+	return p.bng.dist.SumWX2()
+}
+
+// SumWY returns the sum of weights*y in this profile histogram.
+// Overflows are included in the computation.
+func (p *P1D) SumWY() float64 {
+	return p.bng.dist.SumWY()
+}
+
+// SumWY2 returns the sum of weights*y*y in this profile histogram.
+// Overflows are included in the computation.
+func (p *P1D) SumWY2() float64 {
+	return p.bng.dist.SumWY2()
+}
+
 // XMean returns the mean X.
 // Overflows are included in the computation.
 func (p *P1D) XMean() float64 {
@@ -150,23 +232,29 @@ func (p *P1D) Scale(factor float64) {
 var _ Object = (*P1D)(nil)
 var _ Histogram = (*P1D)(nil)
 
+// serialization interfaces
+var _ rio.Marshaler = (*P1D)(nil)
+var _ rio.Unmarshaler = (*P1D)(nil)
+var _ rio.Streamer = (*P1D)(nil)
+
+// RioMarshal implements rio.RioMarshaler
+func (p *P1D) RioMarshal(w io.Writer) error {
+	return rioMarshal(w, p)
+}
+
+// RioUnmarshal implements rio.RioUnmarshaler
+func (p *P1D) RioUnmarshal(r io.Reader) error {
+	return rioUnmarshal(r, p)
+}
+
+// RioVersion implements rio.RioStreamer
+func (p *P1D) RioVersion() rio.Version {
+	return 0
+}
+
 // annToYODA creates a new Annotation with fields compatible with YODA
 func (p *P1D) annToYODA() Annotation {
-	ann := make(Annotation, len(p.ann))
-	ann["Type"] = "Profile1D"
-	ann["Path"] = "/" + p.Name()
-	ann["Title"] = ""
-	for k, v := range p.ann {
-		if k == "name" {
-			continue
-		}
-		if k == "title" {
-			ann["Title"] = v
-			continue
-		}
-		ann[k] = v
-	}
-	return ann
+	return p.ann.toYODA("Profile1D", p.Name())
 }
 
 // annFromYODA creates a new Annotation from YODA compatible fields
@@ -174,20 +262,7 @@ func (p *P1D) annFromYODA(ann Annotation) {
 	if len(p.ann) == 0 {
 		p.ann = make(Annotation, len(ann))
 	}
-	for k, v := range ann {
-		switch k {
-		case "Type":
-			// noop
-		case "Path":
-			name := v.(string)
-			name = strings.TrimPrefix(name, "/")
-			p.ann["name"] = name
-		case "Title":
-			p.ann["title"] = v
-		default:
-			p.ann[k] = v
-		}
-	}
+	p.ann.fromYODA(ann)
 }
 
 // MarshalYODA implements the YODAMarshaler interface.
@@ -563,7 +638,7 @@ type binningP1D struct {
 	dist     Dist2D
 	outflows [2]Dist2D
 	xrange   Range
-	xstep    float64
+	xstep    float64 // inverse of the bins width, zero for variable-size bins.
 }
 
 func newBinningP1D(n int, xmin, xmax float64) binningP1D {
@@ -588,6 +663,29 @@ func newBinningP1D(n int, xmin, xmax float64) binningP1D {
 	return bng
 }
 
+func newBinningP1DFromEdges(edges []float64) binningP1D {
+	if len(edges) <= 1 {
+		panic(errShortXAxis)
+	}
+	if !sort.IsSorted(sort.Float64Slice(edges)) {
+		panic(errNotSortedXAxis)
+	}
+	n := len(edges) - 1
+	bng := binningP1D{
+		bins:   make([]BinP1D, n),
+		xrange: Range{Min: edges[0], Max: edges[n]},
+	}
+	for i := range bng.bins {
+		bin := &bng.bins[i]
+		bin.xrange.Min = edges[i]
+		bin.xrange.Max = edges[i+1]
+		if bin.xrange.Min == bin.xrange.Max {
+			panic(errDupEdgesXAxis)
+		}
+	}
+	return bng
+}
+
 func (bng *binningP1D) entries() int64 {
 	return bng.dist.Entries()
 }
@@ -619,13 +717,16 @@ func (bng *binningP1D) fill(x, y, w float64) {
 // coordToIndex returns the bin index corresponding to the coordinate x.
 func (bng *binningP1D) coordToIndex(x float64) int {
 	switch {
-	default:
-		i := int((x - bng.xrange.Min) * bng.xstep)
-		return i
 	case x < bng.xrange.Min:
 		return UnderflowBin1D
 	case x >= bng.xrange.Max:
 		return OverflowBin1D
+	case bng.xstep == 0:
+		return sort.Search(len(bng.bins), func(i int) bool {
+			return x < bng.bins[i].xrange.Max
+		})
+	default:
+		return int((x - bng.xrange.Min) * bng.xstep)
 	}
 }
 
@@ -644,6 +745,11 @@ func (bng *binningP1D) Bins() []BinP1D {
 	return bng.bins
 }
 
+// Outflows returns the under- and overflow distributions for this binning.
+func (bng *binningP1D) Outflows() [2]Dist2D {
+	return bng.outflows
+}
+
 // BinP1D models a bin in a 1-dim space.
 type BinP1D struct {
 	xrange Range
@@ -681,6 +787,16 @@ func (b *BinP1D) SumW2() float64 {
 	return b.dist.SumW2()
 }
 
+// SumWY returns the sum of weights*y in this bin.
+func (b *BinP1D) SumWY() float64 {
+	return b.dist.SumWY()
+}
+
+// SumWY2 returns the sum of weights*y*y in this bin.
+func (b *BinP1D) SumWY2() float64 {
+	return b.dist.SumWY2()
+}
+
 // XEdges returns the [low,high] edges of this bin.
 func (b *BinP1D) XEdges() Range {
 	return b.xrange
@@ -740,3 +856,28 @@ func (b *BinP1D) XStdErr() float64 {
 func (b *BinP1D) XRMS() float64 {
 	return b.dist.xRMS()
 }
+
+// YMean returns the mean Y.
+func (b *BinP1D) YMean() float64 {
+	return b.dist.yMean()
+}
+
+// YVariance returns the variance in Y.
+func (b *BinP1D) YVariance() float64 {
+	return b.dist.yVariance()
+}
+
+// YStdDev returns the standard deviation in Y.
+func (b *BinP1D) YStdDev() float64 {
+	return b.dist.yStdDev()
+}
+
+// YStdErr returns the standard error in Y.
+func (b *BinP1D) YStdErr() float64 {
+	return b.dist.yStdErr()
+}
+
+// YRMS returns the RMS in Y.
+func (b *BinP1D) YRMS() float64 {
+	return b.dist.yRMS()
+}
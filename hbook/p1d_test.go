@@ -7,6 +7,7 @@ package hbook
 import (
 	"bytes"
 	"encoding/gob"
+	"math"
 	"os"
 	"reflect"
 	"runtime"
@@ -217,3 +218,90 @@ func TestP1DSerialization(t *testing.T) {
 		}
 	}
 }
+
+func TestP1DFromH2D(t *testing.T) {
+	h := NewH2D(4, 0, 4, 5, -10, 10)
+	want := NewP1D(4, 0, 4)
+	for i := 0; i < 20; i++ {
+		var (
+			x = 0.2 * float64(i)
+			y = 0.5*float64(i) - 4
+			w = 1 + 0.1*float64(i%3)
+		)
+		h.Fill(x, y, w)
+		want.Fill(x, y, w)
+	}
+
+	got := NewP1DFromH2D(h)
+	if got, want := got.Entries(), want.Entries(); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+
+	const tol = 1e-12
+	for i, bin := range got.Binning().Bins() {
+		ref := want.Binning().Bins()[i]
+		if bin.XEdges() != ref.XEdges() {
+			t.Fatalf("bin[%d]: invalid edges: got=%v, want=%v", i, bin.XEdges(), ref.XEdges())
+		}
+		for _, v := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"sumw", bin.SumW(), ref.SumW()},
+			{"sumw2", bin.SumW2(), ref.SumW2()},
+			{"ymean", bin.YMean(), ref.YMean()},
+			{"ystddev", bin.YStdDev(), ref.YStdDev()},
+			{"ystderr", bin.YStdErr(), ref.YStdErr()},
+		} {
+			if math.Abs(v.got-v.want) > tol {
+				t.Errorf("bin[%d]: invalid %s: got=%v, want=%v", i, v.name, v.got, v.want)
+			}
+		}
+	}
+
+}
+
+func TestP1DFromH2DVariableBins(t *testing.T) {
+	h := NewH2DFromEdges([]float64{0, 1, 3, 3.5}, []float64{-10, 0, 10})
+	for _, v := range [][3]float64{
+		{0.5, 1, 1},
+		{0.5, 3, 2},
+		{2.5, -2, 1},
+		{1.5, 4, 1},
+		{3.2, 5, 1},
+	} {
+		h.Fill(v[0], v[1], v[2])
+	}
+
+	p := NewP1DFromH2D(h)
+	for i, tc := range []struct {
+		xmin, xmax float64
+		sumw       float64
+		ymean      float64
+	}{
+		{0, 1, 3, 7.0 / 3},
+		{1, 3, 2, 1},
+		{3, 3.5, 1, 5},
+	} {
+		bin := p.Binning().Bins()[i]
+		if got, want := bin.XEdges(), (Range{Min: tc.xmin, Max: tc.xmax}); got != want {
+			t.Fatalf("bin[%d]: invalid edges: got=%v, want=%v", i, got, want)
+		}
+		if got, want := bin.SumW(), tc.sumw; got != want {
+			t.Fatalf("bin[%d]: invalid sumw: got=%v, want=%v", i, got, want)
+		}
+		if got, want := bin.YMean(), tc.ymean; math.Abs(got-want) > 1e-12 {
+			t.Fatalf("bin[%d]: invalid y-mean: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	// subsequent fills use the variable-size bins.
+	p.Fill(2.9, 1, 1)
+	p.Fill(3.1, 1, 1)
+	if got, want := p.Binning().Bins()[1].SumW(), 3.0; got != want {
+		t.Fatalf("invalid sumw after fill: got=%v, want=%v", got, want)
+	}
+	if got, want := p.Binning().Bins()[2].SumW(), 2.0; got != want {
+		t.Fatalf("invalid sumw after fill: got=%v, want=%v", got, want)
+	}
+}
@@ -0,0 +1,725 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+
+	"go-hep.org/x/hep/rio"
+)
+
+// P2D is a 2-dim profile histogram.
+//
+// P2D records, for each (x,y) bin, the distribution of the z-values
+// of its entries.
+type P2D struct {
+	bng binningP2D
+	ann Annotation
+}
+
+// NewP2D returns a 2-dim profile histogram with nx bins between xmin and xmax
+// and ny bins between ymin and ymax.
+func NewP2D(nx int, xmin, xmax float64, ny int, ymin, ymax float64) *P2D {
+	return &P2D{
+		bng: newBinningP2D(nx, xmin, xmax, ny, ymin, ymax),
+		ann: make(Annotation),
+	}
+}
+
+// Name returns the name of this profile histogram, if any
+func (p *P2D) Name() string {
+	v, ok := p.ann["name"]
+	if !ok {
+		return ""
+	}
+	n, ok := v.(string)
+	if !ok {
+		return ""
+	}
+	return n
+}
+
+// Annotation returns the annotations attached to this profile histogram
+func (p *P2D) Annotation() Annotation {
+	return p.ann
+}
+
+// Rank returns the number of dimensions for this profile histogram
+func (p *P2D) Rank() int {
+	return 2
+}
+
+// Entries returns the number of entries in this profile histogram
+func (p *P2D) Entries() int64 {
+	return p.bng.entries()
+}
+
+// EffEntries returns the number of effective entries in this profile histogram
+func (p *P2D) EffEntries() float64 {
+	return p.bng.effEntries()
+}
+
+// Binning returns the binning of this profile histogram
+func (p *P2D) Binning() *binningP2D {
+	return &p.bng
+}
+
+// SumW returns the sum of weights in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumW() float64 {
+	return p.bng.dist.SumW()
+}
+
+// SumW2 returns the sum of squared weights in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumW2() float64 {
+	return p.bng.dist.SumW2()
+}
+
+// SumWX returns the sum of weights*x in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumWX() float64 {
+	return p.bng.dist.SumWX()
+}
+
+// SumWX2 returns the sum of weights*x*x in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumWX2() float64 {
+	return p.bng.dist.SumWX2()
+}
+
+// SumWY returns the sum of weights*y in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumWY() float64 {
+	return p.bng.dist.SumWY()
+}
+
+// SumWY2 returns the sum of weights*y*y in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumWY2() float64 {
+	return p.bng.dist.SumWY2()
+}
+
+// SumWZ returns the sum of weights*z in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumWZ() float64 {
+	return p.bng.dist.SumWZ()
+}
+
+// SumWZ2 returns the sum of weights*z*z in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumWZ2() float64 {
+	return p.bng.dist.SumWZ2()
+}
+
+// SumWXY returns the sum of weights*x*y in this profile histogram.
+// Overflows are included in the computation.
+func (p *P2D) SumWXY() float64 {
+	return p.bng.dist.SumWXY()
+}
+
+// XMean returns the mean X.
+// Overflows are included in the computation.
+func (p *P2D) XMean() float64 {
+	return p.bng.dist.xMean()
+}
+
+// YMean returns the mean Y.
+// Overflows are included in the computation.
+func (p *P2D) YMean() float64 {
+	return p.bng.dist.yMean()
+}
+
+// XVariance returns the variance in X.
+// Overflows are included in the computation.
+func (p *P2D) XVariance() float64 {
+	return p.bng.dist.xVariance()
+}
+
+// YVariance returns the variance in Y.
+// Overflows are included in the computation.
+func (p *P2D) YVariance() float64 {
+	return p.bng.dist.yVariance()
+}
+
+// XStdDev returns the standard deviation in X.
+// Overflows are included in the computation.
+func (p *P2D) XStdDev() float64 {
+	return p.bng.dist.xStdDev()
+}
+
+// YStdDev returns the standard deviation in Y.
+// Overflows are included in the computation.
+func (p *P2D) YStdDev() float64 {
+	return p.bng.dist.yStdDev()
+}
+
+// XStdErr returns the standard error in X.
+// Overflows are included in the computation.
+func (p *P2D) XStdErr() float64 {
+	return p.bng.dist.xStdErr()
+}
+
+// YStdErr returns the standard error in Y.
+// Overflows are included in the computation.
+func (p *P2D) YStdErr() float64 {
+	return p.bng.dist.yStdErr()
+}
+
+// XRMS returns the RMS in X.
+// Overflows are included in the computation.
+func (p *P2D) XRMS() float64 {
+	return p.bng.dist.xRMS()
+}
+
+// YRMS returns the RMS in Y.
+// Overflows are included in the computation.
+func (p *P2D) YRMS() float64 {
+	return p.bng.dist.yRMS()
+}
+
+// Fill fills this profile histogram with x,y,z and weight w.
+func (p *P2D) Fill(x, y, z, w float64) {
+	p.bng.fill(x, y, z, w)
+}
+
+// Bin returns the bin at coordinates (x,y) for this profile histogram.
+// Bin returns nil for under/over flow bins.
+func (p *P2D) Bin(x, y float64) *BinP2D {
+	idx := p.bng.coordToIndex(x, y)
+	if idx < 0 {
+		return nil
+	}
+	return &p.bng.bins[idx]
+}
+
+// XMin returns the low edge of the X-axis of this profile histogram.
+func (p *P2D) XMin() float64 {
+	return p.bng.xrange.Min
+}
+
+// XMax returns the high edge of the X-axis of this profile histogram.
+func (p *P2D) XMax() float64 {
+	return p.bng.xrange.Max
+}
+
+// YMin returns the low edge of the Y-axis of this profile histogram.
+func (p *P2D) YMin() float64 {
+	return p.bng.yrange.Min
+}
+
+// YMax returns the high edge of the Y-axis of this profile histogram.
+func (p *P2D) YMax() float64 {
+	return p.bng.yrange.Max
+}
+
+// Scale scales the content of each bin by the given factor.
+func (p *P2D) Scale(factor float64) {
+	p.bng.scaleW(factor)
+}
+
+// check various interfaces
+var _ Object = (*P2D)(nil)
+var _ Histogram = (*P2D)(nil)
+
+// serialization interfaces
+var _ rio.Marshaler = (*P2D)(nil)
+var _ rio.Unmarshaler = (*P2D)(nil)
+var _ rio.Streamer = (*P2D)(nil)
+
+// RioMarshal implements rio.RioMarshaler
+func (p *P2D) RioMarshal(w io.Writer) error {
+	return rioMarshal(w, p)
+}
+
+// RioUnmarshal implements rio.RioUnmarshaler
+func (p *P2D) RioUnmarshal(r io.Reader) error {
+	return rioUnmarshal(r, p)
+}
+
+// RioVersion implements rio.RioStreamer
+func (p *P2D) RioVersion() rio.Version {
+	return 0
+}
+
+// annToYODA creates a new Annotation with fields compatible with YODA
+func (p *P2D) annToYODA() Annotation {
+	return p.ann.toYODA("Profile2D", p.Name())
+}
+
+// annFromYODA creates a new Annotation from YODA compatible fields
+func (p *P2D) annFromYODA(ann Annotation) {
+	if len(p.ann) == 0 {
+		p.ann = make(Annotation, len(ann))
+	}
+	p.ann.fromYODA(ann)
+}
+
+// MarshalYODA implements the YODAMarshaler interface.
+//
+// As in YODA, the x*z and y*z cross-terms and the outflows of the
+// profile histogram are not persistified.
+func (p *P2D) MarshalYODA() ([]byte, error) {
+	return p.marshalYODA(2)
+}
+
+func (p *P2D) marshalYODA(vers int) ([]byte, error) {
+	var (
+		buf  = new(bytes.Buffer)
+		ann  = p.annToYODA()
+		data []byte
+		err  error
+		tag  = "YODA_PROFILE2D"
+		n    = func(d Dist3D) string { return fmt.Sprintf("%d", d.Entries()) }
+	)
+	switch vers {
+	case 1:
+		data, err = ann.marshalYODAv1()
+	case 2:
+		tag += "_V2"
+		data, err = ann.marshalYODAv2()
+		n = func(d Dist3D) string { return fmt.Sprintf("%e", float64(d.Entries())) }
+	default:
+		return nil, fmt.Errorf("hbook: invalid YODA version %v", vers)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(buf, "BEGIN %s %s\n", tag, ann["Path"])
+	buf.Write(data)
+	if vers == 2 {
+		buf.Write([]byte("---\n"))
+	}
+
+	fmt.Fprintf(buf, "# Mean: (%e, %e)\n", p.XMean(), p.YMean())
+	fmt.Fprintf(buf, "# ID\t ID\t sumw\t sumw2\t sumwx\t sumwx2\t sumwy\t sumwy2\t sumwz\t sumwz2\t sumwxy\t numEntries\n")
+	d := p.bng.dist
+	fmt.Fprintf(
+		buf,
+		"Total   \tTotal   \t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%s\n",
+		d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(),
+		d.SumWZ(), d.SumWZ2(), d.SumWXY(), n(d),
+	)
+
+	// outflows
+	fmt.Fprintf(buf, "# 2D outflow persistency not currently supported until API is stable\n")
+
+	// bins
+	fmt.Fprintf(buf, "# xlow\t xhigh\t ylow\t yhigh\t sumw\t sumw2\t sumwx\t sumwx2\t sumwy\t sumwy2\t sumwz\t sumwz2\t sumwxy\t numEntries\n")
+	for ix := 0; ix < p.bng.nx; ix++ {
+		for iy := 0; iy < p.bng.ny; iy++ {
+			bin := p.bng.bins[iy*p.bng.nx+ix]
+			d := bin.dist
+			fmt.Fprintf(
+				buf,
+				"%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%s\n",
+				bin.xrange.Min, bin.xrange.Max, bin.yrange.Min, bin.yrange.Max,
+				d.SumW(), d.SumW2(), d.SumWX(), d.SumWX2(), d.SumWY(), d.SumWY2(),
+				d.SumWZ(), d.SumWZ2(), d.SumWXY(), n(d),
+			)
+		}
+	}
+	fmt.Fprintf(buf, "END %s\n\n", tag)
+	return buf.Bytes(), err
+}
+
+// UnmarshalYODA implements the YODAUnmarshaler interface.
+func (p *P2D) UnmarshalYODA(data []byte) error {
+	r := newRBuffer(data)
+	_, vers, err := readYODAHeader(r, "BEGIN YODA_PROFILE2D")
+	if err != nil {
+		return err
+	}
+
+	ann := make(Annotation)
+
+	// pos of end of annotations
+	pos := bytes.Index(r.Bytes(), []byte("\n# Mean:"))
+	if pos < 0 {
+		return fmt.Errorf("hbook: invalid P2D-YODA data")
+	}
+	switch vers {
+	case 1:
+		err = ann.unmarshalYODAv1(r.Bytes()[:pos+1])
+	case 2:
+		err = ann.unmarshalYODAv2(r.Bytes()[:pos+1])
+	default:
+		return fmt.Errorf("hbook: invalid YODA version %v", vers)
+	}
+	if err != nil {
+		return fmt.Errorf("hbook: %q\nhbook: %w", string(r.Bytes()[:pos+1]), err)
+	}
+	p.annFromYODA(ann)
+	r.next(pos)
+
+	var ctx struct {
+		dist bool
+		bins bool
+	}
+
+	// sets of xlow and ylow values, to infer number of bins in X and Y.
+	xset := make(map[float64]int)
+	yset := make(map[float64]int)
+
+	var (
+		dist Dist3D
+		bins []BinP2D
+		xmin = math.Inf(+1)
+		xmax = math.Inf(-1)
+		ymin = math.Inf(+1)
+		ymax = math.Inf(-1)
+	)
+
+	// scanDist decodes the moments of a distribution.
+	// numEntries is an integer in YODA v1 and a float in YODA v2:
+	// decode it as a float in both cases.
+	scanDist := func(buf []byte, format string, d *Dist3D, args ...any) error {
+		var n float64
+		args = append(args,
+			&d.X.Dist.SumW, &d.X.Dist.SumW2,
+			&d.X.Stats.SumWX, &d.X.Stats.SumWX2,
+			&d.Y.Stats.SumWX, &d.Y.Stats.SumWX2,
+			&d.Z.Stats.SumWX, &d.Z.Stats.SumWX2,
+			&d.Stats.SumWXY, &n,
+		)
+		_, err := fmt.Fscanf(bytes.NewReader(buf), format, args...)
+		if err != nil {
+			return fmt.Errorf("hbook: %q\nhbook: %w", string(buf), err)
+		}
+		d.X.Dist.N = int64(n)
+		d.Y.Dist = d.X.Dist
+		d.Z.Dist = d.X.Dist
+		return nil
+	}
+
+	s := bufio.NewScanner(r)
+scanLoop:
+	for s.Scan() {
+		buf := s.Bytes()
+		if len(buf) == 0 || buf[0] == '#' {
+			continue
+		}
+		switch {
+		case bytes.HasPrefix(buf, []byte("END YODA_PROFILE2D")):
+			break scanLoop
+		case !ctx.dist && bytes.HasPrefix(buf, []byte("Total   \t")):
+			ctx.dist = true
+			err = scanDist(
+				buf,
+				"Total   \tTotal   \t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+				&dist,
+			)
+			if err != nil {
+				return err
+			}
+			ctx.bins = true
+		case ctx.bins:
+			var bin BinP2D
+			err = scanDist(
+				buf,
+				"%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\t%e\n",
+				&bin.dist,
+				&bin.xrange.Min, &bin.xrange.Max, &bin.yrange.Min, &bin.yrange.Max,
+			)
+			if err != nil {
+				return err
+			}
+			xset[bin.xrange.Min] = 1
+			yset[bin.yrange.Min] = 1
+			xmin = math.Min(xmin, bin.xrange.Min)
+			xmax = math.Max(xmax, bin.xrange.Max)
+			ymin = math.Min(ymin, bin.yrange.Min)
+			ymax = math.Max(ymax, bin.yrange.Max)
+			bins = append(bins, bin)
+
+		default:
+			return fmt.Errorf("hbook: invalid P2D-YODA data: %q", string(buf))
+		}
+	}
+	if len(bins) != len(xset)*len(yset) {
+		return fmt.Errorf("hbook: invalid P2D-YODA data: got %d bins, want %dx%d", len(bins), len(xset), len(yset))
+	}
+	p.bng = newBinningP2D(len(xset), xmin, xmax, len(yset), ymin, ymax)
+	p.bng.dist = dist
+	// YODA bins are transposed wrt ours
+	for ix := 0; ix < p.bng.nx; ix++ {
+		for iy := 0; iy < p.bng.ny; iy++ {
+			p.bng.bins[iy*p.bng.nx+ix] = bins[ix*p.bng.ny+iy]
+		}
+	}
+	return err
+}
+
+// binningP2D is a 2-dim binning for 2-dim profile histograms.
+type binningP2D struct {
+	bins     []BinP2D
+	dist     Dist3D
+	outflows [8]Dist3D
+	xrange   Range
+	yrange   Range
+	nx       int
+	ny       int
+	xstep    float64
+	ystep    float64
+}
+
+func newBinningP2D(nx int, xmin, xmax float64, ny int, ymin, ymax float64) binningP2D {
+	if xmin >= xmax {
+		panic(errInvalidXAxis)
+	}
+	if ymin >= ymax {
+		panic(errInvalidYAxis)
+	}
+	if nx <= 0 {
+		panic(errEmptyXAxis)
+	}
+	if ny <= 0 {
+		panic(errEmptyYAxis)
+	}
+	bng := binningP2D{
+		bins:   make([]BinP2D, nx*ny),
+		xrange: Range{Min: xmin, Max: xmax},
+		yrange: Range{Min: ymin, Max: ymax},
+		nx:     nx,
+		ny:     ny,
+	}
+	bng.xstep = float64(nx) / bng.xrange.Width()
+	bng.ystep = float64(ny) / bng.yrange.Width()
+	xwidth := bng.xrange.Width() / float64(nx)
+	ywidth := bng.yrange.Width() / float64(ny)
+	for iy := 0; iy < ny; iy++ {
+		for ix := 0; ix < nx; ix++ {
+			bin := &bng.bins[iy*nx+ix]
+			bin.xrange.Min = xmin + float64(ix)*xwidth
+			bin.xrange.Max = xmin + float64(ix+1)*xwidth
+			bin.yrange.Min = ymin + float64(iy)*ywidth
+			bin.yrange.Max = ymin + float64(iy+1)*ywidth
+		}
+	}
+	return bng
+}
+
+func (bng *binningP2D) entries() int64 {
+	return bng.dist.Entries()
+}
+
+func (bng *binningP2D) effEntries() float64 {
+	return bng.dist.EffEntries()
+}
+
+func (bng *binningP2D) fill(x, y, z, w float64) {
+	idx := bng.coordToIndex(x, y)
+	bng.dist.fill(x, y, z, w)
+	if idx < 0 {
+		bng.outflows[-idx-1].fill(x, y, z, w)
+		return
+	}
+	bng.bins[idx].fill(x, y, z, w)
+}
+
+// coordToIndex returns the bin index corresponding to the coordinates (x,y).
+// Outflows are indexed by the negated BngXXX constants.
+func (bng *binningP2D) coordToIndex(x, y float64) int {
+	ix := bng.axisIndex(x, bng.xrange, bng.xstep, bng.nx)
+	iy := bng.axisIndex(y, bng.yrange, bng.ystep, bng.ny)
+
+	switch {
+	case ix == OverflowBin1D && iy == OverflowBin1D:
+		return -BngNE
+	case ix == OverflowBin1D && iy == UnderflowBin1D:
+		return -BngSE
+	case ix == UnderflowBin1D && iy == UnderflowBin1D:
+		return -BngSW
+	case ix == UnderflowBin1D && iy == OverflowBin1D:
+		return -BngNW
+	case ix == OverflowBin1D:
+		return -BngE
+	case ix == UnderflowBin1D:
+		return -BngW
+	case iy == OverflowBin1D:
+		return -BngN
+	case iy == UnderflowBin1D:
+		return -BngS
+	}
+	return iy*bng.nx + ix
+}
+
+func (bng *binningP2D) axisIndex(v float64, rng Range, step float64, n int) int {
+	switch {
+	case v < rng.Min:
+		return UnderflowBin1D
+	case v >= rng.Max:
+		return OverflowBin1D
+	}
+	i := int((v - rng.Min) * step)
+	if i >= n {
+		// guard against rounding errors at the upper edge.
+		i = n - 1
+	}
+	return i
+}
+
+func (bng *binningP2D) scaleW(f float64) {
+	bng.dist.scaleW(f)
+	for i := range bng.outflows {
+		bng.outflows[i].scaleW(f)
+	}
+	for i := range bng.bins {
+		bin := &bng.bins[i]
+		bin.scaleW(f)
+	}
+}
+
+// Nx returns the number of bins along the X-axis.
+func (bng *binningP2D) Nx() int {
+	return bng.nx
+}
+
+// Ny returns the number of bins along the Y-axis.
+func (bng *binningP2D) Ny() int {
+	return bng.ny
+}
+
+// Bins returns the slice of bins for this binning.
+// The bin (ix,iy) is located at index iy*Nx+ix.
+func (bng *binningP2D) Bins() []BinP2D {
+	return bng.bins
+}
+
+// Outflows returns the outflow distributions for this binning,
+// indexed by the BngXXX constants minus one.
+func (bng *binningP2D) Outflows() [8]Dist3D {
+	return bng.outflows
+}
+
+// BinP2D models a bin in a 2-dim space.
+type BinP2D struct {
+	xrange Range
+	yrange Range
+	dist   Dist3D
+}
+
+// Rank returns the number of dimensions for this bin.
+func (BinP2D) Rank() int { return 2 }
+
+func (b *BinP2D) scaleW(f float64) {
+	b.dist.scaleW(f)
+}
+
+func (b *BinP2D) fill(x, y, z, w float64) {
+	b.dist.fill(x, y, z, w)
+}
+
+// Entries returns the number of entries in this bin.
+func (b *BinP2D) Entries() int64 {
+	return b.dist.Entries()
+}
+
+// EffEntries returns the effective number of entries \f$ = (\sum w)^2 / \sum w^2 \f$
+func (b *BinP2D) EffEntries() float64 {
+	return b.dist.EffEntries()
+}
+
+// SumW returns the sum of weights in this bin.
+func (b *BinP2D) SumW() float64 {
+	return b.dist.SumW()
+}
+
+// SumW2 returns the sum of squared weights in this bin.
+func (b *BinP2D) SumW2() float64 {
+	return b.dist.SumW2()
+}
+
+// SumWZ returns the sum of weights*z in this bin.
+func (b *BinP2D) SumWZ() float64 {
+	return b.dist.SumWZ()
+}
+
+// SumWZ2 returns the sum of weights*z*z in this bin.
+func (b *BinP2D) SumWZ2() float64 {
+	return b.dist.SumWZ2()
+}
+
+// XEdges returns the [low,high] edges of this bin.
+func (b *BinP2D) XEdges() Range {
+	return b.xrange
+}
+
+// YEdges returns the [low,high] edges of this bin.
+func (b *BinP2D) YEdges() Range {
+	return b.yrange
+}
+
+// XMin returns the lower limit of the bin (inclusive).
+func (b *BinP2D) XMin() float64 {
+	return b.xrange.Min
+}
+
+// YMin returns the lower limit of the bin (inclusive).
+func (b *BinP2D) YMin() float64 {
+	return b.yrange.Min
+}
+
+// XMax returns the upper limit of the bin (exclusive).
+func (b *BinP2D) XMax() float64 {
+	return b.xrange.Max
+}
+
+// YMax returns the upper limit of the bin (exclusive).
+func (b *BinP2D) YMax() float64 {
+	return b.yrange.Max
+}
+
+// XMid returns the geometric center of the bin.
+// i.e.: 0.5*(high+low)
+func (b *BinP2D) XMid() float64 {
+	return 0.5 * (b.xrange.Min + b.xrange.Max)
+}
+
+// YMid returns the geometric center of the bin.
+// i.e.: 0.5*(high+low)
+func (b *BinP2D) YMid() float64 {
+	return 0.5 * (b.yrange.Min + b.yrange.Max)
+}
+
+// XWidth returns the (signed) width of the bin
+func (b *BinP2D) XWidth() float64 {
+	return b.xrange.Max - b.xrange.Min
+}
+
+// YWidth returns the (signed) width of the bin
+func (b *BinP2D) YWidth() float64 {
+	return b.yrange.Max - b.yrange.Min
+}
+
+// ZMean returns the mean Z.
+func (b *BinP2D) ZMean() float64 {
+	return b.dist.zMean()
+}
+
+// ZVariance returns the variance in Z.
+func (b *BinP2D) ZVariance() float64 {
+	return b.dist.zVariance()
+}
+
+// ZStdDev returns the standard deviation in Z.
+func (b *BinP2D) ZStdDev() float64 {
+	return b.dist.zStdDev()
+}
+
+// ZStdErr returns the standard error in Z, i.e. the error on the mean Z.
+func (b *BinP2D) ZStdErr() float64 {
+	return b.dist.zStdErr()
+}
+
+// ZRMS returns the RMS in Z.
+func (b *BinP2D) ZRMS() float64 {
+	return b.dist.zRMS()
+}
@@ -0,0 +1,185 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"math"
+	"os"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func newTestP2D() *P2D {
+	p := NewP2D(4, 0, 4, 2, -1, +1)
+	p.Annotation()["name"] = "p2d"
+	p.Annotation()["title"] = "my title"
+
+	for i := 0; i < 4; i++ {
+		x := float64(i) + 0.5
+		p.Fill(x, -0.5, 2*x, 1)
+		p.Fill(x, -0.5, 2*x+1, 2)
+		p.Fill(x, +0.5, -x, 1)
+	}
+	p.Fill(-1, 0, 10, 1)
+	p.Fill(+5, 2, 20, 1)
+	return p
+}
+
+func TestP2D(t *testing.T) {
+	p := newTestP2D()
+
+	if got, want := p.Name(), "p2d"; got != want {
+		t.Errorf("got=%q. want=%q\n", got, want)
+	}
+	if got, want := p.Entries(), int64(14); got != want {
+		t.Errorf("invalid entries: got=%d, want=%d", got, want)
+	}
+
+	for _, test := range []struct {
+		name string
+		f    func() float64
+		want float64
+	}{
+		{"xmin", p.XMin, 0},
+		{"xmax", p.XMax, 4},
+		{"ymin", p.YMin, -1},
+		{"ymax", p.YMax, +1},
+		{"sumw", p.SumW, 18},
+		{"sumw2", p.SumW2, 26},
+		{"sumwz", p.SumWZ, 78},
+		{"xmean", p.XMean, 2},
+		{"ymean", p.YMean, -1.0 / 9},
+	} {
+		got := test.f()
+		if !scalar.EqualWithinULP(got, test.want, 2) {
+			t.Errorf("test: %v. got=%v. want=%v\n", test.name, got, test.want)
+		}
+	}
+
+	bin := p.Bin(1.5, -0.5)
+	if bin == nil {
+		t.Fatalf("could not find bin")
+	}
+	if got, want := bin.Entries(), int64(2); got != want {
+		t.Errorf("invalid bin entries: got=%d, want=%d", got, want)
+	}
+	if got, want := bin.ZMean(), (3.0+2*4)/3; !scalar.EqualWithinULP(got, want, 2) {
+		t.Errorf("invalid bin z-mean: got=%v, want=%v", got, want)
+	}
+	if got, want := bin.XMid(), 1.5; got != want {
+		t.Errorf("invalid bin x-mid: got=%v, want=%v", got, want)
+	}
+	if got, want := bin.YMid(), -0.5; got != want {
+		t.Errorf("invalid bin y-mid: got=%v, want=%v", got, want)
+	}
+	if got, want := bin.ZVariance(), 0.5; !scalar.EqualWithinRel(got, want, 1e-12) {
+		t.Errorf("invalid bin z-variance: got=%v, want=%v", got, want)
+	}
+	if got, want := bin.ZStdErr(), bin.ZStdDev()/math.Sqrt(bin.EffEntries()); !scalar.EqualWithinRel(got, want, 1e-12) {
+		t.Errorf("invalid bin z-stderr: got=%v, want=%v", got, want)
+	}
+
+	if bin := p.Bin(-1, 0); bin != nil {
+		t.Errorf("expected a nil bin for outflows")
+	}
+
+	oflows := p.Binning().Outflows()
+	if got, want := oflows[BngW-1].Entries(), int64(1); got != want {
+		t.Errorf("invalid W-outflow entries: got=%d, want=%d", got, want)
+	}
+	if got, want := oflows[BngNE-1].Entries(), int64(1); got != want {
+		t.Errorf("invalid NE-outflow entries: got=%d, want=%d", got, want)
+	}
+
+	p.Scale(2)
+	if got, want := p.SumW(), 36.0; got != want {
+		t.Errorf("invalid scaled sumw: got=%v, want=%v", got, want)
+	}
+	if got, want := p.Bin(1.5, -0.5).ZMean(), (3.0+2*4)/3; !scalar.EqualWithinULP(got, want, 2) {
+		t.Errorf("invalid scaled bin z-mean: got=%v, want=%v", got, want)
+	}
+}
+
+func TestP2DWriteYODA(t *testing.T) {
+	p := newTestP2D()
+
+	chk, err := p.MarshalYODA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := os.ReadFile("testdata/p2d_v2_golden.yoda")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(chk, ref) {
+		fatalf := t.Fatalf
+		if runtime.GOOS == "darwin" {
+			// ignore errors for darwin and mac-silicon
+			fatalf = t.Logf
+		}
+		fatalf("p2d file differ:\n%s\n",
+			cmp.Diff(
+				string(ref),
+				string(chk),
+			),
+		)
+	}
+}
+
+func TestP2DReadYODA(t *testing.T) {
+	for _, tc := range []struct {
+		vers  int
+		fname string
+	}{
+		{1, "testdata/p2d_v1_golden.yoda"},
+		{2, "testdata/p2d_v2_golden.yoda"},
+	} {
+		t.Run(tc.fname, func(t *testing.T) {
+			ref, err := os.ReadFile(tc.fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var p P2D
+			err = p.UnmarshalYODA(ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			chk, err := p.marshalYODA(tc.vers)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(chk, ref) {
+				t.Fatalf("p2d file differ:\n%s\n",
+					cmp.Diff(
+						string(ref),
+						string(chk),
+					),
+				)
+			}
+
+			if got, want := p.Name(), "p2d"; got != want {
+				t.Fatalf("invalid name: got=%q, want=%q", got, want)
+			}
+			if got, want := p.Binning().Nx(), 4; got != want {
+				t.Fatalf("invalid nx: got=%d, want=%d", got, want)
+			}
+			if got, want := p.Binning().Ny(), 2; got != want {
+				t.Fatalf("invalid ny: got=%d, want=%d", got, want)
+			}
+			if got, want := p.Bin(1.5, -0.5).ZMean(), (3.0+2*4)/3; !scalar.EqualWithinRel(got, want, 1e-6) {
+				t.Fatalf("invalid bin z-mean: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
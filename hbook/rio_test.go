@@ -0,0 +1,70 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/rio"
+)
+
+func TestRioStreamers(t *testing.T) {
+	h2 := NewH2D(3, 0, 3, 2, 0, 2)
+	h2.Fill(1, 1, 2)
+	h2.Fill(5, 1, 1)
+	h2.Annotation()["name"] = "h2"
+
+	h3 := NewH3D(2, 0, 2, 2, 0, 2, 2, 0, 2)
+	h3.Fill(1, 1, 1, 2)
+	h3.Fill(-1, 1, 3, 1)
+
+	p1 := NewP1D(3, 0, 3)
+	p1.Fill(1, 2, 1)
+	p1.Fill(4, 2, 1)
+
+	p2 := NewP2D(2, 0, 2, 2, 0, 2)
+	p2.Fill(1, 1, 2, 1)
+	p2.Fill(3, 1, 2, 1)
+
+	s2 := NewS2D(Point2D{X: 1, Y: 2}, Point2D{X: 2, Y: 3, ErrY: Range{Min: 1, Max: 2}})
+	s2.Annotation()["title"] = "s2"
+
+	eff := NewEff1D(2, 0, 2)
+	eff.Fill(0.5, true, 1)
+	eff.Fill(1.5, false, 1)
+	eff.BinPriors = [][2]float64{{1, 1}, {2, 3}}
+
+	for _, tc := range []struct {
+		name string
+		want rio.Streamer
+		got  rio.Streamer
+	}{
+		{"H2D", h2, new(H2D)},
+		{"H3D", h3, new(H3D)},
+		{"P1D", p1, new(P1D)},
+		{"P2D", p2, new(P2D)},
+		{"S2D", s2, new(S2D)},
+		{"Eff1D", eff, new(Eff1D)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := tc.want.RioMarshal(buf)
+			if err != nil {
+				t.Fatalf("could not serialize: %+v", err)
+			}
+
+			err = tc.got.RioUnmarshal(buf)
+			if err != nil {
+				t.Fatalf("could not deserialize: %+v", err)
+			}
+
+			if !reflect.DeepEqual(tc.got, tc.want) {
+				t.Fatalf("r/w round-trip failed:\ngot= %+v\nwant=%+v", tc.got, tc.want)
+			}
+		})
+	}
+}
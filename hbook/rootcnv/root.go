@@ -3,6 +3,13 @@
 // license that can be found in the LICENSE file.
 
 // Package rootcnv provides tools to convert ROOT histograms and graphs to go-hep/hbook ones.
+//
+// Conversions preserve the name and title of the ROOT objects, as well as the
+// titles of their axes, stored under the "xlabel", "ylabel" and "zlabel" keys
+// of the hbook annotations.
+// The titles of the axes of graphs are only read, when the graph holds a
+// histogram.
+// Other annotations have no ROOT counterpart and are not converted.
 package rootcnv
 
 import (
@@ -16,7 +23,9 @@ type h1der interface {
 
 // H1D creates a new H1D from a TH1x.
 func H1D(h1 rhist.H1) *hbook.H1D {
-	return h1.(h1der).AsH1D()
+	h := h1.(h1der).AsH1D()
+	annFromAxes(h.Annotation(), h1)
+	return h
 }
 
 type h2der interface {
@@ -25,7 +34,27 @@ type h2der interface {
 
 // H2D creates a new H2D from a TH2x.
 func H2D(h2 rhist.H2) *hbook.H2D {
-	return h2.(h2der).AsH2D()
+	h := h2.(h2der).AsH2D()
+	annFromAxes(h.Annotation(), h2)
+	return h
+}
+
+type h3der interface {
+	AsH3D() *hbook.H3D
+}
+
+// H3D creates a new H3D from a TH3x.
+func H3D(h3 rhist.H3) *hbook.H3D {
+	h := h3.(h3der).AsH3D()
+	annFromAxes(h.Annotation(), h3)
+	return h
+}
+
+// P1D creates a new P1D from a TProfile.
+func P1D(p *rhist.Profile1D) *hbook.P1D {
+	pp := p.AsP1D()
+	annFromAxes(pp.Annotation(), p)
+	return pp
 }
 
 // S2D creates a new S2D from a TGraph, TGraphErrors or TGraphAsymmErrors.
@@ -49,20 +78,102 @@ func S2D(g rhist.Graph) *hbook.S2D {
 	s2d := hbook.NewS2D(pts...)
 	s2d.Annotation()["name"] = g.Name()
 	s2d.Annotation()["title"] = g.Title()
+	annFromAxes(s2d.Annotation(), g)
 	return s2d
 }
 
+// Eff1D creates a new Eff1D from a TEfficiency.
+// Eff1D returns an error if the efficiency is not 1-dimensional.
+func Eff1D(e *rhist.Efficiency) (*hbook.Eff1D, error) {
+	return e.AsEff1D()
+}
+
 // FromH1D creates a new ROOT TH1D from a 1-dim hbook histogram.
 func FromH1D(h1 *hbook.H1D) *rhist.H1D {
-	return rhist.NewH1DFrom(h1)
+	h := rhist.NewH1DFrom(h1)
+	annToAxes(h, h1.Annotation())
+	return h
 }
 
 // FromH2D creates a new ROOT TH2D from a 2-dim hbook histogram.
 func FromH2D(h2 *hbook.H2D) *rhist.H2D {
-	return rhist.NewH2DFrom(h2)
+	h := rhist.NewH2DFrom(h2)
+	annToAxes(h, h2.Annotation())
+	return h
+}
+
+// FromH3D creates a new ROOT TH3D from a 3-dim hbook histogram.
+func FromH3D(h3 *hbook.H3D) *rhist.H3D {
+	h := rhist.NewH3DFrom(h3)
+	annToAxes(h, h3.Annotation())
+	return h
+}
+
+// FromP1D creates a new ROOT TProfile from a 1-dim hbook profile histogram.
+func FromP1D(p *hbook.P1D) *rhist.Profile1D {
+	pp := rhist.NewProfile1DFrom(p)
+	annToAxes(pp, p.Annotation())
+	return pp
 }
 
 // FromS2D creates a new ROOT TGraphAsymmErrors from 2-dim hbook data points.
 func FromS2D(s2 *hbook.S2D) rhist.GraphErrors {
 	return rhist.NewGraphAsymmErrorsFrom(s2)
 }
+
+// FromS2DErrors creates a new ROOT TGraphErrors from 2-dim hbook data points.
+//
+// TGraphErrors only holds symmetric errors: the low errors of the points
+// are used.
+func FromS2DErrors(s2 *hbook.S2D) rhist.GraphErrors {
+	return rhist.NewGraphErrorsFrom(s2)
+}
+
+// FromEff1D creates a new ROOT TEfficiency from a 1-dim hbook efficiency.
+func FromEff1D(e *hbook.Eff1D) *rhist.Efficiency {
+	return rhist.NewEfficiencyFrom(e)
+}
+
+var axisLabels = [...]string{"xlabel", "ylabel", "zlabel"}
+
+// annFromAxes stores the titles of the axes of the ROOT object o
+// into the annotation ann.
+func annFromAxes(ann hbook.Annotation, o any) {
+	for i, axis := range axes(o) {
+		if axis == nil {
+			continue
+		}
+		if v := axis.Title(); v != "" {
+			ann[axisLabels[i]] = v
+		}
+	}
+}
+
+// annToAxes sets the titles of the axes of the ROOT object o
+// from the annotation ann.
+func annToAxes(o any, ann hbook.Annotation) {
+	for i, axis := range axes(o) {
+		axis, ok := axis.(interface{ SetTitle(string) })
+		if !ok {
+			continue
+		}
+		if v, ok := ann[axisLabels[i]].(string); ok {
+			axis.SetTitle(v)
+		}
+	}
+}
+
+// axes returns the x, y and z axes of the ROOT object o, if any.
+func axes(o any) [3]rhist.Axis {
+	var axes [3]rhist.Axis
+	if o, ok := o.(interface{ XAxis() rhist.Axis }); ok {
+		axes[0] = o.XAxis()
+	}
+	if o, ok := o.(interface{ YAxis() rhist.Axis }); ok {
+		axes[1] = o.YAxis()
+	}
+	if o, ok := o.(interface{ ZAxis() rhist.Axis }); ok {
+		axes[2] = o.ZAxis()
+	}
+	return axes
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"testing"
@@ -15,6 +16,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hbook/rootcnv"
@@ -561,6 +564,138 @@ func TestFromH2D(t *testing.T) {
 	}
 }
 
+func TestFromH2DVariableBins(t *testing.T) {
+	h := hbook.NewH2DFromEdges(
+		[]float64{0, 1, 3, 6},
+		[]float64{-1, 0, 0.5, 2},
+	)
+	h.Fill(0.5, -0.5, 1)
+	h.Fill(2.0, +0.2, 2)
+	h.Fill(5.0, +1.5, 4)
+
+	hh := rootcnv.H2D(rootcnv.FromH2D(h))
+
+	if got, want := hh.Binning.XEdges, h.Binning.XEdges; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid x-edges:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := hh.Binning.YEdges, h.Binning.YEdges; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid y-edges:\ngot= %v\nwant=%v", got, want)
+	}
+	if got, want := hh.Bin(2.0, 0.2).SumW(), 2.0; got != want {
+		t.Fatalf("invalid bin content: got=%v, want=%v", got, want)
+	}
+}
+
+func TestFromH3D(t *testing.T) {
+	const npoints = 10000
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	// Draw some random values from the standard
+	// normal distribution.
+	h := hbook.NewH3D(5, -4, +4, 6, -4, +4, 4, -4, +4)
+	for i := 0; i < npoints; i++ {
+		x := dist.Rand()
+		y := dist.Rand()
+		z := dist.Rand()
+		h.Fill(x, y, z, 1)
+	}
+	h.Fill(+0, +5, +0, 1)
+	h.Fill(-5, +5, +0, 2)
+	h.Fill(-5, -5, -5, 3)
+	h.Fill(+5, +0, +5, 4)
+	h.Fill(+5, +5, +5, 5)
+
+	h.Annotation()["name"] = "my-name"
+	h.Annotation()["title"] = "my-title"
+
+	for _, tc := range []struct {
+		name string
+		h3   rhist.H3
+	}{
+		{
+			name: "TH3D",
+			h3:   rootcnv.FromH3D(h),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, v := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"entries", tc.h3.Entries(), float64(h.Entries())},
+				{"sumw", tc.h3.SumW(), h.SumW()},
+				{"sumw2", tc.h3.SumW2(), h.SumW2()},
+				{"sumwx", tc.h3.SumWX(), h.SumWX()},
+				{"sumwx2", tc.h3.SumWX2(), h.SumWX2()},
+				{"sumwy", tc.h3.SumWY(), h.SumWY()},
+				{"sumwy2", tc.h3.SumWY2(), h.SumWY2()},
+				{"sumwxy", tc.h3.SumWXY(), h.SumWXY()},
+				{"sumwz", tc.h3.SumWZ(), h.SumWZ()},
+				{"sumwz2", tc.h3.SumWZ2(), h.SumWZ2()},
+				{"sumwxz", tc.h3.SumWXZ(), h.SumWXZ()},
+				{"sumwyz", tc.h3.SumWYZ(), h.SumWYZ()},
+			} {
+				if v.got != v.want {
+					t.Fatalf("%s: got=%v, want=%v", v.name, v.got, v.want)
+				}
+			}
+
+			if got, want := tc.h3.Name(), "my-name"; got != want {
+				t.Fatalf("name: got=%q, want=%q", got, want)
+			}
+			if got, want := tc.h3.Title(), "my-title"; got != want {
+				t.Fatalf("title: got=%q, want=%q", got, want)
+			}
+
+			hh := rootcnv.H3D(tc.h3)
+
+			if got, want := hh.Entries(), h.Entries(); got != want {
+				t.Fatalf("entries: got=%v, want=%v", got, want)
+			}
+			if got, want := hh.SumW(), h.SumW(); got != want {
+				t.Fatalf("sumw: got=%v, want=%v", got, want)
+			}
+			if got, want := hh.SumWXZ(), h.SumWXZ(); got != want {
+				t.Fatalf("sumwxz: got=%v, want=%v", got, want)
+			}
+			if got, want := hh.Binning.XEdges, h.Binning.XEdges; !reflect.DeepEqual(got, want) {
+				t.Fatalf("x-edges: got=%v, want=%v", got, want)
+			}
+			if got, want := hh.Binning.ZEdges, h.Binning.ZEdges; !reflect.DeepEqual(got, want) {
+				t.Fatalf("z-edges: got=%v, want=%v", got, want)
+			}
+			for i := range h.Binning.Bins {
+				var (
+					got  = hh.Binning.Bins[i].Dist
+					want = h.Binning.Bins[i].Dist
+				)
+				if got.SumW() != want.SumW() || got.SumW2() != want.SumW2() {
+					t.Fatalf("bin[%d]: got=(%v, %v), want=(%v, %v)",
+						i, got.SumW(), got.SumW2(), want.SumW(), want.SumW2(),
+					)
+				}
+			}
+			for i := range h.Binning.Outflows {
+				var (
+					got  = hh.Binning.Outflows[i]
+					want = h.Binning.Outflows[i]
+				)
+				if got.SumW() != want.SumW() || got.SumW2() != want.SumW2() {
+					t.Fatalf("outflow[%d]: got=(%v, %v), want=(%v, %v)",
+						i, got.SumW(), got.SumW2(), want.SumW(), want.SumW2(),
+					)
+				}
+			}
+		})
+	}
+}
+
 func TestFromS2D(t *testing.T) {
 	hg := hbook.NewS2D(
 		hbook.Point2D{X: 1, Y: 1, ErrX: hbook.Range{Min: 1, Max: 2}, ErrY: hbook.Range{Min: 3, Max: 4}},
@@ -591,3 +726,111 @@ func TestFromS2D(t *testing.T) {
 		)
 	}
 }
+
+func TestFromP1D(t *testing.T) {
+	p := hbook.NewP1D(10, -5, +5)
+	rnd := rand.New(rand.NewSource(0))
+	for i := 0; i < 1000; i++ {
+		x := 12*rnd.Float64() - 6
+		p.Fill(x, 2*x+rnd.NormFloat64(), 1)
+	}
+	p.Annotation()["name"] = "p1"
+	p.Annotation()["title"] = "my-title"
+	p.Annotation()["xlabel"] = "x [cm]"
+	p.Annotation()["ylabel"] = "<y> [cm]"
+
+	pr := rootcnv.P1D(roundTrip(t, rootcnv.FromP1D(p)).(*rhist.Profile1D))
+
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"entries", float64(pr.Entries()), float64(p.Entries())},
+		{"sumw", pr.SumW(), p.SumW()},
+		{"sumw2", pr.SumW2(), p.SumW2()},
+		{"sumwx", pr.SumWX(), p.SumWX()},
+		{"sumwx2", pr.SumWX2(), p.SumWX2()},
+		{"sumwy", pr.SumWY(), p.SumWY()},
+		{"sumwy2", pr.SumWY2(), p.SumWY2()},
+	} {
+		if tc.got != tc.want {
+			t.Fatalf("invalid %s: got=%v, want=%v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	for i, want := range p.Binning().Bins() {
+		got := pr.Binning().Bins()[i]
+		if got.XEdges() != want.XEdges() {
+			t.Fatalf("bin[%d]: invalid edges: got=%v, want=%v", i, got.XEdges(), want.XEdges())
+		}
+		if got.Entries() != want.Entries() || got.SumW() != want.SumW() ||
+			got.SumWY() != want.SumWY() || got.SumWY2() != want.SumWY2() {
+			t.Fatalf("bin[%d]: invalid content:\ngot= %+v\nwant=%+v", i, got, want)
+		}
+	}
+
+	if got, want := pr.Annotation(), p.Annotation(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid annotation:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestAxisLabels(t *testing.T) {
+	h1 := hbook.NewH1D(10, 0, 10)
+	h1.Fill(1, 1)
+	h1.Annotation()["name"] = "h1"
+	h1.Annotation()["title"] = "my-title"
+	h1.Annotation()["xlabel"] = "p_{T} [GeV]"
+	h1.Annotation()["ylabel"] = "events"
+
+	got := rootcnv.H1D(roundTrip(t, rootcnv.FromH1D(h1)).(rhist.H1))
+	if got, want := got.Annotation(), h1.Annotation(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid H1D annotation:\ngot= %v\nwant=%v", got, want)
+	}
+
+	h2 := hbook.NewH2D(10, 0, 10, 5, 0, 5)
+	h2.Fill(1, 1, 1)
+	h2.Annotation()["name"] = "h2"
+	h2.Annotation()["title"] = "my-title"
+	h2.Annotation()["xlabel"] = "x"
+	h2.Annotation()["ylabel"] = "y"
+	h2.Annotation()["zlabel"] = "z"
+
+	hh := rootcnv.H2D(roundTrip(t, rootcnv.FromH2D(h2)).(rhist.H2))
+	if got, want := hh.Annotation(), h2.Annotation(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid H2D annotation:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+// roundTrip writes obj to a ROOT file and reads it back.
+func roundTrip(t *testing.T, obj root.Object) root.Object {
+	t.Helper()
+
+	fname := filepath.Join(t.TempDir(), "cnv.root")
+	w, err := groot.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	err = w.Put("obj", obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	r, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	o, err := r.Get("obj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
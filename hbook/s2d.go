@@ -7,17 +7,24 @@ package hbook
 import (
 	"bufio"
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"math"
 	"sort"
-	"strings"
+
+	"go-hep.org/x/hep/rio"
+	"gonum.org/v1/gonum/mat"
 )
 
 // S2D is a collection of 2-dim data points with errors.
+//
+// S2D may optionally hold the covariance matrix of the Y values of its points,
+// to keep track of the point-to-point correlations.
 type S2D struct {
 	pts []Point2D
 	ann Annotation
+	cov symMatrix // covariance matrix of the Y values.
 }
 
 // NewS2D creates a new 2-dim scatter with pts as an optional
@@ -141,6 +148,33 @@ func NewS2DFromP1D(p *P1D, opts ...S2DOpts) *S2D {
 	return s
 }
 
+// NewS2DFromEff1D creates a new 2-dim scatter from the given Eff1D.
+// The Y errors of the scatter points hold the (possibly asymmetric)
+// confidence intervals of the efficiencies.
+func NewS2DFromEff1D(e *Eff1D) *S2D {
+	s := NewS2D()
+	for k, v := range e.Ann {
+		s.ann[k] = v
+	}
+	// YODA support
+	if _, ok := s.ann["Type"]; ok {
+		s.ann["Type"] = "Scatter2D"
+	}
+	for i, bin := range e.Total.Binning.Bins {
+		var (
+			x      = bin.XMid()
+			exm    = x - bin.XMin()
+			exp    = bin.XMax() - x
+			y      = e.Eff(i)
+			lo, hi = e.Interval(i)
+			eym    = math.Max(0, y-lo)
+			eyp    = math.Max(0, hi-y)
+		)
+		s.Fill(Point2D{X: x, Y: y, ErrX: Range{exm, exp}, ErrY: Range{eym, eyp}})
+	}
+	return s
+}
+
 // Annotation returns the annotations attached to the
 // scatter. (e.g. name, title, ...)
 func (s *S2D) Annotation() Annotation {
@@ -171,6 +205,10 @@ func (s *S2D) Entries() int64 {
 }
 
 // Fill adds new points to the scatter.
+//
+// If the scatter holds a covariance matrix, the new points are uncorrelated
+// with the other points, with a variance given by the square of their mean
+// Y error.
 func (s *S2D) Fill(pts ...Point2D) {
 	if len(pts) == 0 {
 		return
@@ -179,11 +217,110 @@ func (s *S2D) Fill(pts ...Point2D) {
 	i := len(s.pts)
 	s.pts = append(s.pts, make([]Point2D, len(pts))...)
 	copy(s.pts[i:], pts)
+
+	if len(s.cov) == 0 {
+		return
+	}
+	var (
+		n   = len(s.pts)
+		cov = make(symMatrix, n*n)
+	)
+	for j := 0; j < i; j++ {
+		copy(cov[j*n:j*n+i], s.cov[j*i:(j+1)*i])
+	}
+	for j := i; j < n; j++ {
+		ey := 0.5 * (s.pts[j].ErrY.Min + s.pts[j].ErrY.Max)
+		cov[j*n+j] = ey * ey
+	}
+	s.cov = cov
 }
 
 // Sort sorts the data points by x,y and x-err,y-err.
+// The covariance matrix of the Y values, if any, is reordered accordingly.
 func (s *S2D) Sort() {
-	sort.Sort(points2D(s.pts))
+	if len(s.cov) == 0 {
+		sort.Sort(points2D(s.pts))
+		return
+	}
+
+	idx := make([]int, len(s.pts))
+	for i := range idx {
+		idx[i] = i
+	}
+	pts := points2D(s.pts)
+	sort.SliceStable(idx, func(i, j int) bool {
+		return pts.Less(idx[i], idx[j])
+	})
+
+	var (
+		n    = len(s.pts)
+		opts = make([]Point2D, n)
+		ocov = make(symMatrix, n*n)
+	)
+	for i, ii := range idx {
+		opts[i] = s.pts[ii]
+		for j, jj := range idx {
+			ocov[i*n+j] = s.cov[ii*n+jj]
+		}
+	}
+	s.pts = opts
+	s.cov = ocov
+}
+
+// CovY returns the covariance matrix of the Y values of the points of the
+// scatter, or nil if the scatter has no covariance matrix.
+//
+// The returned matrix is a copy: modifying it does not modify the scatter.
+func (s *S2D) CovY() *mat.SymDense {
+	if len(s.cov) == 0 {
+		return nil
+	}
+	return mat.NewSymDense(len(s.pts), append([]float64(nil), s.cov...))
+}
+
+// SetCovY sets the covariance matrix of the Y values of the points of the
+// scatter. A nil matrix removes the covariance matrix from the scatter.
+//
+// SetCovY does not modify the Y errors of the points.
+// SetCovY panics if the dimension of the matrix does not match the number
+// of points of the scatter.
+func (s *S2D) SetCovY(cov mat.Symmetric) {
+	if cov == nil {
+		s.cov = nil
+		return
+	}
+	n := len(s.pts)
+	if cov.SymmetricDim() != n {
+		panic(fmt.Errorf(
+			"hbook: invalid covariance matrix dimension (got=%d, want=%d)",
+			cov.SymmetricDim(), n,
+		))
+	}
+	s.cov = make(symMatrix, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			s.cov[i*n+j] = cov.At(i, j)
+		}
+	}
+}
+
+// CorrY returns the correlation coefficient between the Y values of
+// the points i and j.
+// CorrY returns 0 if the scatter has no covariance matrix and i != j.
+//
+// CorrY panics if i or j are out of bounds.
+func (s *S2D) CorrY(i, j int) float64 {
+	n := len(s.pts)
+	if i < 0 || i >= n || j < 0 || j >= n {
+		panic("hbook: index out of range")
+	}
+	if i == j {
+		return 1
+	}
+	if len(s.cov) == 0 {
+		return 0
+	}
+	return s.cov[i*n+j] / math.Sqrt(s.cov[i*n+i]*s.cov[j*n+j])
 }
 
 // Points returns the points of the scatter.
@@ -216,6 +353,7 @@ func (s *S2D) ScaleY(f float64) {
 		p := &s.pts[i]
 		p.ScaleY(f)
 	}
+	s.scaleCovY(f)
 }
 
 // ScaleXY rescales the X and Y values by a factor f.
@@ -225,6 +363,14 @@ func (s *S2D) ScaleXY(f float64) {
 		p.ScaleX(f)
 		p.ScaleY(f)
 	}
+	s.scaleCovY(f)
+}
+
+func (s *S2D) scaleCovY(f float64) {
+	f2 := f * f
+	for i := range s.cov {
+		s.cov[i] *= f2
+	}
 }
 
 // Len returns the number of points in the scatter.
@@ -278,23 +424,57 @@ func (s *S2D) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return
 }
 
+// symMatrix is a dense symmetric matrix, stored in row-major order.
+type symMatrix []float64
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (m symMatrix) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8*len(m))
+	for i, v := range m {
+		binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(v))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (m *symMatrix) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return fmt.Errorf("hbook: invalid symmetric matrix binary size %d", len(data))
+	}
+	if len(data) == 0 {
+		*m = nil
+		return nil
+	}
+	*m = make(symMatrix, len(data)/8)
+	for i := range *m {
+		(*m)[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	return nil
+}
+
+// serialization interfaces
+var _ rio.Marshaler = (*S2D)(nil)
+var _ rio.Unmarshaler = (*S2D)(nil)
+var _ rio.Streamer = (*S2D)(nil)
+
+// RioMarshal implements rio.RioMarshaler
+func (s *S2D) RioMarshal(w io.Writer) error {
+	return rioMarshal(w, s)
+}
+
+// RioUnmarshal implements rio.RioUnmarshaler
+func (s *S2D) RioUnmarshal(r io.Reader) error {
+	return rioUnmarshal(r, s)
+}
+
+// RioVersion implements rio.RioStreamer
+func (s *S2D) RioVersion() rio.Version {
+	return 0
+}
+
 // annToYODA creates a new Annotation with fields compatible with YODA
 func (s *S2D) annToYODA() Annotation {
-	ann := make(Annotation, len(s.ann))
-	ann["Type"] = "Scatter2D"
-	ann["Path"] = "/" + s.Name()
-	ann["Title"] = ""
-	for k, v := range s.ann {
-		if k == "name" {
-			continue
-		}
-		if k == "title" {
-			ann["Title"] = v
-			continue
-		}
-		ann[k] = v
-	}
-	return ann
+	return s.ann.toYODA("Scatter2D", s.Name())
 }
 
 // annFromYODA creates a new Annotation from YODA compatible fields
@@ -302,20 +482,7 @@ func (s *S2D) annFromYODA(ann Annotation) {
 	if len(s.ann) == 0 {
 		s.ann = make(Annotation, len(ann))
 	}
-	for k, v := range ann {
-		switch k {
-		case "Type":
-			// noop
-		case "Path":
-			name := v.(string)
-			name = strings.TrimPrefix(name, "/")
-			s.ann["name"] = name
-		case "Title":
-			s.ann["title"] = v
-		default:
-			s.ann[k] = v
-		}
-	}
+	s.ann.fromYODA(ann)
 }
 
 // MarshalYODA implements the YODAMarshaler interface.
@@ -323,6 +490,11 @@ func (s *S2D) MarshalYODA() ([]byte, error) {
 	return s.marshalYODAv2()
 }
 
+// MarshalYODA2 marshals the scatter into the YODA2 format.
+func (s *S2D) MarshalYODA2() ([]byte, error) {
+	return s.marshalYODAv3()
+}
+
 func (s *S2D) marshalYODAv1() ([]byte, error) {
 	buf := new(bytes.Buffer)
 	ann := s.annToYODA()
@@ -372,9 +544,49 @@ func (s *S2D) marshalYODAv2() ([]byte, error) {
 	return buf.Bytes(), err
 }
 
+func (s *S2D) marshalYODAv3() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	ann := s.annToYODA()
+	fmt.Fprintf(buf, "BEGIN YODA_SCATTER2D_V3 %s\n", ann["Path"])
+	data, err := ann.marshalYODAv2()
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(data)
+	buf.Write([]byte("---\n"))
+
+	fmt.Fprintf(buf, "# xval\t xerr-\t xerr+\t yval\t yerr-\t yerr+\n")
+	s.Sort()
+	for _, pt := range s.pts {
+		fmt.Fprintf(
+			buf,
+			"%e\t%e\t%e\t%e\t%e\t%e\n",
+			pt.X, pt.ErrX.Min, pt.ErrX.Max, pt.Y, pt.ErrY.Min, pt.ErrY.Max,
+		)
+	}
+	fmt.Fprintf(buf, "END YODA_SCATTER2D_V3\n\n")
+	return buf.Bytes(), err
+}
+
 // UnmarshalYODA implements the YODAUnmarshaler interface.
+//
+// UnmarshalYODA also decodes YODA2 1-dim estimates, where each in-range bin
+// is converted into a point, with the half-width of the bin as the error
+// along X, and the quadratic sum of all the error sources as the error
+// along Y.
 func (s *S2D) UnmarshalYODA(data []byte) error {
 	r := newRBuffer(data)
+	if bytes.HasPrefix(data, []byte("BEGIN YODA_ESTIMATE1D")) {
+		_, vers, err := readYODAHeader(r, "BEGIN YODA_ESTIMATE1D")
+		if err != nil {
+			return err
+		}
+		if vers != 3 {
+			return fmt.Errorf("hbook: invalid YODA version %v", vers)
+		}
+		return s.unmarshalYODAEstimate1D(r)
+	}
+
 	_, vers, err := readYODAHeader(r, "BEGIN YODA_SCATTER2D")
 	if err != nil {
 		return err
@@ -384,6 +596,8 @@ func (s *S2D) UnmarshalYODA(data []byte) error {
 		return s.unmarshalYODAv1(r)
 	case 2:
 		return s.unmarshalYODAv2(r)
+	case 3:
+		return s.unmarshalYODAv3(r)
 	default:
 		return fmt.Errorf("hbook: invalid YODA version %v", vers)
 	}
@@ -482,3 +696,77 @@ scanLoop:
 	s.Sort()
 	return err
 }
+
+func (s *S2D) unmarshalYODAv3(r *rbuffer) error {
+	ann, err := readYODA2Ann(r)
+	if err != nil {
+		return err
+	}
+	s.annFromYODA(ann)
+
+	body, err := readYODA2Body(r, 6)
+	if err != nil {
+		return err
+	}
+	for _, row := range body.rows {
+		s.Fill(Point2D{
+			X:    row[0],
+			ErrX: Range{Min: row[1], Max: row[2]},
+			Y:    row[3],
+			ErrY: Range{Min: row[4], Max: row[5]},
+		})
+	}
+	s.Sort()
+	return nil
+}
+
+func (s *S2D) unmarshalYODAEstimate1D(r *rbuffer) error {
+	ann, err := readYODA2Ann(r)
+	if err != nil {
+		return err
+	}
+	s.annFromYODA(ann)
+
+	body, err := readYODA2Body(r, -1)
+	if err != nil {
+		return err
+	}
+	if len(body.edges) != 1 {
+		return fmt.Errorf("hbook: invalid Estimate1D-YODA2 number of axes (got=%d, want=1)", len(body.edges))
+	}
+	var (
+		edges = body.edges[0]
+		n     = len(edges) - 1
+		ncols = 1 + 2*len(body.labels)
+	)
+	if n < 1 || len(body.rows) != n+2 {
+		return fmt.Errorf("hbook: invalid Estimate1D-YODA2 number of bins (got=%d, want=%d)", len(body.rows), n+2)
+	}
+	for i, row := range body.rows {
+		if len(row) != ncols {
+			return fmt.Errorf("hbook: invalid Estimate1D-YODA2 row %d (got=%d columns, want=%d)", i, len(row), ncols)
+		}
+		if i == 0 || i == n+1 || body.isMasked(i) {
+			continue
+		}
+		var (
+			xlo = edges[i-1]
+			xhi = edges[i]
+			dx  = 0.5 * (xhi - xlo)
+			dn  = 0.0
+			up  = 0.0
+		)
+		for j := 1; j < len(row); j += 2 {
+			dn += row[j] * row[j]
+			up += row[j+1] * row[j+1]
+		}
+		s.Fill(Point2D{
+			X:    xlo + dx,
+			ErrX: Range{Min: dx, Max: dx},
+			Y:    row[0],
+			ErrY: Range{Min: math.Sqrt(dn), Max: math.Sqrt(up)},
+		})
+	}
+	s.Sort()
+	return nil
+}
@@ -7,12 +7,15 @@ package hbook
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
+	"math"
 	"os"
 	"reflect"
 	"runtime"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"gonum.org/v1/gonum/mat"
 )
 
 func TestS2D(t *testing.T) {
@@ -37,6 +40,39 @@ func TestS2D(t *testing.T) {
 	}
 }
 
+func TestS2DFromEff1D(t *testing.T) {
+	e := NewEff1D(2, 0, 2)
+	e.Ann["name"] = "eff"
+	e.Stat = EffWilson
+	for i := 0; i < 10; i++ {
+		e.Fill(0.5, i < 3, 1)
+	}
+
+	s := NewS2DFromEff1D(e)
+	if got, want := s.Name(), "eff"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := s.Len(), 2; got != want {
+		t.Fatalf("invalid len: got=%d, want=%d", got, want)
+	}
+
+	lo, hi := e.Interval(0)
+	want := Point2D{
+		X:    0.5,
+		Y:    0.3,
+		ErrX: Range{Min: 0.5, Max: 0.5},
+		ErrY: Range{Min: 0.3 - lo, Max: hi - 0.3},
+	}
+	if got := s.Point(0); got != want {
+		t.Fatalf("invalid pt[0]:\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	// empty bin.
+	if got, want := s.Point(1).ErrY, (Range{Min: 0, Max: 1}); got != want {
+		t.Fatalf("invalid empty-bin errors: got=%+v, want=%+v", got, want)
+	}
+}
+
 func TestS2DWriteYODA(t *testing.T) {
 	h := NewH1D(20, -4, +4)
 	h.Fill(1, 2)
@@ -156,3 +192,90 @@ func TestS2DSerialization(t *testing.T) {
 		}
 	}
 }
+
+func TestS2DCovY(t *testing.T) {
+	s := NewS2D(
+		Point2D{X: 2, Y: 1, ErrY: Range{Min: 1, Max: 1}},
+		Point2D{X: 0, Y: 2, ErrY: Range{Min: 2, Max: 2}},
+		Point2D{X: 1, Y: 3, ErrY: Range{Min: 3, Max: 3}},
+	)
+	if s.CovY() != nil {
+		t.Fatalf("unexpected covariance matrix")
+	}
+	if got := s.CorrY(0, 1); got != 0 {
+		t.Fatalf("invalid correlation: got=%v, want=0", got)
+	}
+
+	s.SetCovY(mat.NewSymDense(3, []float64{
+		1.0, 0.5, 0.0,
+		0.5, 4.0, 1.2,
+		0.0, 1.2, 9.0,
+	}))
+	if got, want := s.CorrY(1, 2), 0.2; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid correlation: got=%v, want=%v", got, want)
+	}
+
+	s.Sort()
+	s.Fill(Point2D{X: 3, Y: 4, ErrY: Range{Min: 1, Max: 3}})
+	s.ScaleY(2)
+
+	want := mat.NewSymDense(4, []float64{
+		16.0, 4.8, 2.0, 0,
+		4.8, 36.0, 0.0, 0,
+		2.0, 0.0, 4.0, 0,
+		0.0, 0.0, 0.0, 16,
+	})
+	if got := s.CovY(); !mat.EqualApprox(got, want, 1e-12) {
+		t.Fatalf("invalid covariance matrix:\ngot= %v\nwant=%v", mat.Formatted(got), mat.Formatted(want))
+	}
+
+	{
+		buf := new(bytes.Buffer)
+		err := gob.NewEncoder(buf).Encode(s)
+		if err != nil {
+			t.Fatalf("could not serialize scatter2d: %+v", err)
+		}
+
+		var got S2D
+		err = gob.NewDecoder(buf).Decode(&got)
+		if err != nil {
+			t.Fatalf("could not deserialize scatter2d: %+v", err)
+		}
+		if !reflect.DeepEqual(s, &got) {
+			t.Fatalf("ref=%v\nnew=%v\n", s, &got)
+		}
+	}
+
+	{
+		raw, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("could not marshal scatter2d: %+v", err)
+		}
+
+		var got S2D
+		err = json.Unmarshal(raw, &got)
+		if err != nil {
+			t.Fatalf("could not unmarshal scatter2d: %+v", err)
+		}
+		if !reflect.DeepEqual(got.cov, s.cov) {
+			t.Fatalf("invalid covariance matrix:\ngot= %v\nwant=%v", got.cov, s.cov)
+		}
+	}
+
+	s.SetCovY(nil)
+	if s.CovY() != nil {
+		t.Fatalf("unexpected covariance matrix")
+	}
+
+	defer func() {
+		e := recover()
+		if e == nil {
+			t.Fatalf("expected a panic")
+		}
+		const want = "hbook: invalid covariance matrix dimension (got=2, want=4)"
+		if got := e.(error).Error(); got != want {
+			t.Fatalf("invalid panic message:\ngot= %q\nwant=%q", got, want)
+		}
+	}()
+	s.SetCovY(mat.NewSymDense(2, nil))
+}
@@ -0,0 +1,104 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"sync"
+)
+
+// XW is a value x and its weight w, as filled into a histogram.
+type XW struct {
+	X float64
+	W float64
+}
+
+// FillStream fills a 1-dim histogram with the (x,w) pairs sent over
+// a channel, possibly from multiple producer goroutines.
+//
+// The pairs are received by a single goroutine and filled into the
+// histogram by batches, so the histogram can be inspected, with H1D,
+// while the stream is running.
+type FillStream struct {
+	c    chan XW
+	done chan struct{}
+
+	mu  sync.Mutex
+	h   *H1D
+	buf []XW
+}
+
+// NewFillStream returns a new stream filling the provided histogram.
+// The channel of the stream is buffered with n pairs, and at most n pairs
+// are filled into the histogram at once.
+// NewFillStream panics if n is not strictly positive.
+func NewFillStream(h *H1D, n int) *FillStream {
+	if n <= 0 {
+		panic("hbook: invalid fill-stream batch size")
+	}
+	s := &FillStream{
+		c:    make(chan XW, n),
+		done: make(chan struct{}),
+		h:    h,
+		buf:  make([]XW, 0, n),
+	}
+	go s.run()
+	return s
+}
+
+// C returns the channel over which (x,w) pairs are sent to the histogram.
+func (s *FillStream) C() chan<- XW {
+	return s.c
+}
+
+// Fill sends x and weight w to the histogram.
+func (s *FillStream) Fill(x, w float64) {
+	s.c <- XW{X: x, W: w}
+}
+
+// H1D returns a snapshot of the histogram, with all the pairs
+// filled so far.
+func (s *FillStream) H1D() *H1D {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Clone()
+}
+
+// Close closes the channel of the stream, waits for all the pending
+// pairs to be filled and returns the histogram.
+//
+// Close must be called once, after all the producers are done sending.
+func (s *FillStream) Close() *H1D {
+	close(s.c)
+	<-s.done
+	return s.h
+}
+
+func (s *FillStream) run() {
+	defer close(s.done)
+	for xw := range s.c {
+		s.buf = append(s.buf[:0], xw)
+	batch:
+		for len(s.buf) < cap(s.buf) {
+			select {
+			case xw, ok := <-s.c:
+				if !ok {
+					break batch
+				}
+				s.buf = append(s.buf, xw)
+			default:
+				break batch
+			}
+		}
+		s.flush()
+	}
+}
+
+func (s *FillStream) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, xw := range s.buf {
+		s.h.Fill(xw.X, xw.W)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook_test
+
+import (
+	"fmt"
+	"sync"
+
+	"go-hep.org/x/hep/hbook"
+)
+
+func ExampleFillStream() {
+	var (
+		h  = hbook.NewH1D(10, 0, 10)
+		s  = hbook.NewFillStream(h, 64)
+		wg sync.WaitGroup
+	)
+
+	const nworkers = 4
+	wg.Add(nworkers)
+	for i := 0; i < nworkers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			// producers send (x,w) pairs over the channel of the stream.
+			for j := 0; j < 100; j++ {
+				s.C() <- hbook.XW{X: float64(i + j%10), W: 1}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// wait for all the pairs to be filled into h.
+	s.Close()
+
+	fmt.Printf("entries:  %d\n", h.Entries())
+	fmt.Printf("sumw:     %v\n", h.SumW())
+	fmt.Printf("overflow: %v\n", h.Binning.Overflow().SumW())
+
+	// Output:
+	// entries:  400
+	// sumw:     400
+	// overflow: 60
+}
@@ -0,0 +1,80 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestFillStream(t *testing.T) {
+	const (
+		nworkers = 8
+		nevts    = 1000
+	)
+
+	for _, n := range []int{1, 16, 1024} {
+		var (
+			want = NewH1D(10, 0, 10)
+			got  = NewH1D(10, 0, 10)
+			s    = NewFillStream(got, n)
+			wg   sync.WaitGroup
+		)
+
+		// values and weights are exactly representable so that
+		// the order of the summations is irrelevant.
+		xval := func(i, j int) float64 { return float64((i*nevts+j)%12) - 0.5 }
+		wval := func(i, j int) float64 { return float64(1 + (i+j)%3) }
+
+		for i := 0; i < nworkers; i++ {
+			for j := 0; j < nevts; j++ {
+				want.Fill(xval(i, j), wval(i, j))
+			}
+		}
+
+		wg.Add(nworkers)
+		for i := 0; i < nworkers; i++ {
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < nevts; j++ {
+					if j%2 == 0 {
+						s.Fill(xval(i, j), wval(i, j))
+						continue
+					}
+					s.C() <- XW{X: xval(i, j), W: wval(i, j)}
+				}
+			}(i)
+		}
+
+		// snapshots may be taken while producers are running.
+		if snap := s.H1D(); snap == got || snap.Entries() > want.Entries() {
+			t.Fatalf("n=%d: invalid snapshot", n)
+		}
+
+		wg.Wait()
+
+		if o := s.Close(); o != got {
+			t.Fatalf("n=%d: invalid closed histogram", n)
+		}
+
+		if !reflect.DeepEqual(got.Binning, want.Binning) {
+			t.Fatalf("n=%d: invalid binning:\ngot= %+v\nwant=%+v", n, got.Binning, want.Binning)
+		}
+	}
+}
+
+func TestFillStreamInvalidSize(t *testing.T) {
+	defer func() {
+		e := recover()
+		if e == nil {
+			t.Fatalf("expected a panic")
+		}
+		if got, want := e.(string), "hbook: invalid fill-stream batch size"; got != want {
+			t.Fatalf("invalid panic message: got=%q, want=%q", got, want)
+		}
+	}()
+	_ = NewFillStream(NewH1D(10, 0, 10), 0)
+}
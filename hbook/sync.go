@@ -0,0 +1,144 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import "sync"
+
+// SyncH1D is a 1-dim histogram that can be filled concurrently
+// from multiple goroutines.
+//
+// Each goroutine fills its own shard of the histogram, as returned by Shard,
+// without any synchronization.
+// The shards are merged into the histogram when Flush is called.
+//
+// Shard and Flush are safe for concurrent use.
+type SyncH1D struct {
+	h      *H1D
+	shards shards[*H1D]
+}
+
+// NewSyncH1D returns a new concurrent-safe filler for the provided histogram.
+func NewSyncH1D(h *H1D) *SyncH1D {
+	return &SyncH1D{h: h}
+}
+
+// Shard returns a new empty histogram, with the same binning as the
+// underlying histogram, to be filled by a single goroutine.
+func (s *SyncH1D) Shard() *H1D {
+	return s.shards.add(&H1D{
+		Binning: s.h.Binning.empty(),
+		Ann:     make(Annotation),
+	})
+}
+
+// Flush merges all the shards into the underlying histogram and returns it.
+//
+// Flush must be called once the goroutines filling the shards are done.
+// The shards are released and must not be filled afterwards.
+func (s *SyncH1D) Flush() *H1D {
+	s.shards.flush(func(shard *H1D) {
+		s.h.Binning.addScaled(1, 1, &shard.Binning)
+	})
+	return s.h
+}
+
+// SyncH2D is a 2-dim histogram that can be filled concurrently
+// from multiple goroutines.
+//
+// Each goroutine fills its own shard of the histogram, as returned by Shard,
+// without any synchronization.
+// The shards are merged into the histogram when Flush is called.
+//
+// Shard and Flush are safe for concurrent use.
+type SyncH2D struct {
+	h      *H2D
+	shards shards[*H2D]
+}
+
+// NewSyncH2D returns a new concurrent-safe filler for the provided histogram.
+func NewSyncH2D(h *H2D) *SyncH2D {
+	return &SyncH2D{h: h}
+}
+
+// Shard returns a new empty histogram, with the same binning as the
+// underlying histogram, to be filled by a single goroutine.
+func (s *SyncH2D) Shard() *H2D {
+	return s.shards.add(&H2D{
+		Binning: s.h.Binning.empty(),
+		Ann:     make(Annotation),
+	})
+}
+
+// Flush merges all the shards into the underlying histogram and returns it.
+//
+// Flush must be called once the goroutines filling the shards are done.
+// The shards are released and must not be filled afterwards.
+func (s *SyncH2D) Flush() *H2D {
+	s.shards.flush(func(shard *H2D) {
+		s.h.Binning.addScaled(1, 1, &shard.Binning)
+	})
+	return s.h
+}
+
+// SyncH3D is a 3-dim histogram that can be filled concurrently
+// from multiple goroutines.
+//
+// Each goroutine fills its own shard of the histogram, as returned by Shard,
+// without any synchronization.
+// The shards are merged into the histogram when Flush is called.
+//
+// Shard and Flush are safe for concurrent use.
+type SyncH3D struct {
+	h      *H3D
+	shards shards[*H3D]
+}
+
+// NewSyncH3D returns a new concurrent-safe filler for the provided histogram.
+func NewSyncH3D(h *H3D) *SyncH3D {
+	return &SyncH3D{h: h}
+}
+
+// Shard returns a new empty histogram, with the same binning as the
+// underlying histogram, to be filled by a single goroutine.
+func (s *SyncH3D) Shard() *H3D {
+	return s.shards.add(&H3D{
+		Binning: s.h.Binning.empty(),
+		Ann:     make(Annotation),
+	})
+}
+
+// Flush merges all the shards into the underlying histogram and returns it.
+//
+// Flush must be called once the goroutines filling the shards are done.
+// The shards are released and must not be filled afterwards.
+func (s *SyncH3D) Flush() *H3D {
+	s.shards.flush(func(shard *H3D) {
+		s.h.Binning.addScaled(1, 1, &shard.Binning)
+	})
+	return s.h
+}
+
+// shards holds the histograms filled by the goroutines.
+type shards[T any] struct {
+	mu sync.Mutex
+	hs []T
+}
+
+func (s *shards[T]) add(h T) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hs = append(s.hs, h)
+	return h
+}
+
+// flush merges all the shards with the provided function and releases them.
+func (s *shards[T]) flush(merge func(h T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, h := range s.hs {
+		merge(h)
+	}
+	s.hs = nil
+}
@@ -0,0 +1,46 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook_test
+
+import (
+	"fmt"
+	"sync"
+
+	"go-hep.org/x/hep/hbook"
+)
+
+func ExampleSyncH1D() {
+	var (
+		h  = hbook.NewH1D(10, 0, 10)
+		sh = hbook.NewSyncH1D(h)
+		wg sync.WaitGroup
+	)
+
+	const nworkers = 4
+	wg.Add(nworkers)
+	for i := 0; i < nworkers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			// each goroutine fills its own shard, without locking.
+			shard := sh.Shard()
+			for j := 0; j < 100; j++ {
+				shard.Fill(float64(i+j%10), 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// merge all the shards into h.
+	sh.Flush()
+
+	fmt.Printf("entries:  %d\n", h.Entries())
+	fmt.Printf("sumw:     %v\n", h.SumW())
+	fmt.Printf("overflow: %v\n", h.Binning.Overflow().SumW())
+
+	// Output:
+	// entries:  400
+	// sumw:     400
+	// overflow: 60
+}
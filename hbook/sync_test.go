@@ -0,0 +1,143 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hbook
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSyncH1D(t *testing.T) {
+	const (
+		nworkers = 8
+		nevts    = 1000
+	)
+
+	var (
+		want = NewH1D(10, 0, 10)
+		got  = NewH1D(10, 0, 10)
+		sh   = NewSyncH1D(got)
+		wg   sync.WaitGroup
+	)
+
+	// values and weights are exactly representable so that
+	// the order of the summations is irrelevant.
+	xval := func(i, j int) float64 { return float64((i*nevts+j)%12) - 0.5 }
+	wval := func(i, j int) float64 { return float64(1 + (i+j)%3) }
+
+	for i := 0; i < nworkers; i++ {
+		for j := 0; j < nevts; j++ {
+			want.Fill(xval(i, j), wval(i, j))
+		}
+	}
+
+	wg.Add(nworkers)
+	for i := 0; i < nworkers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			h := sh.Shard()
+			for j := 0; j < nevts; j++ {
+				h.Fill(xval(i, j), wval(i, j))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if o := sh.Flush(); o != got {
+		t.Fatalf("invalid flushed histogram")
+	}
+
+	if !reflect.DeepEqual(got.Binning, want.Binning) {
+		t.Fatalf("invalid binning:\ngot= %+v\nwant=%+v", got.Binning, want.Binning)
+	}
+
+	// shards have been released.
+	sh.Flush()
+	if !reflect.DeepEqual(got.Binning, want.Binning) {
+		t.Fatalf("invalid binning after second flush")
+	}
+}
+
+func TestSyncH2D(t *testing.T) {
+	const (
+		nworkers = 8
+		nevts    = 1000
+	)
+
+	var (
+		want = NewH2DFromEdges([]float64{0, 1, 3, 6}, []float64{0, 2, 3})
+		got  = NewH2DFromEdges([]float64{0, 1, 3, 6}, []float64{0, 2, 3})
+		sh   = NewSyncH2D(got)
+		wg   sync.WaitGroup
+	)
+
+	xval := func(i, j int) float64 { return float64((i*nevts+j)%8) - 0.5 }
+	yval := func(i, j int) float64 { return float64((i+j)%5) - 0.5 }
+
+	for i := 0; i < nworkers; i++ {
+		for j := 0; j < nevts; j++ {
+			want.Fill(xval(i, j), yval(i, j), 1)
+		}
+	}
+
+	wg.Add(nworkers)
+	for i := 0; i < nworkers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			h := sh.Shard()
+			for j := 0; j < nevts; j++ {
+				h.Fill(xval(i, j), yval(i, j), 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	sh.Flush()
+
+	if !reflect.DeepEqual(got.Binning, want.Binning) {
+		t.Fatalf("invalid binning:\ngot= %+v\nwant=%+v", got.Binning, want.Binning)
+	}
+}
+
+func TestSyncH3D(t *testing.T) {
+	const (
+		nworkers = 8
+		nevts    = 1000
+	)
+
+	var (
+		want = NewH3D(3, 0, 3, 2, 0, 2, 4, 0, 4)
+		got  = NewH3D(3, 0, 3, 2, 0, 2, 4, 0, 4)
+		sh   = NewSyncH3D(got)
+		wg   sync.WaitGroup
+	)
+
+	xval := func(i, j int) float64 { return float64((i*nevts+j)%5) - 0.5 }
+	yval := func(i, j int) float64 { return float64((i+j)%4) - 0.5 }
+	zval := func(i, j int) float64 { return float64(j%6) - 0.5 }
+
+	for i := 0; i < nworkers; i++ {
+		for j := 0; j < nevts; j++ {
+			want.Fill(xval(i, j), yval(i, j), zval(i, j), 2)
+		}
+	}
+
+	wg.Add(nworkers)
+	for i := 0; i < nworkers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			h := sh.Shard()
+			for j := 0; j < nevts; j++ {
+				h.Fill(xval(i, j), yval(i, j), zval(i, j), 2)
+			}
+		}(i)
+	}
+	wg.Wait()
+	sh.Flush()
+
+	if !reflect.DeepEqual(got.Binning, want.Binning) {
+		t.Fatalf("invalid binning:\ngot= %+v\nwant=%+v", got.Binning, want.Binning)
+	}
+}
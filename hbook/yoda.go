@@ -5,8 +5,10 @@
 package hbook // import "go-hep.org/x/hep/hbook"
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -23,6 +25,9 @@ func readYODAHeader(r *rbuffer, hdr string) (string, int, error) {
 		vers int
 	)
 	switch {
+	case strings.HasPrefix(path, hdr+"_V3 "):
+		hdr += "_V3"
+		vers = 3
 	case strings.HasPrefix(path, hdr+"_V2 "):
 		hdr += "_V2"
 		vers = 2
@@ -34,3 +39,141 @@ func readYODAHeader(r *rbuffer, hdr string) (string, int, error) {
 
 	return path[len(hdr)+1 : len(path)-1], vers, nil
 }
+
+// readYODA2Ann parses the annotations of a YODA2 object, up to the
+// "---" separator line.
+func readYODA2Ann(r *rbuffer) (Annotation, error) {
+	const sep = "---\n"
+	pos := bytes.Index(r.Bytes(), []byte("\n"+sep))
+	if pos < 0 {
+		return nil, fmt.Errorf("hbook: could not find YODA2 annotations separator")
+	}
+	ann := make(Annotation)
+	err := ann.unmarshalYODAv2(r.Bytes()[:pos+1])
+	if err != nil {
+		return nil, fmt.Errorf("hbook: %q\nhbook: %w", string(r.Bytes()[:pos+1]), err)
+	}
+	r.next(pos + 1 + len(sep))
+	return ann, nil
+}
+
+// yoda2Body is the content of a YODA2 object, after its annotations.
+type yoda2Body struct {
+	edges  [][]float64 // bin edges of each axis
+	masked []int       // global indices of the masked bins
+	labels []string    // labels of the error sources
+	rows   [][]float64 // rows of values, including the outflow bins
+}
+
+// isMasked returns whether the i-th global bin is masked.
+func (b *yoda2Body) isMasked(i int) bool {
+	for _, j := range b.masked {
+		if i == j {
+			return true
+		}
+	}
+	return false
+}
+
+// readYODA2Body parses the content of a YODA2 object, up to its end marker.
+// Each row must contain exactly ncols values, unless ncols is negative.
+func readYODA2Body(r *rbuffer, ncols int) (yoda2Body, error) {
+	var (
+		body yoda2Body
+		sc   = bufio.NewScanner(r)
+	)
+scanLoop:
+	for sc.Scan() {
+		txt := strings.TrimSpace(sc.Text())
+		if len(txt) == 0 || txt[0] == '#' {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(txt, "END YODA_"):
+			break scanLoop
+		case strings.HasPrefix(txt, "Edges(A"):
+			vs, err := parseYODA2List(txt)
+			if err != nil {
+				return body, err
+			}
+			edges := make([]float64, len(vs))
+			for i, v := range vs {
+				edges[i], err = strconv.ParseFloat(v, 64)
+				if err != nil {
+					return body, fmt.Errorf("hbook: could not parse YODA2 edges %q: %w", txt, err)
+				}
+			}
+			for i := 1; i < len(edges); i++ {
+				if !(edges[i-1] < edges[i]) {
+					return body, fmt.Errorf("hbook: invalid YODA2 edges %q (not strictly increasing)", txt)
+				}
+			}
+			body.edges = append(body.edges, edges)
+		case strings.HasPrefix(txt, "MaskedBins:"):
+			vs, err := parseYODA2List(txt)
+			if err != nil {
+				return body, err
+			}
+			for _, v := range vs {
+				i, err := strconv.Atoi(v)
+				if err != nil {
+					return body, fmt.Errorf("hbook: could not parse YODA2 masked bins %q: %w", txt, err)
+				}
+				body.masked = append(body.masked, i)
+			}
+		case strings.HasPrefix(txt, "ErrorLabels:"):
+			vs, err := parseYODA2List(txt)
+			if err != nil {
+				return body, err
+			}
+			for _, v := range vs {
+				body.labels = append(body.labels, strings.Trim(v, `"`))
+			}
+		default:
+			fields := strings.Fields(txt)
+			if ncols >= 0 && len(fields) != ncols {
+				return body, fmt.Errorf("hbook: invalid YODA2 row %q (got=%d columns, want=%d)", txt, len(fields), ncols)
+			}
+			row := make([]float64, len(fields))
+			for i, v := range fields {
+				f, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return body, fmt.Errorf("hbook: could not parse YODA2 row %q: %w", txt, err)
+				}
+				row[i] = f
+			}
+			body.rows = append(body.rows, row)
+		}
+	}
+	return body, sc.Err()
+}
+
+// parseYODA2List parses a YODA2 line of the form "Key: [v1, v2, ...]".
+func parseYODA2List(txt string) ([]string, error) {
+	beg := strings.Index(txt, "[")
+	end := strings.LastIndex(txt, "]")
+	if beg < 0 || end < beg {
+		return nil, fmt.Errorf("hbook: invalid YODA2 list %q", txt)
+	}
+	txt = strings.TrimSpace(txt[beg+1 : end])
+	if txt == "" {
+		return nil, nil
+	}
+	vs := strings.Split(txt, ",")
+	for i, v := range vs {
+		vs[i] = strings.TrimSpace(v)
+	}
+	return vs, nil
+}
+
+// writeYODA2Edges writes the edges of the i-th axis of a YODA2 object.
+func writeYODA2Edges(buf *bytes.Buffer, i int, edges []float64) {
+	fmt.Fprintf(buf, "Edges(A%d): [", i)
+	for j, v := range edges {
+		if j > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "%e", v)
+	}
+	buf.WriteString("]\n")
+}
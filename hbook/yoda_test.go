@@ -5,8 +5,13 @@
 package hbook
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"reflect"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestReadYODAHeader(t *testing.T) {
@@ -37,6 +42,11 @@ func TestReadYODAHeader(t *testing.T) {
 			want: "/name with whitespace",
 			vers: 2,
 		},
+		{
+			str:  "BEGIN YODA_HISTO1D_V3 /name\n",
+			want: "/name",
+			vers: 3,
+		},
 		{
 			str:  "BEGIN YODA /name",
 			want: "",
@@ -85,3 +95,232 @@ func TestReadYODAHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestH1DYODA2(t *testing.T) {
+	h := NewH1D(10, -4, 4)
+	h.Annotation()["name"] = "h1"
+	h.Annotation()["title"] = "my title"
+	h.Fill(1, 1)
+	h.Fill(2, 1)
+	h.Fill(-3, 1)
+	h.Fill(-4, 1)
+	h.Fill(0, 1)
+	h.Fill(0, 1)
+	h.Fill(10, 1)
+	h.Fill(-10, 1)
+
+	chk, err := h.MarshalYODA2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := os.ReadFile("testdata/h1d_v3_golden.yoda")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(chk, ref) {
+		t.Fatalf("h1d file differ:\n%s\n", cmp.Diff(string(ref), string(chk)))
+	}
+
+	var hh H1D
+	err = hh.UnmarshalYODA(ref)
+	if err != nil {
+		t.Fatalf("could not unmarshal YODA2: %+v", err)
+	}
+
+	chk, err = hh.MarshalYODA2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(chk, ref) {
+		t.Fatalf("h1d round-trip differ:\n%s\n", cmp.Diff(string(ref), string(chk)))
+	}
+	if got, want := hh.Name(), h.Name(); got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := hh.Ann["title"], h.Ann["title"]; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+}
+
+func TestH1DGapsYODA2(t *testing.T) {
+	h := NewH1DFromBins([]Range{
+		{Min: 0, Max: 1},
+		{Min: 1, Max: 2},
+		{Min: 3, Max: 4},
+		{Min: 5, Max: 6},
+	}...)
+	h.Fill(0.5, 1)
+	h.Fill(3.5, 2)
+	h.Fill(5.5, 3)
+
+	raw, err := h.MarshalYODA2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(raw, []byte("\nMaskedBins: [3, 5]\n")) {
+		t.Fatalf("missing masked bins:\n%s", raw)
+	}
+
+	var hh H1D
+	err = hh.UnmarshalYODA(raw)
+	if err != nil {
+		t.Fatalf("could not unmarshal YODA2: %+v", err)
+	}
+
+	if !reflect.DeepEqual(hh.Binning, h.Binning) {
+		t.Fatalf("invalid binning:\ngot= %+v\nwant=%+v", hh.Binning, h.Binning)
+	}
+}
+
+func TestH2DYODA2(t *testing.T) {
+	h := NewH2DFromEdges([]float64{-1, 0, 0.5, 1}, []float64{-2, 0, 2})
+	h.Fill(+0.5, +1, 1)
+	h.Fill(-0.5, +1, 1)
+	h.Fill(+0.0, -1, 1)
+	h.Fill(-2.0, +3, 2) // N-W
+	h.Fill(+2.0, +0, 3) // E
+	h.Fill(+0.7, -3, 4) // S
+
+	chk, err := h.MarshalYODA2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := os.ReadFile("testdata/h2d_v3_golden.yoda")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(chk, ref) {
+		t.Fatalf("h2d file differ:\n%s\n", cmp.Diff(string(ref), string(chk)))
+	}
+
+	var hh H2D
+	err = hh.UnmarshalYODA(ref)
+	if err != nil {
+		t.Fatalf("could not unmarshal YODA2: %+v", err)
+	}
+
+	chk, err = hh.MarshalYODA2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(chk, ref) {
+		t.Fatalf("h2d round-trip differ:\n%s\n", cmp.Diff(string(ref), string(chk)))
+	}
+}
+
+func TestS2DYODA2(t *testing.T) {
+	s := NewS2D(
+		Point2D{X: 1, Y: 2, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 1, Max: 2}},
+		Point2D{X: 2, Y: 4, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 2, Max: 3}},
+	)
+	s.Annotation()["name"] = "s2"
+
+	chk, err := s.MarshalYODA2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := os.ReadFile("testdata/s2d_v3_golden.yoda")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(chk, ref) {
+		t.Fatalf("s2d file differ:\n%s\n", cmp.Diff(string(ref), string(chk)))
+	}
+
+	var ss S2D
+	err = ss.UnmarshalYODA(ref)
+	if err != nil {
+		t.Fatalf("could not unmarshal YODA2: %+v", err)
+	}
+
+	if !reflect.DeepEqual(ss.pts, s.pts) {
+		t.Fatalf("invalid points:\ngot= %+v\nwant=%+v", ss.pts, s.pts)
+	}
+}
+
+func TestEstimate1DYODA2(t *testing.T) {
+	const raw = `BEGIN YODA_ESTIMATE1D_V3 /ref/e1
+Path: /ref/e1
+Title: my estimate
+Type: Estimate1D
+---
+Edges(A1): [0.000000e+00, 1.000000e+00, 3.000000e+00, 4.000000e+00]
+MaskedBins: [2]
+ErrorLabels: ["stat", "syst"]
+# value	errDn(1)	errUp(1)	errDn(2)	errUp(2)
+nan	nan	nan	nan	nan
+1.000000e+01	-3.000000e+00	3.000000e+00	-4.000000e+00	4.000000e+00
+0.000000e+00	0.000000e+00	0.000000e+00	0.000000e+00	0.000000e+00
+2.000000e+01	-1.000000e+00	2.000000e+00	0.000000e+00	0.000000e+00
+nan	nan	nan	nan	nan
+END YODA_ESTIMATE1D_V3
+`
+
+	var s S2D
+	err := s.UnmarshalYODA([]byte(raw))
+	if err != nil {
+		t.Fatalf("could not unmarshal YODA2 estimate: %+v", err)
+	}
+
+	want := []Point2D{
+		{X: 0.5, Y: 10, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 5, Max: 5}},
+		{X: 3.5, Y: 20, ErrX: Range{Min: 0.5, Max: 0.5}, ErrY: Range{Min: 1, Max: 2}},
+	}
+	if !reflect.DeepEqual(s.pts, want) {
+		t.Fatalf("invalid points:\ngot= %+v\nwant=%+v", s.pts, want)
+	}
+	if got, want := s.Annotation()["name"], "ref/e1"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := s.Annotation()["title"], "my estimate"; got != want {
+		t.Fatalf("invalid title: got=%q, want=%q", got, want)
+	}
+}
+
+func TestReadYODA2Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		raw  string
+		err  string
+	}{
+		{
+			name: "no-separator",
+			raw:  "BEGIN YODA_HISTO1D_V3 /h\nPath: /h\nEdges(A1): [0, 1]\nEND YODA_HISTO1D_V3\n",
+			err:  "hbook: could not find YODA2 annotations separator",
+		},
+		{
+			name: "unsorted-edges",
+			raw:  "BEGIN YODA_HISTO1D_V3 /h\nPath: /h\n---\nEdges(A1): [1, 0]\nEND YODA_HISTO1D_V3\n",
+			err:  `hbook: invalid YODA2 edges "Edges(A1): [1, 0]" (not strictly increasing)`,
+		},
+		{
+			name: "missing-bins",
+			raw:  "BEGIN YODA_HISTO1D_V3 /h\nPath: /h\n---\nEdges(A1): [0, 1]\n0 0 0 0 0\nEND YODA_HISTO1D_V3\n",
+			err:  "hbook: invalid H1D-YODA2 number of bins (got=1, want=3)",
+		},
+		{
+			name: "invalid-columns",
+			raw:  "BEGIN YODA_HISTO1D_V3 /h\nPath: /h\n---\nEdges(A1): [0, 1]\n0 0 0 0\nEND YODA_HISTO1D_V3\n",
+			err:  `hbook: invalid YODA2 row "0 0 0 0" (got=4 columns, want=5)`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var h H1D
+			err := h.UnmarshalYODA([]byte(tc.raw))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
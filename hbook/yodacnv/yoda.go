@@ -27,6 +27,7 @@ var (
 
 // Read reads a YODA stream and converts the YODA values into their
 // go-hep/hbook equivalents.
+// Read handles both the legacy YODA format and the YODA2 one.
 func Read(r io.Reader) ([]hbook.Object, error) {
 	var (
 		err    error
@@ -98,6 +99,24 @@ func Write(w io.Writer, args ...Marshaler) error {
 	return nil
 }
 
+// Write2 writes values to a YODA2 stream.
+func Write2(w io.Writer, args ...Marshaler2) error {
+	for _, v := range args {
+		raw, err := v.MarshalYODA2()
+		if err != nil {
+			return err
+		}
+		n, err := w.Write(raw)
+		if err != nil {
+			return err
+		}
+		if n < len(raw) {
+			return io.ErrShortWrite
+		}
+	}
+	return nil
+}
+
 func splitHeader(raw []byte) (reflect.Type, error) {
 	raw = raw[len(begYoda):]
 	i := bytes.Index(raw, []byte(" "))
@@ -108,21 +127,27 @@ func splitHeader(raw []byte) (reflect.Type, error) {
 	var rt reflect.Type
 
 	switch string(raw[:i]) {
-	case "HISTO1D", "HISTO1D_V2":
+	case "HISTO1D", "HISTO1D_V2", "HISTO1D_V3":
 		rt = reflect.TypeOf((*hbook.H1D)(nil)).Elem()
-	case "HISTO2D", "HISTO2D_V2":
+	case "HISTO2D", "HISTO2D_V2", "HISTO2D_V3":
 		rt = reflect.TypeOf((*hbook.H2D)(nil)).Elem()
 	case "PROFILE1D", "PROFILE1D_V2":
 		rt = reflect.TypeOf((*hbook.P1D)(nil)).Elem()
 	case "PROFILE2D", "PROFILE2D_V2":
+		rt = reflect.TypeOf((*hbook.P2D)(nil)).Elem()
+	case "PROFILE1D_V3", "PROFILE2D_V3":
 		return nil, errIgnore
-	case "SCATTER1D", "SCATTER1D_V2":
+	case "SCATTER1D", "SCATTER1D_V2", "SCATTER1D_V3":
 		return nil, errIgnore
-	case "SCATTER2D", "SCATTER2D_V2":
+	case "SCATTER2D", "SCATTER2D_V2", "SCATTER2D_V3":
 		rt = reflect.TypeOf((*hbook.S2D)(nil)).Elem()
-	case "SCATTER3D", "SCATTER3D_V2":
+	case "SCATTER3D", "SCATTER3D_V2", "SCATTER3D_V3":
 		return nil, errIgnore
-	case "COUNTER", "COUNTER_V2":
+	case "ESTIMATE1D_V3":
+		rt = reflect.TypeOf((*hbook.S2D)(nil)).Elem()
+	case "ESTIMATE0D_V3", "ESTIMATE2D_V3", "ESTIMATE3D_V3":
+		return nil, errIgnore
+	case "COUNTER", "COUNTER_V2", "COUNTER_V3":
 		return nil, errIgnore
 	default:
 		return nil, fmt.Errorf("unhandled YODA object type %q", string(raw[:i]))
@@ -142,3 +167,9 @@ type Unmarshaler interface {
 type Marshaler interface {
 	MarshalYODA() ([]byte, error)
 }
+
+// Marshaler2 is the interface implemented by an object that can
+// marshal itself into a YODA2 form.
+type Marshaler2 interface {
+	MarshalYODA2() ([]byte, error)
+}
@@ -18,6 +18,7 @@ var (
 	h1    *hbook.H1D
 	h2    *hbook.H2D
 	p1    *hbook.P1D
+	p2    *hbook.P2D
 	s2    *hbook.S2D
 )
 
@@ -81,6 +82,70 @@ END YODA_SCATTER1D
 	}
 }
 
+func TestReadWriteYODA2(t *testing.T) {
+	w := new(bytes.Buffer)
+	err := yodacnv.Write2(w, h1, h2, s2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := w.Bytes()
+
+	// profiles and estimates are read from YODA2 streams too.
+	raw := append([]byte(nil), want...)
+	raw = append(raw, []byte(`BEGIN YODA_PROFILE1D_V3 /p1
+Path: /p1
+Type: Profile1D
+---
+END YODA_PROFILE1D_V3
+
+BEGIN YODA_ESTIMATE1D_V3 /e1
+Path: /e1
+Type: Estimate1D
+---
+Edges(A1): [0.000000e+00, 1.000000e+00]
+ErrorLabels: ["stat"]
+# value	errDn(1)	errUp(1)
+nan	nan	nan
+2.000000e+00	-1.000000e+00	1.000000e+00
+nan	nan	nan
+END YODA_ESTIMATE1D_V3
+`)...)
+
+	objs, err := yodacnv.Read(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(objs), 4; got != want {
+		t.Fatalf("invalid number of values: got=%d, want=%d (PROFILE1D_V3 not implemented)", got, want)
+	}
+
+	w.Reset()
+	for _, v := range objs[:3] {
+		err = yodacnv.Write2(w, v.(yodacnv.Marshaler2))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !bytes.Equal(w.Bytes(), want) {
+		t.Fatalf("got:\n%s\nwant:\n%s\n", w.String(), string(want))
+	}
+
+	e1, ok := objs[3].(*hbook.S2D)
+	if !ok {
+		t.Fatalf("invalid estimate type %T", objs[3])
+	}
+	if got, want := e1.Len(), 1; got != want {
+		t.Fatalf("invalid estimate length: got=%d, want=%d", got, want)
+	}
+	if got, want := e1.Point(0), (hbook.Point2D{
+		X: 0.5, Y: 2, ErrX: hbook.Range{Min: 0.5, Max: 0.5}, ErrY: hbook.Range{Min: 1, Max: 1},
+	}); got != want {
+		t.Fatalf("invalid estimate point: got=%+v, want=%+v", got, want)
+	}
+}
+
 func init() {
 
 	add := func(o yodacnv.Marshaler) {
@@ -121,6 +186,14 @@ func init() {
 
 	add(p1)
 
+	p2 = hbook.NewP2D(4, -2, +2, 2, -1, +1)
+	p2.Annotation()["name"] = "profile-2d"
+	p2.Fill(+0.5, +0.5, 2, 1)
+	p2.Fill(+0.5, +0.5, 4, 2)
+	p2.Fill(-1.5, -0.5, 1, 1)
+
+	add(p2)
+
 	s2 = hbook.NewS2DFromH1D(h1)
 	add(s2)
 }
@@ -10,10 +10,24 @@ import (
 	"sort"
 
 	"go-hep.org/x/hep/fmom"
+	"go-hep.org/x/hep/groot/rtree"
 	"go-hep.org/x/hep/hepmc"
 	"go-hep.org/x/hep/sliceop"
 )
 
+// Branches returns the names of the branches of a flat HepMC tree.
+func Branches() []string {
+	var (
+		evt   event
+		wvars = rtree.WriteVarsFromStruct(&evt)
+		names = make([]string, len(wvars))
+	)
+	for i, wvar := range wvars {
+		names[i] = wvar.Name
+	}
+	return names
+}
+
 type event struct {
 	SignalProcessID  int32   `groot:"Event_processID"` // id of the signal process
 	Event_number     int32   `groot:"Event_nbr"`       // event number
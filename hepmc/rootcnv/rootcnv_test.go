@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"go-hep.org/x/hep/groot"
@@ -98,3 +99,92 @@ func TestRW(t *testing.T) {
 		})
 	}
 }
+
+func TestFlatTreeWriterWith(t *testing.T) {
+	dir, err := os.MkdirTemp("", "hepmc-rootcnv-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	raw, err := os.ReadFile("../testdata/small.hepmc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fname := filepath.Join(dir, "small.root")
+	o, err := groot.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create output ROOT file: %+v", err)
+	}
+	defer o.Close()
+
+	_, err = NewFlatTreeWriterWith(o, "none", func(string) bool { return false })
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), `hepmc: could not create flat-tree writer "none": no branch selected`; got != want {
+		t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+	}
+
+	want := []string{"Event_nbr", "Particle_pid", "Particle_px"}
+	w, err := NewFlatTreeWriterWith(o, "tree", func(name string) bool {
+		for _, v := range want {
+			if v == name {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		t.Fatalf("could not create ROOT tree writer: %+v", err)
+	}
+
+	n, err := hepmc.Copy(w, hepmc.NewASCIIReader(bytes.NewReader(raw)))
+	if err != nil {
+		t.Fatalf("could not copy hepmc event to ROOT: %+v", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close ROOT tree writer: %+v", err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		t.Fatalf("could not close ROOT file: %+v", err)
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	tree, err := riofs.Get[rtree.Tree](f, "tree")
+	if err != nil {
+		t.Fatalf("could not retrieve ROOT tree: %+v", err)
+	}
+
+	if got, want := tree.Entries(), n; got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	var got []string
+	for _, b := range tree.Branches() {
+		got = append(got, b.Name())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid branches:\ngot= %q\nwant=%q", got, want)
+	}
+}
+
+func TestBranches(t *testing.T) {
+	names := Branches()
+	if got, want := len(names), 67; got != want {
+		t.Fatalf("invalid number of branches: got=%d, want=%d", got, want)
+	}
+	if got, want := names[0], "Event_processID"; got != want {
+		t.Fatalf("invalid first branch: got=%q, want=%q", got, want)
+	}
+}
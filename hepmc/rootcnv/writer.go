@@ -22,8 +22,31 @@ type FlatTreeWriter struct {
 
 // NewFlatTreeWriter creates a new named tree under the dir directory.
 func NewFlatTreeWriter(dir riofs.Directory, name string, opts ...rtree.WriteOption) (*FlatTreeWriter, error) {
+	return NewFlatTreeWriterWith(dir, name, nil, opts...)
+}
+
+// NewFlatTreeWriterWith creates a new named tree under the dir directory,
+// holding only the branches for which sel returns true.
+// All branches are written when sel is nil.
+//
+// Trees that do not hold all the branches can not be read back with a
+// FlatTreeReader.
+func NewFlatTreeWriterWith(dir riofs.Directory, name string, sel func(branch string) bool, opts ...rtree.WriteOption) (*FlatTreeWriter, error) {
 	var w FlatTreeWriter
 	w.wvars = rtree.WriteVarsFromStruct(&w.evt)
+	if sel != nil {
+		wvars := w.wvars[:0]
+		for _, wvar := range w.wvars {
+			if sel(wvar.Name) {
+				wvars = append(wvars, wvar)
+			}
+		}
+		w.wvars = wvars
+	}
+	if len(w.wvars) == 0 {
+		return nil, fmt.Errorf("hepmc: could not create flat-tree writer %q: no branch selected", name)
+	}
+
 	tree, err := rtree.NewWriter(dir, name, w.wvars, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("hepmc: could not create flat-tree writer %q: %w", name, err)
@@ -0,0 +1,294 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// TextBox displays a user-defined text string on a plot, inside an
+// optionally filled and framed box.
+//
+// The text is anchored at (X, Y), in data coordinates or, when Normalized
+// is true, in coordinates normalized to the data canvas ((0,0) being the
+// bottom-left corner and (1,1) the top-right one).
+// The alignment of the text with regard to its anchor is controlled by
+// the XAlign and YAlign fields of the TextStyle.
+type TextBox struct {
+	Text      string         // Text of the box
+	X, Y      float64        // Position of the anchor of the box
+	TextStyle draw.TextStyle // Text style of the box
+
+	// Normalized indicates whether the anchor position is in data
+	// coordinates or normalized with regard to the data canvas.
+	// If true, NewTextBox panics if x or y are outside [0, 1].
+	Normalized bool
+
+	// AutoAdjust moves the box inside the data canvas when it
+	// would otherwise be partly outside of it.
+	AutoAdjust bool
+
+	// Padding is the space between the text and the box.
+	Padding vg.Length
+
+	// FillColor is the color used to fill the box.
+	// Use nil to disable the filling.
+	FillColor color.Color
+
+	// LineStyle is the style of the frame of the box.
+	// Use zero width to disable.
+	LineStyle draw.LineStyle
+}
+
+// NewTextBox creates a new txt box anchored at position (x, y).
+//
+// The options of NewLabel are used to configure the text style and
+// the coordinates system of the box.
+// By default, the box is filled in white and framed in black.
+func NewTextBox(x, y float64, txt string, opts ...LabelOption) *TextBox {
+	lbl := NewLabel(x, y, txt, opts...)
+	return &TextBox{
+		Text:       lbl.Text,
+		X:          lbl.X,
+		Y:          lbl.Y,
+		TextStyle:  lbl.TextStyle,
+		Normalized: lbl.Normalized,
+		AutoAdjust: lbl.AutoAdjust,
+		Padding:    vg.Points(3),
+		FillColor:  color.White,
+		LineStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(0.5),
+		},
+	}
+}
+
+// Plot implements the Plotter interface,
+// drawing the text box on the canvas.
+func (tb *TextBox) Plot(c draw.Canvas, p *plot.Plot) {
+	pt, ok := annotationPoint(c, p, tb.X, tb.Y, tb.Normalized)
+	if !ok || !c.Contains(pt) {
+		return
+	}
+
+	box := tb.rect().Add(pt)
+	if tb.AutoAdjust {
+		var dx, dy vg.Length
+		switch {
+		case box.Max.X > c.Max.X:
+			dx = c.Max.X - box.Max.X
+		case box.Min.X < c.Min.X:
+			dx = c.Min.X - box.Min.X
+		}
+		switch {
+		case box.Max.Y > c.Max.Y:
+			dy = c.Max.Y - box.Max.Y
+		case box.Min.Y < c.Min.Y:
+			dy = c.Min.Y - box.Min.Y
+		}
+		delta := vg.Point{X: dx, Y: dy}
+		pt = pt.Add(delta)
+		box = box.Add(delta)
+	}
+
+	if tb.FillColor != nil {
+		c.SetColor(tb.FillColor)
+		c.Fill(box.Path())
+	}
+	if tb.LineStyle.Width != 0 {
+		c.StrokeLines(tb.LineStyle, []vg.Point{
+			{X: box.Min.X, Y: box.Min.Y},
+			{X: box.Max.X, Y: box.Min.Y},
+			{X: box.Max.X, Y: box.Max.Y},
+			{X: box.Min.X, Y: box.Max.Y},
+			{X: box.Min.X, Y: box.Min.Y},
+		})
+	}
+	c.FillText(tb.TextStyle, pt, tb.Text)
+}
+
+// rect returns the rectangle of the box, relative to its anchor.
+func (tb *TextBox) rect() vg.Rectangle {
+	var (
+		box = tb.TextStyle.Rectangle(tb.Text)
+		pad = tb.Padding + tb.LineStyle.Width/2
+	)
+	box.Min = box.Min.Sub(vg.Point{X: pad, Y: pad})
+	box.Max = box.Max.Add(vg.Point{X: pad, Y: pad})
+	return box
+}
+
+// DataRange returns the minimum and maximum x and
+// y values, implementing the plot.DataRanger interface.
+func (tb *TextBox) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if tb.Normalized {
+		return math.Inf(+1), math.Inf(-1), math.Inf(+1), math.Inf(-1)
+	}
+	return tb.X, tb.X, tb.Y, tb.Y
+}
+
+// GlyphBoxes returns a GlyphBox, corresponding to the text box.
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+func (tb *TextBox) GlyphBoxes(p *plot.Plot) []plot.GlyphBox {
+	if tb.Normalized {
+		return nil
+	}
+	return []plot.GlyphBox{{
+		X:         p.X.Norm(tb.X),
+		Y:         p.Y.Norm(tb.Y),
+		Rectangle: tb.rect(),
+	}}
+}
+
+// Arrow draws an arrow from (X1, Y1) to (X2, Y2), with its head at (X2, Y2).
+//
+// The arrow coordinates are data coordinates or, when Normalized is
+// true, coordinates normalized to the data canvas.
+type Arrow struct {
+	X1, Y1 float64 // Position of the tail of the arrow
+	X2, Y2 float64 // Position of the head of the arrow
+
+	// Normalized indicates whether the arrow coordinates are
+	// data coordinates or normalized with regard to the data canvas.
+	Normalized bool
+
+	// LineStyle is the style of the arrow.
+	// The head of the arrow is filled with the color of the line.
+	LineStyle draw.LineStyle
+
+	// HeadLength is the length of the head of the arrow.
+	// Use zero to disable the head.
+	HeadLength vg.Length
+
+	// HeadAngle is the angle, in degrees, between the
+	// shaft and each side of the head of the arrow.
+	HeadAngle float64
+}
+
+// NewArrow creates a new arrow from (x1, y1) to (x2, y2), in data
+// coordinates, with the default line style.
+func NewArrow(x1, y1, x2, y2 float64) *Arrow {
+	return &Arrow{
+		X1:         x1,
+		Y1:         y1,
+		X2:         x2,
+		Y2:         y2,
+		LineStyle:  plotter.DefaultLineStyle,
+		HeadLength: vg.Points(8),
+		HeadAngle:  25,
+	}
+}
+
+// Plot implements the Plotter interface,
+// drawing the arrow on the canvas.
+func (arr *Arrow) Plot(c draw.Canvas, p *plot.Plot) {
+	beg, ok1 := annotationPoint(c, p, arr.X1, arr.Y1, arr.Normalized)
+	end, ok2 := annotationPoint(c, p, arr.X2, arr.Y2, arr.Normalized)
+	if !ok1 || !ok2 {
+		return
+	}
+
+	var (
+		dx  = float64(end.X - beg.X)
+		dy  = float64(end.Y - beg.Y)
+		n   = math.Hypot(dx, dy)
+		l   = float64(arr.HeadLength)
+		phi = arr.HeadAngle * math.Pi / 180
+	)
+	if n == 0 {
+		return
+	}
+	dx /= n
+	dy /= n
+
+	// stop the shaft at the base of the head, so the
+	// line doesn't stick out of the tip of the arrow.
+	base := end
+	if l > 0 {
+		d := math.Min(l*math.Cos(phi), n)
+		base = vg.Point{
+			X: end.X - vg.Length(d*dx),
+			Y: end.Y - vg.Length(d*dy),
+		}
+	}
+	if arr.LineStyle.Width != 0 {
+		c.StrokeLines(arr.LineStyle, c.ClipLinesXY([]vg.Point{beg, base})...)
+	}
+
+	if l <= 0 || !c.Contains(end) {
+		return
+	}
+	var (
+		theta = math.Atan2(dy, dx)
+		head  = []vg.Point{
+			end,
+			{
+				X: end.X - vg.Length(l*math.Cos(theta+phi)),
+				Y: end.Y - vg.Length(l*math.Sin(theta+phi)),
+			},
+			{
+				X: end.X - vg.Length(l*math.Cos(theta-phi)),
+				Y: end.Y - vg.Length(l*math.Sin(theta-phi)),
+			},
+		}
+	)
+	c.FillPolygon(arr.LineStyle.Color, head)
+}
+
+// DataRange returns the minimum and maximum x and
+// y values, implementing the plot.DataRanger interface.
+func (arr *Arrow) DataRange() (xmin, xmax, ymin, ymax float64) {
+	if arr.Normalized {
+		return math.Inf(+1), math.Inf(-1), math.Inf(+1), math.Inf(-1)
+	}
+	xmin = math.Min(arr.X1, arr.X2)
+	xmax = math.Max(arr.X1, arr.X2)
+	ymin = math.Min(arr.Y1, arr.Y2)
+	ymax = math.Max(arr.Y1, arr.Y2)
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail returns the thumbnail for the Arrow,
+// implementing the plot.Thumbnailer interface.
+func (arr *Arrow) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(arr.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// annotationPoint returns the position on the canvas of the provided
+// data or normalized coordinates.
+func annotationPoint(c draw.Canvas, p *plot.Plot, x, y float64, normalized bool) (vg.Point, bool) {
+	if normalized {
+		return vg.Point{
+			X: c.Min.X + vg.Length(x)*(c.Max.X-c.Min.X),
+			Y: c.Min.Y + vg.Length(y)*(c.Max.Y-c.Min.Y),
+		}, true
+	}
+
+	trX, trY := p.Transforms(&c)
+	pt := vg.Point{X: trX(x), Y: trY(y)}
+	if math.IsInf(float64(pt.X), 0) || math.IsNaN(float64(pt.X)) ||
+		math.IsInf(float64(pt.Y), 0) || math.IsNaN(float64(pt.Y)) {
+		// e.g. non-positive value on a log-scale axis.
+		return pt, false
+	}
+	return pt, true
+}
+
+var (
+	_ plot.Plotter    = (*TextBox)(nil)
+	_ plot.DataRanger = (*TextBox)(nil)
+	_ plot.GlyphBoxer = (*TextBox)(nil)
+
+	_ plot.Plotter     = (*Arrow)(nil)
+	_ plot.DataRanger  = (*Arrow)(nil)
+	_ plot.Thumbnailer = (*Arrow)(nil)
+)
@@ -0,0 +1,85 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleTextBox() {
+	const npoints = 10000
+
+	// Di-muon invariant mass around the Z peak,
+	// on top of a falling background.
+	var (
+		src  = rand.New(rand.NewSource(1234))
+		sig  = distuv.Normal{Mu: 91.19, Sigma: 3, Src: src}
+		bkg  = distuv.Exponential{Rate: 1.0 / 30, Src: src}
+		frac = distuv.Uniform{Min: 0, Max: 1, Src: src}
+	)
+
+	h := hbook.NewH1D(50, 60, 120)
+	for i := 0; i < npoints; i++ {
+		switch {
+		case frac.Rand() < 0.7:
+			h.Fill(sig.Rand(), 1)
+		default:
+			h.Fill(60+bkg.Rand(), 1)
+		}
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Annotations"
+	p.X.Label.Text = "m_μμ [GeV]"
+	p.Y.Label.Text = "Events / 1.2 GeV"
+
+	hh := hplot.NewH1D(h)
+	hh.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+
+	// Reference line at the Z mass.
+	mass := hplot.VLine(91.19, nil, nil)
+	mass.Line.Color = color.NRGBA{R: 255, A: 255}
+	mass.Line.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+
+	// Cut value, shading the rejected region.
+	cut := hplot.VLine(110, nil, color.NRGBA{A: 40})
+
+	// Text boxes, in normalized and data coordinates.
+	info := hplot.NewTextBox(
+		0.03, 0.97, "Z → μμ\nm_Z = 91.19 GeV",
+		hplot.WithLabelNormalized(true),
+	)
+	info.TextStyle.YAlign = draw.YTop
+
+	note := hplot.NewTextBox(111, 600, "rejected", hplot.WithLabelTextStyle(draw.TextStyle{
+		Color:  color.Black,
+		Font:   hplot.DefaultStyle.Fonts.Tick,
+		XAlign: draw.XLeft,
+		YAlign: draw.YCenter,
+	}))
+	note.Padding = vg.Points(2)
+	note.FillColor = nil
+	note.LineStyle.Width = 0
+
+	// Arrow pointing to the peak.
+	arrow := hplot.NewArrow(78, 1000, 88.5, 900)
+
+	p.Add(hh, mass, cut, info, note, arrow)
+	p.Y.Min = 0
+	p.Y.Max = 1400
+
+	err := p.Save(15*vg.Centimeter, -1, "testdata/annotation_plot.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestTextBox(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleTextBox, t, "annotation_plot.png")
+}
+
+func TestAnnotationDataRange(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		dr   interface {
+			DataRange() (xmin, xmax, ymin, ymax float64)
+		}
+		want [4]float64
+	}{
+		{
+			name: "textbox",
+			dr:   hplot.NewTextBox(1, 2, "txt"),
+			want: [4]float64{1, 1, 2, 2},
+		},
+		{
+			name: "textbox-normalized",
+			dr:   hplot.NewTextBox(0.5, 0.5, "txt", hplot.WithLabelNormalized(true)),
+			want: [4]float64{math.Inf(+1), math.Inf(-1), math.Inf(+1), math.Inf(-1)},
+		},
+		{
+			name: "arrow",
+			dr:   hplot.NewArrow(3, 4, 1, 2),
+			want: [4]float64{1, 3, 2, 4},
+		},
+		{
+			name: "arrow-normalized",
+			dr: func() *hplot.Arrow {
+				arr := hplot.NewArrow(0.1, 0.2, 0.3, 0.4)
+				arr.Normalized = true
+				return arr
+			}(),
+			want: [4]float64{math.Inf(+1), math.Inf(-1), math.Inf(+1), math.Inf(-1)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			xmin, xmax, ymin, ymax := tc.dr.DataRange()
+			if got := [4]float64{xmin, xmax, ymin, ymax}; got != tc.want {
+				t.Fatalf("invalid data range: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
@@ -27,6 +28,10 @@ type Band struct {
 	// the top and bottom data points.
 	// Use nil to disable the filling.
 	FillColor color.Color
+
+	// Hatch is the hatching of the area between
+	// the top and bottom data points.
+	Hatch HatchStyle
 }
 
 func NewBand(fill color.Color, top, bottom plotter.XYer) *Band {
@@ -70,6 +75,15 @@ func (band *Band) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 
 	poly.Plot(c, plt)
+
+	if band.Hatch.Kind != HatchNone {
+		trX, trY := plt.Transforms(&c)
+		pts := make([]vg.Point, len(xys))
+		for i, p := range xys {
+			pts[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		}
+		band.Hatch.Fill(c, pts)
+	}
 }
 
 // DataRange returns the minimum and maximum
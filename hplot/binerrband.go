@@ -12,6 +12,7 @@ import (
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
@@ -33,6 +34,10 @@ type BinnedErrBand struct {
 	// Use nil to disable the filling.
 	FillColor color.Color
 
+	// Hatch is the hatching of the area
+	// between the top and bottom data points.
+	Hatch HatchStyle
+
 	// LogY allows rendering with a log-scaled Y axis.
 	// When enabled, bins with negative or zero minimal value (val-err)
 	// will be discarded from the error band.
@@ -54,6 +59,7 @@ func NewBinnedErrBand(cs []hbook.Count) *BinnedErrBand {
 // drawing a colored box defined by width
 // of bins (x-axis) and error (y-axis).
 func (b *BinnedErrBand) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
 
 	for _, count := range b.Counts {
 
@@ -76,6 +82,14 @@ func (b *BinnedErrBand) Plot(c draw.Canvas, plt *plot.Plot) {
 		poly := plotter.Polygon{XYs: []plotter.XYs{xys}, Color: b.FillColor}
 		poly.Plot(c, plt)
 
+		if b.Hatch.Kind != HatchNone {
+			pts := make([]vg.Point, len(xys))
+			for i, p := range xys {
+				pts[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+			}
+			b.Hatch.Fill(c, pts)
+		}
+
 		// Bottom line
 		xysBo := plotter.XYs{xys[0], xys[3]}
 		lBo := plotter.Line{XYs: xysBo, LineStyle: b.LineStyle}
@@ -107,7 +121,26 @@ func (b *BinnedErrBand) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return xmin, xmax, ymin, ymax
 }
 
+// Thumbnail draws a rectangle in the given style of the band,
+// implementing the plot.Thumbnailer interface.
+func (b *BinnedErrBand) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+	}
+	if b.FillColor != nil {
+		c.FillPolygon(b.FillColor, c.ClipPolygonXY(pts))
+	}
+	b.Hatch.Fill(*c, pts)
+	if b.LineStyle.Width != 0 {
+		c.StrokeLines(b.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+	}
+}
+
 var (
-	_ plot.Plotter    = (*BinnedErrBand)(nil)
-	_ plot.DataRanger = (*BinnedErrBand)(nil)
+	_ plot.Plotter     = (*BinnedErrBand)(nil)
+	_ plot.DataRanger  = (*BinnedErrBand)(nil)
+	_ plot.Thumbnailer = (*BinnedErrBand)(nil)
 )
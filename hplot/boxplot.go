@@ -0,0 +1,161 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// BoxPlot displays the distribution of a sample, or of a histogram,
+// as a box spanning the first and third quartiles, with a line at the
+// median and whiskers extending to the most extreme values within 1.5
+// times the interquartile range of the box.
+//
+// BoxPlot boxes are vertical and drawn at their Location along the X-axis.
+// Several boxes can be compared by giving them different locations, e.g.
+// with plot.NominalX for named categories.
+type BoxPlot struct {
+	*plotter.BoxPlot
+}
+
+// NewBoxPlot creates a new box plot with width w, at location loc, that
+// represents the distribution of the provided values.
+// Values outside of the whiskers are drawn as glyphs.
+func NewBoxPlot(w vg.Length, loc float64, vs plotter.Valuer) (*BoxPlot, error) {
+	if vs.Len() == 0 {
+		return nil, fmt.Errorf("hplot: could not create box plot: no values")
+	}
+
+	box, err := plotter.NewBoxPlot(w, loc, vs)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not create box plot: %w", err)
+	}
+
+	return &BoxPlot{BoxPlot: box}, nil
+}
+
+// NewBoxPlotH1D creates a new box plot with width w, at location loc, that
+// represents the distribution of the provided histogram.
+//
+// The quartiles are computed from the cumulative distribution of the
+// in-range bins, interpolated linearly within each bin.
+// The whiskers are bounded by the edges of the first and last non-empty
+// bins. As individual values are not known, no outside glyph is drawn.
+func NewBoxPlotH1D(w vg.Length, loc float64, h *hbook.H1D) (*BoxPlot, error) {
+	if w < 0 {
+		return nil, fmt.Errorf("hplot: could not create box plot: negative width")
+	}
+	lo, hi, err := h1dRange(h)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not create box plot: %w", err)
+	}
+
+	box := new(plotter.BoxPlot)
+	box.Location = loc
+	box.Median = h1dQuantile(h, 0.5)
+	box.Quartile1 = h1dQuantile(h, 0.25)
+	box.Quartile3 = h1dQuantile(h, 0.75)
+	box.Min = lo
+	box.Max = hi
+
+	iqr := box.Quartile3 - box.Quartile1
+	box.AdjLow = math.Max(box.Quartile1-1.5*iqr, lo)
+	box.AdjHigh = math.Min(box.Quartile3+1.5*iqr, hi)
+
+	box.Width = w
+	box.CapWidth = 3 * w / 4
+	box.GlyphStyle = plotter.DefaultGlyphStyle
+	box.BoxStyle = plotter.DefaultLineStyle
+	box.MedianStyle = plotter.DefaultLineStyle
+	box.WhiskerStyle = draw.LineStyle{
+		Width:  vg.Points(0.5),
+		Dashes: []vg.Length{vg.Points(4), vg.Points(2)},
+	}
+
+	return &BoxPlot{BoxPlot: box}, nil
+}
+
+// Thumbnail draws a box in the style of the box plot,
+// implementing the plot.Thumbnailer interface.
+func (b *BoxPlot) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Min.Y},
+	}
+	if b.FillColor != nil {
+		c.FillPolygon(b.FillColor, c.ClipPolygonXY(pts))
+	}
+	c.StrokeLines(b.BoxStyle, c.ClipLinesXY(pts)...)
+}
+
+// h1dRange returns the low edge of the first non-empty bin and the high
+// edge of the last non-empty bin of the provided histogram.
+func h1dRange(h *hbook.H1D) (lo, hi float64, err error) {
+	var (
+		bins = h.Binning.Bins
+		ilo  = -1
+		ihi  = -1
+	)
+	for i, bin := range bins {
+		if bin.SumW() <= 0 {
+			continue
+		}
+		if ilo < 0 {
+			ilo = i
+		}
+		ihi = i
+	}
+	if ilo < 0 {
+		return 0, 0, errors.New("empty histogram")
+	}
+	return bins[ilo].XMin(), bins[ihi].XMax(), nil
+}
+
+// h1dQuantile returns the p-quantile of the distribution of the in-range
+// bins of the provided histogram, interpolating linearly within bins.
+// Bins with a negative sum of weights are ignored.
+func h1dQuantile(h *hbook.H1D, p float64) float64 {
+	var (
+		bins = h.Binning.Bins
+		tot  = 0.0
+	)
+	for _, bin := range bins {
+		tot += math.Max(bin.SumW(), 0)
+	}
+
+	var (
+		want = p * tot
+		cum  = 0.0
+	)
+	for _, bin := range bins {
+		w := math.Max(bin.SumW(), 0)
+		if w == 0 {
+			continue
+		}
+		if cum+w >= want {
+			return bin.XMin() + (want-cum)/w*bin.XWidth()
+		}
+		cum += w
+	}
+	return bins[len(bins)-1].XMax()
+}
+
+var (
+	_ plot.Plotter     = (*BoxPlot)(nil)
+	_ plot.DataRanger  = (*BoxPlot)(nil)
+	_ plot.GlyphBoxer  = (*BoxPlot)(nil)
+	_ plot.Thumbnailer = (*BoxPlot)(nil)
+)
@@ -0,0 +1,76 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleBoxPlot() {
+	const (
+		nruns   = 5
+		npoints = 500
+	)
+
+	p := hplot.New()
+	p.Title.Text = "Data quality"
+	p.Y.Label.Text = "Noise [ADC counts]"
+
+	var (
+		src   = rand.New(rand.NewSource(1234))
+		names = make([]string, nruns)
+	)
+	for i := 0; i < nruns; i++ {
+		names[i] = fmt.Sprintf("run %d", 1000+i)
+
+		dist := distuv.Normal{
+			Mu:    10 + 0.5*float64(i),
+			Sigma: 1 + 0.2*float64(i%3),
+			Src:   src,
+		}
+
+		// the last run is displayed from a histogram.
+		if i == nruns-1 {
+			h := hbook.NewH1D(40, 0, 25)
+			for j := 0; j < npoints; j++ {
+				h.Fill(dist.Rand(), 1)
+			}
+			box, err := hplot.NewBoxPlotH1D(vg.Points(20), float64(i), h)
+			if err != nil {
+				log.Fatalf("could not create box plot: %+v", err)
+			}
+			box.FillColor = color.NRGBA{R: 255, A: 64}
+			p.Add(box)
+			continue
+		}
+
+		vs := make(plotter.Values, npoints)
+		for j := range vs {
+			vs[j] = dist.Rand()
+		}
+		box, err := hplot.NewBoxPlot(vg.Points(20), float64(i), vs)
+		if err != nil {
+			log.Fatalf("could not create box plot: %+v", err)
+		}
+		box.FillColor = color.NRGBA{B: 255, A: 64}
+		p.Add(box)
+	}
+	p.NominalX(names...)
+	p.Add(hplot.NewGrid())
+
+	err := p.Save(15*vg.Centimeter, -1, "testdata/boxplot.png")
+	if err != nil {
+		log.Fatalf("could not save plot: %+v", err)
+	}
+}
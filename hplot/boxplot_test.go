@@ -0,0 +1,61 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+func TestBoxPlot(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleBoxPlot, t, "boxplot.png")
+}
+
+func TestBoxPlotH1D(t *testing.T) {
+	// uniform distribution over [0, 4).
+	h := hbook.NewH1D(8, -2, 6)
+	for _, x := range []float64{0.5, 1.5, 2.5, 3.5} {
+		h.Fill(x, 10)
+	}
+	h.Fill(10, 100) // overflow, ignored.
+
+	box, err := hplot.NewBoxPlotH1D(vg.Points(10), 1, h)
+	if err != nil {
+		t.Fatalf("could not create box plot: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"median", box.Median, 2},
+		{"q1", box.Quartile1, 1},
+		{"q3", box.Quartile3, 3},
+		{"min", box.Min, 0},
+		{"max", box.Max, 4},
+		{"adj-low", box.AdjLow, 0},
+		{"adj-high", box.AdjHigh, 4},
+	} {
+		if math.Abs(tc.got-tc.want) > 1e-12 {
+			t.Errorf("invalid %s: got=%v, want=%v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	_, err = hplot.NewBoxPlotH1D(vg.Points(10), 1, hbook.NewH1D(8, -2, 6))
+	if err == nil {
+		t.Fatalf("expected an error for an empty histogram")
+	}
+
+	_, err = hplot.NewBoxPlot(vg.Points(10), 1, plotter.Values{})
+	if err == nil {
+		t.Fatalf("expected an error for no values")
+	}
+}
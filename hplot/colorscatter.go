@@ -0,0 +1,176 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ColorScatter implements the plot.Plotter interface, drawing a scatter
+// plot of (x, y) points whose glyphs are colored according to a third,
+// z, value.
+//
+// The mapping between z values and colors can be displayed with a
+// color bar, attached to a figure with the WithColorBar option.
+type ColorScatter struct {
+	// Data holds the (x, y, z) points.
+	Data plotter.XYZer
+
+	// GlyphStyle is the style of the glyphs drawn at each point.
+	// The color of the glyphs is set from the z values.
+	draw.GlyphStyle
+
+	// ColorMap maps z values to colors.
+	// The range of the ColorMap is modified by the ColorScatter.
+	ColorMap palette.ColorMap
+
+	// LogZ enables the logarithmic mapping of z values to colors.
+	// When enabled, points with a non-positive z value are not drawn.
+	LogZ bool
+
+	// Min and Max are the range of z values mapped to colors.
+	// Points with a z value outside of that range are drawn with the
+	// color of the closest boundary.
+	Min, Max float64
+}
+
+// NewColorScatter creates a color-mapped scatter plot from a XYZer.
+//
+// The range of z values mapped to colors is set from the data.
+// The default color map is moreland.Kindlmann and the default glyphs
+// are filled circles.
+func NewColorScatter(data plotter.XYZer, opts ...Options) *ColorScatter {
+	cfg := newConfig(opts)
+	s := &ColorScatter{
+		Data:       data,
+		GlyphStyle: plotter.DefaultGlyphStyle,
+		ColorMap:   cfg.cmap,
+		LogZ:       cfg.log.z,
+	}
+	s.GlyphStyle.Shape = draw.CircleGlyph{}
+	if cfg.glyph != (draw.GlyphStyle{}) {
+		s.GlyphStyle = cfg.glyph
+	}
+	if s.ColorMap == nil {
+		s.ColorMap = moreland.Kindlmann()
+	}
+	s.Min, s.Max = s.zrange()
+	return s
+}
+
+// zrange returns the range of the z values of the drawable points.
+func (s *ColorScatter) zrange() (min, max float64) {
+	min = math.Inf(+1)
+	max = math.Inf(-1)
+	for i := 0; i < s.Data.Len(); i++ {
+		_, _, z := s.Data.XYZ(i)
+		if s.skip(z) {
+			continue
+		}
+		min = math.Min(min, z)
+		max = math.Max(max, z)
+	}
+
+	switch {
+	case math.IsInf(min, +1) && s.LogZ:
+		return 1, 10
+	case math.IsInf(min, +1):
+		return 0, 1
+	case min == max && s.LogZ:
+		return min, 10 * max
+	case min == max:
+		return min, min + 1
+	}
+	return min, max
+}
+
+// skip returns whether a point with the provided z value is not drawn.
+func (s *ColorScatter) skip(z float64) bool {
+	return math.IsNaN(z) || (s.LogZ && z <= 0)
+}
+
+// Color returns the color of a point with the provided z value,
+// or nil if such a point is not drawn.
+func (s *ColorScatter) Color(z float64) color.Color {
+	if s.skip(z) {
+		return nil
+	}
+	return s.ColorAt(normZ(s.Min, s.Max, s.LogZ, z))
+}
+
+// ZRange returns the range of z values mapped to colors,
+// implementing the ColorMapper interface.
+func (s *ColorScatter) ZRange() (min, max float64, log bool) {
+	return s.Min, s.Max, s.LogZ
+}
+
+// ColorAt returns the color at the provided position of the color map,
+// implementing the ColorMapper interface.
+func (s *ColorScatter) ColorAt(v float64) color.Color {
+	return colorAtNorm(s.ColorMap, v)
+}
+
+// Plot draws the ColorScatter, implementing the plot.Plotter interface.
+func (s *ColorScatter) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	sty := s.GlyphStyle
+	for i := 0; i < s.Data.Len(); i++ {
+		x, y, z := s.Data.XYZ(i)
+		col := s.Color(z)
+		if col == nil {
+			continue
+		}
+		pt := vg.Point{X: trX(x), Y: trY(y)}
+		if !c.Contains(pt) {
+			continue
+		}
+		sty.Color = col
+		c.DrawGlyph(sty, pt)
+	}
+}
+
+// DataRange returns the minimum and maximum
+// x and y values, implementing the plot.DataRanger
+// interface.
+func (s *ColorScatter) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return plotter.XYRange(plotter.XYValues{XYZer: s.Data})
+}
+
+// GlyphBoxes returns a slice of plot.GlyphBoxes,
+// implementing the plot.GlyphBoxer interface.
+func (s *ColorScatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, s.Data.Len())
+	for i := range bs {
+		x, y, _ := s.Data.XYZ(i)
+		bs[i].X = plt.X.Norm(x)
+		bs[i].Y = plt.Y.Norm(y)
+		bs[i].Rectangle = s.GlyphStyle.Rectangle()
+	}
+	return bs
+}
+
+// Thumbnail draws a glyph, colored with the middle color of the color map,
+// implementing the plot.Thumbnailer interface.
+func (s *ColorScatter) Thumbnail(c *draw.Canvas) {
+	sty := s.GlyphStyle
+	sty.Color = s.ColorAt(0.5)
+	c.DrawGlyph(sty, c.Center())
+}
+
+var (
+	_ plot.Plotter     = (*ColorScatter)(nil)
+	_ plot.DataRanger  = (*ColorScatter)(nil)
+	_ plot.GlyphBoxer  = (*ColorScatter)(nil)
+	_ plot.Thumbnailer = (*ColorScatter)(nil)
+	_ ColorMapper      = (*ColorScatter)(nil)
+)
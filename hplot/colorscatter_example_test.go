@@ -0,0 +1,54 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// An example of making a scatter plot whose points are colored according
+// to a third variable, with a color bar.
+func ExampleColorScatter() {
+	const npoints = 1000
+
+	var (
+		rnd  = rand.New(rand.NewSource(1234))
+		gaus = distuv.Normal{Mu: 0, Sigma: 1, Src: rnd}
+		data = make(plotter.XYZs, npoints)
+	)
+
+	// correlation between x and y, as a function of a parameter z.
+	for i := range data {
+		var (
+			z   = 2*rnd.Float64() - 1
+			rho = 0.9 * z
+			x   = gaus.Rand()
+			y   = rho*x + math.Sqrt(1-rho*rho)*gaus.Rand()
+		)
+		data[i] = plotter.XYZ{X: x, Y: y, Z: z}
+	}
+
+	s := hplot.NewColorScatter(data, hplot.WithColorMap(hplot.Viridis()))
+	s.GlyphStyle.Radius = vg.Points(1.5)
+
+	p := hplot.New()
+	p.Title.Text = "Correlation vs parameter"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+	p.Add(s, hplot.NewGrid())
+
+	fig := hplot.Figure(p, hplot.WithColorBar(s))
+	err := hplot.Save(fig, 12*vg.Centimeter, 10*vg.Centimeter, "testdata/colorscatter.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestColorScatter(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleColorScatter, t, "colorscatter.png")
+}
+
+func TestColorScatterRange(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		data     plotter.XYZs
+		logz     bool
+		min, max float64
+	}{
+		{
+			name: "linear",
+			data: plotter.XYZs{{X: 0, Y: 0, Z: -1}, {X: 1, Y: 1, Z: 3}, {X: 2, Y: 2, Z: math.NaN()}},
+			min:  -1,
+			max:  3,
+		},
+		{
+			name: "log",
+			data: plotter.XYZs{{X: 0, Y: 0, Z: -1}, {X: 1, Y: 1, Z: 2}, {X: 2, Y: 2, Z: 20}},
+			logz: true,
+			min:  2,
+			max:  20,
+		},
+		{
+			name: "single",
+			data: plotter.XYZs{{X: 0, Y: 0, Z: 2}},
+			min:  2,
+			max:  3,
+		},
+		{
+			name: "empty",
+			data: plotter.XYZs{},
+			min:  0,
+			max:  1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := hplot.NewColorScatter(tc.data, hplot.WithLogZ(tc.logz))
+			min, max, logz := s.ZRange()
+			if min != tc.min || max != tc.max || logz != tc.logz {
+				t.Fatalf(
+					"invalid z-range: got=(%v, %v, %v), want=(%v, %v, %v)",
+					min, max, logz, tc.min, tc.max, tc.logz,
+				)
+			}
+		})
+	}
+}
+
+func TestColorScatterColor(t *testing.T) {
+	s := hplot.NewColorScatter(
+		plotter.XYZs{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 1, Z: 10}},
+		hplot.WithColorMap(hplot.Viridis()),
+	)
+
+	for _, tc := range []struct {
+		z    float64
+		want float64
+	}{
+		{-5, 0},
+		{0, 0},
+		{5, 0.5},
+		{10, 1},
+		{15, 1},
+	} {
+		if got, want := s.Color(tc.z), s.ColorAt(tc.want); got != want {
+			t.Fatalf("invalid color for z=%v: got=%v, want=%v", tc.z, got, want)
+		}
+	}
+
+	if got := s.Color(math.NaN()); got != nil {
+		t.Fatalf("invalid color for NaN: got=%v, want=nil", got)
+	}
+}
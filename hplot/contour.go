@@ -0,0 +1,593 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Contour implements the plot.Plotter interface, drawing the contour
+// lines of a 2-dim grid of values and, optionally, filling the areas
+// between contour levels.
+//
+// Contours are computed with a linear interpolation of the values
+// between the nodes of the grid, each cell of the grid being split into
+// 4 triangles around its center.
+type Contour struct {
+	// Grid is the grid of values.
+	Grid plotter.GridXYZ
+
+	// Levels are the values of the contour lines,
+	// in increasing order.
+	Levels []float64
+
+	// LineStyles are the styles of the contour lines,
+	// used in turn for each level.
+	// Use an empty slice to disable the contour lines.
+	LineStyles []draw.LineStyle
+
+	// FillColors are the colors of the areas between levels:
+	// FillColors[0] fills the area below Levels[0], FillColors[i] the
+	// area between Levels[i-1] and Levels[i] and FillColors[len(Levels)]
+	// the area above the last level.
+	// A nil color leaves the corresponding area unfilled.
+	FillColors []color.Color
+
+	// Labels enables the labeling of the contour lines.
+	Labels bool
+
+	// Names are the labels of the contour levels.
+	// Levels without a name are labeled with their value.
+	Names []string
+
+	// TextStyle is the style of the labels.
+	TextStyle text.Style
+}
+
+// NewContour returns a new contour plotter from a 2-dim grid of values.
+//
+// If levels is nil, 9 levels evenly spaced between the minimum and
+// maximum values of the grid are used.
+// Filled contours are enabled with the WithColorMap option: the areas
+// between levels are then colored according to the color map.
+func NewContour(grid plotter.GridXYZ, levels []float64, opts ...Options) *Contour {
+	cfg := newConfig(opts)
+
+	zmin, zmax := gridRange(grid)
+	if levels == nil {
+		const n = 9
+		levels = make([]float64, n)
+		for i := range levels {
+			levels[i] = zmin + float64(i+1)*(zmax-zmin)/(n+1)
+		}
+	}
+
+	ct := &Contour{
+		Grid:       grid,
+		Levels:     levels,
+		LineStyles: []draw.LineStyle{plotter.DefaultLineStyle},
+		TextStyle:  newContourTextStyle(),
+	}
+
+	if cfg.cmap != nil && len(levels) > 0 {
+		var (
+			n   = len(levels)
+			min = levels[0]
+			max = levels[n-1]
+		)
+		if min == max {
+			max = min + 1
+		}
+		cfg.cmap.SetMin(min)
+		cfg.cmap.SetMax(max)
+		ct.FillColors = make([]color.Color, n+1)
+		for i := range ct.FillColors {
+			var v float64
+			switch i {
+			case 0:
+				v = levels[0]
+			case n:
+				v = levels[n-1]
+			default:
+				v = 0.5 * (levels[i-1] + levels[i])
+			}
+			ct.FillColors[i] = colorAt(cfg.cmap, v)
+		}
+	}
+
+	return ct
+}
+
+// NewH2DContour returns a new contour plotter from the bin contents
+// of a 2-dim histogram.
+//
+// See NewContour for a description of the levels and options.
+func NewH2DContour(h *hbook.H2D, levels []float64, opts ...Options) *Contour {
+	return NewContour(h.GridXYZ(), levels, opts...)
+}
+
+// NewLikelihoodContour returns a new contour plotter from a 2-dim scan of
+// a likelihood, where the values of the scan are -2 ln(L).
+//
+// The contour levels correspond to the provided confidence levels
+// (e.g. 0.683, 0.954), assuming the -2 Δln(L) statistic follows a χ²
+// distribution with 2 degrees of freedom.
+// Levels are labeled with their confidence level.
+//
+// Filled contours are enabled with the WithColorMap option: the areas
+// within each contour are then colored according to the color map.
+func NewLikelihoodContour(scan plotter.GridXYZ, cls []float64, opts ...Options) *Contour {
+	cfg := newConfig(opts)
+
+	var (
+		zmin, _ = gridRange(scan)
+		chi2    = distuv.ChiSquared{K: 2}
+		levels  = make([]float64, len(cls))
+		names   = make([]string, len(cls))
+		styles  = make([]draw.LineStyle, len(cls))
+		dashes  = [][]vg.Length{
+			nil,
+			{vg.Points(6), vg.Points(3)},
+			{vg.Points(2), vg.Points(2)},
+		}
+	)
+	for i, cl := range cls {
+		levels[i] = zmin + chi2.Quantile(cl)
+		names[i] = fmt.Sprintf("%.4g%% CL", 100*cl)
+		styles[i] = plotter.DefaultLineStyle
+		styles[i].Dashes = dashes[i%len(dashes)]
+	}
+
+	ct := NewContour(scan, levels)
+	ct.Names = names
+	ct.LineStyles = styles
+
+	if cfg.cmap != nil && len(levels) > 0 {
+		n := len(levels)
+		cfg.cmap.SetMin(0)
+		cfg.cmap.SetMax(math.Max(1, float64(n-1)))
+		ct.FillColors = make([]color.Color, n+1)
+		for i := 0; i < n; i++ {
+			ct.FillColors[i] = colorAt(cfg.cmap, float64(i))
+		}
+	}
+
+	return ct
+}
+
+func newContourTextStyle() text.Style {
+	return text.Style{
+		Color:   color.Black,
+		Font:    font.From(DefaultStyle.Fonts.Tick, DefaultStyle.Fonts.Tick.Size),
+		XAlign:  draw.XCenter,
+		YAlign:  draw.YCenter,
+		Handler: DefaultStyle.TextHandler,
+	}
+}
+
+// colorAt returns the color of the color map at the provided value,
+// or nil if the value is outside the color map range.
+func colorAt(cmap palette.ColorMap, v float64) color.Color {
+	col, err := cmap.At(v)
+	if err != nil {
+		return nil
+	}
+	return col
+}
+
+// gridRange returns the minimum and maximum (non-NaN) values of the grid.
+func gridRange(grid plotter.GridXYZ) (min, max float64) {
+	min = math.Inf(+1)
+	max = math.Inf(-1)
+	nc, nr := grid.Dims()
+	for r := 0; r < nr; r++ {
+		for c := 0; c < nc; c++ {
+			z := grid.Z(c, r)
+			if math.IsNaN(z) {
+				continue
+			}
+			min = math.Min(min, z)
+			max = math.Max(max, z)
+		}
+	}
+	if math.IsInf(min, +1) {
+		return 0, 1
+	}
+	return min, max
+}
+
+// Name returns the name of the i-th level.
+func (ct *Contour) Name(i int) string {
+	if i < len(ct.Names) && ct.Names[i] != "" {
+		return ct.Names[i]
+	}
+	return fmt.Sprintf("%g", ct.Levels[i])
+}
+
+// Plot implements the Plotter interface, drawing the filled areas
+// and the contour lines of the grid.
+func (ct *Contour) Plot(c draw.Canvas, p *plot.Plot) {
+	trX, trY := p.Transforms(&c)
+	tris := ct.triangles()
+
+	for i, col := range ct.FillColors {
+		if col == nil || i > len(ct.Levels) {
+			continue
+		}
+		lo, hi := math.Inf(-1), math.Inf(+1)
+		if i > 0 {
+			lo = ct.Levels[i-1]
+		}
+		if i < len(ct.Levels) {
+			hi = ct.Levels[i]
+		}
+		// stroke the edges of the areas to hide the seams
+		// between adjacent triangles.
+		sty := draw.LineStyle{Color: col, Width: vg.Points(0.5)}
+		for _, tri := range tris {
+			poly := clipTriangle(tri, lo, hi)
+			if len(poly) < 3 {
+				continue
+			}
+			pts := make([]vg.Point, len(poly))
+			for j, v := range poly {
+				pts[j] = vg.Point{X: trX(v.x), Y: trY(v.y)}
+			}
+			c.FillPolygon(col, c.ClipPolygonXY(pts))
+			c.StrokeLines(sty, c.ClipLinesXY(append(pts, pts[0]))...)
+		}
+	}
+
+	if len(ct.LineStyles) == 0 {
+		return
+	}
+
+	for i, lvl := range ct.Levels {
+		sty := ct.LineStyles[i%len(ct.LineStyles)]
+		for _, line := range contourLines(tris, lvl) {
+			pts := make([]vg.Point, len(line))
+			for j, v := range line {
+				pts[j] = vg.Point{X: trX(v.X), Y: trY(v.Y)}
+			}
+			c.StrokeLines(sty, c.ClipLinesXY(pts)...)
+			if ct.Labels {
+				ct.label(c, pts, ct.Name(i))
+			}
+		}
+	}
+}
+
+// label draws the provided label at the middle of the contour line,
+// provided the line is long enough.
+func (ct *Contour) label(c draw.Canvas, pts []vg.Point, txt string) {
+	var (
+		sty = ct.TextStyle
+		w   = sty.Width(txt)
+		h   = sty.Height(txt)
+		tot vg.Length
+	)
+	for i := 1; i < len(pts); i++ {
+		tot += dist(pts[i-1], pts[i])
+	}
+	if tot < 3*w {
+		return
+	}
+
+	var (
+		half = tot / 2
+		cur  vg.Length
+	)
+	for i := 1; i < len(pts); i++ {
+		a, b := pts[i-1], pts[i]
+		d := dist(a, b)
+		if cur+d < half || d == 0 {
+			cur += d
+			continue
+		}
+		f := (half - cur) / d
+		pos := vg.Point{X: a.X + f*(b.X-a.X), Y: a.Y + f*(b.Y-a.Y)}
+		if !c.Contains(pos) {
+			return
+		}
+
+		// keep the labels upright.
+		angle := math.Atan2(float64(b.Y-a.Y), float64(b.X-a.X))
+		switch {
+		case angle > math.Pi/2:
+			angle -= math.Pi
+		case angle < -math.Pi/2:
+			angle += math.Pi
+		}
+		sty.Rotation = angle
+
+		var (
+			cos, sin = vg.Length(math.Cos(angle)), vg.Length(math.Sin(angle))
+			dw, dh   = 0.5*w + h/4, 0.5 * h
+			box      = make([]vg.Point, 0, 4)
+		)
+		for _, v := range [][2]vg.Length{{-dw, -dh}, {+dw, -dh}, {+dw, +dh}, {-dw, +dh}} {
+			box = append(box, vg.Point{
+				X: pos.X + v[0]*cos - v[1]*sin,
+				Y: pos.Y + v[0]*sin + v[1]*cos,
+			})
+		}
+		c.FillPolygon(color.White, box)
+		c.FillText(sty, pos, txt)
+		return
+	}
+}
+
+func dist(a, b vg.Point) vg.Length {
+	return vg.Length(math.Hypot(float64(b.X-a.X), float64(b.Y-a.Y)))
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (ct *Contour) DataRange() (xmin, xmax, ymin, ymax float64) {
+	nc, nr := ct.Grid.Dims()
+	xmin, xmax = math.Inf(+1), math.Inf(-1)
+	ymin, ymax = math.Inf(+1), math.Inf(-1)
+	for i := 0; i < nc; i++ {
+		x := ct.Grid.X(i)
+		xmin = math.Min(xmin, x)
+		xmax = math.Max(xmax, x)
+	}
+	for i := 0; i < nr; i++ {
+		y := ct.Grid.Y(i)
+		ymin = math.Min(ymin, y)
+		ymax = math.Max(ymax, y)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnailers returns a thumbnailer for each contour level, drawn with
+// the line style of the level and filled with the color of the area
+// below the level, if any.
+//
+// Thumbnailers can be used to add the contour levels to a legend.
+func (ct *Contour) Thumbnailers() []plot.Thumbnailer {
+	ths := make([]plot.Thumbnailer, len(ct.Levels))
+	for i := range ct.Levels {
+		var th contourThumb
+		if len(ct.LineStyles) > 0 {
+			th.line = ct.LineStyles[i%len(ct.LineStyles)]
+		}
+		if i < len(ct.FillColors) {
+			th.fill = ct.FillColors[i]
+		}
+		ths[i] = th
+	}
+	return ths
+}
+
+type contourThumb struct {
+	line draw.LineStyle
+	fill color.Color
+}
+
+func (th contourThumb) Thumbnail(c *draw.Canvas) {
+	if th.fill != nil {
+		pts := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y},
+			{X: c.Max.X, Y: c.Min.Y},
+			{X: c.Max.X, Y: c.Max.Y},
+			{X: c.Min.X, Y: c.Max.Y},
+		}
+		c.FillPolygon(th.fill, c.ClipPolygonXY(pts))
+	}
+	if th.line.Width != 0 {
+		y := c.Center().Y
+		c.StrokeLine2(th.line, c.Min.X, y, c.Max.X, y)
+	}
+}
+
+// ctVertex is a vertex of the triangulated grid.
+type ctVertex struct {
+	id      int // identifier of the vertex, used to connect contour segments.
+	x, y, z float64
+}
+
+// triangles returns the triangulation of the grid: each cell of the grid
+// is split into 4 triangles around its center.
+// Cells with a NaN value are discarded.
+func (ct *Contour) triangles() [][3]ctVertex {
+	nc, nr := ct.Grid.Dims()
+	if nc < 2 || nr < 2 {
+		return nil
+	}
+
+	vtx := func(c, r int) ctVertex {
+		return ctVertex{
+			id: r*nc + c,
+			x:  ct.Grid.X(c),
+			y:  ct.Grid.Y(r),
+			z:  ct.Grid.Z(c, r),
+		}
+	}
+
+	tris := make([][3]ctVertex, 0, 4*(nc-1)*(nr-1))
+	for r := 0; r < nr-1; r++ {
+		for c := 0; c < nc-1; c++ {
+			var (
+				a = vtx(c, r)
+				b = vtx(c+1, r)
+				d = vtx(c+1, r+1)
+				e = vtx(c, r+1)
+				o = ctVertex{
+					id: nc*nr + r*(nc-1) + c,
+					x:  0.25 * (a.x + b.x + d.x + e.x),
+					y:  0.25 * (a.y + b.y + d.y + e.y),
+					z:  0.25 * (a.z + b.z + d.z + e.z),
+				}
+			)
+			if math.IsNaN(o.z) {
+				continue
+			}
+			tris = append(tris,
+				[3]ctVertex{a, b, o},
+				[3]ctVertex{b, d, o},
+				[3]ctVertex{d, e, o},
+				[3]ctVertex{e, a, o},
+			)
+		}
+	}
+	return tris
+}
+
+// ctEdge identifies an edge of the triangulated grid.
+type ctEdge [2]int
+
+func newCtEdge(a, b ctVertex) ctEdge {
+	if a.id > b.id {
+		a, b = b, a
+	}
+	return ctEdge{a.id, b.id}
+}
+
+// ctSegment is a segment of a contour line, crossing a triangle.
+type ctSegment struct {
+	edges [2]ctEdge
+	pts   [2]plotter.XY
+}
+
+// interp returns the point of the edge (a,b) where the linearly
+// interpolated value is equal to the provided level.
+func interp(a, b ctVertex, lvl float64) plotter.XY {
+	f := (lvl - a.z) / (b.z - a.z)
+	return plotter.XY{X: a.x + f*(b.x-a.x), Y: a.y + f*(b.y-a.y)}
+}
+
+// contourLines returns the contour lines of the triangulated grid at
+// the provided level.
+func contourLines(tris [][3]ctVertex, lvl float64) []plotter.XYs {
+	var segs []ctSegment
+	for _, tri := range tris {
+		var (
+			seg ctSegment
+			n   int
+		)
+		for i := range tri {
+			a, b := tri[i], tri[(i+1)%3]
+			if (a.z >= lvl) == (b.z >= lvl) {
+				continue
+			}
+			seg.edges[n] = newCtEdge(a, b)
+			seg.pts[n] = interp(a, b, lvl)
+			n++
+		}
+		if n == 2 {
+			segs = append(segs, seg)
+		}
+	}
+
+	// connect the segments sharing an edge into lines.
+	var (
+		edges = make(map[ctEdge][]int, 2*len(segs))
+		used  = make([]bool, len(segs))
+		lines []plotter.XYs
+	)
+	for i, seg := range segs {
+		for _, e := range seg.edges {
+			edges[e] = append(edges[e], i)
+		}
+	}
+
+	follow := func(e ctEdge) plotter.XYs {
+		var pts plotter.XYs
+		for {
+			next := -1
+			for _, i := range edges[e] {
+				if !used[i] {
+					next = i
+					break
+				}
+			}
+			if next < 0 {
+				return pts
+			}
+			used[next] = true
+			seg := segs[next]
+			j := 1
+			if seg.edges[1] == e {
+				j = 0
+			}
+			pts = append(pts, seg.pts[j])
+			e = seg.edges[j]
+		}
+	}
+
+	for i, seg := range segs {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		var (
+			fwd = follow(seg.edges[1])
+			bwd = follow(seg.edges[0])
+		)
+		line := make(plotter.XYs, 0, len(bwd)+len(fwd)+2)
+		for j := len(bwd) - 1; j >= 0; j-- {
+			line = append(line, bwd[j])
+		}
+		line = append(line, seg.pts[0], seg.pts[1])
+		line = append(line, fwd...)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// clipTriangle returns the part of the triangle where the linearly
+// interpolated value lies within [lo, hi].
+func clipTriangle(tri [3]ctVertex, lo, hi float64) []ctVertex {
+	poly := tri[:]
+	if !math.IsInf(lo, -1) {
+		poly = clipPolygon(poly, lo, +1)
+	}
+	if !math.IsInf(hi, +1) {
+		poly = clipPolygon(poly, hi, -1)
+	}
+	return poly
+}
+
+// clipPolygon returns the part of the polygon above (sign=+1) or
+// below (sign=-1) the provided level.
+func clipPolygon(poly []ctVertex, lvl, sign float64) []ctVertex {
+	var (
+		out = make([]ctVertex, 0, len(poly)+1)
+		in  = func(v ctVertex) bool { return sign*(v.z-lvl) >= 0 }
+	)
+	for i, cur := range poly {
+		prev := poly[(i+len(poly)-1)%len(poly)]
+		switch {
+		case in(cur):
+			if !in(prev) {
+				xy := interp(prev, cur, lvl)
+				out = append(out, ctVertex{x: xy.X, y: xy.Y, z: lvl})
+			}
+			out = append(out, cur)
+		case in(prev):
+			xy := interp(prev, cur, lvl)
+			out = append(out, ctVertex{x: xy.X, y: xy.Y, z: lvl})
+		}
+	}
+	return out
+}
+
+var (
+	_ plot.Plotter    = (*Contour)(nil)
+	_ plot.DataRanger = (*Contour)(nil)
+)
@@ -0,0 +1,109 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distmv"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleContour() {
+	h2d := hbook.NewH2D(40, -6, 6, 40, -5, 7)
+
+	const npoints = 100000
+
+	dist, ok := distmv.NewNormal(
+		[]float64{0, 1},
+		mat.NewSymDense(2, []float64{4, 1, 1, 2}),
+		rand.New(rand.NewSource(1234)),
+	)
+	if !ok {
+		log.Fatalf("error creating distmv.Normal")
+	}
+
+	v := make([]float64, 2)
+	for i := 0; i < npoints; i++ {
+		v = dist.Rand(v)
+		h2d.Fill(v[0], v[1], 1)
+	}
+
+	// Display filled contours, with labeled contour lines.
+	ct := hplot.NewH2DContour(
+		h2d, []float64{50, 200, 400},
+		hplot.WithColorMap(moreland.SmoothBlueRed()),
+	)
+	ct.Labels = true
+
+	p := hplot.New()
+	p.Title.Text = "Hist-2D contours"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	p.Add(ct)
+
+	err := p.Save(12*vg.Centimeter, 10*vg.Centimeter, "testdata/contour.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleContour_likelihood() {
+	const (
+		mu0  = 1.0
+		sig0 = 0.5
+	)
+
+	// -2 ln(L) of a 2-dim scan of the (mu, sigma) parameters,
+	// for a non-Gaussian likelihood.
+	nll := func(mu, sig float64) float64 {
+		var (
+			dmu  = (mu - mu0) / 0.2
+			dsig = (sig - sig0) / 0.1
+		)
+		return dmu*dmu + dsig*dsig - 0.8*dmu*dsig + 0.1*dsig*dsig*dsig
+	}
+
+	scan := hbook.NewH2D(60, 0.2, 1.8, 60, 0.1, 0.9)
+	for _, bin := range scan.Binning.Bins {
+		x, y := bin.XMid(), bin.YMid()
+		scan.Fill(x, y, nll(x, y))
+	}
+
+	ct := hplot.NewLikelihoodContour(
+		scan.GridXYZ(), []float64{0.683, 0.954},
+		hplot.WithColorMap(moreland.SmoothBlueRed()),
+	)
+	ct.FillColors[1] = nil // only fill the 68.3% CL area.
+
+	best := hplot.NewS2D(plotter.XYs{{X: mu0, Y: sig0}})
+	best.GlyphStyle.Shape = draw.CrossGlyph{}
+	best.GlyphStyle.Radius = vg.Points(4)
+
+	p := hplot.New()
+	p.Title.Text = "Likelihood scan"
+	p.X.Label.Text = "μ"
+	p.Y.Label.Text = "σ"
+
+	p.Add(ct, best)
+	for i, th := range ct.Thumbnailers() {
+		p.Legend.Add(ct.Name(i), th)
+	}
+	p.Legend.Add("best fit", best)
+	p.Legend.Top = true
+
+	err := p.Save(12*vg.Centimeter, 10*vg.Centimeter, "testdata/contour_likelihood.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/palette/moreland"
+)
+
+func TestContour(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleContour, t, "contour.png")
+}
+
+func TestLikelihoodContour(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleContour_likelihood, t, "contour_likelihood.png")
+}
+
+func TestLikelihoodContourLevels(t *testing.T) {
+	scan := hbook.NewH2D(3, 0, 3, 3, 0, 3)
+	for _, bin := range scan.Binning.Bins {
+		x, y := bin.XMid(), bin.YMid()
+		scan.Fill(x, y, 10+(x-1.5)*(x-1.5)+(y-1.5)*(y-1.5))
+	}
+
+	cls := []float64{0.683, 0.954}
+	ct := hplot.NewLikelihoodContour(scan.GridXYZ(), cls)
+	for i, cl := range cls {
+		// quantile of a χ² distribution with 2 degrees of freedom.
+		want := 10 - 2*math.Log(1-cl)
+		if got := ct.Levels[i]; math.Abs(got-want) > 1e-9 {
+			t.Fatalf("invalid level[%d]: got=%v, want=%v", i, got, want)
+		}
+	}
+	for i, want := range []string{"68.3% CL", "95.4% CL"} {
+		if got := ct.Name(i); got != want {
+			t.Fatalf("invalid name[%d]: got=%q, want=%q", i, got, want)
+		}
+	}
+	if ct.FillColors != nil {
+		t.Fatalf("unexpected fill colors")
+	}
+
+	xmin, xmax, ymin, ymax := ct.DataRange()
+	if xmin != 0.5 || xmax != 2.5 || ymin != 0.5 || ymax != 2.5 {
+		t.Fatalf("invalid data range: x=[%v, %v], y=[%v, %v]", xmin, xmax, ymin, ymax)
+	}
+}
+
+func TestContourDefaultLevels(t *testing.T) {
+	h := hbook.NewH2D(2, 0, 2, 2, 0, 2)
+	h.Fill(0.5, 0.5, 10)
+	h.Fill(1.5, 1.5, 20)
+
+	ct := hplot.NewH2DContour(h, nil, hplot.WithColorMap(moreland.Kindlmann()))
+	if got, want := len(ct.Levels), 9; got != want {
+		t.Fatalf("invalid number of levels: got=%d, want=%d", got, want)
+	}
+	for i, lvl := range ct.Levels {
+		want := float64(2 * (i + 1))
+		if math.Abs(lvl-want) > 1e-12 {
+			t.Fatalf("invalid level[%d]: got=%v, want=%v", i, lvl, want)
+		}
+		if got := ct.Name(i); got != fmt.Sprintf("%g", lvl) {
+			t.Fatalf("invalid name[%d]: got=%q", i, got)
+		}
+	}
+	if got, want := len(ct.FillColors), len(ct.Levels)+1; got != want {
+		t.Fatalf("invalid number of fill colors: got=%d, want=%d", got, want)
+	}
+	if got, want := len(ct.Thumbnailers()), len(ct.Levels); got != want {
+		t.Fatalf("invalid number of thumbnailers: got=%d, want=%d", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// StyleCycle assigns colors, line dashes and glyph shapes to plotters,
+// cycling through the provided styles.
+//
+// When a StyleCycle is attached to a Plot, the plotters added to the plot
+// with Plot.Add are styled automatically: each styled plotter receives the
+// next style of the cycle.
+//
+// Only plotters with a default (nil or black) color are styled, so colors
+// explicitly set by the user are preserved.
+// The supported plotters are H1D, S2D, P1D, Eff1D, Function, plotter.Line
+// and plotter.Scatter.
+type StyleCycle struct {
+	// Colors are the colors of the cycle.
+	Colors []color.Color
+
+	// Dashes are the dash patterns applied to lines without dashes.
+	// Dashes are not modified when empty.
+	Dashes [][]vg.Length
+
+	// Shapes are the glyph shapes applied to glyphs.
+	// Shapes are not modified when empty.
+	Shapes []draw.GlyphDrawer
+
+	n int // index of the next style.
+}
+
+// NewStyleCycle returns a style cycle with the colors of the provided
+// palette.
+func NewStyleCycle(pal palette.Palette) *StyleCycle {
+	return &StyleCycle{
+		Colors: pal.Colors(),
+	}
+}
+
+// Next returns the next style of the cycle.
+// A nil dashes or shape is returned if the cycle has no dashes or shapes.
+func (sc *StyleCycle) Next() (c color.Color, dashes []vg.Length, shape draw.GlyphDrawer) {
+	i := sc.n
+	sc.n++
+	if n := len(sc.Colors); n > 0 {
+		c = sc.Colors[i%n]
+	}
+	if n := len(sc.Dashes); n > 0 {
+		dashes = sc.Dashes[i%n]
+	}
+	if n := len(sc.Shapes); n > 0 {
+		shape = sc.Shapes[i%n]
+	}
+	return c, dashes, shape
+}
+
+// Reset restarts the cycle from its first style.
+func (sc *StyleCycle) Reset() {
+	sc.n = 0
+}
+
+// Apply styles the provided plotter with the next style of the cycle.
+// Apply returns whether the plotter was styled.
+func (sc *StyleCycle) Apply(p plot.Plotter) bool {
+	switch p := p.(type) {
+	case *H1D:
+		if !isDefaultColor(p.LineStyle.Color) {
+			return false
+		}
+		c, dashes, shape := sc.Next()
+		sc.line(&p.LineStyle, c, dashes)
+		if p.GlyphStyle.Radius != 0 {
+			sc.glyph(&p.GlyphStyle, c, shape)
+		}
+		if p.YErrs != nil {
+			p.YErrs.LineStyle.Color = c
+		}
+		return true
+
+	case *S2D:
+		if !isDefaultColor(p.GlyphStyle.Color) {
+			return false
+		}
+		c, dashes, shape := sc.Next()
+		sc.glyph(&p.GlyphStyle, c, shape)
+		if p.LineStyle.Width != 0 {
+			sc.line(&p.LineStyle, c, dashes)
+		}
+		return true
+
+	case *P1D:
+		return sc.Apply(p.S2D)
+
+	case *Eff1D:
+		return sc.Apply(p.S2D)
+
+	case *Function:
+		if !isDefaultColor(p.LineStyle.Color) {
+			return false
+		}
+		c, dashes, _ := sc.Next()
+		sc.line(&p.LineStyle, c, dashes)
+		return true
+
+	case *plotter.Line:
+		if !isDefaultColor(p.LineStyle.Color) {
+			return false
+		}
+		c, dashes, _ := sc.Next()
+		sc.line(&p.LineStyle, c, dashes)
+		return true
+
+	case *plotter.Scatter:
+		if !isDefaultColor(p.GlyphStyle.Color) {
+			return false
+		}
+		c, _, shape := sc.Next()
+		sc.glyph(&p.GlyphStyle, c, shape)
+		return true
+	}
+	return false
+}
+
+func (sc *StyleCycle) line(sty *draw.LineStyle, c color.Color, dashes []vg.Length) {
+	sty.Color = c
+	if len(sty.Dashes) == 0 && dashes != nil {
+		sty.Dashes = dashes
+	}
+}
+
+func (sc *StyleCycle) glyph(sty *draw.GlyphStyle, c color.Color, shape draw.GlyphDrawer) {
+	sty.Color = c
+	if shape != nil {
+		sty.Shape = shape
+	}
+}
+
+// isDefaultColor returns whether the provided color is nil or opaque black,
+// the default color of plotters.
+func isDefaultColor(c color.Color) bool {
+	if c == nil {
+		return true
+	}
+	r, g, b, a := c.RGBA()
+	return r == 0 && g == 0 && b == 0 && a == 0xffff
+}
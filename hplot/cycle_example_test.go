@@ -0,0 +1,62 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleStyleCycle() {
+	const npoints = 10000
+
+	p := hplot.New()
+	p.Title.Text = "Style cycle"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "Entries"
+
+	// Colorblind-safe colors, with a different
+	// dash pattern for each histogram.
+	p.Cycle = hplot.NewStyleCycle(hplot.Petroff6())
+	p.Cycle.Dashes = plotutil.DefaultDashes
+
+	src := rand.New(rand.NewSource(1234))
+	for i, mu := range []float64{-2, 0, 2} {
+		dist := distuv.Normal{Mu: mu, Sigma: 1, Src: src}
+		h := hbook.NewH1D(40, -5, 5)
+		for j := 0; j < npoints; j++ {
+			h.Fill(dist.Rand(), 1)
+		}
+		hh := hplot.NewH1D(h)
+		hh.LineStyle.Width = vg.Points(1.5)
+		p.Add(hh)
+		p.Legend.Add(fmt.Sprintf("h%d: μ=%+g", i, mu), hh)
+	}
+
+	// Explicitly set colors are preserved.
+	f := hplot.NewFunction(func(x float64) float64 {
+		return npoints * 0.25 / math.Sqrt(2*math.Pi) * math.Exp(-0.5*x*x)
+	})
+	f.LineStyle.Color = color.Gray{Y: 100}
+	f.LineStyle.Width = vg.Points(1)
+	p.Add(f)
+	p.Legend.Add("model", f)
+	p.Legend.Top = true
+	p.Y.Max = 1300
+
+	err := p.Save(15*vg.Centimeter, -1, "testdata/style_cycle.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func TestStyleCycle(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleStyleCycle, t, "style_cycle.png")
+}
+
+func TestStyleCycleApply(t *testing.T) {
+	var (
+		red   = color.NRGBA{R: 255, A: 255}
+		green = color.NRGBA{G: 255, A: 255}
+		blue  = color.NRGBA{B: 255, A: 255}
+		dash  = []vg.Length{vg.Points(2), vg.Points(2)}
+	)
+
+	p := hplot.New()
+	p.Cycle = &hplot.StyleCycle{
+		Colors: []color.Color{red, green},
+		Dashes: [][]vg.Length{dash},
+		Shapes: []draw.GlyphDrawer{draw.BoxGlyph{}},
+	}
+
+	h1 := hplot.NewH1D(hbook.NewH1D(10, 0, 10), hplot.WithYErrBars(true))
+	h2 := hplot.NewH1D(hbook.NewH1D(10, 0, 10))
+	h2.LineStyle.Color = blue
+	s1 := hplot.NewS2D(hbook.NewS2D(hbook.Point2D{X: 1, Y: 1}))
+	fct := hplot.NewFunction(func(x float64) float64 { return x })
+	grid := plotter.NewGrid()
+
+	p.Add(h1, h2, s1, fct, grid)
+
+	if got, want := h1.LineStyle.Color, color.Color(red); got != want {
+		t.Fatalf("invalid h1 color: got=%v, want=%v", got, want)
+	}
+	if got := h1.YErrs.LineStyle.Color; got != color.Color(red) {
+		t.Fatalf("invalid h1 y-errors color: got=%v", got)
+	}
+	if got := h1.LineStyle.Dashes; len(got) != len(dash) {
+		t.Fatalf("invalid h1 dashes: got=%v, want=%v", got, dash)
+	}
+	if got, want := h2.LineStyle.Color, color.Color(blue); got != want {
+		t.Fatalf("user color was modified: got=%v, want=%v", got, want)
+	}
+	if got, want := s1.GlyphStyle.Color, color.Color(green); got != want {
+		t.Fatalf("invalid s2d color: got=%v, want=%v", got, want)
+	}
+	if _, ok := s1.GlyphStyle.Shape.(draw.BoxGlyph); !ok {
+		t.Fatalf("invalid s2d glyph shape: %T", s1.GlyphStyle.Shape)
+	}
+	if got, want := fct.LineStyle.Color, color.Color(red); got != want {
+		t.Fatalf("invalid function color: got=%v, want=%v", got, want)
+	}
+
+	p.Cycle.Reset()
+	if c, _, _ := p.Cycle.Next(); c != color.Color(red) {
+		t.Fatalf("invalid color after reset: got=%v, want=%v", c, red)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Eff1D plots a 1-dim efficiency histogram: the efficiency of each x-bin,
+// with the (asymmetric) binomial confidence interval of the efficiency.
+//
+// Bins without any entry are not displayed.
+type Eff1D struct {
+	// Eff is the efficiency histogram being displayed.
+	Eff *hbook.Eff1D
+
+	// S2D displays the efficiency of each non-empty x-bin.
+	// The x error bars span the x-bins and the y error bars
+	// span the confidence intervals.
+	*S2D
+}
+
+// NewEff1D creates a new efficiency plot from the provided efficiency
+// histogram.
+//
+// By default, the statistic and confidence level of the efficiency
+// histogram are used to compute the confidence intervals.
+// They can be overridden with the WithEffStat and WithEffCL options,
+// without modifying the efficiency histogram.
+func NewEff1D(e *hbook.Eff1D, opts ...Options) (*Eff1D, error) {
+	cfg := newConfig(opts)
+
+	eff := *e
+	if cfg.eff.stat != nil {
+		eff.Stat = *cfg.eff.stat
+	}
+	if cfg.eff.cl != 0 {
+		eff.CL = cfg.eff.cl
+	}
+
+	switch eff.Stat {
+	case hbook.EffFeldmanCousins:
+		return nil, fmt.Errorf("hplot: could not create efficiency plot: %v intervals not supported", eff.Stat)
+	}
+	if !(0 < eff.CL && eff.CL < 1) {
+		return nil, fmt.Errorf("hplot: could not create efficiency plot: invalid confidence level %v", eff.CL)
+	}
+
+	var (
+		bins = eff.Total.Binning.Bins
+		pts  = make([]hbook.Point2D, 0, len(bins))
+	)
+	for i, bin := range bins {
+		if bin.SumW() == 0 {
+			continue
+		}
+		var (
+			x      = bin.XMid()
+			y      = eff.Eff(i)
+			lo, hi = eff.Interval(i)
+		)
+		pts = append(pts, hbook.Point2D{
+			X:    x,
+			Y:    y,
+			ErrX: hbook.Range{Min: x - bin.XMin(), Max: bin.XMax() - x},
+			ErrY: hbook.Range{Min: math.Max(0, y-lo), Max: math.Max(0, hi-y)},
+		})
+	}
+
+	opts = append([]Options{WithXErrBars(true), WithYErrBars(true)}, opts...)
+	s := NewS2D(hbook.NewS2D(pts...), opts...)
+	if cfg.glyph == (draw.GlyphStyle{}) {
+		s.GlyphStyle = draw.GlyphStyle{
+			Shape:  draw.CircleGlyph{},
+			Color:  color.Black,
+			Radius: vg.Points(2),
+		}
+	}
+
+	return &Eff1D{
+		Eff: e,
+		S2D: s,
+	}, nil
+}
+
+// NewEff1DFrom creates a new efficiency plot from the histograms of
+// events passing a selection and of all events.
+// Both histograms must have the same binning.
+//
+// The confidence intervals are Clopper-Pearson intervals, at a 68.27%
+// confidence level, unless the WithEffStat or WithEffCL options are used.
+func NewEff1DFrom(passed, total *hbook.H1D, opts ...Options) (*Eff1D, error) {
+	if passed.Len() != total.Len() {
+		return nil, fmt.Errorf(
+			"hplot: could not create efficiency plot: histograms with different number of bins (passed=%d, total=%d)",
+			passed.Len(), total.Len(),
+		)
+	}
+	for i, pbin := range passed.Binning.Bins {
+		tbin := total.Binning.Bins[i]
+		if pbin.Range != tbin.Range {
+			return nil, fmt.Errorf(
+				"hplot: could not create efficiency plot: histograms with different binning (bin=%d, passed=%v, total=%v)",
+				i, pbin.Range, tbin.Range,
+			)
+		}
+	}
+
+	return NewEff1D(hbook.NewEff1DFrom(passed, total), opts...)
+}
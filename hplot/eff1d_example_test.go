@@ -0,0 +1,85 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleEff1D() {
+	const npoints = 2000
+
+	// Turn-on curves of two triggers, as a function
+	// of the transverse momentum of the particles.
+	var (
+		src = rand.New(rand.NewSource(1234))
+		pt  = distuv.Exponential{Rate: 1.0 / 20, Src: src}
+		u   = distuv.Uniform{Min: 0, Max: 1, Src: src}
+	)
+
+	turnOn := func(x, thr, width, plateau float64) float64 {
+		return plateau * 0.5 * (1 + math.Erf((x-thr)/(math.Sqrt2*width)))
+	}
+
+	var (
+		effA  = hbook.NewEff1D(25, 0, 100)
+		total = hbook.NewH1D(25, 0, 100)
+		passB = hbook.NewH1D(25, 0, 100)
+	)
+	for i := 0; i < npoints; i++ {
+		x := pt.Rand()
+		effA.Fill(x, u.Rand() < turnOn(x, 25, 5, 0.98), 1)
+		total.Fill(x, 1)
+		if u.Rand() < turnOn(x, 40, 10, 0.9) {
+			passB.Fill(x, 1)
+		}
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Trigger efficiency"
+	p.X.Label.Text = "p_T [GeV]"
+	p.Y.Label.Text = "Efficiency"
+	p.Y.Min = 0
+	p.Y.Max = 1.2
+
+	// Clopper-Pearson intervals, the default.
+	a, err := hplot.NewEff1D(effA)
+	if err != nil {
+		log.Fatalf("could not create efficiency plot: %+v", err)
+	}
+
+	// Wilson intervals.
+	b, err := hplot.NewEff1DFrom(passB, total,
+		hplot.WithEffStat(hbook.EffWilson),
+		hplot.WithGlyphStyle(draw.GlyphStyle{
+			Shape:  draw.BoxGlyph{},
+			Color:  color.NRGBA{R: 255, A: 255},
+			Radius: vg.Points(2),
+		}),
+	)
+	if err != nil {
+		log.Fatalf("could not create efficiency plot: %+v", err)
+	}
+
+	p.Add(a, b, hplot.NewGrid())
+	p.Legend.Add("trigger A (Clopper-Pearson)", a)
+	p.Legend.Add("trigger B (Wilson)", b)
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	err = p.Save(15*vg.Centimeter, -1, "testdata/eff1d_plot.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
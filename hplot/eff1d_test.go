@@ -0,0 +1,94 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestEff1D(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleEff1D, t, "eff1d_plot.png")
+}
+
+func TestEff1DIntervals(t *testing.T) {
+	eff := hbook.NewEff1D(3, 0, 3)
+	for i := 0; i < 10; i++ {
+		eff.Fill(0.5, i < 7, 1)
+	}
+	for i := 0; i < 4; i++ {
+		eff.Fill(2.5, true, 1)
+	}
+
+	for _, tc := range []struct {
+		stat hbook.EffStat
+		cl   float64
+	}{
+		{hbook.EffClopperPearson, hbook.DefaultEffCL},
+		{hbook.EffWilson, hbook.DefaultEffCL},
+		{hbook.EffClopperPearson, 0.95},
+		{hbook.EffJeffrey, 0.9},
+	} {
+		t.Run(tc.stat.String(), func(t *testing.T) {
+			p, err := hplot.NewEff1D(eff, hplot.WithEffStat(tc.stat), hplot.WithEffCL(tc.cl))
+			if err != nil {
+				t.Fatalf("could not create efficiency plot: %+v", err)
+			}
+			if eff.Stat != hbook.EffClopperPearson || eff.CL != hbook.DefaultEffCL {
+				t.Fatalf("efficiency histogram was modified")
+			}
+			if got, want := p.Data.Len(), 2; got != want {
+				t.Fatalf("invalid number of points: got=%d, want=%d", got, want)
+			}
+
+			ref := *eff
+			ref.Stat = tc.stat
+			ref.CL = tc.cl
+			for i, bin := range []int{0, 2} {
+				x, y := p.Data.XY(i)
+				if want := ref.Eff(bin); x != float64(bin)+0.5 || y != want {
+					t.Fatalf("invalid point %d: got=(%v, %v), want=(%v, %v)", i, x, y, float64(bin)+0.5, want)
+				}
+				lo, hi := ref.Interval(bin)
+				var (
+					elo = p.YErrs.YErrors[i].Low
+					ehi = p.YErrs.YErrors[i].High
+				)
+				if math.Abs(y-elo-lo) > 1e-12 || math.Abs(y+ehi-hi) > 1e-12 {
+					t.Fatalf("invalid interval %d: got=(%v, %v), want=(%v, %v)", i, y-elo, y+ehi, lo, hi)
+				}
+			}
+		})
+	}
+}
+
+func TestEff1DErrors(t *testing.T) {
+	eff := hbook.NewEff1D(3, 0, 3)
+	for _, stat := range []hbook.EffStat{hbook.EffFeldmanCousins} {
+		_, err := hplot.NewEff1D(eff, hplot.WithEffStat(stat))
+		if err == nil {
+			t.Fatalf("expected an error for %v", stat)
+		}
+	}
+
+	_, err := hplot.NewEff1D(eff, hplot.WithEffCL(1.5))
+	if err == nil {
+		t.Fatalf("expected an error for an invalid confidence level")
+	}
+
+	_, err = hplot.NewEff1DFrom(hbook.NewH1D(3, 0, 3), hbook.NewH1D(4, 0, 3))
+	if err == nil {
+		t.Fatalf("expected an error for different number of bins")
+	}
+
+	_, err = hplot.NewEff1DFrom(hbook.NewH1D(3, 0, 3), hbook.NewH1D(3, 0, 4))
+	if err == nil {
+		t.Fatalf("expected an error for different bin edges")
+	}
+}
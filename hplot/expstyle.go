@@ -0,0 +1,424 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+
+	xfnt "golang.org/x/image/font"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ExpLabelPosition describes where the experiment label of an ExpStyle
+// is displayed.
+type ExpLabelPosition int
+
+const (
+	// ExpLabelTopLeft displays the experiment label inside the data area,
+	// in the top-left corner, followed by the energy and luminosity stamps.
+	ExpLabelTopLeft ExpLabelPosition = iota
+	// ExpLabelTopRight displays the experiment label inside the data area,
+	// in the top-right corner, followed by the energy and luminosity stamps.
+	ExpLabelTopRight
+	// ExpLabelAbove displays the experiment label above the data area,
+	// on the left, and the luminosity and energy stamps on the right.
+	ExpLabelAbove
+)
+
+// ExpStyle is the publication style of an experiment.
+//
+// An ExpStyle is applied to the plots of a figure with the WithExpStyle
+// option: the fonts of the plots are modified, the ticks are drawn inside
+// the data area (on all sides, when a frame is drawn) and the experiment
+// label and stamps are displayed on the first plot of the figure.
+type ExpStyle struct {
+	// Name is the name of the experiment (e.g. "ATLAS").
+	Name string
+
+	// Status is the status of the plot (e.g. "Internal", "Preliminary").
+	Status string
+
+	// Energy is the centre-of-mass energy stamp (e.g. "√s = 13 TeV").
+	Energy string
+
+	// Lumi is the integrated luminosity stamp (e.g. "140 fb^{-1}").
+	// Exponents written as ^{...} are displayed as superscripts.
+	Lumi string
+
+	// Font is the font of the texts of the plots.
+	// Only the typeface and the variant of the font are used.
+	Font font.Font
+
+	// NameFont and StatusFont are the fonts of the experiment
+	// name and of the status.
+	// The StatusFont is also used for the energy and luminosity stamps.
+	NameFont   font.Font
+	StatusFont font.Font
+
+	// Position is the position of the experiment label.
+	Position ExpLabelPosition
+
+	// TicksInside draws the ticks inside the data area.
+	TicksInside bool
+
+	// Frame draws a frame around the data area.
+	Frame bool
+}
+
+func expFont(variant font.Variant, style xfnt.Style, weight xfnt.Weight) font.Font {
+	return font.Font{
+		Typeface: "Liberation",
+		Variant:  variant,
+		Style:    style,
+		Weight:   weight,
+		Size:     12,
+	}
+}
+
+// ATLASStyle returns the publication style of the ATLAS experiment,
+// with the provided status (e.g. "Internal", "Preliminary").
+func ATLASStyle(status string) ExpStyle {
+	return ExpStyle{
+		Name:        "ATLAS",
+		Status:      status,
+		Font:        expFont("Sans", xfnt.StyleNormal, xfnt.WeightNormal),
+		NameFont:    expFont("Sans", xfnt.StyleItalic, xfnt.WeightBold),
+		StatusFont:  expFont("Sans", xfnt.StyleNormal, xfnt.WeightNormal),
+		Position:    ExpLabelTopLeft,
+		TicksInside: true,
+		Frame:       true,
+	}
+}
+
+// CMSStyle returns the publication style of the CMS experiment,
+// with the provided status (e.g. "Preliminary", "Simulation").
+func CMSStyle(status string) ExpStyle {
+	return ExpStyle{
+		Name:        "CMS",
+		Status:      status,
+		Font:        expFont("Sans", xfnt.StyleNormal, xfnt.WeightNormal),
+		NameFont:    expFont("Sans", xfnt.StyleNormal, xfnt.WeightBold),
+		StatusFont:  expFont("Sans", xfnt.StyleItalic, xfnt.WeightNormal),
+		Position:    ExpLabelAbove,
+		TicksInside: true,
+		Frame:       true,
+	}
+}
+
+// LHCbStyle returns the publication style of the LHCb experiment,
+// with the provided status (e.g. "Preliminary", "Simulation").
+func LHCbStyle(status string) ExpStyle {
+	return ExpStyle{
+		Name:        "LHCb",
+		Status:      status,
+		Font:        expFont("Serif", xfnt.StyleNormal, xfnt.WeightNormal),
+		NameFont:    expFont("Serif", xfnt.StyleNormal, xfnt.WeightNormal),
+		StatusFont:  expFont("Serif", xfnt.StyleNormal, xfnt.WeightNormal),
+		Position:    ExpLabelTopRight,
+		TicksInside: true,
+		Frame:       true,
+	}
+}
+
+// WithExpStyle applies the provided experiment style to the plots
+// of a figure.
+func WithExpStyle(sty ExpStyle) FigOption {
+	return func(fig *Fig) {
+		fig.ExpStyle = &sty
+	}
+}
+
+// expState is the experiment style attached to a plot while
+// it is being drawn.
+type expState struct {
+	sty   *ExpStyle
+	label bool // whether to display the experiment label.
+}
+
+// attach attaches the experiment style to the plots of the provided drawer.
+// attach returns a function detaching the experiment style.
+func (sty *ExpStyle) attach(d Drawer) func() {
+	var (
+		plots []*Plot
+		first = true
+	)
+	add := func(p *Plot) {
+		if p == nil {
+			return
+		}
+		p.exp = expState{sty: sty, label: first}
+		first = false
+		plots = append(plots, p)
+	}
+
+	var walk func(d Drawer)
+	walk = func(d Drawer) {
+		switch d := d.(type) {
+		case *Plot:
+			add(d)
+		case *Fig:
+			walk(d.Plot)
+		case *TiledPlot:
+			for _, p := range d.Plots {
+				add(p)
+			}
+		case *RatioPlot:
+			add(d.Top)
+			add(d.Bottom)
+		case *H1DRatioPlot:
+			walk(d.RatioPlot)
+		case *PullPlot:
+			walk(d.RatioPlot)
+		}
+	}
+	walk(d)
+
+	return func() {
+		for _, p := range plots {
+			p.exp = expState{}
+		}
+	}
+}
+
+// setup modifies the fonts, ticks and axes of the plot according to the
+// experiment style.
+// setup returns a function restoring the plot.
+func (sty *ExpStyle) setup(p *Plot) func() {
+	var undo []func()
+
+	fonts := []*font.Font{
+		&p.Title.TextStyle.Font,
+		&p.X.Label.TextStyle.Font, &p.Y.Label.TextStyle.Font,
+		&p.X.Tick.Label.Font, &p.Y.Tick.Label.Font,
+		&p.Legend.TextStyle.Font,
+	}
+	for _, fnt := range fonts {
+		old := *fnt
+		fnt.Typeface = sty.Font.Typeface
+		fnt.Variant = sty.Font.Variant
+		undo = append(undo, func() { *fnt = old })
+	}
+
+	if sty.TicksInside {
+		for _, axis := range []*plot.Axis{&p.X, &p.Y} {
+			var (
+				length  = axis.Tick.Length
+				padding = axis.Padding
+			)
+			axis.Tick.Length = 0
+			axis.Padding = 0
+			undo = append(undo, func() {
+				axis.Tick.Length = length
+				axis.Padding = padding
+			})
+		}
+	}
+
+	if sty.Frame {
+		// move the legend inside the frame.
+		var (
+			pad  = 0.5 * sty.NameFont.Size
+			leg  = &p.Legend
+			xoff = leg.XOffs
+			yoff = leg.YOffs
+		)
+		switch {
+		case leg.Left:
+			leg.XOffs += pad
+		default:
+			leg.XOffs -= pad
+		}
+		switch {
+		case leg.Top:
+			leg.YOffs -= pad
+		default:
+			leg.YOffs += pad
+		}
+		undo = append(undo, func() {
+			leg.XOffs = xoff
+			leg.YOffs = yoff
+		})
+	}
+
+	return func() {
+		for _, f := range undo {
+			f()
+		}
+	}
+}
+
+// reserve returns the canvas left to the plot, once the space needed by
+// the frame and the experiment label is reserved.
+func (sty *ExpStyle) reserve(p *Plot, c draw.Canvas, label bool) draw.Canvas {
+	if sty.Frame {
+		// make sure the top and right sides of the frame, and the
+		// topmost tick label, are not clipped.
+		w := p.X.LineStyle.Width
+		c.Max.X -= w
+		c.Max.Y -= max(w, 0.5*p.Y.Tick.Label.Height("0"))
+	}
+	if label && sty.Position == ExpLabelAbove {
+		c.Max.Y -= 1.5 * sty.NameFont.Size
+	}
+	return c
+}
+
+// draw draws the inner ticks, the frame and the experiment label of the
+// plot on the canvas.
+// The ticks lengths are the ones of the plot before setup was applied.
+func (sty *ExpStyle) draw(p *Plot, c draw.Canvas, xlen, ylen vg.Length, label bool) {
+	da := p.DataCanvas(c)
+
+	if sty.TicksInside {
+		for _, t := range p.X.Tick.Marker.Ticks(p.X.Min, p.X.Max) {
+			x := da.X(p.X.Norm(t.Value))
+			if !da.ContainsX(x) {
+				continue
+			}
+			l := xlen
+			if t.IsMinor() {
+				l /= 2
+			}
+			da.StrokeLine2(p.X.Tick.LineStyle, x, da.Min.Y, x, da.Min.Y+l)
+			if sty.Frame {
+				da.StrokeLine2(p.X.Tick.LineStyle, x, da.Max.Y, x, da.Max.Y-l)
+			}
+		}
+		for _, t := range p.Y.Tick.Marker.Ticks(p.Y.Min, p.Y.Max) {
+			y := da.Y(p.Y.Norm(t.Value))
+			if !da.ContainsY(y) {
+				continue
+			}
+			l := ylen
+			if t.IsMinor() {
+				l /= 2
+			}
+			da.StrokeLine2(p.Y.Tick.LineStyle, da.Min.X, y, da.Min.X+l, y)
+			if sty.Frame {
+				da.StrokeLine2(p.Y.Tick.LineStyle, da.Max.X, y, da.Max.X-l, y)
+			}
+		}
+	}
+
+	if sty.Frame {
+		da.StrokeLines(p.X.LineStyle, []vg.Point{
+			{X: da.Min.X, Y: da.Min.Y},
+			{X: da.Max.X, Y: da.Min.Y},
+			{X: da.Max.X, Y: da.Max.Y},
+			{X: da.Min.X, Y: da.Max.Y},
+			{X: da.Min.X, Y: da.Min.Y},
+		})
+	}
+
+	if label {
+		sty.drawLabel(p, c, da)
+	}
+}
+
+// expText is a piece of text of an experiment label.
+type expText struct {
+	txt string
+	fnt font.Font
+}
+
+// drawLabel draws the experiment label and the energy and luminosity stamps.
+func (sty *ExpStyle) drawLabel(p *Plot, c, da draw.Canvas) {
+	hdlr := supHandler(p.X.Tick.Label.Handler)
+	style := func(fnt font.Font) draw.TextStyle {
+		return draw.TextStyle{
+			Color:   color.Black,
+			Font:    fnt,
+			XAlign:  draw.XLeft,
+			YAlign:  draw.YTop,
+			Handler: hdlr,
+		}
+	}
+	width := func(txts []expText) vg.Length {
+		var w vg.Length
+		for i, t := range txts {
+			if i > 0 {
+				w += style(t.fnt).Width(" ")
+			}
+			w += style(t.fnt).Width(t.txt)
+		}
+		return w
+	}
+	fill := func(pt vg.Point, yalign draw.YAlignment, txts []expText) {
+		for i, t := range txts {
+			sty := style(t.fnt)
+			sty.YAlign = yalign
+			if i > 0 {
+				pt.X += sty.Width(" ")
+			}
+			c.FillText(sty, pt, t.txt)
+			pt.X += sty.Width(t.txt)
+		}
+	}
+
+	var name []expText
+	if sty.Name != "" {
+		name = append(name, expText{sty.Name, sty.NameFont})
+	}
+	if sty.Status != "" {
+		name = append(name, expText{sty.Status, sty.StatusFont})
+	}
+
+	var (
+		pad = 0.5 * sty.NameFont.Size
+		gap = 0.3 * sty.NameFont.Size
+	)
+
+	switch sty.Position {
+	case ExpLabelAbove:
+		var stamp string
+		switch {
+		case sty.Lumi != "" && sty.Energy != "":
+			stamp = sty.Lumi + " (" + sty.Energy + ")"
+		default:
+			stamp = sty.Lumi + sty.Energy
+		}
+		y := da.Max.Y + gap
+		fill(vg.Point{X: da.Min.X, Y: y}, draw.YBottom, name)
+		if stamp != "" {
+			fnt := sty.StatusFont
+			fnt.Style = xfnt.StyleNormal
+			stamps := []expText{{stamp, fnt}}
+			fill(vg.Point{X: da.Max.X - width(stamps), Y: y}, draw.YBottom, stamps)
+		}
+
+	default:
+		var stamp string
+		switch {
+		case sty.Energy != "" && sty.Lumi != "":
+			stamp = sty.Energy + ", " + sty.Lumi
+		default:
+			stamp = sty.Energy + sty.Lumi
+		}
+		var lines [][]expText
+		if len(name) > 0 {
+			lines = append(lines, name)
+		}
+		if stamp != "" {
+			lines = append(lines, []expText{{stamp, sty.StatusFont}})
+		}
+
+		y := da.Max.Y - pad
+		for _, line := range lines {
+			x := da.Min.X + pad
+			if sty.Position == ExpLabelTopRight {
+				x = da.Max.X - pad - width(line)
+			}
+			fill(vg.Point{X: x, Y: y}, draw.YTop, line)
+			var h vg.Length
+			for _, t := range line {
+				h = max(h, style(t.fnt).Height(t.txt))
+			}
+			y -= h + gap
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleATLASStyle() {
+	const npoints = 10000
+
+	// Create a background and a signal distributions.
+	var (
+		bkg = distuv.Exponential{
+			Rate: 1. / 40,
+			Src:  rand.New(rand.NewSource(0)),
+		}
+		sig = distuv.Normal{
+			Mu:    125,
+			Sigma: 5,
+			Src:   rand.New(rand.NewSource(1)),
+		}
+	)
+
+	hdata := hbook.NewH1D(40, 100, 160)
+	for i := 0; i < npoints; i++ {
+		hdata.Fill(100+bkg.Rand(), 1)
+		if i%20 == 0 {
+			hdata.Fill(sig.Rand(), 1)
+		}
+	}
+
+	p := hplot.New()
+	p.X.Label.Text = "m [GeV]"
+	p.Y.Label.Text = "Events / 1.5 GeV"
+	p.Y.Min = 0
+	p.Y.Max = 600 // leave room for the experiment label.
+
+	hh := hplot.NewH1D(hdata, hplot.WithYErrBars(true))
+	hh.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+	p.Add(hh)
+
+	p.Legend.Add("data", hh)
+	p.Legend.Top = true
+
+	sty := hplot.ATLASStyle("Internal")
+	sty.Energy = "√s = 13 TeV"
+	sty.Lumi = "140 fb^{-1}"
+
+	fig := hplot.Figure(p, hplot.WithExpStyle(sty))
+
+	const (
+		w = 15 * vg.Centimeter
+		h = w / math.Phi
+	)
+
+	err := hplot.Save(fig, w, h, "testdata/expstyle_atlas.png")
+	if err != nil {
+		log.Fatalf("could not save plot: %+v", err)
+	}
+}
+
+func ExampleCMSStyle() {
+	const (
+		npoints = 5000
+		nbins   = 30
+		xmin    = -3.0
+		xmax    = +3.0
+	)
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	data := hbook.NewH1D(nbins, xmin, xmax)
+	for i := 0; i < npoints; i++ {
+		data.Fill(dist.Rand(), 1)
+	}
+
+	// Model: expected number of entries per bin.
+	const width = (xmax - xmin) / nbins
+	model := func(x float64) float64 {
+		return npoints * width * dist.Prob(x)
+	}
+
+	pp, err := hplot.NewPullPlotFunc(data, model)
+	if err != nil {
+		log.Fatalf("could not create pull plot: %+v", err)
+	}
+
+	pp.Top.Y.Label.Text = "Entries"
+	pp.Top.Legend.Add("data", pp.Data)
+	pp.Top.Legend.Add("model", pp.Model.(*hplot.Function))
+	pp.Top.Legend.Top = true
+
+	pp.Bottom.X.Label.Text = "X"
+
+	sty := hplot.CMSStyle("Preliminary")
+	sty.Energy = "13.6 TeV"
+	sty.Lumi = "62 fb^{-1}"
+
+	fig := hplot.Figure(pp, hplot.WithExpStyle(sty))
+
+	const (
+		w = 15 * vg.Centimeter
+		h = w / math.Phi
+	)
+
+	err = hplot.Save(fig, w, h, "testdata/expstyle_cms.png")
+	if err != nil {
+		log.Fatalf("could not save plot: %+v", err)
+	}
+}
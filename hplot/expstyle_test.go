@@ -0,0 +1,71 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"bytes"
+	"testing"
+
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/vg"
+)
+
+func TestExpStyle(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleATLASStyle, t, "expstyle_atlas.png")
+	checkPlot(cmpimg.CheckPlot)(ExampleCMSStyle, t, "expstyle_cms.png")
+}
+
+func TestExpStyleRestore(t *testing.T) {
+	for _, sty := range []hplot.ExpStyle{
+		hplot.ATLASStyle("Internal"),
+		hplot.CMSStyle("Preliminary"),
+		hplot.LHCbStyle("Simulation"),
+	} {
+		t.Run(sty.Name, func(t *testing.T) {
+			p := hplot.New()
+			p.Title.Text = "title"
+			p.X.Label.Text = "x"
+			p.Y.Label.Text = "y"
+
+			var (
+				xtick = p.X.Tick
+				ytick = p.Y.Tick
+				xpad  = p.X.Padding
+				ypad  = p.Y.Padding
+				title = p.Title.TextStyle
+			)
+
+			fig := hplot.Figure(p, hplot.WithExpStyle(sty))
+			wt, err := hplot.WriterTo(fig, 10*vg.Centimeter, 10*vg.Centimeter, "png")
+			if err != nil {
+				t.Fatalf("could not create writer: %+v", err)
+			}
+			_, err = wt.WriteTo(new(bytes.Buffer))
+			if err != nil {
+				t.Fatalf("could not draw figure: %+v", err)
+			}
+
+			if got, want := p.X.Tick.Length, xtick.Length; got != want {
+				t.Fatalf("invalid x-tick length: got=%v, want=%v", got, want)
+			}
+			if got, want := p.Y.Tick.Length, ytick.Length; got != want {
+				t.Fatalf("invalid y-tick length: got=%v, want=%v", got, want)
+			}
+			if got, want := p.X.Tick.Label.Font, xtick.Label.Font; got != want {
+				t.Fatalf("invalid x-tick font: got=%v, want=%v", got, want)
+			}
+			if got, want := p.X.Padding, xpad; got != want {
+				t.Fatalf("invalid x-padding: got=%v, want=%v", got, want)
+			}
+			if got, want := p.Y.Padding, ypad; got != want {
+				t.Fatalf("invalid y-padding: got=%v, want=%v", got, want)
+			}
+			if got, want := p.Title.TextStyle.Font, title.Font; got != want {
+				t.Fatalf("invalid title font: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
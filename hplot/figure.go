@@ -63,6 +63,17 @@ func WithDPI(dpi float64) FigOption {
 	}
 }
 
+// WithFormatDPI allows to modify the DPI of a plot for the provided image
+// format (e.g. "png" or "jpg"), overriding the default DPI of the plot.
+func WithFormatDPI(format string, dpi float64) FigOption {
+	return func(fig *Fig) {
+		if fig.FormatDPI == nil {
+			fig.FormatDPI = make(map[string]float64)
+		}
+		fig.FormatDPI[format] = dpi
+	}
+}
+
 // WithLegend enables the display of a legend on the righthand-side of a plot.
 func WithLegend(l Legend) FigOption {
 	return func(fig *Fig) {
@@ -71,6 +82,22 @@ func WithLegend(l Legend) FigOption {
 	}
 }
 
+// WithColorBar enables the display of the color bar of a color mapper,
+// e.g. a HeatMap or a ColorScatter, on the righthand-side of a plot.
+func WithColorBar(cm ColorMapper) FigOption {
+	return func(fig *Fig) {
+		fig.ColorBar = NewColorBar(cm)
+	}
+}
+
+// WithMetadata attaches provenance metadata to a figure, embedded in the
+// PDF and PNG files the figure is saved to.
+func WithMetadata(md Metadata) FigOption {
+	return func(fig *Fig) {
+		fig.Metadata = md
+	}
+}
+
 // Fig is a figure, holding a plot and figure-level customizations.
 type Fig struct {
 	// Plot is a gonum/plot.Plot like value.
@@ -79,6 +106,13 @@ type Fig struct {
 	// Legend displays a legend on the righthand-side of the plot.
 	Legend *Legend
 
+	// ColorBar displays a color bar on the righthand-side of the plot.
+	ColorBar *ColorBar
+
+	// ExpStyle is the experiment publication style applied to the plots
+	// of the figure.
+	ExpStyle *ExpStyle
+
 	// Border specifies the borders' sizes, the space between the
 	// end of the plot image (PDF, PNG, ...) and the actual plot.
 	Border Border
@@ -92,11 +126,31 @@ type Fig struct {
 
 	// DPI is the dot-per-inch for PNG,JPEG,... plots.
 	DPI float64
+
+	// FormatDPI holds per-format dot-per-inch values,
+	// overriding DPI for the corresponding image formats.
+	FormatDPI map[string]float64
+
+	// Metadata holds the provenance of the figure, embedded in the
+	// PDF and PNG files the figure is saved to.
+	Metadata Metadata
+}
+
+// dpi returns the dot-per-inch used for the provided image format.
+func (fig *Fig) dpi(format string) float64 {
+	if dpi, ok := fig.FormatDPI[format]; ok {
+		return dpi
+	}
+	return fig.DPI
 }
 
 func (fig *Fig) Draw(dc draw.Canvas) {
 	vgtexBorder(dc)
 
+	if fig.ExpStyle != nil {
+		defer fig.ExpStyle.attach(fig.Plot)()
+	}
+
 	dc = draw.Crop(dc,
 		fig.Border.Left, -fig.Border.Right,
 		fig.Border.Bottom, -fig.Border.Top,
@@ -123,6 +177,36 @@ func (fig *Fig) Draw(dc draw.Canvas) {
 		dc = draw.Crop(dc, 0, -width-vg.Millimeter, 0, 0)
 	}
 
+	if fig.ColorBar != nil {
+		var (
+			width = fig.ColorBar.size()
+			cb    = draw.Crop(dc, dc.Size().X-width, 0, 0, 0)
+		)
+		// carve up space for the color bar.
+		dc = draw.Crop(dc, 0, -width, 0, 0)
+
+		// align the color bar with the data area of the plot.
+		var da draw.Canvas
+		switch p := fig.Plot.(type) {
+		case *plot.Plot:
+			da = p.DataCanvas(dc)
+		case *Plot:
+			if exp := p.exp; exp.sty != nil {
+				undo := exp.sty.setup(p)
+				da = p.DataCanvas(exp.sty.reserve(p, dc, exp.label))
+				undo()
+				break
+			}
+			da = p.DataCanvas(dc)
+		default:
+			da = dc
+		}
+		cb.Min.Y = da.Min.Y
+		cb.Max.Y = da.Max.Y
+
+		fig.ColorBar.Draw(cb)
+	}
+
 	fig.Plot.Draw(dc)
 }
 
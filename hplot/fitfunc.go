@@ -0,0 +1,314 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// FitFunction implements the plot.Plotter interface, drawing the best-fit
+// curve of a model function together with its 1σ and 2σ uncertainty
+// bands, propagated from the covariance matrix of the fitted parameters.
+type FitFunction struct {
+	// F is the model function.
+	F func(x float64, ps []float64) float64
+
+	// Params are the best-fit values of the parameters of the model.
+	Params []float64
+
+	// Cov is the covariance matrix of the fitted parameters.
+	// A nil covariance matrix disables the uncertainty bands.
+	Cov *mat.SymDense
+
+	// Chi2 and NDF are the χ² and the number of degrees of freedom
+	// of the fit.
+	Chi2 float64
+	NDF  int
+
+	// Names are the names of the parameters, displayed in the
+	// statistics box.
+	// Parameters without a name are displayed as p0, p1, ...
+	Names []string
+
+	// XMin and XMax specify the range of the fit,
+	// over which the curve and the bands are drawn.
+	XMin, XMax float64
+
+	// Samples is the number of points at which the curve and
+	// the bands are evaluated.
+	Samples int
+
+	// LineStyle is the style of the best-fit curve.
+	draw.LineStyle
+
+	// Band1 and Band2 are the colors of the 1σ and 2σ uncertainty bands.
+	// Use nil to disable a band.
+	Band1 color.Color
+	Band2 color.Color
+
+	// Stats is the box displaying the values of the parameters and
+	// the χ²/ndf of the fit.
+	// The text of the box is set when the plotter is drawn.
+	// Use nil to disable the box.
+	Stats *TextBox
+}
+
+// NewFitFunction creates a plotter for the result of the fit of the
+// model function of f to the data of f.
+//
+// The covariance matrix of the parameters is computed from the Hessian
+// of the χ² of the fit at its minimum.
+// When f has no measurement errors, the covariance matrix is scaled by
+// χ²/ndf.
+func NewFitFunction(f fit.Func1D, res *optimize.Result) (*FitFunction, error) {
+	if len(f.X) == 0 {
+		return nil, fmt.Errorf("hplot: no data to compute the fit uncertainties")
+	}
+	xmin, xmax := math.Inf(+1), math.Inf(-1)
+	for _, x := range f.X {
+		xmin = math.Min(xmin, x)
+		xmax = math.Max(xmax, x)
+	}
+	return newFitFunction(f, res, xmin, xmax)
+}
+
+// NewFitFunctionH1D creates a plotter for the result of the fit of the
+// histogram h with the model function of f, as performed by fit.H1D.
+//
+// The curve and the bands are drawn over the range of the histogram.
+func NewFitFunctionH1D(h *hbook.H1D, f fit.Func1D, res *optimize.Result) (*FitFunction, error) {
+	f.X, f.Y, f.Err = nil, nil, nil
+	for _, bin := range h.Binning.Bins {
+		if bin.Entries() <= 0 {
+			continue
+		}
+		f.X = append(f.X, bin.XMid())
+		f.Y = append(f.Y, bin.SumW())
+		f.Err = append(f.Err, bin.ErrW())
+	}
+	if len(f.X) == 0 {
+		return nil, fmt.Errorf("hplot: no data to compute the fit uncertainties")
+	}
+	return newFitFunction(f, res, h.XMin(), h.XMax())
+}
+
+func newFitFunction(f fit.Func1D, res *optimize.Result, xmin, xmax float64) (*FitFunction, error) {
+	if res == nil || len(res.X) == 0 {
+		return nil, fmt.Errorf("hplot: invalid fit result")
+	}
+	if len(f.Y) != len(f.X) || (f.Err != nil && len(f.Err) != len(f.X)) {
+		return nil, fmt.Errorf("hplot: fit data length mismatch")
+	}
+
+	ps := make([]float64, len(res.X))
+	copy(ps, res.X)
+
+	chi2 := func(ps []float64) float64 {
+		var sum float64
+		for i, x := range f.X {
+			res := f.F(x, ps) - f.Y[i]
+			if f.Err != nil {
+				res /= f.Err[i]
+			}
+			sum += res * res
+		}
+		return sum
+	}
+
+	var (
+		n   = len(ps)
+		ndf = len(f.X) - n
+		hes = mat.NewSymDense(n, nil)
+	)
+	fd.Hessian(hes, chi2, ps, nil)
+
+	var chol mat.Cholesky
+	if ok := chol.Factorize(hes); !ok {
+		return nil, fmt.Errorf("hplot: fit Hessian matrix is not positive definite")
+	}
+	cov := mat.NewSymDense(n, nil)
+	err := chol.InverseTo(cov)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not invert fit Hessian matrix: %w", err)
+	}
+
+	// the Hessian of the χ² is twice the inverse of the covariance matrix.
+	scale := 2.0
+	v := chi2(ps)
+	if f.Err == nil && ndf > 0 {
+		scale *= v / float64(ndf)
+	}
+	cov.ScaleSym(scale, cov)
+
+	return &FitFunction{
+		F:         f.F,
+		Params:    ps,
+		Cov:       cov,
+		Chi2:      v,
+		NDF:       ndf,
+		XMin:      xmin,
+		XMax:      xmax,
+		Samples:   100,
+		LineStyle: draw.LineStyle{Color: color.RGBA{R: 255, A: 255}, Width: vg.Points(1)},
+		Band1:     color.NRGBA{R: 255, G: 200, A: 200},
+		Band2:     color.NRGBA{R: 255, G: 255, A: 200},
+		Stats: NewTextBox(0.03, 0.97, "", WithLabelNormalized(true), WithLabelTextStyle(draw.TextStyle{
+			Color:   color.Black,
+			Font:    DefaultStyle.Fonts.Tick,
+			XAlign:  draw.XLeft,
+			YAlign:  draw.YTop,
+			Handler: DefaultStyle.TextHandler,
+		})),
+	}, nil
+}
+
+// Err returns the uncertainty on the fitted parameter i.
+func (f *FitFunction) Err(i int) float64 {
+	if f.Cov == nil {
+		return 0
+	}
+	return math.Sqrt(f.Cov.At(i, i))
+}
+
+// Sigma returns the uncertainty on the model function at x,
+// propagated from the covariance matrix of the fitted parameters.
+func (f *FitFunction) Sigma(x float64) float64 {
+	if f.Cov == nil {
+		return 0
+	}
+	grad := fd.Gradient(nil, func(ps []float64) float64 {
+		return f.F(x, ps)
+	}, f.Params, nil)
+	g := mat.NewVecDense(len(grad), grad)
+	return math.Sqrt(math.Max(0, mat.Inner(g, f.Cov, g)))
+}
+
+// Text returns the text of the statistics box, listing the values of the
+// parameters and the χ²/ndf of the fit.
+func (f *FitFunction) Text() string {
+	var o strings.Builder
+	fmt.Fprintf(&o, "χ²/ndf = %.4g/%d", f.Chi2, f.NDF)
+	for i, v := range f.Params {
+		name := fmt.Sprintf("p%d", i)
+		if i < len(f.Names) && f.Names[i] != "" {
+			name = f.Names[i]
+		}
+		fmt.Fprintf(&o, "\n%s = %.4g ± %.2g", name, v, f.Err(i))
+	}
+	return o.String()
+}
+
+// Plot implements the Plotter interface, drawing the uncertainty bands,
+// the best-fit curve and the statistics box.
+func (f *FitFunction) Plot(c draw.Canvas, plt *plot.Plot) {
+	n := f.Samples
+	if n < 2 {
+		n = 2
+	}
+	var (
+		d   = (f.XMax - f.XMin) / float64(n-1)
+		xys = make(plotter.XYs, n)
+		sig = make([]float64, n)
+	)
+	for i := range xys {
+		x := f.XMin + float64(i)*d
+		xys[i].X = x
+		xys[i].Y = f.F(x, f.Params)
+		sig[i] = f.Sigma(x)
+	}
+
+	band := func(k float64, fill color.Color) {
+		if fill == nil || f.Cov == nil {
+			return
+		}
+		top := make(plotter.XYs, n)
+		bot := make(plotter.XYs, n)
+		for i, xy := range xys {
+			top[i] = plotter.XY{X: xy.X, Y: xy.Y + k*sig[i]}
+			bot[i] = plotter.XY{X: xy.X, Y: xy.Y - k*sig[i]}
+		}
+		NewBand(fill, top, bot).Plot(c, plt)
+	}
+	band(2, f.Band2)
+	band(1, f.Band1)
+
+	if f.LineStyle.Width > 0 {
+		trX, trY := plt.Transforms(&c)
+		pts := make([]vg.Point, n)
+		for i, xy := range xys {
+			pts[i] = vg.Point{X: trX(xy.X), Y: trY(xy.Y)}
+		}
+		c.StrokeLines(f.LineStyle, c.ClipLinesXY(pts)...)
+	}
+
+	if f.Stats != nil {
+		f.Stats.Text = f.Text()
+		f.Stats.Plot(c, plt)
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values of the
+// 2σ band, implementing the plot.DataRanger interface.
+func (f *FitFunction) DataRange() (xmin, xmax, ymin, ymax float64) {
+	n := f.Samples
+	if n < 2 {
+		n = 2
+	}
+	ymin = math.Inf(+1)
+	ymax = math.Inf(-1)
+	d := (f.XMax - f.XMin) / float64(n-1)
+	for i := 0; i < n; i++ {
+		x := f.XMin + float64(i)*d
+		y := f.F(x, f.Params)
+		k := 0.0
+		switch {
+		case f.Band2 != nil:
+			k = 2
+		case f.Band1 != nil:
+			k = 1
+		}
+		s := k * f.Sigma(x)
+		ymin = math.Min(ymin, y-s)
+		ymax = math.Max(ymax, y+s)
+	}
+	return f.XMin, f.XMax, ymin, ymax
+}
+
+// Thumbnail draws the best-fit curve over the 1σ band,
+// implementing the plot.Thumbnailer interface.
+func (f *FitFunction) Thumbnail(c *draw.Canvas) {
+	if f.Band1 != nil && f.Cov != nil {
+		pts := []vg.Point{
+			{X: c.Min.X, Y: c.Min.Y},
+			{X: c.Max.X, Y: c.Min.Y},
+			{X: c.Max.X, Y: c.Max.Y},
+			{X: c.Min.X, Y: c.Max.Y},
+		}
+		c.FillPolygon(f.Band1, c.ClipPolygonY(pts))
+	}
+	if f.LineStyle.Width > 0 {
+		y := c.Center().Y
+		c.StrokeLine2(f.LineStyle, c.Min.X, y, c.Max.X, y)
+	}
+}
+
+var (
+	_ plot.Plotter     = (*FitFunction)(nil)
+	_ plot.DataRanger  = (*FitFunction)(nil)
+	_ plot.Thumbnailer = (*FitFunction)(nil)
+)
@@ -0,0 +1,70 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleFitFunction() {
+	dist := distuv.Normal{
+		Mu:    1,
+		Sigma: 2,
+		Src:   rand.New(rand.NewSource(1234)),
+	}
+
+	hist := hbook.NewH1D(40, -8, +10)
+	for i := 0; i < 500; i++ {
+		hist.Fill(dist.Rand(), 1)
+	}
+
+	model := fit.Func1D{
+		F: func(x float64, ps []float64) float64 {
+			v := (x - ps[1]) / ps[2]
+			return ps[0] * math.Exp(-0.5*v*v)
+		},
+		Ps: []float64{30, 0, 3},
+	}
+
+	res, err := fit.H1D(hist, model, nil, &optimize.NelderMead{})
+	if err != nil {
+		log.Fatalf("could not fit histogram: %+v", err)
+	}
+
+	f, err := hplot.NewFitFunctionH1D(hist, model, res)
+	if err != nil {
+		log.Fatalf("could not create fit plotter: %+v", err)
+	}
+	f.Names = []string{"cst", "μ", "σ"}
+
+	h := hplot.NewH1D(hist, hplot.WithDrawStyle(hplot.HDrawPoints))
+	h.GlyphStyle.Shape = draw.CircleGlyph{}
+
+	p := hplot.New()
+	p.Title.Text = "Gaussian fit"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "Entries"
+	p.Y.Min = 0
+
+	p.Add(f, h, hplot.NewGrid())
+	p.Legend.Add("data", h)
+	p.Legend.Add("fit", f)
+	p.Legend.Top = true
+
+	err = p.Save(15*vg.Centimeter, -1, "testdata/fitfunc.png")
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/fit"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestFitFunction(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleFitFunction, t, "fitfunc.png")
+}
+
+func TestFitFunctionCov(t *testing.T) {
+	var (
+		xs   = []float64{0, 1, 2, 3, 4}
+		ys   = []float64{1.1, 2.9, 5.2, 7.1, 8.8}
+		errs = []float64{0.5, 0.5, 0.5, 0.5, 0.5}
+	)
+	model := fit.Func1D{
+		F: func(x float64, ps []float64) float64 {
+			return ps[0] + ps[1]*x
+		},
+		N:   2,
+		X:   xs,
+		Y:   ys,
+		Err: errs,
+	}
+	res, err := fit.Curve1D(model, nil, &optimize.NelderMead{})
+	if err != nil {
+		t.Fatalf("could not fit: %+v", err)
+	}
+
+	f, err := hplot.NewFitFunction(model, res)
+	if err != nil {
+		t.Fatalf("could not create fit plotter: %+v", err)
+	}
+
+	// analytical covariance matrix of a weighted linear fit: (AᵀWA)⁻¹
+	a := mat.NewDense(len(xs), 2, nil)
+	for i, x := range xs {
+		a.Set(i, 0, 1/errs[i])
+		a.Set(i, 1, x/errs[i])
+	}
+	var want mat.Dense
+	want.Mul(a.T(), a)
+	err = want.Inverse(&want)
+	if err != nil {
+		t.Fatalf("could not invert matrix: %+v", err)
+	}
+
+	if !mat.EqualApprox(f.Cov, &want, 1e-4) {
+		t.Fatalf("invalid covariance matrix:\ngot= %v\nwant=%v", mat.Formatted(f.Cov), mat.Formatted(&want))
+	}
+
+	if got, want := f.NDF, 3; got != want {
+		t.Fatalf("invalid ndf: got=%d, want=%d", got, want)
+	}
+
+	// uncertainty on the function at x: sqrt(var(a) + 2x cov(a,b) + x² var(b))
+	x := 2.5
+	sig := math.Sqrt(want.At(0, 0) + 2*x*want.At(0, 1) + x*x*want.At(1, 1))
+	if got := f.Sigma(x); math.Abs(got-sig) > 1e-4 {
+		t.Fatalf("invalid sigma: got=%v, want=%v", got, sig)
+	}
+
+	_, err = hplot.NewFitFunction(fit.Func1D{F: model.F, N: 2}, res)
+	if err == nil {
+		t.Fatalf("expected an error for a fit without data")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"math"
 
 	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/stat/distuv"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/font"
 	"gonum.org/v1/plot/plotter"
@@ -30,6 +31,18 @@ type H1D struct {
 	// then the bars are not filled.
 	FillColor color.Color
 
+	// Hatch is the hatching of the area under the histogram.
+	Hatch HatchStyle
+
+	// DrawStyle is the style used to draw the histogram
+	// (step outline, points with error bars, bars, ...).
+	DrawStyle HDrawStyle
+
+	// BarWidth is the fraction of the bin width covered by a bar,
+	// when the histogram is drawn with the HDrawBars style.
+	// A zero value is interpreted as 1.
+	BarWidth float64
+
 	// LineStyle is the style of the outline of each
 	// bar of the histogram.
 	draw.LineStyle
@@ -56,6 +69,68 @@ type H1D struct {
 	// Band displays a colored band between the y-min and y-max error bars.
 	// The band is shown in the legend thumbnail only if there is no filling.
 	Band *BinnedErrBand
+
+	// yerrf computes the low and high errors of a bin.
+	// When nil, symmetric errors are used.
+	yerrf func(bin hbook.Bin1D) (low, high float64)
+
+	// syst computes the low and high systematic errors of a bin.
+	syst func(bin hbook.Bin1D) (low, high float64)
+
+	// norm describes how the histogram is normalized when displayed.
+	norm hnorm
+}
+
+// HNorm describes how a histogram is normalized when displayed.
+type HNorm int
+
+const (
+	// HNormNone displays the content of the histogram.
+	HNormNone HNorm = iota
+	// HNormUnit displays the histogram normalized to unit area:
+	// the sum of weights of the in-range bins is 1.
+	HNormUnit
+	// HNormWidth displays the content of each bin divided by its width.
+	HNormWidth
+	// HNormDensity displays the histogram as a probability density:
+	// the histogram is normalized to unit area and the content of each
+	// bin is divided by its width.
+	HNormDensity
+)
+
+// HDrawStyle describes how a histogram is drawn.
+type HDrawStyle int
+
+const (
+	// HDrawHist draws the histogram as a step outline,
+	// as ROOT's HIST drawing option does.
+	HDrawHist HDrawStyle = iota
+	// HDrawPoints draws the histogram as markers with error bars at the
+	// center of the non-empty bins, as is customary for data.
+	// Poisson error bars are used unless other errors are requested
+	// with WithYErrBarsFunc.
+	HDrawPoints
+	// HDrawPointsE0 draws the histogram as HDrawPoints does, including
+	// the markers and error bars of the empty bins,
+	// as ROOT's E0 drawing option does.
+	HDrawPointsE0
+	// HDrawFilledStep draws the histogram as a step outline with the area
+	// under it filled.
+	// The area is filled with a translucent version of the line color
+	// when FillColor is nil.
+	HDrawFilledStep
+	// HDrawBars draws each bin as a separate filled bar, centered on the
+	// bin, whose width is scaled by BarWidth, as ROOT's BAR drawing option
+	// does.
+	// The bars are filled with a translucent version of the line color
+	// when FillColor is nil.
+	HDrawBars
+)
+
+// hnorm describes the normalization of a histogram.
+type hnorm struct {
+	kind HNorm
+	ref  *hbook.H1D // reference histogram, whose integral is used as area.
 }
 
 type HInfoStyle uint32
@@ -117,40 +192,169 @@ func NewH1D(h *hbook.H1D, opts ...Options) *H1D {
 
 	h1.LogY = cfg.log.y
 	h1.Infos = cfg.hinfos
+	h1.yerrf = cfg.bars.yerrf
+	h1.syst = cfg.syst
+	h1.norm = cfg.norm
+	h1.DrawStyle = cfg.draw.style
+	h1.BarWidth = cfg.draw.barw
+
+	if h1.points() && h1.yerrf == nil {
+		h1.yerrf = PoissonErrors
+	}
 
 	if cfg.band {
 		h1.Band = h1.withBand()
 	}
 
-	if cfg.bars.yerrs {
+	if cfg.bars.yerrs || h1.points() {
 		h1.YErrs = h1.withYErrBars(nil)
 	}
 
-	if cfg.glyph != (draw.GlyphStyle{}) {
+	switch {
+	case cfg.glyph != (draw.GlyphStyle{}):
 		h1.GlyphStyle = cfg.glyph
+	case h1.points():
+		h1.GlyphStyle = draw.GlyphStyle{
+			Color:  color.Black,
+			Radius: vg.Points(2),
+			Shape:  draw.CircleGlyph{},
+		}
 	}
 
 	return h1
 }
 
+// points returns whether the histogram is drawn as markers with error bars.
+func (h *H1D) points() bool {
+	return h.DrawStyle == HDrawPoints || h.DrawStyle == HDrawPointsE0
+}
+
+// skip returns whether the markers and error bars of the provided bin
+// are not drawn.
+func (h *H1D) skip(bin hbook.Bin1D) bool {
+	if bin.Entries() != 0 {
+		return false
+	}
+	switch h.DrawStyle {
+	case HDrawPoints:
+		return true
+	case HDrawPointsE0:
+		return false
+	}
+	return h.yerrf == nil
+}
+
+// fillColor returns the color used to fill the area under the histogram,
+// or nil if that area is not filled.
+func (h *H1D) fillColor() color.Color {
+	switch h.DrawStyle {
+	case HDrawPoints, HDrawPointsE0:
+		return nil
+	case HDrawFilledStep, HDrawBars:
+		if h.FillColor == nil && h.LineStyle.Color != nil {
+			const alpha = 0.4
+			r, g, b, a := h.LineStyle.Color.RGBA()
+			return color.RGBA64{
+				R: uint16(alpha * float64(r)),
+				G: uint16(alpha * float64(g)),
+				B: uint16(alpha * float64(b)),
+				A: uint16(alpha * float64(a)),
+			}
+		}
+	}
+	return h.FillColor
+}
+
+// bars returns the outlines of the bars of the histogram,
+// when drawn with the HDrawBars style.
+func (h *H1D) bars(bins []hbook.Bin1D, trX func(float64) vg.Length, yfct func(float64) (vg.Length, vg.Length)) [][]vg.Point {
+	frac := h.BarWidth
+	if frac == 0 {
+		frac = 1
+	}
+	bars := make([][]vg.Point, 0, len(bins))
+	for _, bin := range bins {
+		if bin.SumW() == 0 {
+			continue
+		}
+		var (
+			xmid       = bin.XMid()
+			dx         = 0.5 * frac * bin.XWidth()
+			xmin       = trX(xmid - dx)
+			xmax       = trX(xmid + dx)
+			ymin, ymax = yfct(bin.SumW())
+		)
+		bars = append(bars, []vg.Point{
+			{X: xmin, Y: ymin},
+			{X: xmax, Y: ymin},
+			{X: xmax, Y: ymax},
+			{X: xmin, Y: ymax},
+			{X: xmin, Y: ymin},
+		})
+	}
+	return bars
+}
+
+// hist returns the histogram to display, normalized as requested.
+// The underlying hbook histogram is left untouched.
+func (h *H1D) hist() *hbook.H1D {
+	if h.norm.kind == HNormNone && h.norm.ref == nil {
+		return h.Hist
+	}
+
+	var (
+		hist = h.Hist.Clone()
+		area = 1.0
+	)
+	switch {
+	case h.norm.ref != nil:
+		area = h.norm.ref.Integral(h.norm.ref.XMin(), h.norm.ref.XMax())
+		fallthrough
+	case h.norm.kind == HNormUnit, h.norm.kind == HNormDensity:
+		if sumw := hist.Integral(hist.XMin(), hist.XMax()); sumw != 0 {
+			hist.Scale(area / sumw)
+		}
+	}
+
+	switch h.norm.kind {
+	case HNormWidth, HNormDensity:
+		for i := range hist.Binning.Bins {
+			bin := &hist.Binning.Bins[i]
+			if w := bin.XWidth(); w != 0 {
+				scaleBin1D(bin, 1/w)
+			}
+		}
+	}
+	return hist
+}
+
+// scaleBin1D scales the content of the provided bin by the given factor.
+func scaleBin1D(bin *hbook.Bin1D, f float64) {
+	d := &bin.Dist
+	d.Dist.SumW *= f
+	d.Dist.SumW2 *= f * f
+	d.Stats.SumWX *= f
+	d.Stats.SumWX2 *= f
+}
+
 // withYErrBars enables the Y error bars
 func (h *H1D) withYErrBars(yoffs []float64) *plotter.YErrorBars {
-	bins := h.Hist.Binning.Bins
+	bins := h.hist().Binning.Bins
 	if yoffs == nil {
 		yoffs = make([]float64, len(bins))
 	}
 	data := make(plotter.XYs, 0, len(bins))
 	yerr := make(plotter.YErrors, 0, len(bins))
 	for i, bin := range bins {
-		if bin.Entries() == 0 {
+		if h.skip(bin) {
 			continue
 		}
 		data = append(data, plotter.XY{
 			X: bin.XMid(),
 			Y: yoffs[i] + bin.SumW(),
 		})
-		ey := 0.5 * bin.ErrW()
-		yerr = append(yerr, struct{ Low, High float64 }{ey, ey})
+		lo, hi := h.yerr(bin)
+		yerr = append(yerr, struct{ Low, High float64 }{lo, hi})
 	}
 
 	type yerrT struct {
@@ -168,9 +372,64 @@ func (h *H1D) withYErrBars(yoffs []float64) *plotter.YErrorBars {
 	return yplt
 }
 
+// yerr returns the low and high errors of the provided bin.
+func (h *H1D) yerr(bin hbook.Bin1D) (low, high float64) {
+	if h.yerrf != nil {
+		return h.yerrf(bin)
+	}
+	ey := 0.5 * bin.ErrW()
+	return ey, ey
+}
+
+// PoissonErrors returns the low and high errors of the provided bin,
+// computed as the central 68.27% confidence interval of a Poisson
+// distribution (Garwood interval) with the sum of weights of the bin
+// as observed number of events.
+//
+// PoissonErrors can be used with WithYErrBarsFunc.
+func PoissonErrors(bin hbook.Bin1D) (low, high float64) {
+	const alpha = 1 - 0.682689492137086 // 1-sigma
+
+	n := bin.SumW()
+	if n < 0 {
+		return 0, 0
+	}
+	if n > 0 {
+		low = n - 0.5*distuv.ChiSquared{K: 2 * n}.Quantile(0.5*alpha)
+	}
+	high = 0.5*distuv.ChiSquared{K: 2 * (n + 1)}.Quantile(1-0.5*alpha) - n
+	return low, high
+}
+
+// bandErr returns the low and high errors of the provided bin,
+// combining statistical and systematic errors.
+func (h *H1D) bandErr(bin hbook.Bin1D) (low, high float64) {
+	low, high = h.yerr(bin)
+	if h.syst != nil {
+		slo, shi := h.syst(bin)
+		low = math.Hypot(low, slo)
+		high = math.Hypot(high, shi)
+	}
+	return low, high
+}
+
+// counts returns the content of the histogram, with the combined
+// statistical and systematic errors of each bin.
+func (h *H1D) counts() []hbook.Count {
+	hist := h.hist()
+	cs := hist.Counts()
+	if h.yerrf == nil && h.syst == nil {
+		return cs
+	}
+	for i, bin := range hist.Binning.Bins {
+		cs[i].Err.Low, cs[i].Err.High = h.bandErr(bin)
+	}
+	return cs
+}
+
 // withBand enables the band between ymin-ymax error bars.
 func (h1 *H1D) withBand() *BinnedErrBand {
-	b := NewBinnedErrBand(h1.Hist.Counts())
+	b := NewBinnedErrBand(h1.counts())
 	b.FillColor = color.Gray{200}
 	b.LogY = h1.LogY
 	return b
@@ -179,8 +438,9 @@ func (h1 *H1D) withBand() *BinnedErrBand {
 // DataRange returns the minimum and maximum X and Y values
 func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 
+	hist := h.hist()
 	if !h.LogY {
-		xmin, xmax, ymin, ymax = h.Hist.DataRange()
+		xmin, xmax, ymin, ymax = hist.DataRange()
 		if h.YErrs != nil {
 			xmin1, xmax1, ymin1, ymax1 := h.YErrs.DataRange()
 			xmin = math.Min(xmin, xmin1)
@@ -203,7 +463,7 @@ func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 	ymin = math.Inf(+1)
 	ymax = math.Inf(-1)
 	ylow := math.Inf(+1) // ylow will hold the smallest positive y value.
-	for _, bin := range h.Hist.Binning.Bins {
+	for _, bin := range hist.Binning.Bins {
 		xmax = math.Max(bin.XMax(), xmax)
 		xmin = math.Min(bin.XMin(), xmin)
 		ymax = math.Max(bin.SumW(), ymax)
@@ -242,8 +502,8 @@ func (h *H1D) DataRange() (xmin, xmax, ymin, ymax float64) {
 func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
 	var pts []vg.Point
-	hist := h.Hist
-	bins := h.Hist.Binning.Bins
+	hist := h.hist()
+	bins := hist.Binning.Bins
 	nbins := len(bins)
 
 	yfct := func(sumw float64) (ymin, ymax vg.Length) {
@@ -291,7 +551,7 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 			pts = append(pts, vg.Point{X: xmax, Y: ymax})
 		}
 
-		if h.GlyphStyle.Radius != 0 {
+		if h.GlyphStyle.Radius != 0 && !(h.points() && h.skip(bin)) {
 			x := trX(bin.XMid())
 			_, y := yfct(bin.SumW())
 			// capture glyph location, to be drawn after
@@ -300,15 +560,38 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 		}
 	}
 
-	if h.FillColor != nil {
-		c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
+	var bars [][]vg.Point
+	switch {
+	case h.points():
+		// data-like style: neither outline nor filling.
+	case h.DrawStyle == HDrawBars:
+		bars = h.bars(bins, trX, yfct)
+		for _, bar := range bars {
+			if fill := h.fillColor(); fill != nil {
+				c.FillPolygon(fill, c.ClipPolygonXY(bar))
+			}
+			h.Hatch.Fill(c, bar)
+		}
+	default:
+		if fill := h.fillColor(); fill != nil {
+			c.FillPolygon(fill, c.ClipPolygonXY(pts))
+		}
+		h.Hatch.Fill(c, pts)
 	}
 
 	if h.Band != nil {
 		h.Band.Plot(c, p)
 	}
 
-	c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
+	switch {
+	case h.points():
+	case h.DrawStyle == HDrawBars:
+		for _, bar := range bars {
+			c.StrokeLines(h.LineStyle, c.ClipLinesXY(bar)...)
+		}
+	default:
+		c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
+	}
 
 	if h.YErrs != nil {
 		h.YErrs.Plot(c, p)
@@ -334,13 +617,13 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 		for i := uint32(0); i < 32; i++ {
 			switch h.Infos.Style & (1 << i) {
 			case HInfoEntries:
-				legend.Add("Entries", hist.Entries())
+				legend.Add("Entries", h.Hist.Entries())
 			case HInfoMean:
-				legend.Add("Mean", hist.XMean())
+				legend.Add("Mean", h.Hist.XMean())
 			case HInfoRMS:
-				legend.Add("RMS", hist.XRMS())
+				legend.Add("RMS", h.Hist.XRMS())
 			case HInfoStdDev:
-				legend.Add("Std Dev", hist.XStdDev())
+				legend.Add("Std Dev", h.Hist.XStdDev())
 			default:
 			}
 		}
@@ -354,7 +637,7 @@ func (h *H1D) Plot(c draw.Canvas, p *plot.Plot) {
 // one for each of the bins, implementing the
 // plot.GlyphBoxer interface.
 func (h *H1D) GlyphBoxes(p *plot.Plot) []plot.GlyphBox {
-	bins := h.Hist.Binning.Bins
+	bins := h.hist().Binning.Bins
 	bs := make([]plot.GlyphBox, 0, len(bins))
 	for i := range bins {
 		bin := bins[i]
@@ -401,8 +684,8 @@ func (h *H1D) Thumbnail(c *draw.Canvas) {
 	dy := ymax - ymin
 
 	// Style of the histogram
-	hasFill := h.FillColor != nil
-	hasLine := h.LineStyle.Width != 0
+	hasFill := h.fillColor() != nil || (h.Hatch.Kind != HatchNone && !h.points())
+	hasLine := h.LineStyle.Width != 0 && !h.points()
 	hasGlyph := h.GlyphStyle != (draw.GlyphStyle{})
 	hasBand := h.Band != nil
 
@@ -423,7 +706,10 @@ func (h *H1D) Thumbnail(c *draw.Canvas) {
 			{X: xmin, Y: ymax},
 			{X: xmin, Y: ymin},
 		}
-		c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
+		if fill := h.fillColor(); fill != nil {
+			c.FillPolygon(fill, c.ClipPolygonXY(pts))
+		}
+		h.Hatch.Fill(*c, pts)
 	}
 
 	if drawBand {
@@ -434,7 +720,10 @@ func (h *H1D) Thumbnail(c *draw.Canvas) {
 			{X: xmin, Y: ymax - 0.0*dy},
 			{X: xmin, Y: ymin + 0.0*dy},
 		}
-		c.FillPolygon(h.Band.FillColor, c.ClipPolygonXY(pts))
+		if h.Band.FillColor != nil {
+			c.FillPolygon(h.Band.FillColor, c.ClipPolygonXY(pts))
+		}
+		h.Band.Hatch.Fill(*c, pts)
 	}
 
 	if drawBoxLine {
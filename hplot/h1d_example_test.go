@@ -526,3 +526,116 @@ func ExampleH1D_legendStyle() {
 		log.Fatalf("error saving plot: %v\n", err)
 	}
 }
+
+// An example of comparing the shapes of 1D-histograms with different
+// numbers of entries and binnings.
+func ExampleH1D_normalized() {
+	var (
+		dist = distuv.Normal{
+			Mu:    0,
+			Sigma: 1,
+			Src:   rand.New(rand.NewSource(0)),
+		}
+		h1 = hbook.NewH1D(20, -4, +4)
+		h2 = hbook.NewH1DFromEdges([]float64{-4, -3, -2, -1.5, -1, -0.5, 0, 0.5, 1, 1.5, 2, 3, 4})
+	)
+	for i := 0; i < 10000; i++ {
+		h1.Fill(dist.Rand(), 1)
+	}
+	for i := 0; i < 1000; i++ {
+		h2.Fill(dist.Rand(), 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Shape comparison"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Probability density"
+
+	// Display both histograms as probability densities.
+	// The hbook histograms are left untouched.
+	hh1 := hplot.NewH1D(h1, hplot.WithNorm(hplot.HNormDensity))
+	hh1.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+	hh1.FillColor = color.NRGBA{B: 255, A: 64}
+
+	hh2 := hplot.NewH1D(h2,
+		hplot.WithNorm(hplot.HNormDensity),
+		hplot.WithYErrBars(true),
+		hplot.WithGlyphStyle(draw.GlyphStyle{
+			Shape:  draw.CircleGlyph{},
+			Color:  color.Black,
+			Radius: vg.Points(2),
+		}),
+	)
+	hh2.LineStyle.Width = 0
+
+	norm := hplot.NewFunction(dist.Prob)
+	norm.Color = color.NRGBA{R: 255, A: 255}
+	norm.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+
+	p.Add(hh1, hh2, norm, hplot.NewGrid())
+	p.Legend.Add("10000 entries", hh1)
+	p.Legend.Add("1000 entries", hh2)
+	p.Legend.Add("pdf", norm)
+	p.Legend.Top = true
+
+	err := p.Save(15*vg.Centimeter, -1, "testdata/h1d_normalized.png")
+	if err != nil {
+		log.Fatalf("error saving plot: %v\n", err)
+	}
+}
+
+// An example of drawing 1D-histograms with different draw styles
+// in the same plot.
+func ExampleH1D_drawStyles() {
+	var (
+		rnd  = rand.New(rand.NewSource(1234))
+		bkg  = distuv.Exponential{Rate: 0.5, Src: rnd}
+		sig  = distuv.Normal{Mu: 5, Sigma: 0.8, Src: rnd}
+		hbkg = hbook.NewH1D(20, 0, 10)
+		hsig = hbook.NewH1D(20, 0, 10)
+		hdat = hbook.NewH1D(20, 0, 10)
+	)
+	for i := 0; i < 1000; i++ {
+		hbkg.Fill(bkg.Rand(), 0.1)
+	}
+	for i := 0; i < 200; i++ {
+		hsig.Fill(sig.Rand(), 0.1)
+	}
+	for i := 0; i < 100; i++ {
+		hdat.Fill(bkg.Rand(), 1)
+	}
+	for i := 0; i < 20; i++ {
+		hdat.Fill(sig.Rand(), 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Draw styles"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Entries"
+
+	// step outline with a filled area.
+	hb := hplot.NewH1D(hbkg, hplot.WithDrawStyle(hplot.HDrawFilledStep))
+	hb.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+
+	// bars covering 60% of the bin width.
+	hs := hplot.NewH1D(hsig,
+		hplot.WithDrawStyle(hplot.HDrawBars),
+		hplot.WithBarWidth(0.6),
+	)
+	hs.LineStyle.Color = color.NRGBA{R: 255, A: 255}
+
+	// data-style markers with Poisson error bars,
+	// including the empty bins.
+	hd := hplot.NewH1D(hdat, hplot.WithDrawStyle(hplot.HDrawPointsE0))
+
+	p.Add(hplot.NewGrid(), hb, hs, hd)
+	p.Legend.Add("background", hb)
+	p.Legend.Add("signal", hs)
+	p.Legend.Add("data", hd)
+	p.Legend.Top = true
+
+	err := p.Save(15*vg.Centimeter, -1, "testdata/h1d_draw_styles.png")
+	if err != nil {
+		log.Fatalf("error saving plot: %v\n", err)
+	}
+}
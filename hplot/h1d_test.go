@@ -52,6 +52,109 @@ func TestH1DLegendStyle(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleH1D_legendStyle, t, "h1d_legend.png")
 }
 
+func TestH1DNormalized(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleH1D_normalized, t, "h1d_normalized.png")
+}
+
+func TestH1DDrawStyles(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleH1D_drawStyles, t, "h1d_draw_styles.png")
+}
+
+func TestH1DDrawPoints(t *testing.T) {
+	h := hbook.NewH1D(4, 0, 4)
+	h.Fill(0.5, 1)
+	h.Fill(2.5, 4)
+
+	for _, tc := range []struct {
+		style hplot.HDrawStyle
+		n     int // number of error bars.
+	}{
+		{hplot.HDrawHist, 0},
+		{hplot.HDrawPoints, 2},
+		{hplot.HDrawPointsE0, 4},
+	} {
+		hh := hplot.NewH1D(h, hplot.WithDrawStyle(tc.style))
+		n := 0
+		if hh.YErrs != nil {
+			n = hh.YErrs.Len()
+		}
+		if n != tc.n {
+			t.Fatalf("style=%d: invalid number of error bars: got=%d, want=%d", tc.style, n, tc.n)
+		}
+		if tc.n == 0 {
+			continue
+		}
+		if hh.GlyphStyle.Radius == 0 {
+			t.Fatalf("style=%d: expected glyphs", tc.style)
+		}
+		lo, hi := hh.YErrs.YError(0)
+		wlo, whi := hplot.PoissonErrors(h.Binning.Bins[0])
+		if lo != wlo || hi != whi {
+			t.Fatalf("style=%d: invalid errors: got=(%v, %v), want=(%v, %v)", tc.style, lo, hi, wlo, whi)
+		}
+	}
+}
+
+func TestH1DNorm(t *testing.T) {
+	h := hbook.NewH1DFromEdges([]float64{0, 1, 3, 4})
+	h.Fill(0.5, 2)
+	h.Fill(2.0, 4)
+	h.Fill(3.5, 2)
+	h.Fill(9.0, 8) // overflow, not part of the normalization.
+
+	ref := hbook.NewH1D(4, 0, 4)
+	ref.Fill(0.5, 10)
+	ref.Fill(1.5, 10)
+
+	for _, tc := range []struct {
+		name string
+		opts []hplot.Options
+		want float64 // expected y-max.
+	}{
+		{"none", nil, 4},
+		{"unit", []hplot.Options{hplot.WithNorm(hplot.HNormUnit)}, 0.5},
+		{"width", []hplot.Options{hplot.WithNorm(hplot.HNormWidth)}, 2},
+		{"density", []hplot.Options{hplot.WithNorm(hplot.HNormDensity)}, 0.25},
+		{"ref", []hplot.Options{hplot.WithNormTo(ref)}, 10},
+		{"ref-width", []hplot.Options{hplot.WithNormTo(ref), hplot.WithNorm(hplot.HNormWidth)}, 5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hh := hplot.NewH1D(h, tc.opts...)
+			_, _, _, ymax := hh.DataRange()
+			if math.Abs(ymax-tc.want) > 1e-12 {
+				t.Fatalf("invalid y-max: got=%v, want=%v", ymax, tc.want)
+			}
+
+			if got, want := h.Binning.Bins[1].SumW(), 4.0; got != want {
+				t.Fatalf("hbook histogram modified: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestPoissonErrors(t *testing.T) {
+	for _, tc := range []struct {
+		n      float64
+		lo, hi float64
+	}{
+		// reference values from the Garwood intervals of the PDG.
+		{n: 0, lo: 0, hi: 1.841},
+		{n: 1, lo: 0.827, hi: 2.300},
+		{n: 2, lo: 1.292, hi: 2.638},
+		{n: 10, lo: 3.108, hi: 4.266},
+		{n: -1, lo: 0, hi: 0},
+	} {
+		h := hbook.NewH1D(1, 0, 1)
+		if tc.n != 0 {
+			h.Fill(0.5, tc.n)
+		}
+		lo, hi := hplot.PoissonErrors(h.Binning.Bins[0])
+		if math.Abs(lo-tc.lo) > 1e-3 || math.Abs(hi-tc.hi) > 1e-3 {
+			t.Errorf("n=%v: invalid errors: got=(%.3f, %.3f), want=(%.3f, %.3f)", tc.n, lo, hi, tc.lo, tc.hi)
+		}
+	}
+}
+
 func TestH1DWithBorders(t *testing.T) {
 	if runtime.GOOS == "darwin" {
 		t.Skipf("ignore test b/c of darwin+Mac-silicon")
@@ -0,0 +1,190 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// HatchKind describes the pattern used to hatch an area.
+type HatchKind uint8
+
+const (
+	HatchNone         HatchKind = iota // no hatching
+	HatchDiagonal                      // lines going up, at +45 degrees
+	HatchAntiDiagonal                  // lines going down, at -45 degrees
+	HatchCross                         // diagonal and anti-diagonal lines
+	HatchHorizontal                    // horizontal lines
+	HatchVertical                      // vertical lines
+	HatchGrid                          // horizontal and vertical lines
+	HatchDots                          // dots laid out on a square grid
+)
+
+// HatchStyle describes how a closed area is hatched.
+//
+// Patterns are aligned on the page, so that adjacent areas hatched with
+// the same style form a continuous pattern.
+type HatchStyle struct {
+	// Kind is the pattern of the hatching.
+	// The zero value disables the hatching.
+	Kind HatchKind
+
+	// LineStyle is the style of the hatching lines.
+	// For dots, the width of the line is the radius of the dots.
+	// A zero width and a nil color default to 0.5pt and black.
+	draw.LineStyle
+
+	// Spacing is the distance between two hatching lines or dots.
+	// A zero spacing defaults to 4pt.
+	Spacing vg.Length
+}
+
+// Fill hatches the polygon defined by the provided points.
+// The polygon is clipped to the provided canvas.
+func (hs HatchStyle) Fill(c draw.Canvas, pts []vg.Point) {
+	if hs.Kind == HatchNone || len(pts) < 3 {
+		return
+	}
+
+	sty := hs.LineStyle
+	if sty.Width == 0 {
+		sty.Width = 0.5 * vg.Points(1)
+	}
+	if sty.Color == nil {
+		sty.Color = color.Black
+	}
+	dl := hs.Spacing
+	if dl <= 0 {
+		dl = 4 * vg.Points(1)
+	}
+
+	poly := c.ClipPolygonXY(pts)
+	if len(poly) < 3 {
+		return
+	}
+
+	switch hs.Kind {
+	case HatchDiagonal:
+		hatchLines(c, sty, poly, math.Pi/4, dl)
+	case HatchAntiDiagonal:
+		hatchLines(c, sty, poly, -math.Pi/4, dl)
+	case HatchCross:
+		hatchLines(c, sty, poly, +math.Pi/4, dl)
+		hatchLines(c, sty, poly, -math.Pi/4, dl)
+	case HatchHorizontal:
+		hatchLines(c, sty, poly, 0, dl)
+	case HatchVertical:
+		hatchLines(c, sty, poly, math.Pi/2, dl)
+	case HatchGrid:
+		hatchLines(c, sty, poly, 0, dl)
+		hatchLines(c, sty, poly, math.Pi/2, dl)
+	case HatchDots:
+		hatchDots(c, sty, poly, dl)
+	}
+}
+
+// hatchLines strokes, inside the polygon, a set of parallel lines with the
+// provided angle and spacing.
+func hatchLines(c draw.Canvas, sty draw.LineStyle, poly []vg.Point, angle float64, dl vg.Length) {
+	var (
+		// direction of the lines and normal to the lines.
+		dx, dy = math.Cos(angle), math.Sin(angle)
+		nx, ny = -dy, dx
+
+		proj = func(p vg.Point, x, y float64) float64 {
+			return float64(p.X)*x + float64(p.Y)*y
+		}
+
+		smin = math.Inf(+1)
+		smax = math.Inf(-1)
+		step = float64(dl)
+		ts   []float64
+	)
+	for _, p := range poly {
+		s := proj(p, nx, ny)
+		smin = math.Min(smin, s)
+		smax = math.Max(smax, s)
+	}
+
+	for s := math.Ceil(smin/step) * step; s <= smax; s += step {
+		ts = ts[:0]
+		for i := range poly {
+			var (
+				a  = poly[i]
+				b  = poly[(i+1)%len(poly)]
+				sa = proj(a, nx, ny)
+				sb = proj(b, nx, ny)
+			)
+			if (sa <= s) == (sb <= s) {
+				continue
+			}
+			ta := proj(a, dx, dy)
+			tb := proj(b, dx, dy)
+			ts = append(ts, ta+(s-sa)/(sb-sa)*(tb-ta))
+		}
+		sort.Float64s(ts)
+		for i := 0; i+1 < len(ts); i += 2 {
+			c.StrokeLine2(
+				sty,
+				vg.Length(s*nx+ts[i]*dx), vg.Length(s*ny+ts[i]*dy),
+				vg.Length(s*nx+ts[i+1]*dx), vg.Length(s*ny+ts[i+1]*dy),
+			)
+		}
+	}
+}
+
+// hatchDots draws, inside the polygon, a grid of dots with the provided
+// spacing.
+func hatchDots(c draw.Canvas, sty draw.LineStyle, poly []vg.Point, dl vg.Length) {
+	var (
+		xmin, xmax = poly[0].X, poly[0].X
+		ymin, ymax = poly[0].Y, poly[0].Y
+	)
+	for _, p := range poly[1:] {
+		xmin = vg.Length(math.Min(float64(xmin), float64(p.X)))
+		xmax = vg.Length(math.Max(float64(xmax), float64(p.X)))
+		ymin = vg.Length(math.Min(float64(ymin), float64(p.Y)))
+		ymax = vg.Length(math.Max(float64(ymax), float64(p.Y)))
+	}
+
+	glyph := draw.GlyphStyle{
+		Color:  sty.Color,
+		Radius: sty.Width,
+		Shape:  draw.CircleGlyph{},
+	}
+	start := func(v vg.Length) vg.Length {
+		return vg.Length(math.Ceil(float64(v/dl))) * dl
+	}
+	for y := start(ymin); y <= ymax; y += dl {
+		for x := start(xmin); x <= xmax; x += dl {
+			p := vg.Point{X: x, Y: y}
+			if inPolygon(p, poly) {
+				c.DrawGlyph(glyph, p)
+			}
+		}
+	}
+}
+
+// inPolygon returns whether the point lies inside the polygon,
+// using the even-odd rule.
+func inPolygon(p vg.Point, poly []vg.Point) bool {
+	in := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		a, b := poly[i], poly[j]
+		if (a.Y > p.Y) == (b.Y > p.Y) {
+			continue
+		}
+		x := a.X + (p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+		if p.X < x {
+			in = !in
+		}
+	}
+	return in
+}
@@ -0,0 +1,341 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// HeatMap implements the plotter.Plotter interface,
+// drawing a 2-dim histogram as a grid of bins colored according to their
+// content, as ROOT's COLZ drawing option does.
+//
+// The mapping between bin contents and colors can be displayed with a
+// color bar, attached to a figure with the WithColorBar option.
+type HeatMap struct {
+	// H is the histogramming data.
+	H *hbook.H2D
+
+	// ColorMap maps bin contents to colors.
+	// The range of the ColorMap is modified by the HeatMap.
+	ColorMap palette.ColorMap
+
+	// LogZ enables the logarithmic mapping of bin contents to colors.
+	// When enabled, bins with a negative content are considered empty.
+	LogZ bool
+
+	// Min and Max are the range of bin contents mapped to colors.
+	// Bins with a content outside of that range are drawn with the
+	// color of the closest boundary.
+	Min, Max float64
+
+	// Empty is the color of empty bins.
+	// If nil, empty bins are not drawn.
+	Empty color.Color
+}
+
+// NewHeatMap returns a new heat map from a hbook.H2D.
+//
+// The range of bin contents mapped to colors is set from the non-empty
+// bins of the histogram.
+// The default color map is moreland.Kindlmann.
+func NewHeatMap(h *hbook.H2D, opts ...Options) *HeatMap {
+	cfg := newConfig(opts)
+	hm := &HeatMap{
+		H:        h,
+		ColorMap: cfg.cmap,
+		LogZ:     cfg.log.z,
+	}
+	if hm.ColorMap == nil {
+		hm.ColorMap = moreland.Kindlmann()
+	}
+	hm.Min, hm.Max = hm.zrange()
+	return hm
+}
+
+// zrange returns the range of the contents of the non-empty bins.
+func (hm *HeatMap) zrange() (min, max float64) {
+	min = math.Inf(+1)
+	max = math.Inf(-1)
+	for i := range hm.H.Binning.Bins {
+		z := hm.H.Binning.Bins[i].SumW()
+		if hm.empty(z) {
+			continue
+		}
+		min = math.Min(min, z)
+		max = math.Max(max, z)
+	}
+
+	switch {
+	case math.IsInf(min, +1) && hm.LogZ:
+		return 1, 10
+	case math.IsInf(min, +1):
+		return 0, 1
+	case min == max && hm.LogZ:
+		return min, 10 * max
+	case min == max:
+		return min, min + 1
+	}
+	return min, max
+}
+
+func (hm *HeatMap) empty(z float64) bool {
+	return z == 0 || math.IsNaN(z) || (hm.LogZ && z < 0)
+}
+
+// norm returns the position of the provided bin content within the
+// [Min, Max] range, as a value in [0, 1].
+func (hm *HeatMap) norm(z float64) float64 {
+	return normZ(hm.Min, hm.Max, hm.LogZ, z)
+}
+
+// Color returns the color of a bin with the provided content.
+func (hm *HeatMap) Color(z float64) color.Color {
+	if hm.empty(z) {
+		return hm.Empty
+	}
+	return hm.at(hm.norm(z))
+}
+
+// at returns the color at the provided position of the color map.
+func (hm *HeatMap) at(v float64) color.Color {
+	return colorAtNorm(hm.ColorMap, v)
+}
+
+// ZRange returns the range of bin contents mapped to colors,
+// implementing the ColorMapper interface.
+func (hm *HeatMap) ZRange() (min, max float64, log bool) {
+	return hm.Min, hm.Max, hm.LogZ
+}
+
+// ColorAt returns the color at the provided position of the color map,
+// implementing the ColorMapper interface.
+func (hm *HeatMap) ColorAt(v float64) color.Color {
+	return hm.at(v)
+}
+
+// Plot implements the Plotter interface, drawing a colored
+// rectangle for each bin of the histogram.
+func (hm *HeatMap) Plot(c draw.Canvas, p *plot.Plot) {
+	trX, trY := p.Transforms(&c)
+	for i := range hm.H.Binning.Bins {
+		bin := &hm.H.Binning.Bins[i]
+		col := hm.Color(bin.SumW())
+		if col == nil {
+			continue
+		}
+		var (
+			xmin = trX(bin.XMin())
+			xmax = trX(bin.XMax())
+			ymin = trY(bin.YMin())
+			ymax = trY(bin.YMax())
+		)
+		pts := []vg.Point{
+			{X: xmin, Y: ymin},
+			{X: xmax, Y: ymin},
+			{X: xmax, Y: ymax},
+			{X: xmin, Y: ymax},
+		}
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (hm *HeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return hm.H.XMin(), hm.H.XMax(), hm.H.YMin(), hm.H.YMax()
+}
+
+// ColorMapper is the interface implemented by plotters mapping values to
+// colors, such as HeatMap and ColorScatter.
+// The mapping of a ColorMapper can be displayed with a ColorBar.
+type ColorMapper interface {
+	// ZRange returns the range of values mapped to colors and whether
+	// the mapping is logarithmic.
+	ZRange() (min, max float64, log bool)
+
+	// ColorAt returns the color at the provided position, in [0, 1],
+	// within the range of values mapped to colors.
+	ColorAt(v float64) color.Color
+}
+
+// normZ returns the position of z within the [min, max] range, as a
+// value in [0, 1].
+func normZ(min, max float64, log bool, z float64) float64 {
+	var v float64
+	switch {
+	case log:
+		v = plot.LogScale{}.Normalize(min, max, z)
+	default:
+		v = plot.LinearScale{}.Normalize(min, max, z)
+	}
+	switch {
+	case v < 0, math.IsNaN(v):
+		return 0
+	case v > 1:
+		return 1
+	}
+	return v
+}
+
+// colorAtNorm returns the color at the provided position, in [0, 1],
+// of the color map.
+func colorAtNorm(cmap palette.ColorMap, v float64) color.Color {
+	cmap.SetMin(0)
+	cmap.SetMax(1)
+	c, err := cmap.At(v)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// ColorBar draws the mapping between values and colors of a ColorMapper,
+// e.g. the bin contents of a HeatMap, as a vertical bar with ticks and
+// labels on its righthand-side.
+type ColorBar struct {
+	// Mapper is the plotter whose colors are displayed.
+	Mapper ColorMapper
+
+	// Width is the width of the bar.
+	Width vg.Length
+
+	// Padding is the space between the plot and the bar.
+	Padding vg.Length
+
+	// Tick is the style of the ticks of the bar.
+	Tick struct {
+		// Label is the text style of the tick labels.
+		Label draw.TextStyle
+
+		// LineStyle is the style of the tick lines
+		// and of the outline of the bar.
+		draw.LineStyle
+
+		// Length is the length of a major tick mark.
+		// Minor tick marks are half of the length of major ticks.
+		Length vg.Length
+
+		// Marker returns the tick marks.
+		// The default is plot.DefaultTicks, or plot.LogTicks
+		// when the mapping is logarithmic.
+		Marker plot.Ticker
+	}
+}
+
+// NewColorBar returns a new color bar for the provided color mapper,
+// e.g. a HeatMap or a ColorScatter, using the default hplot style.
+func NewColorBar(cm ColorMapper) *ColorBar {
+	p := New()
+	cb := &ColorBar{
+		Mapper:  cm,
+		Width:   0.5 * vg.Centimeter,
+		Padding: 0.3 * vg.Centimeter,
+	}
+	cb.Tick.Label = p.Y.Tick.Label
+	cb.Tick.Label.XAlign = draw.XLeft
+	cb.Tick.Label.YAlign = draw.YCenter
+	cb.Tick.LineStyle = p.Y.Tick.LineStyle
+	cb.Tick.Length = p.Y.Tick.Length
+	cb.Tick.Marker = plot.DefaultTicks{}
+	if _, _, log := cm.ZRange(); log {
+		cb.Tick.Marker = plot.LogTicks{Prec: -1}
+	}
+	return cb
+}
+
+func (cb *ColorBar) ticks() []plot.Tick {
+	var (
+		ticks    []plot.Tick
+		min, max = cb.zrange()
+	)
+	for _, t := range cb.Tick.Marker.Ticks(min, max) {
+		if t.Value < min || t.Value > max {
+			continue
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+func (cb *ColorBar) zrange() (min, max float64) {
+	min, max, _ = cb.Mapper.ZRange()
+	return min, max
+}
+
+// size returns the width needed to draw the color bar.
+func (cb *ColorBar) size() vg.Length {
+	var lbl vg.Length
+	for _, t := range cb.ticks() {
+		if t.IsMinor() {
+			continue
+		}
+		lbl = vg.Length(math.Max(float64(lbl), float64(cb.Tick.Label.Width(t.Label))))
+	}
+	return cb.Padding + cb.Width + cb.Tick.Length + lbl
+}
+
+// Draw draws the color bar on the lefthand-side of the provided canvas.
+func (cb *ColorBar) Draw(c draw.Canvas) {
+	var (
+		cm   = cb.Mapper
+		xmin = c.Min.X + cb.Padding
+		xmax = xmin + cb.Width
+		ymin = c.Min.Y
+		ymax = c.Max.Y
+		n    = int(math.Max(1, math.Ceil(float64(ymax-ymin))))
+		dy   = (ymax - ymin) / vg.Length(n)
+	)
+
+	for i := 0; i < n; i++ {
+		y0 := ymin + vg.Length(i)*dy
+		y1 := y0 + dy
+		if i < n-1 {
+			// avoid gaps between stripes when rasterized.
+			y1 += dy / 2
+		}
+		pts := []vg.Point{
+			{X: xmin, Y: y0},
+			{X: xmax, Y: y0},
+			{X: xmax, Y: y1},
+			{X: xmin, Y: y1},
+		}
+		c.FillPolygon(cm.ColorAt((float64(i)+0.5)/float64(n)), pts)
+	}
+
+	c.StrokeLines(cb.Tick.LineStyle, []vg.Point{
+		{X: xmin, Y: ymin},
+		{X: xmax, Y: ymin},
+		{X: xmax, Y: ymax},
+		{X: xmin, Y: ymax},
+		{X: xmin, Y: ymin},
+	})
+
+	for _, t := range cb.ticks() {
+		zmin, zmax, log := cm.ZRange()
+		y := ymin + vg.Length(normZ(zmin, zmax, log, t.Value))*(ymax-ymin)
+		size := cb.Tick.Length
+		if t.IsMinor() {
+			size /= 2
+		}
+		c.StrokeLine2(cb.Tick.LineStyle, xmax, y, xmax+size, y)
+		if t.IsMinor() {
+			continue
+		}
+		c.FillText(cb.Tick.Label, vg.Point{X: xmax + cb.Tick.Length, Y: y}, t.Label)
+	}
+}
+
+var (
+	_ ColorMapper = (*HeatMap)(nil)
+)
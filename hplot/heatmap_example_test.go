@@ -0,0 +1,95 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distmv"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleHeatMap() {
+	h2d := hbook.NewH2D(50, -10, 10, 50, -10, 10)
+
+	const npoints = 10000
+
+	dist, ok := distmv.NewNormal(
+		[]float64{0, 1},
+		mat.NewSymDense(2, []float64{4, 0, 0, 2}),
+		rand.New(rand.NewSource(1234)),
+	)
+	if !ok {
+		log.Fatalf("error creating distmv.Normal")
+	}
+
+	v := make([]float64, 2)
+	// Draw some random values from the standard
+	// normal distribution.
+	for i := 0; i < npoints; i++ {
+		v = dist.Rand(v)
+		h2d.Fill(v[0], v[1], 1)
+	}
+	h := hplot.NewHeatMap(h2d)
+
+	p := hplot.New()
+	p.Title.Text = "Hist-2D"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	p.Add(h)
+
+	fig := hplot.Figure(p, hplot.WithColorBar(h))
+	err := hplot.Save(fig, 12*vg.Centimeter, 10*vg.Centimeter, "testdata/heatmap.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleHeatMap_logZ() {
+	h2d := hbook.NewH2D(50, -10, 10, 50, -10, 10)
+
+	const npoints = 100000
+
+	dist, ok := distmv.NewNormal(
+		[]float64{0, 1},
+		mat.NewSymDense(2, []float64{4, 0, 0, 2}),
+		rand.New(rand.NewSource(1234)),
+	)
+	if !ok {
+		log.Fatalf("error creating distmv.Normal")
+	}
+
+	v := make([]float64, 2)
+	// Draw some random values from the standard
+	// normal distribution.
+	for i := 0; i < npoints; i++ {
+		v = dist.Rand(v)
+		h2d.Fill(v[0], v[1], 1)
+	}
+	h := hplot.NewHeatMap(
+		h2d,
+		hplot.WithLogZ(true),
+		hplot.WithColorMap(moreland.ExtendedBlackBody()),
+	)
+
+	p := hplot.New()
+	p.Title.Text = "Hist-2D (log-Z)"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	p.Add(h)
+
+	fig := hplot.Figure(p, hplot.WithColorBar(h))
+	err := hplot.Save(fig, 12*vg.Centimeter, 10*vg.Centimeter, "testdata/heatmap_logz.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
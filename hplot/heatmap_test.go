@@ -0,0 +1,56 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestHeatMap(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleHeatMap, t, "heatmap.png")
+}
+
+func TestHeatMapLogZ(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleHeatMap_logZ, t, "heatmap_logz.png")
+}
+
+func TestHeatMapRange(t *testing.T) {
+	h := hbook.NewH2D(2, 0, 2, 2, 0, 2)
+	h.Fill(0, 0, 1)
+	h.Fill(1, 0, -2)
+	h.Fill(0, 1, 4)
+
+	for _, tc := range []struct {
+		name     string
+		logz     bool
+		min, max float64
+	}{
+		{"lin", false, -2, 4},
+		{"log", true, 1, 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hm := hplot.NewHeatMap(h, hplot.WithLogZ(tc.logz))
+			if hm.Min != tc.min || hm.Max != tc.max {
+				t.Fatalf("invalid range: got=[%v, %v], want=[%v, %v]", hm.Min, hm.Max, tc.min, tc.max)
+			}
+
+			if got := hm.Color(0); got != nil {
+				t.Fatalf("invalid color for empty bin: got=%v", got)
+			}
+			hm.Empty = color.White
+			if got := hm.Color(0); got != color.White {
+				t.Fatalf("invalid color for empty bin: got=%v", got)
+			}
+			if got, want := hm.Color(10), hm.Color(4); got != want {
+				t.Fatalf("invalid color for overflow: got=%v, want=%v", got, want)
+			}
+		})
+	}
+}
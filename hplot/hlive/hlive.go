@@ -0,0 +1,219 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hlive serves live-updating plots to web browsers, e.g. for
+// online monitoring displays.
+//
+// A Server is an http.Handler serving a web page that displays the last
+// figure passed to Server.Update. The web page is notified of new figures
+// with server-sent events and refreshes its display without reloading.
+//
+//	srv := hlive.New(20*vg.Centimeter, -1)
+//	go http.ListenAndServe(":8080", srv)
+//
+//	for range time.Tick(time.Second) {
+//		fig := makeFigure()
+//		err := srv.Update(fig)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+package hlive // import "go-hep.org/x/hep/hplot/hlive"
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/vg"
+)
+
+// Server is an http.Handler displaying live-updating figures.
+//
+// Server serves the following resources, relative to the path it is
+// mounted on (which should end with a slash, e.g. with http.StripPrefix):
+//   - "/" (or any other resource): the web page displaying the figure,
+//   - "/plot.svg": the last figure, as an SVG image,
+//   - "/events": the stream of server-sent events, sent each time the
+//     figure is updated.
+//
+// Server is safe for concurrent use.
+type Server struct {
+	w, h vg.Length
+
+	mu   sync.RWMutex
+	gen  int                   // generation of the current figure
+	svg  []byte                // current figure
+	subs map[chan int]struct{} // subscribers to figure updates
+	done chan struct{}
+	stop bool
+}
+
+// New creates a new server displaying figures of size (w,h).
+//
+// If w or h are <= 0, the value is chosen such that it follows the Golden Ratio.
+func New(w, h vg.Length) *Server {
+	w, h = hplot.Dims(w, h)
+	return &Server{
+		w:    w,
+		h:    h,
+		subs: make(map[chan int]struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Update renders the provided figure and notifies the connected web
+// pages that a new figure is available.
+//
+// Update renders the figure synchronously: the figure may be modified
+// once Update has returned.
+func (srv *Server) Update(p hplot.Drawer) error {
+	wt, err := hplot.WriterTo(p, srv.w, srv.h, "svg")
+	if err != nil {
+		return fmt.Errorf("hlive: could not render figure: %w", err)
+	}
+	buf := new(bytes.Buffer)
+	_, err = wt.WriteTo(buf)
+	if err != nil {
+		return fmt.Errorf("hlive: could not render figure: %w", err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.stop {
+		return fmt.Errorf("hlive: server closed")
+	}
+
+	srv.gen++
+	srv.svg = buf.Bytes()
+	for sub := range srv.subs {
+		// only the last generation is of interest:
+		// drop the previous one if it hasn't been consumed yet.
+		select {
+		case <-sub:
+		default:
+		}
+		sub <- srv.gen
+	}
+	return nil
+}
+
+// Close closes the streams of events of all the connected web pages.
+// Subsequent calls to Update return an error.
+func (srv *Server) Close() error {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if !srv.stop {
+		srv.stop = true
+		close(srv.done)
+	}
+	return nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch path.Base(r.URL.Path) {
+	case "plot.svg":
+		srv.serveSVG(w, r)
+	case "events":
+		srv.serveEvents(w, r)
+	default:
+		srv.servePage(w, r)
+	}
+}
+
+func (srv *Server) servePage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := pageTmpl.Execute(w, struct {
+		Width  float64
+		Height float64
+	}{
+		Width:  srv.w.Dots(96),
+		Height: srv.h.Dots(96),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (srv *Server) serveSVG(w http.ResponseWriter, r *http.Request) {
+	srv.mu.RLock()
+	var (
+		gen = srv.gen
+		svg = srv.svg
+	)
+	srv.mu.RUnlock()
+
+	if svg == nil {
+		http.Error(w, "hlive: no figure", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Hlive-Generation", strconv.Itoa(gen))
+	_, _ = w.Write(svg)
+}
+
+func (srv *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "hlive: streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sub := make(chan int, 1)
+	srv.mu.Lock()
+	if srv.stop {
+		srv.mu.Unlock()
+		http.Error(w, "hlive: server closed", http.StatusServiceUnavailable)
+		return
+	}
+	srv.subs[sub] = struct{}{}
+	if srv.gen > 0 {
+		sub <- srv.gen
+	}
+	srv.mu.Unlock()
+
+	defer func() {
+		srv.mu.Lock()
+		delete(srv.subs, sub)
+		srv.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case gen := <-sub:
+			_, err := fmt.Fprintf(w, "data: %d\n\n", gen)
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-srv.done:
+			return
+		}
+	}
+}
+
+//go:embed hlive.tmpl
+var pageSrc string
+
+var pageTmpl = template.Must(template.New("hlive").Parse(pageSrc))
+
+var (
+	_ http.Handler = (*Server)(nil)
+)
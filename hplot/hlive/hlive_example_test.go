@@ -0,0 +1,56 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hlive_test
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"go-hep.org/x/hep/hplot/hlive"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleServer() {
+	srv := hlive.New(20*vg.Centimeter, -1)
+	defer srv.Close()
+
+	// Serve the live display under http://localhost:8080/monitor/
+	http.Handle("/monitor/", http.StripPrefix("/monitor", srv))
+	go func() {
+		log.Fatal(http.ListenAndServe(":8080", nil))
+	}()
+
+	var (
+		h    = hbook.NewH1D(50, -5, 5)
+		dist = distuv.Normal{Mu: 0, Sigma: 1, Src: rand.New(rand.NewSource(1234))}
+		tick = time.NewTicker(time.Second)
+	)
+	defer tick.Stop()
+
+	for i := 0; i < 60; i++ {
+		// Accumulate new data...
+		for j := 0; j < 1000; j++ {
+			h.Fill(dist.Rand(), 1)
+		}
+
+		// ... and refresh the display, at 1 Hz.
+		<-tick.C
+		p := hplot.New()
+		p.Title.Text = fmt.Sprintf("Monitoring (%d entries)", h.Entries())
+		p.X.Label.Text = "x"
+		p.Add(hplot.NewH1D(h), hplot.NewGrid())
+
+		err := srv.Update(p)
+		if err != nil {
+			log.Fatalf("could not update display: %+v", err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hlive
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/hplot"
+)
+
+func TestServer(t *testing.T) {
+	srv := New(-1, -1)
+	defer srv.Close()
+
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	get := func(name string) (*http.Response, string) {
+		t.Helper()
+		resp, err := http.Get(ts.URL + name)
+		if err != nil {
+			t.Fatalf("could not get %q: %+v", name, err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read %q: %+v", name, err)
+		}
+		return resp, string(body)
+	}
+
+	resp, body := get("/")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("invalid status: %v", resp.Status)
+	}
+	if !strings.Contains(body, `new EventSource("events")`) {
+		t.Fatalf("invalid page:\n%s", body)
+	}
+
+	resp, _ = get("/plot.svg")
+	if got, want := resp.StatusCode, http.StatusNotFound; got != want {
+		t.Fatalf("invalid status: got=%d, want=%d", got, want)
+	}
+
+	events, err := http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatalf("could not connect to events stream: %+v", err)
+	}
+	defer events.Body.Close()
+	if got, want := events.Header.Get("Content-Type"), "text/event-stream"; got != want {
+		t.Fatalf("invalid content type: got=%q, want=%q", got, want)
+	}
+
+	gens := make(chan string)
+	go func() {
+		defer close(gens)
+		sc := bufio.NewScanner(events.Body)
+		for sc.Scan() {
+			if v, ok := strings.CutPrefix(sc.Text(), "data: "); ok {
+				gens <- v
+			}
+		}
+	}()
+
+	for i, title := range []string{"first", "second"} {
+		p := hplot.New()
+		p.Title.Text = title
+		err := srv.Update(p)
+		if err != nil {
+			t.Fatalf("could not update figure: %+v", err)
+		}
+
+		select {
+		case gen := <-gens:
+			if got, want := gen, []string{"1", "2"}[i]; got != want {
+				t.Fatalf("invalid generation: got=%q, want=%q", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for event %d", i)
+		}
+
+		resp, body := get("/plot.svg")
+		if got, want := resp.Header.Get("Content-Type"), "image/svg+xml"; got != want {
+			t.Fatalf("invalid content type: got=%q, want=%q", got, want)
+		}
+		if !strings.Contains(body, "<svg") || !strings.Contains(body, title) {
+			t.Fatalf("invalid SVG figure:\n%s", body)
+		}
+	}
+
+	err = srv.Close()
+	if err != nil {
+		t.Fatalf("could not close server: %+v", err)
+	}
+
+	select {
+	case _, ok := <-gens:
+		if ok {
+			t.Fatalf("unexpected event after close")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for events stream to close")
+	}
+
+	err = srv.Update(hplot.New())
+	if err == nil {
+		t.Fatalf("expected an error updating a closed server")
+	}
+}
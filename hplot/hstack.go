@@ -7,6 +7,7 @@ package hplot
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"go-hep.org/x/hep/hbook"
 	"gonum.org/v1/plot"
@@ -40,7 +41,13 @@ type HStack struct {
 	// Band displays a colored band between the y-min and y-max error bars.
 	// Error bars are computed as the bin-by-bin quadratic sum of individual
 	// histogram uncertainties.
+	// Systematic uncertainties, set with the hplot.WithSystErrors option,
+	// are included in the band.
 	Band *BinnedErrBand
+
+	// Signals are histograms displayed on top of the stack,
+	// without being stacked.
+	Signals []*H1D
 }
 
 // HStackKind customizes how a HStack should behave.
@@ -66,6 +73,21 @@ func (hsk HStackKind) yoffs(i int, ys []float64, v float64) {
 	}
 }
 
+// HStackOrder describes how the histograms of a stack are ordered.
+type HStackOrder int
+
+const (
+	// HStackOrderAsIs stacks histograms in the order they were provided,
+	// the first histogram being at the bottom of the stack.
+	HStackOrderAsIs HStackOrder = iota
+	// HStackOrderIncreasing stacks histograms by increasing integral,
+	// the histogram with the smallest integral being at the bottom of the stack.
+	HStackOrderIncreasing
+	// HStackOrderDecreasing stacks histograms by decreasing integral,
+	// the histogram with the largest integral being at the bottom of the stack.
+	HStackOrderDecreasing
+)
+
 // NewHStack creates a new histogram stack from the provided list of histograms.
 // NewHStack panicks if the list of histograms is empty.
 // NewHStack panicks if the histograms have different binning.
@@ -83,6 +105,20 @@ func NewHStack(histos []*H1D, opts ...Options) *HStack {
 	}
 	copy(hstack.hs, histos)
 
+	switch cfg.order {
+	case HStackOrderAsIs:
+	case HStackOrderIncreasing:
+		sort.SliceStable(hstack.hs, func(i, j int) bool {
+			return hstack.hs[i].Hist.SumW() < hstack.hs[j].Hist.SumW()
+		})
+	case HStackOrderDecreasing:
+		sort.SliceStable(hstack.hs, func(i, j int) bool {
+			return hstack.hs[i].Hist.SumW() > hstack.hs[j].Hist.SumW()
+		})
+	default:
+		panic(fmt.Errorf("hplot: unknown HStackOrder value %d", cfg.order))
+	}
+
 	ref := hstack.hs[0].Hist.Binning.Bins
 	for _, h := range hstack.hs {
 		h.LogY = cfg.log.y
@@ -94,6 +130,13 @@ func NewHStack(histos []*H1D, opts ...Options) *HStack {
 			WithBand(true),
 			WithLogY(cfg.log.y),
 		).Band
+		custom := cfg.syst != nil
+		for _, h := range hstack.hs {
+			custom = custom || h.yerrf != nil || h.syst != nil
+		}
+		if custom {
+			hstack.Band.Counts = hstack.summedCounts(cfg.syst)
+		}
 	}
 
 	return hstack
@@ -108,6 +151,38 @@ func (hstack *HStack) summedH1D() *hbook.H1D {
 	return bookHtot
 }
 
+// summedCounts returns the content of the summed histogram, with the errors
+// of each bin computed as the quadratic sum of the (possibly asymmetric)
+// errors of the individual histograms and of the provided systematic errors
+// of the total.
+func (hstack *HStack) summedCounts(syst func(bin hbook.Bin1D) (low, high float64)) []hbook.Count {
+	var (
+		tot = hstack.summedH1D()
+		cs  = tot.Counts()
+	)
+	for i := range cs {
+		var lo2, hi2 float64
+		for _, h := range hstack.hs {
+			lo, hi := h.bandErr(h.Hist.Binning.Bins[i])
+			lo2 += lo * lo
+			hi2 += hi * hi
+		}
+		if syst != nil {
+			lo, hi := syst(tot.Binning.Bins[i])
+			lo2 += lo * lo
+			hi2 += hi * hi
+		}
+		cs[i].Err.Low = math.Sqrt(lo2)
+		cs[i].Err.High = math.Sqrt(hi2)
+	}
+	return cs
+}
+
+// Histos returns the histograms of the stack, in stacking order.
+func (hstack *HStack) Histos() []*H1D {
+	return hstack.hs
+}
+
 // DataRange returns the minimum and maximum X and Y values
 func (hstack *HStack) DataRange() (xmin, xmax, ymin, ymax float64) {
 	xmin = math.Inf(+1)
@@ -151,6 +226,18 @@ func (hstack *HStack) DataRange() (xmin, xmax, ymin, ymax float64) {
 		}
 	}
 
+	for _, h := range hstack.Signals {
+		h.LogY = hstack.LogY
+		_, _, ymin1, ymax1 := h.DataRange()
+		ymin = math.Min(ymin, ymin1)
+		ymax = math.Max(ymax, ymax1)
+		for _, bin := range h.Hist.Binning.Bins {
+			if sumw := bin.SumW(); sumw > 0 {
+				ylow = math.Min(sumw, ylow)
+			}
+		}
+	}
+
 	if hstack.LogY {
 		if ymin <= 0 && !math.IsInf(ylow, +1) {
 			// Reserve a bit of space for the smallest bin to be displayed still.
@@ -172,6 +259,11 @@ func (hstack *HStack) Plot(c draw.Canvas, p *plot.Plot) {
 	for i, h := range hstack.hs {
 		hstack.hplot(c, p, h, yoffs, hstack.Stack, i)
 	}
+
+	for _, h := range hstack.Signals {
+		h.LogY = hstack.LogY
+		h.Plot(c, p)
+	}
 }
 
 func (hstack *HStack) checkBins(refs, bins []hbook.Bin1D) {
@@ -247,7 +339,7 @@ func (hs *HStack) hplot(c draw.Canvas, p *plot.Plot, h *H1D, yoffs []float64, hs
 		}
 	}
 
-	if h.FillColor != nil {
+	if h.FillColor != nil || h.Hatch.Kind != HatchNone {
 		poly := pts
 		for i := range yoffs {
 			j := len(yoffs) - 1 - i
@@ -258,7 +350,10 @@ func (hs *HStack) hplot(c draw.Canvas, p *plot.Plot, h *H1D, yoffs []float64, hs
 			poly = append(poly, vg.Point{X: xmax, Y: ymin})
 			poly = append(poly, vg.Point{X: xmin, Y: ymin})
 		}
-		c.FillPolygon(h.FillColor, c.ClipPolygonXY(poly))
+		if h.FillColor != nil {
+			c.FillPolygon(h.FillColor, c.ClipPolygonXY(poly))
+		}
+		h.Hatch.Fill(c, poly)
 	}
 
 	// Plot individual histo band when not stacked or total band
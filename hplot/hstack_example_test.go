@@ -5,8 +5,10 @@
 package hplot_test
 
 import (
+	"fmt"
 	"image/color"
 	"log"
+	"math"
 
 	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hplot"
@@ -346,6 +348,57 @@ func ExampleHStack_withLogY() {
 	}
 }
 
+func ExampleHStack_withHatch() {
+	h1 := hbook.NewH1D(50, -8, 12)
+	h2 := hbook.NewH1D(50, -8, 12)
+	h3 := hbook.NewH1D(50, -8, 12)
+
+	const seed = 1234
+	fillH1(h1, 2000, -2, 1, seed)
+	fillH1(h2, 2000, +3, 3, seed)
+	fillH1(h3, 2000, +4, 1, seed)
+
+	hh1 := hplot.NewH1D(h1)
+	hh1.LineStyle.Color = color.Black
+	hh1.Hatch = hplot.HatchStyle{Kind: hplot.HatchDiagonal}
+	hh1.Hatch.Color = color.NRGBA{G: 150, A: 255}
+
+	hh2 := hplot.NewH1D(h2)
+	hh2.LineStyle.Color = color.Black
+	hh2.Hatch = hplot.HatchStyle{Kind: hplot.HatchAntiDiagonal}
+	hh2.Hatch.Color = color.NRGBA{B: 200, A: 255}
+
+	hh3 := hplot.NewH1D(h3)
+	hh3.LineStyle.Color = color.Black
+	hh3.FillColor = color.NRGBA{250, 167, 91, 150}
+	hh3.Hatch = hplot.HatchStyle{Kind: hplot.HatchDots, Spacing: 3}
+	hh3.Hatch.Width = 0.6
+
+	hs := []*hplot.H1D{hh1, hh2, hh3}
+
+	p := hplot.New()
+	p.Title.Text = "Stacked histograms with hatched uncertainty band"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+
+	hstack := hplot.NewHStack(hs, hplot.WithBand(true))
+	hstack.Band.FillColor = nil
+	hstack.Band.Hatch = hplot.HatchStyle{Kind: hplot.HatchCross, Spacing: 3}
+	hstack.Band.Hatch.Color = color.Gray{80}
+
+	p.Add(hstack, hplot.NewGrid())
+	p.Legend.Add("h1", hs[0])
+	p.Legend.Add("h2", hs[1])
+	p.Legend.Add("h3", hs[2])
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	err := p.Save(15*vg.Centimeter, 10*vg.Centimeter, "testdata/hstack_hatch.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
+
 func fillH1(h *hbook.H1D, n int, mu, sigma float64, seed uint64) {
 	dist := distuv.Normal{
 		Mu:    mu,
@@ -358,3 +411,75 @@ func fillH1(h *hbook.H1D, n int, mu, sigma float64, seed uint64) {
 		h.Fill(v, 1)
 	}
 }
+
+func ExampleHStack_withSignals() {
+	bkg1 := hbook.NewH1D(50, -8, 12)
+	bkg2 := hbook.NewH1D(50, -8, 12)
+	bkg3 := hbook.NewH1D(50, -8, 12)
+	sig := hbook.NewH1D(50, -8, 12)
+
+	const seed = 1234
+	fillH1(bkg1, 1000, -2, 1, seed)
+	fillH1(bkg2, 4000, +3, 3, seed)
+	fillH1(bkg3, 2000, +4, 1, seed)
+	fillH1(sig, 500, 0, 0.5, seed)
+
+	// 10% systematic uncertainty on each background.
+	syst := func(bin hbook.Bin1D) (lo, hi float64) {
+		v := 0.1 * math.Abs(bin.SumW())
+		return v, v
+	}
+
+	colors := []color.Color{
+		color.NRGBA{122, 195, 106, 150},
+		color.NRGBA{90, 155, 212, 150},
+		color.NRGBA{250, 167, 91, 150},
+	}
+
+	var bkgs []*hplot.H1D
+	for i, h := range []*hbook.H1D{bkg1, bkg2, bkg3} {
+		hh := hplot.NewH1D(h, hplot.WithSystErrors(syst))
+		hh.FillColor = colors[i]
+		hh.LineStyle.Color = color.Black
+		hh.Hist.Annotation()["name"] = fmt.Sprintf("bkg-%d", i+1)
+		bkgs = append(bkgs, hh)
+	}
+
+	// Stack the backgrounds by increasing integral and display
+	// the statistical and systematic uncertainties of the total,
+	// including an additional 5% uncertainty on the total.
+	hstack := hplot.NewHStack(bkgs,
+		hplot.WithBand(true),
+		hplot.WithStackOrder(hplot.HStackOrderIncreasing),
+		hplot.WithSystErrors(func(bin hbook.Bin1D) (lo, hi float64) {
+			return 0.05 * bin.SumW(), 0.05 * bin.SumW()
+		}),
+	)
+	hstack.Band.FillColor = color.NRGBA{R: 100, G: 100, B: 100, A: 150}
+
+	// Overlay the (unstacked) signal.
+	hsig := hplot.NewH1D(sig)
+	hsig.LineStyle.Color = color.NRGBA{R: 220, A: 255}
+	hsig.LineStyle.Width = vg.Points(2)
+	hsig.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	hstack.Signals = append(hstack.Signals, hsig)
+
+	p := hplot.New()
+	p.Title.Text = "Backgrounds and signal"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Add(hstack, hplot.NewGrid())
+
+	for _, h := range hstack.Histos() {
+		p.Legend.Add(h.Hist.Name(), h)
+	}
+	p.Legend.Add("stat+syst", hstack.Band)
+	p.Legend.Add("signal", hsig)
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	err := p.Save(15*vg.Centimeter, 10*vg.Centimeter, "testdata/hstack_signals.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
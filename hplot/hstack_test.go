@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"math"
+	"strings"
 	"testing"
 
 	"go-hep.org/x/hep/hbook"
@@ -20,6 +22,8 @@ func TestHStack(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleHStack, t, "hstack.png")
 	checkPlot(cmpimg.CheckPlot)(ExampleHStack_withBand, t, "hstack_band.png")
 	checkPlot(cmpimg.CheckPlot)(ExampleHStack_withLogY, t, "hstack_logy.png")
+	checkPlot(cmpimg.CheckPlot)(ExampleHStack_withHatch, t, "hstack_hatch.png")
+	checkPlot(cmpimg.CheckPlot)(ExampleHStack_withSignals, t, "hstack_signals.png")
 }
 
 func TestHStackPanic(t *testing.T) {
@@ -145,3 +149,74 @@ func TestHStackCornerBins(t *testing.T) {
 
 	}, t, "hstack_corner_bins.png")
 }
+
+func TestHStackOrder(t *testing.T) {
+	newH1D := func(name string, n int) *hplot.H1D {
+		h := hbook.NewH1D(2, 0, 2)
+		h.Annotation()["name"] = name
+		for i := 0; i < n; i++ {
+			h.Fill(0.5, 1)
+		}
+		return hplot.NewH1D(h)
+	}
+
+	for _, tc := range []struct {
+		order hplot.HStackOrder
+		want  string
+	}{
+		{order: hplot.HStackOrderAsIs, want: "b,a,c"},
+		{order: hplot.HStackOrderIncreasing, want: "a,b,c"},
+		{order: hplot.HStackOrderDecreasing, want: "c,b,a"},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			hs := []*hplot.H1D{newH1D("b", 2), newH1D("a", 1), newH1D("c", 3)}
+			stack := hplot.NewHStack(hs, hplot.WithStackOrder(tc.order))
+			var names []string
+			for _, h := range stack.Histos() {
+				names = append(names, h.Hist.Name())
+			}
+			if got := strings.Join(names, ","); got != tc.want {
+				t.Fatalf("invalid order: got=%q, want=%q", got, tc.want)
+			}
+			if hs[0].Hist.Name() != "b" {
+				t.Fatalf("input slice modified")
+			}
+		})
+	}
+}
+
+func TestHStackSystBand(t *testing.T) {
+	h1 := hbook.NewH1D(1, 0, 1)
+	h2 := hbook.NewH1D(1, 0, 1)
+	h1.Fill(0.5, 4)
+	h2.Fill(0.5, 9)
+
+	syst := func(frac float64) func(bin hbook.Bin1D) (float64, float64) {
+		return func(bin hbook.Bin1D) (float64, float64) {
+			return frac * bin.SumW(), 2 * frac * bin.SumW()
+		}
+	}
+
+	stack := hplot.NewHStack(
+		[]*hplot.H1D{
+			hplot.NewH1D(h1, hplot.WithSystErrors(syst(0.5))),
+			hplot.NewH1D(h2),
+		},
+		hplot.WithBand(true),
+		hplot.WithSystErrors(syst(0.1)),
+	)
+
+	var (
+		cnt = stack.Band.Counts[0]
+		s1  = 0.5 * 4.0 // stat. error of h1
+		s2  = 0.5 * 9.0 // stat. error of h2
+		lo  = math.Sqrt(s1*s1 + 2*2 + s2*s2 + 1.3*1.3)
+		hi  = math.Sqrt(s1*s1 + 4*4 + s2*s2 + 2.6*2.6)
+	)
+	if cnt.Val != 13 {
+		t.Fatalf("invalid band value: got=%v, want=13", cnt.Val)
+	}
+	if math.Abs(cnt.Err.Low-lo) > 1e-12 || math.Abs(cnt.Err.High-hi) > 1e-12 {
+		t.Fatalf("invalid band errors: got=(%v, %v), want=(%v, %v)", cnt.Err.Low, cnt.Err.High, lo, hi)
+	}
+}
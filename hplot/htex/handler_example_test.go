@@ -5,6 +5,7 @@
 package htex_test
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
@@ -36,3 +37,34 @@ func ExampleGoHandler() {
 		log.Fatalf("error compiling latex: %+v", err)
 	}
 }
+
+func ExamplePoolHandler() {
+	hdlr := htex.NewPoolHandler(4, "pdflatex")
+
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("plot-%0d", i)
+		p := hplot.New()
+		p.Title.Text = name
+		p.X.Label.Text = "x"
+		p.Y.Label.Text = "y"
+
+		err := hplot.Save(
+			hplot.Figure(p, hplot.WithLatexHandler(hdlr)),
+			-1, -1, name+".tex",
+		)
+		if err != nil {
+			log.Fatalf("could not save plot: %+v", err)
+		}
+	}
+
+	err := hdlr.Wait()
+	if err != nil {
+		var errs htex.CompileErrors
+		if errors.As(err, &errs) {
+			for _, err := range errs {
+				log.Printf("could not compile %q:\n%s", err.File, err.Log)
+			}
+		}
+		log.Fatalf("error compiling latex: %+v", err)
+	}
+}
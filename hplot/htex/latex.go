@@ -43,9 +43,17 @@ func NewHandler(cmd string) Handler {
 
 // CompileLatex compiles the provided .tex document.
 func (pdf *pdfLatex) CompileLatex(fname string) error {
+	_, err := compileLatex(pdf.cmd, fname)
+	return err
+}
+
+// compileLatex compiles the provided .tex document with the cmd executable
+// and returns the content of the log file generated by the compilation,
+// if any.
+func compileLatex(cmd, fname string) (log []byte, err error) {
 	tmp, err := os.MkdirTemp("", "hplot-htex-")
 	if err != nil {
-		return fmt.Errorf("htex: could not create tmp dir: %w", err)
+		return nil, fmt.Errorf("htex: could not create tmp dir: %w", err)
 	}
 	defer os.RemoveAll(tmp)
 
@@ -55,15 +63,17 @@ func (pdf *pdfLatex) CompileLatex(fname string) error {
 			fmt.Sprintf("-output-directory=%s", tmp),
 			fname,
 		}
+		base = path.Base(fname[:len(fname)-len(".tex")])
 	)
 
-	cmd := exec.Command(pdf.cmd, args...)
-	cmd.Stdout = stdout
-	cmd.Stderr = stdout
+	run := exec.Command(cmd, args...)
+	run.Stdout = stdout
+	run.Stderr = stdout
 
-	err = cmd.Run()
+	err = run.Run()
+	log, _ = os.ReadFile(path.Join(tmp, base+".log"))
 	if err != nil {
-		return fmt.Errorf(
+		return log, fmt.Errorf(
 			"htex: could not generate PDF from vgtex:\n%s\nerror: %w",
 			stdout.Bytes(),
 			err,
@@ -73,27 +83,27 @@ func (pdf *pdfLatex) CompileLatex(fname string) error {
 	oname := fname[:len(fname)-len(".tex")] + ".pdf"
 	o, err := os.Create(oname)
 	if err != nil {
-		return fmt.Errorf("htex: could not create output PDF file: %w", err)
+		return log, fmt.Errorf("htex: could not create output PDF file: %w", err)
 	}
 	defer o.Close()
 
 	f, err := os.Open(path.Join(tmp, path.Base(oname)))
 	if err != nil {
-		return fmt.Errorf("htex: could not open generated PDF file: %w", err)
+		return log, fmt.Errorf("htex: could not open generated PDF file: %w", err)
 	}
 	defer f.Close()
 
 	_, err = io.Copy(o, f)
 	if err != nil {
-		return fmt.Errorf("htex: could not copy PDF file: %w", err)
+		return log, fmt.Errorf("htex: could not copy PDF file: %w", err)
 	}
 
 	err = o.Close()
 	if err != nil {
-		return fmt.Errorf("htex: could not close PDF file: %w", err)
+		return log, fmt.Errorf("htex: could not close PDF file: %w", err)
 	}
 
-	return nil
+	return log, nil
 }
 
 var (
@@ -0,0 +1,198 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package htex
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// PoolHandler is a Latex handler that compiles Latex documents
+// concurrently over a bounded pool of workers.
+//
+// Contrary to GoHandler, PoolHandler captures the logs of the failed
+// compilations and reports the failures of all the documents, as a
+// CompileErrors value, when Wait is called.
+//
+// PoolHandler is safe for concurrent use.
+// A PoolHandler can be reused after Wait has returned.
+type PoolHandler struct {
+	cmd string
+	n   int // maximum number of workers
+
+	wg sync.WaitGroup
+
+	mu      sync.Mutex
+	queue   []poolJob
+	workers int // number of running workers
+	seq     int // sequence number of the next job
+	errs    []*CompileError
+}
+
+// poolJob is a document to compile.
+type poolJob struct {
+	seq   int
+	fname string
+}
+
+// NewPoolHandler creates a new Latex handler that compiles Latex documents
+// with the cmd executable, over a pool of up to n workers.
+//
+// If n<=0, the number of workers will be set to the number of cores.
+func NewPoolHandler(n int, cmd string) *PoolHandler {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	return &PoolHandler{
+		cmd: cmd,
+		n:   n,
+	}
+}
+
+// CompileLatex schedules the compilation of the provided .tex document
+// and returns immediately.
+// Errors are reported by Wait.
+func (ph *PoolHandler) CompileLatex(fname string) error {
+	ph.wg.Add(1)
+
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	ph.queue = append(ph.queue, poolJob{seq: ph.seq, fname: fname})
+	ph.seq++
+	if ph.workers < ph.n {
+		ph.workers++
+		go ph.work()
+	}
+	return nil
+}
+
+// work compiles the scheduled documents until the queue is empty.
+func (ph *PoolHandler) work() {
+	for {
+		ph.mu.Lock()
+		if len(ph.queue) == 0 {
+			ph.workers--
+			ph.mu.Unlock()
+			return
+		}
+		job := ph.queue[0]
+		ph.queue = ph.queue[1:]
+		ph.mu.Unlock()
+
+		log, err := compileLatex(ph.cmd, job.fname)
+		if err != nil {
+			ph.mu.Lock()
+			ph.errs = append(ph.errs, &CompileError{
+				File: job.fname,
+				Log:  string(log),
+				Err:  err,
+				seq:  job.seq,
+			})
+			ph.mu.Unlock()
+		}
+		ph.wg.Done()
+	}
+}
+
+// Wait waits for the completion of all the scheduled compilations.
+//
+// Wait returns nil if all the documents were successfully compiled,
+// and a CompileErrors value, listing the failed compilations in the order
+// they were scheduled, otherwise.
+func (ph *PoolHandler) Wait() error {
+	ph.wg.Wait()
+
+	ph.mu.Lock()
+	errs := ph.errs
+	ph.errs = nil
+	ph.mu.Unlock()
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// sort errors by scheduling order.
+	o := make(CompileErrors, len(errs))
+	copy(o, errs)
+	for i := 1; i < len(o); i++ {
+		for j := i; j > 0 && o[j].seq < o[j-1].seq; j-- {
+			o[j], o[j-1] = o[j-1], o[j]
+		}
+	}
+	return o
+}
+
+// CompileError describes the failed compilation of a .tex document.
+type CompileError struct {
+	File string // name of the .tex document
+	Log  string // content of the log file of the LaTeX compiler, if any
+	Err  error  // underlying error
+
+	seq int // scheduling order of the compilation
+}
+
+// Messages returns the error messages reported in the log of the LaTeX
+// compiler, i.e. the lines starting with an exclamation mark, together with
+// the line number of the document at which the error occurred.
+func (e *CompileError) Messages() []string {
+	var (
+		msgs []string
+		scan = bufio.NewScanner(strings.NewReader(e.Log))
+	)
+	for scan.Scan() {
+		line := scan.Text()
+		switch {
+		case strings.HasPrefix(line, "! "):
+			msgs = append(msgs, strings.TrimPrefix(line, "! "))
+		case strings.HasPrefix(line, "l.") && len(msgs) > 0:
+			if i := strings.Index(line, " "); i > 0 {
+				msgs[len(msgs)-1] = line[:i] + ": " + msgs[len(msgs)-1]
+			}
+		}
+	}
+	return msgs
+}
+
+func (e *CompileError) Error() string {
+	msgs := e.Messages()
+	if len(msgs) == 0 {
+		return fmt.Sprintf("htex: could not compile %q: %v", e.File, e.Err)
+	}
+	return fmt.Sprintf("htex: could not compile %q: %s", e.File, strings.Join(msgs, "; "))
+}
+
+func (e *CompileError) Unwrap() error { return e.Err }
+
+// CompileErrors is a list of failed compilations.
+type CompileErrors []*CompileError
+
+func (errs CompileErrors) Error() string {
+	o := new(bytes.Buffer)
+	fmt.Fprintf(o, "htex: could not compile %d document(s):", len(errs))
+	for _, err := range errs {
+		fmt.Fprintf(o, "\n%v", err)
+	}
+	return o.String()
+}
+
+// Unwrap returns the list of failed compilations.
+func (errs CompileErrors) Unwrap() []error {
+	o := make([]error, len(errs))
+	for i, err := range errs {
+		o[i] = err
+	}
+	return o
+}
+
+var (
+	_ Handler = (*PoolHandler)(nil)
+	_ error   = (*CompileError)(nil)
+	_ error   = (CompileErrors)(nil)
+)
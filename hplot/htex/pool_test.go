@@ -0,0 +1,144 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package htex_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/hplot"
+	"go-hep.org/x/hep/hplot/htex"
+	"gonum.org/v1/plot/vg"
+)
+
+// fakeLatex is a fake pdflatex command that fails to compile
+// the documents whose name contains "bad".
+const fakeLatex = `#!/bin/sh
+for arg in "$@"; do
+	case "$arg" in
+	-output-directory=*) dir="${arg#-output-directory=}" ;;
+	*.tex) fname="$arg" ;;
+	esac
+done
+base=$(basename "$fname" .tex)
+case "$base" in
+*bad*)
+	printf 'This is a fake pdfTeX\n! Undefined control sequence.\nl.12 \\foo\n' > "$dir/$base.log"
+	echo "compilation failed"
+	exit 1
+	;;
+esac
+echo "ok" > "$dir/$base.log"
+echo "%PDF-1.5" > "$dir/$base.pdf"
+`
+
+func TestPoolHandler(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skipf("skipping: fake latex command requires a POSIX shell")
+	}
+
+	tmp := t.TempDir()
+	cmd := filepath.Join(tmp, "pdflatex")
+	err := os.WriteFile(cmd, []byte(fakeLatex), 0755)
+	if err != nil {
+		t.Fatalf("could not create fake latex command: %+v", err)
+	}
+
+	hdlr := htex.NewPoolHandler(3, cmd)
+
+	p := hplot.New()
+	p.Title.Text = "pool"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	fig := hplot.Figure(p, hplot.WithLatexHandler(hdlr))
+
+	var names []string
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("plot-%02d", i)
+		if i%4 == 1 {
+			name = fmt.Sprintf("bad-%02d", i)
+		}
+		names = append(names, name)
+		fname := filepath.Join(tmp, name+".tex")
+		err := hplot.Save(fig, 10*vg.Centimeter, 10*vg.Centimeter, fname)
+		if err != nil {
+			t.Fatalf("could not save %q: %+v", fname, err)
+		}
+	}
+
+	err = hdlr.Wait()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	var errs htex.CompileErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("invalid error type %T: %+v", err, err)
+	}
+
+	want := []string{"bad-01", "bad-05", "bad-09"}
+	if got, want := len(errs), len(want); got != want {
+		t.Fatalf("invalid number of errors: got=%d, want=%d\n%+v", got, want, err)
+	}
+	for i, e := range errs {
+		if got, want := e.File, filepath.Join(tmp, want[i]+".tex"); got != want {
+			t.Fatalf("invalid file name for error #%d: got=%q, want=%q", i, got, want)
+		}
+		if e.Err == nil {
+			t.Fatalf("invalid nil underlying error for %q", e.File)
+		}
+		if !strings.Contains(e.Log, "! Undefined control sequence.") {
+			t.Fatalf("invalid log for %q:\n%s", e.File, e.Log)
+		}
+		if got, want := e.Messages(), []string{"l.12: Undefined control sequence."}; len(got) != 1 || got[0] != want[0] {
+			t.Fatalf("invalid messages for %q: got=%q, want=%q", e.File, got, want)
+		}
+		if got, want := e.Error(), fmt.Sprintf("htex: could not compile %q: l.12: Undefined control sequence.", e.File); got != want {
+			t.Fatalf("invalid error message:\ngot= %q\nwant=%q", got, want)
+		}
+	}
+
+	for _, name := range names {
+		_, err := os.Stat(filepath.Join(tmp, name+".pdf"))
+		switch {
+		case strings.HasPrefix(name, "bad"):
+			if err == nil {
+				t.Fatalf("unexpected PDF file for %q", name)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("missing PDF file for %q: %+v", name, err)
+			}
+		}
+	}
+
+	// handler can be reused.
+	err = hplot.Save(fig, 10*vg.Centimeter, 10*vg.Centimeter, filepath.Join(tmp, "reuse.tex"))
+	if err != nil {
+		t.Fatalf("could not save plot: %+v", err)
+	}
+	err = hdlr.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
+
+func TestCompileErrorNoLog(t *testing.T) {
+	cerr := &htex.CompileError{
+		File: "plot.tex",
+		Err:  os.ErrNotExist,
+	}
+	if got, want := cerr.Error(), `htex: could not compile "plot.tex": file does not exist`; got != want {
+		t.Fatalf("invalid error message:\ngot= %q\nwant=%q", got, want)
+	}
+	if !errors.Is(htex.CompileErrors{cerr}, os.ErrNotExist) {
+		t.Fatalf("could not unwrap compile errors")
+	}
+}
@@ -0,0 +1,547 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"image/color"
+	"io"
+	"math"
+	"reflect"
+	"unsafe"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// HTML returns an io.WriterTo that writes the provided figure as a
+// self-contained interactive HTML page.
+//
+// Unlike the other formats, the HTML page is not a rendering of the figure
+// but a serialization of its content: histograms (H1D, HStack), scatters
+// (S2D), bands (Band, BinnedErrBand), lines and functions are converted to
+// series that are drawn by the web browser.
+// The page displays the values under the mouse cursor, allows to zoom with a
+// mouse drag or wheel (a double click resets the zoom) and to toggle the
+// visibility of series with their legend entry.
+//
+// Plot, Fig, TiledPlot, RatioPlot, H1DRatioPlot and PullPlot drawers are supported.
+// Plotters that can not be serialized are ignored.
+//
+// If w or h are <= 0, the value is chosen such that it follows the Golden Ratio.
+func HTML(p Drawer, w, h vg.Length) (io.WriterTo, error) {
+	w, h = Dims(w, h)
+
+	fig := htmlFig{
+		Width:  htmlPx(w),
+		Height: htmlPx(h),
+	}
+	err := fig.add(p, htmlFrame{0, 0, 1, 1}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not convert figure to HTML: %w", err)
+	}
+
+	fig.Title = "hplot"
+	for _, plt := range fig.Plots {
+		if plt.Title != "" {
+			fig.Title = plt.Title
+			break
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	err = htmlTmpl.Execute(buf, fig)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not generate HTML page: %w", err)
+	}
+	return buf, nil
+}
+
+//go:embed html.tmpl
+var htmlSrc string
+
+var htmlTmpl = template.Must(template.New("hplot").Parse(htmlSrc))
+
+// htmlFig is the serialized form of a figure.
+type htmlFig struct {
+	Title  string     `json:"-"`
+	Width  float64    `json:"width"`
+	Height float64    `json:"height"`
+	Plots  []htmlPlot `json:"plots"`
+	links  int
+}
+
+// htmlFrame is the location of a plot in a figure, in units of the
+// figure size, from the top-left corner of the figure.
+type htmlFrame [4]float64
+
+func (f htmlFrame) sub(x, y, w, h float64) htmlFrame {
+	return htmlFrame{f[0] + x*f[2], f[1] + y*f[3], w * f[2], h * f[3]}
+}
+
+type htmlPlot struct {
+	Frame  htmlFrame    `json:"frame"`
+	Title  string       `json:"title,omitempty"`
+	X      htmlAxis     `json:"x"`
+	Y      htmlAxis     `json:"y"`
+	Grid   bool         `json:"grid,omitempty"`
+	Link   int          `json:"link,omitempty"` // plots with the same link share their X range.
+	Series []htmlSeries `json:"series"`
+}
+
+type htmlAxis struct {
+	Label string  `json:"label,omitempty"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Log   bool    `json:"log,omitempty"`
+}
+
+// htmlSeries is a serialized plotter.
+//
+// Kind is one of:
+//   - hist: a histogram with bins edges, values (Y), optional errors (Lo, Hi)
+//     and optional stacking offsets (Base),
+//   - points: a set of (X,Y) points, with optional errors (XLo, XHi, Lo, Hi),
+//   - bins: a band made of boxes with bins edges and y-ranges (Lo, Hi),
+//   - poly: a closed polygon (X, Y),
+//   - hline, vline: an horizontal (Y) or vertical (X) line.
+type htmlSeries struct {
+	Name   string    `json:"name,omitempty"`
+	Kind   string    `json:"kind"`
+	Line   string    `json:"line,omitempty"`
+	Width  float64   `json:"width,omitempty"`
+	Dash   []float64 `json:"dash,omitempty"`
+	Fill   string    `json:"fill,omitempty"`
+	Glyph  float64   `json:"glyph,omitempty"`
+	Color  string    `json:"color,omitempty"` // color of glyphs
+	Edges  []float64 `json:"edges,omitempty"`
+	X      []float64 `json:"x,omitempty"`
+	Y      []float64 `json:"y,omitempty"`
+	XLo    []float64 `json:"xlo,omitempty"`
+	XHi    []float64 `json:"xhi,omitempty"`
+	Lo     []float64 `json:"lo,omitempty"`
+	Hi     []float64 `json:"hi,omitempty"`
+	Base   []float64 `json:"base,omitempty"`
+	Steps  bool      `json:"steps,omitempty"`
+	Legend bool      `json:"legend,omitempty"`
+}
+
+func (fig *htmlFig) add(p Drawer, frame htmlFrame, legend *Legend) error {
+	switch p := p.(type) {
+	case *Plot:
+		return fig.addPlot(p, frame, 0, legend)
+
+	case *Fig:
+		return fig.add(p.Plot, frame, p.Legend)
+
+	case *TiledPlot:
+		rows := p.Tiles.Rows
+		cols := p.Tiles.Cols
+		if rows <= 0 || cols <= 0 {
+			return fmt.Errorf("invalid tiles (rows=%d, cols=%d)", rows, cols)
+		}
+		link := 0
+		if p.Align {
+			fig.links++
+			link = fig.links
+		}
+		for row := 0; row < rows; row++ {
+			for col := 0; col < cols; col++ {
+				plt := p.Plot(col, row)
+				if plt == nil {
+					continue
+				}
+				sub := frame.sub(
+					float64(col)/float64(cols), float64(row)/float64(rows),
+					1/float64(cols), 1/float64(rows),
+				)
+				err := fig.addPlot(plt, sub, link, legend)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+
+	case *H1DRatioPlot:
+		return fig.add(p.RatioPlot, frame, legend)
+
+	case *PullPlot:
+		return fig.add(p.RatioPlot, frame, legend)
+
+	case *RatioPlot:
+		fig.links++
+		link := fig.links
+		ratio := p.Ratio
+		if ratio <= 0 || ratio >= 1 {
+			ratio = 0.3
+		}
+		err := fig.addPlot(p.Top, frame.sub(0, 0, 1, 1-ratio), link, legend)
+		if err != nil {
+			return err
+		}
+		return fig.addPlot(p.Bottom, frame.sub(0, 1-ratio, 1, ratio), link, nil)
+
+	default:
+		return fmt.Errorf("unsupported drawer type %T", p)
+	}
+}
+
+func (fig *htmlFig) addPlot(p *Plot, frame htmlFrame, link int, legend *Legend) error {
+	var (
+		plt = htmlPlot{
+			Frame: frame,
+			Title: p.Title.Text,
+			X:     htmlAxisFrom(p.X),
+			Y:     htmlAxisFrom(p.Y),
+			Link:  link,
+		}
+		conv = htmlConv{
+			names: make(map[uintptr]string),
+			xmin:  p.X.Min,
+			xmax:  p.X.Max,
+		}
+	)
+	htmlLegend(conv.names, &p.Legend)
+	if legend != nil {
+		htmlLegend(conv.names, legend)
+	}
+
+	for _, ps := range htmlPlotters(p.Plot) {
+		if _, ok := ps.(*plotter.Grid); ok {
+			plt.Grid = true
+			continue
+		}
+		plt.Series = append(plt.Series, conv.series(ps)...)
+	}
+
+	fig.Plots = append(fig.Plots, plt)
+	return nil
+}
+
+func htmlAxisFrom(axis plot.Axis) htmlAxis {
+	_, log := axis.Scale.(plot.LogScale)
+	ax := htmlAxis{
+		Label: axis.Label.Text,
+		Min:   axis.Min,
+		Max:   axis.Max,
+		Log:   log,
+	}
+	if math.IsInf(ax.Min, 0) || math.IsInf(ax.Max, 0) {
+		// empty plot.
+		ax.Min, ax.Max = 0, 1
+	}
+	return ax
+}
+
+// htmlPlotters returns the plotters attached to the provided plot.
+func htmlPlotters(p *plot.Plot) []plot.Plotter {
+	v := reflect.ValueOf(p).Elem().FieldByName("plotters")
+	if !v.IsValid() {
+		return nil
+	}
+	ps, _ := reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem().Interface().([]plot.Plotter)
+	return ps
+}
+
+// htmlLegend collects the names of the legend entries, indexed by the
+// address of their thumbnailers.
+func htmlLegend(names map[uintptr]string, leg *Legend) {
+	entries := reflect.ValueOf(leg).Elem().FieldByName("entries")
+	if !entries.IsValid() {
+		return
+	}
+	for i := 0; i < entries.Len(); i++ {
+		var (
+			entry  = entries.Index(i)
+			text   = entry.FieldByName("text")
+			thumbs = entry.FieldByName("thumbs")
+		)
+		if !text.IsValid() || !thumbs.IsValid() {
+			continue
+		}
+		for j := 0; j < thumbs.Len(); j++ {
+			thumb := thumbs.Index(j).Elem()
+			if thumb.Kind() != reflect.Ptr {
+				continue
+			}
+			names[thumb.Pointer()] = text.String()
+		}
+	}
+}
+
+// htmlConv converts the plotters of a plot to series.
+type htmlConv struct {
+	names      map[uintptr]string // names of the legend entries
+	xmin, xmax float64            // range of the X axis
+}
+
+// name returns the legend entry of the provided plotter, if any.
+func (conv htmlConv) name(v interface{}) (string, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return "", false
+	}
+	name, ok := conv.names[rv.Pointer()]
+	return name, ok
+}
+
+func (conv htmlConv) series(p plot.Plotter) (out []htmlSeries) {
+	switch p := p.(type) {
+	case *H1D:
+		var band []htmlSeries
+		if p.Band != nil {
+			band = conv.series(p.Band)
+		}
+		out = append(out, band...)
+		out = append(out, htmlHist(p, nil, conv))
+
+	case *HStack:
+		base := make([]float64, len(p.hs[0].Hist.Binning.Bins))
+		for _, h := range p.hs {
+			if p.Stack == HStackOff {
+				out = append(out, htmlHist(h, nil, conv))
+				if h.Band != nil {
+					out = append(out, conv.series(h.Band)...)
+				}
+				continue
+			}
+			out = append(out, htmlHist(h, append([]float64(nil), base...), conv))
+			for i, bin := range h.Hist.Binning.Bins {
+				base[i] += bin.SumW()
+			}
+		}
+		if p.Stack == HStackOn && p.Band != nil {
+			out = append(out, conv.series(p.Band)...)
+		}
+		for _, h := range p.Signals {
+			out = append(out, conv.series(h)...)
+		}
+
+	case *BinnedErrBand:
+		s := htmlSeries{
+			Kind: "bins",
+			Fill: htmlColor(p.FillColor),
+		}
+		htmlLineStyle(&s, p.LineStyle)
+		s.Name, s.Legend = conv.name(p)
+		for _, c := range p.Counts {
+			if len(s.Edges) == 0 {
+				s.Edges = append(s.Edges, c.XRange.Min)
+			}
+			s.Edges = append(s.Edges, c.XRange.Max)
+			s.Lo = append(s.Lo, c.Val-c.Err.Low)
+			s.Hi = append(s.Hi, c.Val+c.Err.High)
+		}
+		out = append(out, s)
+
+	case *Band:
+		s := htmlSeries{
+			Kind: "poly",
+			Fill: htmlColor(p.FillColor),
+		}
+		htmlLineStyle(&s, p.LineStyle)
+		s.Name, s.Legend = conv.name(p)
+		for _, xy := range p.bottom {
+			s.X = append(s.X, xy.X)
+			s.Y = append(s.Y, xy.Y)
+		}
+		for i := range p.top {
+			xy := p.top[len(p.top)-1-i]
+			s.X = append(s.X, xy.X)
+			s.Y = append(s.Y, xy.Y)
+		}
+		out = append(out, s)
+
+	case *S2D:
+		if p.Band != nil {
+			out = append(out, conv.series(p.Band)...)
+		}
+		s := htmlSeries{
+			Kind:  "points",
+			Glyph: htmlPx(p.GlyphStyle.Radius),
+			Color: htmlColor(p.GlyphStyle.Color),
+			Steps: p.Steps != NoSteps,
+		}
+		htmlLineStyle(&s, p.LineStyle)
+		s.Name, s.Legend = conv.name(p)
+		n := p.Data.Len()
+		s.X = make([]float64, n)
+		s.Y = make([]float64, n)
+		for i := range s.X {
+			s.X[i], s.Y[i] = p.Data.XY(i)
+		}
+		if xerr, ok := p.Data.(plotter.XErrorer); ok && p.XErrs != nil {
+			s.XLo = make([]float64, n)
+			s.XHi = make([]float64, n)
+			for i := range s.XLo {
+				lo, hi := xerr.XError(i)
+				s.XLo[i] = s.X[i] - lo
+				s.XHi[i] = s.X[i] + hi
+			}
+		}
+		if yerr, ok := p.Data.(plotter.YErrorer); ok && p.YErrs != nil {
+			s.Lo = make([]float64, n)
+			s.Hi = make([]float64, n)
+			for i := range s.Lo {
+				lo, hi := yerr.YError(i)
+				s.Lo[i] = s.Y[i] - lo
+				s.Hi[i] = s.Y[i] + hi
+			}
+		}
+		out = append(out, s)
+
+	case *plotter.Line:
+		s := htmlSeries{
+			Kind: "points",
+			Fill: htmlColor(p.FillColor),
+		}
+		htmlLineStyle(&s, p.LineStyle)
+		s.Name, s.Legend = conv.name(p)
+		for _, xy := range p.XYs {
+			s.X = append(s.X, xy.X)
+			s.Y = append(s.Y, xy.Y)
+		}
+		s.Steps = p.StepStyle != plotter.NoStep
+		out = append(out, s)
+
+	case *plotter.Scatter:
+		s := htmlSeries{
+			Kind:  "points",
+			Glyph: htmlPx(p.GlyphStyle.Radius),
+			Color: htmlColor(p.GlyphStyle.Color),
+		}
+		s.Name, s.Legend = conv.name(p)
+		for _, xy := range p.XYs {
+			s.X = append(s.X, xy.X)
+			s.Y = append(s.Y, xy.Y)
+		}
+		out = append(out, s)
+
+	case *Function:
+		s := htmlSeries{Kind: "points"}
+		htmlLineStyle(&s, p.LineStyle)
+		s.Name, s.Legend = conv.name(p)
+		n := p.Samples
+		if n <= 1 {
+			n = 50
+		}
+		xmin, xmax := p.XMin, p.XMax
+		if xmin == xmax {
+			xmin, xmax = conv.xmin, conv.xmax
+		}
+		if p.LogY {
+			// zero values are discarded on log-scaled Y axes.
+			defer func() {
+				sel := out[:0]
+				for _, s := range out {
+					s.X, s.Y = htmlNonZero(s.X, s.Y)
+					sel = append(sel, s)
+				}
+				out = sel
+			}()
+		}
+		for i := 0; i < n; i++ {
+			x := xmin + float64(i)*(xmax-xmin)/float64(n-1)
+			y := p.F(x)
+			if math.IsNaN(y) || math.IsInf(y, 0) {
+				continue
+			}
+			s.X = append(s.X, x)
+			s.Y = append(s.Y, y)
+		}
+		out = append(out, s)
+
+	case *HorizLine:
+		s := htmlSeries{Kind: "hline", Y: []float64{p.Y}}
+		htmlLineStyle(&s, p.Line)
+		s.Name, s.Legend = conv.name(p)
+		out = append(out, s)
+
+	case *VertLine:
+		s := htmlSeries{Kind: "vline", X: []float64{p.X}}
+		htmlLineStyle(&s, p.Line)
+		s.Name, s.Legend = conv.name(p)
+		out = append(out, s)
+	}
+	return out
+}
+
+// htmlNonZero returns the (x,y) points with a non-zero y value.
+func htmlNonZero(xs, ys []float64) ([]float64, []float64) {
+	var ox, oy []float64
+	for i, y := range ys {
+		if y == 0 {
+			continue
+		}
+		ox = append(ox, xs[i])
+		oy = append(oy, y)
+	}
+	return ox, oy
+}
+
+func htmlHist(h *H1D, offs []float64, conv htmlConv) htmlSeries {
+	s := htmlSeries{
+		Kind:  "hist",
+		Fill:  htmlColor(h.FillColor),
+		Glyph: htmlPx(h.GlyphStyle.Radius),
+		Color: htmlColor(h.GlyphStyle.Color),
+		Base:  offs,
+	}
+	htmlLineStyle(&s, h.LineStyle)
+	s.Name, s.Legend = conv.name(h)
+
+	bins := h.Hist.Binning.Bins
+	for i, bin := range bins {
+		if i == 0 {
+			s.Edges = append(s.Edges, bin.XMin())
+		}
+		s.Edges = append(s.Edges, bin.XMax())
+		s.Y = append(s.Y, bin.SumW())
+	}
+	if h.YErrs != nil {
+		for i, bin := range bins {
+			y := bin.SumW()
+			if offs != nil {
+				y += offs[i]
+			}
+			lo, hi := h.yerr(bin)
+			s.Lo = append(s.Lo, y-lo)
+			s.Hi = append(s.Hi, y+hi)
+		}
+	}
+	return s
+}
+
+func htmlLineStyle(s *htmlSeries, sty draw.LineStyle) {
+	if sty.Width <= 0 || sty.Color == nil {
+		return
+	}
+	s.Line = htmlColor(sty.Color)
+	s.Width = htmlPx(sty.Width)
+	for _, v := range sty.Dashes {
+		s.Dash = append(s.Dash, htmlPx(v))
+	}
+}
+
+// htmlPx converts the provided length to CSS pixels.
+func htmlPx(v vg.Length) float64 {
+	return v.Points() * 96 / 72
+}
+
+// htmlColor converts the provided color to a CSS color.
+// A nil color is converted to the empty string.
+func htmlColor(c color.Color) string {
+	if c == nil {
+		return ""
+	}
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("rgba(%d,%d,%d,%.3g)", nc.R, nc.G, nc.B, float64(nc.A)/255)
+}
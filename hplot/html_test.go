@@ -0,0 +1,244 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/color"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+type htmlSeries struct {
+	Name   string    `json:"name"`
+	Kind   string    `json:"kind"`
+	Edges  []float64 `json:"edges"`
+	X      []float64 `json:"x"`
+	Y      []float64 `json:"y"`
+	Lo     []float64 `json:"lo"`
+	Hi     []float64 `json:"hi"`
+	Base   []float64 `json:"base"`
+	Legend bool      `json:"legend"`
+}
+
+type htmlPlot struct {
+	Frame [4]float64 `json:"frame"`
+	Title string     `json:"title"`
+	X     struct {
+		Label string  `json:"label"`
+		Min   float64 `json:"min"`
+		Max   float64 `json:"max"`
+		Log   bool    `json:"log"`
+	} `json:"x"`
+	Y struct {
+		Log bool `json:"log"`
+	} `json:"y"`
+	Grid   bool         `json:"grid"`
+	Link   int          `json:"link"`
+	Series []htmlSeries `json:"series"`
+}
+
+type htmlFig struct {
+	Width  float64    `json:"width"`
+	Height float64    `json:"height"`
+	Plots  []htmlPlot `json:"plots"`
+}
+
+// decodeHTML extracts the figure serialized in the provided HTML page.
+func decodeHTML(t *testing.T, page []byte) htmlFig {
+	t.Helper()
+
+	const prefix = "const fig = "
+	beg := bytes.Index(page, []byte(prefix))
+	if beg < 0 {
+		t.Fatalf("could not find figure in HTML page")
+	}
+	page = page[beg+len(prefix):]
+	end := bytes.Index(page, []byte(";\n"))
+	if end < 0 {
+		t.Fatalf("could not find end of figure in HTML page")
+	}
+
+	var fig htmlFig
+	err := json.Unmarshal(page[:end], &fig)
+	if err != nil {
+		t.Fatalf("could not decode figure: %+v", err)
+	}
+	return fig
+}
+
+func TestHTML(t *testing.T) {
+	h1 := hbook.NewH1D(4, 0, 4)
+	h2 := hbook.NewH1D(4, 0, 4)
+	for i, v := range []float64{1, 2, 2, 3, 3, 3} {
+		h1.Fill(v+0.5, 1)
+		h2.Fill(float64(i%4)+0.5, 2)
+	}
+
+	hh1 := hplot.NewH1D(h1, hplot.WithYErrBars(true))
+	hh2 := hplot.NewH1D(h2)
+	hh2.FillColor = color.NRGBA{R: 255, A: 128}
+	hs := hplot.NewHStack([]*hplot.H1D{hh1, hh2}, hplot.WithBand(true))
+
+	sca := hplot.NewS2D(plotter.XYs{{X: 0.5, Y: 1}, {X: 1.5, Y: 2}})
+	hline := hplot.HLine(1, nil, nil)
+
+	tp := hplot.NewTiledPlot(draw.Tiles{Cols: 2, Rows: 1})
+	tp.Align = true
+	{
+		p := tp.Plot(0, 0)
+		p.Title.Text = "stack <&>"
+		p.X.Label.Text = "x"
+		p.Add(hs, hplot.NewGrid())
+		p.Legend.Add("h1", hh1)
+		p.Legend.Add("h2", hh2)
+	}
+	{
+		p := tp.Plot(1, 0)
+		p.Add(sca, hline)
+		p.Legend.Add("data", sca)
+	}
+
+	fname := filepath.Join(t.TempDir(), "tiles.html")
+	err := hplot.Save(tp, 20*vg.Centimeter, -1, fname)
+	if err != nil {
+		t.Fatalf("could not save HTML page: %+v", err)
+	}
+
+	page, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("could not read HTML page: %+v", err)
+	}
+
+	if !bytes.Contains(page, []byte("<title>stack &lt;&amp;&gt;</title>")) {
+		t.Fatalf("invalid HTML title")
+	}
+
+	fig := decodeHTML(t, page)
+	if got, want := len(fig.Plots), 2; got != want {
+		t.Fatalf("invalid number of plots: got=%d, want=%d", got, want)
+	}
+
+	p1 := fig.Plots[0]
+	if got, want := p1.Frame, [4]float64{0, 0, 0.5, 1}; got != want {
+		t.Fatalf("invalid frame: got=%v, want=%v", got, want)
+	}
+	if p1.Title != "stack <&>" || p1.X.Label != "x" || !p1.Grid {
+		t.Fatalf("invalid plot: %+v", p1)
+	}
+	if p1.Link == 0 || p1.Link != fig.Plots[1].Link {
+		t.Fatalf("aligned tiles should share their X axis: %d, %d", p1.Link, fig.Plots[1].Link)
+	}
+
+	var kinds []string
+	for _, s := range p1.Series {
+		kinds = append(kinds, s.Kind+":"+s.Name)
+	}
+	if got, want := strings.Join(kinds, ","), "hist:h1,hist:h2,bins:"; got != want {
+		t.Fatalf("invalid series:\ngot= %s\nwant=%s", got, want)
+	}
+
+	var (
+		s1 = p1.Series[0]
+		s2 = p1.Series[1]
+	)
+	if got, want := s1.Edges, []float64{0, 1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid edges: got=%v, want=%v", got, want)
+	}
+	if got, want := s1.Y, []float64{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid h1 values: got=%v, want=%v", got, want)
+	}
+	if len(s1.Lo) != 4 || len(s1.Hi) != 4 || !s1.Legend {
+		t.Fatalf("invalid h1 series: %+v", s1)
+	}
+	if got, want := s2.Base, s1.Y; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid h2 stack offsets: got=%v, want=%v", got, want)
+	}
+	if got, want := s2.Y, []float64{4, 4, 2, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid h2 values: got=%v, want=%v", got, want)
+	}
+
+	p2 := fig.Plots[1]
+	kinds = kinds[:0]
+	for _, s := range p2.Series {
+		kinds = append(kinds, s.Kind+":"+s.Name)
+	}
+	if got, want := strings.Join(kinds, ","), "points:data,hline:"; got != want {
+		t.Fatalf("invalid series:\ngot= %s\nwant=%s", got, want)
+	}
+	if got, want := p2.Series[0].Y, []float64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid points: got=%v, want=%v", got, want)
+	}
+}
+
+func TestHTMLRatioPlot(t *testing.T) {
+	num := hbook.NewH1D(2, 0, 2)
+	den := hbook.NewH1D(2, 0, 2)
+	num.Fill(0.5, 2)
+	num.Fill(1.5, 1)
+	den.Fill(0.5, 1)
+	den.Fill(1.5, 1)
+
+	rp, err := hplot.NewH1DRatioPlot(num, den, hplot.WithLogY(true))
+	if err != nil {
+		t.Fatalf("could not create ratio plot: %+v", err)
+	}
+
+	var buf bytes.Buffer
+	wt, err := hplot.WriterTo(rp, 10*vg.Centimeter, 10*vg.Centimeter, "html")
+	if err != nil {
+		t.Fatalf("could not create HTML page: %+v", err)
+	}
+	_, err = wt.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("could not write HTML page: %+v", err)
+	}
+
+	fig := decodeHTML(t, buf.Bytes())
+	if got, want := len(fig.Plots), 2; got != want {
+		t.Fatalf("invalid number of plots: got=%d, want=%d", got, want)
+	}
+	top, bot := fig.Plots[0], fig.Plots[1]
+	if top.Link == 0 || top.Link != bot.Link {
+		t.Fatalf("ratio plots should share their X axis: %d, %d", top.Link, bot.Link)
+	}
+	if !top.Y.Log || bot.Y.Log {
+		t.Fatalf("invalid log-y axes: top=%v, bottom=%v", top.Y.Log, bot.Y.Log)
+	}
+	if got, want := top.Frame[3]+bot.Frame[3], 1.0; got != want {
+		t.Fatalf("invalid frames: top=%v, bottom=%v", top.Frame, bot.Frame)
+	}
+
+	var ratio *htmlSeries
+	for i, s := range bot.Series {
+		if s.Kind == "points" {
+			ratio = &bot.Series[i]
+		}
+	}
+	if ratio == nil {
+		t.Fatalf("could not find ratio series")
+	}
+	if got, want := ratio.Y, []float64{2, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid ratio: got=%v, want=%v", got, want)
+	}
+}
+
+func TestHTMLUnsupported(t *testing.T) {
+	leg := hplot.NewLegend()
+	_, err := hplot.HTML(&leg, 10*vg.Centimeter, -1)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -5,14 +5,18 @@
 package hplot
 
 import (
+	"errors"
 	"fmt"
 	"image/color"
 	"io"
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
+	"go-hep.org/x/hep/hplot/htex"
 	"go-hep.org/x/hep/hplot/vgop"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
@@ -33,7 +37,18 @@ type Drawer interface {
 //
 // Supported extensions are:
 //
-//	.eps, .jpg, .jpeg, .json, .pdf, .png, .svg, .tex, .tif and .tiff.
+//	.eps, .html, .jpg, .jpeg, .json, .pdf, .png, .svg, .tex, .tif and .tiff.
+//
+// Several files, possibly in several formats, can be saved in one call.
+// The plot is rendered once per file; when p is a Fig, the DPI of each
+// image format can be set with WithFormatDPI.
+// The generation of PDFs from .tex files by the LaTeX handler of a Fig is
+// run concurrently with the rendering of the other files, with at most
+// GOMAXPROCS concurrent LaTeX compilations across all Save calls.
+// When p is a Fig, the metadata of the figure is embedded in the
+// PDF and PNG files.
+// Save returns the errors of all the files that could not be saved,
+// joined with errors.Join.
 //
 // If w or h are <= 0, the value is chosen such that it follows the Golden Ratio.
 // If w and h are <= 0, the values are chosen such that they follow the Golden Ratio
@@ -45,12 +60,7 @@ func Save(p Drawer, w, h vg.Length, fnames ...string) (err error) {
 
 	w, h = Dims(w, h)
 
-	save := func(file string) error {
-		format := strings.ToLower(filepath.Ext(file))
-		if len(format) != 0 {
-			format = format[1:]
-		}
-
+	save := func(file, format string) error {
 		dc, err := WriterTo(p, w, h, format)
 		if err != nil {
 			return err
@@ -67,30 +77,58 @@ func Save(p Drawer, w, h vg.Length, fnames ...string) (err error) {
 			return err
 		}
 
-		err = f.Close()
-		if err != nil {
-			return err
-		}
+		return f.Close()
+	}
 
-		if format == "tex" {
-			if fig, ok := p.(*Fig); ok {
-				err = fig.Latex.CompileLatex(file)
-				if err != nil {
-					return fmt.Errorf("hplot: could not generate PDF: %w", err)
-				}
-			}
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(fnames))
+	)
+	for i, file := range fnames {
+		format := strings.ToLower(filepath.Ext(file))
+		if len(format) != 0 {
+			format = format[1:]
 		}
-		return nil
-	}
 
-	for _, file := range fnames {
-		err := save(file)
+		err := save(file, format)
 		if err != nil {
-			return fmt.Errorf("hplot: could not save plot: %w", err)
+			errs[i] = fmt.Errorf("hplot: could not save plot: %w", err)
+			continue
+		}
+
+		fig, ok := p.(*Fig)
+		if format != "tex" || !ok {
+			continue
 		}
+		wg.Add(1)
+		go func(i int, file string) {
+			defer wg.Done()
+			err := compileLatex(fig.Latex, file)
+			if err != nil {
+				errs[i] = fmt.Errorf("hplot: could not save plot: hplot: could not generate PDF: %w", err)
+			}
+		}(i, file)
 	}
+	wg.Wait()
 
-	return nil
+	return errors.Join(errs...)
+}
+
+var latexJobs struct {
+	once sync.Once
+	sema chan struct{}
+}
+
+// compileLatex compiles the provided .tex file with the LaTeX handler,
+// limiting the number of concurrent compilations to GOMAXPROCS.
+func compileLatex(h htex.Handler, file string) error {
+	latexJobs.once.Do(func() {
+		latexJobs.sema = make(chan struct{}, runtime.GOMAXPROCS(0))
+	})
+	latexJobs.sema <- struct{}{}
+	defer func() { <-latexJobs.sema }()
+
+	return h.CompileLatex(file)
 }
 
 // WriterTo returns an io.WriterTo that will write the plots as
@@ -104,9 +142,13 @@ func Save(p Drawer, w, h vg.Length, fnames ...string) (err error) {
 func WriterTo(p Drawer, w, h vg.Length, format string) (io.WriterTo, error) {
 	w, h = Dims(w, h)
 
+	if format == "html" {
+		return HTML(p, w, h)
+	}
+
 	dpi := float64(vgimg.DefaultDPI)
 	if fig, ok := p.(*Fig); ok {
-		dpi = fig.DPI
+		dpi = fig.dpi(format)
 	}
 
 	c, err := newFormattedCanvas(w, h, format, dpi)
@@ -115,6 +157,9 @@ func WriterTo(p Drawer, w, h vg.Length, format string) (io.WriterTo, error) {
 	}
 	p.Draw(draw.New(c))
 
+	if fig, ok := p.(*Fig); ok {
+		return withMetadata(c, format, fig.Metadata), nil
+	}
 	return c, nil
 }
 
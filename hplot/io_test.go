@@ -6,7 +6,14 @@ package hplot
 
 import (
 	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+
+	"gonum.org/v1/plot/vg"
 )
 
 func TestSave(t *testing.T) {
@@ -35,6 +42,14 @@ func TestSave(t *testing.T) {
 			files: []string{"file.txt"},
 			want:  fmt.Errorf(`hplot: could not save plot: hplot: could not create canvas: unsupported format: "txt"`),
 		},
+		{
+			name:  "multiple-errors",
+			files: []string{"file.txt", "file.foo"},
+			want: fmt.Errorf("%s\n%s",
+				`hplot: could not save plot: hplot: could not create canvas: unsupported format: "txt"`,
+				`hplot: could not save plot: hplot: could not create canvas: unsupported format: "foo"`,
+			),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			err := Save(p, -1, -1, tc.files...)
@@ -48,3 +63,75 @@ func TestSave(t *testing.T) {
 		})
 	}
 }
+
+type latexCounter struct {
+	mu    sync.Mutex
+	files []string
+	fail  string
+}
+
+func (h *latexCounter) CompileLatex(fname string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.files = append(h.files, filepath.Base(fname))
+	if strings.HasSuffix(fname, h.fail) {
+		return fmt.Errorf("boom")
+	}
+	return nil
+}
+
+func TestSaveMultiFormat(t *testing.T) {
+	tmp := t.TempDir()
+
+	p := New()
+	p.Title.Text = "my title"
+
+	latex := &latexCounter{fail: "fail.tex"}
+	fig := Figure(p,
+		WithLatexHandler(latex),
+		WithDPI(96),
+		WithFormatDPI("png", 192),
+	)
+
+	var (
+		w     = 10 * vg.Centimeter
+		h     = 5 * vg.Centimeter
+		fname = func(name string) string { return filepath.Join(tmp, name) }
+	)
+
+	err := Save(fig, w, h,
+		fname("plot.png"), fname("plot.jpg"), fname("plot.pdf"),
+		fname("plot.tex"), fname("fail.tex"),
+	)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "hplot: could not save plot: hplot: could not generate PDF: boom"; got != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+	}
+
+	if got, want := len(latex.files), 2; got != want {
+		t.Fatalf("invalid number of LaTeX compilations: got=%d, want=%d", got, want)
+	}
+
+	for _, name := range []string{"plot.png", "plot.jpg", "plot.pdf", "plot.tex", "fail.tex"} {
+		_, err := os.Stat(fname(name))
+		if err != nil {
+			t.Fatalf("could not stat %q: %+v", name, err)
+		}
+	}
+
+	f, err := os.Open(fname("plot.png"))
+	if err != nil {
+		t.Fatalf("could not open PNG file: %+v", err)
+	}
+	defer f.Close()
+
+	cfg, err := png.DecodeConfig(f)
+	if err != nil {
+		t.Fatalf("could not decode PNG file: %+v", err)
+	}
+	if got, want := cfg.Width, int(w.Dots(192)+0.5); got != want {
+		t.Fatalf("invalid PNG width: got=%d, want=%d", got, want)
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"math"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// LegendPlacement describes how the legend of a plot is placed.
+type LegendPlacement int
+
+const (
+	// LegendManual places the legend according to the Top, Left,
+	// XOffs and YOffs fields of the legend.
+	LegendManual LegendPlacement = iota
+
+	// LegendAuto places the legend in the corner of the data area
+	// overlapping the least with the plotted data.
+	// The Top, Left, XOffs and YOffs fields of the legend are ignored.
+	LegendAuto
+
+	// LegendAutoOutside places the legend as LegendAuto does, unless all
+	// the corners of the data area overlap with the plotted data.
+	// The legend is then placed outside of the frame of the plot,
+	// on its righthand-side.
+	LegendAutoOutside
+)
+
+// legendCorners are the candidate positions of an automatically placed
+// legend, in order of preference.
+var legendCorners = []struct{ top, left bool }{
+	{top: true, left: false},
+	{top: true, left: true},
+	{top: false, left: false},
+	{top: false, left: true},
+}
+
+// placeLegend places the legend of the plot, to be drawn on the provided
+// canvas, according to the legend placement mode of the plot.
+// placeLegend returns the canvas on which the plot should be drawn and a
+// function restoring the legend of the plot, to be called once the plot
+// has been drawn.
+func (p *Plot) placeLegend(dc draw.Canvas) (draw.Canvas, func()) {
+	size := p.Legend.Rectangle(dc).Size()
+	if p.LegendPlacement == LegendManual || size.X <= 0 || size.Y <= 0 {
+		return dc, func() {}
+	}
+
+	var (
+		leg  = p.Legend
+		da   = p.DataCanvas(dc)
+		occ  = p.occupancy()
+		best = -1
+		min  = math.Inf(+1)
+	)
+	for i, corner := range legendCorners {
+		v := occ.overlap(legendRect(da, size, corner.top, corner.left))
+		if v < min {
+			best = i
+			min = v
+		}
+	}
+
+	restore := func() { p.Legend = leg }
+	if min > 0 && p.LegendPlacement == LegendAutoOutside {
+		var (
+			width = size.X + p.Y.Padding
+			lc    = draw.Crop(dc, dc.Size().X-size.X, 0, 0, 0)
+		)
+		lc.Max.Y = da.Max.Y
+
+		// draw the plot with an empty legend.
+		p.Legend = plot.Legend{TextStyle: leg.TextStyle}
+		return draw.Crop(dc, 0, -width, 0, 0), func() {
+			restore()
+			out := leg
+			out.Top = true
+			out.Left = true
+			out.XOffs = 0
+			out.YOffs = 0
+			out.Draw(lc)
+		}
+	}
+
+	p.Legend.Top = legendCorners[best].top
+	p.Legend.Left = legendCorners[best].left
+	p.Legend.XOffs = 0
+	p.Legend.YOffs = 0
+	return dc, restore
+}
+
+// legendRect returns the rectangle, in normalized data coordinates,
+// of a legend of the provided size placed in a corner of the data canvas.
+func legendRect(da draw.Canvas, size vg.Point, top, left bool) vg.Rectangle {
+	var (
+		w = float64(size.X / (da.Max.X - da.Min.X))
+		h = float64(size.Y / (da.Max.Y - da.Min.Y))
+		r vg.Rectangle
+	)
+	switch {
+	case left:
+		r.Min.X, r.Max.X = 0, vg.Length(w)
+	default:
+		r.Min.X, r.Max.X = vg.Length(1-w), 1
+	}
+	switch {
+	case top:
+		r.Min.Y, r.Max.Y = vg.Length(1-h), 1
+	default:
+		r.Min.Y, r.Max.Y = 0, vg.Length(h)
+	}
+	return r
+}
+
+// occupancy returns the grid of cells of the data area occupied by the
+// plotters of the plot.
+func (p *Plot) occupancy() *occGrid {
+	occ := newOccGrid(32)
+	for _, v := range p.plotters {
+		p.occupy(occ, v)
+	}
+	return occ
+}
+
+// occupy marks the cells of the data area occupied by the provided plotter.
+func (p *Plot) occupy(occ *occGrid, v plot.Plotter) {
+	var (
+		nx = p.X.Norm
+		ny = p.Y.Norm
+	)
+	base := 0.0
+	if _, ok := p.Y.Scale.(plot.LogScale); ok || p.Y.Min > 0 {
+		base = p.Y.Min
+	}
+
+	switch v := v.(type) {
+	case *H1D:
+		hist := v.hist()
+		for _, bin := range hist.Binning.Bins {
+			switch {
+			case v.points():
+				if v.skip(bin) {
+					continue
+				}
+				lo, hi := v.yerr(bin)
+				occ.rect(nx(bin.XMid()), nx(bin.XMid()), ny(bin.SumW()-lo), ny(bin.SumW()+hi))
+			default:
+				if bin.SumW() == 0 {
+					continue
+				}
+				occ.rect(nx(bin.XMin()), nx(bin.XMax()), ny(base), ny(bin.SumW()))
+			}
+		}
+		return
+
+	case *HStack:
+		for _, h := range v.Signals {
+			p.occupy(occ, h)
+		}
+		if len(v.hs) == 0 {
+			return
+		}
+		if v.Stack == HStackOff {
+			for _, h := range v.hs {
+				p.occupy(occ, h)
+			}
+			return
+		}
+		bins := v.hs[0].hist().Binning.Bins
+		sumw := make([]float64, len(bins))
+		for _, h := range v.hs {
+			for i, bin := range h.hist().Binning.Bins {
+				sumw[i] += bin.SumW()
+			}
+		}
+		for i, bin := range bins {
+			occ.rect(nx(bin.XMin()), nx(bin.XMax()), ny(base), ny(sumw[i]))
+		}
+		return
+
+	case *S2D:
+		// error bars are handled with the glyph boxes of the plotter.
+		occ.points(p, v.Data)
+
+	case *ColorScatter:
+		occ.points(p, plotter.XYValues{XYZer: v.Data})
+		return
+
+	case *plotter.Scatter:
+		occ.points(p, v.XYs)
+		return
+
+	case *plotter.Line:
+		occ.lines(p, v.XYs)
+		return
+
+	case *Function:
+		xmin, xmax := v.XMin, v.XMax
+		if xmin == 0 && xmax == 0 {
+			xmin, xmax = p.X.Min, p.X.Max
+		}
+		const n = 100
+		xys := make(plotter.XYs, 0, n)
+		for i := 0; i < n; i++ {
+			x := xmin + float64(i)/(n-1)*(xmax-xmin)
+			xys = append(xys, plotter.XY{X: x, Y: v.F(x)})
+		}
+		occ.lines(p, xys)
+		return
+
+	case *HeatMap, *H2D:
+		xmin, xmax, ymin, ymax := v.(plot.DataRanger).DataRange()
+		occ.rect(nx(xmin), nx(xmax), ny(ymin), ny(ymax))
+		return
+	}
+
+	if gb, ok := v.(plot.GlyphBoxer); ok {
+		for _, box := range gb.GlyphBoxes(p.Plot) {
+			occ.point(box.X, box.Y)
+		}
+	}
+}
+
+// occGrid is a regular grid of cells over the normalized data area,
+// recording which cells are occupied by the plotted data.
+type occGrid struct {
+	n     int
+	cells []bool
+}
+
+func newOccGrid(n int) *occGrid {
+	return &occGrid{
+		n:     n,
+		cells: make([]bool, n*n),
+	}
+}
+
+// index returns the index of the cell containing the provided
+// normalized coordinate.
+// Coordinates outside of [0, 1] are clamped.
+func (occ *occGrid) index(v float64) int {
+	switch {
+	case math.IsNaN(v), v < 0:
+		return 0
+	case v >= 1:
+		return occ.n - 1
+	}
+	return int(v * float64(occ.n))
+}
+
+func (occ *occGrid) point(x, y float64) {
+	if !inUnit(x) || !inUnit(y) {
+		return
+	}
+	occ.cells[occ.index(y)*occ.n+occ.index(x)] = true
+}
+
+func (occ *occGrid) rect(x1, x2, y1, y2 float64) {
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	if x2 < 0 || x1 > 1 || y2 < 0 || y1 > 1 {
+		return
+	}
+	for iy := occ.index(y1); iy <= occ.index(y2); iy++ {
+		for ix := occ.index(x1); ix <= occ.index(x2); ix++ {
+			occ.cells[iy*occ.n+ix] = true
+		}
+	}
+}
+
+func (occ *occGrid) points(p *Plot, xys plotter.XYer) {
+	for i := 0; i < xys.Len(); i++ {
+		x, y := xys.XY(i)
+		occ.point(p.X.Norm(x), p.Y.Norm(y))
+	}
+}
+
+func (occ *occGrid) lines(p *Plot, xys plotter.XYer) {
+	for i := 1; i < xys.Len(); i++ {
+		var (
+			x1, y1 = xys.XY(i - 1)
+			x2, y2 = xys.XY(i)
+			nx1    = p.X.Norm(x1)
+			nx2    = p.X.Norm(x2)
+			ny1    = p.Y.Norm(y1)
+			ny2    = p.Y.Norm(y2)
+			d      = 2 * float64(occ.n) * math.Hypot(nx2-nx1, ny2-ny1)
+			n      = 4 * occ.n
+		)
+		if d < float64(n) {
+			// sample the segment at least twice per cell.
+			n = int(math.Ceil(d))
+		}
+		for j := 0; j <= n; j++ {
+			f := 1.0
+			if n > 0 {
+				f = float64(j) / float64(n)
+			}
+			occ.point(nx1+f*(nx2-nx1), ny1+f*(ny2-ny1))
+		}
+	}
+}
+
+// overlap returns the fraction of the cells covered by the provided
+// rectangle, in normalized data coordinates, that are occupied.
+func (occ *occGrid) overlap(r vg.Rectangle) float64 {
+	var (
+		ix1 = occ.index(float64(r.Min.X))
+		ix2 = occ.index(float64(r.Max.X))
+		iy1 = occ.index(float64(r.Min.Y))
+		iy2 = occ.index(float64(r.Max.Y))
+		n   = 0
+		tot = 0
+	)
+	for iy := iy1; iy <= iy2; iy++ {
+		for ix := ix1; ix <= ix2; ix++ {
+			tot++
+			if occ.cells[iy*occ.n+ix] {
+				n++
+			}
+		}
+	}
+	if tot == 0 {
+		return 0
+	}
+	return float64(n) / float64(tot)
+}
+
+func inUnit(v float64) bool {
+	return 0 <= v && v <= 1
+}
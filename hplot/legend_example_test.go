@@ -0,0 +1,66 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// An example of placing legends automatically, avoiding the plotted data.
+func ExampleLegendPlacement() {
+	rnd := rand.New(rand.NewSource(1234))
+
+	tp := hplot.NewTiledPlot(draw.Tiles{Cols: 3, Rows: 1})
+	for i, tc := range []struct {
+		title string
+		rand  func() float64
+		place hplot.LegendPlacement
+	}{
+		{
+			title: "falling",
+			rand:  distuv.Exponential{Rate: 0.5, Src: rnd}.Rand,
+			place: hplot.LegendAuto,
+		},
+		{
+			title: "rising",
+			rand:  func() float64 { return 10 - distuv.Exponential{Rate: 0.5, Src: rnd}.Rand() },
+			place: hplot.LegendAuto,
+		},
+		{
+			title: "flat",
+			rand:  distuv.Uniform{Min: 0, Max: 10, Src: rnd}.Rand,
+			place: hplot.LegendAutoOutside,
+		},
+	} {
+		hist := hbook.NewH1D(20, 0, 10)
+		for j := 0; j < 1000; j++ {
+			hist.Fill(tc.rand(), 1)
+		}
+
+		h := hplot.NewH1D(hist, hplot.WithDrawStyle(hplot.HDrawFilledStep))
+		h.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+
+		p := tp.Plot(i, 0)
+		p.Title.Text = tc.title
+		p.X.Label.Text = "X"
+		p.Add(h)
+		p.Legend.Add("data", h)
+		p.Legend.Add("1000 entries")
+		p.LegendPlacement = tc.place
+	}
+
+	err := tp.Save(24*vg.Centimeter, 8*vg.Centimeter, "testdata/legend_placement.png")
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+func TestLegendPlacement(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleLegendPlacement, t, "legend_placement.png")
+}
+
+func TestLegendPlacementRestore(t *testing.T) {
+	for _, place := range []hplot.LegendPlacement{
+		hplot.LegendManual,
+		hplot.LegendAuto,
+		hplot.LegendAutoOutside,
+	} {
+		for _, entries := range []bool{false, true} {
+			hist := hbook.NewH1D(10, 0, 10)
+			for i := 0; i < 10; i++ {
+				hist.Fill(float64(i)+0.5, 10)
+			}
+			h := hplot.NewH1D(hist)
+
+			p := hplot.New()
+			p.Add(h)
+			if entries {
+				p.Legend.Add("flat", h)
+			}
+			p.Legend.Top = true
+			p.Legend.XOffs = vg.Points(-5)
+			p.LegendPlacement = place
+
+			c := vgimg.New(10*vg.Centimeter, 10*vg.Centimeter)
+			p.Draw(draw.New(c))
+
+			if !p.Legend.Top || p.Legend.Left || p.Legend.XOffs != vg.Points(-5) {
+				t.Fatalf(
+					"placement=%d, entries=%v: legend not restored: top=%v, left=%v, xoffs=%v",
+					place, entries, p.Legend.Top, p.Legend.Left, p.Legend.XOffs,
+				)
+			}
+		}
+	}
+}
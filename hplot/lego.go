@@ -0,0 +1,674 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// LegoKind describes how a 2-dim histogram is rendered in 3D.
+type LegoKind byte
+
+const (
+	LegoBars    LegoKind = iota // one 3D bar per bin
+	LegoSurface                 // surface going through the centers of the bins
+)
+
+// Lego draws a 2-dim histogram in 3D, as a set of bars (a "lego" plot)
+// or as a surface, with a fixed axonometric projection.
+//
+// Lego implements the Drawer interface and can be saved with Save or used
+// within a Figure.
+type Lego struct {
+	// Hist is the histogramming data.
+	Hist *hbook.H2D
+
+	// Kind is the kind of 3D rendering (bars or surface).
+	Kind LegoKind
+
+	Title struct {
+		Text      string
+		Padding   vg.Length
+		TextStyle text.Style
+	}
+
+	// X, Y and Z are the axes of the 3D box.
+	// The X and Y ranges default to the range of the histogram.
+	// Bins that are not fully inside the X and Y ranges are not drawn.
+	// The Z range defaults to the range of the bin contents, including 0.
+	X, Y, Z LegoAxis
+
+	// Azimuth is the angle, in degrees, of rotation of the
+	// histogram around the Z axis.
+	Azimuth float64
+
+	// Elevation is the angle, in degrees, between the
+	// XY plane and the line of sight.
+	Elevation float64
+
+	// FillColor is the color of the bars or of the surface, when ColorMap
+	// is nil.
+	// The sides of the bars are shaded with darker versions of FillColor.
+	// If FillColor is nil, the bars and the surface are not filled.
+	FillColor color.Color
+
+	// ColorMap, if not nil, colors the bars or the surface
+	// according to their height.
+	ColorMap palette.ColorMap
+
+	// LineStyle is the style of the outline of the bars and
+	// of the mesh of the surface.
+	// Use zero width to disable.
+	LineStyle draw.LineStyle
+
+	// FrameStyle is the style of the back panes of the 3D box.
+	FrameStyle draw.LineStyle
+
+	// GridStyle is the style of the Z-grid drawn on the back panes.
+	// Use zero width to disable.
+	GridStyle draw.LineStyle
+}
+
+// LegoAxis is an axis of a 3D box.
+type LegoAxis struct {
+	// Min and Max are the range of the axis.
+	Min, Max float64
+
+	Label struct {
+		Text      string
+		TextStyle text.Style
+	}
+
+	Tick struct {
+		// Label is the text style of the tick labels.
+		Label text.Style
+
+		// Length is the length of a major tick mark.
+		Length vg.Length
+
+		// Marker returns the tick marks.
+		Marker plot.Ticker
+	}
+}
+
+// NewLego returns a new lego plot of the provided 2-dim histogram.
+//
+// The WithColorMap option colors the bars according to their height.
+func NewLego(h *hbook.H2D, opts ...Options) *Lego {
+	cfg := newConfig(opts)
+
+	lego := &Lego{
+		Hist:      h,
+		Kind:      LegoBars,
+		Azimuth:   30,
+		Elevation: 30,
+		FillColor: color.NRGBA{R: 0x56, G: 0xb4, B: 0xe9, A: 0xff},
+		ColorMap:  cfg.cmap,
+		LineStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(0.25),
+		},
+		FrameStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(0.5),
+		},
+		GridStyle: draw.LineStyle{
+			Color:  color.Gray{Y: 180},
+			Width:  vg.Points(0.25),
+			Dashes: []vg.Length{vg.Points(2), vg.Points(2)},
+		},
+	}
+
+	lego.Title.Padding = vg.Points(5)
+	lego.Title.TextStyle = text.Style{
+		Color:   color.Black,
+		Font:    DefaultStyle.Fonts.Title,
+		XAlign:  draw.XCenter,
+		YAlign:  draw.YTop,
+		Handler: DefaultStyle.TextHandler,
+	}
+
+	for _, axis := range []*LegoAxis{&lego.X, &lego.Y, &lego.Z} {
+		axis.Label.TextStyle = text.Style{
+			Color:   color.Black,
+			Font:    DefaultStyle.Fonts.Label,
+			Handler: DefaultStyle.TextHandler,
+		}
+		axis.Tick.Label = text.Style{
+			Color:   color.Black,
+			Font:    DefaultStyle.Fonts.Tick,
+			Handler: DefaultStyle.TextHandler,
+		}
+		axis.Tick.Length = vg.Points(4)
+		axis.Tick.Marker = Ticks{N: 5}
+	}
+	lego.Z.Label.TextStyle.Rotation = math.Pi / 2
+
+	lego.X.Min, lego.X.Max = h.XMin(), h.XMax()
+	lego.Y.Min, lego.Y.Max = h.YMin(), h.YMax()
+	lego.Z.Min, lego.Z.Max = 0, 0
+	for _, bin := range h.Binning.Bins {
+		v := bin.SumW()
+		lego.Z.Min = math.Min(lego.Z.Min, v)
+		lego.Z.Max = math.Max(lego.Z.Max, v)
+	}
+	if lego.Z.Min == lego.Z.Max {
+		lego.Z.Max = lego.Z.Min + 1
+	}
+
+	return lego
+}
+
+// NewSurface returns a new surface plot of the provided 2-dim histogram.
+//
+// The WithColorMap option colors the surface according to its height.
+func NewSurface(h *hbook.H2D, opts ...Options) *Lego {
+	lego := NewLego(h, opts...)
+	lego.Kind = LegoSurface
+	return lego
+}
+
+// Draw draws the 3D plot on the provided canvas,
+// implementing the Drawer interface.
+func (lego *Lego) Draw(c draw.Canvas) {
+	if lego.Title.Text != "" {
+		c.FillText(lego.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, lego.Title.Text)
+		c.Max.Y -= lego.Title.TextStyle.Height(lego.Title.Text) + lego.Title.Padding
+	}
+
+	proj := lego.projection(c)
+
+	lego.drawPanes(c, &proj)
+	switch lego.Kind {
+	case LegoSurface:
+		lego.drawSurface(c, &proj)
+	default:
+		lego.drawBars(c, &proj)
+	}
+	lego.drawAxes(c, &proj)
+}
+
+// legoProj projects the 3D box of a lego plot on a canvas.
+type legoProj struct {
+	lego *Lego
+
+	ca, sa float64 // cos and sin of the azimuth
+	ce, se float64 // cos and sin of the elevation
+
+	// screen transformation.
+	x0, y0 vg.Length
+	sx, sy float64
+}
+
+// unit returns the coordinates in the unit box of the provided point.
+func (p *legoProj) unit(x, y, z float64) (u, v, w float64) {
+	var (
+		lg = p.lego
+		zz = math.Max(lg.Z.Min, math.Min(lg.Z.Max, z))
+	)
+	u = (x-lg.X.Min)/(lg.X.Max-lg.X.Min) - 0.5
+	v = (y-lg.Y.Min)/(lg.Y.Max-lg.Y.Min) - 0.5
+	w = (zz - lg.Z.Min) / (lg.Z.Max - lg.Z.Min)
+	return u, v, w
+}
+
+// view returns the (unscaled) screen coordinates and the depth of the
+// provided point of the unit box.
+// Points with a larger depth are farther from the viewer.
+func (p *legoProj) view(u, v, w float64) (x, y, depth float64) {
+	var (
+		xr = u*p.ca + v*p.sa
+		yr = -u*p.sa + v*p.ca
+	)
+	x = xr
+	y = w*p.ce + yr*p.se
+	depth = yr*p.ce - w*p.se
+	return x, y, depth
+}
+
+// pt returns the canvas point of the provided data point.
+func (p *legoProj) pt(x, y, z float64) vg.Point {
+	sx, sy, _ := p.view(p.unit(x, y, z))
+	return vg.Point{
+		X: p.x0 + vg.Length(sx*p.sx),
+		Y: p.y0 + vg.Length(sy*p.sy),
+	}
+}
+
+// depth returns the depth of the provided data point.
+func (p *legoProj) depth(x, y, z float64) float64 {
+	_, _, d := p.view(p.unit(x, y, z))
+	return d
+}
+
+// projection returns the projection of the 3D box into the canvas,
+// leaving enough space around the box for the axes.
+func (lego *Lego) projection(c draw.Canvas) legoProj {
+	var (
+		azi = lego.Azimuth * math.Pi / 180
+		ele = lego.Elevation * math.Pi / 180
+	)
+	proj := legoProj{
+		lego: lego,
+		ca:   math.Cos(azi),
+		sa:   math.Sin(azi),
+		ce:   math.Cos(ele),
+		se:   math.Sin(ele),
+	}
+
+	xmin, xmax := math.Inf(+1), math.Inf(-1)
+	ymin, ymax := math.Inf(+1), math.Inf(-1)
+	for _, u := range []float64{-0.5, 0.5} {
+		for _, v := range []float64{-0.5, 0.5} {
+			for _, w := range []float64{0, 1} {
+				x, y, _ := proj.view(u, v, w)
+				xmin = math.Min(xmin, x)
+				xmax = math.Max(xmax, x)
+				ymin = math.Min(ymin, y)
+				ymax = math.Max(ymax, y)
+			}
+		}
+	}
+
+	var (
+		left   = lego.zAxisWidth()
+		right  = lego.axisExtent(&lego.Y)
+		bottom = math.Max(float64(lego.axisExtent(&lego.X)), float64(lego.axisExtent(&lego.Y)))
+		top    = lego.Z.Tick.Label.Height("0") / 2
+	)
+	r := vg.Rectangle{
+		Min: vg.Point{X: c.Min.X + left, Y: c.Min.Y + vg.Length(bottom)},
+		Max: vg.Point{X: c.Max.X - right, Y: c.Max.Y - top},
+	}
+
+	proj.sx = float64(r.Max.X-r.Min.X) / (xmax - xmin)
+	proj.sy = float64(r.Max.Y-r.Min.Y) / (ymax - ymin)
+	proj.x0 = r.Min.X - vg.Length(xmin*proj.sx)
+	proj.y0 = r.Min.Y - vg.Length(ymin*proj.sy)
+	return proj
+}
+
+// axisExtent returns the space taken by the tick labels
+// and the label of a horizontal axis.
+func (lego *Lego) axisExtent(axis *LegoAxis) vg.Length {
+	var (
+		pad = axis.Tick.Label.Font.Size / 2
+		ext = axis.Tick.Length + pad + axis.Tick.Label.Height("0")
+	)
+	var wmax vg.Length
+	for _, tck := range axis.Tick.Marker.Ticks(axis.Min, axis.Max) {
+		if tck.IsMinor() {
+			continue
+		}
+		wmax = max(wmax, axis.Tick.Label.Width(tck.Label))
+	}
+	ext = max(ext, axis.Tick.Length+pad+wmax/2)
+	if axis.Label.Text != "" {
+		ext += pad + axis.Label.TextStyle.Height(axis.Label.Text)
+	}
+	return ext
+}
+
+// zAxisWidth returns the space taken by the tick labels
+// and the label of the Z axis.
+func (lego *Lego) zAxisWidth() vg.Length {
+	var (
+		axis = &lego.Z
+		pad  = axis.Tick.Label.Font.Size / 2
+		wmax vg.Length
+	)
+	for _, tck := range axis.Tick.Marker.Ticks(axis.Min, axis.Max) {
+		if tck.IsMinor() {
+			continue
+		}
+		wmax = max(wmax, axis.Tick.Label.Width(tck.Label))
+	}
+	width := axis.Tick.Length + pad + wmax
+	if axis.Label.Text != "" {
+		width += pad + axis.Label.TextStyle.Height(axis.Label.Text)
+	}
+	return width
+}
+
+// legoEdge is an edge of the 3D box.
+type legoEdge struct {
+	beg, end [3]float64 // data coordinates
+}
+
+// farCorner returns the data coordinates of the corner of
+// the floor of the 3D box the farthest from the viewer.
+func (lego *Lego) farCorner(p *legoProj) (x, y float64) {
+	depth := math.Inf(-1)
+	for _, xx := range []float64{lego.X.Min, lego.X.Max} {
+		for _, yy := range []float64{lego.Y.Min, lego.Y.Max} {
+			if d := p.depth(xx, yy, lego.Z.Min); d > depth {
+				depth = d
+				x, y = xx, yy
+			}
+		}
+	}
+	return x, y
+}
+
+// drawPanes draws the floor and the back walls of the 3D box.
+func (lego *Lego) drawPanes(c draw.Canvas, p *legoProj) {
+	var (
+		x0, y0 = lego.farCorner(p)
+		x1     = lego.X.Min + lego.X.Max - x0
+		y1     = lego.Y.Min + lego.Y.Max - y0
+		zmin   = lego.Z.Min
+		zmax   = lego.Z.Max
+	)
+
+	if lego.GridStyle.Width != 0 {
+		for _, tck := range lego.Z.Tick.Marker.Ticks(zmin, zmax) {
+			if tck.IsMinor() || tck.Value <= zmin || tck.Value >= zmax {
+				continue
+			}
+			z := tck.Value
+			c.StrokeLines(lego.GridStyle, []vg.Point{
+				p.pt(x1, y0, z), p.pt(x0, y0, z), p.pt(x0, y1, z),
+			})
+		}
+	}
+
+	sty := lego.FrameStyle
+	// floor.
+	c.StrokeLines(sty, []vg.Point{
+		p.pt(x0, y0, zmin), p.pt(x1, y0, zmin),
+		p.pt(x1, y1, zmin), p.pt(x0, y1, zmin),
+		p.pt(x0, y0, zmin),
+	})
+	// back walls.
+	c.StrokeLines(sty, []vg.Point{
+		p.pt(x1, y0, zmin), p.pt(x1, y0, zmax),
+		p.pt(x0, y0, zmax), p.pt(x0, y1, zmax),
+		p.pt(x0, y1, zmin),
+	})
+	c.StrokeLine2(sty, p.pt(x0, y0, zmin).X, p.pt(x0, y0, zmin).Y, p.pt(x0, y0, zmax).X, p.pt(x0, y0, zmax).Y)
+}
+
+// color returns the color of a bar or of a surface patch of height z.
+func (lego *Lego) color(z float64) color.Color {
+	if lego.ColorMap == nil {
+		return lego.FillColor
+	}
+	cmap := lego.ColorMap
+	cmap.SetMin(lego.Z.Min)
+	cmap.SetMax(lego.Z.Max)
+	col, err := cmap.At(math.Max(lego.Z.Min, math.Min(lego.Z.Max, z)))
+	if err != nil {
+		return lego.FillColor
+	}
+	return col
+}
+
+// inRange returns whether the provided bin is fully inside
+// the X and Y ranges of the plot.
+func (lego *Lego) inRange(bin hbook.Bin2D) bool {
+	return bin.XMin() >= lego.X.Min && bin.XMax() <= lego.X.Max &&
+		bin.YMin() >= lego.Y.Min && bin.YMax() <= lego.Y.Max
+}
+
+// drawBars draws a 3D bar for each non-empty bin of the histogram,
+// from the farthest to the nearest one.
+func (lego *Lego) drawBars(c draw.Canvas, p *legoProj) {
+	type bar struct {
+		bin   hbook.Bin2D
+		depth float64
+	}
+	var (
+		bins = lego.Hist.Binning.Bins
+		bars = make([]bar, 0, len(bins))
+	)
+	for _, bin := range bins {
+		if bin.SumW() == 0 || !lego.inRange(bin) {
+			continue
+		}
+		x, y := bin.XMid(), bin.YMid()
+		bars = append(bars, bar{bin: bin, depth: p.depth(x, y, lego.Z.Min)})
+	}
+	sort.SliceStable(bars, func(i, j int) bool {
+		return bars[i].depth > bars[j].depth
+	})
+
+	z0 := math.Max(lego.Z.Min, math.Min(lego.Z.Max, 0))
+	for _, bar := range bars {
+		var (
+			bin    = bar.bin
+			x0, x1 = bin.XMin(), bin.XMax()
+			y0, y1 = bin.YMin(), bin.YMax()
+			zlo    = math.Min(z0, bin.SumW())
+			zhi    = math.Max(z0, bin.SumW())
+			col    = lego.color(bin.SumW())
+		)
+		faces := []struct {
+			normal [3]float64
+			pts    [][3]float64
+			shade  float64
+		}{
+			{[3]float64{-1, 0, 0}, [][3]float64{{x0, y0, zlo}, {x0, y1, zlo}, {x0, y1, zhi}, {x0, y0, zhi}}, 0.75},
+			{[3]float64{+1, 0, 0}, [][3]float64{{x1, y0, zlo}, {x1, y1, zlo}, {x1, y1, zhi}, {x1, y0, zhi}}, 0.75},
+			{[3]float64{0, -1, 0}, [][3]float64{{x0, y0, zlo}, {x1, y0, zlo}, {x1, y0, zhi}, {x0, y0, zhi}}, 0.55},
+			{[3]float64{0, +1, 0}, [][3]float64{{x0, y1, zlo}, {x1, y1, zlo}, {x1, y1, zhi}, {x0, y1, zhi}}, 0.55},
+			{[3]float64{0, 0, +1}, [][3]float64{{x0, y0, zhi}, {x1, y0, zhi}, {x1, y1, zhi}, {x0, y1, zhi}}, 1},
+		}
+		for _, face := range faces {
+			// only draw the faces facing the viewer.
+			_, _, d := p.view(face.normal[0], face.normal[1], face.normal[2])
+			if d >= 0 {
+				continue
+			}
+			pts := make([]vg.Point, len(face.pts), len(face.pts)+1)
+			for i, pt := range face.pts {
+				pts[i] = p.pt(pt[0], pt[1], pt[2])
+			}
+			if col != nil {
+				c.FillPolygon(shade(col, face.shade), pts)
+			}
+			if lego.LineStyle.Width != 0 {
+				c.StrokeLines(lego.LineStyle, append(pts, pts[0]))
+			}
+		}
+	}
+}
+
+// drawSurface draws the surface going through the centers of the bins,
+// from the farthest to the nearest patch.
+func (lego *Lego) drawSurface(c draw.Canvas, p *legoProj) {
+	var (
+		h      = lego.Hist
+		nx, ny = h.Binning.Nx, h.Binning.Ny
+		bins   = h.Binning.Bins
+	)
+	type patch struct {
+		pts   [4][3]float64
+		z     float64
+		depth float64
+	}
+	patches := make([]patch, 0, (nx-1)*(ny-1))
+	for iy := 0; iy < ny-1; iy++ {
+		for ix := 0; ix < nx-1; ix++ {
+			var (
+				b00 = bins[iy*nx+ix]
+				b10 = bins[iy*nx+ix+1]
+				b11 = bins[(iy+1)*nx+ix+1]
+				b01 = bins[(iy+1)*nx+ix]
+			)
+			if !lego.inRange(b00) || !lego.inRange(b11) {
+				continue
+			}
+			var pa patch
+			for i, bin := range []hbook.Bin2D{b00, b10, b11, b01} {
+				pa.pts[i] = [3]float64{bin.XMid(), bin.YMid(), bin.SumW()}
+				pa.z += 0.25 * bin.SumW()
+			}
+			x := 0.5 * (b00.XMid() + b11.XMid())
+			y := 0.5 * (b00.YMid() + b11.YMid())
+			pa.depth = p.depth(x, y, pa.z)
+			patches = append(patches, pa)
+		}
+	}
+	sort.SliceStable(patches, func(i, j int) bool {
+		return patches[i].depth > patches[j].depth
+	})
+
+	for _, pa := range patches {
+		pts := make([]vg.Point, 4, 5)
+		for i, pt := range pa.pts {
+			pts[i] = p.pt(pt[0], pt[1], pt[2])
+		}
+		if col := lego.color(pa.z); col != nil {
+			c.FillPolygon(col, pts)
+		}
+		if lego.LineStyle.Width != 0 {
+			c.StrokeLines(lego.LineStyle, append(pts, pts[0]))
+		}
+	}
+}
+
+// drawAxes draws the X and Y axes along the nearest edges of the floor of
+// the 3D box, and the Z axis along its leftmost vertical edge.
+func (lego *Lego) drawAxes(c draw.Canvas, p *legoProj) {
+	var (
+		x0, y0 = lego.farCorner(p)
+		x1     = lego.X.Min + lego.X.Max - x0
+		y1     = lego.Y.Min + lego.Y.Max - y0
+		zmin   = lego.Z.Min
+		ctr    = p.pt(0.5*(lego.X.Min+lego.X.Max), 0.5*(lego.Y.Min+lego.Y.Max), zmin)
+	)
+
+	xaxis := legoEdge{beg: [3]float64{lego.X.Min, y1, zmin}, end: [3]float64{lego.X.Max, y1, zmin}}
+	yaxis := legoEdge{beg: [3]float64{x1, lego.Y.Min, zmin}, end: [3]float64{x1, lego.Y.Max, zmin}}
+	lego.drawAxis(c, p, &lego.X, xaxis, 0, ctr)
+	lego.drawAxis(c, p, &lego.Y, yaxis, 1, ctr)
+
+	// the Z axis is drawn on the leftmost vertical edge.
+	var (
+		xz, yz = x0, y0
+		left   = vg.Length(math.Inf(+1))
+	)
+	for _, xx := range []float64{lego.X.Min, lego.X.Max} {
+		for _, yy := range []float64{lego.Y.Min, lego.Y.Max} {
+			if x := p.pt(xx, yy, zmin).X; x < left {
+				left = x
+				xz, yz = xx, yy
+			}
+		}
+	}
+	zaxis := legoEdge{beg: [3]float64{xz, yz, zmin}, end: [3]float64{xz, yz, lego.Z.Max}}
+	lego.drawAxis(c, p, &lego.Z, zaxis, 2, ctr)
+}
+
+// drawAxis draws the provided axis along the edge, with tick marks and
+// tick labels pointing away from the ref point (for the X and Y axes)
+// or to the left (for the Z axis).
+func (lego *Lego) drawAxis(c draw.Canvas, p *legoProj, axis *LegoAxis, edge legoEdge, dim int, ref vg.Point) {
+	var (
+		beg = p.pt(edge.beg[0], edge.beg[1], edge.beg[2])
+		end = p.pt(edge.end[0], edge.end[1], edge.end[2])
+		mid = vg.Point{X: 0.5 * (beg.X + end.X), Y: 0.5 * (beg.Y + end.Y)}
+		pad = axis.Tick.Label.Font.Size / 2
+
+		dx, dy = -1.0, 0.0 // direction of the ticks
+	)
+	if dim != 2 {
+		dx = float64(mid.X - ref.X)
+		dy = float64(mid.Y - ref.Y)
+		n := math.Hypot(dx, dy)
+		dx /= n
+		dy /= n
+	}
+	dir := func(l vg.Length) vg.Point {
+		return vg.Point{X: vg.Length(dx) * l, Y: vg.Length(dy) * l}
+	}
+
+	c.StrokeLine2(lego.FrameStyle, beg.X, beg.Y, end.X, end.Y)
+
+	tsty := axis.Tick.Label
+	tsty.XAlign, tsty.YAlign = legoAlign(dx, dy)
+	var ext vg.Length
+	for _, tck := range axis.Tick.Marker.Ticks(axis.Min, axis.Max) {
+		if tck.Value < axis.Min || tck.Value > axis.Max {
+			continue
+		}
+		pos := edge.beg
+		pos[dim] = tck.Value
+		pt := p.pt(pos[0], pos[1], pos[2])
+		l := axis.Tick.Length
+		if tck.IsMinor() {
+			l /= 2
+		}
+		tip := pt.Add(dir(l))
+		c.StrokeLine2(lego.FrameStyle, pt.X, pt.Y, tip.X, tip.Y)
+		if tck.IsMinor() {
+			continue
+		}
+		c.FillText(tsty, pt.Add(dir(axis.Tick.Length+pad)), tck.Label)
+		r := tsty.Rectangle(tck.Label)
+		ext = max(ext, vg.Length(math.Abs(dx))*r.Size().X+vg.Length(math.Abs(dy))*r.Size().Y)
+	}
+
+	if axis.Label.Text == "" {
+		return
+	}
+	lsty := axis.Label.TextStyle
+	pos := mid.Add(dir(axis.Tick.Length + 2*pad + ext))
+	switch dim {
+	case 2:
+		lsty.XAlign = draw.XCenter
+		lsty.YAlign = draw.YBottom
+	default:
+		lsty.XAlign, lsty.YAlign = legoAlign(dx, dy)
+	}
+	c.FillText(lsty, pos, axis.Label.Text)
+}
+
+// legoAlign returns the alignment of a text placed
+// in the (dx,dy) direction from its anchor.
+func legoAlign(dx, dy float64) (text.XAlignment, text.YAlignment) {
+	xa := draw.XCenter
+	switch {
+	case dx > 0.25:
+		xa = draw.XLeft
+	case dx < -0.25:
+		xa = draw.XRight
+	}
+	ya := draw.YCenter
+	switch {
+	case dy > 0.25:
+		ya = draw.YBottom
+	case dy < -0.25:
+		ya = draw.YTop
+	}
+	return xa, ya
+}
+
+// shade returns a darker version of the provided color.
+func shade(c color.Color, f float64) color.Color {
+	if f == 1 {
+		return c
+	}
+	r, g, b, a := c.RGBA()
+	return color.RGBA64{
+		R: uint16(f * float64(r)),
+		G: uint16(f * float64(g)),
+		B: uint16(f * float64(b)),
+		A: uint16(a),
+	}
+}
+
+var (
+	_ Drawer = (*Lego)(nil)
+)
@@ -0,0 +1,80 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distmv"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+)
+
+// newPeakH2D returns a 2-dim histogram with a resonance
+// peak on top of a flat background.
+func newPeakH2D() *hbook.H2D {
+	const (
+		nsig = 5000
+		nbkg = 20000
+	)
+
+	src := rand.New(rand.NewSource(1234))
+	sig, ok := distmv.NewNormal(
+		[]float64{1, 0.5},
+		mat.NewSymDense(2, []float64{0.25, 0.1, 0.1, 0.25}),
+		src,
+	)
+	if !ok {
+		log.Fatalf("error creating distmv.Normal")
+	}
+	bkg := distuv.Uniform{Min: -4, Max: 4, Src: src}
+
+	h := hbook.NewH2D(20, -4, 4, 20, -4, 4)
+	v := make([]float64, 2)
+	for i := 0; i < nsig; i++ {
+		v = sig.Rand(v)
+		h.Fill(v[0], v[1], 1)
+	}
+	for i := 0; i < nbkg; i++ {
+		h.Fill(bkg.Rand(), bkg.Rand(), 1)
+	}
+	return h
+}
+
+func ExampleLego() {
+	h := newPeakH2D()
+
+	lego := hplot.NewLego(h)
+	lego.Title.Text = "Lego plot"
+	lego.X.Label.Text = "x"
+	lego.Y.Label.Text = "y"
+	lego.Z.Label.Text = "Entries"
+
+	err := hplot.Save(lego, 15*vg.Centimeter, 12*vg.Centimeter, "testdata/lego_bars.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
+
+func ExampleNewSurface() {
+	h := newPeakH2D()
+
+	surf := hplot.NewSurface(h, hplot.WithColorMap(hplot.Bird()))
+	surf.Title.Text = "Surface plot"
+	surf.X.Label.Text = "x"
+	surf.Y.Label.Text = "y"
+	surf.Z.Label.Text = "Entries"
+	surf.Azimuth = 40
+	surf.Elevation = 35
+
+	err := hplot.Save(surf, 15*vg.Centimeter, 12*vg.Centimeter, "testdata/lego_surface.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestLego(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleLego, t, "lego_bars.png")
+	checkPlot(cmpimg.CheckPlot)(ExampleNewSurface, t, "lego_surface.png")
+}
+
+func TestLegoRange(t *testing.T) {
+	h := hbook.NewH2D(2, 0, 2, 2, -1, 1)
+	h.Fill(0.5, -0.5, 3)
+	h.Fill(1.5, 0.5, -1)
+
+	lego := hplot.NewLego(h)
+	for _, tc := range []struct {
+		name     string
+		min, max float64
+		want     [2]float64
+	}{
+		{"x", lego.X.Min, lego.X.Max, [2]float64{0, 2}},
+		{"y", lego.Y.Min, lego.Y.Max, [2]float64{-1, 1}},
+		{"z", lego.Z.Min, lego.Z.Max, [2]float64{-1, 3}},
+	} {
+		if got := [2]float64{tc.min, tc.max}; got != tc.want {
+			t.Fatalf("invalid %s range: got=%v, want=%v", tc.name, got, tc.want)
+		}
+	}
+
+	// empty histograms are drawn with a default Z range.
+	for _, lego := range []*hplot.Lego{
+		hplot.NewLego(hbook.NewH2D(2, 0, 2, 2, 0, 2)),
+		hplot.NewSurface(hbook.NewH2D(2, 0, 2, 2, 0, 2)),
+	} {
+		if got, want := [2]float64{lego.Z.Min, lego.Z.Max}, [2]float64{0, 1}; got != want {
+			t.Fatalf("invalid z range: got=%v, want=%v", got, want)
+		}
+		_, err := hplot.WriterTo(lego, -1, -1, "png")
+		if err != nil {
+			t.Fatalf("could not draw empty histogram: %+v", err)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Metadata describes the provenance of a figure.
+//
+// When saving a figure with metadata, the metadata is embedded in the
+// Info dictionary and the XMP metadata stream of PDF files, and in
+// the iTXt text chunks of PNG files.
+// Other formats are saved without metadata.
+type Metadata struct {
+	Title     string // title of the figure
+	Creator   string // creator of the figure (person or program)
+	Dataset   string // dataset the figure was made from
+	Commit    string // version control commit of the code that made the figure
+	Selection string // selection applied to the data
+
+	// Extra holds additional key/value pairs.
+	Extra map[string]string
+}
+
+// metaEntry is a key/value pair of a figure metadata.
+type metaEntry struct {
+	key   string
+	value string
+}
+
+// entries returns the non-empty key/value pairs of the metadata,
+// with the extra keys sorted in lexicographical order.
+func (md Metadata) entries() []metaEntry {
+	var kvs []metaEntry
+	for _, kv := range []metaEntry{
+		{"Title", md.Title},
+		{"Creator", md.Creator},
+		{"Dataset", md.Dataset},
+		{"Commit", md.Commit},
+		{"Selection", md.Selection},
+	} {
+		if kv.value != "" {
+			kvs = append(kvs, kv)
+		}
+	}
+
+	keys := make([]string, 0, len(md.Extra))
+	for k := range md.Extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == "" || md.Extra[k] == "" {
+			continue
+		}
+		kvs = append(kvs, metaEntry{k, md.Extra[k]})
+	}
+	return kvs
+}
+
+// metaWriter is an io.WriterTo embedding metadata in the output of
+// another io.WriterTo.
+type metaWriter struct {
+	wt     io.WriterTo
+	format string
+	meta   []metaEntry
+}
+
+// withMetadata returns an io.WriterTo embedding the provided metadata in
+// the output of wt, for the formats supporting metadata.
+func withMetadata(wt io.WriterTo, format string, md Metadata) io.WriterTo {
+	meta := md.entries()
+	if len(meta) == 0 {
+		return wt
+	}
+	switch format {
+	case "pdf", "png":
+		return &metaWriter{wt: wt, format: format, meta: meta}
+	}
+	return wt
+}
+
+func (mw *metaWriter) WriteTo(w io.Writer) (int64, error) {
+	buf := new(bytes.Buffer)
+	_, err := mw.wt.WriteTo(buf)
+	if err != nil {
+		return 0, err
+	}
+
+	var raw []byte
+	switch mw.format {
+	case "pdf":
+		raw, err = pdfMetadata(buf.Bytes(), mw.meta)
+	case "png":
+		raw, err = pngMetadata(buf.Bytes(), mw.meta)
+	default:
+		panic(fmt.Errorf("hplot: invalid metadata format %q", mw.format))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("hplot: could not embed metadata: %w", err)
+	}
+
+	n, err := w.Write(raw)
+	return int64(n), err
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadata inserts the provided metadata, as iTXt chunks, right after
+// the IHDR chunk of the provided PNG image.
+func pngMetadata(raw []byte, meta []metaEntry) ([]byte, error) {
+	const ihdr = 8 + 4 + 4 + 13 + 4 // signature + IHDR chunk.
+	if len(raw) < ihdr || !bytes.Equal(raw[:8], pngSignature) || string(raw[12:16]) != "IHDR" {
+		return nil, fmt.Errorf("invalid PNG image")
+	}
+
+	out := new(bytes.Buffer)
+	out.Grow(len(raw) + 64*len(meta))
+	out.Write(raw[:ihdr])
+	for _, kv := range meta {
+		if len(kv.key) > 79 {
+			return nil, fmt.Errorf("PNG metadata key %q too long", kv.key)
+		}
+		// uncompressed iTXt chunk, with no language tag
+		// nor translated keyword.
+		data := make([]byte, 0, len(kv.key)+len(kv.value)+5)
+		data = append(data, kv.key...)
+		data = append(data, 0, 0, 0, 0, 0)
+		data = append(data, kv.value...)
+		writePNGChunk(out, "iTXt", data)
+	}
+	out.Write(raw[ihdr:])
+	return out.Bytes(), nil
+}
+
+func writePNGChunk(w *bytes.Buffer, typ string, data []byte) {
+	var hdr [8]byte
+	binary.BigEndian.PutUint32(hdr[:4], uint32(len(data)))
+	copy(hdr[4:], typ)
+	w.Write(hdr[:])
+	w.Write(data)
+
+	crc := crc32.NewIEEE()
+	crc.Write(hdr[4:])
+	crc.Write(data)
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	w.Write(sum[:])
+}
+
+var (
+	pdfTrailer   = regexp.MustCompile(`(?s)trailer\s*<<(.*?)>>\s*startxref\s+(\d+)\s+%%EOF\s*$`)
+	pdfTrailerKV = regexp.MustCompile(`/(Size|Root|Info)\s+(\d+)`)
+)
+
+// pdfMetadata appends an incremental update to the provided PDF document,
+// with a new Info dictionary and a XMP metadata stream holding the
+// provided metadata.
+func pdfMetadata(raw []byte, meta []metaEntry) ([]byte, error) {
+	m := pdfTrailer.FindSubmatch(raw)
+	if m == nil {
+		return nil, fmt.Errorf("could not find PDF trailer")
+	}
+	prev := string(m[2])
+
+	var size, root, info int
+	for _, kv := range pdfTrailerKV.FindAllSubmatch(m[1], -1) {
+		v, err := strconv.Atoi(string(kv[2]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PDF trailer: %w", err)
+		}
+		switch string(kv[1]) {
+		case "Size":
+			size = v
+		case "Root":
+			root = v
+		case "Info":
+			info = v
+		}
+	}
+	if size == 0 || root == 0 {
+		return nil, fmt.Errorf("invalid PDF trailer")
+	}
+
+	catalog, err := pdfDict(raw, root)
+	if err != nil {
+		return nil, fmt.Errorf("could not find PDF catalog: %w", err)
+	}
+	var infos string
+	if info != 0 {
+		infos, err = pdfDict(raw, info)
+		if err != nil {
+			return nil, fmt.Errorf("could not find PDF info: %w", err)
+		}
+	}
+
+	var (
+		out  = bytes.NewBuffer(raw[:len(raw):len(raw)])
+		offs = make(map[int]int)
+		xmp  = xmpPacket(meta)
+
+		infoID = size
+		xmpID  = size + 1
+	)
+	if !bytes.HasSuffix(raw, []byte("\n")) {
+		out.WriteString("\n")
+	}
+
+	offs[infoID] = out.Len()
+	fmt.Fprintf(out, "%d 0 obj\n<<%s", infoID, strings.TrimRight(infos, "\n"))
+	for _, kv := range meta {
+		fmt.Fprintf(out, "\n/%s %s", pdfName(kv.key), pdfString(kv.value))
+	}
+	out.WriteString("\n>>\nendobj\n")
+
+	offs[xmpID] = out.Len()
+	fmt.Fprintf(out, "%d 0 obj\n<</Type /Metadata /Subtype /XML /Length %d>>\nstream\n", xmpID, len(xmp))
+	out.WriteString(xmp)
+	out.WriteString("\nendstream\nendobj\n")
+
+	offs[root] = out.Len()
+	fmt.Fprintf(out, "%d 0 obj\n<<\n/Metadata %d 0 R%s>>\nendobj\n", root, xmpID, catalog)
+
+	xref := out.Len()
+	fmt.Fprintf(out, "xref\n0 1\n0000000000 65535 f \n%d 1\n%010d 00000 n \n", root, offs[root])
+	fmt.Fprintf(out, "%d 2\n%010d 00000 n \n%010d 00000 n \n", infoID, offs[infoID], offs[xmpID])
+	fmt.Fprintf(out,
+		"trailer\n<<\n/Size %d\n/Root %d 0 R\n/Info %d 0 R\n/Prev %s\n>>\nstartxref\n%d\n%%%%EOF\n",
+		xmpID+1, root, infoID, prev, xref,
+	)
+	return out.Bytes(), nil
+}
+
+// pdfDict returns the content of the dictionary of the provided PDF object,
+// without its enclosing angle brackets.
+func pdfDict(raw []byte, id int) (string, error) {
+	obj := []byte(fmt.Sprintf("\n%d 0 obj", id))
+	beg := bytes.LastIndex(raw, obj)
+	if beg < 0 {
+		return "", fmt.Errorf("could not find object %d", id)
+	}
+	raw = raw[beg+len(obj):]
+	end := bytes.Index(raw, []byte("endobj"))
+	if end < 0 {
+		return "", fmt.Errorf("could not find end of object %d", id)
+	}
+	raw = bytes.TrimSpace(raw[:end])
+	if !bytes.HasPrefix(raw, []byte("<<")) || !bytes.HasSuffix(raw, []byte(">>")) {
+		return "", fmt.Errorf("object %d is not a dictionary", id)
+	}
+	return string(raw[2 : len(raw)-2]), nil
+}
+
+// pdfName returns the provided string as a PDF name, without its
+// leading solidus.
+func pdfName(s string) string {
+	var o strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case c < '!' || c > '~' || strings.IndexByte("()<>[]{}/%#", c) >= 0:
+			fmt.Fprintf(&o, "#%02X", c)
+		default:
+			o.WriteByte(c)
+		}
+	}
+	return o.String()
+}
+
+// pdfString returns the provided text as a PDF text string: a literal
+// string for ASCII text and an UTF-16BE hexadecimal string otherwise.
+func pdfString(s string) string {
+	ascii := true
+	for _, c := range s {
+		if c < ' ' || c > '~' {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+		return "(" + r.Replace(s) + ")"
+	}
+
+	var o strings.Builder
+	o.WriteString("<FEFF")
+	for _, v := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&o, "%04X", v)
+	}
+	o.WriteString(">")
+	return o.String()
+}
+
+// xmpPacket returns a XMP packet holding the provided metadata.
+func xmpPacket(meta []metaEntry) string {
+	esc := func(s string) string {
+		var o strings.Builder
+		_ = xml.EscapeText(&o, []byte(s))
+		return o.String()
+	}
+
+	var o strings.Builder
+	o.WriteString("<?xpacket begin=\"\uFEFF\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	o.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	o.WriteString("<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	o.WriteString("<rdf:Description rdf:about=\"\"" +
+		" xmlns:dc=\"http://purl.org/dc/elements/1.1/\"" +
+		" xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\"" +
+		" xmlns:hplot=\"http://go-hep.org/x/hep/hplot/xmp/1.0/\">\n",
+	)
+	var extra []metaEntry
+	for _, kv := range meta {
+		v := esc(kv.value)
+		switch kv.key {
+		case "Title":
+			fmt.Fprintf(&o, "<dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", v)
+		case "Creator":
+			fmt.Fprintf(&o, "<xmp:CreatorTool>%s</xmp:CreatorTool>\n", v)
+		case "Dataset", "Commit", "Selection":
+			fmt.Fprintf(&o, "<hplot:%[1]s>%[2]s</hplot:%[1]s>\n", kv.key, v)
+		default:
+			extra = append(extra, kv)
+		}
+	}
+	if len(extra) > 0 {
+		o.WriteString("<hplot:Extra><rdf:Bag>\n")
+		for _, kv := range extra {
+			fmt.Fprintf(&o, "<rdf:li>%s=%s</rdf:li>\n", esc(kv.key), esc(kv.value))
+		}
+		o.WriteString("</rdf:Bag></hplot:Extra>\n")
+	}
+	o.WriteString("</rdf:Description>\n</rdf:RDF>\n</x:xmpmeta>\n")
+	o.WriteString("<?xpacket end=\"r\"?>")
+	return o.String()
+}
@@ -0,0 +1,201 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot/vg"
+)
+
+func TestMetadataEntries(t *testing.T) {
+	md := Metadata{
+		Title:   "title",
+		Dataset: "data.root",
+		Extra: map[string]string{
+			"zzz":   "last",
+			"aaa":   "first",
+			"empty": "",
+		},
+	}
+	got := md.entries()
+	want := []metaEntry{
+		{"Title", "title"},
+		{"Dataset", "data.root"},
+		{"aaa", "first"},
+		{"zzz", "last"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid entries:\ngot= %q\nwant=%q", got, want)
+	}
+
+	if got := (Metadata{}).entries(); len(got) != 0 {
+		t.Fatalf("invalid entries for empty metadata: %q", got)
+	}
+}
+
+func TestSaveMetadata(t *testing.T) {
+	tmp, err := os.MkdirTemp("", "hplot-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	p := New()
+	p.Title.Text = "my title"
+	md := Metadata{
+		Title:     "my title",
+		Creator:   "go-hep (test)",
+		Dataset:   "/data/run-1.root",
+		Commit:    "cafebabe",
+		Selection: "pt > 20 && η < 2.5",
+		Extra:     map[string]string{"lumi": "139 fb^-1"},
+	}
+	fig := Figure(p, WithMetadata(md))
+
+	var (
+		fpng = filepath.Join(tmp, "fig.png")
+		fpdf = filepath.Join(tmp, "fig.pdf")
+		fsvg = filepath.Join(tmp, "fig.svg")
+	)
+	err = Save(fig, 10*vg.Centimeter, -1, fpng, fpdf, fsvg)
+	if err != nil {
+		t.Fatalf("could not save figure: %+v", err)
+	}
+
+	t.Run("png", func(t *testing.T) {
+		raw, err := os.ReadFile(fpng)
+		if err != nil {
+			t.Fatalf("could not read PNG file: %+v", err)
+		}
+		_, err = png.Decode(bytes.NewReader(raw))
+		if err != nil {
+			t.Fatalf("could not decode PNG file: %+v", err)
+		}
+
+		got := make(map[string]string)
+		for raw = raw[8:]; len(raw) >= 12; {
+			n := binary.BigEndian.Uint32(raw[:4])
+			typ := string(raw[4:8])
+			data := raw[8 : 8+n]
+			raw = raw[12+n:]
+			if typ != "iTXt" {
+				continue
+			}
+			toks := bytes.SplitN(data, []byte{0}, 2)
+			key, data := string(toks[0]), toks[1]
+			// skip compression flag and method, language tag and
+			// translated keyword.
+			data = data[2:]
+			data = data[bytes.IndexByte(data, 0)+1:]
+			data = data[bytes.IndexByte(data, 0)+1:]
+			got[key] = string(data)
+		}
+		want := map[string]string{
+			"Title":     md.Title,
+			"Creator":   md.Creator,
+			"Dataset":   md.Dataset,
+			"Commit":    md.Commit,
+			"Selection": md.Selection,
+			"lumi":      "139 fb^-1",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid PNG metadata:\ngot= %q\nwant=%q", got, want)
+		}
+	})
+
+	t.Run("pdf", func(t *testing.T) {
+		raw, err := os.ReadFile(fpdf)
+		if err != nil {
+			t.Fatalf("could not read PDF file: %+v", err)
+		}
+
+		for _, want := range []string{
+			"/Title (my title)",
+			"/Creator (go-hep \\(test\\))",
+			"/Dataset (/data/run-1.root)",
+			"/Commit (cafebabe)",
+			"/Selection " + pdfString(md.Selection),
+			"/lumi (139 fb^-1)",
+			"/Producer (",
+			"/Type /Metadata /Subtype /XML",
+			"<hplot:Commit>cafebabe</hplot:Commit>",
+			"<hplot:Selection>pt &gt; 20 &amp;&amp; η &lt; 2.5</hplot:Selection>",
+			"<rdf:li>lumi=139 fb^-1</rdf:li>",
+		} {
+			if !bytes.Contains(raw, []byte(want)) {
+				t.Errorf("missing %q in PDF file", want)
+			}
+		}
+
+		// check the cross-reference table of the update points to
+		// the updated objects.
+		m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(raw)
+		if m == nil {
+			t.Fatalf("could not find startxref")
+		}
+		xref, _ := strconv.Atoi(string(m[1]))
+		if !bytes.HasPrefix(raw[xref:], []byte("xref\n")) {
+			t.Fatalf("invalid startxref offset %d", xref)
+		}
+		sect := regexp.MustCompile(`(\d+) (\d+)\n((?:\d{10} \d{5} [fn] \n)+)`)
+		tbl := raw[xref : bytes.Index(raw[xref:], []byte("trailer"))+xref]
+		for _, sub := range sect.FindAllSubmatch(tbl, -1) {
+			id, _ := strconv.Atoi(string(sub[1]))
+			for i, entry := range strings.Split(strings.TrimSpace(string(sub[3])), "\n") {
+				if strings.HasSuffix(entry, "f") {
+					continue
+				}
+				off, _ := strconv.Atoi(entry[:10])
+				obj := strconv.Itoa(id+i) + " 0 obj"
+				if !bytes.HasPrefix(raw[off:], []byte(obj)) {
+					t.Errorf("invalid offset %d for object %q", off, obj)
+				}
+			}
+		}
+		if !bytes.Contains(raw[xref:], []byte("/Prev ")) {
+			t.Errorf("missing /Prev entry in PDF trailer")
+		}
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		raw, err := os.ReadFile(fsvg)
+		if err != nil {
+			t.Fatalf("could not read SVG file: %+v", err)
+		}
+		if bytes.Contains(raw, []byte("cafebabe")) {
+			t.Fatalf("unexpected metadata in SVG file")
+		}
+	})
+}
+
+func TestPDFString(t *testing.T) {
+	for _, tc := range []struct {
+		str  string
+		want string
+	}{
+		{"", "()"},
+		{"hello", "(hello)"},
+		{`a(b)\c`, `(a\(b\)\\c)`},
+		{"η", "<FEFF03B7>"},
+		{"a\nb", "<FEFF0061000A0062>"},
+	} {
+		t.Run(tc.str, func(t *testing.T) {
+			got := pdfString(tc.str)
+			if got != tc.want {
+				t.Fatalf("invalid PDF string: got=%q, want=%q", got, tc.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@
 package hplot
 
 import (
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot/palette"
 	"gonum.org/v1/plot/vg/draw"
 )
 
@@ -23,14 +25,30 @@ type config struct {
 	bars struct {
 		xerrs bool
 		yerrs bool
+		yerrf func(bin hbook.Bin1D) (low, high float64)
 	}
+	syst   func(bin hbook.Bin1D) (low, high float64)
+	order  HStackOrder
+	pull   PullKind
+	prof   ProfileErrKind
+	norm   hnorm
 	band   bool
 	hinfos HInfos
 	log    struct {
 		y bool
+		z bool
 	}
 	glyph draw.GlyphStyle
+	draw  struct {
+		style HDrawStyle
+		barw  float64
+	}
 	steps StepsKind
+	cmap  palette.ColorMap
+	eff   struct {
+		stat *hbook.EffStat
+		cl   float64
+	}
 }
 
 func newConfig(opts []Options) *config {
@@ -49,6 +67,20 @@ func WithLogY(v bool) Options {
 	}
 }
 
+// WithLogZ sets whether the plotter in Z should handle log-scale.
+func WithLogZ(v bool) Options {
+	return func(c *config) {
+		c.log.z = v
+	}
+}
+
+// WithColorMap sets the color map of a plotter.
+func WithColorMap(cmap palette.ColorMap) Options {
+	return func(c *config) {
+		c.cmap = cmap
+	}
+}
+
 // WithXErrBars enables or disables the display of X-error bars.
 func WithXErrBars(v bool) Options {
 	return func(c *config) {
@@ -63,6 +95,33 @@ func WithYErrBars(v bool) Options {
 	}
 }
 
+// WithYErrBarsFunc enables the display of Y-error bars, using the provided
+// function to compute the (possibly asymmetric) low and high errors of each
+// histogram bin.
+// The same errors are used for the error band and the ratio panels.
+//
+// PoissonErrors can be used to display Poisson (Garwood) intervals.
+func WithYErrBarsFunc(f func(bin hbook.Bin1D) (low, high float64)) Options {
+	return func(c *config) {
+		c.bars.yerrs = f != nil
+		c.bars.yerrf = f
+	}
+}
+
+// WithSystErrors sets the function computing the (possibly asymmetric) low
+// and high systematic errors of each histogram bin.
+// Systematic errors are added in quadrature to the statistical errors
+// when computing error bands.
+//
+// When passed to NewHStack, the systematic errors apply to the total of
+// the stack and are combined with the statistical and systematic errors of
+// the stacked histograms.
+func WithSystErrors(f func(bin hbook.Bin1D) (low, high float64)) Options {
+	return func(c *config) {
+		c.syst = f
+	}
+}
+
 // WithBand enables or disables the display of a colored band between Y-error bars.
 func WithBand(v bool) Options {
 	return func(c *config) {
@@ -84,6 +143,78 @@ func WithGlyphStyle(sty draw.GlyphStyle) Options {
 	}
 }
 
+// WithDrawStyle sets how a histogram is drawn.
+// By default, histograms are drawn as a step outline (HDrawHist).
+func WithDrawStyle(sty HDrawStyle) Options {
+	return func(c *config) {
+		c.draw.style = sty
+	}
+}
+
+// WithBarWidth sets the fraction of the bin width covered by a bar,
+// when a histogram is drawn with the HDrawBars style.
+func WithBarWidth(frac float64) Options {
+	return func(c *config) {
+		c.draw.barw = frac
+	}
+}
+
+// WithStackOrder sets how the histograms of a stack are ordered.
+// By default, histograms are stacked in the order they were provided.
+func WithStackOrder(order HStackOrder) Options {
+	return func(c *config) {
+		c.order = order
+	}
+}
+
+// WithPullKind sets the quantity displayed by a pull plot.
+func WithPullKind(kind PullKind) Options {
+	return func(c *config) {
+		c.pull = kind
+	}
+}
+
+// WithProfileErr sets the uncertainty displayed by a profile plot.
+func WithProfileErr(kind ProfileErrKind) Options {
+	return func(c *config) {
+		c.prof = kind
+	}
+}
+
+// WithNorm sets how a histogram is normalized when displayed.
+// The underlying hbook histogram is not modified.
+func WithNorm(kind HNorm) Options {
+	return func(c *config) {
+		c.norm.kind = kind
+	}
+}
+
+// WithNormTo normalizes the displayed histogram to the integral of the
+// in-range bins of the provided reference histogram.
+// WithNormTo can be combined with WithNorm(HNormWidth) or
+// WithNorm(HNormDensity) to also divide the content of each bin by its width.
+// The underlying hbook histogram is not modified.
+func WithNormTo(ref *hbook.H1D) Options {
+	return func(c *config) {
+		c.norm.ref = ref
+	}
+}
+
+// WithEffStat sets the statistic used to compute the confidence intervals
+// of an efficiency plot.
+func WithEffStat(stat hbook.EffStat) Options {
+	return func(c *config) {
+		c.eff.stat = &stat
+	}
+}
+
+// WithEffCL sets the confidence level of the intervals of an efficiency plot.
+func WithEffCL(cl float64) Options {
+	return func(c *config) {
+		c.eff.cl = cl
+	}
+}
+
 // WithHInfo sets a given histogram info style.
 func WithHInfo(v HInfoStyle) Options {
 	return func(c *config) {
@@ -0,0 +1,107 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// ProfileErrKind describes the uncertainty displayed by a profile plot.
+type ProfileErrKind int
+
+const (
+	// ProfileErrMean displays the uncertainty on the mean of y,
+	// σ/√N_eff, for each x-bin.
+	ProfileErrMean ProfileErrKind = iota
+	// ProfileErrSpread displays the spread of y, σ, for each x-bin.
+	ProfileErrSpread
+)
+
+// P1D plots a 1-dim profile histogram: the mean of y for each x-bin,
+// with its spread or the uncertainty on the mean.
+//
+// Empty bins are not displayed.
+// Bins with not enough entries to estimate the spread of y are displayed
+// without y error bars.
+type P1D struct {
+	// Profile is the profile histogram being displayed.
+	Profile *hbook.P1D
+
+	// Kind is the uncertainty displayed by the y error bars.
+	Kind ProfileErrKind
+
+	// S2D displays the mean of y for each non-empty x-bin.
+	// The x error bars span the x-bins.
+	*S2D
+}
+
+// NewP1D creates a new profile plot from the provided profile histogram.
+//
+// By default, the x and y error bars are displayed and the y error bars
+// show the uncertainty on the mean of y.
+// The spread of y is displayed instead with the WithProfileErr option.
+func NewP1D(p *hbook.P1D, opts ...Options) *P1D {
+	cfg := newConfig(opts)
+
+	var (
+		bins = p.Binning().Bins()
+		pts  = make([]hbook.Point2D, 0, len(bins))
+	)
+	for _, bin := range bins {
+		if bin.SumW() == 0 {
+			continue
+		}
+		var (
+			x  = bin.XMid()
+			y  = bin.YMean()
+			ey float64
+		)
+		switch cfg.prof {
+		case ProfileErrSpread:
+			ey = bin.YStdDev()
+		default:
+			ey = bin.YStdErr()
+		}
+		if math.IsNaN(ey) || math.IsInf(ey, 0) {
+			// not enough entries to estimate the spread.
+			ey = 0
+		}
+		pts = append(pts, hbook.Point2D{
+			X:    x,
+			Y:    y,
+			ErrX: hbook.Range{Min: x - bin.XMin(), Max: bin.XMax() - x},
+			ErrY: hbook.Range{Min: ey, Max: ey},
+		})
+	}
+
+	opts = append([]Options{WithXErrBars(true), WithYErrBars(true)}, opts...)
+	s := NewS2D(hbook.NewS2D(pts...), opts...)
+	if cfg.glyph == (draw.GlyphStyle{}) {
+		s.GlyphStyle = draw.GlyphStyle{
+			Shape:  draw.CircleGlyph{},
+			Color:  color.Black,
+			Radius: vg.Points(2),
+		}
+	}
+
+	return &P1D{
+		Profile: p,
+		Kind:    cfg.prof,
+		S2D:     s,
+	}
+}
+
+// NewP1DFromH2D creates a new profile plot of the mean of y as a
+// function of x, from the provided 2-dim histogram.
+//
+// See NewP1D for the available options.
+func NewP1DFromH2D(h *hbook.H2D, opts ...Options) *P1D {
+	return NewP1D(hbook.NewP1DFromH2D(h), opts...)
+}
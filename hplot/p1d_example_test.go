@@ -0,0 +1,97 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleP1D() {
+	const npoints = 5000
+
+	// Energy response of a calorimeter: the resolution
+	// improves with the energy of the particles.
+	var (
+		src  = rand.New(rand.NewSource(1234))
+		ene  = distuv.Uniform{Min: 10, Max: 200, Src: src}
+		norm = distuv.Normal{Mu: 0, Sigma: 1, Src: src}
+	)
+
+	prof := hbook.NewP1D(19, 10, 200)
+	for i := 0; i < npoints; i++ {
+		var (
+			e     = ene.Rand()
+			sigma = 0.5 / math.Sqrt(e)
+			resp  = 0.95 + 0.01*math.Log(e) + sigma*norm.Rand()
+		)
+		prof.Fill(e, resp, 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Energy response"
+	p.X.Label.Text = "E [GeV]"
+	p.Y.Label.Text = "E_reco / E"
+
+	spread := hplot.NewP1D(prof, hplot.WithProfileErr(hplot.ProfileErrSpread))
+	spread.GlyphStyle.Color = color.NRGBA{B: 255, A: 255}
+
+	mean := hplot.NewP1D(prof, hplot.WithGlyphStyle(draw.GlyphStyle{
+		Shape:  draw.CircleGlyph{},
+		Color:  color.NRGBA{R: 255, A: 255},
+		Radius: vg.Points(2),
+	}))
+
+	p.Add(spread, mean, hplot.NewGrid())
+	p.Legend.Add("spread", spread)
+	p.Legend.Add("error on mean", mean)
+	p.Legend.Top = true
+
+	err := p.Save(15*vg.Centimeter, -1, "testdata/p1d_plot.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
+
+func ExampleNewP1DFromH2D() {
+	const npoints = 20000
+
+	var (
+		src  = rand.New(rand.NewSource(1234))
+		xdst = distuv.Uniform{Min: -4, Max: 4, Src: src}
+		norm = distuv.Normal{Mu: 0, Sigma: 1, Src: src}
+	)
+
+	h2d := hbook.NewH2D(40, -4, 4, 40, -4, 8)
+	for i := 0; i < npoints; i++ {
+		var (
+			x = xdst.Rand()
+			y = 0.2*x*x + 0.5*norm.Rand()
+		)
+		h2d.Fill(x, y, 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Profile of a 2D histogram"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	prof := hplot.NewP1DFromH2D(h2d, hplot.WithProfileErr(hplot.ProfileErrSpread))
+
+	p.Add(hplot.NewH2D(h2d, nil), prof)
+
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/p1d_h2d.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestP1D(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleP1D, t, "p1d_plot.png")
+	checkPlot(cmpimg.CheckPlot)(ExampleNewP1DFromH2D, t, "p1d_h2d.png")
+}
+
+func TestP1DErrors(t *testing.T) {
+	prof := hbook.NewP1D(3, 0, 3)
+	for _, y := range []float64{1, 2, 3, 6} {
+		prof.Fill(0.5, y, 1)
+	}
+	prof.Fill(2.5, 4, 1)
+
+	var (
+		mean = 3.0
+		std  = math.Sqrt(14.0 / 3.0) // unbiased standard deviation.
+	)
+
+	for _, tc := range []struct {
+		kind hplot.ProfileErrKind
+		err  float64
+	}{
+		{hplot.ProfileErrMean, std / 2},
+		{hplot.ProfileErrSpread, std},
+	} {
+		p := hplot.NewP1D(prof, hplot.WithProfileErr(tc.kind))
+		if got, want := p.Data.Len(), 2; got != want {
+			t.Fatalf("invalid number of points: got=%d, want=%d", got, want)
+		}
+
+		x, y := p.Data.XY(0)
+		if x != 0.5 || y != mean {
+			t.Fatalf("invalid point: got=(%v, %v), want=(0.5, %v)", x, y, mean)
+		}
+
+		lo, hi := p.YErrs.YErrors[0].Low, p.YErrs.YErrors[0].High
+		if math.Abs(lo-tc.err) > 1e-12 || math.Abs(hi-tc.err) > 1e-12 {
+			t.Fatalf("kind=%d: invalid y-errors: got=(%v, %v), want=%v", tc.kind, lo, hi, tc.err)
+		}
+
+		lo, hi = p.XErrs.XErrors[0].Low, p.XErrs.XErrors[0].High
+		if lo != 0.5 || hi != 0.5 {
+			t.Fatalf("invalid x-errors: got=(%v, %v), want=0.5", lo, hi)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"gonum.org/v1/plot/palette"
+)
+
+// Viridis returns the perceptually uniform viridis color map of matplotlib,
+// from dark purple to yellow.
+func Viridis() palette.ColorMap {
+	return newGradient([]color.NRGBA{
+		{0x44, 0x01, 0x54, 0xff},
+		{0x47, 0x2d, 0x7b, 0xff},
+		{0x3b, 0x52, 0x8b, 0xff},
+		{0x2c, 0x72, 0x8e, 0xff},
+		{0x21, 0x91, 0x8c, 0xff},
+		{0x28, 0xae, 0x80, 0xff},
+		{0x5e, 0xc9, 0x62, 0xff},
+		{0xad, 0xdc, 0x30, 0xff},
+		{0xfd, 0xe7, 0x25, 0xff},
+	})
+}
+
+// Cividis returns the cividis color map, from dark blue to yellow.
+// Cividis is perceptually uniform and optimized for viewers with color
+// vision deficiencies.
+func Cividis() palette.ColorMap {
+	return newGradient(rgb255(
+		[]float64{0, 5, 65, 97, 124, 156, 189, 224, 255},
+		[]float64{32, 54, 77, 100, 123, 148, 175, 203, 234},
+		[]float64{77, 110, 107, 111, 120, 119, 111, 94, 70},
+	))
+}
+
+// Bird returns the kBird color map, the default color map of ROOT,
+// from dark blue to yellow.
+func Bird() palette.ColorMap {
+	return newGradient(rgb1(
+		[]float64{0.2082, 0.0592, 0.0780, 0.0232, 0.1802, 0.5301, 0.8186, 0.9956, 0.9764},
+		[]float64{0.1664, 0.3599, 0.5041, 0.6419, 0.7178, 0.7492, 0.7328, 0.7862, 0.9832},
+		[]float64{0.5293, 0.8684, 0.8385, 0.7914, 0.6425, 0.4662, 0.3499, 0.1968, 0.0539},
+	))
+}
+
+// OkabeIto returns the colorblind-safe qualitative palette of
+// M. Okabe and K. Ito, with black moved to the end of the palette.
+func OkabeIto() palette.Palette {
+	return hexPalette(
+		0xe69f00, 0x56b4e9, 0x009e73, 0xf0e442,
+		0x0072b2, 0xd55e00, 0xcc79a7, 0x000000,
+	)
+}
+
+// TolBright returns the colorblind-safe "bright" qualitative palette
+// of P. Tol.
+func TolBright() palette.Palette {
+	return hexPalette(
+		0x4477aa, 0xee6677, 0x228833, 0xccbb44,
+		0x66ccee, 0xaa3377, 0xbbbbbb,
+	)
+}
+
+// Petroff6 returns the 6-colors qualitative palette of M. Petroff,
+// optimized for accessibility and aesthetics (arXiv:2107.02270).
+func Petroff6() palette.Palette {
+	return hexPalette(
+		0x5790fc, 0xf89c20, 0xe42536,
+		0x964a8b, 0x9c9ca1, 0x7a21dd,
+	)
+}
+
+// Petroff10 returns the 10-colors qualitative palette of M. Petroff,
+// optimized for accessibility and aesthetics (arXiv:2107.02270).
+func Petroff10() palette.Palette {
+	return hexPalette(
+		0x3f90da, 0xffa90e, 0xbd1f01, 0x94a4a2, 0x832db6,
+		0xa96b59, 0xe76300, 0xb9ac70, 0x717581, 0x92dadd,
+	)
+}
+
+type colors []color.Color
+
+func (cs colors) Colors() []color.Color { return cs }
+
+func hexPalette(vs ...uint32) palette.Palette {
+	cs := make(colors, len(vs))
+	for i, v := range vs {
+		cs[i] = color.NRGBA{
+			R: uint8(v >> 16),
+			G: uint8(v >> 8),
+			B: uint8(v),
+			A: 0xff,
+		}
+	}
+	return cs
+}
+
+func rgb255(r, g, b []float64) []color.NRGBA {
+	cs := make([]color.NRGBA, len(r))
+	for i := range cs {
+		cs[i] = color.NRGBA{
+			R: uint8(r[i]),
+			G: uint8(g[i]),
+			B: uint8(b[i]),
+			A: 0xff,
+		}
+	}
+	return cs
+}
+
+func rgb1(r, g, b []float64) []color.NRGBA {
+	cs := make([]color.NRGBA, len(r))
+	for i := range cs {
+		cs[i] = color.NRGBA{
+			R: uint8(math.Round(255 * r[i])),
+			G: uint8(math.Round(255 * g[i])),
+			B: uint8(math.Round(255 * b[i])),
+			A: 0xff,
+		}
+	}
+	return cs
+}
+
+// gradient is a color map interpolating linearly, in RGB space,
+// between evenly spaced colors.
+type gradient struct {
+	stops []color.NRGBA
+	min   float64
+	max   float64
+	alpha float64
+}
+
+func newGradient(stops []color.NRGBA) *gradient {
+	return &gradient{
+		stops: stops,
+		min:   0,
+		max:   1,
+		alpha: 1,
+	}
+}
+
+// At implements the palette.ColorMap interface.
+func (g *gradient) At(v float64) (color.Color, error) {
+	switch {
+	case g.max == g.min:
+		return nil, fmt.Errorf("hplot: color map max == min == %g", g.max)
+	case g.min > g.max:
+		return nil, fmt.Errorf("hplot: color map max (%g) < min (%g)", g.max, g.min)
+	case math.IsNaN(v):
+		return nil, palette.ErrNaN
+	case v < g.min:
+		return nil, palette.ErrUnderflow
+	case v > g.max:
+		return nil, palette.ErrOverflow
+	}
+
+	var (
+		x = (v - g.min) / (g.max - g.min) * float64(len(g.stops)-1)
+		i = int(x)
+	)
+	if i >= len(g.stops)-1 {
+		i = len(g.stops) - 2
+	}
+	var (
+		f  = x - float64(i)
+		c1 = g.stops[i]
+		c2 = g.stops[i+1]
+		a  = g.alpha
+	)
+	lerp := func(v1, v2 uint8) uint8 {
+		return uint8(math.Round(a * ((1-f)*float64(v1) + f*float64(v2))))
+	}
+	// colors are returned with premultiplied alpha.
+	return color.RGBA{
+		R: lerp(c1.R, c2.R),
+		G: lerp(c1.G, c2.G),
+		B: lerp(c1.B, c2.B),
+		A: uint8(math.Round(255 * a)),
+	}, nil
+}
+
+// Max implements the palette.ColorMap interface.
+func (g *gradient) Max() float64 { return g.max }
+
+// SetMax implements the palette.ColorMap interface.
+func (g *gradient) SetMax(v float64) { g.max = v }
+
+// Min implements the palette.ColorMap interface.
+func (g *gradient) Min() float64 { return g.min }
+
+// SetMin implements the palette.ColorMap interface.
+func (g *gradient) SetMin(v float64) { g.min = v }
+
+// Alpha implements the palette.ColorMap interface.
+func (g *gradient) Alpha() float64 { return g.alpha }
+
+// SetAlpha implements the palette.ColorMap interface.
+func (g *gradient) SetAlpha(v float64) {
+	if !(0 <= v && v <= 1) {
+		panic(fmt.Errorf("hplot: invalid color map alpha value %g", v))
+	}
+	g.alpha = v
+}
+
+// Palette implements the palette.ColorMap interface.
+func (g *gradient) Palette(n int) palette.Palette {
+	var (
+		cs    = make(colors, n)
+		delta = (g.max - g.min) / float64(n-1)
+	)
+	for i := range cs {
+		v := g.min + float64(i)*delta
+		if i == n-1 {
+			v = g.max
+		}
+		c, err := g.At(v)
+		if err != nil {
+			panic(err)
+		}
+		cs[i] = c
+	}
+	return cs
+}
+
+var (
+	_ palette.ColorMap = (*gradient)(nil)
+)
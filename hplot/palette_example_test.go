@@ -0,0 +1,59 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distmv"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExampleViridis() {
+	const npoints = 10000
+
+	dist, ok := distmv.NewNormal(
+		[]float64{0, 1},
+		mat.NewSymDense(2, []float64{4, 0, 0, 2}),
+		rand.New(rand.NewSource(1234)),
+	)
+	if !ok {
+		log.Fatalf("error creating distmv.Normal")
+	}
+
+	h := hbook.NewH2D(40, -10, 10, 40, -10, 10)
+	v := make([]float64, 2)
+	for i := 0; i < npoints; i++ {
+		v = dist.Rand(v)
+		h.Fill(v[0], v[1], 1)
+	}
+
+	tp := hplot.NewTiledPlot(draw.Tiles{Cols: 3, Rows: 1, PadX: 10})
+	for i, cmap := range []struct {
+		name string
+		cmap palette.ColorMap
+	}{
+		{"Viridis", hplot.Viridis()},
+		{"Cividis", hplot.Cividis()},
+		{"Bird", hplot.Bird()},
+	} {
+		p := tp.Plot(i, 0)
+		p.Title.Text = cmap.name
+		p.X.Label.Text = "x"
+		p.Y.Label.Text = "y"
+		p.Add(hplot.NewH2D(h, cmap.cmap.Palette(255)))
+	}
+
+	err := tp.Save(21*vg.Centimeter, 8*vg.Centimeter, "testdata/palette_cmaps.png")
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
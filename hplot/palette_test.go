@@ -0,0 +1,111 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/palette"
+)
+
+func TestPaletteColorMaps(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleViridis, t, "palette_cmaps.png")
+}
+
+func TestColorMap(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		cmap     palette.ColorMap
+		min, max color.RGBA
+	}{
+		{"viridis", hplot.Viridis(), color.RGBA{0x44, 0x01, 0x54, 0xff}, color.RGBA{0xfd, 0xe7, 0x25, 0xff}},
+		{"cividis", hplot.Cividis(), color.RGBA{0x00, 0x20, 0x4d, 0xff}, color.RGBA{0xff, 0xea, 0x46, 0xff}},
+		{"bird", hplot.Bird(), color.RGBA{0x35, 0x2a, 0x87, 0xff}, color.RGBA{0xf9, 0xfb, 0x0e, 0xff}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cmap := tc.cmap
+			cmap.SetMin(-1)
+			cmap.SetMax(+1)
+
+			for _, v := range []struct {
+				v    float64
+				want color.RGBA
+			}{
+				{-1, tc.min},
+				{+1, tc.max},
+			} {
+				got, err := cmap.At(v.v)
+				if err != nil {
+					t.Fatalf("could not get color at %v: %+v", v.v, err)
+				}
+				if got != v.want {
+					t.Fatalf("invalid color at %v: got=%v, want=%v", v.v, got, v.want)
+				}
+			}
+
+			for _, v := range []struct {
+				v   float64
+				err error
+			}{
+				{-2, palette.ErrUnderflow},
+				{+2, palette.ErrOverflow},
+				{math.NaN(), palette.ErrNaN},
+			} {
+				_, err := cmap.At(v.v)
+				if err != v.err {
+					t.Fatalf("invalid error at %v: got=%v, want=%v", v.v, err, v.err)
+				}
+			}
+
+			pal := cmap.Palette(5).Colors()
+			if got, want := len(pal), 5; got != want {
+				t.Fatalf("invalid palette size: got=%d, want=%d", got, want)
+			}
+			if pal[0] != color.Color(tc.min) || pal[4] != color.Color(tc.max) {
+				t.Fatalf("invalid palette bounds: got=(%v, %v)", pal[0], pal[4])
+			}
+
+			cmap.SetAlpha(0.5)
+			got, err := cmap.At(-1)
+			if err != nil {
+				t.Fatalf("could not get color: %+v", err)
+			}
+			if _, _, _, a := got.RGBA(); a != 0x8080 {
+				t.Fatalf("invalid alpha: got=%#x, want=0x8080", a)
+			}
+		})
+	}
+}
+
+func TestQualitativePalettes(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		pal  palette.Palette
+		n    int
+	}{
+		{"okabe-ito", hplot.OkabeIto(), 8},
+		{"tol-bright", hplot.TolBright(), 7},
+		{"petroff-6", hplot.Petroff6(), 6},
+		{"petroff-10", hplot.Petroff10(), 10},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := tc.pal.Colors()
+			if got, want := len(cs), tc.n; got != want {
+				t.Fatalf("invalid number of colors: got=%d, want=%d", got, want)
+			}
+			seen := make(map[color.Color]bool)
+			for _, c := range cs {
+				if seen[c] {
+					t.Fatalf("duplicate color %v", c)
+				}
+				seen[c] = true
+			}
+		})
+	}
+}
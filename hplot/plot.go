@@ -5,6 +5,7 @@
 package hplot
 
 import (
+	"fmt"
 	"io"
 	"math"
 	"sync"
@@ -18,6 +19,18 @@ import (
 type Plot struct {
 	*plot.Plot
 	Style Style
+
+	// Cycle, if not nil, styles the plotters added to the plot.
+	// See StyleCycle for details.
+	Cycle *StyleCycle
+
+	// LegendPlacement controls how the legend of the plot is placed.
+	// The default is LegendManual.
+	LegendPlacement LegendPlacement
+
+	plotters []plot.Plotter // plotters added with Add.
+
+	exp expState // experiment style, attached while drawing a figure.
 }
 
 // muNewPlot protects access to gonum/plot.DefaultFont
@@ -48,10 +61,16 @@ func New() *Plot {
 // axes are changed if necessary to fit the range of
 // the data.
 //
+// If the plot has a style cycle, the plotters are
+// styled with the next styles of the cycle.
+//
 // When drawing the plot, Plotters are drawn in the
 // order in which they were added to the plot.
 func (p *Plot) Add(ps ...plot.Plotter) {
 	for _, d := range ps {
+		if p.Cycle != nil {
+			p.Cycle.Apply(d)
+		}
 		if x, ok := d.(plot.DataRanger); ok {
 			xmin, xmax, ymin, ymax := x.DataRange()
 			p.Plot.X.Min = math.Min(p.Plot.X.Min, xmin)
@@ -61,6 +80,7 @@ func (p *Plot) Add(ps ...plot.Plotter) {
 		}
 	}
 
+	p.plotters = append(p.plotters, ps...)
 	p.Plot.Add(ps...)
 }
 
@@ -69,7 +89,7 @@ func (p *Plot) Add(ps ...plot.Plotter) {
 //
 // Supported extensions are:
 //
-//	.eps, .jpg, .jpeg, .json, .pdf, .png, .svg, .tex, .tif and .tiff.
+//	.eps, .html, .jpg, .jpeg, .json, .pdf, .png, .svg, .tex, .tif and .tiff.
 //
 // If w or h are <= 0, the value is chosen such that it follows the Golden Ratio.
 // If w and h are <= 0, the values are chosen such that they follow the Golden Ratio
@@ -83,7 +103,7 @@ func (p *Plot) Save(w, h vg.Length, file string) error {
 //
 // Supported formats are:
 //
-//	eps, jpg|jpeg, pdf, png, svg, tex and tif|tiff.
+//	eps, html, jpg|jpeg, pdf, png, svg, tex and tif|tiff.
 func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
 	return WriterTo(p, w, h, format)
 }
@@ -95,8 +115,86 @@ func (p *Plot) WriterTo(w, h vg.Length, format string) (io.WriterTo, error) {
 // GlyphBoxer interface will have their GlyphBoxes
 // taken into account when padding the plot so that
 // none of their glyphs are clipped.
+//
+// The legend is placed according to the LegendPlacement of the plot.
+//
+// The exponents of the tick labels of axes with a LogTicks marker are
+// rendered as superscripts, and the powers of 10 factored out of the tick
+// labels of axes with a SciTicks marker are displayed at the end of these
+// axes.
 func (p *Plot) Draw(dc draw.Canvas) {
+	var (
+		exp        = p.exp
+		xlen, ylen = p.X.Tick.Length, p.Y.Tick.Length
+	)
+	if exp.sty != nil {
+		defer exp.sty.setup(p)()
+		dc = exp.sty.reserve(p, dc, exp.label)
+	}
+
+	for _, axis := range []*plot.Axis{&p.X, &p.Y} {
+		if _, ok := axis.Tick.Marker.(LogTicks); !ok {
+			continue
+		}
+		hdlr := axis.Tick.Label.Handler
+		axis.Tick.Label.Handler = supHandler(hdlr)
+		defer func(axis *plot.Axis) {
+			axis.Tick.Label.Handler = hdlr
+		}(axis)
+	}
+
+	dc, restore := p.placeLegend(dc)
 	p.Plot.Draw(dc)
+	restore()
+	p.drawExponents(dc)
+
+	if exp.sty != nil {
+		exp.sty.draw(p, dc, xlen, ylen, exp.label)
+	}
+}
+
+// drawExponents draws the powers of 10 factored out of the tick labels,
+// below the right end of the X axis and above the top end of the Y axis.
+func (p *Plot) drawExponents(dc draw.Canvas) {
+	exp := func(axis plot.Axis) int {
+		tck, ok := axis.Tick.Marker.(SciTicks)
+		if !ok {
+			return 0
+		}
+		return tck.Exponent(axis.Min, axis.Max)
+	}
+
+	var (
+		ex = exp(p.X)
+		ey = exp(p.Y)
+	)
+	if ex == 0 && ey == 0 {
+		return
+	}
+
+	da := p.DataCanvas(dc)
+	if ex != 0 {
+		var (
+			sty = p.X.Tick.Label
+			txt = fmt.Sprintf("×10^{%d}", ex)
+		)
+		sty.Handler = supHandler(sty.Handler)
+		sty.XAlign = draw.XRight
+		sty.YAlign = draw.YTop
+		y := da.Min.Y - p.X.Padding - p.X.Tick.Length - sty.Height("0")
+		dc.FillText(sty, vg.Point{X: da.Max.X, Y: y}, txt)
+	}
+	if ey != 0 {
+		var (
+			sty = p.Y.Tick.Label
+			txt = fmt.Sprintf("×10^{%d}", ey)
+		)
+		sty.Handler = supHandler(sty.Handler)
+		sty.XAlign = draw.XRight
+		sty.YAlign = draw.YBottom
+		y := da.Max.Y + 0.5*sty.Height("0")
+		dc.FillText(sty, vg.Point{X: da.Min.X - p.Y.Padding, Y: y}, txt)
+	}
 }
 
 var (
@@ -0,0 +1,626 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// AngleUnit describes how the angular tick labels of a polar plot
+// are displayed.
+type AngleUnit byte
+
+const (
+	AngleDegrees AngleUnit = iota // angles displayed in degrees, e.g. 90°
+	AngleRadians                  // angles displayed as fractions of π, e.g. π/2
+)
+
+// Polar is a plot in polar coordinates (r, φ), e.g. for detector geometry
+// or angular distributions.
+//
+// Angles are in radians, measured from the direction of the Zero angle of
+// the φ axis.
+//
+// Polar implements the Drawer interface and can be saved with Save or used
+// within a Figure.
+type Polar struct {
+	Title struct {
+		Text      string
+		Padding   vg.Length
+		TextStyle text.Style
+	}
+
+	// R is the radial axis.
+	R PolarRAxis
+
+	// Phi is the angular axis.
+	Phi PolarPhiAxis
+
+	// FrameStyle is the style of the outer circle of the plot.
+	FrameStyle draw.LineStyle
+
+	// GridStyle is the style of the circles and of the rays drawn at
+	// each major tick of the radial and angular axes.
+	// Use zero width to disable.
+	GridStyle draw.LineStyle
+
+	plotters []PolarPlotter
+}
+
+// PolarRAxis is the radial axis of a polar plot.
+type PolarRAxis struct {
+	// Min and Max are the range of the axis.
+	// Min is displayed at the center of the plot.
+	// If Max <= Min, the range is computed from the data of the plotters,
+	// with Min set to 0 and Max rounded up to a major tick.
+	// Values outside of the range are clamped to the range.
+	Min, Max float64
+
+	Label struct {
+		Text      string
+		TextStyle text.Style
+	}
+
+	Tick struct {
+		// Label is the text style of the tick labels.
+		Label text.Style
+
+		// Marker returns the tick marks.
+		Marker plot.Ticker
+	}
+
+	// Angle is the angle φ along which the tick labels are displayed.
+	Angle float64
+}
+
+// PolarPhiAxis is the angular axis of a polar plot.
+type PolarPhiAxis struct {
+	// Unit is the unit of the tick labels.
+	Unit AngleUnit
+
+	// Signed displays the tick labels in (-180°, 180°] or (-π, π],
+	// as customary for the azimuthal angle of particle physics,
+	// instead of [0°, 360°) or [0, 2π).
+	Signed bool
+
+	// Step is the angle, in radians, between two ticks.
+	Step float64
+
+	// Zero is the direction of φ=0 on the canvas, in radians,
+	// measured counter-clockwise from the horizontal rightward direction.
+	Zero float64
+
+	// Clockwise indicates whether angles increase clockwise.
+	Clockwise bool
+
+	Tick struct {
+		// Label is the text style of the tick labels.
+		Label text.Style
+
+		// Padding is the space between the outer circle and the labels.
+		Padding vg.Length
+	}
+}
+
+// Label returns the tick label of the angle phi, in radians.
+func (axis *PolarPhiAxis) Label(phi float64) string {
+	const tol = 1e-9
+	phi = math.Mod(phi, 2*math.Pi)
+	if phi < -tol {
+		phi += 2 * math.Pi
+	}
+	if axis.Signed && phi > math.Pi+tol {
+		phi -= 2 * math.Pi
+	}
+	if math.Abs(phi) < tol || math.Abs(phi-2*math.Pi) < tol {
+		phi = 0
+	}
+
+	switch axis.Unit {
+	case AngleRadians:
+		v := phi / math.Pi
+		for q := 1; q <= 12; q++ {
+			p := math.Round(v * float64(q))
+			if math.Abs(v*float64(q)-p) > tol*float64(q) {
+				continue
+			}
+			var (
+				num = int(p)
+				o   string
+			)
+			switch {
+			case num == 0:
+				return "0"
+			case num == 1:
+				o = "π"
+			case num == -1:
+				o = "-π"
+			default:
+				o = strconv.Itoa(num) + "π"
+			}
+			if q > 1 {
+				o += "/" + strconv.Itoa(q)
+			}
+			return o
+		}
+		return strconv.FormatFloat(phi, 'g', 3, 64)
+	default:
+		deg := phi * 180 / math.Pi
+		return strconv.FormatFloat(math.Round(deg*100)/100, 'f', -1, 64) + "°"
+	}
+}
+
+// PolarPlotter is the interface that wraps the plotters of a polar plot.
+type PolarPlotter interface {
+	// PlotPolar draws the data on the polar plot.
+	// The provided canvas is the area of the plot inside its outer circle.
+	PlotPolar(c draw.Canvas, p *Polar)
+
+	// RRange returns the range of the radial values of the data.
+	RRange() (min, max float64)
+}
+
+// NewPolar returns a new polar plot.
+//
+// By default, φ=0 points to the right, angles increase counter-clockwise
+// and angular ticks are displayed every 30°.
+func NewPolar() *Polar {
+	p := &Polar{
+		FrameStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(0.5),
+		},
+		GridStyle: draw.LineStyle{
+			Color:  color.Gray{Y: 180},
+			Width:  vg.Points(0.25),
+			Dashes: []vg.Length{vg.Points(2), vg.Points(2)},
+		},
+	}
+
+	p.Title.Padding = vg.Points(5)
+	p.Title.TextStyle = text.Style{
+		Color:   color.Black,
+		Font:    DefaultStyle.Fonts.Title,
+		XAlign:  draw.XCenter,
+		YAlign:  draw.YTop,
+		Handler: DefaultStyle.TextHandler,
+	}
+
+	p.R.Label.TextStyle = text.Style{
+		Color:   color.Black,
+		Font:    DefaultStyle.Fonts.Label,
+		XAlign:  draw.XCenter,
+		YAlign:  draw.YBottom,
+		Handler: DefaultStyle.TextHandler,
+	}
+	p.R.Tick.Label = text.Style{
+		Color:   color.Black,
+		Font:    DefaultStyle.Fonts.Tick,
+		Handler: DefaultStyle.TextHandler,
+	}
+	p.R.Tick.Marker = Ticks{N: 4}
+	p.R.Angle = math.Pi / 12
+
+	p.Phi.Step = math.Pi / 6
+	p.Phi.Tick.Label = text.Style{
+		Color:   color.Black,
+		Font:    DefaultStyle.Fonts.Tick,
+		Handler: DefaultStyle.TextHandler,
+	}
+	p.Phi.Tick.Padding = vg.Points(4)
+
+	return p
+}
+
+// Add adds plotters to the polar plot.
+func (p *Polar) Add(ps ...PolarPlotter) {
+	p.plotters = append(p.plotters, ps...)
+}
+
+// Pt returns the point of the canvas at the polar coordinates (r, phi).
+// The provided canvas is the area of the plot inside its outer circle,
+// as passed to the PlotPolar method of the plotters.
+func (p *Polar) Pt(c draw.Canvas, r, phi float64) vg.Point {
+	var (
+		ctr      = c.Center()
+		rad      = 0.5 * math.Min(float64(c.Max.X-c.Min.X), float64(c.Max.Y-c.Min.Y))
+		v        = (math.Max(p.R.Min, math.Min(p.R.Max, r)) - p.R.Min) / (p.R.Max - p.R.Min)
+		sin, cos = math.Sincos(p.angle(phi))
+	)
+	return vg.Point{
+		X: ctr.X + vg.Length(v*rad*cos),
+		Y: ctr.Y + vg.Length(v*rad*sin),
+	}
+}
+
+// angle returns the angle on the canvas of the angle phi.
+func (p *Polar) angle(phi float64) float64 {
+	if p.Phi.Clockwise {
+		phi = -phi
+	}
+	return p.Phi.Zero + phi
+}
+
+// arc returns the points of the arc of radius r between phi1 and phi2,
+// sampled at least every degree.
+func (p *Polar) arc(c draw.Canvas, r, phi1, phi2 float64) []vg.Point {
+	n := int(math.Ceil(math.Abs(phi2-phi1)*180/math.Pi)) + 1
+	if n < 2 {
+		n = 2
+	}
+	pts := make([]vg.Point, n)
+	for i := range pts {
+		pts[i] = p.Pt(c, r, phi1+float64(i)/float64(n-1)*(phi2-phi1))
+	}
+	return pts
+}
+
+// Draw draws the polar plot on the provided canvas,
+// implementing the Drawer interface.
+func (p *Polar) Draw(c draw.Canvas) {
+	if p.Title.Text != "" {
+		c.FillText(p.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, p.Title.Text)
+		c.Max.Y -= p.Title.TextStyle.Height(p.Title.Text) + p.Title.Padding
+	}
+	if p.R.Label.Text != "" {
+		c.FillText(p.R.Label.TextStyle, vg.Point{X: c.Center().X, Y: c.Min.Y}, p.R.Label.Text)
+		c.Min.Y += p.R.Label.TextStyle.Height(p.R.Label.Text) + p.Phi.Tick.Padding
+	}
+
+	if p.R.Max <= p.R.Min {
+		rmin, rmax := p.R.Min, p.R.Max
+		p.R.Min, p.R.Max = p.rrange()
+		defer func() { p.R.Min, p.R.Max = rmin, rmax }()
+	}
+
+	var (
+		phis   = p.phiTicks()
+		labels = make([]string, len(phis))
+		padx   vg.Length
+		pady   vg.Length
+	)
+	for i, phi := range phis {
+		labels[i] = p.Phi.Label(phi)
+		padx = max(padx, p.Phi.Tick.Label.Width(labels[i]))
+		pady = max(pady, p.Phi.Tick.Label.Height(labels[i]))
+	}
+	padx += p.Phi.Tick.Padding
+	pady += p.Phi.Tick.Padding
+
+	var (
+		ctr = c.Center()
+		rad = min((c.Max.X-c.Min.X)/2-padx, (c.Max.Y-c.Min.Y)/2-pady)
+		dc  = draw.Canvas{Canvas: c.Canvas}
+	)
+	if rad <= 0 {
+		return
+	}
+	dc.Min = vg.Point{X: ctr.X - rad, Y: ctr.Y - rad}
+	dc.Max = vg.Point{X: ctr.X + rad, Y: ctr.Y + rad}
+
+	p.drawGrid(dc, phis)
+	for _, v := range p.plotters {
+		v.PlotPolar(dc, p)
+	}
+	c.StrokeLines(p.FrameStyle, p.arc(dc, p.R.Max, 0, 2*math.Pi))
+
+	p.drawTicks(dc, phis, labels)
+}
+
+// rrange returns the range of the radial axis, computed from the data of
+// the plotters.
+func (p *Polar) rrange() (min, max float64) {
+	for _, v := range p.plotters {
+		_, hi := v.RRange()
+		if !math.IsInf(hi, 0) && !math.IsNaN(hi) {
+			max = math.Max(max, hi)
+		}
+	}
+	if max <= min {
+		max = min + 1
+	}
+
+	// round the range up to the next major tick.
+	var majors []float64
+	for _, tck := range p.R.Tick.Marker.Ticks(min, max) {
+		if !tck.IsMinor() {
+			majors = append(majors, tck.Value)
+		}
+	}
+	if n := len(majors); n > 1 && majors[n-1] < max {
+		step := majors[n-1] - majors[n-2]
+		max = majors[n-1] + step*math.Ceil((max-majors[n-1])/step)
+	}
+	return min, max
+}
+
+// phiTicks returns the angles of the angular ticks.
+func (p *Polar) phiTicks() []float64 {
+	step := p.Phi.Step
+	if step <= 0 {
+		step = math.Pi / 6
+	}
+	n := int(math.Round(2 * math.Pi / step))
+	phis := make([]float64, 0, n)
+	for i := 0; i < n; i++ {
+		phis = append(phis, float64(i)*step)
+	}
+	return phis
+}
+
+// rticks returns the major ticks of the radial axis.
+func (p *Polar) rticks() []plot.Tick {
+	var ticks []plot.Tick
+	for _, tck := range p.R.Tick.Marker.Ticks(p.R.Min, p.R.Max) {
+		if tck.IsMinor() || tck.Value < p.R.Min || tck.Value > p.R.Max {
+			continue
+		}
+		ticks = append(ticks, tck)
+	}
+	return ticks
+}
+
+// drawGrid draws the circles and the rays of the grid.
+func (p *Polar) drawGrid(c draw.Canvas, phis []float64) {
+	if p.GridStyle.Width == 0 {
+		return
+	}
+	for _, tck := range p.rticks() {
+		if tck.Value <= p.R.Min || tck.Value >= p.R.Max {
+			continue
+		}
+		c.StrokeLines(p.GridStyle, p.arc(c, tck.Value, 0, 2*math.Pi))
+	}
+	for _, phi := range phis {
+		c.StrokeLines(p.GridStyle, []vg.Point{
+			p.Pt(c, p.R.Min, phi),
+			p.Pt(c, p.R.Max, phi),
+		})
+	}
+}
+
+// drawTicks draws the tick labels of the radial and angular axes.
+func (p *Polar) drawTicks(c draw.Canvas, phis []float64, labels []string) {
+	sty := p.Phi.Tick.Label
+	pad := float64(p.Phi.Tick.Padding)
+	for i, phi := range phis {
+		var (
+			sin, cos = math.Sincos(p.angle(phi))
+			pt       = p.Pt(c, p.R.Max, phi)
+		)
+		pt.X += vg.Length(pad * cos)
+		pt.Y += vg.Length(pad * sin)
+		sty.XAlign = draw.XAlignment(-0.5 + 0.5*cos)
+		sty.YAlign = draw.YAlignment(-0.5 + 0.5*sin)
+		c.FillText(sty, pt, labels[i])
+	}
+
+	sty = p.R.Tick.Label
+	sty.XAlign = draw.XCenter
+	sty.YAlign = draw.YCenter
+	for _, tck := range p.rticks() {
+		if tck.Label == "" || tck.Value == p.R.Min {
+			continue
+		}
+		c.FillText(sty, p.Pt(c, tck.Value, p.R.Angle), tck.Label)
+	}
+}
+
+// PolarLine draws a line through (φ, r) points on a polar plot.
+// Consecutive points are joined with a spiral arc.
+type PolarLine struct {
+	// Data holds the (φ, r) points, φ being stored as the X value
+	// and r as the Y value.
+	Data plotter.XYs
+
+	// Closed joins the last point to the first one.
+	Closed bool
+
+	draw.LineStyle
+}
+
+// NewPolarLine returns a line through the provided (φ, r) points.
+func NewPolarLine(data plotter.XYer) (*PolarLine, error) {
+	xys, err := plotter.CopyXYs(data)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not copy polar line data: %w", err)
+	}
+	return &PolarLine{
+		Data:      xys,
+		LineStyle: plotter.DefaultLineStyle,
+	}, nil
+}
+
+// NewPolarFunction returns a closed line drawing r = f(φ) for φ in [0, 2π).
+func NewPolarFunction(f func(phi float64) float64) *PolarLine {
+	const n = 360
+	xys := make(plotter.XYs, n)
+	for i := range xys {
+		phi := 2 * math.Pi * float64(i) / n
+		xys[i] = plotter.XY{X: phi, Y: f(phi)}
+	}
+	return &PolarLine{
+		Data:      xys,
+		Closed:    true,
+		LineStyle: plotter.DefaultLineStyle,
+	}
+}
+
+// PlotPolar implements the PolarPlotter interface.
+func (line *PolarLine) PlotPolar(c draw.Canvas, p *Polar) {
+	n := len(line.Data)
+	if n == 0 || line.LineStyle.Width == 0 {
+		return
+	}
+	xys := line.Data
+	if line.Closed {
+		xys = append(xys[:n:n], xys[0])
+		xys[n].X += 2 * math.Pi * math.Round((xys[n-1].X-xys[n].X)/(2*math.Pi))
+	}
+
+	pts := []vg.Point{p.Pt(c, xys[0].Y, xys[0].X)}
+	for i := 1; i < len(xys); i++ {
+		var (
+			beg = xys[i-1]
+			end = xys[i]
+			m   = int(math.Ceil(math.Abs(end.X-beg.X)*180/math.Pi)) + 1
+		)
+		for j := 1; j <= m; j++ {
+			f := float64(j) / float64(m)
+			pts = append(pts, p.Pt(c, beg.Y+f*(end.Y-beg.Y), beg.X+f*(end.X-beg.X)))
+		}
+	}
+	c.StrokeLines(line.LineStyle, pts)
+}
+
+// RRange implements the PolarPlotter interface.
+func (line *PolarLine) RRange() (min, max float64) {
+	_, _, min, max = plotter.XYRange(line.Data)
+	return min, max
+}
+
+// Thumbnail draws a line, implementing the plot.Thumbnailer interface.
+func (line *PolarLine) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(line.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// PolarScatter draws glyphs at (φ, r) points on a polar plot.
+type PolarScatter struct {
+	// Data holds the (φ, r) points, φ being stored as the X value
+	// and r as the Y value.
+	Data plotter.XYs
+
+	draw.GlyphStyle
+}
+
+// NewPolarScatter returns a scatter plot of the provided (φ, r) points.
+func NewPolarScatter(data plotter.XYer) (*PolarScatter, error) {
+	xys, err := plotter.CopyXYs(data)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not copy polar scatter data: %w", err)
+	}
+	return &PolarScatter{
+		Data:       xys,
+		GlyphStyle: plotter.DefaultGlyphStyle,
+	}, nil
+}
+
+// PlotPolar implements the PolarPlotter interface.
+func (s *PolarScatter) PlotPolar(c draw.Canvas, p *Polar) {
+	for _, xy := range s.Data {
+		if xy.Y < p.R.Min || xy.Y > p.R.Max {
+			continue
+		}
+		c.DrawGlyph(s.GlyphStyle, p.Pt(c, xy.Y, xy.X))
+	}
+}
+
+// RRange implements the PolarPlotter interface.
+func (s *PolarScatter) RRange() (min, max float64) {
+	_, _, min, max = plotter.XYRange(s.Data)
+	return min, max
+}
+
+// Thumbnail draws a glyph, implementing the plot.Thumbnailer interface.
+func (s *PolarScatter) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(s.GlyphStyle, c.Center())
+}
+
+// PolarHist draws a 1-dim histogram of angles on a polar plot, as a set of
+// circular sectors whose radius is the content of the bins
+// (e.g. the φ occupancy of the sectors of a detector).
+type PolarHist struct {
+	// Hist is the histogram of angles, in radians.
+	Hist *hbook.H1D
+
+	// FillColor is the color of the sectors.
+	// Use nil to disable the filling.
+	FillColor color.Color
+
+	// LineStyle is the style of the outline of the sectors.
+	// Use zero width to disable.
+	LineStyle draw.LineStyle
+}
+
+// NewPolarHist returns a polar histogram of the provided histogram of
+// angles, in radians.
+func NewPolarHist(h *hbook.H1D) *PolarHist {
+	return &PolarHist{
+		Hist:      h,
+		FillColor: color.NRGBA{R: 0x56, G: 0xb4, B: 0xe9, A: 0xaa},
+		LineStyle: plotter.DefaultLineStyle,
+	}
+}
+
+// PlotPolar implements the PolarPlotter interface.
+func (h *PolarHist) PlotPolar(c draw.Canvas, p *Polar) {
+	for _, bin := range h.Hist.Binning.Bins {
+		r := bin.SumW()
+		if r <= p.R.Min {
+			continue
+		}
+		pts := []vg.Point{p.Pt(c, p.R.Min, bin.XMin())}
+		pts = append(pts, p.arc(c, r, bin.XMin(), bin.XMax())...)
+		pts = append(pts, p.Pt(c, p.R.Min, bin.XMax()))
+
+		if h.FillColor != nil {
+			c.FillPolygon(h.FillColor, pts)
+		}
+		if h.LineStyle.Width != 0 {
+			c.StrokeLines(h.LineStyle, append(pts, pts[0]))
+		}
+	}
+}
+
+// RRange implements the PolarPlotter interface.
+func (h *PolarHist) RRange() (min, max float64) {
+	min = math.Inf(+1)
+	max = math.Inf(-1)
+	for _, bin := range h.Hist.Binning.Bins {
+		min = math.Min(min, bin.SumW())
+		max = math.Max(max, bin.SumW())
+	}
+	return min, max
+}
+
+// Thumbnail draws a filled rectangle, implementing the plot.Thumbnailer
+// interface.
+func (h *PolarHist) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+	}
+	if h.FillColor != nil {
+		c.FillPolygon(h.FillColor, c.ClipPolygonY(pts))
+	}
+	if h.LineStyle.Width != 0 {
+		pts = append(pts, pts[0])
+		c.StrokeLines(h.LineStyle, c.ClipLinesY(pts)...)
+	}
+}
+
+var (
+	_ Drawer       = (*Polar)(nil)
+	_ PolarPlotter = (*PolarLine)(nil)
+	_ PolarPlotter = (*PolarScatter)(nil)
+	_ PolarPlotter = (*PolarHist)(nil)
+
+	_ plot.Thumbnailer = (*PolarLine)(nil)
+	_ plot.Thumbnailer = (*PolarScatter)(nil)
+	_ plot.Thumbnailer = (*PolarHist)(nil)
+)
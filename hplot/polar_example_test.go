@@ -0,0 +1,104 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+func ExamplePolar() {
+	// φ occupancy of the 16 sectors of a detector,
+	// with a modulation of the acceptance.
+	var (
+		src  = rand.New(rand.NewSource(1234))
+		hist = hbook.NewH1D(16, -math.Pi, +math.Pi)
+		acc  = func(phi float64) float64 { return 1 + 0.3*math.Cos(2*phi) }
+	)
+	for hist.Entries() < 5000 {
+		phi := (2*src.Float64() - 1) * math.Pi
+		if 1.3*src.Float64() < acc(phi) {
+			hist.Fill(phi, 1)
+		}
+	}
+
+	p := hplot.NewPolar()
+	p.Title.Text = "φ occupancy per sector"
+	p.Phi.Unit = hplot.AngleRadians
+	p.Phi.Signed = true
+	p.Phi.Step = math.Pi / 4
+	p.R.Angle = 3 * math.Pi / 8
+
+	h := hplot.NewPolarHist(hist)
+	h.FillColor = color.NRGBA{B: 255, A: 80}
+	h.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+
+	norm := float64(hist.Entries()) / float64(hist.Len())
+	f := hplot.NewPolarFunction(func(phi float64) float64 {
+		return norm * acc(phi)
+	})
+	f.LineStyle.Color = color.NRGBA{R: 255, A: 255}
+	f.LineStyle.Width = vg.Points(1.5)
+
+	p.Add(h, f)
+
+	err := hplot.Save(p, 12*vg.Centimeter, -1, "testdata/polar_hist.png")
+	if err != nil {
+		log.Fatalf("could not save plot: %+v", err)
+	}
+}
+
+func ExamplePolar_geometry() {
+	// hits on the 3 layers of a barrel detector, in the transverse plane.
+	var (
+		src    = rand.New(rand.NewSource(1234))
+		layers = []float64{30, 50, 80} // radius of the layers, in mm.
+		hits   plotter.XYs
+	)
+	for i := 0; i < 20; i++ {
+		phi := 2 * math.Pi * src.Float64()
+		for _, r := range layers {
+			hits = append(hits, plotter.XY{X: phi + 0.02*src.NormFloat64(), Y: r})
+		}
+	}
+
+	p := hplot.NewPolar()
+	p.Title.Text = "Barrel hits"
+	p.R.Label.Text = "r [mm]"
+	p.R.Min = 0
+	p.R.Max = 100
+	p.Phi.Zero = math.Pi / 2 // φ=0 at the top,
+	p.Phi.Clockwise = true   // increasing clockwise.
+	p.Phi.Step = math.Pi / 4
+
+	for _, r := range layers {
+		r := r
+		layer := hplot.NewPolarFunction(func(float64) float64 { return r })
+		layer.LineStyle.Color = color.Gray{Y: 100}
+		layer.LineStyle.Width = vg.Points(2)
+		p.Add(layer)
+	}
+
+	s, err := hplot.NewPolarScatter(hits)
+	if err != nil {
+		log.Fatalf("could not create scatter: %+v", err)
+	}
+	s.GlyphStyle.Shape = draw.CircleGlyph{}
+	s.GlyphStyle.Color = color.NRGBA{R: 255, A: 255}
+	p.Add(s)
+
+	err = hplot.Save(p, 12*vg.Centimeter, -1, "testdata/polar_geometry.png")
+	if err != nil {
+		log.Fatalf("could not save plot: %+v", err)
+	}
+}
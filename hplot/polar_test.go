@@ -0,0 +1,51 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestPolar(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExamplePolar, t, "polar_hist.png")
+	checkPlot(cmpimg.CheckPlot)(ExamplePolar_geometry, t, "polar_geometry.png")
+}
+
+func TestPolarPhiLabel(t *testing.T) {
+	for _, tc := range []struct {
+		unit   hplot.AngleUnit
+		signed bool
+		phi    float64
+		want   string
+	}{
+		{hplot.AngleDegrees, false, 0, "0°"},
+		{hplot.AngleDegrees, false, math.Pi / 6, "30°"},
+		{hplot.AngleDegrees, false, 3 * math.Pi / 2, "270°"},
+		{hplot.AngleDegrees, false, 2 * math.Pi, "0°"},
+		{hplot.AngleDegrees, false, -math.Pi / 2, "270°"},
+		{hplot.AngleDegrees, true, 3 * math.Pi / 2, "-90°"},
+		{hplot.AngleDegrees, true, math.Pi, "180°"},
+		{hplot.AngleRadians, false, 0, "0"},
+		{hplot.AngleRadians, false, math.Pi, "π"},
+		{hplot.AngleRadians, false, math.Pi / 2, "π/2"},
+		{hplot.AngleRadians, false, 2 * math.Pi / 3, "2π/3"},
+		{hplot.AngleRadians, false, 7 * math.Pi / 4, "7π/4"},
+		{hplot.AngleRadians, true, 7 * math.Pi / 4, "-π/4"},
+		{hplot.AngleRadians, true, 5 * math.Pi / 4, "-3π/4"},
+		{hplot.AngleRadians, true, math.Pi, "π"},
+		{hplot.AngleRadians, false, 1, "1"},
+	} {
+		axis := hplot.PolarPhiAxis{Unit: tc.unit, Signed: tc.signed}
+		if got := axis.Label(tc.phi); got != tc.want {
+			t.Errorf("invalid label for φ=%v (unit=%v, signed=%v): got=%q, want=%q",
+				tc.phi, tc.unit, tc.signed, got, tc.want,
+			)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PullKind describes the quantity displayed on the bottom plot of a PullPlot.
+type PullKind int
+
+const (
+	// PullNormalized displays the pulls, (data-model)/σ, where σ is the
+	// uncertainty on the difference between the data and the model.
+	PullNormalized PullKind = iota
+	// PullResidual displays the residuals, data-model.
+	PullResidual
+)
+
+// PullPlot displays data and a model on the top plot, and the pulls
+// (or residuals) of the data with respect to the model on the bottom plot.
+type PullPlot struct {
+	*RatioPlot
+
+	// Kind is the quantity displayed on the bottom plot.
+	Kind PullKind
+
+	// Data is the data histogram, displayed on the top plot.
+	Data *H1D
+
+	// Model is the model, displayed on the top plot.
+	// Model is a *H1D for histogram models and a *Function
+	// for function models.
+	Model plot.Plotter
+
+	// Pulls holds the pulls (or residuals) of each bin,
+	// displayed on the bottom plot.
+	// Bins with a null uncertainty have a null pull.
+	Pulls *H1D
+
+	// Ref is the reference line at 0.
+	Ref *HorizLine
+
+	// Bands are the ±1σ and ±2σ guide bands around the reference line.
+	// For residuals, the bands follow the uncertainty of each bin.
+	Bands [2]*BinnedErrBand
+}
+
+// NewPullPlot creates a new pull plot from the provided data and
+// model histograms.
+// Both histograms must have the same binning.
+//
+// The uncertainty σ of each bin combines the statistical uncertainties of
+// the data and of the model, √(sum of squared weights).
+// The data uncertainties can be customized with the WithYErrBarsFunc option.
+// Residuals are displayed instead of pulls with the WithPullKind option.
+func NewPullPlot(data, model *hbook.H1D, opts ...Options) (*PullPlot, error) {
+	if err := checkPullBinning(data, model); err != nil {
+		return nil, fmt.Errorf("hplot: could not create pull plot: %w", err)
+	}
+
+	cfg := newConfig(opts)
+	mod := NewH1D(model, WithLogY(cfg.log.y))
+	mod.LineStyle.Color = color.NRGBA{R: 255, A: 255}
+
+	pp := newPullPlot(data, mod, cfg, func(i int) (float64, float64) {
+		bin := model.Binning.Bins[i]
+		return bin.SumW(), bin.ErrW()
+	})
+	return pp, nil
+}
+
+// NewPullPlotFunc creates a new pull plot from the provided data histogram
+// and model function.
+// The model function is evaluated at the center of each bin and is
+// considered to have no uncertainty.
+//
+// The data uncertainties can be customized with the WithYErrBarsFunc option.
+// Residuals are displayed instead of pulls with the WithPullKind option.
+func NewPullPlotFunc(data *hbook.H1D, model func(x float64) float64, opts ...Options) (*PullPlot, error) {
+	if model == nil {
+		return nil, fmt.Errorf("hplot: could not create pull plot: nil model function")
+	}
+
+	cfg := newConfig(opts)
+	mod := NewFunction(model)
+	mod.XMin = data.XMin()
+	mod.XMax = data.XMax()
+	mod.Samples = 200
+	mod.LineStyle.Color = color.NRGBA{R: 255, A: 255}
+	mod.LogY = cfg.log.y
+
+	pp := newPullPlot(data, mod, cfg, func(i int) (float64, float64) {
+		return model(data.Binning.Bins[i].XMid()), 0
+	})
+	return pp, nil
+}
+
+func newPullPlot(data *hbook.H1D, model plot.Plotter, cfg *config, eval func(i int) (val, err float64)) *PullPlot {
+	yerrf := cfg.bars.yerrf
+	if yerrf == nil {
+		yerrf = func(bin hbook.Bin1D) (float64, float64) {
+			e := bin.ErrW()
+			return e, e
+		}
+	}
+
+	pp := &PullPlot{
+		RatioPlot: NewRatioPlot(),
+		Kind:      cfg.pull,
+		Data: NewH1D(data, WithYErrBarsFunc(yerrf), WithLogY(cfg.log.y), WithGlyphStyle(draw.GlyphStyle{
+			Shape:  draw.CircleGlyph{},
+			Color:  color.Black,
+			Radius: vg.Points(2),
+		})),
+		Model: model,
+		Ref:   HLine(0, nil, nil),
+	}
+	pp.ShareX = true
+
+	pp.Data.LineStyle.Width = 0
+	pp.Data.YErrs.LineStyle.Width = vg.Points(1)
+
+	var (
+		bins  = data.Binning.Bins
+		edges = make([]float64, 0, len(bins)+1)
+		pulls = make([]float64, len(bins))
+		sigs  = make([]float64, len(bins))
+	)
+	for i, bin := range bins {
+		edges = append(edges, bin.XMin())
+		var (
+			dat    = bin.SumW()
+			mod, e = eval(i)
+			lo, hi = yerrf(bin)
+		)
+		// use the data uncertainty in the direction of the model.
+		sig := hi
+		if dat > mod {
+			sig = lo
+		}
+		sig = math.Hypot(sig, e)
+		sigs[i] = sig
+
+		switch pp.Kind {
+		case PullResidual:
+			pulls[i] = dat - mod
+		default:
+			if sig != 0 {
+				pulls[i] = (dat - mod) / sig
+			}
+		}
+	}
+	edges = append(edges, data.XMax())
+
+	hpull := hbook.NewH1DFromEdges(edges)
+	for i, bin := range bins {
+		hpull.Fill(bin.XMid(), pulls[i])
+	}
+	pp.Pulls = NewH1D(hpull)
+	pp.Pulls.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+	pp.Pulls.FillColor = color.NRGBA{B: 255, A: 96}
+
+	for i, n := range []float64{2, 1} {
+		cs := make([]hbook.Count, 0, len(bins))
+		for j, bin := range bins {
+			var c hbook.Count
+			c.XRange = bin.Range
+			switch pp.Kind {
+			case PullResidual:
+				if sigs[j] == 0 {
+					continue
+				}
+				c.Err.Low = n * sigs[j]
+			default:
+				c.Err.Low = n
+			}
+			c.Err.High = c.Err.Low
+			cs = append(cs, c)
+		}
+		band := NewBinnedErrBand(cs)
+		band.FillColor = color.Gray{Y: uint8(230 - 30*i)}
+		pp.Bands[i] = band
+		if len(cs) > 0 {
+			pp.Bottom.Add(band)
+		}
+	}
+	pp.Ref.Line.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+
+	if cfg.log.y {
+		pp.Top.Y.Scale = plot.LogScale{}
+		pp.Top.Y.Tick.Marker = plot.LogTicks{Prec: -1}
+	}
+
+	pp.Top.Add(pp.Model, pp.Data)
+	pp.Bottom.Add(pp.Ref, pp.Pulls)
+	switch pp.Kind {
+	case PullResidual:
+		pp.Bottom.Y.Label.Text = "Residual"
+	default:
+		pp.Bottom.Y.Label.Text = "Pull"
+	}
+
+	return pp
+}
+
+// checkPullBinning checks the data and model histograms have the
+// same binning.
+func checkPullBinning(data, model *hbook.H1D) error {
+	if data.Len() != model.Len() {
+		return fmt.Errorf("histograms with different number of bins (data=%d, model=%d)", data.Len(), model.Len())
+	}
+	for i, bd := range data.Binning.Bins {
+		bm := model.Binning.Bins[i]
+		if bd.Range != bm.Range {
+			return fmt.Errorf("histograms with different binning (bin=%d, data=%v, model=%v)", i, bd.Range, bm.Range)
+		}
+	}
+	return nil
+}
+
+var (
+	_ Drawer = (*PullPlot)(nil)
+)
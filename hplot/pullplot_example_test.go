@@ -0,0 +1,109 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExamplePullPlot() {
+	const (
+		npoints = 2000
+		nbins   = 30
+		xmin    = -3.0
+		xmax    = +3.0
+	)
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	data := hbook.NewH1D(nbins, xmin, xmax)
+	for i := 0; i < npoints; i++ {
+		data.Fill(dist.Rand(), 1)
+	}
+
+	// Model: expected number of entries per bin.
+	const width = (xmax - xmin) / nbins
+	model := func(x float64) float64 {
+		return npoints * width * dist.Prob(x)
+	}
+
+	pp, err := hplot.NewPullPlotFunc(data, model)
+	if err != nil {
+		log.Fatalf("could not create pull plot: %+v", err)
+	}
+
+	pp.Top.Title.Text = "Data/Model"
+	pp.Top.Y.Label.Text = "Entries"
+	pp.Top.Legend.Add("data", pp.Data)
+	pp.Top.Legend.Add("model", pp.Model.(*hplot.Function))
+	pp.Top.Legend.Top = true
+
+	pp.Bottom.X.Label.Text = "X"
+
+	const (
+		w = 15 * vg.Centimeter
+		h = w / math.Phi
+	)
+
+	err = hplot.Save(pp, w, h, "testdata/pull_plot.png")
+	if err != nil {
+		log.Fatalf("error: %v\n", err)
+	}
+}
+
+func ExamplePullPlot_residuals() {
+	const npoints = 10000
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	data := hbook.NewH1D(20, -3, +3)
+	simu := hbook.NewH1D(20, -3, +3)
+
+	for i := 0; i < npoints; i++ {
+		data.Fill(dist.Rand()+0.1, 1)
+		simu.Fill(dist.Rand(), 1)
+	}
+
+	pp, err := hplot.NewPullPlot(data, simu, hplot.WithPullKind(hplot.PullResidual))
+	if err != nil {
+		log.Fatalf("could not create residual plot: %+v", err)
+	}
+
+	pp.Top.Title.Text = "Data-Simulation"
+	pp.Top.Y.Label.Text = "Entries"
+	pp.Top.Legend.Add("data", pp.Data)
+	pp.Top.Legend.Add("simu", pp.Model.(*hplot.H1D))
+	pp.Top.Legend.Top = true
+
+	pp.Bottom.X.Label.Text = "X"
+	pp.Bottom.Y.Label.Text = "Data-Simu"
+
+	const (
+		width  = 15 * vg.Centimeter
+		height = width / math.Phi
+	)
+
+	err = hplot.Save(pp, width, height, "testdata/residual_plot.png")
+	if err != nil {
+		log.Fatalf("error: %v\n", err)
+	}
+}
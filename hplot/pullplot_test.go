@@ -0,0 +1,114 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestPullPlot(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExamplePullPlot, t, "pull_plot.png")
+	checkPlot(cmpimg.CheckPlot)(ExamplePullPlot_residuals, t, "residual_plot.png")
+}
+
+func TestPullPlotErrors(t *testing.T) {
+	_, err := hplot.NewPullPlot(hbook.NewH1D(10, 0, 10), hbook.NewH1D(10, 0, 20))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	_, err = hplot.NewPullPlot(hbook.NewH1D(10, 0, 10), hbook.NewH1D(5, 0, 10))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	_, err = hplot.NewPullPlotFunc(hbook.NewH1D(10, 0, 10), nil)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestPullPlotValues(t *testing.T) {
+	data := hbook.NewH1D(3, 0, 3)
+	data.Fill(0.5, 4)
+	data.Fill(1.5, 1)
+	data.Fill(1.5, 1)
+	data.Fill(1.5, 1)
+	data.Fill(1.5, 1)
+
+	model := func(x float64) float64 { return 2 }
+
+	for _, tc := range []struct {
+		kind  hplot.PullKind
+		want  []float64
+		bands []float64
+	}{
+		{
+			kind: hplot.PullNormalized,
+			// bin-0: (4-2)/4, bin-1: (4-2)/2, bin-2: no uncertainty.
+			want:  []float64{0.5, 1, 0},
+			bands: []float64{1, 1, 1},
+		},
+		{
+			kind:  hplot.PullResidual,
+			want:  []float64{2, 2, -2},
+			bands: []float64{4, 2},
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			pp, err := hplot.NewPullPlotFunc(data, model, hplot.WithPullKind(tc.kind))
+			if err != nil {
+				t.Fatalf("could not create pull plot: %+v", err)
+			}
+			if pp.Kind != tc.kind {
+				t.Fatalf("invalid kind: got=%v, want=%v", pp.Kind, tc.kind)
+			}
+
+			for i, want := range tc.want {
+				got := pp.Pulls.Hist.Value(i)
+				if math.Abs(got-want) > 1e-12 {
+					t.Fatalf("invalid pull[%d]: got=%v, want=%v", i, got, want)
+				}
+			}
+
+			band := pp.Bands[1]
+			if got, want := len(band.Counts), len(tc.bands); got != want {
+				t.Fatalf("invalid number of band bins: got=%d, want=%d", got, want)
+			}
+			for i, want := range tc.bands {
+				c := band.Counts[i]
+				if c.Err.Low != want || c.Err.High != want {
+					t.Fatalf("invalid 1-sigma band[%d]: got=%+v, want=%v", i, c.Err, want)
+				}
+				if got := pp.Bands[0].Counts[i].Err.Low; got != 2*want {
+					t.Fatalf("invalid 2-sigma band[%d]: got=%v, want=%v", i, got, 2*want)
+				}
+			}
+		})
+	}
+}
+
+func TestPullPlotModelErrors(t *testing.T) {
+	data := hbook.NewH1D(1, 0, 1)
+	data.Fill(0.5, 9)
+	model := hbook.NewH1D(1, 0, 1)
+	model.Fill(0.5, 4)
+	model.Fill(0.5, 4)
+
+	// σ = hypot(9, 4*√2)
+	pp, err := hplot.NewPullPlot(data, model)
+	if err != nil {
+		t.Fatalf("could not create pull plot: %+v", err)
+	}
+	want := (9.0 - 8.0) / math.Hypot(9, 4*math.Sqrt2)
+	if got := pp.Pulls.Hist.Value(0); math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid pull: got=%v, want=%v", got, want)
+	}
+}
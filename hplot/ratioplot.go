@@ -5,6 +5,11 @@
 package hplot
 
 import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"go-hep.org/x/hep/hbook"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
@@ -23,6 +28,10 @@ type RatioPlot struct {
 	// The top plot will take (1-ratio)*height.
 	// Default is 0.3.
 	Ratio float64
+
+	// ShareX synchronizes the X ranges of the top and bottom plots
+	// before drawing them.
+	ShareX bool
 }
 
 func NewRatioPlot() *RatioPlot {
@@ -57,6 +66,13 @@ func NewRatioPlot() *RatioPlot {
 // taken into account when padding the plot so that
 // none of their glyphs are clipped.
 func (rp *RatioPlot) Draw(dc draw.Canvas) {
+	if rp.ShareX {
+		xmin := math.Min(rp.Top.X.Min, rp.Bottom.X.Min)
+		xmax := math.Max(rp.Top.X.Max, rp.Bottom.X.Max)
+		rp.Top.X.Min, rp.Bottom.X.Min = xmin, xmin
+		rp.Top.X.Max, rp.Bottom.X.Max = xmax, xmax
+	}
+
 	var (
 		top, bot = rp.align(dc)
 	)
@@ -86,6 +102,231 @@ func (rp *RatioPlot) align(dc draw.Canvas) (top, bot draw.Canvas) {
 	return top, bot
 }
 
+// H1DRatioPlot is a ratio plot comparing two 1-dim histograms:
+//   - the top plot displays the numerator and denominator histograms,
+//   - the bottom plot displays their ratio, together with a reference
+//     line at 1.
+type H1DRatioPlot struct {
+	*RatioPlot
+
+	// Num and Den are the numerator and denominator histograms,
+	// displayed on the top plot.
+	Num *H1D
+	Den *H1D
+
+	// Div is the ratio of the numerator and denominator histograms,
+	// displayed on the bottom plot.
+	// The statistical uncertainties of both histograms are propagated
+	// to the ratio, assuming they are uncorrelated.
+	Div *S2D
+
+	// Ref is the reference line at 1.
+	Ref *HorizLine
+
+	// Band displays the relative uncertainties of the denominator
+	// around the reference line.
+	// Band is nil unless enabled with the WithBand option.
+	Band *BinnedErrBand
+}
+
+// NewH1DRatioPlot returns a ratio plot comparing the num and den histograms.
+//
+// The X axes of the top and bottom plots share the same range, and the
+// X labels of the top plot are hidden.
+// NewH1DRatioPlot returns an error if the binnings of the histograms
+// are not compatible.
+//
+// The WithBand option enables the display of the relative uncertainties
+// of the denominator around the reference line.
+// The WithLogY option enables the log-scale of the Y axis of the top plot.
+func NewH1DRatioPlot(num, den *hbook.H1D, opts ...Options) (*H1DRatioPlot, error) {
+	cfg := newConfig(opts)
+
+	div, err := hbook.DivideH1D(num, den, hbook.DivIgnoreNaNs())
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not divide histograms: %w", err)
+	}
+
+	yerrs := WithYErrBars(true)
+	if cfg.bars.yerrf != nil {
+		yerrs = WithYErrBarsFunc(cfg.bars.yerrf)
+		ratioErrs(div, num, den, cfg.bars.yerrf)
+	}
+
+	rp := &H1DRatioPlot{
+		RatioPlot: NewRatioPlot(),
+		Num:       NewH1D(num, yerrs, WithLogY(cfg.log.y)),
+		Den:       NewH1D(den, WithLogY(cfg.log.y)),
+		Div:       NewS2D(div, WithXErrBars(true), WithYErrBars(true)),
+		Ref:       HLine(1, nil, nil),
+	}
+	rp.ShareX = true
+
+	rp.Num.LineStyle.Color = color.NRGBA{R: 255, A: 255}
+	rp.Num.YErrs.LineStyle.Color = rp.Num.LineStyle.Color
+	rp.Den.LineStyle.Color = color.NRGBA{B: 255, A: 255}
+	rp.Den.FillColor = color.NRGBA{B: 255, A: 64}
+
+	rp.Div.GlyphStyle.Shape = draw.CircleGlyph{}
+	rp.Div.GlyphStyle.Radius = vg.Points(2)
+	rp.Ref.Line.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+
+	if cfg.band {
+		rp.Band = newRefBand(den)
+		rp.Band.FillColor = color.Gray{200}
+		if len(rp.Band.Counts) > 0 {
+			rp.Bottom.Add(rp.Band)
+		}
+	}
+
+	if cfg.log.y {
+		rp.Top.Y.Scale = plot.LogScale{}
+		rp.Top.Y.Tick.Marker = plot.LogTicks{Prec: -1}
+	}
+
+	rp.Top.Add(rp.Den, rp.Num)
+	rp.Bottom.Add(rp.Ref, rp.Div)
+	rp.Bottom.Y.Label.Text = "Ratio"
+
+	return rp, nil
+}
+
+// H1DComparePlot is a ratio plot comparing two 1-dim histograms
+// (e.g. data and simulation), annotated with the results of the χ² and
+// Kolmogorov-Smirnov tests of the compatibility of their shapes.
+type H1DComparePlot struct {
+	*H1DRatioPlot
+
+	// Chi2, NDF and Chi2Prob are the χ², the number of degrees of
+	// freedom and the p-value of the χ² test, as computed by
+	// hbook.Chi2TestH1D.
+	Chi2     float64
+	NDF      int
+	Chi2Prob float64
+
+	// KS and KSProb are the distance and the probability of the
+	// Kolmogorov-Smirnov test, as computed by hbook.KSTestH1D.
+	KS     float64
+	KSProb float64
+
+	// Stats is the box displaying the results of the tests,
+	// on the top plot.
+	Stats *TextBox
+}
+
+// NewH1DComparePlot returns a ratio plot comparing the num and den
+// histograms, annotated with the results of the χ² and Kolmogorov-Smirnov
+// tests of their compatibility.
+//
+// The options are the same ones than for NewH1DRatioPlot.
+// NewH1DComparePlot returns an error if the binnings of the histograms
+// are not compatible or if one of the histograms is empty.
+func NewH1DComparePlot(num, den *hbook.H1D, opts ...Options) (*H1DComparePlot, error) {
+	rp, err := NewH1DRatioPlot(num, den, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	chi2, ndf, chi2p, err := hbook.Chi2TestH1D(num, den)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not run χ² test: %w", err)
+	}
+
+	ks, ksp, err := hbook.KSTestH1D(num, den)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not run Kolmogorov-Smirnov test: %w", err)
+	}
+
+	cp := &H1DComparePlot{
+		H1DRatioPlot: rp,
+		Chi2:         chi2,
+		NDF:          ndf,
+		Chi2Prob:     chi2p,
+		KS:           ks,
+		KSProb:       ksp,
+	}
+	cp.Stats = NewTextBox(
+		0.03, 0.95, cp.Text(),
+		WithLabelNormalized(true),
+		WithLabelTextStyle(draw.TextStyle{
+			Color:   color.Black,
+			Font:    DefaultStyle.Fonts.Tick,
+			XAlign:  draw.XLeft,
+			YAlign:  draw.YTop,
+			Handler: DefaultStyle.TextHandler,
+		}),
+	)
+	cp.Top.Add(cp.Stats)
+
+	return cp, nil
+}
+
+// Text returns the text displaying the results of the compatibility tests.
+func (cp *H1DComparePlot) Text() string {
+	return fmt.Sprintf(
+		"χ²/ndf = %.4g/%d (p = %.3g)\nKS = %.3g (p = %.3g)",
+		cp.Chi2, cp.NDF, cp.Chi2Prob, cp.KS, cp.KSProb,
+	)
+}
+
+// ratioErrs updates the Y errors of the ratio of the provided histograms
+// with the (possibly asymmetric) errors of the numerator, computed with f,
+// combined with the relative errors of the denominator.
+func ratioErrs(div *hbook.S2D, num, den *hbook.H1D, f func(bin hbook.Bin1D) (low, high float64)) {
+	var (
+		pts  = div.Points()
+		bins = num.Binning.Bins
+		j    = 0
+	)
+	for i := range pts {
+		pt := &pts[i]
+		for j < len(bins) && bins[j].XMid() != pt.X {
+			j++
+		}
+		if j == len(bins) {
+			return
+		}
+		var (
+			bn     = bins[j]
+			bd     = den.Binning.Bins[j]
+			n      = bn.SumW()
+			d      = bd.SumW()
+			rel    = math.Sqrt(bd.SumW2()) / math.Abs(d)
+			lo, hi = f(bn)
+		)
+		switch {
+		case n != 0:
+			pt.ErrY.Min = math.Abs(pt.Y) * math.Hypot(lo/n, rel)
+			pt.ErrY.Max = math.Abs(pt.Y) * math.Hypot(hi/n, rel)
+		default:
+			pt.ErrY.Min = math.Abs(lo / d)
+			pt.ErrY.Max = math.Abs(hi / d)
+		}
+	}
+}
+
+// newRefBand returns the relative uncertainties of the provided
+// histogram around 1.
+// Bins with no content are discarded.
+func newRefBand(h *hbook.H1D) *BinnedErrBand {
+	cs := make([]hbook.Count, 0, h.Len())
+	for _, bin := range h.Binning.Bins {
+		sumw := bin.SumW()
+		if sumw == 0 {
+			continue
+		}
+		var c hbook.Count
+		c.XRange = bin.Range
+		c.Val = 1
+		c.Err.Low = math.Sqrt(bin.SumW2()) / math.Abs(sumw)
+		c.Err.High = c.Err.Low
+		cs = append(cs, c)
+	}
+	return NewBinnedErrBand(cs)
+}
+
 var (
 	_ Drawer = (*RatioPlot)(nil)
+	_ Drawer = (*H1DRatioPlot)(nil)
+	_ Drawer = (*H1DComparePlot)(nil)
 )
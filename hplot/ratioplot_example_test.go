@@ -14,6 +14,7 @@ import (
 	"golang.org/x/exp/rand"
 	"gonum.org/v1/gonum/stat/distuv"
 	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
 )
 
 func ExampleRatioPlot() {
@@ -81,3 +82,157 @@ func ExampleRatioPlot() {
 		log.Fatalf("error: %v\n", err)
 	}
 }
+
+func ExampleH1DRatioPlot() {
+	const npoints = 100000
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	data := hbook.NewH1D(20, -3, +3)
+	simu := hbook.NewH1D(20, -3, +3)
+
+	for i := 0; i < npoints; i++ {
+		data.Fill(dist.Rand()+0.1, 1)
+		simu.Fill(dist.Rand(), 1)
+	}
+
+	rp, err := hplot.NewH1DRatioPlot(data, simu, hplot.WithBand(true))
+	if err != nil {
+		log.Fatalf("could not create ratio plot: %+v", err)
+	}
+
+	rp.Top.Title.Text = "Data/Simulation"
+	rp.Top.Y.Label.Text = "Entries"
+	rp.Top.Add(hplot.NewGrid())
+
+	rp.Bottom.X.Label.Text = "X"
+	rp.Bottom.Y.Label.Text = "Data/Simu"
+	rp.Bottom.Add(hplot.NewGrid())
+
+	const (
+		width  = 15 * vg.Centimeter
+		height = width / math.Phi
+	)
+
+	err = hplot.Save(rp, width, height, "testdata/h1d_ratio_plot.png")
+	if err != nil {
+		log.Fatalf("error: %v\n", err)
+	}
+}
+
+func ExampleH1DRatioPlot_withPoissonErrors() {
+	const npoints = 200
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	data := hbook.NewH1D(20, -3, +3)
+	simu := hbook.NewH1D(20, -3, +3)
+
+	for i := 0; i < npoints; i++ {
+		data.Fill(dist.Rand(), 1)
+	}
+	for i := 0; i < 100*npoints; i++ {
+		simu.Fill(dist.Rand(), 0.01)
+	}
+
+	// Display the (asymmetric) Poisson errors of the data,
+	// both in the top and the ratio panels.
+	rp, err := hplot.NewH1DRatioPlot(data, simu,
+		hplot.WithYErrBarsFunc(hplot.PoissonErrors),
+		hplot.WithBand(true),
+	)
+	if err != nil {
+		log.Fatalf("could not create ratio plot: %+v", err)
+	}
+
+	rp.Num.LineStyle.Width = 0
+	rp.Num.GlyphStyle.Shape = draw.CircleGlyph{}
+	rp.Num.GlyphStyle.Radius = vg.Points(2)
+	rp.Num.YErrs.LineStyle.Color = color.Black
+	rp.Num.YErrs.LineStyle.Width = vg.Points(1)
+
+	rp.Top.Title.Text = "Data/Simulation"
+	rp.Top.Y.Label.Text = "Entries"
+	rp.Top.Legend.Add("data", rp.Num)
+	rp.Top.Legend.Add("simu", rp.Den)
+	rp.Top.Legend.Top = true
+
+	rp.Bottom.X.Label.Text = "X"
+	rp.Bottom.Y.Label.Text = "Data/Simu"
+
+	const (
+		width  = 15 * vg.Centimeter
+		height = width / math.Phi
+	)
+
+	err = hplot.Save(rp, width, height, "testdata/h1d_ratio_plot_poisson.png")
+	if err != nil {
+		log.Fatalf("error: %v\n", err)
+	}
+}
+
+func ExampleH1DComparePlot() {
+	const npoints = 1000
+
+	// Create a normal distribution.
+	dist := distuv.Normal{
+		Mu:    0,
+		Sigma: 1,
+		Src:   rand.New(rand.NewSource(0)),
+	}
+
+	data := hbook.NewH1D(20, -3, +3)
+	simu := hbook.NewH1D(20, -3, +3)
+
+	for i := 0; i < npoints; i++ {
+		data.Fill(1.1*dist.Rand()+0.1, 1)
+	}
+	for i := 0; i < 10*npoints; i++ {
+		simu.Fill(dist.Rand(), 0.1)
+	}
+
+	cp, err := hplot.NewH1DComparePlot(data, simu,
+		hplot.WithYErrBarsFunc(hplot.PoissonErrors),
+		hplot.WithBand(true),
+	)
+	if err != nil {
+		log.Fatalf("could not create comparison plot: %+v", err)
+	}
+
+	cp.Num.LineStyle.Width = 0
+	cp.Num.GlyphStyle.Shape = draw.CircleGlyph{}
+	cp.Num.GlyphStyle.Radius = vg.Points(2)
+	cp.Num.YErrs.LineStyle.Color = color.Black
+	cp.Num.YErrs.LineStyle.Width = vg.Points(1)
+
+	cp.Top.Title.Text = "Data/Simulation"
+	cp.Top.Y.Label.Text = "Entries"
+	cp.Top.Legend.Add("data", cp.Num)
+	cp.Top.Legend.Add("simu", cp.Den)
+	cp.Top.Legend.Top = true
+
+	cp.Bottom.X.Label.Text = "X"
+	cp.Bottom.Y.Label.Text = "Data/Simu"
+	cp.Bottom.Y.Min = 0
+	cp.Bottom.Y.Max = 2
+
+	const (
+		width  = 15 * vg.Centimeter
+		height = width / math.Phi
+	)
+
+	err = hplot.Save(cp, width, height, "testdata/h1d_compare_plot.png")
+	if err != nil {
+		log.Fatalf("error: %v\n", err)
+	}
+}
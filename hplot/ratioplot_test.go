@@ -5,11 +5,80 @@
 package hplot_test
 
 import (
+	"math"
 	"testing"
 
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
 	"gonum.org/v1/plot/cmpimg"
 )
 
 func TestRatioPlot(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleRatioPlot, t, "diff_plot.png")
 }
+
+func TestH1DRatioPlot(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleH1DRatioPlot, t, "h1d_ratio_plot.png")
+	checkPlot(cmpimg.CheckPlot)(ExampleH1DRatioPlot_withPoissonErrors, t, "h1d_ratio_plot_poisson.png")
+}
+
+func TestH1DComparePlot(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleH1DComparePlot, t, "h1d_compare_plot.png")
+
+	_, err := hplot.NewH1DComparePlot(hbook.NewH1D(10, 0, 10), hbook.NewH1D(10, 0, 10))
+	if err == nil {
+		t.Fatalf("expected an error for empty histograms")
+	}
+}
+
+func TestH1DRatioPlotErrors(t *testing.T) {
+	_, err := hplot.NewH1DRatioPlot(hbook.NewH1D(10, 0, 10), hbook.NewH1D(10, 0, 20))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestH1DRatioPlotAsymErrors(t *testing.T) {
+	num := hbook.NewH1D(2, 0, 2)
+	den := hbook.NewH1D(2, 0, 2)
+	num.Fill(0.5, 1)
+	num.Fill(0.5, 1)
+	num.Fill(1.5, 1)
+	den.Fill(0.5, 1)
+	den.Fill(1.5, 1)
+	den.Fill(1.5, 1)
+	den.Fill(1.5, 1)
+	den.Fill(1.5, 1)
+
+	rp, err := hplot.NewH1DRatioPlot(num, den, hplot.WithYErrBarsFunc(
+		func(bin hbook.Bin1D) (lo, hi float64) {
+			return 0.5 * bin.SumW(), bin.SumW()
+		},
+	))
+	if err != nil {
+		t.Fatalf("could not create ratio plot: %+v", err)
+	}
+
+	div := rp.Div.Data.(*hbook.S2D)
+	for i, want := range []struct {
+		y, lo, hi float64
+	}{
+		{y: 2, lo: 2 * math.Hypot(0.5, 1), hi: 2 * math.Hypot(1, 1)},
+		{y: 0.25, lo: 0.25 * math.Hypot(0.5, 0.5), hi: 0.25 * math.Hypot(1, 0.5)},
+	} {
+		pt := div.Point(i)
+		if pt.Y != want.y {
+			t.Fatalf("point %d: invalid ratio: got=%v, want=%v", i, pt.Y, want.y)
+		}
+		if math.Abs(pt.ErrY.Min-want.lo) > 1e-12 || math.Abs(pt.ErrY.Max-want.hi) > 1e-12 {
+			t.Fatalf("point %d: invalid errors: got=(%v, %v), want=(%v, %v)",
+				i, pt.ErrY.Min, pt.ErrY.Max, want.lo, want.hi,
+			)
+		}
+	}
+
+	yerr := rp.Num.YErrs.YErrors[0]
+	if yerr.Low != 1 || yerr.High != 2 {
+		t.Fatalf("invalid numerator errors: got=(%v, %v), want=(1, 2)", yerr.Low, yerr.High)
+	}
+}
@@ -0,0 +1,409 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rootcnv provides tools to convert ROOT canvases and pads to go-hep/hplot plots.
+//
+// Histograms and graphs read with groot may also be converted directly to
+// hplot plotters, with H1D, H2D and S2D, or to a complete plot, with Plot.
+//
+// The conversion is approximate: histograms, graphs, texts and lines drawn
+// on a pad are converted to their hplot equivalent, together with the
+// range, logarithmic scales and grids of the pad axes.
+// Drawing options, fonts and styles are not converted.
+package rootcnv // import "go-hep.org/x/hep/hplot/rootcnv"
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"go-hep.org/x/hep/groot/rcolors"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rpad"
+	"go-hep.org/x/hep/hbook/rootcnv"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// H1D creates a new hplot H1D from a ROOT TH1x.
+func H1D(h rhist.H1, opts ...hplot.Options) *hplot.H1D {
+	return hplot.NewH1D(rootcnv.H1D(h), opts...)
+}
+
+// H2D creates a new hplot H2D from a ROOT TH2x, using the provided palette.
+// If p is nil, a default palette is used.
+func H2D(h rhist.H2, p palette.Palette) *hplot.H2D {
+	return hplot.NewH2D(rootcnv.H2D(h), p)
+}
+
+// S2D creates a new hplot S2D from a ROOT TGraph, TGraphErrors or
+// TGraphAsymmErrors.
+//
+// The X and Y error bars of TGraphErrors and TGraphAsymmErrors are
+// displayed by default.
+func S2D(g rhist.Graph, opts ...hplot.Options) *hplot.S2D {
+	if _, ok := g.(rhist.GraphErrors); ok {
+		opts = append([]hplot.Options{
+			hplot.WithXErrBars(true),
+			hplot.WithYErrBars(true),
+		}, opts...)
+	}
+	return hplot.NewS2D(rootcnv.S2D(g), opts...)
+}
+
+// Plot creates a new plot displaying the provided ROOT histogram
+// (TH1x, TH2x) or graph (TGraph, TGraphErrors, TGraphAsymmErrors).
+//
+// The title of the plot and the titles of its axes are set from the ones
+// stored in the ROOT object.
+// Alphanumeric bin labels of the ROOT axes are used as tick labels.
+func Plot(o root.Object) (*hplot.Plot, error) {
+	var v plot.Plotter
+	switch o := o.(type) {
+	case rhist.H2:
+		v = H2D(o, nil)
+	case rhist.H1:
+		v = H1D(o)
+	case rhist.Graph:
+		v = S2D(o)
+	default:
+		return nil, fmt.Errorf("rootcnv: invalid ROOT object %q (type=%T)", name(o), o)
+	}
+
+	p := hplot.New()
+	setTitles(p, o)
+	p.Add(v)
+	return p, nil
+}
+
+// Canvas creates a new tiled plot from a ROOT TCanvas.
+//
+// Sub-pads of the canvas are laid out on a regular grid, inferred from
+// their position on the canvas.
+// A canvas without sub-pads is converted to a 1x1 tiled plot.
+func Canvas(c *rpad.Canvas) (*hplot.TiledPlot, error) {
+	var pads []*rpad.Pad
+	for _, o := range c.Pad().Primitives() {
+		if pad, ok := o.(*rpad.Pad); ok {
+			pads = append(pads, pad)
+		}
+	}
+
+	if len(pads) == 0 {
+		p, err := Pad(c.Pad())
+		if err != nil {
+			return nil, fmt.Errorf("rootcnv: could not convert canvas %q: %w", c.Name(), err)
+		}
+		tp := hplot.NewTiledPlot(draw.Tiles{Rows: 1, Cols: 1})
+		tp.Plots[0] = p
+		return tp, nil
+	}
+
+	var (
+		xs   = make([]float64, len(pads))
+		ys   = make([]float64, len(pads))
+		cols []float64
+		rows []float64
+	)
+	for i, pad := range pads {
+		xs[i], ys[i], _, _ = pad.NDC()
+		cols = insert(cols, xs[i])
+		rows = insert(rows, ys[i])
+	}
+	// ROOT NDC coordinates start at the bottom of the canvas, hplot tiles
+	// start at the top.
+	sort.Sort(sort.Reverse(sort.Float64Slice(rows)))
+
+	tp := hplot.NewTiledPlot(draw.Tiles{Rows: len(rows), Cols: len(cols)})
+	for i := range tp.Plots {
+		tp.Plots[i] = nil
+	}
+
+	for i, pad := range pads {
+		p, err := Pad(pad)
+		if err != nil {
+			return nil, fmt.Errorf("rootcnv: could not convert pad %q of canvas %q: %w", pad.Name(), c.Name(), err)
+		}
+		var (
+			col = index(cols, xs[i])
+			row = index(rows, ys[i])
+		)
+		tp.Plots[row*len(cols)+col] = p
+	}
+
+	return tp, nil
+}
+
+// Pad creates a new plot from a ROOT TPad.
+//
+// Histograms (TH1x, TH2x), graphs (TGraph, TGraphErrors, TGraphAsymmErrors),
+// texts (TText, TLatex) and lines (TLine) are converted.
+// Other primitives, including lines expressed in NDC and sub-pads, are ignored.
+func Pad(pad *rpad.Pad) (*hplot.Plot, error) {
+	p := hplot.New()
+	p.Title.Text = pad.Title()
+
+	var (
+		title = true
+		ps    []plot.Plotter
+	)
+	for _, o := range pad.Primitives() {
+		v, err := convert(pad, o)
+		if err != nil {
+			return nil, fmt.Errorf("rootcnv: could not convert primitive %q: %w", name(o), err)
+		}
+		if v == nil {
+			continue
+		}
+		if title {
+			title = setTitles(p, o)
+		}
+		ps = append(ps, v)
+	}
+	p.Add(ps...)
+
+	if pad.LogX() {
+		p.X.Scale = plot.LogScale{}
+		p.X.Tick.Marker = plot.LogTicks{}
+	}
+	if pad.LogY() {
+		p.Y.Scale = plot.LogScale{}
+		p.Y.Tick.Marker = plot.LogTicks{}
+	}
+
+	if pad.GridX() || pad.GridY() {
+		grid := hplot.NewGrid()
+		if !pad.GridX() {
+			grid.Vertical.Color = nil
+		}
+		if !pad.GridY() {
+			grid.Horizontal.Color = nil
+		}
+		p.Add(grid)
+	}
+
+	xmin, xmax, ymin, ymax := pad.UserRange()
+	if pad.LogX() {
+		xmin = math.Pow(10, xmin)
+		xmax = math.Pow(10, xmax)
+	}
+	if pad.LogY() {
+		ymin = math.Pow(10, ymin)
+		ymax = math.Pow(10, ymax)
+	}
+	if xmin < xmax {
+		p.X.Min = xmin
+		p.X.Max = xmax
+	}
+	if ymin < ymax {
+		p.Y.Min = ymin
+		p.Y.Max = ymax
+	}
+
+	return p, nil
+}
+
+func convert(pad *rpad.Pad, o root.Object) (plot.Plotter, error) {
+	switch o := o.(type) {
+	case rhist.H2:
+		return H2D(o, nil), nil
+
+	case rhist.H1:
+		return H1D(o, hplot.WithLogY(pad.LogY())), nil
+
+	case rhist.Graph:
+		return S2D(o), nil
+
+	case *rpad.Latex:
+		return newLabel(&o.Text), nil
+
+	case *rpad.Text:
+		return newLabel(o), nil
+
+	case *rpad.Line:
+		if o.NDC() {
+			return nil, nil
+		}
+		x1, y1, x2, y2 := o.Points()
+		line, err := hplot.NewLine(plotter.XYs{{X: x1, Y: y1}, {X: x2, Y: y2}})
+		if err != nil {
+			return nil, err
+		}
+		att := o.AttLine()
+		line.LineStyle.Color = rootColor(att.Color)
+		line.LineStyle.Width = vg.Points(float64(att.Width))
+		line.LineStyle.Dashes = rootDashes(att.Style)
+		return line, nil
+	}
+
+	return nil, nil
+}
+
+func newLabel(txt *rpad.Text) *hplot.Label {
+	x, y := txt.XY()
+	lbl := hplot.NewLabel(x, y, txt.Text(), hplot.WithLabelNormalized(txt.NDC()))
+	lbl.TextStyle.Color = rootColor(txt.AttText().Color)
+	return lbl
+}
+
+// setTitles sets the axes titles and bin labels of the plot from the
+// provided primitive.
+// setTitles returns whether the titles still need to be set.
+func setTitles(p *hplot.Plot, o root.Object) bool {
+	switch o.(type) {
+	case rhist.H1, rhist.H2, rhist.Graph:
+		// ok.
+	default:
+		return true
+	}
+
+	if p.Title.Text == "" {
+		p.Title.Text = o.(root.Named).Title()
+	}
+
+	type xaxiser interface{ XAxis() rhist.Axis }
+	type yaxiser interface{ YAxis() rhist.Axis }
+
+	// graphs only have axes when they hold a histogram.
+	if o, ok := o.(xaxiser); ok && o.XAxis() != nil {
+		p.X.Label.Text = o.XAxis().Title()
+		if ticks := binLabels(o.XAxis()); ticks != nil {
+			p.X.Tick.Marker = ticks
+		}
+	}
+	if o, ok := o.(yaxiser); ok && o.YAxis() != nil {
+		p.Y.Label.Text = o.YAxis().Title()
+		if _, ok := o.(rhist.H2); ok {
+			if ticks := binLabels(o.YAxis()); ticks != nil {
+				p.Y.Tick.Marker = ticks
+			}
+		}
+	}
+	return false
+}
+
+// binLabels returns tick marks, located at the center of the bins, with the
+// alphanumeric bin labels of the provided axis.
+// binLabels returns nil if the axis is nil or has no bin labels.
+func binLabels(axis rhist.Axis) plot.Ticker {
+	if axis == nil {
+		return nil
+	}
+	var ticks plot.ConstantTicks
+	for i := 1; i <= axis.NBins(); i++ {
+		lbl := axis.BinLabel(i)
+		if lbl == "" {
+			continue
+		}
+		ticks = append(ticks, plot.Tick{Value: axis.BinCenter(i), Label: lbl})
+	}
+	if len(ticks) == 0 {
+		return nil
+	}
+	return ticks
+}
+
+func name(o root.Object) string {
+	if o, ok := o.(root.Named); ok {
+		return o.Name()
+	}
+	return o.Class()
+}
+
+// rootColors holds the RGB values of the first ten colors of ROOT's
+// default color palette.
+var rootColors = [...]color.NRGBA{
+	{R: 255, G: 255, B: 255, A: 255}, // kWhite
+	{R: 0, G: 0, B: 0, A: 255},       // kBlack
+	{R: 255, G: 0, B: 0, A: 255},     // kRed
+	{R: 0, G: 255, B: 0, A: 255},     // kGreen
+	{R: 0, G: 0, B: 255, A: 255},     // kBlue
+	{R: 255, G: 255, B: 0, A: 255},   // kYellow
+	{R: 255, G: 0, B: 255, A: 255},   // kMagenta
+	{R: 0, G: 255, B: 255, A: 255},   // kCyan
+	{R: 89, G: 212, B: 84, A: 255},   // dark green
+	{R: 89, G: 84, B: 217, A: 255},   // dark blue
+}
+
+// rootNamedColors holds the RGB values of ROOT's named colors.
+var rootNamedColors = []struct {
+	idx int16
+	rgb color.NRGBA
+}{
+	{rcolors.Yellow, color.NRGBA{R: 255, G: 255, B: 0, A: 255}},
+	{rcolors.Green, color.NRGBA{R: 0, G: 255, B: 0, A: 255}},
+	{rcolors.Cyan, color.NRGBA{R: 0, G: 255, B: 255, A: 255}},
+	{rcolors.Blue, color.NRGBA{R: 0, G: 0, B: 255, A: 255}},
+	{rcolors.Magenta, color.NRGBA{R: 255, G: 0, B: 255, A: 255}},
+	{rcolors.Red, color.NRGBA{R: 255, G: 0, B: 0, A: 255}},
+	{rcolors.Orange, color.NRGBA{R: 255, G: 204, B: 0, A: 255}},
+	{rcolors.Spring, color.NRGBA{R: 204, G: 255, B: 0, A: 255}},
+	{rcolors.Teal, color.NRGBA{R: 0, G: 255, B: 204, A: 255}},
+	{rcolors.Azure, color.NRGBA{R: 0, G: 204, B: 255, A: 255}},
+	{rcolors.Violet, color.NRGBA{R: 204, G: 0, B: 255, A: 255}},
+	{rcolors.Pink, color.NRGBA{R: 255, G: 0, B: 204, A: 255}},
+	{rcolors.Gray, color.NRGBA{R: 204, G: 204, B: 204, A: 255}},
+}
+
+// rootColor returns the color associated with the provided ROOT color index.
+//
+// Only the first ten colors of ROOT's palette and the named colors
+// (kRed, kBlue, ...) are handled.
+// Named colors with an offset (e.g. kRed+2) are approximated with their base color.
+// Other indices are converted to black.
+func rootColor(idx int16) color.Color {
+	if 0 <= idx && int(idx) < len(rootColors) {
+		return rootColors[idx]
+	}
+
+	for _, c := range rootNamedColors {
+		if c.idx-10 <= idx && idx <= c.idx+4 {
+			return c.rgb
+		}
+	}
+	return rootColors[1]
+}
+
+// rootDashes returns the dash pattern associated with the provided
+// ROOT line style.
+func rootDashes(style int16) []vg.Length {
+	switch style {
+	case 2:
+		return []vg.Length{vg.Points(6), vg.Points(3)}
+	case 3:
+		return []vg.Length{vg.Points(1), vg.Points(3)}
+	case 4:
+		return []vg.Length{vg.Points(6), vg.Points(3), vg.Points(1), vg.Points(3)}
+	}
+	return nil
+}
+
+// insert inserts v into the sorted slice vs, if not already present.
+func insert(vs []float64, v float64) []float64 {
+	i := sort.SearchFloat64s(vs, v-eps)
+	if i < len(vs) && math.Abs(vs[i]-v) < eps {
+		return vs
+	}
+	vs = append(vs, 0)
+	copy(vs[i+1:], vs[i:])
+	vs[i] = v
+	return vs
+}
+
+func index(vs []float64, v float64) int {
+	for i, x := range vs {
+		if math.Abs(x-v) < eps {
+			return i
+		}
+	}
+	panic("rootcnv: impossible")
+}
+
+const eps = 1e-6
@@ -0,0 +1,266 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rootcnv
+
+import (
+	"image/color"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rcolors"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rpad"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+func TestCanvas(t *testing.T) {
+	f, err := groot.Open("../../groot/testdata/tcanvas.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := o.(*rpad.Canvas)
+
+	tp, err := Canvas(c)
+	if err != nil {
+		t.Fatalf("could not convert canvas: %+v", err)
+	}
+
+	if got, want := len(tp.Plots), 1; got != want {
+		t.Fatalf("invalid number of plots: got=%d, want=%d", got, want)
+	}
+
+	prims := c.Pad().Primitives()
+	if got, want := len(prims), 1; got != want {
+		t.Fatalf("invalid number of primitives: got=%d, want=%d", got, want)
+	}
+	v, err := convert(c.Pad(), prims[0])
+	if err != nil {
+		t.Fatalf("could not convert primitive: %+v", err)
+	}
+	if _, ok := v.(*hplot.S2D); !ok {
+		t.Fatalf("invalid plotter type: %T", v)
+	}
+
+	p := tp.Plots[0]
+	xmin, xmax, ymin, ymax := c.Pad().UserRange()
+	if xmin < xmax && (p.X.Min != xmin || p.X.Max != xmax) {
+		t.Fatalf("invalid X range: got=[%v, %v], want=[%v, %v]", p.X.Min, p.X.Max, xmin, xmax)
+	}
+	if ymin < ymax && (p.Y.Min != ymin || p.Y.Max != ymax) {
+		t.Fatalf("invalid Y range: got=[%v, %v], want=[%v, %v]", p.Y.Min, p.Y.Max, ymin, ymax)
+	}
+
+	err = tp.Save(10*vg.Centimeter, 10*vg.Centimeter, filepath.Join(t.TempDir(), "c1.png"))
+	if err != nil {
+		t.Fatalf("could not save canvas: %+v", err)
+	}
+}
+
+func TestRootColor(t *testing.T) {
+	for _, tc := range []struct {
+		idx  int16
+		want color.Color
+	}{
+		{rcolors.White, color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+		{rcolors.Black, color.NRGBA{R: 0, G: 0, B: 0, A: 255}},
+		{2, color.NRGBA{R: 255, G: 0, B: 0, A: 255}},
+		{rcolors.Red, color.NRGBA{R: 255, G: 0, B: 0, A: 255}},
+		{rcolors.Red + 2, color.NRGBA{R: 255, G: 0, B: 0, A: 255}},
+		{rcolors.Blue - 7, color.NRGBA{R: 0, G: 0, B: 255, A: 255}},
+		{rcolors.Green + 4, color.NRGBA{R: 0, G: 255, B: 0, A: 255}},
+		{1000, color.NRGBA{R: 0, G: 0, B: 0, A: 255}},
+	} {
+		if got := rootColor(tc.idx); got != tc.want {
+			t.Fatalf("invalid color for %d: got=%v, want=%v", tc.idx, got, tc.want)
+		}
+	}
+}
+
+func TestConvertPrimitives(t *testing.T) {
+	var (
+		pad  = new(rpad.Pad)
+		txt  = rpad.NewText(0.1, 0.9, "text")
+		ltx  = rpad.NewLatex(1, 2, "E = mc^{2}")
+		line = rpad.NewLine(0, 1, 10, 1)
+		ndc  = rpad.NewLine(0, 0, 1, 1)
+	)
+	txt.SetNDC(true)
+	ndc.SetNDC(true)
+	line.AttLine().Color = rcolors.Red
+
+	v, err := convert(pad, txt)
+	if err != nil {
+		t.Fatalf("could not convert text: %+v", err)
+	}
+	lbl := v.(*hplot.Label)
+	if lbl.X != 0.1 || lbl.Y != 0.9 || lbl.Text != "text" || !lbl.Normalized {
+		t.Fatalf("invalid label: %+v", lbl)
+	}
+
+	v, err = convert(pad, ltx)
+	if err != nil {
+		t.Fatalf("could not convert latex: %+v", err)
+	}
+	lbl = v.(*hplot.Label)
+	if lbl.X != 1 || lbl.Y != 2 || lbl.Text != "E = mc^{2}" || lbl.Normalized {
+		t.Fatalf("invalid label: %+v", lbl)
+	}
+
+	v, err = convert(pad, line)
+	if err != nil {
+		t.Fatalf("could not convert line: %+v", err)
+	}
+	ln := v.(*plotter.Line)
+	if got, want := ln.XYs, (plotter.XYs{{X: 0, Y: 1}, {X: 10, Y: 1}}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid line points: got=%v, want=%v", got, want)
+	}
+	if got, want := ln.LineStyle.Color, rootColor(rcolors.Red); got != want {
+		t.Fatalf("invalid line color: got=%v, want=%v", got, want)
+	}
+
+	v, err = convert(pad, ndc)
+	if err != nil {
+		t.Fatalf("could not convert NDC line: %+v", err)
+	}
+	if v != nil {
+		t.Fatalf("expected NDC line to be ignored, got %T", v)
+	}
+}
+
+func TestPlot(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		key   string
+	}{
+		{"../../hbook/rootcnv/testdata/gauss-h1.root", "h1f"},
+		{"../../hbook/rootcnv/testdata/gauss-h1.root", "h1d-var"},
+		{"../../hbook/rootcnv/testdata/gauss-h2.root", "h2d"},
+		{"../../groot/testdata/graphs.root", "tg"},
+		{"../../groot/testdata/graphs.root", "tge"},
+		{"../../groot/testdata/graphs.root", "tgae"},
+	} {
+		t.Run(tc.key, func(t *testing.T) {
+			f, err := groot.Open(tc.fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			o, err := f.Get(tc.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := Plot(o)
+			if err != nil {
+				t.Fatalf("could not create plot: %+v", err)
+			}
+
+			if got, want := p.Title.Text, o.(root.Named).Title(); got != want {
+				t.Fatalf("invalid title: got=%q, want=%q", got, want)
+			}
+
+			err = p.Save(10*vg.Centimeter, 10*vg.Centimeter, filepath.Join(t.TempDir(), tc.key+".png"))
+			if err != nil {
+				t.Fatalf("could not save plot: %+v", err)
+			}
+		})
+	}
+
+	_, err := Plot(rpad.NewText(0, 0, "text"))
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestPlotGraphNoHistogram(t *testing.T) {
+	g := rhist.NewGraphFrom(hbook.NewS2D(
+		hbook.Point2D{X: 1, Y: 2},
+		hbook.Point2D{X: 2, Y: 4},
+	))
+	if axis := g.(interface{ XAxis() rhist.Axis }).XAxis(); axis != nil {
+		t.Fatalf("expected no x-axis, got %v", axis)
+	}
+
+	p, err := Plot(g)
+	if err != nil {
+		t.Fatalf("could not create plot: %+v", err)
+	}
+	if got, want := p.X.Label.Text, ""; got != want {
+		t.Fatalf("invalid x-label: got=%q, want=%q", got, want)
+	}
+	if got, want := p.Y.Label.Text, ""; got != want {
+		t.Fatalf("invalid y-label: got=%q, want=%q", got, want)
+	}
+	if ticks := binLabels(nil); ticks != nil {
+		t.Fatalf("expected no ticks, got %v", ticks)
+	}
+}
+
+func TestS2D(t *testing.T) {
+	f, err := groot.Open("../../groot/testdata/graphs.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, tc := range []struct {
+		key  string
+		errs bool
+	}{
+		{"tg", false},
+		{"tge", true},
+		{"tgae", true},
+	} {
+		o, err := f.Get(tc.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := S2D(o.(rhist.Graph))
+		if got, want := s.XErrs != nil, tc.errs; got != want {
+			t.Fatalf("%s: invalid x-errors: got=%v, want=%v", tc.key, got, want)
+		}
+		if got, want := s.YErrs != nil, tc.errs; got != want {
+			t.Fatalf("%s: invalid y-errors: got=%v, want=%v", tc.key, got, want)
+		}
+	}
+}
+
+type labeledAxis struct {
+	rhist.Axis
+	labels map[int]string
+}
+
+func (axis labeledAxis) NBins() int              { return 4 }
+func (axis labeledAxis) BinCenter(i int) float64 { return float64(i) - 0.5 }
+func (axis labeledAxis) BinLabel(i int) string   { return axis.labels[i] }
+
+func TestBinLabels(t *testing.T) {
+	if ticks := binLabels(labeledAxis{}); ticks != nil {
+		t.Fatalf("expected no ticks, got %v", ticks)
+	}
+
+	ticks := binLabels(labeledAxis{labels: map[int]string{1: "ee", 3: "mumu"}})
+	want := plot.ConstantTicks{
+		{Value: 0.5, Label: "ee"},
+		{Value: 2.5, Label: "mumu"},
+	}
+	if !reflect.DeepEqual(ticks, want) {
+		t.Fatalf("invalid ticks:\ngot= %v\nwant=%v", ticks, want)
+	}
+}
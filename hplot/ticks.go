@@ -8,10 +8,15 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
+	"time"
 
 	"go-hep.org/x/hep/hplot/internal/talbot"
 	"gonum.org/v1/gonum/floats/scalar"
 	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
 )
 
 const (
@@ -94,3 +99,562 @@ func (tck Ticks) Ticks(min, max float64) []plot.Tick {
 	}
 	return ticks
 }
+
+// LogTicks implements plot.Ticker for log-scaled axes, placing ticks
+// as physicists expect from ROOT axes.
+//
+// Major ticks are placed at each power of 10 and minor ticks at
+// 2, 3, ..., 9 times each power of 10.
+// When the axis spans only a few decades, ticks at 2 and 5 times each
+// power of 10 are labeled as well.
+// When the axis spans many decades, only one power of 10 out of every few
+// ones is labeled and the minor ticks are dropped.
+//
+// Labels use a plain notation (e.g. 0.02, 500) when all labeled values lie
+// within [0.01, 10000), and a scientific notation (e.g. 10^{3}, 2×10^{-2})
+// otherwise.
+// When LogTicks is the tick marker of an axis of a Plot, the exponents
+// of the labels are rendered as superscripts.
+type LogTicks struct {
+	// N is the suggested maximum number of labeled powers of 10.
+	// The zero value defaults to 6.
+	N int
+}
+
+// Ticks returns Ticks in the specified range.
+// Ticks returns nil if min is not strictly positive.
+func (tck LogTicks) Ticks(min, max float64) []plot.Tick {
+	if min <= 0 || max <= 0 || !(min < max) {
+		return nil
+	}
+	if tck.N <= 0 {
+		tck.N = 6
+	}
+
+	const eps = 1e-9 // relative tolerance on the axis range.
+	var (
+		lo = int(math.Floor(math.Log10(min)))
+		hi = int(math.Ceil(math.Log10(max)))
+
+		inside = func(v float64) bool {
+			return min*(1-eps) <= v && v <= max*(1+eps)
+		}
+		ndecs = 0
+	)
+	for e := lo; e <= hi; e++ {
+		if inside(math.Pow10(e)) {
+			ndecs++
+		}
+	}
+
+	var (
+		step  = 1
+		minor = true
+		more  = ndecs <= 2 // label 2 and 5 times each power of 10.
+	)
+	if ndecs > tck.N {
+		step = (ndecs + tck.N - 1) / tck.N
+		minor = false
+	}
+
+	var (
+		ticks   []plot.Tick
+		labeled []bool
+		labels  int
+	)
+	for e := lo; e <= hi; e++ {
+		for m := 1; m < 10; m++ {
+			if m > 1 && !minor {
+				break
+			}
+			v := float64(m) * math.Pow10(e)
+			if !inside(v) {
+				continue
+			}
+			ok := (m == 1 && e%step == 0) || (more && (m == 2 || m == 5))
+			if ok {
+				labels++
+			}
+			ticks = append(ticks, plot.Tick{Value: v})
+			labeled = append(labeled, ok)
+		}
+	}
+
+	if labels < 2 {
+		// not enough labels: label all the ticks.
+		for i := range labeled {
+			labeled[i] = true
+		}
+	}
+
+	plain := true
+	for i, t := range ticks {
+		if labeled[i] && !(0.01*(1-eps) <= t.Value && t.Value < 1e4) {
+			plain = false
+			break
+		}
+	}
+	for i, t := range ticks {
+		if !labeled[i] {
+			continue
+		}
+		e := int(math.Floor(math.Log10(t.Value) + eps))
+		m := int(math.Round(t.Value / math.Pow10(e)))
+		switch {
+		case plain:
+			ticks[i].Label = strconv.FormatFloat(t.Value, 'f', maxInt(0, -e), 64)
+		case m == 1:
+			ticks[i].Label = fmt.Sprintf("10^{%d}", e)
+		default:
+			ticks[i].Label = fmt.Sprintf("%d×10^{%d}", m, e)
+		}
+	}
+	return ticks
+}
+
+// SciTicks implements plot.Ticker for linear axes with large or small
+// values, as ROOT axes do: a common power of 10 is factored out of the
+// labels of the major ticks.
+//
+// When SciTicks is the tick marker of an axis of a Plot, the factored out
+// power of 10 (e.g. ×10⁶) is displayed at the end of the axis.
+type SciTicks struct {
+	N int // N is the suggested number of major ticks to display.
+}
+
+// Ticks returns Ticks in the specified range.
+func (tck SciTicks) Ticks(min, max float64) []plot.Tick {
+	ticks := Ticks{N: tck.N}.Ticks(min, max)
+	e := tck.Exponent(min, max)
+	if e == 0 {
+		return ticks
+	}
+
+	scale := math.Pow10(-e)
+	for i, t := range ticks {
+		if t.IsMinor() {
+			continue
+		}
+		ticks[i].Label = formatFloatTick(t.Value*scale, displayPrecision)
+	}
+	return ticks
+}
+
+// Exponent returns the power of 10 factored out of the tick labels for
+// the specified range.
+// Exponent returns 0 when the values of the range do not need to be
+// factored out, i.e. when they lie within [0.001, 10000).
+func (SciTicks) Exponent(min, max float64) int {
+	v := math.Max(math.Abs(min), math.Abs(max))
+	if v == 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0
+	}
+	e := int(math.Floor(math.Log10(v)))
+	if -3 <= e && e < 4 {
+		return 0
+	}
+	return e
+}
+
+// TimeTicks implements plot.Ticker for time axes, whose values are
+// seconds since the Unix epoch (e.g. float64(t.Unix())).
+//
+// The interval between major ticks is chosen automatically, from one
+// second to many years, and ticks are placed at round times (e.g. every
+// 15 minutes, on the hour, at midnight, on the first day of a month, ...).
+// When the major ticks of an axis placed within a day span several days,
+// the date is added to the label of the first tick of each day.
+type TimeTicks struct {
+	// N is the suggested maximum number of major ticks to display.
+	// The zero value defaults to 5.
+	N int
+
+	// Format is an optional time layout (as used by time.Time.Format)
+	// for the labels of the major ticks.
+	// If empty, a format will be automatically chosen from the interval
+	// between major ticks.
+	Format string
+
+	// Location is the time zone in which ticks are placed and labeled.
+	// The zero value defaults to UTC.
+	Location *time.Location
+}
+
+// timeUnit is a calendar unit of a time interval.
+type timeUnit int
+
+const (
+	timeSecond timeUnit = iota
+	timeMinute
+	timeHour
+	timeDay
+	timeMonth
+	timeYear
+)
+
+// duration returns the approximate duration of n units.
+func (u timeUnit) duration(n int) float64 {
+	switch u {
+	case timeSecond:
+		return float64(n)
+	case timeMinute:
+		return float64(n) * 60
+	case timeHour:
+		return float64(n) * 3600
+	case timeDay:
+		return float64(n) * 86400
+	case timeMonth:
+		return float64(n) * 86400 * 365.25 / 12
+	case timeYear:
+		return float64(n) * 86400 * 365.25
+	}
+	panic(fmt.Errorf("hplot: invalid time unit %d", u))
+}
+
+// timeStep is an interval between time ticks.
+type timeStep struct {
+	unit timeUnit
+	n    int // number of units between major ticks.
+	sub  int // number of units between minor ticks.
+}
+
+// timeSteps are the candidate intervals between major time ticks,
+// in increasing order.
+// Intervals of more than a few years are handled by timeStepYears.
+var timeSteps = []timeStep{
+	{timeSecond, 1, 0},
+	{timeSecond, 2, 1},
+	{timeSecond, 5, 1},
+	{timeSecond, 10, 5},
+	{timeSecond, 15, 5},
+	{timeSecond, 30, 10},
+	{timeMinute, 1, 0},
+	{timeMinute, 2, 1},
+	{timeMinute, 5, 1},
+	{timeMinute, 10, 5},
+	{timeMinute, 15, 5},
+	{timeMinute, 30, 10},
+	{timeHour, 1, 0},
+	{timeHour, 2, 1},
+	{timeHour, 3, 1},
+	{timeHour, 6, 1},
+	{timeHour, 12, 3},
+	{timeDay, 1, 0},
+	{timeDay, 2, 1},
+	{timeDay, 7, 1},
+	{timeDay, 14, 7},
+	{timeMonth, 1, 0},
+	{timeMonth, 2, 1},
+	{timeMonth, 3, 1},
+	{timeMonth, 6, 3},
+	{timeYear, 1, 0},
+	{timeYear, 2, 1},
+	{timeYear, 5, 1},
+}
+
+// timeStepYears returns the interval, in years, between major ticks
+// spanning the provided number of years with at most n major ticks.
+func timeStepYears(years float64, n int) timeStep {
+	for e := 1; ; e++ {
+		for _, m := range []int{1, 2, 5} {
+			step := m * int(math.Pow10(e))
+			if years/float64(step) > float64(n) {
+				continue
+			}
+			sub := step / 5
+			if m == 2 {
+				sub = step / 4
+			}
+			return timeStep{timeYear, step, sub}
+		}
+	}
+}
+
+// Ticks returns Ticks in the specified range.
+func (tck TimeTicks) Ticks(min, max float64) []plot.Tick {
+	if !(min < max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return nil
+	}
+	if tck.N <= 0 {
+		tck.N = 5
+	}
+	loc := tck.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	span := max - min
+	step := timeStepYears(span/timeYear.duration(1), tck.N)
+	for _, v := range timeSteps {
+		if span/v.unit.duration(v.n) <= float64(tck.N) {
+			step = v
+			break
+		}
+	}
+
+	var (
+		beg   = time.Unix(int64(math.Floor(min)), 0).In(loc)
+		ticks []plot.Tick
+	)
+	if step.sub > 0 {
+		for t := timeFloor(beg, step.unit, step.sub); ; t = timeAdd(t, step.unit, step.sub) {
+			v := float64(t.Unix())
+			if v > max {
+				break
+			}
+			if v >= min {
+				ticks = append(ticks, plot.Tick{Value: v})
+			}
+		}
+	}
+
+	var majors []time.Time
+	for t := timeFloor(beg, step.unit, step.n); ; t = timeAdd(t, step.unit, step.n) {
+		v := float64(t.Unix())
+		if v > max {
+			break
+		}
+		if v >= min {
+			majors = append(majors, t)
+		}
+	}
+
+	format := tck.Format
+	if format == "" {
+		format = timeFormat(step.unit)
+	}
+	dates := tck.Format == "" && step.unit < timeDay && len(majors) > 1 &&
+		!sameDay(majors[0], majors[len(majors)-1])
+	for i, t := range majors {
+		label := t.Format(format)
+		if dates && (i == 0 || !sameDay(majors[i-1], t)) {
+			label += "\n" + t.Format("2006-01-02")
+		}
+		ticks = append(ticks, plot.Tick{Value: float64(t.Unix()), Label: label})
+	}
+
+	// remove the minor ticks overlapping with the major ones.
+	o := ticks[:0]
+	major := make(map[float64]bool, len(ticks))
+	for _, t := range ticks {
+		if !t.IsMinor() {
+			major[t.Value] = true
+		}
+	}
+	for _, t := range ticks {
+		if t.IsMinor() && major[t.Value] {
+			continue
+		}
+		o = append(o, t)
+	}
+	return o
+}
+
+// sameDay returns whether t1 and t2 are on the same day.
+func sameDay(t1, t2 time.Time) bool {
+	return t1.YearDay() == t2.YearDay() && t1.Year() == t2.Year()
+}
+
+// timeFloor returns the largest time before t, aligned on a multiple
+// of n units.
+func timeFloor(t time.Time, u timeUnit, n int) time.Time {
+	var (
+		loc = t.Location()
+		y   = t.Year()
+		m   = int(t.Month())
+		d   = t.Day()
+		h   = t.Hour()
+		mn  = t.Minute()
+		s   = t.Second()
+	)
+	switch u {
+	case timeSecond:
+		s -= s % n
+	case timeMinute:
+		mn -= mn % n
+		s = 0
+	case timeHour:
+		h -= h % n
+		mn, s = 0, 0
+	case timeDay:
+		if n == 7 || n == 14 {
+			// align weeks on mondays.
+			d -= (int(t.Weekday()) + 6) % 7
+		} else {
+			d -= (d - 1) % n
+		}
+		h, mn, s = 0, 0, 0
+	case timeMonth:
+		m -= (m - 1) % n
+		d, h, mn, s = 1, 0, 0, 0
+	case timeYear:
+		y -= ((y % n) + n) % n
+		m, d, h, mn, s = 1, 1, 0, 0, 0
+	}
+	return time.Date(y, time.Month(m), d, h, mn, s, 0, loc)
+}
+
+// timeAdd returns t shifted by n units.
+func timeAdd(t time.Time, u timeUnit, n int) time.Time {
+	switch u {
+	case timeSecond:
+		return t.Add(time.Duration(n) * time.Second)
+	case timeMinute:
+		return t.Add(time.Duration(n) * time.Minute)
+	case timeHour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case timeDay:
+		return t.AddDate(0, 0, n)
+	case timeMonth:
+		return t.AddDate(0, n, 0)
+	case timeYear:
+		return t.AddDate(n, 0, 0)
+	}
+	panic(fmt.Errorf("hplot: invalid time unit %d", u))
+}
+
+// timeFormat returns the default layout of time labels for the provided
+// interval unit.
+func timeFormat(u timeUnit) string {
+	switch u {
+	case timeSecond:
+		return "15:04:05"
+	case timeMinute, timeHour:
+		return "15:04"
+	case timeDay:
+		return "Jan 02"
+	case timeMonth:
+		return "Jan 2006"
+	default:
+		return "2006"
+	}
+}
+
+var (
+	_ plot.Ticker = LogTicks{}
+	_ plot.Ticker = SciTicks{}
+	_ plot.Ticker = TimeTicks{}
+)
+
+// supText is a text.Handler rendering the "^{...}" sequences of a
+// single-line text as superscripts.
+// Texts without such a sequence are rendered as plain text.
+type supText struct {
+	text.Plain
+}
+
+// supHandler returns a text handler rendering superscripts, using the
+// fonts of the provided plain text handler.
+// Other text handlers are returned unmodified.
+func supHandler(hdlr text.Handler) text.Handler {
+	switch hdlr := hdlr.(type) {
+	case text.Plain:
+		return supText{hdlr}
+	case *text.Plain:
+		return supText{*hdlr}
+	case nil:
+		return supText{text.Plain{Fonts: DefaultStyle.Fonts.Cache}}
+	}
+	return hdlr
+}
+
+const (
+	supScale = 0.7  // font size of superscripts, relative to the text.
+	supRise  = 0.45 // rise of superscripts, relative to the text ascent.
+)
+
+// supSegment is a segment of text, possibly a superscript.
+type supSegment struct {
+	txt string
+	sup bool
+}
+
+func splitSup(txt string) []supSegment {
+	var segs []supSegment
+	for txt != "" {
+		i := strings.Index(txt, "^{")
+		if i < 0 {
+			segs = append(segs, supSegment{txt: txt})
+			break
+		}
+		j := strings.Index(txt[i:], "}")
+		if j < 0 {
+			segs = append(segs, supSegment{txt: txt})
+			break
+		}
+		if i > 0 {
+			segs = append(segs, supSegment{txt: txt[:i]})
+		}
+		segs = append(segs, supSegment{txt: txt[i+2 : i+j], sup: true})
+		txt = txt[i+j+1:]
+	}
+	return segs
+}
+
+// Box returns the bounding box of the given non-multiline text.
+func (hdlr supText) Box(txt string, fnt font.Font) (width, height, depth vg.Length) {
+	if !strings.Contains(txt, "^{") {
+		return hdlr.Plain.Box(txt, fnt)
+	}
+
+	var (
+		face = hdlr.Fonts.Lookup(fnt, fnt.Size)
+		sup  = hdlr.Fonts.Lookup(fnt, supScale*fnt.Size)
+		ext  = face.Extents()
+	)
+	height = ext.Ascent
+	depth = ext.Descent
+	for _, seg := range splitSup(txt) {
+		switch {
+		case seg.sup:
+			width += sup.Width(seg.txt)
+			height = max(height, supRise*ext.Ascent+sup.Extents().Ascent)
+		default:
+			width += face.Width(seg.txt)
+		}
+	}
+	return width, height, depth
+}
+
+// Draw renders the given text with the provided style and position
+// on the canvas.
+func (hdlr supText) Draw(c vg.Canvas, txt string, sty text.Style, pt vg.Point) {
+	txt = strings.TrimRight(txt, "\n")
+	if !strings.Contains(txt, "^{") {
+		hdlr.Plain.Draw(c, txt, sty, pt)
+		return
+	}
+
+	var (
+		face = hdlr.Fonts.Lookup(sty.Font, sty.Font.Size)
+		sup  = hdlr.Fonts.Lookup(sty.Font, supScale*sty.Font.Size)
+		ext  = face.Extents()
+	)
+	c.SetColor(sty.Color)
+
+	if sty.Rotation != 0 {
+		c.Push()
+		defer c.Pop()
+		c.Rotate(sty.Rotation)
+	}
+
+	sin64, cos64 := math.Sincos(sty.Rotation)
+	cos := vg.Length(cos64)
+	sin := vg.Length(sin64)
+	pt.X, pt.Y = pt.Y*sin+pt.X*cos, pt.Y*cos-pt.X*sin
+
+	// same alignment as text.Plain.
+	pt.X += vg.Length(sty.XAlign) * sty.Width(txt)
+	pt.Y += vg.Length(sty.YAlign)*sty.Height(txt) - ext.Ascent + sty.Font.Size
+
+	for _, seg := range splitSup(txt) {
+		switch {
+		case seg.sup:
+			c.FillString(sup, pt.Add(vg.Point{Y: supRise * ext.Ascent}), seg.txt)
+			pt.X += sup.Width(seg.txt)
+		default:
+			c.FillString(face, pt, seg.txt)
+			pt.X += face.Width(seg.txt)
+		}
+	}
+}
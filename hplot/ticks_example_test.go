@@ -7,10 +7,14 @@ package hplot_test
 import (
 	"image/color"
 	"log"
+	"math"
 	"time"
 
 	"git.sr.ht/~sbinet/epok"
+	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
@@ -274,3 +278,134 @@ func parse(vs ...string) time.Time {
 	}
 	return t
 }
+
+func ExampleLogTicks() {
+	tp := hplot.NewTiledPlot(draw.Tiles{Cols: 2, Rows: 5})
+	tp.Align = true
+
+	ranges := []struct{ min, max float64 }{
+		{2, 8},
+		{0.5, 40},
+		{1, 1000},
+		{1e-3, 1e5},
+		{1e-10, 1e10},
+	}
+
+	for i := 0; i < tp.Tiles.Rows; i++ {
+		for j := 0; j < tp.Tiles.Cols; j++ {
+			p := tp.Plot(j, i)
+			p.X.Min = ranges[i].min
+			p.X.Max = ranges[i].max
+			p.X.Scale = plot.LogScale{}
+			switch j {
+			case 0:
+				p.X.Tick.Marker = hplot.LogTicks{}
+				if i == 0 {
+					p.Title.Text = "hplot.LogTicks"
+				}
+			default:
+				p.X.Tick.Marker = plot.LogTicks{Prec: -1}
+				if i == 0 {
+					p.Title.Text = "plot.LogTicks"
+				}
+			}
+			p.Add(hplot.NewGrid())
+		}
+	}
+
+	const sz = 20 * vg.Centimeter
+	err := tp.Save(sz, sz/2, "testdata/ticks_log.png")
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+}
+
+func ExampleSciTicks() {
+	// Energy spectrum of some particles, in eV.
+	var (
+		src = rand.New(rand.NewSource(1234))
+		h   = hbook.NewH1D(50, 0, 5e6)
+	)
+	for i := 0; i < 100000; i++ {
+		h.Fill(src.ExpFloat64()*1e6, 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Energy spectrum"
+	p.X.Label.Text = "E [eV]"
+	p.Y.Label.Text = "Entries"
+	p.X.Tick.Marker = hplot.SciTicks{N: 5}
+	p.Y.Tick.Marker = hplot.SciTicks{}
+	p.Add(hplot.NewH1D(h), hplot.NewGrid())
+
+	err := p.Save(10*vg.Centimeter, -1, "testdata/ticks_sci.png")
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+}
+
+func ExampleLogTicks_h1d() {
+	// Energy spectrum of some particles, in eV.
+	var (
+		src = rand.New(rand.NewSource(1234))
+		h   = hbook.NewH1D(50, 0, 5e6)
+	)
+	for i := 0; i < 100000; i++ {
+		h.Fill(src.ExpFloat64()*3e5, 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Energy spectrum"
+	p.X.Label.Text = "E [eV]"
+	p.Y.Label.Text = "Entries"
+	p.X.Tick.Marker = hplot.SciTicks{N: 5}
+	p.Y.Scale = plot.LogScale{}
+	p.Y.Tick.Marker = hplot.LogTicks{}
+	p.Add(hplot.NewH1D(h, hplot.WithLogY(true)), hplot.NewGrid())
+
+	err := p.Save(10*vg.Centimeter, -1, "testdata/ticks_log_h1d.png")
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+}
+
+func ExampleTimeTicks() {
+	tp := hplot.NewTiledPlot(draw.Tiles{Cols: 1, Rows: 4, PadY: 2 * vg.Millimeter})
+
+	var (
+		src   = rand.New(rand.NewSource(1234))
+		beg   = parse("2023-12-30 22:10:00")
+		spans = []time.Duration{
+			90 * time.Second,
+			3 * time.Hour,
+			50 * time.Hour,
+			300 * 24 * time.Hour,
+		}
+	)
+
+	for i, span := range spans {
+		const n = 100
+		xys := make(plotter.XYs, n)
+		for j := range xys {
+			t := beg.Add(time.Duration(j) * span / (n - 1))
+			xys[j].X = float64(t.Unix())
+			xys[j].Y = 100 + 10*math.Sin(float64(j)/10) + src.NormFloat64()
+		}
+
+		p := tp.Plot(0, i)
+		p.Y.Label.Text = "Rate [Hz]"
+		p.X.Tick.Marker = hplot.TimeTicks{}
+		line, err := hplot.NewLine(xys)
+		if err != nil {
+			log.Fatalf("could not create line: %+v", err)
+		}
+		line.Color = color.RGBA{B: 255, A: 255}
+		p.Add(line, hplot.NewGrid())
+	}
+	tp.Plot(0, 0).Title.Text = "Trigger rate (UTC)"
+
+	err := tp.Save(15*vg.Centimeter, 20*vg.Centimeter, "testdata/ticks_time.png")
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+}
@@ -5,8 +5,12 @@
 package hplot_test
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/cmpimg"
 )
 
@@ -25,3 +29,172 @@ func TestTicksTimeMonthly(t *testing.T) {
 func TestTicksTimeDaily(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleTicks_daily, t, "timeseries_daily.png")
 }
+
+func TestLogTicks(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleLogTicks, t, "ticks_log.png")
+	checkPlot(cmpimg.CheckPlot)(ExampleLogTicks_h1d, t, "ticks_log_h1d.png")
+}
+
+func TestSciTicks(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleSciTicks, t, "ticks_sci.png")
+}
+
+func TestTimeTicks(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleTimeTicks, t, "ticks_time.png")
+}
+
+func TestLogTicksLabels(t *testing.T) {
+	labels := func(ticks []plot.Tick) []string {
+		var o []string
+		for _, tck := range ticks {
+			if tck.Label != "" {
+				o = append(o, tck.Label)
+			}
+		}
+		return o
+	}
+
+	for _, tc := range []struct {
+		min, max float64
+		n        int
+		want     []string
+		nticks   int
+	}{
+		{min: 2, max: 8, want: []string{"2", "5"}, nticks: 7},
+		{min: 3, max: 4, want: []string{"3", "4"}, nticks: 2},
+		{min: 0.5, max: 40, want: []string{"0.5", "1", "2", "5", "10", "20"}, nticks: 5 + 9 + 4},
+		{min: 1, max: 1000, want: []string{"1", "10", "100", "1000"}, nticks: 28},
+		{min: 1e-3, max: 1e2, want: []string{"10^{-3}", "10^{-2}", "10^{-1}", "10^{0}", "10^{1}", "10^{2}"}, nticks: 46},
+		{min: 1e-3, max: 1e5, want: []string{"10^{-2}", "10^{0}", "10^{2}", "10^{4}"}, nticks: 9},
+		{min: 1e-3, max: 1e5, n: 10, want: []string{"10^{-3}", "10^{-2}", "10^{-1}", "10^{0}", "10^{1}", "10^{2}", "10^{3}", "10^{4}", "10^{5}"}, nticks: 73},
+		{min: 2e4, max: 6e5, want: []string{"2×10^{4}", "5×10^{4}", "10^{5}", "2×10^{5}", "5×10^{5}"}, nticks: 8 + 6},
+		{min: 0, max: 10},
+	} {
+		t.Run("", func(t *testing.T) {
+			ticks := hplot.LogTicks{N: tc.n}.Ticks(tc.min, tc.max)
+			if got, want := labels(ticks), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid labels:\ngot= %q\nwant=%q", got, want)
+			}
+			if got, want := len(ticks), tc.nticks; got != want {
+				t.Fatalf("invalid number of ticks: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestSciTicksExponent(t *testing.T) {
+	for _, tc := range []struct {
+		min, max float64
+		want     int
+	}{
+		{0, 1, 0},
+		{0, 9999, 0},
+		{0, 1e4, 4},
+		{-5e6, 1e3, 6},
+		{0, 1e-3, 0},
+		{0, 9e-4, -4},
+		{0, 0, 0},
+	} {
+		if got := (hplot.SciTicks{}).Exponent(tc.min, tc.max); got != tc.want {
+			t.Errorf("invalid exponent for [%v, %v]: got=%d, want=%d", tc.min, tc.max, got, tc.want)
+		}
+	}
+
+	var labels []string
+	for _, tck := range (hplot.SciTicks{N: 5}).Ticks(0, 5e6) {
+		if tck.Label != "" {
+			labels = append(labels, tck.Label)
+		}
+	}
+	if got, want := labels, []string{"0", "1", "2", "3", "4", "5"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid labels:\ngot= %q\nwant=%q", got, want)
+	}
+}
+
+func TestTimeTicksLabels(t *testing.T) {
+	unix := func(v string) float64 {
+		t, err := time.Parse("2006-01-02 15:04:05", v)
+		if err != nil {
+			panic(err)
+		}
+		return float64(t.Unix())
+	}
+	labels := func(ticks []plot.Tick) []string {
+		var o []string
+		for _, tck := range ticks {
+			if tck.Label != "" {
+				o = append(o, tck.Label)
+			}
+		}
+		return o
+	}
+
+	for _, tc := range []struct {
+		name     string
+		min, max string
+		tck      hplot.TimeTicks
+		want     []string
+	}{
+		{
+			name: "seconds",
+			min:  "2020-01-01 10:00:03",
+			max:  "2020-01-01 10:00:12",
+			want: []string{"10:00:04", "10:00:06", "10:00:08", "10:00:10", "10:00:12"},
+		},
+		{
+			name: "minutes",
+			min:  "2020-01-01 10:07:00",
+			max:  "2020-01-01 11:02:00",
+			want: []string{"10:15", "10:30", "10:45", "11:00"},
+		},
+		{
+			name: "hours-across-days",
+			min:  "2020-01-01 20:00:00",
+			max:  "2020-01-02 08:00:00",
+			want: []string{"21:00\n2020-01-01", "00:00\n2020-01-02", "03:00", "06:00"},
+		},
+		{
+			name: "days",
+			min:  "2020-01-01 00:00:00",
+			max:  "2020-01-05 12:00:00",
+			want: []string{"Jan 01", "Jan 02", "Jan 03", "Jan 04", "Jan 05"},
+		},
+		{
+			name: "months",
+			min:  "2020-01-15 00:00:00",
+			max:  "2020-12-01 00:00:00",
+			want: []string{"Apr 2020", "Jul 2020", "Oct 2020"},
+		},
+		{
+			name: "years",
+			min:  "1995-06-01 00:00:00",
+			max:  "2042-01-01 00:00:00",
+			want: []string{"2000", "2010", "2020", "2030", "2040"},
+		},
+		{
+			name: "format",
+			min:  "2020-01-01 00:00:00",
+			max:  "2020-01-03 00:00:00",
+			tck:  hplot.TimeTicks{N: 2, Format: "01/02"},
+			want: []string{"01/01", "01/02", "01/03"},
+		},
+		{
+			name: "location",
+			min:  "2020-01-01 00:00:00",
+			max:  "2020-01-01 04:00:00",
+			tck:  hplot.TimeTicks{Location: time.FixedZone("UTC+1", 3600)},
+			want: []string{"01:00", "02:00", "03:00", "04:00", "05:00"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ticks := tc.tck.Ticks(unix(tc.min), unix(tc.max))
+			if got, want := labels(ticks), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid labels:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+
+	if ticks := (hplot.TimeTicks{}).Ticks(1, 1); ticks != nil {
+		t.Fatalf("invalid ticks for empty range: %v", ticks)
+	}
+}
@@ -5,16 +5,45 @@
 package hplot
 
 import (
+	"image/color"
+	"math"
+
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 )
 
 // TiledPlot is a regularly spaced set of plots, aranged as tiles.
+//
+// A legend common to all the tiles can be displayed by wrapping
+// the tiled plot in a figure, with the WithLegend option.
 type TiledPlot struct {
 	Plots []*Plot
 	Tiles draw.Tiles
 	Align bool // whether to align all tiles axes
+
+	// ShareX shares the X axis of the plots in each column:
+	// the plots of a column are displayed with the same X range
+	// and only the bottom-most plot displays the X tick labels
+	// and the X axis label.
+	// Sharing axes implies aligning the tiles axes.
+	ShareX bool
+
+	// ShareY shares the Y axis of the plots in each row:
+	// the plots of a row are displayed with the same Y range
+	// and only the left-most plot displays the Y tick labels
+	// and the Y axis label.
+	// Sharing axes implies aligning the tiles axes.
+	ShareY bool
+
+	// Labels are the per-panel labels (e.g. "(a)", "(b)", ...),
+	// displayed at the top-left corner of the data area of each plot.
+	// Labels are indexed as Plots.
+	// Empty labels are not displayed.
+	Labels []string
+
+	// LabelStyle is the style of the per-panel labels.
+	LabelStyle draw.TextStyle
 }
 
 // NewTiledPlot creates a new set of plots aranged as tiles.
@@ -33,6 +62,13 @@ func NewTiledPlot(tiles draw.Tiles) *TiledPlot {
 	plot := &TiledPlot{
 		Plots: make([]*Plot, tiles.Rows*tiles.Cols),
 		Tiles: tiles,
+		LabelStyle: draw.TextStyle{
+			Color:   color.Black,
+			Font:    DefaultStyle.Fonts.Label,
+			XAlign:  draw.XLeft,
+			YAlign:  draw.YTop,
+			Handler: DefaultStyle.TextHandler,
+		},
 	}
 
 	for i := 0; i < tiles.Rows; i++ {
@@ -56,8 +92,12 @@ func (tp *TiledPlot) Plot(i, j int) *Plot {
 // Each non-nil plot.Plot in the aranged set of tiled plots is drawn
 // inside its dedicated sub-canvas, using hplot.Plot.Draw.
 func (tp *TiledPlot) Draw(c draw.Canvas) {
+	if tp.ShareX || tp.ShareY {
+		defer tp.share()()
+	}
+
 	switch {
-	case tp.Align:
+	case tp.Align || tp.ShareX || tp.ShareY:
 		ps := make([][]*plot.Plot, tp.Tiles.Rows)
 		for row := 0; row < tp.Tiles.Rows; row++ {
 			ps[row] = make([]*plot.Plot, tp.Tiles.Cols)
@@ -72,11 +112,12 @@ func (tp *TiledPlot) Draw(c draw.Canvas) {
 		cs := plot.Align(ps, tp.Tiles, c)
 		for i := 0; i < tp.Tiles.Rows; i++ {
 			for j := 0; j < tp.Tiles.Cols; j++ {
-				p := ps[i][j]
+				p := tp.Plots[i*tp.Tiles.Cols+j]
 				if p == nil {
 					continue
 				}
 				p.Draw(cs[i][j])
+				tp.drawLabel(p.DataCanvas(cs[i][j]), i*tp.Tiles.Cols+j)
 			}
 		}
 
@@ -90,9 +131,126 @@ func (tp *TiledPlot) Draw(c draw.Canvas) {
 					continue
 				}
 				p.Draw(sub)
+				tp.drawLabel(p.DataCanvas(sub), i)
+			}
+		}
+	}
+}
+
+// drawLabel draws the label of the i-th plot at the top-left corner
+// of the provided data canvas.
+func (tp *TiledPlot) drawLabel(c draw.Canvas, i int) {
+	if i >= len(tp.Labels) || tp.Labels[i] == "" {
+		return
+	}
+	pad := 0.5 * tp.LabelStyle.Font.Size
+	c.FillText(tp.LabelStyle, vg.Point{X: c.Min.X + pad, Y: c.Max.Y - pad}, tp.Labels[i])
+}
+
+// share shares the X (resp. Y) axes of the plots in each column (resp. row)
+// and hides the redundant tick labels and axis labels.
+// share returns a function restoring the hidden tick labels and axis labels.
+func (tp *TiledPlot) share() func() {
+	var (
+		rows = tp.Tiles.Rows
+		cols = tp.Tiles.Cols
+		undo []func()
+	)
+
+	hide := func(axis *plot.Axis) {
+		var (
+			marker = axis.Tick.Marker
+			label  = axis.Label.Text
+		)
+		axis.Tick.Marker = noTickLabels{marker}
+		axis.Label.Text = ""
+		undo = append(undo, func() {
+			axis.Tick.Marker = marker
+			axis.Label.Text = label
+		})
+	}
+
+	if tp.ShareX {
+		for col := 0; col < cols; col++ {
+			var (
+				min  = math.Inf(+1)
+				max  = math.Inf(-1)
+				last = -1
+			)
+			for row := 0; row < rows; row++ {
+				p := tp.Plots[row*cols+col]
+				if p == nil {
+					continue
+				}
+				min = math.Min(min, p.X.Min)
+				max = math.Max(max, p.X.Max)
+				last = row
+			}
+			for row := 0; row < rows; row++ {
+				p := tp.Plots[row*cols+col]
+				if p == nil {
+					continue
+				}
+				p.X.Min = min
+				p.X.Max = max
+				if row != last {
+					hide(&p.X)
+				}
+			}
+		}
+	}
+
+	if tp.ShareY {
+		for row := 0; row < rows; row++ {
+			var (
+				min   = math.Inf(+1)
+				max   = math.Inf(-1)
+				first = -1
+			)
+			for col := 0; col < cols; col++ {
+				p := tp.Plots[row*cols+col]
+				if p == nil {
+					continue
+				}
+				min = math.Min(min, p.Y.Min)
+				max = math.Max(max, p.Y.Max)
+				if first < 0 {
+					first = col
+				}
+			}
+			for col := 0; col < cols; col++ {
+				p := tp.Plots[row*cols+col]
+				if p == nil {
+					continue
+				}
+				p.Y.Min = min
+				p.Y.Max = max
+				if col != first {
+					hide(&p.Y)
+				}
 			}
 		}
 	}
+
+	return func() {
+		for _, f := range undo {
+			f()
+		}
+	}
+}
+
+// noTickLabels is a plot.Ticker that removes the labels of the ticks
+// of the underlying plot.Ticker.
+type noTickLabels struct {
+	plot.Ticker
+}
+
+func (tck noTickLabels) Ticks(min, max float64) []plot.Tick {
+	ticks := append([]plot.Tick(nil), tck.Ticker.Ticks(min, max)...)
+	for i := range ticks {
+		ticks[i].Label = ""
+	}
+	return ticks
 }
 
 // Save saves the plots to an image file.
@@ -106,3 +106,63 @@ func ExampleTiledPlot_align() {
 		log.Fatalf("error: %+v\n", err)
 	}
 }
+
+// An example of making tile-plots with shared axes, per-panel labels
+// and a common legend.
+func ExampleTiledPlot_shareAxes() {
+	tp := hplot.NewTiledPlot(draw.Tiles{
+		Cols: 2, Rows: 2,
+		PadX: 4, PadY: 4,
+	})
+	tp.ShareX = true
+	tp.ShareY = true
+	tp.Labels = []string{"(a)", "(b)", "(c)", "(d)"}
+
+	var (
+		colors = []color.Color{
+			color.NRGBA{B: 255, A: 255},
+			color.NRGBA{R: 255, A: 255},
+		}
+		thumbs []*hplot.H1D
+	)
+
+	for i := 0; i < tp.Tiles.Rows; i++ {
+		for j := 0; j < tp.Tiles.Cols; j++ {
+			p := tp.Plot(j, i)
+			for k, col := range colors {
+				// Create a normal distribution.
+				dist := distuv.Normal{
+					Mu:    float64(j) + 0.5*float64(k),
+					Sigma: 1 + 0.5*float64(i),
+					Src:   rand.New(rand.NewSource(uint64(10*i + 2*j + k))),
+				}
+
+				hist := hbook.NewH1D(20, -4, +6)
+				for n := 0; n < 1000; n++ {
+					hist.Fill(dist.Rand(), 1)
+				}
+
+				h := hplot.NewH1D(hist)
+				h.LineStyle.Color = col
+				p.Add(h)
+				if i == 0 && j == 0 {
+					thumbs = append(thumbs, h)
+				}
+			}
+			p.X.Label.Text = fmt.Sprintf("x%d", j)
+			p.Y.Label.Text = "Entries"
+		}
+	}
+
+	leg := hplot.NewLegend()
+	leg.Add("sample-1", thumbs[0])
+	leg.Add("sample-2", thumbs[1])
+	leg.Top = true
+
+	fig := hplot.Figure(tp, hplot.WithLegend(leg))
+
+	err := hplot.Save(fig, 15*vg.Centimeter, -1, "testdata/tiled_plot_share_axes.png")
+	if err != nil {
+		log.Fatalf("error: %+v\n", err)
+	}
+}
@@ -7,7 +7,13 @@ package hplot_test
 import (
 	"testing"
 
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
 )
 
 func TestTiledPlot(t *testing.T) {
@@ -17,3 +23,32 @@ func TestTiledPlot(t *testing.T) {
 func TestTiledPlotAlign(t *testing.T) {
 	checkPlot(cmpimg.CheckPlot)(ExampleTiledPlot_align, t, "tiled_plot_aligned_histogram.png")
 }
+
+func TestTiledPlotShareAxes(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleTiledPlot_shareAxes, t, "tiled_plot_share_axes.png")
+}
+
+func TestTiledPlotShareRestore(t *testing.T) {
+	tp := hplot.NewTiledPlot(draw.Tiles{Cols: 1, Rows: 2})
+	tp.ShareX = true
+
+	top := tp.Plot(0, 0)
+	top.X.Label.Text = "top"
+	top.Add(hplot.NewS2D(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}}))
+
+	bot := tp.Plot(0, 1)
+	bot.X.Label.Text = "bottom"
+	bot.Add(hplot.NewS2D(plotter.XYs{{X: -1, Y: 0}, {X: 2, Y: 1}}))
+
+	tp.Draw(draw.New(vgimg.New(10*vg.Centimeter, 10*vg.Centimeter)))
+
+	if top.X.Min != -1 || top.X.Max != 2 {
+		t.Fatalf("invalid shared X range: [%v, %v]", top.X.Min, top.X.Max)
+	}
+	if top.X.Label.Text != "top" || bot.X.Label.Text != "bottom" {
+		t.Fatalf("axis labels not restored: top=%q, bottom=%q", top.X.Label.Text, bot.X.Label.Text)
+	}
+	if _, ok := top.X.Tick.Marker.(plot.DefaultTicks); !ok {
+		t.Fatalf("tick marker not restored: %T", top.X.Tick.Marker)
+	}
+}
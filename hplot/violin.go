@@ -0,0 +1,294 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Violin displays the distribution of a sample, or of a histogram,
+// as a violin: the density of the distribution is mirrored on both
+// sides of the location of the violin.
+// The interquartile range and the median are drawn inside the violin.
+//
+// Violins are vertical and drawn at their Location along the X-axis.
+// Each violin is scaled so its widest part spans Width.
+type Violin struct {
+	// Location is the location of the violin along the X-axis.
+	Location float64
+
+	// Width is the width of the widest part of the violin.
+	Width vg.Length
+
+	// Density holds the density of the distribution (Y) as a function
+	// of the value (X), sorted by increasing values.
+	Density plotter.XYs
+
+	// Median is the median value of the distribution.
+	Median float64
+
+	// Quartile1 and Quartile3 are the first and
+	// third quartiles of the distribution.
+	Quartile1, Quartile3 float64
+
+	// FillColor is the color used to fill the violin.
+	// Use nil to disable the filling.
+	FillColor color.Color
+
+	// LineStyle is the style of the outline of the violin.
+	// Use zero width to disable.
+	LineStyle draw.LineStyle
+
+	// QuartileStyle is the style of the line drawn between
+	// the first and third quartiles.
+	// Use zero width to disable.
+	QuartileStyle draw.LineStyle
+
+	// MedianStyle is the style of the glyph drawn at the median.
+	MedianStyle draw.GlyphStyle
+}
+
+// NewViolin creates a new violin with width w, at location loc, that
+// represents the distribution of the provided values.
+//
+// The density is estimated with a Gaussian kernel, whose bandwidth is
+// given by Silverman's rule of thumb, between the minimum and maximum
+// of the values.
+func NewViolin(w vg.Length, loc float64, vs plotter.Valuer) (*Violin, error) {
+	if w < 0 {
+		return nil, fmt.Errorf("hplot: could not create violin: negative width")
+	}
+	if vs.Len() == 0 {
+		return nil, fmt.Errorf("hplot: could not create violin: no values")
+	}
+
+	values, err := plotter.CopyValues(vs)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not create violin: %w", err)
+	}
+	xs := []float64(values)
+	sort.Float64s(xs)
+
+	v := newViolin(w, loc)
+	v.Median = quantile(xs, 0.5)
+	v.Quartile1 = quantile(xs, 0.25)
+	v.Quartile3 = quantile(xs, 0.75)
+
+	var (
+		n    = float64(len(xs))
+		std  = stat.StdDev(xs, nil)
+		iqr  = v.Quartile3 - v.Quartile1
+		sig  = std
+		xmin = xs[0]
+		xmax = xs[len(xs)-1]
+	)
+	if iqr > 0 {
+		sig = math.Min(std, iqr/1.34)
+	}
+	bw := 0.9 * sig * math.Pow(n, -0.2)
+
+	if bw <= 0 || xmin == xmax {
+		// degenerate distribution.
+		v.Density = plotter.XYs{{X: xmin, Y: 1}, {X: xmax, Y: 1}}
+		return v, nil
+	}
+
+	const npts = 100
+	v.Density = make(plotter.XYs, npts)
+	for i := range v.Density {
+		x := xmin + (xmax-xmin)*float64(i)/(npts-1)
+		// only values within 5 bandwidths contribute significantly.
+		var (
+			beg = sort.SearchFloat64s(xs, x-5*bw)
+			end = sort.SearchFloat64s(xs, x+5*bw)
+			sum = 0.0
+		)
+		for _, xi := range xs[beg:end] {
+			u := (x - xi) / bw
+			sum += math.Exp(-0.5 * u * u)
+		}
+		v.Density[i] = plotter.XY{X: x, Y: sum / (n * bw * math.Sqrt(2*math.Pi))}
+	}
+
+	return v, nil
+}
+
+// NewViolinH1D creates a new violin with width w, at location loc, that
+// represents the distribution of the provided histogram.
+//
+// The density is interpolated linearly between the centers of the bins,
+// from the first to the last non-empty bins.
+// The quartiles are computed as for NewBoxPlotH1D.
+func NewViolinH1D(w vg.Length, loc float64, h *hbook.H1D) (*Violin, error) {
+	if w < 0 {
+		return nil, fmt.Errorf("hplot: could not create violin: negative width")
+	}
+	lo, hi, err := h1dRange(h)
+	if err != nil {
+		return nil, fmt.Errorf("hplot: could not create violin: %w", err)
+	}
+
+	v := newViolin(w, loc)
+	v.Median = h1dQuantile(h, 0.5)
+	v.Quartile1 = h1dQuantile(h, 0.25)
+	v.Quartile3 = h1dQuantile(h, 0.75)
+
+	var (
+		bins = h.Binning.Bins
+		pts  = make(plotter.XYs, 0, len(bins)+2)
+	)
+	for _, bin := range bins {
+		if bin.XMin() < lo || bin.XMax() > hi {
+			continue
+		}
+		d := math.Max(bin.SumW(), 0) / bin.XWidth()
+		if len(pts) == 0 {
+			pts = append(pts, plotter.XY{X: lo, Y: d})
+		}
+		pts = append(pts, plotter.XY{X: bin.XMid(), Y: d})
+	}
+	pts = append(pts, plotter.XY{X: hi, Y: pts[len(pts)-1].Y})
+	v.Density = pts
+
+	return v, nil
+}
+
+// quantile returns the p-quantile of the provided sorted values,
+// interpolating linearly between the closest ranks.
+func quantile(xs []float64, p float64) float64 {
+	var (
+		h = p * float64(len(xs)-1)
+		i = int(math.Floor(h))
+	)
+	if i+1 >= len(xs) {
+		return xs[len(xs)-1]
+	}
+	return xs[i] + (h-float64(i))*(xs[i+1]-xs[i])
+}
+
+func newViolin(w vg.Length, loc float64) *Violin {
+	return &Violin{
+		Location:  loc,
+		Width:     w,
+		FillColor: color.Gray{Y: 200},
+		LineStyle: plotter.DefaultLineStyle,
+		QuartileStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(3),
+		},
+		MedianStyle: draw.GlyphStyle{
+			Color:  color.White,
+			Radius: vg.Points(1.5),
+			Shape:  draw.CircleGlyph{},
+		},
+	}
+}
+
+// Plot draws the violin, implementing the plot.Plotter interface.
+func (v *Violin) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	x := trX(v.Location)
+	if !c.ContainsX(x) {
+		return
+	}
+
+	dmax := 0.0
+	for _, pt := range v.Density {
+		dmax = math.Max(dmax, pt.Y)
+	}
+	scale := 0.0
+	if dmax > 0 {
+		scale = float64(v.Width/2) / dmax
+	}
+
+	n := len(v.Density)
+	pts := make([]vg.Point, 0, 2*n+1)
+	for _, pt := range v.Density {
+		pts = append(pts, vg.Point{X: x + vg.Length(pt.Y*scale), Y: trY(pt.X)})
+	}
+	for i := n - 1; i >= 0; i-- {
+		pt := v.Density[i]
+		pts = append(pts, vg.Point{X: x - vg.Length(pt.Y*scale), Y: trY(pt.X)})
+	}
+	pts = append(pts, pts[0])
+
+	if v.FillColor != nil {
+		c.FillPolygon(v.FillColor, c.ClipPolygonY(pts))
+	}
+	if v.LineStyle.Width != 0 {
+		c.StrokeLines(v.LineStyle, c.ClipLinesY(pts)...)
+	}
+
+	if v.QuartileStyle.Width != 0 {
+		c.StrokeLines(v.QuartileStyle, c.ClipLinesY([]vg.Point{
+			{X: x, Y: trY(v.Quartile1)},
+			{X: x, Y: trY(v.Quartile3)},
+		})...)
+	}
+
+	if y := trY(v.Median); c.ContainsY(y) {
+		c.DrawGlyphNoClip(v.MedianStyle, vg.Point{X: x, Y: y})
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values,
+// implementing the plot.DataRanger interface.
+func (v *Violin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	ymin = math.Inf(+1)
+	ymax = math.Inf(-1)
+	for _, pt := range v.Density {
+		ymin = math.Min(ymin, pt.X)
+		ymax = math.Max(ymax, pt.X)
+	}
+	return v.Location, v.Location, ymin, ymax
+}
+
+// GlyphBoxes returns a slice of GlyphBoxes, accounting for the width of
+// the violin, implementing the plot.GlyphBoxer interface.
+func (v *Violin) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return []plot.GlyphBox{{
+		X: plt.X.Norm(v.Location),
+		Y: plt.Y.Norm(v.Median),
+		Rectangle: vg.Rectangle{
+			Min: vg.Point{X: -(v.Width/2 + v.LineStyle.Width/2)},
+			Max: vg.Point{X: +(v.Width/2 + v.LineStyle.Width/2)},
+		},
+	}}
+}
+
+// Thumbnail draws a box in the style of the violin,
+// implementing the plot.Thumbnailer interface.
+func (v *Violin) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Min.Y},
+	}
+	if v.FillColor != nil {
+		c.FillPolygon(v.FillColor, c.ClipPolygonXY(pts))
+	}
+	if v.LineStyle.Width != 0 {
+		c.StrokeLines(v.LineStyle, c.ClipLinesXY(pts)...)
+	}
+}
+
+var (
+	_ plot.Plotter     = (*Violin)(nil)
+	_ plot.DataRanger  = (*Violin)(nil)
+	_ plot.GlyphBoxer  = (*Violin)(nil)
+	_ plot.Thumbnailer = (*Violin)(nil)
+)
@@ -0,0 +1,79 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"image/color"
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+func ExampleViolin() {
+	const npoints = 1000
+
+	src := rand.New(rand.NewSource(1234))
+
+	p := hplot.New()
+	p.Title.Text = "Resolution per detector region"
+	p.Y.Label.Text = "(E_reco - E) / E"
+
+	// Barrel: a single Gaussian core.
+	barrel := make(plotter.Values, npoints)
+	core := distuv.Normal{Mu: 0, Sigma: 0.05, Src: src}
+	for i := range barrel {
+		barrel[i] = core.Rand()
+	}
+
+	// Endcap: a Gaussian core with a low-side tail.
+	endcap := make(plotter.Values, npoints)
+	tail := distuv.Normal{Mu: -0.15, Sigma: 0.08, Src: src}
+	for i := range endcap {
+		switch {
+		case i%4 == 0:
+			endcap[i] = tail.Rand()
+		default:
+			endcap[i] = 1.5 * core.Rand()
+		}
+	}
+
+	// Forward: from a histogram.
+	fwd := hbook.NewH1D(30, -0.5, 0.5)
+	wide := distuv.Normal{Mu: 0.02, Sigma: 0.12, Src: src}
+	for i := 0; i < npoints; i++ {
+		fwd.Fill(wide.Rand(), 1)
+	}
+
+	v1, err := hplot.NewViolin(vg.Points(40), 0, barrel)
+	if err != nil {
+		log.Fatalf("could not create violin: %+v", err)
+	}
+	v1.FillColor = color.NRGBA{B: 255, A: 96}
+
+	v2, err := hplot.NewViolin(vg.Points(40), 1, endcap)
+	if err != nil {
+		log.Fatalf("could not create violin: %+v", err)
+	}
+	v2.FillColor = color.NRGBA{R: 255, A: 96}
+
+	v3, err := hplot.NewViolinH1D(vg.Points(40), 2, fwd)
+	if err != nil {
+		log.Fatalf("could not create violin: %+v", err)
+	}
+	v3.FillColor = color.NRGBA{G: 200, A: 96}
+
+	p.Add(v1, v2, v3, hplot.NewGrid())
+	p.NominalX("barrel", "endcap", "forward")
+
+	err = p.Save(15*vg.Centimeter, -1, "testdata/violin.png")
+	if err != nil {
+		log.Fatalf("could not save plot: %+v", err)
+	}
+}
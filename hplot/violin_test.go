@@ -0,0 +1,70 @@
+// Copyright ©2026 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+func TestViolin(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleViolin, t, "violin.png")
+}
+
+func TestViolinDensity(t *testing.T) {
+	vs := make(plotter.Values, 1001)
+	for i := range vs {
+		vs[i] = float64(i) / 1000
+	}
+
+	v, err := hplot.NewViolin(vg.Points(10), 0, vs)
+	if err != nil {
+		t.Fatalf("could not create violin: %+v", err)
+	}
+
+	if got, want := v.Median, 0.5; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid median: got=%v, want=%v", got, want)
+	}
+
+	_, _, ymin, ymax := v.DataRange()
+	if ymin != 0 || ymax != 1 {
+		t.Fatalf("invalid data range: got=[%v, %v], want=[0, 1]", ymin, ymax)
+	}
+
+	// the density of a uniform distribution over [0, 1] is 1,
+	// away from the edges.
+	mid := v.Density[len(v.Density)/2]
+	if math.Abs(mid.Y-1) > 0.05 {
+		t.Fatalf("invalid density at x=%v: got=%v, want=1", mid.X, mid.Y)
+	}
+
+	h := hbook.NewH1D(4, 0, 4)
+	h.Fill(1.5, 2)
+	h.Fill(2.5, 4)
+	vh, err := hplot.NewViolinH1D(vg.Points(10), 0, h)
+	if err != nil {
+		t.Fatalf("could not create violin: %+v", err)
+	}
+	want := plotter.XYs{{X: 1, Y: 2}, {X: 1.5, Y: 2}, {X: 2.5, Y: 4}, {X: 3, Y: 4}}
+	if len(vh.Density) != len(want) {
+		t.Fatalf("invalid density: got=%v, want=%v", vh.Density, want)
+	}
+	for i := range want {
+		if vh.Density[i] != want[i] {
+			t.Fatalf("invalid density: got=%v, want=%v", vh.Density, want)
+		}
+	}
+
+	_, err = hplot.NewViolin(vg.Points(10), 0, plotter.Values{})
+	if err == nil {
+		t.Fatalf("expected an error for no values")
+	}
+}